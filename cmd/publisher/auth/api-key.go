@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIKeyProvider exchanges an organization API key for a registry token
+type APIKeyProvider struct {
+	registryURL string
+	apiKey      string
+}
+
+// NewAPIKeyProvider creates a new organization API key provider
+func NewAPIKeyProvider(registryURL, apiKey string) Provider {
+	return &APIKeyProvider{
+		registryURL: registryURL,
+		apiKey:      apiKey,
+	}
+}
+
+// GetToken exchanges the API key for a registry JWT token
+func (p *APIKeyProvider) GetToken(ctx context.Context) (string, error) {
+	if p.apiKey == "" {
+		return "", errors.New("an organization API key is required: pass --api-key or set MCP_REGISTRY_API_KEY")
+	}
+
+	jsonData, err := json.Marshal(map[string]string{"api_key": p.apiKey})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.registryURL+"/v0/auth/api-key", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var tokenResp RegistryTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return tokenResp.RegistryToken, nil
+}
+
+// NeedsLogin always returns false since the API key is passed in directly
+func (p *APIKeyProvider) NeedsLogin() bool {
+	return false
+}
+
+// Login is not needed since the API key is passed in directly
+func (p *APIKeyProvider) Login(_ context.Context) error {
+	return nil
+}
+
+// Name returns the name of this auth provider
+func (p *APIKeyProvider) Name() string {
+	return "api-key"
+}
@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// completionCommand describes a command for shell completion scripts
+type completionCommand struct {
+	name        string
+	description string
+	// args are the values completed for the first positional argument
+	args []string
+	// subArgs are the values completed for the second positional argument, keyed by the first
+	subArgs map[string][]string
+	flags   []string
+	// files completes file names for positional arguments
+	files bool
+}
+
+// completionCommands lists the commands, arguments and flags offered by shell completion
+var completionCommands = []completionCommand{
+	{name: "init", description: "Create a server.json file template"},
+	{
+		name:        "login",
+		description: "Authenticate with the registry",
+		args:        []string{MethodGitHub, MethodGitHubOIDC, MethodDNS, MethodHTTP, MethodAPIKey, MethodNone},
+		subArgs: map[string][]string{
+			MethodDNS:  {string(AzureKeyVaultSignerType), string(GoogleKMSSignerType)},
+			MethodHTTP: {string(AzureKeyVaultSignerType), string(GoogleKMSSignerType)},
+		},
+		flags: []string{"--registry", "--token", "--api-key", "--domain", "--private-key", "--algorithm", "--vault", "--key", "--resource"},
+	},
+	{name: "logout", description: "Clear saved authentication"},
+	{name: "publish", description: "Publish server.json to the registry", files: true},
+	{
+		name:        "status",
+		description: "Update the status of a server version",
+		flags:       []string{"--status", "--message", "--all-versions", "--yes"},
+	},
+	{name: "validate", description: "Validate server.json without publishing", files: true},
+	{
+		name:        "completion",
+		description: "Generate a shell completion script",
+		args:        completionShells,
+	},
+	{name: "version", description: "Print version information"},
+	{name: "help", description: "Show help"},
+}
+
+// completionShells are the shells a completion script can be generated for
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// flagValues are the values completed after flags that take a fixed set of values
+var flagValues = map[string][]string{
+	"--status":    {"active", "deprecated", "deleted"},
+	"--algorithm": {"ed25519", "ecdsap384"},
+}
+
+// CompletionCommand writes a shell completion script for mcp-publisher to stdout
+func CompletionCommand(args []string) error {
+	if len(args) < 1 {
+		return errors.New("shell required (usage: mcp-publisher completion <bash|zsh|fish|powershell>)")
+	}
+
+	return writeCompletion(os.Stdout, args[0])
+}
+
+func writeCompletion(w io.Writer, shell string) error {
+	var script string
+	switch shell {
+	case "bash":
+		script = bashCompletion()
+	case "zsh":
+		// zsh loads bash completion functions through bashcompinit
+		script = "#compdef mcp-publisher\n\nautoload -U +X bashcompinit && bashcompinit\n\n" + bashCompletion()
+	case "fish":
+		script = fishCompletion()
+	case "powershell":
+		script = powershellCompletion()
+	default:
+		return fmt.Errorf("unsupported shell: %s (supported: %s)", shell, strings.Join(completionShells, ", "))
+	}
+
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+func commandNames() []string {
+	names := make([]string, len(completionCommands))
+	for i, command := range completionCommands {
+		names[i] = command.name
+	}
+	return names
+}
+
+func bashCompletion() string {
+	var b strings.Builder
+	b.WriteString(`# bash completion for mcp-publisher
+_mcp_publisher() {
+    local cur prev cword
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    cword=$COMP_CWORD
+
+    if [[ $cword -eq 1 ]]; then
+        COMPREPLY=($(compgen -W "` + strings.Join(commandNames(), " ") + `" -- "$cur"))
+        return
+    fi
+
+    case "$prev" in
+`)
+	for _, flagName := range sortedKeys(flagValues) {
+		fmt.Fprintf(&b, "        %s|-%s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n",
+			flagName, strings.TrimPrefix(flagName, "-"), strings.Join(flagValues[flagName], " "))
+	}
+	b.WriteString(`    esac
+
+    case "${COMP_WORDS[1]}" in
+`)
+	for _, command := range completionCommands {
+		if len(command.args) == 0 && len(command.flags) == 0 && !command.files {
+			continue
+		}
+		fmt.Fprintf(&b, "        %s)\n", command.name)
+		if len(command.flags) > 0 {
+			fmt.Fprintf(&b, "            if [[ $cur == -* ]]; then COMPREPLY=($(compgen -W %q -- \"$cur\")); return; fi\n",
+				strings.Join(command.flags, " "))
+		}
+		if len(command.args) > 0 {
+			fmt.Fprintf(&b, "            if [[ $cword -eq 2 ]]; then COMPREPLY=($(compgen -W %q -- \"$cur\")); return; fi\n",
+				strings.Join(command.args, " "))
+		}
+		for _, arg := range sortedKeys(command.subArgs) {
+			fmt.Fprintf(&b, "            if [[ $cword -eq 3 && ${COMP_WORDS[2]} == %s ]]; then COMPREPLY=($(compgen -W %q -- \"$cur\")); return; fi\n",
+				arg, strings.Join(command.subArgs[arg], " "))
+		}
+		if command.files {
+			b.WriteString("            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+		}
+		b.WriteString("            ;;\n")
+	}
+	b.WriteString(`    esac
+}
+
+complete -o default -F _mcp_publisher mcp-publisher
+`)
+	return b.String()
+}
+
+func fishCompletion() string {
+	var b strings.Builder
+	b.WriteString("# fish completion for mcp-publisher\ncomplete -c mcp-publisher -f\n")
+	for _, command := range completionCommands {
+		fmt.Fprintf(&b, "complete -c mcp-publisher -n __fish_use_subcommand -a %s -d %q\n", command.name, command.description)
+	}
+	for _, command := range completionCommands {
+		seen := fmt.Sprintf("__fish_seen_subcommand_from %s", command.name)
+		if len(command.args) > 0 {
+			fmt.Fprintf(&b, "complete -c mcp-publisher -n '%s; and test (count (commandline -opc)) -eq 2' -a %q\n",
+				seen, strings.Join(command.args, " "))
+		}
+		for _, arg := range sortedKeys(command.subArgs) {
+			fmt.Fprintf(&b, "complete -c mcp-publisher -n '%s; and __fish_seen_subcommand_from %s; and test (count (commandline -opc)) -eq 3' -a %q\n",
+				seen, arg, strings.Join(command.subArgs[arg], " "))
+		}
+		for _, flagName := range command.flags {
+			name := strings.TrimPrefix(flagName, "--")
+			if values, ok := flagValues[flagName]; ok {
+				fmt.Fprintf(&b, "complete -c mcp-publisher -n '%s' -l %s -x -a %q\n", seen, name, strings.Join(values, " "))
+			} else {
+				fmt.Fprintf(&b, "complete -c mcp-publisher -n '%s' -l %s\n", seen, name)
+			}
+		}
+		if command.files {
+			fmt.Fprintf(&b, "complete -c mcp-publisher -n '%s' -F\n", seen)
+		}
+	}
+	return b.String()
+}
+
+func powershellCompletion() string {
+	var b strings.Builder
+	b.WriteString(`# powershell completion for mcp-publisher
+Register-ArgumentCompleter -Native -CommandName mcp-publisher -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $words = @($commandAst.CommandElements | ForEach-Object { $_.ToString() })
+    if ($wordToComplete -ne '') { $words = $words[0..($words.Count - 2)] }
+
+    $candidates = @()
+    if ($words.Count -eq 1) {
+        $candidates = @(` + powershellList(commandNames()) + `)
+    } else {
+        $previous = $words[-1]
+        switch ($words[1]) {
+`)
+	for _, command := range completionCommands {
+		if len(command.args) == 0 && len(command.flags) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "            '%s' {\n", command.name)
+		for _, flagName := range command.flags {
+			if values, ok := flagValues[flagName]; ok {
+				fmt.Fprintf(&b, "                if ($previous -eq '%s' -or $previous -eq '%s') { $candidates = @(%s); break }\n",
+					flagName, strings.TrimPrefix(flagName, "-"), powershellList(values))
+			}
+		}
+		if len(command.flags) > 0 {
+			fmt.Fprintf(&b, "                if ($wordToComplete -like '-*') { $candidates = @(%s); break }\n", powershellList(command.flags))
+		}
+		if len(command.args) > 0 {
+			fmt.Fprintf(&b, "                if ($words.Count -eq 2) { $candidates = @(%s); break }\n", powershellList(command.args))
+		}
+		for _, arg := range sortedKeys(command.subArgs) {
+			fmt.Fprintf(&b, "                if ($words.Count -eq 3 -and $words[2] -eq '%s') { $candidates = @(%s); break }\n",
+				arg, powershellList(command.subArgs[arg]))
+		}
+		b.WriteString("            }\n")
+	}
+	b.WriteString(`        }
+    }
+
+    $candidates | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`)
+	return b.String()
+}
+
+func powershellList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + value + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,64 @@
+package commands_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureStdout returns what fn writes to stdout
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	original := os.Stdout
+	os.Stdout = w
+	t.Cleanup(func() { os.Stdout = original })
+
+	fnErr := fn()
+	require.NoError(t, w.Close())
+	os.Stdout = original
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(output), fnErr
+}
+
+func TestCompletionCommand(t *testing.T) {
+	tests := []struct {
+		shell    string
+		contains []string
+	}{
+		{"bash", []string{"complete -o default -F _mcp_publisher mcp-publisher", "init login logout publish status validate completion", "github github-oidc dns http api-key none", "active deprecated deleted"}},
+		{"zsh", []string{"#compdef mcp-publisher", "bashcompinit", "_mcp_publisher"}},
+		{"fish", []string{"complete -c mcp-publisher -n __fish_use_subcommand -a publish", "-l domain", "-l status -x -a \"active deprecated deleted\""}},
+		{"powershell", []string{"Register-ArgumentCompleter -Native -CommandName mcp-publisher", "'github-oidc'", "'--private-key'"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			output, err := captureStdout(t, func() error {
+				return commands.CompletionCommand([]string{tt.shell})
+			})
+			require.NoError(t, err)
+			for _, want := range tt.contains {
+				assert.Contains(t, output, want)
+			}
+		})
+	}
+}
+
+func TestCompletionCommand_Errors(t *testing.T) {
+	err := commands.CompletionCommand(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "shell required")
+
+	err = commands.CompletionCommand([]string{"tcsh"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported shell: tcsh")
+}
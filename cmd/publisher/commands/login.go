@@ -22,6 +22,7 @@ const (
 	MethodGitHubOIDC   = "github-oidc"
 	MethodDNS          = "dns"
 	MethodHTTP         = "http"
+	MethodAPIKey       = "api-key"
 	MethodNone         = "none"
 )
 
@@ -78,6 +79,11 @@ func parseLoginFlags(method string, args []string) (LoginFlags, error) {
 		loginFlags.StringVar(&token, "token", "", "GitHub Personal Access Token")
 	}
 
+	// Automation usually passes organization API keys through the environment, to keep them out of command lines
+	if method == MethodAPIKey {
+		loginFlags.StringVar(&token, "api-key", os.Getenv("MCP_REGISTRY_API_KEY"), "Organization API key (defaults to $MCP_REGISTRY_API_KEY)")
+	}
+
 	if method == "dns" || method == "http" {
 		loginFlags.StringVar(&flags.Domain, "domain", "", "Domain name")
 		if len(args) > 1 {
@@ -105,7 +111,7 @@ func parseLoginFlags(method string, args []string) (LoginFlags, error) {
 	}
 
 	// Store the token in flags if it was provided
-	if method == MethodGitHub {
+	if method == MethodGitHub || method == MethodAPIKey {
 		flags.Token = Token(token)
 	}
 
@@ -143,6 +149,8 @@ func createAuthProvider(method, registryURL, domain string, token Token, signer
 			return nil, errors.New("http authentication requires --domain")
 		}
 		return auth.NewHTTPProvider(registryURL, domain, &signer), nil
+	case MethodAPIKey:
+		return auth.NewAPIKeyProvider(registryURL, string(token)), nil
 	case MethodNone:
 		return auth.NewNoneProvider(registryURL), nil
 	default:
@@ -150,7 +158,77 @@ func createAuthProvider(method, registryURL, domain string, token Token, signer
 	}
 }
 
+// loginMethodOptions are the authentication methods offered when prompting for one
+var loginMethodOptions = []promptOption{
+	{Value: MethodGitHub, Description: "Interactive GitHub authentication"},
+	{Value: MethodGitHubOIDC, Description: "GitHub Actions OIDC authentication"},
+	{Value: MethodDNS, Description: "DNS-based authentication"},
+	{Value: MethodHTTP, Description: "HTTP-based authentication"},
+	{Value: MethodAPIKey, Description: "Organization API key authentication"},
+	{Value: MethodNone, Description: "Anonymous authentication (for testing)"},
+}
+
+// promptMissingLoginFlags asks for values the chosen method needs but that were not passed as flags.
+// When not running interactively the flags are left as they are and validated later as before.
+func promptMissingLoginFlags(method string, flags *LoginFlags) error {
+	if !prompts.interactive || (method != MethodDNS && method != MethodHTTP) {
+		return nil
+	}
+
+	var err error
+	if flags.Domain == "" {
+		if flags.Domain, err = prompts.Input("Domain", ""); err != nil {
+			return err
+		}
+	}
+
+	switch flags.SignerType {
+	case InProcessSignerType:
+		if flags.PrivateKey == "" {
+			algorithm, err := prompts.Select("Key algorithm", []promptOption{
+				{Value: string(auth.AlgorithmEd25519), Description: "Ed25519 (64 hex chars)"},
+				{Value: string(auth.AlgorithmECDSAP384), Description: "ECDSA P-384 (96 hex chars)"},
+			})
+			if err != nil {
+				return err
+			}
+			flags.CryptoAlgorithm = CryptoAlgorithm(algorithm)
+			if flags.PrivateKey, err = prompts.Input("Private key (hex)", ""); err != nil {
+				return err
+			}
+		}
+	case AzureKeyVaultSignerType:
+		if flags.KvVault == "" {
+			if flags.KvVault, err = prompts.Input("Azure Key Vault name", ""); err != nil {
+				return err
+			}
+		}
+		if flags.KvKeyName == "" {
+			if flags.KvKeyName, err = prompts.Input("Signing key name", ""); err != nil {
+				return err
+			}
+		}
+	case GoogleKMSSignerType:
+		if flags.KmsResource == "" {
+			if flags.KmsResource, err = prompts.Input("Google Cloud KMS key version resource name", ""); err != nil {
+				return err
+			}
+		}
+	case NoSignerType:
+	}
+
+	return nil
+}
+
 func LoginCommand(args []string) error {
+	if len(args) < 1 && prompts.interactive {
+		method, err := prompts.Select("Authentication method", loginMethodOptions)
+		if err != nil {
+			return err
+		}
+		args = []string{method}
+	}
+
 	if len(args) < 1 {
 		return errors.New(`authentication method required
 
@@ -161,6 +239,7 @@ Methods:
   github-oidc       GitHub Actions OIDC authentication
   dns               DNS-based authentication (requires --domain)
   http              HTTP-based authentication (requires --domain)
+  api-key           Organization API key authentication (requires --api-key or $MCP_REGISTRY_API_KEY)
   none              Anonymous authentication (for testing)
 
 Signing providers:
@@ -185,6 +264,9 @@ Examples:
   # Sign in using a specific ECDSA P-384 private key for DNS authentication
   mcp-publisher login dns -algorithm ecdsap384 -domain example.com -private-key <96 hex chars>
   
+  # Sign in from automation with an organization API key
+  MCP_REGISTRY_API_KEY=mcpr_... mcp-publisher login api-key
+
   # Sign in with gcloud CLI, use Google Cloud KMS for signing in DNS authentication
   gcloud auth application-default login
   mcp-publisher login dns google-kms -domain example.com -resource projects/lotr/locations/global/keyRings/fellowship/cryptoKeys/frodo/cryptoKeyVersions/1
@@ -201,6 +283,9 @@ Examples:
 	if err != nil {
 		return err
 	}
+	if err := promptMissingLoginFlags(method, &flags); err != nil {
+		return err
+	}
 
 	var signer auth.Signer
 	if flags.SignerType != NoSignerType {
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// promptOption is one choice offered by a selection prompt
+type promptOption struct {
+	Value       string
+	Description string
+}
+
+// prompter asks for values that were not provided on the command line
+type prompter struct {
+	in          *bufio.Reader
+	out         io.Writer
+	interactive bool
+}
+
+// prompts reads from the terminal. It only prompts when stdin is a terminal and the CI
+// environment variable is unset, so scripts and pipelines keep failing fast on missing flags.
+var prompts = &prompter{
+	in:          bufio.NewReader(os.Stdin),
+	out:         os.Stdout,
+	interactive: stdinIsTerminal() && os.Getenv("CI") == "",
+}
+
+// SetPrompter makes prompts read answers from in and write questions to out, or disables
+// prompting when in is nil (used for testing)
+func SetPrompter(in io.Reader, out io.Writer) {
+	if in == nil {
+		prompts = &prompter{interactive: false}
+		return
+	}
+	prompts = &prompter{in: bufio.NewReader(in), out: out, interactive: true}
+}
+
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	// The null device is a character device too, but nobody can answer from it
+	if null, err := os.Stat(os.DevNull); err == nil && os.SameFile(info, null) {
+		return false
+	}
+	return true
+}
+
+// errNotInteractive is returned when a value is missing and the user cannot be asked for it
+var errNotInteractive = errors.New("not running interactively")
+
+func (p *prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// Select asks the user to pick one of options by number or value
+func (p *prompter) Select(label string, options []promptOption) (string, error) {
+	if !p.interactive {
+		return "", errNotInteractive
+	}
+
+	_, _ = fmt.Fprintf(p.out, "? %s\n", label)
+	for i, option := range options {
+		_, _ = fmt.Fprintf(p.out, "  %d) %-16s %s\n", i+1, option.Value, option.Description)
+	}
+
+	for {
+		_, _ = fmt.Fprintf(p.out, "  Choose [1-%d]: ", len(options))
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return options[n-1].Value, nil
+		}
+		for _, option := range options {
+			if answer == option.Value {
+				return option.Value, nil
+			}
+		}
+		_, _ = fmt.Fprintln(p.out, "  Please enter one of the listed numbers.")
+	}
+}
+
+// Input asks the user for a value, returning defaultValue when the answer is empty.
+// Without a default, an answer is required.
+func (p *prompter) Input(label, defaultValue string) (string, error) {
+	if !p.interactive {
+		return "", errNotInteractive
+	}
+
+	for {
+		if defaultValue != "" {
+			_, _ = fmt.Fprintf(p.out, "? %s (%s): ", label, defaultValue)
+		} else {
+			_, _ = fmt.Fprintf(p.out, "? %s: ", label)
+		}
+
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = defaultValue
+		}
+		if answer != "" {
+			return answer, nil
+		}
+		_, _ = fmt.Fprintln(p.out, "  A value is required.")
+	}
+}
+
+// Confirm asks a yes/no question, returning defaultYes when the answer is empty
+func (p *prompter) Confirm(label string, defaultYes bool) (bool, error) {
+	if !p.interactive {
+		return false, errNotInteractive
+	}
+
+	hint := "y/N"
+	if defaultYes {
+		hint = "Y/n"
+	}
+	_, _ = fmt.Fprintf(p.out, "? %s [%s] ", label, hint)
+
+	answer, err := p.readLine()
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "":
+		return defaultYes, nil
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
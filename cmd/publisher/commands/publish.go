@@ -18,10 +18,19 @@ import (
 func PublishCommand(args []string) error {
 	// Check for server.json file
 	serverFile := "server.json"
-	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+	serverFileGiven := len(args) > 0 && !strings.HasPrefix(args[0], "-")
+	if serverFileGiven {
 		serverFile = args[0]
 	}
 
+	// Ask for the file when the default one is missing
+	if _, err := os.Stat(serverFile); os.IsNotExist(err) && !serverFileGiven && prompts.interactive {
+		_, _ = fmt.Fprintln(prompts.out, "No server.json in the current directory. Run 'mcp-publisher init' to create one.")
+		if serverFile, err = prompts.Input("Path to server.json", ""); err != nil {
+			return err
+		}
+	}
+
 	// Read server.json
 	serverData, err := os.ReadFile(serverFile)
 	if err != nil {
@@ -45,6 +54,19 @@ func PublishCommand(args []string) error {
 
 	tokenPath := filepath.Join(homeDir, TokenFileName)
 	tokenData, err := os.ReadFile(tokenPath)
+	if os.IsNotExist(err) && prompts.interactive {
+		// Offer to log in rather than making the user start over
+		login, promptErr := prompts.Confirm("You are not logged in. Log in now?", true)
+		if promptErr != nil {
+			return promptErr
+		}
+		if login {
+			if err := LoginCommand(nil); err != nil {
+				return err
+			}
+			tokenData, err = os.ReadFile(tokenPath)
+		}
+	}
 	if err != nil {
 		if os.IsNotExist(err) {
 			return errors.New("not authenticated. Run 'mcp-publisher login <method>' first")
@@ -65,7 +87,7 @@ func PublishCommand(args []string) error {
 
 	// Publish to registry
 	_, _ = fmt.Fprintf(os.Stdout, "Publishing to %s...\n", registryURL)
-	response, statusCode, err := publishToRegistry(registryURL, serverData, token)
+	result, statusCode, err := publishToRegistry(registryURL, serverData, token)
 	if err != nil {
 		// If publish failed with 422, call validate endpoint to show detailed errors
 		if statusCode == http.StatusUnprocessableEntity {
@@ -96,12 +118,32 @@ func PublishCommand(args []string) error {
 	}
 
 	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully published")
-	_, _ = fmt.Fprintf(os.Stdout, "✓ Server %s version %s\n", response.Server.Name, response.Server.Version)
+	_, _ = fmt.Fprintf(os.Stdout, "✓ Server %s version %s\n", result.Server.Server.Name, result.Server.Server.Version)
+
+	if len(result.PossibleDuplicates) > 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "⚠ Other servers use the same repository or remote URL: %s\n", strings.Join(result.PossibleDuplicates, ", "))
+		_, _ = fmt.Fprintln(os.Stdout, "  If this server replaces one of them, consider deprecating the old entry.")
+	}
+
+	if len(result.ValidationWarnings) > 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "⚠ Published with %d validation warning(s):\n", len(result.ValidationWarnings))
+		for _, warning := range result.ValidationWarnings {
+			_, _ = fmt.Fprintf(os.Stdout, "  - %s\n", warning)
+		}
+		_, _ = fmt.Fprintln(os.Stdout, "  These may become errors in the future; run 'mcp-publisher validate' for details.")
+	}
 
 	return nil
 }
 
-func publishToRegistry(registryURL string, serverData []byte, token string) (*apiv0.ServerResponse, int, error) {
+// publishResult is the registry's response to a successful publish
+type publishResult struct {
+	Server             *apiv0.ServerResponse
+	PossibleDuplicates []string
+	ValidationWarnings []string
+}
+
+func publishToRegistry(registryURL string, serverData []byte, token string) (*publishResult, int, error) {
 	// Parse the server JSON data
 	var serverJSON apiv0.ServerJSON
 	err := json.Unmarshal(serverData, &serverJSON)
@@ -151,5 +193,11 @@ func publishToRegistry(registryURL string, serverData []byte, token string) (*ap
 		return nil, resp.StatusCode, err
 	}
 
-	return &serverResponse, resp.StatusCode, nil
+	result := &publishResult{Server: &serverResponse}
+	if duplicates := resp.Header.Get(apiv0.PossibleDuplicatesHeader); duplicates != "" {
+		result.PossibleDuplicates = strings.Split(duplicates, ",")
+	}
+	result.ValidationWarnings = resp.Header.Values(apiv0.ValidationWarningHeader)
+
+	return result, resp.StatusCode, nil
 }
@@ -1,15 +1,19 @@
 package commands_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
-	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -59,14 +63,14 @@ func TestPublishCommand_422ValidationFlow(t *testing.T) {
 			_ = json.Unmarshal(body, &req)
 
 			// Return validation result with deprecated schema error
-			result := validators.ValidationResult{
+			result := validator.ValidationResult{
 				Valid: false,
-				Issues: []validators.ValidationIssue{
+				Issues: []validator.ValidationIssue{
 					{
-						Type:      validators.ValidationIssueTypeSemantic,
+						Type:      validator.ValidationIssueTypeSemantic,
 						Path:      "schema",
 						Message:   "schema version 2025-07-09 is not the current version",
-						Severity:  validators.ValidationIssueSeverityWarning,
+						Severity:  validator.ValidationIssueSeverityWarning,
 						Reference: "schema-version-deprecated",
 					},
 				},
@@ -115,21 +119,21 @@ func TestPublishCommand_422WithMultipleIssues(t *testing.T) {
 			validateCallCount++
 			w.Header().Set("Content-Type", "application/json")
 
-			result := validators.ValidationResult{
+			result := validator.ValidationResult{
 				Valid: false,
-				Issues: []validators.ValidationIssue{
+				Issues: []validator.ValidationIssue{
 					{
-						Type:      validators.ValidationIssueTypeSemantic,
+						Type:      validator.ValidationIssueTypeSemantic,
 						Path:      "version",
 						Message:   "version must be a specific version, not a range",
-						Severity:  validators.ValidationIssueSeverityError,
+						Severity:  validator.ValidationIssueSeverityError,
 						Reference: "semantic-version-range",
 					},
 					{
-						Type:      validators.ValidationIssueTypeSchema,
+						Type:      validator.ValidationIssueTypeSchema,
 						Path:      "name",
 						Message:   "name is required",
-						Severity:  validators.ValidationIssueSeverityError,
+						Severity:  validator.ValidationIssueSeverityError,
 						Reference: "schema-field-required",
 					},
 				},
@@ -205,7 +209,7 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 		name           string
 		schema         string
 		publishStatus  int
-		validationOpts func(req apiv0.ServerJSON) validators.ValidationResult
+		validationOpts func(req apiv0.ServerJSON) validator.ValidationResult
 		expectError    bool
 		errorSubstr    string
 		checkLinks     bool
@@ -214,15 +218,15 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 			name:          "deprecated 2025-07-09 schema should show warning",
 			schema:        "https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json",
 			publishStatus: http.StatusUnprocessableEntity,
-			validationOpts: func(_ apiv0.ServerJSON) validators.ValidationResult {
-				return validators.ValidationResult{
+			validationOpts: func(_ apiv0.ServerJSON) validator.ValidationResult {
+				return validator.ValidationResult{
 					Valid: false,
-					Issues: []validators.ValidationIssue{
+					Issues: []validator.ValidationIssue{
 						{
-							Type:      validators.ValidationIssueTypeSemantic,
+							Type:      validator.ValidationIssueTypeSemantic,
 							Path:      "schema",
 							Message:   "schema version 2025-07-09 is not the current version",
-							Severity:  validators.ValidationIssueSeverityWarning,
+							Severity:  validator.ValidationIssueSeverityWarning,
 							Reference: "schema-version-deprecated",
 						},
 					},
@@ -236,9 +240,9 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 			name:          "current 2025-12-11 schema should pass validation",
 			schema:        "https://static.modelcontextprotocol.io/schemas/2025-12-11/server.schema.json",
 			publishStatus: http.StatusCreated,
-			validationOpts: func(_ apiv0.ServerJSON) validators.ValidationResult {
+			validationOpts: func(_ apiv0.ServerJSON) validator.ValidationResult {
 				// Should not be called since publish succeeds
-				return validators.ValidationResult{Valid: true}
+				return validator.ValidationResult{Valid: true}
 			},
 			expectError: false,
 		},
@@ -246,15 +250,15 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 			name:          "empty schema should fail validation",
 			schema:        "",
 			publishStatus: http.StatusUnprocessableEntity,
-			validationOpts: func(_ apiv0.ServerJSON) validators.ValidationResult {
-				return validators.ValidationResult{
+			validationOpts: func(_ apiv0.ServerJSON) validator.ValidationResult {
+				return validator.ValidationResult{
 					Valid: false,
-					Issues: []validators.ValidationIssue{
+					Issues: []validator.ValidationIssue{
 						{
-							Type:      validators.ValidationIssueTypeSemantic,
+							Type:      validator.ValidationIssueTypeSemantic,
 							Path:      "schema",
 							Message:   "$schema field is required",
-							Severity:  validators.ValidationIssueSeverityError,
+							Severity:  validator.ValidationIssueSeverityError,
 							Reference: "schema-field-required",
 						},
 					},
@@ -268,15 +272,15 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 			name:          "custom schema without valid version should fail validation",
 			schema:        "https://example.com/custom.schema.json",
 			publishStatus: http.StatusUnprocessableEntity,
-			validationOpts: func(_ apiv0.ServerJSON) validators.ValidationResult {
-				return validators.ValidationResult{
+			validationOpts: func(_ apiv0.ServerJSON) validator.ValidationResult {
+				return validator.ValidationResult{
 					Valid: false,
-					Issues: []validators.ValidationIssue{
+					Issues: []validator.ValidationIssue{
 						{
-							Type:      validators.ValidationIssueTypeSchema,
+							Type:      validator.ValidationIssueTypeSchema,
 							Path:      "schema",
 							Message:   "failed to extract schema version from URL",
-							Severity:  validators.ValidationIssueSeverityError,
+							Severity:  validator.ValidationIssueSeverityError,
 							Reference: "schema-version-extraction-error",
 						},
 					},
@@ -360,3 +364,47 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishCommand_PromptsForServerFile(t *testing.T) {
+	server := SetupMockRegistryServer(t, nil, nil)
+	SetupTestToken(t, server.URL, "test-token")
+
+	serverJSON := apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	}
+	tempDir, _ := CreateTestServerJSON(t, serverJSON)
+	require.NoError(t, os.Rename(filepath.Join(tempDir, "server.json"), filepath.Join(tempDir, "other.json")))
+
+	var out bytes.Buffer
+	commands.SetPrompter(strings.NewReader("\nother.json\n"), &out)
+	t.Cleanup(func() { commands.SetPrompter(nil, nil) })
+
+	err := commands.PublishCommand([]string{})
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "? Path to server.json")
+	assert.Contains(t, out.String(), "A value is required.")
+}
+
+func TestPublishCommand_NoTokenDeclinesLogin(t *testing.T) {
+	serverJSON := apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	}
+	CreateTestServerJSON(t, serverJSON)
+
+	var out bytes.Buffer
+	commands.SetPrompter(strings.NewReader("n\n"), &out)
+	t.Cleanup(func() { commands.SetPrompter(nil, nil) })
+
+	err := commands.PublishCommand([]string{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not authenticated")
+	assert.Contains(t, out.String(), "Log in now?")
+}
@@ -9,8 +9,8 @@ import (
 	"testing"
 
 	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
-	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,7 +37,7 @@ func SetupMockRegistryServer(t *testing.T, publishHandler func(w http.ResponseWr
 	if validateHandler == nil {
 		validateHandler = func(w http.ResponseWriter, _ *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
-			result := validators.ValidationResult{Valid: true}
+			result := validator.ValidationResult{Valid: true}
 			_ = json.NewEncoder(w).Encode(result)
 		}
 	}
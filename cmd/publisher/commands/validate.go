@@ -11,15 +11,15 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 )
 
 // printSchemaValidationErrors prints nicely formatted error messages for schema validation issues
 // (empty schema or non-current schema) with migration guidance to stdout.
 // Returns the formatted error message string if any schema errors were printed, empty string otherwise.
-func printSchemaValidationErrors(result *validators.ValidationResult, serverJSON *apiv0.ServerJSON) string {
+func printSchemaValidationErrors(result *validator.ValidationResult, serverJSON *apiv0.ServerJSON) string {
 	currentSchemaURL := model.CurrentSchemaURL
 	migrationURL := "https://github.com/modelcontextprotocol/registry/blob/main/docs/reference/server-json/CHANGELOG.md"
 	checklistURL := migrationURL + "#migration-checklist-for-publishers"
@@ -46,7 +46,7 @@ func printSchemaValidationErrors(result *validators.ValidationResult, serverJSON
 
 		case "schema-version-deprecated":
 			// Non-current schema
-			if issue.Severity == validators.ValidationIssueSeverityWarning {
+			if issue.Severity == validator.ValidationIssueSeverityWarning {
 				// Warning format (for validate command)
 				_, _ = fmt.Fprintf(os.Stdout, "⚠️  Deprecated schema detected: %s\n", serverJSON.Schema)
 			} else {
@@ -79,11 +79,12 @@ func printSchemaValidationErrors(result *validators.ValidationResult, serverJSON
 
 // printValidationIssues prints schema validation errors and all other validation issues.
 // Returns the formatted error message string for schema validation errors (empty string if none).
-func printValidationIssues(result *validators.ValidationResult, serverJSON *apiv0.ServerJSON) string {
+func printValidationIssues(result *validator.ValidationResult, serverJSON *apiv0.ServerJSON) string {
 	// Print schema validation errors/warnings with friendly messages
 	formattedErrorMsg := printSchemaValidationErrors(result, serverJSON)
 
 	if result.Valid {
+		printValidationWarnings(result)
 		return formattedErrorMsg
 	}
 
@@ -113,6 +114,17 @@ func printValidationIssues(result *validators.ValidationResult, serverJSON *apiv
 	return formattedErrorMsg
 }
 
+// printValidationWarnings prints non-blocking warnings for a valid server.json. Schema deprecation
+// warnings are skipped as printSchemaValidationErrors already explains them.
+func printValidationWarnings(result *validator.ValidationResult) {
+	for _, issue := range result.Issues {
+		if issue.Severity != validator.ValidationIssueSeverityWarning || issue.Reference == "schema-version-deprecated" {
+			continue
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "⚠️  %s: %s (%s)\n", issue.Path, issue.Message, issue.Reference)
+	}
+}
+
 func ValidateCommand(args []string) error {
 	// Parse arguments
 	serverFile := "server.json"
@@ -192,7 +204,7 @@ func ValidateCommand(args []string) error {
 }
 
 // validateViaAPI calls the /validate endpoint on the registry
-func validateViaAPI(registryURL string, serverData []byte) (*validators.ValidationResult, error) {
+func validateViaAPI(registryURL string, serverData []byte) (*validator.ValidationResult, error) {
 	// Parse the server JSON data to ensure it's valid JSON
 	var serverJSON apiv0.ServerJSON
 	err := json.Unmarshal(serverData, &serverJSON)
@@ -237,7 +249,7 @@ func validateViaAPI(registryURL string, serverData []byte) (*validators.Validati
 	}
 
 	// Parse response - Huma returns ValidationResult directly
-	var result validators.ValidationResult
+	var result validator.ValidationResult
 	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("error parsing response: %w", err)
 	}
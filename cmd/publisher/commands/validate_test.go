@@ -8,9 +8,9 @@ import (
 	"testing"
 
 	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
-	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,9 +24,9 @@ func TestValidateCommand_Success(t *testing.T) {
 			validateCallCount++
 			w.Header().Set("Content-Type", "application/json")
 
-			result := validators.ValidationResult{
+			result := validator.ValidationResult{
 				Valid:  true,
-				Issues: []validators.ValidationIssue{},
+				Issues: []validator.ValidationIssue{},
 			}
 
 			_ = json.NewEncoder(w).Encode(result)
@@ -58,14 +58,14 @@ func TestValidateCommand_WithErrors(t *testing.T) {
 			validateCallCount++
 			w.Header().Set("Content-Type", "application/json")
 
-			result := validators.ValidationResult{
+			result := validator.ValidationResult{
 				Valid: false,
-				Issues: []validators.ValidationIssue{
+				Issues: []validator.ValidationIssue{
 					{
-						Type:      validators.ValidationIssueTypeSemantic,
+						Type:      validator.ValidationIssueTypeSemantic,
 						Path:      "version",
 						Message:   "version must be a specific version, not a range",
-						Severity:  validators.ValidationIssueSeverityError,
+						Severity:  validator.ValidationIssueSeverityError,
 						Reference: "semantic-version-range",
 					},
 				},
@@ -102,14 +102,14 @@ func TestValidateCommand_DeprecatedSchema(t *testing.T) {
 			var req apiv0.ServerJSON
 			_ = json.Unmarshal(body, &req)
 
-			result := validators.ValidationResult{
+			result := validator.ValidationResult{
 				Valid: false,
-				Issues: []validators.ValidationIssue{
+				Issues: []validator.ValidationIssue{
 					{
-						Type:      validators.ValidationIssueTypeSemantic,
+						Type:      validator.ValidationIssueTypeSemantic,
 						Path:      "schema",
 						Message:   "schema version 2025-07-09 is not the current version",
-						Severity:  validators.ValidationIssueSeverityWarning,
+						Severity:  validator.ValidationIssueSeverityWarning,
 						Reference: "schema-version-deprecated",
 					},
 				},
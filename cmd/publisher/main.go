@@ -47,6 +47,8 @@ func main() {
 		err = commands.StatusCommand(os.Args[2:])
 	case "validate":
 		err = commands.ValidateCommand(os.Args[2:])
+	case "completion":
+		err = commands.CompletionCommand(os.Args[2:])
 	case "--version", "-v", "version":
 		log.Printf("mcp-publisher %s (commit: %s, built: %s)", Version, GitCommit, BuildTime)
 		return
@@ -77,6 +79,7 @@ func printUsage() {
 	_, _ = fmt.Fprintln(os.Stdout, "  publish       Publish server.json to the registry")
 	_, _ = fmt.Fprintln(os.Stdout, "  status        Update the status of a server version")
 	_, _ = fmt.Fprintln(os.Stdout, "  validate      Validate server.json without publishing")
+	_, _ = fmt.Fprintln(os.Stdout, "  completion    Generate a shell completion script")
 	_, _ = fmt.Fprintln(os.Stdout)
 	_, _ = fmt.Fprintln(os.Stdout, "Use 'mcp-publisher <command> --help' for more information about a command.")
 }
@@ -111,6 +114,8 @@ func printCommandHelp(command string) {
 		_, _ = fmt.Fprintln(os.Stdout, "Examples:")
 		_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher login github")
 		_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher login dns --domain example.com --private-key <key>")
+		_, _ = fmt.Fprintln(os.Stdout)
+		_, _ = fmt.Fprintln(os.Stdout, "When run in a terminal, missing methods and required flags are prompted for.")
 
 	case "logout":
 		_, _ = fmt.Fprintln(os.Stdout, "Clear saved authentication")
@@ -130,6 +135,7 @@ func printCommandHelp(command string) {
 		_, _ = fmt.Fprintln(os.Stdout, "  server.json   Path to the server.json file (default: ./server.json)")
 		_, _ = fmt.Fprintln(os.Stdout)
 		_, _ = fmt.Fprintln(os.Stdout, "You must be logged in before publishing. Run 'mcp-publisher login' first.")
+		_, _ = fmt.Fprintln(os.Stdout, "When run in a terminal, you are offered to log in if you are not already.")
 
 	case "status":
 		_, _ = fmt.Fprintln(os.Stdout, "Update the status of a server version")
@@ -164,6 +170,25 @@ func printCommandHelp(command string) {
 		_, _ = fmt.Fprintln(os.Stdout)
 		_, _ = fmt.Fprintln(os.Stdout, "You must be logged in before updating status. Run 'mcp-publisher login' first.")
 
+	case "completion":
+		_, _ = fmt.Fprintln(os.Stdout, "Generate a shell completion script")
+		_, _ = fmt.Fprintln(os.Stdout)
+		_, _ = fmt.Fprintln(os.Stdout, "Usage:")
+		_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher completion <bash|zsh|fish|powershell>")
+		_, _ = fmt.Fprintln(os.Stdout)
+		_, _ = fmt.Fprintln(os.Stdout, "Examples:")
+		_, _ = fmt.Fprintln(os.Stdout, "  # Load completions in the current bash session")
+		_, _ = fmt.Fprintln(os.Stdout, "  source <(mcp-publisher completion bash)")
+		_, _ = fmt.Fprintln(os.Stdout)
+		_, _ = fmt.Fprintln(os.Stdout, "  # Install zsh completions (make sure the directory is in your $fpath)")
+		_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher completion zsh > \"${fpath[1]}/_mcp-publisher\"")
+		_, _ = fmt.Fprintln(os.Stdout)
+		_, _ = fmt.Fprintln(os.Stdout, "  # Install fish completions")
+		_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher completion fish > ~/.config/fish/completions/mcp-publisher.fish")
+		_, _ = fmt.Fprintln(os.Stdout)
+		_, _ = fmt.Fprintln(os.Stdout, "  # Load completions in PowerShell (add to your $PROFILE to keep them)")
+		_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher completion powershell | Out-String | Invoke-Expression")
+
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", command)
 		printUsage()
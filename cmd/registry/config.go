@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+const configUsage = `Usage: registry config <command>
+
+Commands:
+  schema    Print the JSON Schema of the YAML configuration file named by ` + config.FileEnv + `
+  validate  Load the configuration from the file and environment and report any errors`
+
+// runConfigCommand runs the config subcommand and returns the process exit code
+func runConfigCommand(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, configUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "schema":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(config.Schema()); err != nil {
+			log.Printf("Failed to write schema: %v", err)
+			return 1
+		}
+		return 0
+	case "validate":
+		if _, err := config.Load(); err != nil {
+			log.Printf("Invalid configuration: %v", err)
+			return 1
+		}
+		log.Println("Configuration is valid")
+		return 0
+	default:
+		fmt.Fprintln(os.Stderr, configUsage)
+		return 2
+	}
+}
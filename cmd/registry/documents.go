@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+const documentsUsage = `Usage: registry documents <command>
+
+Commands:
+  backfill  Store the server.json documents of versions published before the document store existed
+  verify    Check every server version against its stored document, exiting with status 1 on any problem
+
+The database is read from the same MCP_REGISTRY_* environment variables as the server.`
+
+// runDocumentsCommand runs the documents subcommand and returns the process exit code
+func runDocumentsCommand(args []string) int {
+	if len(args) != 1 || (args[0] != "backfill" && args[0] != "verify") {
+		fmt.Fprintln(os.Stderr, documentsUsage)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	db, err := openDatabase(connectCtx, cfg)
+	cancel()
+	if err != nil {
+		log.Printf("Failed to connect to PostgreSQL: %v", err)
+		return 1
+	}
+	defer db.Close()
+	registry, err := service.NewRegistryService(db, cfg)
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+
+	if args[0] == "backfill" {
+		stored, err := registry.BackfillServerDocuments(ctx)
+		if err != nil {
+			log.Printf("Backfill stopped after storing %d documents: %v", stored, err)
+			return 1
+		}
+		log.Printf("Stored %d documents", stored)
+		return 0
+	}
+
+	verification, err := registry.VerifyServerDocuments(ctx)
+	if err != nil {
+		log.Printf("Verification failed: %v", err)
+		return 1
+	}
+	for _, issue := range verification.Issues {
+		fmt.Printf("%s\t%s\t%s\t%s\n", issue.Problem, issue.ServerName, issue.Version, issue.Digest)
+	}
+	log.Printf("Checked %d server versions, %d with problems", verification.Checked, len(verification.Issues))
+	if len(verification.Issues) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+const encryptionUsage = `Usage: registry encryption <command>
+
+Commands:
+  new-key [--id <id>]  Print a new key for MCP_REGISTRY_ENCRYPTION_KEYS
+  rotate               Re-encrypt stored values under the current key
+
+To rotate keys, put a new key first in MCP_REGISTRY_ENCRYPTION_KEYS, keeping the old ones after it,
+restart the registry, run rotate, and then remove the old keys. The database is read from the same
+MCP_REGISTRY_* environment variables as the server.`
+
+// runEncryptionCommand runs the encryption subcommand and returns the process exit code
+func runEncryptionCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, encryptionUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "new-key":
+		return runNewKeyCommand(args[1:])
+	case "rotate":
+		return runRotateCommand()
+	default:
+		fmt.Fprintln(os.Stderr, encryptionUsage)
+		return 2
+	}
+}
+
+// runNewKeyCommand prints a random key in the id:base64-key form the local key provider reads
+func runNewKeyCommand(args []string) int {
+	flags := flag.NewFlagSet("encryption new-key", flag.ContinueOnError)
+	id := flags.String("id", time.Now().UTC().Format("20060102"), "Key ID stored with values encrypted under the key")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		log.Printf("Failed to generate key: %v", err)
+		return 1
+	}
+	fmt.Printf("%s:%s\n", *id, base64.StdEncoding.EncodeToString(key))
+	return 0
+}
+
+// runRotateCommand re-encrypts stored values that are not encrypted under the current key
+func runRotateCommand() int {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+	if cfg.EncryptionKeyProvider == "" {
+		log.Printf("MCP_REGISTRY_ENCRYPTION_KEY_PROVIDER is not set")
+		return 1
+	}
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	db, err := openDatabase(connectCtx, cfg)
+	cancel()
+	if err != nil {
+		log.Printf("Failed to connect to PostgreSQL: %v", err)
+		return 1
+	}
+	defer db.Close()
+
+	registry, err := service.NewRegistryService(db, cfg)
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+	rotated, err := registry.RotateEncryptedColumns(ctx)
+	if err != nil {
+		log.Printf("Rotation stopped after re-encrypting %d values: %v", rotated, err)
+		return 1
+	}
+
+	log.Printf("Re-encrypted %d values", rotated)
+	return 0
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+const eventsUsage = `Usage: registry events replay --since <seq> --target <webhook-url> [--until <seq>] [--limit <n>]
+
+Re-deliver recorded server changes to a webhook, one POST per change in sequence order.
+The database is read from the same MCP_REGISTRY_* environment variables as the server.`
+
+// runEventsCommand runs the events subcommand and returns the process exit code
+func runEventsCommand(args []string) int {
+	if len(args) == 0 || args[0] != "replay" {
+		fmt.Fprintln(os.Stderr, eventsUsage)
+		return 2
+	}
+
+	flags := flag.NewFlagSet("events replay", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, eventsUsage)
+		flags.PrintDefaults()
+	}
+	since := flags.Int64("since", 0, "Replay changes with a sequence number greater than this value")
+	until := flags.Int64("until", 0, "Stop after the change with this sequence number (default: latest)")
+	limit := flags.Int("limit", 0, "Maximum number of changes to deliver (default: no limit)")
+	target := flags.String("target", "", "Webhook URL that receives one POST per change")
+	format := flags.String("format", "cloudevents", "Payload format: cloudevents, full or minimal, as for webhook subscriptions")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *target == "" {
+		fmt.Fprintln(os.Stderr, "--target is required")
+		flags.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	db, err := openDatabase(connectCtx, cfg)
+	cancel()
+	if err != nil {
+		log.Printf("Failed to connect to PostgreSQL: %v", err)
+		return 1
+	}
+	defer db.Close()
+
+	registry, err := service.NewRegistryService(db, cfg)
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+	result, err := service.ReplayChanges(ctx, registry, *target, service.ReplayOptions{
+		Since:  *since,
+		Until:  *until,
+		Limit:  *limit,
+		Format: *format,
+		Source: service.EventSource(cfg),
+	})
+	if err != nil {
+		if result != nil {
+			log.Printf("Replay stopped after delivering %d changes: %v", result.Delivered, err)
+			log.Printf("Resume with --since %d", result.LastSeq)
+		} else {
+			log.Printf("Replay failed: %v", err)
+		}
+		return 1
+	}
+
+	log.Printf("Delivered %d changes to %s (last seq %d)", result.Delivered, *target, result.LastSeq)
+	return 0
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/diff"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+const importUsage = `Usage: registry import [--dry-run [--diff]] [--policy strict|lenient|repair] <source>...
+
+Import seed data into the registry, from any source MCP_REGISTRY_SEED_FROM accepts: files, URLs,
+registry /v0/servers and /servers/export URLs, oci:// snapshots, and smithery: and glama: directories.
+
+With --dry-run nothing is written: the versions the import would create, the versions the registry
+already has with different content, and the invalid records are listed instead. Imports never
+overwrite existing versions, so changed versions are skipped. --diff shows how each changed version
+differs, field by field. The database is read from the same MCP_REGISTRY_* environment variables as
+the server.`
+
+// runImportCommand runs the import subcommand and returns the process exit code
+func runImportCommand(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, importUsage)
+		flags.PrintDefaults()
+	}
+	dryRun := flags.Bool("dry-run", false, "List what the import would do without writing anything")
+	showDiff := flags.Bool("diff", false, "With --dry-run, show field-level diffs of versions that differ from the registry")
+	policyName := flags.String("policy", "", "How invalid records are handled: strict, lenient or repair (default: MCP_REGISTRY_SEED_POLICY)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 || (*showDiff && !*dryRun) {
+		flags.Usage()
+		return 2
+	}
+	switch importer.Policy(*policyName) {
+	case "", importer.PolicyStrict, importer.PolicyLenient, importer.PolicyRepair:
+	default:
+		fmt.Fprintln(os.Stderr, "--policy must be strict, lenient or repair")
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	db, err := openDatabase(connectCtx, cfg)
+	cancel()
+	if err != nil {
+		log.Printf("Failed to connect to PostgreSQL: %v", err)
+		return 1
+	}
+	defer db.Close()
+
+	policy := importer.Policy(cfg.SeedPolicy)
+	if *policyName != "" {
+		policy = importer.Policy(*policyName)
+	}
+	registry, err := service.NewRegistryService(db, cfg)
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+	importerService := importer.NewService(registry).WithPolicy(policy).WithSmitheryAPIKey(cfg.SmitheryAPIKey)
+
+	status := 0
+	for _, source := range flags.Args() {
+		if !*dryRun {
+			log.Printf("Importing data from %s with the %s policy...", source, policy)
+			if err := importerService.ImportFromPath(ctx, source); err != nil {
+				log.Printf("Failed to import seed data from %s: %v", source, err)
+				status = 1
+			}
+			continue
+		}
+
+		plan, err := importerService.PlanImport(ctx, source)
+		if err != nil {
+			log.Printf("Failed to plan import from %s: %v", source, err)
+			status = 1
+			continue
+		}
+		if flags.NArg() > 1 {
+			fmt.Printf("%s:\n", source)
+		}
+		printPlan(os.Stdout, plan, *showDiff)
+	}
+	return status
+}
+
+// printPlan writes the records a planned import would create, skip as changed, or reject as invalid, followed by a
+// summary. Unchanged records are only counted.
+func printPlan(w io.Writer, plan *importer.Plan, showDiff bool) {
+	newServers := 0
+	for _, entry := range plan.Entries {
+		switch entry.Action {
+		case importer.ActionCreate:
+			if entry.NewServer {
+				newServers++
+				fmt.Fprintf(w, "create   %s %s (new server)\n", entry.Name, entry.Version)
+			} else {
+				fmt.Fprintf(w, "create   %s %s\n", entry.Name, entry.Version)
+			}
+		case importer.ActionChanged:
+			fmt.Fprintf(w, "changed  %s %s\n", entry.Name, entry.Version)
+			if showDiff {
+				printDiff(w, entry.Diff)
+			}
+		case importer.ActionInvalid:
+			fmt.Fprintf(w, "invalid  %s %s: %v\n", entry.Name, entry.Version, entry.Error)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d to create (%d new servers), %d changed and skipped, %d unchanged, %d invalid\n",
+		plan.Count(importer.ActionCreate), newServers, plan.Count(importer.ActionChanged),
+		plan.Count(importer.ActionUnchanged), plan.Count(importer.ActionInvalid))
+	if plan.Aborted {
+		fmt.Fprintln(w, "The strict policy would abort the import because of the invalid records: nothing would be created")
+	}
+}
+
+// printDiff writes how the registry's version differs from the seed record, as "registry -> seed" values
+func printDiff(w io.Writer, d *diff.Diff) {
+	printFields(w, "         ", d.Fields)
+	for _, pkg := range d.Packages {
+		fmt.Fprintf(w, "         package %s %s: %s\n", pkg.RegistryType, pkg.Identifier, pkg.Change)
+		if pkg.FromVersion != "" && pkg.ToVersion != "" {
+			fmt.Fprintf(w, "           version: %q -> %q\n", pkg.FromVersion, pkg.ToVersion)
+		}
+		printFields(w, "           ", pkg.Fields)
+		printInputs(w, "environment variable", pkg.EnvironmentVariables)
+		printInputs(w, "runtime argument", pkg.RuntimeArguments)
+		printInputs(w, "package argument", pkg.PackageArguments)
+	}
+	for _, remote := range d.Remotes {
+		fmt.Fprintf(w, "         remote %s %s: %s\n", remote.Type, remote.URL, remote.Change)
+		printFields(w, "           ", remote.Fields)
+		printInputs(w, "header", remote.Headers)
+	}
+}
+
+// printInputs writes changed environment variables, arguments or headers of a package or remote
+func printInputs(w io.Writer, kind string, inputs []diff.InputChange) {
+	for _, input := range inputs {
+		fmt.Fprintf(w, "           %s %s: %s\n", kind, input.Name, input.Change)
+		printFields(w, "             ", input.Fields)
+	}
+}
+
+// printFields writes changed fields as JSON values, with unset values shown as "(unset)"
+func printFields(w io.Writer, indent string, fields []diff.FieldChange) {
+	for _, field := range fields {
+		fmt.Fprintf(w, "%s%s: %s -> %s\n", indent, field.Field, jsonValue(field.From), jsonValue(field.To))
+	}
+}
+
+func jsonValue(value any) string {
+	if value == nil {
+		return "(unset)"
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
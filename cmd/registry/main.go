@@ -4,20 +4,33 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/modelcontextprotocol/registry/data"
 	"github.com/modelcontextprotocol/registry/internal/api"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/artifacts"
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/encryption"
 	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/probe"
+	"github.com/modelcontextprotocol/registry/internal/ranking"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/stale"
+	"github.com/modelcontextprotocol/registry/internal/systemd"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/toolindex"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 )
 
 // Version info for the MCP Registry application
@@ -33,7 +46,42 @@ var (
 	GitCommit = "unknown"
 )
 
+// jobLockRetryInterval is how often replicas that are not running a background job check whether they
+// should take it over, and how often the running replica checks it still holds the job lock
+const jobLockRetryInterval = 30 * time.Second
+
+// bulkJobPollInterval is how often the replica running bulk jobs checks for newly queued ones
+const bulkJobPollInterval = 5 * time.Second
+
+// pendingPublishPollInterval is how often the replica completing asynchronous publishes checks for new ones
+const pendingPublishPollInterval = time.Second
+
+// artifactMirrorPollInterval is how often the replica mirroring package artifacts checks for newly published versions
+const artifactMirrorPollInterval = 5 * time.Second
+
 func main() {
+	// Run a maintenance subcommand instead of the server if one is given
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "events":
+			os.Exit(runEventsCommand(os.Args[2:]))
+		case "config":
+			os.Exit(runConfigCommand(os.Args[2:]))
+		case "encryption":
+			os.Exit(runEncryptionCommand(os.Args[2:]))
+		case "documents":
+			os.Exit(runDocumentsCommand(os.Args[2:]))
+		case "push-oci":
+			os.Exit(runPushOCICommand(os.Args[2:]))
+		case "import":
+			os.Exit(runImportCommand(os.Args[2:]))
+		case "generate-site":
+			os.Exit(runGenerateSiteCommand(os.Args[2:]))
+		case "db":
+			os.Exit(runDBCommand(os.Args[2:]))
+		}
+	}
+
 	// Parse command line flags
 	showVersion := flag.Bool("version", false, "Display version information")
 	flag.Parse()
@@ -55,39 +103,92 @@ func main() {
 	)
 
 	// Initialize configuration
-	cfg := config.NewConfig()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return
+	}
+	if _, err := ranking.NewFromConfig(cfg, nil, nil); err != nil {
+		log.Printf("Invalid search ranking configuration: %v", err)
+		return
+	}
+	if _, err := api.ListenersFromConfig(cfg); err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return
+	}
+	if cfg.RemoteProbeEnabled && (cfg.RemoteProbeInterval <= 0 || cfg.RemoteProbeTimeout <= 0) {
+		log.Printf("Invalid configuration: remote_probe.interval and remote_probe.timeout must be positive")
+		return
+	}
+	if cfg.ToolIndexEnabled && (cfg.ToolIndexInterval <= 0 || cfg.ToolIndexTimeout <= 0) {
+		log.Printf("Invalid configuration: tool_index.interval and tool_index.timeout must be positive")
+		return
+	}
+	if err := v0auth.ValidateProviderNames(cfg.AuthProviders); err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return
+	}
+	if _, err := encryption.NewFromConfig(cfg); err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return
+	}
+	if _, err := artifacts.NewFromConfig(cfg); err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return
+	}
+	if _, err := auth.LoadRoutePolicy(cfg.RoutePolicyFile); err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return
+	}
+	if _, err := v0auth.LoadTrustedIssuers(cfg.OIDCIssuersFile); err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return
+	}
 
 	// Create a context with timeout for PostgreSQL connection
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Connect to PostgreSQL
-	db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL)
+	// Connect to PostgreSQL, or keep data in memory when asked to
+	db, err = openDatabase(ctx, cfg)
 	if err != nil {
 		log.Printf("Failed to connect to PostgreSQL: %v", err)
 		return
 	}
 
-	// Store the PostgreSQL instance for later cleanup
+	// Store the database instance for later cleanup
 	defer func() {
 		if err := db.Close(); err != nil {
-			log.Printf("Error closing PostgreSQL connection: %v", err)
+			log.Printf("Error closing database connection: %v", err)
 		} else {
-			log.Println("PostgreSQL connection closed successfully")
+			log.Println("Database connection closed successfully")
 		}
 	}()
 
-	registryService = service.NewRegistryService(db, cfg)
+	registryService, err = service.NewRegistryService(db, cfg)
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return
+	}
+	// Refuse registry tokens to identities admins have banned
+	auth.SetBanChecker(registryService)
 
-	// Import seed data if seed source is provided
-	if cfg.SeedFrom != "" {
-		log.Printf("Importing data from %s...", cfg.SeedFrom)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-		defer cancel()
+	// Import seed data if seed source is provided, or the embedded demo snapshot into an empty database.
+	// Read-only replicas leave it to the primary, unless their data is their own.
+	if (cfg.SeedFrom != "" || cfg.SeedEmbedded) && (!cfg.ReadOnly || cfg.DatabaseURL == database.MemoryURL) {
+		importSeed(db, registryService, cfg)
+	}
 
-		importerService := importer.NewService(registryService)
-		if err := importerService.ImportFromPath(ctx, cfg.SeedFrom); err != nil {
-			log.Printf("Failed to import seed data: %v", err)
+	// Run background jobs, unless this is a read-only replica whose primary runs them
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	stopPendingPublishes := func(context.Context) {}
+	if cfg.ReadOnly {
+		log.Printf("Serving reads only; writes go to %s", cfg.PrimaryURL)
+	} else {
+		startBackgroundJobs(jobsCtx, db, registryService, cfg)
+		if cfg.AsyncPublishEnabled {
+			stopPendingPublishes = startPendingPublishes(db, registryService)
 		}
 	}
 
@@ -97,6 +198,15 @@ func main() {
 		return
 	}
 
+	sloObjectives := telemetry.SLOObjectives{
+		Availability:     cfg.SLOAvailabilityObjective,
+		Latency:          cfg.SLOLatencyObjective,
+		LatencyThreshold: cfg.SLOLatencyThreshold,
+	}
+	if err := metrics.TrackSLOs(sloObjectives); err != nil {
+		log.Printf("Not reporting SLO burn rates: %v", err)
+	}
+
 	defer func() {
 		if err := shutdownTelemetry(context.Background()); err != nil {
 			log.Printf("Failed to shutdown telemetry: %v", err)
@@ -127,6 +237,9 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	if _, err := systemd.Notify(systemd.Stopping); err != nil {
+		log.Printf("Failed to notify systemd of shutdown: %v", err)
+	}
 
 	// Create context with timeout for shutdown
 	sctx, scancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -136,6 +249,263 @@ func main() {
 	if err := server.Shutdown(sctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
+	stopPendingPublishes(sctx)
 
 	log.Println("Server exiting")
 }
+
+// openDatabase connects to the configured database
+func openDatabase(ctx context.Context, cfg *config.Config) (database.Database, error) {
+	if cfg.DatabaseURL == database.MemoryURL {
+		log.Println("Using in-memory database: data will be lost when the registry stops")
+		return withShadowDatabase(ctx, cfg, database.NewMemory())
+	}
+	if cfg.ReadOnly {
+		return database.NewReadOnlyPostgreSQL(ctx, cfg.DatabaseURL)
+	}
+	db, err := database.NewPostgreSQL(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return nil, err
+	}
+	return withShadowDatabase(ctx, cfg, db)
+}
+
+// withShadowDatabase repeats the writes to db on the shadow database, if one is configured
+func withShadowDatabase(ctx context.Context, cfg *config.Config, db database.Database) (database.Database, error) {
+	if cfg.ShadowDatabaseURL == "" {
+		return db, nil
+	}
+
+	var shadow database.Database = database.NewMemory()
+	if cfg.ShadowDatabaseURL != database.MemoryURL {
+		var err error
+		shadow, err = database.NewPostgreSQL(ctx, cfg.ShadowDatabaseURL)
+		if err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("failed to connect to the shadow database: %w", err)
+		}
+	}
+	log.Println("Repeating every write on the shadow database")
+	return database.NewShadow(db, shadow), nil
+}
+
+// startBackgroundJobs starts the configured background jobs, each on one replica at a time, until jobsCtx is
+// canceled
+func startBackgroundJobs(jobsCtx context.Context, db database.Database, registryService service.RegistryService, cfg *config.Config) {
+	// Continuously replicate from another registry's changes feed if configured
+	if cfg.ReplicateFrom != "" {
+		log.Printf("Replicating changes from %s every %s", cfg.ReplicateFrom, cfg.ReplicateInterval)
+		importerService := importer.NewService(registryService)
+		go database.RunAsLeader(jobsCtx, db, "replication", jobLockRetryInterval, func(ctx context.Context) {
+			importerService.RunReplication(ctx, cfg.ReplicateFrom, db, cfg.ReplicateInterval)
+		})
+	}
+
+	// Periodically prune old versions if a retention policy is configured
+	if policy := service.RetentionPolicyFromConfig(cfg); policy.Enabled() {
+		log.Printf("Pruning versions beyond %d per server or older than %s every %s",
+			policy.MaxVersionsPerServer, policy.MaxAge, cfg.RetentionInterval)
+		go database.RunAsLeader(jobsCtx, db, "retention", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunRetention(ctx, registryService, policy, cfg.RetentionInterval)
+		})
+	}
+
+	// Periodically delete old audit log entries if a retention period is configured
+	if cfg.AuditLogRetention > 0 {
+		log.Printf("Pruning audit log entries older than %s every %s", cfg.AuditLogRetention, cfg.RetentionInterval)
+		go database.RunAsLeader(jobsCtx, db, "audit-retention", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunAuditRetention(ctx, registryService, cfg.AuditLogRetention, cfg.RetentionInterval)
+		})
+	}
+
+	// Periodically delete old changes feed entries if a retention period is configured
+	if cfg.ChangesRetention > 0 {
+		log.Printf("Pruning changes older than %s every %s", cfg.ChangesRetention, cfg.RetentionInterval)
+		go database.RunAsLeader(jobsCtx, db, "changes-retention", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunChangesRetention(ctx, registryService, cfg.ChangesRetention, cfg.RetentionInterval)
+		})
+	}
+
+	// Periodically create the monthly partitions of the audit log and changes feed ahead of time
+	go database.RunAsLeader(jobsCtx, db, "partition-maintenance", jobLockRetryInterval, func(ctx context.Context) {
+		service.RunPartitionMaintenance(ctx, registryService, cfg.RetentionInterval)
+	})
+
+	// Periodically delete old publish attempts from publishers' publish history
+	if cfg.PublishHistoryRetention > 0 {
+		go database.RunAsLeader(jobsCtx, db, "publish-history-retention", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunPublishHistoryRetention(ctx, registryService, cfg.PublishHistoryRetention, cfg.RetentionInterval)
+		})
+	}
+
+	// Periodically delete the server.json of rejected publishes once publishers have had time to review it
+	if cfg.PublishHistoryRetention > 0 && cfg.PublishQuarantineRetention > 0 {
+		go database.RunAsLeader(jobsCtx, db, "publish-quarantine-retention", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunPublishQuarantineRetention(ctx, registryService, cfg.PublishQuarantineRetention, cfg.RetentionInterval)
+		})
+	}
+
+	// Periodically restore the servers quarantined by bans that have expired
+	go database.RunAsLeader(jobsCtx, db, "ban-expiry", jobLockRetryInterval, func(ctx context.Context) {
+		service.RunBanExpiry(ctx, registryService, cfg.RetentionInterval)
+	})
+
+	// Periodically delete install reports that no longer count towards success rates
+	if cfg.InstallFeedbackEnabled && cfg.InstallFeedbackWindow > 0 {
+		go database.RunAsLeader(jobsCtx, db, "install-report-retention", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunInstallReportRetention(ctx, registryService, cfg.RetentionInterval)
+		})
+	}
+
+	// Periodically delete expired upstream registry responses if the metadata cache is enabled
+	if cfg.UpstreamCacheTTL > 0 || cfg.UpstreamCacheNegativeTTL > 0 {
+		go database.RunAsLeader(jobsCtx, db, "upstream-cache-purge", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunUpstreamCachePurge(ctx, registryService, cfg.RetentionInterval)
+		})
+	}
+
+	// Periodically delete expired previews if previews are enabled
+	if cfg.PreviewTTL > 0 {
+		go database.RunAsLeader(jobsCtx, db, "preview-purge", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunPreviewPurge(ctx, registryService, cfg.RetentionInterval)
+		})
+	}
+
+	// Periodically probe declared remote endpoints if remote health probing is enabled
+	if cfg.RemoteProbeEnabled {
+		log.Printf("Probing remote endpoints every %s", cfg.RemoteProbeInterval)
+		prober := probe.NewProber(cfg.RemoteProbeTimeout, false)
+		go database.RunAsLeader(jobsCtx, db, "remote-probe", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunRemoteProbes(ctx, registryService, prober, cfg.RemoteProbeInterval)
+		})
+	}
+
+	// Periodically index the tools servers expose if tool indexing is enabled
+	if cfg.ToolIndexEnabled {
+		log.Printf("Indexing server tools every %s", cfg.ToolIndexInterval)
+		indexer := toolindex.NewIndexer(cfg, probe.NewProber(cfg.ToolIndexTimeout, false))
+		go database.RunAsLeader(jobsCtx, db, "tool-index", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunToolIndexing(ctx, registryService, indexer, cfg.ToolIndexInterval)
+		})
+	}
+
+	// Periodically look for abandoned servers if the stale-entry sweeper is enabled
+	if cfg.StaleSweepEnabled {
+		log.Printf("Sweeping for stale servers every %s", cfg.StaleSweepInterval)
+		checker := stale.NewChecker(cfg, validators.PackageChecker{})
+		go database.RunAsLeader(jobsCtx, db, "stale-sweep", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunStaleSweep(ctx, registryService, checker, cfg.StaleSweepInterval)
+		})
+	}
+
+	// Deliver changes to webhook subscriptions, on one replica at a time so each change is posted once
+	if cfg.WebhookDeliveryInterval > 0 {
+		go database.RunAsLeader(jobsCtx, db, "webhook-delivery", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunWebhookDelivery(ctx, registryService, cfg.WebhookDeliveryInterval)
+		})
+	}
+
+	// Run admin bulk jobs queued through the API, on one replica at a time
+	go database.RunAsLeader(jobsCtx, db, "bulk-jobs", jobLockRetryInterval, func(ctx context.Context) {
+		service.RunBulkJobs(ctx, registryService, bulkJobPollInterval)
+	})
+
+	// Mirror the package artifacts of published versions if artifact mirroring is enabled
+	if cfg.ArtifactStorageDir != "" {
+		go database.RunAsLeader(jobsCtx, db, "artifact-mirrors", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunArtifactMirrors(ctx, registryService, artifactMirrorPollInterval)
+		})
+	}
+}
+
+// startPendingPublishes completes publishes accepted in asynchronous mode, on one replica at a time. Unlike the
+// other background jobs, the worker is stopped before the registry exits rather than when it exits, so the
+// publish it is running returns to pending and its transaction ends while the database is still open. The
+// returned function stops the worker and waits for it, until ctx is done.
+func startPendingPublishes(db database.Database, registryService service.RegistryService) func(ctx context.Context) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		database.RunAsLeader(ctx, db, "pending-publishes", jobLockRetryInterval, func(ctx context.Context) {
+			service.RunPendingPublishes(ctx, registryService, pendingPublishPollInterval)
+		})
+	}()
+
+	return func(shutdownCtx context.Context) {
+		cancel()
+		select {
+		case <-done:
+		case <-shutdownCtx.Done():
+			log.Printf("Pending publish worker did not stop in time: %v", shutdownCtx.Err())
+		}
+	}
+}
+
+// importSeed imports seed data from each source in turn, or the embedded demo snapshot when no source is
+// configured, unless another replica is already importing it
+func importSeed(db database.Database, registryService service.RegistryService, cfg *config.Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	lock, err := db.TryAcquireJobLock(ctx, "seed")
+	if errors.Is(err, database.ErrLockNotAcquired) {
+		log.Printf("Skipping seed import: another instance is importing it")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to import seed data: %v", err)
+		return
+	}
+	defer func() {
+		if err := lock.Release(context.Background()); err != nil {
+			log.Printf("Failed to release seed job lock: %v", err)
+		}
+	}()
+
+	policy := importer.Policy(cfg.SeedPolicy)
+	if cfg.SeedFrom == "" {
+		importEmbeddedSeed(ctx, db, cfg, policy)
+		return
+	}
+
+	importerService := importer.NewService(registryService).WithPolicy(policy).WithSmitheryAPIKey(cfg.SmitheryAPIKey)
+	for _, source := range strings.Split(cfg.SeedFrom, ",") {
+		source = strings.TrimSpace(source)
+		if source == "" {
+			continue
+		}
+		log.Printf("Importing data from %s with the %s policy...", source, policy)
+		if err := importerService.ImportFromPath(ctx, source); err != nil {
+			log.Printf("Failed to import seed data from %s: %v", source, err)
+		}
+	}
+}
+
+// importEmbeddedSeed imports the demo snapshot built into the registry if the database has no servers yet, so a
+// registry started without configuration serves something to evaluate. The snapshot is curated, so its packages
+// are not checked against their registries, which also lets it load without network access.
+func importEmbeddedSeed(ctx context.Context, db database.Database, cfg *config.Config, policy importer.Policy) {
+	includeDeleted := true
+	servers, _, err := db.ListServers(ctx, nil, &database.ServerFilter{IncludeDeleted: &includeDeleted}, "", 1)
+	if err != nil {
+		log.Printf("Failed to import the embedded seed data: %v", err)
+		return
+	}
+	if len(servers) > 0 {
+		return
+	}
+
+	seedCfg := *cfg
+	seedCfg.EnableRegistryValidation = false
+	log.Printf("Database is empty: importing the embedded demo snapshot (set MCP_REGISTRY_SEED_EMBEDDED=false to start empty)")
+	registry, err := service.NewRegistryService(db, &seedCfg)
+	if err != nil {
+		log.Printf("Failed to import the embedded seed data: %v", err)
+		return
+	}
+	importerService := importer.NewService(registry).WithPolicy(policy)
+	if err := importerService.ImportFromData(ctx, data.Seed); err != nil {
+		log.Printf("Failed to import the embedded seed data: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+const pushOCIUsage = `Usage: registry push-oci [--compression gzip|zstd] <reference>
+
+Push a snapshot of every server version that is not deleted to a container registry as an OCI
+artifact, such as harbor.example.com/mcp/registry-mirror:latest. Mirrors import it by setting
+MCP_REGISTRY_SEED_FROM=oci://<reference>. Credentials come from the Docker configuration, as for
+docker push. The database is read from the same MCP_REGISTRY_* environment variables as the server.`
+
+// runPushOCICommand runs the push-oci subcommand and returns the process exit code
+func runPushOCICommand(args []string) int {
+	flags := flag.NewFlagSet("push-oci", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, pushOCIUsage)
+		flags.PrintDefaults()
+	}
+	compressionName := flags.String("compression", string(importer.CompressionGzip), "Compress the snapshot with gzip or zstd. zstd artifacts are smaller, but older registries cannot import them")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	reference := flags.Arg(0)
+	compression, err := importer.ParseCompression(*compressionName)
+	if err != nil || compression == importer.CompressionNone {
+		fmt.Fprintln(os.Stderr, "--compression must be gzip or zstd")
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	db, err := openDatabase(connectCtx, cfg)
+	cancel()
+	if err != nil {
+		log.Printf("Failed to connect to PostgreSQL: %v", err)
+		return 1
+	}
+	defer db.Close()
+
+	registry, err := service.NewRegistryService(db, cfg)
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+	servers, err := importer.Snapshot(ctx, registry)
+	if err != nil {
+		log.Printf("Failed to take snapshot: %v", err)
+		return 1
+	}
+	digest, err := importer.PushSnapshot(ctx, reference, servers, compression)
+	if err != nil {
+		log.Printf("Push failed: %v", err)
+		return 1
+	}
+
+	log.Printf("Pushed %d server versions to %s@%s", len(servers), reference, digest)
+	return 0
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/site"
+)
+
+const generateSiteUsage = `Usage: registry generate-site --output <dir> [--title <title>] [<source>...]
+
+Generate a static HTML catalog of every server that is not deleted: an index with client-side
+search, and a page per server with its packages, remotes, versions and server.json. The site uses
+relative links only, so it can be served from any path of any static web server, or opened from disk.
+
+Without sources the servers are read from the database, configured with the same MCP_REGISTRY_*
+environment variables as the server. With sources, such as a snapshot written by /servers/export or
+an oci:// reference pushed with push-oci, they are read from the sources instead and no database is
+needed: any source MCP_REGISTRY_SEED_FROM accepts works. Everything under <dir>/servers is replaced.`
+
+// runGenerateSiteCommand runs the generate-site subcommand and returns the process exit code
+func runGenerateSiteCommand(args []string) int {
+	flags := flag.NewFlagSet("generate-site", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, generateSiteUsage)
+		flags.PrintDefaults()
+	}
+	output := flags.String("output", "", "Directory to write the site to, created if needed")
+	title := flags.String("title", site.DefaultTitle, "Title shown at the top of every page")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *output == "" {
+		flags.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+
+	var db database.Database
+	if flags.NArg() == 0 {
+		connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		db, err = openDatabase(connectCtx, cfg)
+		cancel()
+		if err != nil {
+			log.Printf("Failed to connect to PostgreSQL: %v", err)
+			return 1
+		}
+	} else {
+		// The sources' servers were validated when they were published, so they are not checked upstream again
+		db = database.NewMemory()
+		cfg.EnableRegistryValidation = false
+		cfg.EnableLinkCheck = false
+		cfg.GitHubRepoVerification = false
+	}
+	defer db.Close()
+	registry, err := service.NewRegistryService(db, cfg)
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return 1
+	}
+
+	// Snapshots are loaded into memory the way read-only mirrors seeded with memory:// load them
+	importerService := importer.NewService(registry).WithPolicy(importer.PolicyLenient).WithSmitheryAPIKey(cfg.SmitheryAPIKey)
+	for _, source := range flags.Args() {
+		if err := importerService.ImportFromPath(ctx, source); err != nil {
+			log.Printf("Failed to read servers from %s: %v", source, err)
+			return 1
+		}
+	}
+
+	result, err := site.Generate(ctx, registry, *output, site.Options{Title: *title})
+	if err != nil {
+		log.Printf("Failed to generate site: %v", err)
+		return 1
+	}
+	log.Printf("Generated pages for %d servers (%d versions) in %s", result.Servers, result.Versions, *output)
+	return 0
+}
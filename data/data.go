@@ -0,0 +1,11 @@
+// Package data holds the demo snapshot built into the registry, which is imported on the first start of a
+// registry whose database is empty and that has no seed source configured. Replace seed.json before building to
+// ship a different snapshot.
+package data
+
+import _ "embed"
+
+// Seed is the embedded snapshot, in the seed format MCP_REGISTRY_SEED_FROM accepts
+//
+//go:embed seed.json
+var Seed []byte
@@ -143,6 +143,11 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 										},
 									},
 								},
+								// Distinct per environment so staging tokens are not accepted by prod
+								&corev1.EnvVarArgs{
+									Name:  pulumi.String("MCP_REGISTRY_JWT_ISSUER"),
+									Value: pulumi.String("https://" + environment + ".registry.modelcontextprotocol.io"),
+								},
 								// Google Cloud Identity OIDC for admin access
 								&corev1.EnvVarArgs{
 									Name:  pulumi.String("MCP_REGISTRY_OIDC_ENABLED"),
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// cacheControl returns the Cache-Control value for a policy. A zero TTL forbids caching altogether.
+func cacheControl(policy config.CachePolicy) string {
+	if policy.TTL <= 0 {
+		return "no-store"
+	}
+	visibility := "public"
+	if policy.Private {
+		visibility = "private"
+	}
+	header := fmt.Sprintf("%s, max-age=%d", visibility, int(policy.TTL.Seconds()))
+	if policy.StaleWhileRevalidate > 0 {
+		header += fmt.Sprintf(", stale-while-revalidate=%d", int(policy.StaleWhileRevalidate.Seconds()))
+	}
+	return header
+}
+
+// cacheRoute returns which kind of anonymous read path is, or "" for paths no cache policy applies to.
+// The changes feed is never cached, since clients poll it for what is new.
+func cacheRoute(r *http.Request) string {
+	rest, found := strings.CutPrefix(r.URL.Path, "/v0.1")
+	if !found {
+		if rest, found = strings.CutPrefix(r.URL.Path, "/v0"); !found {
+			return ""
+		}
+	}
+
+	switch {
+	case rest == "/servers" && r.URL.Query().Get("search") != "", rest == "/servers/suggest":
+		return "search"
+	case rest == "/servers", rest == "/namespaces", strings.HasPrefix(rest, "/namespaces/") && strings.HasSuffix(rest, "/servers"):
+		return "list"
+	case rest == "/servers/changes" || strings.HasPrefix(rest, "/servers/changes/"):
+		return ""
+	case strings.HasPrefix(rest, "/servers/"):
+		return "detail"
+	case rest == "/stats" || (strings.HasPrefix(rest, "/namespaces/") && strings.HasSuffix(rest, "/stats")):
+		return "stats"
+	}
+	return ""
+}
+
+// NewCachePolicyMiddleware sets Cache-Control on successful anonymous reads according to the configured
+// policy for their route, so operators can tune how long CDNs keep lists, details and searches. Requests
+// with an Authorization header and responses that already set Cache-Control are left alone. It does nothing
+// unless a policy is configured.
+func NewCachePolicyMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	if len(cfg.CachePolicies) == 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			policy, found := cfg.CachePolicies[cacheRoute(r)]
+			if !found || (r.Method != http.MethodGet && r.Method != http.MethodHead) || r.Header.Get("Authorization") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(&cachePolicyWriter{ResponseWriter: w, header: cacheControl(policy)}, r)
+		})
+	}
+}
+
+// cachePolicyWriter sets Cache-Control when a response turns out to be successful
+type cachePolicyWriter struct {
+	http.ResponseWriter
+	header      string
+	wroteHeader bool
+}
+
+func (w *cachePolicyWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if status < http.StatusBadRequest && w.Header().Get("Cache-Control") == "" {
+			w.Header().Set("Cache-Control", w.header)
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *cachePolicyWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, for flushing streamed responses
+func (w *cachePolicyWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
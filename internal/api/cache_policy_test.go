@@ -0,0 +1,63 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestCachePolicyMiddleware(t *testing.T) {
+	status := http.StatusOK
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/card") {
+			w.Header().Set("Cache-Control", "private, no-store")
+		}
+		w.WriteHeader(status)
+	})
+
+	middleware := api.NewCachePolicyMiddleware(&config.Config{CachePolicies: config.CachePolicies{
+		"list":   {TTL: time.Minute, StaleWhileRevalidate: 5 * time.Minute},
+		"detail": {TTL: time.Hour},
+		"search": {},
+		"stats":  {TTL: 30 * time.Second, Private: true},
+	}})
+
+	serve := func(method, path, authorization string) string {
+		req := httptest.NewRequest(method, path, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		middleware(handler).ServeHTTP(w, req)
+		return w.Header().Get("Cache-Control")
+	}
+
+	assert.Equal(t, "public, max-age=60, stale-while-revalidate=300", serve(http.MethodGet, "/v0/servers", ""))
+	assert.Equal(t, "public, max-age=60, stale-while-revalidate=300", serve(http.MethodGet, "/v0.1/servers?limit=10", ""))
+	assert.Equal(t, "no-store", serve(http.MethodGet, "/v0/servers?search=weather", ""))
+	assert.Equal(t, "no-store", serve(http.MethodGet, "/v0/servers/suggest?q=we", ""))
+	assert.Equal(t, "public, max-age=3600", serve(http.MethodHead, "/v0/servers/io.github.octocat%2Fweather/versions/latest", ""))
+
+	// Handlers' own Cache-Control wins
+	assert.Equal(t, "private, no-store", serve(http.MethodGet, "/v0/servers/io.github.octocat%2Fweather/versions/1.0.0/card", ""))
+
+	assert.Equal(t, "private, max-age=30", serve(http.MethodGet, "/v0/stats", ""))
+
+	// Authenticated requests, writes, routes without a policy and the changes feed are left alone
+	assert.Empty(t, serve(http.MethodGet, "/v0/servers", "Bearer token"))
+	assert.Empty(t, serve(http.MethodPost, "/v0/servers/io.github.octocat%2Fweather/rename", ""))
+	assert.Empty(t, serve(http.MethodGet, "/v0/health", "Bearer token"))
+	assert.Empty(t, serve(http.MethodGet, "/v0/servers/changes?since=0", ""))
+	assert.Empty(t, serve(http.MethodGet, "/v0/health", ""))
+
+	// Errors are never cached
+	status = http.StatusNotFound
+	assert.Empty(t, serve(http.MethodGet, "/v0/servers/io.github.octocat%2Fmissing/versions/latest", ""))
+}
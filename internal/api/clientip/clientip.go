@@ -0,0 +1,155 @@
+// Package clientip determines the address of the client that made a request when the registry runs
+// behind load balancers or reverse proxies.
+//
+// Forwarding headers are only followed through proxies the registry is configured to trust. The chain in
+// the Forwarded (RFC 7239) or X-Forwarded-For header is read from the nearest hop backwards, and the
+// first address that is not a trusted proxy is the client. Entries further left were supplied by that
+// client and are never used, so clients cannot choose their own address.
+package clientip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+type contextKey struct{}
+
+// Resolver extracts client addresses from requests. A nil or empty Resolver trusts no proxies and
+// always returns the address of the connection.
+type Resolver struct {
+	trusted []netip.Prefix
+}
+
+// ParseTrustedProxies parses CIDRs and single IP addresses into prefixes
+func ParseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: expected a CIDR or IP address", value)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+// NewResolver creates a resolver trusting the given proxy CIDRs and addresses
+func NewResolver(trustedProxies []string) (*Resolver, error) {
+	trusted, err := ParseTrustedProxies(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	return &Resolver{trusted: trusted}, nil
+}
+
+// isTrusted reports whether addr is one of the trusted proxies
+func (r *Resolver) isTrusted(addr netip.Addr) bool {
+	if r == nil {
+		return false
+	}
+	for _, prefix := range r.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the address of the client that made the request
+func (r *Resolver) ClientIP(req *http.Request) string {
+	peer, ok := parseAddr(req.RemoteAddr)
+	if !ok {
+		return req.RemoteAddr
+	}
+	if !r.isTrusted(peer) {
+		return peer.String()
+	}
+
+	hops := forwardedFor(req)
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, ok := parseAddr(hops[i])
+		if !ok {
+			// An obfuscated or malformed entry ends the chain we can verify
+			return peer.String()
+		}
+		if !r.isTrusted(addr) {
+			return addr.String()
+		}
+		peer = addr
+	}
+	return peer.String()
+}
+
+// Middleware stores each request's client address in its context for FromContext
+func (r *Resolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		next.ServeHTTP(w, req.WithContext(NewContext(req.Context(), r.ClientIP(req))))
+	})
+}
+
+// NewContext returns a context carrying the client address
+func NewContext(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, contextKey{}, clientIP)
+}
+
+// FromContext returns the client address stored by Middleware, or "" if there is none
+func FromContext(ctx context.Context) string {
+	clientIP, _ := ctx.Value(contextKey{}).(string)
+	return clientIP
+}
+
+// forwardedFor returns the forwarding chain from the Forwarded header, or from X-Forwarded-For when there
+// is no Forwarded header, ordered from the original client to the nearest proxy
+func forwardedFor(req *http.Request) []string {
+	var hops []string
+	if values := req.Header.Values("Forwarded"); len(values) > 0 {
+		for _, value := range values {
+			for _, element := range strings.Split(value, ",") {
+				hops = append(hops, forwardedElementFor(element))
+			}
+		}
+		return hops
+	}
+
+	for _, value := range req.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(value, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	return hops
+}
+
+// forwardedElementFor returns the for= parameter of a Forwarded element, or "" if it has none
+func forwardedElementFor(element string) string {
+	for _, pair := range strings.Split(element, ";") {
+		name, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if found && strings.EqualFold(name, "for") {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// parseAddr parses an IP address with an optional port, IPv6 brackets and IPv4-mapped form
+func parseAddr(value string) (netip.Addr, bool) {
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	addr, err := netip.ParseAddr(strings.Trim(value, "[]"))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap().WithZone(""), true
+}
@@ -0,0 +1,131 @@
+package clientip_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/clientip"
+)
+
+func TestResolver_ClientIP(t *testing.T) {
+	resolver, err := clientip.NewResolver([]string{"10.0.0.0/8", "192.0.2.1", "2001:db8::/32"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string][]string
+		want       string
+	}{
+		{
+			name:       "direct connection",
+			remoteAddr: "203.0.113.7:51234",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "headers from untrusted peer are ignored",
+			remoteAddr: "203.0.113.7:51234",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "single trusted proxy",
+			remoteAddr: "10.1.2.3:443",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "spoofed entries left of the client are ignored",
+			remoteAddr: "10.1.2.3:443",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1, 198.51.100.1, 10.9.9.9"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "repeated header lines form one chain",
+			remoteAddr: "10.1.2.3:443",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1", "198.51.100.1"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "all hops trusted",
+			remoteAddr: "10.1.2.3:443",
+			headers:    map[string][]string{"X-Forwarded-For": {"10.0.0.5, 192.0.2.1"}},
+			want:       "10.0.0.5",
+		},
+		{
+			name:       "malformed hop stops at the nearest trusted proxy",
+			remoteAddr: "10.1.2.3:443",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1, garbage"}},
+			want:       "10.1.2.3",
+		},
+		{
+			name:       "forwarded header takes precedence",
+			remoteAddr: "10.1.2.3:443",
+			headers: map[string][]string{
+				"Forwarded":       {`for=1.1.1.1, for="[2001:db8:cafe::17]:4711";proto=https, For=192.0.2.1`},
+				"X-Forwarded-For": {"198.51.100.1"},
+			},
+			want: "1.1.1.1",
+		},
+		{
+			name:       "forwarded obfuscated identifier",
+			remoteAddr: "10.1.2.3:443",
+			headers:    map[string][]string{"Forwarded": {"for=_hidden;proto=https"}},
+			want:       "10.1.2.3",
+		},
+		{
+			name:       "ipv4-mapped peer",
+			remoteAddr: "[::ffff:10.1.2.3]:443",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1"}},
+			want:       "198.51.100.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for name, values := range tt.headers {
+				for _, value := range values {
+					req.Header.Add(name, value)
+				}
+			}
+			assert.Equal(t, tt.want, resolver.ClientIP(req))
+		})
+	}
+}
+
+func TestResolver_NoTrustedProxies(t *testing.T) {
+	var resolver *clientip.Resolver
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.1.2.3:443"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	assert.Equal(t, "10.1.2.3", resolver.ClientIP(req))
+}
+
+func TestResolver_Middleware(t *testing.T) {
+	resolver, err := clientip.NewResolver([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	var got string
+	handler := resolver.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = clientip.FromContext(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.1.2.3:443"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "198.51.100.1", got)
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	_, err := clientip.ParseTrustedProxies([]string{"10.0.0.0/8", " 192.0.2.1 ", ""})
+	require.NoError(t, err)
+
+	_, err = clientip.ParseTrustedProxies([]string{"10.0.0.0/33"})
+	assert.ErrorContains(t, err, `invalid trusted proxy "10.0.0.0/33"`)
+}
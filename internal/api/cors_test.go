@@ -30,7 +30,7 @@ func TestCORSHeaders(t *testing.T) {
 
 	// Create test services
 	db := database.NewTestDB(t)
-	registryService := service.NewRegistryService(db, cfg)
+	registryService := service.NewTestRegistryService(t, db, cfg)
 
 	shutdownTelemetry, metrics, err := telemetry.InitMetrics("test")
 	assert.NoError(t, err)
@@ -144,7 +144,7 @@ func TestCORSHeaderValues(t *testing.T) {
 
 	// Create test services
 	db := database.NewTestDB(t)
-	registryService := service.NewRegistryService(db, cfg)
+	registryService := service.NewTestRegistryService(t, db, cfg)
 
 	shutdownTelemetry, metrics, err := telemetry.InitMetrics("test")
 	assert.NoError(t, err)
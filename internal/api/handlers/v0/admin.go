@@ -0,0 +1,65 @@
+package v0
+
+import (
+	"context"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// AuthenticateAdmin validates a bearer Authorization header and checks that the token carries admin permissions.
+// Admin endpoints outside this package use it too, so every admin route rejects requests the same way. When
+// the route policy covers the route, the level it requires replaces the admin check.
+func AuthenticateAdmin(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	if decision := routeAuthFrom(ctx); decision != nil {
+		return decision.claims, nil
+	}
+
+	claims, err := authenticateBearer(ctx, jwtManager, authHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if !jwtManager.IsAdmin(claims.Permissions) {
+		return nil, withErrorCode(apiv0.ErrorCodeAdminRequired, huma.Error403Forbidden("This operation requires admin permissions"))
+	}
+
+	return claims, nil
+}
+
+// authenticateBearer validates a bearer Authorization header and returns the token's claims, or returns the
+// claims the route policy already authenticated. Handlers declare the header optional, since routes the
+// policy opens to anyone are called without it, so a missing header is reported here the way a missing
+// required header would be.
+func authenticateBearer(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	if decision := routeAuthFrom(ctx); decision != nil {
+		return decision.claims, nil
+	}
+	if authHeader == "" {
+		return nil, huma.Error422UnprocessableEntity("validation failed", &huma.ErrorDetail{
+			Message:  "required header parameter is missing",
+			Location: "header.Authorization",
+		})
+	}
+	return validateBearer(ctx, jwtManager, authHeader)
+}
+
+// validateBearer parses a bearer Authorization header and validates the Registry JWT it carries.
+func validateBearer(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	// Extract bearer token
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil, withErrorCode(apiv0.ErrorCodeInvalidAuthHeader, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'"))
+	}
+	token := authHeader[len(bearerPrefix):]
+
+	// Validate Registry JWT token
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, withErrorCode(apiv0.ErrorCodeInvalidToken, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err))
+	}
+	return claims, nil
+}
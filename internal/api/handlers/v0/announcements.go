@@ -0,0 +1,239 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// AnnouncementBody represents the request body for creating an announcement
+type AnnouncementBody struct {
+	Message  string     `json:"message" required:"true" minLength:"1" maxLength:"1000" doc:"Message to show users" example:"Publishing is paused on 2025-11-01 from 09:00 to 10:00 UTC for database maintenance"`
+	Severity string     `json:"severity,omitempty" enum:"info,warning,critical" doc:"How prominently clients should show the message (default: info)"`
+	StartsAt *time.Time `json:"startsAt,omitempty" required:"false" format:"date-time" doc:"When to start showing the announcement. Omit to show it right away."`
+	EndsAt   *time.Time `json:"endsAt,omitempty" required:"false" format:"date-time" doc:"When to stop showing the announcement. Omit to show it until it is deleted."`
+}
+
+// UpdateAnnouncementBody represents the request body for changing an announcement; omitted fields are kept
+type UpdateAnnouncementBody struct {
+	Message  *string    `json:"message,omitempty" minLength:"1" maxLength:"1000" doc:"Message to show users"`
+	Severity *string    `json:"severity,omitempty" enum:"info,warning,critical" doc:"How prominently clients should show the message"`
+	StartsAt *time.Time `json:"startsAt,omitempty" required:"false" format:"date-time" doc:"When to start showing the announcement"`
+	EndsAt   *time.Time `json:"endsAt,omitempty" required:"false" format:"date-time" doc:"When to stop showing the announcement. Set it to the current time to stop showing it now."`
+}
+
+// CreateAnnouncementInput represents the input for creating an announcement
+type CreateAnnouncementInput struct {
+	Authorization string           `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Body          AnnouncementBody `body:""`
+}
+
+// UpdateAnnouncementInput represents the input for changing an announcement
+type UpdateAnnouncementInput struct {
+	Authorization string                 `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ID            int64                  `path:"id" doc:"Announcement ID" example:"1"`
+	Body          UpdateAnnouncementBody `body:""`
+}
+
+// AnnouncementInput represents the input for deleting an announcement
+type AnnouncementInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ID            int64  `path:"id" doc:"Announcement ID" example:"1"`
+}
+
+// ListAnnouncementsInput represents the input for listing all announcements
+type ListAnnouncementsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+}
+
+// AnnouncementListResponse lists all announcements
+type AnnouncementListResponse struct {
+	Announcements []database.AnnouncementRecord `json:"announcements" doc:"Announcements, including those not shown yet or any more, oldest first"`
+}
+
+// ActiveAnnouncementListResponse lists the announcements shown now
+type ActiveAnnouncementListResponse struct {
+	Announcements []apiv0.Announcement `json:"announcements" doc:"Announcements shown now, most severe first"`
+}
+
+// RegisterAnnouncementEndpoints registers the endpoint listing the announcements shown now, and the admin
+// endpoints managing announcements
+func RegisterAnnouncementEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	security := []map[string][]string{{"bearer": {}}}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-active-announcements" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/announcements",
+		Summary:     "List announcements",
+		Description: "List the announcements registry operators are showing now, such as maintenance windows or policy changes, most severe first. Clients can show them in a banner.",
+		Tags:        []string{"announcements"},
+	}, func(ctx context.Context, _ *struct{}) (*CacheableResponse[ActiveAnnouncementListResponse], error) {
+		announcements, err := registry.ListActiveAnnouncements(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list announcements", err)
+		}
+		return newCacheableResponse(ActiveAnnouncementListResponse{Announcements: announcements}, []string{cdn.AnnouncementsKey}), nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-announcements" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/announcements",
+		Summary:     "List all announcements",
+		Description: "List every announcement, including scheduled ones and those that have ended. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *ListAnnouncementsInput) (*Response[AnnouncementListResponse], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		announcements, err := registry.ListAnnouncements(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list announcements", err)
+		}
+
+		body := AnnouncementListResponse{Announcements: make([]database.AnnouncementRecord, 0, len(announcements))}
+		for _, announcement := range announcements {
+			body.Announcements = append(body.Announcements, *announcement)
+		}
+		return &Response[AnnouncementListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-announcement" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodPost,
+		Path:          pathPrefix + "/admin/announcements",
+		Summary:       "Create an announcement",
+		Description:   "Show a message to every client between an optional start and end time. Requires admin permissions.",
+		Tags:          []string{"admin"},
+		Security:      security,
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *CreateAnnouncementInput) (*Response[database.AnnouncementRecord], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		announcement, err := registry.CreateAnnouncement(ctx, database.AnnouncementRecord{
+			Announcement: apiv0.Announcement{
+				Message:  input.Body.Message,
+				Severity: input.Body.Severity,
+				StartsAt: input.Body.StartsAt,
+				EndsAt:   input.Body.EndsAt,
+			},
+			CreatedBy: string(claims.AuthMethod) + ":" + claims.AuthMethodSubject,
+		})
+		if err != nil {
+			return nil, announcementError("Failed to create announcement", err)
+		}
+
+		recordAnnouncementAudit(ctx, registry, claims, database.AuditActionAnnouncementCreate, announcement)
+		return &Response[database.AnnouncementRecord]{Body: *announcement}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-announcement" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPatch,
+		Path:        pathPrefix + "/admin/announcements/{id}",
+		Summary:     "Change an announcement",
+		Description: "Change an announcement's message, severity or schedule. Omitted fields are kept. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *UpdateAnnouncementInput) (*Response[database.AnnouncementRecord], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		announcement, err := registry.GetAnnouncement(ctx, input.ID)
+		if err != nil {
+			return nil, announcementError("Failed to get announcement", err)
+		}
+		if input.Body.Message != nil {
+			announcement.Message = *input.Body.Message
+		}
+		if input.Body.Severity != nil {
+			announcement.Severity = *input.Body.Severity
+		}
+		if input.Body.StartsAt != nil {
+			announcement.StartsAt = input.Body.StartsAt
+		}
+		if input.Body.EndsAt != nil {
+			announcement.EndsAt = input.Body.EndsAt
+		}
+		if err := registry.UpdateAnnouncement(ctx, announcement); err != nil {
+			return nil, announcementError("Failed to update announcement", err)
+		}
+
+		recordAnnouncementAudit(ctx, registry, claims, database.AuditActionAnnouncementUpdate, announcement)
+		return &Response[database.AnnouncementRecord]{Body: *announcement}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-announcement" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/admin/announcements/{id}",
+		Summary:       "Delete an announcement",
+		Description:   "Stop showing an announcement and remove it. Requires admin permissions.",
+		Tags:          []string{"admin"},
+		Security:      security,
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *AnnouncementInput) (*struct{}, error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		announcement, err := registry.GetAnnouncement(ctx, input.ID)
+		if err != nil {
+			return nil, announcementError("Failed to get announcement", err)
+		}
+		if err := registry.DeleteAnnouncement(ctx, input.ID); err != nil {
+			return nil, announcementError("Failed to delete announcement", err)
+		}
+
+		recordAnnouncementAudit(ctx, registry, claims, database.AuditActionAnnouncementDelete, announcement)
+		return nil, nil
+	})
+}
+
+// announcementError maps announcement errors to API errors
+func announcementError(message string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidInput):
+		return withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest(err.Error()))
+	case errors.Is(err, service.ErrNotFound):
+		return withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Announcement not found"))
+	default:
+		return huma.Error500InternalServerError(message, err)
+	}
+}
+
+// recordAnnouncementAudit records a change to an announcement in the audit log
+func recordAnnouncementAudit(ctx context.Context, registry service.RegistryService, claims *auth.JWTClaims, action string, announcement *database.AnnouncementRecord) {
+	RecordAudit(ctx, registry, claims, database.AuditEntry{
+		Action:   action,
+		Resource: "announcement:" + strconv.FormatInt(announcement.ID, 10),
+		Details: map[string]any{
+			"message":  announcement.Message,
+			"severity": announcement.Severity,
+			"startsAt": announcement.StartsAt,
+			"endsAt":   announcement.EndsAt,
+		},
+	})
+}
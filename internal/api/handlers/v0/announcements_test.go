@@ -0,0 +1,150 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestAnnouncementEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterAnnouncementEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterServersEndpoints(api, "/v0", registry, nil)
+
+	token := func(permissions []auth.Permission) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "admin",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	adminToken := token([]auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}})
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	active := func() []apiv0.Announcement {
+		w := do(http.MethodGet, "/v0/announcements", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "announcements", w.Header().Get("Surrogate-Key"))
+		var list v0.ActiveAnnouncementListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		return list.Announcements
+	}
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/admin/announcements", token([]auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.admin/*"}}),
+			map[string]any{"message": "Maintenance tonight"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects schedules ending before they start", func(t *testing.T) {
+		now := time.Now()
+		w := do(http.MethodPost, "/v0/admin/announcements", adminToken, map[string]any{
+			"message":  "Maintenance tonight",
+			"startsAt": now.Add(time.Hour),
+			"endsAt":   now,
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_PARAMETER")
+	})
+
+	var maintenance, policy, scheduled database.AnnouncementRecord
+	t.Run("creates announcements", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/admin/announcements", adminToken, map[string]any{"message": "Maintenance tonight"})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &maintenance))
+		assert.Equal(t, apiv0.AnnouncementSeverityInfo, maintenance.Severity)
+		assert.Equal(t, "github-at:admin", maintenance.CreatedBy)
+
+		w = do(http.MethodPost, "/v0/admin/announcements", adminToken, map[string]any{"message": "New naming policy", "severity": "critical"})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &policy))
+
+		w = do(http.MethodPost, "/v0/admin/announcements", adminToken, map[string]any{"message": "Next week", "startsAt": time.Now().Add(24 * time.Hour)})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &scheduled))
+	})
+
+	t.Run("lists active announcements most severe first", func(t *testing.T) {
+		announcements := active()
+		require.Len(t, announcements, 2)
+		assert.Equal(t, policy.ID, announcements[0].ID)
+		assert.Equal(t, maintenance.ID, announcements[1].ID)
+
+		w := do(http.MethodGet, "/v0/admin/announcements", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.AnnouncementListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Len(t, list.Announcements, 3, "admins see scheduled announcements too")
+	})
+
+	t.Run("embeds announcements in server lists on request", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/servers", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.NotContains(t, w.Body.String(), "announcements")
+
+		w = do(http.MethodGet, "/v0/servers?include_announcements=true", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "servers announcements", w.Header().Get("Surrogate-Key"))
+		var list apiv0.ServerListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Len(t, list.Announcements, 2)
+	})
+
+	t.Run("ends and deletes announcements", func(t *testing.T) {
+		w := do(http.MethodPatch, "/v0/admin/announcements/"+strconv.FormatInt(maintenance.ID, 10), adminToken, map[string]any{"endsAt": time.Now()})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var updated database.AnnouncementRecord
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+		assert.Equal(t, "Maintenance tonight", updated.Message, "omitted fields are kept")
+		require.NotNil(t, updated.EndsAt)
+
+		w = do(http.MethodDelete, "/v0/admin/announcements/"+strconv.FormatInt(policy.ID, 10), adminToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w = do(http.MethodDelete, "/v0/admin/announcements/"+strconv.FormatInt(policy.ID, 10), adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		assert.Empty(t, active())
+	})
+}
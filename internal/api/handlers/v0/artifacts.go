@@ -0,0 +1,184 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/artifacts"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// PackageArtifactsInput represents the input for listing a server version's mirrored artifacts
+type PackageArtifactsInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" doc:"URL-encoded server version, or 'latest'" example:"1.0.0"`
+}
+
+// MirrorPackageArtifactsInput represents the input for mirroring a server version's artifacts again
+type MirrorPackageArtifactsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+}
+
+// DownloadArtifactInput represents the input for downloading a mirrored artifact
+type DownloadArtifactInput struct {
+	SHA256    string `path:"sha256" doc:"Hex-encoded SHA-256 of the artifact" example:"fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce"`
+	Filename  string `path:"filename" doc:"File name of the artifact" example:"server-brave-search-1.0.2.tgz"`
+	Expires   string `query:"expires" doc:"Expiry of the signed URL, in Unix seconds"`
+	Signature string `query:"signature" doc:"Signature of the URL"`
+}
+
+// RegisterArtifactEndpoints registers the artifact proxy endpoints with a custom path prefix
+func RegisterArtifactEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	signer := auth.NewURLSigner(cfg)
+
+	// downloadPath is the path of an artifact's download URL, which its signature covers
+	downloadPath := func(artifact apiv0.PackageArtifact) string {
+		return pathPrefix + "/artifacts/" + artifact.SHA256 + "/" + url.PathEscape(artifact.Filename)
+	}
+	withDownloadURLs := func(mirrored []apiv0.PackageArtifact) []apiv0.PackageArtifact {
+		expiresAt := time.Now().Add(cfg.ArtifactURLTTL).Truncate(time.Second)
+		for i := range mirrored {
+			mirrored[i].DownloadURL = signer.Sign(downloadPath(mirrored[i]), expiresAt)
+		}
+		return mirrored
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-artifacts" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/artifacts",
+		Summary:     "List mirrored artifacts of an MCP server version",
+		Description: "List the package artifacts the registry mirrored when the server version was published, each with a signed, time-limited download URL, so clients without access to npm, PyPI or OCI registries can install the server through the registry.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *PackageArtifactsInput) (*Response[apiv0.PackageArtifactsResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid version encoding", err))
+		}
+
+		serverResponse, err := getServerVersion(ctx, registry, serverName, version, false)
+		if err != nil {
+			return nil, err
+		}
+
+		mirrored, err := registry.GetPackageArtifacts(ctx, serverResponse.Server.Name, serverResponse.Server.Version)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get package artifacts", err)
+		}
+
+		// Signed URLs expire, so the response must not be cached by CDNs
+		return &Response[apiv0.PackageArtifactsResponse]{
+			Body: apiv0.PackageArtifactsResponse{Artifacts: withDownloadURLs(mirrored)},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "download-artifact" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/artifacts/{sha256}/{filename}",
+		Summary:     "Download a mirrored artifact",
+		Description: "Download a package artifact mirrored by the registry. The URL must be signed, as returned in the downloadUrl of a listed artifact.",
+		Tags:        []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Artifact content",
+				Content:     map[string]*huma.MediaType{"application/octet-stream": {}},
+			},
+		},
+	}, func(ctx context.Context, input *DownloadArtifactInput) (*huma.StreamResponse, error) {
+		artifact := apiv0.PackageArtifact{SHA256: input.SHA256, Filename: input.Filename}
+		query := url.Values{auth.SignedURLExpiresParam: {input.Expires}, auth.SignedURLSignatureParam: {input.Signature}}
+		err := signer.Verify(downloadPath(artifact), query, time.Now())
+		switch {
+		case errors.Is(err, auth.ErrSignedURLExpired):
+			return nil, withErrorCode(apiv0.ErrorCodeSignedURLExpired, huma.Error403Forbidden("Signed URL has expired"))
+		case err != nil:
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidURLSignature, huma.Error403Forbidden("Invalid URL signature"))
+		}
+
+		content, err := registry.OpenPackageArtifact(ctx, input.SHA256)
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) || errors.Is(err, artifacts.ErrNotFound) {
+				return nil, huma.Error404NotFound("Artifact not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to open artifact", err)
+		}
+
+		return &huma.StreamResponse{Body: func(hctx huma.Context) {
+			defer content.Close()
+			hctx.SetHeader("Content-Type", "application/octet-stream")
+			hctx.SetHeader("Content-Disposition", `attachment; filename="`+input.Filename+`"`)
+			hctx.SetHeader("ETag", strconv.Quote(input.SHA256))
+			// Artifacts never change, but the URL's signature expires
+			hctx.SetHeader("Cache-Control", "private, max-age="+strconv.Itoa(int(cfg.ArtifactURLTTL.Seconds())))
+			if _, err := io.Copy(hctx.BodyWriter(), content); err != nil {
+				log.Printf("Artifact download of %s aborted: %v", input.SHA256, err)
+			}
+		}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "mirror-server-artifacts" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/servers/{serverName}/versions/{version}/artifacts",
+		Summary:     "Mirror artifacts of an MCP server version",
+		Description: "Mirror the package artifacts of a server version again, for example after mirroring at publish time failed. Artifacts already mirrored are kept. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *MirrorPackageArtifactsInput) (*Response[apiv0.PackageArtifactsResponse], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid version encoding", err))
+		}
+
+		mirrored, err := registry.MirrorPackageArtifacts(ctx, serverName, version)
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("Server not found"))
+			}
+			if mirrored == nil {
+				return nil, huma.Error500InternalServerError("Failed to mirror package artifacts", err)
+			}
+			return nil, huma.Error502BadGateway("Failed to mirror some package artifacts", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionArtifacts,
+			Namespace: serverNamespace(serverName),
+			Resource:  serverName + "@" + version,
+		})
+		return &Response[apiv0.PackageArtifactsResponse]{
+			Body: apiv0.PackageArtifactsResponse{Artifacts: withDownloadURLs(mirrored)},
+		}, nil
+	})
+}
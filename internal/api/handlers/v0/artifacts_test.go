@@ -0,0 +1,169 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestArtifactEndpoints(t *testing.T) {
+	bundle := []byte("mcpb bundle content")
+	sum := sha256.Sum256(bundle)
+	bundleSHA256 := hex.EncodeToString(sum[:])
+	upstreamAvailable := true
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !upstreamAvailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(bundle)
+	}))
+	t.Cleanup(upstream.Close)
+
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:      hex.EncodeToString(testSeed),
+		ReadURLSigningKey:  "test-signing-key",
+		ArtifactStorageDir: t.TempDir(),
+		ArtifactMaxBytes:   1 << 20,
+		ArtifactURLTTL:     time.Hour,
+	}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	ctx := context.Background()
+
+	publish := func(version string) {
+		_, err := registry.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.octocat/weather-server",
+			Description: "Test server",
+			Version:     version,
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeMCPB,
+				Identifier:   upstream.URL + "/releases/weather.mcpb",
+				FileSHA256:   bundleSHA256,
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			}},
+		})
+		require.NoError(t, err)
+	}
+	publish("1.0.0")
+	mirror, err := registry.RunNextArtifactMirror(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, mirror)
+	assert.Equal(t, "1.0.0", mirror.Version)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterArtifactEndpoints(api, "/v0", registry, cfg)
+
+	token := func(action auth.PermissionAction, pattern string) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(ctx, auth.JWTClaims{
+			AuthMethod:  auth.MethodNone,
+			Permissions: []auth.Permission{{Action: action, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	do := func(method, path, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	list := func(version string) []apiv0.PackageArtifact {
+		w := do(http.MethodGet, "/v0/servers/io.github.octocat%2Fweather-server/versions/"+version+"/artifacts", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response apiv0.PackageArtifactsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		return response.Artifacts
+	}
+
+	mirrored := list("1.0.0")
+	require.Len(t, mirrored, 1)
+	assert.Equal(t, "mcpb", mirrored[0].RegistryType)
+	assert.Equal(t, "weather.mcpb", mirrored[0].Filename)
+	assert.Equal(t, bundleSHA256, mirrored[0].SHA256)
+	assert.Equal(t, int64(len(bundle)), mirrored[0].Size)
+	require.True(t, strings.HasPrefix(mirrored[0].DownloadURL, "/v0/artifacts/"+bundleSHA256+"/weather.mcpb?"))
+
+	t.Run("download", func(t *testing.T) {
+		w := do(http.MethodGet, mirrored[0].DownloadURL, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, bundle, w.Body.Bytes())
+		assert.Equal(t, `attachment; filename="weather.mcpb"`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, `"`+bundleSHA256+`"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("download requires a valid signature", func(t *testing.T) {
+		w := do(http.MethodGet, strings.Replace(mirrored[0].DownloadURL, "weather.mcpb", "other.mcpb", 1), "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), apiv0.ErrorCodeInvalidURLSignature)
+
+		w = do(http.MethodGet, "/v0/artifacts/"+bundleSHA256+"/weather.mcpb", "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		expired := auth.NewURLSigner(cfg).Sign("/v0/artifacts/"+bundleSHA256+"/weather.mcpb", time.Now().Add(-time.Minute))
+		w = do(http.MethodGet, expired, "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), apiv0.ErrorCodeSignedURLExpired)
+	})
+
+	t.Run("download of an unknown artifact", func(t *testing.T) {
+		unknown := strings.Repeat("0", 64)
+		w := do(http.MethodGet, auth.NewURLSigner(cfg).Sign("/v0/artifacts/"+unknown+"/weather.mcpb", time.Now().Add(time.Hour)), "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("admin mirrors again after a failed background mirror", func(t *testing.T) {
+		upstreamAvailable = false
+		publish("1.1.0")
+		mirror, err := registry.RunNextArtifactMirror(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, mirror)
+		assert.Equal(t, 1, mirror.Attempts)
+		assert.NotEmpty(t, mirror.Error)
+		assert.Empty(t, list("1.1.0"))
+
+		path := "/v0/admin/servers/io.github.octocat%2Fweather-server/versions/1.1.0/artifacts"
+		w := do(http.MethodPost, path, token(auth.PermissionActionPublish, "io.github.octocat/*"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		adminToken := token(auth.PermissionActionEdit, "*")
+		w = do(http.MethodPost, path, adminToken)
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+
+		upstreamAvailable = true
+		w = do(http.MethodPost, path, adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Len(t, list("1.1.0"), 1)
+
+		w = do(http.MethodPost, "/v0/admin/servers/io.github.octocat%2Fmissing/versions/1.0.0/artifacts", adminToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,271 @@
+package v0
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/api/clientip"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// auditExportPageSize is the number of entries read per query while exporting the audit log
+const auditExportPageSize = 1000
+
+// auditCSVHeader is the first row of CSV exports
+var auditCSVHeader = []string{"id", "createdAt", "actor", "action", "namespace", "resource", "clientIp", "details"}
+
+// AuditFilterInput holds the filters shared by the audit log query and export endpoints
+type AuditFilterInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Actor         string `query:"actor" doc:"Only return entries performed by this actor" required:"false" example:"github-at:octocat"`
+	Namespace     string `query:"namespace" doc:"Only return entries affecting this namespace" required:"false" example:"io.github.octocat"`
+	Action        string `query:"action" doc:"Only return entries for this action" required:"false" example:"server.publish"`
+	Since         string `query:"since" doc:"Only return entries recorded at or after this time (RFC3339 datetime)" required:"false" example:"2025-07-01T00:00:00Z"`
+	Until         string `query:"until" doc:"Only return entries recorded before this time (RFC3339 datetime)" required:"false" example:"2025-10-01T00:00:00Z"`
+}
+
+// ListAuditEntriesInput represents the input for querying the audit log
+type ListAuditEntriesInput struct {
+	AuditFilterInput
+	Cursor string `query:"cursor" doc:"Pagination cursor" required:"false"`
+	Limit  int    `query:"limit" doc:"Number of entries per page" default:"100" minimum:"1" maximum:"1000"`
+}
+
+// ExportAuditEntriesInput represents the input for exporting the audit log
+type ExportAuditEntriesInput struct {
+	AuditFilterInput
+	Format string `query:"format" enum:"csv,ndjson" default:"csv" doc:"Export format: CSV with details as a JSON column, or one JSON entry per line"`
+}
+
+// AuditEntryListResponse is a page of audit log entries
+type AuditEntryListResponse struct {
+	Entries  []database.AuditEntry `json:"entries" doc:"Audit entries, oldest first"`
+	Metadata AuditListMetadata     `json:"metadata"`
+}
+
+// AuditListMetadata holds pagination information for audit log queries
+type AuditListMetadata struct {
+	NextCursor string `json:"nextCursor,omitempty" doc:"Pagination cursor for retrieving the next page of results"`
+	Count      int    `json:"count" doc:"Number of entries in the current page"`
+}
+
+// filter converts the query parameters into a database filter
+func (input *AuditFilterInput) filter() (*database.AuditFilter, error) {
+	filter := &database.AuditFilter{}
+	if input.Actor != "" {
+		filter.Actor = &input.Actor
+	}
+	if input.Namespace != "" {
+		filter.Namespace = &input.Namespace
+	}
+	if input.Action != "" {
+		filter.Action = &input.Action
+	}
+	for _, bound := range []struct {
+		name   string
+		value  string
+		target **time.Time
+	}{{"since", input.Since, &filter.Since}, {"until", input.Until, &filter.Until}} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid "+bound.name+" timestamp, expected RFC3339", err))
+		}
+		*bound.target = &t
+	}
+	return filter, nil
+}
+
+// RegisterAuditEndpoints registers the admin endpoints for querying and exporting the audit log
+func RegisterAuditEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-audit-entries" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/audit",
+		Summary:     "Query the audit log",
+		Description: "List audit log entries for writes performed through the API, oldest first, filtered by actor, namespace, action and time range. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListAuditEntriesInput) (*Response[AuditEntryListResponse], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+		filter, err := input.filter()
+		if err != nil {
+			return nil, err
+		}
+		var afterID int64
+		if input.Cursor != "" {
+			afterID, err = strconv.ParseInt(input.Cursor, 10, 64)
+			if err != nil || afterID < 0 {
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid cursor parameter"))
+			}
+		}
+
+		entries, err := registry.ListAuditEntries(ctx, filter, afterID, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to query audit log", err)
+		}
+
+		body := AuditEntryListResponse{
+			Entries:  make([]database.AuditEntry, 0, len(entries)),
+			Metadata: AuditListMetadata{Count: len(entries)},
+		}
+		for _, entry := range entries {
+			body.Entries = append(body.Entries, *entry)
+		}
+		if len(entries) == input.Limit {
+			body.Metadata.NextCursor = strconv.FormatInt(entries[len(entries)-1].ID, 10)
+		}
+		return &Response[AuditEntryListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "export-audit-entries" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/audit/export",
+		Summary:     "Export the audit log",
+		Description: "Download every audit log entry matching the filters, oldest first, as CSV or newline-delimited JSON. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Audit log entries",
+				Content: map[string]*huma.MediaType{
+					"text/csv":             {},
+					"application/x-ndjson": {},
+				},
+			},
+		},
+	}, func(ctx context.Context, input *ExportAuditEntriesInput) (*huma.StreamResponse, error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+		filter, err := input.filter()
+		if err != nil {
+			return nil, err
+		}
+
+		// Read the first page up front so query errors are reported with a status code
+		page, err := registry.ListAuditEntries(ctx, filter, 0, auditExportPageSize)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to query audit log", err)
+		}
+
+		return &huma.StreamResponse{Body: func(hctx huma.Context) {
+			contentType, extension := "text/csv", "csv"
+			if input.Format == "ndjson" {
+				contentType, extension = "application/x-ndjson", "ndjson"
+			}
+			hctx.SetHeader("Content-Type", contentType)
+			hctx.SetHeader("Content-Disposition", `attachment; filename="audit-log.`+extension+`"`)
+
+			writer := newAuditWriter(hctx, input.Format)
+			for {
+				for _, entry := range page {
+					if err := writer.write(entry); err != nil {
+						log.Printf("Audit log export aborted: %v", err)
+						return
+					}
+				}
+				if len(page) < auditExportPageSize {
+					break
+				}
+				page, err = registry.ListAuditEntries(hctx.Context(), filter, page[len(page)-1].ID, auditExportPageSize)
+				if err != nil {
+					// Headers are already sent, so the truncated export can only be logged
+					log.Printf("Audit log export truncated: %v", err)
+					break
+				}
+			}
+			if err := writer.flush(); err != nil {
+				log.Printf("Audit log export aborted: %v", err)
+			}
+		}}, nil
+	})
+}
+
+// auditWriter encodes audit entries as CSV rows or JSON lines
+type auditWriter struct {
+	csv  *csv.Writer
+	json *json.Encoder
+}
+
+func newAuditWriter(hctx huma.Context, format string) *auditWriter {
+	if format == "ndjson" {
+		return &auditWriter{json: json.NewEncoder(hctx.BodyWriter())}
+	}
+	writer := &auditWriter{csv: csv.NewWriter(hctx.BodyWriter())}
+	_ = writer.csv.Write(auditCSVHeader)
+	return writer
+}
+
+func (w *auditWriter) write(entry *database.AuditEntry) error {
+	if w.json != nil {
+		return w.json.Encode(entry)
+	}
+	details := ""
+	if len(entry.Details) > 0 {
+		encoded, err := json.Marshal(entry.Details)
+		if err != nil {
+			return err
+		}
+		details = string(encoded)
+	}
+	return w.csv.Write([]string{
+		strconv.FormatInt(entry.ID, 10),
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		entry.Actor,
+		entry.Action,
+		entry.Namespace,
+		entry.Resource,
+		entry.ClientIP,
+		details,
+	})
+}
+
+func (w *auditWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// RecordAudit records a successful write in the audit log. Failures are logged rather than
+// returned, since the write they describe has already been committed.
+func RecordAudit(ctx context.Context, registry service.RegistryService, claims *auth.JWTClaims, entry database.AuditEntry) {
+	if claims != nil {
+		entry.Actor = string(claims.AuthMethod) + ":" + claims.AuthMethodSubject
+	}
+	entry.ClientIP = clientip.FromContext(ctx)
+	if err := registry.RecordAuditEntry(ctx, entry); err != nil {
+		log.Printf("Failed to record %s audit entry for %s: %v", entry.Action, entry.Resource, err)
+	}
+}
+
+// serverNamespace returns the namespace part of a server name, e.g. io.github.octocat for io.github.octocat/weather
+func serverNamespace(serverName string) string {
+	namespace, _, _ := strings.Cut(serverName, "/")
+	return namespace
+}
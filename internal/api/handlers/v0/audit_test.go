@@ -0,0 +1,133 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+func TestAuditEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterMaintenanceEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterAuditEndpoints(api, "/v0", registry, cfg)
+
+	token := func(subject string, permissions []auth.Permission) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: subject,
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	adminToken := token("admin", []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}})
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	// Writes through the API are recorded with the token's identity
+	for _, enabled := range []bool{true, false} {
+		w := do(http.MethodPut, "/v0/admin/maintenance", adminToken, map[string]any{"enabled": enabled})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+	require.NoError(t, registry.RecordAuditEntry(context.Background(), database.AuditEntry{
+		Actor: "github-at:alice", Action: database.AuditActionPublish, Namespace: "io.github.alice", Resource: "io.github.alice/weather@1.0.0",
+	}))
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/admin/audit", token("alice", []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}}), nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("filters and paginates", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/admin/audit?actor=github-at:admin&limit=1", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var page v0.AuditEntryListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Entries, 1)
+		assert.Equal(t, database.AuditActionMaintenance, page.Entries[0].Action)
+		assert.Equal(t, map[string]any{"enabled": true}, page.Entries[0].Details)
+		require.NotEmpty(t, page.Metadata.NextCursor)
+
+		w = do(http.MethodGet, "/v0/admin/audit?actor=github-at:admin&limit=1&cursor="+page.Metadata.NextCursor, adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Entries, 1)
+		assert.Equal(t, map[string]any{"enabled": false}, page.Entries[0].Details)
+
+		w = do(http.MethodGet, "/v0/admin/audit?namespace=io.github.alice", adminToken, nil)
+		page = v0.AuditEntryListResponse{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Entries, 1)
+		assert.Equal(t, "io.github.alice/weather@1.0.0", page.Entries[0].Resource)
+		assert.Empty(t, page.Metadata.NextCursor)
+	})
+
+	t.Run("rejects malformed timestamps", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/admin/audit?since=yesterday", adminToken, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("exports csv", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/admin/audit/export", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "audit-log.csv")
+
+		rows, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		assert.Equal(t, []string{"id", "createdAt", "actor", "action", "namespace", "resource", "clientIp", "details"}, rows[0])
+		assert.Equal(t, "github-at:admin", rows[1][2])
+		assert.JSONEq(t, `{"enabled":true}`, rows[1][7])
+		assert.Equal(t, "io.github.alice", rows[3][4])
+	})
+
+	t.Run("exports ndjson", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/admin/audit/export?format=ndjson&action=server.publish", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, 1)
+		var entry database.AuditEntry
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+		assert.Equal(t, "github-at:alice", entry.Actor)
+	})
+}
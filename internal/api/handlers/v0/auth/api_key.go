@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// APIKeyTokenExchangeInput represents the input for organization API key exchange
+type APIKeyTokenExchangeInput struct {
+	Body struct {
+		APIKey string `json:"api_key" doc:"Organization API key" required:"true"`
+	}
+}
+
+// OrgAPIKeyStore looks up organization API keys. service.RegistryService satisfies this interface.
+type OrgAPIKeyStore interface {
+	AuthenticateOrgAPIKey(ctx context.Context, secret string) (*database.OrgAPIKey, error)
+}
+
+// APIKeyHandler exchanges organization API keys for Registry JWTs
+type APIKeyHandler struct {
+	config     *config.Config
+	jwtManager *auth.JWTManager
+	keys       OrgAPIKeyStore
+}
+
+// NewAPIKeyHandler creates a new organization API key handler
+func NewAPIKeyHandler(cfg *config.Config, keys OrgAPIKeyStore) *APIKeyHandler {
+	return &APIKeyHandler{
+		config:     cfg,
+		jwtManager: auth.NewJWTManager(cfg),
+		keys:       keys,
+	}
+}
+
+// Name returns the provider name used in MCP_REGISTRY_AUTH_PROVIDERS
+func (h *APIKeyHandler) Name() string {
+	return "api-key"
+}
+
+// RegisterEndpoints registers the organization API key exchange endpoint
+func (h *APIKeyHandler) RegisterEndpoints(api huma.API, pathPrefix string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-api-key" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/api-key",
+		Summary:     "Exchange organization API key for Registry JWT",
+		Description: "Exchange an organization API key for a short-lived Registry JWT token that can publish and edit servers in the namespaces the key is scoped to",
+		Tags:        []string{"auth"},
+	}, func(ctx context.Context, input *APIKeyTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
+		response, err := h.ExchangeToken(ctx, input.Body.APIKey)
+		if errors.Is(err, auth.ErrIdentityBanned) {
+			return nil, huma.Error403Forbidden("Identity is banned from publishing", err)
+		}
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Token exchange failed", err)
+		}
+
+		return &v0.Response[auth.TokenResponse]{
+			Body: *response,
+		}, nil
+	})
+}
+
+// ExchangeToken exchanges an organization API key for a Registry JWT token
+func (h *APIKeyHandler) ExchangeToken(ctx context.Context, apiKey string) (*auth.TokenResponse, error) {
+	key, err := h.keys.AuthenticateOrgAPIKey(ctx, apiKey)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, errors.New("invalid API key")
+		}
+		return nil, fmt.Errorf("failed to look up API key: %w", err)
+	}
+
+	permissions := make([]auth.Permission, 0, 2*len(key.Namespaces))
+	for _, namespace := range key.Namespaces {
+		permissions = append(permissions,
+			auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: namespace + "/*"},
+			auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: namespace + "/*"},
+		)
+	}
+
+	// The subject names the organization and key rather than a person, so the audit log shows which
+	// automation acted
+	claims := auth.JWTClaims{
+		AuthMethod:        auth.MethodAPIKey,
+		AuthMethodSubject: key.Organization + "/" + key.Name,
+		Permissions:       permissions,
+	}
+
+	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+	}
+
+	return tokenResponse, nil
+}
@@ -0,0 +1,51 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyHandler_ExchangeToken(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		JWTPrivateKey:     hex.EncodeToString(testSeed),
+		OrgAPIKeysEnabled: true,
+	}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	ctx := context.Background()
+
+	_, apiKey, err := registry.CreateOrgAPIKey(ctx, "com.example", "release-pipeline", []string{"com.example", "com.example.tools"}, "github-at:alice")
+	require.NoError(t, err)
+
+	handler := v0auth.NewAPIKeyHandler(cfg, registry)
+	tokenResponse, err := handler.ExchangeToken(ctx, apiKey)
+	require.NoError(t, err)
+
+	claims, err := auth.NewJWTManager(cfg).ValidateToken(ctx, tokenResponse.RegistryToken)
+	require.NoError(t, err)
+	assert.Equal(t, auth.MethodAPIKey, claims.AuthMethod)
+	assert.Equal(t, "com.example/release-pipeline", claims.AuthMethodSubject, "the token names the key, not the member who created it")
+	assert.ElementsMatch(t, []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"},
+		{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.tools/*"},
+		{Action: auth.PermissionActionEdit, ResourcePattern: "com.example.tools/*"},
+	}, claims.Permissions)
+
+	_, err = handler.ExchangeToken(ctx, "mcpr_not-a-key")
+	require.Error(t, err)
+}
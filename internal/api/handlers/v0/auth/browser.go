@@ -0,0 +1,365 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"golang.org/x/oauth2"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Browser login providers, named in /auth/browser/login/{provider}
+const (
+	BrowserProviderGitHub = "github"
+	BrowserProviderOIDC   = "oidc"
+)
+
+// loginCookieName holds the state of a login in progress between the redirect to the provider and the callback
+const loginCookieName = "__Host-mcp-registry-login"
+
+// loginTimeout is how long a person has to complete a login at the provider
+const loginTimeout = 10 * time.Minute
+
+// pendingLogin is the state of a login in progress, kept in an HttpOnly cookie so replicas need not share it
+type pendingLogin struct {
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce,omitempty"`
+	ReturnTo string `json:"returnTo"`
+	// Client is the registered OAuth client the login was started for, which receives an authorization
+	// code instead of a browser session
+	Client *pendingAuthorization `json:"client,omitempty"`
+}
+
+// BrowserSession describes the browser session of the request, for the web UI
+type BrowserSession struct {
+	Authenticated bool              `json:"authenticated"`
+	Providers     []string          `json:"providers"`
+	AuthMethod    auth.Method       `json:"authMethod,omitempty"`
+	Subject       string            `json:"subject,omitempty"`
+	Permissions   []auth.Permission `json:"permissions,omitempty"`
+	IsAdmin       bool              `json:"isAdmin,omitempty"`
+	ExpiresAt     *time.Time        `json:"expiresAt,omitempty"`
+	CSRFToken     string            `json:"csrfToken,omitempty"`
+}
+
+// browserProvider is an identity provider people can log in with from a browser
+type browserProvider struct {
+	oauth *oauth2.Config
+	// nonce is whether the provider issues ID tokens bound to a nonce
+	nonce bool
+	// claims returns the Registry JWT claims for the person a token response belongs to
+	claims func(ctx context.Context, token *oauth2.Token, nonce string) (*auth.JWTClaims, error)
+}
+
+// BrowserLoginHandler lets people log in to the web UI and admin endpoints from a browser with the OAuth
+// authorization code flow and PKCE, against GitHub or the configured OIDC issuer. The registry token it
+// issues lasts for the session and is kept in an HttpOnly cookie; requests that change state must also
+// send the session's CSRF token. Tools keep exchanging tokens with the /auth endpoints instead.
+type BrowserLoginHandler struct {
+	config     *config.Config
+	jwtManager *auth.JWTManager
+	providers  map[string]*browserProvider
+	// clients stores registered OAuth clients; without it, applications cannot register
+	clients OAuthClientStore
+}
+
+// NewBrowserLoginHandler creates a browser login handler for the providers that are configured: GitHub
+// when it has an OAuth app, and the OIDC issuer when OIDC is enabled. Either handler may be nil.
+func NewBrowserLoginHandler(cfg *config.Config, github *GitHubHandler, oidcHandler *OIDCHandler) *BrowserLoginHandler {
+	h := &BrowserLoginHandler{
+		config:     cfg,
+		jwtManager: auth.NewJWTManager(cfg),
+		providers:  map[string]*browserProvider{},
+	}
+	callbackURL := strings.TrimSuffix(cfg.PublicURL, "/") + "/auth/browser/callback/"
+
+	if github != nil && cfg.GithubClientID != "" && cfg.GithubClientSecret != "" {
+		h.providers[BrowserProviderGitHub] = &browserProvider{
+			oauth: &oauth2.Config{
+				ClientID:     cfg.GithubClientID,
+				ClientSecret: cfg.GithubClientSecret,
+				Endpoint: oauth2.Endpoint{
+					AuthURL:  github.oauthURL + "/login/oauth/authorize",
+					TokenURL: github.oauthURL + "/login/oauth/access_token",
+				},
+				RedirectURL: callbackURL + BrowserProviderGitHub,
+				Scopes:      []string{"read:org", "read:user"},
+			},
+			claims: func(ctx context.Context, token *oauth2.Token, _ string) (*auth.JWTClaims, error) {
+				return github.Claims(ctx, token.AccessToken)
+			},
+		}
+	}
+
+	if oidcHandler != nil && oidcHandler.validator != nil {
+		h.providers[BrowserProviderOIDC] = &browserProvider{
+			oauth: &oauth2.Config{
+				ClientID:     cfg.OIDCClientID,
+				ClientSecret: cfg.OIDCClientSecret,
+				Endpoint:     oidcHandler.endpoint,
+				RedirectURL:  callbackURL + BrowserProviderOIDC,
+				Scopes:       []string{"openid", "email", "profile"},
+			},
+			nonce: true,
+			claims: func(ctx context.Context, token *oauth2.Token, nonce string) (*auth.JWTClaims, error) {
+				idToken, _ := token.Extra("id_token").(string)
+				if idToken == "" {
+					return nil, errors.New("the token response has no ID token")
+				}
+				claims, err := oidcHandler.validator.ValidateToken(ctx, idToken)
+				if err != nil {
+					return nil, fmt.Errorf("failed to validate ID token: %w", err)
+				}
+				if tokenNonce, _ := claims.ExtraClaims["nonce"].(string); subtle.ConstantTimeCompare([]byte(tokenNonce), []byte(nonce)) != 1 {
+					return nil, errors.New("the ID token was issued for another login")
+				}
+				return oidcHandler.registryClaims(claims)
+			},
+		}
+	}
+
+	return h
+}
+
+// Providers returns the names of the providers people can log in with, sorted
+func (h *BrowserLoginHandler) Providers() []string {
+	names := make([]string, 0, len(h.providers))
+	for name := range h.providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// RegisterBrowserLoginEndpoints registers browser login on mux when it is enabled, along with the OAuth
+// endpoints for registered clients when client registration is enabled too. The endpoints redirect and set
+// cookies, so they are plain HTTP handlers outside the versioned API.
+func RegisterBrowserLoginEndpoints(mux *http.ServeMux, cfg *config.Config, registry service.RegistryService) {
+	if !cfg.BrowserLoginEnabled {
+		if cfg.OAuthClientRegistrationEnabled {
+			log.Printf("OAuth client registration is disabled: it requires MCP_REGISTRY_BROWSER_LOGIN_ENABLED")
+		}
+		return
+	}
+	if cfg.PublicURL == "" {
+		log.Printf("Browser login is disabled: MCP_REGISTRY_PUBLIC_URL must be set for the OAuth callback")
+		return
+	}
+
+	var github *GitHubHandler
+	if providerAllowed(cfg, "github-at") {
+		github = NewGitHubHandler(cfg)
+	}
+	var oidcHandler *OIDCHandler
+	if cfg.OIDCEnabled && providerAllowed(cfg, "oidc") {
+		oidcHandler = NewOIDCHandler(cfg)
+	}
+	h := NewBrowserLoginHandler(cfg, github, oidcHandler)
+	if cfg.OAuthClientRegistrationEnabled {
+		h.SetOAuthClientStore(registry)
+	}
+	h.RegisterEndpoints(mux)
+}
+
+// providerAllowed reports whether MCP_REGISTRY_AUTH_PROVIDERS allows the token exchange provider name
+func providerAllowed(cfg *config.Config, name string) bool {
+	return len(cfg.AuthProviders) == 0 || slices.Contains(cfg.AuthProviders, name)
+}
+
+// RegisterEndpoints registers the login, callback, session and logout endpoints on mux, and the OAuth
+// endpoints for registered clients when a client store is set
+func (h *BrowserLoginHandler) RegisterEndpoints(mux *http.ServeMux) {
+	mux.HandleFunc("GET /auth/browser/login/{provider}", h.login)
+	mux.HandleFunc("GET /auth/browser/callback/{provider}", h.callback)
+	mux.HandleFunc("GET /auth/browser/session", h.session)
+	mux.HandleFunc("POST /auth/browser/logout", h.logout)
+	if h.clients != nil {
+		h.registerOAuthEndpoints(mux)
+	}
+}
+
+// login redirects to the provider, remembering the login's state, PKCE verifier and nonce in a cookie
+func (h *BrowserLoginHandler) login(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("provider")
+	provider, ok := h.providers[name]
+	if !ok {
+		writeBrowserError(w, http.StatusNotFound, apiv0.ErrorCodeNotFound, fmt.Sprintf("Browser login with %q is not available", name))
+		return
+	}
+
+	h.redirectToProvider(w, r, provider, pendingLogin{
+		Provider: name,
+		ReturnTo: localReturnPath(r.URL.Query().Get("return_to")),
+	})
+}
+
+// redirectToProvider starts a login at the provider, completing pending with the login's state, PKCE verifier
+// and nonce and remembering it in a cookie
+func (h *BrowserLoginHandler) redirectToProvider(w http.ResponseWriter, r *http.Request, provider *browserProvider, pending pendingLogin) {
+	pending.State = rand.Text()
+	pending.Verifier = oauth2.GenerateVerifier()
+	options := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(pending.Verifier)}
+	if provider.nonce {
+		pending.Nonce = rand.Text()
+		options = append(options, oauth2.SetAuthURLParam("nonce", pending.Nonce))
+	}
+
+	value, err := json.Marshal(pending)
+	if err != nil {
+		writeBrowserError(w, http.StatusInternalServerError, apiv0.ErrorCodeInternal, "Failed to start login")
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     loginCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(value),
+		Path:     "/",
+		MaxAge:   int(loginTimeout.Seconds()),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, provider.oauth.AuthCodeURL(pending.State, options...), http.StatusFound)
+}
+
+// callback completes a login: it checks the state, redeems the code with the PKCE verifier, and starts a
+// session for the person the provider vouches for
+func (h *BrowserLoginHandler) callback(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("provider")
+	provider, ok := h.providers[name]
+	if !ok {
+		writeBrowserError(w, http.StatusNotFound, apiv0.ErrorCodeNotFound, fmt.Sprintf("Browser login with %q is not available", name))
+		return
+	}
+
+	pending, err := readPendingLogin(r)
+	// The login can only be completed once
+	http.SetCookie(w, &http.Cookie{Name: loginCookieName, Path: "/", MaxAge: -1, Secure: true, HttpOnly: true, SameSite: http.SameSiteLaxMode})
+	query := r.URL.Query()
+	switch {
+	case err != nil:
+		writeBrowserError(w, http.StatusBadRequest, apiv0.ErrorCodeBadRequest, "No login is in progress in this browser, or it has expired; start again")
+		return
+	case pending.Provider != name || subtle.ConstantTimeCompare([]byte(pending.State), []byte(query.Get("state"))) != 1:
+		writeBrowserError(w, http.StatusBadRequest, apiv0.ErrorCodeBadRequest, "The login response does not match the login started in this browser")
+		return
+	case query.Get("error") != "" && pending.Client != nil:
+		redirectAuthorizationError(w, r, pending.Client, "access_denied", "Login was not completed: "+query.Get("error"))
+		return
+	case query.Get("error") != "":
+		writeBrowserError(w, http.StatusUnauthorized, apiv0.ErrorCodeUnauthorized, "Login was not completed: "+query.Get("error"))
+		return
+	}
+
+	token, err := provider.oauth.Exchange(r.Context(), query.Get("code"), oauth2.VerifierOption(pending.Verifier))
+	if err != nil {
+		log.Printf("Browser login with %s failed to redeem the authorization code: %v", name, err)
+		writeBrowserError(w, http.StatusUnauthorized, apiv0.ErrorCodeUnauthorized, "Login failed: the authorization code was not accepted")
+		return
+	}
+	claims, err := provider.claims(r.Context(), token, pending.Nonce)
+	if err != nil {
+		writeBrowserError(w, http.StatusUnauthorized, apiv0.ErrorCodeUnauthorized, "Login failed: "+err.Error())
+		return
+	}
+	if pending.Client != nil {
+		h.requestConsent(w, r, pending.Client, claims)
+		return
+	}
+	session, err := h.jwtManager.GenerateSessionToken(r.Context(), *claims, h.config.BrowserSessionDuration)
+	if err != nil {
+		writeBrowserError(w, http.StatusForbidden, apiv0.ErrorCodeForbidden, err.Error())
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookieName,
+		Value:    session.RegistryToken,
+		Path:     "/",
+		Expires:  time.Unix(int64(session.ExpiresAt), 0),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, pending.ReturnTo, http.StatusSeeOther)
+}
+
+// session describes the browser session, including its CSRF token, or the providers to log in with
+func (h *BrowserLoginHandler) session(w http.ResponseWriter, r *http.Request) {
+	body := BrowserSession{Providers: h.Providers()}
+	if cookie, err := r.Cookie(auth.SessionCookieName); err == nil {
+		if claims, err := h.jwtManager.ValidateToken(r.Context(), cookie.Value); err == nil {
+			body.Authenticated = true
+			body.AuthMethod = claims.AuthMethod
+			body.Subject = claims.AuthMethodSubject
+			body.Permissions = claims.Permissions
+			body.IsAdmin = h.jwtManager.IsAdmin(claims.Permissions)
+			body.ExpiresAt = &claims.ExpiresAt.Time
+			body.CSRFToken = claims.ID
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "private, no-store")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// logout deletes the session cookie. The session middleware has already checked the CSRF token of
+// requests carrying the cookie.
+func (h *BrowserLoginHandler) logout(w http.ResponseWriter, _ *http.Request) {
+	http.SetCookie(w, &http.Cookie{Name: auth.SessionCookieName, Path: "/", MaxAge: -1, Secure: true, HttpOnly: true, SameSite: http.SameSiteLaxMode})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readPendingLogin reads the login in progress from its cookie
+func readPendingLogin(r *http.Request) (*pendingLogin, error) {
+	cookie, err := r.Cookie(loginCookieName)
+	if err != nil {
+		return nil, err
+	}
+	value, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, err
+	}
+	var pending pendingLogin
+	if err := json.Unmarshal(value, &pending); err != nil {
+		return nil, err
+	}
+	return &pending, nil
+}
+
+// localReturnPath returns path if it is a path on this site, so logins cannot redirect people elsewhere,
+// and the UI otherwise
+func localReturnPath(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.ContainsAny(path, "\\\r\n") {
+		return "/"
+	}
+	return path
+}
+
+// writeBrowserError writes a problem+json error like the API's, for the browser login endpoints
+func writeBrowserError(w http.ResponseWriter, status int, code apiv0.ErrorCode, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&v0.ErrorModel{
+		ErrorModel: huma.ErrorModel{Title: http.StatusText(status), Status: status, Detail: detail},
+		Code:       code,
+	})
+}
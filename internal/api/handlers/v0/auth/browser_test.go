@@ -0,0 +1,258 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// browserLoginConfig returns a config for browser login served at https://registry.example.com
+func browserLoginConfig(t *testing.T) *config.Config {
+	t.Helper()
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	return &config.Config{
+		JWTPrivateKey:          hex.EncodeToString(seed),
+		PublicURL:              "https://registry.example.com",
+		BrowserLoginEnabled:    true,
+		BrowserSessionDuration: 8 * time.Hour,
+		GithubClientID:         "github-client",
+		GithubClientSecret:     "github-secret",
+	}
+}
+
+// fakeGitHub serves GitHub's OAuth token endpoint and the API calls made for a user with no organizations,
+// checking the PKCE verifier against the challenge the login redirected with
+func fakeGitHub(t *testing.T, challenge *string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login/oauth/access_token":
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "valid-code", r.PostForm.Get("code"))
+			sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+			if base64.RawURLEncoding.EncodeToString(sum[:]) != *challenge {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"github-token","token_type":"bearer"}`))
+		case githubUserEndpoint:
+			assert.Equal(t, "Bearer github-token", r.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(v0auth.GitHubUserOrOrg{Login: "testuser", ID: 1})
+		case githubOrgsEndpoint:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// serve sends a request with cookies to the handler's endpoints
+func serve(mux *http.ServeMux, method, target string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+// responseCookie returns the cookie named name a response sets
+func responseCookie(t *testing.T, w *httptest.ResponseRecorder, name string) *http.Cookie {
+	t.Helper()
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	t.Fatalf("response sets no %s cookie", name)
+	return nil
+}
+
+func TestBrowserLogin_GitHub(t *testing.T) {
+	cfg := browserLoginConfig(t)
+	var challenge string
+	server := fakeGitHub(t, &challenge)
+	defer server.Close()
+
+	github := v0auth.NewGitHubHandler(cfg)
+	github.SetBaseURL(server.URL)
+	github.SetOAuthBaseURL(server.URL)
+	handler := v0auth.NewBrowserLoginHandler(cfg, github, nil)
+	assert.Equal(t, []string{v0auth.BrowserProviderGitHub}, handler.Providers())
+	mux := http.NewServeMux()
+	handler.RegisterEndpoints(mux)
+
+	w := serve(mux, http.MethodGet, "/auth/browser/login/github?return_to=/servers?search=weather")
+	require.Equal(t, http.StatusFound, w.Code)
+	redirect, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/login/oauth/authorize", redirect.Scheme+"://"+redirect.Host+redirect.Path)
+	assert.Equal(t, "github-client", redirect.Query().Get("client_id"))
+	assert.Equal(t, "https://registry.example.com/auth/browser/callback/github", redirect.Query().Get("redirect_uri"))
+	assert.Equal(t, "S256", redirect.Query().Get("code_challenge_method"))
+	challenge = redirect.Query().Get("code_challenge")
+	state := redirect.Query().Get("state")
+	require.NotEmpty(t, state)
+	loginCookie := responseCookie(t, w, "__Host-mcp-registry-login")
+	assert.True(t, loginCookie.HttpOnly)
+	assert.True(t, loginCookie.Secure)
+
+	w = serve(mux, http.MethodGet, "/auth/browser/callback/github?code=valid-code&state="+url.QueryEscape(state), loginCookie)
+	require.Equal(t, http.StatusSeeOther, w.Code, w.Body.String())
+	assert.Equal(t, "/servers?search=weather", w.Header().Get("Location"))
+	sessionCookie := responseCookie(t, w, auth.SessionCookieName)
+	assert.True(t, sessionCookie.HttpOnly)
+	assert.True(t, sessionCookie.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, sessionCookie.SameSite)
+
+	w = serve(mux, http.MethodGet, "/auth/browser/session", sessionCookie)
+	require.Equal(t, http.StatusOK, w.Code)
+	var session v0auth.BrowserSession
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&session))
+	assert.True(t, session.Authenticated)
+	assert.Equal(t, auth.MethodGitHubAT, session.AuthMethod)
+	assert.Equal(t, "testuser", session.Subject)
+	assert.NotEmpty(t, session.CSRFToken)
+	require.NotEmpty(t, session.Permissions)
+	assert.Equal(t, "io.github.testuser/*", session.Permissions[0].ResourcePattern)
+
+	jwtManager := auth.NewJWTManager(cfg)
+	claims, err := jwtManager.ValidateToken(context.Background(), sessionCookie.Value)
+	require.NoError(t, err)
+	assert.True(t, auth.ValidCSRFToken(claims, session.CSRFToken))
+
+	w = serve(mux, http.MethodPost, "/auth/browser/logout", sessionCookie)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Negative(t, responseCookie(t, w, auth.SessionCookieName).MaxAge)
+}
+
+func TestBrowserLogin_OIDC(t *testing.T) {
+	var issuer *httptest.Server
+	issuer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"issuer":                 issuer.URL,
+				"authorization_endpoint": issuer.URL + "/authorize",
+				"token_endpoint":         issuer.URL + "/token",
+				"jwks_uri":               issuer.URL + "/jwks",
+			})
+		case "/token":
+			_, _ = w.Write([]byte(`{"access_token":"access","token_type":"Bearer","id_token":"id-token"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer issuer.Close()
+
+	cfg := browserLoginConfig(t)
+	cfg.GithubClientSecret = ""
+	cfg.OIDCEnabled = true
+	cfg.OIDCIssuer = issuer.URL
+	cfg.OIDCClientID = "registry"
+	cfg.OIDCClientSecret = "secret"
+	cfg.OIDCEditPerms = "*"
+
+	oidcHandler := v0auth.NewOIDCHandler(cfg)
+	var nonce string
+	oidcHandler.SetValidator(&MockGenericOIDCValidator{
+		validateFunc: func(_ context.Context, token string) (*v0auth.OIDCClaims, error) {
+			assert.Equal(t, "id-token", token)
+			return &v0auth.OIDCClaims{Subject: "admin", ExtraClaims: map[string]any{"nonce": nonce}}, nil
+		},
+	})
+	handler := v0auth.NewBrowserLoginHandler(cfg, v0auth.NewGitHubHandler(cfg), oidcHandler)
+	assert.Equal(t, []string{v0auth.BrowserProviderOIDC}, handler.Providers(), "GitHub needs a client secret")
+	mux := http.NewServeMux()
+	handler.RegisterEndpoints(mux)
+
+	login := func(t *testing.T) (string, *http.Cookie) {
+		t.Helper()
+		w := serve(mux, http.MethodGet, "/auth/browser/login/oidc?return_to=//evil.example.com")
+		require.Equal(t, http.StatusFound, w.Code)
+		redirect, err := url.Parse(w.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(redirect.String(), issuer.URL+"/authorize?"))
+		assert.Contains(t, redirect.Query().Get("scope"), "openid")
+		require.NotEmpty(t, redirect.Query().Get("nonce"))
+		nonce = redirect.Query().Get("nonce")
+		return redirect.Query().Get("state"), responseCookie(t, w, "__Host-mcp-registry-login")
+	}
+
+	t.Run("completes login", func(t *testing.T) {
+		state, loginCookie := login(t)
+		w := serve(mux, http.MethodGet, "/auth/browser/callback/oidc?code=code&state="+url.QueryEscape(state), loginCookie)
+		require.Equal(t, http.StatusSeeOther, w.Code, w.Body.String())
+		assert.Equal(t, "/", w.Header().Get("Location"), "return paths on other sites are ignored")
+
+		w = serve(mux, http.MethodGet, "/auth/browser/session", responseCookie(t, w, auth.SessionCookieName))
+		var session v0auth.BrowserSession
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&session))
+		assert.True(t, session.Authenticated)
+		assert.True(t, session.IsAdmin)
+		assert.Equal(t, "admin", session.Subject)
+	})
+
+	t.Run("rejects ID token for another login", func(t *testing.T) {
+		state, loginCookie := login(t)
+		nonce = "other"
+		w := serve(mux, http.MethodGet, "/auth/browser/callback/oidc?code=code&state="+url.QueryEscape(state), loginCookie)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("rejects mismatched state", func(t *testing.T) {
+		_, loginCookie := login(t)
+		w := serve(mux, http.MethodGet, "/auth/browser/callback/oidc?code=code&state=forged", loginCookie)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "BAD_REQUEST")
+	})
+
+	t.Run("rejects callback without login", func(t *testing.T) {
+		w := serve(mux, http.MethodGet, "/auth/browser/callback/oidc?code=code&state=state")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rejects unknown provider", func(t *testing.T) {
+		w := serve(mux, http.MethodGet, "/auth/browser/login/github")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestBrowserLogin_AnonymousSession(t *testing.T) {
+	cfg := browserLoginConfig(t)
+	mux := http.NewServeMux()
+	v0auth.NewBrowserLoginHandler(cfg, v0auth.NewGitHubHandler(cfg), nil).RegisterEndpoints(mux)
+
+	w := serve(mux, http.MethodGet, "/auth/browser/session", &http.Cookie{Name: auth.SessionCookieName, Value: "expired"})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "private, no-store", w.Header().Get("Cache-Control"))
+	var session v0auth.BrowserSession
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&session))
+	assert.False(t, session.Authenticated)
+	assert.Empty(t, session.CSRFToken)
+	assert.Equal(t, []string{v0auth.BrowserProviderGitHub}, session.Providers)
+}
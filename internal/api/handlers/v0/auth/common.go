@@ -16,6 +16,7 @@ import (
 
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 )
 
 // CryptoAlgorithm represents the cryptographic algorithm used for a public key
@@ -46,10 +47,18 @@ type SignatureTokenExchangeInput struct {
 // KeyFetcher defines a function type for fetching keys from external sources
 type KeyFetcher func(ctx context.Context, domain string) ([]string, error)
 
+// NamespaceReviewStore records domain login attempts for admin review and reports review decisions.
+// service.RegistryService satisfies this interface.
+type NamespaceReviewStore interface {
+	IsNamespaceApproved(ctx context.Context, domain string) (bool, error)
+	RecordNamespaceVerificationAttempt(ctx context.Context, domain, method string, evidence database.VerificationEvidence) (*database.NamespaceVerification, error)
+}
+
 // CoreAuthHandler represents the common handler structure
 type CoreAuthHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
+	reviews    NamespaceReviewStore
 }
 
 // NewCoreAuthHandler creates a new core authentication handler
@@ -60,6 +69,52 @@ func NewCoreAuthHandler(cfg *config.Config) *CoreAuthHandler {
 	}
 }
 
+// SetNamespaceReviewStore sets the store consulted when namespace review is required
+func (h *CoreAuthHandler) SetNamespaceReviewStore(reviews NamespaceReviewStore) {
+	h.reviews = reviews
+}
+
+// VerificationLookup describes where the public key for a domain is looked up with the given method
+func VerificationLookup(authMethod auth.Method, domain string) string {
+	if authMethod == auth.MethodHTTP {
+		return wellKnownAuthURL(domain)
+	}
+	return domain
+}
+
+// requireNamespaceApproval blocks token issuance for domains an admin has not approved yet, when review is required.
+// Each blocked login adds its evidence to the domain's pending verification request.
+func (h *CoreAuthHandler) requireNamespaceApproval(ctx context.Context, authMethod auth.Method, domain string, keyStrings []string) error {
+	if !h.config.NamespaceReviewRequired {
+		return nil
+	}
+	if h.reviews == nil {
+		return fmt.Errorf("%w: no review store is configured", auth.ErrNamespacePendingReview)
+	}
+
+	approved, err := h.reviews.IsNamespaceApproved(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("failed to check namespace approval: %w", err)
+	}
+	if approved {
+		return nil
+	}
+
+	verification, err := h.reviews.RecordNamespaceVerificationAttempt(ctx, domain, string(authMethod), database.VerificationEvidence{
+		CheckedAt:     time.Now(),
+		Trigger:       database.EvidenceTriggerLogin,
+		Lookup:        VerificationLookup(authMethod, domain),
+		Records:       keyStrings,
+		ValidKeyFound: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record namespace verification request: %w", err)
+	}
+
+	return fmt.Errorf("%w: verification request %d for %s is awaiting approval by a registry admin",
+		auth.ErrNamespacePendingReview, verification.ID, domain)
+}
+
 // ValidateDomainAndTimestamp validates the domain format and timestamp
 func ValidateDomainAndTimestamp(domain, timestamp string) (*time.Time, error) {
 	if !IsValidDomain(domain) {
@@ -204,7 +259,7 @@ func (h *CoreAuthHandler) ExchangeToken(
 			return nil, fmt.Errorf("no MCP public key found in HTTP response")
 		case auth.MethodDNS:
 			return nil, fmt.Errorf("no MCP public key found in DNS TXT records")
-		case auth.MethodGitHubAT, auth.MethodGitHubOIDC, auth.MethodOIDC, auth.MethodNone:
+		case auth.MethodGitHubAT, auth.MethodGitHubOIDC, auth.MethodOIDC, auth.MethodAPIKey, auth.MethodNone:
 		default:
 			return nil, fmt.Errorf("no MCP public key found using %s authentication", authMethod)
 		}
@@ -232,6 +287,10 @@ func (h *CoreAuthHandler) ExchangeToken(
 		return nil, err
 	}
 
+	if err := h.requireNamespaceApproval(ctx, authMethod, domain, keyStrings); err != nil {
+		return nil, err
+	}
+
 	permissions := BuildPermissions(domain, includeSubdomains)
 
 	return h.CreateJWTClaimsAndToken(ctx, authMethod, domain, permissions)
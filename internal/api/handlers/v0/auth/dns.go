@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -51,10 +52,13 @@ func (h *DNSAuthHandler) SetResolver(resolver DNSResolver) {
 	h.resolver = resolver
 }
 
-// RegisterDNSEndpoint registers the DNS authentication endpoint
-func RegisterDNSEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
-	handler := NewDNSAuthHandler(cfg)
+// Name returns the provider name used in MCP_REGISTRY_AUTH_PROVIDERS
+func (h *DNSAuthHandler) Name() string {
+	return "dns"
+}
 
+// RegisterEndpoints registers the DNS authentication endpoint
+func (h *DNSAuthHandler) RegisterEndpoints(api huma.API, pathPrefix string) {
 	// DNS authentication endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "exchange-dns-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -64,7 +68,13 @@ func RegisterDNSEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
 		Description: "Authenticate using DNS TXT record public key and signed timestamp",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *DNSTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
+		response, err := h.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
+		if errors.Is(err, auth.ErrNamespacePendingReview) {
+			return nil, huma.Error403Forbidden("Domain ownership verified, but the namespace is awaiting admin approval", err)
+		}
+		if errors.Is(err, auth.ErrIdentityBanned) {
+			return nil, huma.Error403Forbidden("Identity is banned from publishing", err)
+		}
 		if err != nil {
 			return nil, huma.Error401Unauthorized("DNS authentication failed", err)
 		}
@@ -3,8 +3,10 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"regexp"
 	"strings"
@@ -27,6 +29,7 @@ type GitHubHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
 	baseURL    string // Configurable for testing
+	oauthURL   string // Base URL of GitHub's OAuth endpoints, configurable for testing
 }
 
 // NewGitHubHandler creates a new GitHub handler
@@ -35,6 +38,7 @@ func NewGitHubHandler(cfg *config.Config) *GitHubHandler {
 		config:     cfg,
 		jwtManager: auth.NewJWTManager(cfg),
 		baseURL:    "https://api.github.com",
+		oauthURL:   "https://github.com",
 	}
 }
 
@@ -43,10 +47,18 @@ func (h *GitHubHandler) SetBaseURL(url string) {
 	h.baseURL = url
 }
 
-// RegisterGitHubATEndpoint registers the GitHub access token authentication endpoint with a custom path prefix
-func RegisterGitHubATEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
-	handler := NewGitHubHandler(cfg)
+// SetOAuthBaseURL sets the base URL of GitHub's OAuth endpoints used by browser login (used for testing)
+func (h *GitHubHandler) SetOAuthBaseURL(url string) {
+	h.oauthURL = url
+}
+
+// Name returns the provider name used in MCP_REGISTRY_AUTH_PROVIDERS
+func (h *GitHubHandler) Name() string {
+	return "github-at"
+}
 
+// RegisterEndpoints registers the GitHub access token authentication endpoint
+func (h *GitHubHandler) RegisterEndpoints(api huma.API, pathPrefix string) {
 	// GitHub token exchange endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "exchange-github-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -56,7 +68,10 @@ func RegisterGitHubATEndpoint(api huma.API, pathPrefix string, cfg *config.Confi
 		Description: "Exchange a GitHub OAuth access token for a short-lived Registry JWT token",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *GitHubTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.GitHubToken)
+		response, err := h.ExchangeToken(ctx, input.Body.GitHubToken)
+		if errors.Is(err, auth.ErrIdentityBanned) {
+			return nil, huma.Error403Forbidden("Identity is banned from publishing", err)
+		}
 		if err != nil {
 			return nil, huma.Error401Unauthorized("Token exchange failed", err)
 		}
@@ -69,6 +84,22 @@ func RegisterGitHubATEndpoint(api huma.API, pathPrefix string, cfg *config.Confi
 
 // ExchangeToken exchanges a GitHub OAuth token for a Registry JWT token
 func (h *GitHubHandler) ExchangeToken(ctx context.Context, githubToken string) (*auth.TokenResponse, error) {
+	claims, err := h.Claims(ctx, githubToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate Registry JWT token
+	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, *claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+	}
+
+	return tokenResponse, nil
+}
+
+// Claims returns the Registry JWT claims for the user a GitHub OAuth token belongs to
+func (h *GitHubHandler) Claims(ctx context.Context, githubToken string) (*auth.JWTClaims, error) {
 	// Get GitHub user information
 	user, err := h.getGitHubUser(ctx, githubToken)
 	if err != nil {
@@ -84,20 +115,27 @@ func (h *GitHubHandler) ExchangeToken(ctx context.Context, githubToken string) (
 	// Build permissions based on user and organizations
 	permissions := h.buildPermissions(user.Login, orgs)
 
+	// Roles are best effort: tokens GitHub will not report them to still log in, just without owning any
+	// organization
+	var owned []string
+	if permissions != nil {
+		owned = append(owned, "io.github."+user.Login)
+		adminOrgs, err := h.getGitHubAdminOrgs(ctx, githubToken)
+		if err != nil {
+			log.Printf("failed to get GitHub organization roles of %s: %v", user.Login, err)
+		}
+		for _, org := range adminOrgs {
+			owned = append(owned, "io.github."+org)
+		}
+	}
+
 	// Create JWT claims with GitHub user info
-	claims := auth.JWTClaims{
+	return &auth.JWTClaims{
 		AuthMethod:        auth.MethodGitHubAT,
 		AuthMethodSubject: user.Login,
 		Permissions:       permissions,
-	}
-
-	// Generate Registry JWT token
-	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, claims)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
-	}
-
-	return tokenResponse, nil
+		OwnerOf:           owned,
+	}, nil
 }
 
 type GitHubUserOrOrg struct {
@@ -164,6 +202,50 @@ func (h *GitHubHandler) getGitHubUserOrgs(ctx context.Context, username string,
 	return orgs, nil
 }
 
+// GitHubOrgMembership is the authenticated user's membership of a GitHub organization
+type GitHubOrgMembership struct {
+	State        string          `json:"state"`
+	Role         string          `json:"role"`
+	Organization GitHubUserOrOrg `json:"organization"`
+}
+
+// getGitHubAdminOrgs lists the organizations the authenticated user is an admin of. GitHub only reports
+// memberships to tokens with the read:org scope.
+func (h *GitHubHandler) getGitHubAdminOrgs(ctx context.Context, token string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.baseURL+"/user/memberships/orgs?state=active&per_page=100", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization memberships: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitHub API error (status %d): %s", resp.StatusCode, body)
+	}
+
+	var memberships []GitHubOrgMembership
+	if err := json.NewDecoder(resp.Body).Decode(&memberships); err != nil {
+		return nil, fmt.Errorf("failed to decode organization memberships response: %w", err)
+	}
+
+	var orgs []string
+	for _, membership := range memberships {
+		if membership.State == "active" && membership.Role == "admin" && isValidGitHubName(membership.Organization.Login) {
+			orgs = append(orgs, membership.Organization.Login)
+		}
+	}
+	return orgs, nil
+}
+
 // buildPermissions builds permissions based on GitHub user and their organizations
 func (h *GitHubHandler) buildPermissions(username string, orgs []GitHubUserOrOrg) []auth.Permission {
 	permissions := []auth.Permission{}
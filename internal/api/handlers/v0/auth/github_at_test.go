@@ -102,6 +102,13 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 				}
 				w.Header().Set("Content-Type", "application/json")
 				json.NewEncoder(w).Encode(orgs) //nolint:errcheck
+			case "/user/memberships/orgs":
+				memberships := []v0auth.GitHubOrgMembership{
+					{State: "active", Role: "admin", Organization: v0auth.GitHubUserOrOrg{Login: "test-org-1", ID: 1}},
+					{State: "active", Role: "member", Organization: v0auth.GitHubUserOrOrg{Login: "test-org-2", ID: 2}},
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(memberships) //nolint:errcheck
 			default:
 				w.WriteHeader(http.StatusNotFound)
 			}
@@ -136,6 +143,9 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 			assert.Equal(t, auth.PermissionActionPublish, perm.Action)
 			assert.Equal(t, expectedPatterns[i], perm.ResourcePattern)
 		}
+
+		// Users own their own namespace and the organizations they are an admin of
+		assert.Equal(t, []string{"io.github.testuser", "io.github.test-org-1"}, claims.OwnerOf)
 	})
 
 	t.Run("invalid token returns error", func(t *testing.T) {
@@ -5,11 +5,15 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -29,6 +33,14 @@ type GitHubOIDCTokenExchangeInput struct {
 type GitHubOIDCClaims struct {
 	jwt.RegisteredClaims
 	RepositoryOwner string `json:"repository_owner"` // e.g., "octo-org"
+	Repository      string `json:"repository"`       // e.g., "octo-org/octo-repo"
+	Ref             string `json:"ref"`              // e.g., "refs/tags/v1.2.0"
+	RefType         string `json:"ref_type"`         // "branch" or "tag"
+	RefProtected    string `json:"ref_protected"`    // "true" when the ref has branch or tag protection rules
+	Environment     string `json:"environment"`      // deployment environment of the job, if any
+	// JobWorkflowRef is the workflow the job ran, which differs from the repository's own workflow when the
+	// job calls a reusable workflow, e.g. "octo-org/shared/.github/workflows/publish.yml@refs/heads/main"
+	JobWorkflowRef string `json:"job_workflow_ref"`
 }
 
 // JWKS represents a JSON Web Key Set
@@ -210,6 +222,7 @@ type GitHubOIDCHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
 	validator  OIDCValidator
+	replays    TokenReplayStore
 }
 
 // NewGitHubOIDCHandler creates a new GitHub OIDC handler
@@ -226,10 +239,18 @@ func (h *GitHubOIDCHandler) SetValidator(validator OIDCValidator) {
 	h.validator = validator
 }
 
-// RegisterGitHubOIDCEndpoint registers the GitHub OIDC authentication endpoint
-func RegisterGitHubOIDCEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
-	handler := NewGitHubOIDCHandler(cfg)
+// SetReplayStore sets the store exchanged tokens are remembered in, so replays are rejected; nil accepts them
+func (h *GitHubOIDCHandler) SetReplayStore(store TokenReplayStore) {
+	h.replays = store
+}
+
+// Name returns the provider name used in MCP_REGISTRY_AUTH_PROVIDERS
+func (h *GitHubOIDCHandler) Name() string {
+	return "github-oidc"
+}
 
+// RegisterEndpoints registers the GitHub OIDC authentication endpoint
+func (h *GitHubOIDCHandler) RegisterEndpoints(api huma.API, pathPrefix string) {
 	// GitHub OIDC token exchange endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "exchange-github-oidc-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -239,7 +260,10 @@ func RegisterGitHubOIDCEndpoint(api huma.API, pathPrefix string, cfg *config.Con
 		Description: "Exchange a GitHub Actions OIDC token for a short-lived Registry JWT token",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *GitHubOIDCTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.OIDCToken)
+		response, err := h.ExchangeToken(ctx, input.Body.OIDCToken)
+		if errors.Is(err, auth.ErrIdentityBanned) {
+			return nil, huma.Error403Forbidden("Identity is banned from publishing", err)
+		}
 		if err != nil {
 			return nil, huma.Error401Unauthorized("Token exchange failed", err)
 		}
@@ -258,14 +282,36 @@ func (h *GitHubOIDCHandler) ExchangeToken(ctx context.Context, oidcToken string)
 		return nil, fmt.Errorf("failed to validate OIDC token: %w", err)
 	}
 
+	// Pull request jobs run unreviewed code, so their tokens can only publish previews, whatever the policy
+	pullRequest := pullRequestOf(claims)
+	if pullRequest == "" {
+		if err := h.checkPolicy(claims); err != nil {
+			return nil, err
+		}
+	}
+
+	var expiresAt time.Time
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	if err := claimToken(ctx, h.replays, claims.Issuer, claims.ID, oidcToken, expiresAt); err != nil {
+		return nil, err
+	}
+
 	// Extract repository information and build permissions
 	permissions := h.buildPermissions(claims)
+	if pullRequest != "" {
+		for i := range permissions {
+			permissions[i].Action = auth.PermissionActionPreview
+		}
+	}
 
 	// Create JWT claims with GitHub OIDC info
 	jwtClaims := auth.JWTClaims{
 		AuthMethod:        auth.MethodGitHubOIDC,
 		AuthMethodSubject: claims.Subject, // e.g. "repo:octo-org/octo-repo:environment:prod"
 		Permissions:       permissions,
+		PullRequest:       pullRequest,
 	}
 
 	// Generate Registry JWT token
@@ -295,3 +341,57 @@ func (h *GitHubOIDCHandler) buildPermissions(claims *GitHubOIDCClaims) []auth.Pe
 
 	return permissions
 }
+
+// pullRequestRef matches the refs GitHub Actions runs pull request jobs on
+var pullRequestRef = regexp.MustCompile(`^refs/pull/([0-9]+)/(?:merge|head)$`)
+
+// pullRequestOf returns the pull request a job ran for, as owner/repository#number, or "" for other jobs
+func pullRequestOf(claims *GitHubOIDCClaims) string {
+	match := pullRequestRef.FindStringSubmatch(claims.Ref)
+	if match == nil || claims.Repository == "" {
+		return ""
+	}
+	return claims.Repository + "#" + match[1]
+}
+
+// checkPolicy enforces the configured restrictions on the ref, workflow and environment of the job that
+// requested the token, so operators can keep arbitrary branches of a repository from publishing
+func (h *GitHubOIDCHandler) checkPolicy(claims *GitHubOIDCClaims) error {
+	switch h.config.GitHubOIDCRefPolicy {
+	case "tags":
+		if claims.RefType != "tag" {
+			return fmt.Errorf("publishing is only allowed from tags, got %s %q", claims.RefType, claims.Ref)
+		}
+	case "protected":
+		if claims.RefType != "tag" && claims.RefProtected != "true" {
+			return fmt.Errorf("publishing is only allowed from tags and protected branches, got unprotected %s %q", claims.RefType, claims.Ref)
+		}
+	}
+
+	if len(h.config.GitHubOIDCWorkflows) > 0 && !matchesAnyPattern(claims.JobWorkflowRef, h.config.GitHubOIDCWorkflows) {
+		return fmt.Errorf("workflow %q is not allowed to publish", claims.JobWorkflowRef)
+	}
+
+	if len(h.config.GitHubOIDCEnvironments) > 0 && !slices.Contains(h.config.GitHubOIDCEnvironments, claims.Environment) {
+		if claims.Environment == "" {
+			return fmt.Errorf("publishing jobs must run in one of the environments %s", strings.Join(h.config.GitHubOIDCEnvironments, ", "))
+		}
+		return fmt.Errorf("environment %q is not allowed to publish", claims.Environment)
+	}
+
+	return nil
+}
+
+// matchesAnyPattern reports whether value equals one of patterns, or starts with the prefix of one ending in *
+func matchesAnyPattern(value string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(value, prefix) {
+				return true
+			}
+		} else if value == pattern {
+			return true
+		}
+	}
+	return false
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
 	internalauth "github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -214,3 +216,161 @@ func TestBuildPermissionsFromOIDC(t *testing.T) {
 		})
 	}
 }
+
+func TestGitHubOIDCHandler_Policy(t *testing.T) {
+	reusable := "octo-org/shared/.github/workflows/publish.yml@refs/heads/main"
+
+	tests := []struct {
+		name        string
+		cfg         config.Config
+		claims      auth.GitHubOIDCClaims
+		expectError string
+	}{
+		{
+			name:   "no policy allows any branch",
+			claims: auth.GitHubOIDCClaims{Ref: "refs/heads/feature", RefType: "branch"},
+		},
+		{
+			name:   "tags policy allows tags",
+			cfg:    config.Config{GitHubOIDCRefPolicy: "tags"},
+			claims: auth.GitHubOIDCClaims{Ref: "refs/tags/v1.0.0", RefType: "tag"},
+		},
+		{
+			name:        "tags policy rejects protected branches",
+			cfg:         config.Config{GitHubOIDCRefPolicy: "tags"},
+			claims:      auth.GitHubOIDCClaims{Ref: "refs/heads/main", RefType: "branch", RefProtected: "true"},
+			expectError: "only allowed from tags",
+		},
+		{
+			name:   "protected policy allows protected branches",
+			cfg:    config.Config{GitHubOIDCRefPolicy: "protected"},
+			claims: auth.GitHubOIDCClaims{Ref: "refs/heads/main", RefType: "branch", RefProtected: "true"},
+		},
+		{
+			name:   "protected policy allows tags",
+			cfg:    config.Config{GitHubOIDCRefPolicy: "protected"},
+			claims: auth.GitHubOIDCClaims{Ref: "refs/tags/v1.0.0", RefType: "tag", RefProtected: "false"},
+		},
+		{
+			name:        "protected policy rejects unprotected branches",
+			cfg:         config.Config{GitHubOIDCRefPolicy: "protected"},
+			claims:      auth.GitHubOIDCClaims{Ref: "refs/heads/feature", RefType: "branch", RefProtected: "false"},
+			expectError: "unprotected branch",
+		},
+		{
+			name:   "reusable workflow matches prefix pattern",
+			cfg:    config.Config{GitHubOIDCWorkflows: []string{"octo-org/shared/.github/workflows/publish.yml@*"}},
+			claims: auth.GitHubOIDCClaims{JobWorkflowRef: reusable},
+		},
+		{
+			name:        "other workflow is rejected",
+			cfg:         config.Config{GitHubOIDCWorkflows: []string{reusable}},
+			claims:      auth.GitHubOIDCClaims{JobWorkflowRef: "octo-org/octo-repo/.github/workflows/release.yml@refs/heads/main"},
+			expectError: "not allowed to publish",
+		},
+		{
+			name:   "allowed environment",
+			cfg:    config.Config{GitHubOIDCEnvironments: []string{"release"}},
+			claims: auth.GitHubOIDCClaims{Environment: "release"},
+		},
+		{
+			name:        "missing environment",
+			cfg:         config.Config{GitHubOIDCEnvironments: []string{"release"}},
+			expectError: "must run in one of the environments release",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.JWTPrivateKey = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+			handler := auth.NewGitHubOIDCHandler(&cfg)
+
+			claims := tt.claims
+			claims.RegisteredClaims = jwt.RegisteredClaims{
+				Subject:   "repo:octo-org/octo-repo:ref:" + claims.Ref,
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(1 * time.Hour)),
+				Audience:  jwt.ClaimStrings{"mcp-registry"},
+			}
+			claims.RepositoryOwner = "octo-org"
+			handler.SetValidator(&MockOIDCValidator{
+				validateFunc: func(_ context.Context, _ string, _ string) (*auth.GitHubOIDCClaims, error) {
+					return &claims, nil
+				},
+			})
+
+			response, err := handler.ExchangeToken(context.Background(), "test-token")
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				assert.Nil(t, response)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, response.RegistryToken)
+		})
+	}
+}
+
+func TestGitHubOIDCHandler_PullRequestPreviews(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey:       "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		GitHubOIDCRefPolicy: "tags",
+		GitHubOIDCWorkflows: []string{"octo-org/octo-repo/.github/workflows/release.yml@refs/tags/*"},
+	}
+	handler := auth.NewGitHubOIDCHandler(cfg)
+	handler.SetValidator(&MockOIDCValidator{
+		validateFunc: func(_ context.Context, _ string, _ string) (*auth.GitHubOIDCClaims, error) {
+			return &auth.GitHubOIDCClaims{
+				RegisteredClaims: jwt.RegisteredClaims{
+					Subject:   "repo:octo-org/octo-repo:pull_request",
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(5 * time.Minute)),
+					Audience:  jwt.ClaimStrings{"mcp-registry"},
+				},
+				RepositoryOwner: "octo-org",
+				Repository:      "octo-org/octo-repo",
+				Ref:             "refs/pull/42/merge",
+				JobWorkflowRef:  "octo-org/octo-repo/.github/workflows/preview.yml@refs/pull/42/merge",
+			}, nil
+		},
+	})
+
+	// The ref and workflow policies would reject the job, but its token can only publish previews
+	response, err := handler.ExchangeToken(context.Background(), "test-token")
+	require.NoError(t, err)
+
+	claims, err := internalauth.NewJWTManager(cfg).ValidateToken(context.Background(), response.RegistryToken)
+	require.NoError(t, err)
+	assert.Equal(t, "octo-org/octo-repo#42", claims.PullRequest)
+	assert.Equal(t, []internalauth.Permission{{Action: internalauth.PermissionActionPreview, ResourcePattern: "io.github.octo-org/*"}}, claims.Permissions)
+}
+
+func TestGitHubOIDCHandler_ReplayProtection(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey:    "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		TokenReplayStore: service.TokenReplayStoreDatabase,
+	}
+	handler := auth.NewGitHubOIDCHandler(cfg)
+	handler.SetReplayStore(service.NewTestRegistryService(t, database.NewMemory(), cfg))
+	handler.SetValidator(&MockOIDCValidator{
+		validateFunc: func(_ context.Context, token string, _ string) (*auth.GitHubOIDCClaims, error) {
+			return &auth.GitHubOIDCClaims{
+				RegisteredClaims: jwt.RegisteredClaims{
+					Issuer:    "https://token.actions.githubusercontent.com",
+					Subject:   "repo:octo-org/octo-repo:ref:refs/heads/main",
+					ID:        "jti-" + token,
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(5 * time.Minute)),
+					Audience:  jwt.ClaimStrings{"mcp-registry"},
+				},
+				RepositoryOwner: "octo-org",
+			}, nil
+		},
+	})
+
+	_, err := handler.ExchangeToken(context.Background(), "first")
+	require.NoError(t, err)
+	_, err = handler.ExchangeToken(context.Background(), "first")
+	require.ErrorIs(t, err, auth.ErrTokenReplayed)
+	_, err = handler.ExchangeToken(context.Background(), "second")
+	require.NoError(t, err, "tokens with another jti are accepted")
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -54,7 +55,7 @@ func NewDefaultHTTPKeyFetcherWithClient(client *http.Client) *DefaultHTTPKeyFetc
 
 // FetchKey fetches the public key from the well-known HTTP endpoint
 func (f *DefaultHTTPKeyFetcher) FetchKey(ctx context.Context, domain string) (string, error) {
-	url := fmt.Sprintf("https://%s/.well-known/mcp-registry-auth", domain)
+	url := wellKnownAuthURL(domain)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -88,6 +89,11 @@ func (f *DefaultHTTPKeyFetcher) FetchKey(ctx context.Context, domain string) (st
 	return strings.TrimSpace(string(body)), nil
 }
 
+// wellKnownAuthURL returns the URL where a domain publishes its MCP public key for HTTP authentication
+func wellKnownAuthURL(domain string) string {
+	return fmt.Sprintf("https://%s/.well-known/mcp-registry-auth", domain)
+}
+
 // HTTPAuthHandler handles HTTP-based authentication
 type HTTPAuthHandler struct {
 	CoreAuthHandler
@@ -107,10 +113,13 @@ func (h *HTTPAuthHandler) SetFetcher(fetcher HTTPKeyFetcher) {
 	h.fetcher = fetcher
 }
 
-// RegisterHTTPEndpoint registers the HTTP authentication endpoint
-func RegisterHTTPEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
-	handler := NewHTTPAuthHandler(cfg)
+// Name returns the provider name used in MCP_REGISTRY_AUTH_PROVIDERS
+func (h *HTTPAuthHandler) Name() string {
+	return "http"
+}
 
+// RegisterEndpoints registers the HTTP authentication endpoint
+func (h *HTTPAuthHandler) RegisterEndpoints(api huma.API, pathPrefix string) {
 	// HTTP authentication endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "exchange-http-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -120,7 +129,13 @@ func RegisterHTTPEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
 		Description: "Authenticate using HTTP-hosted public key and signed timestamp",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *HTTPTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
+		response, err := h.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
+		if errors.Is(err, auth.ErrNamespacePendingReview) {
+			return nil, huma.Error403Forbidden("Domain ownership verified, but the namespace is awaiting admin approval", err)
+		}
+		if errors.Is(err, auth.ErrIdentityBanned) {
+			return nil, huma.Error403Forbidden("Identity is banned from publishing", err)
+		}
 		if err != nil {
 			return nil, huma.Error401Unauthorized("HTTP authentication failed", err)
 		}
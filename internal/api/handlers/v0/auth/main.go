@@ -3,25 +3,12 @@ package auth
 import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
 )
 
-// RegisterAuthEndpoints registers all authentication endpoints with a custom path prefix
-func RegisterAuthEndpoints(api huma.API, pathPrefix string, cfg *config.Config) {
-	// Register GitHub access token authentication endpoint
-	RegisterGitHubATEndpoint(api, pathPrefix, cfg)
-
-	// Register GitHub OIDC authentication endpoint
-	RegisterGitHubOIDCEndpoint(api, pathPrefix, cfg)
-
-	// Register configurable OIDC authentication endpoints
-	RegisterOIDCEndpoints(api, pathPrefix, cfg)
-
-	// Register DNS-based authentication endpoint
-	RegisterDNSEndpoint(api, pathPrefix, cfg)
-
-	// Register HTTP-based authentication endpoint
-	RegisterHTTPEndpoint(api, pathPrefix, cfg)
-
-	// Register anonymous authentication endpoint
-	RegisterNoneEndpoint(api, pathPrefix, cfg)
+// RegisterAuthEndpoints registers the endpoints of every enabled authentication provider with a custom path prefix
+func RegisterAuthEndpoints(api huma.API, pathPrefix string, cfg *config.Config, registry service.RegistryService) {
+	for _, provider := range EnabledProviders(ProviderDeps{Config: cfg, Reviews: registry, APIKeys: registry, Replays: registry}) {
+		provider.RegisterEndpoints(api, pathPrefix)
+	}
 }
@@ -26,16 +26,15 @@ func NewNoneHandler(cfg *config.Config) *NoneHandler {
 	}
 }
 
-// RegisterNoneEndpoint registers the anonymous authentication endpoint
+// Name returns the provider name used in MCP_REGISTRY_AUTH_PROVIDERS
+func (h *NoneHandler) Name() string {
+	return "none"
+}
+
+// RegisterEndpoints registers the anonymous authentication endpoint
 // WARNING: This endpoint is intended for local development and automated tests only.
 // It should NOT be enabled in production environments as it bypasses normal authentication.
-func RegisterNoneEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
-	if !cfg.EnableAnonymousAuth {
-		return
-	}
-
-	handler := NewNoneHandler(cfg)
-
+func (h *NoneHandler) RegisterEndpoints(api huma.API, pathPrefix string) {
 	// Anonymous token endpoint for development/testing only
 	huma.Register(api, huma.Operation{
 		OperationID: "get-anonymous-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -45,7 +44,7 @@ func RegisterNoneEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
 		Description: "Get a short-lived Registry JWT token for publishing and editing servers in the io.modelcontextprotocol.anonymous/* namespace. This endpoint is intended for local development and automated testing only.",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, _ *struct{}) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.GetAnonymousToken(ctx)
+		response, err := h.GetAnonymousToken(ctx)
 		if err != nil {
 			return nil, huma.Error500InternalServerError("Failed to generate token", err)
 		}
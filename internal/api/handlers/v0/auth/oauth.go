@@ -0,0 +1,472 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// OAuth endpoints for registered clients, served next to browser login
+const (
+	OAuthMetadataPath  = "/.well-known/oauth-authorization-server"
+	OAuthRegisterPath  = "/oauth/register"
+	OAuthAuthorizePath = "/oauth/authorize"
+	OAuthTokenPath     = "/oauth/token"
+	OAuthConsentPath   = auth.OAuthConsentPath
+)
+
+// consentCookieName holds the authorization a person is asked to approve. SameSite=Strict keeps other sites
+// from posting an approval with it.
+const consentCookieName = "__Host-mcp-registry-consent"
+
+// maxOAuthRequestBytes limits the size of client registration requests and token requests
+const maxOAuthRequestBytes = 64 << 10
+
+// OAuthClientStore registers OAuth clients, looks them up, and remembers the authorization codes they redeemed.
+// The registry service implements it.
+type OAuthClientStore interface {
+	RegisterOAuthClient(ctx context.Context, name string, redirectURIs []string) (*database.OAuthClient, error)
+	GetOAuthClient(ctx context.Context, clientID string) (*database.OAuthClient, error)
+	// ClaimAuthorizationCode fails with an error wrapping database.ErrAlreadyExists when the code was redeemed
+	ClaimAuthorizationCode(ctx context.Context, codeID string, expiresAt time.Time) error
+}
+
+// pendingAuthorization is the authorization request of a registered client, kept with the login in progress
+type pendingAuthorization struct {
+	ClientID      string `json:"clientId"`
+	RedirectURI   string `json:"redirectUri"`
+	State         string `json:"state,omitempty"`
+	CodeChallenge string `json:"codeChallenge"`
+}
+
+// OAuthMetadata is the registry's OAuth authorization server metadata (RFC 8414)
+type OAuthMetadata struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	RegistrationEndpoint              string   `json:"registration_endpoint"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+// OAuthClientMetadata is the metadata of a client registering itself, and of a registered client (RFC 7591)
+type OAuthClientMetadata struct {
+	ClientID                string   `json:"client_id,omitempty"`
+	ClientIDIssuedAt        int64    `json:"client_id_issued_at,omitempty"`
+	ClientName              string   `json:"client_name,omitempty"`
+	RedirectURIs            []string `json:"redirect_uris"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method,omitempty"`
+	GrantTypes              []string `json:"grant_types,omitempty"`
+	ResponseTypes           []string `json:"response_types,omitempty"`
+}
+
+// OAuthTokenResponse is the response of the token endpoint: a registry token for the person who logged in
+type OAuthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// oauthError is an OAuth error response (RFC 6749 section 5.2)
+type oauthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// SetOAuthClientStore lets applications register as OAuth clients with clients, and log people in through the
+// registry
+func (h *BrowserLoginHandler) SetOAuthClientStore(clients OAuthClientStore) {
+	h.clients = clients
+}
+
+// registerOAuthEndpoints registers the authorization server metadata, client registration, authorization and
+// token endpoints on mux
+func (h *BrowserLoginHandler) registerOAuthEndpoints(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+OAuthMetadataPath, h.oauthMetadata)
+	mux.HandleFunc("POST "+OAuthRegisterPath, h.registerClient)
+	mux.HandleFunc("GET "+OAuthAuthorizePath, h.authorize)
+	mux.HandleFunc("POST "+OAuthTokenPath, h.token)
+	mux.HandleFunc("POST "+OAuthConsentPath, h.consent)
+}
+
+// oauthMetadata describes the registry's authorization server, so clients can find its endpoints
+func (h *BrowserLoginHandler) oauthMetadata(w http.ResponseWriter, _ *http.Request) {
+	baseURL := strings.TrimSuffix(h.config.PublicURL, "/")
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	_ = json.NewEncoder(w).Encode(OAuthMetadata{
+		Issuer:                            baseURL,
+		AuthorizationEndpoint:             baseURL + OAuthAuthorizePath,
+		TokenEndpoint:                     baseURL + OAuthTokenPath,
+		RegistrationEndpoint:              baseURL + OAuthRegisterPath,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		TokenEndpointAuthMethodsSupported: []string{"none"},
+	})
+}
+
+// registerClient registers a public client with its redirect URIs and returns its client ID (RFC 7591)
+func (h *BrowserLoginHandler) registerClient(w http.ResponseWriter, r *http.Request) {
+	var metadata OAuthClientMetadata
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxOAuthRequestBytes)).Decode(&metadata); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_client_metadata", "The request body must be a JSON object of client metadata")
+		return
+	}
+
+	// Registered clients are public and only use the authorization code flow
+	switch {
+	case metadata.TokenEndpointAuthMethod != "" && metadata.TokenEndpointAuthMethod != "none":
+		writeOAuthError(w, http.StatusBadRequest, "invalid_client_metadata", "token_endpoint_auth_method must be none: clients are public and use PKCE")
+		return
+	case slices.ContainsFunc(metadata.GrantTypes, func(grantType string) bool { return grantType != "authorization_code" }):
+		writeOAuthError(w, http.StatusBadRequest, "invalid_client_metadata", "grant_types must be authorization_code")
+		return
+	case slices.ContainsFunc(metadata.ResponseTypes, func(responseType string) bool { return responseType != "code" }):
+		writeOAuthError(w, http.StatusBadRequest, "invalid_client_metadata", "response_types must be code")
+		return
+	}
+	for _, redirectURI := range metadata.RedirectURIs {
+		if err := service.ValidateOAuthRedirectURI(redirectURI); err != nil {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_redirect_uri", err.Error())
+			return
+		}
+	}
+
+	client, err := h.clients.RegisterOAuthClient(r.Context(), metadata.ClientName, metadata.RedirectURIs)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInput) {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_client_metadata", err.Error())
+			return
+		}
+		log.Printf("Failed to register OAuth client: %v", err)
+		writeOAuthError(w, http.StatusInternalServerError, "server_error", "Failed to register the client")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(OAuthClientMetadata{
+		ClientID:                client.ClientID,
+		ClientIDIssuedAt:        client.CreatedAt.Unix(),
+		ClientName:              client.ClientName,
+		RedirectURIs:            client.RedirectURIs,
+		TokenEndpointAuthMethod: "none",
+		GrantTypes:              []string{"authorization_code"},
+		ResponseTypes:           []string{"code"},
+	})
+}
+
+// authorize starts a login for a registered client: the person logs in with a provider and approves the client on
+// the registry's consent page, and the client receives an authorization code at its redirect URI. Requests with an unknown client or redirect URI are shown an error
+// rather than redirected, so the endpoint cannot send people to arbitrary sites (RFC 6749 section 4.1.2.1).
+func (h *BrowserLoginHandler) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	client, err := h.clients.GetOAuthClient(r.Context(), query.Get("client_id"))
+	if err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_client", "The client is not registered")
+		return
+	}
+	redirectURI := query.Get("redirect_uri")
+	switch {
+	case redirectURI == "" && len(client.RedirectURIs) == 1:
+		redirectURI = client.RedirectURIs[0]
+	case !slices.Contains(client.RedirectURIs, redirectURI):
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "redirect_uri is not registered for the client")
+		return
+	}
+
+	authorization := &pendingAuthorization{
+		ClientID:      client.ClientID,
+		RedirectURI:   redirectURI,
+		State:         query.Get("state"),
+		CodeChallenge: query.Get("code_challenge"),
+	}
+	name := query.Get("provider")
+	if name == "" && len(h.providers) == 1 {
+		name = h.Providers()[0]
+	}
+	provider, ok := h.providers[name]
+	switch {
+	case query.Get("response_type") != "code":
+		redirectAuthorizationError(w, r, authorization, "unsupported_response_type", "response_type must be code")
+	case authorization.CodeChallenge == "" || query.Get("code_challenge_method") != "S256":
+		redirectAuthorizationError(w, r, authorization, "invalid_request", "PKCE is required: send code_challenge with code_challenge_method S256")
+	case !ok:
+		redirectAuthorizationError(w, r, authorization, "invalid_request", "provider must be one of "+strings.Join(h.Providers(), ", "))
+	default:
+		h.redirectToProvider(w, r, provider, pendingLogin{Provider: name, ReturnTo: "/", Client: authorization})
+	}
+}
+
+// consentPage asks a person to approve a registered OAuth client. Clients choose their own names, so the page
+// also shows where the token will be sent.
+var consentPage = template.Must(template.New("consent").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Authorize {{.ClientName}} - MCP Registry</title>
+<style>body{font-family:system-ui,sans-serif;max-width:40rem;margin:3rem auto;padding:0 1rem;line-height:1.5}button{font-size:1rem;padding:.5rem 1rem;margin-right:.5rem}</style>
+</head>
+<body>
+<h1>Authorize {{.ClientName}}</h1>
+<p><strong>{{.ClientName}}</strong> wants to act for you (<code>{{.Subject}}</code>) in the MCP Registry. Approving sends a registry token to <strong>{{.RedirectHost}}</strong>.</p>
+<p>The token will allow:</p>
+<ul>
+{{range .Permissions}}<li>{{.Action}} <code>{{.ResourcePattern}}</code></li>
+{{else}}<li>Nothing beyond what anyone can do</li>
+{{end}}</ul>
+<p>It never includes registry admin permissions or ownership of organizations, even if you have them.</p>
+<p>The application chose its own name. Only approve if you started this login from it and recognize <strong>{{.RedirectHost}}</strong>.</p>
+<form method="post" action="{{.Action}}">
+<input type="hidden" name="consent" value="{{.ConsentID}}">
+<button type="submit" name="decision" value="approve">Approve</button>
+<button type="submit" name="decision" value="deny">Deny</button>
+</form>
+</body>
+</html>
+`))
+
+// requestConsent asks the person who logged in for a registered client to approve it, showing the client's name,
+// where its token will be sent and what the token will allow. The authorization waits in a cookie, and the
+// approval must echo its ID, so other sites can neither approve it nor read the page to learn the ID.
+func (h *BrowserLoginHandler) requestConsent(w http.ResponseWriter, r *http.Request, authorization *pendingAuthorization, claims *auth.JWTClaims) {
+	client, err := h.clients.GetOAuthClient(r.Context(), authorization.ClientID)
+	if err != nil {
+		writeBrowserError(w, http.StatusBadRequest, apiv0.ErrorCodeBadRequest, "The application is no longer registered")
+		return
+	}
+
+	clientClaims := auth.ClientClaims(*claims, client.ClientID)
+	consent, consentID, err := h.jwtManager.GenerateConsent(auth.ConsentClaims{
+		AuthorizationCodeClaims: auth.AuthorizationCodeClaims{
+			JWTClaims:     clientClaims,
+			ClientID:      authorization.ClientID,
+			RedirectURI:   authorization.RedirectURI,
+			CodeChallenge: authorization.CodeChallenge,
+		},
+		State: authorization.State,
+	})
+	if err != nil {
+		log.Printf("Failed to request consent: %v", err)
+		writeBrowserError(w, http.StatusInternalServerError, apiv0.ErrorCodeInternal, "Failed to complete login")
+		return
+	}
+
+	clientName := client.ClientName
+	if clientName == "" {
+		clientName = "Unnamed application"
+	}
+	var page strings.Builder
+	err = consentPage.Execute(&page, struct {
+		ClientName   string
+		Subject      string
+		RedirectHost string
+		Permissions  []auth.Permission
+		Action       string
+		ConsentID    string
+	}{clientName, string(clientClaims.AuthMethod) + ":" + clientClaims.AuthMethodSubject, redirectHost(authorization.RedirectURI), clientClaims.Permissions, OAuthConsentPath, consentID})
+	if err != nil {
+		log.Printf("Failed to render the consent page: %v", err)
+		writeBrowserError(w, http.StatusInternalServerError, apiv0.ErrorCodeInternal, "Failed to complete login")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     consentCookieName,
+		Value:    consent,
+		Path:     "/",
+		MaxAge:   int(auth.ConsentLifetime.Seconds()),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+	_, _ = w.Write([]byte(page.String()))
+}
+
+// consent completes an authorization once the person approves or denies it on the consent page. Approved clients
+// receive an authorization code at their redirect URI; denied ones an access_denied error.
+func (h *BrowserLoginHandler) consent(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(consentCookieName)
+	// An authorization can only be answered once
+	http.SetCookie(w, &http.Cookie{Name: consentCookieName, Path: "/", MaxAge: -1, Secure: true, HttpOnly: true, SameSite: http.SameSiteStrictMode})
+	var consent *auth.ConsentClaims
+	if err == nil {
+		consent, err = h.jwtManager.ValidateConsent(cookie.Value)
+	}
+	if err != nil {
+		writeBrowserError(w, http.StatusBadRequest, apiv0.ErrorCodeBadRequest, "No authorization is awaiting approval in this browser, or it has expired; start again")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxOAuthRequestBytes)
+	if err := r.ParseForm(); err != nil || subtle.ConstantTimeCompare([]byte(consent.ID), []byte(r.PostForm.Get("consent"))) != 1 {
+		writeBrowserError(w, http.StatusBadRequest, apiv0.ErrorCodeBadRequest, "The approval does not match the authorization shown in this browser")
+		return
+	}
+
+	authorization := &pendingAuthorization{
+		ClientID:      consent.ClientID,
+		RedirectURI:   consent.RedirectURI,
+		State:         consent.State,
+		CodeChallenge: consent.CodeChallenge,
+	}
+	if r.PostForm.Get("decision") != "approve" {
+		redirectAuthorizationError(w, r, authorization, "access_denied", "The request was denied")
+		return
+	}
+	// Clients removed by an admin while the person was deciding are not sent a code
+	if _, err := h.clients.GetOAuthClient(r.Context(), consent.ClientID); err != nil {
+		writeBrowserError(w, http.StatusBadRequest, apiv0.ErrorCodeBadRequest, "The application is no longer registered")
+		return
+	}
+	h.redirectWithCode(w, r, authorization, &consent.JWTClaims)
+}
+
+// redirectHost describes where a redirect URI sends people: its host, or its scheme for private-use schemes
+// that open a native app
+func redirectHost(redirectURI string) string {
+	parsed, err := url.Parse(redirectURI)
+	switch {
+	case err != nil:
+		return redirectURI
+	case parsed.Host != "":
+		return parsed.Host
+	}
+	return parsed.Scheme + ":"
+}
+
+// redirectWithCode completes an approved login for a registered client, sending it an authorization code for
+// claims, which ClientClaims has reduced
+func (h *BrowserLoginHandler) redirectWithCode(w http.ResponseWriter, r *http.Request, authorization *pendingAuthorization, claims *auth.JWTClaims) {
+	code, err := h.jwtManager.GenerateAuthorizationCode(auth.AuthorizationCodeClaims{
+		JWTClaims:     *claims,
+		ClientID:      authorization.ClientID,
+		RedirectURI:   authorization.RedirectURI,
+		CodeChallenge: authorization.CodeChallenge,
+	})
+	if err != nil {
+		log.Printf("Failed to issue an authorization code: %v", err)
+		redirectAuthorizationError(w, r, authorization, "server_error", "Failed to issue an authorization code")
+		return
+	}
+	redirectAuthorization(w, r, authorization, url.Values{"code": {code}})
+}
+
+// token redeems an authorization code for a registry token lasting as long as a browser session. The client
+// must send the redirect URI the code was issued for and the PKCE verifier of its challenge. The token only
+// carries the namespace-scoped permissions ClientClaims leaves the person who approved the client.
+func (h *BrowserLoginHandler) token(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxOAuthRequestBytes)
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "The request body must be form encoded")
+		return
+	}
+	if grantType := r.PostForm.Get("grant_type"); grantType != "authorization_code" {
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "grant_type must be authorization_code")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	claims, err := h.jwtManager.ValidateAuthorizationCode(code)
+	switch {
+	case err != nil:
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "The authorization code is invalid or has expired")
+		return
+	case subtle.ConstantTimeCompare([]byte(claims.ClientID), []byte(r.PostForm.Get("client_id"))) != 1:
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "The authorization code was issued to another client")
+		return
+	case claims.RedirectURI != r.PostForm.Get("redirect_uri"):
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "redirect_uri does not match the authorization request")
+		return
+	case subtle.ConstantTimeCompare([]byte(oauth2.S256ChallengeFromVerifier(r.PostForm.Get("code_verifier"))), []byte(claims.CodeChallenge)) != 1:
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "code_verifier does not match the code challenge")
+		return
+	}
+
+	// Clients removed by an admin can no longer redeem the codes they were sent
+	if _, err := h.clients.GetOAuthClient(r.Context(), claims.ClientID); err != nil {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "The client is not registered")
+		return
+	}
+	if err := h.clients.ClaimAuthorizationCode(r.Context(), claims.ID, claims.ExpiresAt.Time); err != nil {
+		if errors.Is(err, database.ErrAlreadyExists) {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "The authorization code was already redeemed")
+			return
+		}
+		log.Printf("Failed to redeem an authorization code: %v", err)
+		writeOAuthError(w, http.StatusInternalServerError, "server_error", "Failed to redeem the authorization code")
+		return
+	}
+
+	// Codes already carry reduced claims; reducing them again keeps admin permissions out of client tokens even
+	// if a code was issued some other way
+	registryClaims := auth.ClientClaims(claims.JWTClaims, claims.ClientID)
+	token, err := h.jwtManager.GenerateSessionToken(r.Context(), registryClaims, h.config.BrowserSessionDuration)
+	if err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(OAuthTokenResponse{
+		AccessToken: token.RegistryToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(time.Unix(int64(token.ExpiresAt), 0)).Seconds()),
+	})
+}
+
+// redirectAuthorizationError sends an OAuth error to a registered client's redirect URI
+func redirectAuthorizationError(w http.ResponseWriter, r *http.Request, authorization *pendingAuthorization, code, description string) {
+	redirectAuthorization(w, r, authorization, url.Values{"error": {code}, "error_description": {description}})
+}
+
+// redirectAuthorization redirects to a registered client's redirect URI with params and the request's state
+func redirectAuthorization(w http.ResponseWriter, r *http.Request, authorization *pendingAuthorization, params url.Values) {
+	target, err := url.Parse(authorization.RedirectURI)
+	if err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "redirect_uri is not a valid URI")
+		return
+	}
+	query := target.Query()
+	for name, values := range params {
+		query[name] = values
+	}
+	if authorization.State != "" {
+		query.Set("state", authorization.State)
+	}
+	target.RawQuery = query.Encode()
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// writeOAuthError writes an OAuth error response (RFC 6749 section 5.2)
+func writeOAuthError(w http.ResponseWriter, status int, code, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(oauthError{Error: code, ErrorDescription: description})
+}
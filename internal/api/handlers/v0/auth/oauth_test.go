@@ -0,0 +1,279 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// oauthServer returns browser login with client registration against a fake GitHub, the registry behind it and
+// its config
+func oauthServer(t *testing.T, challenge *string) (*http.ServeMux, service.RegistryService, *config.Config) {
+	t.Helper()
+	cfg := browserLoginConfig(t)
+	cfg.OAuthClientRegistrationEnabled = true
+	server := fakeGitHub(t, challenge)
+	t.Cleanup(server.Close)
+
+	github := v0auth.NewGitHubHandler(cfg)
+	github.SetBaseURL(server.URL)
+	github.SetOAuthBaseURL(server.URL)
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	handler := v0auth.NewBrowserLoginHandler(cfg, github, nil)
+	handler.SetOAuthClientStore(registry)
+	mux := http.NewServeMux()
+	handler.RegisterEndpoints(mux)
+	return mux, registry, cfg
+}
+
+// postOAuth sends a form-encoded or JSON request to the OAuth endpoints
+func postOAuth(mux *http.ServeMux, target, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+// consentID matches the ID of the authorization a consent page asks about
+var consentID = regexp.MustCompile(`name="consent" value="([^"]+)"`)
+
+// postConsent answers a consent page with decision
+func postConsent(mux *http.ServeMux, cookie *http.Cookie, id, decision string) *httptest.ResponseRecorder {
+	form := url.Values{"consent": {id}, "decision": {decision}}
+	req := httptest.NewRequest(http.MethodPost, v0auth.OAuthConsentPath, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(cookie)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestOAuth_Metadata(t *testing.T) {
+	mux, _, _ := oauthServer(t, new(string))
+
+	w := serve(mux, http.MethodGet, v0auth.OAuthMetadataPath)
+	require.Equal(t, http.StatusOK, w.Code)
+	var metadata v0auth.OAuthMetadata
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&metadata))
+	assert.Equal(t, "https://registry.example.com", metadata.Issuer)
+	assert.Equal(t, "https://registry.example.com/oauth/register", metadata.RegistrationEndpoint)
+	assert.Equal(t, "https://registry.example.com/oauth/authorize", metadata.AuthorizationEndpoint)
+	assert.Equal(t, "https://registry.example.com/oauth/token", metadata.TokenEndpoint)
+	assert.Equal(t, []string{"S256"}, metadata.CodeChallengeMethodsSupported)
+}
+
+func TestOAuth_RegisterClient(t *testing.T) {
+	mux, registry, _ := oauthServer(t, new(string))
+
+	tests := []struct {
+		name      string
+		body      string
+		wantError string
+	}{
+		{"loopback and private-use scheme", `{"client_name":"Example MCP Client","redirect_uris":["http://127.0.0.1:8123/callback","com.example.client:/callback"]}`, ""},
+		{"https", `{"redirect_uris":["https://client.example.com/callback"],"token_endpoint_auth_method":"none"}`, ""},
+		{"no redirect URIs", `{"client_name":"No redirects"}`, "invalid_client_metadata"},
+		{"http on another host", `{"redirect_uris":["http://client.example.com/callback"]}`, "invalid_redirect_uri"},
+		{"fragment", `{"redirect_uris":["https://client.example.com/callback#done"]}`, "invalid_redirect_uri"},
+		{"scheme without a domain", `{"redirect_uris":["myapp:/callback"]}`, "invalid_redirect_uri"},
+		{"client secret", `{"redirect_uris":["https://client.example.com/callback"],"token_endpoint_auth_method":"client_secret_basic"}`, "invalid_client_metadata"},
+		{"client credentials", `{"redirect_uris":["https://client.example.com/callback"],"grant_types":["client_credentials"]}`, "invalid_client_metadata"},
+		{"not JSON", `redirect_uris=https://client.example.com/callback`, "invalid_client_metadata"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postOAuth(mux, v0auth.OAuthRegisterPath, "application/json", tt.body)
+			if tt.wantError != "" {
+				require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+				assert.Equal(t, tt.wantError, body["error"])
+				return
+			}
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+			var client v0auth.OAuthClientMetadata
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&client))
+			assert.NotEmpty(t, client.ClientID)
+			assert.NotZero(t, client.ClientIDIssuedAt)
+			assert.Equal(t, "none", client.TokenEndpointAuthMethod)
+
+			stored, err := registry.GetOAuthClient(context.Background(), client.ClientID)
+			require.NoError(t, err)
+			assert.Equal(t, client.RedirectURIs, stored.RedirectURIs)
+		})
+	}
+}
+
+func TestOAuth_AuthorizationCodeFlow(t *testing.T) {
+	var githubChallenge string
+	mux, registry, cfg := oauthServer(t, &githubChallenge)
+	client, err := registry.RegisterOAuthClient(context.Background(), "Example MCP Client", []string{"http://127.0.0.1:8123/callback"})
+	require.NoError(t, err)
+
+	verifier := oauth2.GenerateVerifier()
+	authorize := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {client.ClientID},
+		"state":                 {"client-state"},
+		"code_challenge":        {oauth2.S256ChallengeFromVerifier(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	// Unknown clients and redirect URIs are shown an error instead of being redirected
+	w := serve(mux, http.MethodGet, v0auth.OAuthAuthorizePath+"?client_id=unknown&response_type=code")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(mux, http.MethodGet, v0auth.OAuthAuthorizePath+"?"+authorize.Encode()+"&redirect_uri="+url.QueryEscape("https://evil.example.com/"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Requests without PKCE are sent back to the client
+	w = serve(mux, http.MethodGet, v0auth.OAuthAuthorizePath+"?client_id="+client.ClientID+"&response_type=code&state=client-state")
+	require.Equal(t, http.StatusFound, w.Code)
+	redirect, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:8123", redirect.Host)
+	assert.Equal(t, "invalid_request", redirect.Query().Get("error"))
+	assert.Equal(t, "client-state", redirect.Query().Get("state"))
+
+	// The only provider is used, and the registry's own PKCE protects the login at GitHub
+	w = serve(mux, http.MethodGet, v0auth.OAuthAuthorizePath+"?"+authorize.Encode())
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	redirect, err = url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "github-client", redirect.Query().Get("client_id"))
+	githubChallenge = redirect.Query().Get("code_challenge")
+	loginCookie := responseCookie(t, w, "__Host-mcp-registry-login")
+
+	// After logging in, the person is asked to approve the client, named with where its token goes
+	w = serve(mux, http.MethodGet, "/auth/browser/callback/github?code=valid-code&state="+url.QueryEscape(redirect.Query().Get("state")), loginCookie)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+	assert.Contains(t, w.Body.String(), "Example MCP Client")
+	assert.Contains(t, w.Body.String(), "127.0.0.1:8123")
+	for _, cookie := range w.Result().Cookies() {
+		assert.NotEqual(t, auth.SessionCookieName, cookie.Name, "client logins do not start a browser session")
+	}
+	consentCookie := responseCookie(t, w, "__Host-mcp-registry-consent")
+	assert.Equal(t, http.SameSiteStrictMode, consentCookie.SameSite)
+	match := consentID.FindStringSubmatch(w.Body.String())
+	require.Len(t, match, 2)
+
+	// Approvals must come from the page, and must be approvals
+	w = postConsent(mux, consentCookie, "another-id", "approve")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = postOAuth(mux, v0auth.OAuthConsentPath, "application/x-www-form-urlencoded", url.Values{"consent": {match[1]}, "decision": {"approve"}}.Encode())
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = postConsent(mux, consentCookie, match[1], "deny")
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	redirect, err = url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "access_denied", redirect.Query().Get("error"))
+	assert.Empty(t, redirect.Query().Get("code"))
+
+	w = postConsent(mux, consentCookie, match[1], "approve")
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	redirect, err = url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:8123/callback", redirect.Scheme+"://"+redirect.Host+redirect.Path)
+	assert.Equal(t, "client-state", redirect.Query().Get("state"))
+	code := redirect.Query().Get("code")
+	require.NotEmpty(t, code)
+
+	redeem := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"client_id":     {client.ClientID},
+		"redirect_uri":  {"http://127.0.0.1:8123/callback"},
+		"code_verifier": {oauth2.GenerateVerifier()},
+	}
+	w = postOAuth(mux, v0auth.OAuthTokenPath, "application/x-www-form-urlencoded", redeem.Encode())
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_grant")
+
+	redeem.Set("code_verifier", verifier)
+	w = postOAuth(mux, v0auth.OAuthTokenPath, "application/x-www-form-urlencoded", redeem.Encode())
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	var token v0auth.OAuthTokenResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&token))
+	assert.Equal(t, "Bearer", token.TokenType)
+	assert.Positive(t, token.ExpiresIn)
+
+	claims, err := auth.NewJWTManager(cfg).ValidateToken(context.Background(), token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, auth.MethodGitHubAT, claims.AuthMethod)
+	assert.Equal(t, "testuser", claims.AuthMethodSubject)
+	assert.Equal(t, client.ClientID, claims.OAuthClient)
+	assert.Empty(t, claims.OwnerOf)
+	assert.False(t, auth.NewJWTManager(cfg).IsAdmin(claims.Permissions))
+
+	// Codes are redeemed once
+	w = postOAuth(mux, v0auth.OAuthTokenPath, "application/x-www-form-urlencoded", redeem.Encode())
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "already redeemed")
+}
+
+func TestOAuth_AuthorizationCodeIsNotAToken(t *testing.T) {
+	cfg := browserLoginConfig(t)
+	jwtManager := auth.NewJWTManager(cfg)
+
+	code, err := jwtManager.GenerateAuthorizationCode(auth.AuthorizationCodeClaims{
+		JWTClaims:     auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "testuser"},
+		ClientID:      "client",
+		RedirectURI:   "http://127.0.0.1/callback",
+		CodeChallenge: "challenge",
+	})
+	require.NoError(t, err)
+	_, err = jwtManager.ValidateToken(context.Background(), code)
+	assert.Error(t, err)
+
+	claims, err := jwtManager.ValidateAuthorizationCode(code)
+	require.NoError(t, err)
+	assert.Equal(t, "testuser", claims.AuthMethodSubject)
+	assert.Equal(t, "client", claims.ClientID)
+
+	session, err := jwtManager.GenerateSessionToken(context.Background(), auth.JWTClaims{AuthMethod: auth.MethodGitHubAT}, auth.AuthorizationCodeLifetime)
+	require.NoError(t, err)
+	_, err = jwtManager.ValidateAuthorizationCode(session.RegistryToken)
+	assert.Error(t, err)
+}
+
+func TestClientClaims(t *testing.T) {
+	claims := auth.ClientClaims(auth.JWTClaims{
+		AuthMethod:        auth.MethodOIDC,
+		AuthMethodSubject: "admin@example.com",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.admin/*"},
+			{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.*"},
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*/servers"},
+		},
+		OwnerOf: []string{"io.github.admin"},
+	}, "client")
+
+	assert.Equal(t, auth.MethodOIDC, claims.AuthMethod)
+	assert.Equal(t, "admin@example.com", claims.AuthMethodSubject)
+	assert.Equal(t, "client", claims.OAuthClient)
+	assert.Equal(t, []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.admin/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.*"},
+	}, claims.Permissions)
+	assert.Empty(t, claims.OwnerOf)
+}
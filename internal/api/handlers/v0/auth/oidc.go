@@ -2,16 +2,18 @@ package auth
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/danielgtaylor/huma/v2"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"golang.org/x/oauth2"
 )
 
 // OIDCTokenExchangeInput represents the input for OIDC token exchange
@@ -26,6 +28,8 @@ type OIDCClaims struct {
 	Subject     string         `json:"sub"`
 	Issuer      string         `json:"iss"`
 	Audience    []string       `json:"aud"`
+	TokenID     string         `json:"jti"`
+	Expiry      time.Time      `json:"-"`
 	ExtraClaims map[string]any `json:"-"`
 }
 
@@ -80,8 +84,10 @@ func (v *StandardOIDCValidator) ValidateToken(ctx context.Context, tokenString s
 	oidcClaims := &OIDCClaims{
 		Subject:     idToken.Subject,
 		Issuer:      idToken.Issuer,
+		Expiry:      idToken.Expiry,
 		ExtraClaims: make(map[string]any),
 	}
+	oidcClaims.TokenID, _ = allClaims["jti"].(string)
 
 	// Extract audience
 	if aud, ok := allClaims["aud"]; ok {
@@ -115,7 +121,13 @@ func (v *StandardOIDCValidator) ValidateToken(ctx context.Context, tokenString s
 type OIDCHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
-	validator  GenericOIDCValidator
+	// validator validates ID tokens from MCP_REGISTRY_OIDC_ISSUER; nil when only the issuers file is used
+	validator GenericOIDCValidator
+	// endpoint holds the issuer's authorization and token endpoints, used by browser login
+	endpoint oauth2.Endpoint
+	// issuers are the additional issuers of MCP_REGISTRY_OIDC_ISSUERS_FILE
+	issuers *trustedIssuers
+	replays TokenReplayStore
 }
 
 // NewOIDCHandler creates a new OIDC handler
@@ -123,20 +135,24 @@ func NewOIDCHandler(cfg *config.Config) *OIDCHandler {
 	if !cfg.OIDCEnabled {
 		panic("OIDC is not enabled - should not create OIDC handler")
 	}
-	if cfg.OIDCIssuer == "" {
-		panic("OIDC issuer is required when OIDC is enabled")
+	if cfg.OIDCIssuer == "" && cfg.OIDCIssuersFile == "" {
+		panic("OIDC issuer or issuers file is required when OIDC is enabled")
 	}
 
-	validator, err := NewStandardOIDCValidator(cfg.OIDCIssuer, cfg.OIDCClientID)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to initialize OIDC validator: %v", err))
-	}
-
-	return &OIDCHandler{
+	h := &OIDCHandler{
 		config:     cfg,
 		jwtManager: auth.NewJWTManager(cfg),
-		validator:  validator,
+		issuers:    newTrustedIssuers(cfg.OIDCIssuersFile),
 	}
+	if cfg.OIDCIssuer != "" {
+		validator, err := NewStandardOIDCValidator(cfg.OIDCIssuer, cfg.OIDCClientID)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to initialize OIDC validator: %v", err))
+		}
+		h.validator = validator
+		h.endpoint = validator.provider.Endpoint()
+	}
+	return h
 }
 
 // SetValidator sets a custom OIDC validator (used for testing)
@@ -144,14 +160,23 @@ func (h *OIDCHandler) SetValidator(validator GenericOIDCValidator) {
 	h.validator = validator
 }
 
-// RegisterOIDCEndpoints registers all OIDC authentication endpoints
-func RegisterOIDCEndpoints(api huma.API, pathPrefix string, cfg *config.Config) {
-	if !cfg.OIDCEnabled {
-		return // Skip registration if OIDC is not enabled
-	}
+// SetValidatorFactory sets how validators for the issuers of the issuers file are created (used for testing)
+func (h *OIDCHandler) SetValidatorFactory(newValidator func(issuer, clientID string) (GenericOIDCValidator, error)) {
+	h.issuers.newValidator = newValidator
+}
+
+// SetReplayStore sets the store exchanged tokens are remembered in, so replays are rejected; nil accepts them
+func (h *OIDCHandler) SetReplayStore(store TokenReplayStore) {
+	h.replays = store
+}
 
-	handler := NewOIDCHandler(cfg)
+// Name returns the provider name used in MCP_REGISTRY_AUTH_PROVIDERS
+func (h *OIDCHandler) Name() string {
+	return "oidc"
+}
 
+// RegisterEndpoints registers the OIDC authentication endpoint
+func (h *OIDCHandler) RegisterEndpoints(api huma.API, pathPrefix string) {
 	// Direct token exchange endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "exchange-oidc-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -161,7 +186,10 @@ func RegisterOIDCEndpoints(api huma.API, pathPrefix string, cfg *config.Config)
 		Description: "Exchange an OIDC ID token from any configured provider for a short-lived Registry JWT token",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *OIDCTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.OIDCToken)
+		response, err := h.ExchangeToken(ctx, input.Body.OIDCToken)
+		if errors.Is(err, auth.ErrIdentityBanned) {
+			return nil, huma.Error403Forbidden("Identity is banned from publishing", err)
+		}
 		if err != nil {
 			return nil, huma.Error401Unauthorized("Token exchange failed", err)
 		}
@@ -172,31 +200,35 @@ func RegisterOIDCEndpoints(api huma.API, pathPrefix string, cfg *config.Config)
 	})
 }
 
-// ExchangeToken exchanges an OIDC ID token for a Registry JWT token
+// ExchangeToken exchanges an OIDC ID token for a Registry JWT token. Tokens from an issuer listed in the
+// issuers file are validated with that issuer's settings, and any other token with MCP_REGISTRY_OIDC_ISSUER's.
 func (h *OIDCHandler) ExchangeToken(ctx context.Context, oidcToken string) (*auth.TokenResponse, error) {
-	// Validate OIDC token
-	claims, err := h.validator.ValidateToken(ctx, oidcToken)
+	claims, jwtClaims, err := h.issuers.validateTrusted(ctx, oidcToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate OIDC token: %w", err)
+		return nil, err
 	}
+	if jwtClaims == nil {
+		if h.validator == nil {
+			return nil, errUntrustedIssuer
+		}
 
-	// Validate extra claims if configured
-	if err := h.validateExtraClaims(claims); err != nil {
-		return nil, fmt.Errorf("extra claims validation failed: %w", err)
-	}
+		// Validate OIDC token
+		claims, err = h.validator.ValidateToken(ctx, oidcToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate OIDC token: %w", err)
+		}
 
-	// Build permissions based on claims and configuration
-	permissions := h.buildPermissions(claims)
+		if jwtClaims, err = h.registryClaims(claims); err != nil {
+			return nil, err
+		}
+	}
 
-	// Create JWT claims
-	jwtClaims := auth.JWTClaims{
-		AuthMethod:        auth.MethodOIDC,
-		AuthMethodSubject: claims.Subject,
-		Permissions:       permissions,
+	if err := claimToken(ctx, h.replays, claims.Issuer, claims.TokenID, oidcToken, claims.Expiry); err != nil {
+		return nil, err
 	}
 
 	// Generate Registry JWT token
-	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, jwtClaims)
+	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, *jwtClaims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
 	}
@@ -204,19 +236,30 @@ func (h *OIDCHandler) ExchangeToken(ctx context.Context, oidcToken string) (*aut
 	return tokenResponse, nil
 }
 
-// validateExtraClaims validates additional claims based on configuration
-func (h *OIDCHandler) validateExtraClaims(claims *OIDCClaims) error {
-	if h.config.OIDCExtraClaims == "" {
-		return nil // No extra validation required
+// registryClaims checks the claims of a validated OIDC token against the configuration and returns the
+// Registry JWT claims they grant
+func (h *OIDCHandler) registryClaims(claims *OIDCClaims) (*auth.JWTClaims, error) {
+	// Validate extra claims if configured
+	if err := h.validateExtraClaims(claims); err != nil {
+		return nil, fmt.Errorf("extra claims validation failed: %w", err)
 	}
 
-	// Parse extra claims configuration
-	var extraClaimsRules []map[string]any
-	if err := json.Unmarshal([]byte(h.config.OIDCExtraClaims), &extraClaimsRules); err != nil {
-		return fmt.Errorf("invalid extra claims configuration: %w", err)
-	}
+	// Build permissions based on claims and configuration
+	return &auth.JWTClaims{
+		AuthMethod:        auth.MethodOIDC,
+		AuthMethodSubject: claims.Subject,
+		Permissions:       h.buildPermissions(claims),
+	}, nil
+}
+
+// validateExtraClaims validates additional claims based on configuration
+func (h *OIDCHandler) validateExtraClaims(claims *OIDCClaims) error {
+	// Config.Validate has checked the claim sets when the configuration was loaded
+	return matchExtraClaims(h.config.OIDCExtraClaims, claims)
+}
 
-	// Validate each rule
+// matchExtraClaims checks that a token has every claim the rules require, with the required value
+func matchExtraClaims(extraClaimsRules []map[string]any, claims *OIDCClaims) error {
 	for _, rule := range extraClaimsRules {
 		for key, expectedValue := range rule {
 			actualValue, exists := claims.ExtraClaims[key]
@@ -235,32 +278,24 @@ func (h *OIDCHandler) validateExtraClaims(claims *OIDCClaims) error {
 
 // buildPermissions builds permissions based on OIDC claims and configuration
 func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
-	var permissions []auth.Permission
-
 	// Parse permission patterns from configuration
-	if h.config.OIDCPublishPerms != "" {
-		for _, pattern := range strings.Split(h.config.OIDCPublishPerms, ",") {
-			pattern = strings.TrimSpace(pattern)
-			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionPublish,
-					ResourcePattern: pattern,
-				})
-			}
-		}
-	}
+	return append(
+		permissionsFor(auth.PermissionActionPublish, strings.Split(h.config.OIDCPublishPerms, ",")),
+		permissionsFor(auth.PermissionActionEdit, strings.Split(h.config.OIDCEditPerms, ","))...,
+	)
+}
 
-	if h.config.OIDCEditPerms != "" {
-		for _, pattern := range strings.Split(h.config.OIDCEditPerms, ",") {
-			pattern = strings.TrimSpace(pattern)
-			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionEdit,
-					ResourcePattern: pattern,
-				})
-			}
+// permissionsFor grants action on each non-empty resource pattern
+func permissionsFor(action auth.PermissionAction, patterns []string) []auth.Permission {
+	var permissions []auth.Permission
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern != "" {
+			permissions = append(permissions, auth.Permission{
+				Action:          action,
+				ResourcePattern: pattern,
+			})
 		}
 	}
-
 	return permissions
 }
@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gopkg.in/yaml.v3"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+)
+
+// trustedIssuerName is the form of issuer names, which prefix the subjects of the tokens they issue
+var trustedIssuerName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// TrustedIssuer is an OIDC issuer listed in MCP_REGISTRY_OIDC_ISSUERS_FILE, whose ID tokens can be exchanged
+// for Registry JWTs alongside those of MCP_REGISTRY_OIDC_ISSUER
+type TrustedIssuer struct {
+	// Name identifies the issuer; Registry JWT subjects are the name and the subject claim, such as corp:alice
+	Name string `yaml:"name"`
+	// Issuer is the issuer URL, matched against the iss claim of ID tokens
+	Issuer string `yaml:"issuer"`
+	// ClientID is the audience ID tokens must be issued for
+	ClientID string `yaml:"client_id"`
+	// SubjectClaim is the claim identifying the person, sub by default
+	SubjectClaim string `yaml:"subject_claim"`
+	// ExtraClaims are claim sets the token must match, like MCP_REGISTRY_OIDC_EXTRA_CLAIMS
+	ExtraClaims []map[string]any `yaml:"extra_claims"`
+	// PublishPermissions and EditPermissions are the namespace patterns tokens from the issuer may act on
+	PublishPermissions []string `yaml:"publish_permissions"`
+	EditPermissions    []string `yaml:"edit_permissions"`
+}
+
+// trustedIssuersFile is the document MCP_REGISTRY_OIDC_ISSUERS_FILE holds
+type trustedIssuersFile struct {
+	Issuers []TrustedIssuer `yaml:"issuers"`
+}
+
+// LoadTrustedIssuers reads the issuers listed in a YAML file, keyed by issuer URL. An empty path lists none.
+func LoadTrustedIssuers(path string) (map[string]*TrustedIssuer, error) {
+	issuers := map[string]*TrustedIssuer{}
+	if path == "" {
+		return issuers, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OIDC issuers file: %w", err)
+	}
+	// Unknown keys are rejected, so a misspelled setting does not silently grant more than intended
+	var document trustedIssuersFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&document); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse OIDC issuers file %s: %w", path, err)
+	}
+
+	names := map[string]bool{}
+	for i := range document.Issuers {
+		issuer := &document.Issuers[i]
+		switch {
+		case !trustedIssuerName.MatchString(issuer.Name):
+			return nil, fmt.Errorf("invalid OIDC issuers file %s: issuer %d: name %q must be lowercase letters, digits and dashes", path, i+1, issuer.Name)
+		case names[issuer.Name]:
+			return nil, fmt.Errorf("invalid OIDC issuers file %s: issuer name %q is listed twice", path, issuer.Name)
+		case issuer.Issuer == "":
+			return nil, fmt.Errorf("invalid OIDC issuers file %s: issuer %q has no issuer URL", path, issuer.Name)
+		case issuers[issuer.Issuer] != nil:
+			return nil, fmt.Errorf("invalid OIDC issuers file %s: issuer URL %s is listed twice", path, issuer.Issuer)
+		case issuer.ClientID == "":
+			return nil, fmt.Errorf("invalid OIDC issuers file %s: issuer %q has no client ID", path, issuer.Name)
+		}
+		if issuer.SubjectClaim == "" {
+			issuer.SubjectClaim = "sub"
+		}
+		names[issuer.Name] = true
+		issuers[issuer.Issuer] = issuer
+	}
+	return issuers, nil
+}
+
+// registryClaims checks the claims of a validated ID token against the issuer's configuration and returns the
+// Registry JWT claims they grant
+func (i *TrustedIssuer) registryClaims(claims *OIDCClaims) (*auth.JWTClaims, error) {
+	if err := matchExtraClaims(i.ExtraClaims, claims); err != nil {
+		return nil, fmt.Errorf("extra claims validation failed: %w", err)
+	}
+
+	subject := claims.Subject
+	if i.SubjectClaim != "sub" {
+		subject, _ = claims.ExtraClaims[i.SubjectClaim].(string)
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("the ID token has no %s claim", i.SubjectClaim)
+	}
+
+	return &auth.JWTClaims{
+		AuthMethod:        auth.MethodOIDC,
+		AuthMethodSubject: i.Name + ":" + subject,
+		Permissions: append(
+			permissionsFor(auth.PermissionActionPublish, i.PublishPermissions),
+			permissionsFor(auth.PermissionActionEdit, i.EditPermissions)...,
+		),
+	}, nil
+}
+
+// trustedIssuers holds the issuers of MCP_REGISTRY_OIDC_ISSUERS_FILE, reloading them when the file changes so
+// issuers can be added and removed without restarting. Validators are created when an issuer's first token
+// arrives, since creating one fetches the issuer's discovery document.
+type trustedIssuers struct {
+	path         string
+	newValidator func(issuer, clientID string) (GenericOIDCValidator, error)
+
+	mu         sync.Mutex
+	modTime    time.Time
+	issuers    map[string]*TrustedIssuer
+	validators map[string]GenericOIDCValidator
+}
+
+func newTrustedIssuers(path string) *trustedIssuers {
+	return &trustedIssuers{
+		path: path,
+		newValidator: func(issuer, clientID string) (GenericOIDCValidator, error) {
+			return NewStandardOIDCValidator(issuer, clientID)
+		},
+		issuers:    map[string]*TrustedIssuer{},
+		validators: map[string]GenericOIDCValidator{},
+	}
+}
+
+// lookup returns the issuer of an ID token and its validator, or nil when the file does not list the issuer.
+// The token's signature is checked by the validator, not here.
+func (t *trustedIssuers) lookup(token string) (*TrustedIssuer, GenericOIDCValidator, error) {
+	if t.path == "" {
+		return nil, nil, nil
+	}
+	iss := unverifiedIssuer(token)
+	if iss == "" {
+		return nil, nil, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.reload()
+
+	issuer := t.issuers[iss]
+	if issuer == nil {
+		return nil, nil, nil
+	}
+	key := issuer.Issuer + " " + issuer.ClientID
+	validator := t.validators[key]
+	if validator == nil {
+		var err error
+		if validator, err = t.newValidator(issuer.Issuer, issuer.ClientID); err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize validator for OIDC issuer %q: %w", issuer.Name, err)
+		}
+		t.validators[key] = validator
+	}
+	return issuer, validator, nil
+}
+
+// unverifiedIssuer returns the iss claim of a JWT without checking its signature, or "" when the token is not
+// a JWT with one
+func unverifiedIssuer(token string) string {
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return ""
+	}
+	return claims.Issuer
+}
+
+// reload reads the issuers file again when it changed since it was last read. A file that fails to load
+// keeps the issuers it listed before, so a bad edit does not lock everyone out; it is reported once, and
+// read again when it next changes.
+func (t *trustedIssuers) reload() {
+	info, err := os.Stat(t.path)
+	if err != nil {
+		log.Printf("Failed to check OIDC issuers file: %v", err)
+		return
+	}
+	if info.ModTime().Equal(t.modTime) {
+		return
+	}
+	t.modTime = info.ModTime()
+
+	issuers, err := LoadTrustedIssuers(t.path)
+	if err != nil {
+		log.Printf("Keeping the previous OIDC issuers: %v", err)
+		return
+	}
+	t.issuers = issuers
+}
+
+// errUntrustedIssuer is returned for ID tokens from issuers that are neither configured nor listed
+var errUntrustedIssuer = errors.New("the ID token's issuer is not trusted")
+
+// validateTrusted validates an ID token from an issuer listed in the issuers file and returns its claims and
+// the Registry JWT claims they grant, or nil claims when the file does not list the token's issuer
+func (t *trustedIssuers) validateTrusted(ctx context.Context, token string) (*OIDCClaims, *auth.JWTClaims, error) {
+	issuer, validator, err := t.lookup(token)
+	if err != nil || issuer == nil {
+		return nil, nil, err
+	}
+	claims, err := validator.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to validate OIDC token: %w", err)
+	}
+	jwtClaims, err := issuer.registryClaims(claims)
+	if err != nil {
+		return nil, nil, err
+	}
+	return claims, jwtClaims, nil
+}
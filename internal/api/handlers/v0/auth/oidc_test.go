@@ -3,10 +3,17 @@ package auth_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	intauth "github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -37,7 +44,7 @@ func TestOIDCHandler_ExchangeToken(t *testing.T) {
 				OIDCEnabled:      true,
 				OIDCIssuer:       "https://accounts.google.com",
 				OIDCClientID:     "test-client-id",
-				OIDCExtraClaims:  `[{"hd":"modelcontextprotocol.io"}]`,
+				OIDCExtraClaims:  config.ClaimSets{{"hd": "modelcontextprotocol.io"}},
 				OIDCPublishPerms: "*",
 				JWTPrivateKey:    "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef", // 32 byte hex
 			},
@@ -62,7 +69,7 @@ func TestOIDCHandler_ExchangeToken(t *testing.T) {
 				OIDCEnabled:      true,
 				OIDCIssuer:       "https://accounts.google.com",
 				OIDCClientID:     "test-client-id",
-				OIDCExtraClaims:  `[{"hd":"modelcontextprotocol.io"}]`,
+				OIDCExtraClaims:  config.ClaimSets{{"hd": "modelcontextprotocol.io"}},
 				OIDCPublishPerms: "*",
 				JWTPrivateKey:    "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
 			},
@@ -105,3 +112,145 @@ func TestOIDCHandler_ExchangeToken(t *testing.T) {
 		})
 	}
 }
+
+func TestOIDCHandler_TrustedIssuers(t *testing.T) {
+	issuersFile := filepath.Join(t.TempDir(), "issuers.yaml")
+	writeIssuers := func(content string, modTime time.Time) {
+		require.NoError(t, os.WriteFile(issuersFile, []byte(content), 0o600))
+		require.NoError(t, os.Chtimes(issuersFile, modTime, modTime))
+	}
+	writeIssuers(`issuers:
+  - name: corp
+    issuer: https://login.corp.example.com
+    client_id: registry
+    subject_claim: email
+    extra_claims: [{groups: mcp-publishers}]
+    publish_permissions: ["com.corp/*"]
+  - name: lab
+    issuer: https://idp.lab.example.com
+    client_id: registry-lab
+    edit_permissions: ["com.corp.lab/*"]
+`, time.Now().Add(-time.Hour))
+
+	cfg := &config.Config{
+		OIDCEnabled:     true,
+		OIDCIssuersFile: issuersFile,
+		JWTPrivateKey:   "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+	}
+	handler := auth.NewOIDCHandler(cfg)
+	var created []string
+	handler.SetValidatorFactory(func(issuer, clientID string) (auth.GenericOIDCValidator, error) {
+		created = append(created, issuer+" "+clientID)
+		return &MockGenericOIDCValidator{
+			validateFunc: func(_ context.Context, _ string) (*auth.OIDCClaims, error) {
+				return &auth.OIDCClaims{
+					Subject: "user-123",
+					Issuer:  issuer,
+					ExtraClaims: map[string]any{
+						"email":  "alice@corp.example.com",
+						"groups": "mcp-publishers",
+					},
+				}, nil
+			},
+		}, nil
+	})
+	tokenFrom := func(issuer string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iss": issuer}).SignedString([]byte("unverified"))
+		require.NoError(t, err)
+		return token
+	}
+	registryClaims := func(token string) *intauth.JWTClaims {
+		response, err := handler.ExchangeToken(context.Background(), token)
+		require.NoError(t, err)
+		claims, err := intauth.NewJWTManager(cfg).ValidateToken(context.Background(), response.RegistryToken)
+		require.NoError(t, err)
+		return claims
+	}
+
+	claims := registryClaims(tokenFrom("https://login.corp.example.com"))
+	assert.Equal(t, "corp:alice@corp.example.com", claims.AuthMethodSubject)
+	assert.Equal(t, []intauth.Permission{{Action: intauth.PermissionActionPublish, ResourcePattern: "com.corp/*"}}, claims.Permissions)
+
+	claims = registryClaims(tokenFrom("https://idp.lab.example.com"))
+	assert.Equal(t, "lab:user-123", claims.AuthMethodSubject)
+	assert.Equal(t, []intauth.Permission{{Action: intauth.PermissionActionEdit, ResourcePattern: "com.corp.lab/*"}}, claims.Permissions)
+
+	registryClaims(tokenFrom("https://login.corp.example.com"))
+	assert.Equal(t, []string{"https://login.corp.example.com registry", "https://idp.lab.example.com registry-lab"}, created,
+		"validators are created once per issuer")
+
+	_, err := handler.ExchangeToken(context.Background(), tokenFrom("https://accounts.example.net"))
+	require.Error(t, err, "issuers that are not listed are not trusted")
+
+	// Edits to the file apply without restarting; a broken file keeps the previous issuers
+	writeIssuers("issuers: [{name: corp}]", time.Now().Add(-30*time.Minute))
+	registryClaims(tokenFrom("https://idp.lab.example.com"))
+
+	writeIssuers(`issuers:
+  - name: corp
+    issuer: https://login.corp.example.com
+    client_id: registry
+    extra_claims: [{groups: admins}]
+`, time.Now())
+	_, err = handler.ExchangeToken(context.Background(), tokenFrom("https://idp.lab.example.com"))
+	require.Error(t, err, "removed issuers are no longer trusted")
+	_, err = handler.ExchangeToken(context.Background(), tokenFrom("https://login.corp.example.com"))
+	require.Error(t, err, "changed claim requirements apply")
+}
+
+func TestLoadTrustedIssuers_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"missing name":      "issuers: [{issuer: https://a.example.com, client_id: x}]",
+		"invalid name":      "issuers: [{name: Corp, issuer: https://a.example.com, client_id: x}]",
+		"missing client ID": "issuers: [{name: corp, issuer: https://a.example.com}]",
+		"duplicate name":    "issuers: [{name: corp, issuer: https://a.example.com, client_id: x}, {name: corp, issuer: https://b.example.com, client_id: x}]",
+		"duplicate issuer":  "issuers: [{name: a, issuer: https://a.example.com, client_id: x}, {name: b, issuer: https://a.example.com, client_id: y}]",
+		"unknown key":       "issuers: [{name: corp, issuer: https://a.example.com, client_id: x, clientid: y}]",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "issuers.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+			_, err := auth.LoadTrustedIssuers(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestOIDCHandler_ReplayProtection(t *testing.T) {
+	issuersFile := filepath.Join(t.TempDir(), "issuers.yaml")
+	require.NoError(t, os.WriteFile(issuersFile, []byte(`issuers:
+  - name: corp
+    issuer: https://login.corp.example.com
+    client_id: registry
+    publish_permissions: ["com.corp/*"]
+`), 0o600))
+	cfg := &config.Config{
+		OIDCEnabled:      true,
+		OIDCIssuersFile:  issuersFile,
+		JWTPrivateKey:    "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		TokenReplayStore: service.TokenReplayStoreMemory,
+	}
+	handler := auth.NewOIDCHandler(cfg)
+	handler.SetReplayStore(service.NewTestRegistryService(t, database.NewMemory(), cfg))
+	handler.SetValidatorFactory(func(issuer, _ string) (auth.GenericOIDCValidator, error) {
+		return &MockGenericOIDCValidator{
+			validateFunc: func(_ context.Context, _ string) (*auth.OIDCClaims, error) {
+				// Like Google ID tokens, these have no jti, so the whole token identifies them
+				return &auth.OIDCClaims{Subject: "alice", Issuer: issuer, Expiry: time.Now().Add(time.Hour)}, nil
+			},
+		}, nil
+	})
+	tokenFor := func(subject string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iss": "https://login.corp.example.com", "sub": subject}).SignedString([]byte("unverified"))
+		require.NoError(t, err)
+		return token
+	}
+
+	token := tokenFor("alice")
+	_, err := handler.ExchangeToken(context.Background(), token)
+	require.NoError(t, err)
+	_, err = handler.ExchangeToken(context.Background(), token)
+	require.ErrorIs(t, err, auth.ErrTokenReplayed)
+	_, err = handler.ExchangeToken(context.Background(), tokenFor("bob"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// Provider is an authentication method that exchanges proof of identity for a Registry JWT
+type Provider interface {
+	// Name identifies the provider in MCP_REGISTRY_AUTH_PROVIDERS
+	Name() string
+	// RegisterEndpoints registers the provider's token exchange endpoints under pathPrefix
+	RegisterEndpoints(api huma.API, pathPrefix string)
+}
+
+// ProviderDeps holds the services a provider may need beyond the configuration
+type ProviderDeps struct {
+	Config  *config.Config
+	Reviews NamespaceReviewStore
+	APIKeys OrgAPIKeyStore
+	// Replays rejects identity tokens exchanged before; nil accepts them
+	Replays TokenReplayStore
+}
+
+// ProviderFactory creates a provider, or returns nil when the provider's own settings disable it
+type ProviderFactory func(deps ProviderDeps) Provider
+
+var (
+	providersMu       sync.RWMutex
+	providerFactories = map[string]ProviderFactory{}
+	providerOrder     []string
+)
+
+// RegisterProvider makes a provider available under name. Providers are registered from init
+// functions: additional ones such as SAML or LDAP bridges can live in files behind a build tag, or in
+// packages blank-imported by the registry binary. It panics if name is already registered.
+func RegisterProvider(name string, factory ProviderFactory) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	if _, exists := providerFactories[name]; exists {
+		panic(fmt.Sprintf("auth provider %q registered twice", name))
+	}
+	providerFactories[name] = factory
+	providerOrder = append(providerOrder, name)
+}
+
+// ProviderNames returns the registered provider names in registration order
+func ProviderNames() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	return slices.Clone(providerOrder)
+}
+
+// ValidateProviderNames checks that every name refers to a registered provider
+func ValidateProviderNames(names []string) error {
+	registered := ProviderNames()
+	for _, name := range names {
+		if !slices.Contains(registered, name) {
+			return fmt.Errorf("unknown auth provider %q (registered: %v)", name, registered)
+		}
+	}
+	return nil
+}
+
+// EnabledProviders creates the providers named in cfg.AuthProviders, or every registered provider when
+// the list is empty, in registration order. Unknown names and providers disabled by their own
+// settings are skipped.
+func EnabledProviders(deps ProviderDeps) []Provider {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	var providers []Provider
+	for _, name := range providerOrder {
+		if len(deps.Config.AuthProviders) > 0 && !slices.Contains(deps.Config.AuthProviders, name) {
+			continue
+		}
+		if provider := providerFactories[name](deps); provider != nil {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+// The built-in providers, registered in the order their endpoints appear in the API documentation
+func init() {
+	RegisterProvider("github-at", func(deps ProviderDeps) Provider {
+		return NewGitHubHandler(deps.Config)
+	})
+	RegisterProvider("github-oidc", func(deps ProviderDeps) Provider {
+		handler := NewGitHubOIDCHandler(deps.Config)
+		handler.SetReplayStore(deps.Replays)
+		return handler
+	})
+	RegisterProvider("oidc", func(deps ProviderDeps) Provider {
+		if !deps.Config.OIDCEnabled {
+			return nil
+		}
+		handler := NewOIDCHandler(deps.Config)
+		handler.SetReplayStore(deps.Replays)
+		return handler
+	})
+	RegisterProvider("dns", func(deps ProviderDeps) Provider {
+		handler := NewDNSAuthHandler(deps.Config)
+		handler.SetNamespaceReviewStore(deps.Reviews)
+		return handler
+	})
+	RegisterProvider("http", func(deps ProviderDeps) Provider {
+		handler := NewHTTPAuthHandler(deps.Config)
+		handler.SetNamespaceReviewStore(deps.Reviews)
+		return handler
+	})
+	RegisterProvider("api-key", func(deps ProviderDeps) Provider {
+		if !deps.Config.OrgAPIKeysEnabled {
+			return nil
+		}
+		return NewAPIKeyHandler(deps.Config, deps.APIKeys)
+	})
+	// The anonymous provider is for local development and automated tests only
+	RegisterProvider("none", func(deps ProviderDeps) Provider {
+		if !deps.Config.EnableAnonymousAuth {
+			return nil
+		}
+		return NewNoneHandler(deps.Config)
+	})
+}
@@ -0,0 +1,77 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func providerNames(providers []v0auth.Provider) []string {
+	names := make([]string, 0, len(providers))
+	for _, provider := range providers {
+		names = append(names, provider.Name())
+	}
+	return names
+}
+
+func TestEnabledProviders(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Config
+		want []string
+	}{
+		{
+			name: "defaults skip providers disabled by their own settings",
+			cfg:  config.Config{},
+			want: []string{"github-at", "github-oidc", "dns", "http"},
+		},
+		{
+			name: "anonymous auth enabled",
+			cfg:  config.Config{EnableAnonymousAuth: true},
+			want: []string{"github-at", "github-oidc", "dns", "http", "none"},
+		},
+		{
+			name: "organization API keys enabled",
+			cfg:  config.Config{OrgAPIKeysEnabled: true},
+			want: []string{"github-at", "github-oidc", "dns", "http", "api-key"},
+		},
+		{
+			name: "explicit list in registration order",
+			cfg:  config.Config{AuthProviders: []string{"http", "github-oidc"}},
+			want: []string{"github-oidc", "http"},
+		},
+		{
+			name: "listing a provider does not override its own setting",
+			cfg:  config.Config{AuthProviders: []string{"dns", "none"}},
+			want: []string{"dns"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.JWTPrivateKey = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+			providers := v0auth.EnabledProviders(v0auth.ProviderDeps{Config: &cfg})
+			assert.Equal(t, tt.want, providerNames(providers))
+		})
+	}
+}
+
+func TestValidateProviderNames(t *testing.T) {
+	require.NoError(t, v0auth.ValidateProviderNames(nil))
+	require.NoError(t, v0auth.ValidateProviderNames([]string{"github-at", "none"}))
+
+	err := v0auth.ValidateProviderNames([]string{"github-at", "saml"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown auth provider "saml"`)
+}
+
+func TestRegisterProvider_Duplicate(t *testing.T) {
+	assert.Panics(t, func() {
+		v0auth.RegisterProvider("dns", func(v0auth.ProviderDeps) v0auth.Provider { return nil })
+	})
+}
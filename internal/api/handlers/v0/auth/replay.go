@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// ErrTokenReplayed is returned when an identity token that was already exchanged is exchanged again
+var ErrTokenReplayed = errors.New("the token was already exchanged")
+
+// TokenReplayStore remembers the identity tokens exchanged for registry tokens until they expire. The
+// registry service implements it with the store MCP_REGISTRY_TOKEN_REPLAY_STORE selects; deployments can
+// pass another, such as one backed by a cache shared with other services, in ProviderDeps.
+type TokenReplayStore interface {
+	// ClaimTokenUse records the first exchange of a token until expiresAt, failing with an error wrapping
+	// database.ErrAlreadyExists when the token was exchanged before
+	ClaimTokenUse(ctx context.Context, key string, expiresAt time.Time) error
+}
+
+// claimToken rejects an identity token that was exchanged before, so a token leaked from a CI log cannot be
+// exchanged again while it is valid. Tokens are identified by issuer and jti, or by a hash of the whole token
+// when they have no jti, as Google ID tokens do not. Without a store, every token is accepted.
+func claimToken(ctx context.Context, store TokenReplayStore, issuer, tokenID, token string, expiresAt time.Time) error {
+	if store == nil {
+		return nil
+	}
+
+	key := issuer + "#" + tokenID
+	if tokenID == "" {
+		sum := sha256.Sum256([]byte(token))
+		key = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(time.Hour)
+	}
+
+	if err := store.ClaimTokenUse(ctx, key, expiresAt); err != nil {
+		if errors.Is(err, database.ErrAlreadyExists) {
+			return ErrTokenReplayed
+		}
+		return fmt.Errorf("failed to check for token replay: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ListNamespaceVerificationsInput represents the input for listing namespace verification requests
+type ListNamespaceVerificationsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Status        string `query:"status" enum:"pending,approved,rejected,all" default:"pending" doc:"Only return requests with this review status"`
+}
+
+// NamespaceVerificationInput represents the input for operating on a single namespace verification request
+type NamespaceVerificationInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ID            int64  `path:"id" doc:"Verification request ID" example:"42"`
+}
+
+// ReviewNamespaceVerificationBody represents the request body for approving or rejecting a verification request
+type ReviewNamespaceVerificationBody struct {
+	Notes *string `json:"notes,omitempty" maxLength:"1000" doc:"Reviewer notes explaining the decision"`
+}
+
+// ReviewNamespaceVerificationInput represents the input for approving or rejecting a verification request
+type ReviewNamespaceVerificationInput struct {
+	Authorization string                          `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ID            int64                           `path:"id" doc:"Verification request ID" example:"42"`
+	Body          ReviewNamespaceVerificationBody `body:""`
+}
+
+// NamespaceVerificationListResponse represents a list of namespace verification requests
+type NamespaceVerificationListResponse struct {
+	Verifications []database.NamespaceVerification `json:"verifications" doc:"Verification requests, oldest first"`
+}
+
+// NamespaceReviewHandler gathers fresh evidence for namespace verification requests
+type NamespaceReviewHandler struct {
+	resolver DNSResolver
+	fetcher  HTTPKeyFetcher
+}
+
+// NewNamespaceReviewHandler creates a new namespace review handler
+func NewNamespaceReviewHandler() *NamespaceReviewHandler {
+	return &NamespaceReviewHandler{
+		resolver: &DefaultDNSResolver{},
+		fetcher:  NewDefaultHTTPKeyFetcher(),
+	}
+}
+
+// SetResolver sets a custom DNS resolver (used for testing)
+func (h *NamespaceReviewHandler) SetResolver(resolver DNSResolver) {
+	h.resolver = resolver
+}
+
+// SetFetcher sets a custom HTTP key fetcher (used for testing)
+func (h *NamespaceReviewHandler) SetFetcher(fetcher HTTPKeyFetcher) {
+	h.fetcher = fetcher
+}
+
+// CollectEvidence looks up the domain's MCP public key with the given method and records what was found.
+// Lookup failures are captured in the evidence rather than returned.
+func (h *NamespaceReviewHandler) CollectEvidence(ctx context.Context, method, domain string) database.VerificationEvidence {
+	authMethod := auth.Method(method)
+	evidence := database.VerificationEvidence{
+		CheckedAt: time.Now(),
+		Trigger:   database.EvidenceTriggerRecheck,
+		Lookup:    VerificationLookup(authMethod, domain),
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var err error
+	if authMethod == auth.MethodDNS {
+		evidence.Records, err = h.resolver.LookupTXT(timeoutCtx, domain)
+	} else {
+		var body string
+		body, err = h.fetcher.FetchKey(timeoutCtx, domain)
+		if body != "" {
+			evidence.Records = []string{body}
+		}
+	}
+	if err != nil {
+		evidence.Error = err.Error()
+	}
+
+	for _, key := range ParseMCPKeysFromStrings(evidence.Records) {
+		if key.error == nil {
+			evidence.ValidKeyFound = true
+			break
+		}
+	}
+
+	return evidence
+}
+
+// RegisterNamespaceReviewEndpoints registers the admin endpoints for reviewing namespace verification requests
+func RegisterNamespaceReviewEndpoints(api huma.API, pathPrefix string, cfg *config.Config, registry service.RegistryService) {
+	jwtManager := auth.NewJWTManager(cfg)
+	handler := NewNamespaceReviewHandler()
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+	basePath := pathPrefix + "/admin/namespace-verifications"
+	security := []map[string][]string{
+		{"bearer": {}},
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-namespace-verifications" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        basePath,
+		Summary:     "List namespace verification requests",
+		Description: "List DNS and HTTP domain verification requests. Requests are created when a domain proves ownership while namespace review is required. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *ListNamespaceVerificationsInput) (*v0.Response[NamespaceVerificationListResponse], error) {
+		if _, err := v0.AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		status := input.Status
+		if status == "all" {
+			status = ""
+		}
+
+		verifications, err := registry.ListNamespaceVerifications(ctx, status)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list namespace verifications", err)
+		}
+
+		response := NamespaceVerificationListResponse{Verifications: make([]database.NamespaceVerification, len(verifications))}
+		for i, verification := range verifications {
+			response.Verifications[i] = *verification
+		}
+
+		return &v0.Response[NamespaceVerificationListResponse]{
+			Body: response,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-namespace-verification" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        basePath + "/{id}",
+		Summary:     "Get namespace verification request",
+		Description: "Get a verification request with the evidence gathered for it. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *NamespaceVerificationInput) (*v0.Response[database.NamespaceVerification], error) {
+		if _, err := v0.AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		verification, err := registry.GetNamespaceVerification(ctx, input.ID)
+		if err != nil {
+			return nil, namespaceVerificationError(err)
+		}
+
+		return &v0.Response[database.NamespaceVerification]{
+			Body: *verification,
+		}, nil
+	})
+
+	for _, decision := range []struct {
+		action  string
+		approve bool
+		summary string
+	}{
+		{action: "approve", approve: true, summary: "Approve namespace verification request"},
+		{action: "reject", approve: false, summary: "Reject namespace verification request"},
+	} {
+		huma.Register(api, huma.Operation{
+			OperationID: decision.action + "-namespace-verification" + operationSuffix,
+			Method:      http.MethodPost,
+			Path:        basePath + "/{id}/" + decision.action,
+			Summary:     decision.summary,
+			Description: "Record a review decision with optional notes. The most recent decision for a domain determines whether it can authenticate. Requires admin permissions.",
+			Tags:        []string{"admin"},
+			Security:    security,
+		}, func(ctx context.Context, input *ReviewNamespaceVerificationInput) (*v0.Response[database.NamespaceVerification], error) {
+			claims, err := v0.AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+			if err != nil {
+				return nil, err
+			}
+
+			reviewer := claims.AuthMethodSubject
+			if reviewer == "" {
+				reviewer = string(claims.AuthMethod)
+			}
+
+			verification, err := registry.ReviewNamespaceVerification(ctx, input.ID, decision.approve, input.Body.Notes, reviewer)
+			if err != nil {
+				return nil, namespaceVerificationError(err)
+			}
+
+			v0.RecordAudit(ctx, registry, claims, database.AuditEntry{
+				Action:    database.AuditActionNamespaceReview,
+				Namespace: ReverseString(verification.Domain),
+				Resource:  verification.Domain,
+				Details:   map[string]any{"id": verification.ID, "status": verification.Status},
+			})
+
+			return &v0.Response[database.NamespaceVerification]{
+				Body: *verification,
+			}, nil
+		})
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "recheck-namespace-verification" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        basePath + "/{id}/recheck",
+		Summary:     "Re-check namespace verification request",
+		Description: "Look up the domain's public key again and append the result to the request's evidence. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *NamespaceVerificationInput) (*v0.Response[database.NamespaceVerification], error) {
+		if _, err := v0.AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		verification, err := registry.GetNamespaceVerification(ctx, input.ID)
+		if err != nil {
+			return nil, namespaceVerificationError(err)
+		}
+
+		evidence := handler.CollectEvidence(ctx, verification.Method, verification.Domain)
+		verification, err = registry.AddNamespaceVerificationEvidence(ctx, input.ID, evidence)
+		if err != nil {
+			return nil, namespaceVerificationError(err)
+		}
+
+		return &v0.Response[database.NamespaceVerification]{
+			Body: *verification,
+		}, nil
+	})
+}
+
+func namespaceVerificationError(err error) error {
+	if errors.Is(err, database.ErrNotFound) {
+		return huma.Error404NotFound("Namespace verification request not found")
+	}
+	return huma.Error500InternalServerError("Failed to process namespace verification request", err)
+}
@@ -0,0 +1,104 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	intauth "github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// fakeNamespaceReviewStore records verification attempts in memory
+type fakeNamespaceReviewStore struct {
+	approved map[string]bool
+	attempts []database.VerificationEvidence
+}
+
+func (f *fakeNamespaceReviewStore) IsNamespaceApproved(_ context.Context, domain string) (bool, error) {
+	return f.approved[domain], nil
+}
+
+func (f *fakeNamespaceReviewStore) RecordNamespaceVerificationAttempt(_ context.Context, domain, method string, evidence database.VerificationEvidence) (*database.NamespaceVerification, error) {
+	f.attempts = append(f.attempts, evidence)
+	return &database.NamespaceVerification{
+		ID:       int64(len(f.attempts)),
+		Domain:   domain,
+		Method:   method,
+		Status:   database.NamespaceVerificationPending,
+		Evidence: f.attempts,
+	}, nil
+}
+
+func TestDNSAuthHandler_NamespaceReviewRequired(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey:           "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		NamespaceReviewRequired: true,
+	}
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	proofRecord := fmt.Sprintf("v=MCPv1; k=ed25519; p=%s", base64.StdEncoding.EncodeToString(publicKey))
+
+	handler := auth.NewDNSAuthHandler(cfg)
+	handler.SetResolver(&MockDNSResolver{txtRecords: map[string][]string{testDomain: {proofRecord}}})
+	store := &fakeNamespaceReviewStore{approved: map[string]bool{}}
+	handler.SetNamespaceReviewStore(store)
+
+	exchange := func() (*intauth.TokenResponse, error) {
+		timestamp := time.Now().UTC().Format(time.RFC3339)
+		signature := hex.EncodeToString(ed25519.Sign(privateKey, []byte(timestamp)))
+		return handler.ExchangeToken(context.Background(), testDomain, timestamp, signature)
+	}
+
+	// Unapproved domains get no token, and the login is recorded as evidence for review
+	_, err = exchange()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, intauth.ErrNamespacePendingReview))
+	require.Len(t, store.attempts, 1)
+	assert.Equal(t, database.EvidenceTriggerLogin, store.attempts[0].Trigger)
+	assert.Equal(t, testDomain, store.attempts[0].Lookup)
+	assert.Equal(t, []string{proofRecord}, store.attempts[0].Records)
+	assert.True(t, store.attempts[0].ValidKeyFound)
+
+	// Once approved, the same login succeeds
+	store.approved[testDomain] = true
+	response, err := exchange()
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.RegistryToken)
+	assert.Len(t, store.attempts, 1)
+}
+
+func TestNamespaceReviewHandler_CollectEvidence(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	proofRecord := fmt.Sprintf("v=MCPv1; k=ed25519; p=%s", base64.StdEncoding.EncodeToString(publicKey))
+
+	handler := auth.NewNamespaceReviewHandler()
+	handler.SetResolver(&MockDNSResolver{txtRecords: map[string][]string{
+		testDomain: {"google-site-verification=abc", proofRecord},
+	}})
+	handler.SetFetcher(&MockHTTPKeyFetcher{err: errors.New("HTTP 404")})
+
+	evidence := handler.CollectEvidence(context.Background(), "dns", testDomain)
+	assert.Equal(t, database.EvidenceTriggerRecheck, evidence.Trigger)
+	assert.Equal(t, testDomain, evidence.Lookup)
+	assert.Len(t, evidence.Records, 2)
+	assert.True(t, evidence.ValidKeyFound)
+	assert.Empty(t, evidence.Error)
+
+	evidence = handler.CollectEvidence(context.Background(), "http", testDomain)
+	assert.Equal(t, "https://"+testDomain+"/.well-known/mcp-registry-auth", evidence.Lookup)
+	assert.False(t, evidence.ValidKeyFound)
+	assert.Contains(t, evidence.Error, "HTTP 404")
+}
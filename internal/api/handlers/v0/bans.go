@@ -0,0 +1,177 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListIdentityBansInput represents the input for listing identity bans
+type ListIdentityBansInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+}
+
+// IdentityBanInput represents the input for lifting an identity ban
+type IdentityBanInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Kind          string `path:"kind" enum:"github,oidc,namespace" doc:"Kind of the banned identity"`
+	Value         string `path:"value" doc:"URL-encoded banned identity" example:"spammer"`
+}
+
+// BanIdentityBody represents the request body for banning an identity
+type BanIdentityBody struct {
+	Kind      string     `json:"kind" required:"true" enum:"github,oidc,namespace" doc:"What value identifies: a GitHub user or organization, an OIDC subject, or a namespace" example:"github"`
+	Value     string     `json:"value" required:"true" minLength:"1" maxLength:"255" doc:"GitHub login or organization, OIDC subject as recorded on tokens, or namespace" example:"spammer"`
+	Reason    string     `json:"reason" required:"true" minLength:"1" maxLength:"1000" doc:"Why the identity is banned. Shown to the identity when its requests are rejected." example:"Publishing malware"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" required:"false" format:"date-time" doc:"When the ban lapses. Omit to ban until the ban is lifted."`
+}
+
+// BanIdentityInput represents the input for banning an identity
+type BanIdentityInput struct {
+	Authorization string          `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Body          BanIdentityBody `body:""`
+}
+
+// BanIdentityResponse is an identity ban with the servers it quarantined
+type BanIdentityResponse struct {
+	database.IdentityBan
+	QuarantinedServers []string `json:"quarantinedServers" doc:"Servers the identity published, or in the banned namespace, whose versions were deleted"`
+}
+
+// IdentityBanListResponse lists identity bans
+type IdentityBanListResponse struct {
+	Bans []database.IdentityBan `json:"bans" doc:"Identity bans, including expired ones, ordered by kind and value"`
+}
+
+// RegisterBanEndpoints registers the admin endpoints banning identities from publishing
+func RegisterBanEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	security := []map[string][]string{{"bearer": {}}}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-identity-bans" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/bans",
+		Summary:     "List identity bans",
+		Description: "List the identities banned from publishing, including bans that have expired. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *ListIdentityBansInput) (*Response[IdentityBanListResponse], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		bans, err := registry.ListIdentityBans(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list identity bans", err)
+		}
+
+		body := IdentityBanListResponse{Bans: make([]database.IdentityBan, 0, len(bans))}
+		for _, ban := range bans {
+			body.Bans = append(body.Bans, *ban)
+		}
+		return &Response[IdentityBanListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "ban-identity" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/bans",
+		Summary:     "Ban an identity",
+		Description: "Ban a GitHub user or organization, an OIDC subject or a namespace from getting registry tokens and publishing, and quarantine the servers it published until the ban is lifted or expires. Replaces any existing ban of the identity. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *BanIdentityInput) (*Response[BanIdentityResponse], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		ban, quarantined, err := registry.BanIdentity(ctx, database.IdentityBan{
+			Kind:      input.Body.Kind,
+			Value:     input.Body.Value,
+			Reason:    input.Body.Reason,
+			ExpiresAt: input.Body.ExpiresAt,
+			CreatedBy: string(claims.AuthMethod) + ":" + claims.AuthMethodSubject,
+		})
+		if ban != nil {
+			RecordAudit(ctx, registry, claims, database.AuditEntry{
+				Action:   database.AuditActionIdentityBan,
+				Resource: ban.Kind + ":" + ban.Value,
+				Details:  map[string]any{"banned": true, "reason": ban.Reason, "quarantinedServers": quarantined},
+			})
+		}
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidInput) {
+				return nil, huma.Error422UnprocessableEntity("Invalid identity ban", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to ban identity", err)
+		}
+
+		if quarantined == nil {
+			quarantined = []string{}
+		}
+		return &Response[BanIdentityResponse]{Body: BanIdentityResponse{IdentityBan: *ban, QuarantinedServers: quarantined}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-identity-ban" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/admin/bans/{kind}/{value}",
+		Summary:       "Lift an identity ban",
+		Description:   "Allow the identity to get registry tokens and publish again, and restore the servers the ban quarantined to the status they had, unless another ban still covers them. Fails with 409 when another server took a remote URL of a quarantined version since. Requires admin permissions.",
+		Tags:          []string{"admin"},
+		Security:      security,
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *IdentityBanInput) (*struct{}, error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		value, err := url.PathUnescape(input.Value)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid identity encoding", err))
+		}
+
+		restored, err := registry.UnbanIdentity(ctx, input.Kind, value)
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Identity ban not found"))
+			}
+			if errors.Is(err, service.ErrRemoteURLInUse) {
+				return nil, huma.Error409Conflict("Cannot restore the servers the ban quarantined", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to lift identity ban", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:   database.AuditActionIdentityBan,
+			Resource: input.Kind + ":" + value,
+			Details:  map[string]any{"banned": false, "restoredServers": restored},
+		})
+
+		return nil, nil
+	})
+}
+
+// checkIdentityBan rejects requests made with a token of a banned identity, including tokens issued before the ban
+func checkIdentityBan(ctx context.Context, registry service.RegistryService, claims *auth.JWTClaims) error {
+	if err := registry.CheckIdentityBan(ctx, claims); err != nil {
+		if errors.Is(err, auth.ErrIdentityBanned) {
+			return huma.Error403Forbidden("Identity is banned from publishing", err)
+		}
+		return huma.Error500InternalServerError("Failed to check identity bans", err)
+	}
+	return nil
+}
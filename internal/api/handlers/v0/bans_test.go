@@ -0,0 +1,225 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestBanEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), PublishHistoryRetention: 24 * time.Hour}
+	db := database.NewMemory()
+	registry := service.NewTestRegistryService(t, db, cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterBanEndpoints(api, "/v0", registry, cfg)
+
+	jwtManager := auth.NewJWTManager(cfg)
+	token := func(method auth.Method, subject string, permissions ...auth.Permission) string {
+		response, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        method,
+			AuthMethodSubject: subject,
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	publish := func(pattern string) auth.Permission {
+		return auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: pattern}
+	}
+	adminToken := token(auth.MethodGitHubAT, "admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	spammerToken := token(auth.MethodGitHubAT, "Spammer", publish("io.github.Spammer/*"), publish("com.example/*"))
+	aliceToken := token(auth.MethodGitHubAT, "alice", publish("io.github.alice/*"))
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	publishServer := func(authorization, name, version string) *httptest.ResponseRecorder {
+		return do(http.MethodPost, "/v0/publish", authorization, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "A test server",
+			Version:     version,
+		})
+	}
+
+	for _, name := range []string{"io.github.Spammer/weather", "com.example/tool"} {
+		w := publishServer(spammerToken, name, "1.0.0")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+	w := publishServer(aliceToken, "io.github.alice/notes", "1.0.0")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/admin/bans", aliceToken, map[string]any{"kind": "github", "value": "spammer", "reason": "Spam"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects expiry in the past", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/admin/bans", adminToken, map[string]any{
+			"kind": "github", "value": "spammer", "reason": "Spam", "expiresAt": time.Now().Add(-time.Hour),
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	})
+
+	t.Run("banning quarantines servers", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/admin/bans", adminToken, map[string]any{"kind": "github", "value": "Spammer", "reason": "Publishing malware"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response v0.BanIdentityResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "Spammer", response.Value)
+		assert.Equal(t, "github-at:admin", response.CreatedBy)
+		assert.Equal(t, []string{"com.example/tool", "io.github.Spammer/weather"}, response.QuarantinedServers)
+
+		for _, name := range response.QuarantinedServers {
+			server, err := registry.GetServerByName(context.Background(), name, true)
+			require.NoError(t, err)
+			assert.Equal(t, model.StatusQuarantined, server.Meta.Official.Status)
+			_, err = registry.GetServerByName(context.Background(), name, false)
+			assert.ErrorIs(t, err, database.ErrNotFound, "quarantined servers are hidden")
+		}
+		server, err := registry.GetServerByName(context.Background(), "io.github.alice/notes", false)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, server.Meta.Official.Status)
+
+		w = do(http.MethodGet, "/v0/admin/bans", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.IdentityBanListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Bans, 1)
+		assert.Equal(t, "Publishing malware", list.Bans[0].Reason)
+	})
+
+	t.Run("banned identities cannot publish with existing tokens", func(t *testing.T) {
+		w := publishServer(spammerToken, "com.example/tool", "1.0.1")
+		require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+		var body v0.ErrorModel
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, apiv0.ErrorCodeIdentityBanned, body.Code)
+		assert.Contains(t, w.Body.String(), "Publishing malware")
+
+		w = publishServer(aliceToken, "io.github.alice/notes", "1.0.1")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("banned identities cannot get tokens", func(t *testing.T) {
+		auth.SetBanChecker(registry)
+		t.Cleanup(func() { auth.SetBanChecker(nil) })
+
+		for _, claims := range []auth.JWTClaims{
+			{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "SPAMMER"},
+			{AuthMethod: auth.MethodGitHubOIDC, AuthMethodSubject: "repo:spammer/tool:ref:refs/heads/main"},
+			{AuthMethod: auth.MethodDNS, AuthMethodSubject: "example.org", Permissions: []auth.Permission{publish("io.github.spammer/*")}},
+		} {
+			_, err := jwtManager.GenerateTokenResponse(context.Background(), claims)
+			assert.ErrorIs(t, err, auth.ErrIdentityBanned, claims.AuthMethodSubject)
+		}
+		_, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod: auth.MethodGitHubOIDC, AuthMethodSubject: "repo:alice/spammer:ref:refs/heads/main",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("namespace and expired bans", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		_, quarantined, err := registry.BanIdentity(context.Background(), database.IdentityBan{
+			Kind: database.BanKindNamespace, Value: "io.github.alice", Reason: "Squatting", ExpiresAt: &expiresAt, CreatedBy: "test",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"io.github.alice/notes"}, quarantined)
+
+		claims := &auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "alice", Permissions: []auth.Permission{publish("io.github.alice/*")}}
+		err = registry.CheckIdentityBan(context.Background(), claims)
+		assert.ErrorIs(t, err, auth.ErrIdentityBanned)
+		assert.Contains(t, err.Error(), "Squatting")
+
+		// Bans that have lapsed no longer apply
+		lapsedAt := time.Now().Add(-time.Minute)
+		_, err = db.PutIdentityBan(context.Background(), nil, database.IdentityBan{
+			Kind: database.BanKindGitHub, Value: "bob", Reason: "Spam", ExpiresAt: &lapsedAt, CreatedBy: "test",
+		})
+		require.NoError(t, err)
+		assert.NoError(t, registry.CheckIdentityBan(context.Background(), &auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "bob"}))
+
+		// Servers are restored once their ban expires
+		_, err = db.PutIdentityBan(context.Background(), nil, database.IdentityBan{
+			Kind: database.BanKindNamespace, Value: "io.github.alice", Reason: "Squatting", ExpiresAt: &lapsedAt, CreatedBy: "test",
+		})
+		require.NoError(t, err)
+		released, err := registry.ReleaseExpiredBans(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, released)
+		server, err := registry.GetServerByName(context.Background(), "io.github.alice/notes", false)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, server.Meta.Official.Status)
+
+		restored, err := registry.UnbanIdentity(context.Background(), database.BanKindNamespace, "io.github.alice")
+		require.NoError(t, err)
+		assert.Empty(t, restored)
+	})
+
+	t.Run("servers held by several bans", func(t *testing.T) {
+		_, quarantined, err := registry.BanIdentity(context.Background(), database.IdentityBan{
+			Kind: database.BanKindNamespace, Value: "io.github.Spammer", Reason: "Spam", CreatedBy: "test",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"io.github.Spammer/weather"}, quarantined)
+
+		restored, err := registry.UnbanIdentity(context.Background(), database.BanKindNamespace, "io.github.Spammer")
+		require.NoError(t, err)
+		assert.Empty(t, restored, "the GitHub ban still holds the server")
+		server, err := registry.GetServerByName(context.Background(), "io.github.Spammer/weather", true)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusQuarantined, server.Meta.Official.Status)
+	})
+
+	t.Run("lifting bans", func(t *testing.T) {
+		w := do(http.MethodDelete, "/v0/admin/bans/github/Spammer", adminToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		w = do(http.MethodDelete, "/v0/admin/bans/github/Spammer", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		for _, name := range []string{"com.example/tool", "io.github.Spammer/weather"} {
+			server, err := registry.GetServerByName(context.Background(), name, false)
+			require.NoError(t, err, "lifting the ban restores %s", name)
+			assert.Equal(t, model.StatusActive, server.Meta.Official.Status)
+		}
+
+		w = publishServer(spammerToken, "com.example/other", "1.0.0")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
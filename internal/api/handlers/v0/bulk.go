@@ -0,0 +1,172 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// BulkStatusBody represents the request body for changing the status of every server in a namespace
+type BulkStatusBody struct {
+	Namespace     string  `json:"namespace" required:"true" doc:"Namespace whose servers are updated" example:"io.github.spammer"`
+	Status        string  `json:"status" required:"true" enum:"active,deprecated,deleted" doc:"Status set on every version"`
+	StatusMessage *string `json:"statusMessage,omitempty" maxLength:"500" doc:"Optional message explaining the status change" example:"Quarantined pending abuse review"`
+}
+
+// BulkRevalidateBody represents the request body for re-running validation across servers
+type BulkRevalidateBody struct {
+	Namespace string `json:"namespace,omitempty" doc:"Only revalidate servers in this namespace; omit to revalidate every server" example:"io.github.octocat"`
+}
+
+// BulkTransferBody represents the request body for moving every server in a namespace to another namespace
+type BulkTransferBody struct {
+	Namespace    string `json:"namespace" required:"true" doc:"Namespace the servers are moved from" example:"io.github.old-org"`
+	NewNamespace string `json:"newNamespace" required:"true" doc:"Namespace the servers are moved to" example:"io.github.new-org"`
+}
+
+// BulkJobInput represents the input for queueing a bulk job
+type BulkJobInput[T any] struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Body          T      `body:""`
+}
+
+// GetBulkJobInput represents the input for retrieving a bulk job
+type GetBulkJobInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ID            int64  `path:"id" doc:"Job ID" example:"1"`
+}
+
+// ListBulkJobsInput represents the input for listing bulk jobs
+type ListBulkJobsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Limit         int    `query:"limit" doc:"Number of jobs to return" default:"50" minimum:"1" maximum:"100"`
+}
+
+// BulkJobListResponse lists bulk jobs, newest first
+type BulkJobListResponse struct {
+	Jobs []database.BulkJob `json:"jobs" doc:"Bulk jobs, newest first"`
+}
+
+// RegisterBulkEndpoints registers the admin endpoints for queueing bulk jobs and following their progress
+func RegisterBulkEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	registerBulkJob(api, pathPrefix, registry, jwtManager, database.BulkJobStatus, huma.Operation{
+		Summary:     "Change the status of every server in a namespace",
+		Description: "Queue a job that sets the status and status message of every version of every server in a namespace, for example to quarantine a namespace publishing spam. Returns immediately; follow progress with the returned job ID. Requires admin permissions.",
+	}, func(body BulkStatusBody) database.BulkJobParams {
+		return database.BulkJobParams{Namespace: body.Namespace, Status: body.Status, StatusMessage: body.StatusMessage}
+	})
+
+	registerBulkJob(api, pathPrefix, registry, jwtManager, database.BulkJobRevalidate, huma.Operation{
+		Summary:     "Re-run validation across servers",
+		Description: "Queue a job that re-runs publish validation, including package registry checks when enabled, against the latest version of every listed server, optionally limited to a namespace. Servers are not changed; failures are recorded on the job. Requires admin permissions.",
+	}, func(body BulkRevalidateBody) database.BulkJobParams {
+		return database.BulkJobParams{Namespace: body.Namespace}
+	})
+
+	registerBulkJob(api, pathPrefix, registry, jwtManager, database.BulkJobTransfer, huma.Operation{
+		Summary:     "Move every server in a namespace to another namespace",
+		Description: "Queue a job that copies every version of every server in a namespace to the same name under a new namespace, keeping publish times, status and provenance, then deletes the originals with a message pointing at the new name. Use after a GitHub organization or domain rename. Requires admin permissions.",
+	}, func(body BulkTransferBody) database.BulkJobParams {
+		return database.BulkJobParams{Namespace: body.Namespace, NewNamespace: body.NewNamespace}
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-bulk-jobs" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/bulk/jobs",
+		Summary:     "List bulk jobs",
+		Description: "List the most recently queued bulk jobs with their progress, newest first. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListBulkJobsInput) (*Response[BulkJobListResponse], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		jobs, err := registry.ListBulkJobs(ctx, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list bulk jobs", err)
+		}
+
+		body := BulkJobListResponse{Jobs: make([]database.BulkJob, 0, len(jobs))}
+		for _, job := range jobs {
+			body.Jobs = append(body.Jobs, *job)
+		}
+		return &Response[BulkJobListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bulk-job" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/bulk/jobs/{id}",
+		Summary:     "Get bulk job progress",
+		Description: "Return a bulk job's state, how many servers it has processed and the first failures. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *GetBulkJobInput) (*Response[database.BulkJob], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		job, err := registry.GetBulkJob(ctx, input.ID)
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Bulk job not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to get bulk job", err)
+		}
+		return &Response[database.BulkJob]{Body: *job}, nil
+	})
+}
+
+// registerBulkJob registers the endpoint queueing one kind of bulk job, converting its body into job parameters
+func registerBulkJob[T any](api huma.API, pathPrefix string, registry service.RegistryService, jwtManager *auth.JWTManager, kind string, op huma.Operation, params func(T) database.BulkJobParams) {
+	op.OperationID = "bulk-" + kind + strings.ReplaceAll(pathPrefix, "/", "-")
+	op.Method = http.MethodPost
+	op.Path = pathPrefix + "/admin/bulk/" + kind
+	op.DefaultStatus = http.StatusAccepted
+	op.Tags = []string{"admin"}
+	op.Security = []map[string][]string{
+		{"bearer": {}},
+	}
+
+	huma.Register(api, op, func(ctx context.Context, input *BulkJobInput[T]) (*Response[database.BulkJob], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		job, err := registry.EnqueueBulkJob(ctx, kind, params(input.Body), string(claims.AuthMethod)+":"+claims.AuthMethodSubject)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidBulkJob) {
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest(err.Error()))
+			}
+			return nil, huma.Error500InternalServerError("Failed to queue bulk job", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionBulkJob,
+			Namespace: job.Params.Namespace,
+			Resource:  "bulk_job:" + strconv.FormatInt(job.ID, 10),
+			Details:   map[string]any{"kind": job.Kind},
+		})
+
+		return &Response[database.BulkJob]{Body: *job}, nil
+	})
+}
@@ -0,0 +1,177 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestBulkEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	ctx := context.Background()
+
+	for _, server := range []struct{ name, version string }{
+		{"io.github.old-org/weather", "1.0.0"},
+		{"io.github.old-org/weather", "1.1.0"},
+		{"io.github.old-org/notes", "1.0.0"},
+		{"io.github.other/notes", "1.0.0"},
+	} {
+		_, err := registry.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        server.name,
+			Description: "Test server",
+			Version:     server.version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterBulkEndpoints(api, "/v0", registry, cfg)
+
+	token := func(permissions []auth.Permission) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(ctx, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "admin",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	adminToken := token([]auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}})
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	// enqueue queues a job, runs it as the bulk job worker would and returns its final progress
+	enqueue := func(kind string, body any) database.BulkJob {
+		w := do(http.MethodPost, "/v0/admin/bulk/"+kind, adminToken, body)
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		var queued database.BulkJob
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &queued))
+		assert.Equal(t, database.BulkJobQueued, queued.State)
+		assert.Equal(t, "github-at:admin", queued.CreatedBy)
+
+		ran, err := registry.RunNextBulkJob(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, ran)
+		assert.Equal(t, queued.ID, ran.ID)
+
+		w = do(http.MethodGet, fmt.Sprintf("/v0/admin/bulk/jobs/%d", queued.ID), adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var job database.BulkJob
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+		return job
+	}
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/admin/bulk/status", token([]auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.old-org/*"}}),
+			map[string]any{"namespace": "io.github.old-org", "status": "deleted"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects invalid parameters", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/admin/bulk/status", adminToken, map[string]any{"namespace": "io.github.old-org", "status": "active", "statusMessage": "back"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = do(http.MethodPost, "/v0/admin/bulk/transfer", adminToken, map[string]any{"namespace": "io.github.old-org", "newNamespace": "io.github.old-org"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		job := enqueue(database.BulkJobStatus, map[string]any{"namespace": "io.github.old-org", "status": "deprecated", "statusMessage": "Under review"})
+		assert.Equal(t, database.BulkJobSucceeded, job.State)
+		assert.Equal(t, 2, job.Total)
+		assert.Equal(t, 2, job.Processed)
+		assert.Zero(t, job.Failed)
+		require.NotNil(t, job.FinishedAt)
+
+		versions, err := registry.GetAllVersionsByServerName(ctx, "io.github.old-org/weather", false)
+		require.NoError(t, err)
+		for _, version := range versions {
+			assert.Equal(t, model.StatusDeprecated, version.Meta.Official.Status)
+		}
+		other, err := registry.GetServerByName(ctx, "io.github.other/notes", false)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, other.Meta.Official.Status, "other namespaces are untouched")
+	})
+
+	t.Run("revalidate", func(t *testing.T) {
+		job := enqueue(database.BulkJobRevalidate, map[string]any{})
+		assert.Equal(t, database.BulkJobSucceeded, job.State)
+		assert.Equal(t, 3, job.Total)
+		assert.Zero(t, job.Failed)
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		job := enqueue(database.BulkJobTransfer, map[string]any{"namespace": "io.github.old-org", "newNamespace": "io.github.new-org"})
+		assert.Equal(t, database.BulkJobSucceeded, job.State)
+		assert.Equal(t, 2, job.Processed)
+		assert.Zero(t, job.Failed, job.Failures)
+
+		moved, err := registry.GetAllVersionsByServerName(ctx, "io.github.new-org/weather", false)
+		require.NoError(t, err)
+		require.Len(t, moved, 2)
+		latest, err := registry.GetServerByName(ctx, "io.github.new-org/weather", false)
+		require.NoError(t, err)
+		assert.Equal(t, "1.1.0", latest.Server.Version)
+		assert.Equal(t, model.StatusDeprecated, latest.Meta.Official.Status, "status is kept")
+
+		original, err := registry.GetServerByName(ctx, "io.github.old-org/weather", true)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDeleted, original.Meta.Official.Status)
+		require.NotNil(t, original.Meta.Official.StatusMessage)
+		assert.Equal(t, "Moved to io.github.new-org/weather", *original.Meta.Official.StatusMessage)
+	})
+
+	t.Run("lists jobs newest first", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/admin/bulk/jobs?limit=2", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.BulkJobListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Jobs, 2)
+		assert.Equal(t, database.BulkJobTransfer, list.Jobs[0].Kind)
+
+		w = do(http.MethodGet, "/v0/admin/bulk/jobs/999", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("nothing queued", func(t *testing.T) {
+		job, err := registry.RunNextBulkJob(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, job)
+	})
+}
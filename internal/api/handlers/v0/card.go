@@ -0,0 +1,268 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/install"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Size of the embed HTML returned by the oEmbed endpoint, unless the consumer asks for a smaller one
+const (
+	oEmbedWidth  = 480
+	oEmbedHeight = 180
+)
+
+// ServerCard is a compact summary of a server version for embedding in documentation sites and chat clients
+type ServerCard struct {
+	Name          string            `json:"name" doc:"Server name" example:"io.github.user/weather"`
+	Title         string            `json:"title,omitempty" doc:"Human-readable title" example:"Weather"`
+	Description   string            `json:"description" doc:"Server description"`
+	Version       string            `json:"version" doc:"Server version" example:"1.0.2"`
+	Status        string            `json:"status" enum:"active,deprecated,deleted" doc:"Server version status"`
+	IconURL       string            `json:"iconUrl,omitempty" doc:"URL of the server's icon for light backgrounds, or its first icon"`
+	WebsiteURL    string            `json:"websiteUrl,omitempty" doc:"Server homepage"`
+	RepositoryURL string            `json:"repositoryUrl,omitempty" doc:"Source code repository"`
+	URL           string            `json:"url" doc:"Registry URL of the server version's details" example:"https://registry.example.com/v0.1/servers/io.github.user%2Fweather/versions/1.0.2"`
+	InstallLinks  map[string]string `json:"installLinks,omitempty" doc:"One-click install links, by client, for clients that support them"`
+}
+
+// OEmbedInput represents the input for the oEmbed endpoint
+type OEmbedInput struct {
+	URL       string `query:"url" required:"true" doc:"Registry URL of a server version, its card, or its latest version" example:"https://registry.example.com/v0.1/servers/io.github.user%2Fweather/versions/latest"`
+	Format    string `query:"format" required:"false" doc:"Response format; only json is supported" example:"json"`
+	MaxWidth  int    `query:"maxwidth" required:"false" minimum:"0" doc:"Maximum width of the embed in pixels"`
+	MaxHeight int    `query:"maxheight" required:"false" minimum:"0" doc:"Maximum height of the embed in pixels"`
+}
+
+// OEmbedResponse is an oEmbed 1.0 rich response
+type OEmbedResponse struct {
+	Version      string `json:"version" example:"1.0"`
+	Type         string `json:"type" example:"rich"`
+	Title        string `json:"title" doc:"Server title or name"`
+	ProviderName string `json:"provider_name" example:"MCP Registry"`
+	ProviderURL  string `json:"provider_url" doc:"Registry base URL"`
+	HTML         string `json:"html" doc:"Self-contained HTML summarizing the server version"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+}
+
+// RegisterCardEndpoints registers the server card and oEmbed endpoints
+func RegisterCardEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-card" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/card",
+		Summary:     "Get an embeddable card for an MCP server version",
+		Description: "Get a compact summary of a server version, with its icon and one-click install links, for embedding registry entries in documentation sites and chat clients. Use the special version 'latest' to get the latest version.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*CacheableResponse[ServerCard], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid version encoding", err))
+		}
+
+		serverResponse, err := getServerVersion(ctx, registry, serverName, version, false)
+		if err != nil {
+			return nil, err
+		}
+		card := newServerCard(serverResponse, strings.TrimSuffix(cfg.PublicURL, "/")+pathPrefix)
+		return newCacheableResponse(card, cdn.KeysForServer(serverName)), nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-oembed" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/oembed",
+		Summary:     "Get an oEmbed representation of an MCP server version",
+		Description: "oEmbed provider endpoint returning a rich embed for a registry URL of a server version or its card.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *OEmbedInput) (*CacheableResponse[OEmbedResponse], error) {
+		if input.Format != "" && input.Format != "json" {
+			return nil, huma.NewError(http.StatusNotImplemented, "Only the json format is supported")
+		}
+		embedded, err := url.Parse(input.URL)
+		if err != nil || (embedded.Scheme != "http" && embedded.Scheme != "https") || embedded.Host == "" {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("url must be an absolute registry URL"))
+		}
+		serverName, version, ok := parseServerVersionPath(embedded.EscapedPath())
+		if !ok {
+			return nil, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("url is not a server version URL"))
+		}
+
+		serverResponse, err := getServerVersion(ctx, registry, serverName, version, false)
+		if err != nil {
+			return nil, err
+		}
+
+		// Links point at the registry the consumer asked about unless a public URL is configured
+		baseURL := strings.TrimSuffix(cfg.PublicURL, "/")
+		if baseURL == "" {
+			baseURL = embedded.Scheme + "://" + embedded.Host
+		}
+		embed, err := newOEmbedResponse(newServerCard(serverResponse, baseURL+pathPrefix), baseURL, input.MaxWidth, input.MaxHeight)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to render embed", err)
+		}
+		return newCacheableResponse(*embed, cdn.KeysForServer(serverName)), nil
+	})
+}
+
+// getServerVersion retrieves a server version, or its latest version if version is "latest". A name the server
+// was renamed from is looked up under the server's current name.
+func getServerVersion(ctx context.Context, registry service.RegistryService, serverName, version string, includeDeleted bool) (*apiv0.ServerResponse, error) {
+	lookup := func(name string) (*apiv0.ServerResponse, error) {
+		if version == "latest" {
+			return registry.GetServerByName(ctx, name, includeDeleted)
+		}
+		return registry.GetServerByNameAndVersion(ctx, name, version, includeDeleted)
+	}
+	serverResponse, err := lookup(serverName)
+	if errors.Is(err, service.ErrNotFound) {
+		if alias, aliasErr := registry.GetServerAlias(ctx, serverName); aliasErr == nil {
+			serverResponse, err = lookup(alias.ServerName)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrNotFound) {
+			return nil, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("Server not found"))
+		}
+		return nil, huma.Error500InternalServerError("Failed to get server details", err)
+	}
+	return serverResponse, nil
+}
+
+// parseServerVersionPath extracts the server name and version from a registry path such as
+// /v0.1/servers/{serverName}/versions/{version}, optionally followed by /card
+func parseServerVersionPath(path string) (string, string, bool) {
+	_, rest, found := strings.Cut(path, "/servers/")
+	if !found {
+		return "", "", false
+	}
+	rest = strings.TrimSuffix(strings.TrimSuffix(rest, "/"), "/card")
+	escapedName, escapedVersion, found := strings.Cut(rest, "/versions/")
+	if !found || escapedName == "" || escapedVersion == "" || strings.Contains(escapedVersion, "/") {
+		return "", "", false
+	}
+	serverName, err := url.PathUnescape(escapedName)
+	if err != nil {
+		return "", "", false
+	}
+	version, err := url.PathUnescape(escapedVersion)
+	if err != nil {
+		return "", "", false
+	}
+	return serverName, version, true
+}
+
+// newServerCard summarizes a server version. apiBaseURL is the registry's URL including the API version
+// prefix, or just the prefix to link relative to the registry.
+func newServerCard(serverResponse *apiv0.ServerResponse, apiBaseURL string) ServerCard {
+	server := serverResponse.Server
+	card := ServerCard{
+		Name:        server.Name,
+		Title:       server.Title,
+		Description: server.Description,
+		Version:     server.Version,
+		WebsiteURL:  server.WebsiteURL,
+		URL:         fmt.Sprintf("%s/servers/%s/versions/%s", apiBaseURL, url.PathEscape(server.Name), url.PathEscape(server.Version)),
+	}
+	if serverResponse.Meta.Official != nil {
+		card.Status = string(serverResponse.Meta.Official.Status)
+	}
+	if server.Repository != nil {
+		card.RepositoryURL = server.Repository.URL
+	}
+	if len(server.Icons) > 0 {
+		card.IconURL = server.Icons[0].Src
+	}
+	for _, icon := range server.Icons {
+		if icon.Theme == nil || *icon.Theme == "light" {
+			card.IconURL = icon.Src
+			break
+		}
+	}
+
+	for _, client := range []string{"vscode", "cursor"} {
+		snippet, err := install.Render(&server, client, "")
+		if err == nil && snippet.DeepLink != "" {
+			if card.InstallLinks == nil {
+				card.InstallLinks = map[string]string{}
+			}
+			card.InstallLinks[client] = snippet.DeepLink
+		}
+	}
+	return card
+}
+
+var oEmbedTemplate = template.Must(template.New("oembed").Parse(
+	`<blockquote class="mcp-registry-card" style="max-width:{{.Width}}px">` +
+		`{{if .Card.IconURL}}<img src="{{.Card.IconURL}}" alt="" width="32" height="32"> {{end}}` +
+		`<a href="{{.Card.URL}}"><strong>{{.Title}}</strong></a> <code>{{.Card.Version}}</code>` +
+		`<p>{{.Card.Description}}</p>` +
+		`{{range .InstallLinks}}<a href="{{.Link}}">Install in {{.Client}}</a> {{end}}` +
+		`</blockquote>`))
+
+// newOEmbedResponse renders a card as an oEmbed rich response no larger than maxWidth by maxHeight, if set
+func newOEmbedResponse(card ServerCard, baseURL string, maxWidth, maxHeight int) (*OEmbedResponse, error) {
+	title := card.Title
+	if title == "" {
+		title = card.Name
+	}
+	width, height := oEmbedWidth, oEmbedHeight
+	if maxWidth > 0 {
+		width = min(width, maxWidth)
+	}
+	if maxHeight > 0 {
+		height = min(height, maxHeight)
+	}
+
+	// Install links use client URL schemes, which html/template would otherwise replace as unsafe
+	type installLink struct {
+		Client string
+		Link   template.URL
+	}
+	var installLinks []installLink
+	for _, client := range slices.Sorted(maps.Keys(card.InstallLinks)) {
+		installLinks = append(installLinks, installLink{client, template.URL(card.InstallLinks[client])}) //nolint:gosec // built by install.Render, not taken from input
+	}
+
+	var html strings.Builder
+	err := oEmbedTemplate.Execute(&html, struct {
+		Card         ServerCard
+		Title        string
+		Width        int
+		InstallLinks []installLink
+	}{card, title, width, installLinks})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OEmbedResponse{
+		Version:      "1.0",
+		Type:         "rich",
+		Title:        title,
+		ProviderName: "MCP Registry",
+		ProviderURL:  baseURL,
+		HTML:         html.String(),
+		Width:        width,
+		Height:       height,
+	}, nil
+}
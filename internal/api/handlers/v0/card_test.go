@@ -0,0 +1,100 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestCardEndpoints(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	dark := "dark"
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Title:       "Weather",
+		Description: "Weather <forecasts> & alerts",
+		Version:     "1.0.0",
+		Repository:  &model.Repository{URL: "https://github.com/example/weather", Source: "github"},
+		Icons: []model.Icon{
+			{Src: "https://example.com/dark.png", Theme: &dark},
+			{Src: "https://example.com/light.png"},
+		},
+		Remotes: []model.Transport{{Type: model.TransportTypeStreamableHTTP, URL: "https://weather.example.com/mcp"}},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterCardEndpoints(api, "/v0.1", registryService, cfg)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("card", func(t *testing.T) {
+		w := get("/v0.1/servers/com.example%2Fweather/versions/latest/card")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Header().Get("Surrogate-Key"), "com.example/weather")
+
+		var card v0.ServerCard
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &card))
+		assert.Equal(t, "com.example/weather", card.Name)
+		assert.Equal(t, "1.0.0", card.Version)
+		assert.Equal(t, "active", card.Status)
+		assert.Equal(t, "https://example.com/light.png", card.IconURL, "icons for light backgrounds are preferred")
+		assert.Equal(t, "https://github.com/example/weather", card.RepositoryURL)
+		assert.Equal(t, "/v0.1/servers/com.example%2Fweather/versions/1.0.0", card.URL)
+		assert.Contains(t, card.InstallLinks["vscode"], "vscode:")
+	})
+
+	t.Run("missing server", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get("/v0.1/servers/com.example%2Fmissing/versions/latest/card").Code)
+	})
+
+	t.Run("oembed", func(t *testing.T) {
+		embedded := "https://registry.example.com/v0.1/servers/com.example%2Fweather/versions/latest"
+		w := get("/v0.1/servers/oembed?maxwidth=300&url=" + url.QueryEscape(embedded))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var embed v0.OEmbedResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &embed))
+		assert.Equal(t, "1.0", embed.Version)
+		assert.Equal(t, "rich", embed.Type)
+		assert.Equal(t, "Weather", embed.Title)
+		assert.Equal(t, "https://registry.example.com", embed.ProviderURL)
+		assert.Equal(t, 300, embed.Width)
+		assert.Contains(t, embed.HTML, `href="https://registry.example.com/v0.1/servers/com.example%2Fweather/versions/1.0.0"`)
+		assert.Contains(t, embed.HTML, "Weather &lt;forecasts&gt; &amp; alerts")
+		assert.Contains(t, embed.HTML, `href="vscode:`)
+	})
+
+	t.Run("oembed errors", func(t *testing.T) {
+		card := url.QueryEscape("https://registry.example.com/v0.1/servers/com.example%2Fweather/versions/1.0.0/card")
+		assert.Equal(t, http.StatusOK, get("/v0.1/servers/oembed?url="+card).Code)
+		assert.Equal(t, http.StatusNotImplemented, get("/v0.1/servers/oembed?format=xml&url="+card).Code)
+		assert.Equal(t, http.StatusNotFound, get("/v0.1/servers/oembed?url="+url.QueryEscape("https://registry.example.com/v0.1/stats")).Code)
+		assert.Equal(t, http.StatusBadRequest, get("/v0.1/servers/oembed?url=%2Fv0.1%2Fservers").Code)
+	})
+}
@@ -0,0 +1,94 @@
+package v0
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/sse"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerChangesInput represents the input for reading the changes feed
+type ServerChangesInput struct {
+	Since int64 `query:"since" doc:"Return changes with a sequence number greater than this value" default:"0" minimum:"0" example:"1024"`
+	Limit int   `query:"limit" doc:"Number of changes per page" default:"100" minimum:"1" maximum:"1000" example:"100"`
+}
+
+// ServerChangesStreamInput represents the input for streaming the changes feed
+type ServerChangesStreamInput struct {
+	Since       int64  `query:"since" doc:"Stream changes with a sequence number greater than this value" default:"0" minimum:"0" example:"1024"`
+	LastEventID int64  `header:"Last-Event-ID" doc:"Sequence number of the last change received, sent by EventSource clients when reconnecting. Takes precedence over since." minimum:"0"`
+	Format      string `query:"format" enum:"cloudevents,feed" default:"cloudevents" doc:"Event format: cloudevents sends cloudevent events holding CloudEvents 1.0 envelopes; feed sends change events holding changes as served by the changes feed"`
+}
+
+// RegisterServerChangesEndpoint registers the changes feed endpoint with a custom path prefix
+func RegisterServerChangesEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	source := service.EventSource(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-changes" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/changes",
+		Summary:     "List server changes",
+		Description: "Get an ordered feed of server version changes for replication. Pass metadata.nextSince as the since parameter to continue reading.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerChangesInput) (*Response[apiv0.ServerChangesResponse], error) {
+		changes, err := registry.ListServerChanges(ctx, input.Since, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get server changes", err)
+		}
+
+		// Convert []*ServerChange to []ServerChange
+		changeValues := make([]apiv0.ServerChange, len(changes))
+		nextSince := input.Since
+		for i, change := range changes {
+			changeValues[i] = *change
+			nextSince = change.Seq
+		}
+
+		return &Response[apiv0.ServerChangesResponse]{
+			Body: apiv0.ServerChangesResponse{
+				Changes: changeValues,
+				Metadata: apiv0.ChangesMetadata{
+					NextSince: nextSince,
+					Count:     len(changes),
+				},
+			},
+		}, nil
+	})
+
+	sse.Register(api, huma.Operation{
+		OperationID: "stream-server-changes" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/changes/stream",
+		Summary:     "Stream server changes",
+		Description: "Stream the changes feed as server-sent events: changes after since, then each change as it is published to any replica. Events are CloudEvents, or changes as served by the changes feed with format=feed. Every event's ID is the change's sequence number, so EventSource clients resume where they left off when they reconnect. The stream may end when a client falls too far behind; reconnect to continue.",
+		Tags:        []string{"servers"},
+	}, map[string]any{
+		"change":     apiv0.ServerChange{},
+		"cloudevent": apiv0.CloudEvent{},
+	}, func(ctx context.Context, input *ServerChangesStreamInput, send sse.Sender) {
+		since := max(input.Since, input.LastEventID)
+		changes, err := registry.SubscribeChanges(ctx, since)
+		if err != nil {
+			// The stream has started, so the client can only learn of this by reconnecting
+			log.Printf("Failed to stream server changes: %v", err)
+			return
+		}
+		for change := range changes {
+			var data any = apiv0.NewServerChangeEvent(change, source)
+			if input.Format == "feed" {
+				data = *change
+			}
+			if err := send(sse.Message{ID: int(change.Seq), Data: data}); err != nil {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package v0_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServerChangesStreamEndpoint(t *testing.T) {
+	registry := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	publish := func(name string) {
+		_, err := registry.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+	publish("com.example/alpha")
+	publish("com.example/beta")
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServerChangesEndpoint(api, "/v0", registry, &config.Config{})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v0/servers/changes/stream?format=feed", nil)
+	require.NoError(t, err)
+	// Reconnecting clients resume after the last event they received
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	lines := bufio.NewScanner(resp.Body)
+	next := func() (string, apiv0.ServerChange) {
+		t.Helper()
+		var id string
+		for lines.Scan() {
+			line := lines.Text()
+			if value, ok := strings.CutPrefix(line, "id: "); ok {
+				id = value
+			}
+			if value, ok := strings.CutPrefix(line, "data: "); ok {
+				var change apiv0.ServerChange
+				require.NoError(t, json.Unmarshal([]byte(value), &change))
+				return id, change
+			}
+		}
+		require.FailNow(t, "stream ended", lines.Err())
+		return "", apiv0.ServerChange{}
+	}
+
+	id, change := next()
+	assert.Equal(t, "2", id)
+	assert.Equal(t, "com.example/beta", change.Server.Server.Name)
+
+	publish("com.example/gamma")
+	id, change = next()
+	assert.Equal(t, "3", id)
+	assert.Equal(t, "com.example/gamma", change.Server.Server.Name)
+}
+
+func TestServerChangesStreamCloudEvents(t *testing.T) {
+	cfg := &config.Config{EnableRegistryValidation: false, PublicURL: "https://registry.example.com"}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	_, err := registry.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/alpha",
+		Description: "Test server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServerChangesEndpoint(api, "/v0", registry, cfg)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v0/servers/changes/stream", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	lines := bufio.NewScanner(resp.Body)
+	var eventName string
+	for lines.Scan() {
+		line := lines.Text()
+		if value, ok := strings.CutPrefix(line, "event: "); ok {
+			eventName = value
+		}
+		if value, ok := strings.CutPrefix(line, "data: "); ok {
+			var event apiv0.CloudEvent
+			require.NoError(t, json.Unmarshal([]byte(value), &event))
+			assert.Equal(t, "cloudevent", eventName)
+			assert.Equal(t, "1.0", event.SpecVersion)
+			assert.Equal(t, "1", event.ID)
+			assert.Equal(t, "https://registry.example.com", event.Source)
+			assert.Equal(t, apiv0.EventTypeServerPublished, event.Type)
+			assert.Equal(t, "com.example/alpha@1.0.0", event.Subject)
+			return
+		}
+	}
+	require.FailNow(t, "stream ended", lines.Err())
+}
@@ -0,0 +1,247 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// CollectionBody represents the request body for creating or replacing a collection
+type CollectionBody struct {
+	Title       string                    `json:"title" required:"true" minLength:"1" maxLength:"100" doc:"Collection title" example:"Best database MCP servers"`
+	Description string                    `json:"description,omitempty" maxLength:"1000" doc:"What the collection gathers and how servers were chosen"`
+	Items       []database.CollectionItem `json:"items" required:"true" maxItems:"200" doc:"Servers in the collection, in the order clients should show them. Each server may appear once and must be published."`
+}
+
+// CreateCollectionInput represents the input for creating a collection
+type CreateCollectionInput struct {
+	Authorization string         `header:"Authorization" doc:"Registry JWT token"`
+	Body          CollectionBody `body:""`
+}
+
+// UpdateCollectionInput represents the input for replacing a collection
+type UpdateCollectionInput struct {
+	Authorization string         `header:"Authorization" doc:"Registry JWT token of the curator or an admin"`
+	ID            int64          `path:"id" doc:"Collection ID" example:"1"`
+	Body          CollectionBody `body:""`
+}
+
+// DeleteCollectionInput represents the input for deleting a collection
+type DeleteCollectionInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the curator or an admin"`
+	ID            int64  `path:"id" doc:"Collection ID" example:"1"`
+}
+
+// GetCollectionInput represents the input for retrieving a collection
+type GetCollectionInput struct {
+	ID int64 `path:"id" doc:"Collection ID" example:"1"`
+}
+
+// ListCollectionsInput represents the input for listing collections
+type ListCollectionsInput struct {
+	Owner  string `query:"owner" doc:"Only list collections curated by this identity" required:"false" example:"github-at:octocat"`
+	Cursor string `query:"cursor" doc:"Pagination cursor" required:"false"`
+	Limit  int    `query:"limit" doc:"Number of collections per page" default:"30" minimum:"1" maximum:"100"`
+}
+
+// CollectionListResponse is a page of collections
+type CollectionListResponse struct {
+	Collections []database.Collection  `json:"collections" doc:"Collections, oldest first"`
+	Metadata    CollectionListMetadata `json:"metadata"`
+}
+
+// CollectionListMetadata holds pagination information for collection listings
+type CollectionListMetadata struct {
+	NextCursor string `json:"nextCursor,omitempty" doc:"Pagination cursor for retrieving the next page of results"`
+	Count      int    `json:"count" doc:"Number of collections in the current page"`
+}
+
+// RegisterCollectionEndpoints registers the endpoints for user-curated server collections. Anyone can browse
+// collections; any authenticated user can create one, and only its curator or an admin can change or delete it.
+func RegisterCollectionEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	security := []map[string][]string{{"bearer": {}}}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-collections" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/collections",
+		Summary:     "List server collections",
+		Description: "List collections of servers curated by registry users, oldest first, optionally only those of one curator.",
+		Tags:        []string{"collections"},
+	}, func(ctx context.Context, input *ListCollectionsInput) (*Response[CollectionListResponse], error) {
+		var afterID int64
+		if input.Cursor != "" {
+			var err error
+			afterID, err = strconv.ParseInt(input.Cursor, 10, 64)
+			if err != nil || afterID < 0 {
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid cursor parameter"))
+			}
+		}
+
+		collections, err := registry.ListCollections(ctx, input.Owner, afterID, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list collections", err)
+		}
+
+		body := CollectionListResponse{
+			Collections: make([]database.Collection, 0, len(collections)),
+			Metadata:    CollectionListMetadata{Count: len(collections)},
+		}
+		for _, collection := range collections {
+			body.Collections = append(body.Collections, *collection)
+		}
+		if len(collections) == input.Limit {
+			body.Metadata.NextCursor = strconv.FormatInt(collections[len(collections)-1].ID, 10)
+		}
+		return &Response[CollectionListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-collection" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/collections/{id}",
+		Summary:     "Get a server collection",
+		Description: "Get a collection with its servers in the curator's order. Clients following a collection can poll this and compare updatedAt.",
+		Tags:        []string{"collections"},
+	}, func(ctx context.Context, input *GetCollectionInput) (*Response[database.Collection], error) {
+		collection, err := registry.GetCollection(ctx, input.ID)
+		if err != nil {
+			return nil, collectionError("Failed to get collection", err)
+		}
+		return &Response[database.Collection]{Body: *collection}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-collection" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodPost,
+		Path:          pathPrefix + "/collections",
+		Summary:       "Create a server collection",
+		Description:   "Create a collection of published servers in a chosen order, attributed to the authenticated identity.",
+		Tags:          []string{"collections"},
+		Security:      security,
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *CreateCollectionInput) (*Response[database.Collection], error) {
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		collection, err := registry.CreateCollection(ctx, database.Collection{
+			Title:       input.Body.Title,
+			Description: input.Body.Description,
+			Items:       input.Body.Items,
+			Owner:       publishIdentity(claims),
+		})
+		if err != nil {
+			return nil, collectionError("Failed to create collection", err)
+		}
+
+		recordCollectionAudit(ctx, registry, claims, database.AuditActionCollectionCreate, collection)
+		return &Response[database.Collection]{Body: *collection}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-collection" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/collections/{id}",
+		Summary:     "Replace a server collection",
+		Description: "Replace a collection's title, description and servers, including their order. Only the curator or an admin can change a collection.",
+		Tags:        []string{"collections"},
+		Security:    security,
+	}, func(ctx context.Context, input *UpdateCollectionInput) (*Response[database.Collection], error) {
+		claims, collection, err := authorizeCollection(ctx, jwtManager, registry, input.Authorization, input.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		collection.Title = input.Body.Title
+		collection.Description = input.Body.Description
+		collection.Items = input.Body.Items
+		if err := registry.UpdateCollection(ctx, collection); err != nil {
+			return nil, collectionError("Failed to update collection", err)
+		}
+
+		recordCollectionAudit(ctx, registry, claims, database.AuditActionCollectionUpdate, collection)
+		return &Response[database.Collection]{Body: *collection}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-collection" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/collections/{id}",
+		Summary:       "Delete a server collection",
+		Description:   "Delete a collection. Only the curator or an admin can delete a collection.",
+		Tags:          []string{"collections"},
+		Security:      security,
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *DeleteCollectionInput) (*struct{}, error) {
+		claims, collection, err := authorizeCollection(ctx, jwtManager, registry, input.Authorization, input.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteCollection(ctx, collection.ID); err != nil {
+			return nil, collectionError("Failed to delete collection", err)
+		}
+
+		recordCollectionAudit(ctx, registry, claims, database.AuditActionCollectionDelete, collection)
+		return nil, nil
+	})
+}
+
+// authorizeCollection authenticates the caller and retrieves a collection they may change: their own, or any
+// collection for admins
+func authorizeCollection(ctx context.Context, jwtManager *auth.JWTManager, registry service.RegistryService, authHeader string, id int64) (*auth.JWTClaims, *database.Collection, error) {
+	claims, err := authenticateBearer(ctx, jwtManager, authHeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	collection, err := registry.GetCollection(ctx, id)
+	if err != nil {
+		return nil, nil, collectionError("Failed to get collection", err)
+	}
+	if collection.Owner != publishIdentity(claims) && !jwtManager.IsAdmin(claims.Permissions) {
+		return nil, nil, withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("Only the collection's curator can change it"))
+	}
+	return claims, collection, nil
+}
+
+// collectionError converts a collection service error into an API error
+func collectionError(message string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidCollection):
+		return withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest(err.Error()))
+	case errors.Is(err, service.ErrNotFound):
+		return withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Collection not found"))
+	default:
+		return huma.Error500InternalServerError(message, err)
+	}
+}
+
+// recordCollectionAudit records a change to a collection in the audit log
+func recordCollectionAudit(ctx context.Context, registry service.RegistryService, claims *auth.JWTClaims, action string, collection *database.Collection) {
+	servers := make([]string, 0, len(collection.Items))
+	for _, item := range collection.Items {
+		servers = append(servers, item.ServerName)
+	}
+	RecordAudit(ctx, registry, claims, database.AuditEntry{
+		Action:   action,
+		Resource: "collection:" + strconv.FormatInt(collection.ID, 10),
+		Details: map[string]any{
+			"title":   collection.Title,
+			"servers": servers,
+		},
+	})
+}
@@ -0,0 +1,178 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestCollectionEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	for _, name := range []string{"com.example/postgres", "com.example/sqlite", "com.example/redis"} {
+		_, err := registry.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "A test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterCollectionEndpoints(api, "/v0", registry, cfg)
+
+	token := func(subject string, permissions []auth.Permission) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: subject,
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	curatorToken := token("curator", nil)
+	otherToken := token("other", nil)
+	adminToken := token("admin", []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}})
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("requires a token to create", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/collections", "", map[string]any{"title": "Databases", "items": []any{}})
+		assert.NotEqual(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("rejects invalid items", func(t *testing.T) {
+		for _, items := range [][]database.CollectionItem{
+			{{ServerName: "com.example/postgres"}, {ServerName: "com.example/postgres"}},
+			{{ServerName: "com.example/missing"}},
+		} {
+			w := do(http.MethodPost, "/v0/collections", curatorToken, map[string]any{"title": "Databases", "items": items})
+			assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), "INVALID_PARAMETER")
+		}
+	})
+
+	var created database.Collection
+	t.Run("creates a collection owned by the caller", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/collections", curatorToken, map[string]any{
+			"title": "Best database MCP servers",
+			"items": []database.CollectionItem{
+				{ServerName: "com.example/sqlite"},
+				{ServerName: "com.example/postgres", Note: "Read-only mode"},
+			},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.Equal(t, "github-at:curator", created.Owner)
+		require.Len(t, created.Items, 2)
+		assert.Equal(t, "com.example/sqlite", created.Items[0].ServerName)
+	})
+	path := "/v0/collections/" + strconv.FormatInt(created.ID, 10)
+
+	t.Run("only the curator or an admin can change it", func(t *testing.T) {
+		body := map[string]any{"title": "Databases", "items": []database.CollectionItem{{ServerName: "com.example/redis"}}}
+		w := do(http.MethodPut, path, otherToken, body)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "PERMISSION_DENIED")
+
+		w = do(http.MethodPut, path, adminToken, body)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(http.MethodDelete, path, otherToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("reorders items", func(t *testing.T) {
+		w := do(http.MethodPut, path, curatorToken, map[string]any{
+			"title": "Best database MCP servers",
+			"items": []database.CollectionItem{
+				{ServerName: "com.example/postgres"},
+				{ServerName: "com.example/redis"},
+				{ServerName: "com.example/sqlite"},
+			},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(http.MethodGet, path, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var collection database.Collection
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &collection))
+		assert.Equal(t, "github-at:curator", collection.Owner)
+		var names []string
+		for _, item := range collection.Items {
+			names = append(names, item.ServerName)
+		}
+		assert.Equal(t, []string{"com.example/postgres", "com.example/redis", "com.example/sqlite"}, names)
+		assert.False(t, collection.UpdatedAt.Before(created.UpdatedAt))
+	})
+
+	t.Run("lists collections publicly", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/collections", otherToken, map[string]any{"title": "Caches", "items": []database.CollectionItem{{ServerName: "com.example/redis"}}})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var list v0.CollectionListResponse
+		w = do(http.MethodGet, "/v0/collections?limit=1", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Collections, 1)
+		assert.Equal(t, created.ID, list.Collections[0].ID)
+		require.NotEmpty(t, list.Metadata.NextCursor)
+
+		w = do(http.MethodGet, "/v0/collections?cursor="+list.Metadata.NextCursor, "", nil)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Collections, 1)
+		assert.Equal(t, "Caches", list.Collections[0].Title)
+
+		w = do(http.MethodGet, "/v0/collections?owner=github-at:curator", "", nil)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Collections, 1)
+		assert.Equal(t, created.ID, list.Collections[0].ID)
+	})
+
+	t.Run("deletes a collection", func(t *testing.T) {
+		w := do(http.MethodDelete, path, curatorToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = do(http.MethodGet, path, "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
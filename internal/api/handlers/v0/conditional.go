@@ -0,0 +1,95 @@
+package v0
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ConditionalParams are the conditional request headers the server detail endpoints honor, so clients can
+// revalidate a copy they already have without transferring it again
+type ConditionalParams struct {
+	IfNoneMatch     string    `header:"If-None-Match" doc:"ETags of copies the client has; 304 Not Modified is returned when one is current"`
+	IfModifiedSince time.Time `header:"If-Modified-Since" doc:"Time of the client's copy; 304 Not Modified is returned when the resource has not changed since. Ignored when If-None-Match is set."`
+}
+
+// notModified reports whether the client's copy is current. If-None-Match takes precedence over
+// If-Modified-Since, as RFC 9110 requires, since the modification time does not cover every change.
+func (p *ConditionalParams) notModified(headers DetailHeaders) bool {
+	if p.IfNoneMatch != "" {
+		for _, tag := range strings.Split(p.IfNoneMatch, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == headers.ETag {
+				return true
+			}
+		}
+		return false
+	}
+	return !p.IfModifiedSince.IsZero() && !headers.LastModified.After(p.IfModifiedSince)
+}
+
+// DetailHeaders are the headers of a server detail response. HEAD requests return them without the body.
+type DetailHeaders struct {
+	ETag         string    `header:"ETag" doc:"Changes whenever the response body does"`
+	LastModified time.Time `header:"Last-Modified" doc:"When a returned version was last published, edited or changed status"`
+	SurrogateKey string    `header:"Surrogate-Key" doc:"Space-separated cache keys for Fastly-style CDN purging"`
+	CacheTag     string    `header:"Cache-Tag" doc:"Comma-separated cache keys for Cloudflare-style CDN purging"`
+}
+
+// DetailResponse is a server detail response body with its DetailHeaders
+type DetailResponse[T any] struct {
+	DetailHeaders
+	Body T
+}
+
+// newDetailHeaders returns the headers of a detail response with body, which lists servers, tagged with the given
+// surrogate keys. The ETag is a hash of the body as JSON.
+func newDetailHeaders(body any, servers []apiv0.ServerResponse, keys []string) (DetailHeaders, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return DetailHeaders{}, err
+	}
+	sum := sha256.Sum256(data)
+
+	var modified time.Time
+	for _, server := range servers {
+		official := server.Meta.Official
+		if official == nil {
+			continue
+		}
+		for _, t := range []time.Time{official.PublishedAt, official.UpdatedAt, official.StatusChangedAt} {
+			if t.After(modified) {
+				modified = t
+			}
+		}
+	}
+
+	return DetailHeaders{
+		ETag: `"` + hex.EncodeToString(sum[:16]) + `"`,
+		// HTTP dates have second precision
+		LastModified: modified.UTC().Truncate(time.Second),
+		SurrogateKey: cdn.SurrogateKeyHeader(keys),
+		CacheTag:     cdn.CacheTagHeader(keys),
+	}, nil
+}
+
+// notModifiedError is the 304 Not Modified response to a conditional request, carrying the headers of the
+// current response
+func notModifiedError(headers DetailHeaders) error {
+	header := http.Header{"Etag": {headers.ETag}}
+	if !headers.LastModified.IsZero() {
+		header.Set("Last-Modified", headers.LastModified.Format(http.TimeFormat))
+	}
+	if headers.SurrogateKey != "" {
+		header.Set("Surrogate-Key", headers.SurrogateKey)
+		header.Set("Cache-Tag", headers.CacheTag)
+	}
+	return huma.ErrorWithHeaders(huma.Status304NotModified(), header)
+}
@@ -0,0 +1,159 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListServerCurationsInput represents the input for listing curated servers
+type ListServerCurationsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Label         string `query:"label" enum:"featured,official,community" doc:"Only list servers with this label" required:"false" example:"featured"`
+}
+
+// ServerCurationInput represents the input for removing a server's curation
+type ServerCurationInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+}
+
+// PutServerCurationBody represents the request body for curating a server
+type PutServerCurationBody struct {
+	Labels   []string `json:"labels" required:"true" minItems:"1" doc:"Curation labels: featured, official (vendor-verified) or community (community-maintained). A server cannot be both official and community." example:"[\"featured\", \"official\"]"`
+	Position *int     `json:"position,omitempty" minimum:"0" doc:"Position in curated sections, lowest first. Servers without a position follow those with one." example:"1"`
+	Note     string   `json:"note,omitempty" maxLength:"1000" doc:"Why the server was curated, for other operators" example:"Verified with the vendor"`
+}
+
+// PutServerCurationInput represents the input for curating a server
+type PutServerCurationInput struct {
+	Authorization string                `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ServerName    string                `path:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+	Body          PutServerCurationBody `body:""`
+}
+
+// ServerCurationListResponse lists curated servers
+type ServerCurationListResponse struct {
+	Curations []database.ServerCuration `json:"curations" doc:"Server curations, ordered by position and then server name"`
+}
+
+// RegisterCurationEndpoints registers the admin endpoints labelling servers as featured, official or community-maintained
+func RegisterCurationEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	security := []map[string][]string{{"bearer": {}}}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-curations" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/curation",
+		Summary:     "List curated servers",
+		Description: "List the servers operators labelled as featured, official or community-maintained, with their positions and notes. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *ListServerCurationsInput) (*Response[ServerCurationListResponse], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		curations, err := registry.ListServerCurations(ctx, input.Label)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list server curations", err)
+		}
+
+		body := ServerCurationListResponse{Curations: make([]database.ServerCuration, 0, len(curations))}
+		for _, curation := range curations {
+			body.Curations = append(body.Curations, *curation)
+		}
+		return &Response[ServerCurationListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "put-server-curation" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/admin/curation/{serverName}",
+		Summary:     "Curate a server",
+		Description: "Label a published server as featured, official (vendor-verified) or community-maintained, for curated sections in clients. The labels appear in the server's registry metadata and can be filtered and sorted on when listing servers. Replaces any existing curation for the server. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *PutServerCurationInput) (*Response[database.ServerCuration], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		serverName, err := parseScreeningServerName(input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		curation, err := registry.PutServerCuration(ctx, serverName, input.Body.Labels, input.Body.Position, input.Body.Note, string(claims.AuthMethod)+":"+claims.AuthMethodSubject)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidCuration):
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest(err.Error()))
+			case errors.Is(err, service.ErrNotFound):
+				return nil, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("Server not found"))
+			case errors.Is(err, service.ErrInvalidInput):
+				return nil, huma.Error422UnprocessableEntity("Invalid server curation", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to store server curation", err)
+		}
+
+		details := map[string]any{"labels": curation.Labels}
+		if curation.Position != nil {
+			details["position"] = *curation.Position
+		}
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionCuration,
+			Namespace: serverNamespace(serverName),
+			Resource:  serverName,
+			Details:   details,
+		})
+
+		return &Response[database.ServerCuration]{Body: *curation}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-server-curation" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/admin/curation/{serverName}",
+		Summary:       "Remove a server's curation",
+		Description:   "Remove every curation label from a server. Requires admin permissions.",
+		Tags:          []string{"admin"},
+		Security:      security,
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *ServerCurationInput) (*struct{}, error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		serverName, err := parseScreeningServerName(input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteServerCuration(ctx, serverName); err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Server curation not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to remove server curation", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionCuration,
+			Namespace: serverNamespace(serverName),
+			Resource:  serverName,
+			Details:   map[string]any{"labels": []string{}},
+		})
+
+		return nil, nil
+	})
+}
@@ -0,0 +1,150 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestCurationEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	for _, name := range []string{"com.example/alpha", "com.example/beta", "com.example/gamma", "com.example/delta"} {
+		_, err := registry.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "A test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registry, nil)
+	v0.RegisterCurationEndpoints(api, "/v0", registry, cfg)
+
+	token := func(permissions []auth.Permission) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "admin",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	adminToken := token([]auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}})
+	publisherToken := token([]auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}})
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	listNames := func(path string) ([]string, string) {
+		w := do(http.MethodGet, path, "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list apiv0.ServerListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		var names []string
+		for _, server := range list.Servers {
+			names = append(names, server.Server.Name)
+		}
+		return names, list.Metadata.NextCursor
+	}
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := do(http.MethodPut, "/v0/admin/curation/com.example%2Falpha", publisherToken, map[string]any{"labels": []string{"featured"}})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects invalid curations", func(t *testing.T) {
+		w := do(http.MethodPut, "/v0/admin/curation/com.example%2Falpha", adminToken, map[string]any{"labels": []string{"official", "community"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		w = do(http.MethodPut, "/v0/admin/curation/com.example%2Fmissing", adminToken, map[string]any{"labels": []string{"featured"}})
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("curated servers are labelled and sorted", func(t *testing.T) {
+		for name, body := range map[string]map[string]any{
+			"com.example%2Fgamma": {"labels": []string{"featured", "official"}, "position": 1},
+			"com.example%2Fbeta":  {"labels": []string{"featured"}, "position": 2, "note": "Popular"},
+			"com.example%2Falpha": {"labels": []string{"featured", "community"}},
+		} {
+			w := do(http.MethodPut, "/v0/admin/curation/"+name, adminToken, body)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		}
+
+		w := do(http.MethodGet, "/v0/admin/curation?label=official", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.ServerCurationListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Curations, 1)
+		assert.Equal(t, "com.example/gamma", list.Curations[0].ServerName)
+		assert.Equal(t, "github-at:admin", list.Curations[0].UpdatedBy)
+
+		names, _ := listNames("/v0/servers?label=featured")
+		assert.Equal(t, []string{"com.example/alpha", "com.example/beta", "com.example/gamma"}, names)
+
+		names, cursor := listNames("/v0/servers?label=featured&sort=curated&limit=2")
+		assert.Equal(t, []string{"com.example/gamma", "com.example/beta"}, names)
+		require.NotEmpty(t, cursor)
+		names, cursor = listNames("/v0/servers?label=featured&sort=curated&limit=2&cursor=" + cursor)
+		assert.Equal(t, []string{"com.example/alpha"}, names)
+		assert.Empty(t, cursor)
+
+		w = do(http.MethodGet, "/v0/servers/com.example%2Fgamma/versions/latest", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var server apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+		require.NotNil(t, server.Meta.Official.Curation)
+		assert.Equal(t, []string{"featured", "official"}, server.Meta.Official.Curation.Labels)
+	})
+
+	t.Run("curated sort requires a label", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/servers?sort=curated", "", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("removing curations", func(t *testing.T) {
+		w := do(http.MethodDelete, "/v0/admin/curation/com.example%2Falpha", adminToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		w = do(http.MethodDelete, "/v0/admin/curation/com.example%2Falpha", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		names, _ := listNames("/v0/servers?label=community")
+		assert.Empty(t, names)
+	})
+}
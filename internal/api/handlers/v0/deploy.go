@@ -0,0 +1,78 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/deploy"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// KubernetesManifestInput represents the input for rendering a Kubernetes manifest
+type KubernetesManifestInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" doc:"URL-encoded server version, or 'latest'" example:"1.0.0"`
+}
+
+// KubernetesManifestOutput is a rendered multi-document YAML manifest
+type KubernetesManifestOutput struct {
+	ContentType  string `header:"Content-Type"`
+	SurrogateKey string `header:"Surrogate-Key" doc:"Space-separated cache keys for Fastly-style CDN purging"`
+	CacheTag     string `header:"Cache-Tag" doc:"Comma-separated cache keys for Cloudflare-style CDN purging"`
+	Body         []byte
+}
+
+// RegisterDeployEndpoints registers the endpoints rendering deployment manifests with a custom path prefix
+func RegisterDeployEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-kubernetes-manifest" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/deploy/kubernetes",
+		Summary:     "Get Kubernetes manifest for an MCP server",
+		Description: "Render a ready-to-apply Deployment and Service running the server's OCI image as a remote MCP server, using the resources, port and secrets from its deployment hints. Secret values are read from a Secret the manifest does not create.",
+		Tags:        []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Multi-document YAML manifest",
+				Content:     map[string]*huma.MediaType{"application/yaml": {}},
+			},
+		},
+	}, func(ctx context.Context, input *KubernetesManifestInput) (*KubernetesManifestOutput, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid version encoding", err))
+		}
+
+		serverResponse, err := getServerVersion(ctx, registry, serverName, version, false)
+		if err != nil {
+			return nil, err
+		}
+
+		manifest, err := deploy.RenderKubernetes(&serverResponse.Server)
+		if err != nil {
+			if errors.Is(err, deploy.ErrNotDeployable) {
+				return nil, huma.Error422UnprocessableEntity("Server has no OCI package serving MCP over streamable-http or sse", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to render Kubernetes manifest", err)
+		}
+
+		keys := cdn.KeysForServer(serverName)
+		return &KubernetesManifestOutput{
+			ContentType:  "application/yaml",
+			SurrogateKey: cdn.SurrogateKeyHeader(keys),
+			CacheTag:     cdn.CacheTagHeader(keys),
+			Body:         manifest,
+		}, nil
+	})
+}
@@ -0,0 +1,51 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/diff"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerDiffInput represents the input for comparing two versions of a server
+type ServerDiffInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	From       string `query:"from" required:"true" doc:"Version to compare from, usually the installed one, or 'latest'" example:"1.0.0"`
+	To         string `query:"to" required:"false" default:"latest" doc:"Version to compare to (default: latest)" example:"1.2.0"`
+}
+
+// RegisterDiffEndpoint registers the endpoint comparing two versions of a server
+func RegisterDiffEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-diff" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/diff",
+		Summary:     "Compare two versions of an MCP server",
+		Description: "Get a structured diff of the server.json documents of two versions: changed top-level fields, added, removed and changed packages and remotes, and their environment variables, arguments and headers. Clients can use it to show users what changes before they upgrade a server. Deleted versions can be compared.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerDiffInput) (*CacheableResponse[diff.Diff], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+
+		// Users may be upgrading away from a version that has since been deleted
+		from, err := getServerVersion(ctx, registry, serverName, input.From, true)
+		if err != nil {
+			return nil, err
+		}
+		to, err := getServerVersion(ctx, registry, serverName, input.To, true)
+		if err != nil {
+			return nil, err
+		}
+
+		return newCacheableResponse(*diff.Compare(&from.Server, &to.Server), cdn.KeysForServer(serverName)), nil
+	})
+}
@@ -0,0 +1,89 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/diff"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestDiffEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		server := &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather",
+			Description: "Weather forecasts",
+			Version:     version,
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@example/weather",
+				Version:      version,
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			}},
+		}
+		if version == "1.1.0" {
+			server.Packages[0].EnvironmentVariables = []model.KeyValueInput{{Name: "WEATHER_API_KEY"}}
+		}
+		_, err := registryService.CreateServer(ctx, server)
+		require.NoError(t, err)
+	}
+	_, err := registryService.UpdateServerStatus(ctx, "com.example/weather", "1.0.0", &service.StatusChangeRequest{NewStatus: model.StatusDeleted})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDiffEndpoint(api, "/v0.1", registryService)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("defaults to the latest version", func(t *testing.T) {
+		w := get("/v0.1/servers/com.example%2Fweather/diff?from=1.0.0")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Header().Get("Surrogate-Key"), "com.example/weather")
+
+		var result diff.Diff
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.Equal(t, "1.0.0", result.From, "deleted versions can be compared")
+		assert.Equal(t, "1.1.0", result.To)
+		require.Len(t, result.Packages, 1)
+		assert.Equal(t, "1.1.0", result.Packages[0].ToVersion)
+		assert.Equal(t, []diff.InputChange{{Change: diff.ChangeAdded, Name: "WEATHER_API_KEY"}}, result.Packages[0].EnvironmentVariables)
+	})
+
+	t.Run("same version", func(t *testing.T) {
+		w := get("/v0.1/servers/com.example%2Fweather/diff?from=1.1.0&to=1.1.0")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var result diff.Diff
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.True(t, result.Identical)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get("/v0.1/servers/com.example%2Fweather/diff?from=0.9.0").Code)
+		assert.Equal(t, http.StatusNotFound, get("/v0.1/servers/com.example%2Fmissing/diff?from=1.0.0").Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, get("/v0.1/servers/com.example%2Fweather/diff").Code, "from is required")
+	})
+}
@@ -0,0 +1,59 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ListDuplicatesInput represents the input for listing possible duplicate servers
+type ListDuplicatesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+}
+
+// DuplicatesResponse represents the list of possible duplicate server groups
+type DuplicatesResponse struct {
+	Groups []database.DuplicateGroup `json:"groups" doc:"Groups of servers that share a repository URL or remote endpoint"`
+}
+
+// RegisterDuplicatesEndpoint registers the admin possible duplicates endpoint with a custom path prefix
+func RegisterDuplicatesEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-possible-duplicates" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/duplicates",
+		Summary:     "List possible duplicate servers",
+		Description: "List groups of servers published under different names that point at the same repository URL or remote endpoint. Only the latest, non-deleted version of each server is considered. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListDuplicatesInput) (*Response[DuplicatesResponse], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		groups, err := registry.ListPossibleDuplicates(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list possible duplicates", err)
+		}
+
+		response := DuplicatesResponse{Groups: make([]database.DuplicateGroup, len(groups))}
+		for i, group := range groups {
+			response.Groups[i] = *group
+		}
+
+		return &Response[DuplicatesResponse]{
+			Body: response,
+		}, nil
+	})
+}
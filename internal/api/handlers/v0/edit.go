@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 
@@ -13,13 +14,15 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
 	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 )
 
 // EditServerInput represents the input for editing a server
 type EditServerInput struct {
-	Authorization string           `header:"Authorization" doc:"Registry JWT token with edit permissions" required:"true"`
+	Authorization string           `header:"Authorization" doc:"Registry JWT token with edit permissions"`
 	ServerName    string           `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 	Version       string           `path:"version" doc:"URL-encoded version to edit" example:"1.0.0"`
 	Body          apiv0.ServerJSON `body:""`
@@ -28,6 +31,7 @@ type EditServerInput struct {
 // RegisterEditEndpoints registers the edit endpoint with a custom path prefix
 func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	jwtManager := auth.NewJWTManager(cfg)
+	repoVerifier := validators.NewGitHubRepositoryVerifier(cfg)
 
 	// Edit server endpoint
 	huma.Register(api, huma.Operation{
@@ -41,71 +45,85 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 			{"bearer": {}},
 		},
 	}, func(ctx context.Context, input *EditServerInput) (*Response[apiv0.ServerResponse], error) {
-		// Extract bearer token
-		const bearerPrefix = "Bearer "
-		authHeader := input.Authorization
-		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
-			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
-		}
-		token := authHeader[len(bearerPrefix):]
-
-		// Validate Registry JWT token
-		claims, err := jwtManager.ValidateToken(ctx, token)
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
 		if err != nil {
-			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+			return nil, err
 		}
 
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
-			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
 		}
 
 		// URL-decode the version
 		version, err := url.PathUnescape(input.Version)
 		if err != nil {
-			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid version encoding", err))
 		}
 
 		// Get current server to check permissions against existing name
 		// Deleted servers return 404 - restore via status endpoint first
 		currentServer, err := registry.GetServerByNameAndVersion(ctx, serverName, version, false)
 		if err != nil {
-			if errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("Server not found"))
 			}
 			return nil, huma.Error500InternalServerError("Failed to get current server", err)
 		}
 
 		// Verify edit permissions for this server using the existing server name
-		if !jwtManager.HasPermission(currentServer.Server.Name, auth.PermissionActionEdit, claims.Permissions) {
-			return nil, huma.Error403Forbidden("You do not have edit permissions for this server")
+		if err := authorizeServer(ctx, jwtManager, claims, currentServer.Server.Name, auth.AuthLevelEdit); err != nil {
+			return nil, err
+		}
+		if err := checkIdentityBan(ctx, registry, claims); err != nil {
+			return nil, err
 		}
 
 		// Prevent renaming servers
 		if currentServer.Server.Name != input.Body.Name {
-			return nil, huma.Error400BadRequest("Cannot rename server")
+			return nil, withErrorCode(apiv0.ErrorCodeRenameNotAllowed, huma.Error400BadRequest("Cannot rename server"))
 		}
 
 		// Validate that the version in the body matches the URL parameter
 		if input.Body.Version != version {
-			return nil, huma.Error400BadRequest("Version in request body must match URL path parameter")
+			return nil, withErrorCode(apiv0.ErrorCodeVersionMismatch, huma.Error400BadRequest("Version in request body must match URL path parameter"))
 		}
 
 		// Validate server JSON structure and schema (returns 422 on validation failure)
-		validationResult := validators.ValidateServerJSON(&input.Body, validators.ValidationSchemaVersionAndSemantic)
+		opts, err := namespaceValidationOptions(ctx, registry, validator.ValidationSchemaVersionAndSemantic, serverName)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		validationResult := validator.ValidateServerJSON(&input.Body, opts)
+		telemetry.RecordValidatorStage(ctx, telemetry.ValidatorStageSchema, "", start, validationResult.FirstError())
 		if !validationResult.Valid {
-			return nil, huma.Error422UnprocessableEntity("Failed to edit server, invalid schema: call /validate for details")
+			return nil, withErrorCode(apiv0.ErrorCodeSchemaValidationFailed, huma.Error422UnprocessableEntity("Failed to edit server, invalid schema: call /validate for details"))
+		}
+
+		// Block edits once the linked GitHub repository was deleted or transferred, and edits that use
+		// reserved or prohibited terms. Admins may still edit, for example to scrub such a server.
+		if !jwtManager.IsAdmin(claims.Permissions) {
+			if err := repoVerifier.Verify(ctx, &input.Body); err != nil {
+				return nil, huma.Error403Forbidden("Failed to edit server", err)
+			}
+			if err := registry.ScreenServer(ctx, &input.Body); err != nil {
+				return nil, huma.Error422UnprocessableEntity("Failed to edit server", err)
+			}
 		}
 
 		updatedServer, err := registry.UpdateServer(ctx, serverName, version, &input.Body, nil)
 		if err != nil {
-			if errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
-			}
-			return nil, huma.Error400BadRequest("Failed to edit server", err)
+			return nil, serviceError(http.StatusBadRequest, "Failed to edit server", err)
 		}
 
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionEdit,
+			Namespace: serverNamespace(serverName),
+			Resource:  serverName + "@" + version,
+		})
+
 		return &Response[apiv0.ServerResponse]{
 			Body: *updatedServer,
 		}, nil
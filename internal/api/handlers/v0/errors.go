@@ -0,0 +1,156 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/deploy"
+	"github.com/modelcontextprotocol/registry/internal/install"
+	"github.com/modelcontextprotocol/registry/internal/jsonlimit"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ErrorModel is the RFC 7807 problem+json body returned for every API error.
+// It extends Huma's error model with a stable machine-readable code.
+type ErrorModel struct {
+	huma.ErrorModel
+	Code apiv0.ErrorCode `json:"code" doc:"Stable machine-readable error code" example:"SERVER_NOT_FOUND"`
+}
+
+// errorCodeBySentinel maps errors returned from lower layers to their specific codes
+var errorCodeBySentinel = []struct {
+	err  error
+	code apiv0.ErrorCode
+}{
+	{auth.ErrNamespacePendingReview, apiv0.ErrorCodeNamespacePendingReview},
+	{auth.ErrIdentityBanned, apiv0.ErrorCodeIdentityBanned},
+	{service.ErrForbiddenNamespace, apiv0.ErrorCodeNamespaceForbidden},
+	{service.ErrNotFound, apiv0.ErrorCodeServerNotFound},
+	{service.ErrDuplicateVersion, apiv0.ErrorCodeVersionExists},
+	{service.ErrMaxVersionsReached, apiv0.ErrorCodeMaxVersionsReached},
+	{service.ErrAlreadyExists, apiv0.ErrorCodeConflict},
+	{service.ErrRemoteURLInUse, apiv0.ErrorCodeRemoteURLInUse},
+	{install.ErrNotInstallable, apiv0.ErrorCodeNotInstallable},
+	{deploy.ErrNotDeployable, apiv0.ErrorCodeNotDeployable},
+	// Check the more specific upstream error before the generic registry validation wrapper
+	{registries.ErrPackageNotFound, apiv0.ErrorCodePackageNotFoundUpstream},
+	{validators.ErrRegistryValidationFailed, apiv0.ErrorCodePackageValidationFailed},
+	{validators.ErrLinkUnreachable, apiv0.ErrorCodeLinkUnreachable},
+	{validators.ErrRepositoryVerificationFailed, apiv0.ErrorCodeRepositoryVerificationFailed},
+	{validators.ErrReservedTerm, apiv0.ErrorCodeReservedTerm},
+	{validators.ErrProhibitedTerm, apiv0.ErrorCodeProhibitedTerm},
+	{jsonlimit.ErrInvalid, apiv0.ErrorCodeInvalidJSON},
+}
+
+// statusBySentinel maps the domain errors of the service layer to the HTTP status they are reported with,
+// so every endpoint answers the same failure with the same status
+var statusBySentinel = []struct {
+	err    error
+	status int
+}{
+	{service.ErrNotFound, http.StatusNotFound},
+	{service.ErrForbiddenNamespace, http.StatusForbidden},
+	{auth.ErrIdentityBanned, http.StatusForbidden},
+	{validators.ErrRepositoryVerificationFailed, http.StatusForbidden},
+	{service.ErrDuplicateVersion, http.StatusConflict},
+	{service.ErrAlreadyExists, http.StatusConflict},
+	{service.ErrRemoteURLInUse, http.StatusConflict},
+	{service.ErrInvalidInput, http.StatusUnprocessableEntity},
+}
+
+// errorCodeByStatus provides the fallback code for each HTTP status
+var errorCodeByStatus = map[int]apiv0.ErrorCode{
+	http.StatusBadRequest:            apiv0.ErrorCodeBadRequest,
+	http.StatusUnauthorized:          apiv0.ErrorCodeUnauthorized,
+	http.StatusForbidden:             apiv0.ErrorCodeForbidden,
+	http.StatusNotFound:              apiv0.ErrorCodeNotFound,
+	http.StatusMethodNotAllowed:      apiv0.ErrorCodeMethodNotAllowed,
+	http.StatusConflict:              apiv0.ErrorCodeConflict,
+	http.StatusRequestEntityTooLarge: apiv0.ErrorCodeRequestTooLarge,
+	http.StatusUnprocessableEntity:   apiv0.ErrorCodeValidationFailed,
+	http.StatusTooManyRequests:       apiv0.ErrorCodeRateLimited,
+	http.StatusServiceUnavailable:    apiv0.ErrorCodeServiceUnavailable,
+}
+
+func init() {
+	// Route all Huma errors, including request validation failures, through our error model
+	huma.NewError = NewError
+}
+
+// NewError creates an ErrorModel, deriving its code from the wrapped errors or falling back to the HTTP status
+func NewError(status int, msg string, errs ...error) huma.StatusError {
+	details := make([]*huma.ErrorDetail, 0, len(errs))
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if converted, ok := err.(huma.ErrorDetailer); ok {
+			details = append(details, converted.ErrorDetail())
+		} else {
+			details = append(details, &huma.ErrorDetail{Message: err.Error()})
+		}
+	}
+
+	return &ErrorModel{
+		ErrorModel: huma.ErrorModel{
+			Status: status,
+			Title:  http.StatusText(status),
+			Detail: msg,
+			Errors: details,
+		},
+		Code: errorCodeFor(status, errs),
+	}
+}
+
+// ErrorCodeForStatus returns the generic error code for an HTTP status
+func ErrorCodeForStatus(status int) apiv0.ErrorCode {
+	if code, ok := errorCodeByStatus[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return apiv0.ErrorCodeInternal
+	}
+	return apiv0.ErrorCodeBadRequest
+}
+
+func errorCodeFor(status int, errs []error) apiv0.ErrorCode {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		for _, mapping := range errorCodeBySentinel {
+			if errors.Is(err, mapping.err) {
+				return mapping.code
+			}
+		}
+	}
+	return ErrorCodeForStatus(status)
+}
+
+// serviceError reports an error from the service layer with the status of the domain error it wraps, or
+// fallbackStatus when it wraps none
+func serviceError(fallbackStatus int, msg string, err error) huma.StatusError {
+	status := fallbackStatus
+	for _, mapping := range statusBySentinel {
+		if errors.Is(err, mapping.err) {
+			status = mapping.status
+			break
+		}
+	}
+	return huma.NewError(status, msg, err)
+}
+
+// withErrorCode overrides the code of an error created by one of the huma.ErrorXXX helpers
+func withErrorCode(code apiv0.ErrorCode, err huma.StatusError) huma.StatusError {
+	var model *ErrorModel
+	if errors.As(err, &model) {
+		model.Code = code
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestNewError_Codes(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		errs     []error
+		expected apiv0.ErrorCode
+	}{
+		{
+			name:     "status fallback",
+			status:   http.StatusUnauthorized,
+			expected: apiv0.ErrorCodeUnauthorized,
+		},
+		{
+			name:     "unknown server error status",
+			status:   http.StatusBadGateway,
+			expected: apiv0.ErrorCodeInternal,
+		},
+		{
+			name:     "version already exists",
+			status:   http.StatusBadRequest,
+			errs:     []error{fmt.Errorf("%w: version 1.0.0 already exists", database.ErrInvalidVersion)},
+			expected: apiv0.ErrorCodeVersionExists,
+		},
+		{
+			name:     "remote url in use",
+			status:   http.StatusBadRequest,
+			errs:     []error{fmt.Errorf("%w: remote URL https://example.com is already used", service.ErrRemoteURLInUse)},
+			expected: apiv0.ErrorCodeRemoteURLInUse,
+		},
+		{
+			name:     "forbidden namespace",
+			status:   http.StatusForbidden,
+			errs:     []error{fmt.Errorf("%w: com.spam is blocked", service.ErrForbiddenNamespace)},
+			expected: apiv0.ErrorCodeNamespaceForbidden,
+		},
+		{
+			name:   "package missing upstream wins over generic registry validation",
+			status: http.StatusBadRequest,
+			errs: []error{fmt.Errorf("%w for package 0 (npm): %w",
+				validators.ErrRegistryValidationFailed, fmt.Errorf("wrapped: %w", registries.ErrPackageNotFound))},
+			expected: apiv0.ErrorCodePackageNotFoundUpstream,
+		},
+		{
+			name:     "nil and unrelated errors are ignored",
+			status:   http.StatusNotFound,
+			errs:     []error{nil, errors.New("something else")},
+			expected: apiv0.ErrorCodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v0.NewError(tt.status, "message", tt.errs...)
+
+			var model *v0.ErrorModel
+			require.ErrorAs(t, err, &model)
+			assert.Equal(t, tt.expected, model.Code)
+			assert.Equal(t, tt.status, err.GetStatus())
+		})
+	}
+}
+
+func TestPublishEndpoint_ErrorCodes(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	// Every request here fails authentication or authorization, so the registry only records attempts
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", service.NewTestRegistryService(t, database.NewMemory(), cfg), cfg)
+
+	otherNamespaceToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.other/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		authHeader     string
+		expectedStatus int
+		expectedCode   apiv0.ErrorCode
+	}{
+		{
+			name:           "malformed authorization header",
+			authHeader:     "Token abc",
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   apiv0.ErrorCodeInvalidAuthHeader,
+		},
+		{
+			name:           "invalid token",
+			authHeader:     "Bearer not-a-jwt",
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   apiv0.ErrorCodeInvalidToken,
+		},
+		{
+			name:           "token for another namespace",
+			authHeader:     "Bearer " + otherNamespaceToken,
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   apiv0.ErrorCodeNamespaceForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "io.github.example/test-server",
+				Description: "A test server",
+				Version:     "1.0.0",
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", tt.authHeader)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+
+			var problem v0.ErrorModel
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+			assert.Equal(t, tt.expectedCode, problem.Code)
+			assert.Equal(t, tt.expectedStatus, problem.Status)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// maxReplayLimit is the most changes a single replay request delivers, keeping requests short;
+// larger replays continue from lastSeq or use the registry events replay command
+const maxReplayLimit = 1000
+
+// ReplayEventsBody represents the request body for replaying change events
+type ReplayEventsBody struct {
+	Target string `json:"target" minLength:"1" doc:"Webhook URL that receives one POST per change" example:"https://consumer.example.com/hooks/registry"`
+	Since  int64  `json:"since" minimum:"0" doc:"Replay changes with a sequence number greater than this value" example:"1024"`
+	Until  int64  `json:"until,omitempty" minimum:"0" doc:"Stop after the change with this sequence number (default: latest)"`
+	Limit  int    `json:"limit,omitempty" minimum:"1" maximum:"1000" doc:"Maximum number of changes to deliver (default: 1000)"`
+	Format string `json:"format,omitempty" enum:"cloudevents,full,minimal" doc:"Payload format, as for webhook subscriptions (default: cloudevents)"`
+}
+
+// ReplayEventsInput represents the input for replaying change events
+type ReplayEventsInput struct {
+	Authorization string           `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Body          ReplayEventsBody `body:""`
+}
+
+// ReplayEventsResponse reports the outcome of a replay request
+type ReplayEventsResponse struct {
+	service.ReplayResult
+	Error string `json:"error,omitempty" doc:"Why delivery stopped before the selected changes were all delivered"`
+}
+
+// RegisterEventsEndpoints registers the admin endpoint for replaying change events with a custom path prefix
+func RegisterEventsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "replay-events" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/events/replay",
+		Summary:     "Replay change events",
+		Description: "Re-deliver recorded server changes to a webhook, one POST per change in sequence order, for a consumer recovering from data loss. Delivery stops at the first change the target rejects; continue from lastSeq. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ReplayEventsInput) (*Response[ReplayEventsResponse], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		if input.Body.Until > 0 && input.Body.Until <= input.Body.Since {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("until must be greater than since"))
+		}
+		limit := input.Body.Limit
+		if limit <= 0 {
+			limit = maxReplayLimit
+		}
+
+		result, err := service.ReplayChanges(ctx, registry, input.Body.Target, service.ReplayOptions{
+			Since:  input.Body.Since,
+			Until:  input.Body.Until,
+			Limit:  limit,
+			Format: input.Body.Format,
+			Source: service.EventSource(cfg),
+		})
+		if errors.Is(err, service.ErrInvalidReplayTarget) || errors.Is(err, service.ErrInvalidReplayFormat) {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest(err.Error()))
+		}
+
+		response := ReplayEventsResponse{}
+		if result != nil {
+			response.ReplayResult = *result
+			RecordAudit(ctx, registry, claims, database.AuditEntry{
+				Action:   database.AuditActionEventReplay,
+				Resource: input.Body.Target,
+				Details:  map[string]any{"since": input.Body.Since, "delivered": result.Delivered, "lastSeq": result.LastSeq},
+			})
+		}
+		if err != nil {
+			response.Error = err.Error()
+		}
+		return &Response[ReplayEventsResponse]{Body: response}, nil
+	})
+}
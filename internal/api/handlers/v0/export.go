@@ -0,0 +1,95 @@
+package v0
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ExportServersInput represents the input for exporting every server version
+type ExportServersInput struct {
+	AcceptEncoding string `header:"Accept-Encoding" doc:"Compressions the client accepts. zstd is preferred to gzip when both are accepted." example:"zstd, gzip"`
+}
+
+// ExportServersOutput is the full dataset served as a single, possibly compressed, seed file
+type ExportServersOutput struct {
+	ContentType        string `header:"Content-Type"`
+	ContentEncoding    string `header:"Content-Encoding"`
+	ContentDisposition string `header:"Content-Disposition"`
+	Vary               string `header:"Vary"`
+	SurrogateKey       string `header:"Surrogate-Key" doc:"Space-separated cache keys for Fastly-style CDN purging"`
+	CacheTag           string `header:"Cache-Tag" doc:"Comma-separated cache keys for Cloudflare-style CDN purging"`
+	Body               []byte
+}
+
+// RegisterExportEndpoint registers the endpoint serving every server version in seed format, for mirrors
+func RegisterExportEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "export-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/export",
+		Summary:     "Export every server version",
+		Description: "Download the server.json of every version that is not deleted as a single JSON array, the seed format mirrors import with MCP_REGISTRY_SEED_FROM. The response is compressed with zstd or gzip when the Accept-Encoding header allows it.",
+		Tags:        []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Array of server.json documents",
+				Content:     map[string]*huma.MediaType{"application/json": {}},
+			},
+		},
+	}, func(ctx context.Context, input *ExportServersInput) (*ExportServersOutput, error) {
+		servers, err := importer.Snapshot(ctx, registry)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to export servers", err)
+		}
+
+		compression := negotiateCompression(input.AcceptEncoding)
+		var body bytes.Buffer
+		if err := importer.WriteSnapshot(&body, servers, compression); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to export servers", err)
+		}
+
+		output := &ExportServersOutput{
+			ContentType:        "application/json",
+			ContentDisposition: `attachment; filename="seed.json"`,
+			Vary:               "Accept-Encoding",
+			SurrogateKey:       cdn.SurrogateKeyHeader([]string{cdn.ListKey}),
+			CacheTag:           cdn.CacheTagHeader([]string{cdn.ListKey}),
+			Body:               body.Bytes(),
+		}
+		if compression != importer.CompressionNone {
+			output.ContentEncoding = string(compression)
+		}
+		return output, nil
+	})
+}
+
+// negotiateCompression picks the compression for a response from an Accept-Encoding header: zstd if the client
+// accepts it, then gzip, and none otherwise. Codings with q=0 are refused.
+func negotiateCompression(acceptEncoding string) importer.Compression {
+	accepted := map[string]bool{}
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if q, found := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); found && strings.Trim(q, "0.") == "" {
+			continue
+		}
+		accepted[name] = true
+	}
+
+	switch {
+	case accepted["zstd"]:
+		return importer.CompressionZstd
+	case accepted["gzip"] || accepted["*"]:
+		return importer.CompressionGzip
+	default:
+		return importer.CompressionNone
+	}
+}
@@ -0,0 +1,72 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestExportEndpoint(t *testing.T) {
+	registry := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{})
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registry.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather",
+			Description: "Weather server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterExportEndpoint(api, "/v0.1", registry)
+
+	export := func(acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v0.1/servers/export", nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+		return w
+	}
+
+	t.Run("uncompressed", func(t *testing.T) {
+		w := export("")
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		var servers []apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &servers))
+		assert.Len(t, servers, 2)
+	})
+
+	t.Run("prefers zstd", func(t *testing.T) {
+		w := export("gzip, deflate, zstd")
+		assert.Equal(t, "zstd", w.Header().Get("Content-Encoding"))
+		decoder, err := zstd.NewReader(w.Body)
+		require.NoError(t, err)
+		defer decoder.Close()
+		var servers []apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(decoder).Decode(&servers))
+		assert.Len(t, servers, 2)
+	})
+
+	t.Run("falls back to gzip", func(t *testing.T) {
+		assert.Equal(t, "gzip", export("zstd;q=0, gzip").Header().Get("Content-Encoding"))
+	})
+}
@@ -17,6 +17,8 @@ import (
 type HealthBody struct {
 	Status         string `json:"status" example:"ok" doc:"Health status"`
 	GitHubClientID string `json:"github_client_id,omitempty" doc:"GitHub OAuth App Client ID"`
+	ReadOnly       bool   `json:"read_only,omitempty" doc:"Whether this registry serves reads only, rejecting writes"`
+	PrimaryURL     string `json:"primary_url,omitempty" doc:"Base URL of the primary registry that accepts writes, when this registry serves reads only"`
 }
 
 // RegisterHealthEndpoint registers the health check endpoint with a custom path prefix
@@ -36,6 +38,8 @@ func RegisterHealthEndpoint(api huma.API, pathPrefix string, cfg *config.Config,
 			Body: HealthBody{
 				Status:         "ok",
 				GitHubClientID: cfg.GithubClientID,
+				ReadOnly:       cfg.ReadOnly,
+				PrimaryURL:     cfg.PrimaryURL,
 			},
 		}, nil
 	})
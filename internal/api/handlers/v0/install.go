@@ -0,0 +1,59 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/install"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// InstallInput represents the input for rendering an install configuration
+type InstallInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" doc:"URL-encoded server version, or 'latest'" example:"1.0.0"`
+	Client     string `query:"client" required:"true" enum:"claude,vscode,cursor" doc:"Client to render the configuration for" example:"vscode"`
+	Source     string `query:"source" required:"false" enum:"package,remote" doc:"Render from the server's packages or its remotes. Defaults to a remote when the server has one." example:"package"`
+}
+
+// RegisterInstallEndpoint registers the endpoint rendering client configuration snippets
+func RegisterInstallEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-install" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/install",
+		Summary:     "Get install configuration for an MCP server",
+		Description: "Render a ready-to-use configuration snippet and, where supported, a one-click install deep link for a client. Runtime arguments, environment variables and headers are filled in from server.json; values the user must supply are returned as placeholders listed in inputs.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *InstallInput) (*CacheableResponse[install.Snippet], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid version encoding", err))
+		}
+
+		serverResponse, err := getServerVersion(ctx, registry, serverName, version, false)
+		if err != nil {
+			return nil, err
+		}
+
+		snippet, err := install.Render(&serverResponse.Server, input.Client, input.Source)
+		if err != nil {
+			if errors.Is(err, install.ErrNotInstallable) {
+				return nil, huma.Error422UnprocessableEntity("Server has no package or remote that can be installed with this client", err)
+			}
+			return nil, huma.Error400BadRequest("Failed to render install configuration", err)
+		}
+
+		return newCacheableResponse(*snippet, cdn.KeysForServer(serverName)), nil
+	})
+}
@@ -0,0 +1,75 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/api/clientip"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// InstallReportInput represents the input for reporting whether a server version installed and ran
+type InstallReportInput struct {
+	ServerName string            `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string            `path:"version" doc:"URL-encoded server version, or 'latest'" example:"1.0.0"`
+	Body       InstallReportBody `body:""`
+}
+
+// InstallReportBody is an MCP client's report of whether installing and running a server version worked. It
+// carries no details of the user, their machine or the error.
+type InstallReportBody struct {
+	Outcome string `json:"outcome" enum:"success,failure" doc:"success if the server was installed and answered the MCP handshake, failure otherwise"`
+	Stage   string `json:"stage,omitempty" required:"false" enum:"install,runtime" doc:"Where a failure happened: install when installing or configuring the server, runtime when starting or connecting to it. Defaults to install."`
+	Client  string `json:"client,omitempty" required:"false" maxLength:"64" pattern:"^[A-Za-z0-9._-]+$" doc:"Name of the reporting MCP client" example:"vscode"`
+}
+
+// RegisterInstallFeedbackEndpoint registers the endpoint MCP clients report install outcomes to
+func RegisterInstallFeedbackEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	// Anyone can report, so limit each client before reading the body
+	op := rateLimitedOperation(api, huma.Operation{
+		OperationID:   "report-server-install" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodPost,
+		Path:          pathPrefix + "/servers/{serverName}/versions/{version}/feedback",
+		Summary:       "Report whether a server version installed and ran",
+		Description:   "Report, with the user's consent, whether installing and running a server version worked. Each client network (the /24 of an IPv4 address or the /64 of an IPv6 one) counts once per version: a new report replaces the network's earlier one. Networks are only stored as a keyed hash. Versions with enough reporters show the share of reporters they worked for under `io.modelcontextprotocol.registry/install-feedback` in server responses. No authentication is required; requests are rate limited per client address and rejected with 429 over the limit.",
+		Tags:          []string{"servers"},
+		DefaultStatus: http.StatusNoContent,
+	}, "report-server-install", cfg.InstallFeedbackRateLimit)
+	huma.Register(api, op, func(ctx context.Context, input *InstallReportInput) (*struct{}, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid version encoding", err))
+		}
+
+		serverResponse, err := getServerVersion(ctx, registry, serverName, version, false)
+		if err != nil {
+			return nil, err
+		}
+
+		report := database.InstallReport{
+			ServerName: serverResponse.Server.Name,
+			Version:    serverResponse.Server.Version,
+			Outcome:    input.Body.Outcome,
+			Stage:      input.Body.Stage,
+			Client:     input.Body.Client,
+		}
+		if err := registry.ReportInstall(ctx, report, clientip.FromContext(ctx)); err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("Server not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to record install report", err)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,100 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/clientip"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestInstallFeedbackEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		InstallFeedbackEnabled:    true,
+		InstallFeedbackMinReports: 3,
+		InstallFeedbackRateLimit:  100,
+		InstallFeedbackKey:        "test-install-feedback-key",
+	}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registry.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather",
+			Description: "Forecasts",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterInstallFeedbackEndpoint(api, "/v0", registry, cfg)
+	resolver, err := clientip.NewResolver(nil)
+	require.NoError(t, err)
+	handler := resolver.Middleware(mux)
+
+	report := func(client, version string, body map[string]any) *httptest.ResponseRecorder {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/servers/com.example%2Fweather/versions/"+version+"/feedback", bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = net.JoinHostPort(client, "1234")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+	feedback := func(version string) *apiv0.InstallFeedback {
+		server, err := registry.GetServerByNameAndVersion(ctx, "com.example/weather", version, false)
+		require.NoError(t, err)
+		return server.Meta.InstallFeedback
+	}
+
+	t.Run("hidden until enough clients report", func(t *testing.T) {
+		for i := range 2 {
+			w := report(fmt.Sprintf("10.0.%d.1", i+1), "latest", map[string]any{"outcome": "success", "client": "vscode"})
+			require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		}
+		// Reporting again replaces a client's report rather than counting twice
+		w := report("10.0.1.1", "1.1.0", map[string]any{"outcome": "failure", "stage": "runtime"})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		// So does reporting from another address in the same network
+		w = report("10.0.2.99", "1.1.0", map[string]any{"outcome": "success"})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		assert.Nil(t, feedback("1.1.0"))
+	})
+
+	t.Run("success rate", func(t *testing.T) {
+		w := report("2001:db8::1", "1.1.0", map[string]any{"outcome": "success"})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		w = report("2001:db8::2", "1.1.0", map[string]any{"outcome": "failure"})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		assert.Equal(t, &apiv0.InstallFeedback{Reports: 3, SuccessRate: 33}, feedback("1.1.0"))
+		assert.Nil(t, feedback("1.0.0"))
+	})
+
+	t.Run("rejects invalid reports", func(t *testing.T) {
+		w := report("10.0.4.1", "1.1.0", map[string]any{"outcome": "maybe"})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		w = report("10.0.4.1", "1.1.0", map[string]any{"outcome": "success", "client": "not a client name"})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		w = report("10.0.4.1", "9.9.9", map[string]any{"outcome": "success"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,93 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// GetMaintenanceInput represents the input for reading maintenance mode
+type GetMaintenanceInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+}
+
+// UpdateMaintenanceBody represents the request body for toggling maintenance mode
+type UpdateMaintenanceBody struct {
+	Enabled           bool    `json:"enabled" doc:"Whether to reject write operations"`
+	Message           *string `json:"message,omitempty" maxLength:"500" doc:"Optional message returned to clients while maintenance is in progress"`
+	RetryAfterSeconds int     `json:"retryAfterSeconds,omitempty" minimum:"0" maximum:"86400" doc:"Retry-After value in seconds for rejected writes (default: 300)"`
+}
+
+// UpdateMaintenanceInput represents the input for toggling maintenance mode
+type UpdateMaintenanceInput struct {
+	Authorization string                `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Body          UpdateMaintenanceBody `body:""`
+}
+
+// RegisterMaintenanceEndpoints registers the admin maintenance mode endpoints with a custom path prefix
+func RegisterMaintenanceEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-maintenance" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/maintenance",
+		Summary:     "Get maintenance mode",
+		Description: "Get whether the registry is currently rejecting writes for maintenance. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *GetMaintenanceInput) (*Response[database.MaintenanceState], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		state, err := registry.GetMaintenanceState(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get maintenance state", err)
+		}
+
+		return &Response[database.MaintenanceState]{
+			Body: *state,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-maintenance" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/admin/maintenance",
+		Summary:     "Update maintenance mode",
+		Description: "Enable or disable maintenance mode. While enabled, write operations return 503 with a Retry-After header and reads keep working. The setting is persisted and shared by all replicas. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *UpdateMaintenanceInput) (*Response[database.MaintenanceState], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		state, err := registry.SetMaintenanceState(ctx, input.Body.Enabled, input.Body.Message, input.Body.RetryAfterSeconds)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to update maintenance state", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:  database.AuditActionMaintenance,
+			Details: map[string]any{"enabled": state.Enabled},
+		})
+
+		return &Response[database.MaintenanceState]{
+			Body: *state,
+		}, nil
+	})
+}
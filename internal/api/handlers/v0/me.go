@@ -0,0 +1,250 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/api/clientip"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListMyPublishesInput represents the input for listing the caller's publish attempts
+type ListMyPublishesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token"`
+	Cursor        string `query:"cursor" doc:"Pagination cursor" required:"false"`
+	Limit         int    `query:"limit" doc:"Number of attempts per page" default:"50" minimum:"1" maximum:"100"`
+}
+
+// PublishAttemptListResponse is a page of the caller's publish attempts
+type PublishAttemptListResponse struct {
+	Attempts []database.PublishAttempt `json:"attempts" doc:"Publish attempts, newest first"`
+	Metadata AuditListMetadata         `json:"metadata"`
+}
+
+// GetMyPublishInput represents the input for getting one of the caller's publish attempts
+type GetMyPublishInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token"`
+	ID            int64  `path:"id" doc:"Attempt ID" example:"1"`
+}
+
+// PublishAttemptDetail is a publish attempt with the server.json and validation output quarantined when it was rejected
+type PublishAttemptDetail struct {
+	database.PublishAttempt
+	Quarantine *database.QuarantinedPublish `json:"quarantine,omitempty" doc:"server.json and full validation output of a rejected attempt. Omitted for successful attempts and once the registry's quarantine retention has passed."`
+}
+
+// GetMyLimitsInput represents the input for getting the caller's limits
+type GetMyLimitsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token"`
+}
+
+// MyLimitsResponse is what the caller may still do: their rate limit budgets, quota consumption and token expiry
+type MyLimitsResponse struct {
+	Token                TokenSummary      `json:"token"`
+	RateLimits           []RateLimitBudget `json:"rateLimits" doc:"Budgets of the rate-limited operations for the caller's client address, by operation"`
+	Publishes            *PublishUsage     `json:"publishes,omitempty" doc:"Publish attempts made today. Omitted when the registry keeps no publish history."`
+	Namespaces           []NamespaceUsage  `json:"namespaces" doc:"Namespaces the token can publish to, with how many servers they have"`
+	MaxVersionsPerServer int               `json:"maxVersionsPerServer" doc:"Versions a server can have before publishing more is rejected" example:"10000"`
+}
+
+// TokenSummary describes the registry token a request was made with
+type TokenSummary struct {
+	Identity    string            `json:"identity" doc:"Identity the token was issued to, as authentication method and subject" example:"github-at:octocat"`
+	ExpiresAt   *time.Time        `json:"expiresAt,omitempty" doc:"When the token expires and a new one must be exchanged for"`
+	Permissions []auth.Permission `json:"permissions" doc:"What the token allows"`
+}
+
+// PublishUsage counts the caller's publish attempts since midnight UTC
+type PublishUsage struct {
+	Since     time.Time `json:"since" doc:"Midnight UTC of the current day"`
+	Attempts  int       `json:"attempts" doc:"Publish attempts made since, successful or not" example:"3"`
+	Published int       `json:"published" doc:"Versions published since" example:"2"`
+}
+
+// NamespaceUsage counts the servers of a namespace the caller can publish to
+type NamespaceUsage struct {
+	Namespace string `json:"namespace" example:"io.github.octocat"`
+	Servers   int    `json:"servers" doc:"Servers of the namespace that are not deleted" example:"4"`
+}
+
+// publishUsagePageSize is how many publish attempts are read at a time when counting today's
+const publishUsagePageSize = 100
+
+// RegisterMeEndpoints registers the endpoints describing the identity a registry token was issued to
+func RegisterMeEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-my-publishes" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/me/publishes",
+		Summary:     "List my publish attempts",
+		Description: "List the publish attempts made with registry tokens for the caller's identity, newest first, including failed attempts with their errors and validation issues. Attempts are kept for the registry's publish history retention period. Requests rejected before their token was checked are not included.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListMyPublishesInput) (*Response[PublishAttemptListResponse], error) {
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		var beforeID int64
+		if input.Cursor != "" {
+			beforeID, err = strconv.ParseInt(input.Cursor, 10, 64)
+			if err != nil || beforeID <= 0 {
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid cursor parameter"))
+			}
+		}
+
+		attempts, err := registry.ListPublishAttempts(ctx, publishIdentity(claims), beforeID, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list publish attempts", err)
+		}
+
+		body := PublishAttemptListResponse{
+			Attempts: make([]database.PublishAttempt, 0, len(attempts)),
+			Metadata: AuditListMetadata{Count: len(attempts)},
+		}
+		for _, attempt := range attempts {
+			body.Attempts = append(body.Attempts, *attempt)
+		}
+		if len(attempts) == input.Limit {
+			body.Metadata.NextCursor = strconv.FormatInt(attempts[len(attempts)-1].ID, 10)
+		}
+		return &Response[PublishAttemptListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-my-publish" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/me/publishes/{id}",
+		Summary:     "Get my publish attempt",
+		Description: "Get one of the publish attempts made with registry tokens for the caller's identity. Rejected attempts include the server.json as submitted and every issue the validator reported, for when CI logs cut the error short; these are kept for the registry's publish quarantine retention period, which is shorter than the publish history's. Attempts of other identities are not found.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *GetMyPublishInput) (*Response[PublishAttemptDetail], error) {
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		attempt, quarantined, err := registry.GetPublishAttempt(ctx, publishIdentity(claims), input.ID)
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Publish attempt not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to get publish attempt", err)
+		}
+		return &Response[PublishAttemptDetail]{Body: PublishAttemptDetail{PublishAttempt: *attempt, Quarantine: quarantined}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-my-limits" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/me/limits",
+		Summary:     "Get my limits",
+		Description: "Get what the caller may still do, so CI pipelines can pace themselves and tell why a request was rejected with 429: the budgets left of the rate-limited operations for the caller's client address, the publish attempts made today with registry tokens for the caller's identity, the namespaces the token can publish to with how many servers they have, and when the token expires. Rate limits are per client address, so callers sharing an address share budgets.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *GetMyLimitsInput) (*Response[MyLimitsResponse], error) {
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+
+		body := MyLimitsResponse{
+			Token: TokenSummary{
+				Identity:    publishIdentity(claims),
+				Permissions: claims.Permissions,
+			},
+			RateLimits:           rateLimitBudgets(api, clientip.FromContext(ctx), now),
+			Namespaces:           []NamespaceUsage{},
+			MaxVersionsPerServer: service.MaxVersionsPerServer,
+		}
+		if claims.ExpiresAt != nil {
+			body.Token.ExpiresAt = &claims.ExpiresAt.Time
+		}
+
+		if cfg.PublishHistoryRetention > 0 {
+			body.Publishes, err = publishUsage(ctx, registry, publishIdentity(claims), now)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to count publish attempts", err)
+			}
+		}
+
+		isLatest := true
+		for _, namespace := range publishNamespaces(claims.Permissions) {
+			servers, _, err := registry.CountServers(ctx, &database.ServerFilter{Namespace: &namespace, IsLatest: &isLatest})
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to count servers", err)
+			}
+			body.Namespaces = append(body.Namespaces, NamespaceUsage{Namespace: namespace, Servers: servers})
+		}
+		return &Response[MyLimitsResponse]{Body: body}, nil
+	})
+}
+
+// publishUsage counts identity's publish attempts since midnight UTC
+func publishUsage(ctx context.Context, registry service.RegistryService, identity string, now time.Time) (*PublishUsage, error) {
+	usage := &PublishUsage{Since: now.UTC().Truncate(24 * time.Hour)}
+	var beforeID int64
+	for {
+		attempts, err := registry.ListPublishAttempts(ctx, identity, beforeID, publishUsagePageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, attempt := range attempts {
+			if attempt.AttemptedAt.Before(usage.Since) {
+				return usage, nil
+			}
+			usage.Attempts++
+			if attempt.Status == http.StatusOK {
+				usage.Published++
+			}
+		}
+		if len(attempts) < publishUsagePageSize {
+			return usage, nil
+		}
+		beforeID = attempts[len(attempts)-1].ID
+	}
+}
+
+// publishNamespaces returns the namespaces permissions allow publishing every server of, such as io.github.octocat
+// for io.github.octocat/*. Patterns matching several namespaces, such as com.example.* or *, are left out.
+func publishNamespaces(permissions []auth.Permission) []string {
+	var namespaces []string
+	seen := map[string]bool{}
+	for _, permission := range permissions {
+		if permission.Action != auth.PermissionActionPublish {
+			continue
+		}
+		namespace, ok := strings.CutSuffix(permission.ResourcePattern, "/*")
+		if !ok || namespace == "" || strings.Contains(namespace, "*") || seen[namespace] {
+			continue
+		}
+		seen[namespace] = true
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces
+}
+
+// publishIdentity identifies whose publish history an attempt belongs to, in the same form as audit log actors
+func publishIdentity(claims *auth.JWTClaims) string {
+	return string(claims.AuthMethod) + ":" + claims.AuthMethodSubject
+}
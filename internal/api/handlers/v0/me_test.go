@@ -0,0 +1,292 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/clientip"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+func TestListMyPublishes(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), PublishHistoryRetention: 24 * time.Hour, PublishQuarantineRetention: time.Hour}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterMeEndpoints(api, "/v0", registry, cfg)
+
+	token := func(subject string) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: subject,
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + subject + "/*"}},
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	aliceToken := token("alice")
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	server := func(version string, remotes ...model.Transport) apiv0.ServerJSON {
+		return apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.alice/weather",
+			Description: "Weather forecasts",
+			Version:     version,
+			Remotes:     remotes,
+		}
+	}
+
+	w := do(http.MethodPost, "/v0/publish", aliceToken, server("1.0.0"))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = do(http.MethodPost, "/v0/publish", aliceToken, server("1.0.1", model.Transport{Type: "streamable-http", URL: "not a url"}))
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	w = do(http.MethodPost, "/v0/publish", aliceToken, server("1.0.0"))
+	require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	// Attempts rejected before the token is checked have no identity to belong to
+	w = do(http.MethodPost, "/v0/publish", "Bearer invalid", server("1.0.2"))
+	require.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+
+	list := func(path, authorization string) v0.PublishAttemptListResponse {
+		w := do(http.MethodGet, path, authorization, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body v0.PublishAttemptListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body
+	}
+
+	t.Run("lists the caller's attempts newest first", func(t *testing.T) {
+		body := list("/v0/me/publishes", aliceToken)
+		require.Len(t, body.Attempts, 3)
+		assert.Empty(t, body.Metadata.NextCursor)
+
+		duplicate, invalid, published := body.Attempts[0], body.Attempts[1], body.Attempts[2]
+		assert.Equal(t, http.StatusConflict, duplicate.Status)
+		assert.Equal(t, string(apiv0.ErrorCodeVersionExists), duplicate.ErrorCode)
+		assert.Contains(t, duplicate.Error, "cannot publish duplicate version")
+
+		assert.Equal(t, "1.0.1", invalid.Version)
+		assert.Equal(t, http.StatusUnprocessableEntity, invalid.Status)
+		assert.Equal(t, string(apiv0.ErrorCodeSchemaValidationFailed), invalid.ErrorCode)
+		require.NotEmpty(t, invalid.ValidationErrors)
+		assert.Contains(t, invalid.ValidationErrors[0], "remotes[0]")
+
+		assert.Equal(t, "io.github.alice/weather", published.ServerName)
+		assert.Equal(t, http.StatusOK, published.Status)
+		assert.Empty(t, published.Error)
+		assert.False(t, published.AttemptedAt.IsZero())
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		first := list("/v0/me/publishes?limit=2", aliceToken)
+		require.Len(t, first.Attempts, 2)
+		require.NotEmpty(t, first.Metadata.NextCursor)
+		second := list("/v0/me/publishes?limit=2&cursor="+first.Metadata.NextCursor, aliceToken)
+		require.Len(t, second.Attempts, 1)
+		assert.Equal(t, "1.0.0", second.Attempts[0].Version)
+		assert.Equal(t, http.StatusOK, second.Attempts[0].Status)
+	})
+
+	t.Run("shows only the caller's attempts", func(t *testing.T) {
+		assert.Empty(t, list("/v0/me/publishes", token("bob")).Attempts)
+	})
+
+	t.Run("requires a token", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/me/publishes", "Bearer invalid", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	get := func(id int64, authorization string) *httptest.ResponseRecorder {
+		return do(http.MethodGet, "/v0/me/publishes/"+strconv.FormatInt(id, 10), authorization, nil)
+	}
+	attempts := list("/v0/me/publishes", aliceToken).Attempts
+
+	t.Run("returns a rejected attempt with its quarantined server.json", func(t *testing.T) {
+		w := get(attempts[1].ID, aliceToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var detail v0.PublishAttemptDetail
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+		assert.Equal(t, http.StatusUnprocessableEntity, detail.Status)
+		require.NotNil(t, detail.Quarantine)
+		assert.Equal(t, "1.0.1", detail.Quarantine.Server.Version)
+		require.Len(t, detail.Quarantine.Server.Remotes, 1)
+		assert.Equal(t, "not a url", detail.Quarantine.Server.Remotes[0].URL)
+		require.NotEmpty(t, detail.Quarantine.ValidationIssues)
+		assert.Equal(t, "remotes[0].url", detail.Quarantine.ValidationIssues[0].Path)
+		assert.Equal(t, validator.ValidationIssueSeverityError, detail.Quarantine.ValidationIssues[0].Severity)
+	})
+
+	t.Run("returns a successful attempt without quarantine", func(t *testing.T) {
+		w := get(attempts[2].ID, aliceToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var detail v0.PublishAttemptDetail
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+		assert.Equal(t, http.StatusOK, detail.Status)
+		assert.Nil(t, detail.Quarantine)
+	})
+
+	t.Run("hides other identities' attempts", func(t *testing.T) {
+		w := get(attempts[1].ID, token("bob"))
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+		assert.Equal(t, http.StatusNotFound, get(attempts[0].ID+100, aliceToken).Code)
+	})
+
+	t.Run("drops the quarantine after its retention", func(t *testing.T) {
+		deleted, err := registry.PrunePublishQuarantine(context.Background(), time.Nanosecond)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		w := get(attempts[1].ID, aliceToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var detail v0.PublishAttemptDetail
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+		assert.Equal(t, http.StatusUnprocessableEntity, detail.Status, "the attempt outlives its quarantine")
+		assert.Nil(t, detail.Quarantine)
+	})
+}
+
+func TestGetMyLimits(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), PublishHistoryRetention: 24 * time.Hour, ValidateRateLimit: 3}
+
+	setup := func(cfg *config.Config) http.Handler {
+		registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		for _, prefix := range []string{"/v0", "/v0.1"} {
+			v0.RegisterPublishEndpoint(api, prefix, registry, cfg)
+			v0.RegisterValidateEndpoint(api, prefix, registry, cfg)
+			v0.RegisterMeEndpoints(api, prefix, registry, cfg)
+		}
+		resolver, err := clientip.NewResolver(nil)
+		require.NoError(t, err)
+		return resolver.Middleware(mux)
+	}
+	token, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "alice",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"},
+			{Action: auth.PermissionActionPublish, ResourcePattern: "com.alice.*"},
+			{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.bob/*"},
+		},
+	})
+	require.NoError(t, err)
+	authorization := "Bearer " + token.RegistryToken
+
+	do := func(handler http.Handler, method, path, client string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		req.RemoteAddr = client + ":1234"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+	limits := func(handler http.Handler, client string) v0.MyLimitsResponse {
+		w := do(handler, http.MethodGet, "/v0.1/me/limits", client, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body v0.MyLimitsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body
+	}
+	server := func(version string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: "io.github.alice/weather", Description: "Weather forecasts", Version: version}
+	}
+
+	handler := setup(cfg)
+	w := do(handler, http.MethodPost, "/v0/publish", "192.0.2.1", server("1.0.0"))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = do(handler, http.MethodPost, "/v0/publish", "192.0.2.1", server("1.0.0"))
+	require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	// Both path prefixes draw on the same budget
+	for _, path := range []string{"/v0/validate", "/v0.1/validate"} {
+		w = do(handler, http.MethodPost, path, "192.0.2.1", server("1.0.1"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	t.Run("reports budgets, quotas and token expiry", func(t *testing.T) {
+		body := limits(handler, "192.0.2.1")
+		assert.Equal(t, "github-at:alice", body.Token.Identity)
+		require.NotNil(t, body.Token.ExpiresAt)
+		assert.True(t, body.Token.ExpiresAt.After(time.Now()))
+		assert.Len(t, body.Token.Permissions, 3)
+
+		require.Len(t, body.RateLimits, 1)
+		budget := body.RateLimits[0]
+		assert.Equal(t, "validate-server", budget.Operation)
+		assert.Equal(t, 3, budget.LimitPerMinute)
+		assert.Equal(t, 1, budget.Remaining)
+		assert.True(t, budget.ResetAt.After(time.Now()))
+
+		require.NotNil(t, body.Publishes)
+		assert.Equal(t, 2, body.Publishes.Attempts)
+		assert.Equal(t, 1, body.Publishes.Published)
+		assert.Equal(t, time.Now().UTC().Truncate(24*time.Hour), body.Publishes.Since.UTC())
+
+		assert.Equal(t, []v0.NamespaceUsage{{Namespace: "io.github.alice", Servers: 1}}, body.Namespaces)
+		assert.Equal(t, service.MaxVersionsPerServer, body.MaxVersionsPerServer)
+	})
+
+	t.Run("budgets are per client address", func(t *testing.T) {
+		budget := limits(handler, "192.0.2.2").RateLimits[0]
+		assert.Equal(t, 3, budget.Remaining)
+	})
+
+	t.Run("leaves out publishes without publish history", func(t *testing.T) {
+		withoutHistory := *cfg
+		withoutHistory.PublishHistoryRetention = 0
+		assert.Nil(t, limits(setup(&withoutHistory), "192.0.2.1").Publishes)
+	})
+
+	t.Run("requires a token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/me/limits", nil)
+		req.Header.Set("Authorization", "Bearer invalid")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
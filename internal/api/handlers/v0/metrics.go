@@ -0,0 +1,83 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// GetMetricTimeSeriesInput represents the input for reading a metric's time series
+type GetMetricTimeSeriesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Metric        string `query:"metric" required:"true" enum:"publishes,new_servers,failed_publishes" doc:"Metric to chart: server versions published, servers published for the first time, or publish attempts that were rejected" example:"publishes"`
+	Window        string `query:"window" default:"30d" doc:"How far back the series goes, in hours (h), days (d) or weeks (w)" example:"30d"`
+	Step          string `query:"step" default:"1d" doc:"Span of each point, in hours (h), days (d) or weeks (w). The window must be a whole number of steps, at most 1000." example:"1d"`
+}
+
+// RegisterMetricsEndpoints registers the admin endpoint charting registry activity from its internal counters
+func RegisterMetricsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-metric-timeseries" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/metrics/timeseries",
+		Summary:     "Get a metric's time series",
+		Description: "Get a metric's counts per step over a window ending with the step in progress, from counters the registry keeps itself, so registry growth can be charted without a separate metrics stack. Steps are aligned in UTC. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *GetMetricTimeSeriesInput) (*Response[service.MetricTimeSeries], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		window, err := parseSpan(input.Window)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid window", err))
+		}
+		step, err := parseSpan(input.Step)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid step", err))
+		}
+
+		series, err := registry.GetMetricTimeSeries(ctx, input.Metric, window, step)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidTimeSeries) {
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest(err.Error()))
+			}
+			return nil, huma.Error500InternalServerError("Failed to read metric counters", err)
+		}
+		return &Response[service.MetricTimeSeries]{Body: *series}, nil
+	})
+}
+
+// spanUnits are the units a span can be given in
+var spanUnits = map[byte]time.Duration{
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// parseSpan parses a whole number of hours, days or weeks, such as 30d
+func parseSpan(value string) (time.Duration, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("%q is not a number of hours (h), days (d) or weeks (w)", value)
+	}
+	unit, ok := spanUnits[value[len(value)-1]]
+	count, err := strconv.Atoi(value[:len(value)-1])
+	if !ok || err != nil || count <= 0 || count > 100000 {
+		return 0, fmt.Errorf("%q is not a number of hours (h), days (d) or weeks (w)", value)
+	}
+	return time.Duration(count) * unit, nil
+}
@@ -0,0 +1,112 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestMetricTimeSeriesEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	for _, server := range []struct{ name, version string }{
+		{"com.example/alpha", "1.0.0"},
+		{"com.example/alpha", "1.1.0"},
+		{"com.example/beta", "1.0.0"},
+	} {
+		_, err := registry.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        server.name,
+			Description: "A test server",
+			Version:     server.version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterMetricsEndpoints(api, "/v0", registry, cfg)
+
+	token := func(permissions []auth.Permission) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "admin",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	adminToken := token([]auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}})
+	publisherToken := token([]auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}})
+
+	get := func(path, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := get("/v0/admin/metrics/timeseries?metric=publishes", publisherToken)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("counts publishes per step", func(t *testing.T) {
+		w := get("/v0/admin/metrics/timeseries?metric=publishes&window=7d&step=1d", adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var series service.MetricTimeSeries
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &series))
+		assert.Equal(t, "publishes", series.Metric)
+		assert.Equal(t, int64(3), series.Total)
+		require.Len(t, series.Points, 7)
+		assert.Equal(t, 7*24*time.Hour, series.To.Sub(series.From))
+		assert.Equal(t, int64(3), series.Points[6].Count)
+		assert.Equal(t, series.From, series.Points[0].Time)
+	})
+
+	t.Run("counts new servers once", func(t *testing.T) {
+		w := get("/v0/admin/metrics/timeseries?metric=new_servers", adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var series service.MetricTimeSeries
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &series))
+		assert.Equal(t, int64(2), series.Total)
+		assert.Len(t, series.Points, 30)
+	})
+
+	t.Run("rejects invalid spans", func(t *testing.T) {
+		for _, query := range []string{
+			"metric=publishes&window=30x",
+			"metric=publishes&step=0d",
+			"metric=publishes&window=10d&step=3d",
+			"metric=publishes&window=100w&step=1h",
+			"metric=downloads",
+		} {
+			w := get("/v0/admin/metrics/timeseries?"+query, adminToken)
+			assert.Contains(t, []int{http.StatusBadRequest, http.StatusUnprocessableEntity}, w.Code, query)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListNamespacesInput represents the input for listing namespaces
+type ListNamespacesInput struct {
+	Parent string `query:"parent" doc:"Only list this namespace and the namespaces beneath it, such as com.example.api for com.example" required:"false" pattern:"^[a-zA-Z0-9.-]+$" example:"io.github"`
+	Cursor string `query:"cursor" doc:"Pagination cursor" required:"false" example:"io.github.acme"`
+	Limit  int    `query:"limit" doc:"Number of namespaces per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+}
+
+// NamespaceListResponse is a page of namespaces
+type NamespaceListResponse struct {
+	Namespaces []database.NamespaceSummary `json:"namespaces" doc:"Namespaces ordered by name"`
+	Metadata   NamespaceListMetadata       `json:"metadata"`
+}
+
+// NamespaceListMetadata describes a page of namespaces
+type NamespaceListMetadata struct {
+	NextCursor string `json:"nextCursor,omitempty" doc:"Pagination cursor for retrieving the next page of namespaces. Use this exact value in the cursor query parameter of your next request."`
+	Count      int    `json:"count" doc:"Number of namespaces in the current page"`
+}
+
+// NamespaceServersInput represents the input for listing the servers of a namespace
+type NamespaceServersInput struct {
+	Namespace string `path:"namespace" doc:"Namespace, the part of server names before the slash" pattern:"^[a-zA-Z0-9.-]+$" example:"io.github.acme"`
+	Cursor    string `query:"cursor" doc:"Pagination cursor" required:"false" example:"io.github.acme/weather:1.0.0"`
+	Limit     int    `query:"limit" doc:"Number of servers per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+}
+
+// RegisterNamespaceEndpoints registers the endpoints for browsing servers by namespace
+func RegisterNamespaceEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-namespaces" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces",
+		Summary:     "List namespaces",
+		Description: "List the namespaces with servers, ordered by name, with how many servers and versions each has and whether their latest versions were published by verified publishers. Use parent to browse a part of the namespace hierarchy, such as every namespace under io.github.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ListNamespacesInput) (*CacheableResponse[NamespaceListResponse], error) {
+		namespaces, nextCursor, err := registry.ListNamespaces(ctx, input.Parent, input.Cursor, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list namespaces", err)
+		}
+
+		body := NamespaceListResponse{
+			Namespaces: make([]database.NamespaceSummary, len(namespaces)),
+			Metadata:   NamespaceListMetadata{NextCursor: nextCursor, Count: len(namespaces)},
+		}
+		for i, namespace := range namespaces {
+			body.Namespaces[i] = *namespace
+		}
+		return newCacheableResponse(body, []string{cdn.ListKey}), nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-namespace-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces/{namespace}/servers",
+		Summary:     "List the servers of a namespace",
+		Description: "List the latest version of every server in a namespace, ordered by name. Namespaces beneath it are not included. Returns 404 if the namespace has no servers.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *NamespaceServersInput) (*CacheableResponse[apiv0.ServerListResponse], error) {
+		isLatest := true
+		includeDeleted := false
+		filter := &database.ServerFilter{Namespace: &input.Namespace, IsLatest: &isLatest, IncludeDeleted: &includeDeleted}
+
+		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list servers", err)
+		}
+		if len(servers) == 0 && input.Cursor == "" {
+			return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Namespace not found"))
+		}
+		total, totalIsEstimate, err := registry.CountServers(ctx, filter)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to count servers", err)
+		}
+
+		serverValues := make([]apiv0.ServerResponse, len(servers))
+		for i, server := range servers {
+			serverValues[i] = *server
+		}
+		return newCacheableResponse(apiv0.ServerListResponse{
+			Servers: serverValues,
+			Metadata: apiv0.Metadata{
+				NextCursor:      nextCursor,
+				Count:           len(servers),
+				Total:           total,
+				TotalIsEstimate: totalIsEstimate,
+			},
+		}, []string{cdn.ListKey, cdn.NamespaceKey(input.Namespace)}), nil
+	})
+}
@@ -0,0 +1,108 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestNamespaceEndpoints(t *testing.T) {
+	registry := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{})
+	verified := service.WithPublisher(context.Background(), &apiv0.Publisher{AuthMethod: "github-at", Subject: "acme"})
+	anonymous := service.WithPublisher(context.Background(), &apiv0.Publisher{AuthMethod: "none", Subject: "anonymous"})
+	for _, server := range []struct {
+		ctx     context.Context
+		name    string
+		version string
+	}{
+		{verified, "io.github.acme/weather", "1.0.0"},
+		{verified, "io.github.acme/weather", "1.1.0"},
+		{verified, "io.github.acme/notes", "1.0.0"},
+		{verified, "io.github.bob/tool", "1.0.0"},
+		{anonymous, "io.github.bob/other", "1.0.0"},
+		{verified, "com.example/api", "1.0.0"},
+	} {
+		_, err := registry.CreateServer(server.ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        server.name,
+			Description: "A test server",
+			Version:     server.version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterNamespaceEndpoints(api, "/v0", registry)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	listNamespaces := func(query string) v0.NamespaceListResponse {
+		w := get("/v0/namespaces" + query)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response v0.NamespaceListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("counts and verification", func(t *testing.T) {
+		response := listNamespaces("")
+		require.Len(t, response.Namespaces, 3)
+		assert.Equal(t, "com.example", response.Namespaces[0].Namespace)
+
+		acme := response.Namespaces[1]
+		assert.Equal(t, "io.github.acme", acme.Namespace)
+		assert.Equal(t, 2, acme.Servers)
+		assert.Equal(t, 3, acme.Versions)
+		assert.True(t, acme.Verified)
+
+		bob := response.Namespaces[2]
+		assert.Equal(t, 2, bob.Servers)
+		assert.Equal(t, 1, bob.VerifiedServers)
+		assert.False(t, bob.Verified)
+	})
+
+	t.Run("parent and pagination", func(t *testing.T) {
+		first := listNamespaces("?parent=io.github&limit=1")
+		require.Len(t, first.Namespaces, 1)
+		assert.Equal(t, "io.github.acme", first.Namespaces[0].Namespace)
+		require.NotEmpty(t, first.Metadata.NextCursor)
+
+		second := listNamespaces("?parent=io.github&limit=1&cursor=" + first.Metadata.NextCursor)
+		require.Len(t, second.Namespaces, 1)
+		assert.Equal(t, "io.github.bob", second.Namespaces[0].Namespace)
+
+		assert.Empty(t, listNamespaces("?parent=io.git").Namespaces)
+	})
+
+	t.Run("servers of a namespace", func(t *testing.T) {
+		w := get("/v0/namespaces/io.github.acme/servers")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response apiv0.ServerListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Servers, 2)
+		assert.Equal(t, "io.github.acme/notes", response.Servers[0].Server.Name)
+		assert.Equal(t, "1.1.0", response.Servers[1].Server.Version)
+		assert.Equal(t, 2, response.Metadata.Total)
+
+		w = get("/v0/namespaces/io.github/servers")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
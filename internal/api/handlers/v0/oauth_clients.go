@@ -0,0 +1,102 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListOAuthClientsInput represents the input for listing registered OAuth clients
+type ListOAuthClientsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+}
+
+// OAuthClientInput represents the input for removing a registered OAuth client
+type OAuthClientInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ClientID      string `path:"clientId" doc:"Client ID" example:"BF2YNQQD7PD3IUW5KZTLVPR2NS"`
+}
+
+// OAuthClientListResponse lists the registered OAuth clients
+type OAuthClientListResponse struct {
+	Clients []database.OAuthClient `json:"clients" doc:"Registered OAuth clients, oldest first"`
+}
+
+// RegisterOAuthClientEndpoints registers the admin endpoints listing and removing the OAuth clients applications
+// registered at /oauth/register
+func RegisterOAuthClientEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	security := []map[string][]string{{"bearer": {}}}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-oauth-clients" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/oauth-clients",
+		Summary:     "List registered OAuth clients",
+		Description: "List the applications that registered themselves as OAuth clients to log people in through the registry. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *ListOAuthClientsInput) (*Response[OAuthClientListResponse], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+		clients, err := registry.ListOAuthClients(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list OAuth clients", err)
+		}
+		body := OAuthClientListResponse{Clients: make([]database.OAuthClient, 0, len(clients))}
+		for _, client := range clients {
+			body.Clients = append(body.Clients, *client)
+		}
+		return &Response[OAuthClientListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-oauth-client" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/admin/oauth-clients/{clientId}",
+		Summary:       "Remove a registered OAuth client",
+		Description:   "Remove a registered OAuth client, so it can no longer log people in or redeem the authorization codes it was sent. Registry tokens it already obtained stay valid until they expire. Requires admin permissions.",
+		Tags:          []string{"admin"},
+		Security:      security,
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *OAuthClientInput) (*struct{}, error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		client, err := registry.GetOAuthClient(ctx, input.ClientID)
+		if err != nil {
+			return nil, oauthClientError("Failed to get OAuth client", err)
+		}
+		if err := registry.DeleteOAuthClient(ctx, input.ClientID); err != nil {
+			return nil, oauthClientError("Failed to delete OAuth client", err)
+		}
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:   database.AuditActionOAuthClientDelete,
+			Resource: "oauth_client:" + client.ClientID,
+			Details: map[string]any{
+				"clientName":   client.ClientName,
+				"redirectUris": client.RedirectURIs,
+			},
+		})
+		return nil, nil
+	})
+}
+
+// oauthClientError maps OAuth client errors to API errors
+func oauthClientError(message string, err error) error {
+	if errors.Is(err, service.ErrNotFound) {
+		return withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("OAuth client not found"))
+	}
+	return huma.Error500InternalServerError(message, err)
+}
@@ -0,0 +1,426 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListOrgAPIKeysInput represents the input for listing an organization's API keys
+type ListOrgAPIKeysInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of an organization owner or member"`
+	Organization  string `path:"organization" doc:"Organization namespace" example:"com.example"`
+}
+
+// CreateOrgAPIKeyBody represents the request body for creating an organization API key
+type CreateOrgAPIKeyBody struct {
+	Name       string   `json:"name" required:"true" minLength:"1" maxLength:"100" doc:"Name describing what uses the key, unique within the organization" example:"release-pipeline"`
+	Namespaces []string `json:"namespaces,omitempty" doc:"Namespaces the key may publish and edit servers in: the organization namespace or its subdomains. Defaults to the organization namespace." example:"[\"com.example\"]"`
+}
+
+// CreateOrgAPIKeyInput represents the input for creating an organization API key
+type CreateOrgAPIKeyInput struct {
+	Authorization string              `header:"Authorization" doc:"Registry JWT token of an organization owner or member"`
+	Organization  string              `path:"organization" doc:"Organization namespace" example:"com.example"`
+	Body          CreateOrgAPIKeyBody `body:""`
+}
+
+// OrgAPIKeyInput represents the input for operating on a single organization API key
+type OrgAPIKeyInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of an organization owner or member"`
+	Organization  string `path:"organization" doc:"Organization namespace" example:"com.example"`
+	ID            int64  `path:"id" doc:"Key ID" example:"42"`
+}
+
+// OrgMemberListResponse lists an organization's members
+type OrgMemberListResponse struct {
+	Members []database.OrgMember `json:"members" doc:"Members, oldest first"`
+}
+
+// AddOrgMemberBody represents the request body for adding an organization member
+type AddOrgMemberBody struct {
+	Subject string `json:"subject" required:"true" minLength:"1" maxLength:"255" doc:"Authentication method and subject of the person to add: github-at:<login> or oidc:<subject>" example:"github-at:octocat"`
+}
+
+// AddOrgMemberInput represents the input for adding an organization member
+type AddOrgMemberInput struct {
+	Authorization string           `header:"Authorization" doc:"Registry JWT token of an organization owner"`
+	Organization  string           `path:"organization" doc:"Organization namespace" example:"com.example"`
+	Body          AddOrgMemberBody `body:""`
+}
+
+// OrgMemberInput represents the input for removing an organization member
+type OrgMemberInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of an organization owner"`
+	Organization  string `path:"organization" doc:"Organization namespace" example:"com.example"`
+	ID            int64  `path:"id" doc:"Member ID" example:"7"`
+}
+
+// OrgAPIKeyListResponse lists an organization's API keys
+type OrgAPIKeyListResponse struct {
+	Keys []database.OrgAPIKey `json:"keys" doc:"API keys, oldest first"`
+}
+
+// OrgAPIKeySecretResponse is a created or rotated organization API key, including the key itself
+type OrgAPIKeySecretResponse struct {
+	Key    database.OrgAPIKey `json:"key"`
+	APIKey string             `json:"apiKey" doc:"The API key. It is only returned once; store it as a secret in the automation that uses it." example:"mcpr_3f9a1c..."`
+}
+
+// RegisterOrgAPIKeyEndpoints registers the endpoints org admins use to manage their organization's API keys and
+// members
+func RegisterOrgAPIKeyEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	security := []map[string][]string{{"bearer": {}}}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-org-api-keys" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/orgs/{organization}/api-keys",
+		Summary:     "List organization API keys",
+		Description: "List an organization's API keys with their scopes and when they were last used. Keys themselves are never returned. Requires a registry token of an owner or member of the organization.",
+		Tags:        []string{"orgs"},
+		Security:    security,
+	}, func(ctx context.Context, input *ListOrgAPIKeysInput) (*Response[OrgAPIKeyListResponse], error) {
+		if _, err := authenticateOrgAdmin(ctx, jwtManager, registry, cfg, input.Authorization, input.Organization); err != nil {
+			return nil, err
+		}
+
+		keys, err := registry.ListOrgAPIKeys(ctx, input.Organization)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list API keys", err)
+		}
+
+		body := OrgAPIKeyListResponse{Keys: make([]database.OrgAPIKey, 0, len(keys))}
+		for _, key := range keys {
+			body.Keys = append(body.Keys, *key)
+		}
+		return &Response[OrgAPIKeyListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-org-api-key" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodPost,
+		Path:          pathPrefix + "/orgs/{organization}/api-keys",
+		Summary:       "Create an organization API key",
+		Description:   "Create an API key that publishing automation exchanges for a registry token at /auth/api-key. The key belongs to the organization rather than the member who created it, so it keeps working after they leave. The caller's token must cover every namespace the key is scoped to.",
+		Tags:          []string{"orgs"},
+		Security:      security,
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *CreateOrgAPIKeyInput) (*Response[OrgAPIKeySecretResponse], error) {
+		claims, err := authenticateOrgAdmin(ctx, jwtManager, registry, cfg, input.Authorization, input.Organization)
+		if err != nil {
+			return nil, err
+		}
+
+		namespaces := input.Body.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{input.Organization}
+		}
+		// Members may not hand out access to sub-namespaces they could not publish to themselves
+		for _, namespace := range namespaces {
+			if !jwtManager.HasPermission(namespace+"/*", auth.PermissionActionPublish, claims.Permissions) {
+				return nil, withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("You do not have permission to publish to namespace "+namespace))
+			}
+		}
+
+		key, secret, err := registry.CreateOrgAPIKey(ctx, input.Organization, input.Body.Name, namespaces, string(claims.AuthMethod)+":"+claims.AuthMethodSubject)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidOrgAPIKey):
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest(err.Error()))
+			case errors.Is(err, service.ErrAlreadyExists):
+				return nil, withErrorCode(apiv0.ErrorCodeConflict, huma.Error409Conflict("The organization already has an API key with this name"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to create API key", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionOrgAPIKeyCreate,
+			Namespace: key.Organization,
+			Resource:  orgAPIKeyResource(key.ID),
+			Details:   map[string]any{"name": key.Name, "namespaces": key.Namespaces},
+		})
+
+		return &Response[OrgAPIKeySecretResponse]{Body: OrgAPIKeySecretResponse{Key: *key, APIKey: secret}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "rotate-org-api-key" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/orgs/{organization}/api-keys/{id}/rotate",
+		Summary:     "Rotate an organization API key",
+		Description: "Replace an organization API key with a new one, keeping its name and scope. The old key stops working immediately; tokens already issued for it expire as usual.",
+		Tags:        []string{"orgs"},
+		Security:    security,
+	}, func(ctx context.Context, input *OrgAPIKeyInput) (*Response[OrgAPIKeySecretResponse], error) {
+		claims, err := authenticateOrgAdmin(ctx, jwtManager, registry, cfg, input.Authorization, input.Organization)
+		if err != nil {
+			return nil, err
+		}
+
+		key, secret, err := registry.RotateOrgAPIKey(ctx, input.Organization, input.ID)
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("API key not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to rotate API key", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionOrgAPIKeyRotate,
+			Namespace: key.Organization,
+			Resource:  orgAPIKeyResource(key.ID),
+			Details:   map[string]any{"name": key.Name},
+		})
+
+		return &Response[OrgAPIKeySecretResponse]{Body: OrgAPIKeySecretResponse{Key: *key, APIKey: secret}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-org-api-key" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/orgs/{organization}/api-keys/{id}",
+		Summary:       "Revoke an organization API key",
+		Description:   "Permanently delete an organization API key. Tokens already issued for it expire as usual.",
+		Tags:          []string{"orgs"},
+		Security:      security,
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *OrgAPIKeyInput) (*struct{}, error) {
+		claims, err := authenticateOrgAdmin(ctx, jwtManager, registry, cfg, input.Authorization, input.Organization)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := registry.RevokeOrgAPIKey(ctx, input.Organization, input.ID)
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("API key not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to revoke API key", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionOrgAPIKeyRevoke,
+			Namespace: key.Organization,
+			Resource:  orgAPIKeyResource(key.ID),
+			Details:   map[string]any{"name": key.Name},
+		})
+
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-org-members" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/orgs/{organization}/members",
+		Summary:     "List organization members",
+		Description: "List the people an organization's owners have let manage its API keys. Requires a registry token of an owner or member of the organization.",
+		Tags:        []string{"orgs"},
+		Security:    security,
+	}, func(ctx context.Context, input *ListOrgAPIKeysInput) (*Response[OrgMemberListResponse], error) {
+		if _, err := authenticateOrgAdmin(ctx, jwtManager, registry, cfg, input.Authorization, input.Organization); err != nil {
+			return nil, err
+		}
+
+		members, err := registry.ListOrgMembers(ctx, input.Organization)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list members", err)
+		}
+
+		body := OrgMemberListResponse{Members: make([]database.OrgMember, 0, len(members))}
+		for _, member := range members {
+			body.Members = append(body.Members, *member)
+		}
+		return &Response[OrgMemberListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "add-org-member" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodPost,
+		Path:          pathPrefix + "/orgs/{organization}/members",
+		Summary:       "Add an organization member",
+		Description:   "Let a person manage the organization's API keys while their own token can publish to the whole organization namespace. Only owners of the organization can add members.",
+		Tags:          []string{"orgs"},
+		Security:      security,
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *AddOrgMemberInput) (*Response[database.OrgMember], error) {
+		claims, err := authenticateOrgOwner(ctx, jwtManager, registry, cfg, input.Authorization, input.Organization)
+		if err != nil {
+			return nil, err
+		}
+
+		member, err := registry.AddOrgMember(ctx, input.Organization, input.Body.Subject, string(claims.AuthMethod)+":"+claims.AuthMethodSubject)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidOrgMember):
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest(err.Error()))
+			case errors.Is(err, service.ErrAlreadyExists):
+				return nil, withErrorCode(apiv0.ErrorCodeConflict, huma.Error409Conflict("The person is already a member of the organization"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to add member", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionOrgMemberAdd,
+			Namespace: member.Organization,
+			Resource:  orgMemberResource(member.ID),
+			Details:   map[string]any{"subject": member.Subject},
+		})
+
+		return &Response[database.OrgMember]{Body: *member}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "remove-org-member" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/orgs/{organization}/members/{id}",
+		Summary:       "Remove an organization member",
+		Description:   "Stop a member from managing the organization's API keys. Keys they created keep working. Only owners of the organization can remove members.",
+		Tags:          []string{"orgs"},
+		Security:      security,
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *OrgMemberInput) (*struct{}, error) {
+		claims, err := authenticateOrgOwner(ctx, jwtManager, registry, cfg, input.Authorization, input.Organization)
+		if err != nil {
+			return nil, err
+		}
+
+		member, err := registry.RemoveOrgMember(ctx, input.Organization, input.ID)
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Member not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to remove member", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionOrgMemberRemove,
+			Namespace: member.Organization,
+			Resource:  orgMemberResource(member.ID),
+			Details:   map[string]any{"subject": member.Subject},
+		})
+
+		return nil, nil
+	})
+}
+
+// authenticateOrgOwner checks that a token belongs to an owner of a verified organization: a GitHub admin of an
+// io.github organization, or someone who proved they own the organization's domain with DNS or HTTP
+// authentication. Registry admins count as owners of every organization.
+func authenticateOrgOwner(ctx context.Context, jwtManager *auth.JWTManager, registry service.RegistryService, cfg *config.Config, authHeader, organization string) (*auth.JWTClaims, error) {
+	claims, err := authenticateOrgToken(ctx, jwtManager, authHeader, organization)
+	if err != nil {
+		return nil, err
+	}
+	if !isOrgOwner(jwtManager, claims, organization) {
+		return nil, withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("Only owners of the organization can manage its members"))
+	}
+	if err := checkOrgVerified(ctx, jwtManager, registry, cfg, claims, organization); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// authenticateOrgAdmin checks that a token belongs to an org admin of a verified organization: one of its owners,
+// or a member they added whose token can still publish to the whole organization namespace, so members lose
+// access when they leave the organization.
+func authenticateOrgAdmin(ctx context.Context, jwtManager *auth.JWTManager, registry service.RegistryService, cfg *config.Config, authHeader, organization string) (*auth.JWTClaims, error) {
+	claims, err := authenticateOrgToken(ctx, jwtManager, authHeader, organization)
+	if err != nil {
+		return nil, err
+	}
+	if !isOrgOwner(jwtManager, claims, organization) {
+		isMember := false
+		if jwtManager.HasPermission(organization+"/*", auth.PermissionActionPublish, claims.Permissions) {
+			isMember, err = registry.IsOrgMember(ctx, organization, string(claims.AuthMethod)+":"+claims.AuthMethodSubject)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to check organization membership", err)
+			}
+		}
+		if !isMember {
+			return nil, withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("You do not have permission to manage API keys for this organization"))
+		}
+	}
+	if err := checkOrgVerified(ctx, jwtManager, registry, cfg, claims, organization); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// authenticateOrgToken authenticates a token that may act for a person. Tokens issued for API keys cannot manage
+// keys, so a leaked key cannot mint more, and tokens from GitHub Actions cannot either, so a workflow held to
+// a ref or environment policy cannot mint a key that is not. Nor can tokens issued to OAuth clients, which only
+// act on the namespaces of the person who approved them.
+func authenticateOrgToken(ctx context.Context, jwtManager *auth.JWTManager, authHeader, organization string) (*auth.JWTClaims, error) {
+	claims, err := authenticateBearer(ctx, jwtManager, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	switch claims.AuthMethod {
+	case auth.MethodAPIKey:
+		return nil, withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("Organization API keys cannot manage API keys"))
+	case auth.MethodGitHubOIDC:
+		return nil, withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("GitHub Actions tokens cannot manage API keys"))
+	}
+	if claims.OAuthClient != "" {
+		return nil, withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("Tokens issued to OAuth clients cannot manage API keys"))
+	}
+	if organization == "" || strings.Contains(organization, "/") {
+		return nil, withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("You do not have permission to manage API keys for this organization"))
+	}
+	return claims, nil
+}
+
+// isOrgOwner reports whether a token belongs to an owner of an organization
+func isOrgOwner(jwtManager *auth.JWTManager, claims *auth.JWTClaims, organization string) bool {
+	switch {
+	case jwtManager.IsAdmin(claims.Permissions):
+		return true
+	case claims.AuthMethod == auth.MethodDNS || claims.AuthMethod == auth.MethodHTTP:
+		// The token proves control of the domain the namespace was derived from
+		return jwtManager.HasPermission(organization+"/*", auth.PermissionActionPublish, claims.Permissions)
+	}
+	return slices.Contains(claims.OwnerOf, organization)
+}
+
+// checkOrgVerified checks that an organization is verified. GitHub vouches for io.github organizations, and
+// domain organizations need their domain approved by a registry admin when namespace review is required.
+// Registry admins may act for unverified organizations, such as to revoke a leaked key.
+func checkOrgVerified(ctx context.Context, jwtManager *auth.JWTManager, registry service.RegistryService, cfg *config.Config, claims *auth.JWTClaims, organization string) error {
+	if jwtManager.IsAdmin(claims.Permissions) || strings.HasPrefix(organization, "io.github.") || !cfg.NamespaceReviewRequired {
+		return nil
+	}
+
+	labels := strings.Split(organization, ".")
+	slices.Reverse(labels)
+	approved, err := registry.IsNamespaceApproved(ctx, strings.Join(labels, "."))
+	if err != nil {
+		return huma.Error500InternalServerError("Failed to check organization verification", err)
+	}
+	if !approved {
+		return withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("The organization's domain has not been approved by a registry admin"))
+	}
+	return nil
+}
+
+// orgMemberResource identifies an organization member in the audit log
+func orgMemberResource(id int64) string {
+	return "org_member:" + strconv.FormatInt(id, 10)
+}
+
+// orgAPIKeyResource identifies an organization API key in the audit log
+func orgAPIKeyResource(id int64) string {
+	return "org_api_key:" + strconv.FormatInt(id, 10)
+}
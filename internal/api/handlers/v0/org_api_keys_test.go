@@ -0,0 +1,196 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+func TestOrgAPIKeyEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), OrgAPIKeysEnabled: true}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterOrgAPIKeyEndpoints(api, "/v0", registry, cfg)
+
+	issue := func(claims auth.JWTClaims) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), claims)
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	token := func(method auth.Method, patterns ...string) string {
+		var permissions []auth.Permission
+		for _, pattern := range patterns {
+			permissions = append(permissions, auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: pattern})
+		}
+		return issue(auth.JWTClaims{AuthMethod: method, AuthMethodSubject: "alice", Permissions: permissions})
+	}
+	orgAdmin := token(auth.MethodDNS, "com.example/*", "com.example.*")
+	serverOnly := token(auth.MethodDNS, "com.example/weather")
+	apiKeyToken := token(auth.MethodAPIKey, "com.example/*")
+	orgMember := token(auth.MethodGitHubAT, "com.example/*")
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	// Only owners and the members they add may manage keys, and never tokens from a key or a workflow
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/v0/orgs/com.example/api-keys", serverOnly, nil).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/v0/orgs/com.example/api-keys", apiKeyToken, nil).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/v0/orgs/com.example/api-keys", token(auth.MethodGitHubOIDC, "com.example/*"), nil).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/v0/orgs/com.example/api-keys", orgMember, nil).Code, "publishing to the namespace is not enough")
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/v0/orgs/org.other/api-keys", orgAdmin, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/v0/orgs/com.example/api-keys", "Basic abc", nil).Code)
+
+	// Owners add members; members cannot add more
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/v0/orgs/com.example/members", orgMember, map[string]any{"subject": "github-at:bob"}).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/v0/orgs/com.example/members", orgAdmin, map[string]any{"subject": "github-oidc:example/ci"}).Code)
+	w := do(http.MethodPost, "/v0/orgs/com.example/members", orgAdmin, map[string]any{"subject": "github-at:alice"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var member database.OrgMember
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &member))
+	assert.Equal(t, "dns:alice", member.AddedBy)
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/v0/orgs/com.example/members", orgAdmin, map[string]any{"subject": "github-at:alice"}).Code)
+
+	w = do(http.MethodGet, "/v0/orgs/com.example/members", orgMember, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var members v0.OrgMemberListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &members))
+	require.Len(t, members.Members, 1)
+	assert.Equal(t, "github-at:alice", members.Members[0].Subject)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/v0/orgs/com.example/api-keys", token(auth.MethodGitHubAT, "io.github.alice/*"), nil).Code,
+		"members lose access once their token no longer covers the organization")
+
+	// Members cannot scope keys to namespaces they cannot publish to themselves
+	w = do(http.MethodPost, "/v0/orgs/com.example/api-keys", orgMember,
+		map[string]any{"name": "ci", "namespaces": []string{"com.example.tools"}})
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	w = do(http.MethodPost, "/v0/orgs/com.example/api-keys", orgAdmin, map[string]any{"name": "ci"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created v0.OrgAPIKeySecretResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, []string{"com.example"}, created.Key.Namespaces, "keys default to the organization namespace")
+	assert.Equal(t, "dns:alice", created.Key.CreatedBy)
+	assert.NotEmpty(t, created.APIKey)
+	assert.NotContains(t, w.Body.String(), "keyHash")
+
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/v0/orgs/com.example/api-keys", orgAdmin, map[string]any{"name": "ci"}).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/v0/orgs/com.example/api-keys", orgAdmin,
+		map[string]any{"name": "other", "namespaces": []string{"org.other"}}).Code)
+	registryAdmin := issue(auth.JWTClaims{AuthMethod: auth.MethodOIDC, AuthMethodSubject: "admin", Permissions: []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+	}})
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/v0/orgs/com.example/api-keys", registryAdmin,
+		map[string]any{"name": "other", "namespaces": []string{"org.other"}}).Code, "even registry admins cannot scope keys outside the organization")
+
+	w = do(http.MethodGet, "/v0/orgs/com.example/api-keys", orgMember, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), created.APIKey, "listings never include keys")
+	var list v0.OrgAPIKeyListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Keys, 1)
+	assert.Equal(t, "ci", list.Keys[0].Name)
+
+	rotatePath := fmt.Sprintf("/v0/orgs/com.example/api-keys/%d/rotate", created.Key.ID)
+	w = do(http.MethodPost, rotatePath, orgAdmin, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var rotated v0.OrgAPIKeySecretResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotated))
+	assert.NotEqual(t, created.APIKey, rotated.APIKey)
+	assert.NotNil(t, rotated.Key.RotatedAt)
+
+	keyPath := fmt.Sprintf("/v0/orgs/com.example/api-keys/%d", created.Key.ID)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, fmt.Sprintf("/v0/orgs/org.other/api-keys/%d", created.Key.ID), token(auth.MethodDNS, "org.other/*"), nil).Code)
+	assert.Equal(t, http.StatusNoContent, do(http.MethodDelete, keyPath, orgAdmin, nil).Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, keyPath, orgAdmin, nil).Code)
+
+	memberPath := fmt.Sprintf("/v0/orgs/com.example/members/%d", member.ID)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodDelete, memberPath, orgMember, nil).Code)
+	assert.Equal(t, http.StatusNoContent, do(http.MethodDelete, memberPath, orgAdmin, nil).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/v0/orgs/com.example/api-keys", orgMember, nil).Code)
+
+	namespace := "com.example"
+	entries, err := registry.ListAuditEntries(context.Background(), &database.AuditFilter{Namespace: &namespace}, 0, 10)
+	require.NoError(t, err)
+	var actions []string
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+	}
+	assert.Equal(t, []string{
+		database.AuditActionOrgMemberAdd, database.AuditActionOrgAPIKeyCreate, database.AuditActionOrgAPIKeyRotate,
+		database.AuditActionOrgAPIKeyRevoke, database.AuditActionOrgMemberRemove,
+	}, actions)
+}
+
+func TestOrgAPIKeyOwners(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), OrgAPIKeysEnabled: true, NamespaceReviewRequired: true}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterOrgAPIKeyEndpoints(api, "/v0", registry, cfg)
+
+	list := func(organization string, claims auth.JWTClaims) int {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), claims)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/v0/orgs/"+organization+"/api-keys", nil)
+		req.Header.Set("Authorization", "Bearer "+response.RegistryToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+	publish := []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.acme/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"},
+	}
+
+	// GitHub organization admins own io.github organizations, which GitHub vouches for
+	assert.Equal(t, http.StatusOK, list("io.github.acme", auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "alice", Permissions: publish, OwnerOf: []string{"io.github.acme"}}))
+	assert.Equal(t, http.StatusForbidden, list("io.github.acme", auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "bob", Permissions: publish}))
+
+	// Domain owners need their domain approved when namespace review is required
+	domainOwner := auth.JWTClaims{AuthMethod: auth.MethodDNS, AuthMethodSubject: "example.com", Permissions: publish}
+	assert.Equal(t, http.StatusForbidden, list("com.example", domainOwner))
+	verification, err := registry.RecordNamespaceVerificationAttempt(context.Background(), "example.com", "dns", database.VerificationEvidence{CheckedAt: time.Now(), ValidKeyFound: true})
+	require.NoError(t, err)
+	_, err = registry.ReviewNamespaceVerification(context.Background(), verification.ID, true, nil, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, list("com.example", domainOwner))
+}
@@ -0,0 +1,137 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/install"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// GetPreviewInput represents the input for retrieving a preview
+type GetPreviewInput struct {
+	ID string `path:"id" doc:"Preview ID, from the URL returned when the preview was published" example:"3f2a9c0e4b1d7a8e6c5f4d3b2a1e0f9c"`
+}
+
+// PreviewInstallInput represents the input for rendering a preview's install configuration
+type PreviewInstallInput struct {
+	ID     string `path:"id" doc:"Preview ID, from the URL returned when the preview was published" example:"3f2a9c0e4b1d7a8e6c5f4d3b2a1e0f9c"`
+	Client string `query:"client" required:"true" enum:"claude,vscode,cursor" doc:"Client to render the configuration for" example:"vscode"`
+	Source string `query:"source" required:"false" enum:"package,remote" doc:"Render from the server's packages or its remotes. Defaults to a remote when the server has one." example:"package"`
+}
+
+// RegisterPreviewEndpoints registers the endpoints serving previews published with POST /publish?preview=true.
+// Previews are only reachable through their ID, so they are never listed.
+func RegisterPreviewEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-preview" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/previews/{id}",
+		Summary:     "Get a server preview",
+		Description: "Get a server.json published as a preview, as the server detail endpoints would return it once published. Previews expire and are not listed anywhere.",
+		Tags:        []string{"publish"},
+	}, func(ctx context.Context, input *GetPreviewInput) (*Response[apiv0.ServerResponse], error) {
+		preview, err := getPreview(ctx, registry, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &Response[apiv0.ServerResponse]{Body: previewResponse(preview, cfg, pathPrefix)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-preview-install" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/previews/{id}/install",
+		Summary:     "Get install configuration for a server preview",
+		Description: "Render a preview's configuration snippet for a client, like the install endpoint of published server versions.",
+		Tags:        []string{"publish"},
+	}, func(ctx context.Context, input *PreviewInstallInput) (*Response[install.Snippet], error) {
+		preview, err := getPreview(ctx, registry, input.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		snippet, err := install.Render(&preview.Server, input.Client, input.Source)
+		if err != nil {
+			if errors.Is(err, install.ErrNotInstallable) {
+				return nil, huma.Error422UnprocessableEntity("Server has no package or remote that can be installed with this client", err)
+			}
+			return nil, huma.Error400BadRequest("Failed to render install configuration", err)
+		}
+		return &Response[install.Snippet]{Body: *snippet}, nil
+	})
+}
+
+// publishPreview checks a server.json like a publish and stores it as a preview. Tokens that may only publish
+// previews are accepted, and checks that need upstream requests are skipped, since nothing is published.
+func publishPreview(ctx context.Context, registry service.RegistryService, cfg *config.Config, jwtManager *auth.JWTManager, claims *auth.JWTClaims, server *apiv0.ServerJSON, pathPrefix string) (*validator.ValidationResult, *apiv0.ServerResponse, error) {
+	if cfg.PreviewTTL <= 0 {
+		return nil, nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Previews are disabled on this registry"))
+	}
+	if !jwtManager.HasPermission(server.Name, auth.PermissionActionPreview, claims.Permissions) &&
+		!jwtManager.HasPermission(server.Name, auth.PermissionActionPublish, claims.Permissions) {
+		return nil, nil, withErrorCode(apiv0.ErrorCodeNamespaceForbidden, huma.Error403Forbidden(buildPermissionErrorMessage(server.Name, claims.Permissions)))
+	}
+
+	result, err := validatePublish(ctx, registry, cfg, server)
+	if err != nil {
+		return result, nil, err
+	}
+
+	preview, err := registry.CreatePreview(ctx, database.Preview{
+		Server:      *server,
+		Publisher:   publisherFromClaims(claims, server.Name),
+		SubmittedBy: publishIdentity(claims),
+		PullRequest: claims.PullRequest,
+	})
+	if err != nil {
+		return result, nil, serviceError(http.StatusBadRequest, "Failed to publish preview", err)
+	}
+
+	RecordAudit(ctx, registry, claims, database.AuditEntry{
+		Action:    database.AuditActionPreview,
+		Namespace: serverNamespace(server.Name),
+		Resource:  server.Name + "@" + server.Version,
+		Details:   map[string]any{"preview": preview.ID, "pullRequest": preview.PullRequest},
+	})
+
+	response := previewResponse(preview, cfg, pathPrefix)
+	return result, &response, nil
+}
+
+// getPreview retrieves an unexpired preview, as a 404 if there is none
+func getPreview(ctx context.Context, registry service.RegistryService, id string) (*database.Preview, error) {
+	preview, err := registry.GetPreview(ctx, id)
+	if err != nil {
+		if errors.Is(err, service.ErrNotFound) {
+			return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Preview not found or expired"))
+		}
+		return nil, huma.Error500InternalServerError("Failed to get preview", err)
+	}
+	return preview, nil
+}
+
+// previewResponse presents a preview as a server response, with the preview's URL and expiry in _meta
+func previewResponse(preview *database.Preview, cfg *config.Config, pathPrefix string) apiv0.ServerResponse {
+	return apiv0.ServerResponse{
+		Server: preview.Server,
+		Meta: apiv0.ResponseMeta{
+			Preview: &apiv0.PreviewMetadata{
+				URL:         strings.TrimSuffix(cfg.PublicURL, "/") + pathPrefix + "/previews/" + preview.ID,
+				PullRequest: preview.PullRequest,
+				Publisher:   preview.Publisher,
+				CreatedAt:   preview.CreatedAt,
+				ExpiresAt:   preview.ExpiresAt,
+			},
+		},
+	}
+}
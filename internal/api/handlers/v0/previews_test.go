@@ -0,0 +1,117 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestPreviewPublishes(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), PreviewTTL: time.Hour, PublicURL: "https://registry.example.com"}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterPreviewEndpoints(api, "/v0", registry, cfg)
+
+	response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(ctx, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubOIDC,
+		AuthMethodSubject: "repo:octocat/weather:pull_request",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPreview, ResourcePattern: "io.github.octocat/*"}},
+		PullRequest:       "octocat/weather#42",
+	})
+	require.NoError(t, err)
+	previewToken := "Bearer " + response.RegistryToken
+
+	server := apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.octocat/weather",
+		Description: "Weather forecasts for any city",
+		Version:     "1.1.0",
+		Remotes:     []model.Transport{{Type: model.TransportTypeStreamableHTTP, URL: "https://weather.example.com/mcp"}},
+	}
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("pull request tokens cannot publish", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/publish", previewToken, server)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "can only publish previews")
+	})
+
+	var preview apiv0.ServerResponse
+	t.Run("publishes a preview", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/publish?preview=true", previewToken, server)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &preview))
+		require.NotNil(t, preview.Meta.Preview)
+		assert.True(t, strings.HasPrefix(preview.Meta.Preview.URL, "https://registry.example.com/v0/previews/"))
+		assert.Equal(t, "octocat/weather#42", preview.Meta.Preview.PullRequest)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), preview.Meta.Preview.ExpiresAt, time.Minute)
+		assert.Nil(t, preview.Meta.Official)
+	})
+	path := strings.TrimPrefix(preview.Meta.Preview.URL, "https://registry.example.com")
+
+	t.Run("the preview is not published", func(t *testing.T) {
+		_, err := registry.GetServerByName(ctx, server.Name, false)
+		assert.ErrorIs(t, err, service.ErrNotFound)
+	})
+
+	t.Run("serves the preview and its install configuration", func(t *testing.T) {
+		w := do(http.MethodGet, path, "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var served apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &served))
+		assert.Equal(t, server.Version, served.Server.Version)
+
+		w = do(http.MethodGet, path+"/install?client=vscode", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "https://weather.example.com/mcp")
+	})
+
+	t.Run("unknown and expired previews are not found", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/previews/0123456789abcdef", "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		deleted, err := registry.PurgePreviews(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, deleted, "unexpired previews are kept")
+	})
+}
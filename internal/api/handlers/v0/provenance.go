@@ -0,0 +1,63 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// PackageProvenanceInput represents the input for retrieving a server version's package provenance
+type PackageProvenanceInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" doc:"URL-encoded server version, or 'latest'" example:"1.0.0"`
+}
+
+// RegisterProvenanceEndpoint registers the endpoint returning the upstream provenance recorded at publish
+func RegisterProvenanceEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-provenance" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/provenance",
+		Summary:     "Get package provenance for an MCP server version",
+		Description: "Return the exact version, integrity hash or digest, and upstream publish time each package resolved to when the server version was validated against its registry. Records are immutable, so a package whose upstream integrity no longer matches has been republished since.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *PackageProvenanceInput) (*CacheableResponse[apiv0.PackageProvenanceResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid version encoding", err))
+		}
+
+		// Provenance outlives a deletion, since that's when consumers are most likely to need it
+		var serverResponse *apiv0.ServerResponse
+		if version == "latest" {
+			serverResponse, err = registry.GetServerByName(ctx, serverName, true)
+		} else {
+			serverResponse, err = registry.GetServerByNameAndVersion(ctx, serverName, version, true)
+		}
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("Server not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server details", err)
+		}
+
+		provenance, err := registry.GetPackageProvenance(ctx, serverName, serverResponse.Server.Version)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get package provenance", err)
+		}
+
+		body := apiv0.PackageProvenanceResponse{Packages: provenance}
+		return newCacheableResponse(body, cdn.KeysForServer(serverName)), nil
+	})
+}
@@ -2,77 +2,274 @@ package v0
 
 import (
 	"context"
+	"errors"
+	"log"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
 	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 )
 
 // PublishServerInput represents the input for publishing a server
 type PublishServerInput struct {
-	Authorization string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"true"`
+	Authorization string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)"`
+	Preview       bool             `query:"preview" doc:"Store the server as a preview instead of publishing it. Previews are only reachable through the URL in the response's _meta and expire." default:"false"`
 	Body          apiv0.ServerJSON `body:""`
 }
 
+// PublishServerOutput represents the response for publishing a server
+type PublishServerOutput struct {
+	PossibleDuplicates string   `header:"X-Registry-Possible-Duplicates" doc:"Comma-separated names of other servers sharing this server's repository URL or a remote endpoint"`
+	ValidationWarnings []string `header:"X-Registry-Validation-Warning" doc:"Non-blocking validation warnings, one header per warning, formatted as '<path>: <message> (<rule>)'"`
+	Body               apiv0.ServerResponse
+}
+
 // RegisterPublishEndpoint registers the publish endpoint with a custom path prefix
 func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	// Create JWT manager for token validation
 	jwtManager := auth.NewJWTManager(cfg)
+	repoVerifier := validators.NewGitHubRepositoryVerifier(cfg)
 
-	huma.Register(api, huma.Operation{
+	// Bound the body before it is decoded, since anyone can send one before their token is checked
+	huma.Register(api, upgradedBodyOperation(api, limitedBodyOperation(api, huma.Operation{
 		OperationID: "publish-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
 		Method:      http.MethodPost,
 		Path:        pathPrefix + "/publish",
 		Summary:     "Publish MCP server",
-		Description: "Publish a new MCP server to the registry or update an existing one",
+		Description: "Publish a new MCP server to the registry or update an existing one. Bodies larger than the registry's size limit are rejected with 413, and bodies nested too deeply, repeating a key or with top-level fields server.json does not define are rejected with 400 and code INVALID_JSON.",
 		Tags:        []string{"publish"},
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
-	}, func(ctx context.Context, input *PublishServerInput) (*Response[apiv0.ServerResponse], error) {
-		// Extract bearer token
-		const bearerPrefix = "Bearer "
-		authHeader := input.Authorization
-		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
-			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}, publishLimits(cfg))), func(ctx context.Context, input *PublishServerInput) (output *PublishServerOutput, err error) {
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
 		}
-		token := authHeader[len(bearerPrefix):]
 
-		// Validate Registry JWT token
-		claims, err := jwtManager.ValidateToken(ctx, token)
-		if err != nil {
-			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+		// Keep the outcome in the publisher's history, so CI owners can see why a publish failed
+		var validationResult *validator.ValidationResult
+		defer func() {
+			recordPublishAttempt(ctx, registry, claims, &input.Body, http.StatusOK, validationResult, err)
+		}()
+
+		if input.Preview {
+			var preview *apiv0.ServerResponse
+			validationResult, preview, err = publishPreview(ctx, registry, cfg, jwtManager, claims, &input.Body, pathPrefix)
+			if err != nil {
+				return nil, err
+			}
+			return &PublishServerOutput{
+				ValidationWarnings: formatValidationIssues(validationResult.Warnings()),
+				Body:               *preview,
+			}, nil
 		}
 
-		// Verify that the token has permission to publish the server
-		if !jwtManager.HasPermission(input.Body.Name, auth.PermissionActionPublish, claims.Permissions) {
-			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(input.Body.Name, claims.Permissions))
+		validationResult, err = checkPublish(ctx, registry, cfg, jwtManager, claims, &input.Body)
+		if err != nil {
+			return nil, err
 		}
 
-		// Validate server JSON structure and schema (returns 422 on validation failure)
-		validationResult := validators.ValidateServerJSON(&input.Body, validators.ValidationSchemaVersionAndSemantic)
-		if !validationResult.Valid {
-			return nil, huma.Error422UnprocessableEntity("Failed to publish server, invalid schema: call /validate for details")
+		// Check the linked GitHub repository still belongs to the namespace owner
+		if err := repoVerifier.Verify(ctx, &input.Body); err != nil {
+			return nil, huma.Error403Forbidden("Failed to publish server", err)
 		}
 
 		// Publish the server with extensions
-		publishedServer, err := registry.CreateServer(ctx, &input.Body)
+		publishedServer, err := registry.CreateServer(service.WithPublisher(withSubmittedDocument(ctx), publisherFromClaims(claims, input.Body.Name)), &input.Body)
 		if err != nil {
-			return nil, huma.Error400BadRequest("Failed to publish server", err)
+			return nil, serviceError(http.StatusBadRequest, "Failed to publish server", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionPublish,
+			Namespace: serverNamespace(publishedServer.Server.Name),
+			Resource:  publishedServer.Server.Name + "@" + publishedServer.Server.Version,
+		})
+
+		// Warn about likely duplicates without failing the publish
+		duplicates, err := registry.FindPossibleDuplicates(ctx, publishedServer.Server.Name)
+		if err != nil {
+			log.Printf("Failed to check possible duplicates of %s: %v", publishedServer.Server.Name, err)
 		}
 
 		// Return the published server response with metadata
-		return &Response[apiv0.ServerResponse]{
-			Body: *publishedServer,
+		return &PublishServerOutput{
+			PossibleDuplicates: strings.Join(duplicates, ","),
+			ValidationWarnings: formatValidationIssues(validationResult.Warnings()),
+			Body:               *publishedServer,
 		}, nil
 	})
 }
 
+// checkPublish runs the checks that need no upstream requests: the token's permission to publish the server,
+// validation with the namespace's policy, and screening of its name and description. The validation result
+// is returned even when the server is invalid, for the publish history.
+func checkPublish(ctx context.Context, registry service.RegistryService, cfg *config.Config, jwtManager *auth.JWTManager, claims *auth.JWTClaims, server *apiv0.ServerJSON) (*validator.ValidationResult, error) {
+	// Verify that the token has permission to publish the server
+	if err := authorizeServer(ctx, jwtManager, claims, server.Name, auth.AuthLevelPublish); err != nil {
+		return nil, err
+	}
+	if err := checkIdentityBan(ctx, registry, claims); err != nil {
+		return nil, err
+	}
+	return validatePublish(ctx, registry, cfg, server)
+}
+
+// validatePublish validates a server.json with the namespace's policy and screens its name and description.
+// The validation result is returned even when the server is invalid, for the publish history.
+func validatePublish(ctx context.Context, registry service.RegistryService, cfg *config.Config, server *apiv0.ServerJSON) (*validator.ValidationResult, error) {
+	// Validate server JSON structure and schema (returns 422 on validation failure)
+	opts, err := namespaceValidationOptions(ctx, registry, lintedValidationOptions(validator.ValidationSchemaVersionAndSemantic, cfg), server.Name)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	result := validator.ValidateServerJSON(server, opts)
+	mergeSchemaUpgrade(ctx, result)
+	telemetry.RecordValidatorStage(ctx, telemetry.ValidatorStageSchema, "", start, result.FirstError())
+	if !result.Valid {
+		return result, withErrorCode(apiv0.ErrorCodeSchemaValidationFailed, huma.Error422UnprocessableEntity("Failed to publish server, invalid schema: call /validate for details"))
+	}
+
+	// Reject reserved and prohibited terms in the name, title and description
+	if err := registry.ScreenServer(ctx, server); err != nil {
+		return result, huma.Error422UnprocessableEntity("Failed to publish server", err)
+	}
+	return result, nil
+}
+
+// publisherFromClaims describes the identity a registry token proved, for display on the server versions
+// published with it
+func publisherFromClaims(claims *auth.JWTClaims, serverName string) *apiv0.Publisher {
+	publisher := &apiv0.Publisher{AuthMethod: string(claims.AuthMethod), Subject: claims.AuthMethodSubject}
+	switch claims.AuthMethod {
+	case auth.MethodGitHubAT:
+		// The login may publish for itself or any organization it belongs to, named by the namespace
+		publisher.GitHubLogin = claims.AuthMethodSubject
+		publisher.GitHubOrg = githubAccount(serverNamespace(serverName))
+	case auth.MethodGitHubOIDC:
+		// Subjects have the form repo:<owner>/<repository>:<context>
+		if repository, found := strings.CutPrefix(claims.AuthMethodSubject, "repo:"); found {
+			publisher.GitHubOrg, _, _ = strings.Cut(repository, "/")
+		}
+	case auth.MethodDNS, auth.MethodHTTP:
+		publisher.Domain = claims.AuthMethodSubject
+	case auth.MethodAPIKey:
+		// Subjects have the form <organization namespace>/<key name>
+		organization, _, _ := strings.Cut(claims.AuthMethodSubject, "/")
+		if account := githubAccount(organization); account != "" {
+			publisher.GitHubOrg = account
+		} else {
+			labels := strings.Split(organization, ".")
+			slices.Reverse(labels)
+			publisher.Domain = strings.Join(labels, ".")
+		}
+	}
+	return publisher
+}
+
+// githubAccount returns the GitHub account named by an io.github namespace, or "" for other namespaces
+func githubAccount(namespace string) string {
+	account, found := strings.CutPrefix(namespace, "io.github.")
+	if !found {
+		return ""
+	}
+	return account
+}
+
+// lintedValidationOptions adds the best-practice checks to opts, blocking on the rules the registry enforces
+func lintedValidationOptions(opts validator.ValidationOptions, cfg *config.Config) validator.ValidationOptions {
+	opts.ValidateLint = true
+	opts.BlockingLintRules = cfg.ValidationBlockingLintRules
+	return opts
+}
+
+// namespaceValidationOptions adds the validation policy operators set for the server's namespace, if any, to opts
+func namespaceValidationOptions(ctx context.Context, registry service.RegistryService, opts validator.ValidationOptions, serverName string) (validator.ValidationOptions, error) {
+	policy, err := registry.ValidationPolicyFor(ctx, serverName)
+	if err != nil {
+		return opts, huma.Error500InternalServerError("Failed to get validation policy", err)
+	}
+	opts.Policy = policy
+	return opts, nil
+}
+
+// formatValidationIssues renders each issue as "<path>: <message> (<rule>)" for response headers
+func formatValidationIssues(issues []validator.ValidationIssue) []string {
+	var formatted []string
+	for _, issue := range issues {
+		text := issue.Message
+		if issue.Path != "" {
+			text = issue.Path + ": " + text
+		}
+		if issue.Reference != "" {
+			text += " (" + issue.Reference + ")"
+		}
+		formatted = append(formatted, text)
+	}
+	return formatted
+}
+
+// recordPublishAttempt stores the outcome of a publish request in the publisher's history, with status if
+// it succeeded, quarantining the server.json and validation output of a rejected one. Failing to store it is
+// logged without failing the request.
+func recordPublishAttempt(ctx context.Context, registry service.RegistryService, claims *auth.JWTClaims, server *apiv0.ServerJSON, status int, result *validator.ValidationResult, err error) {
+	attempt := database.PublishAttempt{
+		Identity:   publishIdentity(claims),
+		ServerName: server.Name,
+		Version:    server.Version,
+		Status:     status,
+	}
+	if upgrade := schemaUpgradeFromContext(ctx); upgrade != nil {
+		attempt.SchemaVersion = upgrade.Version
+	}
+	quarantined := &database.QuarantinedPublish{Server: *server}
+	if result != nil {
+		quarantined.ValidationIssues = result.Issues
+		var blocking []validator.ValidationIssue
+		for _, issue := range result.Issues {
+			if issue.Severity == validator.ValidationIssueSeverityError {
+				blocking = append(blocking, issue)
+			}
+		}
+		attempt.ValidationErrors = formatValidationIssues(blocking)
+		attempt.ValidationWarnings = formatValidationIssues(result.Warnings())
+	}
+	if err != nil {
+		attempt.Status = http.StatusInternalServerError
+		attempt.Error = err.Error()
+		var model *ErrorModel
+		if errors.As(err, &model) {
+			attempt.Status = model.Status
+			attempt.ErrorCode = string(model.Code)
+			attempt.Error = model.Detail
+			details := make([]string, 0, len(model.Errors))
+			for _, detail := range model.Errors {
+				details = append(details, detail.Message)
+			}
+			if len(details) > 0 {
+				attempt.Error += ": " + strings.Join(details, "; ")
+			}
+		}
+	}
+
+	// Record the attempt even if the client has gone away
+	if err := registry.RecordPublishAttempt(context.WithoutCancel(ctx), attempt, quarantined); err != nil {
+		log.Printf("Failed to record publish attempt for %s: %v", server.Name, err)
+	}
+}
+
 // buildPermissionErrorMessage creates a detailed error message showing what permissions
 // the user has and what they're trying to publish
 func buildPermissionErrorMessage(attemptedResource string, permissions []auth.Permission) string {
@@ -91,6 +288,11 @@ func buildPermissionErrorMessage(attemptedResource string, permissions []auth.Pe
 	}
 	errorMsg += ". Attempting to publish: " + attemptedResource
 
+	// Tokens issued for pull request jobs can only publish previews
+	if slices.ContainsFunc(permissions, func(perm auth.Permission) bool { return perm.Action == auth.PermissionActionPreview }) {
+		errorMsg += ". Tokens issued for pull requests can only publish previews, with ?preview=true"
+	}
+
 	// Add helpful hint for GitHub organization publishing issues
 	if strings.HasPrefix(attemptedResource, "io.github.") {
 		errorMsg += ". If you're trying to publish to a GitHub organization, you may need to make your organization membership public in your GitHub settings: https://docs.github.com/en/account-and-profile/how-tos/organization-membership/publicizing-or-hiding-organization-membership"
@@ -0,0 +1,106 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// PublishServerAsyncOutput represents the response for accepting an asynchronous publish
+type PublishServerAsyncOutput struct {
+	Location           string   `header:"Location" doc:"URL to follow the publish at"`
+	ValidationWarnings []string `header:"X-Registry-Validation-Warning" doc:"Non-blocking validation warnings, one header per warning, formatted as '<path>: <message> (<rule>)'"`
+	Body               database.PendingPublish
+}
+
+// GetPendingPublishInput represents the input for following an asynchronous publish
+type GetPendingPublishInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token that submitted the publish, or can publish the server"`
+	ID            int64  `path:"id" doc:"Publish ID" example:"1"`
+}
+
+// RegisterAsyncPublishEndpoints registers the endpoints for publishing asynchronously and following the outcome
+func RegisterAsyncPublishEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, upgradedBodyOperation(api, limitedBodyOperation(api, huma.Operation{
+		OperationID:   "publish-server-async" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodPost,
+		Path:          pathPrefix + "/publish/async",
+		DefaultStatus: http.StatusAccepted,
+		Summary:       "Publish MCP server asynchronously",
+		Description:   "Accept a server version for publishing after the checks that need no upstream requests: permissions, schema and semantic validation, and name screening. Package registry lookups, link checks and repository verification run in the background; follow the publish at the returned Location until it is active or rejected. Failed checks are reported like /publish does.",
+		Tags:          []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, publishLimits(cfg))), func(ctx context.Context, input *PublishServerInput) (output *PublishServerAsyncOutput, err error) {
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		var validationResult *validator.ValidationResult
+		defer func() {
+			recordPublishAttempt(ctx, registry, claims, &input.Body, http.StatusAccepted, validationResult, err)
+		}()
+
+		validationResult, err = checkPublish(ctx, registry, cfg, jwtManager, claims, &input.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		publish, err := registry.SubmitPublish(service.WithPublisher(withSubmittedDocument(ctx), publisherFromClaims(claims, input.Body.Name)), &input.Body, publishIdentity(claims))
+		if err != nil {
+			if errors.Is(err, service.ErrAlreadyExists) {
+				return nil, huma.Error409Conflict("A publish of this version is already pending", err)
+			}
+			return nil, serviceError(http.StatusBadRequest, "Failed to publish server", err)
+		}
+
+		return &PublishServerAsyncOutput{
+			Location:           pathPrefix + "/publish/async/" + strconv.FormatInt(publish.ID, 10),
+			ValidationWarnings: formatValidationIssues(validationResult.Warnings()),
+			Body:               *publish,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-async-publish" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/publish/async/{id}",
+		Summary:     "Get asynchronous publish status",
+		Description: "Return whether an asynchronous publish is still pending, became active, or was rejected and why. Available to the identity that submitted it and to tokens that can publish the server, for a week after it finishes.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *GetPendingPublishInput) (*Response[database.PendingPublish], error) {
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		publish, err := registry.GetPendingPublish(ctx, input.ID)
+		if err != nil && !errors.Is(err, service.ErrNotFound) {
+			return nil, huma.Error500InternalServerError("Failed to get publish", err)
+		}
+		// Publishes the caller may not see are reported as missing, so IDs reveal nothing about other namespaces
+		if err != nil || (publish.SubmittedBy != publishIdentity(claims) &&
+			!jwtManager.HasPermission(publish.ServerName, auth.PermissionActionPublish, claims.Permissions)) {
+			return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Publish not found"))
+		}
+		return &Response[database.PendingPublish]{Body: *publish}, nil
+	})
+}
@@ -0,0 +1,169 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestAsyncPublishEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), AsyncPublishEnabled: true}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterAsyncPublishEndpoints(api, "/v0", registry, cfg)
+
+	token := func(subject, pattern string) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(ctx, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: subject,
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	publisherToken := token("octocat", "io.github.octocat/*")
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	server := func(version string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.octocat/weather",
+			Description: "Weather forecasts",
+			Version:     version,
+		}
+	}
+
+	// status follows a publish at the Location it was accepted with
+	status := func(location, authorization string) (int, database.PendingPublish) {
+		w := do(http.MethodGet, location, authorization, nil)
+		var publish database.PendingPublish
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &publish))
+		}
+		return w.Code, publish
+	}
+
+	t.Run("accepts then publishes", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/publish/async", publisherToken, server("1.0.0"))
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		var accepted database.PendingPublish
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &accepted))
+		assert.Equal(t, database.PublishPending, accepted.State)
+		location := w.Header().Get("Location")
+		require.NotEmpty(t, location)
+
+		// Not published until the worker runs
+		_, err := registry.GetServerByNameAndVersion(ctx, "io.github.octocat/weather", "1.0.0", false)
+		assert.ErrorIs(t, err, database.ErrNotFound)
+
+		code, publish := status(location, publisherToken)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, database.PublishPending, publish.State)
+
+		// A second publish of the same version can't be pending at the same time
+		w = do(http.MethodPost, "/v0/publish/async", publisherToken, server("1.0.0"))
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		ran, err := registry.RunNextPendingPublish(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, ran)
+		assert.Equal(t, accepted.ID, ran.ID)
+
+		code, publish = status(location, publisherToken)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, database.PublishActive, publish.State)
+		assert.NotNil(t, publish.FinishedAt)
+
+		published, err := registry.GetServerByNameAndVersion(ctx, "io.github.octocat/weather", "1.0.0", false)
+		require.NoError(t, err)
+		assert.Equal(t, "Weather forecasts", published.Server.Description)
+		// The version's document is the request body as it was submitted
+		submitted, err := json.Marshal(server("1.0.0"))
+		require.NoError(t, err)
+		assert.Equal(t, apiv0.DocumentDigest(append(submitted, '\n')), published.Meta.Official.DocumentDigest)
+
+		ran, err = registry.RunNextPendingPublish(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, ran)
+	})
+
+	t.Run("rejects when a background check fails", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/publish/async", publisherToken, server("2.0.0"))
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		location := w.Header().Get("Location")
+
+		// Another publish of the version lands first, so creating it in the background fails
+		existing := server("2.0.0")
+		_, err := registry.CreateServer(ctx, &existing)
+		require.NoError(t, err)
+
+		_, err = registry.RunNextPendingPublish(ctx)
+		require.NoError(t, err)
+
+		code, publish := status(location, publisherToken)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, database.PublishRejected, publish.State)
+		assert.NotEmpty(t, publish.Error)
+	})
+
+	t.Run("quick checks fail synchronously", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/publish/async", token("octocat", "io.github.other/*"), server("3.0.0"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = do(http.MethodPost, "/v0/publish/async", publisherToken, server("1.0.0"))
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("hides publishes from other identities", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/publish/async", publisherToken, server("4.0.0"))
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		location := w.Header().Get("Location")
+
+		code, _ := status(location, token("someone-else", "io.github.someone-else/*"))
+		assert.Equal(t, http.StatusNotFound, code)
+
+		// Tokens that can publish the server see it even if they didn't submit it
+		code, _ = status(location, token("maintainer", "io.github.octocat/*"))
+		assert.Equal(t, http.StatusOK, code)
+
+		code, _ = status("/v0/publish/async/999", publisherToken)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
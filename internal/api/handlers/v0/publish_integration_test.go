@@ -46,7 +46,7 @@ func TestPublishIntegration(t *testing.T) {
 	}
 
 	// Setup fake service
-	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), testConfig)
 
 	// Create a new ServeMux and Huma API
 	mux := http.NewServeMux()
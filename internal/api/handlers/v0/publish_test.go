@@ -9,7 +9,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
@@ -201,7 +203,7 @@ func TestPublishEndpoint(t *testing.T) {
 				}
 				_, _ = registry.CreateServer(context.Background(), &existingServer)
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusConflict,
 			expectedError:  "invalid version: cannot publish duplicate version",
 		},
 		{
@@ -389,7 +391,7 @@ func TestPublishEndpoint(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Create registry service
-			registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+			registryService := service.NewTestRegistryService(t, database.NewTestDB(t), testConfig)
 
 			// Setup registry service
 			tc.setupRegistryService(registryService)
@@ -491,7 +493,7 @@ func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Create registry service
-			registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+			registryService := service.NewTestRegistryService(t, database.NewTestDB(t), testConfig)
 
 			// Create a new ServeMux and Huma API
 			mux := http.NewServeMux()
@@ -542,3 +544,311 @@ func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishEndpoint_ValidationWarnings(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	publish := func(t *testing.T, cfg *config.Config) *httptest.ResponseRecorder {
+		t.Helper()
+		registryService := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+
+		// No icons and a short description only produce warnings by default
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/warned-server",
+			Description: "Short",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:  auth.MethodNone,
+			Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}},
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("warnings are returned as headers", func(t *testing.T) {
+		rr := publish(t, &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		warnings := rr.Header().Values(apiv0.ValidationWarningHeader)
+		assert.Equal(t, []string{
+			"icons: server has no icons; clients show a generic placeholder (missing-icon)",
+			"description: description is shorter than 20 characters; describe what the server does (short-description)",
+		}, warnings)
+	})
+
+	t.Run("blocking lint rules reject the publish", func(t *testing.T) {
+		rr := publish(t, &config.Config{
+			JWTPrivateKey:               hex.EncodeToString(testSeed),
+			ValidationBlockingLintRules: []string{"short-description"},
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
+
+func TestPublishEndpoint_RecordsPublisher(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	testCases := []struct {
+		name       string
+		serverName string
+		method     auth.Method
+		subject    string
+		expected   *apiv0.Publisher
+		verified   bool
+	}{
+		{
+			name:       "GitHub login publishing for an organization",
+			serverName: "io.github.acme/weather",
+			method:     auth.MethodGitHubAT,
+			subject:    "octocat",
+			expected:   &apiv0.Publisher{AuthMethod: "github-at", Subject: "octocat", GitHubLogin: "octocat", GitHubOrg: "acme"},
+			verified:   true,
+		},
+		{
+			name:       "GitHub Actions",
+			serverName: "io.github.acme/weather",
+			method:     auth.MethodGitHubOIDC,
+			subject:    "repo:acme/weather:ref:refs/heads/main",
+			expected:   &apiv0.Publisher{AuthMethod: "github-oidc", Subject: "repo:acme/weather:ref:refs/heads/main", GitHubOrg: "acme"},
+			verified:   true,
+		},
+		{
+			name:       "DNS",
+			serverName: "com.example/weather",
+			method:     auth.MethodDNS,
+			subject:    "example.com",
+			expected:   &apiv0.Publisher{AuthMethod: "dns", Subject: "example.com", Domain: "example.com"},
+			verified:   true,
+		},
+		{
+			name:       "organization API key of a domain namespace",
+			serverName: "com.example/weather",
+			method:     auth.MethodAPIKey,
+			subject:    "com.example/release-pipeline",
+			expected:   &apiv0.Publisher{AuthMethod: "api-key", Subject: "com.example/release-pipeline", Domain: "example.com"},
+			verified:   true,
+		},
+		{
+			name:       "anonymous",
+			serverName: "com.example/weather",
+			method:     auth.MethodNone,
+			subject:    "anonymous",
+			expected:   &apiv0.Publisher{AuthMethod: "none", Subject: "anonymous"},
+			verified:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registryService := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+
+			body, err := json.Marshal(apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        tc.serverName,
+				Description: "Publisher identity test server",
+				Version:     "1.0.0",
+			})
+			require.NoError(t, err)
+			token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+				AuthMethod:        tc.method,
+				AuthMethodSubject: tc.subject,
+				Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}},
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+			// The publisher is stored, not only echoed in the publish response
+			stored, err := registryService.GetServerByName(context.Background(), tc.serverName, false)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, stored.Meta.Official.Publisher)
+			assert.Equal(t, tc.verified, stored.Meta.Official.VerifiedPublisher)
+		})
+	}
+}
+
+func TestPublishEndpoint_BoundedBody(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), PublishMaxBodyBytes: 2048, PublishMaxJSONDepth: 8}
+
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+
+	token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:  auth.MethodNone,
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}},
+	})
+	require.NoError(t, err)
+
+	const validPrefix = `{"$schema":"` + model.CurrentSchemaURL + `","name":"com.example/weather","description":"Weather server","version":"1.0.0"`
+	testCases := []struct {
+		name     string
+		body     string
+		status   int
+		code     apiv0.ErrorCode
+		contains string
+	}{
+		{
+			name:   "valid body",
+			body:   validPrefix + `,"_meta":{"io.modelcontextprotocol.registry/publisher-provided":{"tool":"ci"}}}`,
+			status: http.StatusOK,
+		},
+		{
+			name:   "too large",
+			body:   validPrefix + `,"title":"` + strings.Repeat("a", 2048) + `"}`,
+			status: http.StatusRequestEntityTooLarge,
+			code:   apiv0.ErrorCodeRequestTooLarge,
+		},
+		{
+			name:     "duplicate key",
+			body:     validPrefix + `,"name":"com.example/other"}`,
+			status:   http.StatusBadRequest,
+			code:     apiv0.ErrorCodeInvalidJSON,
+			contains: `duplicate key \"name\"`,
+		},
+		{
+			name:     "unknown top-level field",
+			body:     validPrefix + `,"vendor":{"tool":"ci"}}`,
+			status:   http.StatusBadRequest,
+			code:     apiv0.ErrorCodeInvalidJSON,
+			contains: `unknown field \"vendor\"`,
+		},
+		{
+			name:     "field of an earlier schema version",
+			body:     validPrefix + `,"status":"active"}`,
+			status:   http.StatusBadRequest,
+			code:     apiv0.ErrorCodeInvalidJSON,
+			contains: `unknown field \"status\"`,
+		},
+		{
+			name:     "nested too deeply",
+			body:     validPrefix + `,"_meta":{"io.modelcontextprotocol.registry/publisher-provided":` + strings.Repeat("[", 10) + strings.Repeat("]", 10) + `}}`,
+			status:   http.StatusBadRequest,
+			code:     apiv0.ErrorCodeInvalidJSON,
+			contains: "nested more than 8 levels deep",
+		},
+		{
+			name:   "trailing data",
+			body:   validPrefix + `}{}`,
+			status: http.StatusBadRequest,
+			code:   apiv0.ErrorCodeInvalidJSON,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v0/publish", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+			require.Equal(t, tc.status, rr.Code, rr.Body.String())
+			if tc.code != "" {
+				var errorBody v0.ErrorModel
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorBody))
+				assert.Equal(t, tc.code, errorBody.Code)
+			}
+			assert.Contains(t, rr.Body.String(), tc.contains)
+		})
+	}
+}
+
+func TestPublishEndpoint_EarlierSchemaVersions(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), PublishHistoryRetention: time.Hour}
+
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+
+	token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:  auth.MethodNone,
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}},
+	})
+	require.NoError(t, err)
+	publish := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("upgrades documents to the current schema", func(t *testing.T) {
+		rr := publish(`{
+			"$schema": "https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json",
+			"name": "com.example/weather",
+			"description": "Weather forecasts for any city",
+			"version": "1.0.0",
+			"status": "active",
+			"website_url": "https://example.com/weather",
+			"packages": [{"registry_type": "npm", "identifier": "@example/weather", "version": "1.0.0", "transport": {"type": "stdio"}}]
+		}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Header().Values(apiv0.ValidationWarningHeader),
+			"schema: server.json was written for schema version 2025-07-09 and was upgraded to the current version ("+model.CurrentSchemaVersion+"). Update it to the current schema version (schema-version-upgraded)")
+
+		server, err := registryService.GetServerByNameAndVersion(context.Background(), "com.example/weather", "1.0.0", false)
+		require.NoError(t, err)
+		assert.Equal(t, model.CurrentSchemaURL, server.Server.Schema)
+		assert.Equal(t, "https://example.com/weather", server.Server.WebsiteURL)
+		require.Len(t, server.Server.Packages, 1)
+		assert.Equal(t, "npm", server.Server.Packages[0].RegistryType)
+	})
+
+	t.Run("rejects documents invalid for their own schema version", func(t *testing.T) {
+		rr := publish(`{
+			"$schema": "https://static.modelcontextprotocol.io/schemas/2025-09-29/server.schema.json",
+			"name": "com.example/weather",
+			"description": "Weather forecasts for any city",
+			"version": "1.0.1",
+			"packages": [{"registryType": "mcpb", "identifier": "https://github.com/example/weather/releases/download/v1.0.1/weather.mcpb", "fileSha256": "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce", "transport": {"type": "stdio"}}]
+		}`)
+		// Package versions were required until 2025-10-11
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+	})
+
+	t.Run("records the original schema version", func(t *testing.T) {
+		attempts, err := registryService.ListPublishAttempts(context.Background(), "none:", 0, 10)
+		require.NoError(t, err)
+		require.Len(t, attempts, 2)
+		assert.Equal(t, "2025-09-29", attempts[0].SchemaVersion)
+		require.NotEmpty(t, attempts[0].ValidationErrors)
+		assert.Contains(t, attempts[0].ValidationErrors[0], "version")
+		assert.Equal(t, "2025-07-09", attempts[1].SchemaVersion)
+	})
+}
@@ -0,0 +1,149 @@
+package v0
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"golang.org/x/time/rate"
+
+	"github.com/modelcontextprotocol/registry/internal/api/clientip"
+)
+
+// maxRateLimitedClients bounds how many client addresses a limiter tracks before forgetting idle ones
+const maxRateLimitedClients = 10000
+
+// clientRateLimiter allows each client address a number of requests per minute, in bursts of up to that many
+type clientRateLimiter struct {
+	perMinute int
+
+	mu      sync.Mutex
+	clients map[string]*clientLimit
+}
+
+type clientLimit struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newClientRateLimiter(perMinute int) *clientRateLimiter {
+	return &clientRateLimiter{perMinute: perMinute, clients: map[string]*clientLimit{}}
+}
+
+// allow reports whether client may make a request now and, if not, how long until it may
+func (l *clientRateLimiter) allow(client string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit, ok := l.clients[client]
+	if !ok {
+		if len(l.clients) >= maxRateLimitedClients {
+			l.forgetIdleLocked(now)
+		}
+		limit = &clientLimit{limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(l.perMinute)), l.perMinute)}
+		l.clients[client] = limit
+	}
+	limit.lastSeen = now
+
+	reservation := limit.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// remaining returns how many requests client may make now, and when its whole budget is available again
+func (l *clientRateLimiter) remaining(client string, now time.Time) (int, time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit, ok := l.clients[client]
+	if !ok {
+		return l.perMinute, now
+	}
+	tokens := limit.limiter.TokensAt(now)
+	if tokens <= 0 {
+		tokens = 0
+	}
+	missing := float64(l.perMinute) - tokens
+	if missing <= 0 {
+		return l.perMinute, now
+	}
+	refill := time.Duration(missing * float64(time.Minute) / float64(l.perMinute))
+	return int(math.Floor(tokens)), now.Add(refill)
+}
+
+// forgetIdleLocked drops clients idle long enough for their whole burst to be available again
+func (l *clientRateLimiter) forgetIdleLocked(now time.Time) {
+	for client, limit := range l.clients {
+		if now.Sub(limit.lastSeen) > time.Minute {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// rateLimiterKey identifies the limiter of an operation of an API, shared by the operation's path prefixes
+type rateLimiterKey struct {
+	api  huma.API
+	name string
+}
+
+// rateLimiters holds the limiter of every rate-limited operation, so /v0 and /v0.1 draw on the same budget and
+// callers can be told what is left of it
+var rateLimiters sync.Map
+
+// RateLimitBudget is what is left of a client's budget for a rate-limited operation
+type RateLimitBudget struct {
+	Operation      string    `json:"operation" doc:"Operation the limit applies to, without its path prefix" example:"validate-server"`
+	LimitPerMinute int       `json:"limitPerMinute" doc:"Requests allowed per minute, which can all be made at once" example:"60"`
+	Remaining      int       `json:"remaining" doc:"Requests that can be made now before being rejected with 429" example:"58"`
+	ResetAt        time.Time `json:"resetAt" doc:"When the whole budget is available again" example:"2025-01-01T12:00:02Z"`
+}
+
+// rateLimitBudgets returns what is left of client's budget for every rate-limited operation of api, by operation
+func rateLimitBudgets(api huma.API, client string, now time.Time) []RateLimitBudget {
+	budgets := []RateLimitBudget{}
+	rateLimiters.Range(func(key, value any) bool {
+		limiterKey, _ := key.(rateLimiterKey)
+		if limiterKey.api != api {
+			return true
+		}
+		limiter, _ := value.(*clientRateLimiter)
+		remaining, resetAt := limiter.remaining(client, now)
+		budgets = append(budgets, RateLimitBudget{
+			Operation:      limiterKey.name,
+			LimitPerMinute: limiter.perMinute,
+			Remaining:      remaining,
+			ResetAt:        resetAt,
+		})
+		return true
+	})
+	sort.Slice(budgets, func(i, j int) bool { return budgets[i].Operation < budgets[j].Operation })
+	return budgets
+}
+
+// rateLimitedOperation limits how often each client address may call an operation, rejecting requests over
+// perMinute with 429 and a Retry-After header. Operations of an API with the same name, registered under
+// several path prefixes, share a limit. A limit of zero leaves the operation unlimited.
+func rateLimitedOperation(api huma.API, op huma.Operation, name string, perMinute int) huma.Operation {
+	if perMinute <= 0 {
+		return op
+	}
+	stored, _ := rateLimiters.LoadOrStore(rateLimiterKey{api: api, name: name}, newClientRateLimiter(perMinute))
+	limiter, _ := stored.(*clientRateLimiter)
+	op.Middlewares = append(op.Middlewares, func(ctx huma.Context, next func(huma.Context)) {
+		allowed, retryAfter := limiter.allow(clientip.FromContext(ctx.Context()), time.Now())
+		if !allowed {
+			ctx.SetHeader("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			_ = huma.WriteErr(api, ctx, http.StatusTooManyRequests, "Too many requests, retry after the time in the Retry-After header")
+			return
+		}
+		next(ctx)
+	})
+	return op
+}
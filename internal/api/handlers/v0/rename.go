@@ -0,0 +1,131 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// RenameServerBody represents the request body for renaming a server
+type RenameServerBody struct {
+	NewName string `json:"newName" required:"true" minLength:"3" maxLength:"200" doc:"New name of the server" example:"io.github.octocat/weather"`
+}
+
+// RenameServerInput represents the input for renaming a server
+type RenameServerInput struct {
+	Authorization string           `header:"Authorization" doc:"Registry JWT token with publish or edit permissions for both names"`
+	ServerName    string           `path:"serverName" doc:"URL-encoded current server name" example:"io.github.octocat%2Fweather-server"`
+	Body          RenameServerBody `body:""`
+}
+
+// ServerAliasesResponse lists the names a server was renamed from
+type ServerAliasesResponse struct {
+	Aliases []database.ServerAlias `json:"aliases" doc:"Names that still redirect to the server, ordered by name"`
+}
+
+// RegisterRenameEndpoints registers the endpoints for renaming servers and listing their aliases
+func RegisterRenameEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "rename-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/rename",
+		Summary:     "Rename an MCP server",
+		Description: "Move every version of a server to a new name. The old versions are deleted with a message pointing at the new name, and the old name becomes an alias: detail fetches of it redirect to the new name until the alias expires. The rename appears in the changes feed as a renamed change with previousName set. Requires publish or edit permission for both names.",
+		Tags:        []string{"servers"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RenameServerInput) (*Response[database.ServerAlias], error) {
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+
+		for _, name := range []string{serverName, input.Body.NewName} {
+			if err := authorizeServer(ctx, jwtManager, claims, name, auth.AuthLevelOwner); err != nil {
+				return nil, err
+			}
+		}
+
+		alias, err := registry.RenameServer(ctx, serverName, input.Body.NewName, publishIdentity(claims))
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidRename) {
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Failed to rename server", err))
+			}
+			return nil, serviceError(http.StatusUnprocessableEntity, "Failed to rename server", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionRename,
+			Namespace: serverNamespace(serverName),
+			Resource:  serverName,
+			Details:   map[string]any{"newName": alias.ServerName},
+		})
+
+		return &Response[database.ServerAlias]{Body: *alias}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-aliases" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/aliases",
+		Summary:     "List names an MCP server was renamed from",
+		Description: "List the previous names of a server that still redirect to it, with when each stops redirecting.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerDetailInput) (*CacheableResponse[ServerAliasesResponse], error) {
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+
+		aliases, err := registry.ListServerAliases(ctx, serverName)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list server aliases", err)
+		}
+
+		body := ServerAliasesResponse{Aliases: make([]database.ServerAlias, len(aliases))}
+		for i, alias := range aliases {
+			body.Aliases[i] = *alias
+		}
+		return newCacheableResponse(body, cdn.KeysForServer(serverName)), nil
+	})
+}
+
+// aliasRedirect returns a 308 redirect to the same path under the server's current name when serverName is
+// an alias, or nil when it is not. Callers use it once the name was not found, so aliases cost nothing on
+// successful fetches.
+func aliasRedirect(ctx context.Context, registry service.RegistryService, pathPrefix, serverName, subpath string, query url.Values) error {
+	alias, err := registry.GetServerAlias(ctx, serverName)
+	if err != nil {
+		return nil
+	}
+
+	location := pathPrefix + "/servers/" + url.PathEscape(alias.ServerName) + subpath
+	if encoded := query.Encode(); encoded != "" {
+		location += "?" + encoded
+	}
+	return huma.ErrorWithHeaders(
+		huma.NewError(http.StatusPermanentRedirect, "Server renamed to "+alias.ServerName),
+		http.Header{"Location": {location}},
+	)
+}
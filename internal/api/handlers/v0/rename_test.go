@@ -0,0 +1,174 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestRenameEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), ServerAliasDuration: 24 * time.Hour}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	ctx := context.Background()
+
+	for _, server := range []struct{ name, version string }{
+		{"io.github.octocat/weather-server", "1.0.0"},
+		{"io.github.octocat/weather-server", "1.1.0"},
+		{"io.github.octocat/notes", "1.0.0"},
+	} {
+		_, err := registry.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        server.name,
+			Description: "Test server",
+			Version:     server.version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registry, nil)
+	v0.RegisterServerChangesEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterRenameEndpoints(api, "/v0", registry, cfg)
+
+	token := func(pattern string) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(ctx, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "octocat",
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	ownerToken := token("io.github.octocat/*")
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	rename := func(from, to string) *httptest.ResponseRecorder {
+		return do(http.MethodPost, "/v0/servers/"+from+"/rename", ownerToken, map[string]any{"newName": to})
+	}
+
+	t.Run("requires permission for both names", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/servers/io.github.octocat%2Fweather-server/rename", token("io.github.octocat/weather-server"),
+			map[string]any{"newName": "io.github.octocat/weather"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = do(http.MethodPost, "/v0/servers/io.github.octocat%2Fweather-server/rename", token("io.github.other/*"),
+			map[string]any{"newName": "io.github.other/weather"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects invalid and taken names", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, rename("io.github.octocat%2Fweather-server", "io.github.octocat/weather-server").Code)
+		assert.Equal(t, http.StatusConflict, rename("io.github.octocat%2Fweather-server", "io.github.octocat/notes").Code)
+		assert.Equal(t, http.StatusNotFound, rename("io.github.octocat%2Fmissing", "io.github.octocat/found").Code)
+	})
+
+	t.Run("renames and redirects", func(t *testing.T) {
+		seq, err := registry.ListServerChanges(ctx, 0, 1000)
+		require.NoError(t, err)
+		since := seq[len(seq)-1].Seq
+
+		w := rename("io.github.octocat%2Fweather-server", "io.github.octocat/weather")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var alias database.ServerAlias
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &alias))
+		assert.Equal(t, "io.github.octocat/weather-server", alias.Alias)
+		assert.Equal(t, "io.github.octocat/weather", alias.ServerName)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), alias.ExpiresAt, time.Minute)
+
+		versions, err := registry.GetAllVersionsByServerName(ctx, "io.github.octocat/weather", false)
+		require.NoError(t, err)
+		assert.Len(t, versions, 2)
+
+		w = do(http.MethodGet, "/v0/servers/io.github.octocat%2Fweather-server/versions/latest", "", nil)
+		assert.Equal(t, http.StatusPermanentRedirect, w.Code)
+		assert.Equal(t, "/v0/servers/io.github.octocat%2Fweather/versions/latest", w.Header().Get("Location"))
+
+		w = do(http.MethodGet, "/v0/servers/io.github.octocat%2Fweather-server/versions?as_of=2030-01-01T00:00:00Z", "", nil)
+		assert.Equal(t, http.StatusPermanentRedirect, w.Code)
+		assert.Equal(t, "/v0/servers/io.github.octocat%2Fweather/versions?as_of=2030-01-01T00%3A00%3A00Z", w.Header().Get("Location"))
+
+		// The deleted originals are still served to clients asking for them
+		w = do(http.MethodGet, "/v0/servers/io.github.octocat%2Fweather-server/versions/1.0.0?include_deleted=true", "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = do(http.MethodGet, "/v0/servers/io.github.octocat%2Fweather/aliases", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var aliases v0.ServerAliasesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &aliases))
+		require.Len(t, aliases.Aliases, 1)
+		assert.Equal(t, "io.github.octocat/weather-server", aliases.Aliases[0].Alias)
+
+		changes, err := registry.ListServerChanges(ctx, since, 100)
+		require.NoError(t, err)
+		require.NotEmpty(t, changes)
+		renamed := changes[len(changes)-1]
+		assert.Equal(t, "renamed", renamed.Type)
+		assert.Equal(t, "io.github.octocat/weather-server", renamed.PreviousName)
+		assert.Equal(t, "io.github.octocat/weather", renamed.Server.Server.Name)
+		assert.Equal(t, "1.1.0", renamed.Server.Server.Version)
+	})
+
+	t.Run("renaming again moves aliases", func(t *testing.T) {
+		w := rename("io.github.octocat%2Fweather", "io.github.octocat/forecast")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(http.MethodGet, "/v0/servers/io.github.octocat%2Fweather-server/versions", "", nil)
+		assert.Equal(t, http.StatusPermanentRedirect, w.Code)
+		assert.Equal(t, "/v0/servers/io.github.octocat%2Fforecast/versions", w.Header().Get("Location"))
+
+		// Names keep the deleted versions they had, so they can't be renamed to again
+		w = rename("io.github.octocat%2Fforecast", "io.github.octocat/weather-server")
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		aliases, err := registry.ListServerAliases(ctx, "io.github.octocat/forecast")
+		require.NoError(t, err)
+		names := make([]string, len(aliases))
+		for i, alias := range aliases {
+			names[i] = alias.Alias
+		}
+		assert.Equal(t, []string{"io.github.octocat/weather", "io.github.octocat/weather-server"}, names)
+	})
+
+	t.Run("unknown names are still not found", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/servers/io.github.octocat%2Fmissing/versions/latest", "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,82 @@
+package v0
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/jsonlimit"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// serverJSONFields are the top-level fields of server.json; extension data belongs in _meta
+var serverJSONFields = jsonFieldNames(reflect.TypeFor[apiv0.ServerJSON]())
+
+// publishLimits bounds the server.json documents accepted by the publish endpoint. The top-level fields of
+// earlier schema versions are let through for upgradedBodyOperation to upgrade.
+func publishLimits(cfg *config.Config) jsonlimit.Limits {
+	return jsonlimit.Limits{
+		MaxBytes:       cfg.PublishMaxBodyBytes,
+		MaxDepth:       cfg.PublishMaxJSONDepth,
+		TopLevelFields: slices.Concat(serverJSONFields, validator.LegacyTopLevelFields),
+	}
+}
+
+// limitedBodyOperation makes an operation check its request body against limits before Huma decodes it
+func limitedBodyOperation(api huma.API, op huma.Operation, limits jsonlimit.Limits) huma.Operation {
+	if limits.MaxBytes > 0 {
+		// Huma rejects bodies of exactly MaxBodyBytes, and the middleware has already enforced the limit
+		op.MaxBodyBytes = limits.MaxBytes + 1
+	}
+	op.Middlewares = append(op.Middlewares, func(ctx huma.Context, next func(huma.Context)) {
+		body, err := jsonlimit.Read(ctx.BodyReader(), limits)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, jsonlimit.ErrTooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			_ = huma.WriteErr(api, ctx, status, err.Error(), err)
+			return
+		}
+		next(&bodyContext{humaContext: ctx, body: bytes.NewReader(body)})
+	})
+	return op
+}
+
+// humaContext lets bodyContext embed huma.Context without the field hiding its Context method
+type humaContext = huma.Context
+
+// bodyContext replays a request body that was already read
+type bodyContext struct {
+	humaContext
+	body io.Reader
+}
+
+func (c *bodyContext) BodyReader() io.Reader {
+	return c.body
+}
+
+// jsonFieldNames returns the JSON names of a struct's fields
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -1,11 +1,29 @@
 package v0
 
+import "github.com/modelcontextprotocol/registry/internal/cdn"
+
 // Response is a generic wrapper for Huma responses
 // Usage: Response[HealthBody] instead of HealthOutput
 type Response[T any] struct {
 	Body T
 }
 
+// CacheableResponse is a Response that carries surrogate keys so CDNs can purge it selectively
+type CacheableResponse[T any] struct {
+	SurrogateKey string `header:"Surrogate-Key" doc:"Space-separated cache keys for Fastly-style CDN purging"`
+	CacheTag     string `header:"Cache-Tag" doc:"Comma-separated cache keys for Cloudflare-style CDN purging"`
+	Body         T
+}
+
+// newCacheableResponse wraps body in a CacheableResponse tagged with the given surrogate keys
+func newCacheableResponse[T any](body T, keys []string) *CacheableResponse[T] {
+	return &CacheableResponse[T]{
+		SurrogateKey: cdn.SurrogateKeyHeader(keys),
+		CacheTag:     cdn.CacheTagHeader(keys),
+		Body:         body,
+	}
+}
+
 // Example usage:
 // Instead of:
 //   type HealthOutput struct {
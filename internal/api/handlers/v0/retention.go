@@ -0,0 +1,113 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// maxPrunePreview is the maximum number of versions returned by a dry run
+const maxPrunePreview = 1000
+
+// PruneVersionsInput represents the input for pruning old server versions
+type PruneVersionsInput struct {
+	Authorization        string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	DryRun               bool   `query:"dry_run" doc:"List the versions that would be deleted without deleting them" default:"false"`
+	MaxVersionsPerServer int    `query:"max_versions_per_server" doc:"Override the configured per-server version limit (dry runs only)" minimum:"0" required:"false"`
+	MaxAge               string `query:"max_age" doc:"Override the configured maximum age as a Go duration, e.g. 720h (dry runs only)" required:"false" example:"720h"`
+}
+
+// RetentionPolicyBody describes the retention policy applied by a prune request
+type RetentionPolicyBody struct {
+	MaxVersionsPerServer int    `json:"maxVersionsPerServer" doc:"Versions kept per server, newest first (0 means unlimited)" example:"20"`
+	MaxAge               string `json:"maxAge" doc:"Maximum age of non-latest versions (0s means unlimited)" example:"720h0m0s"`
+}
+
+// PruneVersionsResponse lists the versions deleted, or that would be deleted, by a prune request
+type PruneVersionsResponse struct {
+	DryRun   bool                       `json:"dryRun" doc:"Whether versions were only listed"`
+	Policy   RetentionPolicyBody        `json:"policy" doc:"Retention policy that was applied"`
+	Count    int                        `json:"count" doc:"Number of versions listed"`
+	Versions []database.PrunableVersion `json:"versions" doc:"Versions deleted, or selected for deletion in a dry run (at most 1000)"`
+}
+
+// RegisterRetentionEndpoints registers the admin endpoint for pruning old server versions
+func RegisterRetentionEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "prune-versions" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/retention/prune",
+		Summary:     "Prune old server versions",
+		Description: "Permanently delete server versions outside the configured retention policy, or list them with dry_run=true. The latest version of each server is always kept. Dry runs may override the policy to preview a change before configuring it. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *PruneVersionsInput) (*Response[PruneVersionsResponse], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		policy := service.RetentionPolicyFromConfig(cfg)
+		if input.MaxVersionsPerServer > 0 || input.MaxAge != "" {
+			if !input.DryRun {
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Policy overrides are only allowed with dry_run=true"))
+			}
+			if input.MaxVersionsPerServer > 0 {
+				policy.MaxVersionsPerServer = input.MaxVersionsPerServer
+			}
+			if input.MaxAge != "" {
+				maxAge, err := time.ParseDuration(input.MaxAge)
+				if err != nil || maxAge < 0 {
+					return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid max_age duration", err))
+				}
+				policy.MaxAge = maxAge
+			}
+		}
+
+		var versions []*database.PrunableVersion
+		if input.DryRun {
+			versions, err = registry.ListPrunableVersions(ctx, policy, maxPrunePreview)
+		} else {
+			versions, err = registry.PruneVersions(ctx, policy)
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to prune versions", err)
+		}
+		if !input.DryRun {
+			RecordAudit(ctx, registry, claims, database.AuditEntry{
+				Action:  database.AuditActionPrune,
+				Details: map[string]any{"deleted": len(versions)},
+			})
+		}
+
+		response := PruneVersionsResponse{
+			DryRun: input.DryRun,
+			Policy: RetentionPolicyBody{
+				MaxVersionsPerServer: policy.MaxVersionsPerServer,
+				MaxAge:               policy.MaxAge.String(),
+			},
+			Count:    len(versions),
+			Versions: make([]database.PrunableVersion, len(versions)),
+		}
+		for i, version := range versions {
+			response.Versions[i] = *version
+		}
+
+		return &Response[PruneVersionsResponse]{
+			Body: response,
+		}, nil
+	})
+}
@@ -0,0 +1,121 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// routeAuthKey is the context key of the routeAuth of a request authenticated by RouteAuthMiddleware
+type routeAuthKey struct{}
+
+// routeAuth is the route policy's decision for a request: the level the route requires and the claims
+// of the token that satisfied it
+type routeAuth struct {
+	level  auth.AuthLevel
+	claims *auth.JWTClaims
+}
+
+// anonymousClaims identify requests without a token to routes the policy leaves open to anyone
+var anonymousClaims = auth.JWTClaims{AuthMethod: auth.MethodNone, AuthMethodSubject: "anonymous"}
+
+// RouteAuthMiddleware authenticates requests to the routes policy covers at the level it requires for them,
+// before their input is parsed. Handlers take the claims from the context instead of checking the
+// Authorization header themselves, and check publish, edit and owner levels against the server they act
+// on. Routes the policy does not cover are left to their handlers.
+func RouteAuthMiddleware(api huma.API, cfg *config.Config, policy auth.RoutePolicy) func(huma.Context, func(huma.Context)) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	return func(ctx huma.Context, next func(huma.Context)) {
+		op := ctx.Operation()
+		level, found := policy.Level(op.Method, routePolicyPath(op.Path))
+		if !found {
+			next(ctx)
+			return
+		}
+
+		authHeader := ctx.Header("Authorization")
+		decision := &routeAuth{level: level}
+		if level == auth.AuthLevelAnonymous && authHeader == "" {
+			claims := anonymousClaims
+			decision.claims = &claims
+		} else {
+			claims, err := validateBearer(ctx.Context(), jwtManager, authHeader)
+			if err == nil && level == auth.AuthLevelAdmin && !jwtManager.IsAdmin(claims.Permissions) {
+				err = withErrorCode(apiv0.ErrorCodeAdminRequired, huma.Error403Forbidden("This operation requires admin permissions"))
+			}
+			if err != nil {
+				writeStatusError(api, ctx, err)
+				return
+			}
+			decision.claims = claims
+		}
+
+		next(huma.WithValue(ctx, routeAuthKey{}, decision))
+	}
+}
+
+// routePolicyPath returns the path of an operation relative to its API version, as routes are named in
+// route policies
+func routePolicyPath(path string) string {
+	for _, prefix := range []string{"/v0.1", "/v0"} {
+		if rest, found := strings.CutPrefix(path, prefix); found && strings.HasPrefix(rest, "/") {
+			return rest
+		}
+	}
+	return path
+}
+
+// routeAuthFrom returns the route policy's decision for the request, or nil when RouteAuthMiddleware is not
+// installed or the policy does not cover the route
+func routeAuthFrom(ctx context.Context) *routeAuth {
+	decision, _ := ctx.Value(routeAuthKey{}).(*routeAuth)
+	return decision
+}
+
+// authorizeServer checks that claims satisfy the level the route requires for acting on the server, or
+// defaultLevel when no route policy covers the route
+func authorizeServer(ctx context.Context, jwtManager *auth.JWTManager, claims *auth.JWTClaims, serverName string, defaultLevel auth.AuthLevel) error {
+	level := defaultLevel
+	if decision := routeAuthFrom(ctx); decision != nil {
+		level = decision.level
+	}
+
+	switch level {
+	case auth.AuthLevelPublish:
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) {
+			return withErrorCode(apiv0.ErrorCodeNamespaceForbidden, huma.Error403Forbidden(buildPermissionErrorMessage(serverName, claims.Permissions)))
+		}
+	case auth.AuthLevelEdit:
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionEdit, claims.Permissions) {
+			return withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("You do not have edit permissions for this server"))
+		}
+	case auth.AuthLevelOwner:
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) &&
+			!jwtManager.HasPermission(serverName, auth.PermissionActionEdit, claims.Permissions) {
+			return withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("You do not have publish or edit permissions for this server"))
+		}
+	case auth.AuthLevelAdmin:
+		if !jwtManager.IsAdmin(claims.Permissions) {
+			return withErrorCode(apiv0.ErrorCodeAdminRequired, huma.Error403Forbidden("This operation requires admin permissions"))
+		}
+	}
+	return nil
+}
+
+// writeStatusError writes an error from a middleware, keeping the code it carries
+func writeStatusError(api huma.API, ctx huma.Context, err error) {
+	status := http.StatusInternalServerError
+	if statusErr, ok := err.(huma.StatusError); ok {
+		status = statusErr.GetStatus()
+	}
+	ctx.SetHeader("Content-Type", "application/problem+json")
+	ctx.SetStatus(status)
+	_ = api.Marshal(ctx.BodyWriter(), "application/problem+json", err)
+}
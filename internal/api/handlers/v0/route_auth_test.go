@@ -0,0 +1,144 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestRouteAuthMiddleware(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	ctx := context.Background()
+
+	token := func(permissions ...auth.Permission) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(ctx, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "octocat",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	publisherToken := token(
+		auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.octocat/*"},
+		auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.octocat/*"},
+	)
+	adminToken := token(auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+
+	server := func(name, version string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: name, Description: "Test server", Version: version}
+	}
+	setup := func(t *testing.T, policy auth.RoutePolicy) func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+		weather := server("io.github.octocat/weather-server", "1.0.0")
+		_, err := registry.CreateServer(ctx, &weather)
+		require.NoError(t, err)
+
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		api.UseMiddleware(v0.RouteAuthMiddleware(api, cfg, policy))
+		v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
+		v0.RegisterEditEndpoints(api, "/v0", registry, cfg)
+		v0.RegisterMaintenanceEndpoints(api, "/v0", registry, cfg)
+
+		return func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+			var reader bytes.Buffer
+			if body != nil {
+				require.NoError(t, json.NewEncoder(&reader).Encode(body))
+			}
+			req := httptest.NewRequest(method, path, &reader)
+			req.Header.Set("Content-Type", "application/json")
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			return w
+		}
+	}
+
+	t.Run("default policy", func(t *testing.T) {
+		do := setup(t, auth.DefaultRoutePolicy())
+
+		w := do(http.MethodPost, "/v0/publish", "", server("io.github.octocat/notes", "1.0.0"))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), apiv0.ErrorCodeInvalidAuthHeader)
+
+		w = do(http.MethodPost, "/v0/publish", publisherToken, server("io.github.someone-else/notes", "1.0.0"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), apiv0.ErrorCodeNamespaceForbidden)
+
+		w = do(http.MethodPost, "/v0/publish", publisherToken, server("io.github.octocat/notes", "1.0.0"))
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(http.MethodPut, "/v0/servers/io.github.octocat%2Fweather-server/versions/1.0.0", publisherToken, server("io.github.octocat/weather-server", "1.0.0"))
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(http.MethodGet, "/v0/admin/maintenance", publisherToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), apiv0.ErrorCodeAdminRequired)
+
+		w = do(http.MethodGet, "/v0/admin/maintenance", "Bearer not-a-token", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), apiv0.ErrorCodeInvalidToken)
+
+		w = do(http.MethodGet, "/v0/admin/maintenance", adminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("custom policy", func(t *testing.T) {
+		policy := auth.DefaultRoutePolicy()
+		policy["POST /publish"] = auth.AuthLevelAnonymous
+		policy["PUT /servers/{serverName}/versions/{version}"] = auth.AuthLevelAdmin
+		policy["GET /admin/maintenance"] = auth.AuthLevelAuthenticated
+		do := setup(t, policy)
+
+		// Anonymous publishes are allowed to any namespace
+		w := do(http.MethodPost, "/v0/publish", "", server("io.github.someone-else/notes", "1.0.0"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var published apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&published))
+		assert.Equal(t, "io.github.someone-else/notes", published.Server.Name)
+
+		// A token sent to an anonymous route must still be valid
+		w = do(http.MethodPost, "/v0/publish", "Bearer not-a-token", server("io.github.someone-else/notes", "1.0.1"))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// Edits need an admin rather than edit permission for the server
+		edit := server("io.github.octocat/weather-server", "1.0.0")
+		w = do(http.MethodPut, "/v0/servers/io.github.octocat%2Fweather-server/versions/1.0.0", publisherToken, edit)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), apiv0.ErrorCodeAdminRequired)
+		w = do(http.MethodPut, "/v0/servers/io.github.octocat%2Fweather-server/versions/1.0.0", adminToken, edit)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		// Reading maintenance mode needs any token, while changing it still needs an admin
+		w = do(http.MethodGet, "/v0/admin/maintenance", publisherToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = do(http.MethodPut, "/v0/admin/maintenance", publisherToken, map[string]any{"enabled": false})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,62 @@
+package v0
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/jsonlimit"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// schemaUpgradeKey is the context key of the schema upgrade of a request's server.json
+type schemaUpgradeKey struct{}
+
+// upgradedBodyOperation makes an operation taking a server.json body accept documents written for earlier
+// schema versions, upgrading them to the current version before Huma decodes them. It must follow
+// limitedBodyOperation with publishLimits, which let through the top-level fields of earlier versions: they
+// are checked against the current version's once the document is upgraded.
+func upgradedBodyOperation(api huma.API, op huma.Operation) huma.Operation {
+	op.Middlewares = append(op.Middlewares, func(ctx huma.Context, next func(huma.Context)) {
+		body, err := io.ReadAll(ctx.BodyReader())
+		if err != nil {
+			_ = huma.WriteErr(api, ctx, http.StatusBadRequest, "Failed to read request body", err)
+			return
+		}
+		upgrade := validator.UpgradeServerJSON(body)
+		if err := jsonlimit.Check(upgrade.Document, jsonlimit.Limits{TopLevelFields: serverJSONFields}); err != nil {
+			_ = huma.WriteErr(api, ctx, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		next(&bodyContext{humaContext: huma.WithValue(ctx, schemaUpgradeKey{}, upgrade), body: bytes.NewReader(upgrade.Document)})
+	})
+	return op
+}
+
+// schemaUpgradeFromContext returns the schema upgrade of the request's server.json, or nil for requests that
+// did not go through upgradedBodyOperation
+func schemaUpgradeFromContext(ctx context.Context) *validator.SchemaUpgrade {
+	upgrade, _ := ctx.Value(schemaUpgradeKey{}).(*validator.SchemaUpgrade)
+	return upgrade
+}
+
+// withSubmittedDocument returns a context that makes the registry store the request's server.json as it was
+// submitted, before any upgrade, as the version's document
+func withSubmittedDocument(ctx context.Context) context.Context {
+	if upgrade := schemaUpgradeFromContext(ctx); upgrade != nil {
+		return service.WithDocument(ctx, upgrade.Original)
+	}
+	return ctx
+}
+
+// mergeSchemaUpgrade adds the violations of the request's server.json of its own schema version, and the
+// warning that it was upgraded, to result
+func mergeSchemaUpgrade(ctx context.Context, result *validator.ValidationResult) {
+	if upgrade := schemaUpgradeFromContext(ctx); upgrade != nil {
+		result.Merge(upgrade.Result)
+	}
+}
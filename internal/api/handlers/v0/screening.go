@@ -0,0 +1,161 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListScreeningExceptionsInput represents the input for listing screening exceptions
+type ListScreeningExceptionsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+}
+
+// ScreeningExceptionInput represents the input for removing a screening exception
+type ScreeningExceptionInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+}
+
+// PutScreeningExceptionBody represents the request body for exempting a server from screening
+type PutScreeningExceptionBody struct {
+	Reason string `json:"reason" required:"true" minLength:"1" maxLength:"1000" doc:"Why the server is exempt" example:"Official server of the vendor"`
+}
+
+// PutScreeningExceptionInput represents the input for exempting a server from screening
+type PutScreeningExceptionInput struct {
+	Authorization string                    `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ServerName    string                    `path:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+	Body          PutScreeningExceptionBody `body:""`
+}
+
+// ScreeningExceptionListResponse lists screening exceptions
+type ScreeningExceptionListResponse struct {
+	Exceptions []database.ScreeningException `json:"exceptions" doc:"Screening exceptions, ordered by server name"`
+}
+
+// RegisterScreeningEndpoints registers the admin endpoints managing which servers are exempt from name and description screening
+func RegisterScreeningEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	security := []map[string][]string{{"bearer": {}}}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-screening-exceptions" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/screening/exceptions",
+		Summary:     "List screening exceptions",
+		Description: "List the servers exempt from screening of reserved and prohibited terms. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *ListScreeningExceptionsInput) (*Response[ScreeningExceptionListResponse], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		exceptions, err := registry.ListScreeningExceptions(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list screening exceptions", err)
+		}
+
+		body := ScreeningExceptionListResponse{Exceptions: make([]database.ScreeningException, 0, len(exceptions))}
+		for _, exception := range exceptions {
+			body.Exceptions = append(body.Exceptions, *exception)
+		}
+		return &Response[ScreeningExceptionListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "put-screening-exception" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/admin/screening/exceptions/{serverName}",
+		Summary:     "Exempt a server from screening",
+		Description: "Allow a server to be published and edited even if its name, title or description uses a reserved or prohibited term. The server does not need to exist yet. Replaces any existing exception for the server. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *PutScreeningExceptionInput) (*Response[database.ScreeningException], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		serverName, err := parseScreeningServerName(input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		exception, err := registry.PutScreeningException(ctx, serverName, input.Body.Reason, string(claims.AuthMethod)+":"+claims.AuthMethodSubject)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidInput) {
+				return nil, huma.Error422UnprocessableEntity("Invalid screening exception", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to store screening exception", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionScreening,
+			Namespace: serverNamespace(serverName),
+			Resource:  serverName,
+			Details:   map[string]any{"exempt": true, "reason": exception.Reason},
+		})
+
+		return &Response[database.ScreeningException]{Body: *exception}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-screening-exception" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/admin/screening/exceptions/{serverName}",
+		Summary:       "Remove a screening exception",
+		Description:   "Screen the server's future publishes and edits again. Versions already published are not affected. Requires admin permissions.",
+		Tags:          []string{"admin"},
+		Security:      security,
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *ScreeningExceptionInput) (*struct{}, error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		serverName, err := parseScreeningServerName(input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteScreeningException(ctx, serverName); err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Screening exception not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to remove screening exception", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionScreening,
+			Namespace: serverNamespace(serverName),
+			Resource:  serverName,
+			Details:   map[string]any{"exempt": false},
+		})
+
+		return nil, nil
+	})
+}
+
+// parseScreeningServerName decodes a server name path parameter, which must have a namespace and a name
+func parseScreeningServerName(escaped string) (string, error) {
+	serverName, err := url.PathUnescape(escaped)
+	if err != nil {
+		return "", withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+	}
+	namespace, name, found := strings.Cut(serverName, "/")
+	if !found || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Server name must be in the format namespace/name"))
+	}
+	return serverName, nil
+}
@@ -0,0 +1,125 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestScreeningEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		ScreeningEnabled:         true,
+		ScreeningReservedTerms:   []string{"official"},
+		ScreeningProhibitedTerms: []string{"heck"},
+	}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterScreeningEndpoints(api, "/v0", registry, cfg)
+
+	token := func(permissions []auth.Permission) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "admin",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	adminToken := token([]auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}})
+	publisherToken := token([]auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}})
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	publish := func(name, description string) *httptest.ResponseRecorder {
+		return do(http.MethodPost, "/v0/publish", publisherToken, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: description,
+			Version:     "1.0.0",
+		})
+	}
+	errorCode := func(w *httptest.ResponseRecorder) apiv0.ErrorCode {
+		var body v0.ErrorModel
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body.Code
+	}
+
+	t.Run("publishes are screened", func(t *testing.T) {
+		w := publish("com.example/official-weather", "Weather forecasts for any city")
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+		assert.Equal(t, apiv0.ErrorCodeReservedTerm, errorCode(w))
+
+		w = publish("com.example/weather", "Weather forecasts, what the heck")
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+		assert.Equal(t, apiv0.ErrorCodeProhibitedTerm, errorCode(w))
+	})
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := do(http.MethodPut, "/v0/admin/screening/exceptions/com.example%2Fofficial-weather", publisherToken, map[string]any{"reason": "mine"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("exceptions allow publishing", func(t *testing.T) {
+		w := do(http.MethodPut, "/v0/admin/screening/exceptions/com.example%2Fofficial-weather", adminToken, map[string]any{"reason": "Vendor's own server"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var exception database.ScreeningException
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &exception))
+		assert.Equal(t, "com.example/official-weather", exception.ServerName)
+		assert.Equal(t, "github-at:admin", exception.CreatedBy)
+
+		w = publish("com.example/official-weather", "Weather forecasts for any city")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(http.MethodGet, "/v0/admin/screening/exceptions", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.ScreeningExceptionListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Exceptions, 1)
+		assert.Equal(t, "Vendor's own server", list.Exceptions[0].Reason)
+	})
+
+	t.Run("removing exceptions", func(t *testing.T) {
+		w := do(http.MethodDelete, "/v0/admin/screening/exceptions/com.example%2Fofficial-weather", adminToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		w = do(http.MethodDelete, "/v0/admin/screening/exceptions/com.example%2Fofficial-weather", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = do(http.MethodDelete, "/v0/admin/screening/exceptions/weather", adminToken, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,40 @@
+package v0
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// SecurityTxtPath is where the registry's security.txt is served, as RFC 9116 requires
+const SecurityTxtPath = "/.well-known/security.txt"
+
+// securityTxtLifetime is how far ahead the Expires field of security.txt lies. The file is generated on every
+// request, so it never expires while the registry serves it; RFC 9116 recommends less than a year.
+const securityTxtLifetime = 180 * 24 * time.Hour
+
+// SecurityTxtHandler serves the registry's security.txt, listing where vulnerabilities in the registry itself
+// should be reported. Reports about listed servers are pointed to the security contacts in their server.json.
+func SecurityTxtHandler(cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		var body strings.Builder
+		body.WriteString("# Vulnerabilities in this MCP registry. Report vulnerabilities in a listed server to the\n")
+		body.WriteString("# contacts in the \"security\" field of its server.json, returned by the server detail endpoints.\n")
+		for _, contact := range cfg.SecurityContacts {
+			fmt.Fprintf(&body, "Contact: %s\n", strings.TrimSpace(contact))
+		}
+		fmt.Fprintf(&body, "Expires: %s\n", time.Now().UTC().Truncate(24*time.Hour).Add(securityTxtLifetime).Format(time.RFC3339))
+		if cfg.SecurityPolicyURL != "" {
+			fmt.Fprintf(&body, "Policy: %s\n", cfg.SecurityPolicyURL)
+		}
+		if cfg.PublicURL != "" {
+			fmt.Fprintf(&body, "Canonical: %s\n", strings.TrimSuffix(cfg.PublicURL, "/")+SecurityTxtPath)
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(body.String()))
+	}
+}
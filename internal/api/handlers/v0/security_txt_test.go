@@ -0,0 +1,47 @@
+package v0_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestSecurityTxtHandler(t *testing.T) {
+	cfg := &config.Config{
+		PublicURL:         "https://registry.example.com/",
+		SecurityContacts:  []string{"mailto:security@example.com", " https://example.com/report"},
+		SecurityPolicyURL: "https://example.com/disclosure",
+	}
+
+	w := httptest.NewRecorder()
+	v0.SecurityTxtHandler(cfg)(w, httptest.NewRequest(http.MethodGet, v0.SecurityTxtPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+
+	fields := map[string][]string{}
+	for _, line := range strings.Split(strings.TrimSpace(w.Body.String()), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, found := strings.Cut(line, ": ")
+		require.True(t, found, line)
+		fields[name] = append(fields[name], value)
+	}
+	assert.Equal(t, []string{"mailto:security@example.com", "https://example.com/report"}, fields["Contact"])
+	assert.Equal(t, []string{"https://example.com/disclosure"}, fields["Policy"])
+	assert.Equal(t, []string{"https://registry.example.com/.well-known/security.txt"}, fields["Canonical"])
+
+	require.Len(t, fields["Expires"], 1)
+	expires, err := time.Parse(time.RFC3339, fields["Expires"][0])
+	require.NoError(t, err)
+	assert.True(t, expires.After(time.Now().Add(90*24*time.Hour)))
+	assert.True(t, expires.Before(time.Now().Add(365*24*time.Hour)))
+}
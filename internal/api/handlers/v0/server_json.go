@@ -0,0 +1,114 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerJSONDocumentOutput is a server.json document served as a file download
+type ServerJSONDocumentOutput struct {
+	ContentType        string `header:"Content-Type"`
+	ContentDisposition string `header:"Content-Disposition"`
+	SurrogateKey       string `header:"Surrogate-Key" doc:"Space-separated cache keys for Fastly-style CDN purging"`
+	CacheTag           string `header:"Cache-Tag" doc:"Comma-separated cache keys for Cloudflare-style CDN purging"`
+	Body               []byte
+}
+
+// ServerDocumentInput represents the input for fetching a stored server.json document by digest
+type ServerDocumentInput struct {
+	Digest string `path:"digest" pattern:"^sha256:[0-9a-f]{64}$" doc:"Document digest, as in a version's documentDigest" example:"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"`
+}
+
+// ServerDocumentOutput is a stored server.json document, which never changes
+type ServerDocumentOutput struct {
+	ContentType  string `header:"Content-Type"`
+	ETag         string `header:"ETag" doc:"The document digest"`
+	CacheControl string `header:"Cache-Control"`
+	Body         []byte
+}
+
+// RegisterServerJSONEndpoint registers the endpoints serving a server version's server.json as published, and
+// stored server.json documents by digest
+func RegisterServerJSONEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-json" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/server.json",
+		Summary:     "Download server.json for an MCP server version",
+		Description: "Download the server.json document a server version was published with, byte for byte as it was submitted and without the registry metadata added to other responses. Its SHA-256 is the version's documentDigest. Versions imported, edited by admins or published before documents were stored are served as canonical JSON: object keys sorted, no insignificant whitespace. Use the special version 'latest' to get the latest version.",
+		Tags:        []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "server.json document as published",
+				Content:     map[string]*huma.MediaType{"application/json": {}},
+			},
+		},
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*ServerJSONDocumentOutput, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid version encoding", err))
+		}
+
+		serverResponse, err := getServerVersion(ctx, registry, serverName, version, input.IncludeDeleted)
+		if err != nil {
+			return nil, err
+		}
+
+		document, err := registry.GetPublishedDocument(ctx, serverResponse)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get server.json", err)
+		}
+
+		keys := cdn.KeysForServer(serverName)
+		return &ServerJSONDocumentOutput{
+			ContentType:        "application/json",
+			ContentDisposition: `attachment; filename="server.json"`,
+			SurrogateKey:       cdn.SurrogateKeyHeader(keys),
+			CacheTag:           cdn.CacheTagHeader(keys),
+			Body:               document,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-document" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/documents/{digest}",
+		Summary:     "Get a server.json document by digest",
+		Description: "Get a server.json document from the registry's content-addressed document store by its digest, the documentDigest of the versions that published it. The document's SHA-256 always matches the digest, so it can be verified, and it never changes, so it can be cached indefinitely.",
+		Tags:        []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Stored server.json document",
+				Content:     map[string]*huma.MediaType{"application/json": {}},
+			},
+		},
+	}, func(ctx context.Context, input *ServerDocumentInput) (*ServerDocumentOutput, error) {
+		document, err := registry.GetServerDocument(ctx, input.Digest)
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Document not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to get document", err)
+		}
+
+		return &ServerDocumentOutput{
+			ContentType:  "application/json",
+			ETag:         `"` + input.Digest + `"`,
+			CacheControl: "public, max-age=31536000, immutable",
+			Body:         document,
+		}, nil
+	})
+}
@@ -0,0 +1,132 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServerJSONEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather",
+			Description: "Weather <forecasts> & alerts",
+			Version:     version,
+			Packages: []model.Package{
+				{RegistryType: model.RegistryTypeNPM, Identifier: "@example/weather", Version: version, Transport: model.Transport{Type: model.TransportTypeStdio}},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServerJSONEndpoint(api, "/v0", registryService)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/v0/servers/com.example%2Fweather/versions/1.0.0/server.json")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="server.json"`, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Header().Get("Surrogate-Key"), "com.example/weather")
+
+	// Keys are sorted at every level, without whitespace or HTML escaping, and registry metadata is left out
+	assert.Equal(t,
+		`{"$schema":"`+model.CurrentSchemaURL+`","description":"Weather <forecasts> & alerts","name":"com.example/weather",`+
+			`"packages":[{"identifier":"@example/weather","registryType":"npm","transport":{"type":"stdio"},"version":"1.0.0"}],"version":"1.0.0"}`,
+		w.Body.String())
+
+	var document apiv0.ServerJSON
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &document))
+	assert.Equal(t, "1.0.0", document.Version)
+
+	w = get("/v0/servers/com.example%2Fweather/versions/latest/server.json")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"version":"1.1.0"`)
+	assert.Equal(t, w.Body.String(), get("/v0/servers/com.example%2Fweather/versions/1.1.0/server.json").Body.String(), "output is stable")
+
+	w = get("/v0/servers/com.example%2Fweather/versions/9.9.9/server.json")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Versions published with their request body serve it byte for byte
+	submitted := []byte("{\n  \"name\": \"com.example/weather\",\n  \"version\": \"2.0.0\",\n  \"$schema\": \"" + model.CurrentSchemaURL + "\",\n" +
+		"  \"description\": \"Weather <forecasts> & alerts\"\n}\n")
+	_, err := registryService.CreateServer(service.WithDocument(ctx, submitted), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather <forecasts> & alerts",
+		Version:     "2.0.0",
+	})
+	require.NoError(t, err)
+	w = get("/v0/servers/com.example%2Fweather/versions/2.0.0/server.json")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, string(submitted), w.Body.String())
+}
+
+func TestServerDocumentEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather forecasts",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	server, err := registryService.GetServerByNameAndVersion(ctx, "com.example/weather", "1.0.0", false)
+	require.NoError(t, err)
+	digest := server.Meta.Official.DocumentDigest
+	require.NotEmpty(t, digest)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServerJSONEndpoint(api, "/v0", registryService)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	// The stored document is the one the server.json endpoint serves, and hashes to its digest
+	w := get("/v0/documents/" + digest)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, get("/v0/servers/com.example%2Fweather/versions/1.0.0/server.json").Body.String(), w.Body.String())
+	assert.Equal(t, digest, apiv0.DocumentDigest(w.Body.Bytes()))
+	assert.Equal(t, `"`+digest+`"`, w.Header().Get("ETag"))
+	assert.Contains(t, w.Header().Get("Cache-Control"), "immutable")
+
+	w = get("/v0/documents/" + apiv0.DocumentDigest([]byte("{}")))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = get("/v0/documents/md5:abc")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
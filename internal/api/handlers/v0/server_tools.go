@@ -0,0 +1,53 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerToolsInput represents the input for retrieving a server's indexed tools
+type ServerToolsInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// RegisterServerToolsEndpoint registers the endpoint returning the tools the registry indexed for a server
+func RegisterServerToolsEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-tools" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/tools",
+		Summary:     "Get the indexed tools of an MCP server",
+		Description: "Return the tools the registry found for the latest version of a server, which the tool filter of the list endpoint matches against. Tools are read from the MCPB manifest.json in the server's GitHub repository, or listed by one of its remotes that answers without credentials. Servers whose tools were not indexed return 404.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerToolsInput) (*CacheableResponse[database.ServerTools], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+		}
+
+		if _, err := registry.GetServerByName(ctx, serverName, false); err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("Server not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server details", err)
+		}
+
+		tools, err := registry.GetServerTools(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("No tools were indexed for this server"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server tools", err)
+		}
+		return newCacheableResponse(*tools, cdn.KeysForServer(serverName)), nil
+	})
+}
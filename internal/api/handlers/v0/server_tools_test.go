@@ -0,0 +1,79 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServerToolsEndpoints(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	cfg.EnableRegistryValidation = false
+	db := database.NewMemory()
+	registryService := service.NewTestRegistryService(t, db, cfg)
+
+	for _, name := range []string{"com.example/postgres", "com.example/weather"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "A server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.PutServerTools(ctx, nil, database.ServerTools{
+		ServerName: "com.example/postgres",
+		Version:    "1.0.0",
+		Source:     database.ToolSourceManifest,
+		Tools:      []database.ServerTool{{Name: "query", Description: "Run a read-only SQL query against PostgreSQL"}},
+	}))
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
+	v0.RegisterServerToolsEndpoint(api, "/v0", registryService)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/v0/servers?tool=Query+postgres")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var list apiv0.ServerListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Servers, 1)
+	assert.Equal(t, "com.example/postgres", list.Servers[0].Server.Name)
+	assert.Equal(t, 1, list.Metadata.Total)
+
+	w = get("/v0/servers?tool=forecast")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Empty(t, list.Servers)
+
+	w = get("/v0/servers/com.example%2Fpostgres/tools")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var tools database.ServerTools
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tools))
+	assert.Equal(t, database.ToolSourceManifest, tools.Source)
+	assert.Equal(t, "query", tools.Tools[0].Name)
+
+	assert.Equal(t, http.StatusNotFound, get("/v0/servers/com.example%2Fweather/tools").Code, "servers without indexed tools")
+	assert.Equal(t, http.StatusNotFound, get("/v0/servers/com.example%2Fmissing/tools").Code)
+}
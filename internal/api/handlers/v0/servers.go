@@ -1,22 +1,41 @@
 package v0
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"net/http"
 	"net/url"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/cdn"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/ranking"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 const errRecordNotFound = "record not found"
 
+// Sort orders for server lists
+const (
+	sortRelevance = "relevance"
+	sortName      = "name"
+	sortCurated   = "curated"
+)
+
+// maxCuratedCandidates bounds how many server versions are ordered when sorting by curation position
+const maxCuratedCandidates = 1000
+
+// maxAsOfVersionsPage is how many versions are read at a time when listing a server's past versions
+const maxAsOfVersionsPage = 100
+
 // OptionalBool tracks whether a bool query parameter was explicitly set
 type OptionalBool struct {
 	Value bool
@@ -44,7 +63,7 @@ func resolveIncludeDeleted(includeDeleted OptionalBool, hasUpdatedSince bool) (b
 	if hasUpdatedSince {
 		// When updated_since is provided, include_deleted must be true for incremental sync
 		if includeDeleted.IsSet && !includeDeleted.Value {
-			return false, huma.Error400BadRequest("Cannot set include_deleted=false when using updated_since (incremental sync requires deleted servers)")
+			return false, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Cannot set include_deleted=false when using updated_since (incremental sync requires deleted servers)"))
 		}
 		return true, nil
 	}
@@ -54,12 +73,32 @@ func resolveIncludeDeleted(includeDeleted OptionalBool, hasUpdatedSince bool) (b
 
 // ListServersInput represents the input for listing servers
 type ListServersInput struct {
-	Cursor         string       `query:"cursor" doc:"Pagination cursor" required:"false" example:"server-cursor-123"`
-	Limit          int          `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
-	UpdatedSince   string       `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
-	Search         string       `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
-	Version        string       `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
-	IncludeDeleted OptionalBool `query:"include_deleted" doc:"Include deleted servers in results (default: false, but always true when updated_since is provided)" required:"false"`
+	Cursor               string       `query:"cursor" doc:"Pagination cursor" required:"false" example:"server-cursor-123"`
+	Limit                int          `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+	UpdatedSince         string       `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	Search               string       `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
+	Repo                 string       `query:"repo" doc:"Filter by repository URL (case-insensitive, ignoring trailing slashes and .git suffix)" required:"false" example:"https://github.com/modelcontextprotocol/servers"`
+	Runtime              string       `query:"runtime" enum:"node,python,docker,dotnet,binary" doc:"Filter by the runtime needed to run the server locally, derived from its package types unless overridden in server.json _meta" required:"false" example:"python"`
+	Version              string       `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	IncludeDeleted       OptionalBool `query:"include_deleted" doc:"Include deleted and quarantined servers in results (default: false, but always true when updated_since is provided)" required:"false"`
+	Label                string       `query:"label" enum:"featured,official,community" doc:"Filter by a curation label registry operators gave the server" required:"false" example:"featured"`
+	Unmaintained         OptionalBool `query:"unmaintained" doc:"Only list servers the registry marked unmaintained (true) or only those it did not (false)" required:"false"`
+	Tool                 string       `query:"tool" maxLength:"200" doc:"Filter by keywords matched case-insensitively against the names and descriptions of the tools a server exposes, as indexed by the registry; every keyword must match" required:"false" example:"query postgres"`
+	Sort                 string       `query:"sort" enum:"relevance,name,curated" doc:"Result order: 'relevance' ranks matches by text match, recency, downloads and verified namespace; 'name' orders by server name; 'curated' orders by the position operators gave each server and requires label (default: relevance when search is provided, otherwise name)" required:"false"`
+	AsOf                 string       `query:"as_of" doc:"List servers as they were at this time (RFC3339 datetime), reconstructed from the changes feed" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	IncludeAnnouncements bool         `query:"include_announcements" doc:"Include the announcements registry operators are showing now in the response" required:"false" default:"false"`
+}
+
+// SuggestServersInput represents the input for suggesting servers
+type SuggestServersInput struct {
+	Query string `query:"q" required:"true" minLength:"2" maxLength:"100" doc:"Partially typed server name or namespace, matched case-insensitively against the start of any word of the name" example:"fi"`
+	Limit int    `query:"limit" doc:"Maximum number of servers and of namespaces to suggest" default:"10" minimum:"1" maximum:"25" example:"5"`
+}
+
+// ServerSuggestResponse lists servers and namespaces for a partially typed name
+type ServerSuggestResponse struct {
+	Servers    []database.ServerSuggestion `json:"servers" doc:"Latest versions of matching servers: names starting with the query first, then names whose part after the slash does, then shorter names"`
+	Namespaces []string                    `json:"namespaces" doc:"Namespaces with servers and a word starting with the query" example:"[\"io.github.firecrawl\"]"`
 }
 
 // ServerDetailInput represents the input for getting server details
@@ -71,17 +110,29 @@ type ServerDetailInput struct {
 type ServerVersionDetailInput struct {
 	ServerName     string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 	Version        string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
-	IncludeDeleted bool   `query:"include_deleted" doc:"Include deleted servers in results (default: false)" required:"false" default:"false"`
+	IncludeDeleted bool   `query:"include_deleted" doc:"Include deleted and quarantined servers in results (default: false)" required:"false" default:"false"`
+	AsOf           string `query:"as_of" doc:"Get the version as it was at this time (RFC3339 datetime), reconstructed from the changes feed" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	ConditionalParams
 }
 
 // ServerVersionsInput represents the input for listing all versions of a server
 type ServerVersionsInput struct {
 	ServerName     string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
-	IncludeDeleted bool   `query:"include_deleted" doc:"Include deleted servers in results (default: false)" required:"false" default:"false"`
+	IncludeDeleted bool   `query:"include_deleted" doc:"Include deleted and quarantined servers in results (default: false)" required:"false" default:"false"`
+	AsOf           string `query:"as_of" doc:"List versions as they were at this time (RFC3339 datetime), reconstructed from the changes feed" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	ConditionalParams
+}
+
+// ServerExistsInput represents the input for checking whether a server exists
+type ServerExistsInput struct {
+	ServerName     string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version        string `query:"version" doc:"Check this version of the server instead of any version" required:"false" example:"1.0.0"`
+	IncludeDeleted bool   `query:"include_deleted" doc:"Count deleted servers and versions as existing (default: false)" required:"false" default:"false"`
 }
 
-// RegisterServersEndpoints registers all server-related endpoints with a custom path prefix
-func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+// RegisterServersEndpoints registers all server-related endpoints with a custom path prefix.
+// ranker orders search results by relevance; with a nil ranker results are always ordered by name.
+func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, ranker *ranking.Ranker) {
 	// List servers endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "list-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -90,7 +141,7 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Summary:     "List MCP servers",
 		Description: "Get a paginated list of MCP servers from the registry",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ListServersInput) (*Response[apiv0.ServerListResponse], error) {
+	}, func(ctx context.Context, input *ListServersInput) (*CacheableResponse[apiv0.ServerListResponse], error) {
 		// Build filter from input parameters
 		filter := &database.ServerFilter{}
 
@@ -100,15 +151,46 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			if updatedTime, err := time.Parse(time.RFC3339, input.UpdatedSince); err == nil {
 				filter.UpdatedSince = &updatedTime
 			} else {
-				return nil, huma.Error400BadRequest("Invalid updated_since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid updated_since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)"))
 			}
 		}
 
+		asOf, err := parseAsOf(input.AsOf)
+		if err != nil {
+			return nil, err
+		}
+		filter.AsOf = asOf
+
 		// Handle search parameter
 		if input.Search != "" {
 			filter.SubstringName = &input.Search
 		}
 
+		// Handle repo parameter
+		if input.Repo != "" {
+			filter.RepositoryURL = &input.Repo
+		}
+
+		// Handle runtime parameter
+		if input.Runtime != "" {
+			filter.Runtime = &input.Runtime
+		}
+
+		// Handle label parameter
+		if input.Label != "" {
+			filter.CurationLabel = &input.Label
+		}
+
+		// Handle unmaintained parameter
+		if input.Unmaintained.IsSet {
+			filter.Unmaintained = &input.Unmaintained.Value
+		}
+
+		// Handle tool parameter
+		if keywords := strings.Fields(input.Tool); len(keywords) > 0 {
+			filter.ToolKeywords = keywords
+		}
+
 		// Handle version parameter
 		if input.Version != "" {
 			if input.Version == "latest" {
@@ -128,10 +210,39 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		}
 		filter.IncludeDeleted = &includeDeleted
 
+		sortOrder := input.Sort
+		if sortOrder == "" {
+			sortOrder = sortName
+			if input.Search != "" && ranker != nil {
+				sortOrder = sortRelevance
+			}
+		}
+
 		// Get paginated results with filtering
-		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
+		var servers []*apiv0.ServerResponse
+		var nextCursor string
+		switch sortOrder {
+		case sortRelevance:
+			servers, nextCursor, err = listServersByRelevance(ctx, registry, ranker, filter, input.Search, input.Cursor, input.Limit)
+			if err != nil {
+				return nil, err
+			}
+		case sortCurated:
+			servers, nextCursor, err = listServersByCuration(ctx, registry, filter, input.Cursor, input.Limit)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			servers, nextCursor, err = registry.ListServers(ctx, filter, input.Cursor, input.Limit)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to get registry list", err)
+			}
+		}
+
+		// Count all matching servers regardless of the cursor, so every page reports the same total
+		total, totalIsEstimate, err := registry.CountServers(ctx, filter)
 		if err != nil {
-			return nil, huma.Error500InternalServerError("Failed to get registry list", err)
+			return nil, huma.Error500InternalServerError("Failed to count servers", err)
 		}
 
 		// Convert []*ServerResponse to []ServerResponse
@@ -140,15 +251,56 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			serverValues[i] = *server
 		}
 
-		return &Response[apiv0.ServerListResponse]{
-			Body: apiv0.ServerListResponse{
-				Servers: serverValues,
-				Metadata: apiv0.Metadata{
-					NextCursor: nextCursor,
-					Count:      len(servers),
-				},
+		// Let read analytics, when enabled, count the servers this search showed
+		if input.Search != "" {
+			serverNames := make([]string, len(servers))
+			for i, server := range servers {
+				serverNames[i] = server.Server.Name
+			}
+			telemetry.RecordSearchResults(ctx, serverNames)
+		}
+
+		body := apiv0.ServerListResponse{
+			Servers: serverValues,
+			Metadata: apiv0.Metadata{
+				NextCursor:      nextCursor,
+				Count:           len(servers),
+				Total:           total,
+				TotalIsEstimate: totalIsEstimate,
 			},
-		}, nil
+		}
+		keys := []string{cdn.ListKey}
+		if input.IncludeAnnouncements {
+			if body.Announcements, err = registry.ListActiveAnnouncements(ctx); err != nil {
+				return nil, huma.Error500InternalServerError("Failed to list announcements", err)
+			}
+			keys = append(keys, cdn.AnnouncementsKey)
+		}
+		return newCacheableResponse(body, keys), nil
+	})
+
+	// Suggest servers endpoint, for autocomplete
+	huma.Register(api, huma.Operation{
+		OperationID: "suggest-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/suggest",
+		Summary:     "Suggest MCP servers for a partial name",
+		Description: "Suggest server names and namespaces for autocomplete as a user types. Any word of a name may match: the query 'fi' suggests io.github.user/filesystem. Only names are matched and only the latest version of each server is considered, so this is much cheaper than searching with the list endpoint.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *SuggestServersInput) (*CacheableResponse[ServerSuggestResponse], error) {
+		servers, namespaces, err := registry.SuggestServers(ctx, input.Query, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to suggest servers", err)
+		}
+
+		body := ServerSuggestResponse{Servers: servers, Namespaces: namespaces}
+		if body.Servers == nil {
+			body.Servers = []database.ServerSuggestion{}
+		}
+		if body.Namespaces == nil {
+			body.Namespaces = []string{}
+		}
+		return newCacheableResponse(body, []string{cdn.ListKey}), nil
 	})
 
 	// Get specific server version endpoint (supports "latest" as special version)
@@ -159,37 +311,27 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Summary:     "Get specific MCP server version",
 		Description: "Get detailed information about a specific version of an MCP server. Use the special version 'latest' to get the latest version.",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[apiv0.ServerResponse], error) {
-		// URL-decode the server name
-		serverName, err := url.PathUnescape(input.ServerName)
-		if err != nil {
-			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
-		}
-
-		// URL-decode the version
-		version, err := url.PathUnescape(input.Version)
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*DetailResponse[apiv0.ServerResponse], error) {
+		serverResponse, headers, err := getServerVersionDetail(ctx, registry, pathPrefix, input)
 		if err != nil {
-			return nil, huma.Error400BadRequest("Invalid version encoding", err)
-		}
-
-		var serverResponse *apiv0.ServerResponse
-		// Handle "latest" as a special version
-		if version == "latest" {
-			serverResponse, err = registry.GetServerByName(ctx, serverName, input.IncludeDeleted)
-		} else {
-			serverResponse, err = registry.GetServerByNameAndVersion(ctx, serverName, version, input.IncludeDeleted)
+			return nil, err
 		}
+		return &DetailResponse[apiv0.ServerResponse]{DetailHeaders: headers, Body: *serverResponse}, nil
+	})
 
+	huma.Register(api, huma.Operation{
+		OperationID: "head-server-version" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodHead,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}",
+		Summary:     "Check an MCP server version",
+		Description: "Get the headers of a server version without its body: its ETag and Last-Modified time, or the status of the request when the version does not exist.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*DetailHeaders, error) {
+		_, headers, err := getServerVersionDetail(ctx, registry, pathPrefix, input)
 		if err != nil {
-			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
-			}
-			return nil, huma.Error500InternalServerError("Failed to get server details", err)
+			return nil, err
 		}
-
-		return &Response[apiv0.ServerResponse]{
-			Body: *serverResponse,
-		}, nil
+		return &headers, nil
 	})
 
 	// Get server versions endpoint
@@ -200,35 +342,353 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Summary:     "Get all versions of an MCP server",
 		Description: "Get all available versions for a specific MCP server",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ServerVersionsInput) (*Response[apiv0.ServerListResponse], error) {
-		// URL-decode the server name
+	}, func(ctx context.Context, input *ServerVersionsInput) (*DetailResponse[apiv0.ServerListResponse], error) {
+		body, headers, err := getServerVersionsDetail(ctx, registry, pathPrefix, input)
+		if err != nil {
+			return nil, err
+		}
+		return &DetailResponse[apiv0.ServerListResponse]{DetailHeaders: headers, Body: *body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "head-server-versions" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodHead,
+		Path:        pathPrefix + "/servers/{serverName}/versions",
+		Summary:     "Check the versions of an MCP server",
+		Description: "Get the headers of a server's version list without its body, to check whether a copy of the list is current",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerVersionsInput) (*DetailHeaders, error) {
+		_, headers, err := getServerVersionsDetail(ctx, registry, pathPrefix, input)
+		if err != nil {
+			return nil, err
+		}
+		return &headers, nil
+	})
+
+	// Server existence check, for clients validating many installed servers at once
+	huma.Register(api, huma.Operation{
+		OperationID: "server-exists" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/exists",
+		Summary:     "Check whether an MCP server exists",
+		Description: "Check whether a server, or one version of it, is in the registry, returning only its latest version and status instead of the full server.json",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerExistsInput) (*CacheableResponse[apiv0.ServerExistsResponse], error) {
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
-			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
 		}
 
-		// Get all versions for this server
-		servers, err := registry.GetAllVersionsByServerName(ctx, serverName, input.IncludeDeleted)
+		latest, err := registry.GetServerByName(ctx, serverName, input.IncludeDeleted)
 		if err != nil {
-			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
+			if err.Error() == errRecordNotFound || errors.Is(err, service.ErrNotFound) {
+				query := detailQuery(input.IncludeDeleted, "")
+				if input.Version != "" {
+					query.Set("version", input.Version)
+				}
+				if redirect := aliasRedirect(ctx, registry, pathPrefix, serverName, "/exists", query); redirect != nil {
+					return nil, redirect
+				}
+				return newCacheableResponse(apiv0.ServerExistsResponse{}, cdn.KeysForServer(serverName)), nil
 			}
-			return nil, huma.Error500InternalServerError("Failed to get server versions", err)
+			return nil, huma.Error500InternalServerError("Failed to check server", err)
 		}
 
-		// Convert []*ServerResponse to []ServerResponse
-		serverValues := make([]apiv0.ServerResponse, len(servers))
-		for i, server := range servers {
-			serverValues[i] = *server
+		body := apiv0.ServerExistsResponse{Exists: true, LatestVersion: latest.Server.Version}
+		if latest.Meta.Official != nil {
+			body.Status = latest.Meta.Official.Status
+		}
+		if input.Version != "" && input.Version != latest.Server.Version {
+			version, err := registry.GetServerByNameAndVersion(ctx, serverName, input.Version, input.IncludeDeleted)
+			switch {
+			case err == nil:
+				if version.Meta.Official != nil {
+					body.Status = version.Meta.Official.Status
+				}
+			case err.Error() == errRecordNotFound || errors.Is(err, service.ErrNotFound):
+				body.Exists = false
+				body.Status = ""
+			default:
+				return nil, huma.Error500InternalServerError("Failed to check server version", err)
+			}
 		}
+		return newCacheableResponse(body, cdn.KeysForServer(serverName)), nil
+	})
+}
 
-		return &Response[apiv0.ServerListResponse]{
-			Body: apiv0.ServerListResponse{
-				Servers: serverValues,
-				Metadata: apiv0.Metadata{
-					Count: len(servers),
-				},
-			},
-		}, nil
+// getServerVersionDetail looks up the version a server version detail request asks for, and the headers of its
+// response. Conditional requests for a current copy fail with 304 Not Modified.
+func getServerVersionDetail(
+	ctx context.Context, registry service.RegistryService, pathPrefix string, input *ServerVersionDetailInput,
+) (*apiv0.ServerResponse, DetailHeaders, error) {
+	// URL-decode the server name
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, DetailHeaders{}, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+	}
+
+	// URL-decode the version
+	version, err := url.PathUnescape(input.Version)
+	if err != nil {
+		return nil, DetailHeaders{}, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid version encoding", err))
+	}
+
+	asOf, err := parseAsOf(input.AsOf)
+	if err != nil {
+		return nil, DetailHeaders{}, err
+	}
+
+	var serverResponse *apiv0.ServerResponse
+	// Handle "latest" as a special version
+	if asOf != nil {
+		serverResponse, err = getServerVersionAsOf(ctx, registry, serverName, version, *asOf, input.IncludeDeleted)
+	} else if version == "latest" {
+		serverResponse, err = registry.GetServerByName(ctx, serverName, input.IncludeDeleted)
+	} else {
+		serverResponse, err = registry.GetServerByNameAndVersion(ctx, serverName, version, input.IncludeDeleted)
+	}
+
+	if err != nil {
+		if err.Error() == errRecordNotFound || errors.Is(err, service.ErrNotFound) {
+			if redirect := aliasRedirect(ctx, registry, pathPrefix, serverName, "/versions/"+url.PathEscape(version), detailQuery(input.IncludeDeleted, input.AsOf)); redirect != nil {
+				return nil, DetailHeaders{}, redirect
+			}
+			return nil, DetailHeaders{}, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("Server not found"))
+		}
+		return nil, DetailHeaders{}, huma.Error500InternalServerError("Failed to get server details", err)
+	}
+
+	headers, err := newDetailHeaders(serverResponse, []apiv0.ServerResponse{*serverResponse}, cdn.KeysForServer(serverName))
+	if err != nil {
+		return nil, DetailHeaders{}, huma.Error500InternalServerError("Failed to get server details", err)
+	}
+	if input.notModified(headers) {
+		return nil, DetailHeaders{}, notModifiedError(headers)
+	}
+	return serverResponse, headers, nil
+}
+
+// getServerVersionsDetail lists the versions a server versions request asks for, and the headers of its response.
+// Conditional requests for a current copy fail with 304 Not Modified.
+func getServerVersionsDetail(
+	ctx context.Context, registry service.RegistryService, pathPrefix string, input *ServerVersionsInput,
+) (*apiv0.ServerListResponse, DetailHeaders, error) {
+	// URL-decode the server name
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, DetailHeaders{}, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
+	}
+
+	asOf, err := parseAsOf(input.AsOf)
+	if err != nil {
+		return nil, DetailHeaders{}, err
+	}
+
+	// Get all versions for this server
+	var servers []*apiv0.ServerResponse
+	if asOf != nil {
+		servers, err = getServerVersionsAsOf(ctx, registry, serverName, *asOf, input.IncludeDeleted)
+	} else {
+		servers, err = registry.GetAllVersionsByServerName(ctx, serverName, input.IncludeDeleted)
+	}
+	if err != nil {
+		if err.Error() == errRecordNotFound || errors.Is(err, service.ErrNotFound) {
+			if redirect := aliasRedirect(ctx, registry, pathPrefix, serverName, "/versions", detailQuery(input.IncludeDeleted, input.AsOf)); redirect != nil {
+				return nil, DetailHeaders{}, redirect
+			}
+			return nil, DetailHeaders{}, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("Server not found"))
+		}
+		return nil, DetailHeaders{}, huma.Error500InternalServerError("Failed to get server versions", err)
+	}
+
+	// Convert []*ServerResponse to []ServerResponse
+	serverValues := make([]apiv0.ServerResponse, len(servers))
+	for i, server := range servers {
+		serverValues[i] = *server
+	}
+	body := &apiv0.ServerListResponse{
+		Servers: serverValues,
+		Metadata: apiv0.Metadata{
+			Count: len(servers),
+			Total: len(servers),
+		},
+	}
+
+	headers, err := newDetailHeaders(body, serverValues, cdn.KeysForServer(serverName))
+	if err != nil {
+		return nil, DetailHeaders{}, huma.Error500InternalServerError("Failed to get server versions", err)
+	}
+	if input.notModified(headers) {
+		return nil, DetailHeaders{}, notModifiedError(headers)
+	}
+	return body, headers, nil
+}
+
+// detailQuery returns the query parameters of a server detail request that were set, for redirects
+func detailQuery(includeDeleted bool, asOf string) url.Values {
+	query := url.Values{}
+	if includeDeleted {
+		query.Set("include_deleted", "true")
+	}
+	if asOf != "" {
+		query.Set("as_of", asOf)
+	}
+	return query
+}
+
+// parseAsOf parses the as_of parameter, returning nil when it is not set
+func parseAsOf(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	asOf, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid as_of format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)"))
+	}
+	return &asOf, nil
+}
+
+// getServerVersionAsOf returns a server version, or the version that was latest for "latest", as it was at asOf
+func getServerVersionAsOf(
+	ctx context.Context, registry service.RegistryService, serverName, version string, asOf time.Time, includeDeleted bool,
+) (*apiv0.ServerResponse, error) {
+	filter := &database.ServerFilter{Name: &serverName, IncludeDeleted: &includeDeleted, AsOf: &asOf}
+	if version == "latest" {
+		isLatest := true
+		filter.IsLatest = &isLatest
+	} else {
+		filter.Version = &version
+	}
+
+	servers, _, err := registry.ListServers(ctx, filter, "", 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(servers) == 0 {
+		return nil, service.ErrNotFound
+	}
+	return servers[0], nil
+}
+
+// getServerVersionsAsOf returns the versions of a server as they were at asOf, newest first like
+// GetAllVersionsByServerName
+func getServerVersionsAsOf(
+	ctx context.Context, registry service.RegistryService, serverName string, asOf time.Time, includeDeleted bool,
+) ([]*apiv0.ServerResponse, error) {
+	filter := &database.ServerFilter{Name: &serverName, IncludeDeleted: &includeDeleted, AsOf: &asOf}
+
+	var servers []*apiv0.ServerResponse
+	cursor := ""
+	for {
+		page, nextCursor, err := registry.ListServers(ctx, filter, cursor, maxAsOfVersionsPage)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, page...)
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+	if len(servers) == 0 {
+		return nil, service.ErrNotFound
+	}
+
+	slices.SortFunc(servers, func(a, b *apiv0.ServerResponse) int {
+		return cmp.Or(
+			b.Meta.Official.PublishedAt.Compare(a.Meta.Official.PublishedAt),
+			strings.Compare(b.Server.Version, a.Server.Version),
+		)
+	})
+	return servers, nil
+}
+
+// listServersByRelevance ranks the matching servers and returns one page of them. The cursor is the
+// offset of the page, since ranked results have no stable key to resume from.
+func listServersByRelevance(
+	ctx context.Context, registry service.RegistryService, ranker *ranking.Ranker, filter *database.ServerFilter, query, cursor string, limit int,
+) ([]*apiv0.ServerResponse, string, error) {
+	if ranker == nil {
+		return nil, "", withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Relevance sorting is not available on this registry"))
+	}
+
+	offset := 0
+	if cursor != "" {
+		var err error
+		if offset, err = strconv.Atoi(cursor); err != nil || offset < 0 {
+			return nil, "", withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid cursor for relevance-sorted results"))
+		}
+	}
+
+	candidates, _, err := registry.ListServers(ctx, filter, "", ranker.MaxCandidates())
+	if err != nil {
+		return nil, "", huma.Error500InternalServerError("Failed to get registry list", err)
+	}
+	if err := ranker.Rank(ctx, query, candidates); err != nil {
+		return nil, "", huma.Error500InternalServerError("Failed to rank servers", err)
+	}
+
+	servers, nextCursor := offsetPage(candidates, offset, limit)
+	return servers, nextCursor, nil
+}
+
+// listServersByCuration returns a page of servers with a curation label ordered by the position operators
+// gave them, then by name. Like relevance-sorted results, pages use an offset as their cursor.
+func listServersByCuration(
+	ctx context.Context, registry service.RegistryService, filter *database.ServerFilter, cursor string, limit int,
+) ([]*apiv0.ServerResponse, string, error) {
+	if filter.CurationLabel == nil {
+		return nil, "", withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("sort=curated requires the label parameter"))
+	}
+
+	offset := 0
+	if cursor != "" {
+		var err error
+		if offset, err = strconv.Atoi(cursor); err != nil || offset < 0 {
+			return nil, "", withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid cursor for curated results"))
+		}
+	}
+
+	candidates, _, err := registry.ListServers(ctx, filter, "", maxCuratedCandidates)
+	if err != nil {
+		return nil, "", huma.Error500InternalServerError("Failed to get registry list", err)
+	}
+	// Candidates are ordered by name, which a stable sort keeps among servers with the same position
+	slices.SortStableFunc(candidates, func(a, b *apiv0.ServerResponse) int {
+		aPosition, aSet := curationPosition(a)
+		bPosition, bSet := curationPosition(b)
+		switch {
+		case aSet && bSet:
+			return cmp.Compare(aPosition, bPosition)
+		case aSet:
+			return -1
+		case bSet:
+			return 1
+		}
+		return 0
 	})
+
+	servers, nextCursor := offsetPage(candidates, offset, limit)
+	return servers, nextCursor, nil
+}
+
+// curationPosition returns the curation position of a server, if operators set one
+func curationPosition(server *apiv0.ServerResponse) (int, bool) {
+	if server.Meta.Official == nil || server.Meta.Official.Curation == nil || server.Meta.Official.Curation.Position == nil {
+		return 0, false
+	}
+	return *server.Meta.Official.Curation.Position, true
+}
+
+// offsetPage returns the page of candidates starting at offset, and the cursor of the next page
+func offsetPage(candidates []*apiv0.ServerResponse, offset, limit int) ([]*apiv0.ServerResponse, string) {
+	if offset >= len(candidates) {
+		return []*apiv0.ServerResponse{}, ""
+	}
+	end := min(offset+limit, len(candidates))
+	nextCursor := ""
+	if end < len(candidates) {
+		nextCursor = strconv.Itoa(end)
+	}
+	return candidates[offset:end], nextCursor
 }
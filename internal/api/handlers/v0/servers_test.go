@@ -7,12 +7,14 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/ranking"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
@@ -22,7 +24,7 @@ import (
 
 func TestListServersEndpoint(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), config.NewConfig())
 
 	// Setup test data
 	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
@@ -44,7 +46,7 @@ func TestListServersEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
 
 	tests := []struct {
 		name           string
@@ -116,7 +118,7 @@ func TestListServersEndpoint(t *testing.T) {
 
 func TestGetLatestServerVersionEndpoint(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), config.NewConfig())
 
 	// Setup test data
 	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
@@ -130,7 +132,7 @@ func TestGetLatestServerVersionEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
 
 	tests := []struct {
 		name           string
@@ -177,7 +179,7 @@ func TestGetLatestServerVersionEndpoint(t *testing.T) {
 
 func TestGetServerVersionEndpoint(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), config.NewConfig())
 
 	serverName := "com.example/version-server"
 
@@ -210,7 +212,7 @@ func TestGetServerVersionEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
 
 	tests := []struct {
 		name           string
@@ -302,7 +304,7 @@ func TestGetServerVersionEndpoint(t *testing.T) {
 
 func TestGetAllVersionsEndpoint(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), config.NewConfig())
 
 	serverName := "com.example/multi-version-server"
 
@@ -321,7 +323,7 @@ func TestGetAllVersionsEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
 
 	tests := []struct {
 		name           string
@@ -394,7 +396,7 @@ func TestGetAllVersionsEndpoint(t *testing.T) {
 
 func TestListServersDeletedFiltering(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), config.NewConfig())
 
 	// Setup test data: 2 active servers and 1 deleted server
 	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
@@ -430,7 +432,7 @@ func TestListServersDeletedFiltering(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
 
 	tests := []struct {
 		name           string
@@ -510,7 +512,7 @@ func TestListServersDeletedFiltering(t *testing.T) {
 
 func TestServersEndpointEdgeCases(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), config.NewConfig())
 
 	// Setup test data with edge case names that comply with constraints
 	specialServers := []struct {
@@ -536,7 +538,7 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
 
 	t.Run("URL encoding edge cases", func(t *testing.T) {
 		tests := []struct {
@@ -637,7 +639,7 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 
 func TestIncludeDeletedOnDetailEndpoints(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), config.NewConfig())
 
 	serverName := "com.example/deleted-detail-test"
 
@@ -667,7 +669,7 @@ func TestIncludeDeletedOnDetailEndpoints(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
 
 	encodedName := url.PathEscape(serverName)
 
@@ -768,3 +770,402 @@ func TestIncludeDeletedOnDetailEndpoints(t *testing.T) {
 		}
 	})
 }
+
+func TestListServersEndpoint_RelevanceSort(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), config.NewConfig())
+
+	for _, name := range []string{"com.example/my-weather-tools", "com.example/weather", "com.example/weatherly", "com.example/unrelated"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	ranker, err := ranking.New(ranking.Weights{ranking.SignalText: 1}, ranking.TextRelevance())
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, ranker)
+
+	list := func(t *testing.T, query string) (int, apiv0.ServerListResponse) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers"+query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		var resp apiv0.ServerListResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		}
+		return w.Code, resp
+	}
+	names := func(resp apiv0.ServerListResponse) []string {
+		var result []string
+		for _, s := range resp.Servers {
+			result = append(result, s.Server.Name)
+		}
+		return result
+	}
+
+	t.Run("search defaults to relevance with offset cursors", func(t *testing.T) {
+		status, page := list(t, "?search=weather&limit=2")
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []string{"com.example/weather", "com.example/weatherly"}, names(page))
+		assert.Equal(t, "2", page.Metadata.NextCursor)
+		assert.Equal(t, 3, page.Metadata.Total)
+
+		status, page = list(t, "?search=weather&limit=2&cursor="+page.Metadata.NextCursor)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []string{"com.example/my-weather-tools"}, names(page))
+		assert.Empty(t, page.Metadata.NextCursor)
+	})
+
+	t.Run("sort=name keeps name order", func(t *testing.T) {
+		status, page := list(t, "?search=weather&sort=name")
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []string{"com.example/my-weather-tools", "com.example/weather", "com.example/weatherly"}, names(page))
+	})
+
+	t.Run("invalid relevance cursor", func(t *testing.T) {
+		status, _ := list(t, "?search=weather&cursor=com.example/weather")
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestListServersEndpoint_RuntimeFilter(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	for _, server := range []apiv0.ServerJSON{
+		{
+			Name: "com.example/node-server",
+			Packages: []model.Package{
+				{RegistryType: model.RegistryTypeNPM, Identifier: "@example/node-server", Version: "1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+			},
+		},
+		{
+			Name: "com.example/python-server",
+			Packages: []model.Package{
+				{RegistryType: model.RegistryTypePyPI, Identifier: "python-server", Version: "1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+				{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/example/python-server:1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+			},
+		},
+		{
+			// The override replaces the runtime derived from the npm package
+			Name: "com.example/bundled-server",
+			Packages: []model.Package{
+				{RegistryType: model.RegistryTypeNPM, Identifier: "@example/bundled-server", Version: "1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+			},
+			Meta: &apiv0.ServerMeta{Runtimes: []string{model.RuntimeBinary}},
+		},
+		{Name: "com.example/remote-server"},
+	} {
+		server.Schema = model.CurrentSchemaURL
+		server.Description = "Runtime test server"
+		server.Version = "1.0.0"
+		_, err := registryService.CreateServer(ctx, &server)
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
+
+	list := func(query string) map[string][]string {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers"+query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		runtimes := map[string][]string{}
+		for _, server := range resp.Servers {
+			runtimes[server.Server.Name] = server.Meta.Official.Runtimes
+		}
+		return runtimes
+	}
+
+	assert.Equal(t, map[string][]string{
+		"com.example/node-server":    {"node"},
+		"com.example/python-server":  {"python", "docker"},
+		"com.example/bundled-server": {"binary"},
+		"com.example/remote-server":  nil,
+	}, list(""))
+	assert.Equal(t, map[string][]string{"com.example/python-server": {"python", "docker"}}, list("?runtime=python"))
+	assert.Empty(t, list("?runtime=node&search=bundled"))
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/servers?runtime=ruby", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestSuggestServersEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	for _, server := range []apiv0.ServerJSON{
+		{Name: "io.github.user/filesystem", Title: "Filesystem"},
+		{Name: "io.github.firecrawl/firecrawl-mcp"},
+		{Name: "com.example/weather"},
+	} {
+		server.Schema = model.CurrentSchemaURL
+		server.Description = "Suggest test server"
+		server.Version = "1.0.0"
+		_, err := registryService.CreateServer(ctx, &server)
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
+
+	suggest := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers/suggest"+query, nil))
+		return w
+	}
+
+	w := suggest("?q=fi")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "servers", w.Header().Get("Surrogate-Key"))
+	var resp v0.ServerSuggestResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, []database.ServerSuggestion{
+		{Name: "io.github.user/filesystem", Title: "Filesystem"},
+		{Name: "io.github.firecrawl/firecrawl-mcp"},
+	}, resp.Servers)
+	assert.Equal(t, []string{"io.github.firecrawl"}, resp.Namespaces)
+
+	w = suggest("?q=fi&limit=1")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Len(t, resp.Servers, 1)
+
+	// No matches are empty lists, not null
+	w = suggest("?q=zz")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"servers":[]`)
+	assert.Contains(t, w.Body.String(), `"namespaces":[]`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, suggest("?q=f").Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, suggest("").Code)
+}
+
+func TestServersEndpoints_AsOf(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), config.NewConfig())
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	// checkpoint returns an as_of value between the changes made before and after it
+	checkpoint := func() string {
+		time.Sleep(2 * time.Millisecond)
+		defer time.Sleep(2 * time.Millisecond)
+		return url.QueryEscape(time.Now().Format(time.RFC3339Nano))
+	}
+
+	beforeAll := checkpoint()
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/history",
+		Description: "Original description",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	afterFirst := checkpoint()
+
+	_, err = registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/history",
+		Description: "Second release",
+		Version:     "2.0.0",
+	})
+	require.NoError(t, err)
+	_, err = registryService.UpdateServerStatus(ctx, "com.example/history", "1.0.0", &service.StatusChangeRequest{NewStatus: model.StatusDeleted})
+	require.NoError(t, err)
+
+	t.Run("list", func(t *testing.T) {
+		var list apiv0.ServerListResponse
+		w := get("/v0/servers?as_of=" + afterFirst)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Servers, 1)
+		assert.Equal(t, "1.0.0", list.Servers[0].Server.Version)
+		assert.Equal(t, model.StatusActive, list.Servers[0].Meta.Official.Status)
+		assert.True(t, list.Servers[0].Meta.Official.IsLatest)
+		assert.Equal(t, 1, list.Metadata.Total)
+
+		w = get("/v0/servers?as_of=" + beforeAll)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Empty(t, list.Servers)
+
+		w = get("/v0/servers")
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Servers, 1)
+		assert.Equal(t, "2.0.0", list.Servers[0].Server.Version)
+	})
+
+	t.Run("version detail", func(t *testing.T) {
+		var server apiv0.ServerResponse
+		w := get("/v0/servers/com.example%2Fhistory/versions/latest?as_of=" + afterFirst)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+		assert.Equal(t, "1.0.0", server.Server.Version)
+		assert.Equal(t, "Original description", server.Server.Description)
+
+		w = get("/v0/servers/com.example%2Fhistory/versions/1.0.0?as_of=" + afterFirst)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, http.StatusNotFound, get("/v0/servers/com.example%2Fhistory/versions/1.0.0").Code)
+		assert.Equal(t, http.StatusNotFound, get("/v0/servers/com.example%2Fhistory/versions/2.0.0?as_of="+afterFirst).Code)
+		assert.Equal(t, http.StatusNotFound, get("/v0/servers/com.example%2Fhistory/versions/latest?as_of="+beforeAll).Code)
+	})
+
+	t.Run("versions", func(t *testing.T) {
+		var list apiv0.ServerListResponse
+		w := get("/v0/servers/com.example%2Fhistory/versions?as_of=" + afterFirst)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Servers, 1)
+		assert.Equal(t, "1.0.0", list.Servers[0].Server.Version)
+
+		w = get("/v0/servers/com.example%2Fhistory/versions?include_deleted=true&as_of=" + url.QueryEscape(time.Now().Format(time.RFC3339Nano)))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Servers, 2)
+		assert.Equal(t, "2.0.0", list.Servers[0].Server.Version)
+		assert.Equal(t, model.StatusDeleted, list.Servers[1].Meta.Official.Status)
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("/v0/servers?as_of=yesterday").Code)
+		assert.Equal(t, http.StatusBadRequest, get("/v0/servers/com.example%2Fhistory/versions?as_of=yesterday").Code)
+	})
+}
+
+func TestServerDetailConditionalAndHeadRequests(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), config.NewConfig())
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/conditional-server",
+		Description: "Server for conditional requests",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
+
+	request := func(method, path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, path := range []string{
+		"/v0/servers/com.example%2Fconditional-server/versions/1.0.0",
+		"/v0/servers/com.example%2Fconditional-server/versions",
+	} {
+		t.Run(path, func(t *testing.T) {
+			get := request(http.MethodGet, path, nil)
+			require.Equal(t, http.StatusOK, get.Code)
+			etag := get.Header().Get("ETag")
+			lastModified := get.Header().Get("Last-Modified")
+			require.NotEmpty(t, etag)
+			require.NotEmpty(t, lastModified)
+			assert.Contains(t, get.Header().Get("Surrogate-Key"), "server:com.example/conditional-server")
+
+			head := request(http.MethodHead, path, nil)
+			assert.Equal(t, http.StatusOK, head.Code)
+			assert.Equal(t, etag, head.Header().Get("ETag"))
+			assert.Equal(t, lastModified, head.Header().Get("Last-Modified"))
+			assert.Empty(t, head.Body.String())
+
+			notModified := request(http.MethodGet, path, http.Header{"If-None-Match": {`"stale", ` + etag}})
+			assert.Equal(t, http.StatusNotModified, notModified.Code)
+			assert.Equal(t, etag, notModified.Header().Get("ETag"))
+			assert.Empty(t, notModified.Body.String())
+
+			assert.Equal(t, http.StatusNotModified, request(http.MethodHead, path, http.Header{"If-None-Match": {etag}}).Code)
+			assert.Equal(t, http.StatusNotModified, request(http.MethodGet, path, http.Header{"If-Modified-Since": {lastModified}}).Code)
+			assert.Equal(t, http.StatusOK, request(http.MethodGet, path, http.Header{"If-None-Match": {`"stale"`}}).Code)
+			// A stale ETag wins over a current modification time
+			assert.Equal(t, http.StatusOK, request(http.MethodGet, path, http.Header{
+				"If-None-Match":     {`"stale"`},
+				"If-Modified-Since": {lastModified},
+			}).Code)
+		})
+	}
+
+	assert.Equal(t, http.StatusNotFound, request(http.MethodHead, "/v0/servers/com.example%2Fmissing/versions/latest", nil).Code)
+}
+
+func TestServerExistsEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), config.NewConfig())
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/exists-server",
+			Description: "Server for existence checks",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	_, err := registryService.UpdateServerStatus(ctx, "com.example/exists-server", "1.0.0", &service.StatusChangeRequest{
+		NewStatus: model.StatusDeleted,
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, nil)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected apiv0.ServerExistsResponse
+	}{
+		{"existing server", "/v0/servers/com.example%2Fexists-server/exists", apiv0.ServerExistsResponse{Exists: true, LatestVersion: "1.1.0", Status: model.StatusActive}},
+		{"existing version", "/v0/servers/com.example%2Fexists-server/exists?version=1.1.0", apiv0.ServerExistsResponse{Exists: true, LatestVersion: "1.1.0", Status: model.StatusActive}},
+		{"missing version", "/v0/servers/com.example%2Fexists-server/exists?version=2.0.0", apiv0.ServerExistsResponse{LatestVersion: "1.1.0"}},
+		{"deleted version", "/v0/servers/com.example%2Fexists-server/exists?version=1.0.0", apiv0.ServerExistsResponse{LatestVersion: "1.1.0"}},
+		{"deleted version included", "/v0/servers/com.example%2Fexists-server/exists?version=1.0.0&include_deleted=true", apiv0.ServerExistsResponse{Exists: true, LatestVersion: "1.1.0", Status: model.StatusDeleted}},
+		{"missing server", "/v0/servers/com.example%2Fmissing/exists", apiv0.ServerExistsResponse{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerExistsResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, tt.expected, resp)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// defaultSignedURLTTL is how long a signed URL stays valid when no lifetime is requested
+const defaultSignedURLTTL = time.Hour
+
+// CreateSignedURLBody represents the request body for creating a signed read URL
+type CreateSignedURLBody struct {
+	Path             string `json:"path" minLength:"1" doc:"Read endpoint path to sign, without query parameters" example:"/v0.1/servers"`
+	ExpiresInSeconds int    `json:"expiresInSeconds,omitempty" minimum:"1" doc:"Lifetime of the URL in seconds (default: 3600, at most MCP_REGISTRY_READ_URL_MAX_TTL)"`
+}
+
+// CreateSignedURLInput represents the input for creating a signed read URL
+type CreateSignedURLInput struct {
+	Authorization string              `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Body          CreateSignedURLBody `body:""`
+}
+
+// SignedURLBody is a signed read URL
+type SignedURLBody struct {
+	URL       string    `json:"url" doc:"Path with the expires and signature query parameters; other query parameters may be added" example:"/v0.1/servers?expires=1767225600&signature=5f1d..."`
+	ExpiresAt time.Time `json:"expiresAt" format:"date-time" doc:"Time after which the URL is rejected"`
+}
+
+// RegisterSignedURLEndpoints registers the admin endpoint for creating signed read URLs with a custom path prefix
+func RegisterSignedURLEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	signer := auth.NewURLSigner(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-signed-url" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/signed-urls",
+		Summary:     "Create signed read URL",
+		Description: "Create a time-limited URL granting read access to one server or stats endpoint of a private registry, for example for a CI job or preview environment, without issuing a registry JWT. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *CreateSignedURLInput) (*Response[SignedURLBody], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		if signer == nil {
+			return nil, huma.Error400BadRequest("Signed URLs are not enabled on this registry")
+		}
+		if strings.ContainsAny(input.Body.Path, "?#") || !auth.IsReadPath(input.Body.Path) {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Path must be a server or stats read endpoint without query parameters, e.g. /v0.1/servers"))
+		}
+
+		ttl := defaultSignedURLTTL
+		if input.Body.ExpiresInSeconds > 0 {
+			ttl = time.Duration(input.Body.ExpiresInSeconds) * time.Second
+		}
+		if cfg.ReadURLMaxTTL > 0 && ttl > cfg.ReadURLMaxTTL {
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Requested lifetime exceeds the maximum of "+cfg.ReadURLMaxTTL.String()))
+		}
+
+		expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:   database.AuditActionSignedURL,
+			Resource: input.Body.Path,
+			Details:  map[string]any{"expiresAt": expiresAt.Format(time.RFC3339)},
+		})
+		return &Response[SignedURLBody]{
+			Body: SignedURLBody{
+				URL:       signer.Sign(input.Body.Path, expiresAt),
+				ExpiresAt: expiresAt,
+			},
+		}, nil
+	})
+}
@@ -0,0 +1,54 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ListStaleServersInput represents the input for listing stale servers
+type ListStaleServersInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+}
+
+// StaleServerListResponse lists stale servers
+type StaleServerListResponse struct {
+	Servers []database.ServerStaleness `json:"servers" doc:"Stale servers, oldest first. Those with unmaintainedAt set are marked unmaintained; the others are in their grace period."`
+}
+
+// RegisterStaleServerEndpoints registers the admin endpoint listing servers the stale-entry sweeper found abandoned
+func RegisterStaleServerEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-stale-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/stale-servers",
+		Summary:     "List stale servers",
+		Description: "List servers the stale-entry sweeper found untouched with an archived or deleted repository or an unpublished package, including those already marked unmaintained. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *ListStaleServersInput) (*Response[StaleServerListResponse], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		stale, err := registry.ListStaleServers(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list stale servers", err)
+		}
+
+		body := StaleServerListResponse{Servers: make([]database.ServerStaleness, 0, len(stale))}
+		for _, staleness := range stale {
+			body.Servers = append(body.Servers, *staleness)
+		}
+		return &Response[StaleServerListResponse]{Body: body}, nil
+	})
+}
@@ -0,0 +1,73 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// StatsInput represents the input for the read analytics endpoint
+type StatsInput struct {
+	Limit int `query:"limit" doc:"Maximum number of entries per list" default:"20" minimum:"1" maximum:"100" example:"10"`
+}
+
+// NamespaceStatsInput represents the input for a namespace's read analytics
+type NamespaceStatsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permission for the namespace"`
+	Namespace     string `path:"namespace" doc:"Namespace, the part of server names before the slash" example:"io.github.octocat"`
+}
+
+// RegisterStatsEndpoint registers the anonymous read analytics endpoint
+func RegisterStatsEndpoint(api huma.API, pathPrefix string, readStats *telemetry.ReadStats) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-stats" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/stats",
+		Summary:     "Get read analytics",
+		Description: "Get the most searched terms and most fetched servers from the last completed aggregation window. Entries seen by fewer distinct clients than minUniqueClients are withheld.",
+		Tags:        []string{"stats"},
+	}, func(_ context.Context, input *StatsInput) (*Response[telemetry.ReadStatsSnapshot], error) {
+		return &Response[telemetry.ReadStatsSnapshot]{
+			Body: readStats.Snapshot(input.Limit),
+		}, nil
+	})
+}
+
+// RegisterNamespaceStatsEndpoint registers the read analytics endpoint for namespace owners
+func RegisterNamespaceStatsEndpoint(api huma.API, pathPrefix string, cfg *config.Config, readStats *telemetry.ReadStats) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-namespace-stats" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces/{namespace}/stats",
+		Summary:     "Get read analytics for a namespace",
+		Description: "Get downloads, search impressions and version adoption for every server in a namespace from the last completed aggregation window. Requires a registry token that can publish to the whole namespace. Counts seen by fewer distinct clients than minUniqueClients are withheld.",
+		Tags:        []string{"stats"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *NamespaceStatsInput) (*Response[telemetry.NamespaceReadStats], error) {
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only tokens covering every server in the namespace may see its stats, not tokens for a single server
+		if input.Namespace == "" || strings.Contains(input.Namespace, "/") ||
+			!jwtManager.HasPermission(input.Namespace+"/*", auth.PermissionActionPublish, claims.Permissions) {
+			return nil, withErrorCode(apiv0.ErrorCodePermissionDenied, huma.Error403Forbidden("You do not have permission to view stats for this namespace"))
+		}
+
+		return &Response[telemetry.NamespaceReadStats]{
+			Body: readStats.NamespaceStats(input.Namespace),
+		}, nil
+	})
+}
@@ -0,0 +1,82 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+func TestNamespaceStatsEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	now := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	readStats := telemetry.NewReadStats(1, time.Hour)
+	readStats.SetClock(func() time.Time { return now })
+	readStats.RecordServerFetch("a", "io.github.octocat/weather")
+	readStats.RecordServerFetch("a", "io.github.other/weather")
+	now = now.Add(time.Hour)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterNamespaceStatsEndpoint(api, "/v0", cfg, readStats)
+
+	token := func(pattern string) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:  auth.MethodGitHubAT,
+			Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+
+	tests := []struct {
+		name           string
+		namespace      string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "namespace owner", namespace: "io.github.octocat", authorization: token("io.github.octocat/*"), expectedStatus: http.StatusOK},
+		{name: "other namespace", namespace: "io.github.other", authorization: token("io.github.octocat/*"), expectedStatus: http.StatusForbidden},
+		{name: "single server token", namespace: "io.github.octocat", authorization: token("io.github.octocat/weather"), expectedStatus: http.StatusForbidden},
+		{name: "missing token", namespace: "io.github.octocat", expectedStatus: http.StatusUnprocessableEntity},
+		{name: "invalid token", namespace: "io.github.octocat", authorization: "Bearer nope", expectedStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/namespaces/"+tt.namespace+"/stats", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.expectedStatus == http.StatusOK {
+				var body telemetry.NamespaceReadStats
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Len(t, body.Servers, 1)
+				assert.Equal(t, "io.github.octocat/weather", body.Servers[0].Name)
+				assert.Equal(t, 1, body.TotalDownloads)
+			}
+		})
+	}
+}
@@ -26,7 +26,7 @@ type UpdateServerStatusBody struct {
 
 // UpdateServerStatusInput represents the input for updating server status
 type UpdateServerStatusInput struct {
-	Authorization string                 `header:"Authorization" doc:"Registry JWT token with publish or edit permissions" required:"true"`
+	Authorization string                 `header:"Authorization" doc:"Registry JWT token with publish or edit permissions"`
 	ServerName    string                 `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 	Version       string                 `path:"version" doc:"URL-encoded version to update" example:"1.0.0"`
 	Body          UpdateServerStatusBody `body:""`
@@ -43,12 +43,12 @@ func validateStatusTransition(currentServer *apiv0.ServerResponse, newStatus mod
 		model.StatusDeleted:    true,
 	}
 	if !validStatuses[newStatus] {
-		return huma.Error400BadRequest(fmt.Sprintf("Invalid status: %s. Must be one of: active, deprecated, deleted", newStatus))
+		return withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest(fmt.Sprintf("Invalid status: %s. Must be one of: active, deprecated, deleted", newStatus)))
 	}
 
 	// Reject status_message when setting status to active
 	if newStatus == model.StatusActive && body.StatusMessage != nil {
-		return huma.Error400BadRequest("status_message cannot be provided when setting status to active")
+		return withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("status_message cannot be provided when setting status to active"))
 	}
 
 	if currentServer.Meta.Official == nil {
@@ -68,7 +68,7 @@ func validateStatusTransition(currentServer *apiv0.ServerResponse, newStatus mod
 		return nil
 	}
 
-	return huma.Error400BadRequest("No changes to apply: status and message are already set to the provided values")
+	return withErrorCode(apiv0.ErrorCodeNoStatusChange, huma.Error400BadRequest("No changes to apply: status and message are already set to the provided values"))
 }
 
 // RegisterStatusEndpoints registers the status update endpoint with a custom path prefix
@@ -87,30 +87,21 @@ func RegisterStatusEndpoints(api huma.API, pathPrefix string, registry service.R
 			{"bearer": {}},
 		},
 	}, func(ctx context.Context, input *UpdateServerStatusInput) (*Response[apiv0.ServerResponse], error) {
-		// Extract bearer token
-		const bearerPrefix = "Bearer "
-		authHeader := input.Authorization
-		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
-			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
-		}
-		token := authHeader[len(bearerPrefix):]
-
-		// Validate Registry JWT token
-		claims, err := jwtManager.ValidateToken(ctx, token)
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
 		if err != nil {
-			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+			return nil, err
 		}
 
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
-			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
 		}
 
 		// URL-decode the version
 		version, err := url.PathUnescape(input.Version)
 		if err != nil {
-			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid version encoding", err))
 		}
 
 		newStatus := model.Status(input.Body.Status)
@@ -119,17 +110,15 @@ func RegisterStatusEndpoints(api huma.API, pathPrefix string, registry service.R
 		// Include deleted servers since we need to be able to restore them
 		currentServer, err := registry.GetServerByNameAndVersion(ctx, serverName, version, true)
 		if err != nil {
-			if errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server version not found")
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("Server version not found"))
 			}
 			return nil, huma.Error500InternalServerError("Failed to get server", err)
 		}
 
 		// Verify publish or edit permissions for this server
-		hasPublish := jwtManager.HasPermission(currentServer.Server.Name, auth.PermissionActionPublish, claims.Permissions)
-		hasEdit := jwtManager.HasPermission(currentServer.Server.Name, auth.PermissionActionEdit, claims.Permissions)
-		if !hasPublish && !hasEdit {
-			return nil, huma.Error403Forbidden("You do not have publish or edit permissions for this server")
+		if err := authorizeServer(ctx, jwtManager, claims, currentServer.Server.Name, auth.AuthLevelOwner); err != nil {
+			return nil, err
 		}
 
 		// Validate status transition is allowed
@@ -143,12 +132,16 @@ func RegisterStatusEndpoints(api huma.API, pathPrefix string, registry service.R
 		// Update the server status using the service
 		updatedServer, err := registry.UpdateServerStatus(ctx, serverName, version, statusChange)
 		if err != nil {
-			if errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
-			}
-			return nil, huma.Error400BadRequest("Failed to update server status", err)
+			return nil, serviceError(http.StatusBadRequest, "Failed to update server status", err)
 		}
 
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionStatus,
+			Namespace: serverNamespace(serverName),
+			Resource:  serverName + "@" + version,
+			Details:   map[string]any{"status": string(statusChange.NewStatus)},
+		})
+
 		return &Response[apiv0.ServerResponse]{
 			Body: *updatedServer,
 		}, nil
@@ -174,7 +167,7 @@ func buildStatusChangeRequestFromBody(body UpdateServerStatusBody) *service.Stat
 
 // UpdateAllVersionsStatusInput represents the input for updating all versions' status
 type UpdateAllVersionsStatusInput struct {
-	Authorization string                 `header:"Authorization" doc:"Registry JWT token with publish or edit permissions" required:"true"`
+	Authorization string                 `header:"Authorization" doc:"Registry JWT token with publish or edit permissions"`
 	ServerName    string                 `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 	Body          UpdateServerStatusBody `body:""`
 }
@@ -201,41 +194,30 @@ func RegisterAllVersionsStatusEndpoints(api huma.API, pathPrefix string, registr
 			{"bearer": {}},
 		},
 	}, func(ctx context.Context, input *UpdateAllVersionsStatusInput) (*Response[UpdateAllVersionsStatusResponse], error) {
-		// Extract bearer token
-		const bearerPrefix = "Bearer "
-		authHeader := input.Authorization
-		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
-			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
-		}
-		token := authHeader[len(bearerPrefix):]
-
-		// Validate Registry JWT token
-		claims, err := jwtManager.ValidateToken(ctx, token)
+		claims, err := authenticateBearer(ctx, jwtManager, input.Authorization)
 		if err != nil {
-			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+			return nil, err
 		}
 
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
-			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+			return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest("Invalid server name encoding", err))
 		}
 
 		// Get any version to verify server exists and check permissions
 		// Include deleted servers since we need to be able to restore them
 		currentServer, err := registry.GetServerByName(ctx, serverName, true)
 		if err != nil {
-			if errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeServerNotFound, huma.Error404NotFound("Server not found"))
 			}
 			return nil, huma.Error500InternalServerError("Failed to get server", err)
 		}
 
 		// Verify publish or edit permissions for this server
-		hasPublish := jwtManager.HasPermission(currentServer.Server.Name, auth.PermissionActionPublish, claims.Permissions)
-		hasEdit := jwtManager.HasPermission(currentServer.Server.Name, auth.PermissionActionEdit, claims.Permissions)
-		if !hasPublish && !hasEdit {
-			return nil, huma.Error403Forbidden("You do not have publish or edit permissions for this server")
+		if err := authorizeServer(ctx, jwtManager, claims, currentServer.Server.Name, auth.AuthLevelOwner); err != nil {
+			return nil, err
 		}
 
 		newStatus := model.Status(input.Body.Status)
@@ -257,12 +239,16 @@ func RegisterAllVersionsStatusEndpoints(api huma.API, pathPrefix string, registr
 		// Update all versions' status using the service
 		updatedServers, err := registry.UpdateAllVersionsStatus(ctx, serverName, statusChange)
 		if err != nil {
-			if errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
-			}
-			return nil, huma.Error400BadRequest("Failed to update server status", err)
+			return nil, serviceError(http.StatusBadRequest, "Failed to update server status", err)
 		}
 
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionStatus,
+			Namespace: serverNamespace(serverName),
+			Resource:  serverName,
+			Details:   map[string]any{"status": string(statusChange.NewStatus), "versions": len(updatedServers)},
+		})
+
 		// Convert to response format
 		servers := make([]apiv0.ServerResponse, len(updatedServers))
 		for i, s := range updatedServers {
@@ -295,5 +281,5 @@ func validateBulkStatusTransition(versions []*apiv0.ServerResponse, newStatus mo
 	}
 
 	// No versions would have any changes
-	return huma.Error400BadRequest("No changes to apply: all versions already have the requested status and message")
+	return withErrorCode(apiv0.ErrorCodeNoStatusChange, huma.Error400BadRequest("No changes to apply: all versions already have the requested status and message"))
 }
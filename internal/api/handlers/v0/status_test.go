@@ -37,7 +37,7 @@ func TestUpdateServerStatusEndpoint(t *testing.T) {
 	}
 
 	// Create registry service and test data
-	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), cfg)
 
 	// Create test servers for different scenarios
 	testServers := map[string]*apiv0.ServerJSON{
@@ -460,7 +460,7 @@ func TestUpdateServerStatusEndpointSameStatusTransition(t *testing.T) {
 	}
 
 	// Create registry service
-	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), cfg)
 
 	// Create an active server
 	activeServer := &apiv0.ServerJSON{
@@ -519,7 +519,7 @@ func TestUpdateServerStatusEndpointURLEncoding(t *testing.T) {
 	}
 
 	// Create registry service
-	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), cfg)
 
 	// Create a server with build metadata version
 	buildMetadataServer := &apiv0.ServerJSON{
@@ -585,7 +585,7 @@ func TestUpdateAllVersionsStatusEndpoint(t *testing.T) {
 	}
 
 	// Create registry service and test data
-	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	registryService := service.NewTestRegistryService(t, database.NewTestDB(t), cfg)
 
 	// Create a server with multiple versions
 	multiVersionServer := &apiv0.ServerJSON{
@@ -2,12 +2,16 @@ package v0
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 )
 
 // ValidateServerInput represents the input for validating a server JSON
@@ -16,21 +20,45 @@ type ValidateServerInput struct {
 }
 
 // RegisterValidateEndpoint registers the validate endpoint with a custom path prefix
-func RegisterValidateEndpoint(api huma.API, pathPrefix string) {
-	huma.Register(api, huma.Operation{
+func RegisterValidateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	// Anyone can call this, so limit each client before reading the body, and bound the body as publish does
+	op := rateLimitedOperation(api, huma.Operation{
 		OperationID: "validate-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
 		Method:      http.MethodPost,
 		Path:        pathPrefix + "/validate",
 		Summary:     "Validate MCP server JSON",
-		Description: "Validate a server.json file without publishing it to the registry",
+		Description: "Validate a server.json file without publishing it to the registry, using the same rules as publishing: schema, semantic and best-practice checks, the lint rules this registry enforces, the validation policy of the server's namespace and its name screening. Reports blocking errors and the non-blocking warnings a publish would return. No authentication is required, so checks that depend on the publisher, such as namespace permissions and repository ownership, are not made. Requests are rate limited per client address and rejected with 429 over the limit.",
 		Tags:        []string{"validate"},
-	}, func(_ context.Context, input *ValidateServerInput) (*Response[validators.ValidationResult], error) {
-		// Perform comprehensive validation (schema version, full schema validation, and semantic)
-		result := validators.ValidateServerJSON(&input.Body, validators.ValidationAll)
+	}, "validate-server", cfg.ValidateRateLimit)
+	huma.Register(api, upgradedBodyOperation(api, limitedBodyOperation(api, op, publishLimits(cfg))), func(ctx context.Context, input *ValidateServerInput) (*Response[validator.ValidationResult], error) {
+		// Perform comprehensive validation (schema version, full schema validation, semantic, and lint)
+		opts, err := namespaceValidationOptions(ctx, registry, lintedValidationOptions(validator.ValidationAll, cfg), input.Body.Name)
+		if err != nil {
+			return nil, err
+		}
+		result := validator.ValidateServerJSON(&input.Body, opts)
+		mergeSchemaUpgrade(ctx, result)
+
+		// Apply the registry's policies that publishing would
+		if err := registry.ScreenServer(ctx, &input.Body); err != nil {
+			var screening *validators.ScreeningError
+			if !errors.As(err, &screening) {
+				return nil, huma.Error500InternalServerError("Failed to screen server", err)
+			}
+			result.AddIssue(validator.NewValidationIssue(validator.ValidationIssueTypePolicy, screening.Field, screening.Error(), validator.ValidationIssueSeverityError, screeningRule(screening)))
+		}
 
 		// Return validation result (always 200 OK, validity indicated in result.Valid)
-		return &Response[validators.ValidationResult]{
+		return &Response[validator.ValidationResult]{
 			Body: *result,
 		}, nil
 	})
 }
+
+// screeningRule names the policy a screening failure broke
+func screeningRule(err *validators.ScreeningError) string {
+	if errors.Is(err, validators.ErrProhibitedTerm) {
+		return "prohibited-term"
+	}
+	return "reserved-term"
+}
@@ -12,6 +12,9 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
@@ -32,7 +35,8 @@ func TestValidateEndpoint(t *testing.T) {
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
 
 	// Register the endpoint
-	v0.RegisterValidateEndpoint(api, "/v0")
+	cfg := &config.Config{}
+	v0.RegisterValidateEndpoint(api, "/v0", service.NewTestRegistryService(t, database.NewMemory(), cfg), cfg)
 
 	testCases := []struct {
 		name           string
@@ -47,8 +51,9 @@ func TestValidateEndpoint(t *testing.T) {
 			serverJSON: apiv0.ServerJSON{
 				Schema:      model.CurrentSchemaURL,
 				Name:        "com.example/test-server",
-				Description: "A test server",
+				Description: "A test server for validation",
 				Version:     "1.0.0",
+				Icons:       []model.Icon{{Src: "https://example.com/icon.png"}},
 			},
 			expectedValid:  true,
 			expectedStatus: http.StatusOK,
@@ -58,6 +63,26 @@ func TestValidateEndpoint(t *testing.T) {
 				assert.Empty(t, issues, "Valid server JSON should have no issues")
 			},
 		},
+		{
+			name: "warnings do not invalidate server json",
+			serverJSON: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Version:     "1.0.0",
+			},
+			expectedValid:  true,
+			expectedStatus: http.StatusOK,
+			description:    "Should return valid with lint warnings for a server without icons",
+			validateIssues: func(t *testing.T, issues []issueStruct) {
+				t.Helper()
+				require.Len(t, issues, 2)
+				assert.Equal(t, "linter", issues[0].Type)
+				assert.Equal(t, "warning", issues[0].Severity)
+				assert.Equal(t, "missing-icon", issues[0].Reference)
+				assert.Equal(t, "short-description", issues[1].Reference)
+			},
+		},
 		{
 			name: "version range should be invalid",
 			serverJSON: apiv0.ServerJSON{
@@ -171,3 +196,63 @@ func TestValidateEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEndpoint_RegistryPolicies(t *testing.T) {
+	cfg := &config.Config{
+		ScreeningEnabled:       true,
+		ScreeningReservedTerms: []string{"official"},
+		PublishMaxBodyBytes:    1024,
+		ValidateRateLimit:      2,
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterValidateEndpoint(api, "/v0", service.NewTestRegistryService(t, database.NewMemory(), cfg), cfg)
+
+	validate := func(body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v0/validate", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	serverJSON, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/official-weather",
+		Description: "Weather forecasts for any city",
+		Version:     "1.0.0",
+		Icons:       []model.Icon{{Src: "https://example.com/icon.png"}},
+	})
+	require.NoError(t, err)
+
+	t.Run("reports screened terms", func(t *testing.T) {
+		rr := validate(serverJSON)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var result struct {
+			Valid  bool          `json:"valid"`
+			Issues []issueStruct `json:"issues"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.False(t, result.Valid)
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, issueStruct{
+			Type:      "policy",
+			Path:      "name",
+			Message:   `server name uses a reserved term "official"`,
+			Severity:  "error",
+			Reference: "reserved-term",
+		}, result.Issues[0])
+	})
+
+	t.Run("applies publish body limits", func(t *testing.T) {
+		rr := validate([]byte(`{"name": "com.example/weather", "name": "com.example/other"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "INVALID_JSON")
+	})
+
+	t.Run("rate limits each client", func(t *testing.T) {
+		rr := validate(serverJSON)
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code, rr.Body.String())
+		assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+		assert.Contains(t, rr.Body.String(), "RATE_LIMITED")
+	})
+}
@@ -0,0 +1,157 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListValidationPoliciesInput represents the input for listing validation policies
+type ListValidationPoliciesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+}
+
+// ValidationPolicyInput represents the input for removing a validation policy
+type ValidationPolicyInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Namespace     string `path:"namespace" doc:"Namespace the policy applies to" example:"com.bank"`
+}
+
+// PutValidationPolicyBody represents the request body for setting a namespace's validation policy
+type PutValidationPolicyBody struct {
+	AllowHTTPRemotes     bool     `json:"allowHttpRemotes,omitempty" doc:"Accept http:// remote URLs, which are otherwise rejected"`
+	RequirePackageHashes bool     `json:"requirePackageHashes,omitempty" doc:"Reject packages without a fileSha256 hash"`
+	BlockingLintRules    []string `json:"blockingLintRules,omitempty" enum:"missing-icon,short-description,unused-variable,unpinned-package,secret-header-value" doc:"Lint rules that fail publishes, in addition to those the registry enforces everywhere"`
+	Reason               string   `json:"reason" required:"true" minLength:"1" maxLength:"1000" doc:"Why the namespace has its own policy" example:"Regulated publisher; packages must be verifiable"`
+}
+
+// PutValidationPolicyInput represents the input for setting a namespace's validation policy
+type PutValidationPolicyInput struct {
+	Authorization string                  `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Namespace     string                  `path:"namespace" doc:"Namespace the policy applies to, along with the namespaces under it" example:"com.bank"`
+	Body          PutValidationPolicyBody `body:""`
+}
+
+// ValidationPolicyListResponse lists validation policies
+type ValidationPolicyListResponse struct {
+	Policies []database.ValidationPolicy `json:"policies" doc:"Validation policies, ordered by namespace"`
+}
+
+// RegisterValidationPolicyEndpoints registers the admin endpoints managing per-namespace validation policies
+func RegisterValidationPolicyEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	security := []map[string][]string{{"bearer": {}}}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-validation-policies" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/validation-policies",
+		Summary:     "List validation policies",
+		Description: "List the namespaces validated with stricter or looser rules than the rest of the registry. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *ListValidationPoliciesInput) (*Response[ValidationPolicyListResponse], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		policies, err := registry.ListValidationPolicies(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list validation policies", err)
+		}
+
+		body := ValidationPolicyListResponse{Policies: make([]database.ValidationPolicy, 0, len(policies))}
+		for _, policy := range policies {
+			body.Policies = append(body.Policies, *policy)
+		}
+		return &Response[ValidationPolicyListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "put-validation-policy" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/admin/validation-policies/{namespace}",
+		Summary:     "Set a namespace's validation policy",
+		Description: "Validate publishes and edits of servers in a namespace, and the namespaces under it, with stricter or looser rules: com.bank covers com.bank and com.bank.payments. Where several policies cover a namespace, the most specific applies. Replaces any existing policy for the namespace, and applies from the next publish; versions already published are not affected. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *PutValidationPolicyInput) (*Response[database.ValidationPolicy], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		policy, err := registry.PutValidationPolicy(ctx, database.ValidationPolicy{
+			Namespace:            input.Namespace,
+			AllowHTTPRemotes:     input.Body.AllowHTTPRemotes,
+			RequirePackageHashes: input.Body.RequirePackageHashes,
+			BlockingLintRules:    input.Body.BlockingLintRules,
+			Reason:               input.Body.Reason,
+			UpdatedBy:            string(claims.AuthMethod) + ":" + claims.AuthMethodSubject,
+		})
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidValidationPolicy) {
+				return nil, withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest(err.Error()))
+			}
+			if errors.Is(err, service.ErrInvalidInput) {
+				return nil, huma.Error422UnprocessableEntity("Invalid validation policy", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to store validation policy", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionValidation,
+			Namespace: policy.Namespace,
+			Resource:  policy.Namespace,
+			Details: map[string]any{
+				"allowHttpRemotes":     policy.AllowHTTPRemotes,
+				"requirePackageHashes": policy.RequirePackageHashes,
+				"blockingLintRules":    policy.BlockingLintRules,
+				"reason":               policy.Reason,
+			},
+		})
+
+		return &Response[database.ValidationPolicy]{Body: *policy}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-validation-policy" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/admin/validation-policies/{namespace}",
+		Summary:       "Remove a namespace's validation policy",
+		Description:   "Validate the namespace's future publishes and edits like the rest of the registry again, or by the policy of a namespace above it. Versions already published are not affected. Requires admin permissions.",
+		Tags:          []string{"admin"},
+		Security:      security,
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *ValidationPolicyInput) (*struct{}, error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteValidationPolicy(ctx, input.Namespace); err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				return nil, withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Validation policy not found"))
+			}
+			return nil, huma.Error500InternalServerError("Failed to remove validation policy", err)
+		}
+
+		RecordAudit(ctx, registry, claims, database.AuditEntry{
+			Action:    database.AuditActionValidation,
+			Namespace: input.Namespace,
+			Resource:  input.Namespace,
+			Details:   map[string]any{"deleted": true},
+		})
+
+		return nil, nil
+	})
+}
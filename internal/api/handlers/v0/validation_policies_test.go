@@ -0,0 +1,171 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+func TestValidationPolicyEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), ValidateRateLimit: 100}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterValidateEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterValidationPolicyEndpoints(api, "/v0", registry, cfg)
+
+	token := func(permissions []auth.Permission) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "admin",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	adminToken := token([]auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}})
+	publisherToken := token([]auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}})
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	httpRemote := func(name string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "A server under test with its own validation policy",
+			Version:     "1.0.0",
+			Remotes:     []model.Transport{{Type: model.TransportTypeStreamableHTTP, URL: "http://mcp.example.com/mcp"}},
+		}
+	}
+	unhashedPackage := func(name string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "A server under test with its own validation policy",
+			Version:     "1.0.0",
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "policy-server",
+				Version:      "1.0.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			}},
+		}
+	}
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := do(http.MethodPut, "/v0/admin/validation-policies/io.sandbox", publisherToken, map[string]any{"allowHttpRemotes": true, "reason": "mine"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("policies loosen validation for a namespace and those under it", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/publish", publisherToken, httpRemote("io.sandbox.team/tool"))
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+
+		w = do(http.MethodPut, "/v0/admin/validation-policies/io.sandbox", adminToken, map[string]any{"allowHttpRemotes": true, "reason": "Local experiments"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var policy database.ValidationPolicy
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &policy))
+		assert.Equal(t, "io.sandbox", policy.Namespace)
+		assert.True(t, policy.AllowHTTPRemotes)
+		assert.Equal(t, "github-at:admin", policy.UpdatedBy)
+
+		w = do(http.MethodPost, "/v0/publish", publisherToken, httpRemote("io.sandbox.team/tool"))
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		// Namespaces that only share a prefix are not covered
+		w = do(http.MethodPost, "/v0/publish", publisherToken, httpRemote("io.sandboxed/tool"))
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	})
+
+	t.Run("policies tighten validation", func(t *testing.T) {
+		w := do(http.MethodPut, "/v0/admin/validation-policies/com.bank", adminToken, map[string]any{"requirePackageHashes": true, "reason": "Regulated publisher"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(http.MethodPost, "/v0/publish", publisherToken, unhashedPackage("com.bank/payments"))
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+
+		w = do(http.MethodPost, "/v0/validate", "", unhashedPackage("com.bank/payments"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var result validator.ValidationResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.False(t, result.Valid)
+		assert.Contains(t, issueRefs(result), validator.PolicyRulePackageHashRequired)
+	})
+
+	t.Run("the most specific policy applies", func(t *testing.T) {
+		w := do(http.MethodPut, "/v0/admin/validation-policies/com.bank.sandbox", adminToken, map[string]any{"reason": "Test environment"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(http.MethodPost, "/v0/publish", publisherToken, unhashedPackage("com.bank.sandbox/payments"))
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("rejects unknown lint rules and invalid namespaces", func(t *testing.T) {
+		w := do(http.MethodPut, "/v0/admin/validation-policies/com.bank", adminToken, map[string]any{"blockingLintRules": []string{"no-such-rule"}, "reason": "Strict"})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+		w = do(http.MethodPut, "/v0/admin/validation-policies/-bank", adminToken, map[string]any{"reason": "Strict"})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("listing and removing policies", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/admin/validation-policies", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.ValidationPolicyListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Policies, 3)
+		assert.Equal(t, "com.bank", list.Policies[0].Namespace)
+
+		w = do(http.MethodDelete, "/v0/admin/validation-policies/com.bank", adminToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		w = do(http.MethodDelete, "/v0/admin/validation-policies/com.bank", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		unhashed := unhashedPackage("com.bank/payments")
+		unhashed.Version = "1.0.1"
+		w = do(http.MethodPost, "/v0/publish", publisherToken, unhashed)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
+
+// issueRefs returns the references of a validation result's issues
+func issueRefs(result validator.ValidationResult) []string {
+	references := make([]string, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		references = append(references, issue.Reference)
+	}
+	return references
+}
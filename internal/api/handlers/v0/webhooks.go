@@ -0,0 +1,257 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// WebhookSubscriptionBody represents the request body for creating a webhook subscription
+type WebhookSubscriptionBody struct {
+	URL        string   `json:"url" required:"true" minLength:"1" doc:"URL receiving one POST per change" example:"https://consumer.example.com/hooks/registry"`
+	EventTypes []string `json:"eventTypes,omitempty" doc:"Change types to deliver (created, updated, renamed); omit to deliver every type" example:"[\"created\"]"`
+	Namespaces []string `json:"namespaces,omitempty" doc:"Namespaces whose changes are delivered; omit to deliver every namespace" example:"[\"io.github.octocat\"]"`
+	Format     string   `json:"format,omitempty" enum:"cloudevents,full,minimal" doc:"Payload format (default: cloudevents)"`
+}
+
+// UpdateWebhookSubscriptionBody represents the request body for changing a webhook subscription; omitted fields are kept
+type UpdateWebhookSubscriptionBody struct {
+	URL        *string   `json:"url,omitempty" minLength:"1" doc:"URL receiving one POST per change"`
+	EventTypes *[]string `json:"eventTypes,omitempty" doc:"Change types to deliver; empty delivers every type"`
+	Namespaces *[]string `json:"namespaces,omitempty" doc:"Namespaces whose changes are delivered; empty delivers every namespace"`
+	Format     *string   `json:"format,omitempty" enum:"cloudevents,full,minimal" doc:"Payload format"`
+}
+
+// CreateWebhookSubscriptionInput represents the input for creating a webhook subscription
+type CreateWebhookSubscriptionInput struct {
+	Authorization string                  `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	Body          WebhookSubscriptionBody `body:""`
+}
+
+// UpdateWebhookSubscriptionInput represents the input for changing a webhook subscription
+type UpdateWebhookSubscriptionInput struct {
+	Authorization string                        `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ID            int64                         `path:"id" doc:"Subscription ID" example:"1"`
+	Body          UpdateWebhookSubscriptionBody `body:""`
+}
+
+// WebhookSubscriptionInput represents the input for retrieving or deleting a webhook subscription
+type WebhookSubscriptionInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+	ID            int64  `path:"id" doc:"Subscription ID" example:"1"`
+}
+
+// ListWebhookSubscriptionsInput represents the input for listing webhook subscriptions
+type ListWebhookSubscriptionsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions"`
+}
+
+// WebhookSubscriptionListResponse lists webhook subscriptions
+type WebhookSubscriptionListResponse struct {
+	Subscriptions []database.WebhookSubscription `json:"subscriptions" doc:"Webhook subscriptions, oldest first"`
+}
+
+// RegisterWebhookEndpoints registers the admin endpoints managing webhook subscriptions
+func RegisterWebhookEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	security := []map[string][]string{{"bearer": {}}}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-webhook-subscriptions" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/webhooks",
+		Summary:     "List webhook subscriptions",
+		Description: "List webhook subscriptions with their filters, format and delivery state. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *ListWebhookSubscriptionsInput) (*Response[WebhookSubscriptionListResponse], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		subscriptions, err := registry.ListWebhookSubscriptions(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list webhook subscriptions", err)
+		}
+
+		body := WebhookSubscriptionListResponse{Subscriptions: make([]database.WebhookSubscription, 0, len(subscriptions))}
+		for _, subscription := range subscriptions {
+			body.Subscriptions = append(body.Subscriptions, *subscription)
+		}
+		return &Response[WebhookSubscriptionListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-webhook-subscription" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodPost,
+		Path:          pathPrefix + "/admin/webhooks",
+		Summary:       "Create a webhook subscription",
+		Description:   "Deliver server changes recorded from now on to a URL, one POST per change in sequence order. Event type and namespace filters select which changes are delivered, and the format chooses between a CloudEvents 1.0 event (the default), the full changes feed entry, and a minimal envelope naming the server version. Each delivery is signed in the X-Registry-Signature header with the secret in the response, which is not shown again. Requires admin permissions.",
+		Tags:          []string{"admin"},
+		Security:      security,
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *CreateWebhookSubscriptionInput) (*Response[database.WebhookSubscription], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		subscription, err := registry.CreateWebhookSubscription(ctx, database.WebhookSubscription{
+			URL:        input.Body.URL,
+			EventTypes: input.Body.EventTypes,
+			Namespaces: input.Body.Namespaces,
+			Format:     input.Body.Format,
+			CreatedBy:  string(claims.AuthMethod) + ":" + claims.AuthMethodSubject,
+		})
+		if err != nil {
+			return nil, webhookError("Failed to create webhook subscription", err)
+		}
+
+		recordWebhookAudit(ctx, registry, claims, database.AuditActionWebhookCreate, subscription)
+		return &Response[database.WebhookSubscription]{Body: *subscription}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-webhook-subscription" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/webhooks/{id}",
+		Summary:     "Get a webhook subscription",
+		Description: "Get a webhook subscription with its filters, format and delivery state. lastError says why the last delivery failed; failed changes are retried until delivered. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *WebhookSubscriptionInput) (*Response[database.WebhookSubscription], error) {
+		if _, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		subscription, err := registry.GetWebhookSubscription(ctx, input.ID)
+		if err != nil {
+			return nil, webhookError("Failed to get webhook subscription", err)
+		}
+		return &Response[database.WebhookSubscription]{Body: *subscription}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-webhook-subscription" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPatch,
+		Path:        pathPrefix + "/admin/webhooks/{id}",
+		Summary:     "Change a webhook subscription",
+		Description: "Change a webhook subscription's URL, filters or format. Omitted fields are kept. The new settings apply from the next change delivered; changes already delivered or skipped are not sent again. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *UpdateWebhookSubscriptionInput) (*Response[database.WebhookSubscription], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		subscription, err := registry.GetWebhookSubscription(ctx, input.ID)
+		if err != nil {
+			return nil, webhookError("Failed to get webhook subscription", err)
+		}
+		if input.Body.URL != nil {
+			subscription.URL = *input.Body.URL
+		}
+		if input.Body.EventTypes != nil {
+			subscription.EventTypes = *input.Body.EventTypes
+		}
+		if input.Body.Namespaces != nil {
+			subscription.Namespaces = *input.Body.Namespaces
+		}
+		if input.Body.Format != nil {
+			subscription.Format = *input.Body.Format
+		}
+		if err := registry.UpdateWebhookSubscription(ctx, subscription); err != nil {
+			return nil, webhookError("Failed to update webhook subscription", err)
+		}
+
+		recordWebhookAudit(ctx, registry, claims, database.AuditActionWebhookUpdate, subscription)
+		return &Response[database.WebhookSubscription]{Body: *subscription}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "rotate-webhook-secret" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/webhooks/{id}/secret",
+		Summary:     "Rotate a webhook subscription's secret",
+		Description: "Replace the secret a webhook subscription's deliveries are signed with. The new secret is in the response and is not shown again; deliveries are signed with it from the next one. Requires admin permissions.",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *WebhookSubscriptionInput) (*Response[database.WebhookSubscription], error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		subscription, err := registry.RotateWebhookSecret(ctx, input.ID)
+		if err != nil {
+			return nil, webhookError("Failed to rotate webhook secret", err)
+		}
+
+		recordWebhookAudit(ctx, registry, claims, database.AuditActionWebhookRotate, subscription)
+		return &Response[database.WebhookSubscription]{Body: *subscription}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-webhook-subscription" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/admin/webhooks/{id}",
+		Summary:       "Delete a webhook subscription",
+		Description:   "Stop delivering changes to a webhook subscription and remove it. Requires admin permissions.",
+		Tags:          []string{"admin"},
+		Security:      security,
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *WebhookSubscriptionInput) (*struct{}, error) {
+		claims, err := AuthenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		subscription, err := registry.GetWebhookSubscription(ctx, input.ID)
+		if err != nil {
+			return nil, webhookError("Failed to get webhook subscription", err)
+		}
+		if err := registry.DeleteWebhookSubscription(ctx, input.ID); err != nil {
+			return nil, webhookError("Failed to delete webhook subscription", err)
+		}
+
+		recordWebhookAudit(ctx, registry, claims, database.AuditActionWebhookDelete, subscription)
+		return nil, nil
+	})
+}
+
+// webhookError maps webhook subscription errors to API errors
+func webhookError(message string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidWebhook):
+		return withErrorCode(apiv0.ErrorCodeInvalidParameter, huma.Error400BadRequest(err.Error()))
+	case errors.Is(err, service.ErrNotFound):
+		return withErrorCode(apiv0.ErrorCodeNotFound, huma.Error404NotFound("Webhook subscription not found"))
+	default:
+		return huma.Error500InternalServerError(message, err)
+	}
+}
+
+// recordWebhookAudit records a change to a webhook subscription in the audit log
+func recordWebhookAudit(ctx context.Context, registry service.RegistryService, claims *auth.JWTClaims, action string, subscription *database.WebhookSubscription) {
+	RecordAudit(ctx, registry, claims, database.AuditEntry{
+		Action:   action,
+		Resource: "webhook:" + strconv.FormatInt(subscription.ID, 10),
+		Details: map[string]any{
+			"url":        subscription.URL,
+			"eventTypes": subscription.EventTypes,
+			"namespaces": subscription.Namespaces,
+			"format":     subscription.Format,
+		},
+	})
+}
@@ -0,0 +1,123 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+func TestWebhookEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	registry := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterWebhookEndpoints(api, "/v0", registry, cfg)
+
+	token := func(permissions []auth.Permission) string {
+		response, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "admin",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+	adminToken := token([]auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}})
+
+	do := func(method, path, authorization string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/admin/webhooks", token([]auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.admin/*"}}), nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects invalid subscriptions", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/admin/webhooks", adminToken, map[string]any{"url": "https://example.com/hooks", "eventTypes": []string{"published"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_PARAMETER")
+	})
+
+	var created database.WebhookSubscription
+	t.Run("creates a subscription", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/admin/webhooks", adminToken, map[string]any{
+			"url":        "https://example.com/hooks",
+			"namespaces": []string{"io.github.octocat"},
+			"format":     "cloudevents",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.Equal(t, []string{"io.github.octocat"}, created.Namespaces)
+		assert.Equal(t, "cloudevents", created.Format)
+		assert.Equal(t, "github-at:admin", created.CreatedBy)
+		assert.NotEmpty(t, created.Secret)
+	})
+
+	t.Run("updates only the given fields", func(t *testing.T) {
+		w := do(http.MethodPatch, "/v0/admin/webhooks/"+strconv.FormatInt(created.ID, 10), adminToken, map[string]any{"eventTypes": []string{"created"}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var updated database.WebhookSubscription
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+		assert.Equal(t, []string{"created"}, updated.EventTypes)
+		assert.Equal(t, []string{"io.github.octocat"}, updated.Namespaces)
+		assert.Equal(t, "cloudevents", updated.Format)
+		assert.Empty(t, updated.Secret)
+	})
+
+	t.Run("rotates the secret", func(t *testing.T) {
+		w := do(http.MethodPost, "/v0/admin/webhooks/"+strconv.FormatInt(created.ID, 10)+"/secret", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var rotated database.WebhookSubscription
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotated))
+		assert.NotEmpty(t, rotated.Secret)
+		assert.NotEqual(t, created.Secret, rotated.Secret)
+
+		w = do(http.MethodPost, "/v0/admin/webhooks/999/secret", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("lists and deletes subscriptions", func(t *testing.T) {
+		w := do(http.MethodGet, "/v0/admin/webhooks", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.WebhookSubscriptionListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Subscriptions, 1)
+
+		w = do(http.MethodDelete, "/v0/admin/webhooks/"+strconv.FormatInt(created.ID, 10), adminToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w = do(http.MethodGet, "/v0/admin/webhooks/"+strconv.FormatInt(created.ID, 10), adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
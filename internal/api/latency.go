@@ -0,0 +1,100 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// maxLoggedSQLLength bounds how much of each statement a slow request log line includes
+const maxLoggedSQLLength = 500
+
+// NewSlowRequestMiddleware logs requests that take longer than their latency budget, together with the SQL
+// statements they ran and how long each took, so p99 regressions can be diagnosed without debug logging.
+// Statement arguments are never logged. It does nothing unless a slow request threshold or budget is set.
+func NewSlowRequestMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	if cfg.SlowRequestThreshold <= 0 && len(cfg.SlowRequestBudgets) == 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			budget, found := cfg.SlowRequestBudgets.Match(r.URL.Path)
+			if !found {
+				budget = cfg.SlowRequestThreshold
+			}
+			if budget <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, queryLog := database.WithQueryLog(r.Context())
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			start := time.Now()
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+			elapsed := time.Since(start)
+
+			// Event streams stay open for as long as clients listen, so their duration says nothing about latency
+			if elapsed > budget && !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/event-stream") {
+				logSlowRequest(r, recorder.status, elapsed, budget, queryLog)
+			}
+		})
+	}
+}
+
+// logSlowRequest writes one log entry describing a slow request and its SQL statements
+func logSlowRequest(r *http.Request, status int, elapsed, budget time.Duration, queryLog *database.QueryLog) {
+	queries, dropped := queryLog.Queries()
+	var queryTime time.Duration
+	for _, query := range queries {
+		queryTime += query.Duration
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Slow request: %s %s -> %d in %s (budget %s), %d SQL statements in %s",
+		r.Method, r.URL.Path, status, elapsed.Round(time.Microsecond), budget, len(queries)+dropped, queryTime.Round(time.Microsecond))
+	for _, query := range queries {
+		sql := query.SQL
+		if len(sql) > maxLoggedSQLLength {
+			sql = sql[:maxLoggedSQLLength] + "..."
+		}
+		fmt.Fprintf(&b, "\n  %10s  %s", query.Duration.Round(time.Microsecond), sql)
+		if query.Err != nil {
+			fmt.Fprintf(&b, " (error: %v)", query.Err)
+		}
+	}
+	if dropped > 0 {
+		fmt.Fprintf(&b, "\n  ... %d more statements not recorded", dropped)
+	}
+	log.Print(b.String())
+}
+
+// statusRecorder remembers the status code written through it
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, for flushing streamed responses
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,65 @@
+package api_test
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestSlowRequestMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v0/slow" {
+			time.Sleep(20 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	serve := func(t *testing.T, cfg *config.Config, path string) {
+		t.Helper()
+		logs.Reset()
+		middleware := api.NewSlowRequestMiddleware(cfg)
+		w := httptest.NewRecorder()
+		middleware(handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		serve(t, &config.Config{}, "/v0/slow")
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("slow request is logged", func(t *testing.T) {
+		serve(t, &config.Config{SlowRequestThreshold: time.Millisecond}, "/v0/slow")
+		assert.Contains(t, logs.String(), "Slow request: GET /v0/slow -> 418")
+		assert.Contains(t, logs.String(), "(budget 1ms), 0 SQL statements")
+	})
+
+	t.Run("fast request is not logged", func(t *testing.T) {
+		serve(t, &config.Config{SlowRequestThreshold: time.Second}, "/v0/fast")
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("longest matching budget wins", func(t *testing.T) {
+		cfg := &config.Config{SlowRequestThreshold: time.Millisecond, SlowRequestBudgets: config.PathDurations{"/v0": time.Millisecond, "/v0/slow": time.Second}}
+		serve(t, cfg, "/v0/slow")
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("budget without threshold", func(t *testing.T) {
+		serve(t, &config.Config{SlowRequestBudgets: config.PathDurations{"/v0/slow": time.Millisecond}}, "/v0/slow")
+		assert.Contains(t, logs.String(), "(budget 1ms)")
+	})
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// ListenerConfig is one address the HTTP server listens on, with its own TLS settings
+type ListenerConfig struct {
+	// Network is "tcp" or "unix"
+	Network string
+	// Address is a host:port for TCP or a socket path for Unix domain sockets
+	Address string
+	// CertFile and KeyFile enable TLS when set
+	CertFile string
+	KeyFile  string
+	// ClientCAFile, if set, requires clients to present a certificate signed by one of its CAs
+	ClientCAFile string
+	// SocketMode sets the permissions of a Unix domain socket; zero keeps the umask default
+	SocketMode fs.FileMode
+}
+
+// String returns the listener's address as it appears in logs
+func (l ListenerConfig) String() string {
+	switch {
+	case l.Network == "unix":
+		return "unix:" + l.Address
+	case l.CertFile != "":
+		return "https://" + l.Address
+	default:
+		return l.Address
+	}
+}
+
+// ParseListener parses a listen address. Plain host:port addresses and http:// URLs listen on TCP,
+// https://host:port?cert=FILE&key=FILE listens with TLS, and unix:PATH listens on a Unix domain socket,
+// optionally with mode=0660 and with TLS. client_ca=FILE on a TLS listener requires client certificates.
+func ParseListener(spec string) (ListenerConfig, error) {
+	spec = strings.TrimSpace(spec)
+	if !strings.Contains(spec, "://") && !strings.HasPrefix(spec, "unix:") {
+		if _, _, err := net.SplitHostPort(spec); err != nil {
+			return ListenerConfig{}, fmt.Errorf("invalid listen address %q: %w", spec, err)
+		}
+		return ListenerConfig{Network: "tcp", Address: spec}, nil
+	}
+
+	u, err := url.Parse(spec)
+	if err != nil {
+		return ListenerConfig{}, fmt.Errorf("invalid listen address %q: %w", spec, err)
+	}
+	query := u.Query()
+	listener := ListenerConfig{
+		CertFile:     query.Get("cert"),
+		KeyFile:      query.Get("key"),
+		ClientCAFile: query.Get("client_ca"),
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		if _, _, err := net.SplitHostPort(u.Host); err != nil {
+			return ListenerConfig{}, fmt.Errorf("invalid listen address %q: %w", spec, err)
+		}
+		listener.Network, listener.Address = "tcp", u.Host
+		if u.Scheme == "http" && (listener.CertFile != "" || listener.KeyFile != "") {
+			return ListenerConfig{}, fmt.Errorf("invalid listen address %q: use https:// for TLS listeners", spec)
+		}
+		if u.Scheme == "https" && (listener.CertFile == "" || listener.KeyFile == "") {
+			return ListenerConfig{}, fmt.Errorf("invalid listen address %q: https listeners need cert and key", spec)
+		}
+	case "unix":
+		listener.Network, listener.Address = "unix", u.Path
+		if listener.Address == "" {
+			listener.Address = u.Opaque
+		}
+		if listener.Address == "" {
+			return ListenerConfig{}, fmt.Errorf("invalid listen address %q: missing socket path", spec)
+		}
+		if mode := query.Get("mode"); mode != "" {
+			parsed, err := strconv.ParseUint(mode, 8, 32)
+			if err != nil || parsed > 0o777 {
+				return ListenerConfig{}, fmt.Errorf("invalid listen address %q: mode must be octal permissions such as 0660", spec)
+			}
+			listener.SocketMode = fs.FileMode(parsed)
+		}
+		if (listener.CertFile == "") != (listener.KeyFile == "") {
+			return ListenerConfig{}, fmt.Errorf("invalid listen address %q: TLS needs both cert and key", spec)
+		}
+	default:
+		return ListenerConfig{}, fmt.Errorf("invalid listen address %q: scheme must be http, https or unix", spec)
+	}
+
+	if listener.ClientCAFile != "" && listener.CertFile == "" {
+		return ListenerConfig{}, fmt.Errorf("invalid listen address %q: client_ca needs a TLS listener", spec)
+	}
+	return listener, nil
+}
+
+// ListenersFromConfig returns the configured listeners: server.addresses if set, otherwise server.address
+func ListenersFromConfig(cfg *config.Config) ([]ListenerConfig, error) {
+	specs := cfg.ServerAddresses
+	if len(specs) == 0 {
+		specs = []string{cfg.ServerAddress}
+	}
+
+	listeners := make([]ListenerConfig, 0, len(specs))
+	for _, spec := range specs {
+		listener, err := ParseListener(spec)
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners, nil
+}
+
+// listen opens the listener, loading its TLS certificates. A stale Unix socket left by a previous
+// process is removed first.
+func (l ListenerConfig) listen() (net.Listener, error) {
+	if l.Network == "unix" {
+		if info, err := os.Lstat(l.Address); err == nil && info.Mode().Type() == fs.ModeSocket {
+			if err := os.Remove(l.Address); err != nil {
+				return nil, fmt.Errorf("failed to remove stale socket %s: %w", l.Address, err)
+			}
+		}
+	}
+
+	listener, err := net.Listen(l.Network, l.Address)
+	if err != nil {
+		return nil, err
+	}
+	if l.Network == "unix" && l.SocketMode != 0 {
+		if err := os.Chmod(l.Address, l.SocketMode); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+		}
+	}
+	if l.CertFile == "" {
+		return listener, nil
+	}
+
+	tlsConfig, err := l.tlsConfig()
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return tls.NewListener(listener, tlsConfig), nil
+}
+
+func (l ListenerConfig) tlsConfig() (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(l.CertFile, l.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate for %s: %w", l, err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	if l.ClientCAFile != "" {
+		pem, err := os.ReadFile(l.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA for %s: %w", l, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in client CA file " + l.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,115 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+func TestParseListener(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected api.ListenerConfig
+		errorMsg string
+	}{
+		{spec: ":8080", expected: api.ListenerConfig{Network: "tcp", Address: ":8080"}},
+		{spec: "[::]:8080", expected: api.ListenerConfig{Network: "tcp", Address: "[::]:8080"}},
+		{spec: "http://127.0.0.1:8080", expected: api.ListenerConfig{Network: "tcp", Address: "127.0.0.1:8080"}},
+		{
+			spec:     "https://[::]:8443?cert=/tls/tls.crt&key=/tls/tls.key&client_ca=/tls/ca.pem",
+			expected: api.ListenerConfig{Network: "tcp", Address: "[::]:8443", CertFile: "/tls/tls.crt", KeyFile: "/tls/tls.key", ClientCAFile: "/tls/ca.pem"},
+		},
+		{spec: "unix:/run/registry.sock?mode=0660", expected: api.ListenerConfig{Network: "unix", Address: "/run/registry.sock", SocketMode: 0o660}},
+		{spec: "unix:///run/registry.sock", expected: api.ListenerConfig{Network: "unix", Address: "/run/registry.sock"}},
+		{spec: "unix:registry.sock", expected: api.ListenerConfig{Network: "unix", Address: "registry.sock"}},
+		{spec: "8080", errorMsg: "missing port"},
+		{spec: "https://:8443", errorMsg: "need cert and key"},
+		{spec: "http://:8080?cert=a&key=b", errorMsg: "use https://"},
+		{spec: "http://:8080?client_ca=ca.pem", errorMsg: "client_ca needs a TLS listener"},
+		{spec: "unix:", errorMsg: "missing socket path"},
+		{spec: "unix:/run/registry.sock?mode=rw", errorMsg: "octal permissions"},
+		{spec: "unix:/run/registry.sock?cert=a", errorMsg: "both cert and key"},
+		{spec: "tcp://:8080", errorMsg: "scheme must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			listener, err := api.ParseListener(tt.spec)
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, listener)
+		})
+	}
+}
+
+func TestServerMultipleListeners(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	// Reserve a free TCP port, then release it for the server
+	reserved, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	tcpAddress := reserved.Addr().String()
+	require.NoError(t, reserved.Close())
+	socketPath := filepath.Join(t.TempDir(), "registry.sock")
+
+	cfg := config.NewConfig()
+	cfg.JWTPrivateKey = hex.EncodeToString(testSeed)
+	cfg.ServerAddresses = []string{tcpAddress, "unix:" + socketPath + "?mode=0600"}
+
+	shutdownTelemetry, metrics, err := telemetry.InitMetrics("test")
+	require.NoError(t, err)
+	defer func() { _ = shutdownTelemetry(context.Background()) }()
+
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), cfg)
+	server := api.NewServer(cfg, registryService, metrics, &v0.VersionBody{Version: "test"})
+	started := make(chan error, 1)
+	go func() { started <- server.Start() }()
+	defer func() {
+		require.NoError(t, server.Shutdown(context.Background()))
+		assert.ErrorIs(t, <-started, http.ErrServerClosed)
+	}()
+
+	clients := map[string]*http.Client{
+		"http://" + tcpAddress: {Timeout: time.Second},
+		"http://unix": {
+			Timeout: time.Second,
+			Transport: &http.Transport{DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+			}},
+		},
+	}
+	for baseURL, client := range clients {
+		require.Eventually(t, func() bool {
+			resp, err := client.Get(baseURL + "/v0/ping")
+			if err != nil {
+				return false
+			}
+			defer resp.Body.Close()
+			_, _ = io.Copy(io.Discard, resp.Body)
+			return resp.StatusCode == http.StatusOK
+		}, 5*time.Second, 20*time.Millisecond, baseURL)
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// maintenanceStateTTL is how long a replica caches the maintenance state before re-reading it
+const maintenanceStateTTL = 5 * time.Second
+
+// MaintenanceStateProvider supplies the current maintenance mode state (satisfied by service.RegistryService)
+type MaintenanceStateProvider interface {
+	GetMaintenanceState(ctx context.Context) (*database.MaintenanceState, error)
+}
+
+// maintenanceSubscriber is implemented by providers that announce maintenance mode changes made by any
+// replica (satisfied by service.RegistryService)
+type maintenanceSubscriber interface {
+	SubscribeMaintenance(ctx context.Context, fn func()) error
+}
+
+// maintenanceCache avoids a database read on every request by caching the state for a short TTL. Requests
+// read the cached state without locking; once it expires, one request refreshes it while the others keep
+// serving the last known state.
+type maintenanceCache struct {
+	provider   MaintenanceStateProvider
+	ttl        time.Duration
+	cached     atomic.Pointer[cachedMaintenanceState]
+	refreshing atomic.Bool
+}
+
+// cachedMaintenanceState is a maintenance state and when it was read
+type cachedMaintenanceState struct {
+	state     *database.MaintenanceState
+	fetchedAt time.Time
+}
+
+func (c *maintenanceCache) get(ctx context.Context) *database.MaintenanceState {
+	cached := c.cached.Load()
+	if cached != nil && time.Since(cached.fetchedAt) < c.ttl {
+		return cached.state
+	}
+	if !c.refreshing.CompareAndSwap(false, true) {
+		if cached != nil && cached.state != nil {
+			return cached.state
+		}
+		// Nothing has been read yet, so there is no state to fall back on while another request refreshes it
+		return c.fetch(ctx, cached)
+	}
+	defer c.refreshing.Store(false)
+	return c.fetch(ctx, cached)
+}
+
+// fetch reads the state from the provider and caches it in place of cached, falling back to the cached state
+// if the read fails. If the cache was invalidated during the read, the state is returned but not cached, as it
+// may predate the change.
+func (c *maintenanceCache) fetch(ctx context.Context, cached *cachedMaintenanceState) *database.MaintenanceState {
+	state, err := c.provider.GetMaintenanceState(ctx)
+	if err != nil {
+		// Keep serving the last known state rather than failing requests when the database is unavailable
+		log.Printf("failed to refresh maintenance state: %v", err)
+		if cached == nil || cached.state == nil {
+			return &database.MaintenanceState{}
+		}
+		return cached.state
+	}
+
+	c.cached.CompareAndSwap(cached, &cachedMaintenanceState{state: state, fetchedAt: time.Now()})
+	return state
+}
+
+// invalidate makes the next request re-read the state. The invalidated state is kept so other requests
+// can serve it while that read is in progress.
+func (c *maintenanceCache) invalidate() {
+	var state *database.MaintenanceState
+	if cached := c.cached.Load(); cached != nil {
+		state = cached.state
+	}
+	c.cached.Store(&cachedMaintenanceState{state: state})
+}
+
+// NewMaintenanceMiddleware rejects write requests with 503 and a Retry-After header while maintenance mode is enabled.
+// Reads keep working and are marked with an X-Registry-Maintenance header. Auth, admin and validate endpoints are
+// never blocked so admins can still log in and turn maintenance mode off.
+func NewMaintenanceMiddleware(provider MaintenanceStateProvider) func(http.Handler) http.Handler {
+	return newMaintenanceMiddleware(context.Background(), provider)
+}
+
+// newMaintenanceMiddleware creates the maintenance middleware. If the provider announces changes, the cached
+// state is dropped as soon as any replica changes it, until ctx is done; otherwise changes apply once the
+// cached state expires.
+func newMaintenanceMiddleware(ctx context.Context, provider MaintenanceStateProvider) func(http.Handler) http.Handler {
+	cache := &maintenanceCache{provider: provider, ttl: maintenanceStateTTL}
+	if subscriber, ok := provider.(maintenanceSubscriber); ok {
+		if err := subscriber.SubscribeMaintenance(ctx, cache.invalidate); err != nil {
+			log.Printf("Maintenance mode changes will apply after up to %s: %v", maintenanceStateTTL, err)
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			state := cache.get(r.Context())
+			if !state.Enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !isMaintenanceBlocked(r) {
+				w.Header().Set("X-Registry-Maintenance", "read-only")
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			detail := "The registry is in maintenance mode and is not accepting changes. Please retry later."
+			if state.Message != nil && *state.Message != "" {
+				detail = *state.Message
+			}
+			w.Header().Set("Retry-After", strconv.Itoa(state.RetryAfterSeconds))
+			writeErrorResponse(w, http.StatusServiceUnavailable, apiv0.ErrorCodeMaintenanceMode, detail)
+		})
+	}
+}
+
+// isMaintenanceBlocked reports whether a request modifies registry data and should be rejected during maintenance
+func isMaintenanceBlocked(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return false
+	}
+
+	route := apiRoutePath(r.URL.Path)
+	return !strings.HasPrefix(route, "/auth/") &&
+		!strings.HasPrefix(route, "/admin/") &&
+		route != "/validate"
+}
+
+// apiRoutePath returns path relative to its API version prefix, or path itself for routes outside the
+// versioned API. Server names can contain segments such as "admin", so exemptions must match here rather
+// than anywhere in the path.
+func apiRoutePath(path string) string {
+	for _, prefix := range []string{"/v0.1", "/v0"} {
+		if rest, found := strings.CutPrefix(path, prefix); found && strings.HasPrefix(rest, "/") {
+			return rest
+		}
+	}
+	return path
+}
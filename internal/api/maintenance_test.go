@@ -0,0 +1,85 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+type fakeMaintenanceProvider struct {
+	state *database.MaintenanceState
+}
+
+func (f *fakeMaintenanceProvider) GetMaintenanceState(_ context.Context) (*database.MaintenanceState, error) {
+	return f.state, nil
+}
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	message := "Database migration in progress"
+	provider := &fakeMaintenanceProvider{state: &database.MaintenanceState{
+		Enabled:           true,
+		Message:           &message,
+		RetryAfterSeconds: 120,
+	}}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	middleware := api.NewMaintenanceMiddleware(provider)(handler)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "list servers is allowed", method: http.MethodGet, path: "/v0/servers", expectedStatus: http.StatusOK},
+		{name: "publish is rejected", method: http.MethodPost, path: "/v0/publish", expectedStatus: http.StatusServiceUnavailable},
+		{name: "edit is rejected", method: http.MethodPut, path: "/v0.1/servers/com.example%2Fserver/versions/1.0.0", expectedStatus: http.StatusServiceUnavailable},
+		{name: "status change is rejected", method: http.MethodPatch, path: "/v0/servers/com.example%2Fserver/status", expectedStatus: http.StatusServiceUnavailable},
+		{name: "token exchange is allowed", method: http.MethodPost, path: "/v0/auth/github-at", expectedStatus: http.StatusOK},
+		{name: "admin toggle is allowed", method: http.MethodPut, path: "/v0/admin/maintenance", expectedStatus: http.StatusOK},
+		{name: "validate is allowed", method: http.MethodPost, path: "/v0/validate", expectedStatus: http.StatusOK},
+		{name: "browser logout is allowed", method: http.MethodPost, path: "/auth/browser/logout", expectedStatus: http.StatusOK},
+		{name: "edit of a server named admin is rejected", method: http.MethodPut, path: "/v0/servers/io.github.x/admin/versions/1.0.0", expectedStatus: http.StatusServiceUnavailable},
+		{name: "delete of a server named auth is rejected", method: http.MethodDelete, path: "/v0.1/servers/io.github.x/auth/versions/1.0.0", expectedStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			middleware.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				assert.Equal(t, "120", w.Header().Get("Retry-After"))
+				assert.Contains(t, w.Body.String(), message)
+			} else {
+				assert.Equal(t, "read-only", w.Header().Get("X-Registry-Maintenance"))
+			}
+		})
+	}
+}
+
+func TestMaintenanceMiddleware_Disabled(t *testing.T) {
+	provider := &fakeMaintenanceProvider{state: &database.MaintenanceState{Enabled: false}}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	middleware := api.NewMaintenanceMiddleware(provider)(handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/publish", nil)
+	w := httptest.NewRecorder()
+	middleware.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("X-Registry-Maintenance"))
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// privateCacheControl keeps protected reads out of shared caches
+const privateCacheControl = "private, no-store"
+
+// NewReadAuthMiddleware makes the registry private when read authentication is required: reads of server
+// and stats endpoints need a registry JWT or a signed URL from POST /v0/admin/signed-urls. Signed URLs need
+// no Authorization header, so browsers can use them cross-origin without a CORS preflight. Responses to
+// protected reads are marked private so shared caches and CDNs do not serve them to other clients.
+func NewReadAuthMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	if !cfg.RequireReadAuth {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	jwtManager := auth.NewJWTManager(cfg)
+	signer := auth.NewURLSigner(cfg)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if (r.Method != http.MethodGet && r.Method != http.MethodHead) || !auth.IsReadPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("Cache-Control", privateCacheControl)
+
+			query := r.URL.Query()
+			if query.Has(auth.SignedURLSignatureParam) && signer != nil {
+				err := signer.Verify(r.URL.EscapedPath(), query, time.Now())
+				switch {
+				case errors.Is(err, auth.ErrSignedURLExpired):
+					writeErrorResponse(w, http.StatusForbidden, apiv0.ErrorCodeSignedURLExpired, "Signed URL has expired")
+				case err != nil:
+					writeErrorResponse(w, http.StatusForbidden, apiv0.ErrorCodeInvalidURLSignature, "Invalid URL signature")
+				default:
+					next.ServeHTTP(&privateWriter{ResponseWriter: w}, r)
+				}
+				return
+			}
+
+			const bearerPrefix = "Bearer "
+			authHeader := r.Header.Get("Authorization")
+			if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+				writeErrorResponse(w, http.StatusUnauthorized, apiv0.ErrorCodeReadAuthRequired, "This registry is private: provide a registry JWT or use a signed URL")
+				return
+			}
+			if _, err := jwtManager.ValidateToken(r.Context(), authHeader[len(bearerPrefix):]); err != nil {
+				writeErrorResponse(w, http.StatusUnauthorized, apiv0.ErrorCodeInvalidToken, "Invalid or expired Registry JWT token")
+				return
+			}
+
+			next.ServeHTTP(&privateWriter{ResponseWriter: w}, r)
+		})
+	}
+}
+
+// privateWriter keeps a protected response private even if its handler sets its own Cache-Control, as the
+// immutable server.json documents do
+type privateWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *privateWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.Header().Set("Cache-Control", privateCacheControl)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *privateWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, for flushing streamed responses
+func (w *privateWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,103 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestReadAuthMiddleware(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:     hex.EncodeToString(seed),
+		RequireReadAuth:   true,
+		ReadURLSigningKey: "test-signing-key",
+	}
+
+	tokenResponse, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{AuthMethod: auth.MethodNone})
+	require.NoError(t, err)
+
+	signer := auth.NewURLSigner(cfg)
+	detailPath := "/v0.1/servers/com.example%2Fserver/versions/latest"
+	signedDetail := signer.Sign(detailPath, time.Now().Add(time.Hour))
+	signedList := signer.Sign("/v0/servers", time.Now().Add(time.Hour))
+	expiredList := signer.Sign("/v0/servers", time.Now().Add(-time.Minute))
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/documents/") {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	middleware := api.NewReadAuthMiddleware(cfg)(handler)
+	documentPath := "/v0.1/documents/sha256:" + strings.Repeat("0", 64)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		authorization  string
+		expectedStatus int
+		expectedCode   apiv0.ErrorCode
+	}{
+		{name: "anonymous list is rejected", method: http.MethodGet, target: "/v0/servers", expectedStatus: http.StatusUnauthorized, expectedCode: apiv0.ErrorCodeReadAuthRequired},
+		{name: "registry JWT is accepted", method: http.MethodGet, target: "/v0/servers", authorization: "Bearer " + tokenResponse.RegistryToken, expectedStatus: http.StatusOK},
+		{name: "invalid JWT is rejected", method: http.MethodGet, target: "/v0/servers", authorization: "Bearer nope", expectedStatus: http.StatusUnauthorized, expectedCode: apiv0.ErrorCodeInvalidToken},
+		{name: "signed list URL is accepted", method: http.MethodGet, target: signedList, expectedStatus: http.StatusOK},
+		{name: "signed URL allows extra query parameters", method: http.MethodGet, target: signedList + "&search=weather&cursor=abc", expectedStatus: http.StatusOK},
+		{name: "signed URL with encoded path is accepted", method: http.MethodGet, target: signedDetail, expectedStatus: http.StatusOK},
+		{name: "signature is bound to the path", method: http.MethodGet, target: strings.Replace(signedList, "/v0/servers", "/v0/stats", 1), expectedStatus: http.StatusForbidden, expectedCode: apiv0.ErrorCodeInvalidURLSignature},
+		{name: "expired signed URL is rejected", method: http.MethodGet, target: expiredList, expectedStatus: http.StatusForbidden, expectedCode: apiv0.ErrorCodeSignedURLExpired},
+		{name: "anonymous document is rejected", method: http.MethodGet, target: documentPath, expectedStatus: http.StatusUnauthorized, expectedCode: apiv0.ErrorCodeReadAuthRequired},
+		{name: "document stays private", method: http.MethodGet, target: documentPath, authorization: "Bearer " + tokenResponse.RegistryToken, expectedStatus: http.StatusOK},
+		{name: "health stays public", method: http.MethodGet, target: "/v0/health", expectedStatus: http.StatusOK},
+		{name: "writes are left to their handlers", method: http.MethodPost, target: "/v0/publish", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			middleware.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if auth.IsReadPath(req.URL.Path) {
+				assert.Equal(t, "private, no-store", w.Header().Get("Cache-Control"))
+			}
+			if tt.expectedCode != "" {
+				assert.Contains(t, w.Body.String(), string(tt.expectedCode))
+			}
+		})
+	}
+}
+
+func TestReadAuthMiddleware_Disabled(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	middleware := api.NewReadAuthMiddleware(&config.Config{})(handler)
+
+	w := httptest.NewRecorder()
+	middleware.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// NewReadOnlyMiddleware rejects every write request with 405 when the registry serves reads only, pointing
+// clients at the primary registry. Read-only registries advertise the primary on every response, so clients
+// can send writes there without being told first. Validation only reads, so it keeps working.
+func NewReadOnlyMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !cfg.ReadOnly {
+			return next
+		}
+		primaryURL := strings.TrimSuffix(cfg.PrimaryURL, "/")
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if primaryURL != "" {
+				w.Header().Set(apiv0.PrimaryURLHeader, primaryURL)
+			}
+			if !isReadOnlyBlocked(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			detail := "This registry serves reads only."
+			if primaryURL != "" {
+				detail = "This registry serves reads only; send writes to " + primaryURL + r.URL.EscapedPath() + "."
+			}
+			w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+			writeErrorResponse(w, http.StatusMethodNotAllowed, apiv0.ErrorCodeReadOnly, detail)
+		})
+	}
+}
+
+// isReadOnlyBlocked reports whether a request is a write a read-only registry rejects. Unlike maintenance
+// mode, authentication and admin writes are rejected too, since they need the primary database.
+func isReadOnlyBlocked(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return !strings.HasSuffix(r.URL.Path, "/validate")
+	default:
+		return false
+	}
+}
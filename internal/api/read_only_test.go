@@ -0,0 +1,64 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	middleware := api.NewReadOnlyMiddleware(&config.Config{ReadOnly: true, PrimaryURL: "https://registry.example.com/"})(handler)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "list servers is allowed", method: http.MethodGet, path: "/v0/servers", expectedStatus: http.StatusOK},
+		{name: "validate is allowed", method: http.MethodPost, path: "/v0/validate", expectedStatus: http.StatusOK},
+		{name: "publish is rejected", method: http.MethodPost, path: "/v0/publish", expectedStatus: http.StatusMethodNotAllowed},
+		{name: "edit is rejected", method: http.MethodPut, path: "/v0.1/servers/com.example%2Fserver/versions/1.0.0", expectedStatus: http.StatusMethodNotAllowed},
+		{name: "token exchange is rejected", method: http.MethodPost, path: "/v0/auth/github-at", expectedStatus: http.StatusMethodNotAllowed},
+		{name: "admin writes are rejected", method: http.MethodDelete, path: "/v0/admin/webhooks/1", expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			middleware.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, "https://registry.example.com", w.Header().Get(apiv0.PrimaryURLHeader))
+			if tt.expectedStatus == http.StatusMethodNotAllowed {
+				assert.Equal(t, "GET, HEAD, OPTIONS", w.Header().Get("Allow"))
+				assert.Contains(t, w.Body.String(), string(apiv0.ErrorCodeReadOnly))
+				assert.Contains(t, w.Body.String(), "https://registry.example.com"+tt.path)
+			}
+		})
+	}
+}
+
+func TestReadOnlyMiddleware_Disabled(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	middleware := api.NewReadOnlyMiddleware(&config.Config{PrimaryURL: "https://registry.example.com"})(handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/publish", nil)
+	w := httptest.NewRecorder()
+	middleware.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(apiv0.PrimaryURLHeader))
+}
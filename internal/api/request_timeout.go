@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ErrRequestTimeout is the cause of the context cancellation of requests that ran past their timeout
+var ErrRequestTimeout = errors.New("request timed out")
+
+// NewRequestTimeoutMiddleware cancels the context of requests that have not started their response within their
+// timeout, so the database queries they are waiting on are cancelled rather than left running for a client that
+// has given up. Responses that have started, such as exports and artifact downloads, are not cut off, and event
+// streams have no timeout. A request that fails because its timeout passed is answered with 503 and
+// REQUEST_TIMEOUT.
+func NewRequestTimeoutMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	if cfg.RequestTimeout <= 0 && len(cfg.RequestTimeouts) == 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout, found := cfg.RequestTimeouts.Match(r.URL.Path)
+			if !found {
+				timeout = cfg.RequestTimeout
+			}
+			if timeout <= 0 || strings.HasSuffix(r.URL.Path, "/stream") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithCancelCause(r.Context())
+			defer cancel(nil)
+			tw := &timeoutWriter{ResponseWriter: w}
+			timer := time.AfterFunc(timeout, func() {
+				if tw.expire() {
+					cancel(ErrRequestTimeout)
+				}
+			})
+			defer timer.Stop()
+
+			next.ServeHTTP(tw, r.WithContext(ctx))
+			tw.finish()
+		})
+	}
+}
+
+// timeoutWriter tracks whether a response started before its request's timeout, and replaces the server error
+// a handler writes after its timeout passed with a timeout error
+type timeoutWriter struct {
+	http.ResponseWriter
+
+	mu      sync.Mutex
+	started bool
+	expired bool
+	// replaced is set once the timeout error has been written in place of the handler's response
+	replaced bool
+}
+
+// expire marks the request as timed out, reporting false if its response had already started
+func (w *timeoutWriter) expire() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started {
+		return false
+	}
+	w.expired = true
+	return true
+}
+
+// start marks the response as started with status, reporting whether it was replaced by the timeout error
+func (w *timeoutWriter) start(status int) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.started {
+		w.started = true
+		if w.expired && status >= http.StatusInternalServerError {
+			w.replaced = true
+			w.writeTimeoutError()
+		}
+	}
+	return w.replaced
+}
+
+// finish answers requests whose handler gave up without writing anything after their timeout passed
+func (w *timeoutWriter) finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.expired && !w.started {
+		w.started = true
+		w.writeTimeoutError()
+	}
+}
+
+func (w *timeoutWriter) writeTimeoutError() {
+	// Drop what the handler said about the body it would have written, keeping headers such as CORS
+	header := w.ResponseWriter.Header()
+	for _, key := range []string{"Content-Length", "Content-Encoding", "ETag", "Last-Modified", "Surrogate-Key"} {
+		header.Del(key)
+	}
+	header.Set("Cache-Control", "no-store")
+	writeErrorResponse(w.ResponseWriter, http.StatusServiceUnavailable, apiv0.ErrorCodeRequestTimeout,
+		"The request took too long and was cancelled; try again later or narrow the request")
+}
+
+func (w *timeoutWriter) WriteHeader(status int) {
+	if w.start(status) {
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	if w.start(http.StatusOK) {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, for flushing streamed responses
+func (w *timeoutWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,90 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestRequestTimeoutMiddleware(t *testing.T) {
+	// slowQuery stands in for a database query that runs until its context is cancelled
+	slowQuery := func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return nil
+		}
+	}
+	var cause error
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cause = nil
+		if err := slowQuery(r.Context()); err != nil {
+			cause = context.Cause(r.Context())
+			http.Error(w, "Failed to list servers", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	serve := func(t *testing.T, cfg *config.Config, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		middleware := api.NewRequestTimeoutMiddleware(cfg)
+		w := httptest.NewRecorder()
+		middleware(handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("cancels slow requests", func(t *testing.T) {
+		w := serve(t, &config.Config{RequestTimeout: 10 * time.Millisecond}, "/v0/servers")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.True(t, errors.Is(cause, api.ErrRequestTimeout))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+		var body v0.ErrorModel
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, apiv0.ErrorCodeRequestTimeout, body.Code)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		w := serve(t, &config.Config{}, "/v0/servers")
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	})
+
+	t.Run("longest matching prefix wins", func(t *testing.T) {
+		cfg := &config.Config{RequestTimeout: 10 * time.Millisecond, RequestTimeouts: config.PathDurations{"/v0/publish": 0}}
+		assert.Equal(t, http.StatusTeapot, serve(t, cfg, "/v0/publish").Code)
+		assert.Equal(t, http.StatusServiceUnavailable, serve(t, cfg, "/v0/servers").Code)
+	})
+
+	t.Run("event streams have no timeout", func(t *testing.T) {
+		w := serve(t, &config.Config{RequestTimeout: 10 * time.Millisecond}, "/v0/servers/changes/stream")
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	})
+
+	t.Run("started responses are not cut off", func(t *testing.T) {
+		middleware := api.NewRequestTimeoutMiddleware(&config.Config{RequestTimeout: 10 * time.Millisecond})
+		streaming := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("first chunk,"))
+			time.Sleep(30 * time.Millisecond)
+			assert.NoError(t, r.Context().Err())
+			_, _ = w.Write([]byte("second chunk"))
+		})
+		w := httptest.NewRecorder()
+		middleware(streaming).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers/export", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "first chunk,second chunk", w.Body.String())
+	})
+}
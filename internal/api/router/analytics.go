@@ -0,0 +1,60 @@
+package router
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+
+	"github.com/modelcontextprotocol/registry/internal/api/clientip"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// ReadAnalyticsMiddleware records successful searches, the servers they returned and server fetches in readStats
+func ReadAnalyticsMiddleware(readStats *telemetry.ReadStats) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		searchCtx, results := telemetry.WithSearchResults(ctx.Context())
+		ctx = huma.WithContext(ctx, searchCtx)
+		next(ctx)
+
+		if ctx.Method() != http.MethodGet || ctx.Status() != http.StatusOK {
+			return
+		}
+
+		path := ctx.Operation().Path
+		switch {
+		case strings.HasSuffix(path, "/servers"):
+			if search := ctx.Query("search"); search != "" {
+				readStats.RecordSearch(clientAddress(ctx), search)
+				readStats.RecordImpressions(clientAddress(ctx), results.ServerNames())
+			}
+		case strings.HasSuffix(path, "/servers/{serverName}/versions/{version}"):
+			serverName, err := url.PathUnescape(ctx.Param("serverName"))
+			if err != nil {
+				return
+			}
+			readStats.RecordServerFetch(clientAddress(ctx), serverName)
+			if version, err := url.PathUnescape(ctx.Param("version")); err == nil {
+				readStats.RecordVersionFetch(clientAddress(ctx), serverName, version)
+			}
+		}
+	}
+}
+
+// clientAddress returns the address of the client that made the request, as resolved through the trusted
+// proxies by the clientip middleware, or the connection's address when the middleware is not installed
+func clientAddress(ctx huma.Context) string {
+	if address := clientip.FromContext(ctx.Context()); address != "" {
+		return address
+	}
+
+	r, _ := humago.Unwrap(ctx)
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -4,6 +4,7 @@ package router
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
 	"time"
@@ -14,9 +15,13 @@ import (
 	"go.opentelemetry.io/otel/metric"
 
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/ranking"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 // Middleware configuration options
@@ -64,7 +69,8 @@ func MetricTelemetryMiddleware(metrics *telemetry.Metrics, options ...Middleware
 
 		next(ctx)
 
-		duration := time.Since(start).Seconds()
+		elapsed := time.Since(start)
+		duration := elapsed.Seconds()
 		statusCode := ctx.Status()
 
 		// Combine common and custom attributes
@@ -82,6 +88,10 @@ func MetricTelemetryMiddleware(metrics *telemetry.Metrics, options ...Middleware
 		}
 
 		metrics.RequestDuration.Record(ctx.Context(), duration, metric.WithAttributes(attrs...))
+
+		if metrics.SLO != nil {
+			metrics.SLO.Record(statusCode, elapsed)
+		}
 	}
 }
 
@@ -115,6 +125,7 @@ func handle404(w http.ResponseWriter, r *http.Request) {
 		"title":  "Not Found",
 		"status": 404,
 		"detail": detail,
+		"code":   apiv0.ErrorCodeEndpointNotFound,
 	}
 
 	// Use JSON marshal to ensure consistent formatting
@@ -154,6 +165,10 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 			Name:        "auth",
 			Description: "Authentication operations for obtaining tokens to publish servers",
 		},
+		{
+			Name:        "collections",
+			Description: "Curated collections of servers, browsable by anyone and managed by their curators",
+		},
 		{
 			Name:        "admin",
 			Description: "Administrative operations for managing servers (requires elevated permissions)",
@@ -170,6 +185,10 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 			Name:        "version",
 			Description: "Version information endpoint for retrieving build and version details",
 		},
+		{
+			Name:        "stats",
+			Description: "Anonymous, aggregated read analytics (only when enabled)",
+		},
 	}
 
 	// Add metrics middleware with options
@@ -177,9 +196,49 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 		WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
 	))
 
+	// Authenticate requests at the level the route policy requires for their route
+	routePolicy, err := auth.LoadRoutePolicy(cfg.RoutePolicyFile)
+	if err != nil {
+		log.Printf("Invalid route policy, using the default: %v", err)
+		routePolicy = auth.DefaultRoutePolicy()
+	}
+	api.UseMiddleware(v0.RouteAuthMiddleware(api, cfg, routePolicy))
+
+	// Add opt-in read analytics; the middleware must be added before routes are registered
+	var readStats *telemetry.ReadStats
+	if cfg.ReadAnalyticsEnabled {
+		readStats = telemetry.NewReadStats(cfg.ReadAnalyticsMinClients, cfg.ReadAnalyticsWindow)
+		api.UseMiddleware(ReadAnalyticsMiddleware(readStats))
+	}
+
+	// Rank search results; fetch counts from read analytics stand in for downloads when enabled
+	var downloads ranking.DownloadCounter
+	if readStats != nil {
+		downloads = readStats
+	}
+	ranker, err := ranking.NewFromConfig(cfg, registry, downloads)
+	if err != nil {
+		log.Printf("Invalid search ranking configuration, ordering search results by name: %v", err)
+	}
+
 	// Register routes for all API versions
-	RegisterV0Routes(api, cfg, registry, metrics, versionInfo)
-	RegisterV0_1Routes(api, cfg, registry, metrics, versionInfo)
+	RegisterV0Routes(api, cfg, registry, metrics, versionInfo, ranker)
+	RegisterV0_1Routes(api, cfg, registry, metrics, versionInfo, ranker)
+
+	if readStats != nil {
+		v0.RegisterStatsEndpoint(api, "/v0", readStats)
+		v0.RegisterStatsEndpoint(api, "/v0.1", readStats)
+		v0.RegisterNamespaceStatsEndpoint(api, "/v0", cfg, readStats)
+		v0.RegisterNamespaceStatsEndpoint(api, "/v0.1", cfg, readStats)
+	}
+
+	// Browser login for the UI redirects and sets cookies, so it is served outside the versioned API
+	v0auth.RegisterBrowserLoginEndpoints(mux, cfg, registry)
+
+	// Serve the registry's security.txt when a security contact is configured
+	if len(cfg.SecurityContacts) > 0 {
+		mux.HandleFunc("GET "+v0.SecurityTxtPath, v0.SecurityTxtHandler(cfg))
+	}
 
 	// Add /metrics for Prometheus metrics using promhttp
 	mux.Handle("/metrics", metrics.PrometheusHandler())
@@ -7,36 +7,127 @@ import (
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/ranking"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
 func RegisterV0Routes(
-	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics, versionInfo *v0.VersionBody,
+	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics, versionInfo *v0.VersionBody, ranker *ranking.Ranker,
 ) {
 	v0.RegisterHealthEndpoint(api, "/v0", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0")
 	v0.RegisterVersionEndpoint(api, "/v0", versionInfo)
-	v0.RegisterServersEndpoints(api, "/v0", registry)
+	v0.RegisterServersEndpoints(api, "/v0", registry, ranker)
+	v0.RegisterNamespaceEndpoints(api, "/v0", registry)
+	v0.RegisterExportEndpoint(api, "/v0", registry)
+	v0.RegisterServerChangesEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterInstallEndpoint(api, "/v0", registry)
+	v0.RegisterProvenanceEndpoint(api, "/v0", registry)
+	v0.RegisterServerToolsEndpoint(api, "/v0", registry)
+	if cfg.InstallFeedbackEnabled {
+		v0.RegisterInstallFeedbackEndpoint(api, "/v0", registry, cfg)
+	}
+	if cfg.ArtifactStorageDir != "" {
+		v0.RegisterArtifactEndpoints(api, "/v0", registry, cfg)
+	}
+	v0.RegisterServerJSONEndpoint(api, "/v0", registry)
+	v0.RegisterCardEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterDiffEndpoint(api, "/v0", registry)
+	v0.RegisterDeployEndpoints(api, "/v0", registry)
 	v0.RegisterEditEndpoints(api, "/v0", registry, cfg)
 	v0.RegisterStatusEndpoints(api, "/v0", registry, cfg)
 	v0.RegisterAllVersionsStatusEndpoints(api, "/v0", registry, cfg)
-	v0auth.RegisterAuthEndpoints(api, "/v0", cfg)
+	v0.RegisterRenameEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterMaintenanceEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterDuplicatesEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterRetentionEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterSignedURLEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterEventsEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterWebhookEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterAnnouncementEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterOAuthClientEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterAuditEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterMeEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterBulkEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterScreeningEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterBanEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterValidationPolicyEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterCurationEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterCollectionEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterStaleServerEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterMetricsEndpoints(api, "/v0", registry, cfg)
+	if cfg.OrgAPIKeysEnabled {
+		v0.RegisterOrgAPIKeyEndpoints(api, "/v0", registry, cfg)
+	}
+	v0auth.RegisterAuthEndpoints(api, "/v0", cfg, registry)
+	v0auth.RegisterNamespaceReviewEndpoints(api, "/v0", cfg, registry)
 	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
-	v0.RegisterValidateEndpoint(api, "/v0")
+	if cfg.PreviewTTL > 0 {
+		v0.RegisterPreviewEndpoints(api, "/v0", registry, cfg)
+	}
+	if cfg.AsyncPublishEnabled {
+		v0.RegisterAsyncPublishEndpoints(api, "/v0", registry, cfg)
+	}
+	v0.RegisterValidateEndpoint(api, "/v0", registry, cfg)
 }
 
 func RegisterV0_1Routes(
-	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics, versionInfo *v0.VersionBody,
+	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics, versionInfo *v0.VersionBody, ranker *ranking.Ranker,
 ) {
 	v0.RegisterHealthEndpoint(api, "/v0.1", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0.1")
 	v0.RegisterVersionEndpoint(api, "/v0.1", versionInfo)
-	v0.RegisterServersEndpoints(api, "/v0.1", registry)
+	v0.RegisterServersEndpoints(api, "/v0.1", registry, ranker)
+	v0.RegisterNamespaceEndpoints(api, "/v0.1", registry)
+	v0.RegisterExportEndpoint(api, "/v0.1", registry)
+	v0.RegisterServerChangesEndpoint(api, "/v0.1", registry, cfg)
+	v0.RegisterInstallEndpoint(api, "/v0.1", registry)
+	v0.RegisterProvenanceEndpoint(api, "/v0.1", registry)
+	v0.RegisterServerToolsEndpoint(api, "/v0.1", registry)
+	if cfg.InstallFeedbackEnabled {
+		v0.RegisterInstallFeedbackEndpoint(api, "/v0.1", registry, cfg)
+	}
+	if cfg.ArtifactStorageDir != "" {
+		v0.RegisterArtifactEndpoints(api, "/v0.1", registry, cfg)
+	}
+	v0.RegisterServerJSONEndpoint(api, "/v0.1", registry)
+	v0.RegisterCardEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterDiffEndpoint(api, "/v0.1", registry)
+	v0.RegisterDeployEndpoints(api, "/v0.1", registry)
 	v0.RegisterEditEndpoints(api, "/v0.1", registry, cfg)
 	v0.RegisterStatusEndpoints(api, "/v0.1", registry, cfg)
 	v0.RegisterAllVersionsStatusEndpoints(api, "/v0.1", registry, cfg)
-	v0auth.RegisterAuthEndpoints(api, "/v0.1", cfg)
+	v0.RegisterRenameEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterMaintenanceEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterDuplicatesEndpoint(api, "/v0.1", registry, cfg)
+	v0.RegisterRetentionEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterSignedURLEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterEventsEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterWebhookEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterAnnouncementEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterOAuthClientEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterAuditEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterMeEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterBulkEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterScreeningEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterBanEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterValidationPolicyEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterCurationEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterCollectionEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterStaleServerEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterMetricsEndpoints(api, "/v0.1", registry, cfg)
+	if cfg.OrgAPIKeysEnabled {
+		v0.RegisterOrgAPIKeyEndpoints(api, "/v0.1", registry, cfg)
+	}
+	v0auth.RegisterAuthEndpoints(api, "/v0.1", cfg, registry)
+	v0auth.RegisterNamespaceReviewEndpoints(api, "/v0.1", cfg, registry)
 	v0.RegisterPublishEndpoint(api, "/v0.1", registry, cfg)
-	v0.RegisterValidateEndpoint(api, "/v0.1")
+	if cfg.PreviewTTL > 0 {
+		v0.RegisterPreviewEndpoints(api, "/v0.1", registry, cfg)
+	}
+	if cfg.AsyncPublishEnabled {
+		v0.RegisterAsyncPublishEndpoints(api, "/v0.1", registry, cfg)
+	}
+	v0.RegisterValidateEndpoint(api, "/v0.1", registry, cfg)
 }
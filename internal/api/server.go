@@ -3,7 +3,9 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -11,11 +13,14 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/rs/cors"
 
+	"github.com/modelcontextprotocol/registry/internal/api/clientip"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/api/router"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/systemd"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 // NulByteValidationMiddleware rejects requests containing NUL bytes in URL path or query parameters.
@@ -26,13 +31,13 @@ func NulByteValidationMiddleware(next http.Handler) http.Handler {
 		// Check URL path for literal NUL bytes or URL-encoded %00
 		// Path needs %00 check because handlers call url.PathUnescape() which would decode it
 		if containsNulByte(r.URL.Path) {
-			writeErrorResponse(w, http.StatusBadRequest, "Invalid request: URL path contains null bytes")
+			writeErrorResponse(w, http.StatusBadRequest, apiv0.ErrorCodeBadRequest, "Invalid request: URL path contains null bytes")
 			return
 		}
 
 		// Check raw query string for literal NUL bytes or URL-encoded %00
 		if containsNulByte(r.URL.RawQuery) {
-			writeErrorResponse(w, http.StatusBadRequest, "Invalid request: query parameters contain null bytes")
+			writeErrorResponse(w, http.StatusBadRequest, apiv0.ErrorCodeBadRequest, "Invalid request: query parameters contain null bytes")
 			return
 		}
 
@@ -40,16 +45,19 @@ func NulByteValidationMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// writeErrorResponse writes a JSON error response using huma's ErrorModel format
+// writeErrorResponse writes a JSON error response using the API's ErrorModel format
 // for consistency with the rest of the API.
-func writeErrorResponse(w http.ResponseWriter, status int, detail string) {
+func writeErrorResponse(w http.ResponseWriter, status int, code apiv0.ErrorCode, detail string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 
-	errModel := &huma.ErrorModel{
-		Title:  http.StatusText(status),
-		Status: status,
-		Detail: detail,
+	errModel := &v0.ErrorModel{
+		ErrorModel: huma.ErrorModel{
+			Title:  http.StatusText(status),
+			Status: status,
+			Detail: detail,
+		},
+		Code: code,
 	}
 	_ = json.NewEncoder(w).Encode(errModel)
 }
@@ -84,12 +92,33 @@ func TrailingSlashMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// endStreamsMiddleware cancels event stream requests once ctx is done. Streams never finish on their own,
+// so without this a graceful shutdown would wait for every client to disconnect.
+func endStreamsMiddleware(ctx context.Context) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/stream") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			streamCtx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			stop := context.AfterFunc(ctx, cancel)
+			defer stop()
+			next.ServeHTTP(w, r.WithContext(streamCtx))
+		})
+	}
+}
+
 // Server represents the HTTP server
 type Server struct {
 	config   *config.Config
 	registry service.RegistryService
 	humaAPI  huma.API
 	server   *http.Server
+	// stopEvents ends the server's subscriptions to registry events and its event streams
+	stopEvents context.CancelFunc
 }
 
 // NewServer creates a new HTTP server
@@ -110,36 +139,84 @@ func NewServer(cfg *config.Config, registryService service.RegistryService, metr
 			http.MethodOptions,
 		},
 		AllowedHeaders:   []string{"*"},
-		ExposedHeaders:   []string{"Content-Type", "Content-Length"},
+		ExposedHeaders:   []string{"Content-Type", "Content-Length", "Retry-After", "X-Registry-Maintenance", apiv0.PrimaryURLHeader, apiv0.PossibleDuplicatesHeader, apiv0.ValidationWarningHeader},
 		AllowCredentials: false, // Must be false when AllowedOrigins is "*"
 		MaxAge:           86400, // 24 hours
 	})
 
+	// Resolve client addresses through the configured load balancers; Config.Validate has checked the CIDRs
+	clientIPs, err := clientip.NewResolver(cfg.TrustedProxies)
+	if err != nil {
+		log.Printf("Ignoring trusted proxies: %v", err)
+	}
+
 	// Wrap the mux with middleware stack
-	// Order: NulByteValidation -> TrailingSlash -> CORS -> Mux
-	handler := NulByteValidationMiddleware(TrailingSlashMiddleware(corsHandler.Handler(mux)))
+	// Order: ClientIP -> SlowRequest -> RequestTimeout -> NulByteValidation -> TrailingSlash -> CORS -> ReadOnly -> Maintenance -> Session -> CachePolicy -> ReadAuth -> EndStreams -> Mux
+	eventsCtx, stopEvents := context.WithCancel(context.Background())
+	maintenanceMiddleware := newMaintenanceMiddleware(eventsCtx, registryService)
+	sessionMiddleware := NewSessionMiddleware(cfg)
+	readAuthMiddleware := NewReadAuthMiddleware(cfg)
+	readOnlyMiddleware := NewReadOnlyMiddleware(cfg)
+	slowRequestMiddleware := NewSlowRequestMiddleware(cfg)
+	requestTimeoutMiddleware := NewRequestTimeoutMiddleware(cfg)
+	cachePolicyMiddleware := NewCachePolicyMiddleware(cfg)
+	handler := clientIPs.Middleware(slowRequestMiddleware(requestTimeoutMiddleware(NulByteValidationMiddleware(TrailingSlashMiddleware(corsHandler.Handler(readOnlyMiddleware(maintenanceMiddleware(sessionMiddleware(cachePolicyMiddleware(readAuthMiddleware(endStreamsMiddleware(eventsCtx)(mux))))))))))))
 
 	server := &Server{
 		config:   cfg,
 		registry: registryService,
 		humaAPI:  api,
 		server: &http.Server{
-			Addr:              cfg.ServerAddress,
 			Handler:           handler,
 			ReadHeaderTimeout: 10 * time.Second,
 		},
+		stopEvents: stopEvents,
 	}
 
 	return server
 }
 
-// Start begins listening for incoming HTTP requests
+// Start listens on every configured address and serves HTTP requests until the server is shut down or a
+// listener fails. Every address is opened before any is served, so a bad address fails startup. Once they
+// are all open, systemd is told the registry is ready.
 func (s *Server) Start() error {
-	log.Printf("HTTP server starting on %s", s.config.ServerAddress)
-	return s.server.ListenAndServe()
+	configs, err := ListenersFromConfig(s.config)
+	if err != nil {
+		return err
+	}
+
+	listeners := make([]net.Listener, 0, len(configs))
+	for _, listenerConfig := range configs {
+		listener, err := listenerConfig.listen()
+		if err != nil {
+			for _, opened := range listeners {
+				opened.Close()
+			}
+			return fmt.Errorf("failed to listen on %s: %w", listenerConfig, err)
+		}
+		listeners = append(listeners, listener)
+	}
+
+	errs := make(chan error, len(listeners))
+	for i, listener := range listeners {
+		log.Printf("HTTP server starting on %s", configs[i])
+		go func() {
+			errs <- s.server.Serve(listener)
+		}()
+	}
+	if _, err := systemd.Notify(systemd.Ready); err != nil {
+		log.Printf("Failed to notify systemd of readiness: %v", err)
+	}
+	return <-errs
+}
+
+// Handler returns the server's HTTP handler with every middleware applied, for serving it in tests
+func (s *Server) Handler() http.Handler {
+	return s.server.Handler
 }
 
 // Shutdown gracefully shuts down the server
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.stopEvents()
 	return s.server.Shutdown(ctx)
 }
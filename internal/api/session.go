@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// NewSessionMiddleware authenticates requests from logged-in browsers, when browser login is enabled, by
+// passing the registry token in their session cookie on as a bearer Authorization header, so every
+// endpoint accepts sessions as it accepts tokens. Requests with their own Authorization header are left
+// alone. Requests that may change state must carry the session's CSRF token, since browsers send the
+// cookie with requests other sites trigger too. The OAuth consent form protects itself, so it is posted
+// without the session.
+func NewSessionMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	if !cfg.BrowserLoginEnabled {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	jwtManager := auth.NewJWTManager(cfg)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie(auth.SessionCookieName)
+			if err != nil || r.Header.Get("Authorization") != "" || r.URL.Path == auth.OAuthConsentPath {
+				next.ServeHTTP(w, r)
+				return
+			}
+			// An expired session is treated like no session, so public endpoints keep working
+			claims, err := jwtManager.ValidateToken(r.Context(), cookie.Value)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+			default:
+				if !auth.ValidCSRFToken(claims, r.Header.Get(auth.CSRFHeader)) {
+					writeErrorResponse(w, http.StatusForbidden, apiv0.ErrorCodeInvalidCSRFToken,
+						"Requests authenticated by a browser session must send its CSRF token in the "+auth.CSRFHeader+" header")
+					return
+				}
+			}
+
+			r = r.Clone(r.Context())
+			r.Header.Set("Authorization", "Bearer "+cookie.Value)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
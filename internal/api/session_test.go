@@ -0,0 +1,87 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestSessionMiddleware(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(seed), BrowserLoginEnabled: true}
+
+	jwtManager := auth.NewJWTManager(cfg)
+	session, err := jwtManager.GenerateSessionToken(context.Background(), auth.JWTClaims{AuthMethod: auth.MethodOIDC, AuthMethodSubject: "admin"}, time.Hour)
+	require.NoError(t, err)
+	claims, err := jwtManager.ValidateToken(context.Background(), session.RegistryToken)
+	require.NoError(t, err)
+	csrfToken := claims.ID
+
+	var gotAuthorization string
+	handler := api.NewSessionMiddleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name              string
+		method            string
+		path              string
+		cookie            string
+		authorization     string
+		csrfToken         string
+		wantStatus        int
+		wantAuthorization string
+	}{
+		{name: "no session", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "read with session", method: http.MethodGet, cookie: session.RegistryToken, wantStatus: http.StatusOK, wantAuthorization: "Bearer " + session.RegistryToken},
+		{name: "write without CSRF token", method: http.MethodPost, cookie: session.RegistryToken, wantStatus: http.StatusForbidden},
+		{name: "write with wrong CSRF token", method: http.MethodDelete, cookie: session.RegistryToken, csrfToken: "guess", wantStatus: http.StatusForbidden},
+		{name: "write with CSRF token", method: http.MethodPut, cookie: session.RegistryToken, csrfToken: csrfToken, wantStatus: http.StatusOK, wantAuthorization: "Bearer " + session.RegistryToken},
+		{name: "own Authorization header", method: http.MethodPost, cookie: session.RegistryToken, authorization: "Bearer other", wantStatus: http.StatusOK, wantAuthorization: "Bearer other"},
+		{name: "invalid session", method: http.MethodPost, cookie: "not-a-token", wantStatus: http.StatusOK},
+		{name: "OAuth consent", method: http.MethodPost, path: auth.OAuthConsentPath, cookie: session.RegistryToken, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAuthorization = ""
+			path := tt.path
+			if path == "" {
+				path = "/v0/admin/maintenance"
+			}
+			req := httptest.NewRequest(tt.method, path, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: tt.cookie})
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.csrfToken != "" {
+				req.Header.Set(auth.CSRFHeader, tt.csrfToken)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			assert.Equal(t, tt.wantAuthorization, gotAuthorization)
+			if tt.wantStatus == http.StatusForbidden {
+				assert.Contains(t, w.Body.String(), "INVALID_CSRF_TOKEN")
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+// Package artifacts mirrors the artifacts of published packages into storage the registry controls, so MCP
+// clients without access to npm, PyPI or OCI registries can install servers entirely through the registry.
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Mirroring errors
+var (
+	ErrDigestMismatch = errors.New("artifact does not match its recorded digest")
+	ErrTooLarge       = errors.New("artifact exceeds the mirroring size limit")
+)
+
+// downloadTimeout bounds how long one artifact may take to download
+const downloadTimeout = 10 * time.Minute
+
+// Mirror downloads package artifacts, checks them against the digests recorded at publish time, and keeps
+// them in a Store
+type Mirror struct {
+	store    Store
+	client   *http.Client
+	maxBytes int64
+}
+
+// NewMirror creates a mirror keeping artifacts of at most maxBytes in store
+func NewMirror(store Store, maxBytes int64) *Mirror {
+	return &Mirror{store: store, client: &http.Client{Timeout: downloadTimeout}, maxBytes: maxBytes}
+}
+
+// NewFromConfig creates the mirror configured for the registry, or returns nil when the artifact proxy is
+// disabled. Download URLs are signed with the read URL signing key, so the proxy requires one.
+func NewFromConfig(cfg *config.Config) (*Mirror, error) {
+	if cfg.ArtifactStorageDir == "" {
+		return nil, nil
+	}
+	if cfg.ReadURLSigningKey == "" {
+		return nil, errors.New("the artifact proxy requires read_auth.url_signing_key to sign download URLs")
+	}
+	store, err := NewDirStore(cfg.ArtifactStorageDir)
+	if err != nil {
+		return nil, err
+	}
+	return NewMirror(store, cfg.ArtifactMaxBytes), nil
+}
+
+// Open returns a mirrored artifact's content by its SHA-256, or ErrNotFound
+func (m *Mirror) Open(ctx context.Context, sha256 string) (io.ReadCloser, error) {
+	return m.store.Open(ctx, sha256)
+}
+
+// MirrorPackage mirrors the artifacts of pkg, checking them against provenance when it is known: the npm
+// tarball, every PyPI distribution file, the MCPB bundle, or the OCI image as a docker load archive.
+// NuGet packages have no mirrored artifacts.
+func (m *Mirror) MirrorPackage(ctx context.Context, pkg model.Package, provenance *apiv0.PackageProvenance) ([]apiv0.PackageArtifact, error) {
+	switch pkg.RegistryType {
+	case model.RegistryTypeNPM:
+		return m.mirrorNPM(ctx, pkg, provenance)
+	case model.RegistryTypePyPI:
+		return m.mirrorPyPI(ctx, pkg, provenance)
+	case model.RegistryTypeMCPB:
+		return m.mirrorMCPB(ctx, pkg)
+	case model.RegistryTypeOCI:
+		return m.mirrorOCI(ctx, pkg, provenance)
+	default:
+		return nil, nil
+	}
+}
+
+// mirrorNPM mirrors the tarball of an npm package version, checking its Subresource Integrity string
+func (m *Mirror) mirrorNPM(ctx context.Context, pkg model.Package, provenance *apiv0.PackageProvenance) ([]apiv0.PackageArtifact, error) {
+	baseURL := pkg.RegistryBaseURL
+	if baseURL == "" {
+		baseURL = model.RegistryURLNPM
+	}
+	version := pkg.Version
+	if provenance != nil && provenance.Version != "" {
+		version = provenance.Version
+	}
+
+	var metadata struct {
+		Dist struct {
+			Tarball   string `json:"tarball"`
+			Integrity string `json:"integrity"`
+		} `json:"dist"`
+	}
+	if err := m.getJSON(ctx, baseURL+"/"+url.PathEscape(pkg.Identifier)+"/"+url.PathEscape(version), &metadata); err != nil {
+		return nil, err
+	}
+	if metadata.Dist.Tarball == "" {
+		return nil, fmt.Errorf("npm package %s@%s has no tarball", pkg.Identifier, version)
+	}
+
+	integrity := metadata.Dist.Integrity
+	if provenance != nil && strings.HasPrefix(provenance.Digest, "sha512-") {
+		integrity = provenance.Digest
+	}
+	artifact, err := m.mirrorURL(ctx, pkg, metadata.Dist.Tarball, func(sums checksums) error {
+		return checkIntegrity(sums, integrity)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []apiv0.PackageArtifact{artifact}, nil
+}
+
+// mirrorPyPI mirrors every distribution file of a PyPI release, checking each file's SHA-256
+func (m *Mirror) mirrorPyPI(ctx context.Context, pkg model.Package, provenance *apiv0.PackageProvenance) ([]apiv0.PackageArtifact, error) {
+	baseURL := pkg.RegistryBaseURL
+	if baseURL == "" {
+		baseURL = model.RegistryURLPyPI
+	}
+	version := pkg.Version
+	if provenance != nil && provenance.Version != "" {
+		version = provenance.Version
+	}
+
+	var metadata struct {
+		URLs []struct {
+			Filename string `json:"filename"`
+			URL      string `json:"url"`
+			Digests  struct {
+				SHA256 string `json:"sha256"`
+			} `json:"digests"`
+		} `json:"urls"`
+	}
+	if err := m.getJSON(ctx, baseURL+"/pypi/"+url.PathEscape(pkg.Identifier)+"/"+url.PathEscape(version)+"/json", &metadata); err != nil {
+		return nil, err
+	}
+
+	// Digests recorded at publish take precedence, so files republished since then are refused
+	recorded := map[string]string{}
+	if provenance != nil {
+		for _, file := range provenance.Files {
+			recorded[file.Filename] = strings.TrimPrefix(file.Digest, "sha256:")
+		}
+	}
+
+	var mirrored []apiv0.PackageArtifact
+	for _, file := range metadata.URLs {
+		expected := file.Digests.SHA256
+		if digest, ok := recorded[file.Filename]; ok {
+			expected = digest
+		}
+		artifact, err := m.mirrorURL(ctx, pkg, file.URL, func(sums checksums) error {
+			return checkSHA256(sums, expected)
+		})
+		if err != nil {
+			return nil, err
+		}
+		mirrored = append(mirrored, artifact)
+	}
+	return mirrored, nil
+}
+
+// mirrorMCPB mirrors an MCPB bundle, checking it against the fileSha256 declared in server.json
+func (m *Mirror) mirrorMCPB(ctx context.Context, pkg model.Package) ([]apiv0.PackageArtifact, error) {
+	artifact, err := m.mirrorURL(ctx, pkg, pkg.Identifier, func(sums checksums) error {
+		return checkSHA256(sums, pkg.FileSHA256)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []apiv0.PackageArtifact{artifact}, nil
+}
+
+// mirrorOCI mirrors an OCI image as an archive docker load accepts. The image is fetched by the digest
+// recorded at publish, which the registry client verifies the manifest against, so tags moved since are not
+// followed. For multi-platform images the linux/amd64 image is mirrored.
+func (m *Mirror) mirrorOCI(ctx context.Context, pkg model.Package, provenance *apiv0.PackageProvenance) ([]apiv0.PackageArtifact, error) {
+	identifier := pkg.Identifier
+	if provenance != nil {
+		identifier = registries.PinOCIDigest(identifier, provenance.Digest)
+	}
+	ref, err := name.ParseReference(identifier)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI reference %q: %w", identifier, err)
+	}
+	image, err := remote.Image(ref, remote.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OCI image %s: %w", identifier, err)
+	}
+
+	filename := path.Base(ref.Context().RepositoryStr()) + ".tar"
+	artifact, err := m.save(ctx, pkg, filename, func(w io.Writer) error {
+		return tarball.Write(ref, image, w)
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return []apiv0.PackageArtifact{artifact}, nil
+}
+
+// getJSON fetches and decodes a JSON metadata document
+func (m *Mirror) getJSON(ctx context.Context, requestURL string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "MCP-Registry-Artifact-Proxy/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", requestURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch %s: status %d", requestURL, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", requestURL, err)
+	}
+	return nil
+}
+
+// mirrorURL downloads an artifact, naming it after the last segment of its URL
+func (m *Mirror) mirrorURL(ctx context.Context, pkg model.Package, artifactURL string, check func(checksums) error) (apiv0.PackageArtifact, error) {
+	parsed, err := url.Parse(artifactURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return apiv0.PackageArtifact{}, fmt.Errorf("invalid artifact URL %q", artifactURL)
+	}
+
+	return m.save(ctx, pkg, path.Base(parsed.Path), func(w io.Writer) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, artifactURL, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("User-Agent", "MCP-Registry-Artifact-Proxy/1.0")
+
+		resp, err := m.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", artifactURL, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to download %s: status %d", artifactURL, resp.StatusCode)
+		}
+		_, err = io.Copy(w, resp.Body)
+		return err
+	}, check)
+}
+
+// checksums are the digests of a downloaded artifact
+type checksums struct {
+	sha256 []byte
+	sha512 []byte
+}
+
+// save writes an artifact to a temporary file through write, checks its digests, and moves it to the store.
+// Checking before storing keeps artifacts that don't match their recorded digests out of the store.
+func (m *Mirror) save(ctx context.Context, pkg model.Package, filename string, write func(io.Writer) error, check func(checksums) error) (apiv0.PackageArtifact, error) {
+	tmp, err := os.CreateTemp("", "mcp-artifact-*")
+	if err != nil {
+		return apiv0.PackageArtifact{}, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	sha256Hash, sha512Hash := sha256.New(), sha512.New()
+	limited := &limitedWriter{w: io.MultiWriter(tmp, sha256Hash, sha512Hash), remaining: m.maxBytes}
+	if err := write(limited); err != nil {
+		if limited.exceeded {
+			return apiv0.PackageArtifact{}, fmt.Errorf("%w: %s", ErrTooLarge, filename)
+		}
+		return apiv0.PackageArtifact{}, err
+	}
+
+	sums := checksums{sha256: sha256Hash.Sum(nil), sha512: sha512Hash.Sum(nil)}
+	if check != nil {
+		if err := check(sums); err != nil {
+			return apiv0.PackageArtifact{}, fmt.Errorf("%s: %w", filename, err)
+		}
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return apiv0.PackageArtifact{}, fmt.Errorf("failed to read temporary file: %w", err)
+	}
+	digest := hex.EncodeToString(sums.sha256)
+	if err := m.store.Put(ctx, digest, tmp); err != nil {
+		return apiv0.PackageArtifact{}, err
+	}
+
+	return apiv0.PackageArtifact{
+		RegistryType: pkg.RegistryType,
+		Identifier:   pkg.Identifier,
+		Filename:     sanitizeFilename(filename),
+		SHA256:       digest,
+		Size:         limited.written,
+		MirroredAt:   time.Now(),
+	}, nil
+}
+
+// checkSHA256 compares an artifact's SHA-256 to a hex-encoded expected digest; an empty digest isn't checked
+func checkSHA256(sums checksums, expected string) error {
+	if expected == "" || strings.EqualFold(hex.EncodeToString(sums.sha256), expected) {
+		return nil
+	}
+	return ErrDigestMismatch
+}
+
+// checkIntegrity compares an artifact to an npm Subresource Integrity string. Only sha512 and sha256 are
+// checked; older packages with just a SHA-1 shasum are mirrored unchecked.
+func checkIntegrity(sums checksums, integrity string) error {
+	for _, entry := range strings.Fields(integrity) {
+		algorithm, encoded, found := strings.Cut(entry, "-")
+		if !found {
+			continue
+		}
+		var sum []byte
+		switch algorithm {
+		case "sha512":
+			sum = sums.sha512
+		case "sha256":
+			sum = sums.sha256
+		default:
+			continue
+		}
+		if base64.StdEncoding.EncodeToString(sum) != encoded {
+			return ErrDigestMismatch
+		}
+	}
+	return nil
+}
+
+// unsafeFilenameChars are replaced in artifact file names, which end up in download URLs and headers
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._+-]`)
+
+func sanitizeFilename(filename string) string {
+	filename = unsafeFilenameChars.ReplaceAllString(filename, "_")
+	if filename == "" || filename == "." || filename == ".." {
+		return "artifact"
+	}
+	return filename
+}
+
+// limitedWriter fails writes past a size limit, counting what was written
+type limitedWriter struct {
+	w         io.Writer
+	remaining int64
+	written   int64
+	exceeded  bool
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining {
+		l.exceeded = true
+		return 0, ErrTooLarge
+	}
+	n, err := l.w.Write(p)
+	l.remaining -= int64(n)
+	l.written += int64(n)
+	return n, err
+}
@@ -0,0 +1,115 @@
+package artifacts_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/artifacts"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestMirror(t *testing.T) {
+	tarball := []byte("npm tarball content")
+	wheel := []byte("python wheel content")
+	bundle := []byte("mcpb bundle content")
+	sha512Sum := sha512.Sum512(tarball)
+	integrity := "sha512-" + base64.StdEncoding.EncodeToString(sha512Sum[:])
+	hexSHA256 := func(content []byte) string {
+		sum := sha256.Sum256(content)
+		return hex.EncodeToString(sum[:])
+	}
+
+	var upstream *httptest.Server
+	upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/weather/1.0.0":
+			_, _ = io.WriteString(w, `{"dist":{"tarball":"`+upstream.URL+`/weather/-/weather-1.0.0.tgz","integrity":"`+integrity+`"}}`)
+		case "/weather/-/weather-1.0.0.tgz":
+			_, _ = w.Write(tarball)
+		case "/pypi/weather/1.0.0/json":
+			_, _ = io.WriteString(w, `{"urls":[{"filename":"weather-1.0.0-py3-none-any.whl","url":"`+upstream.URL+`/files/weather-1.0.0-py3-none-any.whl","digests":{"sha256":"`+hexSHA256(wheel)+`"}}]}`)
+		case "/files/weather-1.0.0-py3-none-any.whl":
+			_, _ = w.Write(wheel)
+		case "/releases/weather.mcpb":
+			_, _ = w.Write(bundle)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	store, err := artifacts.NewDirStore(t.TempDir())
+	require.NoError(t, err)
+	mirror := artifacts.NewMirror(store, 1024)
+	ctx := context.Background()
+
+	t.Run("npm", func(t *testing.T) {
+		pkg := model.Package{RegistryType: model.RegistryTypeNPM, RegistryBaseURL: upstream.URL, Identifier: "weather", Version: "1.0.0"}
+		mirrored, err := mirror.MirrorPackage(ctx, pkg, &apiv0.PackageProvenance{Version: "1.0.0", Digest: integrity})
+		require.NoError(t, err)
+		require.Len(t, mirrored, 1)
+		assert.Equal(t, "weather-1.0.0.tgz", mirrored[0].Filename)
+		assert.Equal(t, hexSHA256(tarball), mirrored[0].SHA256)
+		assert.Equal(t, int64(len(tarball)), mirrored[0].Size)
+
+		content, err := mirror.Open(ctx, mirrored[0].SHA256)
+		require.NoError(t, err)
+		defer content.Close()
+		stored, err := io.ReadAll(content)
+		require.NoError(t, err)
+		assert.Equal(t, tarball, stored)
+
+		// A tarball that differs from the integrity recorded at publish was republished and is refused
+		republished := sha512.Sum512([]byte("other content"))
+		_, err = mirror.MirrorPackage(ctx, pkg, &apiv0.PackageProvenance{Digest: "sha512-" + base64.StdEncoding.EncodeToString(republished[:])})
+		assert.ErrorIs(t, err, artifacts.ErrDigestMismatch)
+	})
+
+	t.Run("pypi", func(t *testing.T) {
+		pkg := model.Package{RegistryType: model.RegistryTypePyPI, RegistryBaseURL: upstream.URL, Identifier: "weather", Version: "1.0.0"}
+		mirrored, err := mirror.MirrorPackage(ctx, pkg, nil)
+		require.NoError(t, err)
+		require.Len(t, mirrored, 1)
+		assert.Equal(t, "weather-1.0.0-py3-none-any.whl", mirrored[0].Filename)
+		assert.Equal(t, hexSHA256(wheel), mirrored[0].SHA256)
+
+		_, err = mirror.MirrorPackage(ctx, pkg, &apiv0.PackageProvenance{Files: []apiv0.ProvenanceFile{
+			{Filename: "weather-1.0.0-py3-none-any.whl", Digest: "sha256:" + hexSHA256([]byte("other content"))},
+		}})
+		assert.ErrorIs(t, err, artifacts.ErrDigestMismatch)
+	})
+
+	t.Run("mcpb", func(t *testing.T) {
+		pkg := model.Package{RegistryType: model.RegistryTypeMCPB, Identifier: upstream.URL + "/releases/weather.mcpb", FileSHA256: hexSHA256(bundle)}
+		mirrored, err := mirror.MirrorPackage(ctx, pkg, nil)
+		require.NoError(t, err)
+		require.Len(t, mirrored, 1)
+		assert.Equal(t, "weather.mcpb", mirrored[0].Filename)
+
+		small := artifacts.NewMirror(store, 4)
+		_, err = small.MirrorPackage(ctx, pkg, nil)
+		assert.ErrorIs(t, err, artifacts.ErrTooLarge)
+	})
+
+	t.Run("unsupported registries are skipped", func(t *testing.T) {
+		mirrored, err := mirror.MirrorPackage(ctx, model.Package{RegistryType: model.RegistryTypeNuGet, Identifier: "Weather"}, nil)
+		require.NoError(t, err)
+		assert.Empty(t, mirrored)
+	})
+
+	_, err = mirror.Open(ctx, hexSHA256([]byte("never stored")))
+	assert.ErrorIs(t, err, artifacts.ErrNotFound)
+	_, err = mirror.Open(ctx, "../../etc/passwd")
+	assert.ErrorIs(t, err, artifacts.ErrNotFound)
+}
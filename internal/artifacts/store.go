@@ -0,0 +1,110 @@
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotFound is returned when a store has no artifact with the requested SHA-256
+var ErrNotFound = errors.New("artifact not found")
+
+// Store keeps mirrored artifacts by the hex-encoded SHA-256 of their content, so a file shared by several
+// server versions is stored once
+type Store interface {
+	// Put stores the content read from r under sha256, which the caller has already computed. Storing content
+	// that is already present succeeds without replacing it.
+	Put(ctx context.Context, sha256 string, r io.Reader) error
+	// Open returns the content stored under sha256, or ErrNotFound
+	Open(ctx context.Context, sha256 string) (io.ReadCloser, error)
+}
+
+// DirStore is a Store keeping artifacts as files in a local directory, such as a mounted volume or bucket
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore creates a store in dir, creating the directory if needed
+func NewDirStore(dir string) (*DirStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+	return &DirStore{dir: dir}, nil
+}
+
+// path spreads artifacts over subdirectories named by the first two hex digits of their hash
+func (s *DirStore) path(sha256 string) string {
+	return filepath.Join(s.dir, sha256[:2], sha256)
+}
+
+// Put stores the content read from r under sha256. The content is written to a temporary file first, so
+// readers never see a partially written artifact.
+func (s *DirStore) Put(ctx context.Context, sha256 string, r io.Reader) error {
+	if !isSHA256(sha256) {
+		return fmt.Errorf("invalid artifact hash %q", sha256)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	path := s.path(sha256)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create artifact file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write artifact: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write artifact: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store artifact: %w", err)
+	}
+	return nil
+}
+
+// Open returns the content stored under sha256, or ErrNotFound
+func (s *DirStore) Open(ctx context.Context, sha256 string) (io.ReadCloser, error) {
+	if !isSHA256(sha256) {
+		return nil, ErrNotFound
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	file, err := os.Open(s.path(sha256))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	return file, nil
+}
+
+// isSHA256 reports whether s is a lowercase hex-encoded SHA-256, which also keeps it safe to use in a path
+func isSHA256(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
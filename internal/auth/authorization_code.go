@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AuthorizationCodeLifetime is how long an OAuth client has to redeem an authorization code
+const AuthorizationCodeLifetime = time.Minute
+
+// ConsentLifetime is how long a person has to approve an OAuth client once they logged in
+const ConsentLifetime = 10 * time.Minute
+
+// authorizationCodeAudience is appended to the registry's audience for authorization codes, so a code can
+// never be used as a registry token and a registry token never redeemed as a code
+const authorizationCodeAudience = "/authorization-code"
+
+// consentAudience is appended to the registry's audience for authorizations awaiting consent, so they can
+// never be redeemed as codes or used as registry tokens
+const consentAudience = "/consent"
+
+// AuthorizationCodeClaims are the claims of an authorization code issued to a registered OAuth client. The code
+// is a signed JWT, so replicas need not share state: it carries the client claims of the person who logged in,
+// the client and redirect URI it was issued for, and the PKCE challenge the client must answer to redeem it.
+type AuthorizationCodeClaims struct {
+	JWTClaims
+	ClientID      string `json:"client_id"`
+	RedirectURI   string `json:"redirect_uri"`
+	CodeChallenge string `json:"code_challenge"`
+}
+
+// ConsentClaims are the claims of an authorization awaiting the approval of the person who logged in: the
+// authorization code the client is sent once they approve, and the state to send it with
+type ConsentClaims struct {
+	AuthorizationCodeClaims
+	State string `json:"state,omitempty"`
+}
+
+// ClientClaims reduces the claims of a person logging in through a registered OAuth client to what the client
+// may act on: their permissions scoped to namespaces, such as com.example/* or com.example.*. Global
+// permissions, which registry admins have, and organization ownership are never passed on to clients.
+func ClientClaims(claims JWTClaims, clientID string) JWTClaims {
+	reduced := JWTClaims{
+		AuthMethod:        claims.AuthMethod,
+		AuthMethodSubject: claims.AuthMethodSubject,
+		OAuthClient:       clientID,
+	}
+	for _, permission := range claims.Permissions {
+		namespace, _, _ := strings.Cut(permission.ResourcePattern, "/")
+		namespace = strings.TrimSuffix(namespace, ".*")
+		if namespace != "" && !strings.Contains(namespace, "*") {
+			reduced.Permissions = append(reduced.Permissions, permission)
+		}
+	}
+	return reduced
+}
+
+// GenerateAuthorizationCode signs an authorization code lasting AuthorizationCodeLifetime. The code's ID lets
+// the token endpoint redeem it only once.
+func (j *JWTManager) GenerateAuthorizationCode(claims AuthorizationCodeClaims) (string, error) {
+	claims.RegisteredClaims = j.authorizationClaims(authorizationCodeAudience, AuthorizationCodeLifetime)
+	code, err := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, claims).SignedString(j.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign authorization code: %w", err)
+	}
+	return code, nil
+}
+
+// ValidateAuthorizationCode validates an authorization code this registry issued and returns its claims
+func (j *JWTManager) ValidateAuthorizationCode(code string) (*AuthorizationCodeClaims, error) {
+	claims := &AuthorizationCodeClaims{}
+	if err := j.parseAuthorization(code, claims, authorizationCodeAudience); err != nil {
+		return nil, fmt.Errorf("failed to parse authorization code: %w", err)
+	}
+	return claims, nil
+}
+
+// GenerateConsent signs an authorization awaiting consent, lasting ConsentLifetime, and returns it with its ID
+func (j *JWTManager) GenerateConsent(claims ConsentClaims) (string, string, error) {
+	claims.RegisteredClaims = j.authorizationClaims(consentAudience, ConsentLifetime)
+	consent, err := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, claims).SignedString(j.privateKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sign consent request: %w", err)
+	}
+	return consent, claims.ID, nil
+}
+
+// ValidateConsent validates an authorization awaiting consent that this registry issued and returns its claims
+func (j *JWTManager) ValidateConsent(consent string) (*ConsentClaims, error) {
+	claims := &ConsentClaims{}
+	if err := j.parseAuthorization(consent, claims, consentAudience); err != nil {
+		return nil, fmt.Errorf("failed to parse consent request: %w", err)
+	}
+	return claims, nil
+}
+
+// authorizationClaims returns the registered claims of a new authorization for audience, lasting lifetime
+func (j *JWTManager) authorizationClaims(audience string, lifetime time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		ID:        rand.Text(),
+		Issuer:    j.issuer,
+		Audience:  jwt.ClaimStrings{j.audience + audience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
+	}
+}
+
+// parseAuthorization validates a signed authorization for audience into claims
+func (j *JWTManager) parseAuthorization(value string, claims jwt.Claims, audience string) error {
+	token, err := jwt.ParseWithClaims(
+		value,
+		claims,
+		func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return j.publicKey, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithIssuer(j.issuer),
+		jwt.WithAudience(j.audience+audience),
+		jwt.WithLeeway(j.clockSkew),
+	)
+	if err != nil {
+		return err
+	}
+	if !token.Valid {
+		return fmt.Errorf("invalid token")
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrIdentityBanned is returned when a registry token is requested or used by an identity an admin banned
+var ErrIdentityBanned = errors.New("identity is banned from publishing")
+
+// BanChecker decides whether the identity registry token claims were issued to is banned
+type BanChecker interface {
+	// CheckIdentityBan returns an error wrapping ErrIdentityBanned when claims belong to a banned identity
+	CheckIdentityBan(ctx context.Context, claims *JWTClaims) error
+}
+
+var (
+	bansMu     sync.RWMutex
+	banChecker BanChecker
+)
+
+// SetBanChecker makes every JWTManager refuse registry tokens to the identities checker reports as banned. The
+// registry sets its service at startup; until one is set, no identity is banned.
+func SetBanChecker(checker BanChecker) {
+	bansMu.Lock()
+	defer bansMu.Unlock()
+	banChecker = checker
+}
+
+// checkBan checks claims with the ban checker, if one is set
+func checkBan(ctx context.Context, claims *JWTClaims) error {
+	bansMu.RLock()
+	checker := banChecker
+	bansMu.RUnlock()
+	if checker == nil {
+		return nil
+	}
+	return checker.CheckIdentityBan(ctx, claims)
+}
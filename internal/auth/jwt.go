@@ -20,10 +20,13 @@ const (
 	PermissionActionPublish PermissionAction = "publish"
 	// PermissionActionEdit allows editing server configuration.
 	PermissionActionEdit PermissionAction = "edit"
+	// PermissionActionPreview allows publishing previews only, which are never listed and expire. Tokens for
+	// pull request jobs carry it instead of publish.
+	PermissionActionPreview PermissionAction = "preview"
 )
 
 type Permission struct {
-	Action          PermissionAction `json:"action"`   // The action type (publish or edit)
+	Action          PermissionAction `json:"action"`   // The action type (publish, edit or preview)
 	ResourcePattern string           `json:"resource"` // e.g., "io.github.username/*"
 }
 
@@ -34,6 +37,15 @@ type JWTClaims struct {
 	AuthMethod        Method       `json:"auth_method"`
 	AuthMethodSubject string       `json:"auth_method_sub"`
 	Permissions       []Permission `json:"permissions"`
+	// PullRequest is the pull request the token was issued for, as owner/repository#number, when it was
+	// exchanged from a GitHub Actions job running for one
+	PullRequest string `json:"pull_request,omitempty"`
+	// OwnerOf lists the organization namespaces the identity provider reports the subject as an owner of, such as
+	// the GitHub organizations a user is an admin of. Owners manage their organization's API keys and members.
+	OwnerOf []string `json:"owner_of,omitempty"`
+	// OAuthClient is the registered OAuth client the token was issued to, whose tokens only carry the
+	// namespace-scoped permissions of the person who logged in
+	OAuthClient string `json:"oauth_client,omitempty"`
 }
 
 type TokenResponse struct {
@@ -41,11 +53,17 @@ type TokenResponse struct {
 	ExpiresAt     int    `json:"expires_at"`
 }
 
+// defaultIssuer is the issuer of registry tokens when none is configured
+const defaultIssuer = "mcp-registry"
+
 // JWTManager handles JWT token operations
 type JWTManager struct {
 	privateKey    ed25519.PrivateKey
 	publicKey     ed25519.PublicKey
 	tokenDuration time.Duration
+	issuer        string
+	audience      string
+	clockSkew     time.Duration
 }
 
 func NewJWTManager(cfg *config.Config) *JWTManager {
@@ -63,15 +81,29 @@ func NewJWTManager(cfg *config.Config) *JWTManager {
 	privateKey := ed25519.NewKeyFromSeed(seed)
 	publicKey := privateKey.Public().(ed25519.PublicKey)
 
+	// Tokens are only accepted by deployments with the same issuer and audience, so a token minted by one
+	// registry cannot be replayed against another even if they share a signing key
+	issuer := cfg.JWTIssuer
+	if issuer == "" {
+		issuer = defaultIssuer
+	}
+	audience := cfg.JWTAudience
+	if audience == "" {
+		audience = issuer
+	}
+
 	return &JWTManager{
 		privateKey:    privateKey,
 		publicKey:     publicKey,
 		tokenDuration: 5 * time.Minute, // 5-minute tokens as per requirements
+		issuer:        issuer,
+		audience:      audience,
+		clockSkew:     cfg.JWTClockSkew,
 	}
 }
 
 // GenerateToken generates a new Registry JWT token
-func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims) (*TokenResponse, error) {
+func (j *JWTManager) GenerateTokenResponse(ctx context.Context, claims JWTClaims) (*TokenResponse, error) {
 	// Check whether they have global permissions (used by admins)
 	hasGlobalPermissions := false
 	for _, perm := range claims.Permissions {
@@ -88,6 +120,9 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 				return nil, fmt.Errorf("your namespace is blocked. raise an issue at https://github.com/modelcontextprotocol/registry/ if you think this is a mistake")
 			}
 		}
+		if err := checkBan(ctx, &claims); err != nil {
+			return nil, err
+		}
 	}
 
 	if claims.IssuedAt == nil {
@@ -99,9 +134,8 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 	if claims.NotBefore == nil {
 		claims.NotBefore = jwt.NewNumericDate(time.Now())
 	}
-	if claims.Issuer == "" {
-		claims.Issuer = "mcp-registry"
-	}
+	claims.Issuer = j.issuer
+	claims.Audience = jwt.ClaimStrings{j.audience}
 
 	// Create token with claims
 	token := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, claims)
@@ -121,13 +155,23 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 // ValidateToken validates a Registry JWT token and returns the claims
 func (j *JWTManager) ValidateToken(_ context.Context, tokenString string) (*JWTClaims, error) {
 	// Parse token
-	// This also validates expiry
+	// This also validates expiry, not-before, issued-at, issuer, and audience, allowing for clock skew
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&JWTClaims{},
-		func(_ *jwt.Token) (interface{}, error) { return j.publicKey, nil },
-		jwt.WithValidMethods([]string{"EdDSA"}),
+		func(token *jwt.Token) (interface{}, error) {
+			// Pin the algorithm to the key type, regardless of what the token header claims
+			if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return j.publicKey, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
 		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithIssuer(j.issuer),
+		jwt.WithAudience(j.audience),
+		jwt.WithLeeway(j.clockSkew),
 	)
 	// Validate token
 	if err != nil {
@@ -155,6 +199,16 @@ func (j *JWTManager) HasPermission(resource string, action PermissionAction, per
 	return false
 }
 
+// IsAdmin reports whether the permissions grant edit access to every server, which is what admin tokens carry
+func (j *JWTManager) IsAdmin(permissions []Permission) bool {
+	for _, perm := range permissions {
+		if perm.Action == PermissionActionEdit && perm.ResourcePattern == "*" {
+			return true
+		}
+	}
+	return false
+}
+
 func isResourceMatch(resource, pattern string) bool {
 	if pattern == "*" {
 		return true
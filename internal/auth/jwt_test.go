@@ -52,6 +52,7 @@ func TestJWTManager_GenerateAndVerifyToken(t *testing.T) {
 		assert.Equal(t, auth.MethodGitHubAT, verifiedClaims.AuthMethod)
 		assert.Equal(t, "testuser", verifiedClaims.AuthMethodSubject)
 		assert.Equal(t, "mcp-registry", verifiedClaims.Issuer)
+		assert.Equal(t, jwt.ClaimStrings{"mcp-registry"}, verifiedClaims.Audience)
 		assert.Len(t, verifiedClaims.Permissions, 1)
 		assert.Equal(t, auth.PermissionActionPublish, verifiedClaims.Permissions[0].Action)
 		assert.Equal(t, "io.github.testuser/*", verifiedClaims.Permissions[0].ResourcePattern)
@@ -67,7 +68,8 @@ func TestJWTManager_GenerateAndVerifyToken(t *testing.T) {
 				IssuedAt:  issuedAt,
 				ExpiresAt: expiresAt,
 				NotBefore: notBefore,
-				Issuer:    "custom-issuer",
+				// The issuer is always set by the manager
+				Issuer: "custom-issuer",
 			},
 			AuthMethod:        auth.MethodNone,
 			AuthMethodSubject: "anonymous",
@@ -88,7 +90,7 @@ func TestJWTManager_GenerateAndVerifyToken(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, auth.MethodNone, verifiedClaims.AuthMethod)
 		assert.Equal(t, "anonymous", verifiedClaims.AuthMethodSubject)
-		assert.Equal(t, "custom-issuer", verifiedClaims.Issuer)
+		assert.Equal(t, "mcp-registry", verifiedClaims.Issuer)
 		assert.Equal(t, issuedAt.Unix(), verifiedClaims.IssuedAt.Unix())
 		assert.Equal(t, expiresAt.Unix(), verifiedClaims.ExpiresAt.Unix())
 		assert.Equal(t, notBefore.Unix(), verifiedClaims.NotBefore.Unix())
@@ -180,6 +182,98 @@ func TestJWTManager_GenerateAndVerifyToken(t *testing.T) {
 	})
 }
 
+func TestJWTManager_IssuerAndAudience(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	key := hex.EncodeToString(testSeed)
+	ctx := context.Background()
+
+	staging := auth.NewJWTManager(&config.Config{JWTPrivateKey: key, JWTIssuer: "https://staging.registry.example.com"})
+	production := auth.NewJWTManager(&config.Config{
+		JWTPrivateKey: key,
+		JWTIssuer:     "https://registry.example.com",
+		JWTAudience:   "https://registry.example.com/v0",
+	})
+
+	tokenResponse, err := staging.GenerateTokenResponse(ctx, auth.JWTClaims{AuthMethod: auth.MethodNone})
+	require.NoError(t, err)
+
+	claims, err := staging.ValidateToken(ctx, tokenResponse.RegistryToken)
+	require.NoError(t, err)
+	assert.Equal(t, "https://staging.registry.example.com", claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{"https://staging.registry.example.com"}, claims.Audience)
+
+	// A token from one deployment is rejected by another, even with the same signing key
+	_, err = production.ValidateToken(ctx, tokenResponse.RegistryToken)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+
+	sameIssuer := auth.NewJWTManager(&config.Config{JWTPrivateKey: key, JWTIssuer: "https://staging.registry.example.com", JWTAudience: "other"})
+	_, err = sameIssuer.ValidateToken(ctx, tokenResponse.RegistryToken)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+}
+
+func TestJWTManager_ClockSkew(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	jwtManager := auth.NewJWTManager(&config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), JWTClockSkew: time.Minute})
+
+	// Tokens from a clock slightly ahead or behind are accepted within the skew
+	tokenResponse, err := jwtManager.GenerateTokenResponse(ctx, auth.JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(30 * time.Second)),
+			NotBefore: jwt.NewNumericDate(time.Now().Add(30 * time.Second)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-30 * time.Second)),
+		},
+	})
+	require.NoError(t, err)
+	_, err = jwtManager.ValidateToken(ctx, tokenResponse.RegistryToken)
+	require.NoError(t, err)
+
+	// But not beyond it
+	tokenResponse, err = jwtManager.GenerateTokenResponse(ctx, auth.JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt: jwt.NewNumericDate(time.Now().Add(5 * time.Minute)),
+		},
+	})
+	require.NoError(t, err)
+	_, err = jwtManager.ValidateToken(ctx, tokenResponse.RegistryToken)
+	assert.ErrorIs(t, err, jwt.ErrTokenUsedBeforeIssued)
+}
+
+func TestJWTManager_RejectsOtherAlgorithms(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	jwtManager := auth.NewJWTManager(&config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)})
+
+	claims := auth.JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "mcp-registry",
+			Audience:  jwt.ClaimStrings{"mcp-registry"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+	for _, token := range []*jwt.Token{
+		jwt.NewWithClaims(jwt.SigningMethodHS256, claims),
+		jwt.NewWithClaims(jwt.SigningMethodNone, claims),
+	} {
+		var key any = []byte("secret")
+		if token.Method == jwt.SigningMethodNone {
+			key = jwt.UnsafeAllowNoneSignatureType
+		}
+		tokenString, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		_, err = jwtManager.ValidateToken(context.Background(), tokenString)
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid, token.Method.Alg())
+	}
+}
+
 func TestJWTManager_HasPermission(t *testing.T) {
 	// Generate a proper Ed25519 seed for testing
 	testSeed := make([]byte, ed25519.SeedSize)
@@ -272,6 +366,26 @@ func TestJWTManager_HasPermission(t *testing.T) {
 	}
 }
 
+func TestJWTManager_IsAdmin(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	jwtManager := auth.NewJWTManager(&config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)})
+
+	assert.True(t, jwtManager.IsAdmin([]auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+	}))
+	assert.False(t, jwtManager.IsAdmin([]auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+	}))
+	assert.False(t, jwtManager.IsAdmin([]auth.Permission{
+		{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.testuser/*"},
+	}))
+	assert.False(t, jwtManager.IsAdmin(nil))
+}
+
 func TestNewJWTManager_InvalidKeySize(t *testing.T) {
 	// Test with invalid key size (should panic)
 	cfg := &config.Config{
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AuthLevel is what a request must prove to use a route
+type AuthLevel string
+
+const (
+	// AuthLevelAnonymous needs no token; requests without one act as the anonymous identity
+	AuthLevelAnonymous AuthLevel = "anonymous"
+	// AuthLevelAuthenticated needs any valid registry JWT
+	AuthLevelAuthenticated AuthLevel = "authenticated"
+	// AuthLevelPublish needs publish permission for the server the request acts on
+	AuthLevelPublish AuthLevel = "publish"
+	// AuthLevelEdit needs edit permission for the server the request acts on
+	AuthLevelEdit AuthLevel = "edit"
+	// AuthLevelOwner needs publish or edit permission for the server the request acts on
+	AuthLevelOwner AuthLevel = "owner"
+	// AuthLevelAdmin needs admin permissions
+	AuthLevelAdmin AuthLevel = "admin"
+)
+
+// AuthLevels are the valid authentication levels
+var AuthLevels = []AuthLevel{AuthLevelAnonymous, AuthLevelAuthenticated, AuthLevelPublish, AuthLevelEdit, AuthLevelOwner, AuthLevelAdmin}
+
+// RoutePolicy maps routes to the authentication level they require. Routes are "METHOD /path" with the
+// path relative to the API version, such as "POST /publish" or "PUT /servers/{serverName}/versions/{version}".
+// The method may be * for any method, and a path ending in /* covers every path beneath it. Exact paths take
+// precedence over wildcards, longer wildcards over shorter ones, and specific methods over *.
+type RoutePolicy map[string]AuthLevel
+
+// DefaultRoutePolicy returns the routes that require authentication by default. Routes it does not list are
+// read anonymously or authorize requests themselves, such as organization API keys and namespace stats.
+func DefaultRoutePolicy() RoutePolicy {
+	return RoutePolicy{
+		"POST /publish":                                         AuthLevelPublish,
+		"POST /publish/async":                                   AuthLevelPublish,
+		"GET /publish/async/{id}":                               AuthLevelAuthenticated,
+		"PUT /servers/{serverName}/versions/{version}":          AuthLevelEdit,
+		"PATCH /servers/{serverName}/versions/{version}/status": AuthLevelOwner,
+		"PATCH /servers/{serverName}/status":                    AuthLevelOwner,
+		"POST /servers/{serverName}/rename":                     AuthLevelOwner,
+		"GET /me/publishes":                                     AuthLevelAuthenticated,
+		"GET /me/publishes/{id}":                                AuthLevelAuthenticated,
+		"GET /me/limits":                                        AuthLevelAuthenticated,
+		"POST /collections":                                     AuthLevelAuthenticated,
+		"PUT /collections/{id}":                                 AuthLevelAuthenticated,
+		"DELETE /collections/{id}":                              AuthLevelAuthenticated,
+		"* /admin/*":                                            AuthLevelAdmin,
+	}
+}
+
+// LoadRoutePolicy reads a YAML file mapping routes to levels and applies it over the default policy.
+// An empty path returns the default policy.
+func LoadRoutePolicy(path string) (RoutePolicy, error) {
+	policy := DefaultRoutePolicy()
+	if path == "" {
+		return policy, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read route policy: %w", err)
+	}
+	var overrides map[string]AuthLevel
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse route policy %s: %w", path, err)
+	}
+
+	for route, level := range overrides {
+		method, routePath, ok := strings.Cut(route, " ")
+		if !ok || !strings.HasPrefix(routePath, "/") || !isPolicyMethod(method) {
+			return nil, fmt.Errorf("invalid route %q in route policy %s: expected \"METHOD /path\"", route, path)
+		}
+		if !slices.Contains(AuthLevels, level) {
+			return nil, fmt.Errorf("invalid level %q for route %q in route policy %s", level, route, path)
+		}
+		policy[method+" "+routePath] = level
+	}
+	return policy, nil
+}
+
+// Level returns the level required by the route with method and path, a route path relative to the API
+// version, and whether the policy covers the route at all
+func (p RoutePolicy) Level(method, path string) (AuthLevel, bool) {
+	for _, m := range []string{method, "*"} {
+		if level, found := p[m+" "+path]; found {
+			return level, true
+		}
+	}
+
+	var level AuthLevel
+	longest := -1
+	for route, candidate := range p {
+		m, prefix, _ := strings.Cut(route, " ")
+		prefix, wildcard := strings.CutSuffix(prefix, "*")
+		if !wildcard || (m != method && m != "*") || !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		// Among equally long wildcards, one naming the method wins
+		if len(prefix) > longest || (len(prefix) == longest && m != "*") {
+			level, longest = candidate, len(prefix)
+		}
+	}
+	return level, longest >= 0
+}
+
+func isPolicyMethod(method string) bool {
+	switch method {
+	case "*", http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
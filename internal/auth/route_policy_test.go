@@ -0,0 +1,88 @@
+package auth_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+)
+
+func TestRoutePolicy_Level(t *testing.T) {
+	policy := auth.RoutePolicy{
+		"POST /publish":          auth.AuthLevelPublish,
+		"* /admin/*":             auth.AuthLevelAdmin,
+		"GET /admin/*":           auth.AuthLevelAuthenticated,
+		"* /admin/audit/*":       auth.AuthLevelOwner,
+		"* /admin/maintenance":   auth.AuthLevelAnonymous,
+		"PUT /admin/maintenance": auth.AuthLevelAdmin,
+	}
+
+	tests := []struct {
+		method, path string
+		level        auth.AuthLevel
+		found        bool
+	}{
+		{"POST", "/publish", auth.AuthLevelPublish, true},
+		{"GET", "/publish", "", false},
+		{"GET", "/servers", "", false},
+		{"POST", "/admin/webhooks", auth.AuthLevelAdmin, true},
+		{"GET", "/admin/webhooks", auth.AuthLevelAuthenticated, true},
+		{"GET", "/admin/audit/export", auth.AuthLevelOwner, true},
+		{"GET", "/admin/maintenance", auth.AuthLevelAnonymous, true},
+		{"PUT", "/admin/maintenance", auth.AuthLevelAdmin, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			level, found := policy.Level(tt.method, tt.path)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.level, level)
+		})
+	}
+}
+
+func TestLoadRoutePolicy(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "routes.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("empty path uses the default policy", func(t *testing.T) {
+		policy, err := auth.LoadRoutePolicy("")
+		require.NoError(t, err)
+		assert.Equal(t, auth.DefaultRoutePolicy(), policy)
+	})
+
+	t.Run("file overrides the default policy", func(t *testing.T) {
+		policy, err := auth.LoadRoutePolicy(write(t, "POST /publish: anonymous\nPUT /servers/{serverName}/versions/{version}: admin\n"))
+		require.NoError(t, err)
+
+		level, _ := policy.Level("POST", "/publish")
+		assert.Equal(t, auth.AuthLevelAnonymous, level)
+		level, _ = policy.Level("PUT", "/servers/{serverName}/versions/{version}")
+		assert.Equal(t, auth.AuthLevelAdmin, level)
+		level, _ = policy.Level("DELETE", "/admin/webhooks/{id}")
+		assert.Equal(t, auth.AuthLevelAdmin, level)
+	})
+
+	for name, content := range map[string]string{
+		"unknown level":  "POST /publish: moderator\n",
+		"missing method": "/publish: publish\n",
+		"unknown method": "FETCH /publish: publish\n",
+		"relative path":  "POST publish: publish\n",
+		"not a mapping":  "- POST /publish\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := auth.LoadRoutePolicy(write(t, content))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := auth.LoadRoutePolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
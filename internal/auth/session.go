@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Browser sessions keep a registry JWT in a cookie. The __Host- prefix makes browsers reject the cookie
+// unless it is secure, host-only and scoped to the whole site, so other subdomains cannot set it.
+const (
+	// SessionCookieName is the cookie holding the registry JWT of a browser session
+	SessionCookieName = "__Host-mcp-registry-session"
+	// CSRFHeader carries the session's CSRF token on requests that change state
+	CSRFHeader = "X-CSRF-Token"
+)
+
+// GenerateSessionToken issues a registry JWT for a browser session lasting duration. The token's ID is
+// the session's CSRF token, which pages read from GET /auth/browser/session and send in CSRFHeader.
+func (j *JWTManager) GenerateSessionToken(ctx context.Context, claims JWTClaims, duration time.Duration) (*TokenResponse, error) {
+	claims.ID = rand.Text()
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(duration))
+	response, err := j.GenerateTokenResponse(ctx, claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session token: %w", err)
+	}
+	return response, nil
+}
+
+// OAuthConsentPath is where people approve or deny a registered OAuth client's request to act for them. Its form
+// is protected by a consent cookie of its own instead of the session's CSRF token.
+const OAuthConsentPath = "/oauth/consent"
+
+// ValidCSRFToken reports whether token is the CSRF token of the session claims belong to
+func ValidCSRFToken(claims *JWTClaims, token string) bool {
+	return claims.ID != "" && subtle.ConstantTimeCompare([]byte(claims.ID), []byte(token)) == 1
+}
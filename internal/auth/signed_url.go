@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// Query parameters carrying a signed URL's expiry and signature
+const (
+	SignedURLExpiresParam   = "expires"
+	SignedURLSignatureParam = "signature"
+)
+
+// Signed URL errors
+var (
+	ErrSignedURLInvalid = errors.New("invalid URL signature")
+	ErrSignedURLExpired = errors.New("signed URL has expired")
+)
+
+// URLSigner creates and verifies time-limited read URLs. The signature is an HMAC-SHA256 of the URL
+// path and expiry, so query parameters such as search or cursor can change without re-signing.
+type URLSigner struct {
+	key []byte
+}
+
+// NewURLSigner creates a signer from the registry configuration, or returns nil when no signing key is set
+func NewURLSigner(cfg *config.Config) *URLSigner {
+	if cfg.ReadURLSigningKey == "" {
+		return nil
+	}
+	return &URLSigner{key: []byte(cfg.ReadURLSigningKey)}
+}
+
+// Sign returns path with the expiry and signature query parameters appended
+func (s *URLSigner) Sign(path string, expires time.Time) string {
+	expiresUnix := strconv.FormatInt(expires.Unix(), 10)
+	query := url.Values{
+		SignedURLExpiresParam:   {expiresUnix},
+		SignedURLSignatureParam: {s.signature(path, expiresUnix)},
+	}
+	return path + "?" + query.Encode()
+}
+
+// Verify checks the signature and expiry in query against path
+func (s *URLSigner) Verify(path string, query url.Values, now time.Time) error {
+	expiresUnix := query.Get(SignedURLExpiresParam)
+	signature, err := hex.DecodeString(query.Get(SignedURLSignatureParam))
+	if err != nil || expiresUnix == "" {
+		return ErrSignedURLInvalid
+	}
+
+	expected, _ := hex.DecodeString(s.signature(path, expiresUnix))
+	if !hmac.Equal(signature, expected) {
+		return ErrSignedURLInvalid
+	}
+
+	expires, err := strconv.ParseInt(expiresUnix, 10, 64)
+	if err != nil {
+		return ErrSignedURLInvalid
+	}
+	if now.After(time.Unix(expires, 0)) {
+		return ErrSignedURLExpired
+	}
+	return nil
+}
+
+func (s *URLSigner) signature(path, expiresUnix string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(path + "\n" + expiresUnix))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsReadPath reports whether path is a read endpoint that is private when read authentication is
+// required, and so can be shared with a signed URL: server and namespace listings, server details and changes,
+// stored server.json documents, and stats.
+func IsReadPath(path string) bool {
+	rest, found := strings.CutPrefix(path, "/v0.1")
+	if !found {
+		if rest, found = strings.CutPrefix(path, "/v0"); !found {
+			return false
+		}
+	}
+	return rest == "/servers" || strings.HasPrefix(rest, "/servers/") || strings.HasPrefix(rest, "/documents/") ||
+		rest == "/namespaces" || strings.HasPrefix(rest, "/namespaces/") || rest == "/stats"
+}
@@ -1,5 +1,10 @@
 package auth
 
+import "errors"
+
+// ErrNamespacePendingReview is returned when domain ownership was proven but an admin has not approved the namespace yet
+var ErrNamespacePendingReview = errors.New("namespace is pending admin review")
+
 // Method represents the authentication method used
 type Method string
 
@@ -14,6 +19,8 @@ const (
 	MethodDNS Method = "dns"
 	// HTTP-based public/private key authentication
 	MethodHTTP Method = "http"
+	// Organization API key exchanged by publishing automation
+	MethodAPIKey Method = "api-key"
 	// No authentication - should only be used for local development and testing
 	MethodNone Method = "none"
 )
@@ -0,0 +1,94 @@
+// Package cdn contains helpers for running the registry behind a caching CDN:
+// surrogate keys for tagging cacheable responses and purgers that invalidate
+// those keys when server data changes.
+package cdn
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// ListKey tags every paginated server listing response
+const ListKey = "servers"
+
+// AnnouncementsKey tags every response listing operator announcements
+const AnnouncementsKey = "announcements"
+
+// ServerKey returns the surrogate key for all responses about a single server
+func ServerKey(serverName string) string {
+	return "server:" + serverName
+}
+
+// NamespaceKey returns the surrogate key shared by all servers in the namespace of serverName
+func NamespaceKey(serverName string) string {
+	namespace, _, _ := strings.Cut(serverName, "/")
+	return "namespace:" + namespace
+}
+
+// KeysForServer returns the surrogate keys attached to responses about a single server
+func KeysForServer(serverName string) []string {
+	return []string{ServerKey(serverName), NamespaceKey(serverName)}
+}
+
+// SurrogateKeyHeader formats keys for the Surrogate-Key header (space separated, used by Fastly)
+func SurrogateKeyHeader(keys []string) string {
+	return strings.Join(keys, " ")
+}
+
+// CacheTagHeader formats keys for the Cache-Tag header (comma separated, used by Cloudflare)
+func CacheTagHeader(keys []string) string {
+	return strings.Join(keys, ",")
+}
+
+// Purger invalidates cached responses tagged with the given surrogate keys
+type Purger interface {
+	Purge(ctx context.Context, keys []string) error
+}
+
+// NewPurger creates a purger for every CDN configured in cfg.
+// If no CDN is configured, the returned purger does nothing.
+func NewPurger(cfg *config.Config) Purger {
+	if cfg == nil {
+		return noopPurger{}
+	}
+
+	var purgers multiPurger
+	if cfg.FastlyAPIToken != "" && cfg.FastlyServiceID != "" {
+		purgers = append(purgers, NewFastlyPurger(cfg.FastlyAPIToken, cfg.FastlyServiceID))
+	}
+	if cfg.CloudflareAPIToken != "" && cfg.CloudflareZoneID != "" {
+		purgers = append(purgers, NewCloudflarePurger(cfg.CloudflareAPIToken, cfg.CloudflareZoneID))
+	}
+
+	if len(purgers) == 0 {
+		return noopPurger{}
+	}
+	return purgers
+}
+
+type noopPurger struct{}
+
+func (noopPurger) Purge(context.Context, []string) error {
+	return nil
+}
+
+// multiPurger fans a purge out to several CDNs in parallel, attempting all of them even if one fails
+type multiPurger []Purger
+
+func (m multiPurger) Purge(ctx context.Context, keys []string) error {
+	errs := make([]error, len(m))
+	var wg sync.WaitGroup
+	for i, p := range m {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = p.Purge(ctx, keys)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,117 @@
+package cdn_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestKeysForServer(t *testing.T) {
+	keys := cdn.KeysForServer("io.github.example/weather")
+	assert.Equal(t, []string{"server:io.github.example/weather", "namespace:io.github.example"}, keys)
+
+	assert.Equal(t, "server:io.github.example/weather namespace:io.github.example", cdn.SurrogateKeyHeader(keys))
+	assert.Equal(t, "server:io.github.example/weather,namespace:io.github.example", cdn.CacheTagHeader(keys))
+}
+
+func TestNewPurger_NotConfigured(t *testing.T) {
+	purger := cdn.NewPurger(&config.Config{})
+	require.NoError(t, purger.Purge(context.Background(), []string{"servers"}))
+
+	purger = cdn.NewPurger(nil)
+	require.NoError(t, purger.Purge(context.Background(), []string{"servers"}))
+}
+
+func TestFastlyPurger(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		expectError bool
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "rejected token", status: http.StatusUnauthorized, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath, gotKey, gotKeys string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				gotKey = r.Header.Get("Fastly-Key")
+				gotKeys = r.Header.Get("Surrogate-Key")
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			purger := cdn.NewFastlyPurger("token", "svc123")
+			purger.SetBaseURL(server.URL)
+
+			err := purger.Purge(context.Background(), []string{"server:com.example/a", "servers"})
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "/service/svc123/purge", gotPath)
+			assert.Equal(t, "token", gotKey)
+			assert.Equal(t, "server:com.example/a servers", gotKeys)
+		})
+	}
+}
+
+func TestCloudflarePurger(t *testing.T) {
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/zone1/purge_cache", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		var body struct {
+			Tags []string `json:"tags"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		batches = append(batches, body.Tags)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"errors":[]}`))
+	}))
+	defer server.Close()
+
+	purger := cdn.NewCloudflarePurger("token", "zone1")
+	purger.SetBaseURL(server.URL)
+
+	// More tags than fit in a single request get split into batches
+	keys := make([]string, 45)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("server:com.example/s%d", i)
+	}
+
+	require.NoError(t, purger.Purge(context.Background(), keys))
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0], 30)
+	assert.Len(t, batches[1], 15)
+}
+
+func TestCloudflarePurger_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":1012,"message":"invalid tag"}]}`))
+	}))
+	defer server.Close()
+
+	purger := cdn.NewCloudflarePurger("token", "zone1")
+	purger.SetBaseURL(server.URL)
+
+	err := purger.Purge(context.Background(), []string{"servers"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid tag")
+}
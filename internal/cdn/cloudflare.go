@@ -0,0 +1,97 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const cloudflareAPIURL = "https://api.cloudflare.com/client/v4"
+
+// cloudflareMaxTagsPerRequest is the maximum number of cache tags Cloudflare accepts in one purge call
+const cloudflareMaxTagsPerRequest = 30
+
+// CloudflarePurger purges cache tags using the Cloudflare purge_cache API
+type CloudflarePurger struct {
+	apiToken   string
+	zoneID     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewCloudflarePurger creates a new Cloudflare purger for the given zone
+func NewCloudflarePurger(apiToken, zoneID string) *CloudflarePurger {
+	return &CloudflarePurger{
+		apiToken:   apiToken,
+		zoneID:     zoneID,
+		baseURL:    cloudflareAPIURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SetBaseURL sets the base URL for the Cloudflare API (used for testing)
+func (p *CloudflarePurger) SetBaseURL(baseURL string) {
+	p.baseURL = baseURL
+}
+
+type cloudflarePurgeRequest struct {
+	Tags []string `json:"tags"`
+}
+
+type cloudflarePurgeResponse struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Purge purges all objects tagged with any of the keys
+func (p *CloudflarePurger) Purge(ctx context.Context, keys []string) error {
+	for start := 0; start < len(keys); start += cloudflareMaxTagsPerRequest {
+		end := min(start+cloudflareMaxTagsPerRequest, len(keys))
+		if err := p.purgeBatch(ctx, keys[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *CloudflarePurger) purgeBatch(ctx context.Context, tags []string) error {
+	body, err := json.Marshal(cloudflarePurgeRequest{Tags: tags})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Cloudflare purge request: %w", err)
+	}
+
+	requestURL := p.baseURL + "/zones/" + url.PathEscape(p.zoneID) + "/purge_cache"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Cloudflare purge request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Cloudflare purge request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var purgeResp cloudflarePurgeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&purgeResp); err != nil {
+		return fmt.Errorf("failed to parse Cloudflare purge response (status %d): %w", resp.StatusCode, err)
+	}
+
+	if resp.StatusCode != http.StatusOK || !purgeResp.Success {
+		if len(purgeResp.Errors) > 0 {
+			return fmt.Errorf("cloudflare purge failed with status %d: %s", resp.StatusCode, purgeResp.Errors[0].Message)
+		}
+		return fmt.Errorf("cloudflare purge failed with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const fastlyAPIURL = "https://api.fastly.com"
+
+// FastlyPurger purges surrogate keys using the Fastly purge API
+type FastlyPurger struct {
+	apiToken   string
+	serviceID  string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewFastlyPurger creates a new Fastly purger for the given service
+func NewFastlyPurger(apiToken, serviceID string) *FastlyPurger {
+	return &FastlyPurger{
+		apiToken:   apiToken,
+		serviceID:  serviceID,
+		baseURL:    fastlyAPIURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SetBaseURL sets the base URL for the Fastly API (used for testing)
+func (p *FastlyPurger) SetBaseURL(baseURL string) {
+	p.baseURL = baseURL
+}
+
+// Purge soft-purges all objects tagged with any of the keys
+func (p *FastlyPurger) Purge(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	requestURL := p.baseURL + "/service/" + url.PathEscape(p.serviceID) + "/purge"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create Fastly purge request: %w", err)
+	}
+	req.Header.Set("Fastly-Key", p.apiToken)
+	req.Header.Set("Surrogate-Key", SurrogateKeyHeader(keys))
+	// Soft purge marks content stale rather than evicting it, so the CDN can still serve it if the origin is down
+	req.Header.Set("Fastly-Soft-Purge", "1")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Fastly purge request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fastly purge failed with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package cdn
+
+import (
+	"context"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// QueuedPurger purges keys in the background, so writes don't wait on CDN APIs. Keys queued while a purge is
+// running are merged into the next one, so bursts of writes, such as a ban quarantining many servers, cost a
+// single purge per CDN.
+type QueuedPurger struct {
+	purger  Purger
+	timeout time.Duration
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+	wake    chan struct{}
+	start   sync.Once
+}
+
+// NewQueuedPurger creates a queue that purges keys through purger, giving each purge up to timeout
+func NewQueuedPurger(purger Purger, timeout time.Duration) *QueuedPurger {
+	return &QueuedPurger{
+		purger:  purger,
+		timeout: timeout,
+		pending: map[string]struct{}{},
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Enqueue schedules a purge of keys and returns without waiting for it. Failed purges are logged, since
+// the writes that queued them have already committed.
+func (q *QueuedPurger) Enqueue(keys ...string) {
+	if _, ok := q.purger.(noopPurger); ok || len(keys) == 0 {
+		return
+	}
+
+	q.mu.Lock()
+	for _, key := range keys {
+		q.pending[key] = struct{}{}
+	}
+	q.mu.Unlock()
+
+	q.start.Do(func() { go q.run() })
+	select {
+	case q.wake <- struct{}{}:
+	default:
+		// A purge is already due and will pick up these keys
+	}
+}
+
+func (q *QueuedPurger) run() {
+	for range q.wake {
+		q.mu.Lock()
+		keys := slices.Sorted(maps.Keys(q.pending))
+		clear(q.pending)
+		q.mu.Unlock()
+		if len(keys) == 0 {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), q.timeout)
+		if err := q.purger.Purge(ctx, keys); err != nil {
+			log.Printf("failed to purge CDN cache for %s: %v", strings.Join(keys, " "), err)
+		}
+		cancel()
+	}
+}
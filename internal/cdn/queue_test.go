@@ -0,0 +1,51 @@
+package cdn_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+)
+
+// blockingPurger reports each purge and waits until it is released
+type blockingPurger struct {
+	purged  chan []string
+	release chan struct{}
+}
+
+func (p *blockingPurger) Purge(_ context.Context, keys []string) error {
+	p.purged <- keys
+	<-p.release
+	return nil
+}
+
+func TestQueuedPurger(t *testing.T) {
+	purger := &blockingPurger{purged: make(chan []string, 10), release: make(chan struct{})}
+	queue := cdn.NewQueuedPurger(purger, time.Minute)
+
+	// Enqueue returns while the purge is still running
+	queue.Enqueue("server:a", "servers")
+	assert.Equal(t, []string{"server:a", "servers"}, receive(t, purger.purged))
+
+	// Keys queued meanwhile are merged into a single purge
+	queue.Enqueue("server:b", "servers")
+	queue.Enqueue("server:c", "servers")
+	purger.release <- struct{}{}
+	assert.Equal(t, []string{"server:b", "server:c", "servers"}, receive(t, purger.purged))
+	purger.release <- struct{}{}
+}
+
+func receive(t *testing.T, purged <-chan []string) []string {
+	t.Helper()
+	select {
+	case keys := <-purged:
+		return keys
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for a purge")
+		return nil
+	}
+}
@@ -1,39 +1,244 @@
 package config
 
 import (
+	"os"
+	"time"
+
 	env "github.com/caarlos0/env/v11"
 )
 
 // Config holds the application configuration
 // See .env.example for more documentation
+//
+// Each field is set by its MCP_REGISTRY_-prefixed env variable or by its key in the YAML configuration file.
+// The enum, format and minimum tags are checked by Validate, and together with doc they describe the
+// field in the JSON Schema returned by Schema. Fields with structured values, such as PathDurations, are
+// checked and described by their type.
 type Config struct {
-	ServerAddress            string `env:"SERVER_ADDRESS" envDefault:":8080"`
-	DatabaseURL              string `env:"DATABASE_URL" envDefault:"postgres://localhost:5432/mcp-registry?sslmode=disable"`
-	SeedFrom                 string `env:"SEED_FROM" envDefault:""`
-	Version                  string `env:"VERSION" envDefault:"dev"`
-	GithubClientID           string `env:"GITHUB_CLIENT_ID" envDefault:""`
-	GithubClientSecret       string `env:"GITHUB_CLIENT_SECRET" envDefault:""`
-	JWTPrivateKey            string `env:"JWT_PRIVATE_KEY" envDefault:""`
-	EnableAnonymousAuth      bool   `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
-	EnableRegistryValidation bool   `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
+	ServerAddress            string        `env:"SERVER_ADDRESS" envDefault:":8080" key:"server.address" doc:"Address the HTTP server listens on"`
+	ServerAddresses          []string      `env:"SERVER_ADDRESSES" envSeparator:"," key:"server.addresses" doc:"Addresses the HTTP server listens on, replacing server.address: host:port, https://host:port?cert=FILE&key=FILE or unix:PATH"`
+	PublicURL                string        `env:"PUBLIC_URL" envDefault:"" key:"server.public_url" format:"uri" doc:"Public base URL of the registry, used for absolute links in server cards and as the source of CloudEvents (default: links are relative)"`
+	TrustedProxies           []string      `env:"TRUSTED_PROXIES" envSeparator:"," key:"server.trusted_proxies" format:"cidr" doc:"CIDRs or addresses of load balancers and proxies whose Forwarded and X-Forwarded-For headers are trusted"`
+	RequestTimeout           time.Duration `env:"REQUEST_TIMEOUT" envDefault:"30s" key:"server.request_timeout" doc:"How long a request may run before it starts its response; its database queries are then cancelled (0 disables)"`
+	RequestTimeouts          PathDurations `env:"REQUEST_TIMEOUTS" key:"server.request_timeouts" doc:"Request timeouts by path prefix overriding server.request_timeout, 0 for none; the longest matching prefix wins"`
+	DatabaseURL              string        `env:"DATABASE_URL" envDefault:"postgres://localhost:5432/mcp-registry?sslmode=disable" key:"database.url" doc:"PostgreSQL connection URL, or memory:// to keep data in memory"`
+	ShadowDatabaseURL        string        `env:"SHADOW_DATABASE_URL" envDefault:"" key:"database.shadow_url" doc:"PostgreSQL connection URL, or memory://, of a copy of the database to repeat every write on, to try a new database before switching to it"`
+	ReadOnly                 bool          `env:"READ_ONLY" envDefault:"false" key:"server.read_only" doc:"Serve reads only, from a follower database: write endpoints are rejected, migrations are not run and background jobs do not start"`
+	PrimaryURL               string        `env:"PRIMARY_URL" envDefault:"" key:"server.primary_url" format:"uri" doc:"Base URL of the primary registry, advertised by read-only registries for writes"`
+	SeedFrom                 string        `env:"SEED_FROM" envDefault:"" key:"seed.from" doc:"Comma-separated paths or URLs of seed data to import at startup, oci://<reference> of a snapshot pushed with registry push-oci, or smithery: and glama: for third-party directories"`
+	SeedEmbedded             bool          `env:"SEED_EMBEDDED" envDefault:"true" key:"seed.embedded" doc:"Import the demo snapshot built into the registry at startup when the database is empty and seed.from is not set"`
+	SeedPolicy               string        `env:"SEED_POLICY" envDefault:"lenient" key:"seed.policy" enum:"strict,lenient,repair" doc:"How invalid seed records are handled: abort the import, skip them, or repair them where safe"`
+	SmitheryAPIKey           string        `env:"SMITHERY_API_KEY" envDefault:"" key:"seed.smithery_api_key" doc:"API key for reading the Smithery registry when seeding from smithery:"`
+	ReplicateFrom            string        `env:"REPLICATE_FROM" envDefault:"" key:"replication.from" format:"uri" doc:"Base URL of a registry whose changes feed is replicated"`
+	ReplicateInterval        time.Duration `env:"REPLICATE_INTERVAL" envDefault:"1m" key:"replication.interval" doc:"How often the remote changes feed is polled"`
+	Version                  string        `env:"VERSION" envDefault:"dev" key:"server.version" doc:"Version reported by the registry"`
+	GithubClientID           string        `env:"GITHUB_CLIENT_ID" envDefault:"" key:"github.client_id" doc:"GitHub OAuth app client ID"`
+	GithubClientSecret       string        `env:"GITHUB_CLIENT_SECRET" envDefault:"" key:"github.client_secret" doc:"GitHub OAuth app client secret"`
+	JWTPrivateKey            string        `env:"JWT_PRIVATE_KEY" envDefault:"" key:"jwt.private_key" doc:"Hex-encoded 32-byte Ed25519 seed used to sign registry tokens"`
+	JWTIssuer                string        `env:"JWT_ISSUER" envDefault:"mcp-registry" key:"jwt.issuer" doc:"Issuer (iss) claim of registry tokens"`
+	JWTAudience              string        `env:"JWT_AUDIENCE" envDefault:"" key:"jwt.audience" doc:"Audience (aud) claim of registry tokens (default: the issuer)"`
+	JWTClockSkew             time.Duration `env:"JWT_CLOCK_SKEW" envDefault:"30s" key:"jwt.clock_skew" doc:"Tolerance for clock differences when checking token times"`
+	EnableAnonymousAuth      bool          `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false" key:"auth.anonymous" doc:"Issue tokens for the anonymous namespace to anyone (development only)"`
+	AuthProviders            []string      `env:"AUTH_PROVIDERS" envSeparator:"," key:"auth.providers" doc:"Names of the authentication providers to enable (default: all registered providers)"`
+	EnableRegistryValidation bool          `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true" key:"validation.registry" doc:"Check that packages exist in their package registries and belong to the server"`
+	EnableLinkCheck          bool          `env:"ENABLE_LINK_CHECK" envDefault:"false" key:"validation.link_check" doc:"Reject servers whose website, documentation, support or security links return an error status"`
+	NamespaceReviewRequired  bool          `env:"NAMESPACE_REVIEW_REQUIRED" envDefault:"false" key:"auth.namespace_review_required" doc:"Require admin approval of each domain before DNS or HTTP authentication issues tokens"`
+	RoutePolicyFile          string        `env:"ROUTE_POLICY_FILE" envDefault:"" key:"auth.route_policy_file" doc:"YAML file mapping routes to the authentication they require, applied over the default matrix"`
+	TokenReplayStore         string        `env:"TOKEN_REPLAY_STORE" envDefault:"none" key:"auth.token_replay_store" enum:"none,memory,database" doc:"Where exchanged GitHub OIDC and OIDC tokens are remembered until they expire, so exchanging one again is rejected: none, memory (per replica) or database (shared by all replicas)"`
+
+	// Upstream registry metadata cache, shared by all replicas through the database (zero disables caching)
+	UpstreamCacheTTL         time.Duration `env:"UPSTREAM_CACHE_TTL" envDefault:"10m" key:"validation.upstream_cache_ttl" doc:"How long successful package registry lookups are cached"`
+	UpstreamCacheNegativeTTL time.Duration `env:"UPSTREAM_CACHE_NEGATIVE_TTL" envDefault:"1m" key:"validation.upstream_cache_negative_ttl" doc:"How long package registry lookups that found nothing are cached"`
+
+	// Private registries: reads require a registry JWT or a time-limited signed URL
+	RequireReadAuth   bool          `env:"REQUIRE_READ_AUTH" envDefault:"false" key:"read_auth.required" doc:"Require a registry JWT or signed URL to read servers and stats"`
+	ReadURLSigningKey string        `env:"READ_URL_SIGNING_KEY" envDefault:"" key:"read_auth.url_signing_key" doc:"Secret used to sign read URLs; empty disables signed URLs"`
+	ReadURLMaxTTL     time.Duration `env:"READ_URL_MAX_TTL" envDefault:"24h" key:"read_auth.url_max_ttl" doc:"Longest lifetime of a signed read URL"`
+
+	// Artifact proxy: package artifacts are mirrored at publish time and downloaded through signed URLs
+	ArtifactStorageDir string        `env:"ARTIFACT_STORAGE_DIR" envDefault:"" key:"artifacts.storage_dir" doc:"Directory the artifacts of published npm, PyPI, MCPB and OCI packages are mirrored into; empty disables the artifact proxy, which requires read_auth.url_signing_key"`
+	ArtifactMaxBytes   int64         `env:"ARTIFACT_MAX_BYTES" envDefault:"1073741824" key:"artifacts.max_bytes" minimum:"1" doc:"Largest artifact that is mirrored, in bytes"`
+	ArtifactURLTTL     time.Duration `env:"ARTIFACT_URL_TTL" envDefault:"1h" key:"artifacts.url_ttl" doc:"Lifetime of signed artifact download URLs"`
+
+	// GitHub repository re-verification for io.github.* servers on publish and edit
+	GitHubRepoVerification bool   `env:"GITHUB_REPO_VERIFICATION" envDefault:"false" key:"github.repo_verification" doc:"Re-check io.github.* repository ownership on publish and edit"`
+	GitHubRepoVisibility   string `env:"GITHUB_REPO_VISIBILITY" envDefault:"public" key:"github.repo_visibility" enum:"public,any" doc:"Repository visibility accepted by repository verification"`
+	GitHubAPIToken         string `env:"GITHUB_API_TOKEN" envDefault:"" key:"github.api_token" doc:"Token for GitHub API requests made by repository verification"`
+
+	// Repository URL canonicalization on publish and edit
+	RepositoryURLResolveRedirects bool `env:"REPOSITORY_URL_RESOLVE_REDIRECTS" envDefault:"false" key:"repository.resolve_redirects" doc:"Follow redirects of renamed and transferred repositories when canonicalizing repository URLs"`
+
+	// Restrictions on the GitHub Actions runs that may exchange OIDC tokens for publish tokens
+	GitHubOIDCRefPolicy    string   `env:"GITHUB_OIDC_REF_POLICY" envDefault:"any" key:"github_oidc.ref_policy" enum:"any,tags,protected" doc:"Git refs GitHub Actions may publish from: any ref, tags only, or tags and protected branches"`
+	GitHubOIDCWorkflows    []string `env:"GITHUB_OIDC_WORKFLOWS" envSeparator:"," key:"github_oidc.workflows" doc:"job_workflow_ref patterns allowed to publish, where a trailing * matches any suffix; empty allows any workflow"`
+	GitHubOIDCEnvironments []string `env:"GITHUB_OIDC_ENVIRONMENTS" envSeparator:"," key:"github_oidc.environments" doc:"Deployment environments publishing jobs must run in; empty allows jobs with or without one"`
+
+	// Screening of server names, titles and descriptions on publish and edit; admins can exempt servers
+	ScreeningEnabled         bool     `env:"SCREENING_ENABLED" envDefault:"false" key:"screening.enabled" doc:"Reject publishes and edits whose name, title or description uses a reserved or prohibited term"`
+	ScreeningReservedTerms   []string `env:"SCREENING_RESERVED_TERMS" envDefault:"official,verified" envSeparator:"," key:"screening.reserved_terms" doc:"Comma-separated terms that suggest an affiliation, matched as whole words; a trailing * matches word prefixes"`
+	ScreeningProhibitedTerms []string `env:"SCREENING_PROHIBITED_TERMS" envSeparator:"," key:"screening.prohibited_terms" doc:"Comma-separated terms that are never allowed, such as profanity, matched as whole words; a trailing * matches word prefixes"`
+	ScreeningAllowedWords    []string `env:"SCREENING_ALLOWED_WORDS" envSeparator:"," key:"screening.allowed_words" doc:"Comma-separated words that never match a reserved or prohibited term"`
+
+	// Organization API keys, which org admins create for publishing automation and which outlive any one member's account
+	OrgAPIKeysEnabled bool `env:"ORG_API_KEYS_ENABLED" envDefault:"false" key:"org_api_keys.enabled" doc:"Let organizations create API keys that exchange for registry tokens scoped to their namespaces"`
+
+	// Lint rules that reject publishes instead of returning warnings (e.g. missing-icon,short-description)
+	ValidationBlockingLintRules []string `env:"VALIDATION_BLOCKING_LINT_RULES" envSeparator:"," key:"validation.blocking_lint_rules" enum:"missing-icon,short-description,unused-variable,unpinned-package,secret-header-value" doc:"Lint rules that reject publishes instead of returning warnings"`
+
+	// Limits on publish request bodies, checked in one streaming pass before the body is decoded (zero disables a limit)
+	PublishMaxBodyBytes int64 `env:"PUBLISH_MAX_BODY_BYTES" envDefault:"262144" key:"publish.max_body_bytes" minimum:"0" doc:"Largest accepted publish request body, in bytes"`
+	PublishMaxJSONDepth int   `env:"PUBLISH_MAX_JSON_DEPTH" envDefault:"32" key:"publish.max_json_depth" minimum:"0" doc:"How deeply objects and arrays may be nested in a published server.json"`
+
+	// Asynchronous publishing accepts publishes after the quick checks and runs package lookups and repository checks in the background
+	AsyncPublishEnabled bool `env:"ASYNC_PUBLISH_ENABLED" envDefault:"false" key:"publish.async_enabled" doc:"Serve POST /v0/publish/async, which accepts publishes as pending and completes them in the background"`
+
+	// How long the old name of a renamed server keeps redirecting to the new one
+	ServerAliasDuration time.Duration `env:"SERVER_ALIAS_DURATION" envDefault:"2160h" key:"publish.alias_duration" doc:"How long the name a server was renamed from redirects to its new name"`
+
+	// Requests each client may make to the unauthenticated validate endpoint per minute (zero disables the limit)
+	ValidateRateLimit int `env:"VALIDATE_RATE_LIMIT" envDefault:"60" key:"validate.rate_limit" minimum:"0" doc:"Validation requests each client address may make per minute"`
+
+	// Search result ranking: comma-separated signal=weight pairs for the text, recency, downloads and verified signals
+	SearchRankingWeights         string        `env:"SEARCH_RANKING_WEIGHTS" envDefault:"text=1,recency=0.2,downloads=0.3,verified=0.2" key:"search.ranking_weights" doc:"Comma-separated signal=weight pairs for text, recency, downloads and verified"`
+	SearchRankingRecencyHalfLife time.Duration `env:"SEARCH_RANKING_RECENCY_HALF_LIFE" envDefault:"2160h" key:"search.recency_half_life" doc:"Age at which the recency signal halves"`
+	SearchRankingMaxCandidates   int           `env:"SEARCH_RANKING_MAX_CANDIDATES" envDefault:"500" key:"search.max_candidates" minimum:"0" doc:"How many matching servers are ranked"`
+
+	// List totals are counted exactly up to this many servers and estimated from the query planner beyond it
+	ListTotalExactLimit int `env:"LIST_TOTAL_EXACT_LIMIT" envDefault:"1000" key:"search.total_exact_limit" minimum:"0" doc:"List totals are counted exactly up to this many servers and estimated beyond it"`
+
+	// Retention policy for old server versions (zero disables a limit)
+	RetentionMaxVersionsPerServer int           `env:"RETENTION_MAX_VERSIONS_PER_SERVER" envDefault:"0" key:"retention.max_versions_per_server" minimum:"0" doc:"Versions kept per server, newest first (0 is unlimited)"`
+	RetentionMaxAge               time.Duration `env:"RETENTION_MAX_AGE" envDefault:"0" key:"retention.max_age" doc:"Maximum age of non-latest versions (0 is unlimited)"`
+	RetentionInterval             time.Duration `env:"RETENTION_INTERVAL" envDefault:"1h" key:"retention.interval" doc:"How often the retention policy is applied"`
+	AuditLogRetention             time.Duration `env:"AUDIT_LOG_RETENTION" envDefault:"0" key:"audit.retention" doc:"How long audit log entries are kept, pruned every retention interval (0 keeps them forever)"`
+	ChangesRetention              time.Duration `env:"CHANGES_RETENTION" envDefault:"0" key:"changes.retention" doc:"How long changes feed entries are kept, pruned every retention interval (0 keeps them forever)"`
+	WebhookDeliveryInterval       time.Duration `env:"WEBHOOK_DELIVERY_INTERVAL" envDefault:"10s" key:"webhooks.delivery_interval" doc:"How often new changes are delivered to webhook subscriptions (0 disables delivery)"`
+	PublishHistoryRetention       time.Duration `env:"PUBLISH_HISTORY_RETENTION" envDefault:"720h" key:"publish.history_retention" doc:"How long publish attempts are kept for publishers to review at /v0/me/publishes, pruned every retention interval (0 disables publish history)"`
+	PublishQuarantineRetention    time.Duration `env:"PUBLISH_QUARANTINE_RETENTION" envDefault:"72h" key:"publish.quarantine_retention" doc:"How long the server.json and full validation output of rejected publishes are kept for publishers at /v0/me/publishes/{id}, pruned every retention interval (0 disables the quarantine)"`
+	PreviewTTL                    time.Duration `env:"PREVIEW_TTL" envDefault:"168h" key:"publish.preview_ttl" doc:"How long previews published with ?preview=true are served before they expire and are deleted every retention interval (0 disables previews)"`
+
+	// Envelope encryption of sensitive columns; keys are id:base64 pairs for the local provider, the first one current
+	EncryptionKeyProvider string   `env:"ENCRYPTION_KEY_PROVIDER" envDefault:"" key:"encryption.key_provider" doc:"Key provider wrapping the keys that encrypt sensitive columns, such as local; empty stores them unencrypted"`
+	EncryptionKeys        []string `env:"ENCRYPTION_KEYS" envSeparator:"," key:"encryption.keys" doc:"Comma-separated id:base64-key pairs of 32-byte keys for the local key provider; the first is current"`
+
+	// Slow request logging: requests over their latency budget are logged with the SQL statements they ran
+	SlowRequestThreshold time.Duration `env:"SLOW_REQUEST_THRESHOLD" envDefault:"0" key:"observability.slow_request_threshold" doc:"Requests slower than this are logged with the SQL statements they ran (0 disables)"`
+	SlowRequestBudgets   PathDurations `env:"SLOW_REQUEST_BUDGETS" key:"observability.slow_request_budgets" doc:"Latency budgets by path prefix overriding the threshold, 0 to never log; the longest matching prefix wins"`
+
+	// The registry's own security.txt (RFC 9116), served at /.well-known/security.txt when a contact is set
+	SecurityContacts  []string `env:"SECURITY_CONTACTS" envSeparator:"," key:"security_txt.contacts" doc:"Comma-separated mailto: addresses or https URLs where vulnerabilities in the registry should be reported, most preferred first; empty serves no security.txt"`
+	SecurityPolicyURL string   `env:"SECURITY_POLICY_URL" envDefault:"" key:"security_txt.policy_url" format:"uri" doc:"URL of the registry's vulnerability disclosure policy, listed in security.txt"`
+
+	// Caching of anonymous reads by CDNs and other shared caches
+	CachePolicies CachePolicies `env:"CACHE_POLICIES" key:"server.cache_policies" doc:"Cache-Control policies for anonymous list, search, detail and stats reads"`
+
+	// Anonymous read analytics
+	ReadAnalyticsEnabled    bool          `env:"READ_ANALYTICS_ENABLED" envDefault:"false" key:"read_analytics.enabled" doc:"Collect anonymous read analytics served at /v0/stats"`
+	ReadAnalyticsMinClients int           `env:"READ_ANALYTICS_MIN_CLIENTS" envDefault:"10" key:"read_analytics.min_clients" minimum:"1" doc:"Distinct clients required before an entry is published"`
+	ReadAnalyticsWindow     time.Duration `env:"READ_ANALYTICS_WINDOW" envDefault:"24h" key:"read_analytics.window" doc:"Aggregation window for read analytics"`
+
+	// Install feedback: MCP clients report, with their users' consent, whether installing and running servers worked
+	InstallFeedbackEnabled    bool          `env:"INSTALL_FEEDBACK_ENABLED" envDefault:"false" key:"install_feedback.enabled" doc:"Accept install reports from MCP clients and show the share of reporters each server version works for"`
+	InstallFeedbackMinReports int           `env:"INSTALL_FEEDBACK_MIN_REPORTS" envDefault:"10" key:"install_feedback.min_reports" minimum:"1" doc:"Distinct reporters a version needs before its success rate is shown"`
+	InstallFeedbackWindow     time.Duration `env:"INSTALL_FEEDBACK_WINDOW" envDefault:"2160h" key:"install_feedback.window" doc:"How long install reports count towards success rates before they are deleted; 0 keeps them"`
+	InstallFeedbackRateLimit  int           `env:"INSTALL_FEEDBACK_RATE_LIMIT" envDefault:"30" key:"install_feedback.rate_limit" minimum:"0" doc:"Install reports each client address may send per minute"`
+	InstallFeedbackKey        string        `env:"INSTALL_FEEDBACK_KEY" envDefault:"" key:"install_feedback.key" doc:"Secret keying the hashes that identify install reporters; required when install feedback is enabled"`
+
+	// Remote health probing: periodic MCP handshakes with the remote endpoints servers declare
+	RemoteProbeEnabled   bool          `env:"REMOTE_PROBE_ENABLED" envDefault:"false" key:"remote_probe.enabled" doc:"Probe declared remote endpoints and show their health in server responses"`
+	RemoteProbeInterval  time.Duration `env:"REMOTE_PROBE_INTERVAL" envDefault:"1h" key:"remote_probe.interval" doc:"How often every remote endpoint is probed"`
+	RemoteProbeTimeout   time.Duration `env:"REMOTE_PROBE_TIMEOUT" envDefault:"10s" key:"remote_probe.timeout" doc:"How long a single probe may take"`
+	RemoteProbeDeadAfter time.Duration `env:"REMOTE_PROBE_DEAD_AFTER" envDefault:"336h" key:"remote_probe.dead_after" doc:"How long every remote of a server must fail before it is flagged as dead"`
+
+	// Service level objectives: the SLIs and burn rates reported on /metrics are computed against these
+	SLOAvailabilityObjective float64       `env:"SLO_AVAILABILITY_OBJECTIVE" envDefault:"0.999" key:"slo.availability_objective" minimum:"0" doc:"Fraction of requests that must not fail with a 5xx status, below 1"`
+	SLOLatencyObjective      float64       `env:"SLO_LATENCY_OBJECTIVE" envDefault:"0.99" key:"slo.latency_objective" minimum:"0" doc:"Fraction of requests that must complete within the latency threshold, below 1"`
+	SLOLatencyThreshold      time.Duration `env:"SLO_LATENCY_THRESHOLD" envDefault:"500ms" key:"slo.latency_threshold" doc:"How long a request may take and still count towards the latency objective"`
+
+	// Stale-entry sweeper: flags untouched servers whose repository or packages are gone, notifies their maintainers,
+	// and marks them unmaintained after a grace period
+	StaleSweepEnabled  bool          `env:"STALE_SWEEP_ENABLED" envDefault:"false" key:"stale_sweep.enabled" doc:"Periodically look for abandoned servers and mark them unmaintained"`
+	StaleSweepInterval time.Duration `env:"STALE_SWEEP_INTERVAL" envDefault:"24h" key:"stale_sweep.interval" doc:"How often every server is checked for staleness"`
+	StaleAfter         time.Duration `env:"STALE_AFTER" envDefault:"4380h" key:"stale_sweep.stale_after" doc:"How long a server must go without a new version before it is checked"`
+	StaleGracePeriod   time.Duration `env:"STALE_GRACE_PERIOD" envDefault:"720h" key:"stale_sweep.grace_period" doc:"How long maintainers have to publish a new version after a server is found stale before it is marked unmaintained"`
+
+	// Tool indexing: finds the tools servers expose in their repository's MCPB manifest or by listing them from their
+	// remotes, so servers can be searched by tool keywords
+	ToolIndexEnabled  bool          `env:"TOOL_INDEX_ENABLED" envDefault:"false" key:"tool_index.enabled" doc:"Index the tools servers expose so they can be searched with the tool filter"`
+	ToolIndexInterval time.Duration `env:"TOOL_INDEX_INTERVAL" envDefault:"24h" key:"tool_index.interval" doc:"How often the tools of every server are indexed"`
+	ToolIndexTimeout  time.Duration `env:"TOOL_INDEX_TIMEOUT" envDefault:"10s" key:"tool_index.timeout" doc:"How long listing the tools of a single remote may take"`
 
 	// OIDC Configuration
-	OIDCEnabled      bool   `env:"OIDC_ENABLED" envDefault:"false"`
-	OIDCIssuer       string `env:"OIDC_ISSUER" envDefault:""`
-	OIDCClientID     string `env:"OIDC_CLIENT_ID" envDefault:""`
-	OIDCExtraClaims  string `env:"OIDC_EXTRA_CLAIMS" envDefault:""`
-	OIDCEditPerms    string `env:"OIDC_EDIT_PERMISSIONS" envDefault:""`
-	OIDCPublishPerms string `env:"OIDC_PUBLISH_PERMISSIONS" envDefault:""`
+	OIDCEnabled      bool      `env:"OIDC_ENABLED" envDefault:"false" key:"oidc.enabled" doc:"Enable OIDC authentication for admin accounts"`
+	OIDCIssuer       string    `env:"OIDC_ISSUER" envDefault:"" key:"oidc.issuer" format:"uri" doc:"OIDC issuer URL"`
+	OIDCClientID     string    `env:"OIDC_CLIENT_ID" envDefault:"" key:"oidc.client_id" doc:"OIDC client ID expected as the token audience"`
+	OIDCExtraClaims  ClaimSets `env:"OIDC_EXTRA_CLAIMS" key:"oidc.extra_claims" doc:"Claim sets the token must have every claim of, each mapping a claim to its required value"`
+	OIDCEditPerms    string    `env:"OIDC_EDIT_PERMISSIONS" envDefault:"" key:"oidc.edit_permissions" doc:"Comma-separated resource patterns OIDC users may edit"`
+	OIDCPublishPerms string    `env:"OIDC_PUBLISH_PERMISSIONS" envDefault:"" key:"oidc.publish_permissions" doc:"Comma-separated resource patterns OIDC users may publish"`
+	OIDCClientSecret string    `env:"OIDC_CLIENT_SECRET" envDefault:"" key:"oidc.client_secret" doc:"OIDC client secret used by browser login; leave empty for public clients, which rely on PKCE alone"`
+	OIDCIssuersFile  string    `env:"OIDC_ISSUERS_FILE" envDefault:"" key:"oidc.issuers_file" doc:"YAML file listing more trusted OIDC issuers, each with its own client ID, claims and permissions; reloaded when it changes"`
+
+	// Browser login for people using the web UI and admin endpoints, separate from token exchange by tools
+	BrowserLoginEnabled    bool          `env:"BROWSER_LOGIN_ENABLED" envDefault:"false" key:"browser_login.enabled" doc:"Let people log in from a browser with GitHub or the OIDC issuer, keeping the registry token in a session cookie (requires MCP_REGISTRY_PUBLIC_URL)"`
+	BrowserSessionDuration time.Duration `env:"BROWSER_SESSION_DURATION" envDefault:"8h" key:"browser_login.session_duration" doc:"How long a browser session lasts before logging in again"`
+
+	// OAuth authorization server for applications, such as MCP clients, logging people in through the registry
+	OAuthClientRegistrationEnabled bool `env:"OAUTH_CLIENT_REGISTRATION_ENABLED" envDefault:"false" key:"oauth.client_registration_enabled" doc:"Let applications register themselves as OAuth clients (RFC 7591) and log people in with the authorization code flow and PKCE (requires browser login)"`
+
+	// CDN purge configuration
+	FastlyAPIToken     string `env:"FASTLY_API_TOKEN" envDefault:"" key:"cdn.fastly_api_token" doc:"Fastly API token used to purge surrogate keys"`
+	FastlyServiceID    string `env:"FASTLY_SERVICE_ID" envDefault:"" key:"cdn.fastly_service_id" doc:"Fastly service to purge"`
+	CloudflareAPIToken string `env:"CLOUDFLARE_API_TOKEN" envDefault:"" key:"cdn.cloudflare_api_token" doc:"Cloudflare API token used to purge cache tags"`
+	CloudflareZoneID   string `env:"CLOUDFLARE_ZONE_ID" envDefault:"" key:"cdn.cloudflare_zone_id" doc:"Cloudflare zone to purge"`
 }
 
-// NewConfig creates a new configuration with default values
-func NewConfig() *Config {
+// envPrefix is the prefix of every configuration env variable
+const envPrefix = "MCP_REGISTRY_"
+
+// FileEnv names the env variable holding the path of an optional YAML configuration file.
+// Env variables take precedence over values in the file.
+const FileEnv = envPrefix + "CONFIG_FILE"
+
+// Load reads the configuration from the YAML file named by MCP_REGISTRY_CONFIG_FILE, if any, and the
+// environment, and validates it
+func Load() (*Config, error) {
+	environment := env.ToMap(os.Environ())
+	if path := environment[FileEnv]; path != "" {
+		fileValues, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range fileValues {
+			if _, set := environment[name]; !set {
+				environment[name] = value
+			}
+		}
+	}
+
 	var cfg Config
-	err := env.ParseWithOptions(&cfg, env.Options{
-		Prefix: "MCP_REGISTRY_",
-	})
+	if err := env.ParseWithOptions(&cfg, env.Options{Prefix: envPrefix, Environment: environment}); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// NewConfig creates a new configuration with default values, panicking if it is invalid
+func NewConfig() *Config {
+	cfg, err := Load()
 	if err != nil {
 		panic(err)
 	}
+	return cfg
+}
+
+// Defaults returns the default configuration, ignoring the environment and any configuration file
+func Defaults() *Config {
+	var cfg Config
+	if err := env.ParseWithOptions(&cfg, env.Options{Prefix: envPrefix, Environment: map[string]string{}}); err != nil {
+		panic(err)
+	}
 	return &cfg
 }
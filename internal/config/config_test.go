@@ -0,0 +1,193 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "registry.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv(config.FileEnv, path)
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, ":8080", cfg.ServerAddress)
+	assert.Equal(t, 30*time.Second, cfg.JWTClockSkew)
+	assert.Equal(t, "public", cfg.GitHubRepoVisibility)
+}
+
+func TestLoad_File(t *testing.T) {
+	writeConfigFile(t, `
+server:
+  address: ":9090"
+jwt:
+  clock_skew: 1m
+validation:
+  registry: false
+  blocking_lint_rules: [missing-icon, short-description]
+search:
+  max_candidates: 50
+`)
+	t.Setenv("MCP_REGISTRY_SERVER_ADDRESS", ":7070")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, ":7070", cfg.ServerAddress, "env variables take precedence over the file")
+	assert.Equal(t, time.Minute, cfg.JWTClockSkew)
+	assert.False(t, cfg.EnableRegistryValidation)
+	assert.Equal(t, []string{"missing-icon", "short-description"}, cfg.ValidationBlockingLintRules)
+	assert.Equal(t, 50, cfg.SearchRankingMaxCandidates)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "unknown file key", file: "github:\n  repo_visiblity: any\n", wantErr: `unknown key "github.repo_visiblity"`},
+		{name: "malformed duration", file: "jwt:\n  clock_skew: soon\n", wantErr: `invalid duration "soon"`},
+		{name: "enum", env: map[string]string{"MCP_REGISTRY_GITHUB_REPO_VISIBILITY": "private"}, wantErr: `"private" is not one of public, any`},
+		{name: "enum list", env: map[string]string{"MCP_REGISTRY_VALIDATION_BLOCKING_LINT_RULES": "missing-icon,no-such-rule"}, wantErr: `"no-such-rule"`},
+		{name: "minimum", env: map[string]string{"MCP_REGISTRY_READ_ANALYTICS_MIN_CLIENTS": "0"}, wantErr: "MCP_REGISTRY_READ_ANALYTICS_MIN_CLIENTS: 0 is less than 1"},
+		{name: "cidr", env: map[string]string{"MCP_REGISTRY_TRUSTED_PROXIES": "10.0.0.0/8,nope"}, wantErr: `"nope" is not a CIDR or IP address`},
+		{name: "uri", env: map[string]string{"MCP_REGISTRY_REPLICATE_FROM": "registry.example.com"}, wantErr: "not an absolute URL"},
+		{name: "path duration pair", env: map[string]string{"MCP_REGISTRY_REQUEST_TIMEOUTS": "/v0"}, wantErr: `"/v0" is not a /path-prefix=duration pair`},
+		{name: "path duration", env: map[string]string{"MCP_REGISTRY_SLOW_REQUEST_BUDGETS": "/v0=fast"}, wantErr: `invalid duration "fast"`},
+		{name: "path prefix", env: map[string]string{"MCP_REGISTRY_REQUEST_TIMEOUTS": "v0=1s"}, wantErr: `MCP_REGISTRY_REQUEST_TIMEOUTS: path prefix "v0" must start with /`},
+		{name: "negative path duration", file: "server:\n  request_timeouts:\n    /v0: -1s\n", wantErr: "/v0: duration must not be negative"},
+		{name: "cache route", env: map[string]string{"MCP_REGISTRY_CACHE_POLICIES": "publish=1m"}, wantErr: `route "publish" is not one of list, search, detail, stats`},
+		{name: "cache policy part", env: map[string]string{"MCP_REGISTRY_CACHE_POLICIES": "list=1m/later"}, wantErr: `"later" is not a duration, private or public`},
+		{name: "cache policy key", file: "server:\n  cache_policies:\n    list: {ttl: 1m, max_age: 1h}\n", wantErr: `unknown field "max_age"`},
+		{name: "claim sets", env: map[string]string{"MCP_REGISTRY_OIDC_EXTRA_CLAIMS": `{"hd":"example.com"}`}, wantErr: "expected a JSON array of claim sets"},
+		{name: "claim value", file: "oidc:\n  extra_claims: [{groups: [a, b]}]\n", wantErr: "groups must be a string, number or boolean"},
+		{name: "mapping for a plain value", file: "jwt:\n  issuer: {name: registry}\n", wantErr: "jwt.issuer must not be a mapping"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.file != "" {
+				writeConfigFile(t, tt.file)
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			_, err := config.Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_StructuredValues(t *testing.T) {
+	writeConfigFile(t, `
+server:
+  request_timeouts:
+    /v0/publish: 2m
+    /v0/artifacts: 0
+  cache_policies:
+    list: {ttl: 1m, stale_while_revalidate: 5m}
+    detail: {ttl: 1h, private: true}
+    search: {ttl: 0s}
+oidc:
+  extra_claims:
+    - hd: example.com
+      email_verified: true
+`)
+	t.Setenv("MCP_REGISTRY_SLOW_REQUEST_BUDGETS", " /v0=1s, /v0/publish=2s ")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, config.PathDurations{"/v0/publish": 2 * time.Minute, "/v0/artifacts": 0}, cfg.RequestTimeouts)
+	assert.Equal(t, config.PathDurations{"/v0": time.Second, "/v0/publish": 2 * time.Second}, cfg.SlowRequestBudgets)
+	assert.Equal(t, config.CachePolicies{
+		"list":   {TTL: time.Minute, StaleWhileRevalidate: 5 * time.Minute},
+		"detail": {TTL: time.Hour, Private: true},
+		"search": {},
+	}, cfg.CachePolicies)
+	assert.Equal(t, config.ClaimSets{{"hd": "example.com", "email_verified": true}}, cfg.OIDCExtraClaims)
+
+	// The same values can be set in env variables
+	t.Setenv("MCP_REGISTRY_CACHE_POLICIES", "list=1m/5m,detail=1h/private,search=0s")
+	t.Setenv("MCP_REGISTRY_OIDC_EXTRA_CLAIMS", `[{"hd":"example.com","email_verified":true}]`)
+	fromEnv, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, cfg.CachePolicies, fromEnv.CachePolicies)
+	assert.Equal(t, cfg.OIDCExtraClaims, fromEnv.OIDCExtraClaims)
+}
+
+func TestPathDurationsMatch(t *testing.T) {
+	durations := config.PathDurations{"/v0": 5 * time.Second, "/v0/publish": 2 * time.Minute, "/v0/artifacts": 0}
+
+	for path, want := range map[string]time.Duration{
+		"/v0/servers":       5 * time.Second,
+		"/v0/publish":       2 * time.Minute,
+		"/v0/artifacts/abc": 0,
+	} {
+		got, found := durations.Match(path)
+		assert.True(t, found, path)
+		assert.Equal(t, want, got, path)
+	}
+
+	_, found := durations.Match("/health")
+	assert.False(t, found)
+}
+
+func TestSchema(t *testing.T) {
+	schema := config.Schema()
+
+	github := schema["properties"].(map[string]any)["github"].(map[string]any)
+	visibility := github["properties"].(map[string]any)["repo_visibility"].(map[string]any)
+	assert.Equal(t, "string", visibility["type"])
+	assert.Equal(t, []string{"public", "any"}, visibility["enum"])
+	assert.Equal(t, "public", visibility["default"])
+	assert.Contains(t, visibility["description"], "MCP_REGISTRY_GITHUB_REPO_VISIBILITY")
+
+	jwt := schema["properties"].(map[string]any)["jwt"].(map[string]any)
+	skew := jwt["properties"].(map[string]any)["clock_skew"].(map[string]any)
+	assert.Equal(t, "string", skew["type"])
+	assert.NotEmpty(t, skew["pattern"])
+
+	server := schema["properties"].(map[string]any)["server"].(map[string]any)
+	policies := server["properties"].(map[string]any)["cache_policies"].(map[string]any)
+	assert.Equal(t, "object", policies["type"])
+	assert.Equal(t, false, policies["additionalProperties"])
+	assert.Contains(t, policies["properties"], "list")
+	assert.Contains(t, policies["description"], "MCP_REGISTRY_CACHE_POLICIES")
+}
+
+// Every field needs a unique file key and a description to be settable from the file and described in the schema
+func TestConfigFieldsHaveKeys(t *testing.T) {
+	configType := reflect.TypeOf(config.Config{})
+	keys := map[string]string{}
+	for i := range configType.NumField() {
+		field := configType.Field(i)
+		key := field.Tag.Get("key")
+		assert.NotEmpty(t, key, "%s has no key tag", field.Name)
+		assert.NotEmpty(t, field.Tag.Get("doc"), "%s has no doc tag", field.Name)
+		if other, seen := keys[key]; seen {
+			t.Errorf("%s and %s share the key %q", field.Name, other, key)
+		}
+		keys[key] = field.Name
+	}
+	for key := range keys {
+		for other := range keys {
+			assert.False(t, strings.HasPrefix(other, key+"."), "%q is both a value and a section", key)
+		}
+	}
+}
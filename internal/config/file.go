@@ -0,0 +1,81 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readFile reads a YAML configuration file and returns its values keyed by prefixed env variable name,
+// so they can be parsed the same way as the environment
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var document map[string]any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	fieldsByKey := map[string]field{}
+	for _, f := range fields() {
+		fieldsByKey[f.key] = f
+	}
+
+	values := map[string]string{}
+	if err := flattenFile(document, "", fieldsByKey, values); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+func flattenFile(section map[string]any, prefix string, fieldsByKey map[string]field, values map[string]string) error {
+	for name, value := range section {
+		key := prefix + name
+		f, known := fieldsByKey[key]
+		if nested, ok := value.(map[string]any); ok && !known {
+			if err := flattenFile(nested, key+".", fieldsByKey, values); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !known {
+			return fmt.Errorf("unknown key %q", key)
+		}
+		envName := envPrefix + f.env
+		if f.structured() && value != nil {
+			// Structured values are passed on as JSON, which their UnmarshalText accepts along with env text
+			if text, isText := value.(string); isText {
+				values[envName] = text
+				continue
+			}
+			data, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			values[envName] = string(data)
+			continue
+		}
+		switch v := value.(type) {
+		case nil:
+			continue
+		case map[string]any:
+			return fmt.Errorf("%s must not be a mapping", key)
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[envName] = strings.Join(items, ",")
+		default:
+			values[envName] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
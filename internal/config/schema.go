@@ -0,0 +1,197 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// durationPattern matches the Go duration strings accepted by time.ParseDuration
+const durationPattern = `^0$|^-?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// structuredValue is implemented by field types with structured values, which validate and describe themselves.
+// In the configuration file their values are YAML mappings or lists, and in env variables text their
+// UnmarshalText parses.
+type structuredValue interface {
+	validate() error
+	jsonSchema() map[string]any
+}
+
+// field describes one configuration field from its struct tags
+type field struct {
+	index   int
+	env     string // env variable name without the prefix
+	key     string // dotted YAML key, e.g. github.repo_verification
+	typ     reflect.Type
+	tag     reflect.StructTag
+	enum    []string
+	minimum *float64
+}
+
+// fields returns the configuration fields in declaration order
+func fields() []field {
+	t := reflect.TypeOf(Config{})
+	result := make([]field, 0, t.NumField())
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		f := field{index: i, env: sf.Tag.Get("env"), key: sf.Tag.Get("key"), typ: sf.Type, tag: sf.Tag}
+		if enum := sf.Tag.Get("enum"); enum != "" {
+			f.enum = strings.Split(enum, ",")
+		}
+		if minimum, err := strconv.ParseFloat(sf.Tag.Get("minimum"), 64); err == nil {
+			f.minimum = &minimum
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// Validate checks the enum, format and minimum constraints declared on the configuration fields
+func (c *Config) Validate() error {
+	v := reflect.ValueOf(c).Elem()
+	var errs []error
+	for _, f := range fields() {
+		if err := f.validate(v.Field(f.index)); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s%s: %w", envPrefix, f.env, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// structured reports whether the field's type validates and describes itself
+func (f field) structured() bool {
+	return f.typ.Implements(reflect.TypeOf((*structuredValue)(nil)).Elem())
+}
+
+func (f field) validate(value reflect.Value) error {
+	if structured, ok := value.Interface().(structuredValue); ok {
+		return structured.validate()
+	}
+	switch value.Kind() { //nolint:exhaustive // other kinds have no declared constraints
+	case reflect.String:
+		s := value.String()
+		if len(f.enum) > 0 && !slices.Contains(f.enum, s) {
+			return fmt.Errorf("%q is not one of %s", s, strings.Join(f.enum, ", "))
+		}
+		if f.tag.Get("format") == "uri" && s != "" {
+			if u, err := url.Parse(s); err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("%q is not an absolute URL", s)
+			}
+		}
+	case reflect.Slice:
+		for i := range value.Len() {
+			s := value.Index(i).String()
+			if len(f.enum) > 0 && !slices.Contains(f.enum, s) {
+				return fmt.Errorf("%q is not one of %s", s, strings.Join(f.enum, ", "))
+			}
+			if f.tag.Get("format") == "cidr" {
+				if _, err := netip.ParsePrefix(s); err != nil {
+					if _, err := netip.ParseAddr(s); err != nil {
+						return fmt.Errorf("%q is not a CIDR or IP address", s)
+					}
+				}
+			}
+		}
+	case reflect.Int, reflect.Int64:
+		if f.minimum != nil && value.Type() != durationType && float64(value.Int()) < *f.minimum {
+			return fmt.Errorf("%d is less than %g", value.Int(), *f.minimum)
+		}
+	case reflect.Float64:
+		if f.minimum != nil && value.Float() < *f.minimum {
+			return fmt.Errorf("%g is less than %g", value.Float(), *f.minimum)
+		}
+	}
+	return nil
+}
+
+// Schema returns a JSON Schema describing the YAML configuration file, for editor completion and validation
+func Schema() map[string]any {
+	root := map[string]any{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                "MCP Registry configuration",
+		"description":          "Configuration file named by " + FileEnv + ". Env variables take precedence over values in the file.",
+		"type":                 "object",
+		"properties":           map[string]any{},
+		"additionalProperties": false,
+	}
+
+	for _, f := range fields() {
+		parent := root
+		path := strings.Split(f.key, ".")
+		for _, section := range path[:len(path)-1] {
+			properties := parent["properties"].(map[string]any)
+			child, ok := properties[section].(map[string]any)
+			if !ok {
+				child = map[string]any{"type": "object", "properties": map[string]any{}, "additionalProperties": false}
+				properties[section] = child
+			}
+			parent = child
+		}
+		parent["properties"].(map[string]any)[path[len(path)-1]] = f.schema()
+	}
+	return root
+}
+
+func (f field) schema() map[string]any {
+	description := f.tag.Get("doc") + " (" + envPrefix + f.env + ")"
+	if structured, ok := reflect.Zero(f.typ).Interface().(structuredValue); ok {
+		s := structured.jsonSchema()
+		s["description"] = description
+		return s
+	}
+
+	s := map[string]any{"description": description}
+	defaultValue, hasDefault := f.tag.Lookup("envDefault")
+
+	switch {
+	case f.typ == durationType:
+		s["type"] = "string"
+		s["pattern"] = durationPattern
+	case f.typ.Kind() == reflect.Bool:
+		s["type"] = "boolean"
+		if b, err := strconv.ParseBool(defaultValue); err == nil {
+			s["default"] = b
+		}
+		hasDefault = false
+	case f.typ.Kind() == reflect.Int:
+		s["type"] = "integer"
+		if n, err := strconv.Atoi(defaultValue); err == nil {
+			s["default"] = n
+		}
+		hasDefault = false
+	case f.typ.Kind() == reflect.Float64:
+		s["type"] = "number"
+	case f.typ.Kind() == reflect.Slice:
+		items := map[string]any{"type": "string"}
+		if len(f.enum) > 0 {
+			items["enum"] = f.enum
+		}
+		s["type"] = "array"
+		s["items"] = items
+		return s
+	default:
+		s["type"] = "string"
+		if len(f.enum) > 0 {
+			s["enum"] = f.enum
+		}
+		if format := f.tag.Get("format"); format != "" {
+			s["format"] = format
+		}
+	}
+
+	if f.minimum != nil {
+		s["minimum"] = *f.minimum
+	}
+	if hasDefault && defaultValue != "" {
+		s["default"] = defaultValue
+	}
+	return s
+}
@@ -0,0 +1,260 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PathDurations maps URL path prefixes to durations, such as a request timeout per route. The duration of the
+// longest prefix matching a path applies to it.
+//
+// In the configuration file it is a mapping of prefixes to durations; in env variables it is comma-separated
+// prefix=duration pairs, such as "/v0/publish=2m,/v0/servers=5s".
+type PathDurations map[string]time.Duration
+
+// UnmarshalText parses comma-separated prefix=duration pairs, or a JSON object of prefixes to durations
+func (d *PathDurations) UnmarshalText(text []byte) error {
+	durations := PathDurations{}
+	if strings.HasPrefix(strings.TrimSpace(string(text)), "{") {
+		var values map[string]any
+		if err := json.Unmarshal(text, &values); err != nil {
+			return fmt.Errorf("expected a mapping of path prefixes to durations: %w", err)
+		}
+		for prefix, value := range values {
+			duration, err := parseDuration(value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", prefix, err)
+			}
+			durations[prefix] = duration
+		}
+	} else {
+		for _, pair := range strings.Split(string(text), ",") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			prefix, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return fmt.Errorf("%q is not a /path-prefix=duration pair", pair)
+			}
+			duration, err := time.ParseDuration(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("%s: %w", pair, err)
+			}
+			durations[strings.TrimSpace(prefix)] = duration
+		}
+	}
+	*d = durations
+	return nil
+}
+
+// Match returns the duration of the longest prefix matching path, reporting whether any matched
+func (d PathDurations) Match(path string) (time.Duration, bool) {
+	longest := -1
+	var match time.Duration
+	for prefix, duration := range d {
+		if len(prefix) > longest && strings.HasPrefix(path, prefix) {
+			longest = len(prefix)
+			match = duration
+		}
+	}
+	return match, longest >= 0
+}
+
+func (d PathDurations) validate() error {
+	for _, prefix := range slices.Sorted(maps.Keys(d)) {
+		if !strings.HasPrefix(prefix, "/") {
+			return fmt.Errorf("path prefix %q must start with /", prefix)
+		}
+		if d[prefix] < 0 {
+			return fmt.Errorf("%s: duration must not be negative", prefix)
+		}
+	}
+	return nil
+}
+
+func (PathDurations) jsonSchema() map[string]any {
+	return map[string]any{
+		"type":                 "object",
+		"propertyNames":        map[string]any{"pattern": "^/"},
+		"additionalProperties": map[string]any{"type": "string", "pattern": durationPattern},
+	}
+}
+
+// parseDuration parses a duration from the configuration file, where durations are strings such as "30s" and
+// a bare 0 means none
+func parseDuration(value any) (time.Duration, error) {
+	switch v := value.(type) {
+	case string:
+		return time.ParseDuration(v)
+	case float64:
+		if v == 0 {
+			return 0, nil
+		}
+	}
+	return 0, fmt.Errorf("%v is not a duration such as 30s", value)
+}
+
+// CacheRoutes are the kinds of anonymous read a cache policy can be set for
+var CacheRoutes = []string{"list", "search", "detail", "stats"}
+
+// CachePolicy is how long shared caches may keep responses to one kind of anonymous read. A zero TTL forbids
+// caching altogether.
+type CachePolicy struct {
+	TTL                  time.Duration
+	StaleWhileRevalidate time.Duration
+	Private              bool
+}
+
+// CachePolicies maps the routes in CacheRoutes to their cache policy.
+//
+// In the configuration file it is a mapping of routes to policies with ttl, stale_while_revalidate and private
+// keys; in env variables it is comma-separated route=ttl[/stale-while-revalidate][/private|public] entries, such
+// as "list=1m/5m,detail=1h/24h,search=0s". Policies are public by default.
+type CachePolicies map[string]CachePolicy
+
+// cachePolicyFile is a cache policy as the configuration file holds it
+type cachePolicyFile struct {
+	TTL                  any  `json:"ttl"`
+	StaleWhileRevalidate any  `json:"stale_while_revalidate"`
+	Private              bool `json:"private"`
+}
+
+// UnmarshalText parses comma-separated route=ttl[/stale-while-revalidate][/private|public] entries, or a JSON
+// object of routes to policies
+func (p *CachePolicies) UnmarshalText(text []byte) error {
+	policies := CachePolicies{}
+	if strings.HasPrefix(strings.TrimSpace(string(text)), "{") {
+		var values map[string]cachePolicyFile
+		decoder := json.NewDecoder(strings.NewReader(string(text)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&values); err != nil {
+			return fmt.Errorf("expected a mapping of routes to policies with ttl, stale_while_revalidate and private: %w", err)
+		}
+		for route, value := range values {
+			policy := CachePolicy{Private: value.Private}
+			var err error
+			if policy.TTL, err = parseDuration(value.TTL); err != nil {
+				return fmt.Errorf("%s: ttl: %w", route, err)
+			}
+			if value.StaleWhileRevalidate != nil {
+				if policy.StaleWhileRevalidate, err = parseDuration(value.StaleWhileRevalidate); err != nil {
+					return fmt.Errorf("%s: stale_while_revalidate: %w", route, err)
+				}
+			}
+			policies[route] = policy
+		}
+	} else {
+		for _, entry := range strings.Split(string(text), ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			route, value, ok := strings.Cut(entry, "=")
+			if !ok {
+				return fmt.Errorf("%q is not a route=ttl entry", entry)
+			}
+
+			parts := strings.Split(strings.TrimSpace(value), "/")
+			var policy CachePolicy
+			var err error
+			if policy.TTL, err = time.ParseDuration(parts[0]); err != nil {
+				return fmt.Errorf("%s: %w", entry, err)
+			}
+			for _, part := range parts[1:] {
+				switch part {
+				case "private":
+					policy.Private = true
+				case "public":
+					policy.Private = false
+				default:
+					if policy.StaleWhileRevalidate, err = time.ParseDuration(part); err != nil {
+						return fmt.Errorf("%s: %q is not a duration, private or public", entry, part)
+					}
+				}
+			}
+			policies[strings.TrimSpace(route)] = policy
+		}
+	}
+	*p = policies
+	return nil
+}
+
+func (p CachePolicies) validate() error {
+	for _, route := range slices.Sorted(maps.Keys(p)) {
+		if !slices.Contains(CacheRoutes, route) {
+			return fmt.Errorf("route %q is not one of %s", route, strings.Join(CacheRoutes, ", "))
+		}
+		if p[route].TTL < 0 || p[route].StaleWhileRevalidate < 0 {
+			return fmt.Errorf("%s: durations must not be negative", route)
+		}
+	}
+	return nil
+}
+
+func (CachePolicies) jsonSchema() map[string]any {
+	policy := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"ttl":                    map[string]any{"type": "string", "pattern": durationPattern, "description": "How long responses may be cached; 0s forbids caching"},
+			"stale_while_revalidate": map[string]any{"type": "string", "pattern": durationPattern, "description": "How long a stale response may be served while it is refreshed"},
+			"private":                map[string]any{"type": "boolean", "default": false, "description": "Only let browsers cache responses, not shared caches"},
+		},
+		"required":             []string{"ttl"},
+		"additionalProperties": false,
+	}
+	properties := map[string]any{}
+	for _, route := range CacheRoutes {
+		properties[route] = policy
+	}
+	return map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+}
+
+// ClaimSets are sets of claims an identity token must have, each mapping a claim name to its required value.
+// It is a JSON array of objects in env variables and a list of mappings in the configuration file.
+type ClaimSets []map[string]any
+
+// UnmarshalText parses a JSON array of claim sets
+func (c *ClaimSets) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		*c = nil
+		return nil
+	}
+	var sets []map[string]any
+	if err := json.Unmarshal(text, &sets); err != nil {
+		return fmt.Errorf("expected a JSON array of claim sets: %w", err)
+	}
+	*c = sets
+	return nil
+}
+
+func (c ClaimSets) validate() error {
+	for i, set := range c {
+		if len(set) == 0 {
+			return fmt.Errorf("claim set %d is empty", i)
+		}
+		for _, name := range slices.Sorted(maps.Keys(set)) {
+			switch set[name].(type) {
+			case string, float64, bool:
+			default:
+				return fmt.Errorf("claim set %d: %s must be a string, number or boolean", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (ClaimSets) jsonSchema() map[string]any {
+	return map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type":                 "object",
+			"minProperties":        1,
+			"additionalProperties": map[string]any{"type": []string{"string", "number", "boolean"}},
+		},
+	}
+}
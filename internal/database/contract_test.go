@@ -0,0 +1,1745 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// contractTests run against every backend, so the in-memory database keeps behaving like PostgreSQL
+var contractTests = []struct {
+	name string
+	run  func(t *testing.T, db database.Database)
+}{
+	{"CreateServerConstraints", testCreateServerConstraints},
+	{"ListServers", testListServers},
+	{"ReturnsCopies", testReturnsCopies},
+	{"InTransaction", testInTransaction},
+	{"StatusAndChanges", testStatusAndChanges},
+	{"DeleteServerChangesBefore", testDeleteServerChangesBefore},
+	{"ListPrunableVersions", testListPrunableVersions},
+	{"ListPossibleDuplicates", testListPossibleDuplicates},
+	{"NamespaceVerifications", testNamespaceVerifications},
+	{"AuditLog", testAuditLog},
+	{"MaintenanceAndCheckpoints", testMaintenanceAndCheckpoints},
+	{"TryAcquireJobLock", testTryAcquireJobLock},
+	{"PackageProvenance", testPackageProvenance},
+	{"ArtifactMirrors", testArtifactMirrors},
+	{"UpstreamCache", testUpstreamCache},
+	{"TokenUses", testTokenUses},
+	{"BulkJobs", testBulkJobs},
+	{"RemoteHealth", testRemoteHealth},
+	{"ServerTools", testServerTools},
+	{"ScreeningExceptions", testScreeningExceptions},
+	{"ServerDocuments", testServerDocuments},
+	{"ServerCuration", testServerCuration},
+	{"SuggestServers", testSuggestServers},
+	{"OrgAPIKeys", testOrgAPIKeys},
+	{"OrgMembers", testOrgMembers},
+	{"OAuthClients", testOAuthClients},
+	{"Events", testEvents},
+	{"QuarantinedPublishes", testQuarantinedPublishes},
+	{"PublishAttempts", testPublishAttempts},
+	{"IdentityBans", testIdentityBans},
+	{"QuarantinedVersions", testQuarantinedVersions},
+	{"ServerStaleness", testServerStaleness},
+	{"PendingPublishes", testPendingPublishes},
+	{"ServerAliases", testServerAliases},
+	{"WebhookSubscriptions", testWebhookSubscriptions},
+	{"Announcements", testAnnouncements},
+	{"Collections", testCollections},
+	{"Previews", testPreviews},
+	{"Metrics", testMetrics},
+	{"InstallReports", testInstallReports},
+	{"ValidationPolicies", testValidationPolicies},
+}
+
+func TestDatabaseContract(t *testing.T) {
+	backends := []struct {
+		name string
+		open func(t *testing.T) database.Database
+	}{
+		{"memory", func(*testing.T) database.Database { return database.NewMemory() }},
+		{"shadow", func(*testing.T) database.Database {
+			return database.NewShadow(database.NewMemory(), database.NewMemory())
+		}},
+		{"postgres", database.NewTestDB},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			for _, test := range contractTests {
+				t.Run(test.name, func(t *testing.T) {
+					test.run(t, backend.open(t))
+				})
+			}
+		})
+	}
+}
+
+func createTestServer(t *testing.T, db database.Database, name, version string, publishedAt time.Time, isLatest bool) {
+	t.Helper()
+	_, err := db.CreateServer(context.Background(), nil, &apiv0.ServerJSON{
+		Name:        name,
+		Description: "Test server",
+		Version:     version,
+	}, &apiv0.RegistryExtensions{
+		Status:          model.StatusActive,
+		StatusChangedAt: publishedAt,
+		PublishedAt:     publishedAt,
+		UpdatedAt:       publishedAt,
+		IsLatest:        isLatest,
+	})
+	require.NoError(t, err)
+}
+
+func testCreateServerConstraints(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	timeNow := time.Now()
+
+	createTestServer(t, db, "com.example/server", "1.0.0", timeNow, true)
+
+	meta := func() *apiv0.RegistryExtensions {
+		return &apiv0.RegistryExtensions{
+			Status:          model.StatusActive,
+			StatusChangedAt: timeNow,
+			PublishedAt:     timeNow,
+			UpdatedAt:       timeNow,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		server    *apiv0.ServerJSON
+		meta      func() *apiv0.RegistryExtensions
+		errorType error
+	}{
+		{
+			name:      "duplicate version",
+			server:    &apiv0.ServerJSON{Name: "com.example/server", Version: "1.0.0"},
+			meta:      meta,
+			errorType: database.ErrAlreadyExists,
+		},
+		{
+			name:   "second latest version",
+			server: &apiv0.ServerJSON{Name: "com.example/server", Version: "2.0.0"},
+			meta: func() *apiv0.RegistryExtensions {
+				m := meta()
+				m.IsLatest = true
+				return m
+			},
+			errorType: database.ErrAlreadyExists,
+		},
+		{
+			name:      "invalid name format",
+			server:    &apiv0.ServerJSON{Name: "invalid-name-format", Version: "1.0.0"},
+			meta:      meta,
+			errorType: database.ErrInvalidInput,
+		},
+		{
+			name:   "invalid status",
+			server: &apiv0.ServerJSON{Name: "com.example/other", Version: "1.0.0"},
+			meta: func() *apiv0.RegistryExtensions {
+				m := meta()
+				m.Status = "unknown"
+				return m
+			},
+			errorType: database.ErrInvalidInput,
+		},
+		{
+			name:   "status changed before publish",
+			server: &apiv0.ServerJSON{Name: "com.example/other", Version: "1.0.0"},
+			meta: func() *apiv0.RegistryExtensions {
+				m := meta()
+				m.StatusChangedAt = timeNow.Add(-time.Hour)
+				return m
+			},
+			errorType: database.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := db.CreateServer(ctx, nil, tt.server, tt.meta())
+			assert.ErrorIs(t, err, tt.errorType)
+			assert.Nil(t, result)
+		})
+	}
+
+	// A non-latest version of an existing server is fine
+	_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{Name: "com.example/server", Version: "0.9.0"}, meta())
+	require.NoError(t, err)
+	count, err := db.CountServerVersions(ctx, nil, "com.example/server")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func testListServers(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	timeNow := time.Now()
+
+	for i := range 5 {
+		createTestServer(t, db, fmt.Sprintf("com.example/server-%d", i), "1.0.0", timeNow, true)
+	}
+	createTestServer(t, db, "com.example/server-1", "0.1.0", timeNow.Add(-time.Hour), false)
+	_, err := db.SetServerStatus(ctx, nil, "com.example/server-4", "1.0.0", model.StatusDeleted, nil)
+	require.NoError(t, err)
+
+	// Pages follow name and version order and skip deleted servers
+	var names []string
+	cursor := ""
+	for {
+		page, nextCursor, err := db.ListServers(ctx, nil, &database.ServerFilter{}, cursor, 2)
+		require.NoError(t, err)
+		for _, server := range page {
+			names = append(names, server.Server.Name+"@"+server.Server.Version)
+		}
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+	assert.Equal(t, []string{
+		"com.example/server-0@1.0.0",
+		"com.example/server-1@0.1.0",
+		"com.example/server-1@1.0.0",
+		"com.example/server-2@1.0.0",
+		"com.example/server-3@1.0.0",
+	}, names)
+
+	isLatest := true
+	includeDeleted := true
+	results, _, err := db.ListServers(ctx, nil, &database.ServerFilter{IsLatest: &isLatest, IncludeDeleted: &includeDeleted}, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, results, 5)
+
+	search := "SERVER-1"
+	count, estimated, err := db.CountServers(ctx, nil, &database.ServerFilter{SubstringName: &search}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.False(t, estimated)
+
+	latest, err := db.GetServerByName(ctx, nil, "com.example/server-1", false)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", latest.Server.Version)
+
+	_, err = db.GetServerByName(ctx, nil, "com.example/server-4", false)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	versions, err := db.GetAllVersionsByServerName(ctx, nil, "com.example/server-1", false)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "1.0.0", versions[0].Server.Version, "newest first")
+}
+
+func testReturnsCopies(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	createTestServer(t, db, "com.example/server", "1.0.0", time.Now(), true)
+
+	server, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/server", "1.0.0", false)
+	require.NoError(t, err)
+	server.Server.Description = "modified"
+
+	server, err = db.GetServerByNameAndVersion(ctx, nil, "com.example/server", "1.0.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, "Test server", server.Server.Description)
+}
+
+func testInTransaction(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	createTestServer(t, db, "com.example/server", "1.0.0", time.Now(), true)
+
+	// A failed transaction leaves no trace, including in the changes feed
+	errRollback := errors.New("rollback")
+	err := db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		require.NoError(t, db.AcquirePublishLock(ctx, tx, "com.example/server"))
+		require.NoError(t, db.UnmarkAsLatest(ctx, tx, "com.example/server"))
+		_, err := db.UpdateServer(ctx, tx, "com.example/server", "1.0.0", &apiv0.ServerJSON{
+			Name: "com.example/server", Version: "1.0.0", Description: "Updated",
+		})
+		require.NoError(t, err)
+		return errRollback
+	})
+	assert.ErrorIs(t, err, errRollback)
+
+	server, err := db.GetServerByName(ctx, nil, "com.example/server", false)
+	require.NoError(t, err)
+	assert.Equal(t, "Test server", server.Server.Description)
+	assert.True(t, server.Meta.Official.IsLatest)
+	changes, err := db.ListServerChanges(ctx, nil, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, changes, 1)
+
+	// A successful transaction is kept
+	err = db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := db.UpdateServer(ctx, tx, "com.example/server", "1.0.0", &apiv0.ServerJSON{
+			Name: "com.example/server", Version: "1.0.0", Description: "Updated",
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	server, err = db.GetServerByName(ctx, nil, "com.example/server", false)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", server.Server.Description)
+}
+
+func testQuarantinedVersions(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	publishedAt := time.Now().Add(-time.Hour)
+
+	createTestServer(t, db, "com.spam/server", "1.0.0", publishedAt, false)
+	createTestServer(t, db, "com.spam/server", "2.0.0", publishedAt, false)
+	createTestServer(t, db, "com.spam/server", "3.0.0", publishedAt, true)
+	deprecation := "Use 3.0.0"
+	_, err := db.SetServerStatus(ctx, nil, "com.spam/server", "1.0.0", model.StatusDeprecated, &deprecation)
+	require.NoError(t, err)
+	_, err = db.SetServerStatus(ctx, nil, "com.spam/server", "2.0.0", model.StatusDeleted, nil)
+	require.NoError(t, err)
+
+	message := "Quarantined"
+	quarantined, err := db.QuarantineServer(ctx, nil, "com.spam/server", database.BanKindGitHub, "spammer", &message)
+	require.NoError(t, err)
+	require.Len(t, quarantined, 2, "deleted versions are left alone")
+	for _, version := range quarantined {
+		assert.Equal(t, model.StatusQuarantined, version.Meta.Official.Status)
+		assert.Equal(t, &message, version.Meta.Official.StatusMessage)
+	}
+
+	// Quarantined versions are hidden like deleted ones
+	_, err = db.GetServerByName(ctx, nil, "com.spam/server", false)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	includeDeleted := true
+	all, _, err := db.ListServers(ctx, nil, &database.ServerFilter{IncludeDeleted: &includeDeleted}, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	// A second ban records the status the first one saw
+	quarantined, err = db.QuarantineServer(ctx, nil, "com.spam/server", database.BanKindNamespace, "com.spam", &message)
+	require.NoError(t, err)
+	assert.Empty(t, quarantined)
+	records, err := db.ListQuarantinedVersions(ctx, nil, database.BanKindNamespace, "com.spam")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "1.0.0", records[0].Version)
+	assert.Equal(t, model.StatusDeprecated, records[0].PriorStatus)
+	assert.Equal(t, &deprecation, records[0].PriorStatusMessage)
+	assert.Equal(t, model.StatusActive, records[1].PriorStatus)
+
+	// Versions are restored once no ban holds them, unless their status changed in the meantime
+	restored, err := db.ReleaseQuarantine(ctx, nil, database.BanKindGitHub, "spammer")
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+	_, err = db.SetServerStatus(ctx, nil, "com.spam/server", "3.0.0", model.StatusDeleted, nil)
+	require.NoError(t, err)
+	restored, err = db.ReleaseQuarantine(ctx, nil, database.BanKindNamespace, "com.spam")
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	assert.Equal(t, "1.0.0", restored[0].Server.Version)
+	assert.Equal(t, model.StatusDeprecated, restored[0].Meta.Official.Status)
+	assert.Equal(t, &deprecation, restored[0].Meta.Official.StatusMessage)
+	records, err = db.ListQuarantinedVersions(ctx, nil, database.BanKindNamespace, "com.spam")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func testStatusAndChanges(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	publishedAt := time.Now().Add(-time.Hour)
+
+	createTestServer(t, db, "com.example/server", "1.0.0", publishedAt, false)
+	createTestServer(t, db, "com.example/server", "2.0.0", publishedAt, true)
+
+	message := "Use com.example/other"
+	updated, err := db.SetAllVersionsStatus(ctx, nil, "com.example/server", model.StatusDeprecated, &message)
+	require.NoError(t, err)
+	require.Len(t, updated, 2)
+	assert.True(t, updated[0].Meta.Official.StatusChangedAt.After(publishedAt))
+
+	// Nothing changes when the status and message already match
+	_, err = db.SetAllVersionsStatus(ctx, nil, "com.example/server", model.StatusDeprecated, &message)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	tooLong := strings.Repeat("x", 501)
+	_, err = db.SetServerStatus(ctx, nil, "com.example/server", "1.0.0", model.StatusDeprecated, &tooLong)
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	changes, err := db.ListServerChanges(ctx, nil, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	assert.Equal(t, "created", changes[0].Type)
+	assert.Equal(t, "updated", changes[3].Type)
+	assert.Equal(t, model.StatusDeprecated, changes[0].Server.Meta.Official.Status, "changes show the current state")
+
+	changes, err = db.ListServerChanges(ctx, nil, changes[1].Seq, 1)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, int64(3), changes[0].Seq)
+
+	// Deleting a version is recorded in the feed with the state it was deleted in
+	beforeDelete := time.Now()
+	require.NoError(t, db.DeleteServerVersion(ctx, nil, "com.example/server", "1.0.0"))
+	assert.ErrorIs(t, db.DeleteServerVersion(ctx, nil, "com.example/server", "1.0.0"), database.ErrNotFound)
+	changes, err = db.ListServerChanges(ctx, nil, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 5)
+	assert.Equal(t, "deleted", changes[4].Type)
+	assert.Equal(t, "1.0.0", changes[4].Server.Server.Version)
+	assert.Equal(t, model.StatusDeprecated, changes[4].Server.Meta.Official.Status)
+	assert.Equal(t, "1.0.0", changes[0].Server.Server.Version, "earlier changes of a deleted version are kept")
+
+	// Reads of the past see the version until it was deleted
+	_, err = db.GetServerByNameAndVersion(ctx, nil, "com.example/server", "1.0.0", true)
+	require.ErrorIs(t, err, database.ErrNotFound)
+	includeDeleted := true
+	past, _, err := db.ListServers(ctx, nil, &database.ServerFilter{AsOf: &beforeDelete, IncludeDeleted: &includeDeleted}, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, past, 2)
+	now := time.Now()
+	current, _, err := db.ListServers(ctx, nil, &database.ServerFilter{AsOf: &now, IncludeDeleted: &includeDeleted}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, current, 1)
+	assert.Equal(t, "2.0.0", current[0].Server.Version)
+}
+
+func testDeleteServerChangesBefore(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	deleted, err := db.DeleteServerChangesBefore(ctx, nil, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	createTestServer(t, db, "com.example/server", "1.0.0", time.Now(), false)
+	createTestServer(t, db, "com.example/server", "2.0.0", time.Now(), true)
+	createTestServer(t, db, "com.example/other", "1.0.0", time.Now(), true)
+
+	// The most recent change is kept, so the latest sequence number does not go backwards
+	deleted, err = db.DeleteServerChangesBefore(ctx, nil, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	changes, err := db.ListServerChanges(ctx, nil, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, int64(3), changes[0].Seq)
+	seq, err := db.LatestChangeSeq(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), seq)
+
+	// Partitions that already exist are not created again
+	_, err = db.CreatePartitions(ctx, nil, time.Now())
+	require.NoError(t, err)
+	created, err := db.CreatePartitions(ctx, nil, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, created)
+}
+
+func testListPrunableVersions(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	base := time.Now().Add(-72 * time.Hour)
+
+	for i := range 4 {
+		createTestServer(t, db, "com.example/server", fmt.Sprintf("1.0.%d", i), base.Add(time.Duration(i)*time.Hour), i == 3)
+	}
+
+	versions, err := db.ListPrunableVersions(ctx, nil, 2, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "1.0.0", versions[0].Version)
+	assert.Equal(t, database.PruneReasonMaxVersions, versions[0].Reason)
+
+	cutoff := base.Add(150 * time.Minute)
+	versions, err = db.ListPrunableVersions(ctx, nil, 0, &cutoff, 10)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, database.PruneReasonMaxAge, versions[2].Reason)
+
+	// Versions are ranked by semantic version, not by when they were published or as strings
+	createTestServer(t, db, "com.example/backport", "2.0.0", base, true)
+	createTestServer(t, db, "com.example/backport", "1.0.10", base.Add(time.Hour), false)
+	createTestServer(t, db, "com.example/backport", "1.0.9", base.Add(2*time.Hour), false)
+	versions, err = db.ListPrunableVersions(ctx, nil, 2, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, "com.example/backport", versions[0].ServerName)
+	assert.Equal(t, "1.0.9", versions[0].Version)
+
+	versions, err = db.ListPrunableVersions(ctx, nil, 2, nil, 1)
+	require.NoError(t, err)
+	assert.Len(t, versions, 1)
+}
+
+func testListPossibleDuplicates(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	timeNow := time.Now()
+
+	for _, server := range []struct{ name, repo string }{
+		{"com.example/server-a", "https://github.com/Example/Repo.git"},
+		{"com.example/server-b", "https://github.com/example/repo/"},
+		{"com.example/server-c", "https://github.com/example/other"},
+	} {
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:       server.name,
+			Version:    "1.0.0",
+			Repository: &model.Repository{URL: server.repo, Source: "github"},
+		}, &apiv0.RegistryExtensions{Status: model.StatusActive, StatusChangedAt: timeNow, PublishedAt: timeNow, UpdatedAt: timeNow, IsLatest: true})
+		require.NoError(t, err)
+	}
+
+	groups, err := db.ListPossibleDuplicates(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "repository", groups[0].Reason)
+	assert.Equal(t, "https://github.com/example/repo", groups[0].URL)
+	assert.Equal(t, []string{"com.example/server-a", "com.example/server-b"}, groups[0].ServerNames)
+
+	other := "com.example/server-c"
+	groups, err = db.ListPossibleDuplicates(ctx, nil, &other)
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	repo := "https://github.com/example/REPO"
+	results, _, err := db.ListServers(ctx, nil, &database.ServerFilter{RepositoryURL: &repo}, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+}
+
+func testNamespaceVerifications(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	evidence := database.VerificationEvidence{CheckedAt: time.Now(), Trigger: database.EvidenceTriggerLogin, Lookup: "example.com"}
+	first, err := db.RecordNamespaceVerificationAttempt(ctx, nil, "example.com", "dns", evidence)
+	require.NoError(t, err)
+
+	// Repeated attempts add evidence to the pending request, keeping the most recent entries
+	for range 25 {
+		_, err = db.RecordNamespaceVerificationAttempt(ctx, nil, "example.com", "dns", evidence)
+		require.NoError(t, err)
+	}
+	verification, err := db.GetNamespaceVerification(ctx, nil, first.ID)
+	require.NoError(t, err)
+	assert.Len(t, verification.Evidence, 20)
+
+	approved, err := db.IsNamespaceApproved(ctx, nil, "example.com")
+	require.NoError(t, err)
+	assert.False(t, approved)
+
+	_, err = db.ReviewNamespaceVerification(ctx, nil, first.ID, database.NamespaceVerificationApproved, nil, "admin")
+	require.NoError(t, err)
+	approved, err = db.IsNamespaceApproved(ctx, nil, "example.com")
+	require.NoError(t, err)
+	assert.True(t, approved)
+
+	// After review, a new attempt opens a new request
+	second, err := db.RecordNamespaceVerificationAttempt(ctx, nil, "example.com", "dns", evidence)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	pending := database.NamespaceVerificationPending
+	verifications, err := db.ListNamespaceVerifications(ctx, nil, &pending)
+	require.NoError(t, err)
+	require.Len(t, verifications, 1)
+	assert.Equal(t, second.ID, verifications[0].ID)
+
+	_, err = db.ReviewNamespaceVerification(ctx, nil, first.ID, database.NamespaceVerificationPending, nil, "admin")
+	assert.ErrorIs(t, err, database.ErrAlreadyExists)
+	_, err = db.RecordNamespaceVerificationAttempt(ctx, nil, "example.com", "smtp", evidence)
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+	_, err = db.GetNamespaceVerification(ctx, nil, 999)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testAuditLog(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	_, err := db.RecordAuditEntry(ctx, nil, database.AuditEntry{Actor: "github-at:alice"})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	for _, entry := range []database.AuditEntry{
+		{Actor: "github-at:alice", Action: database.AuditActionPublish, Namespace: "io.github.alice", Resource: "io.github.alice/weather@1.0.0"},
+		{Actor: "github-at:bob", Action: database.AuditActionPublish, Namespace: "io.github.bob", Resource: "io.github.bob/notes@1.0.0"},
+		{Actor: "github-at:alice", Action: database.AuditActionStatus, Namespace: "io.github.alice", Details: map[string]any{"status": "deprecated", "versions": 2}},
+	} {
+		recorded, err := db.RecordAuditEntry(ctx, nil, entry)
+		require.NoError(t, err)
+		assert.NotZero(t, recorded.ID)
+		assert.False(t, recorded.CreatedAt.IsZero())
+	}
+
+	all, err := db.ListAuditEntries(ctx, nil, nil, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Less(t, all[0].ID, all[1].ID)
+	assert.Equal(t, map[string]any{"status": "deprecated", "versions": float64(2)}, all[2].Details, "details round-trip through JSON")
+
+	alice := "github-at:alice"
+	filtered, err := db.ListAuditEntries(ctx, nil, &database.AuditFilter{Actor: &alice}, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, filtered, 2)
+
+	page, err := db.ListAuditEntries(ctx, nil, &database.AuditFilter{Actor: &alice}, filtered[0].ID, 10)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, filtered[1].ID, page[0].ID)
+
+	require.NoError(t, db.UpdateAuditEntryClientIP(ctx, nil, all[0].ID, "enc:v1:k1:wrapped:sealed"))
+	updated, err := db.ListAuditEntries(ctx, nil, nil, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "enc:v1:k1:wrapped:sealed", updated[0].ClientIP)
+	require.ErrorIs(t, db.UpdateAuditEntryClientIP(ctx, nil, 999, ""), database.ErrNotFound)
+
+	future := time.Now().Add(time.Hour)
+	later, err := db.ListAuditEntries(ctx, nil, &database.AuditFilter{Since: &future}, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, later)
+
+	deleted, err := db.DeleteAuditEntriesBefore(ctx, nil, future)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	all, err = db.ListAuditEntries(ctx, nil, nil, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func testMaintenanceAndCheckpoints(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	state, err := db.GetMaintenanceState(ctx, nil)
+	require.NoError(t, err)
+	assert.False(t, state.Enabled)
+	assert.Equal(t, 300, state.RetryAfterSeconds)
+
+	message := "Upgrading"
+	state, err = db.SetMaintenanceState(ctx, nil, true, &message, 60)
+	require.NoError(t, err)
+	assert.True(t, state.Enabled)
+	_, err = db.SetMaintenanceState(ctx, nil, true, nil, 0)
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	_, err = db.GetImportCheckpoint(ctx, nil, "https://registry.example.com")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	require.NoError(t, db.SetImportCheckpoint(ctx, nil, "https://registry.example.com", 42))
+	seq, err := db.GetImportCheckpoint(ctx, nil, "https://registry.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), seq)
+}
+
+func testTryAcquireJobLock(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	lock, err := db.TryAcquireJobLock(ctx, "retention")
+	require.NoError(t, err)
+	assert.True(t, lock.Held(ctx))
+
+	_, err = db.TryAcquireJobLock(ctx, "retention")
+	assert.ErrorIs(t, err, database.ErrLockNotAcquired)
+
+	require.NoError(t, lock.Release(ctx))
+	assert.False(t, lock.Held(ctx))
+	lock, err = db.TryAcquireJobLock(ctx, "retention")
+	require.NoError(t, err)
+	require.NoError(t, lock.Release(ctx))
+}
+
+func testPackageProvenance(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	createTestServer(t, db, "com.example/server", "1.0.0", time.Now(), true)
+
+	published := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	original := []apiv0.PackageProvenance{
+		{RegistryType: model.RegistryTypeNPM, Identifier: "@example/server", Version: "1.0.0", Digest: "sha512-original", UpstreamPublishedAt: &published},
+		{RegistryType: model.RegistryTypePyPI, Identifier: "example-server", Version: "1.0.0", Files: []apiv0.ProvenanceFile{{Filename: "example_server-1.0.0.tar.gz", Digest: "sha256:abc"}}},
+	}
+	require.NoError(t, db.RecordPackageProvenance(ctx, nil, "com.example/server", "1.0.0", original))
+
+	// Recording the same package again, e.g. after an edit, keeps the original record
+	require.NoError(t, db.RecordPackageProvenance(ctx, nil, "com.example/server", "1.0.0", []apiv0.PackageProvenance{
+		{RegistryType: model.RegistryTypeNPM, Identifier: "@example/server", Version: "1.0.0", Digest: "sha512-republished"},
+		{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/example/server:1.0.0", Digest: "sha256:def"},
+	}))
+
+	provenance, err := db.GetPackageProvenance(ctx, nil, "com.example/server", "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, provenance, 3)
+	assert.Equal(t, "sha512-original", provenance[0].Digest)
+	assert.WithinDuration(t, published, *provenance[0].UpstreamPublishedAt, 0)
+	assert.False(t, provenance[0].RecordedAt.IsZero())
+	assert.Equal(t, original[1].Files, provenance[1].Files)
+	assert.Equal(t, model.RegistryTypeOCI, provenance[2].RegistryType)
+
+	// Returned records are copies
+	provenance[1].Files[0].Digest = "sha256:tampered"
+	again, err := db.GetPackageProvenance(ctx, nil, "com.example/server", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:abc", again[1].Files[0].Digest)
+
+	assert.ErrorIs(t, db.RecordPackageProvenance(ctx, nil, "com.example/missing", "1.0.0", original), database.ErrNotFound)
+
+	require.NoError(t, db.DeleteServerVersion(ctx, nil, "com.example/server", "1.0.0"))
+	provenance, err = db.GetPackageProvenance(ctx, nil, "com.example/server", "1.0.0")
+	require.NoError(t, err)
+	assert.Empty(t, provenance)
+}
+
+func testUpstreamCache(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	_, err := db.GetUpstreamCacheEntry(ctx, nil, "GET https://registry.npmjs.org/left-pad")
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	require.NoError(t, db.PutUpstreamCacheEntry(ctx, nil, database.UpstreamCacheEntry{
+		Key: "GET https://registry.npmjs.org/left-pad", StatusCode: 200,
+		Header: map[string][]string{"Content-Type": {"application/json"}}, Body: []byte(`{}`),
+		ExpiresAt: time.Now().Add(time.Minute),
+	}))
+	require.NoError(t, db.PutUpstreamCacheEntry(ctx, nil, database.UpstreamCacheEntry{
+		Key: "GET https://registry.npmjs.org/expired", StatusCode: 404, ExpiresAt: time.Now().Add(-time.Minute),
+	}))
+
+	// The cache can be used while a transaction is open, since it isn't part of it
+	err = db.InTransaction(ctx, func(ctx context.Context, _ pgx.Tx) error {
+		entry, err := db.GetUpstreamCacheEntry(ctx, nil, "GET https://registry.npmjs.org/left-pad")
+		require.NoError(t, err)
+		assert.Equal(t, 200, entry.StatusCode)
+		assert.Equal(t, []string{"application/json"}, entry.Header["Content-Type"])
+		assert.Equal(t, `{}`, string(entry.Body))
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = db.GetUpstreamCacheEntry(ctx, nil, "GET https://registry.npmjs.org/expired")
+	require.ErrorIs(t, err, database.ErrNotFound, "expired entries are not served")
+
+	deleted, err := db.DeleteExpiredUpstreamCacheEntries(ctx, nil, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func testTokenUses(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	require.NoError(t, db.RecordTokenUse(ctx, nil, "https://issuer.example.com#abc", time.Now().Add(time.Minute)))
+	require.ErrorIs(t, db.RecordTokenUse(ctx, nil, "https://issuer.example.com#abc", time.Now().Add(time.Minute)), database.ErrAlreadyExists)
+	require.NoError(t, db.RecordTokenUse(ctx, nil, "https://issuer.example.com#def", time.Now().Add(time.Minute)))
+
+	// Tokens can be recorded again once their earlier record expired
+	require.NoError(t, db.RecordTokenUse(ctx, nil, "https://issuer.example.com#old", time.Now().Add(-time.Second)))
+	require.NoError(t, db.RecordTokenUse(ctx, nil, "https://issuer.example.com#old", time.Now().Add(time.Minute)))
+}
+
+func testBulkJobs(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	_, err := db.CreateBulkJob(ctx, nil, database.BulkJob{Kind: "rename", CreatedBy: "github-at:admin"})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	_, err = db.ClaimBulkJob(ctx, nil)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	message := "spam"
+	first, err := db.CreateBulkJob(ctx, nil, database.BulkJob{
+		Kind:      database.BulkJobStatus,
+		Params:    database.BulkJobParams{Namespace: "io.github.spammer", Status: "deleted", StatusMessage: &message},
+		CreatedBy: "github-at:admin",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, database.BulkJobQueued, first.State)
+	second, err := db.CreateBulkJob(ctx, nil, database.BulkJob{Kind: database.BulkJobRevalidate, CreatedBy: "github-at:admin"})
+	require.NoError(t, err)
+
+	jobs, err := db.ListBulkJobs(ctx, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, second.ID, jobs[0].ID, "newest first")
+
+	// The oldest unfinished job is claimed, and claimed again if it was interrupted
+	claimed, err := db.ClaimBulkJob(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, claimed.ID)
+	assert.Equal(t, database.BulkJobRunning, claimed.State)
+	require.NotNil(t, claimed.StartedAt)
+
+	claimed.Total, claimed.Processed, claimed.Failed = 3, 2, 1
+	claimed.Failures = []database.BulkJobFailure{{ServerName: "io.github.spammer/a", Error: "boom"}}
+	require.NoError(t, db.UpdateBulkJob(ctx, nil, claimed))
+
+	reclaimed, err := db.ClaimBulkJob(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, reclaimed.ID)
+	assert.Zero(t, reclaimed.Processed, "progress restarts with the job")
+	assert.Empty(t, reclaimed.Failures)
+
+	finishedAt := time.Now()
+	reclaimed.State = database.BulkJobSucceeded
+	reclaimed.Total, reclaimed.Processed = 3, 3
+	reclaimed.FinishedAt = &finishedAt
+	require.NoError(t, db.UpdateBulkJob(ctx, nil, reclaimed))
+
+	stored, err := db.GetBulkJob(ctx, nil, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, database.BulkJobSucceeded, stored.State)
+	assert.Equal(t, 3, stored.Processed)
+	assert.Equal(t, "spam", *stored.Params.StatusMessage)
+
+	next, err := db.ClaimBulkJob(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, next.ID)
+
+	_, err = db.GetBulkJob(ctx, nil, 999)
+	require.ErrorIs(t, err, database.ErrNotFound)
+	require.ErrorIs(t, db.UpdateBulkJob(ctx, nil, &database.BulkJob{ID: 999, State: database.BulkJobFailed}), database.ErrNotFound)
+}
+
+func testRemoteHealth(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	err := db.PutRemoteHealth(ctx, nil, apiv0.RemoteHealth{URL: "https://a.example.com/mcp", Status: "asleep", CheckedAt: time.Now()})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	checkedAt := time.Now().Add(-time.Hour)
+	require.NoError(t, db.PutRemoteHealth(ctx, nil, apiv0.RemoteHealth{
+		URL: "https://a.example.com/mcp", Status: apiv0.RemoteHealthy, ProtocolVersion: "2025-06-18",
+		LatencyMs: 42, CheckedAt: checkedAt, LastHealthyAt: &checkedAt,
+	}))
+	require.NoError(t, db.PutRemoteHealth(ctx, nil, apiv0.RemoteHealth{
+		URL: "https://b.example.com/mcp", Status: apiv0.RemoteUnhealthy, Error: "connection refused",
+		CheckedAt: time.Now(), FailingSince: &checkedAt,
+	}))
+
+	health, err := db.GetRemoteHealth(ctx, nil, []string{"https://a.example.com/mcp", "https://b.example.com/mcp", "https://c.example.com/mcp"})
+	require.NoError(t, err)
+	require.Len(t, health, 2, "URLs that were never probed are omitted")
+	assert.Equal(t, "2025-06-18", health["https://a.example.com/mcp"].ProtocolVersion)
+	assert.Equal(t, apiv0.RemoteUnhealthy, health["https://b.example.com/mcp"].Status)
+	require.NotNil(t, health["https://b.example.com/mcp"].FailingSince)
+
+	deleted, err := db.DeleteRemoteHealthCheckedBefore(ctx, nil, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	health, err = db.GetRemoteHealth(ctx, nil, []string{"https://a.example.com/mcp", "https://b.example.com/mcp"})
+	require.NoError(t, err)
+	assert.Len(t, health, 1)
+	assert.Contains(t, health, "https://b.example.com/mcp")
+}
+
+func testServerTools(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	timeNow := time.Now()
+	for _, name := range []string{"com.example/postgres", "com.example/sqlite", "com.example/weather"} {
+		createTestServer(t, db, name, "1.0.0", timeNow, true)
+	}
+
+	err := db.PutServerTools(ctx, nil, database.ServerTools{ServerName: "com.example/postgres", Version: "1.0.0", Source: "readme"})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	require.NoError(t, db.PutServerTools(ctx, nil, database.ServerTools{
+		ServerName: "com.example/postgres", Version: "1.0.0", Source: database.ToolSourceManifest,
+		Tools: []database.ServerTool{{Name: "query", Description: "Run a read-only SQL query against PostgreSQL"}, {Name: "list_tables"}},
+	}))
+	require.NoError(t, db.PutServerTools(ctx, nil, database.ServerTools{
+		ServerName: "com.example/sqlite", Version: "1.0.0", Source: database.ToolSourceRemote,
+		Tools: []database.ServerTool{{Name: "query", Description: "Query a SQLite database"}},
+	}))
+
+	tools, err := db.GetServerTools(ctx, nil, "com.example/postgres")
+	require.NoError(t, err)
+	assert.Equal(t, database.ToolSourceManifest, tools.Source)
+	assert.Len(t, tools.Tools, 2)
+	assert.False(t, tools.IndexedAt.IsZero())
+	_, err = db.GetServerTools(ctx, nil, "com.example/weather")
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// Every keyword must match a tool name or description, case-insensitively
+	names := func(keywords ...string) []string {
+		servers, _, err := db.ListServers(ctx, nil, &database.ServerFilter{ToolKeywords: keywords}, "", 10)
+		require.NoError(t, err)
+		var names []string
+		for _, server := range servers {
+			names = append(names, server.Server.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"com.example/postgres", "com.example/sqlite"}, names("QUERY"))
+	assert.Equal(t, []string{"com.example/postgres"}, names("query", "postgres"))
+	assert.Equal(t, []string{"com.example/postgres"}, names("tables"))
+	assert.Empty(t, names("query", "forecast"))
+
+	require.NoError(t, db.DeleteServerTools(ctx, nil, "com.example/postgres"))
+	require.ErrorIs(t, db.DeleteServerTools(ctx, nil, "com.example/postgres"), database.ErrNotFound)
+	assert.Equal(t, []string{"com.example/sqlite"}, names("query"))
+}
+
+func testScreeningExceptions(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	_, err := db.PutScreeningException(ctx, nil, database.ScreeningException{ServerName: "com.example/a", CreatedBy: "admin"})
+	require.ErrorIs(t, err, database.ErrInvalidInput, "a reason is required")
+
+	_, err = db.PutScreeningException(ctx, nil, database.ScreeningException{ServerName: "com.example/b", Reason: "first", CreatedBy: "admin"})
+	require.NoError(t, err)
+	_, err = db.PutScreeningException(ctx, nil, database.ScreeningException{ServerName: "com.example/a", Reason: "vendor", CreatedBy: "admin"})
+	require.NoError(t, err)
+	replaced, err := db.PutScreeningException(ctx, nil, database.ScreeningException{ServerName: "com.example/b", Reason: "second", CreatedBy: "other"})
+	require.NoError(t, err)
+	assert.False(t, replaced.CreatedAt.IsZero())
+
+	exception, err := db.GetScreeningException(ctx, nil, "com.example/b")
+	require.NoError(t, err)
+	assert.Equal(t, "second", exception.Reason)
+	assert.Equal(t, "other", exception.CreatedBy)
+
+	exceptions, err := db.ListScreeningExceptions(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, exceptions, 2)
+	assert.Equal(t, "com.example/a", exceptions[0].ServerName)
+
+	require.NoError(t, db.DeleteScreeningException(ctx, nil, "com.example/a"))
+	require.ErrorIs(t, db.DeleteScreeningException(ctx, nil, "com.example/a"), database.ErrNotFound)
+	_, err = db.GetScreeningException(ctx, nil, "com.example/a")
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testServerDocuments(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	publishedAt := time.Now()
+
+	createTestServer(t, db, "com.example/beta", "1.0.0", publishedAt, true)
+	createTestServer(t, db, "com.example/alpha", "1.0.0", publishedAt, true)
+
+	refs, cursor, err := db.ListServerDocumentRefs(ctx, nil, "", 1)
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	assert.Equal(t, "com.example/alpha", refs[0].ServerName)
+	assert.Equal(t, "com.example/alpha:1.0.0", cursor)
+
+	// Versions point at their canonical server.json, which is stored once per distinct document
+	server, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/alpha", "1.0.0", false)
+	require.NoError(t, err)
+	document, digest, err := apiv0.ServerDocument(&server.Server)
+	require.NoError(t, err)
+	assert.Equal(t, digest, refs[0].Digest)
+	stored, err := db.GetServerDocument(ctx, nil, digest)
+	require.NoError(t, err)
+	assert.Equal(t, document, stored)
+
+	// Edits point the version at the new document and keep the old one
+	server.Server.Description = "Edited"
+	_, err = db.UpdateServer(ctx, nil, "com.example/alpha", "1.0.0", &server.Server)
+	require.NoError(t, err)
+	refs, _, err = db.ListServerDocumentRefs(ctx, nil, "", 10)
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+	assert.NotEqual(t, digest, refs[0].Digest)
+	_, err = db.GetServerDocument(ctx, nil, digest)
+	require.NoError(t, err)
+
+	// Pointing a version back at a stored document is not a change
+	changes, err := db.ListServerChanges(ctx, nil, 0, 10)
+	require.NoError(t, err)
+	putDigest, err := db.PutServerDocument(ctx, nil, "com.example/alpha", "1.0.0", document)
+	require.NoError(t, err)
+	assert.Equal(t, digest, putDigest)
+	after, err := db.ListServerChanges(ctx, nil, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, after, len(changes))
+
+	digests, err := db.ListServerDocumentDigests(ctx, nil, []string{"com.example/alpha", "com.example/missing"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"com.example/alpha": {"1.0.0": digest}}, digests)
+
+	_, err = db.PutServerDocument(ctx, nil, "com.example/missing", "1.0.0", document)
+	require.ErrorIs(t, err, database.ErrNotFound)
+	_, err = db.GetServerDocument(ctx, nil, apiv0.DocumentDigest([]byte("{}")))
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testServerCuration(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	timeNow := time.Now()
+	for _, name := range []string{"com.example/alpha", "com.example/beta", "com.example/gamma"} {
+		createTestServer(t, db, name, "1.0.0", timeNow, true)
+	}
+	position := func(p int) *int { return &p }
+
+	_, err := db.PutServerCuration(ctx, nil, database.ServerCuration{ServerName: "com.example/alpha", Labels: []string{"trending"}, UpdatedBy: "admin"})
+	require.ErrorIs(t, err, database.ErrInvalidInput, "unknown labels are rejected")
+	_, err = db.PutServerCuration(ctx, nil, database.ServerCuration{ServerName: "com.example/alpha", Labels: []string{"community", "official"}, UpdatedBy: "admin"})
+	require.ErrorIs(t, err, database.ErrInvalidInput, "a server cannot be both official and community")
+
+	_, err = db.PutServerCuration(ctx, nil, database.ServerCuration{ServerName: "com.example/gamma", Labels: []string{"featured"}, UpdatedBy: "admin"})
+	require.NoError(t, err)
+	_, err = db.PutServerCuration(ctx, nil, database.ServerCuration{ServerName: "com.example/beta", Labels: []string{"featured", "official"}, Position: position(2), UpdatedBy: "admin"})
+	require.NoError(t, err)
+	stored, err := db.PutServerCuration(ctx, nil, database.ServerCuration{ServerName: "com.example/alpha", Labels: []string{"community", "featured"}, Position: position(1), Note: "popular", UpdatedBy: "admin"})
+	require.NoError(t, err)
+	assert.False(t, stored.UpdatedAt.IsZero())
+
+	// Servers with a position come first, then the rest by name
+	curations, err := db.ListServerCurations(ctx, nil, database.CurationLabelFeatured)
+	require.NoError(t, err)
+	require.Len(t, curations, 3)
+	assert.Equal(t, "com.example/alpha", curations[0].ServerName)
+	assert.Equal(t, "com.example/beta", curations[1].ServerName)
+	assert.Equal(t, "com.example/gamma", curations[2].ServerName)
+
+	curations, err = db.ListServerCurations(ctx, nil, database.CurationLabelOfficial)
+	require.NoError(t, err)
+	require.Len(t, curations, 1)
+	assert.Equal(t, "com.example/beta", curations[0].ServerName)
+
+	byName, err := db.GetServerCurations(ctx, nil, []string{"com.example/alpha", "com.example/missing"})
+	require.NoError(t, err)
+	require.Len(t, byName, 1)
+	assert.Equal(t, "popular", byName["com.example/alpha"].Note)
+
+	label := database.CurationLabelCommunity
+	servers, _, err := db.ListServers(ctx, nil, &database.ServerFilter{CurationLabel: &label}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "com.example/alpha", servers[0].Server.Name)
+
+	require.NoError(t, db.DeleteServerCuration(ctx, nil, "com.example/alpha"))
+	require.ErrorIs(t, db.DeleteServerCuration(ctx, nil, "com.example/alpha"), database.ErrNotFound)
+	servers, _, err = db.ListServers(ctx, nil, &database.ServerFilter{CurationLabel: &label}, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, servers)
+}
+
+func testSuggestServers(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	timeNow := time.Now()
+
+	for _, name := range []string{
+		"io.github.user/filesystem",
+		"io.github.firecrawl/firecrawl-mcp",
+		"com.example/git-files",
+		"com.example/profile",
+		"com.example/fi_tools",
+		"fi.example/search",
+	} {
+		createTestServer(t, db, name, "1.0.0", timeNow, true)
+	}
+	createTestServer(t, db, "com.example/fixtures", "1.0.0", timeNow, true)
+	_, err := db.SetServerStatus(ctx, nil, "com.example/fixtures", "1.0.0", model.StatusDeleted, nil)
+	require.NoError(t, err)
+
+	// Word prefixes match, substrings inside a word don't, and deleted servers are left out
+	servers, namespaces, err := db.SuggestServers(ctx, nil, "FI", 10)
+	require.NoError(t, err)
+	var names []string
+	for _, server := range servers {
+		names = append(names, server.Name)
+	}
+	assert.Equal(t, []string{
+		"fi.example/search",
+		"com.example/fi_tools",
+		"io.github.user/filesystem",
+		"io.github.firecrawl/firecrawl-mcp",
+		"com.example/git-files",
+	}, names)
+	assert.Equal(t, []string{"fi.example", "io.github.firecrawl"}, namespaces)
+
+	servers, namespaces, err = db.SuggestServers(ctx, nil, "fi", 2)
+	require.NoError(t, err)
+	assert.Len(t, servers, 2)
+	assert.Len(t, namespaces, 2)
+
+	// LIKE wildcards and regular expression syntax match literally
+	servers, namespaces, err = db.SuggestServers(ctx, nil, "f%", 10)
+	require.NoError(t, err)
+	assert.Empty(t, servers)
+	assert.Empty(t, namespaces)
+	servers, _, err = db.SuggestServers(ctx, nil, "fi_", 10)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "com.example/fi_tools", servers[0].Name)
+}
+
+func testOrgAPIKeys(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	_, err := db.CreateOrgAPIKey(ctx, nil, database.OrgAPIKey{Organization: "com.example", Name: "ci", KeyHash: "hash-1", CreatedBy: "github-at:alice"})
+	require.ErrorIs(t, err, database.ErrInvalidInput, "at least one namespace is required")
+
+	first, err := db.CreateOrgAPIKey(ctx, nil, database.OrgAPIKey{
+		Organization: "com.example", Name: "ci", Namespaces: []string{"com.example"}, KeyHash: "hash-1", KeyPrefix: "mcpr_1", CreatedBy: "github-at:alice",
+	})
+	require.NoError(t, err)
+	assert.False(t, first.CreatedAt.IsZero())
+	_, err = db.CreateOrgAPIKey(ctx, nil, database.OrgAPIKey{
+		Organization: "com.example", Name: "ci", Namespaces: []string{"com.example"}, KeyHash: "hash-2", CreatedBy: "github-at:alice",
+	})
+	require.ErrorIs(t, err, database.ErrAlreadyExists, "names are unique within an organization")
+	second, err := db.CreateOrgAPIKey(ctx, nil, database.OrgAPIKey{
+		Organization: "com.example", Name: "release", Namespaces: []string{"com.example.tools"}, KeyHash: "hash-2", CreatedBy: "github-at:alice",
+	})
+	require.NoError(t, err)
+	_, err = db.CreateOrgAPIKey(ctx, nil, database.OrgAPIKey{
+		Organization: "org.other", Name: "ci", Namespaces: []string{"org.other"}, KeyHash: "hash-3", CreatedBy: "github-at:bob",
+	})
+	require.NoError(t, err)
+
+	keys, err := db.ListOrgAPIKeys(ctx, nil, "com.example")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, first.ID, keys[0].ID)
+
+	found, err := db.GetOrgAPIKeyByHash(ctx, nil, "hash-2")
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, found.ID)
+
+	usedAt := time.Now()
+	require.NoError(t, db.TouchOrgAPIKey(ctx, nil, first.ID, usedAt))
+	rotated, err := db.RotateOrgAPIKey(ctx, nil, first.ID, "hash-4", "mcpr_4")
+	require.NoError(t, err)
+	assert.Equal(t, "mcpr_4", rotated.KeyPrefix)
+	require.NotNil(t, rotated.RotatedAt)
+	require.NotNil(t, rotated.LastUsedAt)
+	_, err = db.GetOrgAPIKeyByHash(ctx, nil, "hash-1")
+	require.ErrorIs(t, err, database.ErrNotFound)
+	_, err = db.RotateOrgAPIKey(ctx, nil, first.ID, "hash-2", "mcpr_2")
+	require.ErrorIs(t, err, database.ErrAlreadyExists)
+
+	require.NoError(t, db.DeleteOrgAPIKey(ctx, nil, first.ID))
+	require.ErrorIs(t, db.DeleteOrgAPIKey(ctx, nil, first.ID), database.ErrNotFound)
+	_, err = db.GetOrgAPIKey(ctx, nil, first.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+	require.ErrorIs(t, db.TouchOrgAPIKey(ctx, nil, first.ID, usedAt), database.ErrNotFound)
+}
+
+func testOrgMembers(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	first, err := db.CreateOrgMember(ctx, nil, database.OrgMember{Organization: "com.example", Subject: "github-at:alice", AddedBy: "dns:example.com"})
+	require.NoError(t, err)
+	assert.False(t, first.CreatedAt.IsZero())
+	_, err = db.CreateOrgMember(ctx, nil, database.OrgMember{Organization: "com.example", Subject: "github-at:alice", AddedBy: "dns:example.com"})
+	require.ErrorIs(t, err, database.ErrAlreadyExists, "subjects are unique within an organization")
+	second, err := db.CreateOrgMember(ctx, nil, database.OrgMember{Organization: "com.example", Subject: "oidc:bob", AddedBy: "dns:example.com"})
+	require.NoError(t, err)
+	_, err = db.CreateOrgMember(ctx, nil, database.OrgMember{Organization: "org.other", Subject: "github-at:alice", AddedBy: "dns:other.org"})
+	require.NoError(t, err)
+
+	members, err := db.ListOrgMembers(ctx, nil, "com.example")
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, first.ID, members[0].ID)
+	assert.Equal(t, second.ID, members[1].ID)
+
+	found, err := db.GetOrgMemberBySubject(ctx, nil, "com.example", "oidc:bob")
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, found.ID)
+	_, err = db.GetOrgMemberBySubject(ctx, nil, "org.other", "oidc:bob")
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	require.NoError(t, db.DeleteOrgMember(ctx, nil, first.ID))
+	require.ErrorIs(t, db.DeleteOrgMember(ctx, nil, first.ID), database.ErrNotFound)
+	_, err = db.GetOrgMember(ctx, nil, first.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testOAuthClients(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	_, err := db.CreateOAuthClient(ctx, nil, database.OAuthClient{ClientID: "client-1"})
+	require.ErrorIs(t, err, database.ErrInvalidInput, "at least one redirect URI is required")
+
+	redirectURIs := []string{"http://127.0.0.1/callback"}
+	first, err := db.CreateOAuthClient(ctx, nil, database.OAuthClient{ClientID: "client-1", ClientName: "Example", RedirectURIs: redirectURIs})
+	require.NoError(t, err)
+	assert.False(t, first.CreatedAt.IsZero())
+	redirectURIs[0] = "https://changed.example.com/"
+	_, err = db.CreateOAuthClient(ctx, nil, database.OAuthClient{ClientID: "client-1", RedirectURIs: redirectURIs})
+	require.ErrorIs(t, err, database.ErrAlreadyExists)
+	_, err = db.CreateOAuthClient(ctx, nil, database.OAuthClient{ClientID: "client-2", RedirectURIs: redirectURIs})
+	require.NoError(t, err)
+
+	found, err := db.GetOAuthClient(ctx, nil, "client-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://127.0.0.1/callback"}, found.RedirectURIs, "stored clients do not share the caller's slice")
+	clients, err := db.ListOAuthClients(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, clients, 2)
+	assert.Equal(t, "client-1", clients[0].ClientID)
+
+	require.NoError(t, db.DeleteOAuthClient(ctx, nil, "client-1"))
+	require.ErrorIs(t, db.DeleteOAuthClient(ctx, nil, "client-1"), database.ErrNotFound)
+	_, err = db.GetOAuthClient(ctx, nil, "client-1")
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testEvents(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	timeNow := time.Now()
+
+	seq, err := db.LatestChangeSeq(ctx, nil)
+	require.NoError(t, err)
+	assert.Zero(t, seq)
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	events := make(chan database.Event, 10)
+	ready := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- db.ListenForEvents(listenCtx, func() { close(ready) }, func(event database.Event) { events <- event })
+	}()
+	<-ready
+
+	createTestServer(t, db, "com.example/alpha", "1.0.0", timeNow, true)
+	assert.Equal(t, database.Event{Type: database.EventServerChange, Seq: 1}, <-events)
+
+	// Events of a rolled back transaction are discarded with it
+	err = db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := db.SetMaintenanceState(ctx, tx, true, nil, 60)
+		require.NoError(t, err)
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+	_, err = db.SetMaintenanceState(ctx, nil, false, nil, 60)
+	require.NoError(t, err)
+	assert.Equal(t, database.Event{Type: database.EventMaintenance}, <-events)
+	assert.Empty(t, events)
+
+	seq, err = db.LatestChangeSeq(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), seq)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	createTestServer(t, db, "com.example/beta", "1.0.0", timeNow, true)
+	assert.Empty(t, events, "listeners are removed when their context is done")
+}
+
+func testQuarantinedPublishes(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	err := db.QuarantinePublish(ctx, nil, database.QuarantinedPublish{AttemptID: 1})
+	require.ErrorIs(t, err, database.ErrNotFound, "quarantines belong to an attempt")
+
+	attempt, err := db.RecordPublishAttempt(ctx, nil, database.PublishAttempt{Identity: "github-at:alice", ServerName: "io.github.alice/weather", Version: "1.0.0", Status: 422})
+	require.NoError(t, err)
+	got, err := db.GetPublishAttempt(ctx, nil, attempt.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "io.github.alice/weather", got.ServerName)
+	_, err = db.GetPublishAttempt(ctx, nil, attempt.ID+1)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	server := apiv0.ServerJSON{Name: "io.github.alice/weather", Version: "1.0.0", Remotes: []model.Transport{{Type: "streamable-http", URL: "not a url"}}}
+	require.NoError(t, db.QuarantinePublish(ctx, nil, database.QuarantinedPublish{
+		AttemptID:        attempt.ID,
+		Server:           server,
+		ValidationIssues: []validator.ValidationIssue{{Type: validator.ValidationIssueTypeSemantic, Path: "remotes[0].url", Message: "invalid URL", Severity: validator.ValidationIssueSeverityError}},
+	}))
+	server.Remotes[0].URL = "https://example.com/mcp"
+
+	quarantined, err := db.GetQuarantinedPublish(ctx, nil, attempt.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "not a url", quarantined.Server.Remotes[0].URL, "the stored server.json is a copy")
+	require.Len(t, quarantined.ValidationIssues, 1)
+	assert.Equal(t, "remotes[0].url", quarantined.ValidationIssues[0].Path)
+	assert.False(t, quarantined.QuarantinedAt.IsZero())
+
+	deleted, err := db.DeleteQuarantinedPublishesBefore(ctx, nil, quarantined.QuarantinedAt)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+	deleted, err = db.DeleteQuarantinedPublishesBefore(ctx, nil, quarantined.QuarantinedAt.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	_, err = db.GetQuarantinedPublish(ctx, nil, attempt.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// Deleting an attempt deletes its quarantine
+	require.NoError(t, db.QuarantinePublish(ctx, nil, database.QuarantinedPublish{AttemptID: attempt.ID, Server: server}))
+	deleted, err = db.DeletePublishAttemptsBefore(ctx, nil, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	_, err = db.GetQuarantinedPublish(ctx, nil, attempt.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testPublishAttempts(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	var ids []int64
+	for _, attempt := range []database.PublishAttempt{
+		{Identity: "github-at:alice", ServerName: "io.github.alice/weather", Version: "1.0.0", Status: 200},
+		{Identity: "github-at:bob", ServerName: "io.github.bob/notes", Version: "1.0.0", Status: 403},
+		{Identity: "github-at:alice", ServerName: "io.github.alice/weather", Version: "1.0.1", Status: 422},
+	} {
+		recorded, err := db.RecordPublishAttempt(ctx, nil, attempt)
+		require.NoError(t, err)
+		assert.False(t, recorded.AttemptedAt.IsZero())
+		ids = append(ids, recorded.ID)
+	}
+
+	// Attempts are listed newest first, one identity at a time
+	attempts, err := db.ListPublishAttempts(ctx, nil, "github-at:alice", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	assert.Equal(t, ids[2], attempts[0].ID)
+	assert.Equal(t, 422, attempts[0].Status)
+
+	attempts, err = db.ListPublishAttempts(ctx, nil, "github-at:alice", ids[2], 10)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, ids[0], attempts[0].ID)
+
+	deleted, err := db.DeletePublishAttemptsBefore(ctx, nil, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+}
+
+func testIdentityBans(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	_, err := db.PutIdentityBan(ctx, nil, database.IdentityBan{Kind: "email", Value: "spammer", Reason: "spam", CreatedBy: "admin"})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+	_, err = db.PutIdentityBan(ctx, nil, database.IdentityBan{Kind: database.BanKindGitHub, Value: "spammer", CreatedBy: "admin"})
+	require.ErrorIs(t, err, database.ErrInvalidInput, "a reason is required")
+
+	_, err = db.PutIdentityBan(ctx, nil, database.IdentityBan{Kind: database.BanKindNamespace, Value: "com.spam", Reason: "spam", CreatedBy: "admin"})
+	require.NoError(t, err)
+	_, err = db.PutIdentityBan(ctx, nil, database.IdentityBan{Kind: database.BanKindGitHub, Value: "spammer", Reason: "spam", CreatedBy: "admin"})
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(time.Hour)
+	replaced, err := db.PutIdentityBan(ctx, nil, database.IdentityBan{Kind: database.BanKindGitHub, Value: "spammer", Reason: "malware", ExpiresAt: &expiresAt, CreatedBy: "other"})
+	require.NoError(t, err)
+	assert.False(t, replaced.CreatedAt.IsZero())
+
+	bans, err := db.ListIdentityBans(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, bans, 2)
+	assert.Equal(t, database.BanKindGitHub, bans[0].Kind, "bans are ordered by kind and value")
+	assert.Equal(t, "malware", bans[0].Reason)
+	require.NotNil(t, bans[0].ExpiresAt)
+	assert.WithinDuration(t, expiresAt, *bans[0].ExpiresAt, time.Millisecond)
+
+	// Bans are found by the lowercased identities and namespaces of a token, or by wildcard prefixes
+	_, err = db.PutIdentityBan(ctx, nil, database.IdentityBan{Kind: database.BanKindOIDC, Value: "Subject", Reason: "spam", CreatedBy: "admin"})
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		query database.IdentityBanQuery
+		want  []string
+	}{
+		{database.IdentityBanQuery{GitHub: []string{"spammer"}}, []string{"spammer"}},
+		{database.IdentityBanQuery{Namespaces: []string{"com.spam", "com"}}, []string{"com.spam"}},
+		{database.IdentityBanQuery{OIDC: []string{"subject"}}, nil},
+		{database.IdentityBanQuery{OIDC: []string{"Subject"}}, []string{"Subject"}},
+		{database.IdentityBanQuery{Prefixes: []string{"io.github.spam"}}, []string{"spammer"}},
+		{database.IdentityBanQuery{Prefixes: []string{"com.spam/"}}, []string{"com.spam"}},
+		{database.IdentityBanQuery{Prefixes: []string{"com.spam/server"}}, nil},
+		{database.IdentityBanQuery{GitHub: []string{"alice"}, Namespaces: []string{"io.github.alice"}}, nil},
+	} {
+		found, err := db.FindIdentityBans(ctx, nil, tc.query)
+		require.NoError(t, err)
+		var values []string
+		for _, ban := range found {
+			values = append(values, ban.Value)
+		}
+		assert.Equal(t, tc.want, values, "%+v", tc.query)
+	}
+	require.NoError(t, db.DeleteIdentityBan(ctx, nil, database.BanKindOIDC, "Subject"))
+
+	require.NoError(t, db.DeleteIdentityBan(ctx, nil, database.BanKindGitHub, "spammer"))
+	require.ErrorIs(t, db.DeleteIdentityBan(ctx, nil, database.BanKindGitHub, "spammer"), database.ErrNotFound)
+	bans, err = db.ListIdentityBans(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, bans, 1)
+}
+
+func testServerStaleness(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	detectedAt := time.Now().Add(-time.Hour)
+
+	err := db.PutServerStaleness(ctx, nil, database.ServerStaleness{ServerName: "com.example/a", Reasons: []string{"abandoned"}})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	require.NoError(t, db.PutServerStaleness(ctx, nil, database.ServerStaleness{
+		ServerName: "com.example/b", Reasons: []string{database.StaleReasonRepositoryArchived},
+	}))
+	require.NoError(t, db.PutServerStaleness(ctx, nil, database.ServerStaleness{
+		ServerName: "com.example/a", Reasons: []string{database.StaleReasonRepositoryDeleted}, DetectedAt: detectedAt,
+	}))
+
+	stale, err := db.GetServerStaleness(ctx, nil, []string{"com.example/a", "com.example/missing"})
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, []string{database.StaleReasonRepositoryDeleted}, stale["com.example/a"].Reasons)
+
+	// Servers are listed in the order they were found stale
+	all, err := db.ListServerStaleness(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "com.example/a", all[0].ServerName)
+	assert.False(t, all[1].DetectedAt.IsZero(), "the detection time defaults to now")
+
+	require.NoError(t, db.DeleteServerStaleness(ctx, nil, "com.example/a"))
+	require.ErrorIs(t, db.DeleteServerStaleness(ctx, nil, "com.example/a"), database.ErrNotFound)
+}
+
+func testArtifactMirrors(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	createTestServer(t, db, "com.example/server", "1.0.0", time.Now(), false)
+	createTestServer(t, db, "com.example/server", "1.0.1", time.Now(), true)
+
+	_, err := db.NextArtifactMirror(ctx, nil)
+	require.ErrorIs(t, err, database.ErrNotFound)
+	require.ErrorIs(t, db.QueueArtifactMirror(ctx, nil, "com.example/missing", "1.0.0"), database.ErrNotFound)
+
+	require.NoError(t, db.QueueArtifactMirror(ctx, nil, "com.example/server", "1.0.0"))
+	require.NoError(t, db.QueueArtifactMirror(ctx, nil, "com.example/server", "1.0.0"), "queueing a version again is harmless")
+	require.NoError(t, db.QueueArtifactMirror(ctx, nil, "com.example/server", "1.0.1"))
+
+	first, err := db.NextArtifactMirror(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", first.Version, "the oldest mirror runs first")
+
+	// A mirror that is retried later waits until it is due
+	retried, err := db.RetryArtifactMirror(ctx, nil, first.ID, "status 503", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, retried.Attempts)
+	assert.Equal(t, "status 503", retried.Error)
+	next, err := db.NextArtifactMirror(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.1", next.Version, "mirrors waiting for a retry are skipped")
+
+	require.NoError(t, db.DeleteArtifactMirror(ctx, nil, next.ID))
+	require.ErrorIs(t, db.DeleteArtifactMirror(ctx, nil, next.ID), database.ErrNotFound)
+	_, err = db.NextArtifactMirror(ctx, nil)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// Deleting a version drops it from the queue
+	require.NoError(t, db.DeleteServerVersion(ctx, nil, "com.example/server", "1.0.0"))
+	_, err = db.RetryArtifactMirror(ctx, nil, first.ID, "status 503", time.Now())
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testPendingPublishes(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	_, err := db.NextPendingPublish(ctx, nil)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	server := apiv0.ServerJSON{Name: "com.example/server", Description: "Test server", Version: "1.0.0"}
+	first, err := db.CreatePendingPublish(ctx, nil, database.PendingPublish{Server: server, SubmittedBy: "github-at:alice"})
+	require.NoError(t, err)
+	assert.Equal(t, database.PublishPending, first.State)
+	_, err = db.CreatePendingPublish(ctx, nil, database.PendingPublish{Server: server, SubmittedBy: "github-at:alice"})
+	require.ErrorIs(t, err, database.ErrAlreadyExists, "a version can only be pending once")
+
+	server.Version = "1.0.1"
+	second, err := db.CreatePendingPublish(ctx, nil, database.PendingPublish{Server: server, SubmittedBy: "github-at:alice"})
+	require.NoError(t, err)
+
+	next, err := db.NextPendingPublish(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, next.ID, "the oldest publish is processed first")
+	assert.Equal(t, "Test server", next.Server.Description)
+
+	// A publish that is retried later waits until it is due
+	retried, err := db.RetryPendingPublish(ctx, nil, first.ID, "GitHub API status 503", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, database.PublishPending, retried.State)
+	assert.Equal(t, 1, retried.Attempts)
+	assert.Equal(t, "GitHub API status 503", retried.Error)
+	next, err = db.NextPendingPublish(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, next.ID, "publishes waiting for a retry are skipped")
+	retried, err = db.RetryPendingPublish(ctx, nil, first.ID, "GitHub API status 503", time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 2, retried.Attempts)
+	next, err = db.NextPendingPublish(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, next.ID, "due retries are processed in order")
+
+	_, err = db.FinishPendingPublish(ctx, nil, first.ID, "done", "")
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+	finished, err := db.FinishPendingPublish(ctx, nil, first.ID, database.PublishRejected, "package not found")
+	require.NoError(t, err)
+	assert.Equal(t, "package not found", finished.Error)
+	require.NotNil(t, finished.FinishedAt)
+	_, err = db.FinishPendingPublish(ctx, nil, first.ID, database.PublishActive, "")
+	require.ErrorIs(t, err, database.ErrNotFound, "finished publishes cannot be finished again")
+	_, err = db.RetryPendingPublish(ctx, nil, first.ID, "", time.Now())
+	require.ErrorIs(t, err, database.ErrNotFound, "finished publishes cannot be retried")
+
+	next, err = db.NextPendingPublish(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, next.ID)
+
+	// Only finished publishes are removed
+	deleted, err := db.DeletePendingPublishesBefore(ctx, nil, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	_, err = db.GetPendingPublish(ctx, nil, first.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+	_, err = db.GetPendingPublish(ctx, nil, second.ID)
+	require.NoError(t, err)
+}
+
+func testServerAliases(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	createTestServer(t, db, "com.example/new", "1.0.0", time.Now(), true)
+	expiresAt := time.Now().Add(time.Hour)
+
+	_, err := db.CreateServerAlias(ctx, nil, database.ServerAlias{Alias: "com.example/new", ServerName: "com.example/new", ExpiresAt: expiresAt})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+	_, err = db.CreateServerAlias(ctx, nil, database.ServerAlias{Alias: "com.example/old", ServerName: "com.example/missing", ExpiresAt: expiresAt})
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	alias, err := db.CreateServerAlias(ctx, nil, database.ServerAlias{Alias: "com.example/old", ServerName: "com.example/new", CreatedBy: "github-at:alice", ExpiresAt: expiresAt})
+	require.NoError(t, err)
+	assert.False(t, alias.CreatedAt.IsZero())
+	_, err = db.CreateServerAlias(ctx, nil, database.ServerAlias{Alias: "com.example/expired", ServerName: "com.example/new", ExpiresAt: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+
+	// Renames are recorded in the changes feed
+	changes, err := db.ListServerChanges(ctx, nil, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "renamed", changes[1].Type)
+
+	found, err := db.GetServerAlias(ctx, nil, "com.example/old")
+	require.NoError(t, err)
+	assert.Equal(t, "com.example/new", found.ServerName)
+	_, err = db.GetServerAlias(ctx, nil, "com.example/expired")
+	require.ErrorIs(t, err, database.ErrNotFound, "expired aliases no longer redirect")
+	aliases, err := db.ListServerAliases(ctx, nil, "com.example/new")
+	require.NoError(t, err)
+	assert.Len(t, aliases, 1)
+
+	// Renaming back to an alias drops it rather than pointing it at itself
+	retargeted, err := db.RetargetServerAliases(ctx, nil, "com.example/new", "com.example/old")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), retargeted)
+	_, err = db.GetServerAlias(ctx, nil, "com.example/old")
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	require.NoError(t, db.DeleteServerAlias(ctx, nil, "com.example/expired"))
+	require.ErrorIs(t, db.DeleteServerAlias(ctx, nil, "com.example/expired"), database.ErrNotFound)
+}
+
+func testWebhookSubscriptions(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	_, err := db.CreateWebhookSubscription(ctx, nil, database.WebhookSubscription{URL: "https://consumer.example.com/hook", Format: "xml", CreatedBy: "admin"})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	created, err := db.CreateWebhookSubscription(ctx, nil, database.WebhookSubscription{
+		URL: "https://consumer.example.com/hook", EventTypes: []string{"created"}, Format: database.WebhookFormatCloudEvents, Secret: "first", CreatedBy: "admin",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, created.Namespaces)
+	assert.Equal(t, "first", created.Secret)
+	_, err = db.CreateWebhookSubscription(ctx, nil, database.WebhookSubscription{URL: "https://other.example.com/hook", Format: database.WebhookFormatMinimal, CreatedBy: "admin"})
+	require.NoError(t, err)
+
+	created.Namespaces = []string{"io.github.alice"}
+	created.Format = database.WebhookFormatFull
+	created.Secret = "ignored"
+	require.NoError(t, db.UpdateWebhookSubscription(ctx, nil, created))
+	require.NoError(t, db.RecordWebhookDelivery(ctx, nil, created.ID, 7, "connection refused"))
+	require.NoError(t, db.UpdateWebhookSubscriptionSecret(ctx, nil, created.ID, "second"))
+
+	stored, err := db.GetWebhookSubscription(ctx, nil, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"io.github.alice"}, stored.Namespaces)
+	assert.Equal(t, database.WebhookFormatFull, stored.Format)
+	assert.Equal(t, int64(7), stored.LastSeq)
+	assert.Equal(t, "connection refused", stored.LastError)
+	assert.Equal(t, "second", stored.Secret)
+	require.NotNil(t, stored.LastAttemptAt)
+
+	subscriptions, err := db.ListWebhookSubscriptions(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 2)
+	assert.Equal(t, created.ID, subscriptions[0].ID)
+
+	require.NoError(t, db.DeleteWebhookSubscription(ctx, nil, created.ID))
+	require.ErrorIs(t, db.DeleteWebhookSubscription(ctx, nil, created.ID), database.ErrNotFound)
+	require.ErrorIs(t, db.RecordWebhookDelivery(ctx, nil, created.ID, 8, ""), database.ErrNotFound)
+	require.ErrorIs(t, db.UpdateWebhookSubscriptionSecret(ctx, nil, created.ID, "third"), database.ErrNotFound)
+}
+
+func testAnnouncements(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	startsAt := time.Now().Add(time.Hour)
+	endsAt := startsAt.Add(-time.Minute)
+
+	_, err := db.CreateAnnouncement(ctx, nil, database.AnnouncementRecord{Announcement: apiv0.Announcement{Message: "Hi", Severity: "urgent"}})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+	_, err = db.CreateAnnouncement(ctx, nil, database.AnnouncementRecord{Announcement: apiv0.Announcement{Message: "Hi", Severity: "info", StartsAt: &startsAt, EndsAt: &endsAt}})
+	require.ErrorIs(t, err, database.ErrInvalidInput, "announcements must end after they start")
+
+	created, err := db.CreateAnnouncement(ctx, nil, database.AnnouncementRecord{
+		Announcement: apiv0.Announcement{Message: "Maintenance tonight", Severity: "warning", StartsAt: &startsAt}, CreatedBy: "admin",
+	})
+	require.NoError(t, err)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	created.Severity = "critical"
+	created.StartsAt = nil
+	require.NoError(t, db.UpdateAnnouncement(ctx, nil, created))
+	stored, err := db.GetAnnouncement(ctx, nil, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "critical", stored.Severity)
+	assert.Nil(t, stored.StartsAt)
+
+	announcements, err := db.ListAnnouncements(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, announcements, 1)
+
+	require.NoError(t, db.DeleteAnnouncement(ctx, nil, created.ID))
+	require.ErrorIs(t, db.DeleteAnnouncement(ctx, nil, created.ID), database.ErrNotFound)
+	require.ErrorIs(t, db.UpdateAnnouncement(ctx, nil, created), database.ErrNotFound)
+}
+
+func testCollections(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	first, err := db.CreateCollection(ctx, nil, database.Collection{Title: "Databases", Owner: "github-at:alice"})
+	require.NoError(t, err)
+	assert.Empty(t, first.Items)
+	second, err := db.CreateCollection(ctx, nil, database.Collection{Title: "Search", Owner: "github-at:bob"})
+	require.NoError(t, err)
+	_, err = db.CreateCollection(ctx, nil, database.Collection{Title: "Files", Owner: "github-at:alice"})
+	require.NoError(t, err)
+
+	first.Items = []database.CollectionItem{{ServerName: "com.example/postgres", Note: "Read-only mode"}, {ServerName: "com.example/sqlite"}}
+	require.NoError(t, db.UpdateCollection(ctx, nil, first))
+	stored, err := db.GetCollection(ctx, nil, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.Items, stored.Items, "items keep the curator's order")
+
+	collections, err := db.ListCollections(ctx, nil, "github-at:alice", 0, 1)
+	require.NoError(t, err)
+	require.Len(t, collections, 1)
+	assert.Equal(t, first.ID, collections[0].ID)
+	collections, err = db.ListCollections(ctx, nil, "", first.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, collections, 2)
+	assert.Equal(t, second.ID, collections[0].ID)
+
+	require.NoError(t, db.DeleteCollection(ctx, nil, first.ID))
+	require.ErrorIs(t, db.DeleteCollection(ctx, nil, first.ID), database.ErrNotFound)
+	_, err = db.GetCollection(ctx, nil, first.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testPreviews(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	server := apiv0.ServerJSON{Name: "com.example/server", Description: "Preview", Version: "1.0.0"}
+
+	created, err := db.CreatePreview(ctx, nil, database.Preview{ID: "abc", Server: server, SubmittedBy: "github-oidc:octo-org", ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.False(t, created.CreatedAt.IsZero())
+	_, err = db.CreatePreview(ctx, nil, database.Preview{ID: "abc", Server: server, ExpiresAt: time.Now().Add(time.Hour)})
+	require.ErrorIs(t, err, database.ErrAlreadyExists)
+	_, err = db.CreatePreview(ctx, nil, database.Preview{ID: "old", Server: server, ExpiresAt: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+
+	preview, err := db.GetPreview(ctx, nil, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "Preview", preview.Server.Description)
+	_, err = db.GetPreview(ctx, nil, "old")
+	require.ErrorIs(t, err, database.ErrNotFound, "expired previews are not served")
+
+	deleted, err := db.DeleteExpiredPreviews(ctx, nil, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func testMetrics(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	bucket := time.Now().UTC().Truncate(database.MetricBucket)
+
+	require.NoError(t, db.IncrementMetric(ctx, nil, database.MetricPublishes, bucket.Add(time.Minute), 1))
+	require.NoError(t, db.IncrementMetric(ctx, nil, database.MetricPublishes, bucket.Add(2*time.Minute), 2))
+	require.NoError(t, db.IncrementMetric(ctx, nil, database.MetricPublishes, bucket.Add(-database.MetricBucket), 5))
+	require.NoError(t, db.IncrementMetric(ctx, nil, database.MetricFailedPublishes, bucket, 1))
+
+	counts, err := db.ListMetricCounts(ctx, nil, database.MetricPublishes, bucket.Add(-database.MetricBucket), bucket.Add(database.MetricBucket))
+	require.NoError(t, err)
+	require.Len(t, counts, 2)
+	assert.True(t, counts[0].Bucket.Equal(bucket.Add(-database.MetricBucket)), "oldest bucket first")
+	assert.Equal(t, int64(5), counts[0].Count)
+	assert.Equal(t, int64(3), counts[1].Count, "increments in the same bucket add up")
+
+	counts, err = db.ListMetricCounts(ctx, nil, database.MetricPublishes, bucket, bucket)
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+}
+
+func testInstallReports(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	createTestServer(t, db, "com.example/server", "1.0.0", time.Now(), true)
+	reportedAt := time.Now().Add(-time.Hour)
+
+	err := db.PutInstallReport(ctx, nil, database.InstallReport{ServerName: "com.example/missing", Version: "1.0.0", Reporter: "a", Outcome: database.InstallOutcomeSuccess, ReportedAt: reportedAt})
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	for _, report := range []database.InstallReport{
+		{Reporter: "a", Outcome: database.InstallOutcomeFailure},
+		{Reporter: "a", Outcome: database.InstallOutcomeSuccess},
+		{Reporter: "b", Outcome: database.InstallOutcomeFailure},
+	} {
+		report.ServerName, report.Version, report.ReportedAt = "com.example/server", "1.0.0", reportedAt
+		require.NoError(t, db.PutInstallReport(ctx, nil, report))
+	}
+
+	// A reporter's later report replaces its earlier one
+	counts, err := db.CountInstallReports(ctx, nil, []string{"com.example/server"}, reportedAt.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, database.InstallReportCount{Reports: 2, Successes: 1}, counts["com.example/server"]["1.0.0"])
+
+	counts, err = db.CountInstallReports(ctx, nil, []string{"com.example/server"}, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+
+	deleted, err := db.DeleteInstallReportsBefore(ctx, nil, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
+
+func testValidationPolicies(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	_, err := db.PutValidationPolicy(ctx, nil, database.ValidationPolicy{Namespace: "com.bank", UpdatedBy: "admin"})
+	require.ErrorIs(t, err, database.ErrInvalidInput, "a reason is required")
+
+	_, err = db.PutValidationPolicy(ctx, nil, database.ValidationPolicy{Namespace: "com.bank", RequirePackageHashes: true, Reason: "regulated", UpdatedBy: "admin"})
+	require.NoError(t, err)
+	stored, err := db.PutValidationPolicy(ctx, nil, database.ValidationPolicy{
+		Namespace: "com.bank", AllowHTTPRemotes: true, BlockingLintRules: []string{"missing-icon"}, Reason: "internal", UpdatedBy: "admin",
+	})
+	require.NoError(t, err)
+	assert.False(t, stored.UpdatedAt.IsZero())
+	_, err = db.PutValidationPolicy(ctx, nil, database.ValidationPolicy{Namespace: "ai.acme", Reason: "internal", UpdatedBy: "admin"})
+	require.NoError(t, err)
+
+	policies, err := db.ListValidationPolicies(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "ai.acme", policies[0].Namespace)
+	assert.Empty(t, policies[0].BlockingLintRules)
+	assert.True(t, policies[1].AllowHTTPRemotes)
+	assert.False(t, policies[1].RequirePackageHashes, "a policy is replaced as a whole")
+
+	require.NoError(t, db.DeleteValidationPolicy(ctx, nil, "com.bank"))
+	require.ErrorIs(t, db.DeleteValidationPolicy(ctx, nil, "com.bank"), database.ErrNotFound)
+}
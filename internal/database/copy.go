@@ -0,0 +1,304 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrTargetNotEmpty is returned when copying into a database that already holds registry data
+var ErrTargetNotEmpty = errors.New("target database is not empty")
+
+// TableCopy reports how many rows of a table were read from the source database and written to the target
+type TableCopy struct {
+	Table      string
+	SourceRows int64
+	CopiedRows int64
+}
+
+// seededTables hold rows inserted by migrations rather than by the registry, which are replaced by the
+// source's rows when copying
+var seededTables = []string{"maintenance_mode"}
+
+// CopyPostgreSQL copies every table of source into target, which must have no registry data yet. Both
+// databases must be on the same schema version, which opening source read-only and target read-write ensures.
+// Source is read from a single snapshot, so the copy is consistent while the source registry keeps running.
+// Rows are streamed table by table in foreign key order, with target triggers disabled so copied rows do not
+// record changes or notifications again, and the row counts of both databases are compared before the target
+// commits. Sequences are advanced to their source values. With dryRun set, only the source rows are counted
+// and the target checked. report is called as each table is done.
+func CopyPostgreSQL(ctx context.Context, source, target *PostgreSQL, dryRun bool, report func(TableCopy)) ([]TableCopy, error) {
+	sourceTx, err := source.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin source snapshot: %w", err)
+	}
+	defer func() { _ = sourceTx.Rollback(ctx) }()
+
+	targetTx, err := target.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin target transaction: %w", err)
+	}
+	defer func() { _ = targetTx.Rollback(ctx) }()
+
+	tables, err := listCopyTables(ctx, sourceTx)
+	if err != nil {
+		return nil, err
+	}
+	if err := prepareCopyTarget(ctx, targetTx, tables); err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		if err := createCopyPartitions(ctx, sourceTx, targetTx); err != nil {
+			return nil, err
+		}
+	}
+
+	copies := make([]TableCopy, 0, len(tables))
+	for _, table := range tables {
+		copied := TableCopy{Table: table}
+		if copied.SourceRows, err = countRows(ctx, sourceTx, table); err != nil {
+			return copies, err
+		}
+		if !dryRun {
+			if copied.CopiedRows, err = copyTable(ctx, sourceTx, targetTx, table); err != nil {
+				return copies, err
+			}
+			if copied.CopiedRows != copied.SourceRows {
+				return copies, fmt.Errorf("copied %d rows of %s, but the source has %d", copied.CopiedRows, table, copied.SourceRows)
+			}
+		}
+		copies = append(copies, copied)
+		if report != nil {
+			report(copied)
+		}
+	}
+	if dryRun {
+		return copies, nil
+	}
+
+	// Count again once everything is written, in case a table was written to by a trigger or cascade
+	for _, copied := range copies {
+		targetRows, err := countRows(ctx, targetTx, copied.Table)
+		if err != nil {
+			return copies, err
+		}
+		if targetRows != copied.SourceRows {
+			return copies, fmt.Errorf("target has %d rows of %s, but the source has %d", targetRows, copied.Table, copied.SourceRows)
+		}
+	}
+
+	if err := copySequences(ctx, sourceTx, targetTx); err != nil {
+		return copies, err
+	}
+	if err := targetTx.Commit(ctx); err != nil {
+		return copies, fmt.Errorf("failed to commit target transaction: %w", err)
+	}
+	return copies, nil
+}
+
+// listCopyTables lists the registry's tables, excluding partitions and the migrations table, ordered so that
+// tables come after the tables their foreign keys reference
+func listCopyTables(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT relname
+		FROM pg_class
+		WHERE relnamespace = current_schema()::regnamespace
+		  AND relkind IN ('r', 'p')
+		  AND NOT relispartition
+		  AND relname <> 'schema_migrations'
+		ORDER BY relname`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT DISTINCT child.relname, parent.relname
+		FROM pg_constraint con
+		JOIN pg_class child ON child.oid = con.conrelid
+		JOIN pg_class parent ON parent.oid = con.confrelid
+		WHERE con.contype = 'f'
+		  AND con.connamespace = current_schema()::regnamespace
+		  AND child.oid <> parent.oid`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+	}
+	references := make(map[string][]string)
+	var child, parent string
+	if _, err := pgx.ForEachRow(rows, []any{&child, &parent}, func() error {
+		references[child] = append(references[child], parent)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+	}
+
+	// Repeatedly take the tables whose referenced tables are all taken, keeping the alphabetical order
+	ordered := make([]string, 0, len(tables))
+	for len(tables) > 0 {
+		remaining := tables[:0:0]
+		for _, table := range tables {
+			ready := !slices.ContainsFunc(references[table], func(parent string) bool {
+				return slices.Contains(tables, parent) && !slices.Contains(ordered, parent)
+			})
+			if ready {
+				ordered = append(ordered, table)
+			} else {
+				remaining = append(remaining, table)
+			}
+		}
+		if len(remaining) == len(tables) {
+			return nil, fmt.Errorf("foreign keys between %s form a cycle", strings.Join(remaining, ", "))
+		}
+		tables = remaining
+	}
+	return ordered, nil
+}
+
+// prepareCopyTarget checks that the target holds no registry data, and removes the rows migrations seeded
+func prepareCopyTarget(ctx context.Context, tx pgx.Tx, tables []string) error {
+	for _, table := range tables {
+		if slices.Contains(seededTables, table) {
+			if _, err := tx.Exec(ctx, "DELETE FROM "+pgx.Identifier{table}.Sanitize()); err != nil {
+				return fmt.Errorf("failed to clear %s: %w", table, err)
+			}
+			continue
+		}
+		var hasRows bool
+		if err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+pgx.Identifier{table}.Sanitize()+")").Scan(&hasRows); err != nil {
+			return fmt.Errorf("failed to check %s: %w", table, err)
+		}
+		if hasRows {
+			return fmt.Errorf("%w: %s has rows", ErrTargetNotEmpty, table)
+		}
+	}
+	return nil
+}
+
+// createCopyPartitions creates the target's monthly partitions for every month the source has rows for, so
+// copied rows do not all land in the default partition
+func createCopyPartitions(ctx context.Context, sourceTx, targetTx pgx.Tx) error {
+	for _, partitioned := range partitionedTables {
+		var oldest *time.Time
+		query := fmt.Sprintf("SELECT MIN(%s) FROM %s", partitioned.column, partitioned.table)
+		if err := sourceTx.QueryRow(ctx, query).Scan(&oldest); err != nil {
+			return fmt.Errorf("failed to find the oldest %s row: %w", partitioned.table, err)
+		}
+		if oldest == nil {
+			continue
+		}
+		if _, err := targetTx.Exec(ctx, `SELECT create_monthly_partitions($1, $2, $3, NOW())`,
+			partitioned.table, partitioned.column, *oldest); err != nil {
+			return fmt.Errorf("failed to create %s partitions: %w", partitioned.table, err)
+		}
+	}
+	return nil
+}
+
+// countRows counts the rows of a table
+func countRows(ctx context.Context, tx pgx.Tx, table string) (int64, error) {
+	var count int64
+	if err := tx.QueryRow(ctx, "SELECT count(*) FROM "+pgx.Identifier{table}.Sanitize()).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", table, err)
+	}
+	return count, nil
+}
+
+// copyTable streams the rows of a table from source to target in PostgreSQL's binary COPY format, with the
+// target's user triggers disabled, and returns how many rows the target received
+func copyTable(ctx context.Context, sourceTx, targetTx pgx.Tx, table string) (int64, error) {
+	identifier := pgx.Identifier{table}.Sanitize()
+
+	// Generated columns are computed by the target and cannot be copied
+	rows, err := sourceTx.Query(ctx, `
+		SELECT attname
+		FROM pg_attribute
+		WHERE attrelid = $1::regclass AND attnum > 0 AND NOT attisdropped AND attgenerated = ''
+		ORDER BY attnum`, identifier)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list columns of %s: %w", table, err)
+	}
+	columnNames, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, fmt.Errorf("failed to list columns of %s: %w", table, err)
+	}
+	columns := make([]string, len(columnNames))
+	for i, column := range columnNames {
+		columns[i] = pgx.Identifier{column}.Sanitize()
+	}
+	columnList := strings.Join(columns, ", ")
+
+	if _, err := targetTx.Exec(ctx, "ALTER TABLE "+identifier+" DISABLE TRIGGER USER"); err != nil {
+		return 0, fmt.Errorf("failed to disable triggers on %s: %w", table, err)
+	}
+
+	reader, writer := io.Pipe()
+	written := make(chan struct {
+		tag pgconn.CommandTag
+		err error
+	}, 1)
+	go func() {
+		tag, err := targetTx.Conn().PgConn().CopyFrom(ctx, reader,
+			fmt.Sprintf("COPY %s (%s) FROM STDIN (FORMAT binary)", identifier, columnList))
+		// Stop the source from writing into a pipe nobody reads any more
+		reader.CloseWithError(err)
+		written <- struct {
+			tag pgconn.CommandTag
+			err error
+		}{tag, err}
+	}()
+	_, readErr := sourceTx.Conn().PgConn().CopyTo(ctx, writer,
+		fmt.Sprintf("COPY (SELECT %s FROM %s) TO STDOUT (FORMAT binary)", columnList, identifier))
+	writer.CloseWithError(readErr)
+	result := <-written
+	if result.err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", table, result.err)
+	}
+	if readErr != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", table, readErr)
+	}
+
+	if _, err := targetTx.Exec(ctx, "ALTER TABLE "+identifier+" ENABLE TRIGGER USER"); err != nil {
+		return 0, fmt.Errorf("failed to enable triggers on %s: %w", table, err)
+	}
+	return result.tag.RowsAffected(), nil
+}
+
+// copySequences sets every sequence of the target to its value in the source, so IDs assigned after the
+// copy follow on from the copied ones
+func copySequences(ctx context.Context, sourceTx, targetTx pgx.Tx) error {
+	rows, err := sourceTx.Query(ctx, `
+		SELECT relname
+		FROM pg_class
+		WHERE relnamespace = current_schema()::regnamespace AND relkind = 'S'
+		ORDER BY relname`)
+	if err != nil {
+		return fmt.Errorf("failed to list sequences: %w", err)
+	}
+	sequences, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("failed to list sequences: %w", err)
+	}
+
+	for _, sequence := range sequences {
+		identifier := pgx.Identifier{sequence}.Sanitize()
+		var lastValue int64
+		var isCalled bool
+		if err := sourceTx.QueryRow(ctx, "SELECT last_value, is_called FROM "+identifier).Scan(&lastValue, &isCalled); err != nil {
+			return fmt.Errorf("failed to read sequence %s: %w", sequence, err)
+		}
+		if _, err := targetTx.Exec(ctx, "SELECT setval($1::regclass, $2, $3)", identifier, lastValue, isCalled); err != nil {
+			return fmt.Errorf("failed to set sequence %s: %w", sequence, err)
+		}
+	}
+	return nil
+}
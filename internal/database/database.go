@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 )
 
 // Common database errors
@@ -18,48 +19,950 @@ var (
 	ErrDatabase          = errors.New("database error")
 	ErrInvalidVersion    = errors.New("invalid version: cannot publish duplicate version")
 	ErrMaxServersReached = errors.New("maximum number of versions for this server reached (10000): please reach out at https://github.com/modelcontextprotocol/registry to explain your use case")
+	ErrLockNotAcquired   = errors.New("lock is held by another instance")
 )
 
 // ServerFilter defines filtering options for server queries
 type ServerFilter struct {
 	Name           *string    // for finding versions of same server
 	RemoteURL      *string    // for duplicate URL detection
+	RepositoryURL  *string    // for mapping a repository to its registry entries (canonical match)
+	Runtime        *string    // for finding servers that run on a runtime (node, python, docker, dotnet, binary)
+	Namespace      *string    // for finding servers in a namespace (exact match of the part before the slash)
 	UpdatedSince   *time.Time // for incremental sync filtering
 	SubstringName  *string    // for substring search on name
 	Version        *string    // for exact version matching
 	IsLatest       *bool      // for filtering latest versions only
-	IncludeDeleted *bool      // for including deleted packages in results (default: exclude)
+	CurationLabel  *string    // for finding servers operators gave a curation label (featured, official, community)
+	Unmaintained   *bool      // for finding or hiding servers the stale-entry sweeper marked unmaintained
+	ToolKeywords   []string   // for finding servers whose indexed tools mention every keyword in their names or descriptions
+	IncludeDeleted *bool      // for including deleted and quarantined packages in results (default: exclude)
+	AsOf           *time.Time // for reading server versions as they were at a past time, from the changes feed
 }
 
-// Database defines the interface for database operations
+// ServerSuggestion is a server suggested for a partially typed name
+type ServerSuggestion struct {
+	Name  string `json:"name" doc:"Server name" example:"io.github.user/filesystem"`
+	Title string `json:"title,omitempty" doc:"Human-readable title of the server's latest version" example:"Filesystem"`
+}
+
+// NamespaceSummary counts the servers of a namespace, the part of server names before the slash
+type NamespaceSummary struct {
+	Namespace       string    `json:"namespace" doc:"Namespace, the part of its server names before the slash" example:"io.github.acme"`
+	Servers         int       `json:"servers" doc:"Servers in the namespace whose latest version is not deleted" example:"12"`
+	Versions        int       `json:"versions" doc:"Versions of servers in the namespace that are not deleted" example:"57"`
+	VerifiedServers int       `json:"verifiedServers" doc:"Servers whose latest version was published by a verified publisher" example:"11"`
+	Verified        bool      `json:"verified" doc:"Whether the latest version of every server in the namespace was published by a verified publisher"`
+	UpdatedAt       time.Time `json:"updatedAt" format:"date-time" doc:"When a version in the namespace was last published or updated"`
+}
+
+// MaintenanceState describes whether the registry is rejecting writes for maintenance
+type MaintenanceState struct {
+	Enabled           bool      `json:"enabled" doc:"Whether write operations are currently rejected"`
+	Message           *string   `json:"message,omitempty" maxLength:"500" doc:"Optional message shown to clients while maintenance is in progress"`
+	RetryAfterSeconds int       `json:"retryAfterSeconds" doc:"Value of the Retry-After header sent with rejected writes"`
+	UpdatedAt         time.Time `json:"updatedAt" format:"date-time" doc:"Timestamp when maintenance mode was last changed"`
+}
+
+// Event types
+const (
+	// EventServerChange is sent when a server change is recorded in the changes feed
+	EventServerChange = "change"
+	// EventMaintenance is sent when the maintenance mode state changes
+	EventMaintenance = "maintenance"
+)
+
+// Event notifies replicas of a committed change. Events are hints: they are not redelivered to replicas
+// that were not listening, which re-read the changes feed instead.
+type Event struct {
+	Type string `json:"type"`
+	// Seq is the sequence number of the recorded change, for server change events
+	Seq int64 `json:"seq,omitempty"`
+}
+
+// Namespace verification statuses
+const (
+	NamespaceVerificationPending  = "pending"
+	NamespaceVerificationApproved = "approved"
+	NamespaceVerificationRejected = "rejected"
+)
+
+// Verification evidence triggers
+const (
+	EvidenceTriggerLogin   = "login"
+	EvidenceTriggerRecheck = "recheck"
+)
+
+// VerificationEvidence records the result of fetching a domain's MCP public key at a point in time
+type VerificationEvidence struct {
+	CheckedAt     time.Time `json:"checkedAt" format:"date-time" doc:"When the lookup was performed"`
+	Trigger       string    `json:"trigger" enum:"login,recheck" doc:"Whether the lookup was part of a login attempt or an admin re-check"`
+	Lookup        string    `json:"lookup" doc:"DNS TXT name or well-known URL that was queried" example:"https://example.com/.well-known/mcp-registry-auth"`
+	Records       []string  `json:"records,omitempty" doc:"TXT records or response body returned by the lookup"`
+	ValidKeyFound bool      `json:"validKeyFound" doc:"Whether a parseable MCP public key was found"`
+	Error         string    `json:"error,omitempty" doc:"Lookup error, if any"`
+}
+
+// NamespaceVerification is a request to verify a domain namespace, awaiting or having received admin review
+type NamespaceVerification struct {
+	ID          int64                  `json:"id" doc:"Verification request ID"`
+	Domain      string                 `json:"domain" doc:"Domain being verified" example:"example.com"`
+	Method      string                 `json:"method" enum:"dns,http" doc:"Authentication method used to prove domain ownership"`
+	Status      string                 `json:"status" enum:"pending,approved,rejected" doc:"Review status"`
+	Evidence    []VerificationEvidence `json:"evidence" doc:"Lookups performed for this request, oldest first"`
+	ReviewNotes *string                `json:"reviewNotes,omitempty" doc:"Notes left by the reviewing admin"`
+	ReviewedBy  *string                `json:"reviewedBy,omitempty" doc:"Identity of the reviewing admin"`
+	ReviewedAt  *time.Time             `json:"reviewedAt,omitempty" format:"date-time" doc:"When the request was approved or rejected"`
+	CreatedAt   time.Time              `json:"createdAt" format:"date-time" doc:"When the request was created"`
+	UpdatedAt   time.Time              `json:"updatedAt" format:"date-time" doc:"When the request was last updated"`
+}
+
+// DuplicateGroup is a set of servers with different names that share a repository URL or remote endpoint
+type DuplicateGroup struct {
+	Reason      string   `json:"reason" enum:"repository,remote" doc:"Whether the servers share a repository URL or a remote endpoint"`
+	URL         string   `json:"url" doc:"Canonical repository URL or normalized remote URL shared by the servers"`
+	ServerNames []string `json:"serverNames" doc:"Names of the servers sharing the URL"`
+}
+
+// Retention pruning reasons
+const (
+	PruneReasonMaxVersions = "max_versions"
+	PruneReasonMaxAge      = "max_age"
+)
+
+// PrunableVersion is a server version selected for deletion by a retention policy
+type PrunableVersion struct {
+	ServerName  string    `json:"serverName" doc:"Server name" example:"io.github.user/weather"`
+	Version     string    `json:"version" doc:"Server version" example:"0.0.1-nightly.20250101"`
+	PublishedAt time.Time `json:"publishedAt" format:"date-time" doc:"When the version was published"`
+	Reason      string    `json:"reason" enum:"max_versions,max_age" doc:"Whether the version exceeds the per-server version limit or the maximum age"`
+}
+
+// Audit log actions
+const (
+	AuditActionPublish            = "server.publish"
+	AuditActionPreview            = "server.preview"
+	AuditActionEdit               = "server.edit"
+	AuditActionStatus             = "server.status"
+	AuditActionRename             = "server.rename"
+	AuditActionMaintenance        = "maintenance.update"
+	AuditActionNamespaceReview    = "namespace.review"
+	AuditActionPrune              = "retention.prune"
+	AuditActionEventReplay        = "events.replay"
+	AuditActionSignedURL          = "signed_url.create"
+	AuditActionBulkJob            = "bulk_job.create"
+	AuditActionScreening          = "screening.exception"
+	AuditActionOrgAPIKeyCreate    = "org_api_key.create"
+	AuditActionOrgAPIKeyRotate    = "org_api_key.rotate"
+	AuditActionOrgAPIKeyRevoke    = "org_api_key.revoke"
+	AuditActionOrgMemberAdd       = "org_member.add"
+	AuditActionOrgMemberRemove    = "org_member.remove"
+	AuditActionCuration           = "server.curation"
+	AuditActionWebhookCreate      = "webhook.create"
+	AuditActionWebhookUpdate      = "webhook.update"
+	AuditActionWebhookDelete      = "webhook.delete"
+	AuditActionWebhookRotate      = "webhook.rotate_secret"
+	AuditActionCollectionCreate   = "collection.create"
+	AuditActionCollectionUpdate   = "collection.update"
+	AuditActionCollectionDelete   = "collection.delete"
+	AuditActionValidation         = "validation.policy"
+	AuditActionArtifacts          = "server.artifacts"
+	AuditActionIdentityBan        = "identity.ban"
+	AuditActionAnnouncementCreate = "announcement.create"
+	AuditActionAnnouncementUpdate = "announcement.update"
+	AuditActionAnnouncementDelete = "announcement.delete"
+	AuditActionOAuthClientDelete  = "oauth_client.delete"
+)
+
+// AuditEntry records a write performed through the API and who performed it
+type AuditEntry struct {
+	ID        int64          `json:"id" doc:"Audit entry ID, increasing in the order entries were recorded"`
+	CreatedAt time.Time      `json:"createdAt" format:"date-time" doc:"When the action was performed"`
+	Actor     string         `json:"actor" doc:"Authentication method and subject of the token that performed the action" example:"github-at:octocat"`
+	Action    string         `json:"action" doc:"Action performed" example:"server.publish"`
+	Namespace string         `json:"namespace,omitempty" doc:"Namespace of the affected server or domain" example:"io.github.octocat"`
+	Resource  string         `json:"resource,omitempty" doc:"Affected resource, such as a server version" example:"io.github.octocat/weather@1.0.0"`
+	ClientIP  string         `json:"clientIp,omitempty" doc:"Address of the client that made the request, resolved through trusted proxies" example:"203.0.113.7"`
+	Details   map[string]any `json:"details,omitempty" doc:"Action-specific details"`
+}
+
+// PublishAttempt records a publish request made with a valid registry token and its outcome
+type PublishAttempt struct {
+	ID                 int64     `json:"id" doc:"Attempt ID, increasing in the order attempts were made"`
+	AttemptedAt        time.Time `json:"attemptedAt" format:"date-time" doc:"When the publish was attempted"`
+	Identity           string    `json:"-"`
+	ServerName         string    `json:"serverName" doc:"Name of the server being published" example:"io.github.octocat/weather"`
+	Version            string    `json:"version" doc:"Version being published" example:"1.0.2"`
+	SchemaVersion      string    `json:"schemaVersion,omitempty" doc:"Schema version the submitted server.json declared in $schema; documents for earlier versions are upgraded to the current one" example:"2025-09-29"`
+	Status             int       `json:"status" doc:"HTTP status of the response" example:"422"`
+	ErrorCode          string    `json:"errorCode,omitempty" doc:"Error code of a failed attempt" example:"SCHEMA_VALIDATION_FAILED"`
+	Error              string    `json:"error,omitempty" doc:"Why the attempt failed"`
+	ValidationErrors   []string  `json:"validationErrors,omitempty" doc:"Validation errors that blocked the publish, formatted as '<path>: <message> (<rule>)'"`
+	ValidationWarnings []string  `json:"validationWarnings,omitempty" doc:"Non-blocking validation warnings, formatted as '<path>: <message> (<rule>)'"`
+}
+
+// QuarantinedPublish keeps the server.json of a rejected publish attempt with the validator's full output, so its
+// publisher can see every detail of why it was rejected
+type QuarantinedPublish struct {
+	AttemptID        int64                       `json:"-"`
+	QuarantinedAt    time.Time                   `json:"quarantinedAt" format:"date-time" doc:"When the rejected server.json was kept"`
+	Server           apiv0.ServerJSON            `json:"server" doc:"server.json as submitted, upgraded to the current schema version"`
+	ValidationIssues []validator.ValidationIssue `json:"validationIssues" doc:"Every issue the validator reported, blocking or not"`
+}
+
+// AuditFilter defines filtering options for audit log queries
+type AuditFilter struct {
+	Actor     *string    // exact actor match
+	Namespace *string    // exact namespace match
+	Action    *string    // exact action match
+	Since     *time.Time // entries recorded at or after this time
+	Until     *time.Time // entries recorded before this time
+}
+
+// Bulk job kinds
+const (
+	BulkJobStatus     = "status"
+	BulkJobRevalidate = "revalidate"
+	BulkJobTransfer   = "transfer"
+)
+
+// Bulk job states
+const (
+	BulkJobQueued    = "queued"
+	BulkJobRunning   = "running"
+	BulkJobSucceeded = "succeeded"
+	BulkJobFailed    = "failed"
+)
+
+// BulkJobParams selects the servers a bulk job applies to and what it does to them
+type BulkJobParams struct {
+	Namespace     string  `json:"namespace,omitempty" doc:"Namespace whose servers the job applies to; empty selects every server (revalidate only)" example:"io.github.octocat"`
+	Status        string  `json:"status,omitempty" enum:"active,deprecated,deleted" doc:"Status set on every version (status jobs)"`
+	StatusMessage *string `json:"statusMessage,omitempty" maxLength:"500" doc:"Status message set with the status (status jobs)"`
+	NewNamespace  string  `json:"newNamespace,omitempty" doc:"Namespace the servers are moved to (transfer jobs)" example:"io.github.octo-org"`
+}
+
+// BulkJobFailure records a server a bulk job could not process
+type BulkJobFailure struct {
+	ServerName string `json:"serverName" doc:"Server name" example:"io.github.octocat/weather"`
+	Version    string `json:"version,omitempty" doc:"Server version, when the failure concerns a single version" example:"1.0.0"`
+	Error      string `json:"error" doc:"Why the server could not be processed"`
+}
+
+// BulkJob is an admin operation over many servers, queued and executed in the background
+type BulkJob struct {
+	ID         int64            `json:"id" doc:"Job ID"`
+	Kind       string           `json:"kind" enum:"status,revalidate,transfer" doc:"Operation performed"`
+	Params     BulkJobParams    `json:"params" doc:"Parameters the job was queued with"`
+	State      string           `json:"state" enum:"queued,running,succeeded,failed" doc:"Execution state"`
+	Total      int              `json:"total" doc:"Number of servers selected, known once the job starts"`
+	Processed  int              `json:"processed" doc:"Number of servers processed so far, including failures"`
+	Failed     int              `json:"failed" doc:"Number of servers that could not be processed"`
+	Failures   []BulkJobFailure `json:"failures,omitempty" doc:"The first 100 failures"`
+	Error      string           `json:"error,omitempty" doc:"Why the job stopped, if it failed as a whole"`
+	CreatedBy  string           `json:"createdBy" doc:"Authentication method and subject of the admin that queued the job" example:"github-at:octocat"`
+	CreatedAt  time.Time        `json:"createdAt" format:"date-time" doc:"When the job was queued"`
+	StartedAt  *time.Time       `json:"startedAt,omitempty" format:"date-time" doc:"When the job last started running"`
+	FinishedAt *time.Time       `json:"finishedAt,omitempty" format:"date-time" doc:"When the job finished"`
+	UpdatedAt  time.Time        `json:"updatedAt" format:"date-time" doc:"When progress was last recorded"`
+}
+
+// Pending publish states
+const (
+	PublishPending  = "pending"
+	PublishActive   = "active"
+	PublishRejected = "rejected"
+)
+
+// PendingPublish is a publish accepted in asynchronous mode. Its slower checks run in the background, after
+// which the server version is created and the publish becomes active, or the publish is rejected.
+type PendingPublish struct {
+	ID            int64            `json:"id" doc:"Publish ID"`
+	ServerName    string           `json:"serverName" doc:"Name of the server being published" example:"io.github.octocat/weather"`
+	Version       string           `json:"version" doc:"Version being published" example:"1.0.2"`
+	State         string           `json:"state" enum:"pending,active,rejected" doc:"pending while checks run, active once the version is published, rejected if a check failed"`
+	Error         string           `json:"error,omitempty" doc:"Why the publish was rejected, or while it is pending, why its last attempt failed"`
+	Attempts      int              `json:"attempts,omitempty" doc:"Number of attempts that failed for a transient reason and were retried"`
+	NextAttemptAt time.Time        `json:"-"` // when the worker may pick the publish up again after a failed attempt
+	Server        apiv0.ServerJSON `json:"-"`
+	Document      []byte           `json:"-"` // server.json as it was submitted, stored as the version's document
+	Publisher     *apiv0.Publisher `json:"-"`
+	SubmittedBy   string           `json:"-"` // authentication method and subject of the token it was submitted with
+	CreatedAt     time.Time        `json:"createdAt" format:"date-time" doc:"When the publish was accepted"`
+	FinishedAt    *time.Time       `json:"finishedAt,omitempty" format:"date-time" doc:"When the publish became active or was rejected"`
+}
+
+// ArtifactMirror is a server version whose package artifacts are queued to be mirrored into the artifact
+// proxy's storage in the background
+type ArtifactMirror struct {
+	ID            int64
+	ServerName    string
+	Version       string
+	Attempts      int       // attempts that failed and were retried
+	Error         string    // why the last attempt failed
+	NextAttemptAt time.Time // when the worker may pick the mirror up
+	CreatedAt     time.Time
+}
+
+// ServerAlias is a name a server was renamed from. Until it expires, detail fetches of the alias redirect to
+// the server's current name.
+type ServerAlias struct {
+	Alias      string    `json:"alias" doc:"Previous name of the server" example:"io.github.octocat/weather-server"`
+	ServerName string    `json:"serverName" doc:"Current name of the server" example:"io.github.octocat/weather"`
+	CreatedBy  string    `json:"createdBy" doc:"Authentication method and subject of the token that renamed the server" example:"github-at:octocat"`
+	CreatedAt  time.Time `json:"createdAt" format:"date-time" doc:"When the server was renamed"`
+	ExpiresAt  time.Time `json:"expiresAt" format:"date-time" doc:"When the alias stops redirecting"`
+}
+
+// UpstreamCacheEntry is a cached response from an upstream package registry
+type UpstreamCacheEntry struct {
+	Key        string              // request method, URL and Accept header
+	StatusCode int                 // HTTP status of the cached response
+	Header     map[string][]string // response headers
+	Body       []byte              // response body
+	ExpiresAt  time.Time           // when the entry stops being served
+}
+
+// ServerDocumentRef points a server version at its server.json in the content-addressed document store
+type ServerDocumentRef struct {
+	ServerName string
+	Version    string
+	// Digest is the content address of the version's document, empty if it was never stored
+	Digest string
+}
+
+// ScreeningException exempts a server from name and description screening
+type ScreeningException struct {
+	ServerName string    `json:"serverName" doc:"Exempted server name" example:"io.github.user/weather"`
+	Reason     string    `json:"reason" doc:"Why the server is exempt" example:"Official server of the vendor"`
+	CreatedBy  string    `json:"createdBy" doc:"Authentication method and subject of the admin who added the exception" example:"github-at:octocat"`
+	CreatedAt  time.Time `json:"createdAt" format:"date-time" doc:"When the exception was added or last replaced"`
+}
+
+// Identity ban kinds
+const (
+	// BanKindGitHub bans a GitHub user or organization account
+	BanKindGitHub = "github"
+	// BanKindOIDC bans the subject of a generic OIDC login
+	BanKindOIDC = "oidc"
+	// BanKindNamespace bans every identity allowed to publish to a namespace, such as a domain's
+	BanKindNamespace = "namespace"
+)
+
+// IdentityBan bars an identity from getting registry tokens and publishing
+type IdentityBan struct {
+	Kind      string     `json:"kind" enum:"github,oidc,namespace" doc:"What the value identifies: a GitHub user or organization, an OIDC subject, or a namespace" example:"github"`
+	Value     string     `json:"value" doc:"GitHub login or organization, OIDC subject as recorded on tokens, or namespace" example:"spammer"`
+	Reason    string     `json:"reason" doc:"Why the identity is banned, shown to it when its token exchanges and publishes are rejected" example:"Publishing malware"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" format:"date-time" doc:"When the ban lapses. Bans without an expiry last until they are lifted."`
+	CreatedBy string     `json:"createdBy" doc:"Authentication method and subject of the admin who banned the identity" example:"github-at:octocat"`
+	CreatedAt time.Time  `json:"createdAt" format:"date-time" doc:"When the ban was added or last replaced"`
+}
+
+// Active reports whether the ban is in force at now
+func (b *IdentityBan) Active(now time.Time) bool {
+	return b.ExpiresAt == nil || now.Before(*b.ExpiresAt)
+}
+
+// IdentityBanQuery selects the bans that may cover a token's identity. GitHub and namespace values are matched
+// ignoring case, and must be given lowercased; OIDC subjects are matched exactly.
+type IdentityBanQuery struct {
+	// GitHub selects GitHub bans of these logins or organizations
+	GitHub []string
+	// OIDC selects OIDC bans of these subjects
+	OIDC []string
+	// Namespaces selects namespace bans of these namespaces
+	Namespaces []string
+	// Prefixes selects GitHub and namespace bans whose namespace followed by "/" starts with one of these, for
+	// permissions ending in a wildcard. The namespace of a GitHub ban is io.github.<value>.
+	Prefixes []string
+}
+
+// QuarantinedVersion records a server version a ban quarantined and the status to restore once no ban holds it
+type QuarantinedVersion struct {
+	ServerName         string
+	Version            string
+	BanKind            string
+	BanValue           string
+	PriorStatus        model.Status
+	PriorStatusMessage *string
+	QuarantinedAt      time.Time
+}
+
+// Curation labels registry operators can give servers
+const (
+	CurationLabelFeatured  = "featured"
+	CurationLabelOfficial  = "official"
+	CurationLabelCommunity = "community"
+)
+
+// ServerCuration is how registry operators curated a server for storefront sections in clients
+type ServerCuration struct {
+	ServerName string    `json:"serverName" doc:"Curated server name" example:"io.github.user/weather"`
+	Labels     []string  `json:"labels" doc:"Curation labels, sorted: featured, official (vendor-verified) or community (community-maintained)" example:"[\"featured\", \"official\"]"`
+	Position   *int      `json:"position,omitempty" doc:"Order of the server in curated lists sorted with sort=curated, lowest first; servers without a position come last" example:"1"`
+	Note       string    `json:"note,omitempty" doc:"Note for other admins, such as why the server was curated" example:"Vendor confirmed ownership by email"`
+	UpdatedBy  string    `json:"updatedBy" doc:"Authentication method and subject of the admin who last changed the curation" example:"github-at:octocat"`
+	UpdatedAt  time.Time `json:"updatedAt" format:"date-time" doc:"When the curation was last changed"`
+}
+
+// Reasons the stale-entry sweeper gives for a server being stale
+const (
+	StaleReasonRepositoryArchived = "repository-archived"
+	StaleReasonRepositoryDeleted  = "repository-deleted"
+	StaleReasonPackageUnpublished = "package-unpublished"
+)
+
+// ServerStaleness records that the stale-entry sweeper found a server untouched with its repository or
+// packages gone. It applies to every version of the server.
+type ServerStaleness struct {
+	ServerName     string     `json:"serverName" doc:"Stale server name" example:"io.github.user/weather"`
+	Reasons        []string   `json:"reasons" doc:"Why the server is stale: repository-archived, repository-deleted or package-unpublished" example:"[\"repository-archived\"]"`
+	DetectedAt     time.Time  `json:"detectedAt" format:"date-time" doc:"When the sweeper first found the server stale"`
+	NotifiedAt     *time.Time `json:"notifiedAt,omitempty" format:"date-time" doc:"When the maintainers were notified with an issue on the server's repository; absent if the repository takes no issues"`
+	UnmaintainedAt *time.Time `json:"unmaintainedAt,omitempty" format:"date-time" doc:"When the server was marked unmaintained, once the grace period after detection passed"`
+}
+
+// Where the tool indexer found a server's tools
+const (
+	ToolSourceManifest = "manifest"
+	ToolSourceRemote   = "remote"
+)
+
+// ServerTool is a tool a server exposes, as indexed for search
+type ServerTool struct {
+	Name        string `json:"name" doc:"Tool name" example:"query"`
+	Description string `json:"description,omitempty" doc:"Tool description" example:"Run a read-only SQL query against the PostgreSQL database"`
+}
+
+// ServerTools records the tools the tool indexer found for the latest version of a server. It applies to every
+// version of the server.
+type ServerTools struct {
+	ServerName string       `json:"serverName" doc:"Server name" example:"io.github.user/postgres"`
+	Version    string       `json:"version" doc:"Version whose tools were indexed" example:"1.2.0"`
+	Source     string       `json:"source" enum:"manifest,remote" doc:"Where the tools were found: the MCPB manifest.json in the server's repository, or the tools/list response of one of its remotes"`
+	Tools      []ServerTool `json:"tools" doc:"Tools the server exposes"`
+	IndexedAt  time.Time    `json:"indexedAt" format:"date-time" doc:"When the tools were last indexed"`
+}
+
+// OrgAPIKey is an organization-level API key that exchanges for a registry token scoped to its namespaces
+type OrgAPIKey struct {
+	ID           int64      `json:"id" doc:"Key ID"`
+	Organization string     `json:"organization" doc:"Organization namespace that owns the key" example:"com.example"`
+	Name         string     `json:"name" doc:"Name describing what uses the key" example:"release-pipeline"`
+	Namespaces   []string   `json:"namespaces" doc:"Namespaces the key may publish and edit servers in" example:"[\"com.example\"]"`
+	KeyHash      string     `json:"-"`
+	KeyPrefix    string     `json:"keyPrefix" doc:"First characters of the key, to tell keys apart" example:"mcpr_3f9a1c"`
+	CreatedBy    string     `json:"createdBy" doc:"Authentication method and subject of the member who created the key" example:"github-at:octocat"`
+	CreatedAt    time.Time  `json:"createdAt" format:"date-time" doc:"When the key was created"`
+	RotatedAt    *time.Time `json:"rotatedAt,omitempty" format:"date-time" doc:"When the key was last rotated"`
+	LastUsedAt   *time.Time `json:"lastUsedAt,omitempty" format:"date-time" doc:"When the key was last exchanged for a registry token"`
+}
+
+// OrgMember is someone an organization's owners have let manage its API keys
+type OrgMember struct {
+	ID           int64     `json:"id" doc:"Member ID"`
+	Organization string    `json:"organization" doc:"Organization namespace" example:"com.example"`
+	Subject      string    `json:"subject" doc:"Authentication method and subject of the member" example:"github-at:octocat"`
+	AddedBy      string    `json:"addedBy" doc:"Authentication method and subject of the owner who added the member" example:"github-at:hubot"`
+	CreatedAt    time.Time `json:"createdAt" format:"date-time" doc:"When the member was added"`
+}
+
+// ValidationPolicy loosens or tightens publish validation for the servers of a namespace and the namespaces
+// under it
+type ValidationPolicy struct {
+	Namespace            string    `json:"namespace" doc:"Namespace the policy applies to, along with the namespaces under it: com.bank covers com.bank and com.bank.payments" example:"com.bank"`
+	AllowHTTPRemotes     bool      `json:"allowHttpRemotes" doc:"Accept http:// remote URLs, which are otherwise rejected"`
+	RequirePackageHashes bool      `json:"requirePackageHashes" doc:"Reject packages without a fileSha256 hash"`
+	BlockingLintRules    []string  `json:"blockingLintRules" doc:"Lint rules that fail publishes, in addition to those the registry enforces everywhere" example:"[\"missing-icon\"]"`
+	Reason               string    `json:"reason" doc:"Why the namespace has its own policy" example:"Regulated publisher; packages must be verifiable"`
+	UpdatedBy            string    `json:"updatedBy" doc:"Authentication method and subject of the admin who last set the policy" example:"github-at:octocat"`
+	UpdatedAt            time.Time `json:"updatedAt" format:"date-time" doc:"When the policy was last set"`
+}
+
+// Webhook payload formats
+const (
+	// WebhookFormatFull sends the change as served by the changes feed, with the server's full record
+	WebhookFormatFull = "full"
+	// WebhookFormatMinimal sends an envelope naming the changed server version, for consumers that fetch what they need
+	WebhookFormatMinimal = "minimal"
+	// WebhookFormatCloudEvents sends a CloudEvents 1.0 event in structured mode, with the server's record as its data
+	WebhookFormatCloudEvents = "cloudevents"
+)
+
+// WebhookSubscription delivers the server changes it selects to a URL
+type WebhookSubscription struct {
+	ID            int64      `json:"id" doc:"Subscription ID"`
+	URL           string     `json:"url" doc:"URL receiving one POST per change" example:"https://consumer.example.com/hooks/registry"`
+	EventTypes    []string   `json:"eventTypes" doc:"Change types delivered; empty delivers every type" example:"[\"created\"]"`
+	Namespaces    []string   `json:"namespaces" doc:"Namespaces whose changes are delivered; empty delivers every namespace" example:"[\"io.github.octocat\"]"`
+	Format        string     `json:"format" enum:"full,minimal,cloudevents" doc:"Payload format"`
+	Secret        string     `json:"secret,omitempty" doc:"Key of the HMAC-SHA256 signature sent with each delivery; only returned when the subscription is created or its secret is rotated"`
+	LastSeq       int64      `json:"lastSeq" doc:"Sequence number of the last change delivered or skipped by the filters"`
+	LastError     string     `json:"lastError,omitempty" doc:"Why the last delivery failed, until a delivery succeeds"`
+	LastAttemptAt *time.Time `json:"lastAttemptAt,omitempty" format:"date-time" doc:"When changes were last delivered or attempted"`
+	CreatedBy     string     `json:"createdBy" doc:"Authentication method and subject of the admin who created the subscription" example:"github-at:octocat"`
+	CreatedAt     time.Time  `json:"createdAt" format:"date-time" doc:"When the subscription was created"`
+	UpdatedAt     time.Time  `json:"updatedAt" format:"date-time" doc:"When the subscription's settings were last changed"`
+}
+
+// OAuthClient is an application that registered itself at /oauth/register (RFC 7591) to log people in
+// through the registry with the authorization code flow and PKCE, such as an MCP client publishing on their
+// behalf. Registered clients are public: they have no secret, and PKCE protects their authorization codes.
+type OAuthClient struct {
+	ClientID     string    `json:"clientId" doc:"Client ID the application sends to /oauth/authorize and /oauth/token"`
+	ClientName   string    `json:"clientName,omitempty" doc:"Name the application registered with" example:"Example MCP Client"`
+	RedirectURIs []string  `json:"redirectUris" doc:"URIs the application's authorization codes may be sent to" example:"[\"http://127.0.0.1/callback\"]"`
+	CreatedAt    time.Time `json:"createdAt" format:"date-time" doc:"When the application registered"`
+}
+
+// AnnouncementRecord is an announcement with the admin who created it
+type AnnouncementRecord struct {
+	apiv0.Announcement
+	CreatedBy string    `json:"createdBy" doc:"Authentication method and subject of the admin who created the announcement" example:"github-at:octocat"`
+	CreatedAt time.Time `json:"createdAt" format:"date-time" doc:"When the announcement was created"`
+}
+
+// CollectionItem is a server in a collection
+type CollectionItem struct {
+	ServerName string `json:"serverName" doc:"Server name" example:"io.github.user/postgres"`
+	Note       string `json:"note,omitempty" doc:"Why the curator included the server" example:"Read-only mode makes it safe for production databases"`
+}
+
+// Collection is a curated, ordered set of servers published by a registry user for others to browse and follow
+type Collection struct {
+	ID          int64            `json:"id" doc:"Collection ID"`
+	Title       string           `json:"title" doc:"Collection title" example:"Best database MCP servers"`
+	Description string           `json:"description,omitempty" doc:"What the collection gathers and how servers were chosen"`
+	Items       []CollectionItem `json:"items" doc:"Servers in the collection, in the curator's order"`
+	Owner       string           `json:"owner" doc:"Authentication method and subject of the curator" example:"github-at:octocat"`
+	CreatedAt   time.Time        `json:"createdAt" format:"date-time" doc:"When the collection was created"`
+	UpdatedAt   time.Time        `json:"updatedAt" format:"date-time" doc:"When the collection was last changed; clients following it can compare this to refresh"`
+}
+
+// Preview is a server.json published for review, typically from a pull request's CI, so reviewers can see how
+// the server will appear and install before it is published. Previews are only reachable through their
+// unguessable ID and expire; they are never listed, searched or sent in the changes feed.
+type Preview struct {
+	ID          string           `json:"id" doc:"Unguessable preview ID, the only way to retrieve the preview"`
+	Server      apiv0.ServerJSON `json:"-"`
+	Publisher   *apiv0.Publisher `json:"-"`
+	SubmittedBy string           `json:"-"` // authentication method and subject of the token it was published with
+	PullRequest string           `json:"pullRequest,omitempty" doc:"Pull request the preview was published from, as owner/repository#number" example:"octo-org/weather#42"`
+	CreatedAt   time.Time        `json:"createdAt" format:"date-time" doc:"When the preview was published"`
+	ExpiresAt   time.Time        `json:"expiresAt" format:"date-time" doc:"When the preview stops being served and is deleted"`
+}
+
+// Metrics counted over time for the admin time series endpoint
+const (
+	MetricPublishes       = "publishes"        // server versions published
+	MetricNewServers      = "new_servers"      // servers published for the first time
+	MetricFailedPublishes = "failed_publishes" // publish attempts that were rejected
+)
+
+// MetricBucket is the span of time each metric counter covers. Counts are stored per bucket, so time series
+// steps are whole multiples of it.
+const MetricBucket = time.Hour
+
+// MetricCount is a metric's count for the bucket starting at Bucket
+type MetricCount struct {
+	Bucket time.Time
+	Count  int64
+}
+
+// Outcomes and stages of install reports
+const (
+	InstallOutcomeSuccess = "success"
+	InstallOutcomeFailure = "failure"
+
+	InstallStageInstall = "install" // installing or configuring the server
+	InstallStageRuntime = "runtime" // starting or connecting to the installed server
+)
+
+// InstallReport is an MCP client's report of whether installing and running a server version worked. Each
+// reporter has one report per version, so repeated reports replace earlier ones instead of adding to them.
+type InstallReport struct {
+	ServerName string
+	Version    string
+	Reporter   string // keyed hash of the reporting client's address, never the address itself
+	Outcome    string
+	Stage      string
+	Client     string // name of the reporting MCP client, if it gave one
+	ReportedAt time.Time
+}
+
+// InstallReportCount counts the reporters of a server version and how many of them reported success
+type InstallReportCount struct {
+	Reports   int
+	Successes int
+}
+
+// JobLock is a lock held by this instance for a background job
+type JobLock interface {
+	// Held reports whether the lock is still held. It stops being held if its database connection is lost.
+	Held(ctx context.Context) bool
+	// Release releases the lock so another instance can acquire it
+	Release(ctx context.Context) error
+}
+
+// Database defines the interface for registry storage. PostgreSQL is the production implementation
+// and Memory keeps everything in process memory for tests and embedding; both must behave the same.
+//
+// Implementations follow these rules:
+//   - Methods taking a tx run inside that transaction when it is non-nil, and on their own otherwise.
+//     Only pass a tx received from InTransaction on the same Database.
+//   - Missing records are reported as ErrNotFound. Writes that would break a uniqueness rule (one row per
+//     server name and version, one latest version per server, one pending verification per domain and
+//     method) fail with ErrAlreadyExists, and writes that break a data rule (server name format, status
+//     values, text lengths, timestamps) fail with ErrInvalidInput.
+//   - Every insert or update of a server version is recorded in the changes feed.
+//   - Returned values are copies; modifying them does not change stored data.
 type Database interface {
-	// CreateServer inserts a new server version with official metadata
+	// CreateServer inserts a new server version with official metadata, storing the canonical encoding of its
+	// server.json in the document store
 	CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error)
-	// UpdateServer updates an existing server record
+	// UpdateServer updates an existing server record, storing the canonical encoding of its new server.json in
+	// the document store
 	UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
+	// PutServerDocument stores a server version's server.json document, such as the bytes it was published with,
+	// under its digest, once per distinct document, and points the version at it. It returns the digest, or
+	// ErrNotFound if the version does not exist.
+	PutServerDocument(ctx context.Context, tx pgx.Tx, serverName, version string, document []byte) (string, error)
+	// GetServerDocument retrieves a stored document by its digest, exactly as it was stored
+	GetServerDocument(ctx context.Context, tx pgx.Tx, digest string) ([]byte, error)
+	// ListServerDocumentRefs retrieves the document digest of every server version, including deleted ones,
+	// ordered by name and then version like ListServers. Versions stored before the document store have none.
+	ListServerDocumentRefs(ctx context.Context, tx pgx.Tx, cursor string, limit int) ([]*ServerDocumentRef, string, error)
+	// ListServerDocumentDigests retrieves the document digest of each version, including deleted ones, of each
+	// given server, keyed by server name and then version. Versions with no stored document are left out.
+	ListServerDocumentDigests(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string]map[string]string, error)
 	// SetServerStatus updates the status of a specific server version
 	SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status model.Status, statusMessage *string) (*apiv0.ServerResponse, error)
 	// SetAllVersionsStatus updates the status of all versions of a server in a single query
 	SetAllVersionsStatus(ctx context.Context, tx pgx.Tx, serverName string, status model.Status, statusMessage *string) ([]*apiv0.ServerResponse, error)
-	// ListServers retrieve server entries with optional filtering
+	// ListServers retrieve server entries with optional filtering, ordered by name and then version, both unique
+	// together, so pages never skip or repeat an entry
 	ListServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
-	// GetServerByName retrieve a single server by its name
+	// GetServerByName retrieve a single server by its name, the most recently published if several match, with
+	// ties going to the highest version
 	GetServerByName(ctx context.Context, tx pgx.Tx, serverName string, includeDeleted bool) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
 	GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx, serverName string, version string, includeDeleted bool) (*apiv0.ServerResponse, error)
-	// GetAllVersionsByServerName retrieve all versions of a server by server name
+	// GetAllVersionsByServerName retrieve all versions of a server by server name, newest first, with versions
+	// published at the same time ordered by version descending
 	GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx, serverName string, includeDeleted bool) ([]*apiv0.ServerResponse, error)
 	// GetCurrentLatestVersion retrieve the current latest version of a server by server name
 	GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error)
+	// CountServers count the servers matching a filter, exactly up to exactLimit and estimated beyond it
+	CountServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, exactLimit int) (count int, estimated bool, err error)
+	// SuggestServers suggest latest, non-deleted servers and namespaces with a word of their name starting
+	// with prefix, case-insensitively. Words start at the beginning of the name and after '/', '.', '-' and
+	// '_'. Names starting with prefix come first, then names whose part after the slash does, then shorter names.
+	SuggestServers(ctx context.Context, tx pgx.Tx, prefix string, limit int) ([]ServerSuggestion, []string, error)
+	// ListNamespaces summarizes up to limit namespaces with servers whose latest version is not deleted, ordered by
+	// name and starting after the namespace cursor, if set. With parent set, only parent and the namespaces beneath
+	// it, such as com.example.api for com.example, are listed.
+	ListNamespaces(ctx context.Context, tx pgx.Tx, parent, cursor string, limit int) ([]*NamespaceSummary, string, error)
 	// CountServerVersions count the number of versions for a server
 	CountServerVersions(ctx context.Context, tx pgx.Tx, serverName string) (int, error)
 	// CheckVersionExists check if a specific version exists for a server
 	CheckVersionExists(ctx context.Context, tx pgx.Tx, serverName, version string) (bool, error)
+	// ListExistingVersions retrieves the versions, including deleted ones, of each given server that has any, keyed by server name
+	ListExistingVersions(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string][]string, error)
 	// UnmarkAsLatest marks the current latest version of a server as no longer latest
 	UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error
 	// AcquirePublishLock acquires an exclusive advisory lock for publishing a server
 	// This prevents race conditions when multiple versions are published concurrently
 	AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverName string) error
+	// ListServerChanges retrieves changes recorded after the given sequence number, in sequence order. Changes
+	// become visible in sequence order, so a cursor past the last listed change never skips a later commit
+	ListServerChanges(ctx context.Context, tx pgx.Tx, since int64, limit int) ([]*apiv0.ServerChange, error)
+	// LatestChangeSeq retrieves the sequence number of the most recently recorded change, or 0 if there is none
+	LatestChangeSeq(ctx context.Context, tx pgx.Tx) (int64, error)
+	// DeleteServerChangesBefore permanently removes changes recorded before the given time, except the most
+	// recent change, and returns how many were removed
+	DeleteServerChangesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
+	// CreatePartitions creates the missing monthly partitions of the audit log and changes feed up to and
+	// including the month of through, and returns how many were created
+	CreatePartitions(ctx context.Context, tx pgx.Tx, through time.Time) (int, error)
+	// ListenForEvents passes events committed by any replica to handle, calling ready once it is listening.
+	// It blocks until ctx is done or the connection is lost, and returns why it stopped.
+	ListenForEvents(ctx context.Context, ready func(), handle func(Event)) error
+	// GetImportCheckpoint retrieves the last change sequence applied from a remote registry
+	GetImportCheckpoint(ctx context.Context, tx pgx.Tx, source string) (int64, error)
+	// SetImportCheckpoint records the last change sequence applied from a remote registry
+	SetImportCheckpoint(ctx context.Context, tx pgx.Tx, source string, seq int64) error
+	// GetMaintenanceState retrieves the persisted maintenance mode state
+	GetMaintenanceState(ctx context.Context, tx pgx.Tx) (*MaintenanceState, error)
+	// SetMaintenanceState persists the maintenance mode state
+	SetMaintenanceState(ctx context.Context, tx pgx.Tx, enabled bool, message *string, retryAfterSeconds int) (*MaintenanceState, error)
+	// ListPossibleDuplicates finds groups of latest, non-deleted servers that share a canonical repository
+	// URL or normalized remote URL under different names. If serverName is set, only groups containing it are returned.
+	ListPossibleDuplicates(ctx context.Context, tx pgx.Tx, serverName *string) ([]*DuplicateGroup, error)
+	// PutRepositoryURL records the canonical form of a repository URL servers link, replacing any earlier one
+	PutRepositoryURL(ctx context.Context, tx pgx.Tx, rawURL, canonicalURL string) error
+	// RecordTokenUse records that an identity token, identified by key, was exchanged and keeps the record until
+	// expiresAt. It returns ErrAlreadyExists when the token was recorded before and has not expired.
+	RecordTokenUse(ctx context.Context, tx pgx.Tx, key string, expiresAt time.Time) error
+	// RecordNamespaceVerificationAttempt adds evidence to the pending verification request for a domain and method, creating it if needed
+	RecordNamespaceVerificationAttempt(ctx context.Context, tx pgx.Tx, domain, method string, evidence VerificationEvidence) (*NamespaceVerification, error)
+	// AddNamespaceVerificationEvidence appends evidence to an existing verification request
+	AddNamespaceVerificationEvidence(ctx context.Context, tx pgx.Tx, id int64, evidence VerificationEvidence) (*NamespaceVerification, error)
+	// GetNamespaceVerification retrieves a verification request by ID
+	GetNamespaceVerification(ctx context.Context, tx pgx.Tx, id int64) (*NamespaceVerification, error)
+	// ListNamespaceVerifications retrieves verification requests, optionally filtered by status, oldest first
+	ListNamespaceVerifications(ctx context.Context, tx pgx.Tx, status *string) ([]*NamespaceVerification, error)
+	// ReviewNamespaceVerification records an admin decision on a verification request
+	ReviewNamespaceVerification(ctx context.Context, tx pgx.Tx, id int64, status string, notes *string, reviewer string) (*NamespaceVerification, error)
+	// IsNamespaceApproved reports whether the most recent review decision for a domain approved it
+	IsNamespaceApproved(ctx context.Context, tx pgx.Tx, domain string) (bool, error)
+	// ListPrunableVersions finds versions that are not the latest version of their server and either fall outside the
+	// newest maxVersionsPerServer versions (when positive) or were published before publishedBefore (when set)
+	ListPrunableVersions(ctx context.Context, tx pgx.Tx, maxVersionsPerServer int, publishedBefore *time.Time, limit int) ([]*PrunableVersion, error)
+	// DeleteServerVersion permanently removes a server version, recording its deletion in the changes feed
+	DeleteServerVersion(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// RecordAuditEntry appends an entry to the audit log, assigning its ID and time
+	RecordAuditEntry(ctx context.Context, tx pgx.Tx, entry AuditEntry) (*AuditEntry, error)
+	// ListAuditEntries retrieves up to limit audit entries matching the filter with an ID greater than afterID, oldest first
+	ListAuditEntries(ctx context.Context, tx pgx.Tx, filter *AuditFilter, afterID int64, limit int) ([]*AuditEntry, error)
+	// UpdateAuditEntryClientIP replaces the stored client address of an audit entry, for re-encrypting it under a new key
+	UpdateAuditEntryClientIP(ctx context.Context, tx pgx.Tx, id int64, clientIP string) error
+	// DeleteAuditEntriesBefore permanently removes audit entries recorded before the given time and returns how many were removed
+	DeleteAuditEntriesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
+	// RecordPublishAttempt stores a publish attempt, assigning its ID and time
+	RecordPublishAttempt(ctx context.Context, tx pgx.Tx, attempt PublishAttempt) (*PublishAttempt, error)
+	// ListPublishAttempts retrieves up to limit publish attempts made by identity, newest first, with an ID
+	// less than beforeID, or from the newest if beforeID is 0
+	ListPublishAttempts(ctx context.Context, tx pgx.Tx, identity string, beforeID int64, limit int) ([]*PublishAttempt, error)
+	// GetPublishAttempt retrieves a publish attempt by ID
+	GetPublishAttempt(ctx context.Context, tx pgx.Tx, id int64) (*PublishAttempt, error)
+	// DeletePublishAttemptsBefore permanently removes publish attempts made before the given time, along with
+	// their quarantined server.json, and returns how many were removed
+	DeletePublishAttemptsBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
+	// QuarantinePublish keeps the server.json and validation output of a rejected publish attempt, replacing any
+	// already kept for the attempt
+	QuarantinePublish(ctx context.Context, tx pgx.Tx, quarantined QuarantinedPublish) error
+	// GetQuarantinedPublish retrieves the quarantined server.json of a publish attempt
+	GetQuarantinedPublish(ctx context.Context, tx pgx.Tx, attemptID int64) (*QuarantinedPublish, error)
+	// DeleteQuarantinedPublishesBefore permanently removes server.json documents quarantined before the given
+	// time and returns how many were removed
+	DeleteQuarantinedPublishesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
+	// RecordPackageProvenance stores the upstream provenance of a server version's packages. Packages that
+	// already have a record are skipped, so recorded provenance is never overwritten.
+	RecordPackageProvenance(ctx context.Context, tx pgx.Tx, serverName, version string, provenance []apiv0.PackageProvenance) error
+	// GetPackageProvenance retrieves the recorded provenance of a server version's packages, in the order it was recorded
+	GetPackageProvenance(ctx context.Context, tx pgx.Tx, serverName, version string) ([]apiv0.PackageProvenance, error)
+	// RecordPackageArtifacts stores the artifacts mirrored for a server version's packages. Artifacts that are
+	// already recorded for the version, by package and file name, are skipped.
+	RecordPackageArtifacts(ctx context.Context, tx pgx.Tx, serverName, version string, artifacts []apiv0.PackageArtifact) error
+	// GetPackageArtifacts retrieves the artifacts mirrored for a server version's packages, in the order they were recorded
+	GetPackageArtifacts(ctx context.Context, tx pgx.Tx, serverName, version string) ([]apiv0.PackageArtifact, error)
+	// HasPackageArtifact reports whether any server version has recorded an artifact with the given SHA-256
+	HasPackageArtifact(ctx context.Context, tx pgx.Tx, sha256 string) (bool, error)
+	// QueueArtifactMirror queues the package artifacts of a server version to be mirrored. A version that is
+	// already queued is not queued again.
+	QueueArtifactMirror(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// NextArtifactMirror retrieves the oldest queued mirror that is due for an attempt, or ErrNotFound if there is none
+	NextArtifactMirror(ctx context.Context, tx pgx.Tx) (*ArtifactMirror, error)
+	// RetryArtifactMirror records that an attempt of a queued mirror failed, and leaves it queued until retryAt
+	RetryArtifactMirror(ctx context.Context, tx pgx.Tx, id int64, reason string, retryAt time.Time) (*ArtifactMirror, error)
+	// DeleteArtifactMirror removes a mirror from the queue once it has run or been given up on
+	DeleteArtifactMirror(ctx context.Context, tx pgx.Tx, id int64) error
+	// GetUpstreamCacheEntry retrieves an unexpired cached upstream response, returning ErrNotFound if there is none
+	GetUpstreamCacheEntry(ctx context.Context, tx pgx.Tx, key string) (*UpstreamCacheEntry, error)
+	// PutUpstreamCacheEntry stores a cached upstream response, replacing any entry with the same key
+	PutUpstreamCacheEntry(ctx context.Context, tx pgx.Tx, entry UpstreamCacheEntry) error
+	// DeleteExpiredUpstreamCacheEntries removes cached upstream responses that expired before the given time and returns how many were removed
+	DeleteExpiredUpstreamCacheEntries(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
+	// PutRemoteHealth stores the latest probe result of a remote endpoint, replacing any earlier result for its URL
+	PutRemoteHealth(ctx context.Context, tx pgx.Tx, health apiv0.RemoteHealth) error
+	// GetRemoteHealth retrieves the latest probe results of the given remote URLs, keyed by URL. URLs that were never probed are omitted.
+	GetRemoteHealth(ctx context.Context, tx pgx.Tx, urls []string) (map[string]apiv0.RemoteHealth, error)
+	// DeleteRemoteHealthCheckedBefore removes probe results last checked before the given time and returns how many were removed
+	DeleteRemoteHealthCheckedBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
+	// PutScreeningException exempts a server from screening, replacing any existing exception for it
+	PutScreeningException(ctx context.Context, tx pgx.Tx, exception ScreeningException) (*ScreeningException, error)
+	// GetScreeningException retrieves the screening exception of a server
+	GetScreeningException(ctx context.Context, tx pgx.Tx, serverName string) (*ScreeningException, error)
+	// ListScreeningExceptions retrieves all screening exceptions, ordered by server name
+	ListScreeningExceptions(ctx context.Context, tx pgx.Tx) ([]*ScreeningException, error)
+	// DeleteScreeningException removes the screening exception of a server
+	DeleteScreeningException(ctx context.Context, tx pgx.Tx, serverName string) error
+	// PutIdentityBan bans an identity, replacing any existing ban of it
+	PutIdentityBan(ctx context.Context, tx pgx.Tx, ban IdentityBan) (*IdentityBan, error)
+	// ListIdentityBans retrieves all identity bans, including expired ones, ordered by kind and value
+	ListIdentityBans(ctx context.Context, tx pgx.Tx) ([]*IdentityBan, error)
+	// FindIdentityBans retrieves the bans, including expired ones, selected by query, ordered by kind and value
+	FindIdentityBans(ctx context.Context, tx pgx.Tx, query IdentityBanQuery) ([]*IdentityBan, error)
+	// DeleteIdentityBan lifts the ban of an identity
+	DeleteIdentityBan(ctx context.Context, tx pgx.Tx, kind, value string) error
+	// QuarantineServer records the prior status of every active or deprecated version of a server, and of
+	// versions other bans already quarantined, against a ban and sets the active and deprecated ones to
+	// quarantined with statusMessage. It returns the versions whose status changed.
+	QuarantineServer(ctx context.Context, tx pgx.Tx, serverName, banKind, banValue string, statusMessage *string) ([]*apiv0.ServerResponse, error)
+	// ListQuarantinedVersions retrieves the quarantine records of a ban, ordered by server name and version
+	ListQuarantinedVersions(ctx context.Context, tx pgx.Tx, banKind, banValue string) ([]*QuarantinedVersion, error)
+	// ReleaseQuarantine deletes the quarantine records of a ban and restores the prior status and message of the
+	// versions no other ban holds that are still quarantined. It returns the restored versions.
+	ReleaseQuarantine(ctx context.Context, tx pgx.Tx, banKind, banValue string) ([]*apiv0.ServerResponse, error)
+	// PutServerCuration sets the curation of a server, replacing any existing curation for it
+	PutServerCuration(ctx context.Context, tx pgx.Tx, curation ServerCuration) (*ServerCuration, error)
+	// GetServerCurations retrieves the curation of each given server that has one, keyed by server name
+	GetServerCurations(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string]ServerCuration, error)
+	// ListServerCurations retrieves curated servers with label, or all curated servers if label is empty,
+	// ordered by position with servers without one last, then by server name
+	ListServerCurations(ctx context.Context, tx pgx.Tx, label string) ([]*ServerCuration, error)
+	// DeleteServerCuration removes the curation of a server
+	DeleteServerCuration(ctx context.Context, tx pgx.Tx, serverName string) error
+	// PutServerStaleness records a stale server, replacing any earlier record for it
+	PutServerStaleness(ctx context.Context, tx pgx.Tx, staleness ServerStaleness) error
+	// GetServerStaleness retrieves the staleness of each given server that is stale, keyed by server name
+	GetServerStaleness(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string]ServerStaleness, error)
+	// ListServerStaleness retrieves every stale server, ordered by when it was detected, then by server name
+	ListServerStaleness(ctx context.Context, tx pgx.Tx) ([]*ServerStaleness, error)
+	// DeleteServerStaleness removes the staleness record of a server
+	DeleteServerStaleness(ctx context.Context, tx pgx.Tx, serverName string) error
+	// PutServerTools stores the indexed tools of a server, replacing any earlier index of it
+	PutServerTools(ctx context.Context, tx pgx.Tx, tools ServerTools) error
+	// GetServerTools retrieves the indexed tools of a server
+	GetServerTools(ctx context.Context, tx pgx.Tx, serverName string) (*ServerTools, error)
+	// DeleteServerTools removes the indexed tools of a server
+	DeleteServerTools(ctx context.Context, tx pgx.Tx, serverName string) error
+	// CreateOrgAPIKey stores an organization API key, assigning its ID and creation time. Key names are unique
+	// within an organization.
+	CreateOrgAPIKey(ctx context.Context, tx pgx.Tx, key OrgAPIKey) (*OrgAPIKey, error)
+	// GetOrgAPIKey retrieves an organization API key by ID
+	GetOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64) (*OrgAPIKey, error)
+	// GetOrgAPIKeyByHash retrieves the organization API key with the given key hash
+	GetOrgAPIKeyByHash(ctx context.Context, tx pgx.Tx, keyHash string) (*OrgAPIKey, error)
+	// ListOrgAPIKeys retrieves an organization's API keys, oldest first
+	ListOrgAPIKeys(ctx context.Context, tx pgx.Tx, organization string) ([]*OrgAPIKey, error)
+	// RotateOrgAPIKey replaces the key of an organization API key and records when it was rotated
+	RotateOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64, keyHash, keyPrefix string) (*OrgAPIKey, error)
+	// TouchOrgAPIKey records when an organization API key was last used
+	TouchOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64, usedAt time.Time) error
+	// DeleteOrgAPIKey permanently removes an organization API key
+	DeleteOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64) error
+	// CreateOrgMember adds a member to an organization, assigning its ID and creation time. Subjects are unique
+	// within an organization.
+	CreateOrgMember(ctx context.Context, tx pgx.Tx, member OrgMember) (*OrgMember, error)
+	// GetOrgMember retrieves an organization member by ID
+	GetOrgMember(ctx context.Context, tx pgx.Tx, id int64) (*OrgMember, error)
+	// GetOrgMemberBySubject retrieves the member of an organization with the given subject
+	GetOrgMemberBySubject(ctx context.Context, tx pgx.Tx, organization, subject string) (*OrgMember, error)
+	// ListOrgMembers retrieves an organization's members, oldest first
+	ListOrgMembers(ctx context.Context, tx pgx.Tx, organization string) ([]*OrgMember, error)
+	// DeleteOrgMember removes an organization member
+	DeleteOrgMember(ctx context.Context, tx pgx.Tx, id int64) error
+	// CreateBulkJob queues a bulk job, assigning its ID and creation time
+	CreateBulkJob(ctx context.Context, tx pgx.Tx, job BulkJob) (*BulkJob, error)
+	// GetBulkJob retrieves a bulk job by ID
+	GetBulkJob(ctx context.Context, tx pgx.Tx, id int64) (*BulkJob, error)
+	// ListBulkJobs retrieves the most recently queued bulk jobs, newest first
+	ListBulkJobs(ctx context.Context, tx pgx.Tx, limit int) ([]*BulkJob, error)
+	// ClaimBulkJob marks the oldest queued or running bulk job as running and returns it, or ErrNotFound if there is none.
+	// Running jobs are claimed again because only the instance holding the bulk job lock runs them, so one found
+	// running was interrupted.
+	ClaimBulkJob(ctx context.Context, tx pgx.Tx) (*BulkJob, error)
+	// UpdateBulkJob records a bulk job's state and progress
+	UpdateBulkJob(ctx context.Context, tx pgx.Tx, job *BulkJob) error
+	// CreatePendingPublish stores an accepted publish as pending, assigning its ID and creation time. It fails with
+	// ErrAlreadyExists if a publish of the same version is already pending.
+	CreatePendingPublish(ctx context.Context, tx pgx.Tx, publish PendingPublish) (*PendingPublish, error)
+	// GetPendingPublish retrieves an accepted publish by ID
+	GetPendingPublish(ctx context.Context, tx pgx.Tx, id int64) (*PendingPublish, error)
+	// NextPendingPublish retrieves the oldest publish that is still pending and due for an attempt, or ErrNotFound
+	// if there is none
+	NextPendingPublish(ctx context.Context, tx pgx.Tx) (*PendingPublish, error)
+	// FinishPendingPublish records that a pending publish became active, or was rejected with a reason
+	FinishPendingPublish(ctx context.Context, tx pgx.Tx, id int64, state, reason string) (*PendingPublish, error)
+	// RetryPendingPublish records that an attempt of a pending publish failed for a transient reason, and leaves it
+	// pending until retryAt. It fails with ErrNotFound if the publish is not pending.
+	RetryPendingPublish(ctx context.Context, tx pgx.Tx, id int64, reason string, retryAt time.Time) (*PendingPublish, error)
+	// DeletePendingPublishesBefore permanently removes publishes that finished before the given time and returns how many were removed
+	DeletePendingPublishesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
+	// CreateServerAlias stores an alias, replacing any alias with the same name, and records the rename in the
+	// changes feed against the latest version of the server it points to
+	CreateServerAlias(ctx context.Context, tx pgx.Tx, alias ServerAlias) (*ServerAlias, error)
+	// GetServerAlias retrieves an alias that has not expired, or ErrNotFound if there is none
+	GetServerAlias(ctx context.Context, tx pgx.Tx, alias string) (*ServerAlias, error)
+	// ListServerAliases retrieves the aliases pointing at a server that have not expired, ordered by alias
+	ListServerAliases(ctx context.Context, tx pgx.Tx, serverName string) ([]*ServerAlias, error)
+	// RetargetServerAliases points the aliases of a server at its new name, returning how many were moved
+	RetargetServerAliases(ctx context.Context, tx pgx.Tx, serverName, newName string) (int64, error)
+	// DeleteServerAlias removes an alias, returning ErrNotFound if there is none
+	DeleteServerAlias(ctx context.Context, tx pgx.Tx, alias string) error
+	// CreateWebhookSubscription stores a webhook subscription, assigning its ID and creation time
+	CreateWebhookSubscription(ctx context.Context, tx pgx.Tx, subscription WebhookSubscription) (*WebhookSubscription, error)
+	// GetWebhookSubscription retrieves a webhook subscription by ID
+	GetWebhookSubscription(ctx context.Context, tx pgx.Tx, id int64) (*WebhookSubscription, error)
+	// ListWebhookSubscriptions retrieves all webhook subscriptions, oldest first
+	ListWebhookSubscriptions(ctx context.Context, tx pgx.Tx) ([]*WebhookSubscription, error)
+	// UpdateWebhookSubscription replaces a webhook subscription's URL, filters and format
+	UpdateWebhookSubscription(ctx context.Context, tx pgx.Tx, subscription *WebhookSubscription) error
+	// UpdateWebhookSubscriptionSecret replaces the stored signing secret of a webhook subscription
+	UpdateWebhookSubscriptionSecret(ctx context.Context, tx pgx.Tx, id int64, secret string) error
+	// RecordWebhookDelivery records how far a webhook subscription has been delivered and why delivery stopped, if it failed
+	RecordWebhookDelivery(ctx context.Context, tx pgx.Tx, id int64, lastSeq int64, lastError string) error
+	// DeleteWebhookSubscription permanently removes a webhook subscription
+	DeleteWebhookSubscription(ctx context.Context, tx pgx.Tx, id int64) error
+	// CreateAnnouncement stores an announcement, assigning its ID and creation time
+	CreateAnnouncement(ctx context.Context, tx pgx.Tx, announcement AnnouncementRecord) (*AnnouncementRecord, error)
+	// GetAnnouncement retrieves an announcement by ID
+	GetAnnouncement(ctx context.Context, tx pgx.Tx, id int64) (*AnnouncementRecord, error)
+	// ListAnnouncements retrieves all announcements, including those not shown yet or any more, oldest first
+	ListAnnouncements(ctx context.Context, tx pgx.Tx) ([]*AnnouncementRecord, error)
+	// UpdateAnnouncement replaces an announcement's message, severity and schedule
+	UpdateAnnouncement(ctx context.Context, tx pgx.Tx, announcement *AnnouncementRecord) error
+	// DeleteAnnouncement permanently removes an announcement
+	DeleteAnnouncement(ctx context.Context, tx pgx.Tx, id int64) error
+	// CreateOAuthClient stores a registered OAuth client, assigning its creation time. It returns ErrAlreadyExists
+	// when the client ID is taken.
+	CreateOAuthClient(ctx context.Context, tx pgx.Tx, client OAuthClient) (*OAuthClient, error)
+	// GetOAuthClient retrieves a registered OAuth client by client ID
+	GetOAuthClient(ctx context.Context, tx pgx.Tx, clientID string) (*OAuthClient, error)
+	// ListOAuthClients retrieves all registered OAuth clients, oldest first
+	ListOAuthClients(ctx context.Context, tx pgx.Tx) ([]*OAuthClient, error)
+	// DeleteOAuthClient permanently removes a registered OAuth client
+	DeleteOAuthClient(ctx context.Context, tx pgx.Tx, clientID string) error
+	// CreateCollection stores a collection, assigning its ID and creation time
+	CreateCollection(ctx context.Context, tx pgx.Tx, collection Collection) (*Collection, error)
+	// GetCollection retrieves a collection by ID
+	GetCollection(ctx context.Context, tx pgx.Tx, id int64) (*Collection, error)
+	// ListCollections retrieves up to limit collections with an ID greater than afterID, oldest first, only
+	// those of owner if it is not empty
+	ListCollections(ctx context.Context, tx pgx.Tx, owner string, afterID int64, limit int) ([]*Collection, error)
+	// UpdateCollection replaces a collection's title, description and items
+	UpdateCollection(ctx context.Context, tx pgx.Tx, collection *Collection) error
+	// DeleteCollection permanently removes a collection
+	DeleteCollection(ctx context.Context, tx pgx.Tx, id int64) error
+	// CreatePreview stores a preview under the ID it was given, recording its creation time
+	CreatePreview(ctx context.Context, tx pgx.Tx, preview Preview) (*Preview, error)
+	// GetPreview retrieves an unexpired preview, returning ErrNotFound if there is none
+	GetPreview(ctx context.Context, tx pgx.Tx, id string) (*Preview, error)
+	// DeleteExpiredPreviews removes previews that expired before the given time and returns how many were removed
+	DeleteExpiredPreviews(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
+	// IncrementMetric adds delta to a metric's counter for the bucket containing at
+	IncrementMetric(ctx context.Context, tx pgx.Tx, metric string, at time.Time, delta int64) error
+	// ListMetricCounts retrieves a metric's counters for buckets starting at or after from and before to,
+	// oldest first. Buckets nothing was counted in are left out.
+	ListMetricCounts(ctx context.Context, tx pgx.Tx, metric string, from, to time.Time) ([]MetricCount, error)
+	// PutInstallReport stores an install report, replacing the reporter's earlier report for the version
+	PutInstallReport(ctx context.Context, tx pgx.Tx, report InstallReport) error
+	// CountInstallReports counts the reports made at or after since for every version of the given servers,
+	// keyed by server name and then version. Versions without reports are omitted.
+	CountInstallReports(ctx context.Context, tx pgx.Tx, serverNames []string, since time.Time) (map[string]map[string]InstallReportCount, error)
+	// DeleteInstallReportsBefore removes install reports made before the given time and returns how many were removed
+	DeleteInstallReportsBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
+	// PutValidationPolicy sets the validation policy of a namespace, replacing any existing policy for it
+	PutValidationPolicy(ctx context.Context, tx pgx.Tx, policy ValidationPolicy) (*ValidationPolicy, error)
+	// ListValidationPolicies retrieves all validation policies, ordered by namespace
+	ListValidationPolicies(ctx context.Context, tx pgx.Tx) ([]*ValidationPolicy, error)
+	// DeleteValidationPolicy removes the validation policy of a namespace
+	DeleteValidationPolicy(ctx context.Context, tx pgx.Tx, namespace string) error
+	// TryAcquireJobLock acquires the named background job lock without waiting, returning ErrLockNotAcquired
+	// if another instance holds it. The lock is held until released or its connection is lost.
+	TryAcquireJobLock(ctx context.Context, name string) (JobLock, error)
 	// InTransaction executes a function within a database transaction
 	InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error
 	// Close closes the database connection
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// JobLocker acquires locks for background jobs
+type JobLocker interface {
+	TryAcquireJobLock(ctx context.Context, name string) (JobLock, error)
+}
+
+// RunAsLeader runs job only while this instance holds the named job lock, so that when several registry
+// replicas are running, exactly one of them runs the job. The others retry every retryInterval and take
+// over once the holder shuts down or loses its database connection. The context passed to job is cancelled
+// when the lock is lost. RunAsLeader returns once ctx is cancelled and job has returned.
+func RunAsLeader(ctx context.Context, locker JobLocker, name string, retryInterval time.Duration, job func(ctx context.Context)) {
+	for {
+		lock, err := locker.TryAcquireJobLock(ctx, name)
+		switch {
+		case err == nil:
+			log.Printf("Acquired %s job lock; running %s on this instance", name, name)
+			runWhileHeld(ctx, lock, retryInterval, job)
+			if ctx.Err() == nil {
+				log.Printf("Lost %s job lock; stopped %s on this instance", name, name)
+			}
+		case errors.Is(err, ErrLockNotAcquired), ctx.Err() != nil:
+			// Another instance is running the job, or we are shutting down
+		default:
+			log.Printf("Failed to acquire %s job lock: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// runWhileHeld runs job until it returns, ctx is cancelled, or lock is lost, then releases lock
+func runWhileHeld(ctx context.Context, lock JobLock, checkInterval time.Duration, job func(ctx context.Context)) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		job(jobCtx)
+	}()
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+wait:
+	for {
+		select {
+		case <-done:
+			break wait
+		case <-ctx.Done():
+			break wait
+		case <-ticker.C:
+			checkCtx, cancelCheck := context.WithTimeout(ctx, 5*time.Second)
+			held := lock.Held(checkCtx)
+			cancelCheck()
+			if !held {
+				break wait
+			}
+		}
+	}
+
+	cancel()
+	<-done
+
+	releaseCtx, cancelRelease := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelRelease()
+	if err := lock.Release(releaseCtx); err != nil {
+		log.Printf("Failed to release job lock: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package database_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// fakeLocker grants a single lock per name, like PostgreSQL advisory locks shared by several replicas
+type fakeLocker struct {
+	mu      sync.Mutex
+	holders map[string]*fakeLock
+}
+
+type fakeLock struct {
+	locker *fakeLocker
+	name   string
+	lost   atomic.Bool
+}
+
+func (f *fakeLocker) TryAcquireJobLock(_ context.Context, name string) (database.JobLock, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.holders[name] != nil {
+		return nil, database.ErrLockNotAcquired
+	}
+	lock := &fakeLock{locker: f, name: name}
+	f.holders[name] = lock
+	return lock, nil
+}
+
+func (l *fakeLock) Held(context.Context) bool {
+	return !l.lost.Load()
+}
+
+func (l *fakeLock) Release(context.Context) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+	if l.locker.holders[l.name] == l {
+		delete(l.locker.holders, l.name)
+	}
+	return nil
+}
+
+func (f *fakeLocker) holder(name string) *fakeLock {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.holders[name]
+}
+
+func TestRunAsLeader(t *testing.T) {
+	locker := &fakeLocker{holders: map[string]*fakeLock{}}
+	const interval = 10 * time.Millisecond
+
+	var running atomic.Int32
+	var maxRunning atomic.Int32
+	var runs atomic.Int32
+	job := func(ctx context.Context) {
+		runs.Add(1)
+		n := running.Add(1)
+		if n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		<-ctx.Done()
+		running.Add(-1)
+	}
+
+	// Three replicas compete for the same job
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			database.RunAsLeader(ctx, locker, "retention", interval, job)
+		}()
+	}
+
+	assert.Eventually(t, func() bool { return running.Load() == 1 }, time.Second, interval)
+
+	// When the leader loses its lock, its job stops and another replica takes over
+	locker.holder("retention").lost.Store(true)
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 && running.Load() == 1 }, time.Second, interval)
+
+	cancel()
+	wg.Wait()
+	assert.Equal(t, int32(0), running.Load())
+	assert.Equal(t, int32(1), maxRunning.Load(), "job must never run on two replicas at once")
+	assert.Nil(t, locker.holder("retention"), "lock must be released on shutdown")
+}
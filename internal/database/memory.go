@@ -0,0 +1,3952 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/repourl"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// MemoryURL is the database URL that selects the in-memory database instead of PostgreSQL
+const MemoryURL = "memory://"
+
+// Memory is an implementation of the Database interface that keeps all data in process memory.
+// It enforces the same constraints as the PostgreSQL schema and reports violations with the same
+// sentinel errors, so it can stand in for PostgreSQL in tests, for throwaway registries seeded from
+// a snapshot, and when embedding the registry. Data is lost when the process exits.
+//
+// Transactions run one at a time: InTransaction holds the database lock for the whole callback and
+// restores the previous state if it returns an error. Strings sort by byte value, which may differ
+// from the collation of a PostgreSQL database for names mixing case or punctuation.
+type Memory struct {
+	mu    sync.Mutex // guards state; held for the duration of each call or transaction
+	state *memoryState
+
+	jobLocksMu sync.Mutex
+	jobLocks   map[string]bool
+
+	// The upstream cache is not transactional, like PostgreSQL's which is written outside callers' transactions,
+	// so it has its own lock and can be used while a transaction holds mu
+	upstreamCacheMu sync.Mutex
+	upstreamCache   map[string]UpstreamCacheEntry
+
+	// Event listeners are called after the lock is released, so they may use the database
+	listenersMu  sync.Mutex
+	listeners    map[int]func(Event)
+	nextListener int
+}
+
+type serverKey struct {
+	name    string
+	version string
+}
+
+// memoryServer is a row of the servers table. value holds the server JSON, so every read returns a fresh copy.
+type memoryServer struct {
+	status          model.Status
+	statusChangedAt time.Time
+	statusMessage   *string
+	publishedAt     time.Time
+	updatedAt       time.Time
+	isLatest        bool
+	value           []byte
+	publisher       *apiv0.Publisher
+	documentDigest  string
+}
+
+// memoryChange is a row of the server_changes table, with the state of the server row after the change
+type memoryChange struct {
+	seq          int64
+	key          serverKey
+	changeType   string
+	changedAt    time.Time
+	row          memoryServer
+	previousName string
+}
+
+// memoryAuditEntry is a row of the audit_log table. details holds the JSON-encoded details, so every
+// read returns a fresh copy with the same types PostgreSQL would return.
+type memoryAuditEntry struct {
+	entry   AuditEntry
+	details []byte
+}
+
+// memoryProvenance is a row of the package_provenance table
+type memoryProvenance struct {
+	key    serverKey
+	record apiv0.PackageProvenance
+}
+
+// memoryArtifact is a row of the package_artifacts table
+type memoryArtifact struct {
+	key      serverKey
+	artifact apiv0.PackageArtifact
+}
+
+// memoryState holds all tables. Rows are replaced rather than modified in place, so a shallow
+// clone is enough to roll back a transaction.
+type memoryState struct {
+	servers             map[serverKey]memoryServer
+	documents           map[string][]byte
+	changes             []memoryChange
+	lastSeq             int64
+	checkpoints         map[string]int64
+	maintenance         MaintenanceState
+	verifications       map[int64]NamespaceVerification
+	lastVerificationID  int64
+	auditLog            []memoryAuditEntry
+	lastAuditID         int64
+	publishAttempts     []PublishAttempt
+	lastPublishAttempt  int64
+	quarantine          map[int64]memoryQuarantinedPublish
+	provenance          []memoryProvenance
+	artifacts           []memoryArtifact
+	artifactMirrors     map[int64]ArtifactMirror
+	lastArtifactMirror  int64
+	bulkJobs            map[int64]BulkJob
+	lastBulkJobID       int64
+	pendingPublishes    map[int64]memoryPendingPublish
+	lastPendingPublish  int64
+	remoteHealth        map[string]apiv0.RemoteHealth
+	screening           map[string]ScreeningException
+	bans                map[banKey]IdentityBan
+	quarantinedVersions map[quarantineKey]QuarantinedVersion
+	curation            map[string]ServerCuration
+	staleness           map[string]ServerStaleness
+	serverTools         map[string]ServerTools
+	repositoryURLs      map[string]string
+	tokenUses           map[string]time.Time
+	orgAPIKeys          map[int64]OrgAPIKey
+	lastOrgAPIKeyID     int64
+	orgMembers          map[int64]OrgMember
+	lastOrgMemberID     int64
+	webhooks            map[int64]WebhookSubscription
+	lastWebhookID       int64
+	announcements       map[int64]AnnouncementRecord
+	lastAnnouncementID  int64
+	oauthClients        map[string]OAuthClient
+	collections         map[int64]Collection
+	lastCollectionID    int64
+	previews            map[string]memoryPreview
+	metricCounts        map[metricKey]int64
+	installReports      map[installReportKey]InstallReport
+	validationPolicies  map[string]ValidationPolicy
+	aliases             map[string]ServerAlias
+	// pending holds events to deliver once the call or transaction that caused them finishes, so
+	// events from a rolled back transaction are discarded with it
+	pending []Event
+}
+
+func (s *memoryState) clone() *memoryState {
+	clone := *s
+	clone.servers = maps.Clone(s.servers)
+	clone.documents = maps.Clone(s.documents)
+	clone.changes = slices.Clone(s.changes)
+	clone.checkpoints = maps.Clone(s.checkpoints)
+	clone.verifications = maps.Clone(s.verifications)
+	clone.auditLog = slices.Clone(s.auditLog)
+	clone.publishAttempts = slices.Clone(s.publishAttempts)
+	clone.quarantine = maps.Clone(s.quarantine)
+	clone.provenance = slices.Clone(s.provenance)
+	clone.artifactMirrors = maps.Clone(s.artifactMirrors)
+	clone.bulkJobs = maps.Clone(s.bulkJobs)
+	clone.pendingPublishes = maps.Clone(s.pendingPublishes)
+	clone.remoteHealth = maps.Clone(s.remoteHealth)
+	clone.screening = maps.Clone(s.screening)
+	clone.bans = maps.Clone(s.bans)
+	clone.quarantinedVersions = maps.Clone(s.quarantinedVersions)
+	clone.curation = maps.Clone(s.curation)
+	clone.staleness = maps.Clone(s.staleness)
+	clone.serverTools = maps.Clone(s.serverTools)
+	clone.repositoryURLs = maps.Clone(s.repositoryURLs)
+	clone.tokenUses = maps.Clone(s.tokenUses)
+	clone.orgAPIKeys = maps.Clone(s.orgAPIKeys)
+	clone.orgMembers = maps.Clone(s.orgMembers)
+	clone.webhooks = maps.Clone(s.webhooks)
+	clone.announcements = maps.Clone(s.announcements)
+	clone.oauthClients = maps.Clone(s.oauthClients)
+	clone.collections = maps.Clone(s.collections)
+	clone.previews = maps.Clone(s.previews)
+	clone.metricCounts = maps.Clone(s.metricCounts)
+	clone.installReports = maps.Clone(s.installReports)
+	clone.validationPolicies = maps.Clone(s.validationPolicies)
+	clone.aliases = maps.Clone(s.aliases)
+	clone.pending = slices.Clone(s.pending)
+	return &clone
+}
+
+// memoryTx is passed to InTransaction callbacks so calls made with it skip locking. Memory never
+// calls its pgx.Tx methods, and neither may callers.
+type memoryTx struct {
+	pgx.Tx
+}
+
+// NewMemory creates a new, empty in-memory database
+func NewMemory() *Memory {
+	return &Memory{
+		state: &memoryState{
+			servers:     map[serverKey]memoryServer{},
+			documents:   map[string][]byte{},
+			checkpoints: map[string]int64{},
+			maintenance: MaintenanceState{
+				RetryAfterSeconds: 300,
+				UpdatedAt:         now(),
+			},
+			verifications:       map[int64]NamespaceVerification{},
+			artifactMirrors:     map[int64]ArtifactMirror{},
+			bulkJobs:            map[int64]BulkJob{},
+			pendingPublishes:    map[int64]memoryPendingPublish{},
+			remoteHealth:        map[string]apiv0.RemoteHealth{},
+			screening:           map[string]ScreeningException{},
+			bans:                map[banKey]IdentityBan{},
+			quarantinedVersions: map[quarantineKey]QuarantinedVersion{},
+			curation:            map[string]ServerCuration{},
+			staleness:           map[string]ServerStaleness{},
+			serverTools:         map[string]ServerTools{},
+			quarantine:          map[int64]memoryQuarantinedPublish{},
+			repositoryURLs:      map[string]string{},
+			tokenUses:           map[string]time.Time{},
+			orgAPIKeys:          map[int64]OrgAPIKey{},
+			orgMembers:          map[int64]OrgMember{},
+			webhooks:            map[int64]WebhookSubscription{},
+			announcements:       map[int64]AnnouncementRecord{},
+			oauthClients:        map[string]OAuthClient{},
+			collections:         map[int64]Collection{},
+			previews:            map[string]memoryPreview{},
+			metricCounts:        map[metricKey]int64{},
+			installReports:      map[installReportKey]InstallReport{},
+			validationPolicies:  map[string]ValidationPolicy{},
+			aliases:             map[string]ServerAlias{},
+		},
+		jobLocks:      map[string]bool{},
+		upstreamCache: map[string]UpstreamCacheEntry{},
+		listeners:     map[int]func(Event){},
+	}
+}
+
+// now returns the current time at the microsecond precision PostgreSQL stores
+func now() time.Time {
+	return time.Now().Round(time.Microsecond)
+}
+
+// lock locks the database for a call made outside a transaction and returns the matching unlock.
+// Calls made with a transaction already hold the lock.
+func (db *Memory) lock(tx pgx.Tx) func() {
+	if tx != nil {
+		return func() {}
+	}
+	db.mu.Lock()
+	return db.unlock
+}
+
+// unlock releases the database lock and then delivers the events of the finished call or transaction
+func (db *Memory) unlock() {
+	events := db.state.pending
+	db.state.pending = nil
+	db.mu.Unlock()
+
+	if len(events) == 0 {
+		return
+	}
+	db.listenersMu.Lock()
+	handlers := slices.Collect(maps.Values(db.listeners))
+	db.listenersMu.Unlock()
+	for _, event := range events {
+		for _, handle := range handlers {
+			handle(event)
+		}
+	}
+}
+
+// serverNamePattern mirrors the check_server_name_format constraint
+var serverNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9.-]*[a-zA-Z0-9]/[a-zA-Z0-9][a-zA-Z0-9._-]*[a-zA-Z0-9]$`)
+
+// minPublishedAt mirrors the lower bound of the check_published_at_reasonable constraint
+var minPublishedAt = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// hiddenStatus reports whether versions with status are left out of listings unless deleted versions are included
+func hiddenStatus(status model.Status) bool {
+	return status == model.StatusDeleted || status == model.StatusQuarantined
+}
+
+// checkServerRow enforces the check constraints of the servers table, naming the violated constraint
+func checkServerRow(key serverKey, row memoryServer) error {
+	switch {
+	case !serverNamePattern.MatchString(key.name):
+		return fmt.Errorf("%w: server name %q violates check constraint \"check_server_name_format\"", ErrInvalidInput, key.name)
+	case strings.TrimSpace(key.version) == "":
+		return fmt.Errorf("%w: empty version violates check constraint \"check_version_not_empty\"", ErrInvalidInput)
+	case row.status != model.StatusActive && row.status != model.StatusDeprecated && row.status != model.StatusDeleted &&
+		row.status != model.StatusQuarantined:
+		return fmt.Errorf("%w: status %q violates check constraint \"check_status_valid\"", ErrInvalidInput, row.status)
+	case row.publishedAt.Before(minPublishedAt) || row.publishedAt.After(time.Now().Add(24*time.Hour)):
+		return fmt.Errorf("%w: published_at %s violates check constraint \"check_published_at_reasonable\"", ErrInvalidInput, row.publishedAt)
+	case row.statusChangedAt.Before(row.publishedAt):
+		return fmt.Errorf("%w: status_changed_at before published_at violates check constraint \"check_status_changed_at_after_published\"", ErrInvalidInput)
+	case row.statusMessage != nil && len(*row.statusMessage) > 500:
+		return fmt.Errorf("%w: status message longer than 500 characters violates check constraint \"check_status_message_length\"", ErrInvalidInput)
+	}
+	return nil
+}
+
+// putServer inserts or replaces a server row and records the change in the changes feed
+func (s *memoryState) putServer(key serverKey, row memoryServer, changeType string) {
+	s.servers[key] = row
+	s.lastSeq++
+	s.changes = append(s.changes, memoryChange{seq: s.lastSeq, key: key, changeType: changeType, changedAt: now(), row: row})
+	s.pending = append(s.pending, Event{Type: EventServerChange, Seq: s.lastSeq})
+}
+
+// putDocument adds a document to the server_documents table unless it is already stored
+func (s *memoryState) putDocument(digest string, document []byte) {
+	if _, exists := s.documents[digest]; !exists {
+		s.documents[digest] = slices.Clone(document)
+	}
+}
+
+func (row memoryServer) response() (*apiv0.ServerResponse, error) {
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(row.value, &serverJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+
+	return &apiv0.ServerResponse{
+		Server: serverJSON,
+		Meta: apiv0.ResponseMeta{
+			Official: &apiv0.RegistryExtensions{
+				Status:            row.status,
+				StatusChangedAt:   row.statusChangedAt,
+				StatusMessage:     row.statusMessage,
+				PublishedAt:       row.publishedAt,
+				UpdatedAt:         row.updatedAt,
+				IsLatest:          row.isLatest,
+				Runtimes:          apiv0.ServerRuntimes(&serverJSON),
+				Publisher:         clonePublisher(row.publisher),
+				VerifiedPublisher: row.publisher.IsVerified(),
+			},
+		},
+	}, nil
+}
+
+// clonePublisher copies a publisher, so stored rows don't share it with callers
+func clonePublisher(publisher *apiv0.Publisher) *apiv0.Publisher {
+	if publisher == nil {
+		return nil
+	}
+	clone := *publisher
+	return &clone
+}
+
+// canonicalRepositoryURL mirrors canonicalRepositoryURL in PostgreSQL: the recorded canonical form of a
+// repository URL, or its normalized form if none was recorded
+func (s *memoryState) canonicalRepositoryURL(rawURL string) string {
+	if canonical, exists := s.repositoryURLs[rawURL]; exists {
+		return canonical
+	}
+	return repourl.Normalize(rawURL)
+}
+
+// matchesFilter mirrors buildFilterConditions
+func matchesFilter(key serverKey, row memoryServer, filter *ServerFilter) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+
+	switch {
+	case filter.Name != nil && key.name != *filter.Name,
+		filter.Namespace != nil && !strings.HasPrefix(key.name, *filter.Namespace+"/"),
+		filter.UpdatedSince != nil && !row.updatedAt.After(*filter.UpdatedSince),
+		filter.SubstringName != nil && !strings.Contains(strings.ToLower(key.name), strings.ToLower(*filter.SubstringName)),
+		filter.Version != nil && key.version != *filter.Version,
+		filter.IsLatest != nil && row.isLatest != *filter.IsLatest,
+		(filter.IncludeDeleted == nil || !*filter.IncludeDeleted) && hiddenStatus(row.status):
+		return false, nil
+	}
+
+	if filter.RemoteURL == nil && filter.Runtime == nil {
+		return true, nil
+	}
+
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(row.value, &serverJSON); err != nil {
+		return false, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+	if filter.RemoteURL != nil && !slices.ContainsFunc(serverJSON.Remotes, func(remote model.Transport) bool {
+		return remote.URL == *filter.RemoteURL
+	}) {
+		return false, nil
+	}
+	if filter.Runtime != nil && !slices.Contains(apiv0.ServerRuntimes(&serverJSON), *filter.Runtime) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// asOf returns the state to read servers from: s itself, or with filter.AsOf a copy whose servers hold the
+// state each server version had at that time, as recorded with its changes
+func (s *memoryState) asOf(filter *ServerFilter) *memoryState {
+	if filter == nil || filter.AsOf == nil {
+		return s
+	}
+	past := *s
+	past.servers = map[serverKey]memoryServer{}
+	for _, change := range s.changes {
+		switch {
+		case change.changedAt.After(*filter.AsOf):
+		case change.changeType == "deleted":
+			delete(past.servers, change.key)
+		default:
+			past.servers[change.key] = change.row
+		}
+	}
+	return &past
+}
+
+// filterServers returns the keys of the servers matching filter, ordered by name and version
+func (s *memoryState) filterServers(filter *ServerFilter) ([]serverKey, error) {
+	var keys []serverKey
+	for key, row := range s.servers {
+		matches, err := matchesFilter(key, row, filter)
+		if err != nil {
+			return nil, err
+		}
+		// Curation, staleness, indexed tools and canonical repository URLs are kept in their own tables, so they are checked
+		// here rather than in matchesFilter
+		if matches && filter != nil && filter.RepositoryURL != nil {
+			var serverJSON apiv0.ServerJSON
+			if err := json.Unmarshal(row.value, &serverJSON); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+			}
+			matches = serverJSON.Repository != nil && serverJSON.Repository.URL != "" &&
+				s.canonicalRepositoryURL(serverJSON.Repository.URL) == repourl.Normalize(*filter.RepositoryURL)
+		}
+		if matches && filter != nil && filter.CurationLabel != nil {
+			matches = slices.Contains(s.curation[key.name].Labels, *filter.CurationLabel)
+		}
+		if matches && filter != nil && filter.Unmaintained != nil {
+			matches = (s.staleness[key.name].UnmaintainedAt != nil) == *filter.Unmaintained
+		}
+		if matches && filter != nil && len(filter.ToolKeywords) > 0 {
+			indexed, exists := s.serverTools[key.name]
+			searchText := toolSearchText(indexed.Tools)
+			matches = exists && !slices.ContainsFunc(filter.ToolKeywords, func(keyword string) bool {
+				return !strings.Contains(searchText, strings.ToLower(keyword))
+			})
+		}
+		if matches {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b serverKey) int {
+		return cmp.Or(strings.Compare(a.name, b.name), strings.Compare(a.version, b.version))
+	})
+	return keys, nil
+}
+
+// sortByPublishedDesc orders keys newest first, breaking ties by name and then version, highest first, as
+// ORDER BY published_at DESC, version DESC does for a single server
+func (s *memoryState) sortByPublishedDesc(keys []serverKey) {
+	slices.SortFunc(keys, func(a, b serverKey) int {
+		return cmp.Or(
+			s.servers[b].publishedAt.Compare(s.servers[a].publishedAt),
+			strings.Compare(b.name, a.name),
+			strings.Compare(b.version, a.version),
+		)
+	})
+}
+
+// afterCursor mirrors addCursorCondition
+func afterCursor(key serverKey, cursor string) bool {
+	if cursor == "" {
+		return true
+	}
+	name, version, found := strings.Cut(cursor, ":")
+	if !found {
+		return key.name > cursor
+	}
+	return key.name > name || (key.name == name && key.version > version)
+}
+
+// ListServers retrieves servers matching filter, ordered by name and version, starting after cursor
+func (db *Memory) ListServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	state := db.state.asOf(filter)
+	keys, err := state.filterServers(filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var results []*apiv0.ServerResponse
+	for _, key := range keys {
+		if len(results) >= limit {
+			break
+		}
+		if !afterCursor(key, cursor) {
+			continue
+		}
+		response, err := state.servers[key].response()
+		if err != nil {
+			return nil, "", err
+		}
+		results = append(results, response)
+	}
+
+	nextCursor := ""
+	if len(results) > 0 && len(results) >= limit {
+		lastResult := results[len(results)-1]
+		nextCursor = lastResult.Server.Name + ":" + lastResult.Server.Version
+	}
+
+	return results, nextCursor, nil
+}
+
+// GetServerByName retrieves the latest version of a server by server name
+func (db *Memory) GetServerByName(ctx context.Context, tx pgx.Tx, serverName string, includeDeleted bool) (*apiv0.ServerResponse, error) {
+	isLatest := true
+	return db.getFirst(ctx, tx, &ServerFilter{Name: &serverName, IsLatest: &isLatest, IncludeDeleted: &includeDeleted})
+}
+
+// GetServerByNameAndVersion retrieves a specific version of a server by server name and version
+func (db *Memory) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx, serverName string, version string, includeDeleted bool) (*apiv0.ServerResponse, error) {
+	return db.getFirst(ctx, tx, &ServerFilter{Name: &serverName, Version: &version, IncludeDeleted: &includeDeleted})
+}
+
+// GetCurrentLatestVersion retrieves the current latest version of a server by server name, including deleted servers
+func (db *Memory) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error) {
+	isLatest, includeDeleted := true, true
+	return db.getFirst(ctx, tx, &ServerFilter{Name: &serverName, IsLatest: &isLatest, IncludeDeleted: &includeDeleted})
+}
+
+// getFirst returns the most recently published server matching filter
+func (db *Memory) getFirst(ctx context.Context, tx pgx.Tx, filter *ServerFilter) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	keys, err := db.state.filterServers(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, ErrNotFound
+	}
+
+	db.state.sortByPublishedDesc(keys)
+	return db.state.servers[keys[0]].response()
+}
+
+// GetAllVersionsByServerName retrieves all versions of a server by server name, newest first
+func (db *Memory) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx, serverName string, includeDeleted bool) ([]*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	keys, err := db.state.filterServers(&ServerFilter{Name: &serverName, IncludeDeleted: &includeDeleted})
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, ErrNotFound
+	}
+
+	db.state.sortByPublishedDesc(keys)
+	results := make([]*apiv0.ServerResponse, 0, len(keys))
+	for _, key := range keys {
+		response, err := db.state.servers[key].response()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, response)
+	}
+	return results, nil
+}
+
+// CreateServer inserts a new server version with official metadata
+func (db *Memory) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if serverJSON == nil || officialMeta == nil {
+		return nil, fmt.Errorf("serverJSON and officialMeta are required")
+	}
+
+	if serverJSON.Name == "" || serverJSON.Version == "" {
+		return nil, fmt.Errorf("server name and version are required")
+	}
+
+	valueJSON, err := json.Marshal(serverJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+	document, digest, err := apiv0.ServerDocument(serverJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode server document: %w", err)
+	}
+
+	defer db.lock(tx)()
+
+	key := serverKey{name: serverJSON.Name, version: serverJSON.Version}
+	row := memoryServer{
+		status:          officialMeta.Status,
+		statusChangedAt: officialMeta.StatusChangedAt.Round(time.Microsecond),
+		statusMessage:   officialMeta.StatusMessage,
+		publishedAt:     officialMeta.PublishedAt.Round(time.Microsecond),
+		updatedAt:       officialMeta.UpdatedAt.Round(time.Microsecond),
+		isLatest:        officialMeta.IsLatest,
+		value:           valueJSON,
+		publisher:       clonePublisher(officialMeta.Publisher),
+		documentDigest:  digest,
+	}
+	if err := checkServerRow(key, row); err != nil {
+		return nil, fmt.Errorf("failed to insert server: %w", err)
+	}
+	if _, exists := db.state.servers[key]; exists {
+		return nil, fmt.Errorf("failed to insert server: %w: %s version %s", ErrAlreadyExists, key.name, key.version)
+	}
+	if row.isLatest {
+		for existing, existingRow := range db.state.servers {
+			if existing.name == key.name && existingRow.isLatest {
+				return nil, fmt.Errorf("failed to insert server: %w: %s already has a latest version", ErrAlreadyExists, key.name)
+			}
+		}
+	}
+
+	db.state.putDocument(digest, document)
+	db.state.putServer(key, row, "created")
+
+	return &apiv0.ServerResponse{
+		Server: *serverJSON,
+		Meta: apiv0.ResponseMeta{
+			Official: officialMeta,
+		},
+	}, nil
+}
+
+// UpdateServer replaces the server JSON of an existing server version, keeping its metadata
+func (db *Memory) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if serverJSON == nil {
+		return nil, fmt.Errorf("serverJSON is required")
+	}
+
+	if serverJSON.Name != serverName || serverJSON.Version != version {
+		return nil, fmt.Errorf("%w: server name and version in JSON must match parameters", ErrInvalidInput)
+	}
+
+	valueJSON, err := json.Marshal(serverJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal updated server: %w", err)
+	}
+	document, digest, err := apiv0.ServerDocument(serverJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode server document: %w", err)
+	}
+
+	defer db.lock(tx)()
+
+	key := serverKey{name: serverName, version: version}
+	row, exists := db.state.servers[key]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	row.value = valueJSON
+	row.updatedAt = now()
+	row.documentDigest = digest
+	db.state.putDocument(digest, document)
+	db.state.putServer(key, row, "updated")
+
+	return row.response()
+}
+
+// PutServerDocument stores a server version's document under its digest and points the version at it
+func (db *Memory) PutServerDocument(ctx context.Context, tx pgx.Tx, serverName, version string, document []byte) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key := serverKey{name: serverName, version: version}
+	row, exists := db.state.servers[key]
+	if !exists {
+		return "", ErrNotFound
+	}
+	digest := apiv0.DocumentDigest(document)
+	db.state.putDocument(digest, document)
+	// Pointing a version at its document does not change what is served, so it is not recorded as a change
+	row.documentDigest = digest
+	db.state.servers[key] = row
+	return digest, nil
+}
+
+// GetServerDocument retrieves a stored document by its digest
+func (db *Memory) GetServerDocument(ctx context.Context, tx pgx.Tx, digest string) ([]byte, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	document, exists := db.state.documents[digest]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return slices.Clone(document), nil
+}
+
+// ListServerDocumentRefs retrieves the document digest of every server version, ordered by name and then version
+func (db *Memory) ListServerDocumentRefs(ctx context.Context, tx pgx.Tx, cursor string, limit int) ([]*ServerDocumentRef, string, error) {
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	defer db.lock(tx)()
+
+	keys := make([]serverKey, 0, len(db.state.servers))
+	for key := range db.state.servers {
+		if afterCursor(key, cursor) {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b serverKey) int {
+		return cmp.Or(strings.Compare(a.name, b.name), strings.Compare(a.version, b.version))
+	})
+
+	refs := []*ServerDocumentRef{}
+	for _, key := range keys {
+		if len(refs) >= limit {
+			break
+		}
+		refs = append(refs, &ServerDocumentRef{ServerName: key.name, Version: key.version, Digest: db.state.servers[key].documentDigest})
+	}
+
+	nextCursor := ""
+	if len(refs) > 0 && len(refs) >= limit {
+		last := refs[len(refs)-1]
+		nextCursor = last.ServerName + ":" + last.Version
+	}
+	return refs, nextCursor, nil
+}
+
+// ListServerDocumentDigests retrieves the document digest of each version of each given server, keyed by server name and then version
+func (db *Memory) ListServerDocumentDigests(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string]map[string]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	wanted := make(map[string]bool, len(serverNames))
+	for _, serverName := range serverNames {
+		wanted[serverName] = true
+	}
+
+	digests := map[string]map[string]string{}
+	for key, row := range db.state.servers {
+		if !wanted[key.name] || row.documentDigest == "" {
+			continue
+		}
+		if digests[key.name] == nil {
+			digests[key.name] = map[string]string{}
+		}
+		digests[key.name][key.version] = row.documentDigest
+	}
+	return digests, nil
+}
+
+// SetServerStatus updates the status of a specific server version
+func (db *Memory) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status model.Status, statusMessage *string) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key := serverKey{name: serverName, version: version}
+	row, exists := db.state.servers[key]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	row = withStatus(row, status, statusMessage)
+	if err := checkServerRow(key, row); err != nil {
+		return nil, fmt.Errorf("failed to update server status: %w", err)
+	}
+	db.state.putServer(key, row, "updated")
+
+	return row.response()
+}
+
+// SetAllVersionsStatus updates the status of all versions of a server whose status or message differs
+func (db *Memory) SetAllVersionsStatus(ctx context.Context, tx pgx.Tx, serverName string, status model.Status, statusMessage *string) ([]*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	includeDeleted := true
+	keys, err := db.state.filterServers(&ServerFilter{Name: &serverName, IncludeDeleted: &includeDeleted})
+	if err != nil {
+		return nil, err
+	}
+
+	// Check every row before changing any, as the single UPDATE statement would
+	updates := map[serverKey]memoryServer{}
+	for _, key := range keys {
+		row := db.state.servers[key]
+		if row.status == status && equalStringPtr(row.statusMessage, statusMessage) {
+			continue
+		}
+		row = withStatus(row, status, statusMessage)
+		if err := checkServerRow(key, row); err != nil {
+			return nil, fmt.Errorf("failed to update all server versions status: %w", err)
+		}
+		updates[key] = row
+	}
+
+	var results []*apiv0.ServerResponse
+	for _, key := range keys {
+		row, updated := updates[key]
+		if !updated {
+			continue
+		}
+		db.state.putServer(key, row, "updated")
+		response, err := row.response()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, response)
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return results, nil
+}
+
+// withStatus applies a status change, only moving status_changed_at when the status changes
+func withStatus(row memoryServer, status model.Status, statusMessage *string) memoryServer {
+	changedAt := now()
+	if row.status != status {
+		row.statusChangedAt = changedAt
+	}
+	row.status = status
+	row.statusMessage = statusMessage
+	row.updatedAt = changedAt
+	return row
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// InTransaction runs fn while holding the database lock, restoring the previous state if fn fails
+func (db *Memory) InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.unlock()
+
+	snapshot := db.state.clone()
+	committed := false
+	defer func() {
+		if !committed {
+			db.state = snapshot
+		}
+	}()
+
+	if err := fn(ctx, memoryTx{}); err != nil {
+		return err
+	}
+
+	committed = true
+	return nil
+}
+
+// AcquirePublishLock does nothing: transactions already run one at a time
+func (db *Memory) AcquirePublishLock(ctx context.Context, _ pgx.Tx, _ string) error {
+	return ctx.Err()
+}
+
+// memoryJobLock is a job lock held in the Memory that created it
+type memoryJobLock struct {
+	db       *Memory
+	name     string
+	released bool
+}
+
+// TryAcquireJobLock acquires the named background job lock without waiting
+func (db *Memory) TryAcquireJobLock(ctx context.Context, name string) (JobLock, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.jobLocksMu.Lock()
+	defer db.jobLocksMu.Unlock()
+
+	if db.jobLocks[name] {
+		return nil, ErrLockNotAcquired
+	}
+	db.jobLocks[name] = true
+	return &memoryJobLock{db: db, name: name}, nil
+}
+
+// Held reports whether the lock has not been released
+func (l *memoryJobLock) Held(_ context.Context) bool {
+	l.db.jobLocksMu.Lock()
+	defer l.db.jobLocksMu.Unlock()
+	return !l.released
+}
+
+// Release releases the lock so it can be acquired again
+func (l *memoryJobLock) Release(_ context.Context) error {
+	l.db.jobLocksMu.Lock()
+	defer l.db.jobLocksMu.Unlock()
+	if !l.released {
+		l.released = true
+		delete(l.db.jobLocks, l.name)
+	}
+	return nil
+}
+
+// CountServers counts the servers matching filter. Counting in memory is cheap, so the count is always exact.
+func (db *Memory) CountServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, _ int) (int, bool, error) {
+	if ctx.Err() != nil {
+		return 0, false, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	keys, err := db.state.asOf(filter).filterServers(filter)
+	if err != nil {
+		return 0, false, err
+	}
+	return len(keys), false, nil
+}
+
+// SuggestServers suggests latest servers and namespaces with a word starting with prefix
+func (db *Memory) SuggestServers(ctx context.Context, tx pgx.Tx, prefix string, limit int) ([]ServerSuggestion, []string, error) {
+	if ctx.Err() != nil {
+		return nil, nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	word := regexp.MustCompile("(?i)" + suggestWordPattern(prefix))
+	lowerPrefix := strings.ToLower(prefix)
+	hasPrefix := func(s string) bool { return strings.HasPrefix(strings.ToLower(s), lowerPrefix) }
+
+	var servers []ServerSuggestion
+	namespaceSet := map[string]bool{}
+	for key, row := range db.state.servers {
+		if !row.isLatest || hiddenStatus(row.status) || !word.MatchString(key.name) {
+			continue
+		}
+		var serverJSON apiv0.ServerJSON
+		if err := json.Unmarshal(row.value, &serverJSON); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+		servers = append(servers, ServerSuggestion{Name: key.name, Title: serverJSON.Title})
+		if namespace, _, _ := strings.Cut(key.name, "/"); word.MatchString(namespace) {
+			namespaceSet[namespace] = true
+		}
+	}
+
+	rank := func(name string) int {
+		_, shortName, _ := strings.Cut(name, "/")
+		switch {
+		case hasPrefix(name):
+			return 0
+		case hasPrefix(shortName):
+			return 1
+		}
+		return 2
+	}
+	slices.SortFunc(servers, func(a, b ServerSuggestion) int {
+		return cmp.Or(cmp.Compare(rank(a.Name), rank(b.Name)), cmp.Compare(len(a.Name), len(b.Name)), strings.Compare(a.Name, b.Name))
+	})
+	namespaces := slices.Collect(maps.Keys(namespaceSet))
+	slices.SortFunc(namespaces, func(a, b string) int {
+		return cmp.Or(cmp.Compare(rank(a), rank(b)), cmp.Compare(len(a), len(b)), strings.Compare(a, b))
+	})
+
+	return servers[:min(limit, len(servers))], namespaces[:min(limit, len(namespaces))], nil
+}
+
+// ListNamespaces summarizes up to limit namespaces with servers whose latest version is not deleted
+func (db *Memory) ListNamespaces(ctx context.Context, tx pgx.Tx, parent, cursor string, limit int) ([]*NamespaceSummary, string, error) {
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	summaries := map[string]*NamespaceSummary{}
+	for key, row := range db.state.servers {
+		namespace, _, _ := strings.Cut(key.name, "/")
+		if hiddenStatus(row.status) || namespace <= cursor ||
+			(parent != "" && namespace != parent && !strings.HasPrefix(namespace, parent+".")) {
+			continue
+		}
+		summary := summaries[namespace]
+		if summary == nil {
+			summary = &NamespaceSummary{Namespace: namespace}
+			summaries[namespace] = summary
+		}
+		summary.Versions++
+		if row.isLatest {
+			summary.Servers++
+			if row.publisher.IsVerified() {
+				summary.VerifiedServers++
+			}
+		}
+		for _, t := range []time.Time{row.publishedAt, row.updatedAt} {
+			if t.After(summary.UpdatedAt) {
+				summary.UpdatedAt = t
+			}
+		}
+	}
+
+	var namespaces []*NamespaceSummary
+	for _, summary := range summaries {
+		if summary.Servers > 0 {
+			namespaces = append(namespaces, summary)
+		}
+	}
+	slices.SortFunc(namespaces, func(a, b *NamespaceSummary) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+
+	if len(namespaces) > limit {
+		namespaces = namespaces[:limit]
+	}
+	nextCursor := ""
+	if len(namespaces) > 0 && len(namespaces) >= limit {
+		nextCursor = namespaces[len(namespaces)-1].Namespace
+	}
+	return namespaces, nextCursor, nil
+}
+
+// CountServerVersions counts the number of versions for a server
+func (db *Memory) CountServerVersions(ctx context.Context, tx pgx.Tx, serverName string) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	count := 0
+	for key := range db.state.servers {
+		if key.name == serverName {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CheckVersionExists checks if a specific version exists for a server
+func (db *Memory) CheckVersionExists(ctx context.Context, tx pgx.Tx, serverName, version string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	_, exists := db.state.servers[serverKey{name: serverName, version: version}]
+	return exists, nil
+}
+
+// ListExistingVersions retrieves the versions, including deleted ones, of each given server that has any, keyed by server name
+func (db *Memory) ListExistingVersions(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string][]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	wanted := make(map[string]bool, len(serverNames))
+	for _, serverName := range serverNames {
+		wanted[serverName] = true
+	}
+
+	versions := map[string][]string{}
+	for key := range db.state.servers {
+		if wanted[key.name] {
+			versions[key.name] = append(versions[key.name], key.version)
+		}
+	}
+	return versions, nil
+}
+
+// UnmarkAsLatest marks the current latest version of a server as no longer latest
+func (db *Memory) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	for key, row := range db.state.servers {
+		if key.name == serverName && row.isLatest {
+			row.isLatest = false
+			db.state.putServer(key, row, "updated")
+		}
+	}
+	return nil
+}
+
+// ListServerChanges retrieves changes recorded after the given sequence number, in sequence order
+func (db *Memory) ListServerChanges(ctx context.Context, tx pgx.Tx, since int64, limit int) ([]*apiv0.ServerChange, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+	defer db.lock(tx)()
+
+	start, _ := slices.BinarySearchFunc(db.state.changes, since+1, func(change memoryChange, seq int64) int {
+		return cmp.Compare(change.seq, seq)
+	})
+
+	var results []*apiv0.ServerChange
+	for _, change := range db.state.changes[start:] {
+		if len(results) >= limit {
+			break
+		}
+		// Changes of a deleted version show the state they recorded
+		row, exists := db.state.servers[change.key]
+		if !exists {
+			row = change.row
+		}
+		response, err := row.response()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &apiv0.ServerChange{
+			Seq:          change.seq,
+			Type:         change.changeType,
+			ChangedAt:    change.changedAt,
+			PreviousName: change.previousName,
+			Server:       *response,
+		})
+	}
+	return results, nil
+}
+
+// LatestChangeSeq retrieves the sequence number of the most recently recorded change, or 0 if there is none
+func (db *Memory) LatestChangeSeq(ctx context.Context, tx pgx.Tx) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	return db.state.lastSeq, nil
+}
+
+// DeleteServerChangesBefore permanently removes changes recorded before the given time, except the most
+// recent change, and returns how many were removed
+func (db *Memory) DeleteServerChangesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if len(db.state.changes) == 0 {
+		return 0, nil
+	}
+	last := len(db.state.changes) - 1
+	kept := db.state.changes[:0:0]
+	for i, change := range db.state.changes {
+		if i == last || !change.changedAt.Before(before) {
+			kept = append(kept, change)
+		}
+	}
+	deleted := int64(len(db.state.changes) - len(kept))
+	db.state.changes = kept
+	return deleted, nil
+}
+
+// CreatePartitions does nothing: the in-memory tables are not partitioned
+func (db *Memory) CreatePartitions(ctx context.Context, _ pgx.Tx, _ time.Time) (int, error) {
+	return 0, ctx.Err()
+}
+
+// ListenForEvents passes events to handle after the call or transaction causing them finishes, until ctx is done
+func (db *Memory) ListenForEvents(ctx context.Context, ready func(), handle func(Event)) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.listenersMu.Lock()
+	id := db.nextListener
+	db.nextListener++
+	db.listeners[id] = handle
+	db.listenersMu.Unlock()
+	defer func() {
+		db.listenersMu.Lock()
+		delete(db.listeners, id)
+		db.listenersMu.Unlock()
+	}()
+
+	ready()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// GetImportCheckpoint retrieves the last change sequence applied from a remote registry
+func (db *Memory) GetImportCheckpoint(ctx context.Context, tx pgx.Tx, source string) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	lastSeq, exists := db.state.checkpoints[source]
+	if !exists {
+		return 0, ErrNotFound
+	}
+	return lastSeq, nil
+}
+
+// SetImportCheckpoint records the last change sequence applied from a remote registry
+func (db *Memory) SetImportCheckpoint(ctx context.Context, tx pgx.Tx, source string, seq int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	db.state.checkpoints[source] = seq
+	return nil
+}
+
+// GetMaintenanceState retrieves the maintenance mode state
+func (db *Memory) GetMaintenanceState(ctx context.Context, tx pgx.Tx) (*MaintenanceState, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	state := db.state.maintenance
+	return &state, nil
+}
+
+// SetMaintenanceState stores the maintenance mode state
+func (db *Memory) SetMaintenanceState(ctx context.Context, tx pgx.Tx, enabled bool, message *string, retryAfterSeconds int) (*MaintenanceState, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	switch {
+	case retryAfterSeconds <= 0:
+		return nil, fmt.Errorf("failed to set maintenance state: %w: retry after must be positive", ErrInvalidInput)
+	case message != nil && len(*message) > 500:
+		return nil, fmt.Errorf("failed to set maintenance state: %w: message must not exceed 500 characters", ErrInvalidInput)
+	}
+
+	defer db.lock(tx)()
+
+	db.state.maintenance = MaintenanceState{
+		Enabled:           enabled,
+		Message:           message,
+		RetryAfterSeconds: retryAfterSeconds,
+		UpdatedAt:         now(),
+	}
+	db.state.pending = append(db.state.pending, Event{Type: EventMaintenance})
+	state := db.state.maintenance
+	return &state, nil
+}
+
+// ListPossibleDuplicates finds groups of servers that share a repository URL or remote endpoint under different names
+func (db *Memory) ListPossibleDuplicates(ctx context.Context, tx pgx.Tx, serverName *string) ([]*DuplicateGroup, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	type groupKey struct {
+		reason string
+		url    string
+	}
+	names := map[groupKey]map[string]bool{}
+	add := func(reason, url, name string) {
+		key := groupKey{reason: reason, url: url}
+		if names[key] == nil {
+			names[key] = map[string]bool{}
+		}
+		names[key][name] = true
+	}
+
+	for key, row := range db.state.servers {
+		if !row.isLatest || hiddenStatus(row.status) {
+			continue
+		}
+		var serverJSON apiv0.ServerJSON
+		if err := json.Unmarshal(row.value, &serverJSON); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+		if serverJSON.Repository != nil && serverJSON.Repository.URL != "" {
+			add("repository", db.state.canonicalRepositoryURL(serverJSON.Repository.URL), key.name)
+		}
+		for _, remote := range serverJSON.Remotes {
+			if remote.URL != "" {
+				add("remote", strings.ToLower(strings.TrimRight(remote.URL, "/")), key.name)
+			}
+		}
+	}
+
+	groups := []*DuplicateGroup{}
+	for key, serverNames := range names {
+		if len(serverNames) < 2 || (serverName != nil && !serverNames[*serverName]) {
+			continue
+		}
+		groups = append(groups, &DuplicateGroup{
+			Reason:      key.reason,
+			URL:         key.url,
+			ServerNames: slices.Sorted(maps.Keys(serverNames)),
+		})
+	}
+	slices.SortFunc(groups, func(a, b *DuplicateGroup) int {
+		return cmp.Or(strings.Compare(a.Reason, b.Reason), strings.Compare(a.URL, b.URL))
+	})
+	return groups, nil
+}
+
+// ListPrunableVersions finds non-latest versions exceeding the per-server version limit or older than publishedBefore
+func (db *Memory) ListPrunableVersions(ctx context.Context, tx pgx.Tx, maxVersionsPerServer int, publishedBefore *time.Time, limit int) ([]*PrunableVersion, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	byServer := map[string][]prunableCandidate{}
+	for key, row := range db.state.servers {
+		byServer[key.name] = append(byServer[key.name], prunableCandidate{version: key.version, publishedAt: row.publishedAt, isLatest: row.isLatest})
+	}
+
+	versions := []*PrunableVersion{}
+	for serverName, candidates := range byServer {
+		versions = append(versions, selectPrunableVersions(serverName, candidates, maxVersionsPerServer, publishedBefore)...)
+	}
+
+	slices.SortFunc(versions, func(a, b *PrunableVersion) int {
+		return cmp.Or(strings.Compare(a.ServerName, b.ServerName), a.PublishedAt.Compare(b.PublishedAt), strings.Compare(a.Version, b.Version))
+	})
+	if limit >= 0 && len(versions) > limit {
+		versions = versions[:limit]
+	}
+	return versions, nil
+}
+
+// DeleteServerVersion permanently removes a server version, recording its deletion in the changes feed with the
+// state it was deleted in
+func (db *Memory) DeleteServerVersion(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key := serverKey{name: serverName, version: version}
+	row, exists := db.state.servers[key]
+	if !exists {
+		return ErrNotFound
+	}
+	delete(db.state.servers, key)
+	db.state.lastSeq++
+	db.state.changes = append(db.state.changes, memoryChange{
+		seq:        db.state.lastSeq,
+		key:        key,
+		changeType: "deleted",
+		changedAt:  now(),
+		row:        row,
+	})
+	db.state.pending = append(db.state.pending, Event{Type: EventServerChange, Seq: db.state.lastSeq})
+	db.state.provenance = slices.DeleteFunc(db.state.provenance, func(row memoryProvenance) bool {
+		return row.key == key
+	})
+	db.state.artifacts = slices.DeleteFunc(db.state.artifacts, func(row memoryArtifact) bool {
+		return row.key == key
+	})
+	maps.DeleteFunc(db.state.artifactMirrors, func(_ int64, mirror ArtifactMirror) bool {
+		return mirror.ServerName == serverName && mirror.Version == version
+	})
+	maps.DeleteFunc(db.state.installReports, func(reportKey installReportKey, _ InstallReport) bool {
+		return reportKey.server == key
+	})
+	return nil
+}
+
+// cloneVerification copies a verification request so callers cannot modify stored data
+func cloneVerification(verification NamespaceVerification) *NamespaceVerification {
+	verification.Evidence = slices.Clone(verification.Evidence)
+	return &verification
+}
+
+// appendEvidence adds evidence to a request, keeping only the most recent entries
+func appendEvidence(existing []VerificationEvidence, evidence VerificationEvidence) []VerificationEvidence {
+	combined := append(slices.Clone(existing), evidence)
+	return combined[max(0, len(combined)-maxVerificationEvidence):]
+}
+
+// RecordNamespaceVerificationAttempt adds evidence to the pending verification request for a domain and method, creating it if needed
+func (db *Memory) RecordNamespaceVerificationAttempt(ctx context.Context, tx pgx.Tx, domain, method string, evidence VerificationEvidence) (*NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if method != "dns" && method != "http" {
+		return nil, fmt.Errorf("failed to record namespace verification attempt: %w: invalid method %q", ErrInvalidInput, method)
+	}
+	defer db.lock(tx)()
+
+	for id, verification := range db.state.verifications {
+		if verification.Domain == domain && verification.Method == method && verification.Status == NamespaceVerificationPending {
+			verification.Evidence = appendEvidence(verification.Evidence, evidence)
+			verification.UpdatedAt = now()
+			db.state.verifications[id] = verification
+			return cloneVerification(verification), nil
+		}
+	}
+
+	db.state.lastVerificationID++
+	createdAt := now()
+	verification := NamespaceVerification{
+		ID:        db.state.lastVerificationID,
+		Domain:    domain,
+		Method:    method,
+		Status:    NamespaceVerificationPending,
+		Evidence:  []VerificationEvidence{evidence},
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	db.state.verifications[verification.ID] = verification
+	return cloneVerification(verification), nil
+}
+
+// AddNamespaceVerificationEvidence appends evidence to an existing verification request
+func (db *Memory) AddNamespaceVerificationEvidence(ctx context.Context, tx pgx.Tx, id int64, evidence VerificationEvidence) (*NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	verification, exists := db.state.verifications[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	verification.Evidence = appendEvidence(verification.Evidence, evidence)
+	verification.UpdatedAt = now()
+	db.state.verifications[id] = verification
+	return cloneVerification(verification), nil
+}
+
+// GetNamespaceVerification retrieves a verification request by ID
+func (db *Memory) GetNamespaceVerification(ctx context.Context, tx pgx.Tx, id int64) (*NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	verification, exists := db.state.verifications[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return cloneVerification(verification), nil
+}
+
+// ListNamespaceVerifications retrieves verification requests, optionally filtered by status, oldest first
+func (db *Memory) ListNamespaceVerifications(ctx context.Context, tx pgx.Tx, status *string) ([]*NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	verifications := []*NamespaceVerification{}
+	for _, verification := range db.state.verifications {
+		if status == nil || verification.Status == *status {
+			verifications = append(verifications, cloneVerification(verification))
+		}
+	}
+	slices.SortFunc(verifications, func(a, b *NamespaceVerification) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return verifications, nil
+}
+
+// ReviewNamespaceVerification records an admin decision on a verification request
+func (db *Memory) ReviewNamespaceVerification(ctx context.Context, tx pgx.Tx, id int64, status string, notes *string, reviewer string) (*NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	switch {
+	case status != NamespaceVerificationPending && status != NamespaceVerificationApproved && status != NamespaceVerificationRejected:
+		return nil, fmt.Errorf("failed to review namespace verification: %w: invalid status %q", ErrInvalidInput, status)
+	case notes != nil && len(*notes) > 1000:
+		return nil, fmt.Errorf("failed to review namespace verification: %w: review notes must not exceed 1000 characters", ErrInvalidInput)
+	}
+
+	defer db.lock(tx)()
+
+	verification, exists := db.state.verifications[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	if status == NamespaceVerificationPending {
+		for otherID, other := range db.state.verifications {
+			if otherID != id && other.Domain == verification.Domain && other.Method == verification.Method && other.Status == NamespaceVerificationPending {
+				return nil, fmt.Errorf("failed to review namespace verification: %w: another request for %s is pending", ErrAlreadyExists, verification.Domain)
+			}
+		}
+	}
+
+	reviewedAt := now()
+	verification.Status = status
+	verification.ReviewNotes = notes
+	verification.ReviewedBy = &reviewer
+	verification.ReviewedAt = &reviewedAt
+	verification.UpdatedAt = reviewedAt
+	db.state.verifications[id] = verification
+	return cloneVerification(verification), nil
+}
+
+// IsNamespaceApproved reports whether the most recent review decision for a domain approved it
+func (db *Memory) IsNamespaceApproved(ctx context.Context, tx pgx.Tx, domain string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	var latest *NamespaceVerification
+	for _, verification := range db.state.verifications {
+		if verification.Domain != domain || verification.Status == NamespaceVerificationPending || verification.ReviewedAt == nil {
+			continue
+		}
+		// Decisions made at the same time are ordered by ID, as in ORDER BY reviewed_at DESC, id DESC
+		if latest == nil || cmp.Or(verification.ReviewedAt.Compare(*latest.ReviewedAt), cmp.Compare(verification.ID, latest.ID)) > 0 {
+			latest = &verification
+		}
+	}
+	return latest != nil && latest.Status == NamespaceVerificationApproved, nil
+}
+
+// response decodes a stored audit entry into a fresh copy
+func (row memoryAuditEntry) response() (*AuditEntry, error) {
+	entry := row.entry
+	if err := json.Unmarshal(row.details, &entry.Details); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit details: %w", err)
+	}
+	if len(entry.Details) == 0 {
+		entry.Details = nil
+	}
+	return &entry, nil
+}
+
+// matchesAuditFilter reports whether an audit entry satisfies every set field of a filter
+func matchesAuditFilter(entry AuditEntry, filter *AuditFilter) bool {
+	switch {
+	case filter == nil:
+		return true
+	case filter.Actor != nil && entry.Actor != *filter.Actor,
+		filter.Namespace != nil && entry.Namespace != *filter.Namespace,
+		filter.Action != nil && entry.Action != *filter.Action,
+		filter.Since != nil && entry.CreatedAt.Before(*filter.Since),
+		filter.Until != nil && !entry.CreatedAt.Before(*filter.Until):
+		return false
+	}
+	return true
+}
+
+// RecordAuditEntry appends an entry to the audit log, assigning its ID and time
+func (db *Memory) RecordAuditEntry(ctx context.Context, tx pgx.Tx, entry AuditEntry) (*AuditEntry, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if entry.Action == "" {
+		return nil, fmt.Errorf("failed to record audit entry: %w: action must not be empty", ErrInvalidInput)
+	}
+
+	details := entry.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit details: %w", err)
+	}
+
+	defer db.lock(tx)()
+
+	db.state.lastAuditID++
+	entry.ID = db.state.lastAuditID
+	entry.CreatedAt = now()
+	entry.Details = nil
+	row := memoryAuditEntry{entry: entry, details: detailsJSON}
+	db.state.auditLog = append(db.state.auditLog, row)
+	return row.response()
+}
+
+// ListAuditEntries retrieves up to limit audit entries matching the filter with an ID greater than afterID, oldest first
+func (db *Memory) ListAuditEntries(ctx context.Context, tx pgx.Tx, filter *AuditFilter, afterID int64, limit int) ([]*AuditEntry, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	// Entries are appended in ID order
+	entries := []*AuditEntry{}
+	for _, row := range db.state.auditLog {
+		if len(entries) >= limit {
+			break
+		}
+		if row.entry.ID <= afterID || !matchesAuditFilter(row.entry, filter) {
+			continue
+		}
+		entry, err := row.response()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// DeleteAuditEntriesBefore permanently removes audit entries recorded before the given time and returns how many were removed
+func (db *Memory) DeleteAuditEntriesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	kept := db.state.auditLog[:0:0]
+	for _, row := range db.state.auditLog {
+		if !row.entry.CreatedAt.Before(before) {
+			kept = append(kept, row)
+		}
+	}
+	deleted := int64(len(db.state.auditLog) - len(kept))
+	db.state.auditLog = kept
+	return deleted, nil
+}
+
+// RecordPublishAttempt stores a publish attempt, assigning its ID and time
+func (db *Memory) RecordPublishAttempt(ctx context.Context, tx pgx.Tx, attempt PublishAttempt) (*PublishAttempt, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if attempt.Identity == "" {
+		return nil, fmt.Errorf("failed to record publish attempt: %w: empty identity violates check constraint \"check_publish_attempt_identity_not_empty\"", ErrInvalidInput)
+	}
+	defer db.lock(tx)()
+
+	db.state.lastPublishAttempt++
+	attempt.ID = db.state.lastPublishAttempt
+	attempt.AttemptedAt = now()
+	attempt.ValidationErrors = slices.Clone(nonNilStrings(attempt.ValidationErrors))
+	attempt.ValidationWarnings = slices.Clone(nonNilStrings(attempt.ValidationWarnings))
+	db.state.publishAttempts = append(db.state.publishAttempts, attempt)
+	return publishAttemptResponse(attempt), nil
+}
+
+// ListPublishAttempts retrieves up to limit publish attempts made by identity, newest first, with an ID
+// less than beforeID, or from the newest if beforeID is 0
+func (db *Memory) ListPublishAttempts(ctx context.Context, tx pgx.Tx, identity string, beforeID int64, limit int) ([]*PublishAttempt, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	// Attempts are appended in ID order
+	attempts := []*PublishAttempt{}
+	for i := len(db.state.publishAttempts) - 1; i >= 0 && len(attempts) < limit; i-- {
+		attempt := db.state.publishAttempts[i]
+		if attempt.Identity == identity && (beforeID == 0 || attempt.ID < beforeID) {
+			attempts = append(attempts, publishAttemptResponse(attempt))
+		}
+	}
+	return attempts, nil
+}
+
+// GetPublishAttempt retrieves a publish attempt by ID
+func (db *Memory) GetPublishAttempt(ctx context.Context, tx pgx.Tx, id int64) (*PublishAttempt, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	index, found := slices.BinarySearchFunc(db.state.publishAttempts, id, func(attempt PublishAttempt, id int64) int {
+		return cmp.Compare(attempt.ID, id)
+	})
+	if !found {
+		return nil, ErrNotFound
+	}
+	return publishAttemptResponse(db.state.publishAttempts[index]), nil
+}
+
+// DeletePublishAttemptsBefore permanently removes publish attempts made before the given time, along with
+// their quarantined server.json, and returns how many were removed
+func (db *Memory) DeletePublishAttemptsBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	kept := db.state.publishAttempts[:0:0]
+	for _, attempt := range db.state.publishAttempts {
+		if !attempt.AttemptedAt.Before(before) {
+			kept = append(kept, attempt)
+		} else {
+			delete(db.state.quarantine, attempt.ID)
+		}
+	}
+	deleted := int64(len(db.state.publishAttempts) - len(kept))
+	db.state.publishAttempts = kept
+	return deleted, nil
+}
+
+// publishAttemptResponse copies a stored publish attempt, so callers cannot modify the stored slices
+func publishAttemptResponse(attempt PublishAttempt) *PublishAttempt {
+	attempt.ValidationErrors = slices.Clone(attempt.ValidationErrors)
+	attempt.ValidationWarnings = slices.Clone(attempt.ValidationWarnings)
+	return &attempt
+}
+
+// memoryQuarantinedPublish stores the server.json of a quarantined publish marshaled, like the server_json column
+// of the quarantined_publishes table
+type memoryQuarantinedPublish struct {
+	quarantined QuarantinedPublish
+	value       []byte
+}
+
+// QuarantinePublish keeps the server.json and validation output of a rejected publish attempt, replacing any
+// already kept for the attempt
+func (db *Memory) QuarantinePublish(ctx context.Context, tx pgx.Tx, quarantined QuarantinedPublish) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	value, err := json.Marshal(quarantined.Server)
+	if err != nil {
+		return fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+	defer db.lock(tx)()
+
+	if !slices.ContainsFunc(db.state.publishAttempts, func(attempt PublishAttempt) bool { return attempt.ID == quarantined.AttemptID }) {
+		return ErrNotFound
+	}
+	quarantined.QuarantinedAt = now()
+	quarantined.Server = apiv0.ServerJSON{}
+	quarantined.ValidationIssues = slices.Clone(nonNilIssues(quarantined.ValidationIssues))
+	db.state.quarantine[quarantined.AttemptID] = memoryQuarantinedPublish{quarantined: quarantined, value: value}
+	return nil
+}
+
+// GetQuarantinedPublish retrieves the quarantined server.json of a publish attempt
+func (db *Memory) GetQuarantinedPublish(ctx context.Context, tx pgx.Tx, attemptID int64) (*QuarantinedPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	stored, exists := db.state.quarantine[attemptID]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	quarantined := stored.quarantined
+	quarantined.ValidationIssues = slices.Clone(stored.quarantined.ValidationIssues)
+	if err := json.Unmarshal(stored.value, &quarantined.Server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal quarantined server: %w", err)
+	}
+	return &quarantined, nil
+}
+
+// DeleteQuarantinedPublishesBefore permanently removes server.json documents quarantined before the given time
+// and returns how many were removed
+func (db *Memory) DeleteQuarantinedPublishesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	var deleted int64
+	for id, stored := range db.state.quarantine {
+		if stored.quarantined.QuarantinedAt.Before(before) {
+			delete(db.state.quarantine, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// UpdateAuditEntryClientIP replaces the stored client address of an audit entry, for re-encrypting it under a new key
+func (db *Memory) UpdateAuditEntryClientIP(ctx context.Context, tx pgx.Tx, id int64, clientIP string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	for i := range db.state.auditLog {
+		if db.state.auditLog[i].entry.ID == id {
+			db.state.auditLog[i].entry.ClientIP = clientIP
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// Close does nothing; the data is released with the Memory
+func (db *Memory) Close() error {
+	return nil
+}
+
+// RecordPackageProvenance stores the upstream provenance of a server version's packages. Packages that
+// already have a record are skipped, so recorded provenance is never overwritten.
+func (db *Memory) RecordPackageProvenance(ctx context.Context, tx pgx.Tx, serverName, version string, provenance []apiv0.PackageProvenance) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key := serverKey{name: serverName, version: version}
+	if _, exists := db.state.servers[key]; !exists {
+		return ErrNotFound
+	}
+
+	recordedAt := now()
+	for _, record := range provenance {
+		recorded := slices.ContainsFunc(db.state.provenance, func(row memoryProvenance) bool {
+			return row.key == key && row.record.RegistryType == record.RegistryType &&
+				row.record.Identifier == record.Identifier && row.record.Version == record.Version
+		})
+		if recorded {
+			continue
+		}
+		record.Files = slices.Clone(record.Files)
+		record.RecordedAt = recordedAt
+		if record.UpstreamPublishedAt != nil {
+			published := record.UpstreamPublishedAt.Round(time.Microsecond)
+			record.UpstreamPublishedAt = &published
+		}
+		db.state.provenance = append(db.state.provenance, memoryProvenance{key: key, record: record})
+	}
+	return nil
+}
+
+// GetPackageProvenance retrieves the recorded provenance of a server version's packages, in the order it was recorded
+func (db *Memory) GetPackageProvenance(ctx context.Context, tx pgx.Tx, serverName, version string) ([]apiv0.PackageProvenance, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key := serverKey{name: serverName, version: version}
+	provenance := []apiv0.PackageProvenance{}
+	for _, row := range db.state.provenance {
+		if row.key != key {
+			continue
+		}
+		record := row.record
+		record.Files = slices.Clone(record.Files)
+		if record.UpstreamPublishedAt != nil {
+			published := *record.UpstreamPublishedAt
+			record.UpstreamPublishedAt = &published
+		}
+		provenance = append(provenance, record)
+	}
+	return provenance, nil
+}
+
+// RecordPackageArtifacts stores the artifacts mirrored for a server version's packages. Artifacts that are
+// already recorded for the version, by package and file name, are skipped.
+func (db *Memory) RecordPackageArtifacts(ctx context.Context, tx pgx.Tx, serverName, version string, artifacts []apiv0.PackageArtifact) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key := serverKey{name: serverName, version: version}
+	if _, exists := db.state.servers[key]; !exists {
+		return ErrNotFound
+	}
+
+	mirroredAt := now()
+	for _, artifact := range artifacts {
+		recorded := slices.ContainsFunc(db.state.artifacts, func(row memoryArtifact) bool {
+			return row.key == key && row.artifact.RegistryType == artifact.RegistryType &&
+				row.artifact.Identifier == artifact.Identifier && row.artifact.Filename == artifact.Filename
+		})
+		if recorded {
+			continue
+		}
+		artifact.DownloadURL = ""
+		if artifact.MirroredAt.IsZero() {
+			artifact.MirroredAt = mirroredAt
+		} else {
+			artifact.MirroredAt = artifact.MirroredAt.Round(time.Microsecond)
+		}
+		db.state.artifacts = append(db.state.artifacts, memoryArtifact{key: key, artifact: artifact})
+	}
+	return nil
+}
+
+// GetPackageArtifacts retrieves the artifacts mirrored for a server version's packages, in the order they were recorded
+func (db *Memory) GetPackageArtifacts(ctx context.Context, tx pgx.Tx, serverName, version string) ([]apiv0.PackageArtifact, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key := serverKey{name: serverName, version: version}
+	artifacts := []apiv0.PackageArtifact{}
+	for _, row := range db.state.artifacts {
+		if row.key == key {
+			artifacts = append(artifacts, row.artifact)
+		}
+	}
+	return artifacts, nil
+}
+
+// HasPackageArtifact reports whether any server version has recorded an artifact with the given SHA-256
+func (db *Memory) HasPackageArtifact(ctx context.Context, tx pgx.Tx, sha256 string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	return slices.ContainsFunc(db.state.artifacts, func(row memoryArtifact) bool {
+		return row.artifact.SHA256 == sha256
+	}), nil
+}
+
+// QueueArtifactMirror queues the package artifacts of a server version to be mirrored
+func (db *Memory) QueueArtifactMirror(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.servers[serverKey{name: serverName, version: version}]; !exists {
+		return ErrNotFound
+	}
+	for _, mirror := range db.state.artifactMirrors {
+		if mirror.ServerName == serverName && mirror.Version == version {
+			return nil
+		}
+	}
+
+	db.state.lastArtifactMirror++
+	mirror := ArtifactMirror{
+		ID:         db.state.lastArtifactMirror,
+		ServerName: serverName,
+		Version:    version,
+		CreatedAt:  now(),
+	}
+	mirror.NextAttemptAt = mirror.CreatedAt
+	db.state.artifactMirrors[mirror.ID] = mirror
+	return nil
+}
+
+// NextArtifactMirror retrieves the oldest queued mirror that is due for an attempt
+func (db *Memory) NextArtifactMirror(ctx context.Context, tx pgx.Tx) (*ArtifactMirror, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	current := now()
+	var next *ArtifactMirror
+	for _, mirror := range db.state.artifactMirrors {
+		if mirror.NextAttemptAt.After(current) {
+			continue
+		}
+		if next == nil || mirror.ID < next.ID {
+			next = &mirror
+		}
+	}
+	if next == nil {
+		return nil, ErrNotFound
+	}
+	return next, nil
+}
+
+// RetryArtifactMirror records a failed attempt of a queued mirror and leaves it queued until retryAt
+func (db *Memory) RetryArtifactMirror(ctx context.Context, tx pgx.Tx, id int64, reason string, retryAt time.Time) (*ArtifactMirror, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	mirror, exists := db.state.artifactMirrors[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	mirror.Error = reason
+	mirror.Attempts++
+	mirror.NextAttemptAt = retryAt.Round(time.Microsecond)
+	db.state.artifactMirrors[id] = mirror
+	return &mirror, nil
+}
+
+// DeleteArtifactMirror removes a mirror from the queue
+func (db *Memory) DeleteArtifactMirror(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.artifactMirrors[id]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.artifactMirrors, id)
+	return nil
+}
+
+// cloneUpstreamCacheEntry copies a cache entry so callers cannot modify stored data
+func cloneUpstreamCacheEntry(entry UpstreamCacheEntry) *UpstreamCacheEntry {
+	entry.Header = maps.Clone(entry.Header)
+	for name, values := range entry.Header {
+		entry.Header[name] = slices.Clone(values)
+	}
+	entry.Body = slices.Clone(entry.Body)
+	return &entry
+}
+
+// GetUpstreamCacheEntry retrieves an unexpired cached upstream response, returning ErrNotFound if there is none
+func (db *Memory) GetUpstreamCacheEntry(ctx context.Context, tx pgx.Tx, key string) (*UpstreamCacheEntry, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	db.upstreamCacheMu.Lock()
+	defer db.upstreamCacheMu.Unlock()
+
+	entry, exists := db.upstreamCache[key]
+	if !exists || !entry.ExpiresAt.After(now()) {
+		return nil, ErrNotFound
+	}
+	return cloneUpstreamCacheEntry(entry), nil
+}
+
+// PutUpstreamCacheEntry stores a cached upstream response, replacing any entry with the same key
+func (db *Memory) PutUpstreamCacheEntry(ctx context.Context, tx pgx.Tx, entry UpstreamCacheEntry) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	db.upstreamCacheMu.Lock()
+	defer db.upstreamCacheMu.Unlock()
+
+	entry.ExpiresAt = entry.ExpiresAt.Round(time.Microsecond)
+	db.upstreamCache[entry.Key] = *cloneUpstreamCacheEntry(entry)
+	return nil
+}
+
+// DeleteExpiredUpstreamCacheEntries removes cached upstream responses that expired before the given time and returns how many were removed
+func (db *Memory) DeleteExpiredUpstreamCacheEntries(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	db.upstreamCacheMu.Lock()
+	defer db.upstreamCacheMu.Unlock()
+
+	var deleted int64
+	maps.DeleteFunc(db.upstreamCache, func(_ string, entry UpstreamCacheEntry) bool {
+		if entry.ExpiresAt.Before(before) {
+			deleted++
+			return true
+		}
+		return false
+	})
+	return deleted, nil
+}
+
+// cloneRemoteHealth copies a probe result so callers cannot modify stored data
+func cloneRemoteHealth(health apiv0.RemoteHealth) apiv0.RemoteHealth {
+	health.CheckedAt = health.CheckedAt.Round(time.Microsecond)
+	if health.LastHealthyAt != nil {
+		lastHealthyAt := health.LastHealthyAt.Round(time.Microsecond)
+		health.LastHealthyAt = &lastHealthyAt
+	}
+	if health.FailingSince != nil {
+		failingSince := health.FailingSince.Round(time.Microsecond)
+		health.FailingSince = &failingSince
+	}
+	return health
+}
+
+// PutRemoteHealth stores the latest probe result of a remote endpoint, replacing any earlier result for its URL
+func (db *Memory) PutRemoteHealth(ctx context.Context, tx pgx.Tx, health apiv0.RemoteHealth) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if health.Status != apiv0.RemoteHealthy && health.Status != apiv0.RemoteAuthRequired && health.Status != apiv0.RemoteUnhealthy {
+		return fmt.Errorf("failed to store remote health: %w: status %q violates check constraint \"check_remote_health_status\"", ErrInvalidInput, health.Status)
+	}
+	defer db.lock(tx)()
+
+	db.state.remoteHealth[health.URL] = cloneRemoteHealth(health)
+	return nil
+}
+
+// GetRemoteHealth retrieves the latest probe results of the given remote URLs, keyed by URL. URLs that were never probed are omitted.
+func (db *Memory) GetRemoteHealth(ctx context.Context, tx pgx.Tx, urls []string) (map[string]apiv0.RemoteHealth, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	results := make(map[string]apiv0.RemoteHealth, len(urls))
+	for _, url := range urls {
+		if health, exists := db.state.remoteHealth[url]; exists {
+			results[url] = cloneRemoteHealth(health)
+		}
+	}
+	return results, nil
+}
+
+// DeleteRemoteHealthCheckedBefore removes probe results last checked before the given time and returns how many were removed
+func (db *Memory) DeleteRemoteHealthCheckedBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	var deleted int64
+	maps.DeleteFunc(db.state.remoteHealth, func(_ string, health apiv0.RemoteHealth) bool {
+		if health.CheckedAt.Before(before) {
+			deleted++
+			return true
+		}
+		return false
+	})
+	return deleted, nil
+}
+
+// PutScreeningException exempts a server from screening, replacing any existing exception for it
+func (db *Memory) PutScreeningException(ctx context.Context, tx pgx.Tx, exception ScreeningException) (*ScreeningException, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if exception.Reason == "" || len(exception.Reason) > 1000 {
+		return nil, fmt.Errorf("failed to store screening exception: %w: reason violates check constraint \"check_screening_exception_reason_length\"", ErrInvalidInput)
+	}
+	defer db.lock(tx)()
+
+	exception.CreatedAt = now()
+	db.state.screening[exception.ServerName] = exception
+	return &exception, nil
+}
+
+// GetScreeningException retrieves the screening exception of a server
+func (db *Memory) GetScreeningException(ctx context.Context, tx pgx.Tx, serverName string) (*ScreeningException, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	exception, exists := db.state.screening[serverName]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &exception, nil
+}
+
+// ListScreeningExceptions retrieves all screening exceptions, ordered by server name
+func (db *Memory) ListScreeningExceptions(ctx context.Context, tx pgx.Tx) ([]*ScreeningException, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	exceptions := make([]*ScreeningException, 0, len(db.state.screening))
+	for _, serverName := range slices.Sorted(maps.Keys(db.state.screening)) {
+		exception := db.state.screening[serverName]
+		exceptions = append(exceptions, &exception)
+	}
+	return exceptions, nil
+}
+
+// DeleteScreeningException removes the screening exception of a server
+func (db *Memory) DeleteScreeningException(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.screening[serverName]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.screening, serverName)
+	return nil
+}
+
+// banKey identifies an identity ban
+type banKey struct {
+	kind  string
+	value string
+}
+
+// quarantineKey identifies the record of a server version quarantined by a ban
+type quarantineKey struct {
+	server serverKey
+	ban    banKey
+}
+
+// PutIdentityBan bans an identity, replacing any existing ban of it
+func (db *Memory) PutIdentityBan(ctx context.Context, tx pgx.Tx, ban IdentityBan) (*IdentityBan, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if ban.Kind != BanKindGitHub && ban.Kind != BanKindOIDC && ban.Kind != BanKindNamespace {
+		return nil, fmt.Errorf("failed to store identity ban: %w: kind violates check constraint \"check_identity_ban_kind\"", ErrInvalidInput)
+	}
+	if ban.Reason == "" || len(ban.Reason) > 1000 {
+		return nil, fmt.Errorf("failed to store identity ban: %w: reason violates check constraint \"check_identity_ban_reason_length\"", ErrInvalidInput)
+	}
+	defer db.lock(tx)()
+
+	ban.CreatedAt = now()
+	db.state.bans[banKey{kind: ban.Kind, value: ban.Value}] = ban
+	return &ban, nil
+}
+
+// ListIdentityBans retrieves all identity bans, including expired ones, ordered by kind and value
+func (db *Memory) ListIdentityBans(ctx context.Context, tx pgx.Tx) ([]*IdentityBan, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	bans := make([]*IdentityBan, 0, len(db.state.bans))
+	for _, ban := range db.state.bans {
+		bans = append(bans, &ban)
+	}
+	slices.SortFunc(bans, func(a, b *IdentityBan) int {
+		return cmp.Or(strings.Compare(a.Kind, b.Kind), strings.Compare(a.Value, b.Value))
+	})
+	return bans, nil
+}
+
+// FindIdentityBans retrieves the bans, including expired ones, selected by query, ordered by kind and value
+func (db *Memory) FindIdentityBans(ctx context.Context, tx pgx.Tx, query IdentityBanQuery) ([]*IdentityBan, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	bans := []*IdentityBan{}
+	for _, ban := range db.state.bans {
+		lower := strings.ToLower(ban.Value)
+		var namespace string
+		var selected bool
+		switch ban.Kind {
+		case BanKindGitHub:
+			namespace, selected = "io.github."+lower, slices.Contains(query.GitHub, lower)
+		case BanKindOIDC:
+			selected = slices.Contains(query.OIDC, ban.Value)
+		case BanKindNamespace:
+			namespace, selected = lower, slices.Contains(query.Namespaces, lower)
+		}
+		if namespace != "" && slices.ContainsFunc(query.Prefixes, func(prefix string) bool {
+			return strings.HasPrefix(namespace+"/", prefix)
+		}) {
+			selected = true
+		}
+		if selected {
+			bans = append(bans, &ban)
+		}
+	}
+	slices.SortFunc(bans, func(a, b *IdentityBan) int {
+		return cmp.Or(strings.Compare(a.Kind, b.Kind), strings.Compare(a.Value, b.Value))
+	})
+	return bans, nil
+}
+
+// DeleteIdentityBan lifts the ban of an identity
+func (db *Memory) DeleteIdentityBan(ctx context.Context, tx pgx.Tx, kind, value string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key := banKey{kind: kind, value: value}
+	if _, exists := db.state.bans[key]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.bans, key)
+	return nil
+}
+
+// QuarantineServer records the prior status of every active or deprecated version of a server, and of versions
+// other bans already quarantined, against a ban and sets the active and deprecated ones to quarantined with
+// statusMessage. It returns the versions whose status changed.
+func (db *Memory) QuarantineServer(ctx context.Context, tx pgx.Tx, serverName, banKind, banValue string, statusMessage *string) ([]*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	includeDeleted := true
+	keys, err := db.state.filterServers(&ServerFilter{Name: &serverName, IncludeDeleted: &includeDeleted})
+	if err != nil {
+		return nil, err
+	}
+
+	ban := banKey{kind: banKind, value: banValue}
+	var results []*apiv0.ServerResponse
+	for _, key := range keys {
+		row := db.state.servers[key]
+		record := QuarantinedVersion{
+			ServerName:         key.name,
+			Version:            key.version,
+			BanKind:            banKind,
+			BanValue:           banValue,
+			PriorStatus:        row.status,
+			PriorStatusMessage: row.statusMessage,
+			QuarantinedAt:      now(),
+		}
+		switch row.status {
+		case model.StatusActive, model.StatusDeprecated:
+		case model.StatusQuarantined:
+			// Versions other bans already quarantined keep the status recorded by the first of them
+			first, found := db.state.firstQuarantineRecord(key)
+			if !found {
+				continue
+			}
+			record.PriorStatus, record.PriorStatusMessage = first.PriorStatus, first.PriorStatusMessage
+		default:
+			continue
+		}
+		recordKey := quarantineKey{server: key, ban: ban}
+		if _, exists := db.state.quarantinedVersions[recordKey]; !exists {
+			db.state.quarantinedVersions[recordKey] = record
+		}
+
+		if row.status == model.StatusQuarantined {
+			continue
+		}
+		row = withStatus(row, model.StatusQuarantined, statusMessage)
+		db.state.putServer(key, row, "updated")
+		response, err := row.response()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, response)
+	}
+	return results, nil
+}
+
+// firstQuarantineRecord returns the earliest quarantine record of a server version, if any ban holds it
+func (s *memoryState) firstQuarantineRecord(key serverKey) (QuarantinedVersion, bool) {
+	var first QuarantinedVersion
+	found := false
+	for recordKey, record := range s.quarantinedVersions {
+		if recordKey.server == key && (!found || record.QuarantinedAt.Before(first.QuarantinedAt)) {
+			first, found = record, true
+		}
+	}
+	return first, found
+}
+
+// ListQuarantinedVersions retrieves the quarantine records of a ban, ordered by server name and version
+func (db *Memory) ListQuarantinedVersions(ctx context.Context, tx pgx.Tx, banKind, banValue string) ([]*QuarantinedVersion, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	records := []*QuarantinedVersion{}
+	for key, record := range db.state.quarantinedVersions {
+		if key.ban == (banKey{kind: banKind, value: banValue}) {
+			records = append(records, &record)
+		}
+	}
+	slices.SortFunc(records, func(a, b *QuarantinedVersion) int {
+		return cmp.Or(strings.Compare(a.ServerName, b.ServerName), strings.Compare(a.Version, b.Version))
+	})
+	return records, nil
+}
+
+// ReleaseQuarantine deletes the quarantine records of a ban and restores the prior status and message of the
+// versions no other ban holds that are still quarantined. It returns the restored versions.
+func (db *Memory) ReleaseQuarantine(ctx context.Context, tx pgx.Tx, banKind, banValue string) ([]*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	ban := banKey{kind: banKind, value: banValue}
+	var released []QuarantinedVersion
+	for key, record := range db.state.quarantinedVersions {
+		if key.ban == ban {
+			released = append(released, record)
+			delete(db.state.quarantinedVersions, key)
+		}
+	}
+	slices.SortFunc(released, func(a, b QuarantinedVersion) int {
+		return cmp.Or(strings.Compare(a.ServerName, b.ServerName), strings.Compare(a.Version, b.Version))
+	})
+
+	var results []*apiv0.ServerResponse
+	for _, record := range released {
+		key := serverKey{name: record.ServerName, version: record.Version}
+		row, exists := db.state.servers[key]
+		if _, held := db.state.firstQuarantineRecord(key); !exists || held || row.status != model.StatusQuarantined {
+			continue
+		}
+		row = withStatus(row, record.PriorStatus, record.PriorStatusMessage)
+		db.state.putServer(key, row, "updated")
+		response, err := row.response()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, response)
+	}
+	return results, nil
+}
+
+// PutServerCuration sets the curation of a server, replacing any existing curation for it
+func (db *Memory) PutServerCuration(ctx context.Context, tx pgx.Tx, curation ServerCuration) (*ServerCuration, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := checkServerCuration(curation); err != nil {
+		return nil, fmt.Errorf("failed to store server curation: %w", err)
+	}
+	defer db.lock(tx)()
+
+	curation.UpdatedAt = now()
+	stored := cloneServerCuration(curation)
+	db.state.curation[curation.ServerName] = *stored
+	return cloneServerCuration(*stored), nil
+}
+
+// GetServerCurations retrieves the curation of each given server that has one, keyed by server name
+func (db *Memory) GetServerCurations(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string]ServerCuration, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	curations := map[string]ServerCuration{}
+	for _, serverName := range serverNames {
+		if curation, exists := db.state.curation[serverName]; exists {
+			curations[serverName] = *cloneServerCuration(curation)
+		}
+	}
+	return curations, nil
+}
+
+// ListServerCurations retrieves curated servers with label, or all curated servers if label is empty
+func (db *Memory) ListServerCurations(ctx context.Context, tx pgx.Tx, label string) ([]*ServerCuration, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	curations := []*ServerCuration{}
+	for _, curation := range db.state.curation {
+		if label == "" || slices.Contains(curation.Labels, label) {
+			curations = append(curations, cloneServerCuration(curation))
+		}
+	}
+	slices.SortFunc(curations, compareCurationPosition)
+	return curations, nil
+}
+
+// DeleteServerCuration removes the curation of a server
+func (db *Memory) DeleteServerCuration(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.curation[serverName]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.curation, serverName)
+	return nil
+}
+
+// PutRepositoryURL records the canonical form of a repository URL servers link, replacing any earlier one
+func (db *Memory) PutRepositoryURL(ctx context.Context, tx pgx.Tx, rawURL, canonicalURL string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	db.state.repositoryURLs[rawURL] = canonicalURL
+	return nil
+}
+
+// RecordTokenUse records that an identity token was exchanged until it expires, forgetting expired tokens
+func (db *Memory) RecordTokenUse(ctx context.Context, tx pgx.Tx, key string, expiresAt time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	current := now()
+	for usedKey, usedUntil := range db.state.tokenUses {
+		if !usedUntil.After(current) {
+			delete(db.state.tokenUses, usedKey)
+		}
+	}
+	if _, used := db.state.tokenUses[key]; used {
+		return ErrAlreadyExists
+	}
+	db.state.tokenUses[key] = expiresAt
+	return nil
+}
+
+// PutServerStaleness records a stale server, replacing any earlier record for it
+func (db *Memory) PutServerStaleness(ctx context.Context, tx pgx.Tx, staleness ServerStaleness) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := checkServerStaleness(staleness); err != nil {
+		return fmt.Errorf("failed to store server staleness: %w", err)
+	}
+	defer db.lock(tx)()
+
+	if staleness.DetectedAt.IsZero() {
+		staleness.DetectedAt = now()
+	}
+	db.state.staleness[staleness.ServerName] = cloneServerStaleness(staleness)
+	return nil
+}
+
+// GetServerStaleness retrieves the staleness of each given server that is stale, keyed by server name
+func (db *Memory) GetServerStaleness(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string]ServerStaleness, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	stale := map[string]ServerStaleness{}
+	for _, serverName := range serverNames {
+		if staleness, exists := db.state.staleness[serverName]; exists {
+			stale[serverName] = cloneServerStaleness(staleness)
+		}
+	}
+	return stale, nil
+}
+
+// ListServerStaleness retrieves every stale server, ordered by when it was detected, then by server name
+func (db *Memory) ListServerStaleness(ctx context.Context, tx pgx.Tx) ([]*ServerStaleness, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	stale := []*ServerStaleness{}
+	for _, staleness := range db.state.staleness {
+		clone := cloneServerStaleness(staleness)
+		stale = append(stale, &clone)
+	}
+	slices.SortFunc(stale, func(a, b *ServerStaleness) int {
+		if c := a.DetectedAt.Compare(b.DetectedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ServerName, b.ServerName)
+	})
+	return stale, nil
+}
+
+// DeleteServerStaleness removes the staleness record of a server
+func (db *Memory) DeleteServerStaleness(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.staleness[serverName]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.staleness, serverName)
+	return nil
+}
+
+// checkServerStaleness enforces the check constraints of the server_staleness table
+func checkServerStaleness(staleness ServerStaleness) error {
+	validReasons := []string{StaleReasonRepositoryArchived, StaleReasonRepositoryDeleted, StaleReasonPackageUnpublished}
+	if len(staleness.Reasons) == 0 || slices.ContainsFunc(staleness.Reasons, func(reason string) bool { return !slices.Contains(validReasons, reason) }) {
+		return fmt.Errorf("%w: reasons %v violate check constraint \"check_server_staleness_reasons\"", ErrInvalidInput, staleness.Reasons)
+	}
+	return nil
+}
+
+func cloneServerStaleness(staleness ServerStaleness) ServerStaleness {
+	staleness.Reasons = slices.Clone(staleness.Reasons)
+	return staleness
+}
+
+// PutServerTools stores the indexed tools of a server, replacing any earlier index of it
+func (db *Memory) PutServerTools(ctx context.Context, tx pgx.Tx, tools ServerTools) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if tools.Source != ToolSourceManifest && tools.Source != ToolSourceRemote {
+		return fmt.Errorf("failed to store server tools: %w: source %q violates check constraint \"check_server_tools_source\"", ErrInvalidInput, tools.Source)
+	}
+	defer db.lock(tx)()
+
+	tools.IndexedAt = now()
+	db.state.serverTools[tools.ServerName] = cloneServerTools(tools)
+	return nil
+}
+
+// GetServerTools retrieves the indexed tools of a server
+func (db *Memory) GetServerTools(ctx context.Context, tx pgx.Tx, serverName string) (*ServerTools, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	tools, exists := db.state.serverTools[serverName]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	clone := cloneServerTools(tools)
+	return &clone, nil
+}
+
+// DeleteServerTools removes the indexed tools of a server
+func (db *Memory) DeleteServerTools(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.serverTools[serverName]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.serverTools, serverName)
+	return nil
+}
+
+func cloneServerTools(tools ServerTools) ServerTools {
+	tools.Tools = slices.Clone(tools.Tools)
+	return tools
+}
+
+// checkServerCuration enforces the check constraints of the server_curation table
+func checkServerCuration(curation ServerCuration) error {
+	validLabels := []string{CurationLabelFeatured, CurationLabelOfficial, CurationLabelCommunity}
+	switch {
+	case len(curation.Labels) == 0 || slices.ContainsFunc(curation.Labels, func(label string) bool { return !slices.Contains(validLabels, label) }):
+		return fmt.Errorf("%w: labels %v violate check constraint \"check_server_curation_labels\"", ErrInvalidInput, curation.Labels)
+	case slices.Contains(curation.Labels, CurationLabelOfficial) && slices.Contains(curation.Labels, CurationLabelCommunity):
+		return fmt.Errorf("%w: labels %v violate check constraint \"check_server_curation_maintainer\"", ErrInvalidInput, curation.Labels)
+	case curation.Position != nil && *curation.Position < 0:
+		return fmt.Errorf("%w: position %d violates check constraint \"check_server_curation_position\"", ErrInvalidInput, *curation.Position)
+	case len(curation.Note) > 1000:
+		return fmt.Errorf("%w: note violates check constraint \"check_server_curation_note_length\"", ErrInvalidInput)
+	}
+	return nil
+}
+
+// compareCurationPosition orders curations by position, with curations without one last, then by server name
+func compareCurationPosition(a, b *ServerCuration) int {
+	switch {
+	case a.Position != nil && b.Position != nil && *a.Position != *b.Position:
+		return cmp.Compare(*a.Position, *b.Position)
+	case a.Position != nil && b.Position == nil:
+		return -1
+	case a.Position == nil && b.Position != nil:
+		return 1
+	}
+	return strings.Compare(a.ServerName, b.ServerName)
+}
+
+// cloneServerCuration copies a server curation so callers cannot modify stored data
+func cloneServerCuration(curation ServerCuration) *ServerCuration {
+	curation.Labels = slices.Clone(curation.Labels)
+	if curation.Position != nil {
+		position := *curation.Position
+		curation.Position = &position
+	}
+	return &curation
+}
+
+// cloneOrgAPIKey copies an organization API key so callers cannot modify stored data
+func cloneOrgAPIKey(key OrgAPIKey) *OrgAPIKey {
+	key.Namespaces = slices.Clone(key.Namespaces)
+	if key.RotatedAt != nil {
+		rotatedAt := *key.RotatedAt
+		key.RotatedAt = &rotatedAt
+	}
+	if key.LastUsedAt != nil {
+		lastUsedAt := *key.LastUsedAt
+		key.LastUsedAt = &lastUsedAt
+	}
+	return &key
+}
+
+// checkOrgAPIKeyUnique enforces the unique indexes of the org_api_keys table against every other key
+func (db *Memory) checkOrgAPIKeyUnique(key OrgAPIKey) error {
+	for id, other := range db.state.orgAPIKeys {
+		switch {
+		case id == key.ID:
+		case other.KeyHash == key.KeyHash:
+			return fmt.Errorf("%w: duplicate key value violates unique constraint \"idx_org_api_keys_key_hash\"", ErrAlreadyExists)
+		case other.Organization == key.Organization && other.Name == key.Name:
+			return fmt.Errorf("%w: duplicate key value violates unique constraint \"idx_org_api_keys_organization_name\"", ErrAlreadyExists)
+		}
+	}
+	return nil
+}
+
+// CreateOrgAPIKey stores an organization API key, assigning its ID and creation time. Key names are unique
+// within an organization.
+func (db *Memory) CreateOrgAPIKey(ctx context.Context, tx pgx.Tx, key OrgAPIKey) (*OrgAPIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	switch {
+	case len(key.Namespaces) == 0:
+		return nil, fmt.Errorf("failed to create organization API key: %w: violates check constraint \"check_org_api_key_namespaces\"", ErrInvalidInput)
+	case key.Name == "":
+		return nil, fmt.Errorf("failed to create organization API key: %w: violates check constraint \"check_org_api_key_name\"", ErrInvalidInput)
+	}
+	defer db.lock(tx)()
+
+	created := *cloneOrgAPIKey(key)
+	created.ID = db.state.lastOrgAPIKeyID + 1
+	created.CreatedAt = now()
+	created.RotatedAt, created.LastUsedAt = nil, nil
+	if err := db.checkOrgAPIKeyUnique(created); err != nil {
+		return nil, fmt.Errorf("failed to create organization API key: %w", err)
+	}
+	db.state.lastOrgAPIKeyID = created.ID
+	db.state.orgAPIKeys[created.ID] = created
+	return cloneOrgAPIKey(created), nil
+}
+
+// GetOrgAPIKey retrieves an organization API key by ID
+func (db *Memory) GetOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64) (*OrgAPIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key, exists := db.state.orgAPIKeys[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return cloneOrgAPIKey(key), nil
+}
+
+// GetOrgAPIKeyByHash retrieves the organization API key with the given key hash
+func (db *Memory) GetOrgAPIKeyByHash(ctx context.Context, tx pgx.Tx, keyHash string) (*OrgAPIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	for _, key := range db.state.orgAPIKeys {
+		if key.KeyHash == keyHash {
+			return cloneOrgAPIKey(key), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// ListOrgAPIKeys retrieves an organization's API keys, oldest first
+func (db *Memory) ListOrgAPIKeys(ctx context.Context, tx pgx.Tx, organization string) ([]*OrgAPIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	keys := []*OrgAPIKey{}
+	for _, id := range slices.Sorted(maps.Keys(db.state.orgAPIKeys)) {
+		if key := db.state.orgAPIKeys[id]; key.Organization == organization {
+			keys = append(keys, cloneOrgAPIKey(key))
+		}
+	}
+	return keys, nil
+}
+
+// RotateOrgAPIKey replaces the key of an organization API key and records when it was rotated
+func (db *Memory) RotateOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64, keyHash, keyPrefix string) (*OrgAPIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key, exists := db.state.orgAPIKeys[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	rotatedAt := now()
+	key.KeyHash, key.KeyPrefix, key.RotatedAt = keyHash, keyPrefix, &rotatedAt
+	if err := db.checkOrgAPIKeyUnique(key); err != nil {
+		return nil, fmt.Errorf("failed to rotate organization API key: %w", err)
+	}
+	db.state.orgAPIKeys[id] = key
+	return cloneOrgAPIKey(key), nil
+}
+
+// TouchOrgAPIKey records when an organization API key was last used
+func (db *Memory) TouchOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64, usedAt time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key, exists := db.state.orgAPIKeys[id]
+	if !exists {
+		return ErrNotFound
+	}
+	usedAt = usedAt.Round(time.Microsecond)
+	key.LastUsedAt = &usedAt
+	db.state.orgAPIKeys[id] = key
+	return nil
+}
+
+// DeleteOrgAPIKey permanently removes an organization API key
+func (db *Memory) DeleteOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.orgAPIKeys[id]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.orgAPIKeys, id)
+	return nil
+}
+
+// CreateOrgMember adds a member to an organization, assigning its ID and creation time. Subjects are unique
+// within an organization.
+func (db *Memory) CreateOrgMember(ctx context.Context, tx pgx.Tx, member OrgMember) (*OrgMember, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	for _, other := range db.state.orgMembers {
+		if other.Organization == member.Organization && other.Subject == member.Subject {
+			return nil, fmt.Errorf("failed to create organization member: %w: duplicate key value violates unique constraint \"idx_org_members_organization_subject\"", ErrAlreadyExists)
+		}
+	}
+	member.ID = db.state.lastOrgMemberID + 1
+	member.CreatedAt = now()
+	db.state.lastOrgMemberID = member.ID
+	db.state.orgMembers[member.ID] = member
+	return &member, nil
+}
+
+// GetOrgMember retrieves an organization member by ID
+func (db *Memory) GetOrgMember(ctx context.Context, tx pgx.Tx, id int64) (*OrgMember, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	member, exists := db.state.orgMembers[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &member, nil
+}
+
+// GetOrgMemberBySubject retrieves the member of an organization with the given subject
+func (db *Memory) GetOrgMemberBySubject(ctx context.Context, tx pgx.Tx, organization, subject string) (*OrgMember, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	for _, member := range db.state.orgMembers {
+		if member.Organization == organization && member.Subject == subject {
+			return &member, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// ListOrgMembers retrieves an organization's members, oldest first
+func (db *Memory) ListOrgMembers(ctx context.Context, tx pgx.Tx, organization string) ([]*OrgMember, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	members := []*OrgMember{}
+	for _, id := range slices.Sorted(maps.Keys(db.state.orgMembers)) {
+		if member := db.state.orgMembers[id]; member.Organization == organization {
+			members = append(members, &member)
+		}
+	}
+	return members, nil
+}
+
+// DeleteOrgMember removes an organization member
+func (db *Memory) DeleteOrgMember(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.orgMembers[id]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.orgMembers, id)
+	return nil
+}
+
+// cloneBulkJob copies a bulk job so callers cannot modify stored data
+func cloneBulkJob(job BulkJob) *BulkJob {
+	if job.Params.StatusMessage != nil {
+		message := *job.Params.StatusMessage
+		job.Params.StatusMessage = &message
+	}
+	job.Failures = slices.Clone(job.Failures)
+	return &job
+}
+
+// CreateBulkJob queues a bulk job, assigning its ID and creation time
+func (db *Memory) CreateBulkJob(ctx context.Context, tx pgx.Tx, job BulkJob) (*BulkJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if job.Kind != BulkJobStatus && job.Kind != BulkJobRevalidate && job.Kind != BulkJobTransfer {
+		return nil, fmt.Errorf("failed to create bulk job: %w: kind %q violates check constraint \"check_bulk_job_kind\"", ErrInvalidInput, job.Kind)
+	}
+	defer db.lock(tx)()
+
+	db.state.lastBulkJobID++
+	createdAt := now()
+	created := BulkJob{
+		ID:        db.state.lastBulkJobID,
+		Kind:      job.Kind,
+		Params:    cloneBulkJob(job).Params,
+		State:     BulkJobQueued,
+		CreatedBy: job.CreatedBy,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	db.state.bulkJobs[created.ID] = created
+	return cloneBulkJob(created), nil
+}
+
+// GetBulkJob retrieves a bulk job by ID
+func (db *Memory) GetBulkJob(ctx context.Context, tx pgx.Tx, id int64) (*BulkJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	job, exists := db.state.bulkJobs[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return cloneBulkJob(job), nil
+}
+
+// ListBulkJobs retrieves the most recently queued bulk jobs, newest first
+func (db *Memory) ListBulkJobs(ctx context.Context, tx pgx.Tx, limit int) ([]*BulkJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	jobs := []*BulkJob{}
+	for _, job := range db.state.bulkJobs {
+		jobs = append(jobs, cloneBulkJob(job))
+	}
+	slices.SortFunc(jobs, func(a, b *BulkJob) int {
+		return cmp.Compare(b.ID, a.ID)
+	})
+	return jobs[:min(len(jobs), limit)], nil
+}
+
+// ClaimBulkJob marks the oldest queued or running bulk job as running and returns it
+func (db *Memory) ClaimBulkJob(ctx context.Context, tx pgx.Tx) (*BulkJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	var claimed *BulkJob
+	for _, job := range db.state.bulkJobs {
+		if (job.State == BulkJobQueued || job.State == BulkJobRunning) && (claimed == nil || job.ID < claimed.ID) {
+			claimed = &job
+		}
+	}
+	if claimed == nil {
+		return nil, ErrNotFound
+	}
+
+	// An interrupted job restarts from scratch, so its progress is reset
+	startedAt := now()
+	claimed.State = BulkJobRunning
+	claimed.Total, claimed.Processed, claimed.Failed = 0, 0, 0
+	claimed.Failures = nil
+	claimed.StartedAt = &startedAt
+	claimed.UpdatedAt = startedAt
+	db.state.bulkJobs[claimed.ID] = *claimed
+	return cloneBulkJob(*claimed), nil
+}
+
+// UpdateBulkJob records a bulk job's state and progress
+func (db *Memory) UpdateBulkJob(ctx context.Context, tx pgx.Tx, job *BulkJob) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if job.State != BulkJobQueued && job.State != BulkJobRunning && job.State != BulkJobSucceeded && job.State != BulkJobFailed {
+		return fmt.Errorf("failed to update bulk job: %w: state %q violates check constraint \"check_bulk_job_state\"", ErrInvalidInput, job.State)
+	}
+	defer db.lock(tx)()
+
+	stored, exists := db.state.bulkJobs[job.ID]
+	if !exists {
+		return ErrNotFound
+	}
+	stored.State = job.State
+	stored.Total = job.Total
+	stored.Processed = job.Processed
+	stored.Failed = job.Failed
+	stored.Failures = slices.Clone(job.Failures)
+	stored.Error = job.Error
+	if job.FinishedAt != nil {
+		finishedAt := job.FinishedAt.Round(time.Microsecond)
+		stored.FinishedAt = &finishedAt
+	} else {
+		stored.FinishedAt = nil
+	}
+	stored.UpdatedAt = now()
+	db.state.bulkJobs[job.ID] = stored
+	job.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// memoryPendingPublish is a pending publish with its server JSON encoded, as PostgreSQL stores it, so stored
+// publishes don't share the server's slices with callers
+type memoryPendingPublish struct {
+	publish PendingPublish
+	value   []byte
+}
+
+func (p memoryPendingPublish) response() (*PendingPublish, error) {
+	publish := p.publish
+	publish.Document = slices.Clone(p.publish.Document)
+	publish.Publisher = clonePublisher(p.publish.Publisher)
+	publish.Server = apiv0.ServerJSON{}
+	if err := json.Unmarshal(p.value, &publish.Server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pending publish server: %w", err)
+	}
+	return &publish, nil
+}
+
+// CreatePendingPublish stores an accepted publish as pending, assigning its ID and creation time
+func (db *Memory) CreatePendingPublish(ctx context.Context, tx pgx.Tx, publish PendingPublish) (*PendingPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	value, err := json.Marshal(publish.Server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+	defer db.lock(tx)()
+
+	for _, stored := range db.state.pendingPublishes {
+		if stored.publish.State == PublishPending && stored.publish.ServerName == publish.Server.Name && stored.publish.Version == publish.Server.Version {
+			return nil, fmt.Errorf("failed to create pending publish: %w: duplicate key value violates unique constraint \"idx_pending_publishes_version\"", ErrAlreadyExists)
+		}
+	}
+
+	db.state.lastPendingPublish++
+	stored := memoryPendingPublish{
+		publish: PendingPublish{
+			ID:          db.state.lastPendingPublish,
+			ServerName:  publish.Server.Name,
+			Version:     publish.Server.Version,
+			State:       PublishPending,
+			Document:    slices.Clone(publish.Document),
+			Publisher:   clonePublisher(publish.Publisher),
+			SubmittedBy: publish.SubmittedBy,
+			CreatedAt:   now(),
+		},
+		value: value,
+	}
+	stored.publish.NextAttemptAt = stored.publish.CreatedAt
+	db.state.pendingPublishes[stored.publish.ID] = stored
+	return stored.response()
+}
+
+// GetPendingPublish retrieves an accepted publish by ID
+func (db *Memory) GetPendingPublish(ctx context.Context, tx pgx.Tx, id int64) (*PendingPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	stored, exists := db.state.pendingPublishes[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return stored.response()
+}
+
+// NextPendingPublish retrieves the oldest publish that is still pending and due for an attempt
+func (db *Memory) NextPendingPublish(ctx context.Context, tx pgx.Tx) (*PendingPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	current := now()
+	var next *memoryPendingPublish
+	for _, stored := range db.state.pendingPublishes {
+		if stored.publish.State != PublishPending || stored.publish.NextAttemptAt.After(current) {
+			continue
+		}
+		if next == nil || stored.publish.ID < next.publish.ID {
+			next = &stored
+		}
+	}
+	if next == nil {
+		return nil, ErrNotFound
+	}
+	return next.response()
+}
+
+// FinishPendingPublish records that a pending publish became active, or was rejected with a reason
+func (db *Memory) FinishPendingPublish(ctx context.Context, tx pgx.Tx, id int64, state, reason string) (*PendingPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if state != PublishPending && state != PublishActive && state != PublishRejected {
+		return nil, fmt.Errorf("failed to finish pending publish: %w: state %q violates check constraint \"check_pending_publish_state\"", ErrInvalidInput, state)
+	}
+	defer db.lock(tx)()
+
+	stored, exists := db.state.pendingPublishes[id]
+	if !exists || stored.publish.State != PublishPending {
+		return nil, ErrNotFound
+	}
+	finishedAt := now()
+	stored.publish.State = state
+	stored.publish.Error = reason
+	stored.publish.FinishedAt = &finishedAt
+	db.state.pendingPublishes[id] = stored
+	return stored.response()
+}
+
+// RetryPendingPublish records a failed attempt of a pending publish and leaves it pending until retryAt
+func (db *Memory) RetryPendingPublish(ctx context.Context, tx pgx.Tx, id int64, reason string, retryAt time.Time) (*PendingPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	stored, exists := db.state.pendingPublishes[id]
+	if !exists || stored.publish.State != PublishPending {
+		return nil, ErrNotFound
+	}
+	stored.publish.Error = reason
+	stored.publish.Attempts++
+	stored.publish.NextAttemptAt = retryAt.Round(time.Microsecond)
+	db.state.pendingPublishes[id] = stored
+	return stored.response()
+}
+
+// DeletePendingPublishesBefore permanently removes publishes that finished before the given time and returns how many were removed
+func (db *Memory) DeletePendingPublishesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	var deleted int64
+	for id, stored := range db.state.pendingPublishes {
+		if stored.publish.State != PublishPending && stored.publish.FinishedAt.Before(before) {
+			delete(db.state.pendingPublishes, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// CreateServerAlias stores an alias, replacing any alias with the same name, and records the rename in the
+// changes feed against the latest version of the server it points to
+func (db *Memory) CreateServerAlias(ctx context.Context, tx pgx.Tx, alias ServerAlias) (*ServerAlias, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if alias.Alias == alias.ServerName {
+		return nil, fmt.Errorf("failed to create server alias: %w: alias equal to server name violates check constraint \"check_server_alias_differs\"", ErrInvalidInput)
+	}
+	defer db.lock(tx)()
+
+	var latest *serverKey
+	for key, row := range db.state.servers {
+		if key.name == alias.ServerName && row.isLatest {
+			latest = &key
+			break
+		}
+	}
+
+	if latest == nil {
+		return nil, ErrNotFound
+	}
+
+	alias.CreatedAt = now()
+	alias.ExpiresAt = alias.ExpiresAt.Truncate(time.Microsecond)
+	db.state.aliases[alias.Alias] = alias
+
+	db.state.lastSeq++
+	db.state.changes = append(db.state.changes, memoryChange{
+		seq:          db.state.lastSeq,
+		key:          *latest,
+		changeType:   "renamed",
+		changedAt:    now(),
+		row:          db.state.servers[*latest],
+		previousName: alias.Alias,
+	})
+	db.state.pending = append(db.state.pending, Event{Type: EventServerChange, Seq: db.state.lastSeq})
+	return &alias, nil
+}
+
+// GetServerAlias retrieves an alias that has not expired
+func (db *Memory) GetServerAlias(ctx context.Context, tx pgx.Tx, alias string) (*ServerAlias, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	found, exists := db.state.aliases[alias]
+	if !exists || !found.ExpiresAt.After(time.Now()) {
+		return nil, ErrNotFound
+	}
+	return &found, nil
+}
+
+// ListServerAliases retrieves the aliases pointing at a server that have not expired, ordered by alias
+func (db *Memory) ListServerAliases(ctx context.Context, tx pgx.Tx, serverName string) ([]*ServerAlias, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	var aliases []*ServerAlias
+	for _, alias := range db.state.aliases {
+		if alias.ServerName == serverName && alias.ExpiresAt.After(time.Now()) {
+			aliases = append(aliases, &alias)
+		}
+	}
+	slices.SortFunc(aliases, func(a, b *ServerAlias) int {
+		return strings.Compare(a.Alias, b.Alias)
+	})
+	return aliases, nil
+}
+
+// RetargetServerAliases points the aliases of a server at its new name, returning how many were moved
+func (db *Memory) RetargetServerAliases(ctx context.Context, tx pgx.Tx, serverName, newName string) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	var moved int64
+	for name, alias := range db.state.aliases {
+		if alias.ServerName != serverName {
+			continue
+		}
+		// An alias of the new name would point at itself, so it is dropped rather than moved
+		if name == newName {
+			delete(db.state.aliases, name)
+			continue
+		}
+		alias.ServerName = newName
+		db.state.aliases[name] = alias
+		moved++
+	}
+	return moved, nil
+}
+
+// DeleteServerAlias removes an alias
+func (db *Memory) DeleteServerAlias(ctx context.Context, tx pgx.Tx, alias string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.aliases[alias]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.aliases, alias)
+	return nil
+}
+
+// cloneWebhookSubscription copies a webhook subscription so callers cannot modify stored data
+func cloneWebhookSubscription(subscription WebhookSubscription) *WebhookSubscription {
+	subscription.EventTypes = slices.Clone(nonNilStrings(subscription.EventTypes))
+	subscription.Namespaces = slices.Clone(nonNilStrings(subscription.Namespaces))
+	if subscription.LastAttemptAt != nil {
+		lastAttemptAt := *subscription.LastAttemptAt
+		subscription.LastAttemptAt = &lastAttemptAt
+	}
+	return &subscription
+}
+
+// checkWebhookFormat enforces the check constraint on webhook subscription formats
+func checkWebhookFormat(format string) error {
+	if format != WebhookFormatFull && format != WebhookFormatMinimal && format != WebhookFormatCloudEvents {
+		return fmt.Errorf("%w: format %q violates check constraint \"check_webhook_subscription_format\"", ErrInvalidInput, format)
+	}
+	return nil
+}
+
+// CreateWebhookSubscription stores a webhook subscription, assigning its ID and creation time
+func (db *Memory) CreateWebhookSubscription(ctx context.Context, tx pgx.Tx, subscription WebhookSubscription) (*WebhookSubscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := checkWebhookFormat(subscription.Format); err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	defer db.lock(tx)()
+
+	db.state.lastWebhookID++
+	createdAt := now()
+	created := *cloneWebhookSubscription(subscription)
+	created.ID = db.state.lastWebhookID
+	created.LastError = ""
+	created.LastAttemptAt = nil
+	created.CreatedAt = createdAt
+	created.UpdatedAt = createdAt
+	db.state.webhooks[created.ID] = created
+	return cloneWebhookSubscription(created), nil
+}
+
+// GetWebhookSubscription retrieves a webhook subscription by ID
+func (db *Memory) GetWebhookSubscription(ctx context.Context, tx pgx.Tx, id int64) (*WebhookSubscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	subscription, exists := db.state.webhooks[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return cloneWebhookSubscription(subscription), nil
+}
+
+// ListWebhookSubscriptions retrieves all webhook subscriptions, oldest first
+func (db *Memory) ListWebhookSubscriptions(ctx context.Context, tx pgx.Tx) ([]*WebhookSubscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	subscriptions := []*WebhookSubscription{}
+	for _, subscription := range db.state.webhooks {
+		subscriptions = append(subscriptions, cloneWebhookSubscription(subscription))
+	}
+	slices.SortFunc(subscriptions, func(a, b *WebhookSubscription) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return subscriptions, nil
+}
+
+// UpdateWebhookSubscription replaces a webhook subscription's URL, filters and format
+func (db *Memory) UpdateWebhookSubscription(ctx context.Context, tx pgx.Tx, subscription *WebhookSubscription) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := checkWebhookFormat(subscription.Format); err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	defer db.lock(tx)()
+
+	stored, exists := db.state.webhooks[subscription.ID]
+	if !exists {
+		return ErrNotFound
+	}
+	stored.URL = subscription.URL
+	stored.EventTypes = slices.Clone(nonNilStrings(subscription.EventTypes))
+	stored.Namespaces = slices.Clone(nonNilStrings(subscription.Namespaces))
+	stored.Format = subscription.Format
+	stored.UpdatedAt = now()
+	db.state.webhooks[subscription.ID] = stored
+	subscription.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// UpdateWebhookSubscriptionSecret replaces the stored signing secret of a webhook subscription
+func (db *Memory) UpdateWebhookSubscriptionSecret(ctx context.Context, tx pgx.Tx, id int64, secret string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	stored, exists := db.state.webhooks[id]
+	if !exists {
+		return ErrNotFound
+	}
+	stored.Secret = secret
+	db.state.webhooks[id] = stored
+	return nil
+}
+
+// RecordWebhookDelivery records how far a webhook subscription has been delivered and why delivery stopped, if it failed
+func (db *Memory) RecordWebhookDelivery(ctx context.Context, tx pgx.Tx, id int64, lastSeq int64, lastError string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	stored, exists := db.state.webhooks[id]
+	if !exists {
+		return ErrNotFound
+	}
+	attemptedAt := now()
+	stored.LastSeq = lastSeq
+	stored.LastError = lastError
+	stored.LastAttemptAt = &attemptedAt
+	db.state.webhooks[id] = stored
+	return nil
+}
+
+// DeleteWebhookSubscription permanently removes a webhook subscription
+func (db *Memory) DeleteWebhookSubscription(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.webhooks[id]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.webhooks, id)
+	return nil
+}
+
+// cloneAnnouncement copies an announcement so callers cannot modify stored data
+func cloneAnnouncement(announcement AnnouncementRecord) *AnnouncementRecord {
+	if announcement.StartsAt != nil {
+		startsAt := *announcement.StartsAt
+		announcement.StartsAt = &startsAt
+	}
+	if announcement.EndsAt != nil {
+		endsAt := *announcement.EndsAt
+		announcement.EndsAt = &endsAt
+	}
+	return &announcement
+}
+
+// checkAnnouncement enforces the check constraints on announcement severities and schedules
+func checkAnnouncement(announcement *AnnouncementRecord) error {
+	if !slices.Contains(apiv0.AnnouncementSeverities, announcement.Severity) {
+		return fmt.Errorf("%w: severity %q violates check constraint \"check_announcement_severity\"", ErrInvalidInput, announcement.Severity)
+	}
+	if announcement.StartsAt != nil && announcement.EndsAt != nil && !announcement.StartsAt.Before(*announcement.EndsAt) {
+		return fmt.Errorf("%w: schedule violates check constraint \"check_announcement_schedule\"", ErrInvalidInput)
+	}
+	return nil
+}
+
+// CreateAnnouncement stores an announcement, assigning its ID and creation time
+func (db *Memory) CreateAnnouncement(ctx context.Context, tx pgx.Tx, announcement AnnouncementRecord) (*AnnouncementRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := checkAnnouncement(&announcement); err != nil {
+		return nil, fmt.Errorf("failed to create announcement: %w", err)
+	}
+	defer db.lock(tx)()
+
+	db.state.lastAnnouncementID++
+	createdAt := now()
+	created := *cloneAnnouncement(announcement)
+	created.ID = db.state.lastAnnouncementID
+	created.CreatedAt = createdAt
+	created.UpdatedAt = createdAt
+	db.state.announcements[created.ID] = created
+	return cloneAnnouncement(created), nil
+}
+
+// GetAnnouncement retrieves an announcement by ID
+func (db *Memory) GetAnnouncement(ctx context.Context, tx pgx.Tx, id int64) (*AnnouncementRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	announcement, exists := db.state.announcements[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return cloneAnnouncement(announcement), nil
+}
+
+// ListAnnouncements retrieves all announcements, including those not shown yet or any more, oldest first
+func (db *Memory) ListAnnouncements(ctx context.Context, tx pgx.Tx) ([]*AnnouncementRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	announcements := []*AnnouncementRecord{}
+	for _, announcement := range db.state.announcements {
+		announcements = append(announcements, cloneAnnouncement(announcement))
+	}
+	slices.SortFunc(announcements, func(a, b *AnnouncementRecord) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return announcements, nil
+}
+
+// UpdateAnnouncement replaces an announcement's message, severity and schedule
+func (db *Memory) UpdateAnnouncement(ctx context.Context, tx pgx.Tx, announcement *AnnouncementRecord) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := checkAnnouncement(announcement); err != nil {
+		return fmt.Errorf("failed to update announcement: %w", err)
+	}
+	defer db.lock(tx)()
+
+	stored, exists := db.state.announcements[announcement.ID]
+	if !exists {
+		return ErrNotFound
+	}
+	updated := *cloneAnnouncement(*announcement)
+	stored.Message = updated.Message
+	stored.Severity = updated.Severity
+	stored.StartsAt = updated.StartsAt
+	stored.EndsAt = updated.EndsAt
+	stored.UpdatedAt = now()
+	db.state.announcements[announcement.ID] = stored
+	announcement.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// DeleteAnnouncement permanently removes an announcement
+func (db *Memory) DeleteAnnouncement(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.announcements[id]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.announcements, id)
+	return nil
+}
+
+// cloneOAuthClient copies a registered OAuth client so callers cannot modify stored data
+func cloneOAuthClient(client OAuthClient) *OAuthClient {
+	client.RedirectURIs = slices.Clone(client.RedirectURIs)
+	return &client
+}
+
+// CreateOAuthClient stores a registered OAuth client, assigning its creation time. It returns ErrAlreadyExists
+// when the client ID is taken.
+func (db *Memory) CreateOAuthClient(ctx context.Context, tx pgx.Tx, client OAuthClient) (*OAuthClient, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if len(client.RedirectURIs) == 0 {
+		return nil, fmt.Errorf("failed to create OAuth client: %w: no redirect URIs violates check constraint \"check_oauth_client_redirect_uris\"", ErrInvalidInput)
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.oauthClients[client.ClientID]; exists {
+		return nil, fmt.Errorf("failed to create OAuth client: %w", ErrAlreadyExists)
+	}
+	created := *cloneOAuthClient(client)
+	created.CreatedAt = now()
+	db.state.oauthClients[created.ClientID] = created
+	return cloneOAuthClient(created), nil
+}
+
+// GetOAuthClient retrieves a registered OAuth client by client ID
+func (db *Memory) GetOAuthClient(ctx context.Context, tx pgx.Tx, clientID string) (*OAuthClient, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	client, exists := db.state.oauthClients[clientID]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return cloneOAuthClient(client), nil
+}
+
+// ListOAuthClients retrieves all registered OAuth clients, oldest first
+func (db *Memory) ListOAuthClients(ctx context.Context, tx pgx.Tx) ([]*OAuthClient, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	clients := []*OAuthClient{}
+	for _, client := range db.state.oauthClients {
+		clients = append(clients, cloneOAuthClient(client))
+	}
+	slices.SortFunc(clients, func(a, b *OAuthClient) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ClientID, b.ClientID))
+	})
+	return clients, nil
+}
+
+// DeleteOAuthClient permanently removes a registered OAuth client
+func (db *Memory) DeleteOAuthClient(ctx context.Context, tx pgx.Tx, clientID string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.oauthClients[clientID]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.oauthClients, clientID)
+	return nil
+}
+
+// cloneCollection copies a collection so callers cannot modify stored data
+func cloneCollection(collection Collection) *Collection {
+	collection.Items = slices.Clone(collection.Items)
+	if collection.Items == nil {
+		collection.Items = []CollectionItem{}
+	}
+	return &collection
+}
+
+// CreateCollection stores a collection, assigning its ID and creation time
+func (db *Memory) CreateCollection(ctx context.Context, tx pgx.Tx, collection Collection) (*Collection, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	db.state.lastCollectionID++
+	createdAt := now()
+	created := *cloneCollection(collection)
+	created.ID = db.state.lastCollectionID
+	created.CreatedAt = createdAt
+	created.UpdatedAt = createdAt
+	db.state.collections[created.ID] = created
+	return cloneCollection(created), nil
+}
+
+// GetCollection retrieves a collection by ID
+func (db *Memory) GetCollection(ctx context.Context, tx pgx.Tx, id int64) (*Collection, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	collection, exists := db.state.collections[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return cloneCollection(collection), nil
+}
+
+// ListCollections retrieves up to limit collections with an ID greater than afterID, oldest first, only
+// those of owner if it is not empty
+func (db *Memory) ListCollections(ctx context.Context, tx pgx.Tx, owner string, afterID int64, limit int) ([]*Collection, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	collections := []*Collection{}
+	for _, collection := range db.state.collections {
+		if collection.ID > afterID && (owner == "" || collection.Owner == owner) {
+			collections = append(collections, cloneCollection(collection))
+		}
+	}
+	slices.SortFunc(collections, func(a, b *Collection) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	if len(collections) > limit {
+		collections = collections[:limit]
+	}
+	return collections, nil
+}
+
+// UpdateCollection replaces a collection's title, description and items
+func (db *Memory) UpdateCollection(ctx context.Context, tx pgx.Tx, collection *Collection) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	stored, exists := db.state.collections[collection.ID]
+	if !exists {
+		return ErrNotFound
+	}
+	stored.Title = collection.Title
+	stored.Description = collection.Description
+	stored.Items = cloneCollection(*collection).Items
+	stored.UpdatedAt = now()
+	db.state.collections[collection.ID] = stored
+	collection.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// DeleteCollection permanently removes a collection
+func (db *Memory) DeleteCollection(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.collections[id]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.collections, id)
+	return nil
+}
+
+// memoryPreview is a row of the previews table, holding the server.json as stored JSON
+type memoryPreview struct {
+	preview Preview
+	value   []byte
+}
+
+func (p memoryPreview) response() (*Preview, error) {
+	preview := p.preview
+	preview.Publisher = clonePublisher(p.preview.Publisher)
+	preview.Server = apiv0.ServerJSON{}
+	if err := json.Unmarshal(p.value, &preview.Server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal preview server: %w", err)
+	}
+	return &preview, nil
+}
+
+// CreatePreview stores a preview under the ID it was given, recording its creation time
+func (db *Memory) CreatePreview(ctx context.Context, tx pgx.Tx, preview Preview) (*Preview, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	value, err := json.Marshal(preview.Server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.previews[preview.ID]; exists {
+		return nil, fmt.Errorf("failed to create preview: %w", ErrAlreadyExists)
+	}
+	preview.CreatedAt = now()
+	preview.Publisher = clonePublisher(preview.Publisher)
+	stored := memoryPreview{preview: preview, value: value}
+	db.state.previews[preview.ID] = stored
+	return stored.response()
+}
+
+// GetPreview retrieves an unexpired preview, returning ErrNotFound if there is none
+func (db *Memory) GetPreview(ctx context.Context, tx pgx.Tx, id string) (*Preview, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	stored, exists := db.state.previews[id]
+	if !exists || !stored.preview.ExpiresAt.After(now()) {
+		return nil, ErrNotFound
+	}
+	return stored.response()
+}
+
+// DeleteExpiredPreviews removes previews that expired before the given time and returns how many were removed
+func (db *Memory) DeleteExpiredPreviews(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	var deleted int64
+	for id, stored := range db.state.previews {
+		if stored.preview.ExpiresAt.Before(before) {
+			delete(db.state.previews, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// metricKey identifies a metric's counter for one bucket, by the Unix time the bucket starts at
+type metricKey struct {
+	metric string
+	bucket int64
+}
+
+// IncrementMetric adds delta to a metric's counter for the bucket containing at
+func (db *Memory) IncrementMetric(ctx context.Context, tx pgx.Tx, metric string, at time.Time, delta int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	db.state.metricCounts[metricKey{metric: metric, bucket: at.Truncate(MetricBucket).Unix()}] += delta
+	return nil
+}
+
+// ListMetricCounts retrieves a metric's counters for buckets starting at or after from and before to, oldest first
+func (db *Memory) ListMetricCounts(ctx context.Context, tx pgx.Tx, metric string, from, to time.Time) ([]MetricCount, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	counts := []MetricCount{}
+	for key, count := range db.state.metricCounts {
+		bucket := time.Unix(key.bucket, 0).UTC()
+		if key.metric == metric && !bucket.Before(from) && bucket.Before(to) {
+			counts = append(counts, MetricCount{Bucket: bucket, Count: count})
+		}
+	}
+	slices.SortFunc(counts, func(a, b MetricCount) int {
+		return a.Bucket.Compare(b.Bucket)
+	})
+	return counts, nil
+}
+
+// installReportKey identifies a reporter's install report for a server version
+type installReportKey struct {
+	server   serverKey
+	reporter string
+}
+
+// PutInstallReport stores an install report, replacing the reporter's earlier report for the version
+func (db *Memory) PutInstallReport(ctx context.Context, tx pgx.Tx, report InstallReport) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	key := serverKey{name: report.ServerName, version: report.Version}
+	if _, exists := db.state.servers[key]; !exists {
+		return ErrNotFound
+	}
+	db.state.installReports[installReportKey{server: key, reporter: report.Reporter}] = report
+	return nil
+}
+
+// CountInstallReports counts the reports made at or after since for every version of the given servers
+func (db *Memory) CountInstallReports(ctx context.Context, tx pgx.Tx, serverNames []string, since time.Time) (map[string]map[string]InstallReportCount, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	counts := map[string]map[string]InstallReportCount{}
+	for key, report := range db.state.installReports {
+		if !slices.Contains(serverNames, key.server.name) || report.ReportedAt.Before(since) {
+			continue
+		}
+		if counts[key.server.name] == nil {
+			counts[key.server.name] = map[string]InstallReportCount{}
+		}
+		count := counts[key.server.name][key.server.version]
+		count.Reports++
+		if report.Outcome == InstallOutcomeSuccess {
+			count.Successes++
+		}
+		counts[key.server.name][key.server.version] = count
+	}
+	return counts, nil
+}
+
+// DeleteInstallReportsBefore removes install reports made before the given time and returns how many were removed
+func (db *Memory) DeleteInstallReportsBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	var deleted int64
+	maps.DeleteFunc(db.state.installReports, func(_ installReportKey, report InstallReport) bool {
+		if report.ReportedAt.Before(before) {
+			deleted++
+			return true
+		}
+		return false
+	})
+	return deleted, nil
+}
+
+// cloneValidationPolicy copies a validation policy so callers cannot modify stored data
+func cloneValidationPolicy(policy ValidationPolicy) *ValidationPolicy {
+	policy.BlockingLintRules = slices.Clone(policy.BlockingLintRules)
+	if policy.BlockingLintRules == nil {
+		policy.BlockingLintRules = []string{}
+	}
+	return &policy
+}
+
+// PutValidationPolicy sets the validation policy of a namespace, replacing any existing policy for it
+func (db *Memory) PutValidationPolicy(ctx context.Context, tx pgx.Tx, policy ValidationPolicy) (*ValidationPolicy, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if policy.Reason == "" || len(policy.Reason) > 1000 {
+		return nil, fmt.Errorf("failed to store validation policy: %w: reason violates check constraint \"check_validation_policy_reason_length\"", ErrInvalidInput)
+	}
+	defer db.lock(tx)()
+
+	policy.UpdatedAt = now()
+	stored := cloneValidationPolicy(policy)
+	db.state.validationPolicies[policy.Namespace] = *stored
+	return cloneValidationPolicy(*stored), nil
+}
+
+// ListValidationPolicies retrieves all validation policies, ordered by namespace
+func (db *Memory) ListValidationPolicies(ctx context.Context, tx pgx.Tx) ([]*ValidationPolicy, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	policies := make([]*ValidationPolicy, 0, len(db.state.validationPolicies))
+	for _, namespace := range slices.Sorted(maps.Keys(db.state.validationPolicies)) {
+		policies = append(policies, cloneValidationPolicy(db.state.validationPolicies[namespace]))
+	}
+	return policies, nil
+}
+
+// DeleteValidationPolicy removes the validation policy of a namespace
+func (db *Memory) DeleteValidationPolicy(ctx context.Context, tx pgx.Tx, namespace string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	defer db.lock(tx)()
+
+	if _, exists := db.state.validationPolicies[namespace]; !exists {
+		return ErrNotFound
+	}
+	delete(db.state.validationPolicies, namespace)
+	return nil
+}
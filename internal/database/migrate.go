@@ -162,6 +162,26 @@ func (m *Migrator) Migrate(ctx context.Context) error {
 	return nil
 }
 
+// Check returns an error if any migration has not been applied, without changing the database. Read-only
+// registries use it against follower databases, which get their schema from the primary.
+func (m *Migrator) Check(ctx context.Context) error {
+	applied, err := m.getAppliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	migrations, err := m.loadMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			return fmt.Errorf("migration %d (%s) has not been applied; upgrade the primary registry first", migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
 // applyMigration applies a single migration in a transaction
 func (m *Migrator) applyMigration(ctx context.Context, migration Migration) error {
 	tx, err := m.conn.Begin(ctx)
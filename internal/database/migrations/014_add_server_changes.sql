@@ -0,0 +1,49 @@
+-- Add an append-only changes feed for server versions and checkpoints for replicating from other registries
+-- Every insert or update of a servers row records a change with a monotonically increasing sequence number
+
+BEGIN;
+
+CREATE TABLE server_changes (
+    seq BIGSERIAL PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    change_type VARCHAR(20) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_change_type CHECK (change_type IN ('created', 'updated'))
+);
+
+CREATE INDEX idx_server_changes_server ON server_changes (server_name, version);
+
+-- Backfill existing server versions so the feed starts from a complete snapshot
+INSERT INTO server_changes (server_name, version, change_type, changed_at)
+SELECT server_name, version, 'created', published_at
+FROM servers
+ORDER BY published_at, server_name, version;
+
+CREATE OR REPLACE FUNCTION record_server_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO server_changes (server_name, version, change_type)
+        VALUES (NEW.server_name, NEW.version, 'created');
+    ELSE
+        INSERT INTO server_changes (server_name, version, change_type)
+        VALUES (NEW.server_name, NEW.version, 'updated');
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_record_server_change
+    AFTER INSERT OR UPDATE ON servers
+    FOR EACH ROW
+    EXECUTE FUNCTION record_server_change();
+
+-- Importer checkpoints track the last change sequence applied from each remote registry
+CREATE TABLE import_checkpoints (
+    source VARCHAR(2048) PRIMARY KEY,
+    last_seq BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- Add persisted maintenance mode state
+-- A single row holds whether writes are currently rejected, so the setting survives restarts and is shared by all replicas
+
+BEGIN;
+
+CREATE TABLE maintenance_mode (
+    id INTEGER PRIMARY KEY DEFAULT 1,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    message TEXT,
+    retry_after_seconds INTEGER NOT NULL DEFAULT 300,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_single_row CHECK (id = 1),
+    CONSTRAINT check_retry_after_positive CHECK (retry_after_seconds > 0),
+    CONSTRAINT check_maintenance_message_length CHECK (length(message) <= 500)
+);
+
+INSERT INTO maintenance_mode (id, enabled) VALUES (1, false);
+
+COMMIT;
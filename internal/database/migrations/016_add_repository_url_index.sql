@@ -0,0 +1,10 @@
+-- Index normalized repository URLs for ?repo= lookups and duplicate detection
+-- URLs are compared case-insensitively, ignoring trailing slashes and a trailing .git suffix
+
+BEGIN;
+
+CREATE INDEX idx_servers_repository_url ON servers (
+    lower(regexp_replace(rtrim(value->'repository'->>'url', '/'), '\.git$', ''))
+);
+
+COMMIT;
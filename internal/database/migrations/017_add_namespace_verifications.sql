@@ -0,0 +1,32 @@
+-- Add namespace verification requests for admin review of DNS and HTTP domain authentication
+-- Each request keeps the evidence gathered at login and on re-checks, and the admin's decision
+
+BEGIN;
+
+CREATE TABLE namespace_verifications (
+    id BIGSERIAL PRIMARY KEY,
+    domain VARCHAR(255) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    evidence JSONB NOT NULL DEFAULT '[]'::jsonb,
+    review_notes TEXT,
+    reviewed_by VARCHAR(255),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_namespace_verification_method CHECK (method IN ('dns', 'http')),
+    CONSTRAINT check_namespace_verification_status CHECK (status IN ('pending', 'approved', 'rejected')),
+    CONSTRAINT check_review_notes_length CHECK (length(review_notes) <= 1000)
+);
+
+-- At most one open request per domain and method; repeated logins add evidence to it
+CREATE UNIQUE INDEX idx_namespace_verifications_pending
+    ON namespace_verifications (domain, method) WHERE status = 'pending';
+
+CREATE INDEX idx_namespace_verifications_domain_reviewed
+    ON namespace_verifications (domain, reviewed_at DESC) WHERE status != 'pending';
+
+CREATE INDEX idx_namespace_verifications_status_created
+    ON namespace_verifications (status, created_at);
+
+COMMIT;
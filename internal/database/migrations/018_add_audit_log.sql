@@ -0,0 +1,21 @@
+-- Add an audit log of writes performed through the API, for compliance reporting
+-- Entries are append-only; the retention job deletes the oldest ones when configured
+
+BEGIN;
+
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    namespace VARCHAR(255) NOT NULL DEFAULT '',
+    resource TEXT NOT NULL DEFAULT '',
+    details JSONB NOT NULL DEFAULT '{}'::jsonb,
+    CONSTRAINT check_audit_action_not_empty CHECK (action != '')
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
+CREATE INDEX idx_audit_log_actor ON audit_log (actor, id);
+CREATE INDEX idx_audit_log_namespace ON audit_log (namespace, id) WHERE namespace != '';
+
+COMMIT;
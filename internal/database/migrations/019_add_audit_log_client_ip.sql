@@ -0,0 +1,7 @@
+-- Record the client address of audited requests, resolved through the configured trusted proxies
+
+BEGIN;
+
+ALTER TABLE audit_log ADD COLUMN client_ip VARCHAR(45) NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,32 @@
+-- Record what each package resolved to in its upstream registry when a server version was published,
+-- so consumers can detect artifacts republished under the same version
+-- Rows are insert-only: a record is never overwritten, even when the server version is edited
+
+BEGIN;
+
+CREATE TABLE package_provenance (
+    id BIGSERIAL PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    registry_type VARCHAR(50) NOT NULL,
+    identifier TEXT NOT NULL,
+    package_version VARCHAR(255) NOT NULL DEFAULT '',
+    digest TEXT NOT NULL DEFAULT '',
+    files JSONB NOT NULL DEFAULT '[]'::jsonb,
+    upstream_published_at TIMESTAMP WITH TIME ZONE,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON DELETE CASCADE,
+    CONSTRAINT uq_package_provenance UNIQUE (server_name, version, registry_type, identifier, package_version)
+);
+
+CREATE FUNCTION reject_package_provenance_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'package provenance records are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_package_provenance_immutable
+    BEFORE UPDATE ON package_provenance
+    FOR EACH ROW EXECUTE FUNCTION reject_package_provenance_update();
+
+COMMIT;
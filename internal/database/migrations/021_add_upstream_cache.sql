@@ -0,0 +1,17 @@
+-- Cache responses from upstream package registries (npm, PyPI, NuGet, OCI) shared by every replica,
+-- so bursts of publishes and re-validation don't hit upstream rate limits
+-- The table is unlogged: losing the cache after a crash only costs extra upstream requests
+
+BEGIN;
+
+CREATE UNLOGGED TABLE upstream_cache (
+    key TEXT PRIMARY KEY,
+    status_code INTEGER NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}'::jsonb,
+    body BYTEA NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_upstream_cache_expires_at ON upstream_cache (expires_at);
+
+COMMIT;
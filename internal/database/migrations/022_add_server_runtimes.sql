@@ -0,0 +1,25 @@
+-- Store the runtimes each server version needs to run locally, for ?runtime= filtering
+-- New rows are written by the registry from the package types or the _meta runtimes override; existing
+-- rows are derived from their package types, since the override did not exist before this migration
+
+BEGIN;
+
+ALTER TABLE servers ADD COLUMN runtimes TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE servers
+SET runtimes = ARRAY(
+    SELECT DISTINCT CASE pkg->>'registryType'
+        WHEN 'npm' THEN 'node'
+        WHEN 'pypi' THEN 'python'
+        WHEN 'oci' THEN 'docker'
+        WHEN 'nuget' THEN 'dotnet'
+        WHEN 'mcpb' THEN 'binary'
+    END
+    FROM jsonb_array_elements(COALESCE(value->'packages', '[]'::jsonb)) AS pkg
+    WHERE pkg->>'registryType' IN ('npm', 'pypi', 'oci', 'nuget', 'mcpb')
+)
+WHERE jsonb_typeof(value->'packages') = 'array';
+
+CREATE INDEX idx_servers_runtimes ON servers USING GIN (runtimes);
+
+COMMIT;
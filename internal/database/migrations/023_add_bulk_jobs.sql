@@ -0,0 +1,28 @@
+-- Queue of admin bulk operations, such as status changes across a namespace, executed in the background
+-- by the instance holding the bulk-jobs lock
+
+BEGIN;
+
+CREATE TABLE bulk_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}'::jsonb,
+    state VARCHAR(20) NOT NULL DEFAULT 'queued',
+    total INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    failures JSONB NOT NULL DEFAULT '[]'::jsonb,
+    error TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_bulk_job_kind CHECK (kind IN ('status', 'revalidate', 'transfer')),
+    CONSTRAINT check_bulk_job_state CHECK (state IN ('queued', 'running', 'succeeded', 'failed'))
+);
+
+-- Workers claim the oldest unfinished job
+CREATE INDEX idx_bulk_jobs_unfinished ON bulk_jobs (id) WHERE state IN ('queued', 'running');
+
+COMMIT;
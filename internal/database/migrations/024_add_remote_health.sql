@@ -0,0 +1,20 @@
+-- Record the result of the registry's latest MCP handshake with each remote endpoint,
+-- so responses can show whether a server's remotes are reachable
+
+BEGIN;
+
+CREATE TABLE remote_health (
+    url TEXT PRIMARY KEY,
+    status VARCHAR(20) NOT NULL,
+    protocol_version TEXT NOT NULL DEFAULT '',
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    checked_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_healthy_at TIMESTAMP WITH TIME ZONE,
+    failing_since TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT check_remote_health_status CHECK (status IN ('healthy', 'auth_required', 'unhealthy'))
+);
+
+CREATE INDEX idx_remote_health_checked_at ON remote_health (checked_at);
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Let admins exempt servers from name and description screening, for example an official
+-- server whose description legitimately uses a reserved term
+
+BEGIN;
+
+CREATE TABLE screening_exceptions (
+    server_name VARCHAR(255) PRIMARY KEY,
+    reason TEXT NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_screening_exception_reason_length CHECK (length(reason) BETWEEN 1 AND 1000)
+);
+
+COMMIT;
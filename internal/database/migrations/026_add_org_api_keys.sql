@@ -0,0 +1,25 @@
+-- Add organization API keys, which publishing automation exchanges for registry tokens without
+-- depending on the personal credentials of the employee who set it up.
+-- Only a SHA-256 hash of each key is stored.
+
+BEGIN;
+
+CREATE TABLE org_api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    organization VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    namespaces TEXT[] NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    key_prefix VARCHAR(32) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    rotated_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT check_org_api_key_namespaces CHECK (cardinality(namespaces) > 0),
+    CONSTRAINT check_org_api_key_name CHECK (length(name) > 0)
+);
+
+CREATE UNIQUE INDEX idx_org_api_keys_key_hash ON org_api_keys (key_hash);
+CREATE UNIQUE INDEX idx_org_api_keys_organization_name ON org_api_keys (organization, name);
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Widen the audit log client address so it can hold values encrypted at rest
+
+BEGIN;
+
+ALTER TABLE audit_log ALTER COLUMN client_ip TYPE TEXT;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Index latest server names by trigram, so the suggest endpoint's substring and word prefix matching
+-- stays fast as the registry grows. The index only covers latest versions, which are all it searches.
+
+BEGIN;
+
+CREATE INDEX idx_servers_name_trgm ON servers USING GIN (server_name gin_trgm_ops) WHERE is_latest;
+
+COMMIT;
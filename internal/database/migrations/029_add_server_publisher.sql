@@ -0,0 +1,8 @@
+-- Record the identity each server version was published with, as proven by the publisher's registry token,
+-- so clients can show who published a server. Versions published before this migration have no publisher.
+
+BEGIN;
+
+ALTER TABLE servers ADD COLUMN publisher JSONB;
+
+COMMIT;
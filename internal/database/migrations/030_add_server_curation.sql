@@ -0,0 +1,21 @@
+-- Let registry operators label servers as featured, official (vendor-verified) or community-maintained,
+-- so clients can build curated storefront sections. Labels apply to every version of a server.
+
+BEGIN;
+
+CREATE TABLE server_curation (
+    server_name VARCHAR(255) PRIMARY KEY,
+    labels TEXT[] NOT NULL,
+    position INTEGER,
+    note TEXT NOT NULL DEFAULT '',
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_server_curation_labels CHECK (cardinality(labels) > 0 AND labels <@ ARRAY['featured', 'official', 'community']::TEXT[]),
+    CONSTRAINT check_server_curation_maintainer CHECK (NOT labels @> ARRAY['official', 'community']::TEXT[]),
+    CONSTRAINT check_server_curation_position CHECK (position IS NULL OR position >= 0),
+    CONSTRAINT check_server_curation_note_length CHECK (length(note) <= 1000)
+);
+
+CREATE INDEX idx_server_curation_labels ON server_curation USING GIN (labels);
+
+COMMIT;
@@ -0,0 +1,28 @@
+-- Notify listening replicas when a server change is recorded or maintenance mode changes, so they can push
+-- changes to subscribers and refresh cached state without polling. PostgreSQL sends notifications on commit.
+
+BEGIN;
+
+CREATE OR REPLACE FUNCTION notify_registry_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'server_changes' THEN
+        PERFORM pg_notify('registry_events', json_build_object('type', 'change', 'seq', NEW.seq)::text);
+    ELSE
+        PERFORM pg_notify('registry_events', json_build_object('type', 'maintenance')::text);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_notify_server_change
+    AFTER INSERT ON server_changes
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_registry_event();
+
+CREATE TRIGGER trg_notify_maintenance
+    AFTER INSERT OR UPDATE ON maintenance_mode
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_registry_event();
+
+COMMIT;
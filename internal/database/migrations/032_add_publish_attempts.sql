@@ -0,0 +1,23 @@
+-- Record publish attempts, successful or not, so publishers can see why their recent publishes failed
+-- Attempts are deleted by the publish history retention job once they are older than the retention period
+
+BEGIN;
+
+CREATE TABLE publish_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    attempted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    identity VARCHAR(255) NOT NULL,
+    server_name VARCHAR(255) NOT NULL DEFAULT '',
+    version VARCHAR(255) NOT NULL DEFAULT '',
+    status INTEGER NOT NULL,
+    error_code VARCHAR(100) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    validation_errors JSONB NOT NULL DEFAULT '[]'::jsonb,
+    validation_warnings JSONB NOT NULL DEFAULT '[]'::jsonb,
+    CONSTRAINT check_publish_attempt_identity_not_empty CHECK (identity != '')
+);
+
+CREATE INDEX idx_publish_attempts_identity ON publish_attempts (identity, id);
+CREATE INDEX idx_publish_attempts_attempted_at ON publish_attempts (attempted_at);
+
+COMMIT;
@@ -0,0 +1,22 @@
+-- Webhook subscriptions registered by admins. The instance holding the webhook-delivery lock posts each
+-- server change a subscription selects to its URL, in the subscription's payload format, and records how
+-- far through the changes feed it got.
+
+BEGIN;
+
+CREATE TABLE webhook_subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    namespaces TEXT[] NOT NULL DEFAULT '{}',
+    format VARCHAR(20) NOT NULL DEFAULT 'full',
+    last_seq BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_webhook_subscription_format CHECK (format IN ('full', 'minimal', 'cloudevents'))
+);
+
+COMMIT;
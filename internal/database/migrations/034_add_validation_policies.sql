@@ -0,0 +1,17 @@
+-- Let admins loosen or tighten publish validation for a namespace and the namespaces under it, for example
+-- requiring package hashes for com.bank or accepting http:// remotes for io.sandbox
+
+BEGIN;
+
+CREATE TABLE validation_policies (
+    namespace VARCHAR(255) PRIMARY KEY,
+    allow_http_remotes BOOLEAN NOT NULL DEFAULT FALSE,
+    require_package_hashes BOOLEAN NOT NULL DEFAULT FALSE,
+    blocking_lint_rules TEXT[] NOT NULL DEFAULT '{}',
+    reason TEXT NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_validation_policy_reason_length CHECK (length(reason) BETWEEN 1 AND 1000)
+);
+
+COMMIT;
@@ -0,0 +1,54 @@
+-- Record the state of the server version with each change, so reads can reconstruct what the registry served
+-- at a past time. Earlier states were not kept, so changes recorded before this migration hold the state of
+-- their version at the time of the migration.
+
+BEGIN;
+
+ALTER TABLE server_changes
+    ADD COLUMN status VARCHAR(50),
+    ADD COLUMN status_changed_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN status_message TEXT,
+    ADD COLUMN published_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN is_latest BOOLEAN,
+    ADD COLUMN value JSONB,
+    ADD COLUMN publisher JSONB,
+    ADD COLUMN runtimes TEXT[];
+
+UPDATE server_changes c
+SET status = s.status,
+    status_changed_at = s.status_changed_at,
+    status_message = s.status_message,
+    published_at = s.published_at,
+    updated_at = s.updated_at,
+    is_latest = s.is_latest,
+    value = s.value,
+    publisher = s.publisher,
+    runtimes = s.runtimes
+FROM servers s
+WHERE s.server_name = c.server_name AND s.version = c.version;
+
+CREATE OR REPLACE FUNCTION record_server_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    change_type VARCHAR(20) := 'created';
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        change_type := 'updated';
+    END IF;
+    INSERT INTO server_changes (
+        server_name, version, change_type,
+        status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher, runtimes
+    ) VALUES (
+        NEW.server_name, NEW.version, change_type,
+        NEW.status, NEW.status_changed_at, NEW.status_message, NEW.published_at, NEW.updated_at, NEW.is_latest,
+        NEW.value, NEW.publisher, NEW.runtimes
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Finds the most recent state of each version at a point in time
+CREATE INDEX idx_server_changes_history ON server_changes (server_name, version, seq DESC);
+
+COMMIT;
@@ -0,0 +1,27 @@
+-- Publishes accepted in asynchronous mode, whose slower checks run in the background before the server
+-- version is created, by the instance holding the pending-publishes lock
+
+BEGIN;
+
+CREATE TABLE pending_publishes (
+    id BIGSERIAL PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    value JSONB NOT NULL,
+    publisher JSONB,
+    submitted_by VARCHAR(255) NOT NULL,
+    state VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT check_pending_publish_state CHECK (state IN ('pending', 'active', 'rejected'))
+);
+
+-- A version can only be waiting on one publish at a time; the worker takes the oldest first
+CREATE UNIQUE INDEX idx_pending_publishes_version ON pending_publishes (server_name, version) WHERE state = 'pending';
+CREATE INDEX idx_pending_publishes_pending ON pending_publishes (id) WHERE state = 'pending';
+
+-- Finished publishes are pruned after a while
+CREATE INDEX idx_pending_publishes_finished_at ON pending_publishes (finished_at) WHERE state <> 'pending';
+
+COMMIT;
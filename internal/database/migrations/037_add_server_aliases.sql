@@ -0,0 +1,23 @@
+-- Names servers were renamed from. Detail fetches of an alias redirect to the server's current name until
+-- the alias expires, and each rename is announced in the changes feed with the server's previous name.
+
+BEGIN;
+
+CREATE TABLE server_aliases (
+    alias VARCHAR(255) PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT check_server_alias_differs CHECK (alias <> server_name)
+);
+
+-- Renaming a server again moves the aliases pointing at it
+CREATE INDEX idx_server_aliases_server_name ON server_aliases (server_name);
+
+ALTER TABLE server_changes ADD COLUMN previous_name VARCHAR(255);
+
+ALTER TABLE server_changes DROP CONSTRAINT check_change_type;
+ALTER TABLE server_changes ADD CONSTRAINT check_change_type CHECK (change_type IN ('created', 'updated', 'renamed'));
+
+COMMIT;
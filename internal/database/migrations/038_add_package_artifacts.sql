@@ -0,0 +1,24 @@
+-- Artifacts the registry mirrored into its own storage for each server version's packages, so air-gapped
+-- clients can download them through the registry. Files are stored by SHA-256, so versions sharing an
+-- artifact share one stored copy.
+
+BEGIN;
+
+CREATE TABLE package_artifacts (
+    id BIGSERIAL PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    registry_type VARCHAR(50) NOT NULL,
+    identifier TEXT NOT NULL,
+    filename TEXT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    size BIGINT NOT NULL,
+    mirrored_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON DELETE CASCADE,
+    CONSTRAINT uq_package_artifacts UNIQUE (server_name, version, registry_type, identifier, filename)
+);
+
+-- Downloads look artifacts up by hash
+CREATE INDEX idx_package_artifacts_sha256 ON package_artifacts (sha256);
+
+COMMIT;
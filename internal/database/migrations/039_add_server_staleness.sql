@@ -0,0 +1,18 @@
+-- Record servers the stale-entry sweeper found untouched with their repository archived or deleted, or
+-- their packages unpublished. Once the grace period after notifying the maintainers passes, the server is
+-- marked unmaintained. A row applies to every version of the server and is removed once it is active again.
+
+BEGIN;
+
+CREATE TABLE server_staleness (
+    server_name VARCHAR(255) PRIMARY KEY,
+    reasons TEXT[] NOT NULL,
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    notified_at TIMESTAMP WITH TIME ZONE,
+    unmaintained_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT check_server_staleness_reasons CHECK (cardinality(reasons) > 0 AND reasons <@ ARRAY['repository-archived', 'repository-deleted', 'package-unpublished']::TEXT[])
+);
+
+CREATE INDEX idx_server_staleness_unmaintained ON server_staleness (server_name) WHERE unmaintained_at IS NOT NULL;
+
+COMMIT;
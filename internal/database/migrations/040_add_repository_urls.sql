@@ -0,0 +1,36 @@
+-- Map each repository URL servers link to its canonical form, so entries whose URLs differ only in
+-- formatting (scheme, case, www., trailing slashes, .git, SSH clone URLs) or that link a repository by
+-- a name it was renamed from are recognized as linking the same repository
+
+BEGIN;
+
+CREATE TABLE repository_urls (
+    raw_url TEXT PRIMARY KEY,
+    canonical_url TEXT NOT NULL,
+    resolved_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_repository_urls_canonical_url ON repository_urls (canonical_url);
+CREATE INDEX idx_servers_raw_repository_url ON servers ((value->'repository'->>'url'));
+
+-- Existing URLs get the same canonical form publishing gives them, without following redirects
+INSERT INTO repository_urls (raw_url, canonical_url)
+SELECT url, lower(
+    regexp_replace(
+        regexp_replace(
+            regexp_replace(
+                regexp_replace(
+                    regexp_replace(rtrim(split_part(split_part(trim(url), '#', 1), '?', 1), '/'), '\.git$', ''),
+                    '^[A-Za-z0-9._-]+@([A-Za-z0-9.-]+):/?', 'https://\1/'
+                ),
+                '^(ssh|git|git\+ssh)://([^@/]*@)?(www\.)?([^/:]+)(:[0-9]+)?', 'https://\4', 'i'
+            ),
+            '^(https?|git\+https)://([^@/]*@)?(www\.)?([^/:]+)(:(80|443)(?![0-9]))?', 'https://\4', 'i'
+        ),
+        '/+$', ''
+    )
+)
+FROM (SELECT DISTINCT value->'repository'->>'url' AS url FROM servers) urls
+WHERE COALESCE(url, '') != '';
+
+COMMIT;
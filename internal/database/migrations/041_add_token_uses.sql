@@ -0,0 +1,14 @@
+-- Record the identity tokens exchanged for registry tokens until they expire, so a token exchanged
+-- again within its validity window can be rejected as a replay. Keys are the token's issuer and jti,
+-- or a hash of the token when it has no jti.
+
+BEGIN;
+
+CREATE TABLE token_uses (
+    key TEXT PRIMARY KEY,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_token_uses_expires_at ON token_uses (expires_at);
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- Collections of servers curated by registry users, such as "Best database MCP servers". Items are
+-- kept in the curator's order as a JSON array of server names and notes, and the owner is the
+-- authentication method and subject of the curator.
+
+BEGIN;
+
+CREATE TABLE collections (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    items JSONB NOT NULL DEFAULT '[]',
+    owner VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_collections_owner ON collections (owner, id);
+
+COMMIT;
@@ -0,0 +1,21 @@
+-- Previews of server.json published for review, typically from a pull request's CI. They are kept
+-- apart from servers so they are never listed, searched or sent in the changes feed, are found only
+-- by their random ID, and are deleted by the retention job once they expire.
+
+BEGIN;
+
+CREATE TABLE previews (
+    id VARCHAR(64) PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    value JSONB NOT NULL,
+    publisher JSONB,
+    submitted_by VARCHAR(255) NOT NULL,
+    pull_request VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_previews_expires_at ON previews (expires_at);
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Hourly counters of registry activity, such as publishes, kept so administrators can chart registry
+-- growth from the API without running a separate metrics stack. Rows are only ever incremented.
+
+BEGIN;
+
+CREATE TABLE metric_counts (
+    metric VARCHAR(64) NOT NULL,
+    bucket TIMESTAMP WITH TIME ZONE NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (metric, bucket)
+);
+
+COMMIT;
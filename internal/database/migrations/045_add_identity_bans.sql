@@ -0,0 +1,18 @@
+-- Identities admins banned from publishing: GitHub accounts, OIDC subjects and namespaces. Bans
+-- without an expiry last until an admin lifts them.
+
+BEGIN;
+
+CREATE TABLE identity_bans (
+    kind VARCHAR(20) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (kind, value),
+    CONSTRAINT check_identity_ban_kind CHECK (kind IN ('github', 'oidc', 'namespace')),
+    CONSTRAINT check_identity_ban_reason_length CHECK (length(reason) BETWEEN 1 AND 1000)
+);
+
+COMMIT;
@@ -0,0 +1,41 @@
+-- Content-addressed store of published server.json documents. Each distinct canonical document is stored once
+-- under the SHA-256 of its bytes, and server versions point at theirs, so documents can be fetched by digest
+-- and the servers table checked against them for tampering. Versions published before this migration get
+-- their documents from `registry documents backfill`.
+
+BEGIN;
+
+CREATE TABLE server_documents (
+    digest VARCHAR(71) PRIMARY KEY,
+    document BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_server_document_digest CHECK (digest = 'sha256:' || encode(sha256(document), 'hex'))
+);
+
+ALTER TABLE servers ADD COLUMN document_digest VARCHAR(71) REFERENCES server_documents (digest);
+
+-- Pointing a version at its document does not change what the registry serves, so it is not a change
+CREATE OR REPLACE FUNCTION record_server_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    change_type VARCHAR(20) := 'created';
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF to_jsonb(OLD) - 'document_digest' = to_jsonb(NEW) - 'document_digest' THEN
+            RETURN NEW;
+        END IF;
+        change_type := 'updated';
+    END IF;
+    INSERT INTO server_changes (
+        server_name, version, change_type,
+        status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher, runtimes
+    ) VALUES (
+        NEW.server_name, NEW.version, change_type,
+        NEW.status, NEW.status_changed_at, NEW.status_message, NEW.published_at, NEW.updated_at, NEW.is_latest,
+        NEW.value, NEW.publisher, NEW.runtimes
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
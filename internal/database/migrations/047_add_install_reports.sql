@@ -0,0 +1,23 @@
+-- Reports from MCP clients, sent with their users' consent, of whether installing and running a server version
+-- worked, aggregated into the share of reporters it works for. Reporters are keyed hashes of client addresses,
+-- so each counts once per version and addresses are never stored.
+
+BEGIN;
+
+CREATE TABLE install_reports (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    reporter CHAR(64) NOT NULL,
+    outcome VARCHAR(20) NOT NULL,
+    stage VARCHAR(20) NOT NULL,
+    client VARCHAR(64) NOT NULL DEFAULT '',
+    reported_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (server_name, version, reporter),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON DELETE CASCADE,
+    CONSTRAINT check_install_report_outcome CHECK (outcome IN ('success', 'failure')),
+    CONSTRAINT check_install_report_stage CHECK (stage IN ('install', 'runtime'))
+);
+
+CREATE INDEX idx_install_reports_reported_at ON install_reports (reported_at);
+
+COMMIT;
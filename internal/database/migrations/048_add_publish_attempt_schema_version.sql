@@ -0,0 +1,8 @@
+-- Record the schema version each publish attempt's server.json was written for, since documents for earlier
+-- versions are now upgraded to the current one. Attempts made before this migration have none.
+
+BEGIN;
+
+ALTER TABLE publish_attempts ADD COLUMN schema_version VARCHAR(100) NOT NULL DEFAULT '';
+
+COMMIT;
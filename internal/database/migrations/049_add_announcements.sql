@@ -0,0 +1,19 @@
+-- Announcements registry operators show to every client, such as maintenance windows or policy changes.
+-- Each is shown from starts_at until ends_at; a missing bound leaves that side of the schedule open.
+
+BEGIN;
+
+CREATE TABLE announcements (
+    id BIGSERIAL PRIMARY KEY,
+    message TEXT NOT NULL,
+    severity VARCHAR(20) NOT NULL DEFAULT 'info',
+    starts_at TIMESTAMP WITH TIME ZONE,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_announcement_severity CHECK (severity IN ('info', 'warning', 'critical')),
+    CONSTRAINT check_announcement_schedule CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at)
+);
+
+COMMIT;
@@ -0,0 +1,113 @@
+-- Partition the audit log and the changes feed by month, so writes only touch the current month's indexes and
+-- retention drops whole partitions instead of deleting rows one by one. Partitions are named after their table
+-- and month (audit_log_2025_07) and created ahead of time by the partition maintenance job; rows outside every
+-- monthly partition land in a default partition and are moved out when their month's partition is created.
+
+BEGIN;
+
+-- create_monthly_partitions creates the missing monthly partitions of parent, partitioned on key_column, for
+-- every month from the month of from_time through the month of through_time, in UTC. It returns how many it
+-- created.
+CREATE OR REPLACE FUNCTION create_monthly_partitions(parent TEXT, key_column TEXT, from_time TIMESTAMPTZ, through_time TIMESTAMPTZ)
+RETURNS INTEGER AS $$
+DECLARE
+    partition_month TIMESTAMP := date_trunc('month', from_time AT TIME ZONE 'UTC');
+    lower_bound TIMESTAMPTZ;
+    upper_bound TIMESTAMPTZ;
+    partition_name TEXT;
+    created INTEGER := 0;
+BEGIN
+    WHILE partition_month <= date_trunc('month', through_time AT TIME ZONE 'UTC') LOOP
+        partition_name := parent || '_' || to_char(partition_month, 'YYYY_MM');
+        lower_bound := partition_month AT TIME ZONE 'UTC';
+        upper_bound := (partition_month + INTERVAL '1 month') AT TIME ZONE 'UTC';
+        IF to_regclass(partition_name) IS NULL THEN
+            -- A new partition cannot be attached while the default partition holds rows belonging to it
+            EXECUTE format('CREATE TEMPORARY TABLE moved_partition_rows ON COMMIT DROP AS SELECT * FROM %I WHERE %I >= %L AND %I < %L',
+                parent || '_default', key_column, lower_bound, key_column, upper_bound);
+            EXECUTE format('DELETE FROM %I WHERE %I >= %L AND %I < %L',
+                parent || '_default', key_column, lower_bound, key_column, upper_bound);
+            EXECUTE format('CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%L) TO (%L)',
+                partition_name, parent, lower_bound, upper_bound);
+            EXECUTE format('INSERT INTO %I SELECT * FROM moved_partition_rows', partition_name);
+            DROP TABLE moved_partition_rows;
+            created := created + 1;
+        END IF;
+        partition_month := partition_month + INTERVAL '1 month';
+    END LOOP;
+    RETURN created;
+END;
+$$ LANGUAGE plpgsql;
+
+-- drop_monthly_partitions drops the monthly partitions of parent holding only rows from before the given
+-- cutoff, and returns how many rows they held. The default partition is never dropped.
+CREATE OR REPLACE FUNCTION drop_monthly_partitions(parent TEXT, cutoff TIMESTAMPTZ)
+RETURNS BIGINT AS $$
+DECLARE
+    partition_name TEXT;
+    partition_rows BIGINT;
+    dropped BIGINT := 0;
+BEGIN
+    FOR partition_name IN
+        SELECT c.relname
+        FROM pg_inherits i
+        JOIN pg_class c ON c.oid = i.inhrelid
+        WHERE i.inhparent = parent::regclass
+          AND c.relname ~ ('^' || parent || '_[0-9]{4}_[0-9]{2}$')
+        ORDER BY c.relname
+    LOOP
+        -- The partition for a month ends where the next month starts
+        IF (to_date(right(partition_name, 7), 'YYYY_MM') + INTERVAL '1 month') AT TIME ZONE 'UTC' > cutoff THEN
+            EXIT;
+        END IF;
+        EXECUTE format('SELECT count(*) FROM %I', partition_name) INTO partition_rows;
+        EXECUTE format('DROP TABLE %I', partition_name);
+        dropped := dropped + partition_rows;
+    END LOOP;
+    RETURN dropped;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Audit log: the primary key of a partitioned table must include the partition key
+ALTER TABLE audit_log RENAME TO audit_log_unpartitioned;
+ALTER SEQUENCE audit_log_id_seq OWNED BY NONE;
+
+CREATE TABLE audit_log (LIKE audit_log_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS)
+    PARTITION BY RANGE (created_at);
+CREATE TABLE audit_log_default PARTITION OF audit_log DEFAULT;
+SELECT create_monthly_partitions('audit_log', 'created_at',
+    COALESCE((SELECT MIN(created_at) FROM audit_log_unpartitioned), NOW()), NOW() + INTERVAL '3 months');
+
+INSERT INTO audit_log SELECT * FROM audit_log_unpartitioned;
+DROP TABLE audit_log_unpartitioned;
+ALTER SEQUENCE audit_log_id_seq OWNED BY audit_log.id;
+
+ALTER TABLE audit_log ADD PRIMARY KEY (id, created_at);
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
+CREATE INDEX idx_audit_log_actor ON audit_log (actor, id);
+CREATE INDEX idx_audit_log_namespace ON audit_log (namespace, id) WHERE namespace != '';
+
+-- Changes feed: rows are copied without firing the notification trigger, which is recreated afterwards
+ALTER TABLE server_changes RENAME TO server_changes_unpartitioned;
+ALTER SEQUENCE server_changes_seq_seq OWNED BY NONE;
+
+CREATE TABLE server_changes (LIKE server_changes_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS)
+    PARTITION BY RANGE (changed_at);
+CREATE TABLE server_changes_default PARTITION OF server_changes DEFAULT;
+SELECT create_monthly_partitions('server_changes', 'changed_at',
+    COALESCE((SELECT MIN(changed_at) FROM server_changes_unpartitioned), NOW()), NOW() + INTERVAL '3 months');
+
+INSERT INTO server_changes SELECT * FROM server_changes_unpartitioned;
+DROP TABLE server_changes_unpartitioned;
+ALTER SEQUENCE server_changes_seq_seq OWNED BY server_changes.seq;
+
+ALTER TABLE server_changes ADD PRIMARY KEY (seq, changed_at);
+CREATE INDEX idx_server_changes_server ON server_changes (server_name, version);
+CREATE INDEX idx_server_changes_history ON server_changes (server_name, version, seq DESC);
+
+CREATE TRIGGER trg_notify_server_change
+    AFTER INSERT ON server_changes
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_registry_event();
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- Applications registered through OAuth dynamic client registration (RFC 7591), such as MCP clients that
+-- log people in through the registry with the authorization code flow and PKCE. Clients are public, so
+-- no secret is stored.
+
+BEGIN;
+
+CREATE TABLE oauth_clients (
+    client_id VARCHAR(64) PRIMARY KEY,
+    client_name VARCHAR(200) NOT NULL DEFAULT '',
+    redirect_uris TEXT[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_oauth_client_redirect_uris CHECK (cardinality(redirect_uris) > 0)
+);
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- Index the tools servers expose, as found by the tool indexer in the MCPB manifest of a server's repository or
+-- listed by one of its remotes, so servers can be searched by what they can do. A row applies to every version
+-- of the server. search_text holds the lowercased tool names and descriptions that keywords are matched against.
+
+BEGIN;
+
+CREATE TABLE server_tools (
+    server_name VARCHAR(255) PRIMARY KEY,
+    version VARCHAR(255) NOT NULL,
+    source TEXT NOT NULL,
+    tools JSONB NOT NULL,
+    search_text TEXT NOT NULL,
+    indexed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_server_tools_source CHECK (source IN ('manifest', 'remote'))
+);
+
+CREATE INDEX idx_server_tools_search_text ON server_tools USING GIN (search_text gin_trgm_ops);
+
+COMMIT;
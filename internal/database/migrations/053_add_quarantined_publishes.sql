@@ -0,0 +1,16 @@
+-- Keep the server.json and full validation output of rejected publish attempts, so publishers can see every
+-- detail of why a publish was rejected when CI logs cut the error short. Documents are deleted by the publish
+-- quarantine retention job, and with their attempt by the publish history retention job.
+
+BEGIN;
+
+CREATE TABLE quarantined_publishes (
+    attempt_id BIGINT PRIMARY KEY REFERENCES publish_attempts (id) ON DELETE CASCADE,
+    quarantined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    server_json JSONB NOT NULL,
+    validation_issues JSONB NOT NULL DEFAULT '[]'::jsonb
+);
+
+CREATE INDEX idx_quarantined_publishes_quarantined_at ON quarantined_publishes (quarantined_at);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Record deletions of server versions, such as those of the version retention job, in the changes feed with
+-- the state the version was deleted in, instead of dropping the version's feed entries. Replicas and reads
+-- of a past time can then tell a version was removed.
+
+BEGIN;
+
+ALTER TABLE server_changes DROP CONSTRAINT check_change_type;
+ALTER TABLE server_changes ADD CONSTRAINT check_change_type CHECK (change_type IN ('created', 'updated', 'renamed', 'deleted'));
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Keep the server.json of asynchronous publishes as it was submitted, so the version's stored document holds
+-- the exact bytes that were published once the publish completes.
+
+BEGIN;
+
+ALTER TABLE pending_publishes ADD COLUMN document BYTEA;
+
+COMMIT;
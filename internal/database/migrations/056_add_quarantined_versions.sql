@@ -0,0 +1,25 @@
+-- Quarantine the servers of banned identities in a status of their own, so lifting or expiring the ban can
+-- restore them. Each quarantined version keeps the status it had before, one record per ban covering it, and
+-- is restored once no ban still holds it. Bans are looked up by the lowercased identities of token claims.
+
+BEGIN;
+
+ALTER TABLE servers DROP CONSTRAINT check_status_valid;
+ALTER TABLE servers ADD CONSTRAINT check_status_valid CHECK (status IN ('active', 'deprecated', 'deleted', 'quarantined'));
+
+CREATE TABLE quarantined_versions (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    ban_kind VARCHAR(20) NOT NULL,
+    ban_value VARCHAR(255) NOT NULL,
+    prior_status VARCHAR(50) NOT NULL,
+    prior_status_message TEXT,
+    quarantined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, version, ban_kind, ban_value),
+    CONSTRAINT check_quarantined_prior_status CHECK (prior_status IN ('active', 'deprecated'))
+);
+
+CREATE INDEX idx_quarantined_versions_ban ON quarantined_versions (ban_kind, ban_value);
+CREATE INDEX idx_identity_bans_lower_value ON identity_bans (kind, lower(value));
+
+COMMIT;
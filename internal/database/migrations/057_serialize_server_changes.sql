@@ -0,0 +1,23 @@
+-- Serialize writes to the changes feed so sequence numbers become visible in order. A transaction that drew a
+-- sequence number but committed after one with a higher number would otherwise be skipped by readers whose
+-- cursor already moved past it. Every insert into server_changes, whether from the servers trigger or written
+-- directly, first takes a transaction-level advisory lock, held until commit, so the next writer draws its
+-- sequence number only once the previous one committed or rolled back. The two-key form keeps the lock apart
+-- from the single-key publish locks taken per server name.
+
+BEGIN;
+
+CREATE OR REPLACE FUNCTION lock_server_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(2024, 14);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_lock_server_changes
+    BEFORE INSERT ON server_changes
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION lock_server_changes();
+
+COMMIT;
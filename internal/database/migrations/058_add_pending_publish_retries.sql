@@ -0,0 +1,13 @@
+-- Retry asynchronous publishes whose checks failed for a transient reason, such as an upstream registry or
+-- the database being unavailable, instead of rejecting them. Each publish counts the attempts that failed
+-- this way and waits until next_attempt_at before the worker picks it up again.
+
+BEGIN;
+
+ALTER TABLE pending_publishes ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pending_publishes ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+DROP INDEX idx_pending_publishes_pending;
+CREATE INDEX idx_pending_publishes_pending ON pending_publishes (next_attempt_at, id) WHERE state = 'pending';
+
+COMMIT;
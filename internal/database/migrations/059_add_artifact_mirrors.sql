@@ -0,0 +1,21 @@
+-- Server versions whose package artifacts are waiting to be mirrored. Publishes queue their version here in
+-- the publish transaction and return straight away; a background worker downloads the artifacts, retrying
+-- failed attempts after next_attempt_at.
+
+BEGIN;
+
+CREATE TABLE artifact_mirrors (
+    id BIGSERIAL PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON DELETE CASCADE,
+    CONSTRAINT uq_artifact_mirrors UNIQUE (server_name, version)
+);
+
+CREATE INDEX idx_artifact_mirrors_due ON artifact_mirrors (next_attempt_at, id);
+
+COMMIT;
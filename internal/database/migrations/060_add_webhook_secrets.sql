@@ -0,0 +1,9 @@
+-- Sign webhook deliveries with a secret generated for each subscription, so consumers can check a delivery
+-- came from the registry. The secret is encrypted at rest when encryption is configured. Subscriptions created
+-- before this migration have no secret, and their deliveries are unsigned until an admin rotates it.
+
+BEGIN;
+
+ALTER TABLE webhook_subscriptions ADD COLUMN secret TEXT NOT NULL DEFAULT '';
+
+COMMIT;
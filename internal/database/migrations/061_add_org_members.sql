@@ -0,0 +1,16 @@
+-- Add organization members: people an organization's owners let manage its API keys without being owners
+-- themselves. Owners are GitHub organization admins or the proven owners of the organization's domain.
+
+BEGIN;
+
+CREATE TABLE org_members (
+    id BIGSERIAL PRIMARY KEY,
+    organization VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    added_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_org_members_organization_subject ON org_members (organization, subject);
+
+COMMIT;
@@ -1,11 +1,14 @@
 package database
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,8 +16,11 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/modelcontextprotocol/registry/internal/repourl"
+	"github.com/modelcontextprotocol/registry/internal/versioning"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 )
 
 // PostgreSQL is an implementation of the Database interface using PostgreSQL
@@ -37,13 +43,60 @@ func (db *PostgreSQL) getExecutor(tx pgx.Tx) Executor {
 	return db.pool
 }
 
+// constraintViolation maps unique and check constraint violations to ErrAlreadyExists and ErrInvalidInput,
+// so callers can handle them the same way for every Database implementation
+func constraintViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505": // unique_violation
+			return fmt.Errorf("%w: %s", ErrAlreadyExists, pgErr.Message)
+		case "23514": // check_violation
+			return fmt.Errorf("%w: %s", ErrInvalidInput, pgErr.Message)
+		}
+	}
+	return err
+}
+
+// IsTransient reports whether err is a database failure that may not recur when retried: the connection
+// failed or timed out, the transaction hit a serialization failure or deadlock, or the server ran out of
+// resources or is shutting down
+func IsTransient(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Classes of connection exception, transaction rollback, insufficient resources and operator intervention
+		for _, class := range []string{"08", "40", "53", "57"} {
+			if strings.HasPrefix(pgErr.SQLState(), class) {
+				return true
+			}
+		}
+		return false
+	}
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr) || pgconn.SafeToRetry(err) || pgconn.Timeout(err)
+}
+
 // NewPostgreSQL creates a new instance of the PostgreSQL database
 func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, error) {
+	return newPostgreSQL(ctx, connectionURI, false)
+}
+
+// NewReadOnlyPostgreSQL connects to a PostgreSQL database, typically a follower of the primary registry's
+// database, for serving reads only. Migrations are checked rather than run, and every transaction is read-only,
+// so writes fail even if the database accepts them.
+func NewReadOnlyPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, error) {
+	return newPostgreSQL(ctx, connectionURI, true)
+}
+
+func newPostgreSQL(ctx context.Context, connectionURI string, readOnly bool) (*PostgreSQL, error) {
 	// Parse connection config for pool settings
 	config, err := pgxpool.ParseConfig(connectionURI)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse PostgreSQL config: %w", err)
 	}
+	if readOnly {
+		config.ConnConfig.RuntimeParams["default_transaction_read_only"] = "on"
+	}
 
 	// Configure pool for stability-focused defaults
 	config.MaxConns = 30                      // Handle good concurrent load
@@ -51,6 +104,9 @@ func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, erro
 	config.MaxConnIdleTime = 30 * time.Minute // Keep connections available for bursts
 	config.MaxConnLifetime = 2 * time.Hour    // Refresh connections regularly for stability
 
+	// Record statements for slow-request logging when the request context asks for it
+	config.ConnConfig.Tracer = queryTracer{}
+
 	// Create connection pool with configured settings
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -62,7 +118,7 @@ func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, erro
 		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
 	}
 
-	// Run migrations using a single connection from the pool
+	// Run or check migrations using a single connection from the pool
 	conn, err := pool.Acquire(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire connection for migrations: %w", err)
@@ -70,7 +126,11 @@ func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, erro
 	defer conn.Release()
 
 	migrator := NewMigrator(conn.Conn())
-	if err := migrator.Migrate(ctx); err != nil {
+	if readOnly {
+		if err := migrator.Check(ctx); err != nil {
+			return nil, fmt.Errorf("database schema is out of date: %w", err)
+		}
+	} else if err := migrator.Migrate(ctx); err != nil {
 		return nil, fmt.Errorf("failed to run database migrations: %w", err)
 	}
 
@@ -100,6 +160,21 @@ func buildFilterConditions(filter *ServerFilter, argIndex int) ([]string, []any,
 		args = append(args, *filter.RemoteURL)
 		argIndex++
 	}
+	if filter.RepositoryURL != nil {
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", canonicalRepositoryURL("value->'repository'->>'url'"), argIndex))
+		args = append(args, repourl.Normalize(*filter.RepositoryURL))
+		argIndex++
+	}
+	if filter.Runtime != nil {
+		conditions = append(conditions, fmt.Sprintf("runtimes @> ARRAY[$%d::text]", argIndex))
+		args = append(args, *filter.Runtime)
+		argIndex++
+	}
+	if filter.Namespace != nil {
+		conditions = append(conditions, fmt.Sprintf("starts_with(server_name, $%d)", argIndex))
+		args = append(args, *filter.Namespace+"/")
+		argIndex++
+	}
 	if filter.UpdatedSince != nil {
 		conditions = append(conditions, fmt.Sprintf("updated_at > $%d", argIndex))
 		args = append(args, *filter.UpdatedSince)
@@ -120,13 +195,62 @@ func buildFilterConditions(filter *ServerFilter, argIndex int) ([]string, []any,
 		args = append(args, *filter.IsLatest)
 		argIndex++
 	}
+	if filter.CurationLabel != nil {
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM server_curation c WHERE c.server_name = servers.server_name AND $%d = ANY(c.labels))", argIndex))
+		args = append(args, *filter.CurationLabel)
+		argIndex++
+	}
+	if filter.Unmaintained != nil {
+		unmaintained := "EXISTS (SELECT 1 FROM server_staleness st WHERE st.server_name = servers.server_name AND st.unmaintained_at IS NOT NULL)"
+		if !*filter.Unmaintained {
+			unmaintained = "NOT " + unmaintained
+		}
+		conditions = append(conditions, unmaintained)
+	}
+	if len(filter.ToolKeywords) > 0 {
+		patterns := make([]string, len(filter.ToolKeywords))
+		for i, keyword := range filter.ToolKeywords {
+			patterns[i] = "%" + escapeLike(strings.ToLower(keyword)) + "%"
+		}
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM server_tools t WHERE t.server_name = servers.server_name AND t.search_text LIKE ALL($%d))", argIndex))
+		args = append(args, patterns)
+		argIndex++
+	}
 	if filter.IncludeDeleted == nil || !*filter.IncludeDeleted {
-		conditions = append(conditions, "status != 'deleted'")
+		conditions = append(conditions, "status NOT IN ('deleted', 'quarantined')")
 	}
 
 	return conditions, args, argIndex
 }
 
+// canonicalRepositoryURL wraps a SQL expression of a repository URL in its canonical form recorded in
+// repository_urls. URLs recorded before canonicalization existed fall back to comparing case-insensitively,
+// ignoring trailing slashes and a .git suffix.
+func canonicalRepositoryURL(expr string) string {
+	return fmt.Sprintf(`COALESCE((SELECT r.canonical_url FROM repository_urls r WHERE r.raw_url = %[1]s), lower(regexp_replace(rtrim(%[1]s, '/'), '\.git$', '')))`, expr)
+}
+
+// serversSource returns the relation server queries read, named servers: the servers table, or with
+// filter.AsOf the state each server version had at that time, as recorded with its changes
+func serversSource(filter *ServerFilter, argIndex int) (string, []any, int) {
+	if filter == nil || filter.AsOf == nil {
+		return "servers", nil, argIndex
+	}
+	// Versions whose most recent change deleted them were no longer served
+	source := fmt.Sprintf(`(
+		SELECT server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher, runtimes
+		FROM (
+			SELECT DISTINCT ON (server_name, version)
+				change_type, server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher, runtimes
+			FROM server_changes
+			WHERE changed_at <= $%d AND value IS NOT NULL
+			ORDER BY server_name, version, seq DESC
+		) AS history
+		WHERE change_type <> 'deleted'
+	) AS servers`, argIndex)
+	return source, []any{*filter.AsOf}, argIndex + 1
+}
+
 // addCursorCondition adds pagination cursor condition to WHERE clause
 func addCursorCondition(cursor string, argIndex int) (string, []any, int) {
 	if cursor == "" {
@@ -163,9 +287,11 @@ func (db *PostgreSQL) ListServers(
 		return nil, "", ctx.Err()
 	}
 
+	source, args, argIndex := serversSource(filter, 1)
+
 	// Build WHERE clause conditions
-	argIndex := 1
-	whereConditions, args, argIndex := buildFilterConditions(filter, argIndex)
+	whereConditions, filterArgs, argIndex := buildFilterConditions(filter, argIndex)
+	args = append(args, filterArgs...)
 
 	// Add cursor pagination
 	cursorCondition, cursorArgs, argIndex := addCursorCondition(cursor, argIndex)
@@ -183,12 +309,12 @@ func (db *PostgreSQL) ListServers(
 
 	// Query servers table with hybrid column/JSON data
 	query := fmt.Sprintf(`
-        SELECT server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value
-        FROM servers
+        SELECT server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher
+        FROM %s
         %s
         ORDER BY server_name, version
         LIMIT $%d
-    `, whereClause, argIndex)
+    `, source, whereClause, argIndex)
 	args = append(args, limit)
 
 	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
@@ -204,8 +330,9 @@ func (db *PostgreSQL) ListServers(
 		var statusMessage *string
 		var isLatest bool
 		var valueJSON []byte
+		var publisher *apiv0.Publisher
 
-		err := rows.Scan(&serverName, &version, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		err := rows.Scan(&serverName, &version, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &valueJSON, &publisher)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to scan server row: %w", err)
 		}
@@ -221,12 +348,15 @@ func (db *PostgreSQL) ListServers(
 			Server: serverJSON,
 			Meta: apiv0.ResponseMeta{
 				Official: &apiv0.RegistryExtensions{
-					Status:          model.Status(status),
-					StatusChangedAt: statusChangedAt,
-					StatusMessage:   statusMessage,
-					PublishedAt:     publishedAt,
-					UpdatedAt:       updatedAt,
-					IsLatest:        isLatest,
+					Status:            model.Status(status),
+					StatusChangedAt:   statusChangedAt,
+					StatusMessage:     statusMessage,
+					PublishedAt:       publishedAt,
+					UpdatedAt:         updatedAt,
+					IsLatest:          isLatest,
+					Runtimes:          apiv0.ServerRuntimes(&serverJSON),
+					Publisher:         publisher,
+					VerifiedPublisher: publisher.IsVerified(),
 				},
 			},
 		}
@@ -271,10 +401,10 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 	}
 
 	query := fmt.Sprintf(`
-		SELECT server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher
 		FROM servers
 		%s
-		ORDER BY published_at DESC
+		ORDER BY published_at DESC, version DESC
 		LIMIT 1
 	`, whereClause)
 
@@ -282,8 +412,9 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 	var statusChangedAt, publishedAt, updatedAt time.Time
 	var statusMessage *string
 	var valueJSON []byte
+	var publisher *apiv0.Publisher
 
-	err := db.getExecutor(tx).QueryRow(ctx, query, args...).Scan(&name, &version, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	err := db.getExecutor(tx).QueryRow(ctx, query, args...).Scan(&name, &version, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &valueJSON, &publisher)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -302,12 +433,15 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:          model.Status(status),
-				StatusChangedAt: statusChangedAt,
-				StatusMessage:   statusMessage,
-				PublishedAt:     publishedAt,
-				UpdatedAt:       updatedAt,
-				IsLatest:        isLatest,
+				Status:            model.Status(status),
+				StatusChangedAt:   statusChangedAt,
+				StatusMessage:     statusMessage,
+				PublishedAt:       publishedAt,
+				UpdatedAt:         updatedAt,
+				IsLatest:          isLatest,
+				Runtimes:          apiv0.ServerRuntimes(&serverJSON),
+				Publisher:         publisher,
+				VerifiedPublisher: publisher.IsVerified(),
 			},
 		},
 	}
@@ -337,7 +471,7 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := fmt.Sprintf(`
-		SELECT server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher
 		FROM servers
 		%s
 		LIMIT 1
@@ -348,8 +482,9 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	var statusMessage *string
 	var isLatest bool
 	var valueJSON []byte
+	var publisher *apiv0.Publisher
 
-	err := db.getExecutor(tx).QueryRow(ctx, query, args...).Scan(&name, &vers, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	err := db.getExecutor(tx).QueryRow(ctx, query, args...).Scan(&name, &vers, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &valueJSON, &publisher)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -368,12 +503,15 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:          model.Status(status),
-				StatusChangedAt: statusChangedAt,
-				StatusMessage:   statusMessage,
-				PublishedAt:     publishedAt,
-				UpdatedAt:       updatedAt,
-				IsLatest:        isLatest,
+				Status:            model.Status(status),
+				StatusChangedAt:   statusChangedAt,
+				StatusMessage:     statusMessage,
+				PublishedAt:       publishedAt,
+				UpdatedAt:         updatedAt,
+				IsLatest:          isLatest,
+				Runtimes:          apiv0.ServerRuntimes(&serverJSON),
+				Publisher:         publisher,
+				VerifiedPublisher: publisher.IsVerified(),
 			},
 		},
 	}
@@ -402,10 +540,10 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := fmt.Sprintf(`
-		SELECT server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher
 		FROM servers
 		%s
-		ORDER BY published_at DESC
+		ORDER BY published_at DESC, version DESC
 	`, whereClause)
 
 	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
@@ -421,8 +559,9 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 		var statusMessage *string
 		var isLatest bool
 		var valueJSON []byte
+		var publisher *apiv0.Publisher
 
-		err := rows.Scan(&name, &version, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		err := rows.Scan(&name, &version, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &valueJSON, &publisher)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan server row: %w", err)
 		}
@@ -438,12 +577,15 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 			Server: serverJSON,
 			Meta: apiv0.ResponseMeta{
 				Official: &apiv0.RegistryExtensions{
-					Status:          model.Status(status),
-					StatusChangedAt: statusChangedAt,
-					StatusMessage:   statusMessage,
-					PublishedAt:     publishedAt,
-					UpdatedAt:       updatedAt,
-					IsLatest:        isLatest,
+					Status:            model.Status(status),
+					StatusChangedAt:   statusChangedAt,
+					StatusMessage:     statusMessage,
+					PublishedAt:       publishedAt,
+					UpdatedAt:         updatedAt,
+					IsLatest:          isLatest,
+					Runtimes:          apiv0.ServerRuntimes(&serverJSON),
+					Publisher:         publisher,
+					VerifiedPublisher: publisher.IsVerified(),
 				},
 			},
 		}
@@ -482,11 +624,15 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
 	}
+	digest, err := db.storeServerDocument(ctx, tx, serverJSON)
+	if err != nil {
+		return nil, err
+	}
 
 	// Insert the new server version using composite primary key
 	insertQuery := `
-		INSERT INTO servers (server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO servers (server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value, runtimes, publisher, document_digest)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	_, err = db.getExecutor(tx).Exec(ctx, insertQuery,
@@ -499,9 +645,12 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 		officialMeta.UpdatedAt,
 		officialMeta.IsLatest,
 		valueJSON,
+		apiv0.ServerRuntimes(serverJSON),
+		officialMeta.Publisher,
+		digest,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to insert server: %w", err)
+		return nil, fmt.Errorf("failed to insert server: %w", constraintViolation(err))
 	}
 
 	// Return the complete ServerResponse
@@ -536,21 +685,26 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal updated server: %w", err)
 	}
+	digest, err := db.storeServerDocument(ctx, tx, serverJSON)
+	if err != nil {
+		return nil, err
+	}
 
 	// Update only the JSON data (keep existing metadata columns)
 	query := `
 		UPDATE servers
-		SET value = $1, updated_at = NOW()
+		SET value = $1, runtimes = $4, document_digest = $5, updated_at = NOW()
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest
+		RETURNING server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, publisher
 	`
 
 	var name, vers, status string
 	var statusChangedAt, publishedAt, updatedAt time.Time
 	var statusMessage *string
 	var isLatest bool
+	var publisher *apiv0.Publisher
 
-	err = db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version).Scan(&name, &vers, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest)
+	err = db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version, apiv0.ServerRuntimes(serverJSON), digest).Scan(&name, &vers, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &publisher)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -563,12 +717,15 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		Server: *serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:          model.Status(status),
-				StatusChangedAt: statusChangedAt,
-				StatusMessage:   statusMessage,
-				PublishedAt:     publishedAt,
-				UpdatedAt:       updatedAt,
-				IsLatest:        isLatest,
+				Status:            model.Status(status),
+				StatusChangedAt:   statusChangedAt,
+				StatusMessage:     statusMessage,
+				PublishedAt:       publishedAt,
+				UpdatedAt:         updatedAt,
+				IsLatest:          isLatest,
+				Runtimes:          apiv0.ServerRuntimes(serverJSON),
+				Publisher:         publisher,
+				VerifiedPublisher: publisher.IsVerified(),
 			},
 		},
 	}
@@ -576,6 +733,151 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 	return serverResponse, nil
 }
 
+// storeServerDocument stores the canonical server.json of a server version in the document store, unless the
+// same document is already stored, and returns its digest
+func (db *PostgreSQL) storeServerDocument(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON) (string, error) {
+	document, digest, err := apiv0.ServerDocument(serverJSON)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode server document: %w", err)
+	}
+	_, err = db.getExecutor(tx).Exec(ctx, `
+		INSERT INTO server_documents (digest, document) VALUES ($1, $2)
+		ON CONFLICT (digest) DO NOTHING
+	`, digest, document)
+	if err != nil {
+		return "", fmt.Errorf("failed to store server document: %w", err)
+	}
+	return digest, nil
+}
+
+// PutServerDocument stores a server version's document under its digest and points the version at it
+func (db *PostgreSQL) PutServerDocument(ctx context.Context, tx pgx.Tx, serverName, version string, document []byte) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	// A document stored for a version that no longer exists is harmless, so the two statements need no transaction
+	digest := apiv0.DocumentDigest(document)
+	executor := db.getExecutor(tx)
+	_, err := executor.Exec(ctx, `
+		INSERT INTO server_documents (digest, document) VALUES ($1, $2)
+		ON CONFLICT (digest) DO NOTHING
+	`, digest, document)
+	if err != nil {
+		return "", fmt.Errorf("failed to store server document: %w", err)
+	}
+	result, err := executor.Exec(ctx, `UPDATE servers SET document_digest = $3 WHERE server_name = $1 AND version = $2`, serverName, version, digest)
+	if err != nil {
+		return "", fmt.Errorf("failed to point server at its document: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return "", ErrNotFound
+	}
+	return digest, nil
+}
+
+// GetServerDocument retrieves a stored document by its digest
+func (db *PostgreSQL) GetServerDocument(ctx context.Context, tx pgx.Tx, digest string) ([]byte, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var document []byte
+	err := db.getExecutor(tx).QueryRow(ctx, `SELECT document FROM server_documents WHERE digest = $1`, digest).Scan(&document)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server document: %w", err)
+	}
+	return document, nil
+}
+
+// ListServerDocumentRefs retrieves the document digest of every server version, ordered by name and then version
+func (db *PostgreSQL) ListServerDocumentRefs(ctx context.Context, tx pgx.Tx, cursor string, limit int) ([]*ServerDocumentRef, string, error) {
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	whereClause := ""
+	cursorCondition, args, argIndex := addCursorCondition(cursor, 1)
+	if cursorCondition != "" {
+		whereClause = "WHERE " + cursorCondition
+	}
+	query := fmt.Sprintf(`
+		SELECT server_name, version, COALESCE(document_digest, '')
+		FROM servers
+		%s
+		ORDER BY server_name, version
+		LIMIT $%d
+	`, whereClause, argIndex)
+	args = append(args, limit)
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query server documents: %w", err)
+	}
+	defer rows.Close()
+
+	refs := []*ServerDocumentRef{}
+	for rows.Next() {
+		var ref ServerDocumentRef
+		if err := rows.Scan(&ref.ServerName, &ref.Version, &ref.Digest); err != nil {
+			return nil, "", fmt.Errorf("failed to scan server document row: %w", err)
+		}
+		refs = append(refs, &ref)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	nextCursor := ""
+	if len(refs) > 0 && len(refs) >= limit {
+		last := refs[len(refs)-1]
+		nextCursor = last.ServerName + ":" + last.Version
+	}
+	return refs, nextCursor, nil
+}
+
+// ListServerDocumentDigests retrieves the document digest of each version of each given server, keyed by server name and then version
+func (db *PostgreSQL) ListServerDocumentDigests(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string]map[string]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	digests := map[string]map[string]string{}
+	if len(serverNames) == 0 {
+		return digests, nil
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `
+		SELECT server_name, version, document_digest FROM servers
+		WHERE server_name = ANY($1) AND document_digest IS NOT NULL
+	`, serverNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query document digests: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var serverName, version, digest string
+		if err := rows.Scan(&serverName, &version, &digest); err != nil {
+			return nil, fmt.Errorf("failed to scan document digest: %w", err)
+		}
+		if digests[serverName] == nil {
+			digests[serverName] = map[string]string{}
+		}
+		digests[serverName][version] = digest
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating document digests: %w", err)
+	}
+	return digests, nil
+}
+
 // SetServerStatus updates the status of a specific server version
 func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status model.Status, statusMessage *string) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
@@ -592,21 +894,22 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 			updated_at = NOW(),
 			status_message = $4
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, value, published_at, updated_at, is_latest, status_changed_at, status_message
+		RETURNING server_name, version, status, value, published_at, updated_at, is_latest, status_changed_at, status_message, publisher
 	`
 
 	var name, vers, currentStatus string
 	var publishedAt, updatedAt, statusChangedAt time.Time
 	var isLatest bool
 	var valueJSON []byte
+	var publisher *apiv0.Publisher
 	var resultStatusMessage *string
 
-	err := db.getExecutor(tx).QueryRow(ctx, query, string(status), serverName, version, statusMessage).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest, &statusChangedAt, &resultStatusMessage)
+	err := db.getExecutor(tx).QueryRow(ctx, query, string(status), serverName, version, statusMessage).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest, &statusChangedAt, &resultStatusMessage, &publisher)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
 		}
-		return nil, fmt.Errorf("failed to update server status: %w", err)
+		return nil, fmt.Errorf("failed to update server status: %w", constraintViolation(err))
 	}
 
 	// Unmarshal the JSON data
@@ -620,12 +923,15 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:          model.Status(currentStatus),
-				StatusChangedAt: statusChangedAt,
-				StatusMessage:   resultStatusMessage,
-				PublishedAt:     publishedAt,
-				UpdatedAt:       updatedAt,
-				IsLatest:        isLatest,
+				Status:            model.Status(currentStatus),
+				StatusChangedAt:   statusChangedAt,
+				StatusMessage:     resultStatusMessage,
+				PublishedAt:       publishedAt,
+				UpdatedAt:         updatedAt,
+				IsLatest:          isLatest,
+				Runtimes:          apiv0.ServerRuntimes(&serverJSON),
+				Publisher:         publisher,
+				VerifiedPublisher: publisher.IsVerified(),
 			},
 		},
 	}
@@ -651,13 +957,28 @@ func (db *PostgreSQL) SetAllVersionsStatus(ctx context.Context, tx pgx.Tx, serve
 			status_message = $2
 		WHERE server_name = $3
 			AND (status != $1::varchar OR status_message IS DISTINCT FROM $2)
-		RETURNING server_name, version, status, value, published_at, updated_at, is_latest, status_changed_at, status_message
+		RETURNING server_name, version, status, value, published_at, updated_at, is_latest, status_changed_at, status_message, publisher
 	`
 
 	rows, err := db.getExecutor(tx).Query(ctx, query, string(status), statusMessage, serverName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update all server versions status: %w", err)
+		return nil, fmt.Errorf("failed to update all server versions status: %w", constraintViolation(err))
+	}
+	results, err := scanStatusRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNotFound
 	}
+
+	return results, nil
+}
+
+// scanStatusRows collects the server versions returned by a status update, which select server_name, version,
+// status, value, published_at, updated_at, is_latest, status_changed_at, status_message and publisher
+func scanStatusRows(rows pgx.Rows) ([]*apiv0.ServerResponse, error) {
 	defer rows.Close()
 
 	var results []*apiv0.ServerResponse
@@ -666,9 +987,10 @@ func (db *PostgreSQL) SetAllVersionsStatus(ctx context.Context, tx pgx.Tx, serve
 		var publishedAt, updatedAt, statusChangedAt time.Time
 		var isLatest bool
 		var valueJSON []byte
+		var publisher *apiv0.Publisher
 		var resultStatusMessage *string
 
-		if err := rows.Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest, &statusChangedAt, &resultStatusMessage); err != nil {
+		if err := rows.Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest, &statusChangedAt, &resultStatusMessage, &publisher); err != nil {
 			return nil, fmt.Errorf("failed to scan server row: %w", err)
 		}
 
@@ -682,12 +1004,15 @@ func (db *PostgreSQL) SetAllVersionsStatus(ctx context.Context, tx pgx.Tx, serve
 			Server: serverJSON,
 			Meta: apiv0.ResponseMeta{
 				Official: &apiv0.RegistryExtensions{
-					Status:          model.Status(currentStatus),
-					StatusChangedAt: statusChangedAt,
-					StatusMessage:   resultStatusMessage,
-					PublishedAt:     publishedAt,
-					UpdatedAt:       updatedAt,
-					IsLatest:        isLatest,
+					Status:            model.Status(currentStatus),
+					StatusChangedAt:   statusChangedAt,
+					StatusMessage:     resultStatusMessage,
+					PublishedAt:       publishedAt,
+					UpdatedAt:         updatedAt,
+					IsLatest:          isLatest,
+					Runtimes:          apiv0.ServerRuntimes(&serverJSON),
+					Publisher:         publisher,
+					VerifiedPublisher: publisher.IsVerified(),
 				},
 			},
 		}
@@ -695,13 +1020,8 @@ func (db *PostgreSQL) SetAllVersionsStatus(ctx context.Context, tx pgx.Tx, serve
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating server rows: %w", err)
-	}
-
-	if len(results) == 0 {
-		return nil, ErrNotFound
+		return nil, fmt.Errorf("error iterating server rows: %w", constraintViolation(err))
 	}
-
 	return results, nil
 }
 
@@ -768,6 +1088,58 @@ func hashServerName(name string) int64 {
 	return int64(hash & 0x7FFFFFFFFFFFFFFF)
 }
 
+// postgresJobLock is a session-level advisory lock held on a dedicated pool connection
+type postgresJobLock struct {
+	conn *pgxpool.Conn
+	key  int64
+}
+
+// TryAcquireJobLock acquires a session-level advisory lock for a background job using pg_try_advisory_lock.
+// The connection is taken out of the pool while the lock is held, so PostgreSQL releases the lock if the
+// connection or this instance dies.
+func (db *PostgreSQL) TryAcquireJobLock(ctx context.Context, name string) (JobLock, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	conn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection for job lock: %w", err)
+	}
+
+	// Prefix the key so job locks cannot collide with publish locks on server names
+	key := hashServerName("job:" + name)
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("failed to acquire job lock: %w", err)
+	}
+	if !acquired {
+		conn.Release()
+		return nil, ErrLockNotAcquired
+	}
+
+	return &postgresJobLock{conn: conn, key: key}, nil
+}
+
+// Held reports whether the connection holding the lock is still alive
+func (l *postgresJobLock) Held(ctx context.Context) bool {
+	return l.conn.Ping(ctx) == nil
+}
+
+// Release unlocks the advisory lock and returns the connection to the pool
+func (l *postgresJobLock) Release(ctx context.Context) error {
+	defer l.conn.Release()
+
+	if _, err := l.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		// Closing the connection ends the session, which releases the lock anyway
+		_ = l.conn.Conn().Close(ctx)
+		return fmt.Errorf("failed to release job lock: %w", err)
+	}
+	return nil
+}
+
 // GetCurrentLatestVersion retrieves the current latest version of a server by server name
 func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
@@ -777,7 +1149,7 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 	executor := db.getExecutor(tx)
 
 	query := `
-		SELECT server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher
 		FROM servers
 		WHERE server_name = $1 AND is_latest = true
 	`
@@ -789,8 +1161,9 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 	var statusMessage *string
 	var isLatest bool
 	var jsonValue []byte
+	var publisher *apiv0.Publisher
 
-	err := row.Scan(&name, &version, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &jsonValue)
+	err := row.Scan(&name, &version, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &jsonValue, &publisher)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -809,12 +1182,15 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:          model.Status(status),
-				StatusChangedAt: statusChangedAt,
-				StatusMessage:   statusMessage,
-				PublishedAt:     publishedAt,
-				UpdatedAt:       updatedAt,
-				IsLatest:        isLatest,
+				Status:            model.Status(status),
+				StatusChangedAt:   statusChangedAt,
+				StatusMessage:     statusMessage,
+				PublishedAt:       publishedAt,
+				UpdatedAt:         updatedAt,
+				IsLatest:          isLatest,
+				Runtimes:          apiv0.ServerRuntimes(&serverJSON),
+				Publisher:         publisher,
+				VerifiedPublisher: publisher.IsVerified(),
 			},
 		},
 	}
@@ -822,6 +1198,193 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 	return serverResponse, nil
 }
 
+// CountServers counts the servers matching filter. Rows are only counted up to exactLimit; when more
+// match, the query planner's row estimate is returned instead, so totals stay cheap on large tables.
+func (db *PostgreSQL) CountServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, exactLimit int) (int, bool, error) {
+	if ctx.Err() != nil {
+		return 0, false, ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+
+	source, args, argIndex := serversSource(filter, 1)
+	whereConditions, filterArgs, argIndex := buildFilterConditions(filter, argIndex)
+	args = append(args, filterArgs...)
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	if exactLimit > 0 {
+		// Counting one row past the limit tells us whether the exact count is complete
+		query := fmt.Sprintf(`SELECT COUNT(*) FROM (SELECT 1 FROM %s %s LIMIT $%d) AS capped`, source, whereClause, argIndex)
+
+		var count int
+		if err := executor.QueryRow(ctx, query, append(args, exactLimit+1)...).Scan(&count); err != nil {
+			return 0, false, fmt.Errorf("failed to count servers: %w", err)
+		}
+		if count <= exactLimit {
+			return count, false, nil
+		}
+	}
+
+	// EXPLAIN cannot be prepared with parameters, so arguments are sent with the simple protocol
+	query := fmt.Sprintf(`EXPLAIN (FORMAT JSON) SELECT 1 FROM %s %s`, source, whereClause)
+
+	var planJSON []byte
+	if err := executor.QueryRow(ctx, query, append([]any{pgx.QueryExecModeSimpleProtocol}, args...)...).Scan(&planJSON); err != nil {
+		return 0, false, fmt.Errorf("failed to estimate server count: %w", err)
+	}
+
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(planJSON, &plans); err != nil || len(plans) == 0 {
+		return 0, false, fmt.Errorf("failed to parse query plan: %w", err)
+	}
+
+	// The exact count already showed more than exactLimit rows match
+	return max(int(plans[0].Plan.Rows), exactLimit+1), true, nil
+}
+
+// SuggestServers suggests latest servers and namespaces with a word starting with prefix. The substring
+// match narrows candidates with the trigram index before the word boundary regular expression is checked.
+func (db *PostgreSQL) SuggestServers(ctx context.Context, tx pgx.Tx, prefix string, limit int) ([]ServerSuggestion, []string, error) {
+	if ctx.Err() != nil {
+		return nil, nil, ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+	escaped := escapeLike(prefix)
+	args := []any{"%" + escaped + "%", suggestWordPattern(prefix), escaped + "%", limit}
+
+	rows, err := executor.Query(ctx, `
+		SELECT server_name, COALESCE(value->>'title', '')
+		FROM servers
+		WHERE is_latest AND status NOT IN ('deleted', 'quarantined') AND server_name ILIKE $1 AND server_name ~* $2
+		ORDER BY
+			CASE WHEN server_name ILIKE $3 THEN 0 WHEN split_part(server_name, '/', 2) ILIKE $3 THEN 1 ELSE 2 END,
+			length(server_name), server_name
+		LIMIT $4`, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to suggest servers: %w", err)
+	}
+	defer rows.Close()
+
+	var servers []ServerSuggestion
+	for rows.Next() {
+		var suggestion ServerSuggestion
+		if err := rows.Scan(&suggestion.Name, &suggestion.Title); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan server suggestion: %w", err)
+		}
+		servers = append(servers, suggestion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate server suggestions: %w", err)
+	}
+
+	namespaceRows, err := executor.Query(ctx, `
+		SELECT namespace
+		FROM (
+			SELECT DISTINCT split_part(server_name, '/', 1) AS namespace
+			FROM servers
+			WHERE is_latest AND status NOT IN ('deleted', 'quarantined') AND server_name ILIKE $1
+		) AS namespaces
+		WHERE namespace ~* $2
+		ORDER BY CASE WHEN namespace ILIKE $3 THEN 0 ELSE 1 END, length(namespace), namespace
+		LIMIT $4`, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to suggest namespaces: %w", err)
+	}
+	defer namespaceRows.Close()
+
+	var namespaces []string
+	for namespaceRows.Next() {
+		var namespace string
+		if err := namespaceRows.Scan(&namespace); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan namespace suggestion: %w", err)
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	if err := namespaceRows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate namespace suggestions: %w", err)
+	}
+
+	return servers, namespaces, nil
+}
+
+// escapeLike escapes the LIKE wildcards in s, so it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// toolSearchText returns the lowercased names and descriptions of tools that tool keywords are matched against.
+// PostgreSQL stores it alongside the tools, and Memory computes it when filtering.
+func toolSearchText(tools []ServerTool) string {
+	var text strings.Builder
+	for _, tool := range tools {
+		text.WriteString(strings.ToLower(tool.Name))
+		text.WriteString(" ")
+		text.WriteString(strings.ToLower(tool.Description))
+		text.WriteString("\n")
+	}
+	return text.String()
+}
+
+// suggestWordPattern returns a regular expression matching names with a word starting with prefix. The
+// syntax is shared by Go and PostgreSQL, so Memory uses the same pattern.
+func suggestWordPattern(prefix string) string {
+	return "(^|[/._-])" + regexp.QuoteMeta(prefix)
+}
+
+// ListNamespaces summarizes up to limit namespaces with servers whose latest version is not deleted
+func (db *PostgreSQL) ListNamespaces(ctx context.Context, tx pgx.Tx, parent, cursor string, limit int) ([]*NamespaceSummary, string, error) {
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	query := `
+		SELECT namespace, COUNT(*) FILTER (WHERE is_latest), COUNT(*),
+			COUNT(*) FILTER (WHERE is_latest AND publisher IS NOT NULL AND publisher->>'authMethod' <> 'none'),
+			MAX(GREATEST(published_at, updated_at))
+		FROM (
+			SELECT split_part(server_name, '/', 1) AS namespace, is_latest, publisher, published_at, updated_at
+			FROM servers
+			WHERE status NOT IN ('deleted', 'quarantined')
+		) AS versions
+		WHERE namespace > $1 AND ($2 = '' OR namespace = $2 OR starts_with(namespace, $2 || '.'))
+		GROUP BY namespace
+		HAVING COUNT(*) FILTER (WHERE is_latest) > 0
+		ORDER BY namespace
+		LIMIT $3
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, cursor, parent, limit)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	defer rows.Close()
+
+	var namespaces []*NamespaceSummary
+	for rows.Next() {
+		summary := &NamespaceSummary{}
+		if err := rows.Scan(&summary.Namespace, &summary.Servers, &summary.Versions, &summary.VerifiedServers, &summary.UpdatedAt); err != nil {
+			return nil, "", fmt.Errorf("failed to scan namespace: %w", err)
+		}
+		namespaces = append(namespaces, summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating namespaces: %w", err)
+	}
+
+	nextCursor := ""
+	if len(namespaces) > 0 && len(namespaces) >= limit {
+		nextCursor = namespaces[len(namespaces)-1].Namespace
+	}
+	return namespaces, nextCursor, nil
+}
+
 // CountServerVersions counts the number of versions for a server
 func (db *PostgreSQL) CountServerVersions(ctx context.Context, tx pgx.Tx, serverName string) (int, error) {
 	if ctx.Err() != nil {
@@ -860,6 +1423,36 @@ func (db *PostgreSQL) CheckVersionExists(ctx context.Context, tx pgx.Tx, serverN
 	return exists, nil
 }
 
+// ListExistingVersions retrieves the versions, including deleted ones, of each given server that has any, keyed by server name
+func (db *PostgreSQL) ListExistingVersions(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string][]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	versions := map[string][]string{}
+	if len(serverNames) == 0 {
+		return versions, nil
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT server_name, version FROM servers WHERE server_name = ANY($1)`, serverNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query existing versions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var serverName, version string
+		if err := rows.Scan(&serverName, &version); err != nil {
+			return nil, fmt.Errorf("failed to scan existing version: %w", err)
+		}
+		versions[serverName] = append(versions[serverName], version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating existing versions: %w", err)
+	}
+	return versions, nil
+}
+
 // UnmarkAsLatest marks the current latest version of a server as no longer latest
 func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error {
 	if ctx.Err() != nil {
@@ -878,8 +1471,3471 @@ func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName
 	return nil
 }
 
-// Close closes the database connection
-func (db *PostgreSQL) Close() error {
-	db.pool.Close()
+// ListServerChanges retrieves changes recorded after the given sequence number, in sequence order
+func (db *PostgreSQL) ListServerChanges(ctx context.Context, tx pgx.Tx, since int64, limit int) ([]*apiv0.ServerChange, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = 100
+	}
+
+	// Changes of a deleted version show the state they recorded
+	query := `
+		SELECT c.seq, c.change_type, c.changed_at, COALESCE(c.previous_name, ''),
+			CASE WHEN s.server_name IS NULL THEN c.status ELSE s.status END,
+			CASE WHEN s.server_name IS NULL THEN c.status_changed_at ELSE s.status_changed_at END,
+			CASE WHEN s.server_name IS NULL THEN c.status_message ELSE s.status_message END,
+			CASE WHEN s.server_name IS NULL THEN c.published_at ELSE s.published_at END,
+			CASE WHEN s.server_name IS NULL THEN c.updated_at ELSE s.updated_at END,
+			CASE WHEN s.server_name IS NULL THEN c.is_latest ELSE s.is_latest END,
+			CASE WHEN s.server_name IS NULL THEN c.value ELSE s.value END,
+			CASE WHEN s.server_name IS NULL THEN c.publisher ELSE s.publisher END
+		FROM server_changes c
+		LEFT JOIN servers s ON s.server_name = c.server_name AND s.version = c.version
+		WHERE c.seq > $1 AND (s.server_name IS NOT NULL OR c.value IS NOT NULL)
+		ORDER BY c.seq
+		LIMIT $2
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server changes: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*apiv0.ServerChange
+	for rows.Next() {
+		var seq int64
+		var changeType, previousName, status string
+		var changedAt, statusChangedAt, publishedAt, updatedAt time.Time
+		var statusMessage *string
+		var isLatest bool
+		var valueJSON []byte
+		var publisher *apiv0.Publisher
+
+		if err := rows.Scan(&seq, &changeType, &changedAt, &previousName, &status, &statusChangedAt, &statusMessage, &publishedAt, &updatedAt, &isLatest, &valueJSON, &publisher); err != nil {
+			return nil, fmt.Errorf("failed to scan server change row: %w", err)
+		}
+
+		var serverJSON apiv0.ServerJSON
+		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+
+		results = append(results, &apiv0.ServerChange{
+			Seq:          seq,
+			Type:         changeType,
+			ChangedAt:    changedAt,
+			PreviousName: previousName,
+			Server: apiv0.ServerResponse{
+				Server: serverJSON,
+				Meta: apiv0.ResponseMeta{
+					Official: &apiv0.RegistryExtensions{
+						Status:            model.Status(status),
+						StatusChangedAt:   statusChangedAt,
+						StatusMessage:     statusMessage,
+						PublishedAt:       publishedAt,
+						UpdatedAt:         updatedAt,
+						IsLatest:          isLatest,
+						Runtimes:          apiv0.ServerRuntimes(&serverJSON),
+						Publisher:         publisher,
+						VerifiedPublisher: publisher.IsVerified(),
+					},
+				},
+			},
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server change rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// eventsChannel is the notification channel events are sent on, by triggers added in migration 031
+const eventsChannel = "registry_events"
+
+// LatestChangeSeq retrieves the sequence number of the most recently recorded change, or 0 if there is none
+func (db *PostgreSQL) LatestChangeSeq(ctx context.Context, tx pgx.Tx) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	var seq int64
+	if err := db.getExecutor(tx).QueryRow(ctx, `SELECT COALESCE(MAX(seq), 0) FROM server_changes`).Scan(&seq); err != nil {
+		return 0, fmt.Errorf("failed to get latest change: %w", err)
+	}
+	return seq, nil
+}
+
+// DeleteServerChangesBefore permanently removes changes recorded before the given time, except the most
+// recent change, and returns how many were removed. Months that end before then are dropped as a whole.
+func (db *PostgreSQL) DeleteServerChangesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+
+	// Keeping the most recent change keeps the latest sequence number from going backwards
+	var latest time.Time
+	err := executor.QueryRow(ctx, `SELECT changed_at FROM server_changes ORDER BY seq DESC LIMIT 1`).Scan(&latest)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest change: %w", err)
+	}
+	if latest.Before(before) {
+		before = latest
+	}
+
+	return deleteBefore(ctx, executor, "server_changes", "changed_at", before)
+}
+
+// partitionedTables lists the tables partitioned by month in migration 050, with the column they are partitioned on
+var partitionedTables = []struct{ table, column string }{
+	{"audit_log", "created_at"},
+	{"server_changes", "changed_at"},
+}
+
+// CreatePartitions creates the missing monthly partitions of the audit log and changes feed up to and
+// including the month of through, and returns how many were created
+func (db *PostgreSQL) CreatePartitions(ctx context.Context, tx pgx.Tx, through time.Time) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+	var created int
+	for _, partitioned := range partitionedTables {
+		var tableCreated int
+		if err := executor.QueryRow(ctx, `SELECT create_monthly_partitions($1, $2, NOW(), $3)`,
+			partitioned.table, partitioned.column, through).Scan(&tableCreated); err != nil {
+			return created, fmt.Errorf("failed to create %s partitions: %w", partitioned.table, err)
+		}
+		created += tableCreated
+	}
+	return created, nil
+}
+
+// deleteBefore removes the rows of a table partitioned by month whose partition column is before the given
+// time, dropping the partitions of months that end before then and deleting the remaining rows, and returns
+// how many rows were removed
+func deleteBefore(ctx context.Context, executor Executor, table, column string, before time.Time) (int64, error) {
+	var dropped int64
+	if err := executor.QueryRow(ctx, `SELECT drop_monthly_partitions($1, $2)`, table, before).Scan(&dropped); err != nil {
+		return 0, fmt.Errorf("failed to drop %s partitions: %w", table, err)
+	}
+
+	result, err := executor.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s < $1`, table, column), before)
+	if err != nil {
+		return dropped, fmt.Errorf("failed to delete from %s: %w", table, err)
+	}
+	return dropped + result.RowsAffected(), nil
+}
+
+// ListenForEvents passes events committed by any replica to handle, calling ready once it is listening.
+// It holds a connection for as long as it listens, and returns when ctx is done or the connection is lost.
+func (db *PostgreSQL) ListenForEvents(ctx context.Context, ready func(), handle func(Event)) error {
+	conn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection for events: %w", err)
+	}
+	defer func() {
+		// Stop listening before the connection goes back to the pool, unless it was lost
+		if !conn.Conn().IsClosed() {
+			unlistenCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if _, err := conn.Exec(unlistenCtx, "UNLISTEN "+eventsChannel); err != nil {
+				_ = conn.Conn().Close(unlistenCtx)
+			}
+			cancel()
+		}
+		conn.Release()
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+eventsChannel); err != nil {
+		return fmt.Errorf("failed to listen for events: %w", err)
+	}
+	ready()
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for events: %w", err)
+		}
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Printf("Ignoring malformed event %q: %v", notification.Payload, err)
+			continue
+		}
+		handle(event)
+	}
+}
+
+// GetImportCheckpoint retrieves the last change sequence applied from a remote registry
+func (db *PostgreSQL) GetImportCheckpoint(ctx context.Context, tx pgx.Tx, source string) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	var lastSeq int64
+	err := db.getExecutor(tx).QueryRow(ctx, `SELECT last_seq FROM import_checkpoints WHERE source = $1`, source).Scan(&lastSeq)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to get import checkpoint: %w", err)
+	}
+
+	return lastSeq, nil
+}
+
+// SetImportCheckpoint records the last change sequence applied from a remote registry
+func (db *PostgreSQL) SetImportCheckpoint(ctx context.Context, tx pgx.Tx, source string, seq int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO import_checkpoints (source, last_seq, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (source) DO UPDATE SET last_seq = EXCLUDED.last_seq, updated_at = NOW()
+	`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, source, seq); err != nil {
+		return fmt.Errorf("failed to set import checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// GetMaintenanceState retrieves the persisted maintenance mode state
+func (db *PostgreSQL) GetMaintenanceState(ctx context.Context, tx pgx.Tx) (*MaintenanceState, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT enabled, message, retry_after_seconds, updated_at FROM maintenance_mode WHERE id = 1`
+
+	var state MaintenanceState
+	err := db.getExecutor(tx).QueryRow(ctx, query).Scan(&state.Enabled, &state.Message, &state.RetryAfterSeconds, &state.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get maintenance state: %w", err)
+	}
+
+	return &state, nil
+}
+
+// SetMaintenanceState persists the maintenance mode state
+func (db *PostgreSQL) SetMaintenanceState(ctx context.Context, tx pgx.Tx, enabled bool, message *string, retryAfterSeconds int) (*MaintenanceState, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO maintenance_mode (id, enabled, message, retry_after_seconds, updated_at)
+		VALUES (1, $1, $2, $3, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			message = EXCLUDED.message,
+			retry_after_seconds = EXCLUDED.retry_after_seconds,
+			updated_at = NOW()
+		RETURNING enabled, message, retry_after_seconds, updated_at
+	`
+
+	var state MaintenanceState
+	err := db.getExecutor(tx).QueryRow(ctx, query, enabled, message, retryAfterSeconds).Scan(&state.Enabled, &state.Message, &state.RetryAfterSeconds, &state.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set maintenance state: %w", constraintViolation(err))
+	}
+
+	return &state, nil
+}
+
+// ListPossibleDuplicates finds groups of servers that share a repository URL or remote endpoint under different names
+func (db *PostgreSQL) ListPossibleDuplicates(ctx context.Context, tx pgx.Tx, serverName *string) ([]*DuplicateGroup, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := fmt.Sprintf(`
+		WITH latest AS (
+			SELECT server_name, value FROM servers WHERE is_latest AND status NOT IN ('deleted', 'quarantined')
+		), urls AS (
+			SELECT 'repository' AS reason, %s AS url, server_name
+			FROM latest
+			WHERE COALESCE(value->'repository'->>'url', '') != ''
+			UNION ALL
+			SELECT 'remote', lower(rtrim(remote->>'url', '/')), server_name
+			FROM latest, jsonb_array_elements(COALESCE(value->'remotes', '[]'::jsonb)) AS remote
+			WHERE COALESCE(remote->>'url', '') != ''
+		)
+		SELECT reason, url, array_agg(DISTINCT server_name ORDER BY server_name)
+		FROM urls
+		GROUP BY reason, url
+		HAVING count(DISTINCT server_name) > 1 AND ($1::text IS NULL OR bool_or(server_name = $1))
+		ORDER BY reason, url
+	`, canonicalRepositoryURL("value->'repository'->>'url'"))
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query possible duplicates: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []*DuplicateGroup{}
+	for rows.Next() {
+		var group DuplicateGroup
+		if err := rows.Scan(&group.Reason, &group.URL, &group.ServerNames); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate group: %w", err)
+		}
+		groups = append(groups, &group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duplicate groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// prunableCandidate is a version of a server that a retention policy might delete
+type prunableCandidate struct {
+	version     string
+	publishedAt time.Time
+	isLatest    bool
+}
+
+// selectPrunableVersions ranks the versions of a server newest first, the way the registry picks its latest
+// version, and returns the non-latest versions outside the newest maxVersionsPerServer or published before
+// publishedBefore
+func selectPrunableVersions(serverName string, candidates []prunableCandidate, maxVersionsPerServer int, publishedBefore *time.Time) []*PrunableVersion {
+	slices.SortFunc(candidates, func(a, b prunableCandidate) int {
+		return cmp.Or(versioning.CompareVersions(b.version, a.version, b.publishedAt, a.publishedAt), b.publishedAt.Compare(a.publishedAt))
+	})
+
+	versions := []*PrunableVersion{}
+	for i, candidate := range candidates {
+		if candidate.isLatest {
+			continue
+		}
+		reason := ""
+		switch {
+		case maxVersionsPerServer > 0 && i+1 > maxVersionsPerServer:
+			reason = PruneReasonMaxVersions
+		case publishedBefore != nil && candidate.publishedAt.Before(*publishedBefore):
+			reason = PruneReasonMaxAge
+		default:
+			continue
+		}
+		versions = append(versions, &PrunableVersion{
+			ServerName:  serverName,
+			Version:     candidate.version,
+			PublishedAt: candidate.publishedAt,
+			Reason:      reason,
+		})
+	}
+
+	slices.SortFunc(versions, func(a, b *PrunableVersion) int {
+		return cmp.Or(a.PublishedAt.Compare(b.PublishedAt), strings.Compare(a.Version, b.Version))
+	})
+	return versions
+}
+
+// ListPrunableVersions finds non-latest versions exceeding the per-server version limit or older than publishedBefore
+func (db *PostgreSQL) ListPrunableVersions(ctx context.Context, tx pgx.Tx, maxVersionsPerServer int, publishedBefore *time.Time, limit int) ([]*PrunableVersion, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// Versions are ranked with semantic version ordering, which SQL cannot express, so the versions of every
+	// server that may have some to prune are ranked here
+	query := `
+		SELECT server_name, version, published_at, is_latest
+		FROM servers
+		WHERE server_name IN (
+			SELECT server_name
+			FROM servers
+			GROUP BY server_name
+			HAVING ($1 > 0 AND COUNT(*) > $1) OR ($2::timestamptz IS NOT NULL AND MIN(published_at) < $2)
+		)
+		ORDER BY server_name
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, maxVersionsPerServer, publishedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prunable versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*PrunableVersion{}
+	serverName := ""
+	var candidates []prunableCandidate
+	for rows.Next() {
+		var name string
+		var candidate prunableCandidate
+		if err := rows.Scan(&name, &candidate.version, &candidate.publishedAt, &candidate.isLatest); err != nil {
+			return nil, fmt.Errorf("failed to scan prunable version: %w", err)
+		}
+		if name != serverName {
+			versions = append(versions, selectPrunableVersions(serverName, candidates, maxVersionsPerServer, publishedBefore)...)
+			if limit >= 0 && len(versions) >= limit {
+				break
+			}
+			serverName, candidates = name, nil
+		}
+		candidates = append(candidates, candidate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating prunable versions: %w", err)
+	}
+	if limit < 0 || len(versions) < limit {
+		versions = append(versions, selectPrunableVersions(serverName, candidates, maxVersionsPerServer, publishedBefore)...)
+	}
+
+	if limit >= 0 && len(versions) > limit {
+		versions = versions[:limit]
+	}
+	return versions, nil
+}
+
+// DeleteServerVersion permanently removes a server version, recording its deletion in the changes feed with the
+// state it was deleted in
+func (db *PostgreSQL) DeleteServerVersion(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		WITH deleted AS (
+			DELETE FROM servers WHERE server_name = $1 AND version = $2
+			RETURNING server_name, version, status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher, runtimes
+		)
+		INSERT INTO server_changes (
+			server_name, version, change_type,
+			status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher, runtimes
+		)
+		SELECT server_name, version, 'deleted',
+			status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher, runtimes
+		FROM deleted
+	`
+	result, err := db.getExecutor(tx).Exec(ctx, query, serverName, version)
+	if err != nil {
+		return fmt.Errorf("failed to delete server version: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// maxVerificationEvidence bounds how many lookups are kept per verification request, so repeated logins cannot grow it forever
+const maxVerificationEvidence = 20
+
+const namespaceVerificationColumns = `id, domain, method, status, evidence, review_notes, reviewed_by, reviewed_at, created_at, updated_at`
+
+// appendEvidenceSQL returns a SQL expression concatenating two JSONB evidence arrays, keeping only the most recent entries
+func appendEvidenceSQL(existing, added string) string {
+	return fmt.Sprintf(`(
+		SELECT COALESCE(jsonb_agg(elem ORDER BY ord), '[]'::jsonb)
+		FROM (
+			SELECT elem, ord FROM jsonb_array_elements(%s || %s) WITH ORDINALITY AS t(elem, ord)
+			ORDER BY ord DESC LIMIT %d
+		) AS recent
+	)`, existing, added, maxVerificationEvidence)
+}
+
+func scanNamespaceVerification(row pgx.Row) (*NamespaceVerification, error) {
+	var verification NamespaceVerification
+	var evidenceJSON []byte
+	err := row.Scan(&verification.ID, &verification.Domain, &verification.Method, &verification.Status, &evidenceJSON,
+		&verification.ReviewNotes, &verification.ReviewedBy, &verification.ReviewedAt, &verification.CreatedAt, &verification.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(evidenceJSON, &verification.Evidence); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal verification evidence: %w", err)
+	}
+
+	return &verification, nil
+}
+
+// RecordNamespaceVerificationAttempt adds evidence to the pending verification request for a domain and method, creating it if needed
+func (db *PostgreSQL) RecordNamespaceVerificationAttempt(ctx context.Context, tx pgx.Tx, domain, method string, evidence VerificationEvidence) (*NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	evidenceJSON, err := json.Marshal(evidence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal verification evidence: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO namespace_verifications (domain, method, evidence)
+		VALUES ($1, $2, jsonb_build_array($3::jsonb))
+		ON CONFLICT (domain, method) WHERE status = 'pending'
+		DO UPDATE SET
+			evidence = %s,
+			updated_at = NOW()
+		RETURNING %s
+	`, appendEvidenceSQL("namespace_verifications.evidence", "EXCLUDED.evidence"), namespaceVerificationColumns)
+
+	verification, err := scanNamespaceVerification(db.getExecutor(tx).QueryRow(ctx, query, domain, method, evidenceJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record namespace verification attempt: %w", constraintViolation(err))
+	}
+
+	return verification, nil
+}
+
+// AddNamespaceVerificationEvidence appends evidence to an existing verification request
+func (db *PostgreSQL) AddNamespaceVerificationEvidence(ctx context.Context, tx pgx.Tx, id int64, evidence VerificationEvidence) (*NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	evidenceJSON, err := json.Marshal(evidence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal verification evidence: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE namespace_verifications
+		SET evidence = %s, updated_at = NOW()
+		WHERE id = $1
+		RETURNING %s
+	`, appendEvidenceSQL("evidence", "jsonb_build_array($2::jsonb)"), namespaceVerificationColumns)
+
+	verification, err := scanNamespaceVerification(db.getExecutor(tx).QueryRow(ctx, query, id, evidenceJSON))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to add namespace verification evidence: %w", err)
+	}
+
+	return verification, nil
+}
+
+// GetNamespaceVerification retrieves a verification request by ID
+func (db *PostgreSQL) GetNamespaceVerification(ctx context.Context, tx pgx.Tx, id int64) (*NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + namespaceVerificationColumns + ` FROM namespace_verifications WHERE id = $1`
+
+	verification, err := scanNamespaceVerification(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get namespace verification: %w", err)
+	}
+
+	return verification, nil
+}
+
+// ListNamespaceVerifications retrieves verification requests, optionally filtered by status, oldest first
+func (db *PostgreSQL) ListNamespaceVerifications(ctx context.Context, tx pgx.Tx, status *string) ([]*NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + namespaceVerificationColumns + `
+		FROM namespace_verifications
+		WHERE $1::text IS NULL OR status = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query namespace verifications: %w", err)
+	}
+	defer rows.Close()
+
+	verifications := []*NamespaceVerification{}
+	for rows.Next() {
+		verification, err := scanNamespaceVerification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan namespace verification: %w", err)
+		}
+		verifications = append(verifications, verification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating namespace verifications: %w", err)
+	}
+
+	return verifications, nil
+}
+
+// ReviewNamespaceVerification records an admin decision on a verification request
+func (db *PostgreSQL) ReviewNamespaceVerification(ctx context.Context, tx pgx.Tx, id int64, status string, notes *string, reviewer string) (*NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE namespace_verifications
+		SET status = $2, review_notes = $3, reviewed_by = $4, reviewed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + namespaceVerificationColumns
+
+	verification, err := scanNamespaceVerification(db.getExecutor(tx).QueryRow(ctx, query, id, status, notes, reviewer))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to review namespace verification: %w", constraintViolation(err))
+	}
+
+	return verification, nil
+}
+
+// IsNamespaceApproved reports whether the most recent review decision for a domain approved it
+func (db *PostgreSQL) IsNamespaceApproved(ctx context.Context, tx pgx.Tx, domain string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	query := `
+		SELECT status = 'approved'
+		FROM namespace_verifications
+		WHERE domain = $1 AND status != 'pending'
+		ORDER BY reviewed_at DESC, id DESC
+		LIMIT 1
+	`
+
+	var approved bool
+	if err := db.getExecutor(tx).QueryRow(ctx, query, domain).Scan(&approved); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check namespace approval: %w", err)
+	}
+
+	return approved, nil
+}
+
+const auditEntryColumns = `id, created_at, actor, action, namespace, resource, client_ip, details`
+
+func scanAuditEntry(row pgx.Row) (*AuditEntry, error) {
+	var entry AuditEntry
+	var detailsJSON []byte
+	err := row.Scan(&entry.ID, &entry.CreatedAt, &entry.Actor, &entry.Action, &entry.Namespace, &entry.Resource, &entry.ClientIP, &detailsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(detailsJSON, &entry.Details); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit details: %w", err)
+	}
+	if len(entry.Details) == 0 {
+		entry.Details = nil
+	}
+
+	return &entry, nil
+}
+
+// RecordAuditEntry appends an entry to the audit log, assigning its ID and time
+func (db *PostgreSQL) RecordAuditEntry(ctx context.Context, tx pgx.Tx, entry AuditEntry) (*AuditEntry, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	details := entry.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit details: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_log (actor, action, namespace, resource, client_ip, details)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + auditEntryColumns
+
+	recorded, err := scanAuditEntry(db.getExecutor(tx).QueryRow(ctx, query,
+		entry.Actor, entry.Action, entry.Namespace, entry.Resource, entry.ClientIP, detailsJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record audit entry: %w", constraintViolation(err))
+	}
+
+	return recorded, nil
+}
+
+// ListAuditEntries retrieves up to limit audit entries matching the filter with an ID greater than afterID, oldest first
+func (db *PostgreSQL) ListAuditEntries(ctx context.Context, tx pgx.Tx, filter *AuditFilter, afterID int64, limit int) ([]*AuditEntry, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if filter == nil {
+		filter = &AuditFilter{}
+	}
+
+	query := `
+		SELECT ` + auditEntryColumns + `
+		FROM audit_log
+		WHERE id > $1
+			AND ($2::text IS NULL OR actor = $2)
+			AND ($3::text IS NULL OR namespace = $3)
+			AND ($4::text IS NULL OR action = $4)
+			AND ($5::timestamptz IS NULL OR created_at >= $5)
+			AND ($6::timestamptz IS NULL OR created_at < $6)
+		ORDER BY id
+		LIMIT $7
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, afterID, filter.Actor, filter.Namespace, filter.Action, filter.Since, filter.Until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*AuditEntry{}
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// DeleteAuditEntriesBefore permanently removes audit entries recorded before the given time and returns how many were removed
+func (db *PostgreSQL) DeleteAuditEntriesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	deleted, err := deleteBefore(ctx, db.getExecutor(tx), "audit_log", "created_at", before)
+	if err != nil {
+		return deleted, fmt.Errorf("failed to delete audit entries: %w", err)
+	}
+	return deleted, nil
+}
+
+// publishAttemptColumns lists the columns scanPublishAttempt reads, in order
+const publishAttemptColumns = `id, attempted_at, identity, server_name, version, schema_version, status, error_code, error, validation_errors, validation_warnings`
+
+// scanPublishAttempt reads a publish attempt selected with publishAttemptColumns
+func scanPublishAttempt(row pgx.Row) (*PublishAttempt, error) {
+	var attempt PublishAttempt
+	var validationErrors, validationWarnings []byte
+	if err := row.Scan(&attempt.ID, &attempt.AttemptedAt, &attempt.Identity, &attempt.ServerName, &attempt.Version,
+		&attempt.SchemaVersion, &attempt.Status, &attempt.ErrorCode, &attempt.Error, &validationErrors, &validationWarnings); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(validationErrors, &attempt.ValidationErrors); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal validation errors: %w", err)
+	}
+	if err := json.Unmarshal(validationWarnings, &attempt.ValidationWarnings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal validation warnings: %w", err)
+	}
+	return &attempt, nil
+}
+
+// nonNilStrings returns values, or an empty slice if it is nil, so it is stored as an empty JSON array
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// nonNilIssues returns issues, or an empty slice if it is nil, so it is stored as an empty JSON array
+func nonNilIssues(issues []validator.ValidationIssue) []validator.ValidationIssue {
+	if issues == nil {
+		return []validator.ValidationIssue{}
+	}
+	return issues
+}
+
+// RecordPublishAttempt stores a publish attempt, assigning its ID and time
+func (db *PostgreSQL) RecordPublishAttempt(ctx context.Context, tx pgx.Tx, attempt PublishAttempt) (*PublishAttempt, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	validationErrors, err := json.Marshal(nonNilStrings(attempt.ValidationErrors))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal validation errors: %w", err)
+	}
+	validationWarnings, err := json.Marshal(nonNilStrings(attempt.ValidationWarnings))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal validation warnings: %w", err)
+	}
+
+	query := `
+		INSERT INTO publish_attempts (identity, server_name, version, schema_version, status, error_code, error, validation_errors, validation_warnings)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + publishAttemptColumns
+
+	recorded, err := scanPublishAttempt(db.getExecutor(tx).QueryRow(ctx, query,
+		attempt.Identity, attempt.ServerName, attempt.Version, attempt.SchemaVersion, attempt.Status, attempt.ErrorCode, attempt.Error,
+		validationErrors, validationWarnings))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record publish attempt: %w", constraintViolation(err))
+	}
+	return recorded, nil
+}
+
+// ListPublishAttempts retrieves up to limit publish attempts made by identity, newest first, with an ID
+// less than beforeID, or from the newest if beforeID is 0
+func (db *PostgreSQL) ListPublishAttempts(ctx context.Context, tx pgx.Tx, identity string, beforeID int64, limit int) ([]*PublishAttempt, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + publishAttemptColumns + `
+		FROM publish_attempts
+		WHERE identity = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, identity, beforeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query publish attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []*PublishAttempt{}
+	for rows.Next() {
+		attempt, err := scanPublishAttempt(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan publish attempt: %w", err)
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating publish attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// GetPublishAttempt retrieves a publish attempt by ID
+func (db *PostgreSQL) GetPublishAttempt(ctx context.Context, tx pgx.Tx, id int64) (*PublishAttempt, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + publishAttemptColumns + ` FROM publish_attempts WHERE id = $1`
+	attempt, err := scanPublishAttempt(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get publish attempt: %w", err)
+	}
+	return attempt, nil
+}
+
+// DeletePublishAttemptsBefore permanently removes publish attempts made before the given time, along with
+// their quarantined server.json, and returns how many were removed
+func (db *PostgreSQL) DeletePublishAttemptsBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM publish_attempts WHERE attempted_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete publish attempts: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// QuarantinePublish keeps the server.json and validation output of a rejected publish attempt, replacing any
+// already kept for the attempt
+func (db *PostgreSQL) QuarantinePublish(ctx context.Context, tx pgx.Tx, quarantined QuarantinedPublish) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	serverJSON, err := json.Marshal(quarantined.Server)
+	if err != nil {
+		return fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+	validationIssues, err := json.Marshal(nonNilIssues(quarantined.ValidationIssues))
+	if err != nil {
+		return fmt.Errorf("failed to marshal validation issues: %w", err)
+	}
+
+	// Selecting the attempt reports a missing one as not found rather than as a foreign key violation
+	query := `
+		INSERT INTO quarantined_publishes (attempt_id, server_json, validation_issues)
+		SELECT id, $2, $3 FROM publish_attempts WHERE id = $1
+		ON CONFLICT (attempt_id) DO UPDATE SET
+			quarantined_at = NOW(),
+			server_json = EXCLUDED.server_json,
+			validation_issues = EXCLUDED.validation_issues
+	`
+	result, err := db.getExecutor(tx).Exec(ctx, query, quarantined.AttemptID, serverJSON, validationIssues)
+	if err != nil {
+		return fmt.Errorf("failed to quarantine publish: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetQuarantinedPublish retrieves the quarantined server.json of a publish attempt
+func (db *PostgreSQL) GetQuarantinedPublish(ctx context.Context, tx pgx.Tx, attemptID int64) (*QuarantinedPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT attempt_id, quarantined_at, server_json, validation_issues FROM quarantined_publishes WHERE attempt_id = $1`
+	var quarantined QuarantinedPublish
+	var serverJSON, validationIssues []byte
+	if err := db.getExecutor(tx).QueryRow(ctx, query, attemptID).Scan(&quarantined.AttemptID, &quarantined.QuarantinedAt, &serverJSON, &validationIssues); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get quarantined publish: %w", err)
+	}
+	if err := json.Unmarshal(serverJSON, &quarantined.Server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal quarantined server: %w", err)
+	}
+	if err := json.Unmarshal(validationIssues, &quarantined.ValidationIssues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal validation issues: %w", err)
+	}
+	return &quarantined, nil
+}
+
+// DeleteQuarantinedPublishesBefore permanently removes server.json documents quarantined before the given time
+// and returns how many were removed
+func (db *PostgreSQL) DeleteQuarantinedPublishesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM quarantined_publishes WHERE quarantined_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete quarantined publishes: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// UpdateAuditEntryClientIP replaces the stored client address of an audit entry, for re-encrypting it under a new key
+func (db *PostgreSQL) UpdateAuditEntryClientIP(ctx context.Context, tx pgx.Tx, id int64, clientIP string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `UPDATE audit_log SET client_ip = $2 WHERE id = $1`, id, clientIP)
+	if err != nil {
+		return fmt.Errorf("failed to update audit entry: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Close closes the database connection
+func (db *PostgreSQL) Close() error {
+	db.pool.Close()
+	return nil
+}
+
+// RecordPackageProvenance stores the upstream provenance of a server version's packages. Packages that
+// already have a record are skipped, so recorded provenance is never overwritten.
+func (db *PostgreSQL) RecordPackageProvenance(ctx context.Context, tx pgx.Tx, serverName, version string, provenance []apiv0.PackageProvenance) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO package_provenance (server_name, version, registry_type, identifier, package_version, digest, files, upstream_published_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (server_name, version, registry_type, identifier, package_version) DO NOTHING
+	`
+
+	executor := db.getExecutor(tx)
+	for _, record := range provenance {
+		files := record.Files
+		if files == nil {
+			files = []apiv0.ProvenanceFile{}
+		}
+		filesJSON, err := json.Marshal(files)
+		if err != nil {
+			return fmt.Errorf("failed to marshal provenance files: %w", err)
+		}
+		_, err = executor.Exec(ctx, query, serverName, version, record.RegistryType, record.Identifier, record.Version,
+			record.Digest, filesJSON, record.UpstreamPublishedAt)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation: the server version doesn't exist
+				return ErrNotFound
+			}
+			return fmt.Errorf("failed to record package provenance: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetPackageProvenance retrieves the recorded provenance of a server version's packages, in the order it was recorded
+func (db *PostgreSQL) GetPackageProvenance(ctx context.Context, tx pgx.Tx, serverName, version string) ([]apiv0.PackageProvenance, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT registry_type, identifier, package_version, digest, files, upstream_published_at, recorded_at
+		FROM package_provenance
+		WHERE server_name = $1 AND version = $2
+		ORDER BY id
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query package provenance: %w", err)
+	}
+	defer rows.Close()
+
+	provenance := []apiv0.PackageProvenance{}
+	for rows.Next() {
+		var record apiv0.PackageProvenance
+		var filesJSON []byte
+		if err := rows.Scan(&record.RegistryType, &record.Identifier, &record.Version, &record.Digest, &filesJSON,
+			&record.UpstreamPublishedAt, &record.RecordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan package provenance: %w", err)
+		}
+		if err := json.Unmarshal(filesJSON, &record.Files); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal provenance files: %w", err)
+		}
+		provenance = append(provenance, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating package provenance: %w", err)
+	}
+	return provenance, nil
+}
+
+// RecordPackageArtifacts stores the artifacts mirrored for a server version's packages. Artifacts that are
+// already recorded for the version, by package and file name, are skipped.
+func (db *PostgreSQL) RecordPackageArtifacts(ctx context.Context, tx pgx.Tx, serverName, version string, artifacts []apiv0.PackageArtifact) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO package_artifacts (server_name, version, registry_type, identifier, filename, sha256, size, mirrored_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, NOW()))
+		ON CONFLICT (server_name, version, registry_type, identifier, filename) DO NOTHING
+	`
+
+	executor := db.getExecutor(tx)
+	for _, artifact := range artifacts {
+		var mirroredAt *time.Time
+		if !artifact.MirroredAt.IsZero() {
+			mirroredAt = &artifact.MirroredAt
+		}
+		_, err := executor.Exec(ctx, query, serverName, version, artifact.RegistryType, artifact.Identifier, artifact.Filename,
+			artifact.SHA256, artifact.Size, mirroredAt)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation: the server version doesn't exist
+				return ErrNotFound
+			}
+			return fmt.Errorf("failed to record package artifact: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetPackageArtifacts retrieves the artifacts mirrored for a server version's packages, in the order they were recorded
+func (db *PostgreSQL) GetPackageArtifacts(ctx context.Context, tx pgx.Tx, serverName, version string) ([]apiv0.PackageArtifact, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT registry_type, identifier, filename, sha256, size, mirrored_at
+		FROM package_artifacts
+		WHERE server_name = $1 AND version = $2
+		ORDER BY id
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query package artifacts: %w", err)
+	}
+	defer rows.Close()
+
+	artifacts := []apiv0.PackageArtifact{}
+	for rows.Next() {
+		var artifact apiv0.PackageArtifact
+		if err := rows.Scan(&artifact.RegistryType, &artifact.Identifier, &artifact.Filename, &artifact.SHA256,
+			&artifact.Size, &artifact.MirroredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan package artifact: %w", err)
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating package artifacts: %w", err)
+	}
+	return artifacts, nil
+}
+
+// HasPackageArtifact reports whether any server version has recorded an artifact with the given SHA-256
+func (db *PostgreSQL) HasPackageArtifact(ctx context.Context, tx pgx.Tx, sha256 string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	var exists bool
+	err := db.getExecutor(tx).QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM package_artifacts WHERE sha256 = $1)`, sha256).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check package artifact: %w", err)
+	}
+	return exists, nil
+}
+
+const artifactMirrorColumns = `id, server_name, version, attempts, error, next_attempt_at, created_at`
+
+func scanArtifactMirror(row pgx.Row) (*ArtifactMirror, error) {
+	var mirror ArtifactMirror
+	err := row.Scan(&mirror.ID, &mirror.ServerName, &mirror.Version, &mirror.Attempts, &mirror.Error,
+		&mirror.NextAttemptAt, &mirror.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &mirror, nil
+}
+
+// QueueArtifactMirror queues the package artifacts of a server version to be mirrored
+func (db *PostgreSQL) QueueArtifactMirror(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO artifact_mirrors (server_name, version)
+		VALUES ($1, $2)
+		ON CONFLICT (server_name, version) DO NOTHING`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, serverName, version); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation: the server version doesn't exist
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to queue artifact mirror: %w", err)
+	}
+	return nil
+}
+
+// NextArtifactMirror retrieves the oldest queued mirror that is due for an attempt
+func (db *PostgreSQL) NextArtifactMirror(ctx context.Context, tx pgx.Tx) (*ArtifactMirror, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + artifactMirrorColumns + ` FROM artifact_mirrors
+		WHERE next_attempt_at <= NOW()
+		ORDER BY id
+		LIMIT 1`
+
+	mirror, err := scanArtifactMirror(db.getExecutor(tx).QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get next artifact mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// RetryArtifactMirror records a failed attempt of a queued mirror and leaves it queued until retryAt
+func (db *PostgreSQL) RetryArtifactMirror(ctx context.Context, tx pgx.Tx, id int64, reason string, retryAt time.Time) (*ArtifactMirror, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE artifact_mirrors
+		SET error = $2, attempts = attempts + 1, next_attempt_at = $3
+		WHERE id = $1
+		RETURNING ` + artifactMirrorColumns
+
+	mirror, err := scanArtifactMirror(db.getExecutor(tx).QueryRow(ctx, query, id, reason, retryAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to retry artifact mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// DeleteArtifactMirror removes a mirror from the queue
+func (db *PostgreSQL) DeleteArtifactMirror(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM artifact_mirrors WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete artifact mirror: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetUpstreamCacheEntry retrieves an unexpired cached upstream response, returning ErrNotFound if there is none
+func (db *PostgreSQL) GetUpstreamCacheEntry(ctx context.Context, tx pgx.Tx, key string) (*UpstreamCacheEntry, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT key, status_code, headers, body, expires_at
+		FROM upstream_cache
+		WHERE key = $1 AND expires_at > NOW()
+	`
+
+	var entry UpstreamCacheEntry
+	var headersJSON []byte
+	err := db.getExecutor(tx).QueryRow(ctx, query, key).Scan(&entry.Key, &entry.StatusCode, &headersJSON, &entry.Body, &entry.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get upstream cache entry: %w", err)
+	}
+	if err := json.Unmarshal(headersJSON, &entry.Header); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal upstream cache headers: %w", err)
+	}
+	return &entry, nil
+}
+
+// PutUpstreamCacheEntry stores a cached upstream response, replacing any entry with the same key
+func (db *PostgreSQL) PutUpstreamCacheEntry(ctx context.Context, tx pgx.Tx, entry UpstreamCacheEntry) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	header := entry.Header
+	if header == nil {
+		header = map[string][]string{}
+	}
+	headersJSON, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("failed to marshal upstream cache headers: %w", err)
+	}
+	body := entry.Body
+	if body == nil {
+		body = []byte{}
+	}
+
+	query := `
+		INSERT INTO upstream_cache (key, status_code, headers, body, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (key) DO UPDATE SET
+			status_code = EXCLUDED.status_code,
+			headers = EXCLUDED.headers,
+			body = EXCLUDED.body,
+			expires_at = EXCLUDED.expires_at
+	`
+	if _, err := db.getExecutor(tx).Exec(ctx, query, entry.Key, entry.StatusCode, headersJSON, body, entry.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to store upstream cache entry: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredUpstreamCacheEntries removes cached upstream responses that expired before the given time and returns how many were removed
+func (db *PostgreSQL) DeleteExpiredUpstreamCacheEntries(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM upstream_cache WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired upstream cache entries: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// PutRemoteHealth stores the latest probe result of a remote endpoint, replacing any earlier result for its URL
+func (db *PostgreSQL) PutRemoteHealth(ctx context.Context, tx pgx.Tx, health apiv0.RemoteHealth) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO remote_health (url, status, protocol_version, latency_ms, error, checked_at, last_healthy_at, failing_since)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (url) DO UPDATE SET
+			status = EXCLUDED.status,
+			protocol_version = EXCLUDED.protocol_version,
+			latency_ms = EXCLUDED.latency_ms,
+			error = EXCLUDED.error,
+			checked_at = EXCLUDED.checked_at,
+			last_healthy_at = EXCLUDED.last_healthy_at,
+			failing_since = EXCLUDED.failing_since
+	`
+	_, err := db.getExecutor(tx).Exec(ctx, query, health.URL, health.Status, health.ProtocolVersion, health.LatencyMs,
+		health.Error, health.CheckedAt, health.LastHealthyAt, health.FailingSince)
+	if err != nil {
+		return fmt.Errorf("failed to store remote health: %w", constraintViolation(err))
+	}
+	return nil
+}
+
+// GetRemoteHealth retrieves the latest probe results of the given remote URLs, keyed by URL. URLs that were never probed are omitted.
+func (db *PostgreSQL) GetRemoteHealth(ctx context.Context, tx pgx.Tx, urls []string) (map[string]apiv0.RemoteHealth, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT url, status, protocol_version, latency_ms, error, checked_at, last_healthy_at, failing_since
+		FROM remote_health
+		WHERE url = ANY($1)
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, urls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query remote health: %w", err)
+	}
+	defer rows.Close()
+
+	results := make(map[string]apiv0.RemoteHealth, len(urls))
+	for rows.Next() {
+		var health apiv0.RemoteHealth
+		if err := rows.Scan(&health.URL, &health.Status, &health.ProtocolVersion, &health.LatencyMs, &health.Error,
+			&health.CheckedAt, &health.LastHealthyAt, &health.FailingSince); err != nil {
+			return nil, fmt.Errorf("failed to scan remote health: %w", err)
+		}
+		results[health.URL] = health
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating remote health: %w", err)
+	}
+	return results, nil
+}
+
+// DeleteRemoteHealthCheckedBefore removes probe results last checked before the given time and returns how many were removed
+func (db *PostgreSQL) DeleteRemoteHealthCheckedBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM remote_health WHERE checked_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete remote health: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// PutScreeningException exempts a server from screening, replacing any existing exception for it
+func (db *PostgreSQL) PutScreeningException(ctx context.Context, tx pgx.Tx, exception ScreeningException) (*ScreeningException, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO screening_exceptions (server_name, reason, created_by, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (server_name) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			created_by = EXCLUDED.created_by,
+			created_at = EXCLUDED.created_at
+		RETURNING server_name, reason, created_by, created_at
+	`
+	var stored ScreeningException
+	err := db.getExecutor(tx).QueryRow(ctx, query, exception.ServerName, exception.Reason, exception.CreatedBy).
+		Scan(&stored.ServerName, &stored.Reason, &stored.CreatedBy, &stored.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store screening exception: %w", constraintViolation(err))
+	}
+	return &stored, nil
+}
+
+// GetScreeningException retrieves the screening exception of a server
+func (db *PostgreSQL) GetScreeningException(ctx context.Context, tx pgx.Tx, serverName string) (*ScreeningException, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT server_name, reason, created_by, created_at FROM screening_exceptions WHERE server_name = $1`
+	var exception ScreeningException
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).
+		Scan(&exception.ServerName, &exception.Reason, &exception.CreatedBy, &exception.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get screening exception: %w", err)
+	}
+	return &exception, nil
+}
+
+// ListScreeningExceptions retrieves all screening exceptions, ordered by server name
+func (db *PostgreSQL) ListScreeningExceptions(ctx context.Context, tx pgx.Tx) ([]*ScreeningException, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT server_name, reason, created_by, created_at FROM screening_exceptions ORDER BY server_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query screening exceptions: %w", err)
+	}
+	defer rows.Close()
+
+	exceptions := []*ScreeningException{}
+	for rows.Next() {
+		var exception ScreeningException
+		if err := rows.Scan(&exception.ServerName, &exception.Reason, &exception.CreatedBy, &exception.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan screening exception: %w", err)
+		}
+		exceptions = append(exceptions, &exception)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating screening exceptions: %w", err)
+	}
+	return exceptions, nil
+}
+
+// DeleteScreeningException removes the screening exception of a server
+func (db *PostgreSQL) DeleteScreeningException(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM screening_exceptions WHERE server_name = $1`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to delete screening exception: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const serverCurationColumns = `server_name, labels, position, note, updated_by, updated_at`
+
+func scanServerCuration(row pgx.Row) (*ServerCuration, error) {
+	var curation ServerCuration
+	if err := row.Scan(&curation.ServerName, &curation.Labels, &curation.Position, &curation.Note, &curation.UpdatedBy, &curation.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &curation, nil
+}
+
+// PutIdentityBan bans an identity, replacing any existing ban of it
+func (db *PostgreSQL) PutIdentityBan(ctx context.Context, tx pgx.Tx, ban IdentityBan) (*IdentityBan, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO identity_bans (kind, value, reason, expires_at, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (kind, value) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			expires_at = EXCLUDED.expires_at,
+			created_by = EXCLUDED.created_by,
+			created_at = EXCLUDED.created_at
+		RETURNING kind, value, reason, expires_at, created_by, created_at
+	`
+	var stored IdentityBan
+	err := db.getExecutor(tx).QueryRow(ctx, query, ban.Kind, ban.Value, ban.Reason, ban.ExpiresAt, ban.CreatedBy).
+		Scan(&stored.Kind, &stored.Value, &stored.Reason, &stored.ExpiresAt, &stored.CreatedBy, &stored.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store identity ban: %w", constraintViolation(err))
+	}
+	return &stored, nil
+}
+
+// ListIdentityBans retrieves all identity bans, including expired ones, ordered by kind and value
+func (db *PostgreSQL) ListIdentityBans(ctx context.Context, tx pgx.Tx) ([]*IdentityBan, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `
+		SELECT kind, value, reason, expires_at, created_by, created_at FROM identity_bans ORDER BY kind, value
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query identity bans: %w", err)
+	}
+	defer rows.Close()
+
+	bans := []*IdentityBan{}
+	for rows.Next() {
+		var ban IdentityBan
+		if err := rows.Scan(&ban.Kind, &ban.Value, &ban.Reason, &ban.ExpiresAt, &ban.CreatedBy, &ban.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan identity ban: %w", err)
+		}
+		bans = append(bans, &ban)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating identity bans: %w", err)
+	}
+	return bans, nil
+}
+
+// FindIdentityBans retrieves the bans, including expired ones, selected by query, ordered by kind and value
+func (db *PostgreSQL) FindIdentityBans(ctx context.Context, tx pgx.Tx, query IdentityBanQuery) ([]*IdentityBan, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `
+		SELECT kind, value, reason, expires_at, created_by, created_at
+		FROM identity_bans
+		WHERE (kind = 'github' AND lower(value) = ANY($1))
+			OR (kind = 'oidc' AND value = ANY($2))
+			OR (kind = 'namespace' AND lower(value) = ANY($3))
+			OR (kind IN ('github', 'namespace') AND EXISTS (
+				SELECT 1 FROM unnest($4::text[]) AS prefix
+				WHERE starts_with(lower(CASE WHEN kind = 'github' THEN 'io.github.' || value ELSE value END) || '/', prefix)
+			))
+		ORDER BY kind, value
+	`, nonNilStrings(query.GitHub), nonNilStrings(query.OIDC), nonNilStrings(query.Namespaces), nonNilStrings(query.Prefixes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query identity bans: %w", err)
+	}
+	defer rows.Close()
+
+	bans := []*IdentityBan{}
+	for rows.Next() {
+		var ban IdentityBan
+		if err := rows.Scan(&ban.Kind, &ban.Value, &ban.Reason, &ban.ExpiresAt, &ban.CreatedBy, &ban.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan identity ban: %w", err)
+		}
+		bans = append(bans, &ban)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating identity bans: %w", err)
+	}
+	return bans, nil
+}
+
+// DeleteIdentityBan lifts the ban of an identity
+func (db *PostgreSQL) DeleteIdentityBan(ctx context.Context, tx pgx.Tx, kind, value string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM identity_bans WHERE kind = $1 AND value = $2`, kind, value)
+	if err != nil {
+		return fmt.Errorf("failed to delete identity ban: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// QuarantineServer records the prior status of every active or deprecated version of a server, and of versions
+// other bans already quarantined, against a ban and sets the active and deprecated ones to quarantined with
+// statusMessage. It returns the versions whose status changed.
+func (db *PostgreSQL) QuarantineServer(ctx context.Context, tx pgx.Tx, serverName, banKind, banValue string, statusMessage *string) ([]*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// Versions other bans already quarantined keep the status recorded by the first of them
+	query := `
+		WITH recorded AS (
+			INSERT INTO quarantined_versions (server_name, version, ban_kind, ban_value, prior_status, prior_status_message)
+			SELECT s.server_name, s.version, $2, $3,
+				CASE WHEN s.status = 'quarantined' THEN q.prior_status ELSE s.status END,
+				CASE WHEN s.status = 'quarantined' THEN q.prior_status_message ELSE s.status_message END
+			FROM servers s
+			LEFT JOIN LATERAL (
+				SELECT prior_status, prior_status_message FROM quarantined_versions
+				WHERE server_name = s.server_name AND version = s.version
+				ORDER BY quarantined_at
+				LIMIT 1
+			) q ON TRUE
+			WHERE s.server_name = $1
+				AND (s.status IN ('active', 'deprecated') OR (s.status = 'quarantined' AND q.prior_status IS NOT NULL))
+			ON CONFLICT DO NOTHING
+		)
+		UPDATE servers
+		SET
+			status = 'quarantined',
+			status_changed_at = NOW(),
+			updated_at = NOW(),
+			status_message = $4
+		WHERE server_name = $1 AND status IN ('active', 'deprecated')
+		RETURNING server_name, version, status, value, published_at, updated_at, is_latest, status_changed_at, status_message, publisher
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverName, banKind, banValue, statusMessage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to quarantine server: %w", constraintViolation(err))
+	}
+	return scanStatusRows(rows)
+}
+
+// ListQuarantinedVersions retrieves the quarantine records of a ban, ordered by server name and version
+func (db *PostgreSQL) ListQuarantinedVersions(ctx context.Context, tx pgx.Tx, banKind, banValue string) ([]*QuarantinedVersion, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `
+		SELECT server_name, version, ban_kind, ban_value, prior_status, prior_status_message, quarantined_at
+		FROM quarantined_versions
+		WHERE ban_kind = $1 AND ban_value = $2
+		ORDER BY server_name, version
+	`, banKind, banValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quarantined versions: %w", err)
+	}
+	defer rows.Close()
+
+	records := []*QuarantinedVersion{}
+	for rows.Next() {
+		var record QuarantinedVersion
+		var priorStatus string
+		if err := rows.Scan(&record.ServerName, &record.Version, &record.BanKind, &record.BanValue, &priorStatus, &record.PriorStatusMessage, &record.QuarantinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan quarantined version: %w", err)
+		}
+		record.PriorStatus = model.Status(priorStatus)
+		records = append(records, &record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating quarantined versions: %w", err)
+	}
+	return records, nil
+}
+
+// ReleaseQuarantine deletes the quarantine records of a ban and restores the prior status and message of the
+// versions no other ban holds that are still quarantined. It returns the restored versions.
+func (db *PostgreSQL) ReleaseQuarantine(ctx context.Context, tx pgx.Tx, banKind, banValue string) ([]*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// The statement sees the records as they were before the delete, so the released ones are excluded by hand
+	query := `
+		WITH released AS (
+			DELETE FROM quarantined_versions
+			WHERE ban_kind = $1 AND ban_value = $2
+			RETURNING server_name, version, prior_status, prior_status_message
+		)
+		UPDATE servers s
+		SET
+			status = r.prior_status,
+			status_changed_at = NOW(),
+			updated_at = NOW(),
+			status_message = r.prior_status_message
+		FROM released r
+		WHERE s.server_name = r.server_name AND s.version = r.version AND s.status = 'quarantined'
+			AND NOT EXISTS (
+				SELECT 1 FROM quarantined_versions q
+				WHERE q.server_name = r.server_name AND q.version = r.version AND (q.ban_kind, q.ban_value) <> ($1, $2)
+			)
+		RETURNING s.server_name, s.version, s.status, s.value, s.published_at, s.updated_at, s.is_latest, s.status_changed_at, s.status_message, s.publisher
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, banKind, banValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release quarantine: %w", constraintViolation(err))
+	}
+	return scanStatusRows(rows)
+}
+
+// PutServerCuration sets the curation of a server, replacing any existing curation for it
+func (db *PostgreSQL) PutServerCuration(ctx context.Context, tx pgx.Tx, curation ServerCuration) (*ServerCuration, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_curation (server_name, labels, position, note, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (server_name) DO UPDATE SET
+			labels = EXCLUDED.labels,
+			position = EXCLUDED.position,
+			note = EXCLUDED.note,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING ` + serverCurationColumns
+	stored, err := scanServerCuration(db.getExecutor(tx).QueryRow(ctx, query,
+		curation.ServerName, curation.Labels, curation.Position, curation.Note, curation.UpdatedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store server curation: %w", constraintViolation(err))
+	}
+	return stored, nil
+}
+
+// GetServerCurations retrieves the curation of each given server that has one, keyed by server name
+func (db *PostgreSQL) GetServerCurations(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string]ServerCuration, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	curations := map[string]ServerCuration{}
+	if len(serverNames) == 0 {
+		return curations, nil
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT `+serverCurationColumns+` FROM server_curation WHERE server_name = ANY($1)`, serverNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server curations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		curation, err := scanServerCuration(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server curation: %w", err)
+		}
+		curations[curation.ServerName] = *curation
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server curations: %w", err)
+	}
+	return curations, nil
+}
+
+// ListServerCurations retrieves curated servers with label, or all curated servers if label is empty
+func (db *PostgreSQL) ListServerCurations(ctx context.Context, tx pgx.Tx, label string) ([]*ServerCuration, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + serverCurationColumns + `
+		FROM server_curation
+		WHERE $1 = '' OR $1 = ANY(labels)
+		ORDER BY position NULLS LAST, server_name
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server curations: %w", err)
+	}
+	defer rows.Close()
+
+	curations := []*ServerCuration{}
+	for rows.Next() {
+		curation, err := scanServerCuration(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server curation: %w", err)
+		}
+		curations = append(curations, curation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server curations: %w", err)
+	}
+	return curations, nil
+}
+
+// DeleteServerCuration removes the curation of a server
+func (db *PostgreSQL) DeleteServerCuration(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM server_curation WHERE server_name = $1`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to delete server curation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// PutRepositoryURL records the canonical form of a repository URL servers link, replacing any earlier one
+func (db *PostgreSQL) PutRepositoryURL(ctx context.Context, tx pgx.Tx, rawURL, canonicalURL string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO repository_urls (raw_url, canonical_url)
+		VALUES ($1, $2)
+		ON CONFLICT (raw_url) DO UPDATE SET canonical_url = EXCLUDED.canonical_url, resolved_at = NOW()
+	`
+	if _, err := db.getExecutor(tx).Exec(ctx, query, rawURL, canonicalURL); err != nil {
+		return fmt.Errorf("failed to store repository URL: %w", err)
+	}
+	return nil
+}
+
+// RecordTokenUse records that an identity token was exchanged until it expires, forgetting expired tokens
+func (db *PostgreSQL) RecordTokenUse(ctx context.Context, tx pgx.Tx, key string, expiresAt time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+	if _, err := executor.Exec(ctx, `DELETE FROM token_uses WHERE expires_at <= NOW()`); err != nil {
+		return fmt.Errorf("failed to prune token uses: %w", err)
+	}
+	// A token is recorded again only once its earlier record has expired
+	query := `
+		INSERT INTO token_uses (key, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET expires_at = EXCLUDED.expires_at
+		WHERE token_uses.expires_at <= NOW()
+	`
+	result, err := executor.Exec(ctx, query, key, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to record token use: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+const serverStalenessColumns = `server_name, reasons, detected_at, notified_at, unmaintained_at`
+
+func scanServerStaleness(row pgx.Row) (*ServerStaleness, error) {
+	var staleness ServerStaleness
+	if err := row.Scan(&staleness.ServerName, &staleness.Reasons, &staleness.DetectedAt, &staleness.NotifiedAt, &staleness.UnmaintainedAt); err != nil {
+		return nil, err
+	}
+	return &staleness, nil
+}
+
+// PutServerStaleness records a stale server, replacing any earlier record for it
+func (db *PostgreSQL) PutServerStaleness(ctx context.Context, tx pgx.Tx, staleness ServerStaleness) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var detectedAt *time.Time
+	if !staleness.DetectedAt.IsZero() {
+		detectedAt = &staleness.DetectedAt
+	}
+	query := `
+		INSERT INTO server_staleness (server_name, reasons, detected_at, notified_at, unmaintained_at)
+		VALUES ($1, $2, COALESCE($3, NOW()), $4, $5)
+		ON CONFLICT (server_name) DO UPDATE SET
+			reasons = EXCLUDED.reasons,
+			detected_at = EXCLUDED.detected_at,
+			notified_at = EXCLUDED.notified_at,
+			unmaintained_at = EXCLUDED.unmaintained_at
+	`
+	_, err := db.getExecutor(tx).Exec(ctx, query,
+		staleness.ServerName, staleness.Reasons, detectedAt, staleness.NotifiedAt, staleness.UnmaintainedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store server staleness: %w", constraintViolation(err))
+	}
+	return nil
+}
+
+// GetServerStaleness retrieves the staleness of each given server that is stale, keyed by server name
+func (db *PostgreSQL) GetServerStaleness(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string]ServerStaleness, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	stale := map[string]ServerStaleness{}
+	if len(serverNames) == 0 {
+		return stale, nil
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT `+serverStalenessColumns+` FROM server_staleness WHERE server_name = ANY($1)`, serverNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server staleness: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		staleness, err := scanServerStaleness(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server staleness: %w", err)
+		}
+		stale[staleness.ServerName] = *staleness
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server staleness: %w", err)
+	}
+	return stale, nil
+}
+
+// ListServerStaleness retrieves every stale server, ordered by when it was detected, then by server name
+func (db *PostgreSQL) ListServerStaleness(ctx context.Context, tx pgx.Tx) ([]*ServerStaleness, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT `+serverStalenessColumns+` FROM server_staleness ORDER BY detected_at, server_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server staleness: %w", err)
+	}
+	defer rows.Close()
+
+	stale := []*ServerStaleness{}
+	for rows.Next() {
+		staleness, err := scanServerStaleness(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server staleness: %w", err)
+		}
+		stale = append(stale, staleness)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server staleness: %w", err)
+	}
+	return stale, nil
+}
+
+// DeleteServerStaleness removes the staleness record of a server
+func (db *PostgreSQL) DeleteServerStaleness(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM server_staleness WHERE server_name = $1`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to delete server staleness: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const serverToolsColumns = `server_name, version, source, tools, indexed_at`
+
+func scanServerTools(row pgx.Row) (*ServerTools, error) {
+	var tools ServerTools
+	var toolsJSON []byte
+	if err := row.Scan(&tools.ServerName, &tools.Version, &tools.Source, &toolsJSON, &tools.IndexedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(toolsJSON, &tools.Tools); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tools: %w", err)
+	}
+	return &tools, nil
+}
+
+// PutServerTools stores the indexed tools of a server, replacing any earlier index of it
+func (db *PostgreSQL) PutServerTools(ctx context.Context, tx pgx.Tx, tools ServerTools) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	toolsJSON, err := json.Marshal(tools.Tools)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tools: %w", err)
+	}
+	query := `
+		INSERT INTO server_tools (server_name, version, source, tools, search_text, indexed_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (server_name) DO UPDATE SET
+			version = EXCLUDED.version,
+			source = EXCLUDED.source,
+			tools = EXCLUDED.tools,
+			search_text = EXCLUDED.search_text,
+			indexed_at = EXCLUDED.indexed_at
+	`
+	_, err = db.getExecutor(tx).Exec(ctx, query, tools.ServerName, tools.Version, tools.Source, toolsJSON, toolSearchText(tools.Tools))
+	if err != nil {
+		return fmt.Errorf("failed to store server tools: %w", constraintViolation(err))
+	}
+	return nil
+}
+
+// GetServerTools retrieves the indexed tools of a server
+func (db *PostgreSQL) GetServerTools(ctx context.Context, tx pgx.Tx, serverName string) (*ServerTools, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	tools, err := scanServerTools(db.getExecutor(tx).QueryRow(ctx, `SELECT `+serverToolsColumns+` FROM server_tools WHERE server_name = $1`, serverName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server tools: %w", err)
+	}
+	return tools, nil
+}
+
+// DeleteServerTools removes the indexed tools of a server
+func (db *PostgreSQL) DeleteServerTools(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM server_tools WHERE server_name = $1`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to delete server tools: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const orgAPIKeyColumns = `id, organization, name, namespaces, key_hash, key_prefix, created_by, created_at, rotated_at, last_used_at`
+
+func scanOrgAPIKey(row pgx.Row) (*OrgAPIKey, error) {
+	var key OrgAPIKey
+	err := row.Scan(&key.ID, &key.Organization, &key.Name, &key.Namespaces, &key.KeyHash, &key.KeyPrefix,
+		&key.CreatedBy, &key.CreatedAt, &key.RotatedAt, &key.LastUsedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// CreateOrgAPIKey stores an organization API key, assigning its ID and creation time. Key names are unique
+// within an organization.
+func (db *PostgreSQL) CreateOrgAPIKey(ctx context.Context, tx pgx.Tx, key OrgAPIKey) (*OrgAPIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO org_api_keys (organization, name, namespaces, key_hash, key_prefix, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + orgAPIKeyColumns
+
+	created, err := scanOrgAPIKey(db.getExecutor(tx).QueryRow(ctx, query, key.Organization, key.Name, key.Namespaces,
+		key.KeyHash, key.KeyPrefix, key.CreatedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization API key: %w", constraintViolation(err))
+	}
+	return created, nil
+}
+
+// GetOrgAPIKey retrieves an organization API key by ID
+func (db *PostgreSQL) GetOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64) (*OrgAPIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	key, err := scanOrgAPIKey(db.getExecutor(tx).QueryRow(ctx, `SELECT `+orgAPIKeyColumns+` FROM org_api_keys WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization API key: %w", err)
+	}
+	return key, nil
+}
+
+// GetOrgAPIKeyByHash retrieves the organization API key with the given key hash
+func (db *PostgreSQL) GetOrgAPIKeyByHash(ctx context.Context, tx pgx.Tx, keyHash string) (*OrgAPIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	key, err := scanOrgAPIKey(db.getExecutor(tx).QueryRow(ctx, `SELECT `+orgAPIKeyColumns+` FROM org_api_keys WHERE key_hash = $1`, keyHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization API key: %w", err)
+	}
+	return key, nil
+}
+
+// ListOrgAPIKeys retrieves an organization's API keys, oldest first
+func (db *PostgreSQL) ListOrgAPIKeys(ctx context.Context, tx pgx.Tx, organization string) ([]*OrgAPIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT `+orgAPIKeyColumns+` FROM org_api_keys WHERE organization = $1 ORDER BY id`, organization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query organization API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*OrgAPIKey{}
+	for rows.Next() {
+		key, err := scanOrgAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization API key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating organization API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RotateOrgAPIKey replaces the key of an organization API key and records when it was rotated
+func (db *PostgreSQL) RotateOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64, keyHash, keyPrefix string) (*OrgAPIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE org_api_keys SET key_hash = $2, key_prefix = $3, rotated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + orgAPIKeyColumns
+
+	key, err := scanOrgAPIKey(db.getExecutor(tx).QueryRow(ctx, query, id, keyHash, keyPrefix))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to rotate organization API key: %w", constraintViolation(err))
+	}
+	return key, nil
+}
+
+// TouchOrgAPIKey records when an organization API key was last used
+func (db *PostgreSQL) TouchOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64, usedAt time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `UPDATE org_api_keys SET last_used_at = $2 WHERE id = $1`, id, usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record organization API key use: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteOrgAPIKey permanently removes an organization API key
+func (db *PostgreSQL) DeleteOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM org_api_keys WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization API key: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const orgMemberColumns = `id, organization, subject, added_by, created_at`
+
+func scanOrgMember(row pgx.Row) (*OrgMember, error) {
+	var member OrgMember
+	if err := row.Scan(&member.ID, &member.Organization, &member.Subject, &member.AddedBy, &member.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// CreateOrgMember adds a member to an organization, assigning its ID and creation time. Subjects are unique
+// within an organization.
+func (db *PostgreSQL) CreateOrgMember(ctx context.Context, tx pgx.Tx, member OrgMember) (*OrgMember, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO org_members (organization, subject, added_by)
+		VALUES ($1, $2, $3)
+		RETURNING ` + orgMemberColumns
+
+	created, err := scanOrgMember(db.getExecutor(tx).QueryRow(ctx, query, member.Organization, member.Subject, member.AddedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization member: %w", constraintViolation(err))
+	}
+	return created, nil
+}
+
+// GetOrgMember retrieves an organization member by ID
+func (db *PostgreSQL) GetOrgMember(ctx context.Context, tx pgx.Tx, id int64) (*OrgMember, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	member, err := scanOrgMember(db.getExecutor(tx).QueryRow(ctx, `SELECT `+orgMemberColumns+` FROM org_members WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	return member, nil
+}
+
+// GetOrgMemberBySubject retrieves the member of an organization with the given subject
+func (db *PostgreSQL) GetOrgMemberBySubject(ctx context.Context, tx pgx.Tx, organization, subject string) (*OrgMember, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + orgMemberColumns + ` FROM org_members WHERE organization = $1 AND subject = $2`
+	member, err := scanOrgMember(db.getExecutor(tx).QueryRow(ctx, query, organization, subject))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	return member, nil
+}
+
+// ListOrgMembers retrieves an organization's members, oldest first
+func (db *PostgreSQL) ListOrgMembers(ctx context.Context, tx pgx.Tx, organization string) ([]*OrgMember, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT `+orgMemberColumns+` FROM org_members WHERE organization = $1 ORDER BY id`, organization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query organization members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []*OrgMember{}
+	for rows.Next() {
+		member, err := scanOrgMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating organization members: %w", err)
+	}
+	return members, nil
+}
+
+// DeleteOrgMember removes an organization member
+func (db *PostgreSQL) DeleteOrgMember(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM org_members WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const bulkJobColumns = `id, kind, params, state, total, processed, failed, failures, error, created_by, created_at, started_at, finished_at, updated_at`
+
+func scanBulkJob(row pgx.Row) (*BulkJob, error) {
+	var job BulkJob
+	var paramsJSON, failuresJSON []byte
+	err := row.Scan(&job.ID, &job.Kind, &paramsJSON, &job.State, &job.Total, &job.Processed, &job.Failed, &failuresJSON,
+		&job.Error, &job.CreatedBy, &job.CreatedAt, &job.StartedAt, &job.FinishedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(paramsJSON, &job.Params); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bulk job params: %w", err)
+	}
+	if err := json.Unmarshal(failuresJSON, &job.Failures); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bulk job failures: %w", err)
+	}
+	if len(job.Failures) == 0 {
+		job.Failures = nil
+	}
+
+	return &job, nil
+}
+
+// CreateBulkJob queues a bulk job, assigning its ID and creation time
+func (db *PostgreSQL) CreateBulkJob(ctx context.Context, tx pgx.Tx, job BulkJob) (*BulkJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	paramsJSON, err := json.Marshal(job.Params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bulk job params: %w", err)
+	}
+
+	query := `
+		INSERT INTO bulk_jobs (kind, params, state, created_by)
+		VALUES ($1, $2, 'queued', $3)
+		RETURNING ` + bulkJobColumns
+
+	created, err := scanBulkJob(db.getExecutor(tx).QueryRow(ctx, query, job.Kind, paramsJSON, job.CreatedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk job: %w", constraintViolation(err))
+	}
+
+	return created, nil
+}
+
+// GetBulkJob retrieves a bulk job by ID
+func (db *PostgreSQL) GetBulkJob(ctx context.Context, tx pgx.Tx, id int64) (*BulkJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + bulkJobColumns + ` FROM bulk_jobs WHERE id = $1`
+
+	job, err := scanBulkJob(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get bulk job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ListBulkJobs retrieves the most recently queued bulk jobs, newest first
+func (db *PostgreSQL) ListBulkJobs(ctx context.Context, tx pgx.Tx, limit int) ([]*BulkJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + bulkJobColumns + ` FROM bulk_jobs ORDER BY id DESC LIMIT $1`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bulk jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*BulkJob{}
+	for rows.Next() {
+		job, err := scanBulkJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bulk job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bulk jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// ClaimBulkJob marks the oldest queued or running bulk job as running and returns it
+func (db *PostgreSQL) ClaimBulkJob(ctx context.Context, tx pgx.Tx) (*BulkJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// An interrupted job restarts from scratch, so its progress is reset
+	query := `
+		UPDATE bulk_jobs
+		SET state = 'running', total = 0, processed = 0, failed = 0, failures = '[]'::jsonb,
+			started_at = NOW(), updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM bulk_jobs
+			WHERE state IN ('queued', 'running')
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + bulkJobColumns
+
+	job, err := scanBulkJob(db.getExecutor(tx).QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to claim bulk job: %w", err)
+	}
+
+	return job, nil
+}
+
+// UpdateBulkJob records a bulk job's state and progress
+func (db *PostgreSQL) UpdateBulkJob(ctx context.Context, tx pgx.Tx, job *BulkJob) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	failures := job.Failures
+	if failures == nil {
+		failures = []BulkJobFailure{}
+	}
+	failuresJSON, err := json.Marshal(failures)
+	if err != nil {
+		return fmt.Errorf("failed to marshal bulk job failures: %w", err)
+	}
+
+	query := `
+		UPDATE bulk_jobs
+		SET state = $2, total = $3, processed = $4, failed = $5, failures = $6, error = $7, finished_at = $8, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err = db.getExecutor(tx).QueryRow(ctx, query, job.ID, job.State, job.Total, job.Processed, job.Failed, failuresJSON, job.Error, job.FinishedAt).Scan(&job.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update bulk job: %w", constraintViolation(err))
+	}
+
+	return nil
+}
+
+const pendingPublishColumns = `id, server_name, version, state, error, attempts, next_attempt_at, value, document, publisher, submitted_by, created_at, finished_at`
+
+func scanPendingPublish(row pgx.Row) (*PendingPublish, error) {
+	var publish PendingPublish
+	var valueJSON []byte
+	err := row.Scan(&publish.ID, &publish.ServerName, &publish.Version, &publish.State, &publish.Error, &publish.Attempts,
+		&publish.NextAttemptAt, &valueJSON, &publish.Document, &publish.Publisher, &publish.SubmittedBy, &publish.CreatedAt,
+		&publish.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(valueJSON, &publish.Server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pending publish server: %w", err)
+	}
+
+	return &publish, nil
+}
+
+// CreatePendingPublish stores an accepted publish as pending, assigning its ID and creation time
+func (db *PostgreSQL) CreatePendingPublish(ctx context.Context, tx pgx.Tx, publish PendingPublish) (*PendingPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	valueJSON, err := json.Marshal(publish.Server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+
+	query := `
+		INSERT INTO pending_publishes (server_name, version, value, document, publisher, submitted_by, state)
+		VALUES ($1, $2, $3, $4, $5, $6, 'pending')
+		RETURNING ` + pendingPublishColumns
+
+	created, err := scanPendingPublish(db.getExecutor(tx).QueryRow(ctx, query,
+		publish.Server.Name, publish.Server.Version, valueJSON, publish.Document, publish.Publisher, publish.SubmittedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pending publish: %w", constraintViolation(err))
+	}
+
+	return created, nil
+}
+
+// GetPendingPublish retrieves an accepted publish by ID
+func (db *PostgreSQL) GetPendingPublish(ctx context.Context, tx pgx.Tx, id int64) (*PendingPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + pendingPublishColumns + ` FROM pending_publishes WHERE id = $1`
+
+	publish, err := scanPendingPublish(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get pending publish: %w", err)
+	}
+
+	return publish, nil
+}
+
+// NextPendingPublish retrieves the oldest publish that is still pending and due for an attempt
+func (db *PostgreSQL) NextPendingPublish(ctx context.Context, tx pgx.Tx) (*PendingPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + pendingPublishColumns + ` FROM pending_publishes
+		WHERE state = 'pending' AND next_attempt_at <= NOW()
+		ORDER BY id
+		LIMIT 1`
+
+	publish, err := scanPendingPublish(db.getExecutor(tx).QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get next pending publish: %w", err)
+	}
+
+	return publish, nil
+}
+
+// FinishPendingPublish records that a pending publish became active, or was rejected with a reason
+func (db *PostgreSQL) FinishPendingPublish(ctx context.Context, tx pgx.Tx, id int64, state, reason string) (*PendingPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE pending_publishes
+		SET state = $2, error = $3, finished_at = NOW()
+		WHERE id = $1 AND state = 'pending'
+		RETURNING ` + pendingPublishColumns
+
+	publish, err := scanPendingPublish(db.getExecutor(tx).QueryRow(ctx, query, id, state, reason))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to finish pending publish: %w", constraintViolation(err))
+	}
+
+	return publish, nil
+}
+
+// RetryPendingPublish records a failed attempt of a pending publish and leaves it pending until retryAt
+func (db *PostgreSQL) RetryPendingPublish(ctx context.Context, tx pgx.Tx, id int64, reason string, retryAt time.Time) (*PendingPublish, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE pending_publishes
+		SET error = $2, attempts = attempts + 1, next_attempt_at = $3
+		WHERE id = $1 AND state = 'pending'
+		RETURNING ` + pendingPublishColumns
+
+	publish, err := scanPendingPublish(db.getExecutor(tx).QueryRow(ctx, query, id, reason, retryAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to retry pending publish: %w", err)
+	}
+
+	return publish, nil
+}
+
+// DeletePendingPublishesBefore permanently removes publishes that finished before the given time and returns how many were removed
+func (db *PostgreSQL) DeletePendingPublishesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM pending_publishes WHERE state <> 'pending' AND finished_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete pending publishes: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+const serverAliasColumns = `alias, server_name, created_by, created_at, expires_at`
+
+func scanServerAlias(row pgx.Row) (*ServerAlias, error) {
+	var alias ServerAlias
+	if err := row.Scan(&alias.Alias, &alias.ServerName, &alias.CreatedBy, &alias.CreatedAt, &alias.ExpiresAt); err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+// CreateServerAlias stores an alias, replacing any alias with the same name, and records the rename in the
+// changes feed against the latest version of the server it points to
+func (db *PostgreSQL) CreateServerAlias(ctx context.Context, tx pgx.Tx, alias ServerAlias) (*ServerAlias, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	executor := db.getExecutor(tx)
+
+	// The change carries the state of the latest version, like the changes the servers trigger records
+	result, err := executor.Exec(ctx, `
+		INSERT INTO server_changes (
+			server_name, version, change_type, previous_name,
+			status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher, runtimes
+		)
+		SELECT server_name, version, 'renamed', $2,
+			status, status_changed_at, status_message, published_at, updated_at, is_latest, value, publisher, runtimes
+		FROM servers
+		WHERE server_name = $1 AND is_latest = true
+	`, alias.ServerName, alias.Alias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record server rename: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrNotFound
+	}
+
+	query := `
+		INSERT INTO server_aliases (alias, server_name, created_by, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (alias) DO UPDATE
+		SET server_name = EXCLUDED.server_name, created_by = EXCLUDED.created_by, created_at = NOW(), expires_at = EXCLUDED.expires_at
+		RETURNING ` + serverAliasColumns
+
+	created, err := scanServerAlias(executor.QueryRow(ctx, query, alias.Alias, alias.ServerName, alias.CreatedBy, alias.ExpiresAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server alias: %w", constraintViolation(err))
+	}
+
+	return created, nil
+}
+
+// GetServerAlias retrieves an alias that has not expired
+func (db *PostgreSQL) GetServerAlias(ctx context.Context, tx pgx.Tx, alias string) (*ServerAlias, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + serverAliasColumns + ` FROM server_aliases WHERE alias = $1 AND expires_at > NOW()`
+
+	found, err := scanServerAlias(db.getExecutor(tx).QueryRow(ctx, query, alias))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server alias: %w", err)
+	}
+
+	return found, nil
+}
+
+// ListServerAliases retrieves the aliases pointing at a server that have not expired, ordered by alias
+func (db *PostgreSQL) ListServerAliases(ctx context.Context, tx pgx.Tx, serverName string) ([]*ServerAlias, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + serverAliasColumns + ` FROM server_aliases WHERE server_name = $1 AND expires_at > NOW() ORDER BY alias`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list server aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []*ServerAlias
+	for rows.Next() {
+		alias, err := scanServerAlias(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server alias row: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server alias rows: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// RetargetServerAliases points the aliases of a server at its new name, returning how many were moved
+func (db *PostgreSQL) RetargetServerAliases(ctx context.Context, tx pgx.Tx, serverName, newName string) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	// An alias of the new name would point at itself, so it is dropped rather than moved
+	result, err := db.getExecutor(tx).Exec(ctx, `
+		WITH dropped AS (DELETE FROM server_aliases WHERE server_name = $1 AND alias = $2)
+		UPDATE server_aliases SET server_name = $2 WHERE server_name = $1 AND alias <> $2
+	`, serverName, newName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retarget server aliases: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// DeleteServerAlias removes an alias
+func (db *PostgreSQL) DeleteServerAlias(ctx context.Context, tx pgx.Tx, alias string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM server_aliases WHERE alias = $1`, alias)
+	if err != nil {
+		return fmt.Errorf("failed to delete server alias: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const webhookSubscriptionColumns = `id, url, event_types, namespaces, format, secret, last_seq, last_error, last_attempt_at, created_by, created_at, updated_at`
+
+func scanWebhookSubscription(row pgx.Row) (*WebhookSubscription, error) {
+	var subscription WebhookSubscription
+	err := row.Scan(&subscription.ID, &subscription.URL, &subscription.EventTypes, &subscription.Namespaces, &subscription.Format,
+		&subscription.Secret, &subscription.LastSeq, &subscription.LastError, &subscription.LastAttemptAt, &subscription.CreatedBy,
+		&subscription.CreatedAt, &subscription.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// CreateWebhookSubscription stores a webhook subscription, assigning its ID and creation time
+func (db *PostgreSQL) CreateWebhookSubscription(ctx context.Context, tx pgx.Tx, subscription WebhookSubscription) (*WebhookSubscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO webhook_subscriptions (url, event_types, namespaces, format, secret, last_seq, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + webhookSubscriptionColumns
+
+	created, err := scanWebhookSubscription(db.getExecutor(tx).QueryRow(ctx, query, subscription.URL, nonNilStrings(subscription.EventTypes),
+		nonNilStrings(subscription.Namespaces), subscription.Format, subscription.Secret, subscription.LastSeq, subscription.CreatedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", constraintViolation(err))
+	}
+	return created, nil
+}
+
+// GetWebhookSubscription retrieves a webhook subscription by ID
+func (db *PostgreSQL) GetWebhookSubscription(ctx context.Context, tx pgx.Tx, id int64) (*WebhookSubscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+
+	subscription, err := scanWebhookSubscription(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+// ListWebhookSubscriptions retrieves all webhook subscriptions, oldest first
+func (db *PostgreSQL) ListWebhookSubscriptions(ctx context.Context, tx pgx.Tx) ([]*WebhookSubscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []*WebhookSubscription{}
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// UpdateWebhookSubscription replaces a webhook subscription's URL, filters and format
+func (db *PostgreSQL) UpdateWebhookSubscription(ctx context.Context, tx pgx.Tx, subscription *WebhookSubscription) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE webhook_subscriptions
+		SET url = $2, event_types = $3, namespaces = $4, format = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := db.getExecutor(tx).QueryRow(ctx, query, subscription.ID, subscription.URL, nonNilStrings(subscription.EventTypes),
+		nonNilStrings(subscription.Namespaces), subscription.Format).Scan(&subscription.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update webhook subscription: %w", constraintViolation(err))
+	}
+	return nil
+}
+
+// UpdateWebhookSubscriptionSecret replaces the stored signing secret of a webhook subscription
+func (db *PostgreSQL) UpdateWebhookSubscriptionSecret(ctx context.Context, tx pgx.Tx, id int64, secret string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `UPDATE webhook_subscriptions SET secret = $2 WHERE id = $1`, id, secret)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription secret: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RecordWebhookDelivery records how far a webhook subscription has been delivered and why delivery stopped, if it failed
+func (db *PostgreSQL) RecordWebhookDelivery(ctx context.Context, tx pgx.Tx, id int64, lastSeq int64, lastError string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `
+		UPDATE webhook_subscriptions
+		SET last_seq = $2, last_error = $3, last_attempt_at = NOW()
+		WHERE id = $1
+	`, id, lastSeq, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteWebhookSubscription permanently removes a webhook subscription
+func (db *PostgreSQL) DeleteWebhookSubscription(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const announcementColumns = `id, message, severity, starts_at, ends_at, created_by, created_at, updated_at`
+
+func scanAnnouncement(row pgx.Row) (*AnnouncementRecord, error) {
+	var announcement AnnouncementRecord
+	err := row.Scan(&announcement.ID, &announcement.Message, &announcement.Severity, &announcement.StartsAt,
+		&announcement.EndsAt, &announcement.CreatedBy, &announcement.CreatedAt, &announcement.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+// CreateAnnouncement stores an announcement, assigning its ID and creation time
+func (db *PostgreSQL) CreateAnnouncement(ctx context.Context, tx pgx.Tx, announcement AnnouncementRecord) (*AnnouncementRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO announcements (message, severity, starts_at, ends_at, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + announcementColumns
+
+	created, err := scanAnnouncement(db.getExecutor(tx).QueryRow(ctx, query, announcement.Message, announcement.Severity,
+		announcement.StartsAt, announcement.EndsAt, announcement.CreatedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create announcement: %w", constraintViolation(err))
+	}
+	return created, nil
+}
+
+// GetAnnouncement retrieves an announcement by ID
+func (db *PostgreSQL) GetAnnouncement(ctx context.Context, tx pgx.Tx, id int64) (*AnnouncementRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + announcementColumns + ` FROM announcements WHERE id = $1`
+
+	announcement, err := scanAnnouncement(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get announcement: %w", err)
+	}
+	return announcement, nil
+}
+
+// ListAnnouncements retrieves all announcements, including those not shown yet or any more, oldest first
+func (db *PostgreSQL) ListAnnouncements(ctx context.Context, tx pgx.Tx) ([]*AnnouncementRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT `+announcementColumns+` FROM announcements ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query announcements: %w", err)
+	}
+	defer rows.Close()
+
+	announcements := []*AnnouncementRecord{}
+	for rows.Next() {
+		announcement, err := scanAnnouncement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		announcements = append(announcements, announcement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating announcements: %w", err)
+	}
+	return announcements, nil
+}
+
+// UpdateAnnouncement replaces an announcement's message, severity and schedule
+func (db *PostgreSQL) UpdateAnnouncement(ctx context.Context, tx pgx.Tx, announcement *AnnouncementRecord) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE announcements
+		SET message = $2, severity = $3, starts_at = $4, ends_at = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := db.getExecutor(tx).QueryRow(ctx, query, announcement.ID, announcement.Message, announcement.Severity,
+		announcement.StartsAt, announcement.EndsAt).Scan(&announcement.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update announcement: %w", constraintViolation(err))
+	}
+	return nil
+}
+
+// DeleteAnnouncement permanently removes an announcement
+func (db *PostgreSQL) DeleteAnnouncement(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM announcements WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const oauthClientColumns = `client_id, client_name, redirect_uris, created_at`
+
+func scanOAuthClient(row pgx.Row) (*OAuthClient, error) {
+	var client OAuthClient
+	if err := row.Scan(&client.ClientID, &client.ClientName, &client.RedirectURIs, &client.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+// CreateOAuthClient stores a registered OAuth client, assigning its creation time. It returns ErrAlreadyExists
+// when the client ID is taken.
+func (db *PostgreSQL) CreateOAuthClient(ctx context.Context, tx pgx.Tx, client OAuthClient) (*OAuthClient, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO oauth_clients (client_id, client_name, redirect_uris)
+		VALUES ($1, $2, $3)
+		RETURNING ` + oauthClientColumns
+
+	created, err := scanOAuthClient(db.getExecutor(tx).QueryRow(ctx, query, client.ClientID, client.ClientName, client.RedirectURIs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OAuth client: %w", constraintViolation(err))
+	}
+	return created, nil
+}
+
+// GetOAuthClient retrieves a registered OAuth client by client ID
+func (db *PostgreSQL) GetOAuthClient(ctx context.Context, tx pgx.Tx, clientID string) (*OAuthClient, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + oauthClientColumns + ` FROM oauth_clients WHERE client_id = $1`
+
+	client, err := scanOAuthClient(db.getExecutor(tx).QueryRow(ctx, query, clientID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get OAuth client: %w", err)
+	}
+	return client, nil
+}
+
+// ListOAuthClients retrieves all registered OAuth clients, oldest first
+func (db *PostgreSQL) ListOAuthClients(ctx context.Context, tx pgx.Tx) ([]*OAuthClient, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT `+oauthClientColumns+` FROM oauth_clients ORDER BY created_at, client_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OAuth clients: %w", err)
+	}
+	defer rows.Close()
+
+	clients := []*OAuthClient{}
+	for rows.Next() {
+		client, err := scanOAuthClient(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan OAuth client: %w", err)
+		}
+		clients = append(clients, client)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating OAuth clients: %w", err)
+	}
+	return clients, nil
+}
+
+// DeleteOAuthClient permanently removes a registered OAuth client
+func (db *PostgreSQL) DeleteOAuthClient(ctx context.Context, tx pgx.Tx, clientID string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM oauth_clients WHERE client_id = $1`, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to delete OAuth client: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const collectionColumns = `id, title, description, items, owner, created_at, updated_at`
+
+func scanCollection(row pgx.Row) (*Collection, error) {
+	var collection Collection
+	err := row.Scan(&collection.ID, &collection.Title, &collection.Description, &collection.Items, &collection.Owner,
+		&collection.CreatedAt, &collection.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if collection.Items == nil {
+		collection.Items = []CollectionItem{}
+	}
+	return &collection, nil
+}
+
+// nonNilCollectionItems stores an empty collection as an empty JSON array rather than null
+func nonNilCollectionItems(items []CollectionItem) []CollectionItem {
+	if items == nil {
+		return []CollectionItem{}
+	}
+	return items
+}
+
+// CreateCollection stores a collection, assigning its ID and creation time
+func (db *PostgreSQL) CreateCollection(ctx context.Context, tx pgx.Tx, collection Collection) (*Collection, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO collections (title, description, items, owner)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + collectionColumns
+
+	created, err := scanCollection(db.getExecutor(tx).QueryRow(ctx, query, collection.Title, collection.Description,
+		nonNilCollectionItems(collection.Items), collection.Owner))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", constraintViolation(err))
+	}
+	return created, nil
+}
+
+// GetCollection retrieves a collection by ID
+func (db *PostgreSQL) GetCollection(ctx context.Context, tx pgx.Tx, id int64) (*Collection, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + collectionColumns + ` FROM collections WHERE id = $1`
+
+	collection, err := scanCollection(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	return collection, nil
+}
+
+// ListCollections retrieves up to limit collections with an ID greater than afterID, oldest first, only
+// those of owner if it is not empty
+func (db *PostgreSQL) ListCollections(ctx context.Context, tx pgx.Tx, owner string, afterID int64, limit int) ([]*Collection, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + collectionColumns + `
+		FROM collections
+		WHERE id > $1 AND ($2 = '' OR owner = $2)
+		ORDER BY id
+		LIMIT $3
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, afterID, owner, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query collections: %w", err)
+	}
+	defer rows.Close()
+
+	collections := []*Collection{}
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan collection: %w", err)
+		}
+		collections = append(collections, collection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating collections: %w", err)
+	}
+	return collections, nil
+}
+
+// UpdateCollection replaces a collection's title, description and items
+func (db *PostgreSQL) UpdateCollection(ctx context.Context, tx pgx.Tx, collection *Collection) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE collections
+		SET title = $2, description = $3, items = $4, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := db.getExecutor(tx).QueryRow(ctx, query, collection.ID, collection.Title, collection.Description,
+		nonNilCollectionItems(collection.Items)).Scan(&collection.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update collection: %w", constraintViolation(err))
+	}
+	return nil
+}
+
+// DeleteCollection permanently removes a collection
+func (db *PostgreSQL) DeleteCollection(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM collections WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const previewColumns = `id, value, publisher, submitted_by, pull_request, created_at, expires_at`
+
+func scanPreview(row pgx.Row) (*Preview, error) {
+	var preview Preview
+	var valueJSON []byte
+	if err := row.Scan(&preview.ID, &valueJSON, &preview.Publisher, &preview.SubmittedBy, &preview.PullRequest,
+		&preview.CreatedAt, &preview.ExpiresAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(valueJSON, &preview.Server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal preview server: %w", err)
+	}
+	return &preview, nil
+}
+
+// CreatePreview stores a preview under the ID it was given, recording its creation time
+func (db *PostgreSQL) CreatePreview(ctx context.Context, tx pgx.Tx, preview Preview) (*Preview, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	valueJSON, err := json.Marshal(preview.Server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+
+	query := `
+		INSERT INTO previews (id, server_name, version, value, publisher, submitted_by, pull_request, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + previewColumns
+
+	created, err := scanPreview(db.getExecutor(tx).QueryRow(ctx, query, preview.ID, preview.Server.Name, preview.Server.Version,
+		valueJSON, preview.Publisher, preview.SubmittedBy, preview.PullRequest, preview.ExpiresAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview: %w", constraintViolation(err))
+	}
+	return created, nil
+}
+
+// GetPreview retrieves an unexpired preview, returning ErrNotFound if there is none
+func (db *PostgreSQL) GetPreview(ctx context.Context, tx pgx.Tx, id string) (*Preview, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + previewColumns + ` FROM previews WHERE id = $1 AND expires_at > NOW()`
+
+	preview, err := scanPreview(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get preview: %w", err)
+	}
+	return preview, nil
+}
+
+// DeleteExpiredPreviews removes previews that expired before the given time and returns how many were removed
+func (db *PostgreSQL) DeleteExpiredPreviews(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM previews WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired previews: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// IncrementMetric adds delta to a metric's counter for the bucket containing at
+func (db *PostgreSQL) IncrementMetric(ctx context.Context, tx pgx.Tx, metric string, at time.Time, delta int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO metric_counts (metric, bucket, count)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (metric, bucket) DO UPDATE SET count = metric_counts.count + EXCLUDED.count`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, metric, at.UTC().Truncate(MetricBucket), delta); err != nil {
+		return fmt.Errorf("failed to increment metric %s: %w", metric, err)
+	}
+	return nil
+}
+
+// ListMetricCounts retrieves a metric's counters for buckets starting at or after from and before to, oldest first
+func (db *PostgreSQL) ListMetricCounts(ctx context.Context, tx pgx.Tx, metric string, from, to time.Time) ([]MetricCount, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT bucket, count FROM metric_counts
+		WHERE metric = $1 AND bucket >= $2 AND bucket < $3
+		ORDER BY bucket`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, metric, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metric counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []MetricCount{}
+	for rows.Next() {
+		var count MetricCount
+		if err := rows.Scan(&count.Bucket, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan metric count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating metric counts: %w", err)
+	}
+	return counts, nil
+}
+
+const validationPolicyColumns = `namespace, allow_http_remotes, require_package_hashes, blocking_lint_rules, reason, updated_by, updated_at`
+
+func scanValidationPolicy(row pgx.Row) (*ValidationPolicy, error) {
+	var policy ValidationPolicy
+	if err := row.Scan(&policy.Namespace, &policy.AllowHTTPRemotes, &policy.RequirePackageHashes, &policy.BlockingLintRules,
+		&policy.Reason, &policy.UpdatedBy, &policy.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// PutInstallReport stores an install report, replacing the reporter's earlier report for the version
+func (db *PostgreSQL) PutInstallReport(ctx context.Context, tx pgx.Tx, report InstallReport) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO install_reports (server_name, version, reporter, outcome, stage, client, reported_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (server_name, version, reporter) DO UPDATE SET
+			outcome = EXCLUDED.outcome,
+			stage = EXCLUDED.stage,
+			client = EXCLUDED.client,
+			reported_at = EXCLUDED.reported_at
+	`
+	_, err := db.getExecutor(tx).Exec(ctx, query, report.ServerName, report.Version, report.Reporter, report.Outcome,
+		report.Stage, report.Client, report.ReportedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation: the server version doesn't exist
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to store install report: %w", constraintViolation(err))
+	}
+	return nil
+}
+
+// CountInstallReports counts the reports made at or after since for every version of the given servers
+func (db *PostgreSQL) CountInstallReports(ctx context.Context, tx pgx.Tx, serverNames []string, since time.Time) (map[string]map[string]InstallReportCount, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT server_name, version, COUNT(*), COUNT(*) FILTER (WHERE outcome = 'success')
+		FROM install_reports
+		WHERE server_name = ANY($1) AND reported_at >= $2
+		GROUP BY server_name, version
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverNames, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count install reports: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]map[string]InstallReportCount{}
+	for rows.Next() {
+		var name, version string
+		var count InstallReportCount
+		if err := rows.Scan(&name, &version, &count.Reports, &count.Successes); err != nil {
+			return nil, fmt.Errorf("failed to scan install report count: %w", err)
+		}
+		if counts[name] == nil {
+			counts[name] = map[string]InstallReportCount{}
+		}
+		counts[name][version] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating install report counts: %w", err)
+	}
+	return counts, nil
+}
+
+// DeleteInstallReportsBefore removes install reports made before the given time and returns how many were removed
+func (db *PostgreSQL) DeleteInstallReportsBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM install_reports WHERE reported_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete install reports: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// PutValidationPolicy sets the validation policy of a namespace, replacing any existing policy for it
+func (db *PostgreSQL) PutValidationPolicy(ctx context.Context, tx pgx.Tx, policy ValidationPolicy) (*ValidationPolicy, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if policy.BlockingLintRules == nil {
+		policy.BlockingLintRules = []string{}
+	}
+
+	stored, err := scanValidationPolicy(db.getExecutor(tx).QueryRow(ctx, `
+		INSERT INTO validation_policies (namespace, allow_http_remotes, require_package_hashes, blocking_lint_rules, reason, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (namespace) DO UPDATE SET
+			allow_http_remotes = EXCLUDED.allow_http_remotes,
+			require_package_hashes = EXCLUDED.require_package_hashes,
+			blocking_lint_rules = EXCLUDED.blocking_lint_rules,
+			reason = EXCLUDED.reason,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING `+validationPolicyColumns,
+		policy.Namespace, policy.AllowHTTPRemotes, policy.RequirePackageHashes, policy.BlockingLintRules, policy.Reason, policy.UpdatedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store validation policy: %w", constraintViolation(err))
+	}
+	return stored, nil
+}
+
+// ListValidationPolicies retrieves all validation policies, ordered by namespace
+func (db *PostgreSQL) ListValidationPolicies(ctx context.Context, tx pgx.Tx) ([]*ValidationPolicy, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT `+validationPolicyColumns+` FROM validation_policies ORDER BY namespace`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validation policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := []*ValidationPolicy{}
+	for rows.Next() {
+		policy, err := scanValidationPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan validation policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating validation policies: %w", err)
+	}
+	return policies, nil
+}
+
+// DeleteValidationPolicy removes the validation policy of a namespace
+func (db *PostgreSQL) DeleteValidationPolicy(ctx context.Context, tx pgx.Tx, namespace string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM validation_policies WHERE namespace = $1`, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to delete validation policy: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
 	return nil
 }
@@ -3,6 +3,7 @@ package database_test
 import (
 	"context"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -1735,6 +1736,66 @@ func TestPostgreSQL_IncludeDeletedFilter(t *testing.T) {
 }
 
 // Helper functions for creating pointers to basic types
+func TestPostgreSQL_TryAcquireJobLock(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	lock, err := db.TryAcquireJobLock(ctx, "retention")
+	require.NoError(t, err)
+	assert.True(t, lock.Held(ctx))
+
+	// A second holder is refused while the lock is held, but other jobs are independent
+	_, err = db.TryAcquireJobLock(ctx, "retention")
+	assert.ErrorIs(t, err, database.ErrLockNotAcquired)
+	other, err := db.TryAcquireJobLock(ctx, "replication")
+	require.NoError(t, err)
+	require.NoError(t, other.Release(ctx))
+
+	require.NoError(t, lock.Release(ctx))
+	lock, err = db.TryAcquireJobLock(ctx, "retention")
+	require.NoError(t, err)
+	require.NoError(t, lock.Release(ctx))
+}
+
+func TestPostgreSQL_CountServers(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	for i := range 5 {
+		serverJSON := &apiv0.ServerJSON{
+			Name:        fmt.Sprintf("com.example/count-%d", i),
+			Description: "Count test server",
+			Version:     "1.0.0",
+		}
+		timeNow := time.Now()
+		_, err := db.CreateServer(ctx, nil, serverJSON, &apiv0.RegistryExtensions{
+			Status:          model.StatusActive,
+			StatusChangedAt: timeNow,
+			PublishedAt:     timeNow,
+			UpdatedAt:       timeNow,
+			IsLatest:        true,
+		})
+		require.NoError(t, err)
+	}
+
+	// Below the limit the count is exact
+	count, estimated, err := db.CountServers(ctx, nil, &database.ServerFilter{SubstringName: stringPtr("count-")}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+	assert.False(t, estimated)
+
+	count, estimated, err = db.CountServers(ctx, nil, &database.ServerFilter{Name: stringPtr("com.example/count-1")}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.False(t, estimated)
+
+	// Above the limit the planner estimate is used, but never below what was already counted
+	count, estimated, err = db.CountServers(ctx, nil, &database.ServerFilter{SubstringName: stringPtr("count-")}, 2)
+	require.NoError(t, err)
+	assert.True(t, estimated)
+	assert.GreaterOrEqual(t, count, 3)
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -1746,3 +1807,120 @@ func boolPtr(b bool) *bool {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestPostgreSQL_Partitions(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	// Migration 050 creates partitions three months ahead; further months are created once
+	created, err := db.CreatePartitions(ctx, nil, time.Now().AddDate(0, 6, 0))
+	require.NoError(t, err)
+	assert.Positive(t, created)
+	created, err = db.CreatePartitions(ctx, nil, time.Now().AddDate(0, 6, 0))
+	require.NoError(t, err)
+	assert.Zero(t, created)
+
+	for _, action := range []string{"server.publish", "server.edit"} {
+		_, err := db.RecordAuditEntry(ctx, nil, database.AuditEntry{Actor: "github-at:octocat", Action: action})
+		require.NoError(t, err)
+	}
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		timeNow := time.Now()
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:        "com.example/partitioned",
+			Description: "Partition test server",
+			Version:     version,
+		}, &apiv0.RegistryExtensions{
+			Status:          model.StatusActive,
+			StatusChangedAt: timeNow,
+			PublishedAt:     timeNow,
+			UpdatedAt:       timeNow,
+		})
+		require.NoError(t, err)
+	}
+
+	// Deleting up to the start of next month drops this month's partition and keeps the later ones
+	nextMonth := time.Now().UTC().AddDate(0, 1, 1-time.Now().UTC().Day()).Truncate(24 * time.Hour)
+	deleted, err := db.DeleteAuditEntriesBefore(ctx, nil, nextMonth)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	entries, err := db.ListAuditEntries(ctx, nil, nil, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = db.RecordAuditEntry(ctx, nil, database.AuditEntry{Actor: "github-at:octocat", Action: "server.publish"})
+	require.NoError(t, err, "rows for a dropped month fall into the default partition")
+
+	// The most recent change is always kept
+	latest, err := db.LatestChangeSeq(ctx, nil)
+	require.NoError(t, err)
+	deleted, err = db.DeleteServerChangesBefore(ctx, nil, nextMonth)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	seq, err := db.LatestChangeSeq(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, latest, seq)
+}
+
+func TestPostgreSQL_CopyPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+	source, ok := database.NewTestDB(t).(*database.PostgreSQL)
+	require.True(t, ok)
+	target, ok := database.NewTestDB(t).(*database.PostgreSQL)
+	require.True(t, ok)
+
+	timeNow := time.Now()
+	_, err := source.CreateServer(ctx, nil, &apiv0.ServerJSON{
+		Name:        "com.example/copied",
+		Description: "Copy test server",
+		Version:     testVersion100,
+	}, &apiv0.RegistryExtensions{
+		Status:          model.StatusActive,
+		StatusChangedAt: timeNow,
+		PublishedAt:     timeNow,
+		UpdatedAt:       timeNow,
+		IsLatest:        true,
+	})
+	require.NoError(t, err)
+	_, err = source.RecordAuditEntry(ctx, nil, database.AuditEntry{Actor: "github-at:octocat", Action: "server.publish"})
+	require.NoError(t, err)
+
+	// A dry run counts the rows without writing them
+	copies, err := database.CopyPostgreSQL(ctx, source, target, true, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, copies)
+	_, err = target.GetServerByNameAndVersion(ctx, nil, "com.example/copied", testVersion100, false)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	var reported []string
+	copies, err = database.CopyPostgreSQL(ctx, source, target, false, func(copied database.TableCopy) {
+		reported = append(reported, copied.Table)
+	})
+	require.NoError(t, err)
+	counts := make(map[string]int64)
+	for i, copied := range copies {
+		assert.Equal(t, copied.SourceRows, copied.CopiedRows, copied.Table)
+		assert.Equal(t, copied.Table, reported[i])
+		counts[copied.Table] = copied.CopiedRows
+	}
+	assert.Equal(t, int64(1), counts["servers"])
+	assert.Equal(t, int64(1), counts["server_changes"], "copying a server does not record its change again")
+	assert.Equal(t, int64(1), counts["audit_log"])
+	assert.Less(t, slices.Index(reported, "server_documents"), slices.Index(reported, "servers"), "referenced tables are copied first")
+
+	server, err := target.GetServerByNameAndVersion(ctx, nil, "com.example/copied", testVersion100, false)
+	require.NoError(t, err)
+	assert.Equal(t, "Copy test server", server.Server.Description)
+
+	// Sequences continue from the source's values
+	sourceSeq, err := source.LatestChangeSeq(ctx, nil)
+	require.NoError(t, err)
+	_, err = target.SetServerStatus(ctx, nil, "com.example/copied", testVersion100, model.StatusDeprecated, nil)
+	require.NoError(t, err)
+	targetSeq, err := target.LatestChangeSeq(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sourceSeq+1, targetSeq)
+
+	_, err = database.CopyPostgreSQL(ctx, source, target, false, nil)
+	assert.ErrorIs(t, err, database.ErrTargetNotEmpty)
+}
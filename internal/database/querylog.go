@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// maxLoggedQueries bounds how many statements a QueryLog keeps; later ones are only counted
+const maxLoggedQueries = 100
+
+// LoggedQuery is a SQL statement executed while a QueryLog was collecting, without its arguments
+type LoggedQuery struct {
+	SQL      string
+	Duration time.Duration
+	Err      error
+}
+
+// QueryLog collects the SQL statements PostgreSQL runs for one request, so slow requests can be logged with
+// the queries that made them slow. It is safe for concurrent use.
+type QueryLog struct {
+	mu      sync.Mutex
+	queries []LoggedQuery
+	dropped int
+}
+
+type queryLogKey struct{}
+
+// WithQueryLog returns a context whose PostgreSQL statements are recorded in the returned QueryLog
+func WithQueryLog(ctx context.Context) (context.Context, *QueryLog) {
+	queryLog := &QueryLog{}
+	return context.WithValue(ctx, queryLogKey{}, queryLog), queryLog
+}
+
+// Queries returns the recorded statements in the order they finished, and how many more were not kept
+func (l *QueryLog) Queries() ([]LoggedQuery, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]LoggedQuery(nil), l.queries...), l.dropped
+}
+
+func (l *QueryLog) add(query LoggedQuery) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.queries) >= maxLoggedQueries {
+		l.dropped++
+		return
+	}
+	l.queries = append(l.queries, query)
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	sql   string
+	start time.Time
+}
+
+// queryTracer records statements in the QueryLog of their context, if any. Arguments are never recorded,
+// since they may hold tokens or other user data.
+type queryTracer struct{}
+
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if ctx.Value(queryLogKey{}) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStartKey{}, queryStart{sql: data.SQL, start: time.Now()})
+}
+
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	queryLog, _ := ctx.Value(queryLogKey{}).(*QueryLog)
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if queryLog == nil || !ok {
+		return
+	}
+	queryLog.add(LoggedQuery{
+		SQL:      strings.Join(strings.Fields(start.sql), " "),
+		Duration: time.Since(start.start),
+		Err:      data.Err,
+	})
+}
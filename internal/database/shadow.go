@@ -0,0 +1,1061 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Shadow is a Database that serves every call from a primary database and repeats each successful write on a
+// shadow database, so a new database can run alongside the current one before the registry switches to it.
+// Writes the shadow rejects are logged and counted, but never fail the caller. Writes made in a transaction are
+// repeated in a single shadow transaction once the primary transaction commits, and discarded if it rolls back.
+//
+// The shadow has to start as a copy of the primary, such as one made by CopyPostgreSQL, and must have no other
+// writers: rows are identified by the IDs the primary assigned, which only match while both databases assign
+// the same ones.
+type Shadow struct {
+	Database
+	shadow Database
+
+	mu sync.Mutex
+	// pending holds the writes of each open primary transaction, to repeat once it commits
+	pending  map[pgx.Tx][]shadowWrite
+	failures atomic.Int64
+}
+
+// shadowWrite is a write to repeat on the shadow database
+type shadowWrite struct {
+	method string
+	apply  func(ctx context.Context, tx pgx.Tx) error
+}
+
+// NewShadow creates a Database that serves calls from primary and repeats writes on shadow
+func NewShadow(primary, shadow Database) *Shadow {
+	return &Shadow{Database: primary, shadow: shadow, pending: map[pgx.Tx][]shadowWrite{}}
+}
+
+// Failures returns how many writes the shadow database rejected since the Shadow was created
+func (db *Shadow) Failures() int64 {
+	return db.failures.Load()
+}
+
+// InTransaction executes a function within a transaction of the primary database, then repeats its writes in a
+// transaction of the shadow database if it committed
+func (db *Shadow) InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error {
+	var writes []shadowWrite
+	err := db.Database.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		db.mu.Lock()
+		db.pending[tx] = nil
+		db.mu.Unlock()
+		defer func() {
+			db.mu.Lock()
+			writes = db.pending[tx]
+			delete(db.pending, tx)
+			db.mu.Unlock()
+		}()
+		return fn(ctx, tx)
+	})
+	if err != nil || len(writes) == 0 {
+		return err
+	}
+
+	// The primary committed, so the writes are repeated even if the caller gives up now
+	ctx = context.WithoutCancel(ctx)
+	err = db.shadow.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		for _, write := range writes {
+			if err := write.apply(ctx, tx); err != nil {
+				return fmt.Errorf("%s: %w", write.method, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.fail(fmt.Errorf("transaction of %d writes: %w", len(writes), err))
+	}
+	return nil
+}
+
+// mirror repeats a write that succeeded on the primary database on the shadow database: right away when it was
+// made outside a transaction, or when the transaction it was made in commits
+func (db *Shadow) mirror(ctx context.Context, tx pgx.Tx, method string, apply func(ctx context.Context, tx pgx.Tx) error) {
+	if tx != nil {
+		db.mu.Lock()
+		writes, open := db.pending[tx]
+		if open {
+			db.pending[tx] = append(writes, shadowWrite{method: method, apply: apply})
+		}
+		db.mu.Unlock()
+		if open {
+			return
+		}
+	}
+
+	if err := apply(context.WithoutCancel(ctx), nil); err != nil {
+		db.fail(fmt.Errorf("%s: %w", method, err))
+	}
+}
+
+// fail records a write the shadow database rejected
+func (db *Shadow) fail(err error) {
+	db.failures.Add(1)
+	log.Printf("Shadow database write failed, it no longer matches the primary: %v", err)
+}
+
+// snapshot copies the value a pointer argument points to, so writes repeated later see it as it was passed
+func snapshot[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+// Close closes both databases
+func (db *Shadow) Close() error {
+	return errors.Join(db.Database.Close(), db.shadow.Close())
+}
+
+// CreateServer inserts a new server version with official metadata, storing the canonical encoding of its
+// server.json in the document store
+func (db *Shadow) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
+	serverJSONCopy := snapshot(serverJSON)
+	officialMetaCopy := snapshot(officialMeta)
+	result, err := db.Database.CreateServer(ctx, tx, serverJSON, officialMeta)
+	if err == nil {
+		db.mirror(ctx, tx, "CreateServer", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreateServer(ctx, tx, serverJSONCopy, officialMetaCopy)
+			return err
+		})
+	}
+	return result, err
+}
+
+// UpdateServer updates an existing server record, storing the canonical encoding of its new server.json in
+// the document store
+func (db *Shadow) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	serverJSONCopy := snapshot(serverJSON)
+	result, err := db.Database.UpdateServer(ctx, tx, serverName, version, serverJSON)
+	if err == nil {
+		db.mirror(ctx, tx, "UpdateServer", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.UpdateServer(ctx, tx, serverName, version, serverJSONCopy)
+			return err
+		})
+	}
+	return result, err
+}
+
+// PutServerDocument stores a server version's server.json document, such as the bytes it was published with,
+// under its digest, once per distinct document, and points the version at it. It returns the digest, or
+// ErrNotFound if the version does not exist.
+func (db *Shadow) PutServerDocument(ctx context.Context, tx pgx.Tx, serverName, version string, document []byte) (string, error) {
+	documentCopy := slices.Clone(document)
+	result, err := db.Database.PutServerDocument(ctx, tx, serverName, version, document)
+	if err == nil {
+		db.mirror(ctx, tx, "PutServerDocument", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.PutServerDocument(ctx, tx, serverName, version, documentCopy)
+			return err
+		})
+	}
+	return result, err
+}
+
+// SetServerStatus updates the status of a specific server version
+func (db *Shadow) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status model.Status, statusMessage *string) (*apiv0.ServerResponse, error) {
+	result, err := db.Database.SetServerStatus(ctx, tx, serverName, version, status, statusMessage)
+	if err == nil {
+		db.mirror(ctx, tx, "SetServerStatus", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.SetServerStatus(ctx, tx, serverName, version, status, statusMessage)
+			return err
+		})
+	}
+	return result, err
+}
+
+// SetAllVersionsStatus updates the status of all versions of a server in a single query
+func (db *Shadow) SetAllVersionsStatus(ctx context.Context, tx pgx.Tx, serverName string, status model.Status, statusMessage *string) ([]*apiv0.ServerResponse, error) {
+	result, err := db.Database.SetAllVersionsStatus(ctx, tx, serverName, status, statusMessage)
+	if err == nil {
+		db.mirror(ctx, tx, "SetAllVersionsStatus", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.SetAllVersionsStatus(ctx, tx, serverName, status, statusMessage)
+			return err
+		})
+	}
+	return result, err
+}
+
+// UnmarkAsLatest marks the current latest version of a server as no longer latest
+func (db *Shadow) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if err := db.Database.UnmarkAsLatest(ctx, tx, serverName); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "UnmarkAsLatest", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.UnmarkAsLatest(ctx, tx, serverName)
+	})
+	return nil
+}
+
+// DeleteServerChangesBefore permanently removes changes recorded before the given time, except the most
+// recent change, and returns how many were removed
+func (db *Shadow) DeleteServerChangesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	result, err := db.Database.DeleteServerChangesBefore(ctx, tx, before)
+	if err == nil {
+		db.mirror(ctx, tx, "DeleteServerChangesBefore", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.DeleteServerChangesBefore(ctx, tx, before)
+			return err
+		})
+	}
+	return result, err
+}
+
+// CreatePartitions creates the missing monthly partitions of the audit log and changes feed up to and
+// including the month of through, and returns how many were created
+func (db *Shadow) CreatePartitions(ctx context.Context, tx pgx.Tx, through time.Time) (int, error) {
+	result, err := db.Database.CreatePartitions(ctx, tx, through)
+	if err == nil {
+		db.mirror(ctx, tx, "CreatePartitions", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreatePartitions(ctx, tx, through)
+			return err
+		})
+	}
+	return result, err
+}
+
+// SetImportCheckpoint records the last change sequence applied from a remote registry
+func (db *Shadow) SetImportCheckpoint(ctx context.Context, tx pgx.Tx, source string, seq int64) error {
+	if err := db.Database.SetImportCheckpoint(ctx, tx, source, seq); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "SetImportCheckpoint", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.SetImportCheckpoint(ctx, tx, source, seq)
+	})
+	return nil
+}
+
+// SetMaintenanceState persists the maintenance mode state
+func (db *Shadow) SetMaintenanceState(ctx context.Context, tx pgx.Tx, enabled bool, message *string, retryAfterSeconds int) (*MaintenanceState, error) {
+	result, err := db.Database.SetMaintenanceState(ctx, tx, enabled, message, retryAfterSeconds)
+	if err == nil {
+		db.mirror(ctx, tx, "SetMaintenanceState", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.SetMaintenanceState(ctx, tx, enabled, message, retryAfterSeconds)
+			return err
+		})
+	}
+	return result, err
+}
+
+// PutRepositoryURL records the canonical form of a repository URL servers link, replacing any earlier one
+func (db *Shadow) PutRepositoryURL(ctx context.Context, tx pgx.Tx, rawURL, canonicalURL string) error {
+	if err := db.Database.PutRepositoryURL(ctx, tx, rawURL, canonicalURL); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "PutRepositoryURL", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.PutRepositoryURL(ctx, tx, rawURL, canonicalURL)
+	})
+	return nil
+}
+
+// RecordTokenUse records that an identity token, identified by key, was exchanged and keeps the record until
+// expiresAt. It returns ErrAlreadyExists when the token was recorded before and has not expired.
+func (db *Shadow) RecordTokenUse(ctx context.Context, tx pgx.Tx, key string, expiresAt time.Time) error {
+	if err := db.Database.RecordTokenUse(ctx, tx, key, expiresAt); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "RecordTokenUse", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.RecordTokenUse(ctx, tx, key, expiresAt)
+	})
+	return nil
+}
+
+// RecordNamespaceVerificationAttempt adds evidence to the pending verification request for a domain and method, creating it if needed
+func (db *Shadow) RecordNamespaceVerificationAttempt(ctx context.Context, tx pgx.Tx, domain, method string, evidence VerificationEvidence) (*NamespaceVerification, error) {
+	result, err := db.Database.RecordNamespaceVerificationAttempt(ctx, tx, domain, method, evidence)
+	if err == nil {
+		db.mirror(ctx, tx, "RecordNamespaceVerificationAttempt", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.RecordNamespaceVerificationAttempt(ctx, tx, domain, method, evidence)
+			return err
+		})
+	}
+	return result, err
+}
+
+// AddNamespaceVerificationEvidence appends evidence to an existing verification request
+func (db *Shadow) AddNamespaceVerificationEvidence(ctx context.Context, tx pgx.Tx, id int64, evidence VerificationEvidence) (*NamespaceVerification, error) {
+	result, err := db.Database.AddNamespaceVerificationEvidence(ctx, tx, id, evidence)
+	if err == nil {
+		db.mirror(ctx, tx, "AddNamespaceVerificationEvidence", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.AddNamespaceVerificationEvidence(ctx, tx, id, evidence)
+			return err
+		})
+	}
+	return result, err
+}
+
+// ReviewNamespaceVerification records an admin decision on a verification request
+func (db *Shadow) ReviewNamespaceVerification(ctx context.Context, tx pgx.Tx, id int64, status string, notes *string, reviewer string) (*NamespaceVerification, error) {
+	result, err := db.Database.ReviewNamespaceVerification(ctx, tx, id, status, notes, reviewer)
+	if err == nil {
+		db.mirror(ctx, tx, "ReviewNamespaceVerification", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.ReviewNamespaceVerification(ctx, tx, id, status, notes, reviewer)
+			return err
+		})
+	}
+	return result, err
+}
+
+// DeleteServerVersion permanently removes a server version, recording its deletion in the changes feed
+func (db *Shadow) DeleteServerVersion(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if err := db.Database.DeleteServerVersion(ctx, tx, serverName, version); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteServerVersion", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteServerVersion(ctx, tx, serverName, version)
+	})
+	return nil
+}
+
+// RecordAuditEntry appends an entry to the audit log, assigning its ID and time
+func (db *Shadow) RecordAuditEntry(ctx context.Context, tx pgx.Tx, entry AuditEntry) (*AuditEntry, error) {
+	result, err := db.Database.RecordAuditEntry(ctx, tx, entry)
+	if err == nil {
+		db.mirror(ctx, tx, "RecordAuditEntry", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.RecordAuditEntry(ctx, tx, entry)
+			return err
+		})
+	}
+	return result, err
+}
+
+// UpdateAuditEntryClientIP replaces the stored client address of an audit entry, for re-encrypting it under a new key
+func (db *Shadow) UpdateAuditEntryClientIP(ctx context.Context, tx pgx.Tx, id int64, clientIP string) error {
+	if err := db.Database.UpdateAuditEntryClientIP(ctx, tx, id, clientIP); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "UpdateAuditEntryClientIP", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.UpdateAuditEntryClientIP(ctx, tx, id, clientIP)
+	})
+	return nil
+}
+
+// DeleteAuditEntriesBefore permanently removes audit entries recorded before the given time and returns how many were removed
+func (db *Shadow) DeleteAuditEntriesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	result, err := db.Database.DeleteAuditEntriesBefore(ctx, tx, before)
+	if err == nil {
+		db.mirror(ctx, tx, "DeleteAuditEntriesBefore", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.DeleteAuditEntriesBefore(ctx, tx, before)
+			return err
+		})
+	}
+	return result, err
+}
+
+// RecordPublishAttempt stores a publish attempt, assigning its ID and time
+func (db *Shadow) RecordPublishAttempt(ctx context.Context, tx pgx.Tx, attempt PublishAttempt) (*PublishAttempt, error) {
+	result, err := db.Database.RecordPublishAttempt(ctx, tx, attempt)
+	if err == nil {
+		db.mirror(ctx, tx, "RecordPublishAttempt", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.RecordPublishAttempt(ctx, tx, attempt)
+			return err
+		})
+	}
+	return result, err
+}
+
+// DeletePublishAttemptsBefore permanently removes publish attempts made before the given time, along with
+// their quarantined server.json, and returns how many were removed
+func (db *Shadow) DeletePublishAttemptsBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	result, err := db.Database.DeletePublishAttemptsBefore(ctx, tx, before)
+	if err == nil {
+		db.mirror(ctx, tx, "DeletePublishAttemptsBefore", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.DeletePublishAttemptsBefore(ctx, tx, before)
+			return err
+		})
+	}
+	return result, err
+}
+
+// QuarantinePublish keeps the server.json and validation output of a rejected publish attempt, replacing any
+// already kept for the attempt
+func (db *Shadow) QuarantinePublish(ctx context.Context, tx pgx.Tx, quarantined QuarantinedPublish) error {
+	if err := db.Database.QuarantinePublish(ctx, tx, quarantined); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "QuarantinePublish", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.QuarantinePublish(ctx, tx, quarantined)
+	})
+	return nil
+}
+
+// DeleteQuarantinedPublishesBefore permanently removes server.json documents quarantined before the given
+// time and returns how many were removed
+func (db *Shadow) DeleteQuarantinedPublishesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	result, err := db.Database.DeleteQuarantinedPublishesBefore(ctx, tx, before)
+	if err == nil {
+		db.mirror(ctx, tx, "DeleteQuarantinedPublishesBefore", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.DeleteQuarantinedPublishesBefore(ctx, tx, before)
+			return err
+		})
+	}
+	return result, err
+}
+
+// RecordPackageProvenance stores the upstream provenance of a server version's packages. Packages that
+// already have a record are skipped, so recorded provenance is never overwritten.
+func (db *Shadow) RecordPackageProvenance(ctx context.Context, tx pgx.Tx, serverName, version string, provenance []apiv0.PackageProvenance) error {
+	provenanceCopy := slices.Clone(provenance)
+	if err := db.Database.RecordPackageProvenance(ctx, tx, serverName, version, provenance); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "RecordPackageProvenance", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.RecordPackageProvenance(ctx, tx, serverName, version, provenanceCopy)
+	})
+	return nil
+}
+
+// RecordPackageArtifacts stores the artifacts mirrored for a server version's packages. Artifacts that are
+// already recorded for the version, by package and file name, are skipped.
+func (db *Shadow) RecordPackageArtifacts(ctx context.Context, tx pgx.Tx, serverName, version string, artifacts []apiv0.PackageArtifact) error {
+	artifactsCopy := slices.Clone(artifacts)
+	if err := db.Database.RecordPackageArtifacts(ctx, tx, serverName, version, artifacts); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "RecordPackageArtifacts", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.RecordPackageArtifacts(ctx, tx, serverName, version, artifactsCopy)
+	})
+	return nil
+}
+
+// PutUpstreamCacheEntry stores a cached upstream response, replacing any entry with the same key
+func (db *Shadow) PutUpstreamCacheEntry(ctx context.Context, tx pgx.Tx, entry UpstreamCacheEntry) error {
+	if err := db.Database.PutUpstreamCacheEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "PutUpstreamCacheEntry", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.PutUpstreamCacheEntry(ctx, tx, entry)
+	})
+	return nil
+}
+
+// DeleteExpiredUpstreamCacheEntries removes cached upstream responses that expired before the given time and returns how many were removed
+func (db *Shadow) DeleteExpiredUpstreamCacheEntries(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	result, err := db.Database.DeleteExpiredUpstreamCacheEntries(ctx, tx, before)
+	if err == nil {
+		db.mirror(ctx, tx, "DeleteExpiredUpstreamCacheEntries", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.DeleteExpiredUpstreamCacheEntries(ctx, tx, before)
+			return err
+		})
+	}
+	return result, err
+}
+
+// PutRemoteHealth stores the latest probe result of a remote endpoint, replacing any earlier result for its URL
+func (db *Shadow) PutRemoteHealth(ctx context.Context, tx pgx.Tx, health apiv0.RemoteHealth) error {
+	if err := db.Database.PutRemoteHealth(ctx, tx, health); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "PutRemoteHealth", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.PutRemoteHealth(ctx, tx, health)
+	})
+	return nil
+}
+
+// DeleteRemoteHealthCheckedBefore removes probe results last checked before the given time and returns how many were removed
+func (db *Shadow) DeleteRemoteHealthCheckedBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	result, err := db.Database.DeleteRemoteHealthCheckedBefore(ctx, tx, before)
+	if err == nil {
+		db.mirror(ctx, tx, "DeleteRemoteHealthCheckedBefore", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.DeleteRemoteHealthCheckedBefore(ctx, tx, before)
+			return err
+		})
+	}
+	return result, err
+}
+
+// PutScreeningException exempts a server from screening, replacing any existing exception for it
+func (db *Shadow) PutScreeningException(ctx context.Context, tx pgx.Tx, exception ScreeningException) (*ScreeningException, error) {
+	result, err := db.Database.PutScreeningException(ctx, tx, exception)
+	if err == nil {
+		db.mirror(ctx, tx, "PutScreeningException", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.PutScreeningException(ctx, tx, exception)
+			return err
+		})
+	}
+	return result, err
+}
+
+// DeleteScreeningException removes the screening exception of a server
+func (db *Shadow) DeleteScreeningException(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if err := db.Database.DeleteScreeningException(ctx, tx, serverName); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteScreeningException", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteScreeningException(ctx, tx, serverName)
+	})
+	return nil
+}
+
+// PutIdentityBan bans an identity, replacing any existing ban of it
+func (db *Shadow) PutIdentityBan(ctx context.Context, tx pgx.Tx, ban IdentityBan) (*IdentityBan, error) {
+	result, err := db.Database.PutIdentityBan(ctx, tx, ban)
+	if err == nil {
+		db.mirror(ctx, tx, "PutIdentityBan", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.PutIdentityBan(ctx, tx, ban)
+			return err
+		})
+	}
+	return result, err
+}
+
+// DeleteIdentityBan lifts the ban of an identity
+func (db *Shadow) DeleteIdentityBan(ctx context.Context, tx pgx.Tx, kind, value string) error {
+	if err := db.Database.DeleteIdentityBan(ctx, tx, kind, value); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteIdentityBan", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteIdentityBan(ctx, tx, kind, value)
+	})
+	return nil
+}
+
+// QuarantineServer records the prior status of every active or deprecated version of a server, and of
+// versions other bans already quarantined, against a ban and sets the active and deprecated ones to
+// quarantined with statusMessage. It returns the versions whose status changed.
+func (db *Shadow) QuarantineServer(ctx context.Context, tx pgx.Tx, serverName, banKind, banValue string, statusMessage *string) ([]*apiv0.ServerResponse, error) {
+	result, err := db.Database.QuarantineServer(ctx, tx, serverName, banKind, banValue, statusMessage)
+	if err == nil {
+		db.mirror(ctx, tx, "QuarantineServer", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.QuarantineServer(ctx, tx, serverName, banKind, banValue, statusMessage)
+			return err
+		})
+	}
+	return result, err
+}
+
+// ReleaseQuarantine deletes the quarantine records of a ban and restores the prior status and message of the
+// versions no other ban holds that are still quarantined. It returns the restored versions.
+func (db *Shadow) ReleaseQuarantine(ctx context.Context, tx pgx.Tx, banKind, banValue string) ([]*apiv0.ServerResponse, error) {
+	result, err := db.Database.ReleaseQuarantine(ctx, tx, banKind, banValue)
+	if err == nil {
+		db.mirror(ctx, tx, "ReleaseQuarantine", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.ReleaseQuarantine(ctx, tx, banKind, banValue)
+			return err
+		})
+	}
+	return result, err
+}
+
+// PutServerCuration sets the curation of a server, replacing any existing curation for it
+func (db *Shadow) PutServerCuration(ctx context.Context, tx pgx.Tx, curation ServerCuration) (*ServerCuration, error) {
+	result, err := db.Database.PutServerCuration(ctx, tx, curation)
+	if err == nil {
+		db.mirror(ctx, tx, "PutServerCuration", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.PutServerCuration(ctx, tx, curation)
+			return err
+		})
+	}
+	return result, err
+}
+
+// DeleteServerCuration removes the curation of a server
+func (db *Shadow) DeleteServerCuration(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if err := db.Database.DeleteServerCuration(ctx, tx, serverName); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteServerCuration", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteServerCuration(ctx, tx, serverName)
+	})
+	return nil
+}
+
+// PutServerStaleness records a stale server, replacing any earlier record for it
+func (db *Shadow) PutServerStaleness(ctx context.Context, tx pgx.Tx, staleness ServerStaleness) error {
+	if err := db.Database.PutServerStaleness(ctx, tx, staleness); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "PutServerStaleness", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.PutServerStaleness(ctx, tx, staleness)
+	})
+	return nil
+}
+
+// DeleteServerStaleness removes the staleness record of a server
+func (db *Shadow) DeleteServerStaleness(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if err := db.Database.DeleteServerStaleness(ctx, tx, serverName); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteServerStaleness", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteServerStaleness(ctx, tx, serverName)
+	})
+	return nil
+}
+
+// PutServerTools stores the indexed tools of a server, replacing any earlier index of it
+func (db *Shadow) PutServerTools(ctx context.Context, tx pgx.Tx, tools ServerTools) error {
+	if err := db.Database.PutServerTools(ctx, tx, tools); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "PutServerTools", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.PutServerTools(ctx, tx, tools)
+	})
+	return nil
+}
+
+// DeleteServerTools removes the indexed tools of a server
+func (db *Shadow) DeleteServerTools(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if err := db.Database.DeleteServerTools(ctx, tx, serverName); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteServerTools", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteServerTools(ctx, tx, serverName)
+	})
+	return nil
+}
+
+// CreateOrgAPIKey stores an organization API key, assigning its ID and creation time. Key names are unique
+// within an organization.
+func (db *Shadow) CreateOrgAPIKey(ctx context.Context, tx pgx.Tx, key OrgAPIKey) (*OrgAPIKey, error) {
+	result, err := db.Database.CreateOrgAPIKey(ctx, tx, key)
+	if err == nil {
+		db.mirror(ctx, tx, "CreateOrgAPIKey", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreateOrgAPIKey(ctx, tx, key)
+			return err
+		})
+	}
+	return result, err
+}
+
+// RotateOrgAPIKey replaces the key of an organization API key and records when it was rotated
+func (db *Shadow) RotateOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64, keyHash, keyPrefix string) (*OrgAPIKey, error) {
+	result, err := db.Database.RotateOrgAPIKey(ctx, tx, id, keyHash, keyPrefix)
+	if err == nil {
+		db.mirror(ctx, tx, "RotateOrgAPIKey", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.RotateOrgAPIKey(ctx, tx, id, keyHash, keyPrefix)
+			return err
+		})
+	}
+	return result, err
+}
+
+// TouchOrgAPIKey records when an organization API key was last used
+func (db *Shadow) TouchOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64, usedAt time.Time) error {
+	if err := db.Database.TouchOrgAPIKey(ctx, tx, id, usedAt); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "TouchOrgAPIKey", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.TouchOrgAPIKey(ctx, tx, id, usedAt)
+	})
+	return nil
+}
+
+// DeleteOrgAPIKey permanently removes an organization API key
+func (db *Shadow) DeleteOrgAPIKey(ctx context.Context, tx pgx.Tx, id int64) error {
+	if err := db.Database.DeleteOrgAPIKey(ctx, tx, id); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteOrgAPIKey", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteOrgAPIKey(ctx, tx, id)
+	})
+	return nil
+}
+
+// CreateOrgMember adds a member to an organization, assigning its ID and creation time. Subjects are unique
+// within an organization.
+func (db *Shadow) CreateOrgMember(ctx context.Context, tx pgx.Tx, member OrgMember) (*OrgMember, error) {
+	result, err := db.Database.CreateOrgMember(ctx, tx, member)
+	if err == nil {
+		db.mirror(ctx, tx, "CreateOrgMember", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreateOrgMember(ctx, tx, member)
+			return err
+		})
+	}
+	return result, err
+}
+
+// DeleteOrgMember removes an organization member
+func (db *Shadow) DeleteOrgMember(ctx context.Context, tx pgx.Tx, id int64) error {
+	if err := db.Database.DeleteOrgMember(ctx, tx, id); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteOrgMember", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteOrgMember(ctx, tx, id)
+	})
+	return nil
+}
+
+// CreateBulkJob queues a bulk job, assigning its ID and creation time
+func (db *Shadow) CreateBulkJob(ctx context.Context, tx pgx.Tx, job BulkJob) (*BulkJob, error) {
+	result, err := db.Database.CreateBulkJob(ctx, tx, job)
+	if err == nil {
+		db.mirror(ctx, tx, "CreateBulkJob", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreateBulkJob(ctx, tx, job)
+			return err
+		})
+	}
+	return result, err
+}
+
+// ClaimBulkJob marks the oldest queued or running bulk job as running and returns it, or ErrNotFound if there is none.
+// Running jobs are claimed again because only the instance holding the bulk job lock runs them, so one found
+// running was interrupted.
+func (db *Shadow) ClaimBulkJob(ctx context.Context, tx pgx.Tx) (*BulkJob, error) {
+	result, err := db.Database.ClaimBulkJob(ctx, tx)
+	if err == nil {
+		db.mirror(ctx, tx, "ClaimBulkJob", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.ClaimBulkJob(ctx, tx)
+			return err
+		})
+	}
+	return result, err
+}
+
+// UpdateBulkJob records a bulk job's state and progress
+func (db *Shadow) UpdateBulkJob(ctx context.Context, tx pgx.Tx, job *BulkJob) error {
+	jobCopy := snapshot(job)
+	if err := db.Database.UpdateBulkJob(ctx, tx, job); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "UpdateBulkJob", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.UpdateBulkJob(ctx, tx, jobCopy)
+	})
+	return nil
+}
+
+// CreatePendingPublish stores an accepted publish as pending, assigning its ID and creation time. It fails with
+// ErrAlreadyExists if a publish of the same version is already pending.
+func (db *Shadow) CreatePendingPublish(ctx context.Context, tx pgx.Tx, publish PendingPublish) (*PendingPublish, error) {
+	result, err := db.Database.CreatePendingPublish(ctx, tx, publish)
+	if err == nil {
+		db.mirror(ctx, tx, "CreatePendingPublish", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreatePendingPublish(ctx, tx, publish)
+			return err
+		})
+	}
+	return result, err
+}
+
+// NextPendingPublish retrieves the oldest publish that is still pending, or ErrNotFound if there is none
+func (db *Shadow) NextPendingPublish(ctx context.Context, tx pgx.Tx) (*PendingPublish, error) {
+	result, err := db.Database.NextPendingPublish(ctx, tx)
+	if err == nil {
+		db.mirror(ctx, tx, "NextPendingPublish", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.NextPendingPublish(ctx, tx)
+			return err
+		})
+	}
+	return result, err
+}
+
+// FinishPendingPublish records that a pending publish became active, or was rejected with a reason
+func (db *Shadow) FinishPendingPublish(ctx context.Context, tx pgx.Tx, id int64, state, reason string) (*PendingPublish, error) {
+	result, err := db.Database.FinishPendingPublish(ctx, tx, id, state, reason)
+	if err == nil {
+		db.mirror(ctx, tx, "FinishPendingPublish", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.FinishPendingPublish(ctx, tx, id, state, reason)
+			return err
+		})
+	}
+	return result, err
+}
+
+// RetryPendingPublish records that an attempt of a pending publish failed for a transient reason, and leaves it
+// pending until retryAt. It fails with ErrNotFound if the publish is not pending.
+func (db *Shadow) RetryPendingPublish(ctx context.Context, tx pgx.Tx, id int64, reason string, retryAt time.Time) (*PendingPublish, error) {
+	result, err := db.Database.RetryPendingPublish(ctx, tx, id, reason, retryAt)
+	if err == nil {
+		db.mirror(ctx, tx, "RetryPendingPublish", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.RetryPendingPublish(ctx, tx, id, reason, retryAt)
+			return err
+		})
+	}
+	return result, err
+}
+
+// DeletePendingPublishesBefore permanently removes publishes that finished before the given time and returns how many were removed
+func (db *Shadow) DeletePendingPublishesBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	result, err := db.Database.DeletePendingPublishesBefore(ctx, tx, before)
+	if err == nil {
+		db.mirror(ctx, tx, "DeletePendingPublishesBefore", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.DeletePendingPublishesBefore(ctx, tx, before)
+			return err
+		})
+	}
+	return result, err
+}
+
+// CreateServerAlias stores an alias, replacing any alias with the same name, and records the rename in the
+// changes feed against the latest version of the server it points to
+func (db *Shadow) CreateServerAlias(ctx context.Context, tx pgx.Tx, alias ServerAlias) (*ServerAlias, error) {
+	result, err := db.Database.CreateServerAlias(ctx, tx, alias)
+	if err == nil {
+		db.mirror(ctx, tx, "CreateServerAlias", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreateServerAlias(ctx, tx, alias)
+			return err
+		})
+	}
+	return result, err
+}
+
+// RetargetServerAliases points the aliases of a server at its new name, returning how many were moved
+func (db *Shadow) RetargetServerAliases(ctx context.Context, tx pgx.Tx, serverName, newName string) (int64, error) {
+	result, err := db.Database.RetargetServerAliases(ctx, tx, serverName, newName)
+	if err == nil {
+		db.mirror(ctx, tx, "RetargetServerAliases", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.RetargetServerAliases(ctx, tx, serverName, newName)
+			return err
+		})
+	}
+	return result, err
+}
+
+// DeleteServerAlias removes an alias, returning ErrNotFound if there is none
+func (db *Shadow) DeleteServerAlias(ctx context.Context, tx pgx.Tx, alias string) error {
+	if err := db.Database.DeleteServerAlias(ctx, tx, alias); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteServerAlias", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteServerAlias(ctx, tx, alias)
+	})
+	return nil
+}
+
+// CreateWebhookSubscription stores a webhook subscription, assigning its ID and creation time
+func (db *Shadow) CreateWebhookSubscription(ctx context.Context, tx pgx.Tx, subscription WebhookSubscription) (*WebhookSubscription, error) {
+	result, err := db.Database.CreateWebhookSubscription(ctx, tx, subscription)
+	if err == nil {
+		db.mirror(ctx, tx, "CreateWebhookSubscription", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreateWebhookSubscription(ctx, tx, subscription)
+			return err
+		})
+	}
+	return result, err
+}
+
+// UpdateWebhookSubscription replaces a webhook subscription's URL, filters and format
+func (db *Shadow) UpdateWebhookSubscription(ctx context.Context, tx pgx.Tx, subscription *WebhookSubscription) error {
+	subscriptionCopy := snapshot(subscription)
+	if err := db.Database.UpdateWebhookSubscription(ctx, tx, subscription); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "UpdateWebhookSubscription", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.UpdateWebhookSubscription(ctx, tx, subscriptionCopy)
+	})
+	return nil
+}
+
+// UpdateWebhookSubscriptionSecret replaces the stored signing secret of a webhook subscription
+func (db *Shadow) UpdateWebhookSubscriptionSecret(ctx context.Context, tx pgx.Tx, id int64, secret string) error {
+	if err := db.Database.UpdateWebhookSubscriptionSecret(ctx, tx, id, secret); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "UpdateWebhookSubscriptionSecret", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.UpdateWebhookSubscriptionSecret(ctx, tx, id, secret)
+	})
+	return nil
+}
+
+// RecordWebhookDelivery records how far a webhook subscription has been delivered and why delivery stopped, if it failed
+func (db *Shadow) RecordWebhookDelivery(ctx context.Context, tx pgx.Tx, id int64, lastSeq int64, lastError string) error {
+	if err := db.Database.RecordWebhookDelivery(ctx, tx, id, lastSeq, lastError); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "RecordWebhookDelivery", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.RecordWebhookDelivery(ctx, tx, id, lastSeq, lastError)
+	})
+	return nil
+}
+
+// DeleteWebhookSubscription permanently removes a webhook subscription
+func (db *Shadow) DeleteWebhookSubscription(ctx context.Context, tx pgx.Tx, id int64) error {
+	if err := db.Database.DeleteWebhookSubscription(ctx, tx, id); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteWebhookSubscription", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteWebhookSubscription(ctx, tx, id)
+	})
+	return nil
+}
+
+// CreateAnnouncement stores an announcement, assigning its ID and creation time
+func (db *Shadow) CreateAnnouncement(ctx context.Context, tx pgx.Tx, announcement AnnouncementRecord) (*AnnouncementRecord, error) {
+	result, err := db.Database.CreateAnnouncement(ctx, tx, announcement)
+	if err == nil {
+		db.mirror(ctx, tx, "CreateAnnouncement", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreateAnnouncement(ctx, tx, announcement)
+			return err
+		})
+	}
+	return result, err
+}
+
+// UpdateAnnouncement replaces an announcement's message, severity and schedule
+func (db *Shadow) UpdateAnnouncement(ctx context.Context, tx pgx.Tx, announcement *AnnouncementRecord) error {
+	announcementCopy := snapshot(announcement)
+	if err := db.Database.UpdateAnnouncement(ctx, tx, announcement); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "UpdateAnnouncement", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.UpdateAnnouncement(ctx, tx, announcementCopy)
+	})
+	return nil
+}
+
+// DeleteAnnouncement permanently removes an announcement
+func (db *Shadow) DeleteAnnouncement(ctx context.Context, tx pgx.Tx, id int64) error {
+	if err := db.Database.DeleteAnnouncement(ctx, tx, id); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteAnnouncement", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteAnnouncement(ctx, tx, id)
+	})
+	return nil
+}
+
+// CreateOAuthClient stores a registered OAuth client, assigning its creation time. It returns ErrAlreadyExists
+// when the client ID is taken.
+func (db *Shadow) CreateOAuthClient(ctx context.Context, tx pgx.Tx, client OAuthClient) (*OAuthClient, error) {
+	result, err := db.Database.CreateOAuthClient(ctx, tx, client)
+	if err == nil {
+		db.mirror(ctx, tx, "CreateOAuthClient", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreateOAuthClient(ctx, tx, client)
+			return err
+		})
+	}
+	return result, err
+}
+
+// DeleteOAuthClient permanently removes a registered OAuth client
+func (db *Shadow) DeleteOAuthClient(ctx context.Context, tx pgx.Tx, clientID string) error {
+	if err := db.Database.DeleteOAuthClient(ctx, tx, clientID); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteOAuthClient", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteOAuthClient(ctx, tx, clientID)
+	})
+	return nil
+}
+
+// CreateCollection stores a collection, assigning its ID and creation time
+func (db *Shadow) CreateCollection(ctx context.Context, tx pgx.Tx, collection Collection) (*Collection, error) {
+	result, err := db.Database.CreateCollection(ctx, tx, collection)
+	if err == nil {
+		db.mirror(ctx, tx, "CreateCollection", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreateCollection(ctx, tx, collection)
+			return err
+		})
+	}
+	return result, err
+}
+
+// UpdateCollection replaces a collection's title, description and items
+func (db *Shadow) UpdateCollection(ctx context.Context, tx pgx.Tx, collection *Collection) error {
+	collectionCopy := snapshot(collection)
+	if err := db.Database.UpdateCollection(ctx, tx, collection); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "UpdateCollection", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.UpdateCollection(ctx, tx, collectionCopy)
+	})
+	return nil
+}
+
+// DeleteCollection permanently removes a collection
+func (db *Shadow) DeleteCollection(ctx context.Context, tx pgx.Tx, id int64) error {
+	if err := db.Database.DeleteCollection(ctx, tx, id); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteCollection", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteCollection(ctx, tx, id)
+	})
+	return nil
+}
+
+// CreatePreview stores a preview under the ID it was given, recording its creation time
+func (db *Shadow) CreatePreview(ctx context.Context, tx pgx.Tx, preview Preview) (*Preview, error) {
+	result, err := db.Database.CreatePreview(ctx, tx, preview)
+	if err == nil {
+		db.mirror(ctx, tx, "CreatePreview", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.CreatePreview(ctx, tx, preview)
+			return err
+		})
+	}
+	return result, err
+}
+
+// DeleteExpiredPreviews removes previews that expired before the given time and returns how many were removed
+func (db *Shadow) DeleteExpiredPreviews(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	result, err := db.Database.DeleteExpiredPreviews(ctx, tx, before)
+	if err == nil {
+		db.mirror(ctx, tx, "DeleteExpiredPreviews", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.DeleteExpiredPreviews(ctx, tx, before)
+			return err
+		})
+	}
+	return result, err
+}
+
+// IncrementMetric adds delta to a metric's counter for the bucket containing at
+func (db *Shadow) IncrementMetric(ctx context.Context, tx pgx.Tx, metric string, at time.Time, delta int64) error {
+	if err := db.Database.IncrementMetric(ctx, tx, metric, at, delta); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "IncrementMetric", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.IncrementMetric(ctx, tx, metric, at, delta)
+	})
+	return nil
+}
+
+// PutInstallReport stores an install report, replacing the reporter's earlier report for the version
+func (db *Shadow) PutInstallReport(ctx context.Context, tx pgx.Tx, report InstallReport) error {
+	if err := db.Database.PutInstallReport(ctx, tx, report); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "PutInstallReport", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.PutInstallReport(ctx, tx, report)
+	})
+	return nil
+}
+
+// DeleteInstallReportsBefore removes install reports made before the given time and returns how many were removed
+func (db *Shadow) DeleteInstallReportsBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	result, err := db.Database.DeleteInstallReportsBefore(ctx, tx, before)
+	if err == nil {
+		db.mirror(ctx, tx, "DeleteInstallReportsBefore", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.DeleteInstallReportsBefore(ctx, tx, before)
+			return err
+		})
+	}
+	return result, err
+}
+
+// PutValidationPolicy sets the validation policy of a namespace, replacing any existing policy for it
+func (db *Shadow) PutValidationPolicy(ctx context.Context, tx pgx.Tx, policy ValidationPolicy) (*ValidationPolicy, error) {
+	result, err := db.Database.PutValidationPolicy(ctx, tx, policy)
+	if err == nil {
+		db.mirror(ctx, tx, "PutValidationPolicy", func(ctx context.Context, tx pgx.Tx) error {
+			_, err := db.shadow.PutValidationPolicy(ctx, tx, policy)
+			return err
+		})
+	}
+	return result, err
+}
+
+// DeleteValidationPolicy removes the validation policy of a namespace
+func (db *Shadow) DeleteValidationPolicy(ctx context.Context, tx pgx.Tx, namespace string) error {
+	if err := db.Database.DeleteValidationPolicy(ctx, tx, namespace); err != nil {
+		return err
+	}
+	db.mirror(ctx, tx, "DeleteValidationPolicy", func(ctx context.Context, tx pgx.Tx) error {
+		return db.shadow.DeleteValidationPolicy(ctx, tx, namespace)
+	})
+	return nil
+}
@@ -0,0 +1,59 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestShadow(t *testing.T) {
+	ctx := context.Background()
+	primary, shadow := database.NewMemory(), database.NewMemory()
+	db := database.NewShadow(primary, shadow)
+	publishedAt := time.Now().Add(-time.Hour)
+
+	// Writes outside a transaction are repeated right away
+	createTestServer(t, db, "com.example/server", "1.0.0", publishedAt, true)
+	_, err := shadow.GetServerByNameAndVersion(ctx, nil, "com.example/server", "1.0.0", false)
+	require.NoError(t, err)
+
+	// Writes in a transaction are repeated once it commits, and discarded when it rolls back
+	message := "Use com.example/other"
+	require.NoError(t, db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := db.SetServerStatus(ctx, tx, "com.example/server", "1.0.0", model.StatusDeprecated, &message)
+		return err
+	}))
+	errRollback := errors.New("rollback")
+	err = db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if _, err := db.SetServerStatus(ctx, tx, "com.example/server", "1.0.0", model.StatusDeleted, nil); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	for _, backend := range []database.Database{primary, shadow} {
+		server, err := backend.GetServerByNameAndVersion(ctx, nil, "com.example/server", "1.0.0", true)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDeprecated, server.Meta.Official.Status)
+		assert.Equal(t, &message, server.Meta.Official.StatusMessage)
+	}
+
+	// Writes the shadow rejects are counted without failing the caller
+	require.NoError(t, shadow.DeleteServerVersion(ctx, nil, "com.example/server", "1.0.0"))
+	_, err = db.SetServerStatus(ctx, nil, "com.example/server", "1.0.0", model.StatusActive, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), db.Failures())
+
+	// Reads are served from the primary
+	server, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/server", "1.0.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusActive, server.Meta.Official.Status)
+}
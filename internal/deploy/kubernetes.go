@@ -0,0 +1,333 @@
+// Package deploy renders deployment manifests for remote MCP servers distributed as OCI images,
+// using the deployment hints published in server.json.
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// DefaultPort is the container port used when neither the deployment hints nor the transport URL set one
+const DefaultPort = 8080
+
+// ErrNotDeployable is returned when a server has no OCI package serving MCP over HTTP
+var ErrNotDeployable = errors.New("server has no oci package with a streamable-http or sse transport")
+
+var (
+	// variablePattern matches {variable} references in input values
+	variablePattern = regexp.MustCompile(`\{([a-zA-Z0-9_.-]+)\}`)
+	// invalidNameChars matches characters not allowed in Kubernetes resource names
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// Package returns the first OCI package that serves MCP over HTTP, which deployment hints apply to,
+// or nil if the server has none
+func Package(server *apiv0.ServerJSON) *model.Package {
+	for i, pkg := range server.Packages {
+		if pkg.RegistryType == model.RegistryTypeOCI &&
+			(pkg.Transport.Type == model.TransportTypeStreamableHTTP || pkg.Transport.Type == model.TransportTypeSSE) {
+			return &server.Packages[i]
+		}
+	}
+	return nil
+}
+
+// RenderKubernetes renders a Deployment and a Service running the server's OCI image as a multi-document
+// YAML manifest. Secret environment variables are read from a Secret named after the server, which the
+// manifest does not create; required values without a default are left as <NAME> placeholders.
+func RenderKubernetes(server *apiv0.ServerJSON) ([]byte, error) {
+	pkg := Package(server)
+	if pkg == nil {
+		return nil, ErrNotDeployable
+	}
+	var hints model.DeploymentHints
+	if server.Meta != nil && server.Meta.Deployment != nil {
+		hints = *server.Meta.Deployment
+	}
+
+	name := resourceName(server.Name)
+	secretName := name + "-secrets"
+	labels := map[string]string{
+		"app.kubernetes.io/name":       name,
+		"app.kubernetes.io/managed-by": "mcp-registry",
+	}
+	metadata := objectMeta{
+		Name:   name,
+		Labels: labels,
+		Annotations: map[string]string{
+			"io.modelcontextprotocol.registry/server-name": server.Name,
+			"io.modelcontextprotocol.registry/version":     server.Version,
+		},
+	}
+
+	env, secretKeys := environment(pkg, hints, secretName)
+	serverContainer := container{
+		Name:  "mcp-server",
+		Image: pkg.Identifier,
+		Args:  arguments(pkg.PackageArguments),
+		Ports: []containerPort{{Name: "mcp", ContainerPort: containerPortFor(pkg, hints)}},
+		Env:   env,
+	}
+	if hints.Resources != nil {
+		serverContainer.Resources = &resources{Requests: quantities(hints.Resources.Requests), Limits: quantities(hints.Resources.Limits)}
+	}
+
+	deployment := deploymentManifest{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Metadata:   metadata,
+		Spec: deploymentSpec{
+			Replicas: 1,
+			Selector: labelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": name}},
+			Template: podTemplate{
+				Metadata: objectMeta{Labels: labels},
+				Spec:     podSpec{Containers: []container{serverContainer}},
+			},
+		},
+	}
+	service := serviceManifest{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Metadata:   metadata,
+		Spec: serviceSpec{
+			Selector: map[string]string{"app.kubernetes.io/name": name},
+			Ports:    []servicePort{{Name: "mcp", Port: 80, TargetPort: "mcp"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s %s\n", server.Name, server.Version)
+	if len(secretKeys) > 0 {
+		fmt.Fprintf(&buf, "# Create the secret first: kubectl create secret generic %s", secretName)
+		for _, key := range secretKeys {
+			fmt.Fprintf(&buf, " --from-literal=%s=...", key)
+		}
+		buf.WriteString("\n")
+	}
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(deployment); err != nil {
+		return nil, fmt.Errorf("failed to render deployment: %w", err)
+	}
+	if err := encoder.Encode(service); err != nil {
+		return nil, fmt.Errorf("failed to render service: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resourceName derives a valid Kubernetes resource name from the server name's last segment
+func resourceName(serverName string) string {
+	name := serverName
+	if _, after, found := strings.Cut(serverName, "/"); found {
+		name = after
+	}
+	name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	if name == "" {
+		name = "mcp-server"
+	}
+	return name
+}
+
+// containerPortFor returns the hinted port, else the port in the transport URL, else DefaultPort
+func containerPortFor(pkg *model.Package, hints model.DeploymentHints) int {
+	if hints.Port != 0 {
+		return hints.Port
+	}
+	if u, err := url.Parse(pkg.Transport.URL); err == nil {
+		if port, err := strconv.Atoi(u.Port()); err == nil {
+			return port
+		}
+	}
+	return DefaultPort
+}
+
+// environment renders the package's environment variables and the hinted secrets. It returns the
+// variables and the keys the secret must contain.
+func environment(pkg *model.Package, hints model.DeploymentHints, secretName string) ([]envVar, []string) {
+	var env []envVar
+	var secretKeys []string
+	seen := map[string]bool{}
+	addSecret := func(name string) {
+		env = append(env, envVar{Name: name, ValueFrom: &envVarSource{SecretKeyRef: &secretKeySelector{Name: secretName, Key: name}}})
+		secretKeys = append(secretKeys, name)
+		seen[name] = true
+	}
+
+	for _, variable := range pkg.EnvironmentVariables {
+		if variable.IsSecret {
+			addSecret(variable.Name)
+			continue
+		}
+		if value, ok := resolve(variable.Name, variable.InputWithVariables); ok {
+			env = append(env, envVar{Name: variable.Name, Value: value})
+			seen[variable.Name] = true
+		}
+	}
+	for _, secret := range hints.Secrets {
+		if !seen[secret.Name] {
+			addSecret(secret.Name)
+		}
+	}
+	return env, secretKeys
+}
+
+// arguments renders package arguments in order, skipping optional ones without a value
+func arguments(args []model.Argument) []string {
+	var rendered []string
+	for _, argument := range args {
+		id := argument.ValueHint
+		if id == "" {
+			id = strings.TrimLeft(argument.Name, "-")
+		}
+		value, ok := resolve(id, argument.InputWithVariables)
+		switch {
+		case !ok:
+			continue
+		case argument.Type == model.ArgumentTypeNamed:
+			rendered = append(rendered, argument.Name, value)
+		default:
+			rendered = append(rendered, value)
+		}
+	}
+	return rendered
+}
+
+// resolve returns the value for an input: its fixed value with {variables} substituted, else its default,
+// else a <id> placeholder when it is required. It reports false for optional inputs without a value.
+func resolve(id string, input model.InputWithVariables) (string, bool) {
+	if input.Value != "" {
+		return variablePattern.ReplaceAllStringFunc(input.Value, func(match string) string {
+			name := strings.Trim(match, "{}")
+			variable, ok := input.Variables[name]
+			if !ok {
+				return match
+			}
+			value, _ := resolve(name, model.InputWithVariables{Input: variable})
+			return value
+		}), true
+	}
+	if input.Default != "" {
+		return input.Default, true
+	}
+	if !input.IsRequired {
+		return "", false
+	}
+	return "<" + id + ">", true
+}
+
+func quantities(q *model.ResourceQuantities) map[string]string {
+	if q == nil {
+		return nil
+	}
+	result := map[string]string{}
+	if q.CPU != "" {
+		result["cpu"] = q.CPU
+	}
+	if q.Memory != "" {
+		result["memory"] = q.Memory
+	}
+	return result
+}
+
+// The subset of Kubernetes object fields the manifest uses, in kubectl's field order
+
+type objectMeta struct {
+	Name        string            `yaml:"name,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+type deploymentManifest struct {
+	APIVersion string         `yaml:"apiVersion"`
+	Kind       string         `yaml:"kind"`
+	Metadata   objectMeta     `yaml:"metadata"`
+	Spec       deploymentSpec `yaml:"spec"`
+}
+
+type deploymentSpec struct {
+	Replicas int           `yaml:"replicas"`
+	Selector labelSelector `yaml:"selector"`
+	Template podTemplate   `yaml:"template"`
+}
+
+type labelSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type podTemplate struct {
+	Metadata objectMeta `yaml:"metadata"`
+	Spec     podSpec    `yaml:"spec"`
+}
+
+type podSpec struct {
+	Containers []container `yaml:"containers"`
+}
+
+type container struct {
+	Name      string          `yaml:"name"`
+	Image     string          `yaml:"image"`
+	Args      []string        `yaml:"args,omitempty"`
+	Ports     []containerPort `yaml:"ports"`
+	Env       []envVar        `yaml:"env,omitempty"`
+	Resources *resources      `yaml:"resources,omitempty"`
+}
+
+type containerPort struct {
+	Name          string `yaml:"name"`
+	ContainerPort int    `yaml:"containerPort"`
+}
+
+type envVar struct {
+	Name      string        `yaml:"name"`
+	Value     string        `yaml:"value,omitempty"`
+	ValueFrom *envVarSource `yaml:"valueFrom,omitempty"`
+}
+
+type envVarSource struct {
+	SecretKeyRef *secretKeySelector `yaml:"secretKeyRef"`
+}
+
+type secretKeySelector struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+type resources struct {
+	Requests map[string]string `yaml:"requests,omitempty"`
+	Limits   map[string]string `yaml:"limits,omitempty"`
+}
+
+type serviceManifest struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   objectMeta  `yaml:"metadata"`
+	Spec       serviceSpec `yaml:"spec"`
+}
+
+type serviceSpec struct {
+	Selector map[string]string `yaml:"selector"`
+	Ports    []servicePort     `yaml:"ports"`
+}
+
+type servicePort struct {
+	Name       string `yaml:"name"`
+	Port       int    `yaml:"port"`
+	TargetPort string `yaml:"targetPort"`
+}
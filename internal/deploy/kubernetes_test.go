@@ -0,0 +1,120 @@
+package deploy_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/modelcontextprotocol/registry/internal/deploy"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func weatherServer() *apiv0.ServerJSON {
+	return &apiv0.ServerJSON{
+		Name:    "io.github.example/Weather_Server",
+		Version: "1.2.0",
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@example/weather",
+				Version:      "1.2.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+			{
+				RegistryType: model.RegistryTypeOCI,
+				Identifier:   "ghcr.io/example/weather:1.2.0",
+				Transport:    model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "http://localhost:3000/mcp"},
+				PackageArguments: []model.Argument{
+					{Type: model.ArgumentTypeNamed, Name: "--units", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: "metric"}}},
+					{Type: model.ArgumentTypePositional, ValueHint: "cache_dir"},
+				},
+				EnvironmentVariables: []model.KeyValueInput{
+					{Name: "LOG_LEVEL", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: "info"}}},
+					{Name: "REGION", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true}}},
+					{Name: "WEATHER_API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true, IsSecret: true}}},
+				},
+			},
+		},
+		Meta: &apiv0.ServerMeta{Deployment: &model.DeploymentHints{
+			Resources: &model.ContainerResources{
+				Requests: &model.ResourceQuantities{CPU: "100m", Memory: "128Mi"},
+				Limits:   &model.ResourceQuantities{Memory: "512Mi"},
+			},
+			Secrets: []model.DeploymentSecret{{Name: "WEATHER_API_KEY"}, {Name: "SENTRY_DSN"}},
+		}},
+	}
+}
+
+func decodeManifest(t *testing.T, manifest []byte) []map[string]any {
+	t.Helper()
+	var docs []map[string]any
+	decoder := yaml.NewDecoder(bytes.NewReader(manifest))
+	for {
+		var doc map[string]any
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs
+		}
+		require.NoError(t, err)
+		docs = append(docs, doc)
+	}
+}
+
+func TestRenderKubernetes(t *testing.T) {
+	manifest, err := deploy.RenderKubernetes(weatherServer())
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), "kubectl create secret generic weather-server-secrets --from-literal=WEATHER_API_KEY=... --from-literal=SENTRY_DSN=...")
+
+	docs := decodeManifest(t, manifest)
+	require.Len(t, docs, 2)
+	deployment, service := docs[0], docs[1]
+	assert.Equal(t, "Deployment", deployment["kind"])
+	assert.Equal(t, "Service", service["kind"])
+	assert.Equal(t, "weather-server", deployment["metadata"].(map[string]any)["name"])
+
+	podSpec := deployment["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)
+	container := podSpec["containers"].([]any)[0].(map[string]any)
+	assert.Equal(t, "ghcr.io/example/weather:1.2.0", container["image"])
+	assert.Equal(t, []any{"--units", "metric"}, container["args"])
+	assert.Equal(t, []any{map[string]any{"name": "mcp", "containerPort": 3000}}, container["ports"])
+	assert.Equal(t, map[string]any{
+		"requests": map[string]any{"cpu": "100m", "memory": "128Mi"},
+		"limits":   map[string]any{"memory": "512Mi"},
+	}, container["resources"])
+
+	secretRef := func(key string) map[string]any {
+		return map[string]any{"name": key, "valueFrom": map[string]any{"secretKeyRef": map[string]any{"name": "weather-server-secrets", "key": key}}}
+	}
+	assert.Equal(t, []any{
+		map[string]any{"name": "LOG_LEVEL", "value": "info"},
+		map[string]any{"name": "REGION", "value": "<REGION>"},
+		secretRef("WEATHER_API_KEY"),
+		secretRef("SENTRY_DSN"),
+	}, container["env"])
+}
+
+func TestRenderKubernetes_PortFallback(t *testing.T) {
+	server := weatherServer()
+	server.Packages[1].Transport.URL = "http://localhost:{port}/mcp"
+	manifest, err := deploy.RenderKubernetes(server)
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), "containerPort: 8080")
+
+	server.Meta.Deployment.Port = 9000
+	manifest, err = deploy.RenderKubernetes(server)
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), "containerPort: 9000")
+}
+
+func TestRenderKubernetes_NotDeployable(t *testing.T) {
+	server := weatherServer()
+	server.Packages = server.Packages[:1]
+	_, err := deploy.RenderKubernetes(server)
+	assert.ErrorIs(t, err, deploy.ErrNotDeployable)
+}
@@ -0,0 +1,310 @@
+// Package diff compares two versions of a server.json document, so clients can show users what
+// changes when they upgrade a server.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Kinds of change to a package, remote, environment variable, argument or header
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Diff is the structured difference between two versions of a server
+type Diff struct {
+	ServerName string          `json:"serverName" doc:"Server name" example:"io.github.user/weather"`
+	From       string          `json:"from" doc:"Older version compared" example:"1.0.0"`
+	To         string          `json:"to" doc:"Newer version compared" example:"1.2.0"`
+	Identical  bool            `json:"identical" doc:"Whether the two server.json documents are the same apart from their version"`
+	Fields     []FieldChange   `json:"fields,omitempty" doc:"Changed top-level fields, such as description or repository"`
+	Packages   []PackageChange `json:"packages,omitempty" doc:"Added, removed and changed packages"`
+	Remotes    []RemoteChange  `json:"remotes,omitempty" doc:"Added, removed and changed remotes"`
+}
+
+// FieldChange is a field whose value differs. From or To is omitted when the field is unset in that version.
+type FieldChange struct {
+	Field string `json:"field" doc:"JSON name of the field" example:"description"`
+	From  any    `json:"from,omitempty" doc:"Value in the older version"`
+	To    any    `json:"to,omitempty" doc:"Value in the newer version"`
+}
+
+// PackageChange describes a package that was added, removed or changed. Packages are matched by registry type
+// and identifier, ignoring the tag and digest of OCI images.
+type PackageChange struct {
+	Change               string        `json:"change" enum:"added,removed,changed" doc:"Kind of change"`
+	RegistryType         string        `json:"registryType" example:"npm"`
+	Identifier           string        `json:"identifier" doc:"Package identifier in the newer version, or in the older one if the package was removed" example:"@user/weather-mcp"`
+	FromVersion          string        `json:"fromVersion,omitempty" doc:"Package version in the older version" example:"1.0.0"`
+	ToVersion            string        `json:"toVersion,omitempty" doc:"Package version in the newer version" example:"1.2.0"`
+	Fields               []FieldChange `json:"fields,omitempty" doc:"Other changed package fields, such as transport or runtimeHint"`
+	EnvironmentVariables []InputChange `json:"environmentVariables,omitempty" doc:"Added, removed and changed environment variables"`
+	RuntimeArguments     []InputChange `json:"runtimeArguments,omitempty" doc:"Added, removed and changed runtime arguments"`
+	PackageArguments     []InputChange `json:"packageArguments,omitempty" doc:"Added, removed and changed package arguments"`
+}
+
+// RemoteChange describes a remote that was added, removed or changed. Remotes are matched by URL.
+type RemoteChange struct {
+	Change  string        `json:"change" enum:"added,removed,changed" doc:"Kind of change"`
+	Type    string        `json:"type" doc:"Transport type in the newer version, or in the older one if the remote was removed" example:"streamable-http"`
+	URL     string        `json:"url" example:"https://mcp.example.com/mcp"`
+	Fields  []FieldChange `json:"fields,omitempty" doc:"Other changed remote fields, such as type or variables"`
+	Headers []InputChange `json:"headers,omitempty" doc:"Added, removed and changed headers"`
+}
+
+// InputChange describes an environment variable, argument or header that was added, removed or changed.
+// Named inputs are matched by name and positional arguments by value hint, or by position if they have none.
+type InputChange struct {
+	Change     string        `json:"change" enum:"added,removed,changed" doc:"Kind of change"`
+	Name       string        `json:"name" doc:"Name of the input" example:"WEATHER_API_KEY"`
+	IsRequired bool          `json:"isRequired,omitempty" doc:"Whether the input is required in the newer version, or was in the older one if it was removed"`
+	IsSecret   bool          `json:"isSecret,omitempty" doc:"Whether the input is a secret in the newer version, or was in the older one if it was removed"`
+	Fields     []FieldChange `json:"fields,omitempty" doc:"Changed fields of the input, such as description or default"`
+}
+
+// Compare returns the difference between two versions of the same server
+func Compare(from, to *apiv0.ServerJSON) *Diff {
+	diff := &Diff{
+		ServerName: to.Name,
+		From:       from.Version,
+		To:         to.Version,
+		Fields:     fieldChanges(from, to, "name", "version", "packages", "remotes"),
+		Packages:   comparePackages(from.Packages, to.Packages),
+		Remotes:    compareRemotes(from.Remotes, to.Remotes),
+	}
+	diff.Identical = len(diff.Fields) == 0 && len(diff.Packages) == 0 && len(diff.Remotes) == 0
+	return diff
+}
+
+// comparePackages lists changed packages in the newer version's order, followed by removed packages
+func comparePackages(from, to []model.Package) []PackageChange {
+	var changes []PackageChange
+	matchKeyed(from, to, keysOf(from, packageKey), keysOf(to, packageKey), func(old, pkg *model.Package) {
+		switch {
+		case old == nil:
+			changes = append(changes, PackageChange{
+				Change:       ChangeAdded,
+				RegistryType: pkg.RegistryType,
+				Identifier:   pkg.Identifier,
+				ToVersion:    pkg.Version,
+			})
+		case pkg == nil:
+			changes = append(changes, PackageChange{
+				Change:       ChangeRemoved,
+				RegistryType: old.RegistryType,
+				Identifier:   old.Identifier,
+				FromVersion:  old.Version,
+			})
+		default:
+			change := PackageChange{
+				Change:               ChangeChanged,
+				RegistryType:         pkg.RegistryType,
+				Identifier:           pkg.Identifier,
+				Fields:               fieldChanges(old, pkg, "version", "environmentVariables", "runtimeArguments", "packageArguments"),
+				EnvironmentVariables: compareKeyValueInputs(old.EnvironmentVariables, pkg.EnvironmentVariables),
+				RuntimeArguments:     compareArguments(old.RuntimeArguments, pkg.RuntimeArguments),
+				PackageArguments:     compareArguments(old.PackageArguments, pkg.PackageArguments),
+			}
+			if old.Version != pkg.Version {
+				change.FromVersion, change.ToVersion = old.Version, pkg.Version
+			}
+			if change.FromVersion != "" || change.ToVersion != "" || len(change.Fields) > 0 || len(change.EnvironmentVariables) > 0 ||
+				len(change.RuntimeArguments) > 0 || len(change.PackageArguments) > 0 {
+				changes = append(changes, change)
+			}
+		}
+	})
+	return changes
+}
+
+// packageKey identifies a package across versions. OCI identifiers carry the image version as a tag
+// or digest, which is dropped so a new image of the same repository counts as a change.
+func packageKey(pkg model.Package) string {
+	identifier := pkg.Identifier
+	if pkg.RegistryType == model.RegistryTypeOCI {
+		identifier, _, _ = strings.Cut(identifier, "@")
+		if slash, colon := strings.LastIndex(identifier, "/"), strings.LastIndex(identifier, ":"); colon > slash {
+			identifier = identifier[:colon]
+		}
+	}
+	return pkg.RegistryType + " " + identifier
+}
+
+// compareRemotes lists changed remotes in the newer version's order, followed by removed remotes
+func compareRemotes(from, to []model.Transport) []RemoteChange {
+	var changes []RemoteChange
+	remoteURL := func(remote model.Transport) string { return remote.URL }
+	matchKeyed(from, to, keysOf(from, remoteURL), keysOf(to, remoteURL), func(old, remote *model.Transport) {
+		switch {
+		case old == nil:
+			changes = append(changes, RemoteChange{Change: ChangeAdded, Type: remote.Type, URL: remote.URL})
+		case remote == nil:
+			changes = append(changes, RemoteChange{Change: ChangeRemoved, Type: old.Type, URL: old.URL})
+		default:
+			change := RemoteChange{
+				Change:  ChangeChanged,
+				Type:    remote.Type,
+				URL:     remote.URL,
+				Fields:  fieldChanges(old, remote, "url", "headers"),
+				Headers: compareKeyValueInputs(old.Headers, remote.Headers),
+			}
+			if len(change.Fields) > 0 || len(change.Headers) > 0 {
+				changes = append(changes, change)
+			}
+		}
+	})
+	return changes
+}
+
+// compareKeyValueInputs compares environment variables or headers by name
+func compareKeyValueInputs(from, to []model.KeyValueInput) []InputChange {
+	var changes []InputChange
+	inputName := func(input model.KeyValueInput) string { return input.Name }
+	matchKeyed(from, to, keysOf(from, inputName), keysOf(to, inputName), func(old, input *model.KeyValueInput) {
+		if change, changed := compareInputs(old, input, func(input *model.KeyValueInput) (string, model.Input) {
+			return input.Name, input.Input
+		}); changed {
+			changes = append(changes, change)
+		}
+	})
+	return changes
+}
+
+// compareArguments compares named arguments by name and positional arguments by value hint or position
+func compareArguments(from, to []model.Argument) []InputChange {
+	var changes []InputChange
+	fromKeys, toKeys := argumentKeys(from), argumentKeys(to)
+	names := map[*model.Argument]string{}
+	for i := range from {
+		names[&from[i]] = fromKeys[i]
+	}
+	for i := range to {
+		names[&to[i]] = toKeys[i]
+	}
+	matchKeyed(from, to, fromKeys, toKeys, func(old, argument *model.Argument) {
+		if change, changed := compareInputs(old, argument, func(argument *model.Argument) (string, model.Input) {
+			return names[argument], argument.Input
+		}); changed {
+			changes = append(changes, change)
+		}
+	})
+	return changes
+}
+
+// argumentKeys names arguments for matching and display; positional arguments without a value hint
+// are named by their position among the positional arguments
+func argumentKeys(arguments []model.Argument) []string {
+	keys := make([]string, len(arguments))
+	positional := 0
+	for i, argument := range arguments {
+		switch {
+		case argument.Type == model.ArgumentTypeNamed:
+			keys[i] = argument.Name
+		case argument.ValueHint != "":
+			keys[i] = argument.ValueHint
+		default:
+			keys[i] = fmt.Sprintf("#%d", positional+1)
+		}
+		if argument.Type != model.ArgumentTypeNamed {
+			positional++
+		}
+	}
+	return keys
+}
+
+// compareInputs describes how an input changed, reporting false if it did not. describe returns the
+// input's name and definition.
+func compareInputs[T any](old, input *T, describe func(*T) (string, model.Input)) (InputChange, bool) {
+	switch {
+	case old == nil:
+		name, definition := describe(input)
+		return InputChange{Change: ChangeAdded, Name: name, IsRequired: definition.IsRequired, IsSecret: definition.IsSecret}, true
+	case input == nil:
+		name, definition := describe(old)
+		return InputChange{Change: ChangeRemoved, Name: name, IsRequired: definition.IsRequired, IsSecret: definition.IsSecret}, true
+	}
+	fields := fieldChanges(old, input, "name")
+	if len(fields) == 0 {
+		return InputChange{}, false
+	}
+	name, definition := describe(input)
+	return InputChange{Change: ChangeChanged, Name: name, IsRequired: definition.IsRequired, IsSecret: definition.IsSecret, Fields: fields}, true
+}
+
+// matchKeyed pairs items of the older and newer lists by their keys and calls visit for each pair, in the
+// newer list's order followed by items only in the older list. A nil old or item means the item was added
+// or removed. Repeated keys are matched in order.
+func matchKeyed[T any](from, to []T, fromKeys, toKeys []string, visit func(old, item *T)) {
+	unmatched := map[string][]int{}
+	for j, key := range fromKeys {
+		unmatched[key] = append(unmatched[key], j)
+	}
+	matched := make([]bool, len(from))
+	for i := range to {
+		candidates := unmatched[toKeys[i]]
+		if len(candidates) == 0 {
+			visit(nil, &to[i])
+			continue
+		}
+		j := candidates[0]
+		unmatched[toKeys[i]] = candidates[1:]
+		matched[j] = true
+		visit(&from[j], &to[i])
+	}
+	for j := range from {
+		if !matched[j] {
+			visit(&from[j], nil)
+		}
+	}
+}
+
+// keysOf returns the key of every item
+func keysOf[T any](items []T, key func(T) string) []string {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = key(item)
+	}
+	return keys
+}
+
+// fieldChanges compares the JSON fields of two values, skipping the given fields, in field name order
+func fieldChanges(from, to any, skip ...string) []FieldChange {
+	fromFields, toFields := jsonFields(from), jsonFields(to)
+	names := make([]string, 0, len(fromFields)+len(toFields))
+	for name := range fromFields {
+		names = append(names, name)
+	}
+	for name := range toFields {
+		if _, exists := fromFields[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var changes []FieldChange
+	for _, name := range names {
+		if slices.Contains(skip, name) || reflect.DeepEqual(fromFields[name], toFields[name]) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: name, From: fromFields[name], To: toFields[name]})
+	}
+	return changes
+}
+
+// jsonFields decodes a value's JSON encoding into its fields, so unset and empty fields compare equal
+func jsonFields(value any) map[string]any {
+	// server.json types always encode, so errors cannot happen
+	encoded, _ := json.Marshal(value)
+	var fields map[string]any
+	_ = json.Unmarshal(encoded, &fields)
+	return fields
+}
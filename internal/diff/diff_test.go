@@ -0,0 +1,107 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/diff"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func weatherServer(version string) *apiv0.ServerJSON {
+	return &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.user/weather",
+		Description: "Weather forecasts",
+		Version:     version,
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@user/weather-mcp",
+				Version:      version,
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+				EnvironmentVariables: []model.KeyValueInput{{
+					Name:               "WEATHER_API_KEY",
+					InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true, IsSecret: true}},
+				}},
+				PackageArguments: []model.Argument{{Type: model.ArgumentTypeNamed, Name: "--units"}},
+			},
+			{
+				RegistryType: model.RegistryTypeOCI,
+				Identifier:   "ghcr.io/user/weather:" + version,
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+		},
+		Remotes: []model.Transport{{Type: model.TransportTypeSSE, URL: "https://weather.example.com/sse"}},
+	}
+}
+
+func TestCompare_Identical(t *testing.T) {
+	result := diff.Compare(weatherServer("1.0.0"), weatherServer("1.0.0"))
+	assert.True(t, result.Identical)
+	assert.Empty(t, result.Packages)
+}
+
+func TestCompare(t *testing.T) {
+	from := weatherServer("1.0.0")
+	to := weatherServer("1.2.0")
+	to.Description = "Weather forecasts and alerts"
+	to.Packages[0].EnvironmentVariables = append(to.Packages[0].EnvironmentVariables, model.KeyValueInput{
+		Name:               "WEATHER_REGION",
+		InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true}},
+	})
+	to.Packages[0].EnvironmentVariables[0].Description = "API key"
+	to.Packages[0].PackageArguments = nil
+	to.Packages = append(to.Packages, model.Package{RegistryType: model.RegistryTypePyPI, Identifier: "weather-mcp", Version: "1.2.0"})
+	to.Remotes = []model.Transport{
+		{Type: model.TransportTypeStreamableHTTP, URL: "https://weather.example.com/sse"},
+		{Type: model.TransportTypeStreamableHTTP, URL: "https://weather.example.com/mcp"},
+	}
+
+	result := diff.Compare(from, to)
+	assert.False(t, result.Identical)
+	assert.Equal(t, "1.0.0", result.From)
+	assert.Equal(t, "1.2.0", result.To)
+	assert.Equal(t, []diff.FieldChange{{Field: "description", From: "Weather forecasts", To: "Weather forecasts and alerts"}}, result.Fields)
+
+	require.Len(t, result.Packages, 3)
+	npm := result.Packages[0]
+	assert.Equal(t, diff.ChangeChanged, npm.Change)
+	assert.Equal(t, "1.0.0", npm.FromVersion)
+	assert.Equal(t, "1.2.0", npm.ToVersion)
+	assert.Empty(t, npm.Fields)
+	assert.Equal(t, []diff.InputChange{
+		{Change: diff.ChangeChanged, Name: "WEATHER_API_KEY", IsRequired: true, IsSecret: true, Fields: []diff.FieldChange{{Field: "description", To: "API key"}}},
+		{Change: diff.ChangeAdded, Name: "WEATHER_REGION", IsRequired: true},
+	}, npm.EnvironmentVariables)
+	assert.Equal(t, []diff.InputChange{{Change: diff.ChangeRemoved, Name: "--units"}}, npm.PackageArguments)
+
+	oci := result.Packages[1]
+	assert.Equal(t, diff.ChangeChanged, oci.Change, "a new tag of the same image is a change, not a new package")
+	assert.Equal(t, []diff.FieldChange{{Field: "identifier", From: "ghcr.io/user/weather:1.0.0", To: "ghcr.io/user/weather:1.2.0"}}, oci.Fields)
+
+	assert.Equal(t, diff.ChangeAdded, result.Packages[2].Change)
+	assert.Equal(t, "weather-mcp", result.Packages[2].Identifier)
+
+	assert.Equal(t, []diff.RemoteChange{
+		{Change: diff.ChangeChanged, Type: model.TransportTypeStreamableHTTP, URL: "https://weather.example.com/sse", Fields: []diff.FieldChange{{Field: "type", From: "sse", To: "streamable-http"}}},
+		{Change: diff.ChangeAdded, Type: model.TransportTypeStreamableHTTP, URL: "https://weather.example.com/mcp"},
+	}, result.Remotes)
+}
+
+func TestCompare_PositionalArguments(t *testing.T) {
+	from := weatherServer("1.0.0")
+	to := weatherServer("1.1.0")
+	from.Packages[0].PackageArguments = []model.Argument{{Type: model.ArgumentTypePositional, ValueHint: "city"}}
+	to.Packages[0].PackageArguments = []model.Argument{
+		{Type: model.ArgumentTypePositional, ValueHint: "city"},
+		{Type: model.ArgumentTypePositional, InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "metric"}}},
+	}
+
+	result := diff.Compare(from, to)
+	require.NotEmpty(t, result.Packages)
+	assert.Equal(t, []diff.InputChange{{Change: diff.ChangeAdded, Name: "#2"}}, result.Packages[0].PackageArguments)
+}
@@ -0,0 +1,231 @@
+// Package encryption encrypts sensitive database columns at rest with envelope encryption.
+//
+// Values are encrypted with AES-256-GCM under a data encryption key (DEK), and the DEK is stored with
+// the value wrapped by a key encryption key (KEK) held by a KeyProvider, such as a cloud KMS. Only the
+// KEK's ID and the wrapped DEK are stored, so the database alone cannot decrypt anything. Rotating the
+// KEK means making a new key current in the provider and re-encrypting stored values with
+// `registry encryption rotate`; old keys must stay available until that has finished.
+//
+// Encrypted values are strings of the form enc:v1:<key id>:<wrapped DEK>:<nonce and ciphertext>, so
+// they fit existing text columns, and values without the prefix are read as unencrypted values written
+// before encryption was enabled.
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// prefix starts every encrypted value
+const prefix = "enc:v1:"
+
+// maxDEKUses is how many values are encrypted with one DEK before a new one is generated, well below
+// the limit for random GCM nonces
+const maxDEKUses = 1 << 24
+
+// maxUnwrappedKeys bounds the cache of unwrapped DEKs
+const maxUnwrappedKeys = 256
+
+// ErrNotConfigured is returned when decrypting an encrypted value without a key provider
+var ErrNotConfigured = errors.New("encryption is not configured")
+
+// KeyProvider wraps and unwraps data encryption keys with key encryption keys it holds. Providers must
+// keep every key that has wrapped a stored DEK available for unwrapping until those values are rotated.
+type KeyProvider interface {
+	// CurrentKeyID returns the ID of the key used to wrap new DEKs. IDs must not contain ':'.
+	CurrentKeyID() string
+	// WrapKey encrypts a DEK with the key encryption key keyID
+	WrapKey(ctx context.Context, keyID string, dek []byte) ([]byte, error)
+	// UnwrapKey decrypts a DEK wrapped by WrapKey with the key encryption key keyID
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// Encryptor encrypts and decrypts column values. A nil Encryptor stores values unencrypted, so callers
+// need not check whether encryption is enabled.
+type Encryptor struct {
+	provider KeyProvider
+
+	mu        sync.Mutex
+	dek       *dataKey
+	unwrapped map[string][]byte
+}
+
+// dataKey is the DEK used for new values, with its wrapped form
+type dataKey struct {
+	keyID   string
+	key     []byte
+	wrapped string
+	uses    int
+}
+
+// New creates an encryptor wrapping DEKs with provider
+func New(provider KeyProvider) *Encryptor {
+	return &Encryptor{provider: provider, unwrapped: map[string][]byte{}}
+}
+
+// IsEncrypted reports whether value was produced by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Encrypt encrypts plaintext for the column named by column, such as "audit_log.client_ip". The column
+// is authenticated with the value, so a value copied into another column fails to decrypt. Empty values
+// are returned as they are.
+func (e *Encryptor) Encrypt(ctx context.Context, column, plaintext string) (string, error) {
+	if e == nil || plaintext == "" {
+		return plaintext, nil
+	}
+
+	dek, err := e.currentDEK(ctx)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(dek.key, []byte(plaintext), []byte(column))
+	if err != nil {
+		return "", err
+	}
+	return prefix + dek.keyID + ":" + dek.wrapped + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value produced by Encrypt for the same column. Values that are not encrypted are
+// returned as they are.
+func (e *Encryptor) Decrypt(ctx context.Context, column, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if e == nil {
+		return "", ErrNotConfigured
+	}
+
+	keyID, wrapped, sealed, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+	dek, err := e.unwrap(ctx, keyID, wrapped)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dek, sealed, []byte(column))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s: %w", column, err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether value should be re-encrypted: it is not encrypted, or its DEK is
+// wrapped by a key other than the provider's current key
+func (e *Encryptor) NeedsRotation(value string) bool {
+	if e == nil || value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	keyID, _, _, err := parse(value)
+	return err != nil || keyID != e.provider.CurrentKeyID()
+}
+
+// currentDEK returns the DEK for new values, generating and wrapping a new one when the provider's
+// current key changed or the DEK has been used too often
+func (e *Encryptor) currentDEK(ctx context.Context) (*dataKey, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	keyID := e.provider.CurrentKeyID()
+	if e.dek != nil && e.dek.keyID == keyID && e.dek.uses < maxDEKUses {
+		e.dek.uses++
+		return e.dek, nil
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate data encryption key: %w", err)
+	}
+	wrapped, err := e.provider.WrapKey(ctx, keyID, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data encryption key with key %q: %w", keyID, err)
+	}
+	e.dek = &dataKey{keyID: keyID, key: key, wrapped: base64.RawURLEncoding.EncodeToString(wrapped), uses: 1}
+	return e.dek, nil
+}
+
+// unwrap returns the DEK of a stored value, asking the provider only for DEKs it has not unwrapped yet
+func (e *Encryptor) unwrap(ctx context.Context, keyID, wrapped string) ([]byte, error) {
+	cacheKey := keyID + ":" + wrapped
+	e.mu.Lock()
+	dek, ok := e.unwrapped[cacheKey]
+	e.mu.Unlock()
+	if ok {
+		return dek, nil
+	}
+
+	wrappedBytes, err := base64.RawURLEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, errors.New("malformed encrypted value")
+	}
+	dek, err = e.provider.UnwrapKey(ctx, keyID, wrappedBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data encryption key with key %q: %w", keyID, err)
+	}
+
+	e.mu.Lock()
+	if len(e.unwrapped) >= maxUnwrappedKeys {
+		clear(e.unwrapped)
+	}
+	e.unwrapped[cacheKey] = dek
+	e.mu.Unlock()
+	return dek, nil
+}
+
+// parse splits an encrypted value into its key ID, wrapped DEK and sealed plaintext
+func parse(value string) (string, string, []byte, error) {
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", nil, errors.New("malformed encrypted value")
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", "", nil, errors.New("malformed encrypted value")
+	}
+	return parts[0], parts[1], sealed, nil
+}
+
+// seal encrypts plaintext with AES-256-GCM under key, returning the nonce followed by the ciphertext
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts the output of seal
+func open(key, sealed, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
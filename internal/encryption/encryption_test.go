@@ -0,0 +1,118 @@
+package encryption_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/encryption"
+)
+
+func newKey(t *testing.T, id string) string {
+	t.Helper()
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return id + ":" + base64.StdEncoding.EncodeToString(key)
+}
+
+func TestEncryptor_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	provider, err := encryption.NewLocalKeyProvider([]string{newKey(t, "k1")})
+	require.NoError(t, err)
+	encryptor := encryption.New(provider)
+
+	encrypted, err := encryptor.Encrypt(ctx, "audit_log.client_ip", "203.0.113.7")
+	require.NoError(t, err)
+	assert.True(t, encryption.IsEncrypted(encrypted))
+	assert.NotContains(t, encrypted, "203.0.113.7")
+	assert.True(t, strings.HasPrefix(encrypted, "enc:v1:k1:"))
+
+	again, err := encryptor.Encrypt(ctx, "audit_log.client_ip", "203.0.113.7")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again, "each value has its own nonce")
+
+	decrypted, err := encryptor.Decrypt(ctx, "audit_log.client_ip", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", decrypted)
+
+	_, err = encryptor.Decrypt(ctx, "other.column", encrypted)
+	require.Error(t, err, "values are bound to their column")
+
+	plaintext, err := encryptor.Decrypt(ctx, "audit_log.client_ip", "198.51.100.1")
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.1", plaintext, "values written before encryption are read as they are")
+
+	empty, err := encryptor.Encrypt(ctx, "audit_log.client_ip", "")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestEncryptor_Rotation(t *testing.T) {
+	ctx := context.Background()
+	oldKey, newKeyPair := newKey(t, "old"), newKey(t, "new")
+
+	oldProvider, err := encryption.NewLocalKeyProvider([]string{oldKey})
+	require.NoError(t, err)
+	encrypted, err := encryption.New(oldProvider).Encrypt(ctx, "column", "secret")
+	require.NoError(t, err)
+
+	// With a new current key, old values still decrypt but need rotating
+	provider, err := encryption.NewLocalKeyProvider([]string{newKeyPair, oldKey})
+	require.NoError(t, err)
+	encryptor := encryption.New(provider)
+	assert.True(t, encryptor.NeedsRotation(encrypted))
+	assert.True(t, encryptor.NeedsRotation("plaintext"))
+	assert.False(t, encryptor.NeedsRotation(""))
+
+	decrypted, err := encryptor.Decrypt(ctx, "column", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	rotated, err := encryptor.Encrypt(ctx, "column", decrypted)
+	require.NoError(t, err)
+	assert.False(t, encryptor.NeedsRotation(rotated))
+
+	// Once the old key is removed, values encrypted under it no longer decrypt
+	newOnly, err := encryption.NewLocalKeyProvider([]string{newKeyPair})
+	require.NoError(t, err)
+	_, err = encryption.New(newOnly).Decrypt(ctx, "column", encrypted)
+	require.Error(t, err)
+}
+
+func TestEncryptor_Nil(t *testing.T) {
+	ctx := context.Background()
+	var encryptor *encryption.Encryptor
+
+	value, err := encryptor.Encrypt(ctx, "column", "plaintext")
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", value)
+	assert.False(t, encryptor.NeedsRotation("plaintext"))
+
+	_, err = encryptor.Decrypt(ctx, "column", "enc:v1:k1:abc:def")
+	require.ErrorIs(t, err, encryption.ErrNotConfigured)
+}
+
+func TestNewFromConfig(t *testing.T) {
+	encryptor, err := encryption.NewFromConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Nil(t, encryptor, "encryption is off without a key provider")
+
+	_, err = encryption.NewFromConfig(&config.Config{EncryptionKeyProvider: "vault"})
+	require.ErrorContains(t, err, "unknown encryption key provider")
+
+	for _, keys := range [][]string{nil, {"no-separator"}, {"k1:c2hvcnQ="}, {newKey(t, "k1"), newKey(t, "k1")}} {
+		_, err = encryption.NewFromConfig(&config.Config{EncryptionKeyProvider: "local", EncryptionKeys: keys})
+		require.Error(t, err, "keys %v", keys)
+	}
+
+	encryptor, err = encryption.NewFromConfig(&config.Config{EncryptionKeyProvider: "local", EncryptionKeys: []string{newKey(t, "k1")}})
+	require.NoError(t, err)
+	assert.NotNil(t, encryptor)
+}
@@ -0,0 +1,130 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// KeyProviderFactory creates a key provider from the configuration
+type KeyProviderFactory func(cfg *config.Config) (KeyProvider, error)
+
+var (
+	providersMu       sync.RWMutex
+	providerFactories = map[string]KeyProviderFactory{}
+)
+
+// RegisterKeyProvider makes a key provider available under name for MCP_REGISTRY_ENCRYPTION_KEY_PROVIDER.
+// Providers backed by a cloud KMS can be compiled in from a file behind a build tag that registers them
+// in init. It panics if name is already registered.
+func RegisterKeyProvider(name string, factory KeyProviderFactory) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	if _, exists := providerFactories[name]; exists {
+		panic(fmt.Sprintf("encryption key provider %q registered twice", name))
+	}
+	providerFactories[name] = factory
+}
+
+// KeyProviderNames returns the registered key provider names, sorted
+func KeyProviderNames() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	names := make([]string, 0, len(providerFactories))
+	for name := range providerFactories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// NewFromConfig creates an encryptor using the key provider named in the configuration, or returns nil
+// if no provider is configured, in which case values are stored unencrypted
+func NewFromConfig(cfg *config.Config) (*Encryptor, error) {
+	if cfg.EncryptionKeyProvider == "" {
+		return nil, nil
+	}
+
+	providersMu.RLock()
+	factory, ok := providerFactories[cfg.EncryptionKeyProvider]
+	providersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key provider %q (registered: %v)", cfg.EncryptionKeyProvider, KeyProviderNames())
+	}
+
+	provider, err := factory(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key provider configuration: %w", err)
+	}
+	return New(provider), nil
+}
+
+func init() {
+	RegisterKeyProvider("local", func(cfg *config.Config) (KeyProvider, error) {
+		return NewLocalKeyProvider(cfg.EncryptionKeys)
+	})
+}
+
+// LocalKeyProvider wraps DEKs with AES-256-GCM keys from the configuration. It suits deployments without
+// a KMS; the keys must be kept as secret as the database credentials, and separate from database backups.
+type LocalKeyProvider struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewLocalKeyProvider creates a key provider from id:key pairs, where each key is 32 bytes encoded in
+// standard base64. The first key is current; the others are only used to unwrap existing DEKs.
+func NewLocalKeyProvider(keys []string) (*LocalKeyProvider, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one key is required in MCP_REGISTRY_ENCRYPTION_KEYS")
+	}
+
+	provider := &LocalKeyProvider{keys: map[string][]byte{}}
+	for i, pair := range keys {
+		id, encoded, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found || id == "" {
+			return nil, fmt.Errorf("key %d must be in the form id:base64-key", i+1)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("key %q must be 32 bytes encoded in base64", id)
+		}
+		if _, exists := provider.keys[id]; exists {
+			return nil, fmt.Errorf("key %q is listed twice", id)
+		}
+		if i == 0 {
+			provider.current = id
+		}
+		provider.keys[id] = key
+	}
+	return provider, nil
+}
+
+// CurrentKeyID returns the ID of the first configured key
+func (p *LocalKeyProvider) CurrentKeyID() string {
+	return p.current
+}
+
+// WrapKey encrypts a DEK with the key keyID
+func (p *LocalKeyProvider) WrapKey(_ context.Context, keyID string, dek []byte) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", keyID)
+	}
+	return seal(key, dek, []byte(keyID))
+}
+
+// UnwrapKey decrypts a DEK wrapped with the key keyID
+func (p *LocalKeyProvider) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q; keep old keys in MCP_REGISTRY_ENCRYPTION_KEYS until values are rotated", keyID)
+	}
+	return open(key, wrapped, []byte(keyID))
+}
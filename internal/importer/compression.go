@@ -0,0 +1,113 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Compression is how a snapshot is compressed when it is written
+type Compression string
+
+// Supported snapshot compressions. Zstandard snapshots of the full dataset are much smaller than gzip ones and
+// decompress faster; gzip remains for mirrors running registries that only read gzip.
+const (
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+// Magic bytes starting gzip and Zstandard streams, which seed data read by the importer is checked for
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// ParseCompression parses the name of a supported compression
+func ParseCompression(name string) (Compression, error) {
+	switch compression := Compression(name); compression {
+	case CompressionNone, CompressionGzip, CompressionZstd:
+		return compression, nil
+	default:
+		return "", fmt.Errorf("unsupported compression %q: must be none, gzip or zstd", name)
+	}
+}
+
+// Extension returns the file extension of snapshots with the compression, such as ".zst"
+func (c Compression) Extension() string {
+	switch c {
+	case CompressionGzip:
+		return ".gz"
+	case CompressionZstd:
+		return ".zst"
+	default:
+		return ""
+	}
+}
+
+// WriteSnapshot writes servers to w in the seed format ImportFromPath reads, compressed with compression
+func WriteSnapshot(w io.Writer, servers []*apiv0.ServerResponse, compression Compression) error {
+	var compressor io.WriteCloser
+	switch compression {
+	case CompressionGzip:
+		compressor = gzip.NewWriter(w)
+	case CompressionZstd:
+		encoder, err := zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+		if err != nil {
+			return fmt.Errorf("failed to create zstd encoder: %w", err)
+		}
+		compressor = encoder
+	default:
+		return json.NewEncoder(w).Encode(servers)
+	}
+
+	if err := json.NewEncoder(compressor).Encode(servers); err != nil {
+		_ = compressor.Close()
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := compressor.Close(); err != nil {
+		return fmt.Errorf("failed to compress snapshot: %w", err)
+	}
+	return nil
+}
+
+// decompress returns seed data as it was before compression. Data compressed with gzip or Zstandard is
+// recognized by its magic bytes, so compressed files and downloads need no particular name; anything else is
+// returned as it is.
+func decompress(data []byte) ([]byte, error) {
+	var decompressor io.Reader
+	switch {
+	case bytes.HasPrefix(data, gzipMagic):
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress gzip seed data: %w", err)
+		}
+		defer reader.Close()
+		decompressor = reader
+	case bytes.HasPrefix(data, zstdMagic):
+		decoder, err := zstd.NewReader(bytes.NewReader(data), zstd.WithDecoderMaxMemory(maxSnapshotSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress zstd seed data: %w", err)
+		}
+		defer decoder.Close()
+		decompressor = decoder
+	default:
+		return data, nil
+	}
+
+	decompressed, err := io.ReadAll(io.LimitReader(decompressor, maxSnapshotSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress seed data: %w", err)
+	}
+	if len(decompressed) > maxSnapshotSize {
+		return nil, errors.New("seed data is larger than 1 GiB uncompressed")
+	}
+	return decompressed, nil
+}
@@ -0,0 +1,63 @@
+package importer_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestImportService_CompressedSeedData(t *testing.T) {
+	servers := []*apiv0.ServerResponse{{Server: apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	}}}
+
+	for _, compression := range []importer.Compression{importer.CompressionNone, importer.CompressionGzip, importer.CompressionZstd} {
+		t.Run(string(compression), func(t *testing.T) {
+			var seed bytes.Buffer
+			require.NoError(t, importer.WriteSnapshot(&seed, servers, compression))
+
+			// Files are recognized by their contents, not their name
+			file := filepath.Join(t.TempDir(), "seed.json")
+			require.NoError(t, os.WriteFile(file, seed.Bytes(), 0o600))
+			registry := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+			require.NoError(t, importer.NewService(registry).ImportFromPath(context.Background(), file))
+			_, err := registry.GetServerByName(context.Background(), "com.example/weather", false)
+			require.NoError(t, err)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "zstd, gzip", r.Header.Get("Accept-Encoding"))
+				if compression != importer.CompressionNone {
+					w.Header().Set("Content-Encoding", string(compression))
+				}
+				_, _ = w.Write(seed.Bytes())
+			}))
+			defer server.Close()
+			registry = service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+			require.NoError(t, importer.NewService(registry).ImportFromPath(context.Background(), server.URL+"/v0.1/servers/export"))
+			_, err = registry.GetServerByName(context.Background(), "com.example/weather", false)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("rejects unknown compressions", func(t *testing.T) {
+		_, err := importer.ParseCompression("brotli")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,341 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Seed source prefixes of third-party MCP directories. What follows the prefix is the URL of the directory's
+// API, or the path of a file holding a page of its API responses, as in smithery:./smithery.json; with nothing
+// after the prefix, the directory's public API is read.
+const (
+	SmitherySourcePrefix = "smithery:"
+	GlamaSourcePrefix    = "glama:"
+)
+
+// Public APIs of the supported directories
+const (
+	defaultSmitheryURL = "https://registry.smithery.ai/servers"
+	defaultGlamaURL    = "https://glama.ai/api/mcp/v1/servers"
+)
+
+// Namespaces servers imported from each directory are named in
+const (
+	smitheryNamespace = "ai.smithery"
+	glamaNamespace    = "ai.glama"
+)
+
+// DirectoryVersion is the version given to servers imported from directories, which do not version their
+// listings. Importing a directory again skips servers already imported.
+const DirectoryVersion = "0.0.0"
+
+// ImportProvenanceKey is the key, in a server's publisher-provided _meta, recording which directory an imported
+// server came from and its ID there, so operators can tell imported entries from servers published by their
+// maintainers
+const ImportProvenanceKey = "io.modelcontextprotocol.registry/imported-from"
+
+// maxDirectoryPages bounds how many pages are read from a directory's API, in case it never stops paginating
+const maxDirectoryPages = 1000
+
+// directory reads the servers listed by a third-party MCP directory and maps them to server.json
+type directory struct {
+	name       string
+	defaultURL string
+	// fetch reads every server from the directory's API at url
+	fetch func(s *Service, ctx context.Context, url string) ([]*apiv0.ServerJSON, error)
+	// parse maps a page of the directory's API responses, as read from a file
+	parse func(data []byte) ([]*apiv0.ServerJSON, error)
+}
+
+var directories = map[string]directory{
+	SmitherySourcePrefix: {
+		name:       "smithery",
+		defaultURL: defaultSmitheryURL,
+		fetch:      (*Service).fetchFromSmithery,
+		parse:      parseSmitheryPage,
+	},
+	GlamaSourcePrefix: {
+		name:       "glama",
+		defaultURL: defaultGlamaURL,
+		fetch:      (*Service).fetchFromGlama,
+		parse:      parseGlamaPage,
+	},
+}
+
+// directoryFor returns the directory a seed source reads and the location after its prefix
+func directoryFor(path string) (directory, string, bool) {
+	for prefix, dir := range directories {
+		if location, found := strings.CutPrefix(path, prefix); found {
+			return dir, location, true
+		}
+	}
+	return directory{}, "", false
+}
+
+// readDirectory reads the servers of a directory from its API, or from a file of its API responses
+func (s *Service) readDirectory(ctx context.Context, dir directory, location string) ([]*apiv0.ServerJSON, error) {
+	if location == "" {
+		location = dir.defaultURL
+	}
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return dir.fetch(s, ctx, location)
+	}
+
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil, err
+	}
+	return dir.parse(data)
+}
+
+// smitheryServer is a server as listed by the Smithery registry API
+type smitheryServer struct {
+	QualifiedName string `json:"qualifiedName"`
+	DisplayName   string `json:"displayName"`
+	Description   string `json:"description"`
+	Homepage      string `json:"homepage"`
+	Repository    string `json:"repository"`
+	IsDeployed    bool   `json:"isDeployed"`
+	Remote        bool   `json:"remote"`
+}
+
+// smitheryPage is a page of the Smithery registry API's server list
+type smitheryPage struct {
+	Servers    []smitheryServer `json:"servers"`
+	Pagination struct {
+		CurrentPage int `json:"currentPage"`
+		TotalPages  int `json:"totalPages"`
+	} `json:"pagination"`
+}
+
+// fetchFromSmithery reads every page of the Smithery registry API's server list. The API requires a key, set
+// with WithSmitheryAPIKey.
+func (s *Service) fetchFromSmithery(ctx context.Context, baseURL string) ([]*apiv0.ServerJSON, error) {
+	var servers []*apiv0.ServerJSON
+	for page := 1; page <= maxDirectoryPages; page++ {
+		pageURL, err := withQuery(baseURL, "page", strconv.Itoa(page))
+		if err != nil {
+			return nil, err
+		}
+		data, err := fetchDirectoryPage(ctx, pageURL, s.smitheryAPIKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch page %d from Smithery: %w", page, err)
+		}
+		var response smitheryPage
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse Smithery response: %w", err)
+		}
+		servers = append(servers, response.servers()...)
+
+		if len(response.Servers) == 0 || response.Pagination.CurrentPage >= response.Pagination.TotalPages {
+			break
+		}
+	}
+	return servers, nil
+}
+
+// parseSmitheryPage maps a Smithery server list response read from a file
+func parseSmitheryPage(data []byte) ([]*apiv0.ServerJSON, error) {
+	var response smitheryPage
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Smithery export: %w", err)
+	}
+	return response.servers(), nil
+}
+
+// servers maps the page's servers to server.json. Servers Smithery hosts get its streamable HTTP endpoint as
+// a remote.
+func (p *smitheryPage) servers() []*apiv0.ServerJSON {
+	servers := make([]*apiv0.ServerJSON, 0, len(p.Servers))
+	for _, listed := range p.Servers {
+		server := directoryServer("smithery", smitheryNamespace, directoryListing{
+			ID:          listed.QualifiedName,
+			Title:       listed.DisplayName,
+			Description: listed.Description,
+			Homepage:    listed.Homepage,
+			Repository:  listed.Repository,
+			URL:         "https://smithery.ai/server/" + listed.QualifiedName,
+		})
+		if listed.IsDeployed || listed.Remote {
+			server.Remotes = []model.Transport{{
+				Type: model.TransportTypeStreamableHTTP,
+				URL:  "https://server.smithery.ai/" + listed.QualifiedName + "/mcp",
+			}}
+		}
+		servers = append(servers, server)
+	}
+	return servers
+}
+
+// glamaServer is a server as listed by the Glama MCP API
+type glamaServer struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	Repository  *struct {
+		URL string `json:"url"`
+	} `json:"repository"`
+}
+
+// glamaPage is a page of the Glama MCP API's server list
+type glamaPage struct {
+	Servers  []glamaServer `json:"servers"`
+	PageInfo struct {
+		EndCursor   string `json:"endCursor"`
+		HasNextPage bool   `json:"hasNextPage"`
+	} `json:"pageInfo"`
+}
+
+// fetchFromGlama reads every page of the Glama MCP API's server list
+func (s *Service) fetchFromGlama(ctx context.Context, baseURL string) ([]*apiv0.ServerJSON, error) {
+	var servers []*apiv0.ServerJSON
+	pageURL := baseURL
+	for range maxDirectoryPages {
+		data, err := fetchDirectoryPage(ctx, pageURL, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch page from Glama: %w", err)
+		}
+		var response glamaPage
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse Glama response: %w", err)
+		}
+		servers = append(servers, response.servers()...)
+
+		if !response.PageInfo.HasNextPage || response.PageInfo.EndCursor == "" {
+			break
+		}
+		if pageURL, err = withQuery(baseURL, "after", response.PageInfo.EndCursor); err != nil {
+			return nil, err
+		}
+	}
+	return servers, nil
+}
+
+// parseGlamaPage maps a Glama server list response read from a file
+func parseGlamaPage(data []byte) ([]*apiv0.ServerJSON, error) {
+	var response glamaPage
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Glama export: %w", err)
+	}
+	return response.servers(), nil
+}
+
+// servers maps the page's servers to server.json
+func (p *glamaPage) servers() []*apiv0.ServerJSON {
+	servers := make([]*apiv0.ServerJSON, 0, len(p.Servers))
+	for _, listed := range p.Servers {
+		var repository string
+		if listed.Repository != nil {
+			repository = listed.Repository.URL
+		}
+		id := listed.Slug
+		if listed.Namespace != "" {
+			id = listed.Namespace + "/" + listed.Slug
+		}
+		servers = append(servers, directoryServer("glama", glamaNamespace, directoryListing{
+			ID:          id,
+			Title:       listed.Name,
+			Description: listed.Description,
+			Repository:  repository,
+			URL:         listed.URL,
+		}))
+	}
+	return servers
+}
+
+// invalidNameCharacters are the characters server names cannot hold, replaced when mapping directory IDs
+var invalidNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// directoryListing is the metadata directories share about a server
+type directoryListing struct {
+	// ID identifies the server in the directory
+	ID          string
+	Title       string
+	Description string
+	Homepage    string
+	Repository  string
+	// URL is the server's page in the directory
+	URL string
+}
+
+// directoryServer maps a directory listing to server.json. The server is named in the directory's namespace,
+// so imported servers never take the names their maintainers would publish under, and its publisher-provided
+// _meta records where it was imported from.
+func directoryServer(directoryName, namespace string, listing directoryListing) *apiv0.ServerJSON {
+	name := strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ReplaceAll(listing.ID, "/", "-"), "-"), "._-")
+	description := truncate(strings.Join(strings.Fields(listing.Description), " "), 100)
+	if description == "" {
+		description = truncate("Imported from "+directoryName+": "+listing.ID, 100)
+	}
+	provenance := map[string]any{"directory": directoryName, "id": listing.ID}
+	if listing.URL != "" {
+		provenance["url"] = listing.URL
+	}
+
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        namespace + "/" + name,
+		Title:       truncate(strings.TrimSpace(listing.Title), 100),
+		Description: description,
+		Version:     DirectoryVersion,
+		Meta:        &apiv0.ServerMeta{PublisherProvided: map[string]any{ImportProvenanceKey: provenance}},
+	}
+	if parsed, err := url.Parse(listing.Homepage); err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") {
+		server.WebsiteURL = listing.Homepage
+	}
+	if listing.Repository != "" {
+		repo := &model.Repository{URL: listing.Repository}
+		repairRepository(repo)
+		if repo.Source != "" {
+			server.Repository = repo
+		}
+	}
+	return server
+}
+
+// truncate shortens s to at most n characters, ending it with an ellipsis if it was cut
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return strings.TrimSpace(string([]rune(s)[:n-1])) + "…"
+}
+
+// withQuery returns rawURL with a query parameter set
+func withQuery(rawURL, key, value string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid directory URL %s: %w", rawURL, err)
+	}
+	query := parsed.Query()
+	query.Set(key, value)
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
+
+// fetchDirectoryPage reads a page of a directory's API, authenticating with apiKey if it is set
+func fetchDirectoryPage(ctx context.Context, pageURL, apiKey string) ([]byte, error) {
+	if apiKey == "" {
+		return fetchFromHTTP(ctx, pageURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	return doFetch(req)
+}
@@ -0,0 +1,94 @@
+package importer_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestImportService_Directories(t *testing.T) {
+	smithery := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer smithery-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"servers":[{"qualifiedName":"@acme/postgres","displayName":"Postgres","description":"Query Postgres databases","homepage":"https://smithery.ai/server/@acme/postgres","repository":"https://github.com/acme/postgres-mcp.git","isDeployed":true}],"pagination":{"currentPage":1,"totalPages":2}}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"servers":[{"qualifiedName":"weather","displayName":"Weather","description":""}],"pagination":{"currentPage":2,"totalPages":2}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer smithery.Close()
+
+	glama := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("after") == "" {
+			_, _ = w.Write([]byte(`{"servers":[{"id":"abc","name":"GitLab","namespace":"octo","slug":"gitlab-mcp","description":"Work with GitLab issues","url":"https://glama.ai/mcp/servers/abc","repository":{"url":"https://gitlab.com/octo/gitlab-mcp"}}],"pageInfo":{"endCursor":"abc","hasNextPage":true}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"servers":[],"pageInfo":{"hasNextPage":false}}`))
+	}))
+	defer glama.Close()
+
+	exportFile := filepath.Join(t.TempDir(), "smithery.json")
+	require.NoError(t, os.WriteFile(exportFile, []byte(`{"servers":[{"qualifiedName":"@acme/files","displayName":"Files","description":"Read local files"}]}`), 0o600))
+
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	importerService := importer.NewService(registryService).WithSmitheryAPIKey("smithery-key")
+	for _, source := range []string{importer.SmitherySourcePrefix + smithery.URL, importer.GlamaSourcePrefix + glama.URL, importer.SmitherySourcePrefix + exportFile} {
+		require.NoError(t, importerService.ImportFromPath(context.Background(), source), source)
+	}
+
+	servers, _, err := registryService.ListServers(context.Background(), nil, "", 10)
+	require.NoError(t, err)
+	byName := map[string]*apiv0.ServerJSON{}
+	for _, server := range servers {
+		byName[server.Server.Name] = &server.Server
+	}
+	require.Len(t, byName, 4)
+
+	postgres := byName["ai.smithery/acme-postgres"]
+	require.NotNil(t, postgres)
+	assert.Equal(t, importer.DirectoryVersion, postgres.Version)
+	assert.Equal(t, "Postgres", postgres.Title)
+	require.NotNil(t, postgres.Repository)
+	assert.Equal(t, "https://github.com/acme/postgres-mcp", postgres.Repository.URL)
+	require.Len(t, postgres.Remotes, 1)
+	assert.Equal(t, "https://server.smithery.ai/@acme/postgres/mcp", postgres.Remotes[0].URL)
+	require.NotNil(t, postgres.Meta)
+	assert.Equal(t, map[string]any{"directory": "smithery", "id": "@acme/postgres", "url": "https://smithery.ai/server/@acme/postgres"},
+		postgres.Meta.PublisherProvided[importer.ImportProvenanceKey])
+
+	weather := byName["ai.smithery/weather"]
+	require.NotNil(t, weather)
+	assert.Equal(t, "Imported from smithery: weather", weather.Description)
+	assert.Empty(t, weather.Remotes)
+
+	gitlab := byName["ai.glama/octo-gitlab-mcp"]
+	require.NotNil(t, gitlab)
+	require.NotNil(t, gitlab.Repository)
+	assert.Equal(t, "gitlab", gitlab.Repository.Source)
+
+	assert.NotNil(t, byName["ai.smithery/acme-files"])
+
+	t.Run("reports a missing API key", func(t *testing.T) {
+		err := importer.NewService(registryService).ImportFromPath(context.Background(), importer.SmitherySourcePrefix+smithery.URL)
+		assert.ErrorContains(t, err, "401")
+	})
+}
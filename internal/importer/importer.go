@@ -8,72 +8,204 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/modelcontextprotocol/registry/internal/service"
-	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 )
 
+// Policy controls what happens to seed records that fail validation
+type Policy string
+
+const (
+	// PolicyStrict aborts the import, before anything is created, if any record is invalid
+	PolicyStrict Policy = "strict"
+	// PolicyLenient skips invalid records and reports them
+	PolicyLenient Policy = "lenient"
+	// PolicyRepair applies safe fixes to invalid records, then skips and reports those still invalid
+	PolicyRepair Policy = "repair"
+)
+
+// importBatchSize is the number of seed records whose existing versions are looked up together
+const importBatchSize = 500
+
 // Service handles importing seed data into the registry
 type Service struct {
-	registry service.RegistryService
+	registry       service.RegistryService
+	policy         Policy
+	smitheryAPIKey string
 }
 
-// NewService creates a new importer service
+// NewService creates a new importer service that skips invalid seed records
 func NewService(registry service.RegistryService) *Service {
-	return &Service{registry: registry}
+	return &Service{registry: registry, policy: PolicyLenient}
+}
+
+// WithPolicy sets how invalid seed records are handled. An empty policy keeps the lenient default.
+func (s *Service) WithPolicy(policy Policy) *Service {
+	if policy != "" {
+		s.policy = policy
+	}
+	return s
+}
+
+// WithSmitheryAPIKey sets the key used to read the Smithery registry API
+func (s *Service) WithSmitheryAPIKey(key string) *Service {
+	s.smitheryAPIKey = key
+	return s
 }
 
 // ImportFromPath imports seed data from various sources:
-// 1. Local file paths (*.json files) - expects ServerJSON array format
-// 2. Direct HTTP URLs to seed.json files - expects ServerJSON array format
+// 1. Local file paths (*.json files) - expects seed format
+// 2. Direct HTTP URLs to seed.json files, and registries' /servers/export endpoints - expects seed format
 // 3. Registry root URLs (automatically appends /v0/servers and paginates)
+// 4. OCI artifacts pushed by PushSnapshot, as oci://<reference>
+// 5. Third-party MCP directories, as smithery:[url or file] or glama:[url or file], mapped to server.json
+//
+// Seed format is a JSON array whose records are either server.json documents or, as exports write them,
+// envelopes holding the server.json under "server" and the exporting registry's metadata under "_meta".
+// Files and downloads compressed with gzip or Zstandard are recognized by their magic bytes and decompressed.
+// Records are validated first and handled according to the service's policy. Versions the registry
+// already has, and repeats within the seed data, are skipped, so importing the same data again is cheap.
 func (s *Service) ImportFromPath(ctx context.Context, path string) error {
-	servers, err := readSeedFile(ctx, path)
+	records, err := s.readSeedFile(ctx, path)
 	if err != nil {
 		return fmt.Errorf("failed to read seed data: %w", err)
 	}
+	return s.importRecords(ctx, records)
+}
+
+// ImportFromData imports seed data held in memory, such as a snapshot embedded in the binary, the same way
+// ImportFromPath imports a file
+func (s *Service) ImportFromData(ctx context.Context, data []byte) error {
+	data, err := decompress(data)
+	if err != nil {
+		return fmt.Errorf("failed to read seed data: %w", err)
+	}
+	records, err := parseSeedRecords(data)
+	if err != nil {
+		return fmt.Errorf("failed to read seed data: %w", err)
+	}
+	return s.importRecords(ctx, records)
+}
+
+// importRecords validates seed records and creates the versions the registry doesn't have yet
+func (s *Service) importRecords(ctx context.Context, records []*apiv0.ServerResponse) error {
+	records, err := s.validateRecords(records)
+	if err != nil {
+		return err
+	}
 
-	// Import each server using registry service CreateServer
+	// Servers this registry's operators curated keep their curation rather than taking the imported one
+	curations, err := s.registry.ListServerCurations(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to look up curated servers: %w", err)
+	}
+	curated := make(map[string]bool, len(curations))
+	for _, curation := range curations {
+		curated[curation.ServerName] = true
+	}
+
+	// Import each server using registry service CreateServer, skipping versions the registry already has
 	var successfullyCreated []string
 	var failedCreations []string
+	seen := make(map[versionKey]bool, len(records))
+	skipped := 0
 
-	for _, server := range servers {
-		_, err := s.registry.CreateServer(ctx, server)
+	for start := 0; start < len(records); start += importBatchSize {
+		batch, err := s.newRecords(ctx, records[start:min(start+importBatchSize, len(records))], seen)
 		if err != nil {
-			failedCreations = append(failedCreations, fmt.Sprintf("%s: %v", server.Name, err))
-			log.Printf("Failed to create server %s: %v", server.Name, err)
-		} else {
-			successfullyCreated = append(successfullyCreated, server.Name)
+			return fmt.Errorf("failed to look up existing servers: %w", err)
+		}
+		skipped += min(importBatchSize, len(records)-start) - len(batch)
+
+		for _, record := range batch {
+			if err := s.createRecord(ctx, record, curated); err != nil {
+				failedCreations = append(failedCreations, fmt.Sprintf("%s: %v", record.Server.Name, err))
+				log.Printf("Failed to create server %s: %v", record.Server.Name, err)
+			} else {
+				successfullyCreated = append(successfullyCreated, record.Server.Name)
+			}
 		}
 	}
 
 	// Report import results after actual creation attempts
 	if len(failedCreations) > 0 {
-		log.Printf("Import completed with errors: %d servers created successfully, %d servers failed",
-			len(successfullyCreated), len(failedCreations))
+		log.Printf("Import completed with errors: %d servers created successfully, %d already present, %d servers failed",
+			len(successfullyCreated), skipped, len(failedCreations))
 		log.Printf("Failed servers: %v", failedCreations)
 		return fmt.Errorf("failed to import %d servers", len(failedCreations))
 	}
 
-	log.Printf("Import completed successfully: all %d servers created", len(successfullyCreated))
+	log.Printf("Import completed successfully: %d servers created, %d already present", len(successfullyCreated), skipped)
 	return nil
 }
 
+// versionKey identifies a server version among the records being imported
+type versionKey struct {
+	name    string
+	version string
+}
+
+// newRecords returns the records of batch that the registry doesn't have yet and that weren't seen earlier in
+// the import, looking up the existing versions of every server in the batch with a single query
+func (s *Service) newRecords(ctx context.Context, batch []*apiv0.ServerResponse, seen map[versionKey]bool) ([]*apiv0.ServerResponse, error) {
+	var names []string
+	for _, record := range batch {
+		if !slices.Contains(names, record.Server.Name) {
+			names = append(names, record.Server.Name)
+		}
+	}
+
+	existing, err := s.registry.ListExistingVersions(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	for name, versions := range existing {
+		for _, version := range versions {
+			seen[versionKey{name: name, version: version}] = true
+		}
+	}
+
+	var records []*apiv0.ServerResponse
+	for _, record := range batch {
+		key := versionKey{name: record.Server.Name, version: record.Server.Version}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		records = append(records, record)
+	}
+	return records, nil
+}
+
 // readSeedFile reads seed data from various sources
-func readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error) {
+func (s *Service) readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerResponse, error) {
 	var data []byte
 	var err error
 
-	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+	if dir, location, found := directoryFor(path); found {
+		records, err := s.readDirectory(ctx, dir, location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s directory: %w", dir.name, err)
+		}
+		return serverRecords(records), nil
+	} else if strings.HasPrefix(path, OCISourcePrefix) {
+		data, err = fetchFromOCI(ctx, path)
+	} else if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
 		// Handle HTTP URLs
-		if strings.HasSuffix(path, "/v0/servers") || strings.Contains(path, "/v0/servers") {
+		if strings.Contains(path, "/servers/export") {
+			// This is a registry's export endpoint, which serves the snapshot as a single file
+			data, err = fetchFromHTTP(ctx, path)
+		} else if strings.HasSuffix(path, "/v0/servers") || strings.Contains(path, "/v0/servers") {
 			// This is a registry API endpoint - fetch paginated data
 			return fetchFromRegistryAPI(ctx, path)
+		} else {
+			// This is a direct file URL
+			data, err = fetchFromHTTP(ctx, path)
 		}
-		// This is a direct file URL
-		data, err = fetchFromHTTP(ctx, path)
 	} else {
 		// Handle local file paths
 		data, err = os.ReadFile(path)
@@ -82,49 +214,74 @@ func readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read seed data from %s: %w", path, err)
 	}
-
-	// Parse ServerJSON array format
-	var serverResponses []apiv0.ServerJSON
-	if err := json.Unmarshal(data, &serverResponses); err != nil {
-		return nil, fmt.Errorf("failed to parse seed data as ServerJSON array format: %w", err)
+	if data, err = decompress(data); err != nil {
+		return nil, fmt.Errorf("failed to read seed data from %s: %w", path, err)
 	}
 
-	if len(serverResponses) == 0 {
-		return []*apiv0.ServerJSON{}, nil
-	}
+	return parseSeedRecords(data)
+}
 
-	// Validate servers and collect warnings instead of failing the whole batch
-	var validRecords []*apiv0.ServerJSON
-	var invalidServers []string
-	var validationFailures []string
+// invalidRecord is a seed record that failed validation, with the first problem found
+type invalidRecord struct {
+	record *apiv0.ServerResponse
+	err    error
+}
 
-	for _, response := range serverResponses {
+// checkRecords validates records, applying safe fixes first under the repair policy, and splits them into the
+// valid and the invalid ones
+func (s *Service) checkRecords(records []*apiv0.ServerResponse) (valid []*apiv0.ServerResponse, invalid []invalidRecord, repaired int) {
+	for _, record := range records {
 		// ValidateServerJSON returns all validation results; using FirstError() to preserve existing behavior
-		// In future, consider logging all issues from result.Issues for better diagnostics
-		result := validators.ValidateServerJSON(&response, validators.ValidationSchemaVersionAndSemantic)
-		if err := result.FirstError(); err != nil {
-			// Log warning and track invalid server instead of failing
-			invalidServers = append(invalidServers, response.Name)
-			validationFailures = append(validationFailures, fmt.Sprintf("Server '%s': %v", response.Name, err))
-			log.Printf("Warning: Skipping invalid server '%s': %v", response.Name, err)
+		err := validator.ValidateServerJSON(&record.Server, validator.ValidationSchemaVersionAndSemantic).FirstError()
+		if err != nil && s.policy == PolicyRepair {
+			candidate := *record
+			if candidate.Server.Repository != nil {
+				repository := *candidate.Server.Repository
+				candidate.Server.Repository = &repository
+			}
+			if changes := repairServer(&candidate.Server); len(changes) > 0 &&
+				validator.ValidateServerJSON(&candidate.Server, validator.ValidationSchemaVersionAndSemantic).FirstError() == nil {
+				log.Printf("Repaired server '%s' version %s: %s", candidate.Server.Name, candidate.Server.Version, strings.Join(changes, "; "))
+				record, err = &candidate, nil
+				repaired++
+			}
+		}
+		if err != nil {
+			invalid = append(invalid, invalidRecord{record: record, err: err})
 			continue
 		}
 
-		// Add valid ServerJSON to records
-		validRecords = append(validRecords, &response)
+		valid = append(valid, record)
+	}
+	return valid, invalid, repaired
+}
+
+// validateRecords returns the records to import, applying the service's policy to invalid ones
+func (s *Service) validateRecords(records []*apiv0.ServerResponse) ([]*apiv0.ServerResponse, error) {
+	validRecords, invalid, repaired := s.checkRecords(records)
+
+	var validationFailures []string
+	for _, failure := range invalid {
+		validationFailures = append(validationFailures, fmt.Sprintf("Server '%s' version %s: %v", failure.record.Server.Name, failure.record.Server.Version, failure.err))
 	}
 
 	// Print summary of validation results
-	if len(invalidServers) > 0 {
-		log.Printf("Validation summary: %d servers passed validation, %d invalid servers skipped", len(validRecords), len(invalidServers))
-		log.Printf("Invalid servers: %v", invalidServers)
+	if len(validationFailures) == 0 {
+		log.Printf("Validation summary: All %d servers passed validation (%d repaired)", len(validRecords), repaired)
+		return validRecords, nil
+	}
+
+	if s.policy == PolicyStrict {
 		for _, failure := range validationFailures {
 			log.Printf("  - %s", failure)
 		}
-	} else {
-		log.Printf("Validation summary: All %d servers passed validation", len(validRecords))
+		return nil, fmt.Errorf("strict import policy: %d of %d servers failed validation, nothing was imported", len(validationFailures), len(records))
 	}
 
+	log.Printf("Validation summary: %d servers passed validation (%d repaired), %d invalid servers skipped", len(validRecords), repaired, len(validationFailures))
+	for _, failure := range validationFailures {
+		log.Printf("  - %s", failure)
+	}
 	return validRecords, nil
 }
 
@@ -134,6 +291,13 @@ func fetchFromHTTP(ctx context.Context, url string) ([]byte, error) {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
+	return doFetch(req)
+}
+
+// doFetch sends a GET request and returns the body of a successful response. Servers may compress the
+// response with Zstandard or gzip, which is undone.
+func doFetch(req *http.Request) ([]byte, error) {
+	req.Header.Set("Accept-Encoding", "zstd, gzip")
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch from HTTP: %w", err)
@@ -144,11 +308,15 @@ func fetchFromHTTP(ctx context.Context, url string) ([]byte, error) {
 		return nil, fmt.Errorf("HTTP request failed with status: %d", resp.StatusCode)
 	}
 
-	return io.ReadAll(resp.Body)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSnapshotSize+1))
+	if err != nil {
+		return nil, err
+	}
+	return decompress(body)
 }
 
-func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*apiv0.ServerJSON, error) {
-	var allRecords []*apiv0.ServerJSON
+func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*apiv0.ServerResponse, error) {
+	var allRecords []*apiv0.ServerResponse
 	cursor := ""
 
 	for {
@@ -177,9 +345,9 @@ func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*apiv0.ServerJ
 			return nil, fmt.Errorf("failed to parse registry API response: %w", err)
 		}
 
-		// Extract ServerJSON from each ServerResponse
+		// Keep each server's registry metadata, so imports preserve its status, publisher and curation
 		for _, serverResponse := range response.Servers {
-			allRecords = append(allRecords, &serverResponse.Server)
+			allRecords = append(allRecords, registryMetadata(&serverResponse))
 		}
 
 		// Check if there's a next page
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/modelcontextprotocol/registry/data"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/importer"
@@ -45,7 +46,7 @@ func TestImportService_LocalFile(t *testing.T) {
 
 	// Create registry service
 	testDB := database.NewTestDB(t)
-	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	registryService := service.NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	// Create importer service and test import
 	importerService := importer.NewService(registryService)
@@ -63,6 +64,20 @@ func TestImportService_LocalFile(t *testing.T) {
 	assert.Equal(t, model.StatusActive, servers[0].Meta.Official.Status)
 }
 
+func TestImportService_EmbeddedSnapshot(t *testing.T) {
+	var snapshot []apiv0.ServerJSON
+	require.NoError(t, json.Unmarshal(data.Seed, &snapshot))
+	require.NotEmpty(t, snapshot)
+
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	importerService := importer.NewService(registryService).WithPolicy(importer.PolicyStrict)
+	require.NoError(t, importerService.ImportFromData(context.Background(), data.Seed), "every record of the embedded snapshot is valid")
+
+	servers, _, err := registryService.ListServers(context.Background(), nil, "", 100)
+	require.NoError(t, err)
+	assert.Len(t, servers, len(snapshot))
+}
+
 func TestImportService_HTTPFile(t *testing.T) {
 	// Create a test HTTP server
 	seedData := []*apiv0.ServerJSON{
@@ -91,7 +106,7 @@ func TestImportService_HTTPFile(t *testing.T) {
 
 	// Create registry service
 	testDB := database.NewTestDB(t)
-	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	registryService := service.NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	// Create importer service and test import
 	importerService := importer.NewService(registryService)
@@ -113,7 +128,7 @@ func TestImportService_RegistryPagination(t *testing.T) {
 
 	// Create registry service with test data
 	testDB := database.NewTestDB(t)
-	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	registryService := service.NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	// Setup source registry with test data
 	sourceServers := []*apiv0.ServerJSON{
@@ -160,7 +175,7 @@ func TestImportService_RegistryPagination(t *testing.T) {
 
 	// Create target registry for import
 	targetDB := database.NewTestDB(t)
-	targetRegistryService := service.NewRegistryService(targetDB, &config.Config{EnableRegistryValidation: false})
+	targetRegistryService := service.NewTestRegistryService(t, targetDB, &config.Config{EnableRegistryValidation: false})
 
 	// Create importer service and test registry import
 	importerService := importer.NewService(targetRegistryService)
@@ -184,7 +199,7 @@ func TestImportService_RegistryPagination(t *testing.T) {
 func TestImportService_ErrorHandling(t *testing.T) {
 	// Create registry service
 	testDB := database.NewTestDB(t)
-	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	registryService := service.NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 	importerService := importer.NewService(registryService)
 
 	tests := []struct {
@@ -244,3 +259,102 @@ func TestImportService_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestImportService_Policies(t *testing.T) {
+	seedData := []*apiv0.ServerJSON{
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.test/valid",
+			Description: "Valid server",
+			Version:     "1.0.0",
+		},
+		{
+			// Repairable: missing $schema, padded version and an unnormalized repository without a source
+			Name:        "io.github.test/repairable",
+			Description: "Repairable server",
+			Version:     " 1.0.0 ",
+			Repository:  &model.Repository{URL: "http://www.GitHub.com/test/repairable.git/"},
+		},
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "not a valid name",
+			Description: "Invalid server",
+			Version:     "1.0.0",
+		},
+	}
+	jsonData, err := json.Marshal(seedData)
+	require.NoError(t, err)
+	seedFile := filepath.Join(t.TempDir(), "seed.json")
+	require.NoError(t, os.WriteFile(seedFile, jsonData, 0600))
+
+	tests := []struct {
+		policy        importer.Policy
+		expectError   bool
+		expectedNames []string
+	}{
+		{policy: importer.PolicyStrict, expectError: true},
+		{policy: importer.PolicyLenient, expectedNames: []string{"io.github.test/valid"}},
+		{policy: importer.PolicyRepair, expectedNames: []string{"io.github.test/repairable", "io.github.test/valid"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			registryService := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+
+			err := importer.NewService(registryService).WithPolicy(tt.policy).ImportFromPath(context.Background(), seedFile)
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "nothing was imported")
+			} else {
+				require.NoError(t, err)
+			}
+
+			servers, _, err := registryService.ListServers(context.Background(), nil, "", 10)
+			require.NoError(t, err)
+			names := []string{}
+			for _, server := range servers {
+				names = append(names, server.Server.Name)
+				if server.Server.Name == "io.github.test/repairable" {
+					assert.Equal(t, model.CurrentSchemaURL, server.Server.Schema)
+					assert.Equal(t, "1.0.0", server.Server.Version)
+					assert.Equal(t, &model.Repository{URL: "https://github.com/test/repairable", Source: "github"}, server.Server.Repository)
+				}
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestImportService_SkipsExistingVersions(t *testing.T) {
+	seedData := []*apiv0.ServerJSON{
+		{Schema: model.CurrentSchemaURL, Name: "io.github.test/existing", Description: "Seeded", Version: "1.0.0"},
+		{Schema: model.CurrentSchemaURL, Name: "io.github.test/existing", Description: "Seeded", Version: "1.1.0"},
+		{Schema: model.CurrentSchemaURL, Name: "io.github.test/repeated", Description: "First", Version: "1.0.0"},
+		{Schema: model.CurrentSchemaURL, Name: "io.github.test/repeated", Description: "Second", Version: "1.0.0"},
+	}
+	jsonData, err := json.Marshal(seedData)
+	require.NoError(t, err)
+	seedFile := filepath.Join(t.TempDir(), "seed.json")
+	require.NoError(t, os.WriteFile(seedFile, jsonData, 0600))
+
+	ctx := context.Background()
+	registryService := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	_, err = registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema: model.CurrentSchemaURL, Name: "io.github.test/existing", Description: "Already there", Version: "1.0.0",
+	})
+	require.NoError(t, err)
+
+	importerService := importer.NewService(registryService)
+	require.NoError(t, importerService.ImportFromPath(ctx, seedFile))
+	// Importing the same data again is a no-op rather than a failure
+	require.NoError(t, importerService.ImportFromPath(ctx, seedFile))
+
+	existing, err := registryService.GetServerByNameAndVersion(ctx, "io.github.test/existing", "1.0.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, "Already there", existing.Server.Description)
+	_, err = registryService.GetServerByNameAndVersion(ctx, "io.github.test/existing", "1.1.0", false)
+	require.NoError(t, err)
+
+	repeated, err := registryService.GetServerByNameAndVersion(ctx, "io.github.test/repeated", "1.0.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, "First", repeated.Server.Description)
+}
@@ -0,0 +1,108 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// curatedBy is recorded as who curated servers whose curation was imported with them
+const curatedBy = "seed-import"
+
+// parseSeedRecords parses seed data: a JSON array of server.json documents, of envelopes holding a server.json
+// under "server" and registry metadata under "_meta", as exports write them, or of both
+func parseSeedRecords(data []byte) ([]*apiv0.ServerResponse, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse seed data as a JSON array: %w", err)
+	}
+
+	records := make([]*apiv0.ServerResponse, 0, len(raw))
+	for i, item := range raw {
+		var envelope struct {
+			Server json.RawMessage `json:"server"`
+		}
+		if err := json.Unmarshal(item, &envelope); err != nil {
+			return nil, fmt.Errorf("failed to parse seed record %d: %w", i, err)
+		}
+
+		record := &apiv0.ServerResponse{}
+		var err error
+		if len(envelope.Server) > 0 && !bytes.Equal(envelope.Server, []byte("null")) {
+			err = json.Unmarshal(item, record)
+		} else {
+			err = json.Unmarshal(item, &record.Server)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse seed record %d: %w", i, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// serverRecords wraps server.json documents without registry metadata as seed records
+func serverRecords(servers []*apiv0.ServerJSON) []*apiv0.ServerResponse {
+	records := make([]*apiv0.ServerResponse, 0, len(servers))
+	for _, server := range servers {
+		records = append(records, &apiv0.ServerResponse{Server: *server})
+	}
+	return records
+}
+
+// registryMetadata returns a server version as a seed record, keeping the registry metadata imports preserve,
+// its status, its publisher and whether they were verified, and its curation, along with when it was
+// published. Latest flags, runtimes and health results are left out: importing registries derive their own.
+func registryMetadata(response *apiv0.ServerResponse) *apiv0.ServerResponse {
+	record := &apiv0.ServerResponse{Server: response.Server}
+	if official := response.Meta.Official; official != nil {
+		record.Meta.Official = &apiv0.RegistryExtensions{
+			Status:            official.Status,
+			StatusChangedAt:   official.StatusChangedAt,
+			StatusMessage:     official.StatusMessage,
+			PublishedAt:       official.PublishedAt,
+			UpdatedAt:         official.UpdatedAt,
+			Publisher:         official.Publisher,
+			VerifiedPublisher: official.VerifiedPublisher,
+			Curation:          official.Curation,
+			DocumentDigest:    official.DocumentDigest,
+		}
+	}
+	return record
+}
+
+// createRecord creates a seed record's server version, preserving its registry metadata: the version is
+// recorded as published by the same identity, and given the same status. A server's curation is imported
+// along with it, unless this registry's operators already curated the server.
+func (s *Service) createRecord(ctx context.Context, record *apiv0.ServerResponse, curated map[string]bool) error {
+	official := record.Meta.Official
+	if official != nil && official.Publisher != nil {
+		ctx = service.WithPublisher(ctx, official.Publisher)
+	}
+	if _, err := s.registry.CreateServer(ctx, &record.Server); err != nil {
+		return err
+	}
+	if official == nil {
+		return nil
+	}
+
+	name, version := record.Server.Name, record.Server.Version
+	if official.Status != "" && official.Status != model.StatusActive {
+		status := &service.StatusChangeRequest{NewStatus: official.Status, StatusMessage: official.StatusMessage}
+		if _, err := s.registry.UpdateServerStatus(ctx, name, version, status); err != nil {
+			return fmt.Errorf("failed to set status %s: %w", official.Status, err)
+		}
+	}
+	if official.Curation != nil && len(official.Curation.Labels) > 0 && !curated[name] {
+		if _, err := s.registry.PutServerCuration(ctx, name, official.Curation.Labels, official.Curation.Position, "", curatedBy); err != nil {
+			return fmt.Errorf("failed to import curation: %w", err)
+		}
+		curated[name] = true
+	}
+	return nil
+}
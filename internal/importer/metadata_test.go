@@ -0,0 +1,80 @@
+package importer_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestImportService_PreservesRegistryMetadata(t *testing.T) {
+	ctx := context.Background()
+	source := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	publisher := &apiv0.Publisher{AuthMethod: "github-oidc", Subject: "repo:acme/weather:ref:refs/tags/v1.0.0", GitHubOrg: "acme"}
+	for _, name := range []string{"io.github.acme/weather", "io.github.acme/tides"} {
+		_, err := source.CreateServer(service.WithPublisher(ctx, publisher), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Forecasts",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+	message := "Use io.github.acme/forecast instead"
+	_, err := source.UpdateServerStatus(ctx, "io.github.acme/weather", "1.0.0", &service.StatusChangeRequest{NewStatus: model.StatusDeprecated, StatusMessage: &message})
+	require.NoError(t, err)
+	position := 2
+	for _, name := range []string{"io.github.acme/weather", "io.github.acme/tides"} {
+		_, err = source.PutServerCuration(ctx, name, []string{"featured", "official"}, &position, "Vendor verified", "admin")
+		require.NoError(t, err)
+	}
+
+	servers, err := importer.Snapshot(ctx, source)
+	require.NoError(t, err)
+	var seed bytes.Buffer
+	require.NoError(t, importer.WriteSnapshot(&seed, servers, importer.CompressionNone))
+	file := filepath.Join(t.TempDir(), "seed.json")
+	require.NoError(t, os.WriteFile(file, seed.Bytes(), 0o600))
+
+	mirror := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	// Operators of the mirror curated tides themselves, so its curation is kept
+	_, err = mirror.CreateServer(ctx, &apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: "io.github.acme/tides", Description: "Forecasts", Version: "0.9.0"})
+	require.NoError(t, err)
+	_, err = mirror.PutServerCuration(ctx, "io.github.acme/tides", []string{"community"}, nil, "", "mirror-admin")
+	require.NoError(t, err)
+	require.NoError(t, importer.NewService(mirror).ImportFromPath(ctx, file))
+
+	weather, err := mirror.GetServerByNameAndVersion(ctx, "io.github.acme/weather", "1.0.0", false)
+	require.NoError(t, err)
+	official := weather.Meta.Official
+	require.NotNil(t, official)
+	assert.Equal(t, model.StatusDeprecated, official.Status)
+	require.NotNil(t, official.StatusMessage)
+	assert.Equal(t, message, *official.StatusMessage)
+	assert.Equal(t, publisher, official.Publisher)
+	assert.True(t, official.VerifiedPublisher)
+	require.NotNil(t, official.Curation)
+	assert.Equal(t, []string{"featured", "official"}, official.Curation.Labels)
+	assert.Equal(t, &position, official.Curation.Position)
+	_, digest, err := apiv0.ServerDocument(&weather.Server)
+	require.NoError(t, err)
+	assert.Equal(t, digest, official.DocumentDigest)
+
+	tides, err := mirror.GetServerByNameAndVersion(ctx, "io.github.acme/tides", "1.0.0", false)
+	require.NoError(t, err)
+	require.NotNil(t, tides.Meta.Official.Curation)
+	assert.Equal(t, []string{"community"}, tides.Meta.Official.Curation.Labels)
+	assert.Equal(t, model.StatusActive, tides.Meta.Official.Status)
+	assert.True(t, tides.Meta.Official.VerifiedPublisher)
+}
@@ -0,0 +1,213 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// OCISourcePrefix marks seed sources read from an OCI artifact, as in oci://ghcr.io/example/registry-mirror:latest
+const OCISourcePrefix = "oci://"
+
+// Media types of registry snapshots stored as OCI artifacts. The artifact has an empty config and a single
+// layer holding the snapshot in seed format, compressed with gzip or Zstandard.
+const (
+	SnapshotArtifactType  types.MediaType = "application/vnd.modelcontextprotocol.registry.snapshot.v1"
+	SnapshotLayerType     types.MediaType = "application/vnd.modelcontextprotocol.registry.seed.v1+json+gzip"
+	SnapshotZstdLayerType types.MediaType = "application/vnd.modelcontextprotocol.registry.seed.v1+json+zstd"
+	emptyConfigType       types.MediaType = "application/vnd.oci.empty.v1+json"
+)
+
+// snapshotLayerTypes are the layer media types of each compression snapshots can be pushed with
+var snapshotLayerTypes = map[Compression]types.MediaType{
+	CompressionGzip: SnapshotLayerType,
+	CompressionZstd: SnapshotZstdLayerType,
+}
+
+// maxSnapshotSize bounds the uncompressed size of seed data read from a file, URL or OCI artifact
+const maxSnapshotSize = 1 << 30
+
+// snapshotPageSize is how many server versions are read at a time when taking a snapshot
+const snapshotPageSize = 100
+
+// Snapshot returns every server version that is not deleted, with its registry metadata, in the seed format
+// ImportFromPath reads
+func Snapshot(ctx context.Context, registry service.RegistryService) ([]*apiv0.ServerResponse, error) {
+	var servers []*apiv0.ServerResponse
+	cursor := ""
+	for {
+		page, nextCursor, err := registry.ListServers(ctx, &database.ServerFilter{}, cursor, snapshotPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+		for _, server := range page {
+			servers = append(servers, registryMetadata(server))
+		}
+		if nextCursor == "" {
+			return servers, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// snapshotManifest is an OCI image manifest with the artifactType field go-containerregistry's lacks
+type snapshotManifest struct {
+	v1.Manifest
+	ArtifactType types.MediaType `json:"artifactType,omitempty"`
+}
+
+// artifactManifest is an encoded OCI image manifest pushed as it is
+type artifactManifest []byte
+
+func (m artifactManifest) RawManifest() ([]byte, error) {
+	return m, nil
+}
+
+func (m artifactManifest) MediaType() (types.MediaType, error) {
+	return types.OCIManifestSchema1, nil
+}
+
+// PushSnapshot pushes servers as an OCI artifact to reference, such as harbor.example.com/mcp/registry:latest,
+// compressed with gzip or Zstandard, and returns the manifest digest. Credentials come from the Docker
+// configuration and credential helpers, as for docker push.
+func PushSnapshot(ctx context.Context, reference string, servers []*apiv0.ServerResponse, compression Compression, options ...remote.Option) (string, error) {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return "", fmt.Errorf("invalid OCI reference: %w", err)
+	}
+	layerType, ok := snapshotLayerTypes[compression]
+	if !ok {
+		return "", fmt.Errorf("OCI snapshots must be compressed with gzip or zstd, not %s", compression)
+	}
+
+	var seed bytes.Buffer
+	if err := WriteSnapshot(&seed, servers, compression); err != nil {
+		return "", err
+	}
+
+	config := static.NewLayer([]byte("{}"), emptyConfigType)
+	layer := static.NewLayer(seed.Bytes(), layerType)
+	configDesc, err := descriptorOf(config)
+	if err != nil {
+		return "", err
+	}
+	layerDesc, err := descriptorOf(layer)
+	if err != nil {
+		return "", err
+	}
+	layerDesc.Annotations = map[string]string{"org.opencontainers.image.title": "seed.json" + compression.Extension()}
+
+	manifest, err := json.Marshal(snapshotManifest{
+		Manifest: v1.Manifest{
+			SchemaVersion: 2,
+			MediaType:     types.OCIManifestSchema1,
+			Config:        configDesc,
+			Layers:        []v1.Descriptor{layerDesc},
+			Annotations: map[string]string{
+				"org.opencontainers.image.created":         time.Now().UTC().Format(time.RFC3339),
+				"io.modelcontextprotocol.registry.servers": strconv.Itoa(len(servers)),
+			},
+		},
+		ArtifactType: SnapshotArtifactType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	options = append([]remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain)}, options...)
+	for _, blob := range []v1.Layer{config, layer} {
+		if err := remote.WriteLayer(ref.Context(), blob, options...); err != nil {
+			return "", fmt.Errorf("failed to push snapshot: %w", err)
+		}
+	}
+	if err := remote.Put(ref, artifactManifest(manifest), options...); err != nil {
+		return "", fmt.Errorf("failed to push snapshot manifest: %w", err)
+	}
+
+	digest, _, err := v1.SHA256(bytes.NewReader(manifest))
+	if err != nil {
+		return "", err
+	}
+	return digest.String(), nil
+}
+
+// descriptorOf describes a blob for a manifest
+func descriptorOf(layer v1.Layer) (v1.Descriptor, error) {
+	digest, err := layer.Digest()
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	size, err := layer.Size()
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	mediaType, err := layer.MediaType()
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	return v1.Descriptor{MediaType: mediaType, Digest: digest, Size: size}, nil
+}
+
+// fetchFromOCI reads a snapshot pushed by PushSnapshot, returning it in seed format
+func fetchFromOCI(ctx context.Context, reference string, options ...remote.Option) ([]byte, error) {
+	ref, err := name.ParseReference(strings.TrimPrefix(reference, OCISourcePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI reference: %w", err)
+	}
+	options = append([]remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain)}, options...)
+
+	desc, err := remote.Get(ref, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OCI artifact: %w", err)
+	}
+	var manifest v1.Manifest
+	if err := json.Unmarshal(desc.Manifest, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse OCI manifest: %w", err)
+	}
+	var layerDesc *v1.Descriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].MediaType == SnapshotLayerType || manifest.Layers[i].MediaType == SnapshotZstdLayerType {
+			layerDesc = &manifest.Layers[i]
+			break
+		}
+	}
+	if layerDesc == nil {
+		return nil, fmt.Errorf("OCI artifact %s is not a registry snapshot: it has no %s or %s layer", reference, SnapshotLayerType, SnapshotZstdLayerType)
+	}
+
+	layer, err := remote.Layer(ref.Context().Digest(layerDesc.Digest.String()), options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch snapshot layer: %w", err)
+	}
+	// Reading the layer verifies it against its digest
+	compressed, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch snapshot layer: %w", err)
+	}
+	defer compressed.Close()
+	data, err := io.ReadAll(io.LimitReader(compressed, maxSnapshotSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch snapshot layer: %w", err)
+	}
+	if len(data) > maxSnapshotSize {
+		return nil, errors.New("snapshot is larger than 1 GiB")
+	}
+	return data, nil
+}
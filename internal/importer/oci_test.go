@@ -0,0 +1,77 @@
+package importer_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestOCISnapshot(t *testing.T) {
+	// Keep credentials from the developer's Docker configuration out of the test
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	ctx := context.Background()
+	ociRegistry := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer ociRegistry.Close()
+	reference := strings.TrimPrefix(ociRegistry.URL, "http://") + "/mcp/registry-mirror:latest"
+
+	source := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := source.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather",
+			Description: "Weather server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	servers, err := importer.Snapshot(ctx, source)
+	require.NoError(t, err)
+	require.Len(t, servers, 2)
+	digest, err := importer.PushSnapshot(ctx, reference, servers, importer.CompressionGzip)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(digest, "sha256:"))
+
+	mirror := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	require.NoError(t, importer.NewService(mirror).ImportFromPath(ctx, importer.OCISourcePrefix+reference))
+	latest, err := mirror.GetServerByName(ctx, "com.example/weather", false)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", latest.Server.Version)
+	versions, err := mirror.GetAllVersionsByServerName(ctx, "com.example/weather", false)
+	require.NoError(t, err)
+	assert.Len(t, versions, 2)
+
+	// Pinning the digest reads the same snapshot
+	require.NoError(t, importer.NewService(service.NewTestRegistryService(t, database.NewMemory(), &config.Config{})).
+		ImportFromPath(ctx, importer.OCISourcePrefix+strings.TrimSuffix(reference, ":latest")+"@"+digest))
+
+	err = importer.NewService(mirror).ImportFromPath(ctx, importer.OCISourcePrefix+strings.TrimSuffix(reference, ":latest")+":missing")
+	require.Error(t, err)
+
+	// Zstandard snapshots are read the same way
+	zstdReference := strings.TrimSuffix(reference, ":latest") + ":zstd"
+	_, err = importer.PushSnapshot(ctx, zstdReference, servers, importer.CompressionZstd)
+	require.NoError(t, err)
+	zstdMirror := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	require.NoError(t, importer.NewService(zstdMirror).ImportFromPath(ctx, importer.OCISourcePrefix+zstdReference))
+	versions, err = zstdMirror.GetAllVersionsByServerName(ctx, "com.example/weather", false)
+	require.NoError(t, err)
+	assert.Len(t, versions, 2)
+
+	_, err = importer.PushSnapshot(ctx, zstdReference, servers, importer.CompressionNone)
+	assert.Error(t, err)
+}
@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/diff"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// What importing a seed record would do with it
+const (
+	// ActionCreate records are versions the registry does not have yet, which would be created
+	ActionCreate = "create"
+	// ActionChanged records are versions the registry already has with different content. Imports never
+	// overwrite existing versions, so they would be skipped; their diff shows how the registry differs.
+	ActionChanged = "changed"
+	// ActionUnchanged records are versions the registry already has as they are, or repeats within the seed data
+	ActionUnchanged = "unchanged"
+	// ActionInvalid records fail validation and would be skipped, or abort the import under the strict policy
+	ActionInvalid = "invalid"
+)
+
+// PlanEntry is what importing one seed record would do
+type PlanEntry struct {
+	Action  string
+	Name    string
+	Version string
+	// NewServer is set on created versions of servers the registry has no version of
+	NewServer bool
+	// Diff compares the registry's version to the seed record's, for changed records
+	Diff *diff.Diff
+	// Error is why an invalid record failed validation
+	Error error
+}
+
+// Plan is what importing seed data would do, worked out without changing the registry
+type Plan struct {
+	Entries []PlanEntry
+	// Aborted is set when the strict policy would abort the import because records are invalid, so nothing
+	// would be created
+	Aborted bool
+}
+
+// Count returns how many records the plan handles with action
+func (p *Plan) Count(action string) int {
+	count := 0
+	for _, entry := range p.Entries {
+		if entry.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// PlanImport reads and validates seed data as ImportFromPath would and compares it with the registry, to review
+// what an import would create and how the registry differs from the seed data before running it. Nothing is
+// written. Invalid records are listed first, then the others in the order of the seed data.
+func (s *Service) PlanImport(ctx context.Context, path string) (*Plan, error) {
+	records, err := s.readSeedFile(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed data: %w", err)
+	}
+
+	valid, invalid, _ := s.checkRecords(records)
+	plan := &Plan{Aborted: s.policy == PolicyStrict && len(invalid) > 0}
+	for _, failure := range invalid {
+		plan.Entries = append(plan.Entries, PlanEntry{
+			Action:  ActionInvalid,
+			Name:    failure.record.Server.Name,
+			Version: failure.record.Server.Version,
+			Error:   failure.err,
+		})
+	}
+
+	planner := &planner{service: s, seen: map[versionKey]bool{}, seenServers: map[string]bool{}}
+	for start := 0; start < len(valid); start += importBatchSize {
+		entries, err := planner.planBatch(ctx, valid[start:min(start+importBatchSize, len(valid))])
+		if err != nil {
+			return nil, err
+		}
+		plan.Entries = append(plan.Entries, entries...)
+	}
+	return plan, nil
+}
+
+// planner works out what importing valid records would do, remembering the records already planned so
+// repeats are recognized and earlier records count as creating their server
+type planner struct {
+	service     *Service
+	seen        map[versionKey]bool
+	seenServers map[string]bool
+}
+
+// planBatch plans a batch of records, looking up the existing versions of every server in it with a single query
+func (p *planner) planBatch(ctx context.Context, batch []*apiv0.ServerResponse) ([]PlanEntry, error) {
+	var names []string
+	for _, record := range batch {
+		names = append(names, record.Server.Name)
+	}
+	existing, err := p.service.registry.ListExistingVersions(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up existing servers: %w", err)
+	}
+
+	entries := make([]PlanEntry, 0, len(batch))
+	for _, record := range batch {
+		name, version := record.Server.Name, record.Server.Version
+		entry := PlanEntry{Action: ActionUnchanged, Name: name, Version: version}
+		key := versionKey{name: name, version: version}
+
+		switch {
+		case p.seen[key]:
+		case !slices.Contains(existing[name], version):
+			entry.Action = ActionCreate
+			entry.NewServer = len(existing[name]) == 0 && !p.seenServers[name]
+		default:
+			current, err := p.service.registry.GetServerByNameAndVersion(ctx, name, version, true)
+			if err != nil && !errors.Is(err, database.ErrNotFound) {
+				return nil, fmt.Errorf("failed to look up %s version %s: %w", name, version, err)
+			}
+			if current != nil {
+				entry.Diff = compareRecord(current, record)
+				if !entry.Diff.Identical {
+					entry.Action = ActionChanged
+				}
+			}
+		}
+
+		p.seen[key] = true
+		p.seenServers[name] = true
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// compareRecord compares a version the registry has with a seed record of it: their server.json documents,
+// and their status when the seed record carries registry metadata
+func compareRecord(current, record *apiv0.ServerResponse) *diff.Diff {
+	d := diff.Compare(&current.Server, &record.Server)
+	if official := record.Meta.Official; official != nil && official.Status != "" && current.Meta.Official != nil &&
+		official.Status != current.Meta.Official.Status {
+		d.Fields = append(d.Fields, diff.FieldChange{Field: "status", From: current.Meta.Official.Status, To: official.Status})
+		d.Identical = false
+	}
+	return d
+}
@@ -0,0 +1,92 @@
+package importer_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/diff"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestImportService_PlanImport(t *testing.T) {
+	ctx := context.Background()
+	registry := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	server := func(name, version, description string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: name, Description: description, Version: version}
+	}
+	for _, existing := range []apiv0.ServerJSON{
+		server("io.github.acme/weather", "1.0.0", "Forecasts"),
+		server("io.github.acme/tides", "1.0.0", "Tide tables"),
+	} {
+		_, err := registry.CreateServer(ctx, &existing)
+		require.NoError(t, err)
+	}
+
+	seed := []apiv0.ServerJSON{
+		server("io.github.acme/weather", "1.0.0", "Forecasts"),
+		server("io.github.acme/weather", "1.1.0", "Forecasts"),
+		server("io.github.acme/tides", "1.0.0", "Tide tables and currents"),
+		server("io.github.acme/radar", "0.1.0", "Radar images"),
+		server("io.github.acme/radar", "0.2.0", "Radar images"),
+		server("io.github.acme/radar", "0.2.0", "Radar images"),
+		server("invalid name", "1.0.0", "Broken"),
+	}
+	data, err := json.Marshal(seed)
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "seed.json")
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+
+	plan, err := importer.NewService(registry).PlanImport(ctx, file)
+	require.NoError(t, err)
+	require.Len(t, plan.Entries, 7)
+	assert.False(t, plan.Aborted)
+
+	invalid := plan.Entries[0]
+	assert.Equal(t, importer.ActionInvalid, invalid.Action)
+	assert.Equal(t, "invalid name", invalid.Name)
+	assert.Error(t, invalid.Error)
+
+	type planned struct {
+		action    string
+		name      string
+		version   string
+		newServer bool
+	}
+	var actions []planned
+	for _, entry := range plan.Entries[1:] {
+		actions = append(actions, planned{entry.Action, entry.Name, entry.Version, entry.NewServer})
+	}
+	assert.Equal(t, []planned{
+		{importer.ActionUnchanged, "io.github.acme/weather", "1.0.0", false},
+		{importer.ActionCreate, "io.github.acme/weather", "1.1.0", false},
+		{importer.ActionChanged, "io.github.acme/tides", "1.0.0", false},
+		{importer.ActionCreate, "io.github.acme/radar", "0.1.0", true},
+		{importer.ActionCreate, "io.github.acme/radar", "0.2.0", false},
+		{importer.ActionUnchanged, "io.github.acme/radar", "0.2.0", false},
+	}, actions)
+
+	changed := plan.Entries[3].Diff
+	require.NotNil(t, changed)
+	assert.Equal(t, []diff.FieldChange{{Field: "description", From: "Tide tables", To: "Tide tables and currents"}}, changed.Fields)
+	assert.Equal(t, 3, plan.Count(importer.ActionCreate))
+
+	// Planning writes nothing
+	existing, err := registry.ListExistingVersions(ctx, []string{"io.github.acme/weather", "io.github.acme/radar"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"io.github.acme/weather": {"1.0.0"}}, existing)
+
+	plan, err = importer.NewService(registry).WithPolicy(importer.PolicyStrict).PlanImport(ctx, file)
+	require.NoError(t, err)
+	assert.True(t, plan.Aborted)
+}
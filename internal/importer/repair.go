@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"net/url"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// repositoryHosts maps repository hosts to the source name the validators expect
+var repositoryHosts = map[string]validator.RepositorySource{
+	"github.com": validator.SourceGitHub,
+	"gitlab.com": validator.SourceGitLab,
+}
+
+// repairServer applies fixes that cannot change what a record describes: filling a missing $schema,
+// trimming stray whitespace, and normalizing repository URLs and sources. It returns a description of
+// each change made, or nil if the record was left alone.
+func repairServer(server *apiv0.ServerJSON) []string {
+	var changes []string
+
+	if strings.TrimSpace(server.Schema) == "" {
+		server.Schema = model.CurrentSchemaURL
+		changes = append(changes, "set missing $schema to "+model.CurrentSchemaURL)
+	}
+
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"$schema", &server.Schema},
+		{"name", &server.Name},
+		{"version", &server.Version},
+		{"description", &server.Description},
+		{"title", &server.Title},
+		{"websiteUrl", &server.WebsiteURL},
+		{"documentationUrl", &server.DocumentationURL},
+		{"supportUrl", &server.SupportURL},
+	} {
+		if trimmed := strings.TrimSpace(*field.value); trimmed != *field.value {
+			*field.value = trimmed
+			changes = append(changes, "trimmed whitespace from "+field.name)
+		}
+	}
+
+	if server.Repository != nil {
+		changes = append(changes, repairRepository(server.Repository)...)
+	}
+	return changes
+}
+
+// repairRepository normalizes a GitHub or GitLab repository URL to https://host/owner/repo and fills in
+// or lowercases its source. Repositories on other hosts are only trimmed.
+func repairRepository(repo *model.Repository) []string {
+	var changes []string
+
+	if trimmed := strings.TrimSpace(repo.URL); trimmed != repo.URL {
+		repo.URL = trimmed
+		changes = append(changes, "trimmed whitespace from repository.url")
+	}
+
+	parsed, err := url.Parse(repo.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return changes
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
+	source, known := repositoryHosts[host]
+	if !known {
+		return changes
+	}
+
+	path := strings.TrimSuffix(strings.TrimSuffix(parsed.Path, "/"), ".git")
+	normalized := "https://" + host + path
+	if normalized != repo.URL && parsed.RawQuery == "" && parsed.Fragment == "" {
+		changes = append(changes, "normalized repository.url from "+repo.URL+" to "+normalized)
+		repo.URL = normalized
+	}
+
+	if repo.Source == "" || (repo.Source != string(source) && strings.EqualFold(repo.Source, string(source))) {
+		repo.Source = string(source)
+		changes = append(changes, "set repository.source to "+string(source))
+	}
+	return changes
+}
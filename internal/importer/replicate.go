@@ -0,0 +1,176 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// changesPageSize is the number of changes requested per page from the remote feed
+const changesPageSize = 100
+
+// CheckpointStore persists how far replication from each remote registry has progressed.
+// database.Database satisfies this interface.
+type CheckpointStore interface {
+	GetImportCheckpoint(ctx context.Context, tx pgx.Tx, source string) (int64, error)
+	SetImportCheckpoint(ctx context.Context, tx pgx.Tx, source string, seq int64) error
+}
+
+// RunReplication polls the changes feed of a remote registry every interval until ctx is cancelled
+func (s *Service) RunReplication(ctx context.Context, baseURL string, store CheckpointStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		applied, err := s.ReplicateFromChangesFeed(ctx, baseURL, store)
+		if err != nil {
+			log.Printf("Replication from %s failed: %v", baseURL, err)
+		} else if applied > 0 {
+			log.Printf("Replicated %d changes from %s", applied, baseURL)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReplicateFromChangesFeed applies every change from a remote registry's /v0/servers/changes feed
+// recorded after the stored checkpoint, advancing the checkpoint after each page.
+// A change that fails to apply stops replication with the checkpoint just before it, so the next run
+// retries it rather than dropping it from the replica. It returns the number of changes applied.
+func (s *Service) ReplicateFromChangesFeed(ctx context.Context, baseURL string, store CheckpointStore) (int, error) {
+	source := strings.TrimSuffix(baseURL, "/")
+
+	since, err := store.GetImportCheckpoint(ctx, nil, source)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return 0, fmt.Errorf("failed to load import checkpoint: %w", err)
+	}
+
+	applied := 0
+	for {
+		page, err := fetchChangesPage(ctx, source, since)
+		if err != nil {
+			return applied, err
+		}
+
+		lastApplied := since
+		for _, change := range page.Changes {
+			apply := applyServerChange
+			if change.Type == "deleted" {
+				apply = applyServerDeletion
+			}
+			if err := apply(ctx, s.registry, change.Server); err != nil {
+				err = fmt.Errorf("failed to apply change %d for server '%s' version '%s': %w",
+					change.Seq, change.Server.Server.Name, change.Server.Server.Version, err)
+				if lastApplied > since {
+					if saveErr := store.SetImportCheckpoint(ctx, nil, source, lastApplied); saveErr != nil {
+						return applied, errors.Join(err, fmt.Errorf("failed to save import checkpoint: %w", saveErr))
+					}
+				}
+				return applied, err
+			}
+			lastApplied = change.Seq
+			applied++
+		}
+
+		if page.Metadata.NextSince > since {
+			since = page.Metadata.NextSince
+			if err := store.SetImportCheckpoint(ctx, nil, source, since); err != nil {
+				return applied, fmt.Errorf("failed to save import checkpoint: %w", err)
+			}
+		}
+
+		if page.Metadata.Count < changesPageSize {
+			return applied, nil
+		}
+	}
+}
+
+func fetchChangesPage(ctx context.Context, source string, since int64) (*apiv0.ServerChangesResponse, error) {
+	url := fmt.Sprintf("%s/v0/servers/changes?since=%d&limit=%d", source, since, changesPageSize)
+
+	data, err := fetchFromHTTP(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch changes feed: %w", err)
+	}
+
+	var page apiv0.ServerChangesResponse
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse changes feed response: %w", err)
+	}
+
+	return &page, nil
+}
+
+// applyServerChange makes the local copy of a server version match the remote one,
+// creating it if it does not exist yet
+func applyServerChange(ctx context.Context, registry service.RegistryService, remote apiv0.ServerResponse) error {
+	remoteStatus := &service.StatusChangeRequest{NewStatus: model.StatusActive}
+	if remote.Meta.Official != nil {
+		remoteStatus.NewStatus = remote.Meta.Official.Status
+		remoteStatus.StatusMessage = remote.Meta.Official.StatusMessage
+		// Keep the identity the remote registry verified, so replicas show the same publisher
+		ctx = service.WithPublisher(ctx, remote.Meta.Official.Publisher)
+	}
+
+	name, version := remote.Server.Name, remote.Server.Version
+	existing, err := registry.GetServerByNameAndVersion(ctx, name, version, true)
+	if errors.Is(err, database.ErrNotFound) {
+		if _, err := registry.CreateServer(ctx, &remote.Server); err != nil {
+			return err
+		}
+		if remoteStatus.NewStatus != model.StatusActive {
+			_, err = registry.UpdateServerStatus(ctx, name, version, remoteStatus)
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(existing.Server, remote.Server) && statusMatches(existing, remoteStatus) {
+		return nil
+	}
+
+	_, err = registry.UpdateServer(ctx, name, version, &remote.Server, remoteStatus)
+	return err
+}
+
+// applyServerDeletion removes the local copy of a server version the remote registry deleted, if there is one
+func applyServerDeletion(ctx context.Context, registry service.RegistryService, remote apiv0.ServerResponse) error {
+	err := registry.DeleteServerVersion(ctx, remote.Server.Name, remote.Server.Version)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func statusMatches(existing *apiv0.ServerResponse, want *service.StatusChangeRequest) bool {
+	if existing.Meta.Official == nil {
+		return want.NewStatus == model.StatusActive && want.StatusMessage == nil
+	}
+	current := existing.Meta.Official
+	if current.Status != want.NewStatus {
+		return false
+	}
+	if current.StatusMessage == nil || want.StatusMessage == nil {
+		return current.StatusMessage == want.StatusMessage
+	}
+	return *current.StatusMessage == *want.StatusMessage
+}
@@ -0,0 +1,156 @@
+package importer_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestImportService_ReplicateFromChangesFeed(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{EnableRegistryValidation: false}
+
+	// Setup source registry with a couple of servers, one of them deprecated
+	sourceRegistry := service.NewTestRegistryService(t, database.NewTestDB(t), cfg)
+	for _, name := range []string{"com.source/server-1", "com.source/server-2"} {
+		_, err := sourceRegistry.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Source server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+	deprecationMessage := "Use server-1 instead"
+	_, err := sourceRegistry.UpdateServerStatus(ctx, "com.source/server-2", "1.0.0", &service.StatusChangeRequest{
+		NewStatus:     model.StatusDeprecated,
+		StatusMessage: &deprecationMessage,
+	})
+	require.NoError(t, err)
+
+	// Serve the source registry's changes feed
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/servers/changes", r.URL.Path)
+		since, _ := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+
+		changes, err := sourceRegistry.ListServerChanges(ctx, since, 100)
+		require.NoError(t, err)
+
+		response := apiv0.ServerChangesResponse{Changes: []apiv0.ServerChange{}, Metadata: apiv0.ChangesMetadata{NextSince: since}}
+		for _, change := range changes {
+			response.Changes = append(response.Changes, *change)
+			response.Metadata.NextSince = change.Seq
+		}
+		response.Metadata.Count = len(changes)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer httpServer.Close()
+
+	// Replicate into an empty target registry
+	targetDB := database.NewTestDB(t)
+	targetRegistry := service.NewTestRegistryService(t, targetDB, cfg)
+	importerService := importer.NewService(targetRegistry)
+
+	applied, err := importerService.ReplicateFromChangesFeed(ctx, httpServer.URL, targetDB)
+	require.NoError(t, err)
+	assert.Positive(t, applied)
+
+	replicated, err := targetRegistry.GetServerByNameAndVersion(ctx, "com.source/server-2", "1.0.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDeprecated, replicated.Meta.Official.Status)
+	require.NotNil(t, replicated.Meta.Official.StatusMessage)
+	assert.Equal(t, deprecationMessage, *replicated.Meta.Official.StatusMessage)
+
+	_, err = targetRegistry.GetServerByNameAndVersion(ctx, "com.source/server-1", "1.0.0", false)
+	require.NoError(t, err)
+
+	// The checkpoint is persisted, so a second run has nothing new to apply
+	checkpoint, err := targetDB.GetImportCheckpoint(ctx, nil, httpServer.URL)
+	require.NoError(t, err)
+	assert.Positive(t, checkpoint)
+
+	applied, err = importerService.ReplicateFromChangesFeed(ctx, httpServer.URL, targetDB)
+	require.NoError(t, err)
+	assert.Equal(t, 0, applied)
+
+	// Versions deleted at the source are deleted from the replica
+	require.NoError(t, sourceRegistry.DeleteServerVersion(ctx, "com.source/server-1", "1.0.0"))
+	applied, err = importerService.ReplicateFromChangesFeed(ctx, httpServer.URL, targetDB)
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	_, err = targetRegistry.GetServerByNameAndVersion(ctx, "com.source/server-1", "1.0.0", true)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestImportService_ReplicateFromChangesFeed_StopsAtFailedChange(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{EnableRegistryValidation: false}
+
+	change := func(seq int64, name string) apiv0.ServerChange {
+		return apiv0.ServerChange{Seq: seq, Type: "created", Server: apiv0.ServerResponse{Server: apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Source server",
+			Version:     "1.0.0",
+		}}}
+	}
+	changes := []apiv0.ServerChange{
+		change(1, "com.source/server-1"),
+		change(2, "not a server name"),
+		change(3, "com.source/server-3"),
+	}
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since, _ := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+		response := apiv0.ServerChangesResponse{Changes: []apiv0.ServerChange{}, Metadata: apiv0.ChangesMetadata{NextSince: since}}
+		for _, change := range changes {
+			if change.Seq > since {
+				response.Changes = append(response.Changes, change)
+				response.Metadata.NextSince = change.Seq
+			}
+		}
+		response.Metadata.Count = len(response.Changes)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer httpServer.Close()
+
+	targetDB := database.NewMemory()
+	targetRegistry := service.NewTestRegistryService(t, targetDB, cfg)
+	importerService := importer.NewService(targetRegistry)
+
+	// The failed change stops replication, with the checkpoint just before it
+	applied, err := importerService.ReplicateFromChangesFeed(ctx, httpServer.URL, targetDB)
+	require.Error(t, err)
+	assert.Equal(t, 1, applied)
+	checkpoint, err := targetDB.GetImportCheckpoint(ctx, nil, httpServer.URL)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), checkpoint)
+	_, err = targetRegistry.GetServerByNameAndVersion(ctx, "com.source/server-3", "1.0.0", true)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// Once the change applies, replication picks up from it
+	changes[1] = change(2, "com.source/server-2")
+	applied, err = importerService.ReplicateFromChangesFeed(ctx, httpServer.URL, targetDB)
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	checkpoint, err = targetDB.GetImportCheckpoint(ctx, nil, httpServer.URL)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), checkpoint)
+}
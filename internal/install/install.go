@@ -0,0 +1,361 @@
+// Package install renders client configuration snippets for installing MCP servers
+// from their published packages and remotes.
+package install
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Supported clients
+const (
+	ClientClaude = "claude"
+	ClientVSCode = "vscode"
+	ClientCursor = "cursor"
+)
+
+// Sources an install snippet can be rendered from
+const (
+	SourcePackage = "package"
+	SourceRemote  = "remote"
+)
+
+var (
+	// ErrUnsupportedClient is returned when a snippet is requested for an unknown client
+	ErrUnsupportedClient = errors.New("unsupported client")
+	// ErrNotInstallable is returned when a server has no package or remote that can be rendered
+	ErrNotInstallable = errors.New("server has no installable package or remote")
+)
+
+// variablePattern matches {variable} references in input values and remote URLs
+var variablePattern = regexp.MustCompile(`\{([a-zA-Z0-9_.-]+)\}`)
+
+// Snippet is a ready-to-use client configuration for a server
+type Snippet struct {
+	Client     string         `json:"client" doc:"Client the configuration is rendered for" example:"vscode"`
+	ServerName string         `json:"serverName" doc:"Server name" example:"io.github.user/weather"`
+	Version    string         `json:"version" doc:"Server version the configuration installs" example:"1.0.2"`
+	Source     string         `json:"source" enum:"package,remote" doc:"Whether the configuration runs a package locally or connects to a remote"`
+	Config     map[string]any `json:"config" doc:"Configuration to merge into the client's MCP configuration file"`
+	DeepLink   string         `json:"deepLink,omitempty" doc:"Link that opens the client and offers to install the server, for clients that support it"`
+	Inputs     []UserInput    `json:"inputs,omitempty" doc:"Values the user must supply. Their placeholders appear in the configuration."`
+}
+
+// UserInput describes a value the user has to provide before the configuration works
+type UserInput struct {
+	ID          string `json:"id" doc:"Input identifier" example:"WEATHER_API_KEY"`
+	Placeholder string `json:"placeholder" doc:"Token in the configuration to replace with the value" example:"<WEATHER_API_KEY>"`
+	Description string `json:"description,omitempty" doc:"Description of the input from server.json"`
+	IsSecret    bool   `json:"isSecret,omitempty" doc:"Whether the value is a secret such as an API key"`
+}
+
+// Render builds the configuration snippet for a client. source selects between the server's
+// packages and remotes; when empty, remotes are preferred since they need no local runtime.
+func Render(server *apiv0.ServerJSON, client, source string) (*Snippet, error) {
+	if client != ClientClaude && client != ClientVSCode && client != ClientCursor {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedClient, client)
+	}
+
+	r := &renderer{client: client}
+	snippet := &Snippet{
+		Client:     client,
+		ServerName: server.Name,
+		Version:    server.Version,
+	}
+
+	var entry map[string]any
+	remote := firstRemote(server)
+	pkg := firstRunnablePackage(server)
+	switch {
+	case remote != nil && source != SourcePackage:
+		snippet.Source = SourceRemote
+		entry = r.remoteEntry(remote)
+	case pkg != nil && source != SourceRemote:
+		snippet.Source = SourcePackage
+		entry = r.packageEntry(pkg)
+	default:
+		return nil, ErrNotInstallable
+	}
+
+	key := configKey(server.Name)
+	snippet.Inputs = r.inputs
+	if client == ClientVSCode {
+		snippet.Config = map[string]any{"servers": map[string]any{key: entry}}
+		if len(r.inputs) > 0 {
+			snippet.Config["inputs"] = r.vscodeInputs()
+		}
+	} else {
+		snippet.Config = map[string]any{"mcpServers": map[string]any{key: entry}}
+	}
+
+	deepLink, err := r.deepLink(key, entry)
+	if err != nil {
+		return nil, err
+	}
+	snippet.DeepLink = deepLink
+
+	return snippet, nil
+}
+
+// configKey derives the key the server is stored under in the client configuration
+func configKey(serverName string) string {
+	if _, name, found := strings.Cut(serverName, "/"); found {
+		return name
+	}
+	return serverName
+}
+
+func firstRemote(server *apiv0.ServerJSON) *model.Transport {
+	for i := range server.Remotes {
+		if server.Remotes[i].URL != "" {
+			return &server.Remotes[i]
+		}
+	}
+	return nil
+}
+
+// firstRunnablePackage returns the first package a client can launch over stdio
+func firstRunnablePackage(server *apiv0.ServerJSON) *model.Package {
+	for i := range server.Packages {
+		pkg := &server.Packages[i]
+		if pkg.Transport.Type != model.TransportTypeStdio {
+			continue
+		}
+		if _, ok := defaultRuntimes[pkg.RegistryType]; ok || pkg.RunTimeHint != "" {
+			return pkg
+		}
+	}
+	return nil
+}
+
+// defaultRuntimes maps registry types to the command that runs their packages
+var defaultRuntimes = map[string]string{
+	model.RegistryTypeNPM:   model.RuntimeHintNPX,
+	model.RegistryTypePyPI:  model.RuntimeHintUVX,
+	model.RegistryTypeNuGet: model.RuntimeHintDNX,
+	model.RegistryTypeOCI:   model.RuntimeHintDocker,
+}
+
+// renderer resolves input values for one snippet, collecting the inputs the user must supply
+type renderer struct {
+	client string
+	inputs []UserInput
+}
+
+func (r *renderer) packageEntry(pkg *model.Package) map[string]any {
+	command := pkg.RunTimeHint
+	if command == "" {
+		command = defaultRuntimes[pkg.RegistryType]
+	}
+
+	env := map[string]string{}
+	for _, variable := range pkg.EnvironmentVariables {
+		if value, ok := r.resolve(variable.Name, variable.InputWithVariables); ok {
+			env[variable.Name] = value
+		}
+	}
+
+	args := r.arguments(pkg.RuntimeArguments)
+	switch command {
+	case model.RuntimeHintNPX:
+		if len(pkg.RuntimeArguments) == 0 {
+			args = append(args, "-y")
+		}
+		args = append(args, versioned(pkg.Identifier, "@", pkg.Version))
+	case model.RuntimeHintUVX:
+		args = append(args, versioned(pkg.Identifier, "==", pkg.Version))
+	case model.RuntimeHintDNX:
+		args = append(args, versioned(pkg.Identifier, "@", pkg.Version), "--yes")
+		if len(pkg.PackageArguments) > 0 {
+			args = append(args, "--")
+		}
+	case model.RuntimeHintDocker:
+		if len(pkg.RuntimeArguments) == 0 {
+			args = append(args, "run", "-i", "--rm")
+		}
+		// Environment variables only reach the container when passed through explicitly
+		for _, variable := range pkg.EnvironmentVariables {
+			if _, ok := env[variable.Name]; ok {
+				args = append(args, "-e", variable.Name)
+			}
+		}
+		args = append(args, pkg.Identifier)
+	default:
+		args = append(args, pkg.Identifier)
+	}
+	args = append(args, r.arguments(pkg.PackageArguments)...)
+
+	entry := map[string]any{
+		"command": command,
+		"args":    args,
+	}
+	if r.client == ClientVSCode {
+		entry["type"] = model.TransportTypeStdio
+	}
+	if len(env) > 0 {
+		entry["env"] = env
+	}
+	return entry
+}
+
+func versioned(identifier, separator, version string) string {
+	if version == "" || version == "latest" {
+		return identifier
+	}
+	return identifier + separator + version
+}
+
+func (r *renderer) remoteEntry(remote *model.Transport) map[string]any {
+	remoteURL := variablePattern.ReplaceAllStringFunc(remote.URL, func(match string) string {
+		name := strings.Trim(match, "{}")
+		if variable, ok := remote.Variables[name]; ok {
+			if value, ok := r.resolve(name, model.InputWithVariables{Input: variable}); ok {
+				return value
+			}
+		}
+		return match
+	})
+
+	headers := map[string]string{}
+	for _, header := range remote.Headers {
+		if value, ok := r.resolve(header.Name, header.InputWithVariables); ok {
+			headers[header.Name] = value
+		}
+	}
+
+	entry := map[string]any{"url": remoteURL}
+	if r.client != ClientCursor {
+		entry["type"] = "http"
+		if remote.Type == model.TransportTypeSSE {
+			entry["type"] = model.TransportTypeSSE
+		}
+	}
+	if len(headers) > 0 {
+		entry["headers"] = headers
+	}
+	return entry
+}
+
+// arguments renders runtime or package arguments in order, skipping optional ones without a value
+func (r *renderer) arguments(arguments []model.Argument) []string {
+	var args []string
+	for _, argument := range arguments {
+		id := argument.ValueHint
+		if id == "" {
+			id = strings.TrimLeft(argument.Name, "-")
+		}
+
+		value, ok := r.resolve(id, argument.InputWithVariables)
+		switch {
+		case !ok:
+			continue
+		case argument.Type == model.ArgumentTypeNamed:
+			args = append(args, argument.Name, value)
+		default:
+			args = append(args, value)
+		}
+	}
+	return args
+}
+
+// resolve returns the value to write for an input. Fixed values have their {variables} substituted,
+// defaults are used as-is, and required or secret inputs without either become placeholders.
+// It reports false when an optional input has nothing to render.
+func (r *renderer) resolve(id string, input model.InputWithVariables) (string, bool) {
+	if input.Value != "" {
+		return variablePattern.ReplaceAllStringFunc(input.Value, func(match string) string {
+			name := strings.Trim(match, "{}")
+			variable, ok := input.Variables[name]
+			if !ok {
+				return match
+			}
+			value, _ := r.resolve(name, model.InputWithVariables{Input: variable})
+			return value
+		}), true
+	}
+	if input.Default != "" && !input.IsSecret {
+		return input.Default, true
+	}
+	if !input.IsRequired && !input.IsSecret {
+		return "", false
+	}
+	return r.placeholder(id, input.Input), true
+}
+
+// placeholder records an input the user must supply and returns the token standing in for it
+func (r *renderer) placeholder(id string, input model.Input) string {
+	if id == "" {
+		id = fmt.Sprintf("input_%d", len(r.inputs)+1)
+	}
+
+	token := "<" + id + ">"
+	if r.client == ClientVSCode {
+		token = "${input:" + id + "}"
+	}
+
+	for _, existing := range r.inputs {
+		if existing.ID == id {
+			return existing.Placeholder
+		}
+	}
+	r.inputs = append(r.inputs, UserInput{
+		ID:          id,
+		Placeholder: token,
+		Description: input.Description,
+		IsSecret:    input.IsSecret,
+	})
+	return token
+}
+
+// vscodeInputs converts collected inputs into VS Code's input prompt declarations
+func (r *renderer) vscodeInputs() []map[string]any {
+	inputs := make([]map[string]any, len(r.inputs))
+	for i, input := range r.inputs {
+		inputs[i] = map[string]any{
+			"type":        "promptString",
+			"id":          input.ID,
+			"description": input.Description,
+			"password":    input.IsSecret,
+		}
+	}
+	return inputs
+}
+
+// deepLink builds the client's install link for a single server entry
+func (r *renderer) deepLink(key string, entry map[string]any) (string, error) {
+	switch r.client {
+	case ClientVSCode:
+		// VS Code expects the entry plus its name and inputs as URI-component-encoded JSON
+		withName := map[string]any{"name": key}
+		for k, v := range entry {
+			withName[k] = v
+		}
+		if len(r.inputs) > 0 {
+			withName["inputs"] = r.vscodeInputs()
+		}
+		data, err := json.Marshal(withName)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode deep link: %w", err)
+		}
+		return "vscode:mcp/install?" + strings.ReplaceAll(url.QueryEscape(string(data)), "+", "%20"), nil
+	case ClientCursor:
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode deep link: %w", err)
+		}
+		query := url.Values{}
+		query.Set("name", key)
+		query.Set("config", base64.StdEncoding.EncodeToString(data))
+		return "cursor://anysphere.cursor-deeplink/mcp/install?" + query.Encode(), nil
+	default:
+		return "", nil
+	}
+}
@@ -0,0 +1,214 @@
+package install_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/install"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func weatherServer() *apiv0.ServerJSON {
+	return &apiv0.ServerJSON{
+		Name:    "io.github.example/weather",
+		Version: "1.2.0",
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeMCPB,
+				Identifier:   "https://github.com/example/weather/releases/download/v1.2.0/weather.mcpb",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+			{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@example/weather",
+				Version:      "1.2.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+				PackageArguments: []model.Argument{
+					{
+						Type: model.ArgumentTypeNamed,
+						Name: "--units",
+						InputWithVariables: model.InputWithVariables{
+							Input: model.Input{Default: "metric"},
+						},
+					},
+					{
+						Type:      model.ArgumentTypePositional,
+						ValueHint: "cache_dir",
+					},
+				},
+				EnvironmentVariables: []model.KeyValueInput{
+					{
+						Name: "WEATHER_API_KEY",
+						InputWithVariables: model.InputWithVariables{
+							Input: model.Input{Description: "OpenWeatherMap API key", IsRequired: true, IsSecret: true},
+						},
+					},
+				},
+			},
+		},
+		Remotes: []model.Transport{
+			{
+				Type: model.TransportTypeStreamableHTTP,
+				URL:  "https://{region}.weather.example.com/mcp",
+				Variables: map[string]model.Input{
+					"region": {Default: "us"},
+				},
+				Headers: []model.KeyValueInput{
+					{
+						Name: "Authorization",
+						InputWithVariables: model.InputWithVariables{
+							Input: model.Input{Value: "Bearer {token}"},
+							Variables: map[string]model.Input{
+								"token": {Description: "API token", IsRequired: true, IsSecret: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRender_Package(t *testing.T) {
+	snippet, err := install.Render(weatherServer(), install.ClientClaude, install.SourcePackage)
+	require.NoError(t, err)
+
+	assert.Equal(t, install.SourcePackage, snippet.Source)
+	assert.Empty(t, snippet.DeepLink)
+	assert.Equal(t, map[string]any{
+		"mcpServers": map[string]any{
+			"weather": map[string]any{
+				"command": "npx",
+				"args":    []string{"-y", "@example/weather@1.2.0", "--units", "metric"},
+				"env":     map[string]string{"WEATHER_API_KEY": "<WEATHER_API_KEY>"},
+			},
+		},
+	}, snippet.Config)
+	assert.Equal(t, []install.UserInput{
+		{ID: "WEATHER_API_KEY", Placeholder: "<WEATHER_API_KEY>", Description: "OpenWeatherMap API key", IsSecret: true},
+	}, snippet.Inputs)
+}
+
+func TestRender_PackageCommands(t *testing.T) {
+	tests := []struct {
+		name         string
+		pkg          model.Package
+		expectedCmd  string
+		expectedArgs []string
+	}{
+		{
+			name:         "pypi",
+			pkg:          model.Package{RegistryType: model.RegistryTypePyPI, Identifier: "weather-mcp", Version: "0.3.1"},
+			expectedCmd:  "uvx",
+			expectedArgs: []string{"weather-mcp==0.3.1"},
+		},
+		{
+			name: "nuget with package arguments",
+			pkg: model.Package{
+				RegistryType: model.RegistryTypeNuGet,
+				Identifier:   "Weather.Mcp",
+				Version:      "2.0.0",
+				PackageArguments: []model.Argument{
+					{Type: model.ArgumentTypePositional, InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "serve"}}},
+				},
+			},
+			expectedCmd:  "dnx",
+			expectedArgs: []string{"Weather.Mcp@2.0.0", "--yes", "--", "serve"},
+		},
+		{
+			name: "oci passes environment into the container",
+			pkg: model.Package{
+				RegistryType: model.RegistryTypeOCI,
+				Identifier:   "ghcr.io/example/weather:1.0.0",
+				EnvironmentVariables: []model.KeyValueInput{
+					{Name: "LOG_LEVEL", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: "info"}}},
+					{Name: "OPTIONAL_FLAG"},
+				},
+			},
+			expectedCmd:  "docker",
+			expectedArgs: []string{"run", "-i", "--rm", "-e", "LOG_LEVEL", "ghcr.io/example/weather:1.0.0"},
+		},
+		{
+			name: "runtime hint and runtime arguments",
+			pkg: model.Package{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "weather",
+				Version:      "1.0.0",
+				RunTimeHint:  "bunx",
+				RuntimeArguments: []model.Argument{
+					{Type: model.ArgumentTypeNamed, Name: "--bun", InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "true"}}},
+				},
+			},
+			expectedCmd:  "bunx",
+			expectedArgs: []string{"--bun", "true", "weather"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.pkg.Transport = model.Transport{Type: model.TransportTypeStdio}
+			server := &apiv0.ServerJSON{Name: "io.github.example/weather", Version: "1.0.0", Packages: []model.Package{tt.pkg}}
+
+			snippet, err := install.Render(server, install.ClientCursor, "")
+			require.NoError(t, err)
+
+			entry := snippet.Config["mcpServers"].(map[string]any)["weather"].(map[string]any)
+			assert.Equal(t, tt.expectedCmd, entry["command"])
+			assert.Equal(t, tt.expectedArgs, entry["args"])
+		})
+	}
+}
+
+func TestRender_RemoteVSCode(t *testing.T) {
+	snippet, err := install.Render(weatherServer(), install.ClientVSCode, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, install.SourceRemote, snippet.Source)
+	entry := snippet.Config["servers"].(map[string]any)["weather"].(map[string]any)
+	assert.Equal(t, "http", entry["type"])
+	assert.Equal(t, "https://us.weather.example.com/mcp", entry["url"])
+	assert.Equal(t, map[string]string{"Authorization": "Bearer ${input:token}"}, entry["headers"])
+	assert.Equal(t, []map[string]any{
+		{"type": "promptString", "id": "token", "description": "API token", "password": true},
+	}, snippet.Config["inputs"])
+
+	require.True(t, strings.HasPrefix(snippet.DeepLink, "vscode:mcp/install?"))
+	decoded, err := url.QueryUnescape(strings.TrimPrefix(snippet.DeepLink, "vscode:mcp/install?"))
+	require.NoError(t, err)
+	var link map[string]any
+	require.NoError(t, json.Unmarshal([]byte(decoded), &link))
+	assert.Equal(t, "weather", link["name"])
+	assert.Equal(t, "https://us.weather.example.com/mcp", link["url"])
+	assert.NotEmpty(t, link["inputs"])
+}
+
+func TestRender_CursorDeepLink(t *testing.T) {
+	snippet, err := install.Render(weatherServer(), install.ClientCursor, install.SourceRemote)
+	require.NoError(t, err)
+
+	link, err := url.Parse(snippet.DeepLink)
+	require.NoError(t, err)
+	assert.Equal(t, "cursor", link.Scheme)
+	assert.Equal(t, "weather", link.Query().Get("name"))
+
+	config, err := base64.StdEncoding.DecodeString(link.Query().Get("config"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"url":"https://us.weather.example.com/mcp","headers":{"Authorization":"Bearer <token>"}}`, string(config))
+}
+
+func TestRender_Errors(t *testing.T) {
+	_, err := install.Render(weatherServer(), "emacs", "")
+	assert.ErrorIs(t, err, install.ErrUnsupportedClient)
+
+	server := weatherServer()
+	server.Packages = server.Packages[:1]
+	_, err = install.Render(server, install.ClientClaude, install.SourcePackage)
+	assert.ErrorIs(t, err, install.ErrNotInstallable)
+}
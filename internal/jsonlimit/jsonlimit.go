@@ -0,0 +1,172 @@
+// Package jsonlimit checks untrusted JSON request bodies against size, nesting and key limits before they
+// are decoded, so pathological payloads are rejected by a single streaming pass instead of consuming CPU
+// and memory in the decoder and schema validation.
+package jsonlimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrTooLarge is returned for documents larger than Limits.MaxBytes
+	ErrTooLarge = errors.New("JSON document is too large")
+	// ErrInvalid is returned for documents that are malformed or break a limit other than their size
+	ErrInvalid = errors.New("invalid JSON document")
+)
+
+// Limits bounds the JSON documents Read and Check accept. Zero values disable a limit.
+type Limits struct {
+	// MaxBytes is the largest accepted document, in bytes
+	MaxBytes int64
+	// MaxDepth is how deeply objects and arrays may be nested; the top-level value has depth 1
+	MaxDepth int
+	// TopLevelFields lists the fields a top-level object may have; nil allows any field
+	TopLevelFields []string
+}
+
+// Read reads a document of at most limits.MaxBytes from r and checks it against limits
+func Read(r io.Reader, limits Limits) ([]byte, error) {
+	if limits.MaxBytes > 0 {
+		r = io.LimitReader(r, limits.MaxBytes+1)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := Check(data, limits); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// frame is an object or array being scanned
+type frame struct {
+	path   string
+	object bool
+	// keys holds the keys seen so far in an object, and key the most recent one
+	keys map[string]struct{}
+	key  string
+	// expectKey is whether the next token of an object is a key, or its closing delimiter
+	expectKey bool
+	// index is the position of the next element of an array
+	index int
+}
+
+// Check checks that data holds exactly one JSON value within limits. Objects may not repeat a key: decoders
+// disagree on which of the duplicates wins, so they could be used to show validation one value and store
+// another.
+func Check(data []byte, limits Limits) error {
+	if limits.MaxBytes > 0 && int64(len(data)) > limits.MaxBytes {
+		return fmt.Errorf("%w: the limit is %d bytes", ErrTooLarge, limits.MaxBytes)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var stack []*frame
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalid, syntaxError(err))
+		}
+
+		var parent *frame
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+		if parent != nil && parent.object && parent.expectKey {
+			if token == json.Delim('}') {
+				stack = stack[:len(stack)-1]
+			} else {
+				key, _ := token.(string)
+				if _, exists := parent.keys[key]; exists {
+					return fmt.Errorf("%w: duplicate key %q in %s", ErrInvalid, key, describePath(parent.path))
+				}
+				if len(stack) == 1 && limits.TopLevelFields != nil && !slices.Contains(limits.TopLevelFields, key) {
+					return fmt.Errorf("%w: unknown field %q; put extension data in _meta", ErrInvalid, key)
+				}
+				parent.keys[key] = struct{}{}
+				parent.key = key
+				parent.expectKey = false
+				continue
+			}
+		} else {
+			switch token {
+			case json.Delim('{'), json.Delim('['):
+				if limits.MaxDepth > 0 && len(stack) >= limits.MaxDepth {
+					return fmt.Errorf("%w: %s is nested more than %d levels deep", ErrInvalid, describePath(childPath(parent)), limits.MaxDepth)
+				}
+				child := &frame{path: childPath(parent), object: token == json.Delim('{'), expectKey: true}
+				if child.object {
+					child.keys = map[string]struct{}{}
+				}
+				valueDone(parent)
+				stack = append(stack, child)
+			case json.Delim(']'):
+				stack = stack[:len(stack)-1]
+			default:
+				valueDone(parent)
+			}
+		}
+
+		if len(stack) == 0 {
+			break
+		}
+	}
+
+	// The decoder reads a stream of values, but a request body holds exactly one
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: unexpected data after the top-level value", ErrInvalid)
+	}
+	return nil
+}
+
+// valueDone records that a value of parent, if any, has started, so the next token of an object is a key
+func valueDone(parent *frame) {
+	if parent == nil {
+		return
+	}
+	if parent.object {
+		parent.expectKey = true
+	} else {
+		parent.index++
+	}
+}
+
+// childPath returns the path of the next value of parent, such as packages[0].transport
+func childPath(parent *frame) string {
+	switch {
+	case parent == nil:
+		return ""
+	case parent.object:
+		return parent.path + "." + parent.key
+	default:
+		return parent.path + "[" + strconv.Itoa(parent.index) + "]"
+	}
+}
+
+// describePath names a path in error messages
+func describePath(path string) string {
+	if path == "" {
+		return "the document"
+	}
+	return strings.TrimPrefix(path, ".")
+}
+
+// syntaxError adds the byte offset to errors from the decoder where it is known
+func syntaxError(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	var syntax *json.SyntaxError
+	if errors.As(err, &syntax) {
+		return fmt.Errorf("%s at offset %d", syntax.Error(), syntax.Offset)
+	}
+	return err
+}
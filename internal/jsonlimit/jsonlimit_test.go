@@ -0,0 +1,84 @@
+package jsonlimit_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/jsonlimit"
+)
+
+func TestCheck(t *testing.T) {
+	limits := jsonlimit.Limits{MaxBytes: 256, MaxDepth: 4, TopLevelFields: []string{"name", "packages", "_meta"}}
+
+	testCases := []struct {
+		name    string
+		data    string
+		err     error
+		message string
+	}{
+		{name: "valid", data: `{"name":"a","packages":[{"transport":{"type":"stdio"}}],"_meta":{"x":[1,2.5,null,true]}}`},
+		{name: "same key in different objects", data: `{"packages":[{"name":"a"},{"name":"b"}],"name":"c"}`},
+		{name: "too large", data: `{"name":"` + strings.Repeat("a", 256) + `"}`, err: jsonlimit.ErrTooLarge},
+		{name: "empty", data: ``, err: jsonlimit.ErrInvalid},
+		{name: "malformed", data: `{"name":}`, err: jsonlimit.ErrInvalid},
+		{name: "truncated", data: `{"name":"a"`, err: jsonlimit.ErrInvalid},
+		{name: "trailing value", data: `{"name":"a"} {}`, err: jsonlimit.ErrInvalid, message: "unexpected data after the top-level value"},
+		{name: "duplicate top-level key", data: `{"name":"a","name":"b"}`, err: jsonlimit.ErrInvalid, message: `duplicate key "name" in the document`},
+		{name: "duplicate nested key", data: `{"packages":[{},{"a":1,"a":2}]}`, err: jsonlimit.ErrInvalid, message: `duplicate key "a" in packages[1]`},
+		{name: "unknown top-level field", data: `{"name":"a","extra":1}`, err: jsonlimit.ErrInvalid, message: `unknown field "extra"`},
+		{name: "unknown nested field", data: `{"_meta":{"extra":1}}`},
+		{name: "at the depth limit", data: `{"packages":[{"a":[]}]}`},
+		{name: "too deep", data: `{"packages":[{"a":[[]]}]}`, err: jsonlimit.ErrInvalid, message: "packages[0].a[0] is nested more than 4 levels deep"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := jsonlimit.Check([]byte(tc.data), limits)
+			if tc.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.err)
+			assert.Contains(t, err.Error(), tc.message)
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	limits := jsonlimit.Limits{MaxBytes: 8}
+
+	data, err := jsonlimit.Read(strings.NewReader(`[1,2,3]`), limits)
+	require.NoError(t, err)
+	assert.Equal(t, `[1,2,3]`, string(data))
+
+	// Only one byte more than the limit is read
+	_, err = jsonlimit.Read(strings.NewReader(`[`+strings.Repeat("1,", 1<<20)+`1]`), limits)
+	require.ErrorIs(t, err, jsonlimit.ErrTooLarge)
+}
+
+func FuzzCheck(f *testing.F) {
+	for _, seed := range []string{
+		`{"name":"a","packages":[{"transport":{"type":"stdio"}}]}`,
+		`{"a":1,"a":2}`,
+		`[[[[[[]]]]]]`,
+		`{"_meta":{"x":[1,{"y":null}]}} []`,
+		`"\u0000"`,
+	} {
+		f.Add([]byte(seed))
+	}
+	limits := jsonlimit.Limits{MaxBytes: 4096, MaxDepth: 5, TopLevelFields: []string{"name", "packages", "_meta"}}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if jsonlimit.Check(data, limits) != nil {
+			return
+		}
+		// Accepted documents must be a single valid JSON value
+		if !json.Valid(data) {
+			t.Fatalf("accepted invalid JSON %q", data)
+		}
+	})
+}
@@ -0,0 +1,360 @@
+// Package probe checks whether remote MCP endpoints are reachable by performing the start of an MCP
+// handshake with them.
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ProtocolVersion is the MCP protocol version the prober offers in its initialize request
+const ProtocolVersion = "2025-06-18"
+
+// maxResponseBytes bounds how much of a response the prober reads while looking for the initialize result
+const maxResponseBytes = 1 << 20
+
+// initializeRequestID is the JSON-RPC ID of the prober's initialize request
+const initializeRequestID = 1
+
+// errPrivateAddress is returned when a remote resolves to an address the prober may not connect to
+var errPrivateAddress = errors.New("remote resolves to a private or loopback address")
+
+// Result is the outcome of probing one remote endpoint
+type Result struct {
+	// Status is apiv0.RemoteHealthy, apiv0.RemoteAuthRequired or apiv0.RemoteUnhealthy
+	Status string
+	// ProtocolVersion is the MCP protocol version the endpoint negotiated, if the handshake succeeded
+	ProtocolVersion string
+	// Latency is how long the probe took
+	Latency time.Duration
+	// Error describes why the probe failed
+	Error string
+}
+
+// Prober performs MCP handshakes with remote endpoints
+type Prober struct {
+	client  *http.Client
+	timeout time.Duration
+}
+
+// NewProber creates a prober whose probes give up after timeout. Unless allowPrivate is set, it refuses
+// to connect to loopback, private and link-local addresses, so published remotes cannot be used to reach
+// the registry's own network.
+func NewProber(timeout time.Duration, allowPrivate bool) *Prober {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublic(ip) {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+
+	return &Prober{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext:           dialer.DialContext,
+				TLSHandshakeTimeout:   timeout,
+				ResponseHeaderTimeout: timeout,
+				MaxIdleConnsPerHost:   1,
+				IdleConnTimeout:       time.Minute,
+			},
+		},
+		timeout: timeout,
+	}
+}
+
+// isPublic reports whether ip is a globally routable unicast address
+func isPublic(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast()
+}
+
+// Probeable reports whether a remote can be probed: it must use an HTTP transport and a URL without
+// template variables, whose values only clients know
+func Probeable(remote model.Transport) bool {
+	return (remote.Type == model.TransportTypeStreamableHTTP || remote.Type == model.TransportTypeSSE) &&
+		remote.URL != "" && !strings.Contains(remote.URL, "{")
+}
+
+// Probe sends an MCP initialize request to a remote and reports whether it answered. Endpoints that
+// reject the request as unauthenticated are reported as requiring authentication, since the prober
+// never has credentials.
+func (p *Prober) Probe(ctx context.Context, remote model.Transport) Result {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	start := time.Now()
+	var protocolVersion string
+	var err error
+	if remote.Type == model.TransportTypeSSE {
+		protocolVersion, err = p.probeSSE(ctx, remote.URL)
+	} else {
+		protocolVersion, err = p.probeStreamableHTTP(ctx, remote.URL)
+	}
+	result := Result{Latency: time.Since(start)}
+
+	var statusErr *statusError
+	switch {
+	case err == nil:
+		result.Status = apiv0.RemoteHealthy
+		result.ProtocolVersion = protocolVersion
+	case errors.As(err, &statusErr) && (statusErr.code == http.StatusUnauthorized || statusErr.code == http.StatusForbidden):
+		result.Status = apiv0.RemoteAuthRequired
+		result.Error = err.Error()
+	default:
+		result.Status = apiv0.RemoteUnhealthy
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// statusError is returned when an endpoint answers with an unexpected HTTP status
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status %d %s", e.code, http.StatusText(e.code))
+}
+
+// probeStreamableHTTP performs the initialize exchange of the streamable HTTP transport, ending the
+// session afterwards if the endpoint started one
+func (p *Prober) probeStreamableHTTP(ctx context.Context, endpoint string) (string, error) {
+	resp, err := p.postInitialize(ctx, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if sessionID := resp.Header.Get("Mcp-Session-Id"); sessionID != "" {
+		defer p.endSession(endpoint, sessionID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", &statusError{code: resp.StatusCode}
+	}
+
+	data, err := readResponse(resp, initializeRequestID)
+	if err != nil {
+		return "", err
+	}
+	return parseInitializeResult(data)
+}
+
+// readResponse returns the JSON-RPC response with the given ID from a streamable HTTP response, which holds it
+// either as its JSON body or as an event of its event stream
+func readResponse(resp *http.Response, id int) ([]byte, error) {
+	body := io.LimitReader(resp.Body, maxResponseBytes)
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		data, err := readResponseEvent(newEventReader(body), id)
+		return []byte(data), err
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return data, nil
+}
+
+// probeSSE performs the initialize exchange of the deprecated HTTP+SSE transport: the endpoint event
+// names the URL to post messages to, and the response arrives on the event stream
+func (p *Prober) probeSSE(ctx context.Context, endpoint string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", &statusError{code: resp.StatusCode}
+	}
+
+	events := newEventReader(io.LimitReader(resp.Body, maxResponseBytes))
+	event, data, err := events.next()
+	if err != nil {
+		return "", fmt.Errorf("no endpoint event: %w", err)
+	}
+	if event != "endpoint" {
+		return "", fmt.Errorf("expected endpoint event, got %q", event)
+	}
+	messagesURL, err := resp.Request.URL.Parse(strings.TrimSpace(data))
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint event: %w", err)
+	}
+
+	postResp, err := p.postInitialize(ctx, messagesURL.String(), resp.Request.URL)
+	if err != nil {
+		return "", err
+	}
+	postResp.Body.Close()
+	if postResp.StatusCode != http.StatusOK && postResp.StatusCode != http.StatusAccepted {
+		return "", &statusError{code: postResp.StatusCode}
+	}
+	response, err := readResponseEvent(events, initializeRequestID)
+	if err != nil {
+		return "", err
+	}
+	return parseInitializeResult([]byte(response))
+}
+
+// postInitialize sends the initialize request. For the SSE transport, origin is the stream URL, whose host the
+// messages URL must share.
+func (p *Prober) postInitialize(ctx context.Context, endpoint string, origin *url.URL) (*http.Response, error) {
+	return p.post(ctx, endpoint, origin, http.Header{}, map[string]any{
+		"jsonrpc": "2.0",
+		"id":      initializeRequestID,
+		"method":  "initialize",
+		"params": map[string]any{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]any{},
+			"clientInfo":      map[string]string{"name": "mcp-registry-prober", "version": "1.0.0"},
+		},
+	})
+}
+
+// post sends a JSON-RPC message with the given headers. For the SSE transport, origin is the stream URL, whose
+// host the messages URL must share.
+func (p *Prober) post(ctx context.Context, endpoint string, origin *url.URL, header http.Header, message map[string]any) (*http.Response, error) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if origin != nil && req.URL.Host != origin.Host {
+		return nil, fmt.Errorf("endpoint event points to another host: %s", req.URL.Host)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	return p.client.Do(req)
+}
+
+// endSession asks a streamable HTTP endpoint to end the session the probe started. Failures are ignored,
+// since servers may not support ending sessions.
+func (p *Prober) endSession(endpoint, sessionID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Mcp-Session-Id", sessionID)
+	if resp, err := p.client.Do(req); err == nil {
+		resp.Body.Close()
+	}
+}
+
+// readResponseEvent reads events until the response to the request with the given ID arrives
+func readResponseEvent(events *eventReader, id int) (string, error) {
+	for {
+		event, data, err := events.next()
+		if err != nil {
+			return "", fmt.Errorf("no response to request %d: %w", id, err)
+		}
+		if event != "message" {
+			continue
+		}
+		var message struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if json.Unmarshal([]byte(data), &message) != nil || string(message.ID) != fmt.Sprint(id) {
+			continue
+		}
+		return data, nil
+	}
+}
+
+// parseInitializeResult returns the protocol version from a JSON-RPC initialize response
+func parseInitializeResult(data []byte) (string, error) {
+	var response struct {
+		Result *struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		} `json:"result"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return "", fmt.Errorf("invalid initialize response: %w", err)
+	}
+	switch {
+	case response.Error != nil:
+		return "", fmt.Errorf("initialize failed: %d %s", response.Error.Code, response.Error.Message)
+	case response.Result == nil || response.Result.ProtocolVersion == "":
+		return "", errors.New("initialize response has no protocol version")
+	default:
+		return response.Result.ProtocolVersion, nil
+	}
+}
+
+// eventReader reads server-sent events
+type eventReader struct {
+	r *bufio.Reader
+}
+
+func newEventReader(r io.Reader) *eventReader {
+	return &eventReader{r: bufio.NewReader(r)}
+}
+
+// next returns the type and data of the next event. Events without a type are "message" events.
+func (e *eventReader) next() (string, string, error) {
+	event := ""
+	var data []string
+	for {
+		line, err := e.r.ReadString('\n')
+		if err != nil && (line == "" || !errors.Is(err, io.EOF)) {
+			return "", "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if len(data) > 0 {
+				if event == "" {
+					event = "message"
+				}
+				return event, strings.Join(data, "\n"), nil
+			}
+			event = ""
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+}
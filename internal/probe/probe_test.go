@@ -0,0 +1,175 @@
+package probe_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/probe"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const initializeResponse = `{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2025-06-18","capabilities":{}}}`
+
+func TestProbe_StreamableHTTP(t *testing.T) {
+	var endedSession string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Mcp-Session-Id", "session-1")
+			if r.Method == http.MethodDelete {
+				endedSession = r.Header.Get("Mcp-Session-Id")
+				return
+			}
+			_, _ = io.WriteString(w, initializeResponse)
+		case "/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/message\"}\n\n")
+			_, _ = io.WriteString(w, "data: "+initializeResponse+"\n\n")
+		case "/error":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"unsupported protocol version"}}`)
+		case "/auth":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	prober := probe.NewProber(time.Second, true)
+	probeURL := func(path string) probe.Result {
+		return prober.Probe(context.Background(), model.Transport{Type: model.TransportTypeStreamableHTTP, URL: server.URL + path})
+	}
+
+	result := probeURL("/json")
+	assert.Equal(t, apiv0.RemoteHealthy, result.Status, result.Error)
+	assert.Equal(t, "2025-06-18", result.ProtocolVersion)
+	assert.Equal(t, "session-1", endedSession, "the probe's session is ended")
+
+	result = probeURL("/stream")
+	assert.Equal(t, apiv0.RemoteHealthy, result.Status, result.Error)
+	assert.Equal(t, "2025-06-18", result.ProtocolVersion)
+
+	result = probeURL("/error")
+	assert.Equal(t, apiv0.RemoteUnhealthy, result.Status)
+	assert.Contains(t, result.Error, "unsupported protocol version")
+
+	assert.Equal(t, apiv0.RemoteAuthRequired, probeURL("/auth").Status)
+
+	result = probeURL("/missing")
+	assert.Equal(t, apiv0.RemoteUnhealthy, result.Status)
+	assert.Contains(t, result.Error, "404")
+}
+
+func TestProbe_SSE(t *testing.T) {
+	messages := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/sse":
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, "event: endpoint\ndata: /messages?session=1\n\n")
+			w.(http.Flusher).Flush()
+			select {
+			case message := <-messages:
+				_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", message)
+			case <-r.Context().Done():
+			}
+		case r.Method == http.MethodPost && r.URL.Path == "/messages":
+			w.WriteHeader(http.StatusAccepted)
+			messages <- initializeResponse
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	result := probe.NewProber(time.Second, true).Probe(context.Background(), model.Transport{Type: model.TransportTypeSSE, URL: server.URL + "/sse"})
+	assert.Equal(t, apiv0.RemoteHealthy, result.Status, result.Error)
+	assert.Equal(t, "2025-06-18", result.ProtocolVersion)
+}
+
+func TestProbe_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	result := probe.NewProber(50*time.Millisecond, true).Probe(context.Background(), model.Transport{Type: model.TransportTypeStreamableHTTP, URL: server.URL})
+	assert.Equal(t, apiv0.RemoteUnhealthy, result.Status)
+}
+
+func TestProbe_RefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, initializeResponse)
+	}))
+	defer server.Close()
+
+	result := probe.NewProber(time.Second, false).Probe(context.Background(), model.Transport{Type: model.TransportTypeStreamableHTTP, URL: server.URL})
+	require.Equal(t, apiv0.RemoteUnhealthy, result.Status)
+	assert.Contains(t, result.Error, "private or loopback")
+}
+
+func TestProbeable(t *testing.T) {
+	assert.True(t, probe.Probeable(model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "https://mcp.example.com/mcp"}))
+	assert.True(t, probe.Probeable(model.Transport{Type: model.TransportTypeSSE, URL: "https://mcp.example.com/sse"}))
+	assert.False(t, probe.Probeable(model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "https://{tenant}.example.com/mcp"}))
+	assert.False(t, probe.Probeable(model.Transport{Type: model.TransportTypeStdio}))
+}
+
+func TestListTools(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			return
+		}
+		var message struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+			Params struct {
+				Cursor string `json:"cursor"`
+			} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+		if message.Method != "initialize" && r.Header.Get("Mcp-Session-Id") != "session-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case message.Method == "initialize":
+			w.Header().Set("Mcp-Session-Id", "session-1")
+			_, _ = io.WriteString(w, initializeResponse)
+		case message.Method == "notifications/initialized":
+			w.WriteHeader(http.StatusAccepted)
+		case message.Method == "tools/list" && message.Params.Cursor == "":
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"tools":[{"name":"query","description":"Run a read-only SQL query"}],"nextCursor":"page-2"}}`, message.ID)
+		case message.Method == "tools/list":
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%d,\"result\":{\"tools\":[{\"name\":\"list_tables\"}]}}\n\n", message.ID)
+		}
+	}))
+	defer server.Close()
+
+	prober := probe.NewProber(time.Second, true)
+	tools, err := prober.ListTools(context.Background(), model.Transport{Type: model.TransportTypeStreamableHTTP, URL: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, []probe.Tool{{Name: "query", Description: "Run a read-only SQL query"}, {Name: "list_tables"}}, tools)
+
+	_, err = prober.ListTools(context.Background(), model.Transport{Type: model.TransportTypeSSE, URL: server.URL})
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// maxToolPages bounds how many pages of tools ListTools requests from one endpoint
+const maxToolPages = 10
+
+// errToolsUnsupported is returned when tools are listed from a remote that does not use the streamable HTTP transport
+var errToolsUnsupported = errors.New("tools can only be listed from streamable HTTP remotes")
+
+// Tool is a tool a remote lists
+type Tool struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ListTools performs the MCP handshake with a streamable HTTP remote and lists the tools it offers, following
+// up to maxToolPages pages. Remotes using the deprecated SSE transport are not supported. Like Probe, it never
+// sends credentials, so remotes requiring authentication fail with an unexpected status.
+func (p *Prober) ListTools(ctx context.Context, remote model.Transport) ([]Tool, error) {
+	if remote.Type != model.TransportTypeStreamableHTTP || !Probeable(remote) {
+		return nil, errToolsUnsupported
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	resp, err := p.postInitialize(ctx, remote.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	header := http.Header{}
+	if sessionID := resp.Header.Get("Mcp-Session-Id"); sessionID != "" {
+		header.Set("Mcp-Session-Id", sessionID)
+		defer p.endSession(remote.URL, sessionID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &statusError{code: resp.StatusCode}
+	}
+	data, err := readResponse(resp, initializeRequestID)
+	if err != nil {
+		return nil, err
+	}
+	protocolVersion, err := parseInitializeResult(data)
+	if err != nil {
+		return nil, err
+	}
+	header.Set("Mcp-Protocol-Version", protocolVersion)
+
+	initialized, err := p.post(ctx, remote.URL, nil, header, map[string]any{"jsonrpc": "2.0", "method": "notifications/initialized"})
+	if err != nil {
+		return nil, err
+	}
+	initialized.Body.Close()
+	if initialized.StatusCode != http.StatusAccepted && initialized.StatusCode != http.StatusOK {
+		return nil, &statusError{code: initialized.StatusCode}
+	}
+
+	var tools []Tool
+	cursor := ""
+	for page := range maxToolPages {
+		result, err := p.listToolsPage(ctx, remote.URL, header, initializeRequestID+1+page, cursor)
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, result.Tools...)
+		if result.NextCursor == "" {
+			break
+		}
+		cursor = result.NextCursor
+	}
+	return tools, nil
+}
+
+// toolsListResult is the result of a tools/list request
+type toolsListResult struct {
+	Tools      []Tool `json:"tools"`
+	NextCursor string `json:"nextCursor"`
+}
+
+// listToolsPage sends a tools/list request within the session identified by header
+func (p *Prober) listToolsPage(ctx context.Context, endpoint string, header http.Header, id int, cursor string) (*toolsListResult, error) {
+	params := map[string]any{}
+	if cursor != "" {
+		params["cursor"] = cursor
+	}
+	resp, err := p.post(ctx, endpoint, nil, header, map[string]any{"jsonrpc": "2.0", "id": id, "method": "tools/list", "params": params})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &statusError{code: resp.StatusCode}
+	}
+	data, err := readResponse(resp, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Result *toolsListResult `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("invalid tools/list response: %w", err)
+	}
+	switch {
+	case response.Error != nil:
+		return nil, fmt.Errorf("tools/list failed: %d %s", response.Error.Code, response.Error.Message)
+	case response.Result == nil:
+		return nil, errors.New("tools/list response has no result")
+	default:
+		return response.Result, nil
+	}
+}
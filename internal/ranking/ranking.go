@@ -0,0 +1,133 @@
+// Package ranking orders search results by relevance. A Ranker combines
+// independent signals (text match, recency, downloads, verified namespace),
+// each scoring servers between 0 and 1, using a configurable weight per signal.
+package ranking
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Built-in signal names, as used in MCP_REGISTRY_SEARCH_RANKING_WEIGHTS
+const (
+	SignalText      = "text"
+	SignalRecency   = "recency"
+	SignalDownloads = "downloads"
+	SignalVerified  = "verified"
+)
+
+// Signal scores a batch of servers for a search query. Scores are between 0 and 1, one per server in order.
+// Signals score whole batches so they can share lookups between servers.
+type Signal interface {
+	Name() string
+	Scores(ctx context.Context, query string, servers []*apiv0.ServerResponse) ([]float64, error)
+}
+
+// Weights maps signal names to their weight in the combined score
+type Weights map[string]float64
+
+// ParseWeights parses a comma-separated list of name=weight pairs, e.g. "text=1,recency=0.2"
+func ParseWeights(s string) (Weights, error) {
+	weights := Weights{}
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid ranking weight %q: expected name=weight", pair)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid ranking weight %q: weight must be a non-negative number", pair)
+		}
+		weights[strings.TrimSpace(name)] = weight
+	}
+	return weights, nil
+}
+
+// DefaultMaxCandidates is how many matching servers are ranked when no limit is configured
+const DefaultMaxCandidates = 500
+
+type weightedSignal struct {
+	signal Signal
+	weight float64
+}
+
+// Ranker orders servers by the weighted sum of its signals' scores
+type Ranker struct {
+	signals       []weightedSignal
+	maxCandidates int
+}
+
+// New creates a ranker from signals and their weights. Signals without a positive weight are not
+// evaluated, and weights naming a signal that is not provided are an error.
+func New(weights Weights, signals ...Signal) (*Ranker, error) {
+	known := map[string]bool{}
+	r := &Ranker{maxCandidates: DefaultMaxCandidates}
+	for _, signal := range signals {
+		known[signal.Name()] = true
+		if weight := weights[signal.Name()]; weight > 0 {
+			r.signals = append(r.signals, weightedSignal{signal: signal, weight: weight})
+		}
+	}
+	for name := range weights {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown ranking signal %q", name)
+		}
+	}
+	return r, nil
+}
+
+// NewFromConfig creates a ranker with the built-in signals, weighted by the registry configuration.
+// downloads may be nil when no download counts are available.
+func NewFromConfig(cfg *config.Config, namespaces NamespaceApprover, downloads DownloadCounter) (*Ranker, error) {
+	weights, err := ParseWeights(cfg.SearchRankingWeights)
+	if err != nil {
+		return nil, err
+	}
+	r, err := New(weights,
+		TextRelevance(),
+		Recency(cfg.SearchRankingRecencyHalfLife),
+		Downloads(downloads),
+		VerifiedNamespace(namespaces),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.SearchRankingMaxCandidates > 0 {
+		r.maxCandidates = cfg.SearchRankingMaxCandidates
+	}
+	return r, nil
+}
+
+// MaxCandidates returns how many matching servers are ranked. Matches beyond it are not returned.
+func (r *Ranker) MaxCandidates() int {
+	return r.maxCandidates
+}
+
+// Rank sorts servers by descending combined score. Servers with equal scores keep their relative order.
+func (r *Ranker) Rank(ctx context.Context, query string, servers []*apiv0.ServerResponse) error {
+	totals := make(map[*apiv0.ServerResponse]float64, len(servers))
+	for _, ws := range r.signals {
+		scores, err := ws.signal.Scores(ctx, query, servers)
+		if err != nil {
+			return fmt.Errorf("ranking signal %s: %w", ws.signal.Name(), err)
+		}
+		for i, server := range servers {
+			totals[server] += ws.weight * scores[i]
+		}
+	}
+
+	sort.SliceStable(servers, func(i, j int) bool {
+		return totals[servers[i]] > totals[servers[j]]
+	})
+	return nil
+}
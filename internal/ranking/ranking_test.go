@@ -0,0 +1,113 @@
+package ranking_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/ranking"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+type fakeDownloads map[string]int
+
+func (f fakeDownloads) ServerDownloads(serverName string) int { return f[serverName] }
+
+type fakeApprover map[string]bool
+
+func (f fakeApprover) IsNamespaceApproved(_ context.Context, domain string) (bool, error) {
+	return f[domain], nil
+}
+
+func server(name, description string, updatedAt time.Time) *apiv0.ServerResponse {
+	return &apiv0.ServerResponse{
+		Server: apiv0.ServerJSON{Name: name, Description: description},
+		Meta:   apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{PublishedAt: updatedAt, UpdatedAt: updatedAt}},
+	}
+}
+
+func names(servers []*apiv0.ServerResponse) []string {
+	result := make([]string, len(servers))
+	for i, s := range servers {
+		result[i] = s.Server.Name
+	}
+	return result
+}
+
+func TestParseWeights(t *testing.T) {
+	weights, err := ranking.ParseWeights(" text=1, recency=0.25 ,,verified=0")
+	require.NoError(t, err)
+	assert.Equal(t, ranking.Weights{"text": 1, "recency": 0.25, "verified": 0}, weights)
+
+	for _, invalid := range []string{"text", "text=abc", "text=-1"} {
+		_, err := ranking.ParseWeights(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNew_UnknownSignal(t *testing.T) {
+	_, err := ranking.New(ranking.Weights{"stars": 1}, ranking.TextRelevance())
+	assert.ErrorContains(t, err, `unknown ranking signal "stars"`)
+}
+
+func TestRank_TextRelevance(t *testing.T) {
+	now := time.Now()
+	servers := []*apiv0.ServerResponse{
+		server("com.example/my-weather-tools", "", now),
+		server("com.weather/api", "", now),
+		server("com.example/weather", "", now),
+		server("com.example/forecast", "Weather forecasts", now),
+		server("com.example/weatherly", "", now),
+	}
+
+	ranker, err := ranking.New(ranking.Weights{ranking.SignalText: 1}, ranking.TextRelevance())
+	require.NoError(t, err)
+	require.NoError(t, ranker.Rank(context.Background(), "Weather", servers))
+
+	assert.Equal(t, []string{
+		"com.example/weather",          // exact name
+		"com.example/weatherly",        // name prefix
+		"com.example/my-weather-tools", // name substring
+		"com.weather/api",              // namespace
+		"com.example/forecast",         // description
+	}, names(servers))
+}
+
+func TestRank_CombinedSignals(t *testing.T) {
+	now := time.Now()
+	servers := []*apiv0.ServerResponse{
+		server("com.example/old", "", now.Add(-365*24*time.Hour)),
+		server("com.example/popular", "", now.Add(-365*24*time.Hour)),
+		server("com.example/recent", "", now),
+		server("com.trusted.api/verified", "", now.Add(-365*24*time.Hour)),
+	}
+
+	ranker, err := ranking.New(
+		ranking.Weights{ranking.SignalRecency: 1, ranking.SignalDownloads: 2, ranking.SignalVerified: 0.5},
+		ranking.Recency(30*24*time.Hour),
+		ranking.Downloads(fakeDownloads{"com.example/popular": 1000}),
+		ranking.VerifiedNamespace(fakeApprover{"trusted.com": true}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, ranker.Rank(context.Background(), "", servers))
+
+	assert.Equal(t, []string{"com.example/popular", "com.example/recent", "com.trusted.api/verified", "com.example/old"}, names(servers))
+}
+
+func TestRank_NilSourcesScoreZero(t *testing.T) {
+	now := time.Now()
+	servers := []*apiv0.ServerResponse{server("com.example/b", "", now), server("com.example/a", "", now)}
+
+	ranker, err := ranking.New(
+		ranking.Weights{ranking.SignalDownloads: 1, ranking.SignalVerified: 1},
+		ranking.Downloads(nil),
+		ranking.VerifiedNamespace(nil),
+	)
+	require.NoError(t, err)
+	require.NoError(t, ranker.Rank(context.Background(), "", servers))
+
+	assert.Equal(t, []string{"com.example/b", "com.example/a"}, names(servers), "equal scores keep their order")
+}
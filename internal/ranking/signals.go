@@ -0,0 +1,170 @@
+package ranking
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// downloadsPivot is the download count that scores 0.5; counts are saturating so a few very popular
+// servers do not drown out every other signal
+const downloadsPivot = 10
+
+// NamespaceApprover reports whether an admin approved a domain's namespace verification
+type NamespaceApprover interface {
+	IsNamespaceApproved(ctx context.Context, domain string) (bool, error)
+}
+
+// DownloadCounter reports how often a server was downloaded recently
+type DownloadCounter interface {
+	ServerDownloads(serverName string) int
+}
+
+type signalFunc struct {
+	name   string
+	scores func(ctx context.Context, query string, servers []*apiv0.ServerResponse) ([]float64, error)
+}
+
+func (s signalFunc) Name() string { return s.name }
+
+func (s signalFunc) Scores(ctx context.Context, query string, servers []*apiv0.ServerResponse) ([]float64, error) {
+	return s.scores(ctx, query, servers)
+}
+
+// perServer adapts a per-server scoring function into a batch scoring function
+func perServer(score func(server *apiv0.ServerResponse) float64) func(context.Context, string, []*apiv0.ServerResponse) ([]float64, error) {
+	return func(_ context.Context, _ string, servers []*apiv0.ServerResponse) ([]float64, error) {
+		scores := make([]float64, len(servers))
+		for i, server := range servers {
+			scores[i] = score(server)
+		}
+		return scores, nil
+	}
+}
+
+// TextRelevance scores how closely the server matches the query terms, preferring matches on the
+// server's own name over its namespace, title, and description
+func TextRelevance() Signal {
+	return signalFunc{name: SignalText, scores: func(_ context.Context, query string, servers []*apiv0.ServerResponse) ([]float64, error) {
+		terms := strings.Fields(strings.ToLower(query))
+		scores := make([]float64, len(servers))
+		if len(terms) == 0 {
+			return scores, nil
+		}
+		for i, server := range servers {
+			for _, term := range terms {
+				scores[i] += textMatch(term, &server.Server)
+			}
+			scores[i] /= float64(len(terms))
+		}
+		return scores, nil
+	}}
+}
+
+func textMatch(term string, server *apiv0.ServerJSON) float64 {
+	fullName := strings.ToLower(server.Name)
+	_, name, _ := strings.Cut(fullName, "/")
+	switch {
+	case name == term:
+		return 1
+	case strings.HasPrefix(name, term):
+		return 0.8
+	case strings.Contains(name, term):
+		return 0.6
+	case strings.Contains(fullName, term):
+		return 0.4
+	case strings.Contains(strings.ToLower(server.Title), term):
+		return 0.3
+	case strings.Contains(strings.ToLower(server.Description), term):
+		return 0.2
+	default:
+		return 0
+	}
+}
+
+// Recency scores recently updated servers higher, halving the score every halfLife. A zero halfLife
+// disables the signal.
+func Recency(halfLife time.Duration) Signal {
+	return signalFunc{name: SignalRecency, scores: perServer(func(server *apiv0.ServerResponse) float64 {
+		if halfLife <= 0 || server.Meta.Official == nil {
+			return 0
+		}
+		updated := server.Meta.Official.UpdatedAt
+		if updated.IsZero() {
+			updated = server.Meta.Official.PublishedAt
+		}
+		age := max(time.Since(updated), 0)
+		return math.Pow(0.5, float64(age)/float64(halfLife))
+	})}
+}
+
+// Downloads scores servers by their recent download count. A nil counter scores every server 0.
+func Downloads(counter DownloadCounter) Signal {
+	return signalFunc{name: SignalDownloads, scores: perServer(func(server *apiv0.ServerResponse) float64 {
+		if counter == nil {
+			return 0
+		}
+		downloads := float64(counter.ServerDownloads(server.Server.Name))
+		return downloads / (downloads + downloadsPivot)
+	})}
+}
+
+// VerifiedNamespace scores 1 for servers under a domain namespace an admin approved, including
+// subdomains of an approved domain, and 0 otherwise. A nil approver scores every server 0.
+func VerifiedNamespace(approver NamespaceApprover) Signal {
+	return signalFunc{name: SignalVerified, scores: func(ctx context.Context, _ string, servers []*apiv0.ServerResponse) ([]float64, error) {
+		scores := make([]float64, len(servers))
+		if approver == nil {
+			return scores, nil
+		}
+
+		approved := map[string]bool{}
+		isApproved := func(domain string) (bool, error) {
+			if result, seen := approved[domain]; seen {
+				return result, nil
+			}
+			result, err := approver.IsNamespaceApproved(ctx, domain)
+			if err != nil {
+				return false, err
+			}
+			approved[domain] = result
+			return result, nil
+		}
+
+		for i, server := range servers {
+			for _, domain := range namespaceDomains(server.Server.Name) {
+				ok, err := isApproved(domain)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					scores[i] = 1
+					break
+				}
+			}
+		}
+		return scores, nil
+	}}
+}
+
+// namespaceDomains returns the domain of a reverse-DNS namespace and its parent domains
+// (com.example.api/server -> api.example.com, example.com). GitHub namespaces are verified
+// through GitHub rather than domain review and return none.
+func namespaceDomains(serverName string) []string {
+	namespace, _, _ := strings.Cut(strings.ToLower(serverName), "/")
+	if strings.HasPrefix(namespace, "io.github.") {
+		return nil
+	}
+	labels := strings.Split(namespace, ".")
+	slices.Reverse(labels)
+
+	var domains []string
+	for i := 0; i+2 <= len(labels); i++ {
+		domains = append(domains, strings.Join(labels[i:], "."))
+	}
+	return domains
+}
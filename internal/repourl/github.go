@@ -0,0 +1,35 @@
+package repourl
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// UserAgent identifies the registry to the repository hosts and package registries it calls
+const UserAgent = "MCP-Registry-Validator/1.0"
+
+// ParseGitHub extracts the owner and name from a https://github.com/owner/repo URL
+func ParseGitHub(repositoryURL string) (string, string, error) {
+	parsed, err := url.Parse(repositoryURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	owner, name, found := strings.Cut(strings.Trim(parsed.Path, "/"), "/")
+	if !found || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("repository URL must be https://github.com/<owner>/<repo>: %s", repositoryURL)
+	}
+	return owner, strings.TrimSuffix(name, ".git"), nil
+}
+
+// SetGitHubHeaders prepares a GitHub API request to accept the given media type, authenticating it with token
+// when one is configured
+func SetGitHubHeaders(req *http.Request, accept, token string) {
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", UserAgent)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
@@ -0,0 +1,52 @@
+package repourl_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/repourl"
+)
+
+func TestParseGitHub(t *testing.T) {
+	tests := []struct {
+		raw   string
+		owner string
+		name  string
+	}{
+		{"https://github.com/acme/weather", "acme", "weather"},
+		{"https://github.com/acme/weather.git", "acme", "weather"},
+		{"https://github.com/acme/weather/", "acme", "weather"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			owner, name, err := repourl.ParseGitHub(tt.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tt.owner, owner)
+			assert.Equal(t, tt.name, name)
+		})
+	}
+
+	for _, raw := range []string{"https://github.com/acme", "https://github.com/acme/weather/tree/main", "https://github.com//weather", "://"} {
+		t.Run(raw, func(t *testing.T) {
+			_, _, err := repourl.ParseGitHub(raw)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSetGitHubHeaders(t *testing.T) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.github.com/repos/acme/weather", nil)
+	require.NoError(t, err)
+	repourl.SetGitHubHeaders(req, "application/vnd.github+json", "")
+	assert.Equal(t, "application/vnd.github+json", req.Header.Get("Accept"))
+	assert.Equal(t, repourl.UserAgent, req.Header.Get("User-Agent"))
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	repourl.SetGitHubHeaders(req, "application/vnd.github.raw+json", "secret")
+	assert.Equal(t, "application/vnd.github.raw+json", req.Header.Get("Accept"))
+	assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+}
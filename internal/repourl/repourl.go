@@ -0,0 +1,107 @@
+// Package repourl canonicalizes repository URLs, so entries linking the same repository with differently
+// formatted URLs are recognized as linking the same repository, and parses GitHub repository URLs for the
+// features that call the GitHub API.
+package repourl
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// maxRedirects bounds how many redirects are followed while resolving a repository URL
+const maxRedirects = 5
+
+// scpLikeURL matches SSH remotes written as user@host:owner/repo, the form git clone URLs take
+var scpLikeURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@([A-Za-z0-9.-]+):(.+)$`)
+
+// Normalize returns the canonical form of a repository URL: https, lowercased, without a www. prefix,
+// credentials, default or SSH port, query, fragment, trailing slashes or .git suffix. SSH and git:// clone URLs such as
+// git@github.com:owner/repo.git map to the https URL of the repository. Repository hosts treat paths
+// case-insensitively, so the path is lowercased along with the host, which keeps URLs comparing the way
+// ?repo= lookups always have. Values that are not URLs are only lowercased and trimmed.
+func Normalize(rawURL string) string {
+	trimmed := strings.TrimSpace(rawURL)
+	if match := scpLikeURL.FindStringSubmatch(trimmed); match != nil && !strings.Contains(trimmed, "://") {
+		trimmed = "https://" + match[1] + "/" + strings.TrimPrefix(match[2], "/")
+	}
+
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" {
+		return strings.ToLower(strings.TrimSuffix(strings.TrimRight(trimmed, "/"), ".git"))
+	}
+	switch parsed.Scheme {
+	case "http", "https", "ssh", "git", "git+ssh", "git+https":
+	default:
+		return strings.ToLower(strings.TrimSuffix(strings.TrimRight(trimmed, "/"), ".git"))
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	// Ports of SSH and git:// URLs do not carry over to https; default web ports are dropped
+	isWeb := parsed.Scheme == "http" || parsed.Scheme == "https" || parsed.Scheme == "git+https"
+	if port := parsed.Port(); isWeb && port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+	path := strings.TrimSuffix(strings.TrimRight(parsed.Path, "/"), ".git")
+	path = strings.TrimRight(path, "/")
+	return "https://" + host + strings.ToLower(path)
+}
+
+// Canonicalizer canonicalizes repository URLs, optionally following the redirects repository hosts send
+// for renamed and transferred repositories so old URLs map to the repository's current one
+type Canonicalizer struct {
+	resolveRedirects bool
+	client           *http.Client
+}
+
+// NewCanonicalizer creates a canonicalizer from the registry configuration
+func NewCanonicalizer(cfg *config.Config) *Canonicalizer {
+	return &Canonicalizer{
+		resolveRedirects: cfg.RepositoryURLResolveRedirects,
+		client: &http.Client{
+			Timeout: 5 * time.Second,
+			CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return http.ErrUseLastResponse
+				}
+				return nil
+			},
+		},
+	}
+}
+
+// SetClient sets the HTTP client redirects are resolved with (used for testing)
+func (c *Canonicalizer) SetClient(client *http.Client) {
+	c.client = client
+}
+
+// Canonicalize returns the canonical form of a repository URL. When redirect resolution is enabled and the
+// repository host redirects the URL, the canonical form of where it redirects to is returned. A repository
+// that cannot be reached keeps the canonical form of its own URL, so publishing never depends on its host.
+func (c *Canonicalizer) Canonicalize(ctx context.Context, rawURL string) string {
+	canonical := Normalize(rawURL)
+	if !c.resolveRedirects || !strings.HasPrefix(canonical, "https://") {
+		return canonical
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, canonical, nil)
+	if err != nil {
+		return canonical
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return canonical
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 || resp.Request == nil || resp.Request.URL == nil {
+		return canonical
+	}
+	return Normalize(resp.Request.URL.String())
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/cdn"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// CreateAnnouncement validates and stores an announcement, defaulting its severity to info
+func (s *registryServiceImpl) CreateAnnouncement(ctx context.Context, announcement database.AnnouncementRecord) (*database.AnnouncementRecord, error) {
+	if err := validateAnnouncement(&announcement); err != nil {
+		return nil, err
+	}
+	created, err := s.db.CreateAnnouncement(ctx, nil, announcement)
+	if err != nil {
+		return nil, err
+	}
+	s.purgeAnnouncementCache()
+	return created, nil
+}
+
+// GetAnnouncement retrieves an announcement
+func (s *registryServiceImpl) GetAnnouncement(ctx context.Context, id int64) (*database.AnnouncementRecord, error) {
+	return s.db.GetAnnouncement(ctx, nil, id)
+}
+
+// ListAnnouncements retrieves all announcements, including those not shown yet or any more, oldest first
+func (s *registryServiceImpl) ListAnnouncements(ctx context.Context) ([]*database.AnnouncementRecord, error) {
+	return s.db.ListAnnouncements(ctx, nil)
+}
+
+// ListActiveAnnouncements retrieves the announcements shown now, most severe first and then oldest first
+func (s *registryServiceImpl) ListActiveAnnouncements(ctx context.Context) ([]apiv0.Announcement, error) {
+	announcements, err := s.db.ListAnnouncements(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := []apiv0.Announcement{}
+	for _, announcement := range announcements {
+		if announcement.Active(now) {
+			active = append(active, announcement.Announcement)
+		}
+	}
+	slices.SortStableFunc(active, func(a, b apiv0.Announcement) int {
+		return cmp.Compare(slices.Index(apiv0.AnnouncementSeverities, b.Severity), slices.Index(apiv0.AnnouncementSeverities, a.Severity))
+	})
+	return active, nil
+}
+
+// UpdateAnnouncement validates and stores an announcement's new message, severity and schedule
+func (s *registryServiceImpl) UpdateAnnouncement(ctx context.Context, announcement *database.AnnouncementRecord) error {
+	if err := validateAnnouncement(announcement); err != nil {
+		return err
+	}
+	if err := s.db.UpdateAnnouncement(ctx, nil, announcement); err != nil {
+		return err
+	}
+	s.purgeAnnouncementCache()
+	return nil
+}
+
+// DeleteAnnouncement stops showing an announcement and removes it
+func (s *registryServiceImpl) DeleteAnnouncement(ctx context.Context, id int64) error {
+	if err := s.db.DeleteAnnouncement(ctx, nil, id); err != nil {
+		return err
+	}
+	s.purgeAnnouncementCache()
+	return nil
+}
+
+// validateAnnouncement checks an announcement's message, severity and schedule, defaulting the severity to info
+func validateAnnouncement(announcement *database.AnnouncementRecord) error {
+	announcement.Message = strings.TrimSpace(announcement.Message)
+	if announcement.Message == "" {
+		return fmt.Errorf("%w: message must not be empty", ErrInvalidInput)
+	}
+	if announcement.Severity == "" {
+		announcement.Severity = apiv0.AnnouncementSeverityInfo
+	}
+	if !slices.Contains(apiv0.AnnouncementSeverities, announcement.Severity) {
+		return fmt.Errorf("%w: severity must be one of %s", ErrInvalidInput, strings.Join(apiv0.AnnouncementSeverities, ", "))
+	}
+	if announcement.StartsAt != nil && announcement.EndsAt != nil && !announcement.StartsAt.Before(*announcement.EndsAt) {
+		return fmt.Errorf("%w: endsAt must be after startsAt", ErrInvalidInput)
+	}
+	return nil
+}
+
+// purgeAnnouncementCache queues the invalidation of CDN-cached responses listing announcements after a
+// change. Announcements that start or end on schedule are picked up when cached responses expire.
+func (s *registryServiceImpl) purgeAnnouncementCache() {
+	s.purges.Enqueue(cdn.AnnouncementsKey)
+}
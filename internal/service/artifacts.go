@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/artifacts"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ErrArtifactProxyDisabled is returned by artifact operations when the registry has no artifact storage
+var ErrArtifactProxyDisabled = errors.New("the artifact proxy is not enabled")
+
+// artifactMirrorTimeout bounds how long the artifact mirror worker may spend on a server version
+const artifactMirrorTimeout = 30 * time.Minute
+
+// Mirrors that fail are retried after a delay that doubles with each attempt, and given up once they have failed
+// artifactMirrorMaxAttempts times. Admins can still mirror the version again with MirrorPackageArtifacts.
+const artifactMirrorMaxAttempts = 5
+
+// RunNextArtifactMirror mirrors the package artifacts of the oldest server version queued at publish that is due,
+// returning nil if none is. A failed mirror is retried later unless its artifacts can never be mirrored, such as
+// when they don't match their recorded digests, or it has failed too many times. If ctx is cancelled the mirror
+// is left queued.
+func (s *registryServiceImpl) RunNextArtifactMirror(ctx context.Context) (*database.ArtifactMirror, error) {
+	if s.artifacts == nil {
+		return nil, nil
+	}
+	mirror, err := s.db.NextArtifactMirror(ctx, nil)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mirrorCtx, cancel := context.WithTimeout(ctx, artifactMirrorTimeout)
+	defer cancel()
+	if _, mirrorErr := s.MirrorPackageArtifacts(mirrorCtx, mirror.ServerName, mirror.Version); mirrorErr != nil {
+		if ctx.Err() != nil {
+			return mirror, ctx.Err()
+		}
+		permanent := errors.Is(mirrorErr, database.ErrNotFound) ||
+			errors.Is(mirrorErr, artifacts.ErrDigestMismatch) ||
+			errors.Is(mirrorErr, artifacts.ErrTooLarge)
+		if !permanent && mirror.Attempts+1 < artifactMirrorMaxAttempts {
+			retryAt := time.Now().Add(retryDelay(mirror.Attempts))
+			return s.db.RetryArtifactMirror(ctx, nil, mirror.ID, mirrorErr.Error(), retryAt)
+		}
+		mirror.Error = mirrorErr.Error()
+	}
+
+	if err := s.db.DeleteArtifactMirror(ctx, nil, mirror.ID); err != nil && !errors.Is(err, database.ErrNotFound) {
+		return mirror, err
+	}
+	return mirror, nil
+}
+
+// RunArtifactMirrors mirrors the package artifacts of published server versions one after another, checking for
+// newly queued versions every interval until ctx is cancelled
+func RunArtifactMirrors(ctx context.Context, registry RegistryService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		mirror, err := registry.RunNextArtifactMirror(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("Artifact mirror failed: %v", err)
+		case mirror != nil && mirror.Error != "" && mirror.NextAttemptAt.After(time.Now()):
+			log.Printf("Mirroring artifacts of %s@%s failed attempt %d, retrying at %s: %s", mirror.ServerName, mirror.Version,
+				mirror.Attempts, mirror.NextAttemptAt.Format(time.RFC3339), mirror.Error)
+		case mirror != nil && mirror.Error != "":
+			log.Printf("Gave up mirroring artifacts of %s@%s: %s", mirror.ServerName, mirror.Version, mirror.Error)
+		}
+		if mirror != nil && err == nil {
+			// Look for the next mirror straight away
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MirrorPackageArtifacts mirrors the artifacts of a server version's packages into the registry's artifact
+// storage and returns every artifact mirrored for the version. Packages are checked against the provenance
+// recorded at publish, so artifacts republished upstream since then are refused.
+func (s *registryServiceImpl) MirrorPackageArtifacts(ctx context.Context, serverName, version string) ([]apiv0.PackageArtifact, error) {
+	if s.artifacts == nil {
+		return nil, ErrArtifactProxyDisabled
+	}
+
+	server, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version, true)
+	if err != nil {
+		return nil, err
+	}
+	provenance, err := s.db.GetPackageProvenance(ctx, nil, serverName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, pkg := range server.Server.Packages {
+		var recorded *apiv0.PackageProvenance
+		for i := range provenance {
+			if provenance[i].RegistryType == pkg.RegistryType && provenance[i].Identifier == pkg.Identifier {
+				recorded = &provenance[i]
+				break
+			}
+		}
+
+		mirrored, err := s.artifacts.MirrorPackage(ctx, pkg, recorded)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("package %s: %w", pkg.Identifier, err))
+			continue
+		}
+		if err := s.db.RecordPackageArtifacts(ctx, nil, serverName, version, mirrored); err != nil {
+			return nil, err
+		}
+	}
+
+	artifacts, err := s.db.GetPackageArtifacts(ctx, nil, serverName, version)
+	if err != nil {
+		return nil, err
+	}
+	return artifacts, errors.Join(errs...)
+}
+
+// GetPackageArtifacts returns the artifacts mirrored for a server version's packages
+func (s *registryServiceImpl) GetPackageArtifacts(ctx context.Context, serverName, version string) ([]apiv0.PackageArtifact, error) {
+	return s.db.GetPackageArtifacts(ctx, nil, serverName, version)
+}
+
+// OpenPackageArtifact returns the content of a mirrored artifact by its SHA-256. Only artifacts recorded for
+// a server version are served, so files left in storage by removed versions are not.
+func (s *registryServiceImpl) OpenPackageArtifact(ctx context.Context, sha256 string) (io.ReadCloser, error) {
+	if s.artifacts == nil {
+		return nil, ErrArtifactProxyDisabled
+	}
+
+	recorded, err := s.db.HasPackageArtifact(ctx, nil, sha256)
+	if err != nil {
+		return nil, err
+	}
+	if !recorded {
+		return nil, database.ErrNotFound
+	}
+	return s.artifacts.Open(ctx, sha256)
+}
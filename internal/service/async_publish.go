@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// pendingPublishRetention is how long finished asynchronous publishes can still be looked up
+const pendingPublishRetention = 7 * 24 * time.Hour
+
+// pendingPublishPruneInterval is how often finished asynchronous publishes past retention are deleted
+const pendingPublishPruneInterval = time.Hour
+
+// Publishes whose checks fail for a transient reason are retried after a delay that doubles with each attempt,
+// up to the maximum, and rejected once they have failed pendingPublishMaxAttempts times
+const (
+	pendingPublishMaxAttempts   = 10
+	pendingPublishRetryDelay    = 30 * time.Second
+	pendingPublishMaxRetryDelay = time.Hour
+)
+
+// SubmitPublish accepts a server version for asynchronous publishing. Only the checks that need no upstream
+// requests run here; the version is created, or the publish rejected, by the pending publish worker. The
+// publisher set with WithPublisher and the document set with WithDocument are kept for when the version is
+// created.
+func (s *registryServiceImpl) SubmitPublish(ctx context.Context, req *apiv0.ServerJSON, submittedBy string) (*database.PendingPublish, error) {
+	exists, err := s.db.CheckVersionExists(ctx, nil, req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, database.ErrInvalidVersion
+	}
+
+	return s.db.CreatePendingPublish(ctx, nil, database.PendingPublish{
+		Server:      *req,
+		Document:    documentFromContext(ctx),
+		Publisher:   publisherFromContext(ctx),
+		SubmittedBy: submittedBy,
+	})
+}
+
+// GetPendingPublish returns an asynchronous publish and its outcome
+func (s *registryServiceImpl) GetPendingPublish(ctx context.Context, id int64) (*database.PendingPublish, error) {
+	return s.db.GetPendingPublish(ctx, nil, id)
+}
+
+// RunNextPendingPublish runs the remaining checks of the oldest pending publish and creates its server version,
+// or rejects it if a check fails, returning nil if no publish is due. A check that fails for a transient reason,
+// such as an upstream registry or the database being unavailable, leaves the publish pending to be retried
+// later, until it has failed too many times. The version is created in the same transaction that marks the
+// publish active, so an interrupted run is retried rather than rejected as a duplicate. If ctx is cancelled the
+// publish is left pending.
+func (s *registryServiceImpl) RunNextPendingPublish(ctx context.Context) (*database.PendingPublish, error) {
+	publish, err := s.db.NextPendingPublish(ctx, nil)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	publishCtx := WithPublisher(ctx, publish.Publisher)
+	if publish.Document != nil {
+		publishCtx = WithDocument(publishCtx, publish.Document)
+	}
+	finished, publishErr := s.completePublish(publishCtx, publish)
+	if publishErr != nil {
+		if ctx.Err() != nil {
+			return publish, ctx.Err()
+		}
+		if isTransient(publishErr) && publish.Attempts+1 < pendingPublishMaxAttempts {
+			retryAt := time.Now().Add(retryDelay(publish.Attempts))
+			return s.db.RetryPendingPublish(ctx, nil, publish.ID, publishErr.Error(), retryAt)
+		}
+		finished, err = s.db.FinishPendingPublish(ctx, nil, publish.ID, database.PublishRejected, publishErr.Error())
+		if err != nil {
+			return publish, err
+		}
+	}
+
+	attempt := database.PublishAttempt{
+		Identity:   publish.SubmittedBy,
+		ServerName: publish.ServerName,
+		Version:    publish.Version,
+		Status:     http.StatusOK,
+	}
+	switch {
+	case errors.Is(publishErr, ErrForbiddenNamespace):
+		attempt.Status, attempt.Error = http.StatusForbidden, publishErr.Error()
+	case publishErr != nil:
+		attempt.Status, attempt.Error = http.StatusBadRequest, publishErr.Error()
+	default:
+		namespace, _, _ := strings.Cut(publish.ServerName, "/")
+		if err := s.RecordAuditEntry(ctx, database.AuditEntry{
+			Actor:     publish.SubmittedBy,
+			Action:    database.AuditActionPublish,
+			Namespace: namespace,
+			Resource:  publish.ServerName + "@" + publish.Version,
+			Details:   map[string]any{"publishId": publish.ID},
+		}); err != nil {
+			log.Printf("Failed to record %s audit entry for %s@%s: %v", database.AuditActionPublish, publish.ServerName, publish.Version, err)
+		}
+	}
+	var quarantined *database.QuarantinedPublish
+	if publishErr != nil {
+		quarantined = &database.QuarantinedPublish{Server: publish.Server}
+	}
+	if err := s.RecordPublishAttempt(ctx, attempt, quarantined); err != nil {
+		log.Printf("Failed to record publish attempt for %s: %v", publish.ServerName, err)
+	}
+
+	return finished, nil
+}
+
+// isTransient reports whether a publish check failed for a reason that may go away on its own: the database,
+// an upstream registry or GitHub being unreachable, too slow, rate limiting or failing. Any other failure is
+// the published server's, and rejects the publish.
+func isTransient(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, registries.ErrRateLimited) ||
+		errors.Is(err, validators.ErrUpstreamUnavailable) ||
+		database.IsTransient(err)
+}
+
+// retryDelay returns how long to wait before retrying a publish or artifact mirror that has already been retried
+// attempts times
+func retryDelay(attempts int) time.Duration {
+	delay := pendingPublishRetryDelay
+	for range attempts {
+		delay *= 2
+		if delay >= pendingPublishMaxRetryDelay {
+			return pendingPublishMaxRetryDelay
+		}
+	}
+	return delay
+}
+
+// completePublish runs the slower publish checks and creates the server version of a pending publish
+func (s *registryServiceImpl) completePublish(ctx context.Context, publish *database.PendingPublish) (*database.PendingPublish, error) {
+	if err := s.repoVerifier.Verify(ctx, &publish.Server); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrForbiddenNamespace, err)
+	}
+
+	finished, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.PendingPublish, error) {
+		if _, err := s.createServerInTransaction(ctx, tx, &publish.Server); err != nil {
+			return nil, err
+		}
+		return s.db.FinishPendingPublish(ctx, tx, publish.ID, database.PublishActive, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.purgeServerCache(publish.ServerName)
+	return finished, nil
+}
+
+// PrunePendingPublishes deletes asynchronous publishes that finished longer ago than the retention period and
+// returns how many were deleted
+func (s *registryServiceImpl) PrunePendingPublishes(ctx context.Context) (int64, error) {
+	return s.db.DeletePendingPublishesBefore(ctx, nil, time.Now().Add(-pendingPublishRetention))
+}
+
+// RunPendingPublishes completes pending publishes one after another, checking for new ones every interval until
+// ctx is cancelled, and deletes finished publishes once they are past retention
+func RunPendingPublishes(ctx context.Context, registry RegistryService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var lastPruned time.Time
+
+	for {
+		publish, err := registry.RunNextPendingPublish(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("Pending publish failed: %v", err)
+		case publish != nil && publish.State == database.PublishRejected:
+			log.Printf("Publish %d of %s@%s rejected: %s", publish.ID, publish.ServerName, publish.Version, publish.Error)
+		case publish != nil && publish.State == database.PublishPending:
+			log.Printf("Publish %d of %s@%s failed attempt %d, retrying at %s: %s", publish.ID, publish.ServerName, publish.Version,
+				publish.Attempts, publish.NextAttemptAt.Format(time.RFC3339), publish.Error)
+		}
+		if publish != nil && err == nil {
+			// Look for the next publish straight away
+			continue
+		}
+
+		if time.Since(lastPruned) >= pendingPublishPruneInterval {
+			if deleted, err := registry.PrunePendingPublishes(ctx); err != nil {
+				log.Printf("Pending publish pruning failed: %v", err)
+			} else if deleted > 0 {
+				log.Printf("Deleted %d finished publishes older than %s", deleted, pendingPublishRetention)
+			}
+			lastPruned = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
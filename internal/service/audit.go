@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// auditClientIPColumn names the audit log's client address column, which is encrypted at rest when
+// encryption is configured
+const auditClientIPColumn = "audit_log.client_ip"
+
+// RecordAuditEntry appends an entry to the audit log
+func (s *registryServiceImpl) RecordAuditEntry(ctx context.Context, entry database.AuditEntry) error {
+	clientIP, err := s.encryptor.Encrypt(ctx, auditClientIPColumn, entry.ClientIP)
+	if err != nil {
+		return err
+	}
+	entry.ClientIP = clientIP
+
+	_, err = s.db.RecordAuditEntry(ctx, nil, entry)
+	return err
+}
+
+// ListAuditEntries retrieves up to limit audit entries matching a filter with an ID greater than afterID, oldest first
+func (s *registryServiceImpl) ListAuditEntries(ctx context.Context, filter *database.AuditFilter, afterID int64, limit int) ([]*database.AuditEntry, error) {
+	entries, err := s.db.ListAuditEntries(ctx, nil, filter, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.ClientIP, err = s.encryptor.Decrypt(ctx, auditClientIPColumn, entry.ClientIP); err != nil {
+			return nil, fmt.Errorf("audit entry %d: %w", entry.ID, err)
+		}
+	}
+	return entries, nil
+}
+
+// PruneAuditLog deletes audit entries older than maxAge and returns how many were deleted
+func (s *registryServiceImpl) PruneAuditLog(ctx context.Context, maxAge time.Duration) (int64, error) {
+	if maxAge <= 0 {
+		return 0, nil
+	}
+	return s.db.DeleteAuditEntriesBefore(ctx, nil, time.Now().Add(-maxAge))
+}
+
+// RunAuditRetention deletes audit entries older than maxAge every interval until ctx is cancelled
+func RunAuditRetention(ctx context.Context, registry RegistryService, maxAge, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := registry.PruneAuditLog(ctx, maxAge)
+		if err != nil {
+			log.Printf("Audit log pruning failed: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Audit log pruning deleted %d entries", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
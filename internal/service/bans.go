@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// quarantineMessage is the status message of servers quarantined because their publisher was banned
+const quarantineMessage = "Quarantined: the publisher of this server was banned from the registry"
+
+// BanIdentity bans an identity, replacing any existing ban of it, and quarantines the servers it published, recording
+// the status of each version so lifting the ban restores it. It returns the ban and the names of the quarantined
+// servers.
+func (s *registryServiceImpl) BanIdentity(ctx context.Context, ban database.IdentityBan) (*database.IdentityBan, []string, error) {
+	ban.Value = strings.TrimSpace(ban.Value)
+	if ban.Value == "" {
+		return nil, nil, fmt.Errorf("%w: the banned identity must not be empty", ErrInvalidInput)
+	}
+	if ban.ExpiresAt != nil && !ban.ExpiresAt.After(time.Now()) {
+		return nil, nil, fmt.Errorf("%w: expiresAt must be in the future", ErrInvalidInput)
+	}
+
+	stored, err := s.db.PutIdentityBan(ctx, nil, ban)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serverNames, err := s.bannedServers(ctx, stored)
+	if err != nil {
+		return stored, nil, fmt.Errorf("identity banned, but failed to find its servers: %w", err)
+	}
+	message := quarantineMessage
+	quarantined := make([]string, 0, len(serverNames))
+	for _, serverName := range serverNames {
+		err := s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+				return err
+			}
+			_, err := s.db.QuarantineServer(ctx, tx, serverName, stored.Kind, stored.Value, &message)
+			return err
+		})
+		if err != nil {
+			return stored, quarantined, fmt.Errorf("identity banned, but failed to quarantine %s: %w", serverName, err)
+		}
+		s.purgeServerCache(serverName)
+		quarantined = append(quarantined, serverName)
+	}
+	return stored, quarantined, nil
+}
+
+// releaseQuarantine restores the versions a ban quarantined that no other ban holds, and returns the sorted names
+// of the servers with restored versions. Restoring fails with ErrRemoteURLInUse when another server took a
+// remote URL of a restored version in the meantime.
+func (s *registryServiceImpl) releaseQuarantine(ctx context.Context, tx pgx.Tx, kind, value string) ([]string, error) {
+	records, err := s.db.ListQuarantinedVersions(ctx, tx, kind, value)
+	if err != nil {
+		return nil, err
+	}
+	// Records are ordered by server name, so concurrent releases take the publish locks in the same order
+	for i, record := range records {
+		if i == 0 || records[i-1].ServerName != record.ServerName {
+			if err := s.db.AcquirePublishLock(ctx, tx, record.ServerName); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	restored, err := s.db.ReleaseQuarantine(ctx, tx, kind, value)
+	if err != nil {
+		return nil, err
+	}
+	var serverNames []string
+	for _, version := range restored {
+		if err := s.validateNoDuplicateRemoteURLs(ctx, tx, version.Server); err != nil {
+			return nil, fmt.Errorf("failed to restore %s %s: %w", version.Server.Name, version.Server.Version, err)
+		}
+		if !slices.Contains(serverNames, version.Server.Name) {
+			serverNames = append(serverNames, version.Server.Name)
+		}
+	}
+	slices.Sort(serverNames)
+	return serverNames, nil
+}
+
+// bannedServers returns the sorted names of the servers with versions that are not deleted in the namespace a ban
+// covers, and of the servers the banned identity published according to the publish history. Servers other bans
+// already quarantined are included, so each ban holding them is recorded.
+func (s *registryServiceImpl) bannedServers(ctx context.Context, ban *database.IdentityBan) ([]string, error) {
+	var namespace, identity string
+	switch ban.Kind {
+	case database.BanKindGitHub:
+		namespace, identity = "io.github."+ban.Value, string(auth.MethodGitHubAT)+":"+ban.Value
+	case database.BanKindOIDC:
+		identity = string(auth.MethodOIDC) + ":" + ban.Value
+	case database.BanKindNamespace:
+		namespace = ban.Value
+	}
+
+	seen := map[string]bool{}
+	var names []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	includeDeleted := true
+	if namespace != "" {
+		filter := &database.ServerFilter{Namespace: &namespace, IncludeDeleted: &includeDeleted}
+		cursor := ""
+		for {
+			servers, nextCursor, err := s.db.ListServers(ctx, nil, filter, cursor, 1000)
+			if err != nil {
+				return nil, err
+			}
+			for _, server := range servers {
+				add(server.Server.Name)
+			}
+			if nextCursor == "" {
+				break
+			}
+			cursor = nextCursor
+		}
+	}
+
+	if identity != "" {
+		var beforeID int64
+		for {
+			attempts, err := s.db.ListPublishAttempts(ctx, nil, identity, beforeID, 100)
+			if err != nil {
+				return nil, err
+			}
+			for _, attempt := range attempts {
+				if attempt.Status >= 200 && attempt.Status < 300 {
+					add(attempt.ServerName)
+				}
+			}
+			if len(attempts) < 100 {
+				break
+			}
+			beforeID = attempts[len(attempts)-1].ID
+		}
+	}
+
+	// Servers that were published but no longer exist, or have only deleted versions, are left alone
+	var existing []string
+	for _, name := range names {
+		versions, _, err := s.db.ListServers(ctx, nil, &database.ServerFilter{Name: &name, IncludeDeleted: &includeDeleted}, "", 1000)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(versions, func(version *apiv0.ServerResponse) bool {
+			return version.Meta.Official == nil || version.Meta.Official.Status != model.StatusDeleted
+		}) {
+			existing = append(existing, name)
+		}
+	}
+	slices.Sort(existing)
+	return existing, nil
+}
+
+// ListIdentityBans retrieves all identity bans, including expired ones, ordered by kind and value
+func (s *registryServiceImpl) ListIdentityBans(ctx context.Context) ([]*database.IdentityBan, error) {
+	return s.db.ListIdentityBans(ctx, nil)
+}
+
+// UnbanIdentity lifts the ban of an identity and restores the versions it quarantined to their prior status, unless
+// another ban still holds them or their status was changed since. It returns the names of the restored servers.
+func (s *registryServiceImpl) UnbanIdentity(ctx context.Context, kind, value string) ([]string, error) {
+	restored, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) ([]string, error) {
+		if err := s.db.DeleteIdentityBan(ctx, tx, kind, value); err != nil {
+			return nil, err
+		}
+		return s.releaseQuarantine(ctx, tx, kind, value)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, serverName := range restored {
+		s.purgeServerCache(serverName)
+	}
+	return restored, nil
+}
+
+// ReleaseExpiredBans restores the servers quarantined by bans that have expired, and returns how many servers had
+// versions restored. Expired bans are kept, so they stay listed for admins.
+func (s *registryServiceImpl) ReleaseExpiredBans(ctx context.Context) (int, error) {
+	bans, err := s.db.ListIdentityBans(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	now := time.Now()
+	for _, ban := range bans {
+		if ban.Active(now) {
+			continue
+		}
+		restored, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) ([]string, error) {
+			return s.releaseQuarantine(ctx, tx, ban.Kind, ban.Value)
+		})
+		if err != nil {
+			return released, fmt.Errorf("failed to release the servers of the expired ban of %s:%s: %w", ban.Kind, ban.Value, err)
+		}
+		for _, serverName := range restored {
+			s.purgeServerCache(serverName)
+		}
+		released += len(restored)
+	}
+	return released, nil
+}
+
+// RunBanExpiry restores the servers of expired bans every interval until ctx is cancelled
+func RunBanExpiry(ctx context.Context, registry RegistryService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		released, err := registry.ReleaseExpiredBans(ctx)
+		if err != nil {
+			log.Printf("Ban expiry failed: %v", err)
+		} else if released > 0 {
+			log.Printf("Ban expiry restored %d quarantined servers", released)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckIdentityBan returns an error wrapping auth.ErrIdentityBanned, with the ban's reason, when claims belong to
+// an identity under an active ban. Admin tokens are never banned.
+func (s *registryServiceImpl) CheckIdentityBan(ctx context.Context, claims *auth.JWTClaims) error {
+	if hasGlobalPermission(claims) {
+		return nil
+	}
+
+	bans, err := s.db.FindIdentityBans(ctx, nil, banQuery(claims))
+	if err != nil {
+		return fmt.Errorf("failed to check identity bans: %w", err)
+	}
+	now := time.Now()
+	for _, ban := range bans {
+		if !ban.Active(now) || !banCovers(ban, claims) {
+			continue
+		}
+		if ban.ExpiresAt != nil {
+			return fmt.Errorf("%w until %s: %s", auth.ErrIdentityBanned, ban.ExpiresAt.UTC().Format(time.RFC3339), ban.Reason)
+		}
+		return fmt.Errorf("%w: %s", auth.ErrIdentityBanned, ban.Reason)
+	}
+	return nil
+}
+
+// banQuery selects every ban that may cover the identity claims were issued to: bans of the GitHub account or OIDC
+// subject the token was issued to, of the namespaces its permissions reach and of the GitHub accounts owning those
+// under io.github. banCovers makes the final decision.
+func banQuery(claims *auth.JWTClaims) database.IdentityBanQuery {
+	var query database.IdentityBanQuery
+	switch claims.AuthMethod {
+	case auth.MethodGitHubAT:
+		query.GitHub = append(query.GitHub, strings.ToLower(claims.AuthMethodSubject))
+	case auth.MethodGitHubOIDC:
+		repository, _ := strings.CutPrefix(claims.AuthMethodSubject, "repo:")
+		if owner, _, found := strings.Cut(repository, "/"); found {
+			query.GitHub = append(query.GitHub, strings.ToLower(owner))
+		}
+	case auth.MethodOIDC:
+		query.OIDC = append(query.OIDC, claims.AuthMethodSubject)
+	}
+
+	for _, perm := range claims.Permissions {
+		pattern := strings.ToLower(perm.ResourcePattern)
+		if pattern == "*" {
+			continue
+		}
+		// permitsNamespace matches the namespaces the pattern continues with "/" or "." after
+		for i, c := range pattern {
+			if c != '/' && c != '.' {
+				continue
+			}
+			namespace := pattern[:i]
+			query.Namespaces = append(query.Namespaces, namespace)
+			if login, found := strings.CutPrefix(namespace, "io.github."); found {
+				query.GitHub = append(query.GitHub, login)
+			}
+		}
+		if prefix, wildcard := strings.CutSuffix(pattern, "*"); wildcard {
+			query.Prefixes = append(query.Prefixes, prefix)
+		}
+	}
+	return query
+}
+
+// banCovers reports whether a ban applies to the identity claims were issued to. GitHub bans cover the account's
+// OAuth logins, GitHub Actions jobs in its repositories and every token for its io.github namespace, ignoring case
+// as GitHub does; namespace bans cover every token for the namespace and the namespaces beneath it.
+func banCovers(ban *database.IdentityBan, claims *auth.JWTClaims) bool {
+	switch ban.Kind {
+	case database.BanKindGitHub:
+		switch claims.AuthMethod {
+		case auth.MethodGitHubAT:
+			if strings.EqualFold(claims.AuthMethodSubject, ban.Value) {
+				return true
+			}
+		case auth.MethodGitHubOIDC:
+			// Subjects have the form repo:<owner>/<repository>:<context>
+			repository, _ := strings.CutPrefix(claims.AuthMethodSubject, "repo:")
+			if owner, _, found := strings.Cut(repository, "/"); found && strings.EqualFold(owner, ban.Value) {
+				return true
+			}
+		}
+		return permitsNamespace(claims, "io.github."+ban.Value)
+	case database.BanKindOIDC:
+		return claims.AuthMethod == auth.MethodOIDC && claims.AuthMethodSubject == ban.Value
+	case database.BanKindNamespace:
+		return permitsNamespace(claims, ban.Value)
+	}
+	return false
+}
+
+// permitsNamespace reports whether claims grant any permission on a server in namespace or beneath it
+func permitsNamespace(claims *auth.JWTClaims, namespace string) bool {
+	namespace = strings.ToLower(namespace)
+	for _, perm := range claims.Permissions {
+		pattern := strings.ToLower(perm.ResourcePattern)
+		if pattern == "*" {
+			continue
+		}
+		prefix, wildcard := strings.CutSuffix(pattern, "*")
+		if strings.HasPrefix(pattern, namespace+"/") || strings.HasPrefix(pattern, namespace+".") ||
+			(wildcard && strings.HasPrefix(namespace+"/", prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGlobalPermission reports whether claims grant permissions on every server, as admin tokens do
+func hasGlobalPermission(claims *auth.JWTClaims) bool {
+	for _, perm := range claims.Permissions {
+		if perm.ResourcePattern == "*" {
+			return true
+		}
+	}
+	return false
+}
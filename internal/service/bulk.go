@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// bulkJobPageSize is the number of servers read per query when selecting a bulk job's servers
+const bulkJobPageSize = 1000
+
+// bulkJobProgressInterval is how many servers are processed between progress updates
+const bulkJobProgressInterval = 10
+
+// maxBulkJobFailures is the number of failures kept on a bulk job; later ones are only counted
+const maxBulkJobFailures = 100
+
+// ErrInvalidBulkJob is returned when a bulk job is queued with missing or inconsistent parameters
+var ErrInvalidBulkJob = errors.New("invalid bulk job")
+
+// namespacePattern matches the namespace part of a server name, mirroring the servers table constraint
+var namespacePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9.-]*[a-zA-Z0-9]$`)
+
+// EnqueueBulkJob validates a bulk job's parameters and queues it for the bulk job worker
+func (s *registryServiceImpl) EnqueueBulkJob(ctx context.Context, kind string, params database.BulkJobParams, createdBy string) (*database.BulkJob, error) {
+	if err := validateBulkJobParams(kind, params); err != nil {
+		return nil, err
+	}
+
+	return s.db.CreateBulkJob(ctx, nil, database.BulkJob{Kind: kind, Params: params, CreatedBy: createdBy})
+}
+
+// validateBulkJobParams checks that a job has the parameters its kind requires and no others
+func validateBulkJobParams(kind string, params database.BulkJobParams) error {
+	if params.Namespace != "" && !namespacePattern.MatchString(params.Namespace) {
+		return fmt.Errorf("%w: invalid namespace %q", ErrInvalidBulkJob, params.Namespace)
+	}
+
+	switch kind {
+	case database.BulkJobStatus:
+		switch {
+		case params.Namespace == "":
+			return fmt.Errorf("%w: namespace is required", ErrInvalidBulkJob)
+		case !slices.Contains([]model.Status{model.StatusActive, model.StatusDeprecated, model.StatusDeleted}, model.Status(params.Status)):
+			return fmt.Errorf("%w: status must be one of active, deprecated, deleted", ErrInvalidBulkJob)
+		case model.Status(params.Status) == model.StatusActive && params.StatusMessage != nil:
+			return fmt.Errorf("%w: statusMessage cannot be provided when setting status to active", ErrInvalidBulkJob)
+		case params.NewNamespace != "":
+			return fmt.Errorf("%w: newNamespace is only allowed for transfer jobs", ErrInvalidBulkJob)
+		}
+	case database.BulkJobRevalidate:
+		if params.Status != "" || params.StatusMessage != nil || params.NewNamespace != "" {
+			return fmt.Errorf("%w: revalidate jobs only accept a namespace", ErrInvalidBulkJob)
+		}
+	case database.BulkJobTransfer:
+		switch {
+		case params.Namespace == "" || params.NewNamespace == "":
+			return fmt.Errorf("%w: namespace and newNamespace are required", ErrInvalidBulkJob)
+		case !namespacePattern.MatchString(params.NewNamespace):
+			return fmt.Errorf("%w: invalid newNamespace %q", ErrInvalidBulkJob, params.NewNamespace)
+		case params.NewNamespace == params.Namespace:
+			return fmt.Errorf("%w: newNamespace must differ from namespace", ErrInvalidBulkJob)
+		case params.Status != "" || params.StatusMessage != nil:
+			return fmt.Errorf("%w: status is only allowed for status jobs", ErrInvalidBulkJob)
+		}
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidBulkJob, kind)
+	}
+	return nil
+}
+
+// GetBulkJob returns a bulk job and its progress
+func (s *registryServiceImpl) GetBulkJob(ctx context.Context, id int64) (*database.BulkJob, error) {
+	return s.db.GetBulkJob(ctx, nil, id)
+}
+
+// ListBulkJobs returns the most recently queued bulk jobs, newest first
+func (s *registryServiceImpl) ListBulkJobs(ctx context.Context, limit int) ([]*database.BulkJob, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	return s.db.ListBulkJobs(ctx, nil, limit)
+}
+
+// RunNextBulkJob claims the oldest unfinished bulk job and runs it to completion, returning nil if none is queued.
+// Servers are processed one at a time, each in its own transaction, so a failure only affects that server and
+// is recorded on the job. If ctx is cancelled the job is left running and restarts when next claimed.
+func (s *registryServiceImpl) RunNextBulkJob(ctx context.Context) (*database.BulkJob, error) {
+	job, err := s.db.ClaimBulkJob(ctx, nil)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	servers, err := s.bulkJobServers(ctx, job)
+	if err != nil {
+		return job, s.finishBulkJob(ctx, job, err)
+	}
+	job.Total = len(servers)
+	if err := s.db.UpdateBulkJob(ctx, nil, job); err != nil {
+		return job, err
+	}
+
+	for _, server := range servers {
+		if ctx.Err() != nil {
+			return job, ctx.Err()
+		}
+
+		if err := s.runBulkJobItem(ctx, job, server); err != nil {
+			job.Failed++
+			if len(job.Failures) < maxBulkJobFailures {
+				failure := database.BulkJobFailure{ServerName: server.Server.Name, Error: err.Error()}
+				if job.Kind == database.BulkJobRevalidate {
+					failure.Version = server.Server.Version
+				}
+				job.Failures = append(job.Failures, failure)
+			}
+		}
+		job.Processed++
+
+		if job.Processed%bulkJobProgressInterval == 0 {
+			if err := s.db.UpdateBulkJob(ctx, nil, job); err != nil {
+				return job, err
+			}
+		}
+	}
+
+	return job, s.finishBulkJob(ctx, job, nil)
+}
+
+// finishBulkJob records that a job completed, or failed as a whole with cause
+func (s *registryServiceImpl) finishBulkJob(ctx context.Context, job *database.BulkJob, cause error) error {
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	job.State = database.BulkJobSucceeded
+	if cause != nil {
+		job.State = database.BulkJobFailed
+		job.Error = cause.Error()
+	}
+	return s.db.UpdateBulkJob(ctx, nil, job)
+}
+
+// bulkJobServers returns the latest version of every server a job applies to, ordered by name. Status and
+// transfer jobs include deleted servers; revalidation only checks servers that are still listed.
+func (s *registryServiceImpl) bulkJobServers(ctx context.Context, job *database.BulkJob) ([]*apiv0.ServerResponse, error) {
+	isLatest := true
+	includeDeleted := job.Kind != database.BulkJobRevalidate
+	filter := &database.ServerFilter{IsLatest: &isLatest, IncludeDeleted: &includeDeleted}
+	if job.Params.Namespace != "" {
+		filter.Namespace = &job.Params.Namespace
+	}
+
+	var servers []*apiv0.ServerResponse
+	cursor := ""
+	for {
+		page, nextCursor, err := s.db.ListServers(ctx, nil, filter, cursor, bulkJobPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+		servers = append(servers, page...)
+		if nextCursor == "" {
+			return servers, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// runBulkJobItem applies a job to one server, given its latest version
+func (s *registryServiceImpl) runBulkJobItem(ctx context.Context, job *database.BulkJob, server *apiv0.ServerResponse) error {
+	switch job.Kind {
+	case database.BulkJobStatus:
+		_, err := s.UpdateAllVersionsStatus(ctx, server.Server.Name, &StatusChangeRequest{
+			NewStatus:     model.Status(job.Params.Status),
+			StatusMessage: job.Params.StatusMessage,
+		})
+		if errors.Is(err, database.ErrNotFound) {
+			// Every version already has the requested status and message
+			return nil
+		}
+		return err
+	case database.BulkJobRevalidate:
+		return s.revalidateServer(ctx, server.Server)
+	case database.BulkJobTransfer:
+		return s.transferServer(ctx, server.Server.Name, job.Params.NewNamespace+strings.TrimPrefix(server.Server.Name, job.Params.Namespace))
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidBulkJob, job.Kind)
+	}
+}
+
+// revalidateServer runs the publish-time checks against a stored server version without changing it
+func (s *registryServiceImpl) revalidateServer(ctx context.Context, serverJSON apiv0.ServerJSON) error {
+	start := time.Now()
+	err := validator.ValidateServerJSON(&serverJSON, validator.ValidationSchemaVersionAndSemantic).FirstError()
+	telemetry.RecordValidatorStage(ctx, telemetry.ValidatorStageSchema, "", start, err)
+	if err != nil {
+		return err
+	}
+
+	// Validation pins OCI digests in place, so it works on a copy of the packages
+	serverJSON.Packages = slices.Clone(serverJSON.Packages)
+	_, err = validators.ValidateUpdateRequest(registries.WithMetadataCache(ctx, s.metadataCache), &serverJSON, s.cfg, false)
+	return err
+}
+
+// transferServer copies every version of a server to a new name, keeping its metadata, provenance and mirrored
+// artifacts, then deletes the original versions with a message pointing at the new name. Versions that already
+// exist under the new name are skipped, so transferring a server again is harmless.
+func (s *registryServiceImpl) transferServer(ctx context.Context, oldName, newName string) error {
+	err := s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.transferServerInTransaction(ctx, tx, oldName, newName)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.purgeServerCache(oldName)
+	s.purgeServerCache(newName)
+	return nil
+}
+
+// transferServerInTransaction contains the transferServer logic within a transaction
+func (s *registryServiceImpl) transferServerInTransaction(ctx context.Context, tx pgx.Tx, oldName, newName string) error {
+	if err := s.acquirePublishLocks(ctx, tx, oldName, newName); err != nil {
+		return err
+	}
+
+	versions, err := s.db.GetAllVersionsByServerName(ctx, tx, oldName, true)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		exists, err := s.db.CheckVersionExists(ctx, tx, newName, version.Server.Version)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		serverJSON := version.Server
+		serverJSON.Name = newName
+		officialMeta := *version.Meta.Official
+		officialMeta.UpdatedAt = time.Now()
+		if _, err := s.db.CreateServer(ctx, tx, &serverJSON, &officialMeta); err != nil {
+			return fmt.Errorf("failed to copy version %s: %w", version.Server.Version, err)
+		}
+
+		provenance, err := s.db.GetPackageProvenance(ctx, tx, oldName, version.Server.Version)
+		if err != nil {
+			return err
+		}
+		if err := s.db.RecordPackageProvenance(ctx, tx, newName, version.Server.Version, provenance); err != nil {
+			return err
+		}
+
+		mirrored, err := s.db.GetPackageArtifacts(ctx, tx, oldName, version.Server.Version)
+		if err != nil {
+			return err
+		}
+		if err := s.db.RecordPackageArtifacts(ctx, tx, newName, version.Server.Version, mirrored); err != nil {
+			return err
+		}
+	}
+
+	// Deleting the originals also releases their remote URLs for future publishes under the new name
+	message := "Moved to " + newName
+	_, err = s.db.SetAllVersionsStatus(ctx, tx, oldName, model.StatusDeleted, &message)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// acquirePublishLocks takes the publish locks of several servers in name order, so transactions locking the
+// same servers in a different order can't deadlock
+func (s *registryServiceImpl) acquirePublishLocks(ctx context.Context, tx pgx.Tx, serverNames ...string) error {
+	serverNames = slices.Clone(serverNames)
+	slices.Sort(serverNames)
+	for _, serverName := range slices.Compact(serverNames) {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunBulkJobs runs queued bulk jobs one after another, checking for new jobs every interval until ctx is cancelled
+func RunBulkJobs(ctx context.Context, registry RegistryService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job, err := registry.RunNextBulkJob(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("Bulk job failed: %v", err)
+		case job != nil:
+			log.Printf("Bulk job %d (%s) finished: %s, %d of %d servers processed, %d failed", job.ID, job.Kind, job.State, job.Processed, job.Total, job.Failed)
+		}
+		if job != nil && err == nil {
+			// Look for the next job straight away
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
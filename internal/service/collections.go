@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// ErrInvalidCollection is returned when a collection has an invalid title or description, or lists a server
+// twice or one that does not exist
+var ErrInvalidCollection = errors.New("invalid collection")
+
+// Collection limits, keeping collections small enough to read and to serve in one response
+const (
+	maxCollectionTitleLength       = 100
+	maxCollectionDescriptionLength = 1000
+	maxCollectionNoteLength        = 500
+	maxCollectionItems             = 200
+)
+
+// CreateCollection validates and stores a curated collection of servers
+func (s *registryServiceImpl) CreateCollection(ctx context.Context, collection database.Collection) (*database.Collection, error) {
+	if err := s.validateCollection(ctx, &collection); err != nil {
+		return nil, err
+	}
+	return s.db.CreateCollection(ctx, nil, collection)
+}
+
+// GetCollection retrieves a collection with its items in order
+func (s *registryServiceImpl) GetCollection(ctx context.Context, id int64) (*database.Collection, error) {
+	return s.db.GetCollection(ctx, nil, id)
+}
+
+// ListCollections retrieves up to limit collections after afterID, oldest first, only those of owner if it is not empty
+func (s *registryServiceImpl) ListCollections(ctx context.Context, owner string, afterID int64, limit int) ([]*database.Collection, error) {
+	return s.db.ListCollections(ctx, nil, owner, afterID, limit)
+}
+
+// UpdateCollection validates and stores a collection's new title, description and items. The owner is kept.
+func (s *registryServiceImpl) UpdateCollection(ctx context.Context, collection *database.Collection) error {
+	if err := s.validateCollection(ctx, collection); err != nil {
+		return err
+	}
+	return s.db.UpdateCollection(ctx, nil, collection)
+}
+
+// DeleteCollection removes a collection
+func (s *registryServiceImpl) DeleteCollection(ctx context.Context, id int64) error {
+	return s.db.DeleteCollection(ctx, nil, id)
+}
+
+// validateCollection checks a collection's title, description and items, trimming the title. Every item must
+// name a different server that is published and not deleted.
+func (s *registryServiceImpl) validateCollection(ctx context.Context, collection *database.Collection) error {
+	collection.Title = strings.TrimSpace(collection.Title)
+	switch {
+	case collection.Title == "":
+		return fmt.Errorf("%w: title is required", ErrInvalidCollection)
+	case utf8.RuneCountInString(collection.Title) > maxCollectionTitleLength:
+		return fmt.Errorf("%w: title must be at most %d characters", ErrInvalidCollection, maxCollectionTitleLength)
+	case utf8.RuneCountInString(collection.Description) > maxCollectionDescriptionLength:
+		return fmt.Errorf("%w: description must be at most %d characters", ErrInvalidCollection, maxCollectionDescriptionLength)
+	case len(collection.Items) > maxCollectionItems:
+		return fmt.Errorf("%w: a collection holds at most %d servers", ErrInvalidCollection, maxCollectionItems)
+	}
+
+	seen := map[string]bool{}
+	for _, item := range collection.Items {
+		switch {
+		case seen[item.ServerName]:
+			return fmt.Errorf("%w: server %s is listed twice", ErrInvalidCollection, item.ServerName)
+		case utf8.RuneCountInString(item.Note) > maxCollectionNoteLength:
+			return fmt.Errorf("%w: the note for %s must be at most %d characters", ErrInvalidCollection, item.ServerName, maxCollectionNoteLength)
+		}
+		seen[item.ServerName] = true
+
+		if _, err := s.db.GetServerByName(ctx, nil, item.ServerName, false); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("%w: server %s not found", ErrInvalidCollection, item.ServerName)
+			}
+			return err
+		}
+	}
+	return nil
+}
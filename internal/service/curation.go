@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ErrInvalidCuration is returned when a server curation has unknown or contradictory labels
+var ErrInvalidCuration = errors.New("invalid server curation")
+
+// curationLabels are the labels operators can give servers
+var curationLabels = []string{database.CurationLabelFeatured, database.CurationLabelOfficial, database.CurationLabelCommunity}
+
+// PutServerCuration labels an existing server, replacing its previous curation. Labels are deduplicated
+// and sorted.
+func (s *registryServiceImpl) PutServerCuration(ctx context.Context, serverName string, labels []string, position *int, note, updatedBy string) (*database.ServerCuration, error) {
+	labels = slices.Compact(slices.Sorted(slices.Values(labels)))
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("%w: at least one label is required", ErrInvalidCuration)
+	}
+	for _, label := range labels {
+		if !slices.Contains(curationLabels, label) {
+			return nil, fmt.Errorf("%w: unknown label %q, expected one of %v", ErrInvalidCuration, label, curationLabels)
+		}
+	}
+	if slices.Contains(labels, database.CurationLabelOfficial) && slices.Contains(labels, database.CurationLabelCommunity) {
+		return nil, fmt.Errorf("%w: a server cannot be both official and community-maintained", ErrInvalidCuration)
+	}
+
+	// Curating a name nobody published would let admins reserve storefront slots by accident
+	if _, err := s.db.GetServerByName(ctx, nil, serverName, true); err != nil {
+		return nil, err
+	}
+
+	curation, err := s.db.PutServerCuration(ctx, nil, database.ServerCuration{
+		ServerName: serverName,
+		Labels:     labels,
+		Position:   position,
+		Note:       note,
+		UpdatedBy:  updatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.purgeServerCache(serverName)
+	return curation, nil
+}
+
+// ListServerCurations retrieves curated servers with label, or all curated servers if label is empty
+func (s *registryServiceImpl) ListServerCurations(ctx context.Context, label string) ([]*database.ServerCuration, error) {
+	return s.db.ListServerCurations(ctx, nil, label)
+}
+
+// DeleteServerCuration removes a server's curation
+func (s *registryServiceImpl) DeleteServerCuration(ctx context.Context, serverName string) error {
+	if err := s.db.DeleteServerCuration(ctx, nil, serverName); err != nil {
+		return err
+	}
+
+	s.purgeServerCache(serverName)
+	return nil
+}
+
+// attachCuration adds the curation of each curated server to its versions
+func (s *registryServiceImpl) attachCuration(ctx context.Context, servers ...*apiv0.ServerResponse) error {
+	var names []string
+	for _, server := range servers {
+		if !slices.Contains(names, server.Server.Name) {
+			names = append(names, server.Server.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	curations, err := s.db.GetServerCurations(ctx, nil, names)
+	if err != nil {
+		return err
+	}
+
+	for _, server := range servers {
+		curation, exists := curations[server.Server.Name]
+		if !exists || server.Meta.Official == nil {
+			continue
+		}
+		server.Meta.Official.Curation = &apiv0.Curation{Labels: slices.Clone(curation.Labels), Position: curation.Position}
+	}
+	return nil
+}
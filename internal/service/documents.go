@@ -0,0 +1,224 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// documentPageSize is the number of server versions read per query when backfilling or verifying documents
+const documentPageSize = 500
+
+// Problems document verification finds with a server version
+const (
+	// DocumentProblemMissing is a version with no stored document, published before the document store existed
+	DocumentProblemMissing = "missing"
+	// DocumentProblemNotFound is a version pointing at a digest with no stored document
+	DocumentProblemNotFound = "not-found"
+	// DocumentProblemCorrupt is a stored document whose bytes no longer hash to its digest
+	DocumentProblemCorrupt = "corrupt"
+	// DocumentProblemMismatch is a version whose server.json in the servers table holds different values than its
+	// stored document
+	DocumentProblemMismatch = "mismatch"
+)
+
+// DocumentIssue is a server version whose stored document failed verification
+type DocumentIssue struct {
+	ServerName string
+	Version    string
+	Digest     string
+	Problem    string
+}
+
+// DocumentVerification is the outcome of checking every server version against the document store
+type DocumentVerification struct {
+	// Checked is the number of server versions checked
+	Checked int
+	Issues  []DocumentIssue
+}
+
+type documentKey struct{}
+
+// WithDocument returns a context that makes CreateServer and SubmitPublish store document, the server.json as
+// it was submitted, as the version's document. Versions created without one, such as imported versions, get
+// the canonical encoding of their server.json.
+func WithDocument(ctx context.Context, document []byte) context.Context {
+	return context.WithValue(ctx, documentKey{}, document)
+}
+
+// documentFromContext returns the document set with WithDocument, or nil
+func documentFromContext(ctx context.Context) []byte {
+	document, _ := ctx.Value(documentKey{}).([]byte)
+	return document
+}
+
+// attachDocumentDigests adds the digest of the document each version points at in the document store
+func (s *registryServiceImpl) attachDocumentDigests(ctx context.Context, servers ...*apiv0.ServerResponse) error {
+	var names []string
+	for _, server := range servers {
+		if !slices.Contains(names, server.Server.Name) {
+			names = append(names, server.Server.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	digests, err := s.db.ListServerDocumentDigests(ctx, nil, names)
+	if err != nil {
+		return err
+	}
+
+	for _, server := range servers {
+		if server.Meta.Official == nil {
+			continue
+		}
+		server.Meta.Official.DocumentDigest = digests[server.Server.Name][server.Server.Version]
+	}
+	return nil
+}
+
+// GetServerDocument retrieves a stored server.json document by its digest
+func (s *registryServiceImpl) GetServerDocument(ctx context.Context, digest string) ([]byte, error) {
+	return s.db.GetServerDocument(ctx, nil, digest)
+}
+
+// GetPublishedDocument returns the server.json document a server version was published with, as the document
+// store holds it. Versions published before the document store existed get the canonical encoding of their
+// server.json until their documents are backfilled.
+func (s *registryServiceImpl) GetPublishedDocument(ctx context.Context, server *apiv0.ServerResponse) ([]byte, error) {
+	if server.Meta.Official == nil || server.Meta.Official.DocumentDigest == "" {
+		document, _, err := apiv0.ServerDocument(&server.Server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode server document: %w", err)
+		}
+		return document, nil
+	}
+	return s.db.GetServerDocument(ctx, nil, server.Meta.Official.DocumentDigest)
+}
+
+// BackfillServerDocuments stores the documents of server versions published before the document store existed
+// and returns how many it stored. It is safe to run again after an interruption.
+func (s *registryServiceImpl) BackfillServerDocuments(ctx context.Context) (int, error) {
+	stored := 0
+	cursor := ""
+	for {
+		refs, nextCursor, err := s.db.ListServerDocumentRefs(ctx, nil, cursor, documentPageSize)
+		if err != nil {
+			return stored, err
+		}
+
+		for _, ref := range refs {
+			if ref.Digest != "" {
+				continue
+			}
+			server, err := s.db.GetServerByNameAndVersion(ctx, nil, ref.ServerName, ref.Version, true)
+			if err != nil {
+				return stored, fmt.Errorf("%s version %s: %w", ref.ServerName, ref.Version, err)
+			}
+			document, _, err := apiv0.ServerDocument(&server.Server)
+			if err != nil {
+				return stored, fmt.Errorf("%s version %s: failed to encode server document: %w", ref.ServerName, ref.Version, err)
+			}
+			if _, err := s.db.PutServerDocument(ctx, nil, ref.ServerName, ref.Version, document); err != nil {
+				return stored, fmt.Errorf("%s version %s: %w", ref.ServerName, ref.Version, err)
+			}
+			stored++
+		}
+
+		if nextCursor == "" {
+			return stored, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// VerifyServerDocuments checks that every server version, including deleted ones, points at a stored document
+// whose bytes hash to its digest and match the version's server.json, detecting rows changed outside the registry
+func (s *registryServiceImpl) VerifyServerDocuments(ctx context.Context) (*DocumentVerification, error) {
+	verification := &DocumentVerification{Issues: []DocumentIssue{}}
+	cursor := ""
+	for {
+		refs, nextCursor, err := s.db.ListServerDocumentRefs(ctx, nil, cursor, documentPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ref := range refs {
+			problem, err := s.verifyServerDocument(ctx, ref)
+			if err != nil {
+				return nil, fmt.Errorf("%s version %s: %w", ref.ServerName, ref.Version, err)
+			}
+			verification.Checked++
+			if problem != "" {
+				verification.Issues = append(verification.Issues, DocumentIssue{
+					ServerName: ref.ServerName,
+					Version:    ref.Version,
+					Digest:     ref.Digest,
+					Problem:    problem,
+				})
+			}
+		}
+
+		if nextCursor == "" {
+			return verification, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// verifyServerDocument returns the problem with a server version's document, or an empty string if it has none
+func (s *registryServiceImpl) verifyServerDocument(ctx context.Context, ref *database.ServerDocumentRef) (string, error) {
+	if ref.Digest == "" {
+		return DocumentProblemMissing, nil
+	}
+
+	document, err := s.db.GetServerDocument(ctx, nil, ref.Digest)
+	if errors.Is(err, database.ErrNotFound) {
+		return DocumentProblemNotFound, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if apiv0.DocumentDigest(document) != ref.Digest {
+		return DocumentProblemCorrupt, nil
+	}
+
+	server, err := s.db.GetServerByNameAndVersion(ctx, nil, ref.ServerName, ref.Version, true)
+	if err != nil {
+		return "", err
+	}
+	matches, err := documentMatches(document, &server.Server)
+	if err != nil {
+		return "", err
+	}
+	if !matches {
+		return DocumentProblemMismatch, nil
+	}
+	return "", nil
+}
+
+// documentMatches reports whether a stored document holds server. Documents are stored as they were submitted,
+// so they are upgraded to the current schema and decoded the way publishes are before comparing.
+func documentMatches(document []byte, server *apiv0.ServerJSON) (bool, error) {
+	var stored apiv0.ServerJSON
+	if json.Unmarshal(validator.UpgradeServerJSON(document).Document, &stored) != nil {
+		// A document that no longer decodes does not match
+		return false, nil
+	}
+	want, err := apiv0.CanonicalJSON(server)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode server.json: %w", err)
+	}
+	got, err := apiv0.CanonicalJSON(&stored)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode stored document: %w", err)
+	}
+	return bytes.Equal(got, want), nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/registry/internal/encryption"
+)
+
+// encryptionRotationPageSize is the number of rows read per query when re-encrypting a column
+const encryptionRotationPageSize = 500
+
+// RotateEncryptedColumns re-encrypts every value of the encrypted columns (audit log client addresses and
+// webhook signing secrets) that is not yet encrypted under the key provider's current key, including values
+// written before encryption was enabled, and returns how many were re-encrypted. It is safe to run again
+// after an interruption.
+func (s *registryServiceImpl) RotateEncryptedColumns(ctx context.Context) (int, error) {
+	if s.encryptor == nil {
+		return 0, encryption.ErrNotConfigured
+	}
+
+	rotated, err := s.rotateAuditClientIPs(ctx)
+	if err != nil {
+		return rotated, err
+	}
+	secrets, err := s.rotateWebhookSecrets(ctx)
+	return rotated + secrets, err
+}
+
+// rotateAuditClientIPs re-encrypts the audit log's client addresses that need it
+func (s *registryServiceImpl) rotateAuditClientIPs(ctx context.Context) (int, error) {
+	rotated := 0
+	var afterID int64
+	for {
+		// Read the stored values, not the decrypted ones ListAuditEntries returns
+		entries, err := s.db.ListAuditEntries(ctx, nil, nil, afterID, encryptionRotationPageSize)
+		if err != nil {
+			return rotated, err
+		}
+
+		for _, entry := range entries {
+			afterID = entry.ID
+			if !s.encryptor.NeedsRotation(entry.ClientIP) {
+				continue
+			}
+			clientIP, err := s.encryptor.Decrypt(ctx, auditClientIPColumn, entry.ClientIP)
+			if err != nil {
+				return rotated, fmt.Errorf("audit entry %d: %w", entry.ID, err)
+			}
+			encrypted, err := s.encryptor.Encrypt(ctx, auditClientIPColumn, clientIP)
+			if err != nil {
+				return rotated, err
+			}
+			if err := s.db.UpdateAuditEntryClientIP(ctx, nil, entry.ID, encrypted); err != nil {
+				return rotated, fmt.Errorf("audit entry %d: %w", entry.ID, err)
+			}
+			rotated++
+		}
+
+		if len(entries) < encryptionRotationPageSize {
+			return rotated, nil
+		}
+	}
+}
+
+// rotateWebhookSecrets re-encrypts the webhook subscriptions' signing secrets that need it. There are few
+// subscriptions, so they are read at once.
+func (s *registryServiceImpl) rotateWebhookSecrets(ctx context.Context) (int, error) {
+	subscriptions, err := s.db.ListWebhookSubscriptions(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	rotated := 0
+	for _, subscription := range subscriptions {
+		if !s.encryptor.NeedsRotation(subscription.Secret) {
+			continue
+		}
+		secret, err := s.encryptor.Decrypt(ctx, webhookSecretColumn, subscription.Secret)
+		if err != nil {
+			return rotated, fmt.Errorf("webhook subscription %d: %w", subscription.ID, err)
+		}
+		encrypted, err := s.encryptor.Encrypt(ctx, webhookSecretColumn, secret)
+		if err != nil {
+			return rotated, err
+		}
+		if err := s.db.UpdateWebhookSubscriptionSecret(ctx, nil, subscription.ID, encrypted); err != nil {
+			return rotated, fmt.Errorf("webhook subscription %d: %w", subscription.ID, err)
+		}
+		rotated++
+	}
+	return rotated, nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// Domain errors returned by RegistryService. Errors from the service wrap one of these (or one of the
+// Err* values of the operation), so embedders can handle them with errors.Is without depending on the
+// database package. The storage errors are the database package's own values, so errors from either
+// layer match.
+var (
+	// ErrNotFound means the server, version or other record the operation needs does not exist
+	ErrNotFound = database.ErrNotFound
+	// ErrAlreadyExists means the operation would create a record that already exists
+	ErrAlreadyExists = database.ErrAlreadyExists
+	// ErrInvalidInput means the request was rejected by a storage constraint
+	ErrInvalidInput = database.ErrInvalidInput
+	// ErrDuplicateVersion means the version being published already exists
+	ErrDuplicateVersion = database.ErrInvalidVersion
+	// ErrMaxVersionsReached means the server has as many versions as the registry allows
+	ErrMaxVersionsReached = database.ErrMaxServersReached
+	// ErrForbiddenNamespace means the server's namespace may not be published to: it is blocked, or the
+	// linked repository does not belong to the namespace owner
+	ErrForbiddenNamespace = errors.New("namespace not permitted")
+)
+
+// checkNamespaceAllowed returns ErrForbiddenNamespace when the server's namespace, or a namespace it is a
+// subdomain of, is blocked. Tokens are not issued for blocked namespaces, but tokens issued before a block
+// would otherwise keep working until they expire.
+func checkNamespaceAllowed(serverName string) error {
+	namespace, _, _ := strings.Cut(serverName, "/")
+	for _, blocked := range auth.BlockedNamespaces {
+		if namespace == blocked || strings.HasPrefix(namespace, blocked+".") {
+			return fmt.Errorf("%w: %s is blocked", ErrForbiddenNamespace, namespace)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// eventPollInterval is how often changes are polled while no database connection is listening for events
+	eventPollInterval = 5 * time.Second
+	// eventBatchSize is how many changes are read from the changes feed at a time
+	eventBatchSize = 500
+	// subscriberBuffer is how many changes a subscriber may fall behind before it is dropped
+	subscriberBuffer = 256
+	// Delay before listening for events again after the connection is lost, doubling up to the maximum
+	eventReconnectDelay    = time.Second
+	eventReconnectMaxDelay = 30 * time.Second
+)
+
+// eventHub fans changes committed by any replica out to this replica's subscribers. It learns of changes
+// from database events and reads them from the changes feed, polling the feed instead while events are
+// unavailable. It runs only while there are subscribers.
+type eventHub struct {
+	db           database.Database
+	pollInterval time.Duration
+
+	mu                 sync.Mutex
+	changeSubs         map[chan *apiv0.ServerChange]struct{}
+	maintenanceSubs    map[int]func()
+	nextMaintenanceSub int
+	stop               context.CancelFunc
+
+	wake      chan struct{}
+	listening atomic.Bool
+}
+
+func newEventHub(db database.Database) *eventHub {
+	return &eventHub{
+		db:              db,
+		pollInterval:    eventPollInterval,
+		changeSubs:      map[chan *apiv0.ServerChange]struct{}{},
+		maintenanceSubs: map[int]func(){},
+		wake:            make(chan struct{}, 1),
+	}
+}
+
+// subscribeChanges returns a channel receiving changes recorded from now on. The channel is closed if the
+// subscriber falls too far behind.
+func (h *eventHub) subscribeChanges(ctx context.Context) (chan *apiv0.ServerChange, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.startLocked(ctx); err != nil {
+		return nil, err
+	}
+	ch := make(chan *apiv0.ServerChange, subscriberBuffer)
+	h.changeSubs[ch] = struct{}{}
+	return ch, nil
+}
+
+// unsubscribeChanges removes a change subscriber, closing its channel if it is still open
+func (h *eventHub) unsubscribeChanges(ch chan *apiv0.ServerChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.changeSubs[ch]; ok {
+		delete(h.changeSubs, ch)
+		close(ch)
+	}
+	h.stopIfIdleLocked()
+}
+
+// subscribeMaintenance calls fn whenever the maintenance mode state changes, until ctx is done
+func (h *eventHub) subscribeMaintenance(ctx context.Context, fn func()) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.startLocked(ctx); err != nil {
+		return err
+	}
+	id := h.nextMaintenanceSub
+	h.nextMaintenanceSub++
+	h.maintenanceSubs[id] = fn
+
+	go func() {
+		<-ctx.Done()
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.maintenanceSubs, id)
+		h.stopIfIdleLocked()
+	}()
+	return nil
+}
+
+// startLocked starts the hub if it is not running, reading changes recorded after the latest one
+func (h *eventHub) startLocked(ctx context.Context) error {
+	if h.stop != nil {
+		return nil
+	}
+
+	lastSeq, err := h.db.LatestChangeSeq(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest change: %w", err)
+	}
+	runCtx, stop := context.WithCancel(context.Background())
+	h.stop = stop
+	go h.listen(runCtx)
+	go h.run(runCtx, lastSeq)
+	return nil
+}
+
+// stopIfIdleLocked stops the hub once it has no subscribers
+func (h *eventHub) stopIfIdleLocked() {
+	if h.stop != nil && len(h.changeSubs) == 0 && len(h.maintenanceSubs) == 0 {
+		h.stop()
+		h.stop = nil
+	}
+}
+
+// listen listens for database events until ctx is done, reconnecting with backoff when the connection is lost
+func (h *eventHub) listen(ctx context.Context) {
+	delay := eventReconnectDelay
+	for {
+		err := h.db.ListenForEvents(ctx, func() {
+			h.listening.Store(true)
+			delay = eventReconnectDelay
+			// Catch up on changes recorded while nothing was listening
+			h.poke()
+			h.notifyMaintenance()
+		}, h.handle)
+		h.listening.Store(false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("Not receiving database events, polling for changes until reconnected: %v", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, eventReconnectMaxDelay)
+	}
+}
+
+// handle reacts to a database event
+func (h *eventHub) handle(event database.Event) {
+	switch event.Type {
+	case database.EventServerChange:
+		h.poke()
+	case database.EventMaintenance:
+		h.notifyMaintenance()
+	}
+}
+
+// notifyMaintenance tells maintenance subscribers the state may have changed
+func (h *eventHub) notifyMaintenance() {
+	h.mu.Lock()
+	subs := make([]func(), 0, len(h.maintenanceSubs))
+	for _, fn := range h.maintenanceSubs {
+		subs = append(subs, fn)
+	}
+	h.mu.Unlock()
+	for _, fn := range subs {
+		fn()
+	}
+}
+
+// poke asks the hub to read new changes, without blocking
+func (h *eventHub) poke() {
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run reads new changes when woken by an event, or every poll interval while not listening for events
+func (h *eventHub) run(ctx context.Context, lastSeq int64) {
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.wake:
+		case <-ticker.C:
+			if h.listening.Load() {
+				continue
+			}
+		}
+
+		for {
+			changes, err := h.db.ListServerChanges(ctx, nil, lastSeq, eventBatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Failed to read server changes: %v", err)
+				}
+				break
+			}
+			for _, change := range changes {
+				h.broadcast(change)
+				lastSeq = change.Seq
+			}
+			if len(changes) < eventBatchSize {
+				break
+			}
+		}
+	}
+}
+
+// broadcast sends a change to every subscriber, dropping subscribers whose buffer is full
+func (h *eventHub) broadcast(change *apiv0.ServerChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.changeSubs {
+		select {
+		case ch <- change:
+		default:
+			delete(h.changeSubs, ch)
+			close(ch)
+		}
+	}
+	h.stopIfIdleLocked()
+}
+
+// SubscribeChanges streams changes recorded after since, followed by changes as any replica records them,
+// until ctx is done. The channel is closed when ctx is done, or early if the subscriber falls too far
+// behind or the changes feed cannot be read, in which case it should resubscribe from the last change it
+// received.
+func (s *registryServiceImpl) SubscribeChanges(ctx context.Context, since int64) (<-chan *apiv0.ServerChange, error) {
+	// Subscribe before catching up, so no change falls between the two
+	live, err := s.events.subscribeChanges(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.db.ListServerChanges(ctx, nil, since, eventBatchSize)
+	if err != nil {
+		s.events.unsubscribeChanges(live)
+		return nil, err
+	}
+
+	out := make(chan *apiv0.ServerChange)
+	go func() {
+		defer close(out)
+		defer s.events.unsubscribeChanges(live)
+
+		// Changes can be both caught up on and received live, so only send those after the last one sent
+		last := since
+		send := func(change *apiv0.ServerChange) bool {
+			if change.Seq <= last {
+				return true
+			}
+			select {
+			case out <- change:
+				last = change.Seq
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			for _, change := range page {
+				if !send(change) {
+					return
+				}
+			}
+			if len(page) < eventBatchSize {
+				break
+			}
+			if page, err = s.db.ListServerChanges(ctx, nil, last, eventBatchSize); err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Failed to read server changes: %v", err)
+				}
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case change, ok := <-live:
+				if !ok || !send(change) {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// SubscribeMaintenance calls fn whenever any replica changes the maintenance mode state, and whenever changes
+// may have been missed, until ctx is done
+func (s *registryServiceImpl) SubscribeMaintenance(ctx context.Context, fn func()) error {
+	return s.events.subscribeMaintenance(ctx, fn)
+}
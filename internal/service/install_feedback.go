@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ReportInstall records an MCP client's report of whether installing and running a server version worked.
+// The client is identified by a keyed hash of its network and the server name, so each client counts once per
+// version, its address is never stored, and its reports cannot be linked across servers.
+func (s *registryServiceImpl) ReportInstall(ctx context.Context, report database.InstallReport, clientAddress string) error {
+	if report.Stage == "" {
+		report.Stage = database.InstallStageInstall
+	}
+	report.Reporter = s.installReporter(clientAddress, report.ServerName)
+	report.ReportedAt = time.Now()
+	return s.db.PutInstallReport(ctx, nil, report)
+}
+
+// installReporter hashes a client's network for a server's install reports. The hash is keyed with
+// MCP_REGISTRY_INSTALL_FEEDBACK_KEY, which every replica shares and which never leaves the registry, so it
+// cannot be reversed by trying every network.
+func (s *registryServiceImpl) installReporter(clientAddress, serverName string) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.InstallFeedbackKey))
+	mac.Write([]byte(serverName + "\x00" + reporterNetwork(clientAddress)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// reporterNetwork returns the /24 of an IPv4 address or the /64 of an IPv6 one. A single client can easily
+// use every address in those, so reporters are counted by network to keep one client from reporting many
+// times.
+func reporterNetwork(clientAddress string) string {
+	addr, err := netip.ParseAddr(clientAddress)
+	if err != nil {
+		return clientAddress
+	}
+	addr = addr.Unmap()
+	bits := 64
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return clientAddress
+	}
+	return prefix.String()
+}
+
+// PruneInstallReports deletes install reports older than the feedback window and returns how many were deleted
+func (s *registryServiceImpl) PruneInstallReports(ctx context.Context) (int64, error) {
+	if s.cfg.InstallFeedbackWindow <= 0 {
+		return 0, nil
+	}
+	return s.db.DeleteInstallReportsBefore(ctx, nil, time.Now().Add(-s.cfg.InstallFeedbackWindow))
+}
+
+// attachInstallFeedback adds the share of reporters each version worked for, for versions with enough reporters
+// within the feedback window
+func (s *registryServiceImpl) attachInstallFeedback(ctx context.Context, servers ...*apiv0.ServerResponse) error {
+	if !s.cfg.InstallFeedbackEnabled {
+		return nil
+	}
+
+	var names []string
+	for _, server := range servers {
+		if !slices.Contains(names, server.Server.Name) {
+			names = append(names, server.Server.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	// Without a window, reports count until their version is deleted
+	var since time.Time
+	if s.cfg.InstallFeedbackWindow > 0 {
+		since = time.Now().Add(-s.cfg.InstallFeedbackWindow)
+	}
+	counts, err := s.db.CountInstallReports(ctx, nil, names, since)
+	if err != nil {
+		return fmt.Errorf("failed to count install reports: %w", err)
+	}
+
+	for _, server := range servers {
+		count := counts[server.Server.Name][server.Server.Version]
+		if count.Reports < s.cfg.InstallFeedbackMinReports {
+			continue
+		}
+		server.Meta.InstallFeedback = &apiv0.InstallFeedback{
+			Reports:     count.Reports,
+			SuccessRate: count.Successes * 100 / count.Reports,
+		}
+	}
+	return nil
+}
+
+// RunInstallReportRetention deletes install reports older than the feedback window every interval until ctx is
+// cancelled
+func RunInstallReportRetention(ctx context.Context, registry RegistryService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := registry.PruneInstallReports(ctx)
+		if err != nil {
+			log.Printf("Install report pruning failed: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Install report pruning deleted %d reports", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// Metrics whose time series can be read
+var Metrics = []string{database.MetricPublishes, database.MetricNewServers, database.MetricFailedPublishes}
+
+// maxTimeSeriesPoints bounds how many points a time series has, so small steps cannot ask for huge windows
+const maxTimeSeriesPoints = 1000
+
+// ErrInvalidTimeSeries is returned for a time series of an unknown metric, or with a window or step that cannot
+// be served
+var ErrInvalidTimeSeries = errors.New("invalid time series")
+
+// MetricPoint is a metric's count over one step of a time series
+type MetricPoint struct {
+	Time  time.Time `json:"time" format:"date-time" doc:"Start of the step"`
+	Count int64     `json:"count" doc:"Count over the step"`
+}
+
+// MetricTimeSeries is a metric's counts over a window, one point per step
+type MetricTimeSeries struct {
+	Metric string        `json:"metric" doc:"Metric counted" example:"publishes"`
+	From   time.Time     `json:"from" format:"date-time" doc:"Start of the first step"`
+	To     time.Time     `json:"to" format:"date-time" doc:"End of the last step, which is still in progress"`
+	Total  int64         `json:"total" doc:"Count over the whole window"`
+	Points []MetricPoint `json:"points" doc:"Counts per step, oldest first. Steps nothing was counted in have a count of 0."`
+}
+
+// GetMetricTimeSeries returns a metric's counts over the window ending with the step in progress, one point per
+// step. Steps are aligned in UTC: daily steps start at midnight UTC and weekly steps on Mondays.
+func (s *registryServiceImpl) GetMetricTimeSeries(ctx context.Context, metric string, window, step time.Duration) (*MetricTimeSeries, error) {
+	if !slices.Contains(Metrics, metric) {
+		return nil, fmt.Errorf("%w: unknown metric %q, expected one of %v", ErrInvalidTimeSeries, metric, Metrics)
+	}
+	if step < database.MetricBucket || step%database.MetricBucket != 0 {
+		return nil, fmt.Errorf("%w: step must be a whole number of hours", ErrInvalidTimeSeries)
+	}
+	if window < step || window%step != 0 {
+		return nil, fmt.Errorf("%w: window must be a whole number of steps", ErrInvalidTimeSeries)
+	}
+	steps := int(window / step)
+	if steps > maxTimeSeriesPoints {
+		return nil, fmt.Errorf("%w: window has %d steps, more than %d", ErrInvalidTimeSeries, steps, maxTimeSeriesPoints)
+	}
+
+	// Truncating a UTC time aligns it to multiples of step since the zero time, which is also a UTC midnight
+	to := time.Now().UTC().Truncate(step).Add(step)
+	from := to.Add(-window)
+	counts, err := s.db.ListMetricCounts(ctx, nil, metric, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	series := &MetricTimeSeries{Metric: metric, From: from, To: to, Points: make([]MetricPoint, steps)}
+	for i := range series.Points {
+		series.Points[i].Time = from.Add(time.Duration(i) * step)
+	}
+	for _, count := range counts {
+		series.Points[int(count.Bucket.Sub(from)/step)].Count += count.Count
+		series.Total += count.Count
+	}
+	return series, nil
+}
+
+// countMetric adds to a metric's counter for the current hour. Counting is best effort: a failure is logged
+// rather than failing the operation being counted.
+func (s *registryServiceImpl) countMetric(ctx context.Context, metric string, delta int64) {
+	if err := s.db.IncrementMetric(context.WithoutCancel(ctx), nil, metric, time.Now(), delta); err != nil {
+		log.Printf("Failed to count %s: %v", metric, err)
+	}
+}
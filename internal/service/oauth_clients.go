@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+const (
+	// maxOAuthClientRedirectURIs is how many redirect URIs a client can register
+	maxOAuthClientRedirectURIs = 10
+	// maxOAuthClientNameLength is the longest client name a client can register, in characters
+	maxOAuthClientNameLength = 200
+)
+
+// RegisterOAuthClient validates the redirect URIs of an OAuth client registering itself and stores it under a
+// new client ID
+func (s *registryServiceImpl) RegisterOAuthClient(ctx context.Context, name string, redirectURIs []string) (*database.OAuthClient, error) {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > maxOAuthClientNameLength {
+		return nil, fmt.Errorf("%w: client_name must be at most %d characters", ErrInvalidInput, maxOAuthClientNameLength)
+	}
+	if len(redirectURIs) == 0 {
+		return nil, fmt.Errorf("%w: redirect_uris must not be empty", ErrInvalidInput)
+	}
+	if len(redirectURIs) > maxOAuthClientRedirectURIs {
+		return nil, fmt.Errorf("%w: at most %d redirect_uris can be registered", ErrInvalidInput, maxOAuthClientRedirectURIs)
+	}
+	for _, redirectURI := range redirectURIs {
+		if err := ValidateOAuthRedirectURI(redirectURI); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.db.CreateOAuthClient(ctx, nil, database.OAuthClient{
+		ClientID:     rand.Text(),
+		ClientName:   name,
+		RedirectURIs: redirectURIs,
+	})
+}
+
+// GetOAuthClient retrieves a registered OAuth client
+func (s *registryServiceImpl) GetOAuthClient(ctx context.Context, clientID string) (*database.OAuthClient, error) {
+	return s.db.GetOAuthClient(ctx, nil, clientID)
+}
+
+// ListOAuthClients retrieves all registered OAuth clients, oldest first
+func (s *registryServiceImpl) ListOAuthClients(ctx context.Context) ([]*database.OAuthClient, error) {
+	return s.db.ListOAuthClients(ctx, nil)
+}
+
+// DeleteOAuthClient removes a registered OAuth client, which can no longer log anyone in
+func (s *registryServiceImpl) DeleteOAuthClient(ctx context.Context, clientID string) error {
+	return s.db.DeleteOAuthClient(ctx, nil, clientID)
+}
+
+// ClaimAuthorizationCode records the redemption of an OAuth authorization code until it expires, failing with
+// ErrAlreadyExists when it was redeemed before. Codes are always remembered in the database, whichever token
+// replay store is configured, since a code must only be redeemed once.
+func (s *registryServiceImpl) ClaimAuthorizationCode(ctx context.Context, codeID string, expiresAt time.Time) error {
+	return s.db.RecordTokenUse(ctx, nil, "authorization-code#"+codeID, expiresAt)
+}
+
+// ValidateOAuthRedirectURI checks that authorization codes can safely be sent to a redirect URI, following the
+// OAuth guidance for native apps (RFC 8252): an https URL, an http URL on the loopback interface, or a
+// private-use scheme in reverse domain notation such as com.example.app:/callback. Fragments are not allowed.
+func ValidateOAuthRedirectURI(redirectURI string) error {
+	parsed, err := url.Parse(redirectURI)
+	if err != nil || !parsed.IsAbs() {
+		return fmt.Errorf("%w: redirect URI %q is not an absolute URI", ErrInvalidInput, redirectURI)
+	}
+	if parsed.Fragment != "" || strings.Contains(redirectURI, "#") {
+		return fmt.Errorf("%w: redirect URI %q must not have a fragment", ErrInvalidInput, redirectURI)
+	}
+
+	switch parsed.Scheme {
+	case "https":
+		if parsed.Host == "" {
+			return fmt.Errorf("%w: redirect URI %q has no host", ErrInvalidInput, redirectURI)
+		}
+	case "http":
+		if !isLoopbackHost(parsed.Hostname()) {
+			return fmt.Errorf("%w: redirect URI %q must use https unless it is on the loopback interface", ErrInvalidInput, redirectURI)
+		}
+	default:
+		if !strings.Contains(parsed.Scheme, ".") {
+			return fmt.Errorf("%w: redirect URI %q must use https, http on the loopback interface, or a private-use scheme in reverse domain notation", ErrInvalidInput, redirectURI)
+		}
+	}
+	return nil
+}
+
+// isLoopbackHost reports whether a host name is localhost or a loopback IP address
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
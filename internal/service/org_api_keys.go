@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// OrgAPIKeyPrefix starts every organization API key, so leaked keys are easy to recognize and scan for
+const OrgAPIKeyPrefix = "mcpr_"
+
+// orgAPIKeyDisplayLength is how many characters of a key are kept to tell keys apart in listings
+const orgAPIKeyDisplayLength = len(OrgAPIKeyPrefix) + 8
+
+// maxOrgAPIKeyNameLength mirrors the org_api_keys name column
+const maxOrgAPIKeyNameLength = 100
+
+// ErrInvalidOrgAPIKey is returned when an organization API key is created with a missing name or with
+// namespaces outside the organization
+var ErrInvalidOrgAPIKey = errors.New("invalid organization API key")
+
+// ErrInvalidOrgMember is returned when an organization member is added with a subject that does not identify a
+// person
+var ErrInvalidOrgMember = errors.New("invalid organization member")
+
+// orgMemberMethods are the authentication methods that identify a person, and so may identify members
+var orgMemberMethods = []auth.Method{auth.MethodGitHubAT, auth.MethodOIDC}
+
+// CreateOrgAPIKey creates an API key for an organization, scoped to namespaces the organization owns.
+// The key itself is only returned here; the registry keeps a hash of it.
+func (s *registryServiceImpl) CreateOrgAPIKey(ctx context.Context, organization, name string, namespaces []string, createdBy string) (*database.OrgAPIKey, string, error) {
+	if err := validateOrgAPIKey(organization, name, namespaces); err != nil {
+		return nil, "", err
+	}
+
+	secret, keyHash, err := newOrgAPIKeySecret()
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := s.db.CreateOrgAPIKey(ctx, nil, database.OrgAPIKey{
+		Organization: organization,
+		Name:         name,
+		Namespaces:   namespaces,
+		KeyHash:      keyHash,
+		KeyPrefix:    secret[:orgAPIKeyDisplayLength],
+		CreatedBy:    createdBy,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+// ListOrgAPIKeys retrieves an organization's API keys, oldest first
+func (s *registryServiceImpl) ListOrgAPIKeys(ctx context.Context, organization string) ([]*database.OrgAPIKey, error) {
+	return s.db.ListOrgAPIKeys(ctx, nil, organization)
+}
+
+// RotateOrgAPIKey replaces an organization API key with a new one, which is returned. The old key stops
+// working immediately.
+func (s *registryServiceImpl) RotateOrgAPIKey(ctx context.Context, organization string, id int64) (*database.OrgAPIKey, string, error) {
+	var rotated *database.OrgAPIKey
+	var secret string
+	err := s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if _, err := s.getOrgAPIKey(ctx, tx, organization, id); err != nil {
+			return err
+		}
+
+		var keyHash string
+		var err error
+		secret, keyHash, err = newOrgAPIKeySecret()
+		if err != nil {
+			return err
+		}
+		rotated, err = s.db.RotateOrgAPIKey(ctx, tx, id, keyHash, secret[:orgAPIKeyDisplayLength])
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return rotated, secret, nil
+}
+
+// RevokeOrgAPIKey permanently deletes an organization API key
+func (s *registryServiceImpl) RevokeOrgAPIKey(ctx context.Context, organization string, id int64) (*database.OrgAPIKey, error) {
+	var revoked *database.OrgAPIKey
+	err := s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		if revoked, err = s.getOrgAPIKey(ctx, tx, organization, id); err != nil {
+			return err
+		}
+		return s.db.DeleteOrgAPIKey(ctx, tx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revoked, nil
+}
+
+// AuthenticateOrgAPIKey looks up the organization API key matching secret and records that it was used.
+// Unknown keys return database.ErrNotFound.
+func (s *registryServiceImpl) AuthenticateOrgAPIKey(ctx context.Context, secret string) (*database.OrgAPIKey, error) {
+	if !strings.HasPrefix(secret, OrgAPIKeyPrefix) {
+		return nil, database.ErrNotFound
+	}
+
+	key, err := s.db.GetOrgAPIKeyByHash(ctx, nil, hashOrgAPIKey(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	// Failing to record the last use should not stop automation from publishing
+	usedAt := time.Now()
+	if err := s.db.TouchOrgAPIKey(ctx, nil, key.ID, usedAt); err != nil {
+		log.Printf("failed to record use of organization API key %d: %v", key.ID, err)
+	} else {
+		key.LastUsedAt = &usedAt
+	}
+	return key, nil
+}
+
+// AddOrgMember lets the person a subject such as "github-at:octocat" identifies manage an organization's API
+// keys
+func (s *registryServiceImpl) AddOrgMember(ctx context.Context, organization, subject, addedBy string) (*database.OrgMember, error) {
+	if !namespacePattern.MatchString(organization) {
+		return nil, fmt.Errorf("%w: organization %q is not a valid namespace", ErrInvalidOrgMember, organization)
+	}
+	method, name, _ := strings.Cut(subject, ":")
+	if name == "" || !slices.Contains(orgMemberMethods, auth.Method(method)) {
+		return nil, fmt.Errorf("%w: subject must be github-at:<login> or oidc:<subject>", ErrInvalidOrgMember)
+	}
+
+	return s.db.CreateOrgMember(ctx, nil, database.OrgMember{Organization: organization, Subject: subject, AddedBy: addedBy})
+}
+
+// ListOrgMembers retrieves an organization's members, oldest first
+func (s *registryServiceImpl) ListOrgMembers(ctx context.Context, organization string) ([]*database.OrgMember, error) {
+	return s.db.ListOrgMembers(ctx, nil, organization)
+}
+
+// RemoveOrgMember stops a member from managing an organization's API keys. Keys they created keep working.
+func (s *registryServiceImpl) RemoveOrgMember(ctx context.Context, organization string, id int64) (*database.OrgMember, error) {
+	var removed *database.OrgMember
+	err := s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		member, err := s.db.GetOrgMember(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if member.Organization != organization {
+			return database.ErrNotFound
+		}
+		removed = member
+		return s.db.DeleteOrgMember(ctx, tx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// IsOrgMember reports whether a subject is a member of an organization
+func (s *registryServiceImpl) IsOrgMember(ctx context.Context, organization, subject string) (bool, error) {
+	_, err := s.db.GetOrgMemberBySubject(ctx, nil, organization, subject)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+// getOrgAPIKey retrieves an organization API key, treating keys of other organizations as not found
+func (s *registryServiceImpl) getOrgAPIKey(ctx context.Context, tx pgx.Tx, organization string, id int64) (*database.OrgAPIKey, error) {
+	key, err := s.db.GetOrgAPIKey(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.Organization != organization {
+		return nil, database.ErrNotFound
+	}
+	return key, nil
+}
+
+// validateOrgAPIKey checks that a key has a name and only covers the organization's namespace and its
+// subdomains
+func validateOrgAPIKey(organization, name string, namespaces []string) error {
+	switch {
+	case !namespacePattern.MatchString(organization):
+		return fmt.Errorf("%w: organization %q is not a valid namespace", ErrInvalidOrgAPIKey, organization)
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidOrgAPIKey)
+	case len(name) > maxOrgAPIKeyNameLength:
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidOrgAPIKey, maxOrgAPIKeyNameLength)
+	case len(namespaces) == 0:
+		return fmt.Errorf("%w: at least one namespace is required", ErrInvalidOrgAPIKey)
+	}
+	for _, namespace := range namespaces {
+		if namespace != organization && !strings.HasPrefix(namespace, organization+".") {
+			return fmt.Errorf("%w: namespace %q does not belong to organization %q", ErrInvalidOrgAPIKey, namespace, organization)
+		}
+		if !namespacePattern.MatchString(namespace) {
+			return fmt.Errorf("%w: namespace %q is not a valid namespace", ErrInvalidOrgAPIKey, namespace)
+		}
+	}
+	return nil
+}
+
+// newOrgAPIKeySecret generates a new organization API key and its hash
+func newOrgAPIKeySecret() (string, string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", fmt.Errorf("failed to generate organization API key: %w", err)
+	}
+	secret := OrgAPIKeyPrefix + hex.EncodeToString(random)
+	return secret, hashOrgAPIKey(secret), nil
+}
+
+// hashOrgAPIKey hashes a key for storage. Keys are random, so an unsalted hash is enough.
+func hashOrgAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// partitionMonthsAhead is how many months after the current one have their partitions created in advance,
+// so writes never fall into the default partition while the maintenance job is briefly not running
+const partitionMonthsAhead = 3
+
+// CreatePartitions creates the monthly partitions of the audit log and changes feed for the coming months and returns how many were created
+func (s *registryServiceImpl) CreatePartitions(ctx context.Context) (int, error) {
+	return s.db.CreatePartitions(ctx, nil, time.Now().UTC().AddDate(0, partitionMonthsAhead, 0))
+}
+
+// RunPartitionMaintenance creates upcoming monthly partitions every interval until ctx is cancelled
+func RunPartitionMaintenance(ctx context.Context, registry RegistryService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := registry.CreatePartitions(ctx)
+		if err != nil {
+			log.Printf("Partition maintenance failed: %v", err)
+		} else if created > 0 {
+			log.Printf("Partition maintenance created %d partitions", created)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PruneServerChanges deletes changes feed entries older than maxAge, except the most recent one, and returns how many were deleted
+func (s *registryServiceImpl) PruneServerChanges(ctx context.Context, maxAge time.Duration) (int64, error) {
+	if maxAge <= 0 {
+		return 0, nil
+	}
+	return s.db.DeleteServerChangesBefore(ctx, nil, time.Now().Add(-maxAge))
+}
+
+// RunChangesRetention deletes changes feed entries older than maxAge every interval until ctx is cancelled
+func RunChangesRetention(ctx context.Context, registry RegistryService, maxAge, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := registry.PruneServerChanges(ctx, maxAge)
+		if err != nil {
+			log.Printf("Changes feed pruning failed: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Changes feed pruning deleted %d changes", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// ErrPreviewsDisabled is returned when previews are published to a registry with MCP_REGISTRY_PREVIEW_TTL=0
+var ErrPreviewsDisabled = errors.New("previews are disabled on this registry")
+
+// CreatePreview stores a preview of a server.json under a new random ID, expiring after the registry's preview
+// TTL. The server is not published: it is only reachable through the preview's ID.
+func (s *registryServiceImpl) CreatePreview(ctx context.Context, preview database.Preview) (*database.Preview, error) {
+	if s.cfg.PreviewTTL <= 0 {
+		return nil, ErrPreviewsDisabled
+	}
+	if err := checkNamespaceAllowed(preview.Server.Name); err != nil {
+		return nil, err
+	}
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return nil, fmt.Errorf("failed to generate preview ID: %w", err)
+	}
+	preview.ID = hex.EncodeToString(random)
+	preview.ExpiresAt = time.Now().Add(s.cfg.PreviewTTL)
+	return s.db.CreatePreview(ctx, nil, preview)
+}
+
+// GetPreview retrieves an unexpired preview
+func (s *registryServiceImpl) GetPreview(ctx context.Context, id string) (*database.Preview, error) {
+	return s.db.GetPreview(ctx, nil, id)
+}
+
+// PurgePreviews deletes expired previews and returns how many were deleted
+func (s *registryServiceImpl) PurgePreviews(ctx context.Context) (int64, error) {
+	return s.db.DeleteExpiredPreviews(ctx, nil, time.Now())
+}
+
+// RunPreviewPurge deletes expired previews every interval until ctx is cancelled. Expired previews are never
+// served, so this only reclaims space.
+func RunPreviewPurge(ctx context.Context, registry RegistryService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := registry.PurgePreviews(ctx)
+		if err != nil {
+			log.Printf("Preview purge failed: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Preview purge deleted %d previews", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
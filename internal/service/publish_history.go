@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// RecordPublishAttempt stores a publish attempt for its publisher's history, unless publish history is disabled.
+// The server.json and validation output of a rejected attempt are quarantined with it, unless the quarantine is
+// disabled. Rejected attempts are counted either way.
+func (s *registryServiceImpl) RecordPublishAttempt(ctx context.Context, attempt database.PublishAttempt, quarantined *database.QuarantinedPublish) error {
+	if attempt.Status >= 400 {
+		s.countMetric(ctx, database.MetricFailedPublishes, 1)
+	}
+	if s.cfg.PublishHistoryRetention <= 0 {
+		return nil
+	}
+	recorded, err := s.db.RecordPublishAttempt(ctx, nil, attempt)
+	if err != nil {
+		return err
+	}
+	if quarantined == nil || attempt.Status < 400 || s.cfg.PublishQuarantineRetention <= 0 {
+		return nil
+	}
+	quarantined.AttemptID = recorded.ID
+	return s.db.QuarantinePublish(ctx, nil, *quarantined)
+}
+
+// ListPublishAttempts retrieves up to limit publish attempts made by identity, newest first, with an ID less
+// than beforeID, or from the newest if beforeID is 0
+func (s *registryServiceImpl) ListPublishAttempts(ctx context.Context, identity string, beforeID int64, limit int) ([]*database.PublishAttempt, error) {
+	return s.db.ListPublishAttempts(ctx, nil, identity, beforeID, limit)
+}
+
+// GetPublishAttempt retrieves one of identity's publish attempts, along with its quarantined server.json if it
+// was rejected recently enough to still have one. Attempts made by other identities are not found.
+func (s *registryServiceImpl) GetPublishAttempt(ctx context.Context, identity string, id int64) (*database.PublishAttempt, *database.QuarantinedPublish, error) {
+	attempt, err := s.db.GetPublishAttempt(ctx, nil, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attempt.Identity != identity {
+		return nil, nil, ErrNotFound
+	}
+	quarantined, err := s.db.GetQuarantinedPublish(ctx, nil, id)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, nil, err
+	}
+	return attempt, quarantined, nil
+}
+
+// PrunePublishHistory deletes publish attempts older than maxAge and returns how many were deleted
+func (s *registryServiceImpl) PrunePublishHistory(ctx context.Context, maxAge time.Duration) (int64, error) {
+	if maxAge <= 0 {
+		return 0, nil
+	}
+	return s.db.DeletePublishAttemptsBefore(ctx, nil, time.Now().Add(-maxAge))
+}
+
+// RunPublishHistoryRetention deletes publish attempts older than maxAge every interval until ctx is cancelled
+func RunPublishHistoryRetention(ctx context.Context, registry RegistryService, maxAge, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := registry.PrunePublishHistory(ctx, maxAge)
+		if err != nil {
+			log.Printf("Publish history pruning failed: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Publish history pruning deleted %d attempts", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PrunePublishQuarantine deletes the server.json of rejected publish attempts quarantined longer than maxAge,
+// keeping the attempts themselves, and returns how many were deleted
+func (s *registryServiceImpl) PrunePublishQuarantine(ctx context.Context, maxAge time.Duration) (int64, error) {
+	if maxAge <= 0 {
+		return 0, nil
+	}
+	return s.db.DeleteQuarantinedPublishesBefore(ctx, nil, time.Now().Add(-maxAge))
+}
+
+// RunPublishQuarantineRetention deletes quarantined server.json documents older than maxAge every interval until
+// ctx is cancelled
+func RunPublishQuarantineRetention(ctx context.Context, registry RegistryService, maxAge, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := registry.PrunePublishQuarantine(ctx, maxAge)
+		if err != nil {
+			log.Printf("Publish quarantine pruning failed: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Publish quarantine pruning deleted %d documents", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
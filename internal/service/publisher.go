@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+type publisherKey struct{}
+
+// WithPublisher returns a context that makes CreateServer record publisher as the identity that published
+// the server version. Versions created without one, such as imported versions, have no publisher.
+func WithPublisher(ctx context.Context, publisher *apiv0.Publisher) context.Context {
+	return context.WithValue(ctx, publisherKey{}, publisher)
+}
+
+// publisherFromContext returns the publisher set with WithPublisher, or nil
+func publisherFromContext(ctx context.Context) *apiv0.Publisher {
+	publisher, _ := ctx.Value(publisherKey{}).(*apiv0.Publisher)
+	return publisher
+}
@@ -4,31 +4,103 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 
+	"github.com/modelcontextprotocol/registry/internal/artifacts"
+	"github.com/modelcontextprotocol/registry/internal/cdn"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/encryption"
+	"github.com/modelcontextprotocol/registry/internal/repourl"
 	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/internal/versioning"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
-const maxServerVersionsPerServer = 10000
+// MaxVersionsPerServer is how many versions a server can have; publishing more fails with ErrMaxVersionsReached
+const MaxVersionsPerServer = 10000
+
+// ErrRemoteURLInUse is returned when a remote URL is already registered to a different server
+var ErrRemoteURLInUse = errors.New("remote URL conflict")
+
+// defaultMaintenanceRetryAfter is the Retry-After value used when none is provided
+const defaultMaintenanceRetryAfter = 300
+
+// cdnPurgeTimeout bounds how long a background CDN purge may take
+const cdnPurgeTimeout = 10 * time.Second
 
 // registryServiceImpl implements the RegistryService interface using our Database
 type registryServiceImpl struct {
-	db  database.Database
-	cfg *config.Config
+	db            database.Database
+	cfg           *config.Config
+	purges        *cdn.QueuedPurger
+	metadataCache *registries.MetadataCache
+	screener      *validators.Screener
+	repoVerifier  *validators.GitHubRepositoryVerifier
+	repoURLs      *repourl.Canonicalizer
+	encryptor     *encryption.Encryptor
+	artifacts     *artifacts.Mirror
+	events        *eventHub
+	// localTokenUses holds exchanged tokens when MCP_REGISTRY_TOKEN_REPLAY_STORE is memory
+	localTokenUses *localTokenUses
 }
 
-// NewRegistryService creates a new registry service with the provided database
-func NewRegistryService(db database.Database, cfg *config.Config) RegistryService {
+// NewRegistryService creates a new registry service with the provided database. It fails when the encryption,
+// artifact storage or install feedback settings are invalid.
+func NewRegistryService(db database.Database, cfg *config.Config) (RegistryService, error) {
+	if cfg.InstallFeedbackEnabled && cfg.InstallFeedbackKey == "" {
+		return nil, errors.New("install feedback requires install_feedback.key to key reporter hashes")
+	}
+	encryptor, err := encryption.NewFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	mirror, err := artifacts.NewFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return &registryServiceImpl{
-		db:  db,
-		cfg: cfg,
+		db:             db,
+		cfg:            cfg,
+		purges:         cdn.NewQueuedPurger(cdn.NewPurger(cfg), cdnPurgeTimeout),
+		metadataCache:  registries.NewMetadataCache(db, cfg.UpstreamCacheTTL, cfg.UpstreamCacheNegativeTTL),
+		screener:       validators.NewScreener(cfg),
+		repoVerifier:   validators.NewGitHubRepositoryVerifier(cfg),
+		repoURLs:       repourl.NewCanonicalizer(cfg),
+		encryptor:      encryptor,
+		artifacts:      mirror,
+		events:         newEventHub(db),
+		localTokenUses: &localTokenUses{uses: map[string]time.Time{}},
+	}, nil
+}
+
+// purgeServerCache queues the invalidation of CDN-cached responses for a server after a successful write.
+// The write doesn't wait for the purge, which runs in the background.
+func (s *registryServiceImpl) purgeServerCache(serverName string) {
+	s.purges.Enqueue(append(cdn.KeysForServer(serverName), cdn.ListKey)...)
+}
+
+// attachMeta adds the metadata kept outside the servers table to server versions read from it
+func (s *registryServiceImpl) attachMeta(ctx context.Context, servers ...*apiv0.ServerResponse) error {
+	if err := s.attachRemoteHealth(ctx, servers...); err != nil {
+		return err
 	}
+	if err := s.attachCuration(ctx, servers...); err != nil {
+		return err
+	}
+	if err := s.attachInstallFeedback(ctx, servers...); err != nil {
+		return err
+	}
+	if err := s.attachDocumentDigests(ctx, servers...); err != nil {
+		return err
+	}
+	return s.attachUnmaintained(ctx, servers...)
 }
 
 // ListServers returns registry entries with cursor-based pagination and optional filtering
@@ -43,16 +115,44 @@ func (s *registryServiceImpl) ListServers(ctx context.Context, filter *database.
 	if err != nil {
 		return nil, "", err
 	}
+	if err := s.attachMeta(ctx, serverRecords...); err != nil {
+		return nil, "", err
+	}
 
 	return serverRecords, nextCursor, nil
 }
 
+// CountServers counts the servers matching filter, exactly up to the configured limit and estimated beyond it
+func (s *registryServiceImpl) CountServers(ctx context.Context, filter *database.ServerFilter) (int, bool, error) {
+	return s.db.CountServers(ctx, nil, filter, s.cfg.ListTotalExactLimit)
+}
+
+// SuggestServers suggests servers and namespaces with a word of their name starting with prefix
+func (s *registryServiceImpl) SuggestServers(ctx context.Context, prefix string, limit int) ([]database.ServerSuggestion, []string, error) {
+	return s.db.SuggestServers(ctx, nil, prefix, limit)
+}
+
+// ListNamespaces summarizes namespaces with servers, optionally only parent and the namespaces beneath it
+func (s *registryServiceImpl) ListNamespaces(ctx context.Context, parent, cursor string, limit int) ([]*database.NamespaceSummary, string, error) {
+	namespaces, nextCursor, err := s.db.ListNamespaces(ctx, nil, parent, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, namespace := range namespaces {
+		namespace.Verified = namespace.VerifiedServers == namespace.Servers
+	}
+	return namespaces, nextCursor, nil
+}
+
 // GetServerByName retrieves the latest version of a server by its server name
 func (s *registryServiceImpl) GetServerByName(ctx context.Context, serverName string, includeDeleted bool) (*apiv0.ServerResponse, error) {
 	serverRecord, err := s.db.GetServerByName(ctx, nil, serverName, includeDeleted)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.attachMeta(ctx, serverRecord); err != nil {
+		return nil, err
+	}
 
 	return serverRecord, nil
 }
@@ -63,6 +163,9 @@ func (s *registryServiceImpl) GetServerByNameAndVersion(ctx context.Context, ser
 	if err != nil {
 		return nil, err
 	}
+	if err := s.attachMeta(ctx, serverRecord); err != nil {
+		return nil, err
+	}
 
 	return serverRecord, nil
 }
@@ -73,27 +176,66 @@ func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, se
 	if err != nil {
 		return nil, err
 	}
+	if err := s.attachMeta(ctx, serverRecords...); err != nil {
+		return nil, err
+	}
 
 	return serverRecords, nil
 }
 
+// GetPackageProvenance returns the upstream provenance recorded for a server version's packages
+func (s *registryServiceImpl) GetPackageProvenance(ctx context.Context, serverName, version string) ([]apiv0.PackageProvenance, error) {
+	return s.db.GetPackageProvenance(ctx, nil, serverName, version)
+}
+
+// ListServerChanges returns changes recorded after the given sequence number, oldest first
+func (s *registryServiceImpl) ListServerChanges(ctx context.Context, since int64, limit int) ([]*apiv0.ServerChange, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	changes, err := s.db.ListServerChanges(ctx, nil, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// ListExistingVersions retrieves the versions, including deleted ones, of each given server that has any, keyed by server name
+func (s *registryServiceImpl) ListExistingVersions(ctx context.Context, serverNames []string) (map[string][]string, error) {
+	return s.db.ListExistingVersions(ctx, nil, serverNames)
+}
+
 // CreateServer creates a new server version
 func (s *registryServiceImpl) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
-	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+	result, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
 		return s.createServerInTransaction(ctx, tx, req)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.purgeServerCache(result.Server.Name)
+	return result, nil
 }
 
 // createServerInTransaction contains the actual CreateServer logic within a transaction
 func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	serverJSON := *req
+
+	if err := checkNamespaceAllowed(serverJSON.Name); err != nil {
+		return nil, err
+	}
+
 	// Validate the request
-	if err := validators.ValidatePublishRequest(ctx, *req, s.cfg); err != nil {
+	provenance, err := validators.ValidatePublishRequest(registries.WithMetadataCache(ctx, s.metadataCache), &serverJSON, s.cfg)
+	if err != nil {
 		return nil, err
 	}
 
 	publishTime := time.Now()
-	serverJSON := *req
 
 	// Acquire advisory lock to prevent concurrent publishes of the same server
 	if err := s.db.AcquirePublishLock(ctx, tx, serverJSON.Name); err != nil {
@@ -110,7 +252,7 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 	if err != nil && !errors.Is(err, database.ErrNotFound) {
 		return nil, err
 	}
-	if versionCount >= maxServerVersionsPerServer {
+	if versionCount >= MaxVersionsPerServer {
 		return nil, database.ErrMaxServersReached
 	}
 
@@ -136,7 +278,7 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 		if currentLatest.Meta.Official != nil {
 			existingPublishedAt = currentLatest.Meta.Official.PublishedAt
 		}
-		isNewLatest = CompareVersions(
+		isNewLatest = versioning.CompareVersions(
 			serverJSON.Version,
 			currentLatest.Server.Version,
 			publishTime,
@@ -152,16 +294,78 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 	}
 
 	// Create metadata for the new server
+	publisher := publisherFromContext(ctx)
 	officialMeta := &apiv0.RegistryExtensions{
-		Status:          model.StatusActive, /* New versions are active by default */
-		StatusChangedAt: publishTime,
-		PublishedAt:     publishTime,
-		UpdatedAt:       publishTime,
-		IsLatest:        isNewLatest,
+		Status:            model.StatusActive, /* New versions are active by default */
+		StatusChangedAt:   publishTime,
+		PublishedAt:       publishTime,
+		UpdatedAt:         publishTime,
+		IsLatest:          isNewLatest,
+		Publisher:         publisher,
+		VerifiedPublisher: publisher.IsVerified(),
 	}
 
 	// Insert new server version
-	return s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	created, err := s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the server.json exactly as it was published, rather than its canonical encoding
+	if document := documentFromContext(ctx); document != nil {
+		digest, err := s.db.PutServerDocument(ctx, tx, serverJSON.Name, serverJSON.Version, document)
+		if err != nil {
+			return nil, err
+		}
+		created.Meta.Official.DocumentDigest = digest
+	}
+
+	// Record what each package resolved to upstream alongside the version
+	if err := s.db.RecordPackageProvenance(ctx, tx, serverJSON.Name, serverJSON.Version, provenance); err != nil {
+		return nil, err
+	}
+
+	// Mirroring downloads every artifact, so it is queued for the artifact mirror worker rather than run here
+	if s.artifacts != nil {
+		if err := s.db.QueueArtifactMirror(ctx, tx, serverJSON.Name, serverJSON.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.recordRepositoryURL(ctx, tx, &serverJSON); err != nil {
+		return nil, err
+	}
+
+	// Publishing a new version shows the server is maintained again
+	if err := s.db.DeleteServerStaleness(ctx, tx, serverJSON.Name); err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+
+	// Count the publish with it, so publishes that roll back are not counted
+	if err := s.db.IncrementMetric(ctx, tx, database.MetricPublishes, publishTime, 1); err != nil {
+		return nil, err
+	}
+	if versionCount == 0 {
+		if err := s.db.IncrementMetric(ctx, tx, database.MetricNewServers, publishTime, 1); err != nil {
+			return nil, err
+		}
+	}
+	return created, nil
+}
+
+// recordRepositoryURL records the canonical form of the server's repository URL, so lookups by repository and
+// duplicate detection match it however it is formatted
+func (s *registryServiceImpl) recordRepositoryURL(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON) error {
+	if serverJSON.Repository == nil || serverJSON.Repository.URL == "" {
+		return nil
+	}
+	return s.db.PutRepositoryURL(ctx, tx, serverJSON.Repository.URL, s.repoURLs.Canonicalize(ctx, serverJSON.Repository.URL))
+}
+
+// hiddenStatus reports whether versions with status are hidden from listings, so their remote URLs are free for
+// other servers to take
+func hiddenStatus(status model.Status) bool {
+	return status == model.StatusDeleted || status == model.StatusQuarantined
 }
 
 // validateNoDuplicateRemoteURLs checks that no other server is using the same remote URLs
@@ -179,7 +383,7 @@ func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context,
 		// Check if any conflicting server has a different name
 		for _, conflictingServer := range conflictingServers {
 			if conflictingServer.Server.Name != serverDetail.Name {
-				return fmt.Errorf("remote URL %s is already used by server %s", remote.URL, conflictingServer.Server.Name)
+				return fmt.Errorf("%w: remote URL %s is already used by server %s", ErrRemoteURLInUse, remote.URL, conflictingServer.Server.Name)
 			}
 		}
 	}
@@ -190,9 +394,15 @@ func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context,
 // UpdateServer updates an existing server with new details
 func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, statusChange *StatusChangeRequest) (*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
-	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+	result, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
 		return s.updateServerInTransaction(ctx, tx, serverName, version, req, statusChange)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.purgeServerCache(serverName)
+	return result, nil
 }
 
 // updateServerInTransaction contains the actual UpdateServer logic within a transaction
@@ -205,14 +415,18 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 	}
 
 	// Skip registry validation if:
-	// 1. Server is currently deleted, OR
+	// 1. Server is currently deleted or quarantined, OR
 	// 2. Server is being set to deleted status
-	currentlyDeleted := currentServer.Meta.Official != nil && currentServer.Meta.Official.Status == model.StatusDeleted
+	currentlyDeleted := currentServer.Meta.Official != nil && hiddenStatus(currentServer.Meta.Official.Status)
 	beingDeleted := statusChange != nil && statusChange.NewStatus == model.StatusDeleted
 	skipRegistryValidation := currentlyDeleted || beingDeleted
 
+	// Merge the request with the current server, preserving metadata
+	updatedServer := *req
+
 	// Validate the request, potentially skipping registry validation for deleted servers
-	if err := validators.ValidateUpdateRequest(ctx, *req, s.cfg, skipRegistryValidation); err != nil {
+	provenance, err := validators.ValidateUpdateRequest(registries.WithMetadataCache(ctx, s.metadataCache), &updatedServer, s.cfg, skipRegistryValidation)
+	if err != nil {
 		return nil, err
 	}
 
@@ -221,9 +435,6 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
-	// Merge the request with the current server, preserving metadata
-	updatedServer := *req
-
 	// Check for duplicate remote URLs using the updated server
 	if err := s.validateNoDuplicateRemoteURLs(ctx, tx, updatedServer); err != nil {
 		return nil, err
@@ -235,6 +446,15 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
+	// Packages added by the edit get provenance; packages recorded at publish keep their original record
+	if err := s.db.RecordPackageProvenance(ctx, tx, serverName, version, provenance); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordRepositoryURL(ctx, tx, &updatedServer); err != nil {
+		return nil, err
+	}
+
 	// Handle status change if provided
 	if statusChange != nil {
 		updatedWithStatus, err := s.db.SetServerStatus(ctx, tx, serverName, version, statusChange.NewStatus, statusChange.StatusMessage)
@@ -250,9 +470,15 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 // UpdateServerStatus updates only the status metadata of a server version
 func (s *registryServiceImpl) UpdateServerStatus(ctx context.Context, serverName, version string, statusChange *StatusChangeRequest) (*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
-	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+	result, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
 		return s.updateServerStatusInTransaction(ctx, tx, serverName, version, statusChange)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.purgeServerCache(serverName)
+	return result, nil
 }
 
 // updateServerStatusInTransaction contains the actual UpdateServerStatus logic within a transaction
@@ -269,10 +495,10 @@ func (s *registryServiceImpl) updateServerStatusInTransaction(ctx context.Contex
 		return nil, err
 	}
 
-	// When transitioning to active from deleted, validate remote URLs don't conflict
+	// When transitioning to active from deleted or quarantined, validate remote URLs don't conflict
 	if statusChange.NewStatus == model.StatusActive &&
 		currentServer.Meta.Official != nil &&
-		currentServer.Meta.Official.Status == model.StatusDeleted {
+		hiddenStatus(currentServer.Meta.Official.Status) {
 		if err := s.validateNoDuplicateRemoteURLs(ctx, tx, currentServer.Server); err != nil {
 			return nil, err
 		}
@@ -285,9 +511,15 @@ func (s *registryServiceImpl) updateServerStatusInTransaction(ctx context.Contex
 // UpdateAllVersionsStatus updates the status metadata of all versions of a server in a single transaction
 func (s *registryServiceImpl) UpdateAllVersionsStatus(ctx context.Context, serverName string, statusChange *StatusChangeRequest) ([]*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
-	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) ([]*apiv0.ServerResponse, error) {
+	results, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) ([]*apiv0.ServerResponse, error) {
 		return s.updateAllVersionsStatusInTransaction(ctx, tx, serverName, statusChange)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.purgeServerCache(serverName)
+	return results, nil
 }
 
 // updateAllVersionsStatusInTransaction contains the actual UpdateAllVersionsStatus logic within a transaction
@@ -310,7 +542,7 @@ func (s *registryServiceImpl) updateAllVersionsStatusInTransaction(ctx context.C
 
 		for _, version := range versions {
 			if version.Meta.Official != nil &&
-				version.Meta.Official.Status == model.StatusDeleted {
+				hiddenStatus(version.Meta.Official.Status) {
 				if err := s.validateNoDuplicateRemoteURLs(ctx, tx, version.Server); err != nil {
 					return nil, err
 				}
@@ -321,3 +553,90 @@ func (s *registryServiceImpl) updateAllVersionsStatusInTransaction(ctx context.C
 	// Update all versions' status in a single database call
 	return s.db.SetAllVersionsStatus(ctx, tx, serverName, statusChange.NewStatus, statusChange.StatusMessage)
 }
+
+// ListPossibleDuplicates returns every group of servers that share a repository URL or remote endpoint
+func (s *registryServiceImpl) ListPossibleDuplicates(ctx context.Context) ([]*database.DuplicateGroup, error) {
+	return s.db.ListPossibleDuplicates(ctx, nil, nil)
+}
+
+// FindPossibleDuplicates returns the sorted names of other servers that share a repository URL or remote
+// endpoint with the given server. These are not rejected, only surfaced to the publisher and moderators.
+func (s *registryServiceImpl) FindPossibleDuplicates(ctx context.Context, serverName string) ([]string, error) {
+	groups, err := s.db.ListPossibleDuplicates(ctx, nil, &serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, group := range groups {
+		for _, name := range group.ServerNames {
+			if name != serverName && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// RecordNamespaceVerificationAttempt adds login evidence to the pending verification request for a domain
+func (s *registryServiceImpl) RecordNamespaceVerificationAttempt(ctx context.Context, domain, method string, evidence database.VerificationEvidence) (*database.NamespaceVerification, error) {
+	return s.db.RecordNamespaceVerificationAttempt(ctx, nil, domain, method, evidence)
+}
+
+// AddNamespaceVerificationEvidence appends re-check evidence to a verification request
+func (s *registryServiceImpl) AddNamespaceVerificationEvidence(ctx context.Context, id int64, evidence database.VerificationEvidence) (*database.NamespaceVerification, error) {
+	return s.db.AddNamespaceVerificationEvidence(ctx, nil, id, evidence)
+}
+
+// GetNamespaceVerification returns a verification request by ID
+func (s *registryServiceImpl) GetNamespaceVerification(ctx context.Context, id int64) (*database.NamespaceVerification, error) {
+	return s.db.GetNamespaceVerification(ctx, nil, id)
+}
+
+// ListNamespaceVerifications returns verification requests with the given status, or all if status is empty
+func (s *registryServiceImpl) ListNamespaceVerifications(ctx context.Context, status string) ([]*database.NamespaceVerification, error) {
+	var statusFilter *string
+	if status != "" {
+		statusFilter = &status
+	}
+	return s.db.ListNamespaceVerifications(ctx, nil, statusFilter)
+}
+
+// ReviewNamespaceVerification approves or rejects a verification request.
+// A decision can be revisited later, e.g. to revoke an approval.
+func (s *registryServiceImpl) ReviewNamespaceVerification(ctx context.Context, id int64, approve bool, notes *string, reviewer string) (*database.NamespaceVerification, error) {
+	status := database.NamespaceVerificationRejected
+	if approve {
+		status = database.NamespaceVerificationApproved
+	}
+	return s.db.ReviewNamespaceVerification(ctx, nil, id, status, notes, reviewer)
+}
+
+// IsNamespaceApproved reports whether a domain namespace has been approved by an admin
+func (s *registryServiceImpl) IsNamespaceApproved(ctx context.Context, domain string) (bool, error) {
+	return s.db.IsNamespaceApproved(ctx, nil, domain)
+}
+
+// GetMaintenanceState returns the current maintenance mode state, treating a missing state as disabled
+func (s *registryServiceImpl) GetMaintenanceState(ctx context.Context) (*database.MaintenanceState, error) {
+	state, err := s.db.GetMaintenanceState(ctx, nil)
+	if errors.Is(err, database.ErrNotFound) {
+		return &database.MaintenanceState{RetryAfterSeconds: defaultMaintenanceRetryAfter}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// SetMaintenanceState enables or disables maintenance mode
+func (s *registryServiceImpl) SetMaintenanceState(ctx context.Context, enabled bool, message *string, retryAfterSeconds int) (*database.MaintenanceState, error) {
+	if retryAfterSeconds <= 0 {
+		retryAfterSeconds = defaultMaintenanceRetryAfter
+	}
+
+	return s.db.SetMaintenanceState(ctx, nil, enabled, message, retryAfterSeconds)
+}
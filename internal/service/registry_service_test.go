@@ -2,21 +2,44 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/encryption"
+	"github.com/modelcontextprotocol/registry/internal/probe"
+	"github.com/modelcontextprotocol/registry/internal/stale"
+	"github.com/modelcontextprotocol/registry/internal/toolindex"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
+func TestNewRegistryService_InvalidConfig(t *testing.T) {
+	_, err := NewRegistryService(database.NewMemory(), &config.Config{EncryptionKeyProvider: "unknown"})
+	assert.ErrorContains(t, err, "unknown encryption key provider")
+
+	_, err = NewRegistryService(database.NewMemory(), &config.Config{EncryptionKeyProvider: "local"})
+	assert.Error(t, err)
+}
+
 func TestValidateNoDuplicateRemoteURLs(t *testing.T) {
 	ctx := context.Background()
 
@@ -44,7 +67,7 @@ func TestValidateNoDuplicateRemoteURLs(t *testing.T) {
 	}
 
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	// Create existing servers using the new CreateServer method
 	for _, server := range existingServers {
@@ -131,7 +154,7 @@ func TestValidateNoDuplicateRemoteURLs(t *testing.T) {
 func TestGetServerByName(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	// Create multiple versions of the same server
 	_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
@@ -200,7 +223,7 @@ func TestGetServerByName(t *testing.T) {
 func TestGetServerByNameAndVersion(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	serverName := "com.example/versioned-server"
 
@@ -293,7 +316,7 @@ func TestGetServerByNameAndVersion(t *testing.T) {
 func TestGetAllVersionsByServerName(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	serverName := "com.example/multi-version-server"
 
@@ -389,7 +412,7 @@ func TestGetAllVersionsByServerName(t *testing.T) {
 func TestCreateServerConcurrentVersionsNoRace(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	const concurrency = 100
 	serverName := "com.example/test-concurrent"
@@ -445,7 +468,7 @@ func TestCreateServerConcurrentVersionsNoRace(t *testing.T) {
 func TestUpdateServer(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	serverName := "com.example/update-test-server"
 	version := "1.0.0"
@@ -554,7 +577,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
 	// Enable registry validation to test that it gets skipped for deleted servers
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: true})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: true})
 
 	serverName := "com.example/validation-skip-test"
 	version := "1.0.0"
@@ -652,7 +675,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 func TestListServers(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	// Create test servers
 	testServers := []struct {
@@ -744,7 +767,7 @@ func TestListServers(t *testing.T) {
 func TestVersionComparison(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	serverName := "com.example/version-comparison-server"
 
@@ -793,10 +816,75 @@ func TestVersionComparison(t *testing.T) {
 	assert.Equal(t, 1, latestCount, "Exactly one version should be marked as latest")
 }
 
+func TestDeterministicOrdering(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewMemory()
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
+
+	// Records published in the same instant must still come back in one order, with unique keys breaking ties
+	publishedAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	for _, server := range []struct{ name, version string }{
+		{"com.example/beta", "1.0.0"},
+		{"com.example/alpha", "1.1.0"},
+		{"com.example/alpha", "1.0.0"},
+		{"com.example/alpha", "1.2.0"},
+		{"com.example/gamma", "1.0.0"},
+	} {
+		_, err := testDB.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        server.name,
+			Description: "A server published in the same instant as the others",
+			Version:     server.version,
+		}, &apiv0.RegistryExtensions{
+			Status:          model.StatusActive,
+			StatusChangedAt: publishedAt,
+			PublishedAt:     publishedAt,
+			UpdatedAt:       publishedAt,
+			IsLatest:        server.name != "com.example/alpha" || server.version == "1.2.0",
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("versions are newest first, then by version descending", func(t *testing.T) {
+		for range 10 {
+			versions, err := service.GetAllVersionsByServerName(ctx, "com.example/alpha", false)
+			require.NoError(t, err)
+			got := make([]string, len(versions))
+			for i, version := range versions {
+				got[i] = version.Server.Version
+			}
+			assert.Equal(t, []string{"1.2.0", "1.1.0", "1.0.0"}, got)
+		}
+	})
+
+	t.Run("pages never skip or repeat a record", func(t *testing.T) {
+		var got []string
+		cursor := ""
+		for {
+			page, nextCursor, err := service.ListServers(ctx, nil, cursor, 2)
+			require.NoError(t, err)
+			for _, server := range page {
+				got = append(got, server.Server.Name+"@"+server.Server.Version)
+			}
+			if nextCursor == "" {
+				break
+			}
+			cursor = nextCursor
+		}
+		assert.Equal(t, []string{
+			"com.example/alpha@1.0.0",
+			"com.example/alpha@1.1.0",
+			"com.example/alpha@1.2.0",
+			"com.example/beta@1.0.0",
+			"com.example/gamma@1.0.0",
+		}, got)
+	})
+}
+
 func TestUpdateServerStatus_ValidateRemoteURLsOnRestoreToActive(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	remoteURL := "https://api.example.com/mcp"
 
@@ -844,7 +932,7 @@ func TestUpdateServerStatus_ValidateRemoteURLsOnRestoreToActive(t *testing.T) {
 func TestUpdateServerStatus_ValidateRemoteURLsOnRestoreFromDeleted(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	remoteURL := "https://api.deleted.com/mcp"
 
@@ -892,7 +980,7 @@ func TestUpdateServerStatus_ValidateRemoteURLsOnRestoreFromDeleted(t *testing.T)
 func TestUpdateAllVersionsStatus_ValidateRemoteURLsOnRestoreToActive(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	remoteURL := "https://api.allversions.com/mcp"
 
@@ -952,7 +1040,7 @@ func TestUpdateAllVersionsStatus_ValidateRemoteURLsOnRestoreToActive(t *testing.
 func TestUpdateServerStatus_NoConflictWhenRestoringWithUniqueURLs(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewTestRegistryService(t, testDB, &config.Config{EnableRegistryValidation: false})
 
 	// Create and delete a server
 	server := &apiv0.ServerJSON{
@@ -986,3 +1074,914 @@ func TestUpdateServerStatus_NoConflictWhenRestoringWithUniqueURLs(t *testing.T)
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestPossibleDuplicates(t *testing.T) {
+	ctx := context.Background()
+	service := NewTestRegistryService(t, database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	servers := []*apiv0.ServerJSON{
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.example/weather",
+			Description: "Weather server",
+			Version:     "1.0.0",
+			Repository:  &model.Repository{URL: "https://github.com/example/weather", Source: "github"},
+			Remotes:     []model.Transport{{Type: "streamable-http", URL: "https://weather.example.com/mcp"}},
+		},
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather",
+			Description: "Same weather server under a new name",
+			Version:     "1.0.0",
+			Repository:  &model.Repository{URL: "https://GitHub.com/example/weather.git", Source: "github"},
+		},
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather-mirror",
+			Description: "Mirror of the weather remote",
+			Version:     "1.0.0",
+			Remotes:     []model.Transport{{Type: "streamable-http", URL: "https://weather.example.com/mcp/"}},
+		},
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/unrelated",
+			Description: "Unrelated server",
+			Version:     "1.0.0",
+			Repository:  &model.Repository{URL: "https://github.com/example/unrelated", Source: "github"},
+		},
+	}
+	for _, server := range servers {
+		_, err := service.CreateServer(ctx, server)
+		require.NoError(t, err)
+	}
+
+	duplicates, err := service.FindPossibleDuplicates(ctx, "io.github.example/weather")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"com.example/weather", "com.example/weather-mirror"}, duplicates)
+
+	duplicates, err = service.FindPossibleDuplicates(ctx, "com.example/unrelated")
+	require.NoError(t, err)
+	assert.Empty(t, duplicates)
+
+	groups, err := service.ListPossibleDuplicates(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "remote", groups[0].Reason)
+	assert.Equal(t, "repository", groups[1].Reason)
+	assert.Equal(t, "https://github.com/example/weather", groups[1].URL)
+
+	// The repo filter maps any spelling of the repository URL to its registry entries
+	results, _, err := service.ListServers(ctx, &database.ServerFilter{RepositoryURL: stringPtr("https://github.com/example/weather/")}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "com.example/weather", results[0].Server.Name)
+	assert.Equal(t, "io.github.example/weather", results[1].Server.Name)
+}
+
+func TestRepositoryURLCanonicalization(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemory()
+	service := NewTestRegistryService(t, db, &config.Config{EnableRegistryValidation: false})
+
+	for name, repositoryURL := range map[string]string{
+		"io.github.acme/weather": "git@github.com:Acme/Weather.git",
+		"com.acme/weather":       "http://www.github.com/acme/weather/",
+	} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Weather server",
+			Version:     "1.0.0",
+			Repository:  &model.Repository{URL: repositoryURL, Source: "github"},
+		})
+		require.NoError(t, err)
+	}
+
+	// Both spellings are recorded against the same canonical URL
+	results, _, err := service.ListServers(ctx, &database.ServerFilter{RepositoryURL: stringPtr("https://github.com/acme/weather")}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "git@github.com:Acme/Weather.git", results[1].Server.Repository.URL, "the published URL is kept as is")
+
+	groups, err := service.ListPossibleDuplicates(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "https://github.com/acme/weather", groups[0].URL)
+	assert.Equal(t, []string{"com.acme/weather", "io.github.acme/weather"}, groups[0].ServerNames)
+}
+
+func TestDomainErrors(t *testing.T) {
+	ctx := context.Background()
+	service := NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	}
+
+	_, err := service.GetServerByName(ctx, server.Name, false)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = service.CreateServer(ctx, server)
+	require.NoError(t, err)
+	_, err = service.CreateServer(ctx, server)
+	require.ErrorIs(t, err, ErrDuplicateVersion)
+
+	blocked := auth.BlockedNamespaces
+	t.Cleanup(func() { auth.BlockedNamespaces = blocked })
+	auth.BlockedNamespaces = []string{"com.spam"}
+	for _, name := range []string{"com.spam/weather", "com.spam.sub/weather"} {
+		_, err = service.CreateServer(ctx, &apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: name, Description: "Spam", Version: "1.0.0"})
+		require.ErrorIs(t, err, ErrForbiddenNamespace, name)
+	}
+	_, err = service.CreateServer(ctx, &apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: "com.spammy/weather", Description: "Not spam", Version: "1.0.0"})
+	require.NoError(t, err)
+}
+
+func TestPruneVersions(t *testing.T) {
+	ctx := context.Background()
+	service := NewTestRegistryService(t, database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	for _, version := range []string{"1.0.0", "1.0.1", "1.0.2", "1.0.3"} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/nightly",
+			Description: "Server published on every CI run",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/stable",
+		Description: "Server with a single version",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	// A disabled policy selects nothing
+	versions, err := service.ListPrunableVersions(ctx, RetentionPolicy{}, 100)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+
+	// Nothing has been published long enough ago to exceed the maximum age
+	versions, err = service.ListPrunableVersions(ctx, RetentionPolicy{MaxAge: time.Hour}, 100)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+
+	policy := RetentionPolicy{MaxVersionsPerServer: 2}
+	versions, err = service.ListPrunableVersions(ctx, policy, 100)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "1.0.0", versions[0].Version)
+	assert.Equal(t, "1.0.1", versions[1].Version)
+	assert.Equal(t, database.PruneReasonMaxVersions, versions[0].Reason)
+
+	// Dry runs do not delete anything
+	remaining, err := service.GetAllVersionsByServerName(ctx, "com.example/nightly", true)
+	require.NoError(t, err)
+	assert.Len(t, remaining, 4)
+
+	pruned, err := service.PruneVersions(ctx, policy)
+	require.NoError(t, err)
+	assert.Len(t, pruned, 2)
+
+	remaining, err = service.GetAllVersionsByServerName(ctx, "com.example/nightly", true)
+	require.NoError(t, err)
+	require.Len(t, remaining, 2)
+	latest, err := service.GetServerByName(ctx, "com.example/nightly", false)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.3", latest.Server.Version)
+
+	// Even a limit of one keeps the latest version of every server
+	pruned, err = service.PruneVersions(ctx, RetentionPolicy{MaxVersionsPerServer: 1})
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	assert.Equal(t, "1.0.2", pruned[0].Version)
+
+	_, err = service.GetServerByName(ctx, "com.example/stable", false)
+	require.NoError(t, err)
+}
+
+func TestProbeRemotes(t *testing.T) {
+	ctx := context.Background()
+
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2025-03-26"}}`))
+	}))
+	defer healthy.Close()
+	authRequired := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer authRequired.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	db := database.NewMemory()
+	service := NewTestRegistryService(t, db, &config.Config{RemoteProbeEnabled: true, RemoteProbeDeadAfter: 14 * 24 * time.Hour})
+	for name, remotes := range map[string][]string{
+		"com.example/healthy":  {healthy.URL + "/mcp", authRequired.URL + "/mcp"},
+		"com.example/degraded": {healthy.URL + "/other", down.URL + "/mcp"},
+		"com.example/local":    {"http://localhost:{port}/mcp"},
+	} {
+		server := &apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: name, Description: "A remote server", Version: "1.0.0"}
+		for _, url := range remotes {
+			server.Remotes = append(server.Remotes, model.Transport{Type: model.TransportTypeStreamableHTTP, URL: url})
+		}
+		_, err := service.CreateServer(ctx, server)
+		require.NoError(t, err)
+	}
+
+	probed, err := service.ProbeRemotes(ctx, probe.NewProber(time.Second, true))
+	require.NoError(t, err)
+	assert.Equal(t, 4, probed, "templated URLs are not probed")
+
+	server, err := service.GetServerByName(ctx, "com.example/healthy", false)
+	require.NoError(t, err)
+	require.NotNil(t, server.Meta.RemoteHealth)
+	assert.Equal(t, apiv0.RemoteBadgeHealthy, server.Meta.RemoteHealth.Status)
+	require.Len(t, server.Meta.RemoteHealth.Remotes, 2)
+	assert.Equal(t, apiv0.RemoteHealthy, server.Meta.RemoteHealth.Remotes[0].Status)
+	assert.Equal(t, "2025-03-26", server.Meta.RemoteHealth.Remotes[0].ProtocolVersion)
+	assert.Equal(t, apiv0.RemoteAuthRequired, server.Meta.RemoteHealth.Remotes[1].Status)
+
+	server, err = service.GetServerByName(ctx, "com.example/degraded", false)
+	require.NoError(t, err)
+	require.NotNil(t, server.Meta.RemoteHealth)
+	assert.Equal(t, apiv0.RemoteBadgeDegraded, server.Meta.RemoteHealth.Status)
+	downHealth := server.Meta.RemoteHealth.Remotes[1]
+	assert.Equal(t, apiv0.RemoteUnhealthy, downHealth.Status)
+	assert.NotEmpty(t, downHealth.Error)
+	require.NotNil(t, downHealth.FailingSince)
+
+	// A second round keeps the time the remote started failing
+	_, err = service.ProbeRemotes(ctx, probe.NewProber(time.Second, true))
+	require.NoError(t, err)
+	health, err := db.GetRemoteHealth(ctx, nil, []string{down.URL + "/mcp"})
+	require.NoError(t, err)
+	assert.Equal(t, *downHealth.FailingSince, *health[down.URL+"/mcp"].FailingSince)
+
+	server, err = service.GetServerByName(ctx, "com.example/local", false)
+	require.NoError(t, err)
+	assert.Nil(t, server.Meta.RemoteHealth)
+
+	disabled := NewTestRegistryService(t, db, &config.Config{})
+	server, err = disabled.GetServerByName(ctx, "com.example/healthy", false)
+	require.NoError(t, err)
+	assert.Nil(t, server.Meta.RemoteHealth, "badges are only shown while probing is enabled")
+}
+
+func TestIndexServerTools(t *testing.T) {
+	ctx := context.Background()
+	var githubDown atomic.Bool
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case githubDown.Load():
+			w.WriteHeader(http.StatusBadGateway)
+		case r.URL.Path == "/repos/acme/postgres/contents/manifest.json":
+			_, _ = w.Write([]byte(`{"manifest_version":"0.2","name":"postgres","tools":[{"name":"query","description":"Run a read-only SQL query against PostgreSQL"},{"name":" "}]}`))
+		case r.URL.Path == "/repos/acme/monorepo/contents/servers/weather/manifest.json":
+			_, _ = w.Write([]byte(`{"tools":[{"name":"get_forecast","description":"Get the weather forecast for a city"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer github.Close()
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			return
+		}
+		var message struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+		w.Header().Set("Content-Type", "application/json")
+		switch message.Method {
+		case "initialize":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2025-06-18"}}`))
+		case "tools/list":
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"tools":[{"name":"search_issues","description":"Search issues in the tracker"}]}}`, message.ID)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer remote.Close()
+
+	cfg := &config.Config{}
+	service := NewTestRegistryService(t, database.NewMemory(), cfg)
+	for _, server := range []*apiv0.ServerJSON{
+		{Name: "io.github.acme/postgres", Repository: &model.Repository{URL: "https://github.com/acme/postgres", Source: "github"}},
+		{Name: "io.github.acme/weather", Repository: &model.Repository{URL: "https://github.com/acme/monorepo", Source: "github", Subfolder: "servers/weather"}},
+		{Name: "com.example/issues", Remotes: []model.Transport{{Type: model.TransportTypeStreamableHTTP, URL: remote.URL + "/mcp"}}},
+		{Name: "io.github.acme/no-tools", Repository: &model.Repository{URL: "https://github.com/acme/no-tools", Source: "github"}},
+	} {
+		server.Schema = model.CurrentSchemaURL
+		server.Description = "A server"
+		server.Version = "1.0.0"
+		_, err := service.CreateServer(ctx, server)
+		require.NoError(t, err)
+	}
+
+	indexer := toolindex.NewIndexer(cfg, probe.NewProber(time.Second, true))
+	indexer.SetGitHubBaseURL(github.URL)
+	indexed, err := service.IndexServerTools(ctx, indexer)
+	require.NoError(t, err)
+	assert.Equal(t, 3, indexed)
+
+	tools, err := service.GetServerTools(ctx, "io.github.acme/postgres")
+	require.NoError(t, err)
+	assert.Equal(t, database.ToolSourceManifest, tools.Source)
+	assert.Equal(t, "1.0.0", tools.Version)
+	assert.Equal(t, []database.ServerTool{{Name: "query", Description: "Run a read-only SQL query against PostgreSQL"}}, tools.Tools, "tools without a name are skipped")
+	tools, err = service.GetServerTools(ctx, "com.example/issues")
+	require.NoError(t, err)
+	assert.Equal(t, database.ToolSourceRemote, tools.Source)
+	_, err = service.GetServerTools(ctx, "io.github.acme/no-tools")
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	servers, _, err := service.ListServers(ctx, &database.ServerFilter{ToolKeywords: []string{"query", "postgres"}}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "io.github.acme/postgres", servers[0].Server.Name)
+
+	// Servers whose repository cannot be read keep their tools
+	githubDown.Store(true)
+	_, err = service.IndexServerTools(ctx, indexer)
+	require.NoError(t, err)
+	_, err = service.GetServerTools(ctx, "io.github.acme/postgres")
+	require.NoError(t, err)
+}
+
+func TestRemoteHealthBadge(t *testing.T) {
+	longAgo := time.Now().Add(-30 * 24 * time.Hour)
+	recently := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		remotes []apiv0.RemoteHealth
+		want    string
+	}{
+		{"all answering", []apiv0.RemoteHealth{{Status: apiv0.RemoteHealthy}, {Status: apiv0.RemoteAuthRequired}}, apiv0.RemoteBadgeHealthy},
+		{"some answering", []apiv0.RemoteHealth{{Status: apiv0.RemoteHealthy}, {Status: apiv0.RemoteUnhealthy, FailingSince: &longAgo}}, apiv0.RemoteBadgeDegraded},
+		{"failing recently", []apiv0.RemoteHealth{{Status: apiv0.RemoteUnhealthy, FailingSince: &longAgo}, {Status: apiv0.RemoteUnhealthy, FailingSince: &recently}}, apiv0.RemoteBadgeUnhealthy},
+		{"failing for weeks", []apiv0.RemoteHealth{{Status: apiv0.RemoteUnhealthy, FailingSince: &longAgo}}, apiv0.RemoteBadgeDead},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, remoteHealthBadge(tt.remotes, 14*24*time.Hour).Status)
+		})
+	}
+}
+
+func TestOrgAPIKeys(t *testing.T) {
+	ctx := context.Background()
+	service := NewTestRegistryService(t, database.NewMemory(), &config.Config{})
+
+	_, _, err := service.CreateOrgAPIKey(ctx, "com.example", "ci", []string{"org.other"}, "github-at:alice")
+	require.ErrorIs(t, err, ErrInvalidOrgAPIKey, "keys are limited to the organization's namespaces")
+	_, _, err = service.CreateOrgAPIKey(ctx, "com.example", "ci", []string{"com.examples"}, "github-at:alice")
+	require.ErrorIs(t, err, ErrInvalidOrgAPIKey, "a namespace sharing a prefix is a different organization")
+	_, _, err = service.CreateOrgAPIKey(ctx, "com.example", " ", []string{"com.example"}, "github-at:alice")
+	require.ErrorIs(t, err, ErrInvalidOrgAPIKey)
+
+	key, secret, err := service.CreateOrgAPIKey(ctx, "com.example", "ci", []string{"com.example", "com.example.tools"}, "github-at:alice")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, OrgAPIKeyPrefix))
+	assert.True(t, strings.HasPrefix(secret, key.KeyPrefix))
+	assert.Len(t, key.KeyHash, 64, "only a hash of the key is stored")
+	assert.Nil(t, key.LastUsedAt)
+
+	_, _, err = service.CreateOrgAPIKey(ctx, "com.example", "ci", []string{"com.example"}, "github-at:bob")
+	require.ErrorIs(t, err, database.ErrAlreadyExists)
+
+	// Authenticating records the last use
+	authenticated, err := service.AuthenticateOrgAPIKey(ctx, secret)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, authenticated.ID)
+	keys, err := service.ListOrgAPIKeys(ctx, "com.example")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].LastUsedAt)
+
+	_, err = service.AuthenticateOrgAPIKey(ctx, OrgAPIKeyPrefix+"wrong")
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// Other organizations cannot see or change the key
+	_, _, err = service.RotateOrgAPIKey(ctx, "org.other", key.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+	_, err = service.RevokeOrgAPIKey(ctx, "org.other", key.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// Rotating replaces the key and keeps its scope
+	rotated, newSecret, err := service.RotateOrgAPIKey(ctx, "com.example", key.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, newSecret)
+	assert.NotNil(t, rotated.RotatedAt)
+	assert.Equal(t, key.Namespaces, rotated.Namespaces)
+	_, err = service.AuthenticateOrgAPIKey(ctx, secret)
+	require.ErrorIs(t, err, database.ErrNotFound, "the old key stops working")
+	_, err = service.AuthenticateOrgAPIKey(ctx, newSecret)
+	require.NoError(t, err)
+
+	revoked, err := service.RevokeOrgAPIKey(ctx, "com.example", key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ci", revoked.Name)
+	_, err = service.AuthenticateOrgAPIKey(ctx, newSecret)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestOrgMembers(t *testing.T) {
+	ctx := context.Background()
+	service := NewTestRegistryService(t, database.NewMemory(), &config.Config{})
+
+	for _, subject := range []string{"alice", "github-at:", "github-oidc:acme/ci", "api-key:com.example/ci", "dns:example.com"} {
+		_, err := service.AddOrgMember(ctx, "com.example", subject, "dns:example.com")
+		require.ErrorIs(t, err, ErrInvalidOrgMember, "%s does not identify a person", subject)
+	}
+
+	member, err := service.AddOrgMember(ctx, "com.example", "github-at:alice", "dns:example.com")
+	require.NoError(t, err)
+	_, err = service.AddOrgMember(ctx, "com.example", "github-at:alice", "dns:example.com")
+	require.ErrorIs(t, err, database.ErrAlreadyExists)
+
+	isMember, err := service.IsOrgMember(ctx, "com.example", "github-at:alice")
+	require.NoError(t, err)
+	assert.True(t, isMember)
+	isMember, err = service.IsOrgMember(ctx, "org.other", "github-at:alice")
+	require.NoError(t, err)
+	assert.False(t, isMember)
+
+	// Other organizations cannot remove the member
+	_, err = service.RemoveOrgMember(ctx, "org.other", member.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	removed, err := service.RemoveOrgMember(ctx, "com.example", member.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "github-at:alice", removed.Subject)
+	members, err := service.ListOrgMembers(ctx, "com.example")
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
+
+func TestEncryptedColumns(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemory()
+	key := func(id string, fill byte) string {
+		return id + ":" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{fill}, 32))
+	}
+
+	// An entry and a webhook secret written before encryption was enabled
+	plain := NewTestRegistryService(t, db, &config.Config{})
+	require.NoError(t, plain.RecordAuditEntry(ctx, database.AuditEntry{Action: database.AuditActionPublish, ClientIP: "198.51.100.1"}))
+	plainWebhook, err := plain.CreateWebhookSubscription(ctx, database.WebhookSubscription{URL: "https://consumer.example.com/plain"})
+	require.NoError(t, err)
+
+	cfg := &config.Config{EncryptionKeyProvider: "local", EncryptionKeys: []string{key("k1", 1)}}
+	service := NewTestRegistryService(t, db, cfg)
+	require.NoError(t, service.RecordAuditEntry(ctx, database.AuditEntry{Action: database.AuditActionPublish, ClientIP: "203.0.113.7"}))
+	webhook, err := service.CreateWebhookSubscription(ctx, database.WebhookSubscription{URL: "https://consumer.example.com/encrypted"})
+	require.NoError(t, err)
+	require.NotEmpty(t, webhook.Secret)
+
+	storedWebhook, err := db.GetWebhookSubscription(ctx, nil, webhook.ID)
+	require.NoError(t, err)
+	assert.True(t, encryption.IsEncrypted(storedWebhook.Secret), "the webhook secret is encrypted at rest")
+	hidden, err := service.GetWebhookSubscription(ctx, webhook.ID)
+	require.NoError(t, err)
+	assert.Empty(t, hidden.Secret, "the secret is only returned when it is created")
+
+	stored, err := db.ListAuditEntries(ctx, nil, nil, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	assert.Equal(t, "198.51.100.1", stored[0].ClientIP)
+	assert.True(t, encryption.IsEncrypted(stored[1].ClientIP), "the address is encrypted at rest")
+
+	entries, err := service.ListAuditEntries(ctx, nil, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.1", entries[0].ClientIP)
+	assert.Equal(t, "203.0.113.7", entries[1].ClientIP)
+
+	// Rotating to a new key re-encrypts both entries and both secrets, after which the old key is no longer needed
+	cfg.EncryptionKeys = []string{key("k2", 2), key("k1", 1)}
+	rotated, err := NewTestRegistryService(t, db, cfg).RotateEncryptedColumns(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, rotated)
+
+	cfg.EncryptionKeys = []string{key("k2", 2)}
+	entries, err = NewTestRegistryService(t, db, cfg).ListAuditEntries(ctx, nil, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.1", entries[0].ClientIP)
+	assert.Equal(t, "203.0.113.7", entries[1].ClientIP)
+
+	rotated, err = NewTestRegistryService(t, db, cfg).RotateEncryptedColumns(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, rotated, "rotation is idempotent")
+
+	storedWebhook, err = db.GetWebhookSubscription(ctx, nil, plainWebhook.ID)
+	require.NoError(t, err)
+	assert.True(t, encryption.IsEncrypted(storedWebhook.Secret), "rotation encrypts secrets written before encryption was enabled")
+
+	_, err = plain.RotateEncryptedColumns(ctx)
+	require.ErrorIs(t, err, encryption.ErrNotConfigured)
+}
+
+func TestCreateServerRecordsPublisher(t *testing.T) {
+	ctx := context.Background()
+	service := NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+
+	publisher := &apiv0.Publisher{AuthMethod: "github-oidc", Subject: "repo:acme/weather:ref:refs/heads/main", GitHubOrg: "acme"}
+	created, err := service.CreateServer(WithPublisher(ctx, publisher), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.acme/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, publisher, created.Meta.Official.Publisher)
+	assert.True(t, created.Meta.Official.VerifiedPublisher)
+
+	// Later versions record their own publisher, and edits keep it
+	_, err = service.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.acme/weather",
+		Description: "Weather server",
+		Version:     "1.1.0",
+	})
+	require.NoError(t, err)
+	latest, err := service.GetServerByName(ctx, "io.github.acme/weather", false)
+	require.NoError(t, err)
+	assert.Nil(t, latest.Meta.Official.Publisher)
+	assert.False(t, latest.Meta.Official.VerifiedPublisher)
+
+	edited := created.Server
+	edited.Description = "Weather forecasts"
+	updated, err := service.UpdateServer(ctx, "io.github.acme/weather", "1.0.0", &edited, nil)
+	require.NoError(t, err)
+	assert.Equal(t, publisher, updated.Meta.Official.Publisher)
+	assert.True(t, updated.Meta.Official.VerifiedPublisher)
+}
+
+func TestServerCuration(t *testing.T) {
+	ctx := context.Background()
+	service := NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+
+	_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	_, err = service.PutServerCuration(ctx, "com.example/missing", []string{"featured"}, nil, "", "admin")
+	require.ErrorIs(t, err, database.ErrNotFound)
+	_, err = service.PutServerCuration(ctx, "com.example/weather", nil, nil, "", "admin")
+	require.ErrorIs(t, err, ErrInvalidCuration)
+	_, err = service.PutServerCuration(ctx, "com.example/weather", []string{"trending"}, nil, "", "admin")
+	require.ErrorIs(t, err, ErrInvalidCuration)
+	_, err = service.PutServerCuration(ctx, "com.example/weather", []string{"official", "community"}, nil, "", "admin")
+	require.ErrorIs(t, err, ErrInvalidCuration)
+
+	position := 3
+	curation, err := service.PutServerCuration(ctx, "com.example/weather", []string{"official", "featured", "official"}, &position, "vendor", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"featured", "official"}, curation.Labels)
+
+	server, err := service.GetServerByName(ctx, "com.example/weather", false)
+	require.NoError(t, err)
+	require.NotNil(t, server.Meta.Official.Curation)
+	assert.Equal(t, []string{"featured", "official"}, server.Meta.Official.Curation.Labels)
+	assert.Equal(t, &position, server.Meta.Official.Curation.Position)
+
+	require.NoError(t, service.DeleteServerCuration(ctx, "com.example/weather"))
+	server, err = service.GetServerByName(ctx, "com.example/weather", false)
+	require.NoError(t, err)
+	assert.Nil(t, server.Meta.Official.Curation)
+}
+
+func TestVerifyServerDocuments(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemory()
+	service := NewTestRegistryService(t, db, &config.Config{EnableRegistryValidation: false})
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather",
+			Description: "Weather server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	verification, err := service.VerifyServerDocuments(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, verification.Checked)
+	assert.Empty(t, verification.Issues)
+	stored, err := service.BackfillServerDocuments(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, stored, "every version already has its document")
+
+	// A version whose server.json no longer matches its document is reported
+	_, err = db.PutServerDocument(ctx, nil, "com.example/weather", "1.1.0", []byte(`{"name":"com.example/weather","version":"1.1.0"}`))
+	require.NoError(t, err)
+	verification, err = service.VerifyServerDocuments(ctx)
+	require.NoError(t, err)
+	require.Len(t, verification.Issues, 1)
+	assert.Equal(t, "1.1.0", verification.Issues[0].Version)
+	assert.Equal(t, DocumentProblemMismatch, verification.Issues[0].Problem)
+
+	// Responses carry the digest of the document served for the version
+	server, err := service.GetServerByNameAndVersion(ctx, "com.example/weather", "1.0.0", false)
+	require.NoError(t, err)
+	_, digest, err := apiv0.ServerDocument(&server.Server)
+	require.NoError(t, err)
+	assert.Equal(t, digest, server.Meta.Official.DocumentDigest)
+
+	// Versions published with their submitted document keep it byte for byte
+	submitted := []byte("{\n  \"$schema\": \"" + model.CurrentSchemaURL + "\",\n  \"name\": \"com.example/weather\",\n" +
+		"  \"version\": \"1.2.0\",\n  \"description\": \"Weather server\"\n}\n")
+	published, err := service.CreateServer(WithDocument(ctx, submitted), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.2.0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, apiv0.DocumentDigest(submitted), published.Meta.Official.DocumentDigest)
+	server, err = service.GetServerByNameAndVersion(ctx, "com.example/weather", "1.2.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, apiv0.DocumentDigest(submitted), server.Meta.Official.DocumentDigest)
+	document, err := service.GetServerDocument(ctx, server.Meta.Official.DocumentDigest)
+	require.NoError(t, err)
+	assert.Equal(t, submitted, document)
+	verification, err = service.VerifyServerDocuments(ctx)
+	require.NoError(t, err)
+	assert.Len(t, verification.Issues, 1, "a submitted document holding the version's server.json matches it")
+}
+
+// unpublishedPackages reports every package as missing from its registry
+type unpublishedPackages struct{}
+
+func (unpublishedPackages) CheckPackage(context.Context, model.Package, string) error {
+	return registries.ErrPackageNotFound
+}
+
+func TestSweepStaleServers(t *testing.T) {
+	ctx := context.Background()
+	issues := 0
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/acme/active":
+			_, _ = w.Write([]byte(`{"archived":false,"has_issues":true}`))
+		case "GET /repos/acme/archived":
+			_, _ = w.Write([]byte(`{"archived":true,"has_issues":true}`))
+		case "POST /repos/acme/active/issues":
+			issues++
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer github.Close()
+
+	db := database.NewMemory()
+	cfg := &config.Config{GitHubAPIToken: "token", StaleAfter: 0, StaleGracePeriod: 30 * 24 * time.Hour}
+	service := NewTestRegistryService(t, db, cfg)
+	publish := func(name, repo, version string, packages ...model.Package) {
+		t.Helper()
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "A server",
+			Version:     version,
+			Repository:  &model.Repository{URL: "https://github.com/acme/" + repo, Source: "github"},
+			Packages:    packages,
+		})
+		require.NoError(t, err)
+	}
+	publish("io.github.acme/active", "active", "1.0.0")
+	publish("io.github.acme/archived", "archived", "1.0.0")
+	publish("io.github.acme/deleted", "deleted", "1.0.0")
+	publish("io.github.acme/unpublished", "active", "1.0.0",
+		model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "@acme/unpublished", Version: "1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}})
+
+	checker := stale.NewChecker(cfg, unpublishedPackages{})
+	checker.SetGitHubBaseURL(github.URL)
+
+	// Servers found stale are in their grace period, so they are not flagged yet
+	result, err := service.SweepStaleServers(ctx, checker)
+	require.NoError(t, err)
+	assert.Equal(t, &StaleSweepResult{Checked: 4, Stale: 3, Notified: 1}, result)
+	assert.Equal(t, 1, issues, "only the maintainers of a repository that accepts issues are notified")
+	tracked, err := service.ListStaleServers(ctx)
+	require.NoError(t, err)
+	require.Len(t, tracked, 3)
+	server, err := service.GetServerByName(ctx, "io.github.acme/archived", false)
+	require.NoError(t, err)
+	assert.Nil(t, server.Meta.Official.Unmaintained)
+
+	// Servers still stale after the grace period are marked unmaintained, without notifying anyone again
+	cfg.StaleGracePeriod = 0
+	result, err = service.SweepStaleServers(ctx, checker)
+	require.NoError(t, err)
+	assert.Equal(t, &StaleSweepResult{Checked: 4, Stale: 3, MarkedUnmaintained: 3}, result)
+	assert.Equal(t, 1, issues)
+	server, err = service.GetServerByName(ctx, "io.github.acme/archived", false)
+	require.NoError(t, err)
+	require.NotNil(t, server.Meta.Official.Unmaintained)
+	assert.Equal(t, []string{database.StaleReasonRepositoryArchived}, server.Meta.Official.Unmaintained.Reasons)
+
+	unmaintained := true
+	servers, _, err := service.ListServers(ctx, &database.ServerFilter{Unmaintained: &unmaintained}, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, servers, 3)
+	unmaintained = false
+	servers, _, err = service.ListServers(ctx, &database.ServerFilter{Unmaintained: &unmaintained}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "io.github.acme/active", servers[0].Server.Name)
+
+	// Publishing a new version clears the flag, and recently updated servers are no longer tracked
+	publish("io.github.acme/archived", "archived", "1.1.0")
+	server, err = service.GetServerByName(ctx, "io.github.acme/archived", false)
+	require.NoError(t, err)
+	assert.Nil(t, server.Meta.Official.Unmaintained)
+
+	cfg.StaleAfter = time.Hour
+	result, err = service.SweepStaleServers(ctx, checker)
+	require.NoError(t, err)
+	assert.Equal(t, &StaleSweepResult{Cleared: 2}, result)
+	tracked, err = service.ListStaleServers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, tracked)
+}
+
+// deadlockingDB is a database whose next transactions fail with a deadlock
+type deadlockingDB struct {
+	*database.Memory
+	failures int
+}
+
+func (db *deadlockingDB) InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error {
+	if db.failures > 0 {
+		db.failures--
+		return &pgconn.PgError{Code: "40P01", Message: "deadlock detected"}
+	}
+	return db.Memory.InTransaction(ctx, fn)
+}
+
+func TestRunNextPendingPublish_RetriesTransientFailures(t *testing.T) {
+	ctx := context.Background()
+	db := &deadlockingDB{Memory: database.NewMemory()}
+	service := NewTestRegistryService(t, db, &config.Config{EnableRegistryValidation: false})
+	submit := func(version string) *database.PendingPublish {
+		t.Helper()
+		publish, err := service.SubmitPublish(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/flaky",
+			Description: "A test server",
+			Version:     version,
+		}, "github-at:alice")
+		require.NoError(t, err)
+		return publish
+	}
+
+	t.Run("retries later", func(t *testing.T) {
+		submitted := submit("1.0.0")
+		db.failures = 1
+		retried, err := service.RunNextPendingPublish(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, retried)
+		assert.Equal(t, database.PublishPending, retried.State)
+		assert.Equal(t, 1, retried.Attempts)
+		assert.Contains(t, retried.Error, "deadlock detected")
+		assert.True(t, retried.NextAttemptAt.After(time.Now()))
+
+		ran, err := service.RunNextPendingPublish(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, ran, "the publish waits until its retry is due")
+
+		_, err = db.RetryPendingPublish(ctx, nil, submitted.ID, retried.Error, time.Now())
+		require.NoError(t, err)
+		ran, err = service.RunNextPendingPublish(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, ran)
+		assert.Equal(t, database.PublishActive, ran.State)
+	})
+
+	t.Run("rejects after too many attempts", func(t *testing.T) {
+		submitted := submit("2.0.0")
+		for range pendingPublishMaxAttempts - 1 {
+			_, err := db.RetryPendingPublish(ctx, nil, submitted.ID, "deadlock detected", time.Now())
+			require.NoError(t, err)
+		}
+		db.failures = 1
+		ran, err := service.RunNextPendingPublish(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, ran)
+		assert.Equal(t, database.PublishRejected, ran.State)
+		assert.Contains(t, ran.Error, "deadlock detected")
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryDelay(0))
+	assert.Equal(t, time.Minute, retryDelay(1))
+	assert.Equal(t, 8*time.Minute, retryDelay(4))
+	assert.Equal(t, time.Hour, retryDelay(9))
+}
+
+// unlistenableDB is a database whose event notifications are unavailable
+type unlistenableDB struct {
+	*database.Memory
+}
+
+func (unlistenableDB) ListenForEvents(context.Context, func(), func(database.Event)) error {
+	return fmt.Errorf("notifications unavailable")
+}
+
+func TestSubscribeChanges(t *testing.T) {
+	publish := func(t *testing.T, service RegistryService, name string) {
+		t.Helper()
+		_, err := service.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+	receive := func(t *testing.T, changes <-chan *apiv0.ServerChange) *apiv0.ServerChange {
+		t.Helper()
+		select {
+		case change, ok := <-changes:
+			require.True(t, ok, "stream ended")
+			return change
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no change received")
+			return nil
+		}
+	}
+
+	t.Run("catches up and then streams changes", func(t *testing.T) {
+		service := NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+		publish(t, service, "com.example/alpha")
+		publish(t, service, "com.example/beta")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		changes, err := service.SubscribeChanges(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "com.example/beta", receive(t, changes).Server.Server.Name)
+
+		publish(t, service, "com.example/gamma")
+		change := receive(t, changes)
+		assert.Equal(t, "com.example/gamma", change.Server.Server.Name)
+		assert.Equal(t, int64(3), change.Seq)
+
+		cancel()
+		for range changes {
+		}
+	})
+
+	t.Run("polls while notifications are unavailable", func(t *testing.T) {
+		impl := NewTestRegistryService(t, unlistenableDB{database.NewMemory()}, &config.Config{EnableRegistryValidation: false}).(*registryServiceImpl)
+		impl.events.pollInterval = 10 * time.Millisecond
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		changes, err := impl.SubscribeChanges(ctx, 0)
+		require.NoError(t, err)
+
+		publish(t, impl, "com.example/alpha")
+		assert.Equal(t, "com.example/alpha", receive(t, changes).Server.Server.Name)
+	})
+
+	t.Run("announces maintenance mode changes", func(t *testing.T) {
+		service := NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		changed := make(chan struct{}, 1)
+		wait := func() {
+			t.Helper()
+			select {
+			case <-changed:
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "maintenance change not announced")
+			}
+		}
+		require.NoError(t, service.SubscribeMaintenance(ctx, func() { changed <- struct{}{} }))
+		// Subscribers are told when listening starts, since changes may have been missed until then
+		wait()
+		_, err := service.SetMaintenanceState(ctx, true, nil, 60)
+		require.NoError(t, err)
+		wait()
+	})
+}
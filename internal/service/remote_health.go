@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/probe"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ProbeRemotes probes every remote declared by the latest version of each server and records the results,
+// returning how many remotes were probed. Results for remotes no longer declared by any server are removed.
+func (s *registryServiceImpl) ProbeRemotes(ctx context.Context, prober *probe.Prober) (int, error) {
+	started := time.Now()
+
+	isLatest := true
+	filter := &database.ServerFilter{IsLatest: &isLatest}
+	probed := map[string]bool{}
+	cursor := ""
+	for {
+		page, nextCursor, err := s.db.ListServers(ctx, nil, filter, cursor, bulkJobPageSize)
+		if err != nil {
+			return len(probed), fmt.Errorf("failed to list servers: %w", err)
+		}
+		for _, server := range page {
+			for _, remote := range server.Server.Remotes {
+				if !probe.Probeable(remote) || probed[remote.URL] {
+					continue
+				}
+				probed[remote.URL] = true
+				if err := s.recordProbe(ctx, remote.URL, prober.Probe(ctx, remote)); err != nil {
+					return len(probed), err
+				}
+			}
+		}
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	if _, err := s.db.DeleteRemoteHealthCheckedBefore(ctx, nil, started); err != nil {
+		return len(probed), fmt.Errorf("failed to delete stale remote health: %w", err)
+	}
+	return len(probed), nil
+}
+
+// recordProbe stores a probe result, carrying over when the remote last answered and when it started failing
+func (s *registryServiceImpl) recordProbe(ctx context.Context, url string, result probe.Result) error {
+	previous, err := s.db.GetRemoteHealth(ctx, nil, []string{url})
+	if err != nil {
+		return fmt.Errorf("failed to get remote health: %w", err)
+	}
+
+	checkedAt := time.Now()
+	health := apiv0.RemoteHealth{
+		URL:             url,
+		Status:          result.Status,
+		ProtocolVersion: result.ProtocolVersion,
+		LatencyMs:       result.Latency.Milliseconds(),
+		Error:           result.Error,
+		CheckedAt:       checkedAt,
+	}
+	if before, exists := previous[url]; exists {
+		health.LastHealthyAt = before.LastHealthyAt
+		health.FailingSince = before.FailingSince
+	}
+	if result.Status == apiv0.RemoteUnhealthy {
+		if health.FailingSince == nil {
+			health.FailingSince = &checkedAt
+		}
+	} else {
+		health.LastHealthyAt = &checkedAt
+		health.FailingSince = nil
+	}
+
+	if err := s.db.PutRemoteHealth(ctx, nil, health); err != nil {
+		return fmt.Errorf("failed to store remote health: %w", err)
+	}
+	return nil
+}
+
+// attachRemoteHealth adds a remote health badge to each server version with probed remotes, if probing is enabled
+func (s *registryServiceImpl) attachRemoteHealth(ctx context.Context, servers ...*apiv0.ServerResponse) error {
+	if !s.cfg.RemoteProbeEnabled {
+		return nil
+	}
+
+	var urls []string
+	for _, server := range servers {
+		for _, remote := range server.Server.Remotes {
+			if probe.Probeable(remote) && !slices.Contains(urls, remote.URL) {
+				urls = append(urls, remote.URL)
+			}
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+	health, err := s.db.GetRemoteHealth(ctx, nil, urls)
+	if err != nil {
+		return err
+	}
+
+	for _, server := range servers {
+		var remotes []apiv0.RemoteHealth
+		for _, remote := range server.Server.Remotes {
+			if result, exists := health[remote.URL]; exists && !slices.ContainsFunc(remotes, func(r apiv0.RemoteHealth) bool { return r.URL == remote.URL }) {
+				remotes = append(remotes, result)
+			}
+		}
+		if len(remotes) > 0 {
+			server.Meta.RemoteHealth = remoteHealthBadge(remotes, s.cfg.RemoteProbeDeadAfter)
+		}
+	}
+	return nil
+}
+
+// remoteHealthBadge summarizes the probe results of a server version's remotes. The server is dead if
+// every remote has been failing for longer than deadAfter.
+func remoteHealthBadge(remotes []apiv0.RemoteHealth, deadAfter time.Duration) *apiv0.RemoteHealthBadge {
+	badge := &apiv0.RemoteHealthBadge{Remotes: remotes, CheckedAt: remotes[0].CheckedAt}
+	answering, dead := 0, 0
+	for _, remote := range remotes {
+		if remote.CheckedAt.Before(badge.CheckedAt) {
+			badge.CheckedAt = remote.CheckedAt
+		}
+		switch {
+		case remote.Status != apiv0.RemoteUnhealthy:
+			answering++
+		case remote.FailingSince != nil && time.Since(*remote.FailingSince) > deadAfter:
+			dead++
+		}
+	}
+
+	switch {
+	case answering == len(remotes):
+		badge.Status = apiv0.RemoteBadgeHealthy
+	case answering > 0:
+		badge.Status = apiv0.RemoteBadgeDegraded
+	case dead == len(remotes):
+		badge.Status = apiv0.RemoteBadgeDead
+	default:
+		badge.Status = apiv0.RemoteBadgeUnhealthy
+	}
+	return badge
+}
+
+// RunRemoteProbes probes every declared remote endpoint every interval until ctx is cancelled
+func RunRemoteProbes(ctx context.Context, registry RegistryService, prober *probe.Prober, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		probed, err := registry.ProbeRemotes(ctx, prober)
+		if err != nil {
+			log.Printf("Remote probing failed after %d remotes: %v", probed, err)
+		} else {
+			log.Printf("Probed %d remote endpoints", probed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// ErrInvalidRename is returned when a server is renamed to its own name or one that is not a valid server name
+var ErrInvalidRename = errors.New("invalid rename")
+
+// RenameServer moves every version of a server to a new name the way a namespace transfer does, then keeps
+// the old name as an alias of the new one for the configured deprecation window. Aliases of the old name move
+// to the new one, so chains of renames resolve in one step, and the rename is recorded in the changes feed.
+func (s *registryServiceImpl) RenameServer(ctx context.Context, serverName, newName, renamedBy string) (*database.ServerAlias, error) {
+	if newName == serverName {
+		return nil, fmt.Errorf("%w: new name must differ from the current name", ErrInvalidRename)
+	}
+	if err := validator.ValidateServerName(newName); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRename, err)
+	}
+
+	latest, err := s.db.GetServerByName(ctx, nil, serverName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Renames can't get around the name screening publishes go through
+	renamed := latest.Server
+	renamed.Name = newName
+	if err := s.ScreenServer(ctx, &renamed); err != nil {
+		return nil, err
+	}
+
+	alias, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.ServerAlias, error) {
+		// Versions are never merged into another server's history. Locking both names first keeps a concurrent
+		// publish of the new name from landing between this check and the transfer.
+		if err := s.acquirePublishLocks(ctx, tx, serverName, newName); err != nil {
+			return nil, err
+		}
+		if _, err := s.db.GetServerByName(ctx, tx, newName, true); err == nil {
+			return nil, fmt.Errorf("%w: a server named %s already exists", database.ErrAlreadyExists, newName)
+		} else if !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+
+		if err := s.transferServerInTransaction(ctx, tx, serverName, newName); err != nil {
+			return nil, err
+		}
+
+		// The new name is a server again, so it stops redirecting anywhere
+		if err := s.db.DeleteServerAlias(ctx, tx, newName); err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		if _, err := s.db.RetargetServerAliases(ctx, tx, serverName, newName); err != nil {
+			return nil, err
+		}
+
+		return s.db.CreateServerAlias(ctx, tx, database.ServerAlias{
+			Alias:      serverName,
+			ServerName: newName,
+			CreatedBy:  renamedBy,
+			ExpiresAt:  time.Now().Add(s.cfg.ServerAliasDuration),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.purgeServerCache(serverName)
+	s.purgeServerCache(newName)
+	return alias, nil
+}
+
+// GetServerAlias returns an alias that has not expired
+func (s *registryServiceImpl) GetServerAlias(ctx context.Context, alias string) (*database.ServerAlias, error) {
+	return s.db.GetServerAlias(ctx, nil, alias)
+}
+
+// ListServerAliases returns the names a server was renamed from that still redirect to it
+func (s *registryServiceImpl) ListServerAliases(ctx context.Context, serverName string) ([]*database.ServerAlias, error) {
+	return s.db.ListServerAliases(ctx, nil, serverName)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// replayPageSize is the number of changes read from the changes feed per page when replaying
+const replayPageSize = 100
+
+// Headers sent with each change delivered to a webhook; the replay header marks replayed changes
+const (
+	ReplayEventSeqHeader = "X-Registry-Event-Seq"
+	ReplayEventHeader    = "X-Registry-Event-Replay"
+)
+
+var (
+	// ErrInvalidReplayTarget is returned when the replay target is not an absolute http or https URL
+	ErrInvalidReplayTarget = errors.New("replay target must be an absolute http or https URL")
+	// ErrInvalidReplayFormat is returned when the replay payload format is unknown
+	ErrInvalidReplayFormat = errors.New("replay format must be one of cloudevents, full, minimal")
+)
+
+var replayHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// ReplayOptions selects which recorded changes are replayed
+type ReplayOptions struct {
+	// Since replays changes with a sequence number greater than this value
+	Since int64
+	// Until stops after the change with this sequence number; 0 replays up to the latest change
+	Until int64
+	// Limit caps how many changes are delivered; 0 means no limit
+	Limit int
+	// Format is the payload format, as for webhook subscriptions; empty delivers CloudEvents
+	Format string
+	// Source is the CloudEvents source of cloudevents payloads
+	Source string
+}
+
+// ReplayResult reports how far a replay progressed
+type ReplayResult struct {
+	Delivered int   `json:"delivered" doc:"Number of changes delivered to the target"`
+	LastSeq   int64 `json:"lastSeq" doc:"Sequence number of the last delivered change; pass it as since to resume"`
+	Complete  bool  `json:"complete" doc:"Whether every selected change was delivered"`
+}
+
+// ReplayChanges re-delivers recorded changes to a webhook target, one POST per change in sequence order,
+// so a downstream consumer can recover after losing data. Each request body is the change in opts.Format,
+// as for webhook subscriptions. Delivery stops at the first change the target does not accept with a 2xx status;
+// the result then records the last change delivered so the replay can be resumed from there.
+func ReplayChanges(ctx context.Context, registry RegistryService, target string, opts ReplayOptions) (*ReplayResult, error) {
+	if err := validateReplayTarget(target); err != nil {
+		return nil, err
+	}
+	if opts.Format == "" {
+		opts.Format = database.WebhookFormatCloudEvents
+	}
+	if !validChangeFormat(opts.Format) {
+		return nil, ErrInvalidReplayFormat
+	}
+
+	result := &ReplayResult{LastSeq: opts.Since}
+	for {
+		changes, err := registry.ListServerChanges(ctx, result.LastSeq, replayPageSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to read changes after %d: %w", result.LastSeq, err)
+		}
+
+		for _, change := range changes {
+			if (opts.Until > 0 && change.Seq > opts.Until) || (opts.Limit > 0 && result.Delivered >= opts.Limit) {
+				result.Complete = opts.Until > 0 && change.Seq > opts.Until
+				return result, nil
+			}
+			if err := deliverChange(ctx, target, change, opts); err != nil {
+				return result, fmt.Errorf("failed to deliver change %d: %w", change.Seq, err)
+			}
+			result.Delivered++
+			result.LastSeq = change.Seq
+		}
+
+		if len(changes) < replayPageSize {
+			result.Complete = true
+			return result, nil
+		}
+	}
+}
+
+func validateReplayTarget(target string) error {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidReplayTarget
+	}
+	return nil
+}
+
+func deliverChange(ctx context.Context, target string, change *apiv0.ServerChange, opts ReplayOptions) error {
+	body, contentType, err := changePayload(opts.Format, change, opts.Source)
+	if err != nil {
+		return err
+	}
+	return postChange(ctx, target, change.Seq, contentType, body, true, "")
+}
+
+// postChange posts a payload describing a change to a webhook, with signature as its WebhookSignatureHeader if
+// set, failing unless it responds with a 2xx status
+func postChange(ctx context.Context, target string, seq int64, contentType string, body []byte, replay bool, signature string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(ReplayEventSeqHeader, strconv.FormatInt(seq, 10))
+	if replay {
+		req.Header.Set(ReplayEventHeader, "true")
+	}
+	if signature != "" {
+		req.Header.Set(WebhookSignatureHeader, signature)
+	}
+
+	resp, err := replayHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("target responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestReplayChanges(t *testing.T) {
+	ctx := context.Background()
+	registry := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+	for i := 1; i <= 5; i++ {
+		_, err := registry.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        fmt.Sprintf("com.example/server-%d", i),
+			Description: "A test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	type delivery struct {
+		seq    int64
+		replay string
+		name   string
+	}
+	newTarget := func(t *testing.T, failAtSeq int64) (*httptest.Server, *[]delivery) {
+		t.Helper()
+		var mu sync.Mutex
+		var received []delivery
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var change apiv0.ServerChange
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&change))
+			seq, _ := strconv.ParseInt(r.Header.Get(service.ReplayEventSeqHeader), 10, 64)
+			assert.Equal(t, change.Seq, seq)
+			if seq == failAtSeq {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			mu.Lock()
+			received = append(received, delivery{seq: seq, replay: r.Header.Get(service.ReplayEventHeader), name: change.Server.Server.Name})
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(server.Close)
+		return server, &received
+	}
+
+	t.Run("delivers every change after since in order", func(t *testing.T) {
+		target, received := newTarget(t, -1)
+		result, err := service.ReplayChanges(ctx, registry, target.URL, service.ReplayOptions{Since: 2, Format: database.WebhookFormatFull})
+		require.NoError(t, err)
+		assert.Equal(t, &service.ReplayResult{Delivered: 3, LastSeq: 5, Complete: true}, result)
+		require.Len(t, *received, 3)
+		for i, d := range *received {
+			assert.Equal(t, int64(i+3), d.seq)
+			assert.Equal(t, "true", d.replay)
+			assert.Equal(t, fmt.Sprintf("com.example/server-%d", i+3), d.name)
+		}
+	})
+
+	t.Run("stops at until", func(t *testing.T) {
+		target, received := newTarget(t, -1)
+		result, err := service.ReplayChanges(ctx, registry, target.URL, service.ReplayOptions{Until: 3, Format: database.WebhookFormatFull})
+		require.NoError(t, err)
+		assert.Equal(t, &service.ReplayResult{Delivered: 3, LastSeq: 3, Complete: true}, result)
+		assert.Len(t, *received, 3)
+	})
+
+	t.Run("stops at limit", func(t *testing.T) {
+		target, received := newTarget(t, -1)
+		result, err := service.ReplayChanges(ctx, registry, target.URL, service.ReplayOptions{Limit: 2, Format: database.WebhookFormatFull})
+		require.NoError(t, err)
+		assert.Equal(t, &service.ReplayResult{Delivered: 2, LastSeq: 2, Complete: false}, result)
+		assert.Len(t, *received, 2)
+	})
+
+	t.Run("reports progress when the target rejects a change", func(t *testing.T) {
+		target, received := newTarget(t, 4)
+		result, err := service.ReplayChanges(ctx, registry, target.URL, service.ReplayOptions{Format: database.WebhookFormatFull})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "change 4")
+		assert.Equal(t, &service.ReplayResult{Delivered: 3, LastSeq: 3, Complete: false}, result)
+		assert.Len(t, *received, 3)
+	})
+
+	t.Run("delivers CloudEvents by default", func(t *testing.T) {
+		var events []apiv0.CloudEvent
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, apiv0.CloudEventsContentType, r.Header.Get("Content-Type"))
+			var event apiv0.CloudEvent
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+			events = append(events, event)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer target.Close()
+
+		result, err := service.ReplayChanges(ctx, registry, target.URL, service.ReplayOptions{
+			Since:  4,
+			Source: "https://registry.example.com",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Delivered)
+		require.Len(t, events, 1)
+		assert.Equal(t, "5", events[0].ID)
+		assert.Equal(t, "https://registry.example.com", events[0].Source)
+		assert.Equal(t, apiv0.EventTypeServerPublished, events[0].Type)
+		assert.Equal(t, "com.example/server-5@1.0.0", events[0].Subject)
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		_, err := service.ReplayChanges(ctx, registry, "https://example.com/hooks", service.ReplayOptions{Format: "xml"})
+		assert.ErrorIs(t, err, service.ErrInvalidReplayFormat)
+	})
+
+	t.Run("rejects non-http targets", func(t *testing.T) {
+		_, err := service.ReplayChanges(ctx, registry, "file:///etc/passwd", service.ReplayOptions{})
+		assert.ErrorIs(t, err, service.ErrInvalidReplayTarget)
+	})
+}
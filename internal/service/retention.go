@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// pruneBatchSize is the number of versions deleted per transaction when pruning
+const pruneBatchSize = 500
+
+// RetentionPolicy limits how many versions of each server are kept and for how long.
+// The latest version of a server is always kept. A zero value disables the corresponding limit.
+type RetentionPolicy struct {
+	MaxVersionsPerServer int
+	MaxAge               time.Duration
+}
+
+// RetentionPolicyFromConfig returns the retention policy configured for the registry
+func RetentionPolicyFromConfig(cfg *config.Config) RetentionPolicy {
+	return RetentionPolicy{
+		MaxVersionsPerServer: cfg.RetentionMaxVersionsPerServer,
+		MaxAge:               cfg.RetentionMaxAge,
+	}
+}
+
+// Enabled reports whether the policy limits anything
+func (p RetentionPolicy) Enabled() bool {
+	return p.MaxVersionsPerServer > 0 || p.MaxAge > 0
+}
+
+// publishedBefore returns the publish time cutoff for the maximum age, or nil when there is none
+func (p RetentionPolicy) publishedBefore() *time.Time {
+	if p.MaxAge <= 0 {
+		return nil
+	}
+	cutoff := time.Now().Add(-p.MaxAge)
+	return &cutoff
+}
+
+// ListPrunableVersions returns up to limit versions the policy would delete
+func (s *registryServiceImpl) ListPrunableVersions(ctx context.Context, policy RetentionPolicy, limit int) ([]*database.PrunableVersion, error) {
+	if !policy.Enabled() {
+		return []*database.PrunableVersion{}, nil
+	}
+
+	return s.db.ListPrunableVersions(ctx, nil, policy.MaxVersionsPerServer, policy.publishedBefore(), limit)
+}
+
+// PruneVersions permanently deletes every version the policy selects, in batches, and returns what was deleted
+func (s *registryServiceImpl) PruneVersions(ctx context.Context, policy RetentionPolicy) ([]*database.PrunableVersion, error) {
+	pruned := []*database.PrunableVersion{}
+	if !policy.Enabled() {
+		return pruned, nil
+	}
+
+	// Fix the age cutoff up front so batches agree on what is prunable
+	publishedBefore := policy.publishedBefore()
+	for {
+		batch, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) ([]*database.PrunableVersion, error) {
+			versions, err := s.db.ListPrunableVersions(ctx, tx, policy.MaxVersionsPerServer, publishedBefore, pruneBatchSize)
+			if err != nil {
+				return nil, err
+			}
+
+			deleted := make([]*database.PrunableVersion, 0, len(versions))
+			for _, version := range versions {
+				err := s.db.DeleteServerVersion(ctx, tx, version.ServerName, version.Version)
+				if errors.Is(err, database.ErrNotFound) {
+					// Already pruned concurrently
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to prune %s version %s: %w", version.ServerName, version.Version, err)
+				}
+				deleted = append(deleted, version)
+			}
+			return deleted, nil
+		})
+		if err != nil {
+			return pruned, err
+		}
+
+		purged := map[string]bool{}
+		for _, version := range batch {
+			if !purged[version.ServerName] {
+				s.purgeServerCache(version.ServerName)
+				purged[version.ServerName] = true
+			}
+		}
+		pruned = append(pruned, batch...)
+
+		if len(batch) < pruneBatchSize {
+			return pruned, nil
+		}
+	}
+}
+
+// DeleteServerVersion permanently deletes a single server version, as replicas do when their source pruned it
+func (s *registryServiceImpl) DeleteServerVersion(ctx context.Context, serverName, version string) error {
+	if err := s.db.DeleteServerVersion(ctx, nil, serverName, version); err != nil {
+		return err
+	}
+	s.purgeServerCache(serverName)
+	return nil
+}
+
+// RunRetention prunes versions according to policy every interval until ctx is cancelled
+func RunRetention(ctx context.Context, registry RegistryService, policy RetentionPolicy, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		pruned, err := registry.PruneVersions(ctx, policy)
+		if err != nil {
+			log.Printf("Retention pruning failed after deleting %d versions: %v", len(pruned), err)
+		} else if len(pruned) > 0 {
+			log.Printf("Retention pruning deleted %d versions", len(pruned))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ScreenServer checks a server's name, title and description against the reserved and prohibited terms,
+// unless an admin has exempted the server
+func (s *registryServiceImpl) ScreenServer(ctx context.Context, serverJSON *apiv0.ServerJSON) error {
+	screenErr := s.screener.Screen(serverJSON)
+	if screenErr == nil {
+		return nil
+	}
+
+	// Only look up exceptions for servers that fail, so screening costs nothing for the rest
+	_, err := s.db.GetScreeningException(ctx, nil, serverJSON.Name)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, database.ErrNotFound):
+		return screenErr
+	default:
+		return err
+	}
+}
+
+// PutScreeningException exempts a server from screening, replacing any existing exception for it
+func (s *registryServiceImpl) PutScreeningException(ctx context.Context, serverName, reason, createdBy string) (*database.ScreeningException, error) {
+	return s.db.PutScreeningException(ctx, nil, database.ScreeningException{
+		ServerName: serverName,
+		Reason:     reason,
+		CreatedBy:  createdBy,
+	})
+}
+
+// ListScreeningExceptions retrieves all screening exceptions, ordered by server name
+func (s *registryServiceImpl) ListScreeningExceptions(ctx context.Context) ([]*database.ScreeningException, error) {
+	return s.db.ListScreeningExceptions(ctx, nil)
+}
+
+// DeleteScreeningException removes a server's screening exception
+func (s *registryServiceImpl) DeleteScreeningException(ctx context.Context, serverName string) error {
+	return s.db.DeleteScreeningException(ctx, nil, serverName)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/toolindex"
+)
+
+// IndexServerTools indexes the tools exposed by the latest version of every server, so servers can be found by
+// tool keywords, and returns how many servers have indexed tools. Servers that no longer declare any tools are
+// removed from the index, while servers whose tools could not be read, such as when GitHub is unreachable, keep
+// their previous index.
+func (s *registryServiceImpl) IndexServerTools(ctx context.Context, indexer *toolindex.Indexer) (int, error) {
+	isLatest := true
+	filter := &database.ServerFilter{IsLatest: &isLatest}
+	indexed := 0
+	cursor := ""
+	for {
+		page, nextCursor, err := s.db.ListServers(ctx, nil, filter, cursor, bulkJobPageSize)
+		if err != nil {
+			return indexed, fmt.Errorf("failed to list servers: %w", err)
+		}
+		for _, server := range page {
+			tools, source, err := indexer.Tools(ctx, &server.Server)
+			if err != nil {
+				log.Printf("Skipping tool indexing of %s: %v", server.Server.Name, err)
+				continue
+			}
+			if len(tools) == 0 {
+				if err := s.db.DeleteServerTools(ctx, nil, server.Server.Name); err != nil && !errors.Is(err, database.ErrNotFound) {
+					return indexed, fmt.Errorf("failed to delete tools of %s: %w", server.Server.Name, err)
+				}
+				continue
+			}
+			if err := s.db.PutServerTools(ctx, nil, database.ServerTools{
+				ServerName: server.Server.Name,
+				Version:    server.Server.Version,
+				Source:     source,
+				Tools:      tools,
+			}); err != nil {
+				return indexed, fmt.Errorf("failed to store tools of %s: %w", server.Server.Name, err)
+			}
+			indexed++
+		}
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+	return indexed, nil
+}
+
+// GetServerTools retrieves the indexed tools of a server
+func (s *registryServiceImpl) GetServerTools(ctx context.Context, serverName string) (*database.ServerTools, error) {
+	return s.db.GetServerTools(ctx, nil, serverName)
+}
+
+// RunToolIndexing indexes the tools of every server every interval until ctx is cancelled
+func RunToolIndexing(ctx context.Context, registry RegistryService, indexer *toolindex.Indexer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		indexed, err := registry.IndexServerTools(ctx, indexer)
+		if err != nil {
+			log.Printf("Tool indexing failed after %d servers: %v", indexed, err)
+		} else {
+			log.Printf("Indexed the tools of %d servers", indexed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
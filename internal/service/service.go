@@ -2,10 +2,17 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/probe"
+	"github.com/modelcontextprotocol/registry/internal/stale"
+	"github.com/modelcontextprotocol/registry/internal/toolindex"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 )
 
 // StatusChangeRequest represents a request to change a server's status
@@ -16,20 +23,252 @@ type StatusChangeRequest struct {
 
 // RegistryService defines the interface for registry operations
 type RegistryService interface {
-	// ListServers retrieve all servers with optional filtering
+	// ListServers retrieve all servers with optional filtering, ordered by name and then version
 	ListServers(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
+	// CountServers count the servers matching a filter, reporting whether the count is an estimate
+	CountServers(ctx context.Context, filter *database.ServerFilter) (int, bool, error)
+	// SuggestServers suggest servers and namespaces for a partially typed name, for autocomplete
+	SuggestServers(ctx context.Context, prefix string, limit int) ([]database.ServerSuggestion, []string, error)
+	// ListNamespaces summarize namespaces with servers, optionally only parent and the namespaces beneath it, with cursor-based pagination
+	ListNamespaces(ctx context.Context, parent, cursor string, limit int) ([]*database.NamespaceSummary, string, error)
 	// GetServerByName retrieve latest version of a server by server name
 	GetServerByName(ctx context.Context, serverName string, includeDeleted bool) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
 	GetServerByNameAndVersion(ctx context.Context, serverName string, version string, includeDeleted bool) (*apiv0.ServerResponse, error)
-	// GetAllVersionsByServerName retrieve all versions of a server by server name
+	// GetAllVersionsByServerName retrieve all versions of a server by server name, newest first and then by
+	// version descending
 	GetAllVersionsByServerName(ctx context.Context, serverName string, includeDeleted bool) ([]*apiv0.ServerResponse, error)
+	// GetPackageProvenance retrieve the upstream provenance recorded for a server version's packages
+	GetPackageProvenance(ctx context.Context, serverName, version string) ([]apiv0.PackageProvenance, error)
+	// GetPackageArtifacts retrieve the artifacts mirrored for a server version's packages
+	GetPackageArtifacts(ctx context.Context, serverName, version string) ([]apiv0.PackageArtifact, error)
+	// MirrorPackageArtifacts mirror the artifacts of a server version's packages into the registry's storage
+	MirrorPackageArtifacts(ctx context.Context, serverName, version string) ([]apiv0.PackageArtifact, error)
+	// RunNextArtifactMirror mirror the artifacts of the oldest server version queued at publish, or schedule it to be
+	// retried when mirroring fails, returning nil if none is due
+	RunNextArtifactMirror(ctx context.Context) (*database.ArtifactMirror, error)
+	// OpenPackageArtifact open a mirrored artifact by its SHA-256
+	OpenPackageArtifact(ctx context.Context, sha256 string) (io.ReadCloser, error)
+	// ListServerChanges retrieves changes recorded after the given sequence number
+	ListServerChanges(ctx context.Context, since int64, limit int) ([]*apiv0.ServerChange, error)
+	// SubscribeChanges stream changes recorded after the given sequence number, then changes as they are recorded
+	SubscribeChanges(ctx context.Context, since int64) (<-chan *apiv0.ServerChange, error)
+	// ListPossibleDuplicates retrieve groups of servers sharing a repository URL or remote endpoint under different names
+	ListPossibleDuplicates(ctx context.Context) ([]*database.DuplicateGroup, error)
+	// FindPossibleDuplicates retrieve names of other servers sharing a repository URL or remote endpoint with the given server
+	FindPossibleDuplicates(ctx context.Context, serverName string) ([]string, error)
+	// ListExistingVersions retrieve the versions, including deleted ones, of each given server that has any, keyed by server name
+	ListExistingVersions(ctx context.Context, serverNames []string) (map[string][]string, error)
 	// CreateServer creates a new server version
 	CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
 	// UpdateServer updates an existing server and optionally its status
 	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, statusChange *StatusChangeRequest) (*apiv0.ServerResponse, error)
+	// ClaimTokenUse record the first exchange of an identity token until it expires, failing with
+	// ErrAlreadyExists when it was exchanged before
+	ClaimTokenUse(ctx context.Context, key string, expiresAt time.Time) error
+	// RenameServer moves every version of a server to a new name, keeping the old name as an alias of it
+	RenameServer(ctx context.Context, serverName, newName, renamedBy string) (*database.ServerAlias, error)
+	// GetServerAlias retrieve an alias that has not expired, returning ErrNotFound if the name is not one
+	GetServerAlias(ctx context.Context, alias string) (*database.ServerAlias, error)
+	// ListServerAliases retrieve the names a server was renamed from that still redirect to it
+	ListServerAliases(ctx context.Context, serverName string) ([]*database.ServerAlias, error)
 	// UpdateServerStatus updates only the status metadata of a server version
 	UpdateServerStatus(ctx context.Context, serverName, version string, statusChange *StatusChangeRequest) (*apiv0.ServerResponse, error)
+	// GetMaintenanceState retrieve the current maintenance mode state
+	GetMaintenanceState(ctx context.Context) (*database.MaintenanceState, error)
+	// SubscribeMaintenance call a function whenever the maintenance mode state changes
+	SubscribeMaintenance(ctx context.Context, fn func()) error
+	// SetMaintenanceState enables or disables maintenance mode
+	SetMaintenanceState(ctx context.Context, enabled bool, message *string, retryAfterSeconds int) (*database.MaintenanceState, error)
+	// RecordNamespaceVerificationAttempt adds login evidence to the pending verification request for a domain
+	RecordNamespaceVerificationAttempt(ctx context.Context, domain, method string, evidence database.VerificationEvidence) (*database.NamespaceVerification, error)
+	// AddNamespaceVerificationEvidence appends re-check evidence to a verification request
+	AddNamespaceVerificationEvidence(ctx context.Context, id int64, evidence database.VerificationEvidence) (*database.NamespaceVerification, error)
+	// GetNamespaceVerification retrieve a verification request by ID
+	GetNamespaceVerification(ctx context.Context, id int64) (*database.NamespaceVerification, error)
+	// ListNamespaceVerifications retrieve verification requests with the given status, or all if status is empty
+	ListNamespaceVerifications(ctx context.Context, status string) ([]*database.NamespaceVerification, error)
+	// ReviewNamespaceVerification approves or rejects a verification request
+	ReviewNamespaceVerification(ctx context.Context, id int64, approve bool, notes *string, reviewer string) (*database.NamespaceVerification, error)
+	// IsNamespaceApproved reports whether a domain namespace has been approved by an admin
+	IsNamespaceApproved(ctx context.Context, domain string) (bool, error)
+	// ListPrunableVersions retrieves up to limit versions that a retention policy would delete
+	ListPrunableVersions(ctx context.Context, policy RetentionPolicy, limit int) ([]*database.PrunableVersion, error)
+	// PruneVersions permanently deletes the versions selected by a retention policy
+	PruneVersions(ctx context.Context, policy RetentionPolicy) ([]*database.PrunableVersion, error)
+	// DeleteServerVersion permanently deletes a single server version, as replicas do when their source pruned it
+	DeleteServerVersion(ctx context.Context, serverName, version string) error
+	// RecordAuditEntry appends an entry to the audit log
+	RecordAuditEntry(ctx context.Context, entry database.AuditEntry) error
+	// ListAuditEntries retrieve up to limit audit entries matching a filter with an ID greater than afterID, oldest first
+	ListAuditEntries(ctx context.Context, filter *database.AuditFilter, afterID int64, limit int) ([]*database.AuditEntry, error)
+	// PruneAuditLog deletes audit entries older than maxAge and returns how many were deleted
+	PruneAuditLog(ctx context.Context, maxAge time.Duration) (int64, error)
+	// PruneServerChanges deletes changes feed entries older than maxAge, except the most recent one, and returns how many were deleted
+	PruneServerChanges(ctx context.Context, maxAge time.Duration) (int64, error)
+	// CreatePartitions creates the monthly partitions of the audit log and changes feed for the coming months and returns how many were created
+	CreatePartitions(ctx context.Context) (int, error)
+	// RecordPublishAttempt store a publish attempt for its publisher's history, quarantining the server.json of a rejected one
+	RecordPublishAttempt(ctx context.Context, attempt database.PublishAttempt, quarantined *database.QuarantinedPublish) error
+	// ListPublishAttempts retrieve an identity's publish attempts, newest first
+	ListPublishAttempts(ctx context.Context, identity string, beforeID int64, limit int) ([]*database.PublishAttempt, error)
+	// GetPublishAttempt retrieve one of an identity's publish attempts with its quarantined server.json, if any
+	GetPublishAttempt(ctx context.Context, identity string, id int64) (*database.PublishAttempt, *database.QuarantinedPublish, error)
+	// PrunePublishHistory delete publish attempts older than maxAge and return how many were deleted
+	PrunePublishHistory(ctx context.Context, maxAge time.Duration) (int64, error)
+	// PrunePublishQuarantine delete server.json documents quarantined longer than maxAge and return how many were deleted
+	PrunePublishQuarantine(ctx context.Context, maxAge time.Duration) (int64, error)
+	// GetMetricTimeSeries returns a metric's counts over the window ending now, one point per step
+	GetMetricTimeSeries(ctx context.Context, metric string, window, step time.Duration) (*MetricTimeSeries, error)
+	// RotateEncryptedColumns re-encrypts stored values not yet encrypted under the current key and returns how many were re-encrypted
+	RotateEncryptedColumns(ctx context.Context) (int, error)
+	// GetServerDocument retrieve a stored server.json document by its digest
+	GetServerDocument(ctx context.Context, digest string) ([]byte, error)
+	// GetPublishedDocument returns the server.json document a server version was published with, byte for byte
+	GetPublishedDocument(ctx context.Context, server *apiv0.ServerResponse) ([]byte, error)
+	// BackfillServerDocuments stores the documents of versions published before the document store existed
+	BackfillServerDocuments(ctx context.Context) (int, error)
+	// VerifyServerDocuments checks every server version against its stored document
+	VerifyServerDocuments(ctx context.Context) (*DocumentVerification, error)
+	// ReportInstall records an MCP client's report of whether installing and running a server version worked
+	ReportInstall(ctx context.Context, report database.InstallReport, clientAddress string) error
+	// PruneInstallReports deletes install reports older than the feedback window and returns how many were deleted
+	PruneInstallReports(ctx context.Context) (int64, error)
+	// PurgeUpstreamCache deletes expired cached upstream registry responses and returns how many were deleted
+	PurgeUpstreamCache(ctx context.Context) (int64, error)
+	// ProbeRemotes probes the remotes of every server's latest version, records the results, and returns how many remotes were probed
+	ProbeRemotes(ctx context.Context, prober *probe.Prober) (int, error)
+	// SweepStaleServers checks servers untouched for longer than the configured time for staleness, notifies the
+	// maintainers of newly stale servers, and marks servers unmaintained once their grace period has passed
+	SweepStaleServers(ctx context.Context, checker *stale.Checker) (*StaleSweepResult, error)
+	// ListStaleServers retrieve every stale server, including those already marked unmaintained, oldest first
+	ListStaleServers(ctx context.Context) ([]*database.ServerStaleness, error)
+	// IndexServerTools indexes the tools exposed by every server's latest version for search, and returns how many servers have indexed tools
+	IndexServerTools(ctx context.Context, indexer *toolindex.Indexer) (int, error)
+	// GetServerTools retrieves the indexed tools of a server
+	GetServerTools(ctx context.Context, serverName string) (*database.ServerTools, error)
+	// ScreenServer checks a server's name, title and description against the reserved and prohibited terms, unless it is exempt
+	ScreenServer(ctx context.Context, serverJSON *apiv0.ServerJSON) error
+	// PutScreeningException exempts a server from screening, replacing any existing exception for it
+	PutScreeningException(ctx context.Context, serverName, reason, createdBy string) (*database.ScreeningException, error)
+	// ListScreeningExceptions retrieve all screening exceptions, ordered by server name
+	ListScreeningExceptions(ctx context.Context) ([]*database.ScreeningException, error)
+	// DeleteScreeningException removes a server's screening exception
+	DeleteScreeningException(ctx context.Context, serverName string) error
+	// BanIdentity bans an identity from publishing and quarantines the servers it published, returning their names
+	BanIdentity(ctx context.Context, ban database.IdentityBan) (*database.IdentityBan, []string, error)
+	// ListIdentityBans retrieve all identity bans, including expired ones, ordered by kind and value
+	ListIdentityBans(ctx context.Context) ([]*database.IdentityBan, error)
+	// UnbanIdentity lifts an identity's ban and restores the servers it quarantined, returning their names
+	UnbanIdentity(ctx context.Context, kind, value string) ([]string, error)
+	// ReleaseExpiredBans restores the servers quarantined by expired bans, returning how many were restored
+	ReleaseExpiredBans(ctx context.Context) (int, error)
+	// CheckIdentityBan returns an error wrapping auth.ErrIdentityBanned when claims belong to a banned identity
+	CheckIdentityBan(ctx context.Context, claims *auth.JWTClaims) error
+	// PutServerCuration labels an existing server as featured, official or community-maintained, replacing its previous curation
+	PutServerCuration(ctx context.Context, serverName string, labels []string, position *int, note, updatedBy string) (*database.ServerCuration, error)
+	// ListServerCurations retrieve curated servers with label, or all curated servers if label is empty
+	ListServerCurations(ctx context.Context, label string) ([]*database.ServerCuration, error)
+	// DeleteServerCuration removes a server's curation
+	DeleteServerCuration(ctx context.Context, serverName string) error
+	// CreateOrgAPIKey creates an API key for an organization, scoped to its namespaces, and returns the key once
+	CreateOrgAPIKey(ctx context.Context, organization, name string, namespaces []string, createdBy string) (*database.OrgAPIKey, string, error)
+	// ListOrgAPIKeys retrieve an organization's API keys, oldest first
+	ListOrgAPIKeys(ctx context.Context, organization string) ([]*database.OrgAPIKey, error)
+	// RotateOrgAPIKey replaces an organization API key and returns the new key once
+	RotateOrgAPIKey(ctx context.Context, organization string, id int64) (*database.OrgAPIKey, string, error)
+	// RevokeOrgAPIKey permanently deletes an organization API key
+	RevokeOrgAPIKey(ctx context.Context, organization string, id int64) (*database.OrgAPIKey, error)
+	// AuthenticateOrgAPIKey looks up the organization API key matching a key and records that it was used
+	AuthenticateOrgAPIKey(ctx context.Context, secret string) (*database.OrgAPIKey, error)
+	// AddOrgMember lets a person manage an organization's API keys
+	AddOrgMember(ctx context.Context, organization, subject, addedBy string) (*database.OrgMember, error)
+	// ListOrgMembers retrieves an organization's members, oldest first
+	ListOrgMembers(ctx context.Context, organization string) ([]*database.OrgMember, error)
+	// RemoveOrgMember stops a member from managing an organization's API keys
+	RemoveOrgMember(ctx context.Context, organization string, id int64) (*database.OrgMember, error)
+	// IsOrgMember reports whether a subject is a member of an organization
+	IsOrgMember(ctx context.Context, organization, subject string) (bool, error)
+	// EnqueueBulkJob validates and queues an admin operation over many servers
+	EnqueueBulkJob(ctx context.Context, kind string, params database.BulkJobParams, createdBy string) (*database.BulkJob, error)
+	// GetBulkJob retrieve a bulk job and its progress
+	GetBulkJob(ctx context.Context, id int64) (*database.BulkJob, error)
+	// ListBulkJobs retrieve the most recently queued bulk jobs, newest first
+	ListBulkJobs(ctx context.Context, limit int) ([]*database.BulkJob, error)
+	// RunNextBulkJob runs the oldest unfinished bulk job, returning nil if there is none
+	RunNextBulkJob(ctx context.Context) (*database.BulkJob, error)
+	// SubmitPublish accepts a server version for asynchronous publishing after the checks that need no upstream requests
+	SubmitPublish(ctx context.Context, req *apiv0.ServerJSON, submittedBy string) (*database.PendingPublish, error)
+	// GetPendingPublish retrieve an asynchronous publish and its outcome
+	GetPendingPublish(ctx context.Context, id int64) (*database.PendingPublish, error)
+	// RunNextPendingPublish completes or rejects the oldest pending publish, or schedules it to be retried when a check
+	// fails for a transient reason, returning nil if none is due
+	RunNextPendingPublish(ctx context.Context) (*database.PendingPublish, error)
+	// PrunePendingPublishes delete asynchronous publishes that finished past their retention and return how many were deleted
+	PrunePendingPublishes(ctx context.Context) (int64, error)
+	// CreateWebhookSubscription validates and stores a webhook subscription, which receives changes recorded from now on
+	CreateWebhookSubscription(ctx context.Context, subscription database.WebhookSubscription) (*database.WebhookSubscription, error)
+	// GetWebhookSubscription retrieve a webhook subscription and its delivery state
+	GetWebhookSubscription(ctx context.Context, id int64) (*database.WebhookSubscription, error)
+	// ListWebhookSubscriptions retrieve all webhook subscriptions, oldest first
+	ListWebhookSubscriptions(ctx context.Context) ([]*database.WebhookSubscription, error)
+	// UpdateWebhookSubscription validates and stores a webhook subscription's new URL, filters and format
+	UpdateWebhookSubscription(ctx context.Context, subscription *database.WebhookSubscription) error
+	// RotateWebhookSecret replaces a webhook subscription's signing secret, returning the subscription with the new secret
+	RotateWebhookSecret(ctx context.Context, id int64) (*database.WebhookSubscription, error)
+	// DeleteWebhookSubscription stops delivering to a webhook subscription and removes it
+	DeleteWebhookSubscription(ctx context.Context, id int64) error
+	// CreateAnnouncement validates and stores an announcement
+	CreateAnnouncement(ctx context.Context, announcement database.AnnouncementRecord) (*database.AnnouncementRecord, error)
+	// GetAnnouncement retrieves an announcement
+	GetAnnouncement(ctx context.Context, id int64) (*database.AnnouncementRecord, error)
+	// ListAnnouncements retrieves all announcements, including those not shown yet or any more, oldest first
+	ListAnnouncements(ctx context.Context) ([]*database.AnnouncementRecord, error)
+	// ListActiveAnnouncements retrieves the announcements shown now, most severe first
+	ListActiveAnnouncements(ctx context.Context) ([]apiv0.Announcement, error)
+	// UpdateAnnouncement validates and stores an announcement's new message, severity and schedule
+	UpdateAnnouncement(ctx context.Context, announcement *database.AnnouncementRecord) error
+	// DeleteAnnouncement stops showing an announcement and removes it
+	DeleteAnnouncement(ctx context.Context, id int64) error
+
+	// RegisterOAuthClient validates the redirect URIs of an OAuth client registering itself and stores it
+	// under a new client ID
+	RegisterOAuthClient(ctx context.Context, name string, redirectURIs []string) (*database.OAuthClient, error)
+	// GetOAuthClient retrieves a registered OAuth client
+	GetOAuthClient(ctx context.Context, clientID string) (*database.OAuthClient, error)
+	// ListOAuthClients retrieves all registered OAuth clients, oldest first
+	ListOAuthClients(ctx context.Context) ([]*database.OAuthClient, error)
+	// DeleteOAuthClient removes a registered OAuth client, which can no longer log anyone in
+	DeleteOAuthClient(ctx context.Context, clientID string) error
+	// ClaimAuthorizationCode records the redemption of an OAuth authorization code until it expires, failing
+	// with ErrAlreadyExists when it was redeemed before
+	ClaimAuthorizationCode(ctx context.Context, codeID string, expiresAt time.Time) error
+	// DeliverWebhooks posts the changes each webhook subscription selects that it has not received yet
+	DeliverWebhooks(ctx context.Context) (int, error)
+	// CreateCollection validates and stores a curated collection of servers
+	CreateCollection(ctx context.Context, collection database.Collection) (*database.Collection, error)
+	// GetCollection retrieves a collection with its items in order
+	GetCollection(ctx context.Context, id int64) (*database.Collection, error)
+	// ListCollections retrieves up to limit collections after afterID, oldest first, only those of owner if it is not empty
+	ListCollections(ctx context.Context, owner string, afterID int64, limit int) ([]*database.Collection, error)
+	// UpdateCollection validates and stores a collection's new title, description and items
+	UpdateCollection(ctx context.Context, collection *database.Collection) error
+	// DeleteCollection removes a collection
+	DeleteCollection(ctx context.Context, id int64) error
+	// CreatePreview stores a preview of a server.json under a new random ID, expiring after the preview TTL
+	CreatePreview(ctx context.Context, preview database.Preview) (*database.Preview, error)
+	// GetPreview retrieves an unexpired preview
+	GetPreview(ctx context.Context, id string) (*database.Preview, error)
+	// PurgePreviews deletes expired previews and returns how many were deleted
+	PurgePreviews(ctx context.Context) (int64, error)
+	// PutValidationPolicy validates and stores the validation policy of a namespace, replacing any existing policy for it
+	PutValidationPolicy(ctx context.Context, policy database.ValidationPolicy) (*database.ValidationPolicy, error)
+	// ListValidationPolicies retrieve all validation policies, ordered by namespace
+	ListValidationPolicies(ctx context.Context) ([]*database.ValidationPolicy, error)
+	// DeleteValidationPolicy removes the validation policy of a namespace
+	DeleteValidationPolicy(ctx context.Context, namespace string) error
+	// ValidationPolicyFor returns the most specific validation policy covering a server's namespace, or nil if none does
+	ValidationPolicyFor(ctx context.Context, serverName string) (*validator.NamespacePolicy, error)
 	// UpdateAllVersionsStatus updates the status metadata of all versions of a server in a single transaction
 	UpdateAllVersionsStatus(ctx context.Context, serverName string, statusChange *StatusChangeRequest) ([]*apiv0.ServerResponse, error)
 }
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/stale"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// StaleSweepResult counts what a stale sweep found and did
+type StaleSweepResult struct {
+	// Checked is how many servers were old enough to be checked
+	Checked int
+	// Stale is how many of them are stale
+	Stale int
+	// Notified is how many newly stale servers had their maintainers notified
+	Notified int
+	// MarkedUnmaintained is how many servers were marked unmaintained in this sweep
+	MarkedUnmaintained int
+	// Cleared is how many servers stopped being stale, by being updated, recovering or being deleted
+	Cleared int
+}
+
+// SweepStaleServers checks the latest version of every server not updated within the configured stale-after
+// period. A server is stale when its repository was archived or deleted or one of its packages was unpublished.
+// Newly stale servers have their maintainers notified, and servers still stale once the grace period has passed
+// are marked unmaintained. Servers that were updated or recovered stop being stale. Servers that could not be
+// checked, such as when GitHub or a package registry is unreachable, keep their previous state.
+func (s *registryServiceImpl) SweepStaleServers(ctx context.Context, checker *stale.Checker) (*StaleSweepResult, error) {
+	result := &StaleSweepResult{}
+	now := time.Now()
+
+	tracked, err := s.db.ListServerStaleness(ctx, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to list stale servers: %w", err)
+	}
+	previous := map[string]database.ServerStaleness{}
+	for _, staleness := range tracked {
+		previous[staleness.ServerName] = *staleness
+	}
+
+	isLatest := true
+	filter := &database.ServerFilter{IsLatest: &isLatest}
+	cursor := ""
+	for {
+		page, nextCursor, err := s.db.ListServers(ctx, nil, filter, cursor, bulkJobPageSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to list servers: %w", err)
+		}
+		for _, server := range page {
+			before, wasStale := previous[server.Server.Name]
+			delete(previous, server.Server.Name)
+
+			if server.Meta.Official == nil || now.Sub(server.Meta.Official.UpdatedAt) < s.cfg.StaleAfter {
+				if wasStale {
+					if err := s.clearStaleness(ctx, before); err != nil {
+						return result, err
+					}
+					result.Cleared++
+				}
+				continue
+			}
+
+			result.Checked++
+			reasons, err := checker.Check(ctx, &server.Server)
+			if err != nil {
+				log.Printf("Skipping stale check of %s: %v", server.Server.Name, err)
+				continue
+			}
+			if len(reasons) == 0 {
+				if wasStale {
+					if err := s.clearStaleness(ctx, before); err != nil {
+						return result, err
+					}
+					result.Cleared++
+				}
+				continue
+			}
+
+			result.Stale++
+			if err := s.recordStaleness(ctx, checker, server, before, wasStale, reasons, now, result); err != nil {
+				return result, err
+			}
+		}
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	// Whatever is left belongs to servers that were deleted or renamed since the last sweep
+	for _, staleness := range previous {
+		if err := s.clearStaleness(ctx, staleness); err != nil {
+			return result, err
+		}
+		result.Cleared++
+	}
+	return result, nil
+}
+
+// recordStaleness stores why a server is stale, notifying its maintainers when it was not stale before and
+// marking it unmaintained when its grace period has passed
+func (s *registryServiceImpl) recordStaleness(
+	ctx context.Context, checker *stale.Checker, server *apiv0.ServerResponse, before database.ServerStaleness, wasStale bool,
+	reasons []string, now time.Time, result *StaleSweepResult,
+) error {
+	staleness := before
+	if !wasStale {
+		staleness = database.ServerStaleness{ServerName: server.Server.Name, DetectedAt: now}
+		notified, err := checker.Notify(ctx, &server.Server, reasons, now.Add(s.cfg.StaleGracePeriod))
+		if err != nil {
+			log.Printf("Failed to notify maintainers of stale server %s: %v", server.Server.Name, err)
+		}
+		if notified {
+			staleness.NotifiedAt = &now
+			result.Notified++
+		}
+	}
+	staleness.Reasons = reasons
+
+	markUnmaintained := staleness.UnmaintainedAt == nil && !now.Before(staleness.DetectedAt.Add(s.cfg.StaleGracePeriod))
+	if markUnmaintained {
+		staleness.UnmaintainedAt = &now
+	}
+	if err := s.db.PutServerStaleness(ctx, nil, staleness); err != nil {
+		return fmt.Errorf("failed to store staleness of %s: %w", server.Server.Name, err)
+	}
+
+	if markUnmaintained {
+		result.MarkedUnmaintained++
+		s.purgeServerCache(server.Server.Name)
+	}
+	return nil
+}
+
+// clearStaleness removes a server's staleness record, purging cached responses that flagged it unmaintained
+func (s *registryServiceImpl) clearStaleness(ctx context.Context, staleness database.ServerStaleness) error {
+	if err := s.db.DeleteServerStaleness(ctx, nil, staleness.ServerName); err != nil {
+		return fmt.Errorf("failed to clear staleness of %s: %w", staleness.ServerName, err)
+	}
+	if staleness.UnmaintainedAt != nil {
+		s.purgeServerCache(staleness.ServerName)
+	}
+	return nil
+}
+
+// ListStaleServers retrieve every stale server, including those already marked unmaintained, oldest first
+func (s *registryServiceImpl) ListStaleServers(ctx context.Context) ([]*database.ServerStaleness, error) {
+	return s.db.ListServerStaleness(ctx, nil)
+}
+
+// attachUnmaintained flags the versions of each server marked unmaintained
+func (s *registryServiceImpl) attachUnmaintained(ctx context.Context, servers ...*apiv0.ServerResponse) error {
+	var names []string
+	for _, server := range servers {
+		if !slices.Contains(names, server.Server.Name) {
+			names = append(names, server.Server.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	staleness, err := s.db.GetServerStaleness(ctx, nil, names)
+	if err != nil {
+		return err
+	}
+
+	for _, server := range servers {
+		record, exists := staleness[server.Server.Name]
+		if !exists || record.UnmaintainedAt == nil || server.Meta.Official == nil {
+			continue
+		}
+		server.Meta.Official.Unmaintained = &apiv0.Unmaintained{Since: *record.UnmaintainedAt, Reasons: slices.Clone(record.Reasons)}
+	}
+	return nil
+}
+
+// RunStaleSweep sweeps for stale servers every interval until ctx is cancelled
+func RunStaleSweep(ctx context.Context, registry RegistryService, checker *stale.Checker, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := registry.SweepStaleServers(ctx, checker)
+		if err != nil {
+			log.Printf("Stale sweep failed: %v", err)
+		} else {
+			log.Printf("Stale sweep checked %d servers: %d stale, %d notified, %d marked unmaintained, %d cleared",
+				result.Checked, result.Stale, result.Notified, result.MarkedUnmaintained, result.Cleared)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// NewTestRegistryService creates a registry service for a test, failing the test when the configuration is invalid
+func NewTestRegistryService(t *testing.T, db database.Database, cfg *config.Config) RegistryService {
+	t.Helper()
+
+	registry, err := NewRegistryService(db, cfg)
+	require.NoError(t, err, "Failed to create registry service")
+	return registry
+}
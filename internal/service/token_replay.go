@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// Token replay stores selectable with MCP_REGISTRY_TOKEN_REPLAY_STORE
+const (
+	TokenReplayStoreNone     = "none"
+	TokenReplayStoreMemory   = "memory"
+	TokenReplayStoreDatabase = "database"
+)
+
+// localTokenUses remembers exchanged tokens in this replica only
+type localTokenUses struct {
+	mu   sync.Mutex
+	uses map[string]time.Time
+}
+
+func (l *localTokenUses) record(key string, expiresAt time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for usedKey, usedUntil := range l.uses {
+		if !usedUntil.After(now) {
+			delete(l.uses, usedKey)
+		}
+	}
+	if _, used := l.uses[key]; used {
+		return database.ErrAlreadyExists
+	}
+	l.uses[key] = expiresAt
+	return nil
+}
+
+// ClaimTokenUse records that an identity token, identified by key, was exchanged for a registry token, until it
+// expires. It returns ErrAlreadyExists when the token was exchanged before, and nothing when replay
+// protection is off. The memory store only catches replays sent to the replica that saw the token first.
+func (s *registryServiceImpl) ClaimTokenUse(ctx context.Context, key string, expiresAt time.Time) error {
+	switch s.cfg.TokenReplayStore {
+	case TokenReplayStoreDatabase:
+		return s.db.RecordTokenUse(ctx, nil, key, expiresAt)
+	case TokenReplayStoreMemory:
+		return s.localTokenUses.record(key, expiresAt)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PurgeUpstreamCache deletes expired cached upstream registry responses and returns how many were deleted
+func (s *registryServiceImpl) PurgeUpstreamCache(ctx context.Context) (int64, error) {
+	return s.db.DeleteExpiredUpstreamCacheEntries(ctx, nil, time.Now())
+}
+
+// RunUpstreamCachePurge deletes expired cached upstream registry responses every interval until ctx is cancelled.
+// Expired entries are never served, so this only reclaims space.
+func RunUpstreamCachePurge(ctx context.Context, registry RegistryService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := registry.PurgeUpstreamCache(ctx)
+		if err != nil {
+			log.Printf("Upstream cache purge failed: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Upstream cache purge deleted %d entries", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// ErrInvalidValidationPolicy is returned when a validation policy has an invalid namespace or unknown lint rules
+var ErrInvalidValidationPolicy = errors.New("invalid validation policy")
+
+// PutValidationPolicy validates and stores the validation policy of a namespace, replacing any existing
+// policy for it. Lint rules are deduplicated and sorted.
+func (s *registryServiceImpl) PutValidationPolicy(ctx context.Context, policy database.ValidationPolicy) (*database.ValidationPolicy, error) {
+	if !namespacePattern.MatchString(policy.Namespace) {
+		return nil, fmt.Errorf("%w: invalid namespace %q", ErrInvalidValidationPolicy, policy.Namespace)
+	}
+	for _, rule := range policy.BlockingLintRules {
+		if !slices.Contains(validator.LintRules, rule) {
+			return nil, fmt.Errorf("%w: unknown lint rule %q, expected one of %s", ErrInvalidValidationPolicy, rule, strings.Join(validator.LintRules, ", "))
+		}
+	}
+	policy.BlockingLintRules = compactSorted(policy.BlockingLintRules)
+	return s.db.PutValidationPolicy(ctx, nil, policy)
+}
+
+// ListValidationPolicies retrieves all validation policies, ordered by namespace
+func (s *registryServiceImpl) ListValidationPolicies(ctx context.Context) ([]*database.ValidationPolicy, error) {
+	return s.db.ListValidationPolicies(ctx, nil)
+}
+
+// DeleteValidationPolicy removes the validation policy of a namespace, so its servers are validated like any other
+func (s *registryServiceImpl) DeleteValidationPolicy(ctx context.Context, namespace string) error {
+	return s.db.DeleteValidationPolicy(ctx, nil, namespace)
+}
+
+// ValidationPolicyFor returns the validation policy that applies to a server, or nil if none does. A policy
+// applies to its namespace and the namespaces under it; of several, the most specific applies.
+func (s *registryServiceImpl) ValidationPolicyFor(ctx context.Context, serverName string) (*validator.NamespacePolicy, error) {
+	policies, err := s.db.ListValidationPolicies(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list validation policies: %w", err)
+	}
+
+	namespace, _, _ := strings.Cut(serverName, "/")
+	var match *database.ValidationPolicy
+	for _, policy := range policies {
+		if namespace != policy.Namespace && !strings.HasPrefix(namespace, policy.Namespace+".") {
+			continue
+		}
+		if match == nil || len(policy.Namespace) > len(match.Namespace) {
+			match = policy
+		}
+	}
+	if match == nil {
+		return nil, nil
+	}
+	return &validator.NamespacePolicy{
+		AllowHTTPRemotes:     match.AllowHTTPRemotes,
+		RequirePackageHashes: match.RequirePackageHashes,
+		BlockingLintRules:    match.BlockingLintRules,
+	}, nil
+}
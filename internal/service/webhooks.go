@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ErrInvalidWebhook is returned when a webhook subscription has an invalid URL, filter or format
+var ErrInvalidWebhook = errors.New("invalid webhook subscription")
+
+// webhookChangeTypes are the change types subscriptions can filter on
+var webhookChangeTypes = []string{"created", "updated", "renamed", "deleted"}
+
+// WebhookSignatureHeader carries the signature of each webhook delivery: "sha256=" followed by the hex
+// HMAC-SHA256 of the body, keyed with the subscription's secret
+const WebhookSignatureHeader = "X-Registry-Signature"
+
+// webhookSecretColumn names the webhook subscriptions' signing secret column, which is encrypted at rest
+// when encryption is configured
+const webhookSecretColumn = "webhook_subscriptions.secret"
+
+// DefaultEventSource is the CloudEvents source of events from a registry without a public URL
+const DefaultEventSource = "urn:modelcontextprotocol:registry"
+
+// EventSource returns the CloudEvents source of events this registry emits: its public URL, or
+// DefaultEventSource if it has none
+func EventSource(cfg *config.Config) string {
+	if cfg.PublicURL == "" {
+		return DefaultEventSource
+	}
+	return cfg.PublicURL
+}
+
+// CreateWebhookSubscription validates and stores a webhook subscription with a new signing secret, which is
+// only returned here. It receives changes recorded from now on.
+func (s *registryServiceImpl) CreateWebhookSubscription(ctx context.Context, subscription database.WebhookSubscription) (*database.WebhookSubscription, error) {
+	if err := validateWebhookSubscription(&subscription); err != nil {
+		return nil, err
+	}
+	lastSeq, err := s.db.LatestChangeSeq(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest change: %w", err)
+	}
+	subscription.LastSeq = lastSeq
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Secret, err = s.encryptor.Encrypt(ctx, webhookSecretColumn, secret); err != nil {
+		return nil, err
+	}
+	created, err := s.db.CreateWebhookSubscription(ctx, nil, subscription)
+	if err != nil {
+		return nil, err
+	}
+	created.Secret = secret
+	return created, nil
+}
+
+// GetWebhookSubscription retrieves a webhook subscription and its delivery state, without its secret
+func (s *registryServiceImpl) GetWebhookSubscription(ctx context.Context, id int64) (*database.WebhookSubscription, error) {
+	subscription, err := s.db.GetWebhookSubscription(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+// ListWebhookSubscriptions retrieves all webhook subscriptions, oldest first, without their secrets
+func (s *registryServiceImpl) ListWebhookSubscriptions(ctx context.Context) ([]*database.WebhookSubscription, error) {
+	subscriptions, err := s.db.ListWebhookSubscriptions(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, subscription := range subscriptions {
+		subscription.Secret = ""
+	}
+	return subscriptions, nil
+}
+
+// RotateWebhookSecret replaces a webhook subscription's signing secret with a new one, which is only returned
+// here. Deliveries are signed with the new secret from the next one.
+func (s *registryServiceImpl) RotateWebhookSecret(ctx context.Context, id int64) (*database.WebhookSubscription, error) {
+	subscription, err := s.db.GetWebhookSubscription(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.encryptor.Encrypt(ctx, webhookSecretColumn, secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.UpdateWebhookSubscriptionSecret(ctx, nil, id, encrypted); err != nil {
+		return nil, err
+	}
+	subscription.Secret = secret
+	return subscription, nil
+}
+
+// newWebhookSecret generates a new webhook signing secret
+func newWebhookSecret() (string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(random), nil
+}
+
+// signWebhook returns the WebhookSignatureHeader value of a delivery body
+func signWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// UpdateWebhookSubscription validates and stores a webhook subscription's new URL, filters and format. Changes
+// already delivered or skipped are not delivered again.
+func (s *registryServiceImpl) UpdateWebhookSubscription(ctx context.Context, subscription *database.WebhookSubscription) error {
+	if err := validateWebhookSubscription(subscription); err != nil {
+		return err
+	}
+	return s.db.UpdateWebhookSubscription(ctx, nil, subscription)
+}
+
+// DeleteWebhookSubscription stops delivering to a webhook subscription and removes it
+func (s *registryServiceImpl) DeleteWebhookSubscription(ctx context.Context, id int64) error {
+	return s.db.DeleteWebhookSubscription(ctx, nil, id)
+}
+
+// validateWebhookSubscription checks a subscription's URL, filters and format, defaulting the format to
+// cloudevents and removing duplicate filter values
+func validateWebhookSubscription(subscription *database.WebhookSubscription) error {
+	if err := validateReplayTarget(subscription.URL); err != nil {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if subscription.Format == "" {
+		subscription.Format = database.WebhookFormatCloudEvents
+	}
+	if !validChangeFormat(subscription.Format) {
+		return fmt.Errorf("%w: format must be one of cloudevents, full, minimal", ErrInvalidWebhook)
+	}
+	for _, eventType := range subscription.EventTypes {
+		if !slices.Contains(webhookChangeTypes, eventType) {
+			return fmt.Errorf("%w: event type %q must be one of %s", ErrInvalidWebhook, eventType, strings.Join(webhookChangeTypes, ", "))
+		}
+	}
+	for _, namespace := range subscription.Namespaces {
+		if !namespacePattern.MatchString(namespace) {
+			return fmt.Errorf("%w: invalid namespace %q", ErrInvalidWebhook, namespace)
+		}
+	}
+	subscription.EventTypes = compactSorted(subscription.EventTypes)
+	subscription.Namespaces = compactSorted(subscription.Namespaces)
+	return nil
+}
+
+// compactSorted returns values sorted without duplicates
+func compactSorted(values []string) []string {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return slices.Compact(values)
+}
+
+// webhookSelects reports whether a subscription's filters select a change
+func webhookSelects(subscription *database.WebhookSubscription, change *apiv0.ServerChange) bool {
+	if len(subscription.EventTypes) > 0 && !slices.Contains(subscription.EventTypes, change.Type) {
+		return false
+	}
+	namespace, _, _ := strings.Cut(change.Server.Server.Name, "/")
+	return len(subscription.Namespaces) == 0 || slices.Contains(subscription.Namespaces, namespace)
+}
+
+// validChangeFormat reports whether format is a payload format changePayload renders
+func validChangeFormat(format string) bool {
+	return slices.Contains([]string{database.WebhookFormatCloudEvents, database.WebhookFormatFull, database.WebhookFormatMinimal}, format)
+}
+
+// changePayload renders a change in a webhook payload format, returning the body and its content type.
+// source is the CloudEvents source of cloudevents payloads.
+func changePayload(format string, change *apiv0.ServerChange, source string) ([]byte, string, error) {
+	switch format {
+	case database.WebhookFormatMinimal:
+		body, err := json.Marshal(apiv0.ServerChangeEnvelope{
+			Seq:          change.Seq,
+			Type:         change.Type,
+			ChangedAt:    change.ChangedAt,
+			Name:         change.Server.Server.Name,
+			Version:      change.Server.Server.Version,
+			PreviousName: change.PreviousName,
+		})
+		return body, "application/json", err
+	case database.WebhookFormatCloudEvents:
+		body, err := json.Marshal(apiv0.NewServerChangeEvent(change, source))
+		return body, apiv0.CloudEventsContentType, err
+	default:
+		body, err := json.Marshal(change)
+		return body, "application/json", err
+	}
+}
+
+// DeliverWebhooks posts the changes each webhook subscription selects that it has not received yet, in
+// sequence order, and returns how many were delivered. A subscription whose URL does not accept a change
+// is retried from that change on the next call, without holding back the others.
+func (s *registryServiceImpl) DeliverWebhooks(ctx context.Context) (int, error) {
+	subscriptions, err := s.db.ListWebhookSubscriptions(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	delivered := 0
+	for _, subscription := range subscriptions {
+		secret, err := s.encryptor.Decrypt(ctx, webhookSecretColumn, subscription.Secret)
+		if err != nil {
+			log.Printf("Webhook subscription %d: %v", subscription.ID, err)
+			continue
+		}
+		subscription.Secret = secret
+		count, err := s.deliverWebhook(ctx, subscription)
+		delivered += count
+		if err != nil {
+			if ctx.Err() != nil {
+				return delivered, ctx.Err()
+			}
+			log.Printf("Webhook subscription %d: %v", subscription.ID, err)
+		}
+	}
+	return delivered, nil
+}
+
+// deliverWebhook posts the changes a subscription selects after its last delivered change until it is
+// caught up or a delivery fails, then records how far it got
+func (s *registryServiceImpl) deliverWebhook(ctx context.Context, subscription *database.WebhookSubscription) (int, error) {
+	lastSeq := subscription.LastSeq
+	delivered := 0
+	var deliveryErr error
+	for deliveryErr == nil {
+		changes, err := s.db.ListServerChanges(ctx, nil, lastSeq, replayPageSize)
+		if err != nil {
+			return delivered, fmt.Errorf("failed to read changes after %d: %w", lastSeq, err)
+		}
+		for _, change := range changes {
+			if webhookSelects(subscription, change) {
+				if deliveryErr = s.postWebhook(ctx, subscription, change); deliveryErr != nil {
+					deliveryErr = fmt.Errorf("failed to deliver change %d: %w", change.Seq, deliveryErr)
+					break
+				}
+				delivered++
+			}
+			lastSeq = change.Seq
+		}
+		if len(changes) < replayPageSize {
+			break
+		}
+	}
+
+	// Only record progress or a change in the error, so idle subscriptions cost no writes
+	lastError := ""
+	if deliveryErr != nil {
+		lastError = deliveryErr.Error()
+	}
+	if lastSeq != subscription.LastSeq || lastError != subscription.LastError {
+		if err := s.db.RecordWebhookDelivery(context.WithoutCancel(ctx), nil, subscription.ID, lastSeq, lastError); err != nil {
+			return delivered, fmt.Errorf("failed to record delivery: %w", err)
+		}
+	}
+	return delivered, deliveryErr
+}
+
+// postWebhook posts one change to a subscription's URL in its format, signed with its secret if it has one
+func (s *registryServiceImpl) postWebhook(ctx context.Context, subscription *database.WebhookSubscription, change *apiv0.ServerChange) error {
+	body, contentType, err := changePayload(subscription.Format, change, EventSource(s.cfg))
+	if err != nil {
+		return err
+	}
+	signature := ""
+	if subscription.Secret != "" {
+		signature = signWebhook(subscription.Secret, body)
+	}
+	return postChange(ctx, subscription.URL, change.Seq, contentType, body, false, signature)
+}
+
+// RunWebhookDelivery delivers changes to webhook subscriptions every interval until ctx is cancelled
+func RunWebhookDelivery(ctx context.Context, registry RegistryService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := registry.DeliverWebhooks(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Webhook delivery failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
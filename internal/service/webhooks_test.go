@@ -0,0 +1,216 @@
+package service_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// webhookRequest is a request received by a test webhook
+type webhookRequest struct {
+	contentType string
+	signature   string
+	body        []byte
+}
+
+// newWebhook starts a webhook recording the requests it accepts; it rejects requests while failing is set
+func newWebhook(t *testing.T, failing *atomic.Bool) (*httptest.Server, func() []webhookRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var received []webhookRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing != nil && failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, webhookRequest{contentType: r.Header.Get("Content-Type"), signature: r.Header.Get(service.WebhookSignatureHeader), body: body})
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []webhookRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]webhookRequest(nil), received...)
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+	registry := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{PublicURL: "https://registry.example.com"})
+	publish := func(name, version string) {
+		t.Helper()
+		_, err := registry.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "A test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	// Changes recorded before a subscription is created are not delivered to it
+	publish("com.example/before", "1.0.0")
+
+	fullTarget, fullReceived := newWebhook(t, nil)
+	minimalTarget, minimalReceived := newWebhook(t, nil)
+	cloudTarget, cloudReceived := newWebhook(t, nil)
+	_, err := registry.CreateWebhookSubscription(ctx, database.WebhookSubscription{URL: fullTarget.URL, Namespaces: []string{"com.example"}, Format: database.WebhookFormatFull})
+	require.NoError(t, err)
+	_, err = registry.CreateWebhookSubscription(ctx, database.WebhookSubscription{URL: minimalTarget.URL, EventTypes: []string{"updated"}, Format: database.WebhookFormatMinimal})
+	require.NoError(t, err)
+	cloudSubscription, err := registry.CreateWebhookSubscription(ctx, database.WebhookSubscription{URL: cloudTarget.URL, Format: database.WebhookFormatCloudEvents})
+	require.NoError(t, err)
+
+	publish("com.example/weather", "1.0.0")
+	publish("org.other/maps", "1.0.0")
+	_, err = registry.UpdateServerStatus(ctx, "com.example/weather", "1.0.0", &service.StatusChangeRequest{NewStatus: model.StatusDeprecated})
+	require.NoError(t, err)
+
+	delivered, err := registry.DeliverWebhooks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 6, delivered)
+
+	t.Run("full format filtered by namespace", func(t *testing.T) {
+		received := fullReceived()
+		require.Len(t, received, 2)
+		var changes []apiv0.ServerChange
+		for _, request := range received {
+			assert.Equal(t, "application/json", request.contentType)
+			var change apiv0.ServerChange
+			require.NoError(t, json.Unmarshal(request.body, &change))
+			changes = append(changes, change)
+		}
+		assert.Equal(t, "created", changes[0].Type)
+		assert.Equal(t, "updated", changes[1].Type)
+		assert.Equal(t, "com.example/weather", changes[1].Server.Server.Name)
+		assert.Equal(t, model.StatusDeprecated, changes[1].Server.Meta.Official.Status)
+	})
+
+	t.Run("minimal format filtered by event type", func(t *testing.T) {
+		received := minimalReceived()
+		require.Len(t, received, 1)
+		var envelope map[string]any
+		require.NoError(t, json.Unmarshal(received[0].body, &envelope))
+		assert.Equal(t, "updated", envelope["type"])
+		assert.Equal(t, "com.example/weather", envelope["name"])
+		assert.Equal(t, "1.0.0", envelope["version"])
+		assert.NotContains(t, envelope, "server")
+	})
+
+	t.Run("cloudevents format", func(t *testing.T) {
+		received := cloudReceived()
+		require.Len(t, received, 3)
+		assert.Equal(t, "application/cloudevents+json", received[0].contentType)
+		var event struct {
+			apiv0.CloudEvent
+			Data apiv0.ServerResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(received[1].body, &event))
+		assert.Equal(t, "1.0", event.SpecVersion)
+		assert.Equal(t, "https://registry.example.com", event.Source)
+		assert.Equal(t, "io.modelcontextprotocol.registry.server.published", event.Type)
+		assert.Equal(t, "org.other/maps@1.0.0", event.Subject)
+		assert.Equal(t, "org.other/maps", event.Data.Server.Name)
+	})
+
+	t.Run("deliveries are signed with the subscription's secret", func(t *testing.T) {
+		received := cloudReceived()
+		require.NotEmpty(t, received)
+		mac := hmac.New(sha256.New, []byte(cloudSubscription.Secret))
+		mac.Write(received[0].body)
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), received[0].signature)
+
+		rotated, err := registry.RotateWebhookSecret(ctx, cloudSubscription.ID)
+		require.NoError(t, err)
+		assert.NotEqual(t, cloudSubscription.Secret, rotated.Secret)
+	})
+
+	t.Run("nothing is delivered twice", func(t *testing.T) {
+		delivered, err := registry.DeliverWebhooks(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+	})
+}
+
+func TestWebhookDelivery_RetriesFailedChanges(t *testing.T) {
+	ctx := context.Background()
+	registry := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{})
+	var failing atomic.Bool
+	failing.Store(true)
+	target, received := newWebhook(t, &failing)
+	subscription, err := registry.CreateWebhookSubscription(ctx, database.WebhookSubscription{URL: target.URL})
+	require.NoError(t, err)
+
+	_, err = registry.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	delivered, err := registry.DeliverWebhooks(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	stored, err := registry.GetWebhookSubscription(ctx, subscription.ID)
+	require.NoError(t, err)
+	assert.Equal(t, subscription.LastSeq, stored.LastSeq)
+	assert.Contains(t, stored.LastError, "status 503")
+	assert.NotNil(t, stored.LastAttemptAt)
+
+	failing.Store(false)
+	delivered, err = registry.DeliverWebhooks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Len(t, received(), 1)
+	stored, err = registry.GetWebhookSubscription(ctx, subscription.ID)
+	require.NoError(t, err)
+	assert.Equal(t, subscription.LastSeq+1, stored.LastSeq)
+	assert.Empty(t, stored.LastError)
+}
+
+func TestWebhookSubscriptionValidation(t *testing.T) {
+	ctx := context.Background()
+	registry := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{})
+
+	for name, subscription := range map[string]database.WebhookSubscription{
+		"relative url":       {URL: "/hooks"},
+		"unknown event type": {URL: "https://example.com/hooks", EventTypes: []string{"archived"}},
+		"invalid namespace":  {URL: "https://example.com/hooks", Namespaces: []string{"com.example/weather"}},
+		"unknown format":     {URL: "https://example.com/hooks", Format: "xml"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := registry.CreateWebhookSubscription(ctx, subscription)
+			assert.ErrorIs(t, err, service.ErrInvalidWebhook)
+		})
+	}
+
+	created, err := registry.CreateWebhookSubscription(ctx, database.WebhookSubscription{
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"updated", "created", "updated"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, database.WebhookFormatCloudEvents, created.Format)
+	assert.Equal(t, []string{"created", "updated"}, created.EventTypes)
+	assert.Empty(t, created.Namespaces)
+}
@@ -0,0 +1,31 @@
+// Filters the server list as the user types, matching every word of the query against each server's
+// entry in the search index embedded in the page
+(function () {
+    const index = JSON.parse(document.getElementById('search-index').textContent);
+    const text = new Map(index.map((entry) => [entry.name, entry.text]));
+    const search = document.getElementById('search');
+    const items = Array.from(document.querySelectorAll('#servers li'));
+    const noResults = document.getElementById('no-results');
+
+    function filter() {
+        const words = search.value.toLowerCase().split(/\s+/).filter(Boolean);
+        let shown = 0;
+        for (const item of items) {
+            const haystack = text.get(item.dataset.name) || '';
+            const match = words.every((word) => haystack.includes(word));
+            item.hidden = !match;
+            if (match) {
+                shown++;
+            }
+        }
+        noResults.hidden = shown > 0;
+    }
+
+    search.addEventListener('input', filter);
+    // Keep the query when coming back to the page, and allow linking to a search with ?q=
+    const query = new URLSearchParams(window.location.search).get('q');
+    if (query) {
+        search.value = query;
+    }
+    filter();
+})();
@@ -0,0 +1,243 @@
+// Package site renders the registry as a static HTML catalog: an index of every server with client-side search,
+// and a page per server with its packages, remotes, versions and server.json, so organizations can publish a
+// browsable catalog on plain static hosting.
+package site
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/install"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// DefaultTitle is the title of sites generated without one
+const DefaultTitle = "MCP Server Catalog"
+
+// serversDir is the directory of the site holding a page per server, replaced on every generation
+const serversDir = "servers"
+
+// listPageSize is how many servers are read at a time
+const listPageSize = 100
+
+//go:embed templates/*.html assets/*
+var files embed.FS
+
+var templates = template.Must(template.New("site").Funcs(template.FuncMap{
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.DateOnly)
+	},
+	"displayTitle": func(server apiv0.ServerJSON) string {
+		if server.Title != "" {
+			return server.Title
+		}
+		return server.Name
+	},
+	"deprecated": func(server *apiv0.ServerResponse) bool {
+		return server.Meta.Official != nil && server.Meta.Official.Status == model.StatusDeprecated
+	},
+	"pagePath": pagePath,
+}).ParseFS(files, "templates/*.html"))
+
+// Options configures a generated site
+type Options struct {
+	// Title is shown at the top of every page; DefaultTitle when empty
+	Title string
+}
+
+// Result counts what Generate wrote
+type Result struct {
+	Servers  int
+	Versions int
+}
+
+// page holds what every page's layout shows
+type page struct {
+	SiteTitle   string
+	PageTitle   string
+	GeneratedAt time.Time
+	// Root is the relative path from the page to the site's root, so the site works under any path and from disk
+	Root string
+}
+
+// searchEntry is a server's entry in the search index embedded in the index page
+type searchEntry struct {
+	Name string `json:"name"`
+	Text string `json:"text"`
+}
+
+// installLink is a one-click install link for an MCP client
+type installLink struct {
+	Client string
+	Link   template.URL
+}
+
+// Generate writes a site for every server that is not deleted into dir, creating it if needed. Server pages
+// show the latest version, and list every version that is not deleted. Everything under dir/servers is
+// replaced, so servers removed from the registry disappear from the site.
+func Generate(ctx context.Context, registry service.RegistryService, dir string, options Options) (*Result, error) {
+	title := options.Title
+	if title == "" {
+		title = DefaultTitle
+	}
+	generatedAt := time.Now()
+
+	latest, err := listLatest(ctx, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := os.RemoveAll(filepath.Join(dir, serversDir)); err != nil {
+		return nil, fmt.Errorf("failed to remove old server pages: %w", err)
+	}
+	result := &Result{}
+	index := make([]searchEntry, 0, len(latest))
+	for _, server := range latest {
+		versions, err := registry.GetAllVersionsByServerName(ctx, server.Server.Name, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list versions of %s: %w", server.Server.Name, err)
+		}
+		document, err := registry.GetPublishedDocument(ctx, server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get server.json of %s: %w", server.Server.Name, err)
+		}
+		if err := writeServer(dir, server, versions, document, page{
+			SiteTitle:   title,
+			PageTitle:   server.Server.Name + " - " + title,
+			GeneratedAt: generatedAt,
+			Root:        "../../../",
+		}); err != nil {
+			return nil, err
+		}
+		index = append(index, searchEntry{Name: server.Server.Name, Text: searchText(&server.Server)})
+		result.Servers++
+		result.Versions += len(versions)
+	}
+
+	err = writeTemplate(filepath.Join(dir, "index.html"), "index.html", struct {
+		page
+		Servers     []*apiv0.ServerResponse
+		SearchIndex []searchEntry
+	}{page{SiteTitle: title, PageTitle: title, GeneratedAt: generatedAt}, latest, index})
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := fs.Sub(files, "assets")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"style.css", "search.js"} {
+		data, err := fs.ReadFile(assets, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil { //nolint:gosec // the site is meant to be served
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return result, nil
+}
+
+// listLatest returns the latest version of every server that is not deleted, ordered by name
+func listLatest(ctx context.Context, registry service.RegistryService) ([]*apiv0.ServerResponse, error) {
+	isLatest := true
+	filter := &database.ServerFilter{IsLatest: &isLatest}
+	var servers []*apiv0.ServerResponse
+	cursor := ""
+	for {
+		batch, nextCursor, err := registry.ListServers(ctx, filter, cursor, listPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+		servers = append(servers, batch...)
+		if nextCursor == "" {
+			return servers, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// writeServer writes the page of a server and document, the server.json its latest version was published with
+func writeServer(dir string, latest *apiv0.ServerResponse, versions []*apiv0.ServerResponse, document []byte, p page) error {
+	name := latest.Server.Name
+	// Names are validated when published, but the site must never be written outside dir
+	relative := filepath.FromSlash(path.Join(serversDir, name))
+	if !filepath.IsLocal(relative) || strings.Count(name, "/") != 1 {
+		return fmt.Errorf("server name %q cannot be used as a path", name)
+	}
+	serverDir := filepath.Join(dir, relative)
+	if err := os.MkdirAll(serverDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", name, err)
+	}
+
+	var installLinks []installLink
+	for _, client := range []struct{ id, name string }{{install.ClientVSCode, "VS Code"}, {install.ClientCursor, "Cursor"}} {
+		snippet, err := install.Render(&latest.Server, client.id, "")
+		if err == nil && snippet.DeepLink != "" {
+			installLinks = append(installLinks, installLink{client.name, template.URL(snippet.DeepLink)}) //nolint:gosec // built by install.Render, not taken from input
+		}
+	}
+
+	err := writeTemplate(filepath.Join(serverDir, "index.html"), "server.html", struct {
+		page
+		Latest       *apiv0.ServerResponse
+		Versions     []*apiv0.ServerResponse
+		InstallLinks []installLink
+	}{p, latest, versions, installLinks})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(serverDir, "server.json"), document, 0o644); err != nil { //nolint:gosec // the site is meant to be served
+		return fmt.Errorf("failed to write server.json of %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeTemplate renders a template into file
+func writeTemplate(file, name string, data any) error {
+	var rendered strings.Builder
+	if err := templates.ExecuteTemplate(&rendered, name, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", file, err)
+	}
+	if err := os.WriteFile(file, []byte(rendered.String()), 0o644); err != nil { //nolint:gosec // the site is meant to be served
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return nil
+}
+
+// pagePath returns the path of a server's page relative to the site's root
+func pagePath(name string) string {
+	return serversDir + "/" + name + "/index.html"
+}
+
+// searchText returns the lowercased text a server is found by: its name, title and description, and the
+// identifiers of its packages and URLs of its remotes
+func searchText(server *apiv0.ServerJSON) string {
+	parts := []string{server.Name, server.Title, server.Description}
+	for _, pkg := range server.Packages {
+		parts = append(parts, pkg.Identifier)
+	}
+	for _, remote := range server.Remotes {
+		parts = append(parts, remote.URL)
+	}
+	return strings.ToLower(strings.Join(parts, " "))
+}
@@ -0,0 +1,82 @@
+package site_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/site"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	registry := service.NewTestRegistryService(t, database.NewMemory(), &config.Config{})
+	for _, server := range []apiv0.ServerJSON{
+		{Name: "com.example/weather", Title: "Weather", Description: "Forecasts", Version: "1.0.0"},
+		{Name: "com.example/weather", Title: "Weather", Description: "Forecasts <script>alert(1)</script>", Version: "1.1.0",
+			Packages: []model.Package{{RegistryType: "npm", Identifier: "@example/weather-mcp", Version: "1.1.0", Transport: model.Transport{Type: "stdio"}}}},
+		{Name: "com.example/old", Description: "Superseded", Version: "1.0.0"},
+		{Name: "com.example/gone", Description: "Removed", Version: "1.0.0"},
+	} {
+		server.Schema = model.CurrentSchemaURL
+		_, err := registry.CreateServer(ctx, &server)
+		require.NoError(t, err)
+	}
+	message := "Use com.example/weather"
+	_, err := registry.UpdateServerStatus(ctx, "com.example/old", "1.0.0", &service.StatusChangeRequest{NewStatus: model.StatusDeprecated, StatusMessage: &message})
+	require.NoError(t, err)
+	_, err = registry.UpdateServerStatus(ctx, "com.example/gone", "1.0.0", &service.StatusChangeRequest{NewStatus: model.StatusDeleted})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	// Pages of servers that are no longer in the registry are removed
+	stale := filepath.Join(dir, "servers", "com.example", "removed")
+	require.NoError(t, os.MkdirAll(stale, 0o755))
+
+	result, err := site.Generate(ctx, registry, dir, site.Options{Title: "Acme Catalog"})
+	require.NoError(t, err)
+	assert.Equal(t, &site.Result{Servers: 2, Versions: 3}, result)
+	assert.NoDirExists(t, stale)
+	assert.NoDirExists(t, filepath.Join(dir, "servers", "com.example", "gone"))
+	assert.FileExists(t, filepath.Join(dir, "style.css"))
+	assert.FileExists(t, filepath.Join(dir, "search.js"))
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	index := read("index.html")
+	assert.Contains(t, index, "<title>Acme Catalog</title>")
+	assert.Contains(t, index, `href="servers/com.example/weather/index.html"`)
+	assert.Contains(t, index, `"name":"com.example/weather"`)
+	assert.Contains(t, index, "@example/weather-mcp", "package identifiers are searchable")
+	assert.NotContains(t, index, "<script>alert(1)</script>")
+	assert.NotContains(t, index, "com.example/gone")
+
+	weather := read("servers/com.example/weather/index.html")
+	assert.Contains(t, weather, `href="../../../style.css"`)
+	assert.Contains(t, weather, "<h1>Weather</h1>")
+	assert.Contains(t, weather, "<code>@example/weather-mcp</code>")
+	assert.Contains(t, weather, "<td>1.0.0</td>")
+	assert.Contains(t, weather, "Install in VS Code")
+
+	old := read("servers/com.example/old/index.html")
+	assert.Contains(t, old, "This server is deprecated. Use com.example/weather")
+
+	latest, err := registry.GetServerByName(ctx, "com.example/weather", false)
+	require.NoError(t, err)
+	document, _, err := apiv0.ServerDocument(&latest.Server)
+	require.NoError(t, err)
+	assert.Equal(t, string(document), read("servers/com.example/weather/server.json"))
+}
@@ -0,0 +1,172 @@
+// Package stale detects servers whose source repository or packages have disappeared, and notifies their
+// maintainers through the repository's issue tracker.
+package stale
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/repourl"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// githubRepository is the subset of the GitHub repository API response that is checked
+type githubRepository struct {
+	Archived    bool `json:"archived"`
+	HasIssues   bool `json:"has_issues"`
+	deleted     bool
+	owner, name string
+}
+
+// Checker checks whether servers are stale and notifies their maintainers
+type Checker struct {
+	packages validator.PackageChecker
+	token    string
+	baseURL  string // Configurable for testing
+	client   *http.Client
+}
+
+// NewChecker creates a checker that looks repositories up with the configured GitHub API token and checks
+// packages with packages. Maintainers are only notified when a token is configured.
+func NewChecker(cfg *config.Config, packages validator.PackageChecker) *Checker {
+	return &Checker{
+		packages: packages,
+		token:    cfg.GitHubAPIToken,
+		baseURL:  "https://api.github.com",
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SetGitHubBaseURL sets the GitHub API base URL (used for testing)
+func (c *Checker) SetGitHubBaseURL(baseURL string) {
+	c.baseURL = baseURL
+}
+
+// Check returns why the server is stale, as database.StaleReason* constants, or nothing when its
+// repository and packages are still available. Only GitHub repositories are checked. An error is returned when
+// availability cannot be determined, so servers are never flagged because of a registry being unreachable.
+func (c *Checker) Check(ctx context.Context, server *apiv0.ServerJSON) ([]string, error) {
+	var reasons []string
+
+	repo, err := c.repository(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case repo == nil:
+	case repo.deleted:
+		reasons = append(reasons, database.StaleReasonRepositoryDeleted)
+	case repo.Archived:
+		reasons = append(reasons, database.StaleReasonRepositoryArchived)
+	}
+
+	for _, pkg := range server.Packages {
+		err := c.packages.CheckPackage(ctx, pkg, server.Name)
+		if errors.Is(err, registries.ErrPackageNotFound) {
+			reasons = append(reasons, database.StaleReasonPackageUnpublished)
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check package %s: %w", pkg.Identifier, err)
+		}
+	}
+	return reasons, nil
+}
+
+// Notify opens an issue on the server's GitHub repository telling its maintainers why the server is stale and
+// that it will be marked unmaintained at deadline unless a new version is published. It reports whether an
+// issue was opened, which it cannot be without a GitHub API token or when the repository is gone, archived or
+// has issues disabled.
+func (c *Checker) Notify(ctx context.Context, server *apiv0.ServerJSON, reasons []string, deadline time.Time) (bool, error) {
+	if c.token == "" {
+		return false, nil
+	}
+	repo, err := c.repository(ctx, server)
+	if err != nil || repo == nil || repo.deleted || repo.Archived || !repo.HasIssues {
+		return false, err
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"title": fmt.Sprintf("MCP Registry: %s will be marked unmaintained", server.Name),
+		"body": fmt.Sprintf("The MCP Registry entry for `%s` has not been updated for a long time and looks abandoned (%s).\n\n"+
+			"It will be marked unmaintained on %s unless a new version is published before then. "+
+			"Unmaintained servers stay listed, but are flagged to clients and can be filtered out.",
+			server.Name, strings.Join(reasons, ", "), deadline.UTC().Format(time.DateOnly)),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode issue: %w", err)
+	}
+
+	requestURL := c.baseURL + "/repos/" + url.PathEscape(repo.owner) + "/" + url.PathEscape(repo.name) + "/issues"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to open issue on github.com/%s/%s: %w", repo.owner, repo.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return false, fmt.Errorf("failed to open issue on github.com/%s/%s: GitHub API status %d", repo.owner, repo.name, resp.StatusCode)
+	}
+	return true, nil
+}
+
+// repository looks up the server's GitHub repository, returning nil when the server does not link one
+func (c *Checker) repository(ctx context.Context, server *apiv0.ServerJSON) (*githubRepository, error) {
+	if server.Repository == nil || server.Repository.Source != string(validator.SourceGitHub) {
+		return nil, nil
+	}
+	owner, name, err := repourl.ParseGitHub(server.Repository.URL)
+	if err != nil {
+		return nil, nil //nolint:nilerr // servers not linking a GitHub repository have nothing to check
+	}
+
+	requestURL := c.baseURL + "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setHeaders(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch repository github.com/%s/%s: %w", owner, name, err)
+	}
+	defer resp.Body.Close()
+
+	repo := &githubRepository{owner: owner, name: name}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusGone, http.StatusUnavailableForLegalReasons:
+		repo.deleted = true
+		return repo, nil
+	default:
+		return nil, fmt.Errorf("unable to check repository github.com/%s/%s (GitHub API status %d)", owner, name, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(repo); err != nil {
+		return nil, fmt.Errorf("failed to parse repository response: %w", err)
+	}
+	return repo, nil
+}
+
+func (c *Checker) setHeaders(req *http.Request) {
+	repourl.SetGitHubHeaders(req, "application/vnd.github+json", c.token)
+}
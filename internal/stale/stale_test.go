@@ -0,0 +1,132 @@
+package stale_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/stale"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// fakePackages reports packages as unpublished or unreachable by identifier
+type fakePackages map[string]error
+
+func (f fakePackages) CheckPackage(_ context.Context, pkg model.Package, _ string) error {
+	return f[pkg.Identifier]
+}
+
+func newGitHub(t *testing.T, issues *[]map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/acme/active":
+			_, _ = io.WriteString(w, `{"archived":false,"has_issues":true}`)
+		case "GET /repos/acme/archived":
+			_, _ = io.WriteString(w, `{"archived":true,"has_issues":true}`)
+		case "GET /repos/acme/limited":
+			w.WriteHeader(http.StatusForbidden)
+		case "POST /repos/acme/active/issues":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			var issue map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&issue))
+			*issues = append(*issues, issue)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func serverWithRepo(repo string, packages ...string) *apiv0.ServerJSON {
+	server := &apiv0.ServerJSON{Name: "io.github.acme/weather", Version: "1.0.0"}
+	if repo != "" {
+		server.Repository = &model.Repository{URL: "https://github.com/acme/" + repo, Source: "github"}
+	}
+	for _, identifier := range packages {
+		server.Packages = append(server.Packages, model.Package{RegistryType: model.RegistryTypeNPM, Identifier: identifier, Version: "1.0.0"})
+	}
+	return server
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	github := newGitHub(t, nil)
+	checker := stale.NewChecker(&config.Config{}, fakePackages{
+		"@acme/gone":    fmt.Errorf("npm: %w", registries.ErrPackageNotFound),
+		"@acme/flaky":   fmt.Errorf("npm registry unavailable"),
+		"@acme/weather": nil,
+	})
+	checker.SetGitHubBaseURL(github.URL)
+
+	tests := []struct {
+		name    string
+		server  *apiv0.ServerJSON
+		reasons []string
+		wantErr bool
+	}{
+		{"active repository and package", serverWithRepo("active", "@acme/weather"), nil, false},
+		{"no repository", serverWithRepo(""), nil, false},
+		{"archived repository", serverWithRepo("archived"), []string{database.StaleReasonRepositoryArchived}, false},
+		{"deleted repository", serverWithRepo("deleted"), []string{database.StaleReasonRepositoryDeleted}, false},
+		{"unpublished package", serverWithRepo("archived", "@acme/weather", "@acme/gone"),
+			[]string{database.StaleReasonRepositoryArchived, database.StaleReasonPackageUnpublished}, false},
+		{"unreachable package registry", serverWithRepo("active", "@acme/flaky"), nil, true},
+		{"unreachable GitHub", serverWithRepo("limited"), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons, err := checker.Check(ctx, tt.server)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.reasons, reasons)
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	ctx := context.Background()
+	var issues []map[string]string
+	github := newGitHub(t, &issues)
+	deadline := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	withoutToken := stale.NewChecker(&config.Config{}, fakePackages{})
+	withoutToken.SetGitHubBaseURL(github.URL)
+	notified, err := withoutToken.Notify(ctx, serverWithRepo("active"), []string{database.StaleReasonPackageUnpublished}, deadline)
+	require.NoError(t, err)
+	assert.False(t, notified, "issues are only opened with a GitHub API token")
+
+	checker := stale.NewChecker(&config.Config{GitHubAPIToken: "token"}, fakePackages{})
+	checker.SetGitHubBaseURL(github.URL)
+	for _, repo := range []string{"archived", "deleted", ""} {
+		notified, err := checker.Notify(ctx, serverWithRepo(repo), []string{database.StaleReasonRepositoryArchived}, deadline)
+		require.NoError(t, err)
+		assert.False(t, notified, "no issue can be opened on repository %q", repo)
+	}
+	assert.Empty(t, issues)
+
+	notified, err = checker.Notify(ctx, serverWithRepo("active"), []string{database.StaleReasonPackageUnpublished}, deadline)
+	require.NoError(t, err)
+	assert.True(t, notified)
+	require.Len(t, issues, 1)
+	assert.Contains(t, issues[0]["title"], "io.github.acme/weather")
+	assert.Contains(t, issues[0]["body"], database.StaleReasonPackageUnpublished)
+	assert.Contains(t, issues[0]["body"], "2026-03-01")
+}
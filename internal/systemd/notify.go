@@ -0,0 +1,45 @@
+// Package systemd tells systemd about the registry's state with the sd_notify protocol, so Type=notify units
+// only count the registry as started once it accepts connections.
+package systemd
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+// States the registry reports
+const (
+	// Ready tells systemd that startup finished and the registry is serving requests
+	Ready = "READY=1"
+	// Stopping tells systemd that the registry is shutting down
+	Stopping = "STOPPING=1"
+)
+
+// socketEnv names the notification socket systemd passes to Type=notify services
+const socketEnv = "NOTIFY_SOCKET"
+
+// Notify sends state to the socket in NOTIFY_SOCKET. It reports whether a notification was sent: when the
+// registry is not run by systemd, or the unit does not expect notifications, NOTIFY_SOCKET is unset and Notify
+// does nothing.
+func Notify(state string) (bool, error) {
+	path := os.Getenv(socketEnv)
+	if path == "" {
+		return false, nil
+	}
+	// Abstract sockets are passed with a leading @, which stands for the NUL byte that starts their name
+	if path[0] == '@' {
+		path = "\x00" + path[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to systemd notification socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, fmt.Errorf("failed to notify systemd: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,46 @@
+package systemd_test
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/systemd"
+)
+
+func TestNotify(t *testing.T) {
+	t.Run("without systemd", func(t *testing.T) {
+		t.Setenv("NOTIFY_SOCKET", "")
+		sent, err := systemd.Notify(systemd.Ready)
+		require.NoError(t, err)
+		assert.False(t, sent)
+	})
+
+	t.Run("sends state", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notify.sock")
+		conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+		require.NoError(t, err)
+		defer conn.Close()
+		t.Setenv("NOTIFY_SOCKET", path)
+
+		for _, state := range []string{systemd.Ready, systemd.Stopping} {
+			sent, err := systemd.Notify(state)
+			require.NoError(t, err)
+			assert.True(t, sent)
+
+			buf := make([]byte, 64)
+			n, err := conn.Read(buf)
+			require.NoError(t, err)
+			assert.Equal(t, state, string(buf[:n]))
+		}
+	})
+
+	t.Run("missing socket", func(t *testing.T) {
+		t.Setenv("NOTIFY_SOCKET", filepath.Join(t.TempDir(), "missing.sock"))
+		_, err := systemd.Notify(systemd.Ready)
+		assert.Error(t, err)
+	})
+}
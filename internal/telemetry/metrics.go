@@ -32,6 +32,11 @@ type Metrics struct {
 
 	// Up tracks the health of the service
 	Up metric.Int64Gauge
+
+	// SLO computes SLIs and burn rates from the requests, once TrackSLOs is called
+	SLO *SLOTracker
+
+	meter metric.Meter
 }
 
 // ShutdownFunc is a delegate that shuts down the OpenTelemetry components.
@@ -78,9 +83,20 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		RequestDuration: reqDuration,
 		ErrorCount:      errCount,
 		Up:              up,
+		meter:           meter,
 	}, nil
 }
 
+// TrackSLOs starts computing availability and latency SLIs against objectives and reporting their burn rates
+func (m *Metrics) TrackSLOs(objectives SLOObjectives) error {
+	slo, err := NewSLOTracker(m.meter, objectives)
+	if err != nil {
+		return err
+	}
+	m.SLO = slo
+	return nil
+}
+
 func NewPrometheusMeterProvider(res *resource.Resource, exp *prometheus.Exporter) (*sdkmetric.MeterProvider, error) {
 	if exp == nil {
 		return nil, errors.New("exporter cannot be nil")
@@ -0,0 +1,367 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxTrackedKeys bounds how many distinct search terms or server names are counted per window
+	maxTrackedKeys = 2000
+	// maxClientsPerKey bounds the distinct clients remembered for one term or server
+	maxClientsPerKey = 500
+	// maxSearchTermLength is the length search terms are truncated to before counting
+	maxSearchTermLength = 64
+	// versionKeySeparator joins a server name and version into one counter key; neither can contain it
+	versionKeySeparator = "\n"
+)
+
+// ReadStatsEntry is an aggregated count for one search term or server
+type ReadStatsEntry struct {
+	Value         string `json:"value" doc:"Search term or server name" example:"filesystem"`
+	UniqueClients int    `json:"uniqueClients" doc:"Number of distinct clients in the window, capped at 500" example:"42"`
+	Requests      int    `json:"requests" doc:"Number of requests in the window" example:"97"`
+}
+
+// ReadStatsSnapshot holds the published aggregates for one completed window
+type ReadStatsSnapshot struct {
+	WindowStart      *time.Time       `json:"windowStart,omitempty" doc:"Start of the aggregation window. Omitted until the first window completes."`
+	WindowEnd        *time.Time       `json:"windowEnd,omitempty" doc:"End of the aggregation window"`
+	MinUniqueClients int              `json:"minUniqueClients" doc:"Entries seen by fewer distinct clients than this are withheld" example:"10"`
+	TopSearches      []ReadStatsEntry `json:"topSearches" doc:"Most searched terms, by distinct clients"`
+	TopServers       []ReadStatsEntry `json:"topServers" doc:"Most fetched servers, by distinct clients"`
+}
+
+// ReadStatsCount is an aggregated count for one server in a namespace's read analytics
+type ReadStatsCount struct {
+	UniqueClients int `json:"uniqueClients" doc:"Number of distinct clients in the window, capped at 500" example:"42"`
+	Requests      int `json:"requests" doc:"Number of requests in the window" example:"97"`
+}
+
+// ServerReadStats holds the published aggregates for one server
+type ServerReadStats struct {
+	Name        string           `json:"name" doc:"Server name" example:"io.github.octocat/weather"`
+	Downloads   ReadStatsCount   `json:"downloads" doc:"Fetches of the server's details"`
+	Impressions ReadStatsCount   `json:"impressions" doc:"Appearances of the server in search results"`
+	Versions    []ReadStatsEntry `json:"versions" doc:"Fetches by requested version, most distinct clients first. Fetches of 'latest' are counted as 'latest'."`
+}
+
+// NamespaceReadStats holds the published aggregates for every server in a namespace for one completed window
+type NamespaceReadStats struct {
+	Namespace        string            `json:"namespace" doc:"Namespace the stats cover" example:"io.github.octocat"`
+	WindowStart      *time.Time        `json:"windowStart,omitempty" doc:"Start of the aggregation window. Omitted until the first window completes."`
+	WindowEnd        *time.Time        `json:"windowEnd,omitempty" doc:"End of the aggregation window"`
+	MinUniqueClients int               `json:"minUniqueClients" doc:"Counts seen by fewer distinct clients than this are withheld" example:"10"`
+	TotalDownloads   int               `json:"totalDownloads" doc:"Fetch requests across all listed servers" example:"1204"`
+	TotalImpressions int               `json:"totalImpressions" doc:"Search result appearances across all listed servers" example:"5310"`
+	Servers          []ServerReadStats `json:"servers" doc:"Servers with published counts, most downloaded first"`
+}
+
+// ReadStats aggregates anonymous read traffic in fixed windows. Clients are identified only by a keyed
+// hash whose key is regenerated every window and never stored, so addresses cannot be recovered and
+// clients cannot be linked across windows. Entries are ranked by distinct clients so a single client
+// repeating requests cannot inflate them, and only entries seen by at least minClients distinct clients
+// are published.
+type ReadStats struct {
+	minClients int
+	window     time.Duration
+	now        func() time.Time
+
+	mu          sync.Mutex
+	salt        []byte
+	windowStart time.Time
+	searches    map[string]*readCounter
+	servers     map[string]*readCounter
+	versions    map[string]*readCounter
+	impressions map[string]*readCounter
+	published   ReadStatsSnapshot
+	// Published counts by key, for ServerDownloads and NamespaceStats
+	publishedServers     map[string]ReadStatsEntry
+	publishedVersions    map[string]ReadStatsEntry
+	publishedImpressions map[string]ReadStatsEntry
+}
+
+type readCounter struct {
+	requests int
+	clients  map[uint64]struct{}
+}
+
+// NewReadStats creates an aggregator publishing entries seen by at least minClients distinct clients per window
+func NewReadStats(minClients int, window time.Duration) *ReadStats {
+	s := &ReadStats{
+		minClients: max(minClients, 1),
+		window:     window,
+		now:        time.Now,
+	}
+	s.published = ReadStatsSnapshot{MinUniqueClients: s.minClients, TopSearches: []ReadStatsEntry{}, TopServers: []ReadStatsEntry{}}
+	s.reset(s.now())
+	return s
+}
+
+// SetClock sets a custom clock and starts a new window from it (used for testing)
+func (s *ReadStats) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+	s.reset(now())
+}
+
+// RecordSearch counts a search for term by the client at clientAddress
+func (s *ReadStats) RecordSearch(clientAddress, term string) {
+	term = strings.Join(strings.Fields(strings.ToLower(term)), " ")
+	if len(term) > maxSearchTermLength {
+		term = strings.ToValidUTF8(term[:maxSearchTermLength], "")
+	}
+	if term == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rotate()
+	s.record(s.searches, s.clientID(clientAddress), term)
+}
+
+// RecordServerFetch counts a fetch of serverName by the client at clientAddress
+func (s *ReadStats) RecordServerFetch(clientAddress, serverName string) {
+	if serverName == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rotate()
+	s.record(s.servers, s.clientID(clientAddress), serverName)
+}
+
+// RecordVersionFetch counts a fetch of a specific version of serverName, as requested, by the client at clientAddress
+func (s *ReadStats) RecordVersionFetch(clientAddress, serverName, version string) {
+	if serverName == "" || version == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rotate()
+	s.record(s.versions, s.clientID(clientAddress), serverName+versionKeySeparator+version)
+}
+
+// RecordImpressions counts an appearance of each of serverNames in the search results returned to the client
+// at clientAddress
+func (s *ReadStats) RecordImpressions(clientAddress string, serverNames []string) {
+	if len(serverNames) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rotate()
+	client := s.clientID(clientAddress)
+	for _, serverName := range serverNames {
+		s.record(s.impressions, client, serverName)
+	}
+}
+
+// ServerDownloads returns the number of distinct clients that fetched serverName in the last completed
+// window, or 0 when it was below the publishing threshold. It approximates downloads for search ranking.
+func (s *ReadStats) ServerDownloads(serverName string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rotate()
+	return s.publishedServers[serverName].UniqueClients
+}
+
+// NamespaceStats returns the downloads, search impressions and version adoption of every server in namespace
+// from the last completed window. Like Snapshot, it only includes counts seen by at least minClients distinct
+// clients.
+func (s *ReadStats) NamespaceStats(namespace string) NamespaceReadStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rotate()
+
+	prefix := namespace + "/"
+	servers := map[string]*ServerReadStats{}
+	server := func(name string) *ServerReadStats {
+		if servers[name] == nil {
+			servers[name] = &ServerReadStats{Name: name, Versions: []ReadStatsEntry{}}
+		}
+		return servers[name]
+	}
+
+	for name, entry := range s.publishedServers {
+		if strings.HasPrefix(name, prefix) {
+			server(name).Downloads = ReadStatsCount{UniqueClients: entry.UniqueClients, Requests: entry.Requests}
+		}
+	}
+	for name, entry := range s.publishedImpressions {
+		if strings.HasPrefix(name, prefix) {
+			server(name).Impressions = ReadStatsCount{UniqueClients: entry.UniqueClients, Requests: entry.Requests}
+		}
+	}
+	for key, entry := range s.publishedVersions {
+		name, version, _ := strings.Cut(key, versionKeySeparator)
+		if strings.HasPrefix(name, prefix) {
+			entry.Value = version
+			server(name).Versions = append(server(name).Versions, entry)
+		}
+	}
+
+	stats := NamespaceReadStats{
+		Namespace:        namespace,
+		WindowStart:      s.published.WindowStart,
+		WindowEnd:        s.published.WindowEnd,
+		MinUniqueClients: s.minClients,
+		Servers:          make([]ServerReadStats, 0, len(servers)),
+	}
+	for _, server := range servers {
+		sortEntries(server.Versions)
+		stats.TotalDownloads += server.Downloads.Requests
+		stats.TotalImpressions += server.Impressions.Requests
+		stats.Servers = append(stats.Servers, *server)
+	}
+	sort.Slice(stats.Servers, func(i, j int) bool {
+		a, b := stats.Servers[i], stats.Servers[j]
+		if a.Downloads.UniqueClients != b.Downloads.UniqueClients {
+			return a.Downloads.UniqueClients > b.Downloads.UniqueClients
+		}
+		if a.Impressions.UniqueClients != b.Impressions.UniqueClients {
+			return a.Impressions.UniqueClients > b.Impressions.UniqueClients
+		}
+		return a.Name < b.Name
+	})
+	return stats
+}
+
+// Snapshot returns up to limit entries of each kind from the last completed window
+func (s *ReadStats) Snapshot(limit int) ReadStatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rotate()
+
+	snapshot := s.published
+	snapshot.TopSearches = snapshot.TopSearches[:min(limit, len(snapshot.TopSearches))]
+	snapshot.TopServers = snapshot.TopServers[:min(limit, len(snapshot.TopServers))]
+	return snapshot
+}
+
+func (s *ReadStats) record(counters map[string]*readCounter, client uint64, key string) {
+	counter, ok := counters[key]
+	if !ok {
+		if len(counters) >= maxTrackedKeys {
+			return
+		}
+		counter = &readCounter{clients: map[uint64]struct{}{}}
+		counters[key] = counter
+	}
+
+	counter.requests++
+	if len(counter.clients) < maxClientsPerKey {
+		counter.clients[client] = struct{}{}
+	}
+}
+
+// clientID derives an opaque identifier for a client that is only stable within the current window
+func (s *ReadStats) clientID(clientAddress string) uint64 {
+	mac := hmac.New(sha256.New, s.salt)
+	mac.Write([]byte(clientAddress))
+	return binary.BigEndian.Uint64(mac.Sum(nil))
+}
+
+// rotate publishes the current window once it has ended and starts a new one with a fresh salt
+func (s *ReadStats) rotate() {
+	now := s.now()
+	windowEnd := s.windowStart.Add(s.window)
+	if now.Before(windowEnd) {
+		return
+	}
+
+	start := s.windowStart
+	s.published = ReadStatsSnapshot{
+		WindowStart:      &start,
+		WindowEnd:        &windowEnd,
+		MinUniqueClients: s.minClients,
+		TopSearches:      s.rank(s.searches),
+		TopServers:       s.rank(s.servers),
+	}
+	s.publishedServers = entriesByValue(s.published.TopServers)
+	s.publishedVersions = entriesByValue(s.rank(s.versions))
+	s.publishedImpressions = entriesByValue(s.rank(s.impressions))
+	s.reset(now)
+}
+
+func (s *ReadStats) reset(now time.Time) {
+	s.salt = make([]byte, 32)
+	_, _ = rand.Read(s.salt)
+	s.windowStart = now
+	s.searches = map[string]*readCounter{}
+	s.servers = map[string]*readCounter{}
+	s.versions = map[string]*readCounter{}
+	s.impressions = map[string]*readCounter{}
+}
+
+// rank returns the entries meeting the distinct client threshold, most distinct clients first
+func (s *ReadStats) rank(counters map[string]*readCounter) []ReadStatsEntry {
+	entries := []ReadStatsEntry{}
+	for key, counter := range counters {
+		if len(counter.clients) < s.minClients {
+			continue
+		}
+		entries = append(entries, ReadStatsEntry{Value: key, UniqueClients: len(counter.clients), Requests: counter.requests})
+	}
+	sortEntries(entries)
+	return entries
+}
+
+// sortEntries orders entries by distinct clients, then requests, then value
+func sortEntries(entries []ReadStatsEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].UniqueClients != entries[j].UniqueClients {
+			return entries[i].UniqueClients > entries[j].UniqueClients
+		}
+		if entries[i].Requests != entries[j].Requests {
+			return entries[i].Requests > entries[j].Requests
+		}
+		return entries[i].Value < entries[j].Value
+	})
+}
+
+// entriesByValue indexes published entries by their value
+func entriesByValue(entries []ReadStatsEntry) map[string]ReadStatsEntry {
+	byValue := make(map[string]ReadStatsEntry, len(entries))
+	for _, entry := range entries {
+		byValue[entry.Value] = entry
+	}
+	return byValue
+}
+
+type searchResultsKey struct{}
+
+// SearchResults collects the servers a search request returned, so their impressions can be counted
+type SearchResults struct {
+	serverNames []string
+}
+
+// WithSearchResults returns a context in which RecordSearchResults collects into the returned SearchResults
+func WithSearchResults(ctx context.Context) (context.Context, *SearchResults) {
+	results := &SearchResults{}
+	return context.WithValue(ctx, searchResultsKey{}, results), results
+}
+
+// RecordSearchResults notes the servers returned by a search, if ctx is collecting search results
+func RecordSearchResults(ctx context.Context, serverNames []string) {
+	if results, ok := ctx.Value(searchResultsKey{}).(*SearchResults); ok {
+		results.serverNames = append(results.serverNames, serverNames...)
+	}
+}
+
+// ServerNames returns the collected server names
+func (r *SearchResults) ServerNames() []string {
+	return r.serverNames
+}
@@ -0,0 +1,119 @@
+package telemetry_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+func TestReadStats_KAnonymity(t *testing.T) {
+	now := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	stats := telemetry.NewReadStats(3, time.Hour)
+	stats.SetClock(func() time.Time { return now })
+
+	// Seen by three distinct clients, with differing case and spacing
+	for i, term := range []string{"filesystem", "FileSystem", "  filesystem "} {
+		stats.RecordSearch(fmt.Sprintf("10.0.0.%d", i), term)
+	}
+	// One client repeating a search many times does not make it publishable
+	for range 50 {
+		stats.RecordSearch("10.0.0.99", "my secret project")
+	}
+	for i := range 4 {
+		stats.RecordServerFetch(fmt.Sprintf("10.0.1.%d", i), "io.github.example/weather")
+	}
+	stats.RecordServerFetch("10.0.1.0", "io.github.example/rare")
+
+	// Nothing is published until the window completes
+	snapshot := stats.Snapshot(10)
+	assert.Nil(t, snapshot.WindowStart)
+	assert.Empty(t, snapshot.TopSearches)
+	assert.Equal(t, 3, snapshot.MinUniqueClients)
+
+	now = now.Add(time.Hour)
+	snapshot = stats.Snapshot(10)
+	require.NotNil(t, snapshot.WindowStart)
+	assert.Equal(t, now.Add(-time.Hour), *snapshot.WindowStart)
+	assert.Equal(t, []telemetry.ReadStatsEntry{{Value: "filesystem", UniqueClients: 3, Requests: 3}}, snapshot.TopSearches)
+	assert.Equal(t, []telemetry.ReadStatsEntry{{Value: "io.github.example/weather", UniqueClients: 4, Requests: 4}}, snapshot.TopServers)
+	assert.Equal(t, 4, stats.ServerDownloads("io.github.example/weather"))
+	assert.Zero(t, stats.ServerDownloads("io.github.example/rare"), "entries below the threshold are not counted")
+
+	// The next window starts empty
+	now = now.Add(time.Hour)
+	snapshot = stats.Snapshot(10)
+	assert.Empty(t, snapshot.TopSearches)
+	assert.Empty(t, snapshot.TopServers)
+}
+
+func TestReadStats_RankingAndLimit(t *testing.T) {
+	now := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	stats := telemetry.NewReadStats(1, time.Hour)
+	stats.SetClock(func() time.Time { return now })
+
+	stats.RecordServerFetch("a", "com.example/one")
+	stats.RecordServerFetch("a", "com.example/one")
+	stats.RecordServerFetch("a", "com.example/two")
+	stats.RecordServerFetch("b", "com.example/two")
+	stats.RecordServerFetch("a", "com.example/three")
+
+	now = now.Add(time.Hour)
+	snapshot := stats.Snapshot(2)
+	assert.Equal(t, []telemetry.ReadStatsEntry{
+		{Value: "com.example/two", UniqueClients: 2, Requests: 2},
+		{Value: "com.example/one", UniqueClients: 1, Requests: 2},
+	}, snapshot.TopServers)
+}
+
+func TestReadStats_NamespaceStats(t *testing.T) {
+	now := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	stats := telemetry.NewReadStats(2, time.Hour)
+	stats.SetClock(func() time.Time { return now })
+
+	for _, client := range []string{"a", "b", "c"} {
+		stats.RecordServerFetch(client, "io.github.octocat/weather")
+		stats.RecordVersionFetch(client, "io.github.octocat/weather", "1.0.0")
+		stats.RecordImpressions(client, []string{"io.github.octocat/weather", "io.github.octocat/notes", "io.github.other/weather"})
+	}
+	stats.RecordVersionFetch("a", "io.github.octocat/weather", "latest")
+	stats.RecordVersionFetch("b", "io.github.octocat/weather", "latest")
+	stats.RecordVersionFetch("a", "io.github.octocat/weather", "0.9.0")
+	stats.RecordServerFetch("a", "io.github.octocat/notes")
+
+	// Nothing is published until the window completes
+	namespaceStats := stats.NamespaceStats("io.github.octocat")
+	assert.Nil(t, namespaceStats.WindowStart)
+	assert.Empty(t, namespaceStats.Servers)
+
+	now = now.Add(time.Hour)
+	namespaceStats = stats.NamespaceStats("io.github.octocat")
+	require.NotNil(t, namespaceStats.WindowStart)
+	assert.Equal(t, "io.github.octocat", namespaceStats.Namespace)
+	assert.Equal(t, 3, namespaceStats.TotalDownloads)
+	assert.Equal(t, 6, namespaceStats.TotalImpressions)
+	assert.Equal(t, []telemetry.ServerReadStats{
+		{
+			Name:        "io.github.octocat/weather",
+			Downloads:   telemetry.ReadStatsCount{UniqueClients: 3, Requests: 3},
+			Impressions: telemetry.ReadStatsCount{UniqueClients: 3, Requests: 3},
+			Versions: []telemetry.ReadStatsEntry{
+				{Value: "1.0.0", UniqueClients: 3, Requests: 3},
+				{Value: "latest", UniqueClients: 2, Requests: 2},
+			},
+		},
+		{
+			// A single client's fetch stays below the threshold
+			Name:        "io.github.octocat/notes",
+			Impressions: telemetry.ReadStatsCount{UniqueClients: 3, Requests: 3},
+			Versions:    []telemetry.ReadStatsEntry{},
+		},
+	}, namespaceStats.Servers)
+
+	// Namespaces only match whole name segments
+	assert.Empty(t, stats.NamespaceStats("io.github.octo").Servers)
+}
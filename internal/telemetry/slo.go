@@ -0,0 +1,190 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// SLIs reported in the slo attribute of the SLO gauges
+const (
+	// SLOAvailability counts requests that did not fail with a 5xx status as good
+	SLOAvailability = "availability"
+	// SLOLatency counts requests that completed within the latency threshold as good
+	SLOLatency = "latency"
+)
+
+// BurnRateWindows are the windows SLIs and burn rates are reported over: the short window catches fast burns,
+// such as an outage, and the long one slow burns that would still exhaust the error budget
+var BurnRateWindows = []time.Duration{time.Hour, 6 * time.Hour}
+
+// sloBucketWidth is the resolution of the sliding windows
+const sloBucketWidth = time.Minute
+
+// SLOObjectives are the service level objectives burn rates are computed against
+type SLOObjectives struct {
+	// Availability is the fraction of requests that must not fail with a 5xx status, such as 0.999
+	Availability float64
+	// Latency is the fraction of requests that must complete within LatencyThreshold, such as 0.99
+	Latency float64
+	// LatencyThreshold is how long a request may take and still count as fast
+	LatencyThreshold time.Duration
+}
+
+// sloBucket counts the requests that completed during one minute
+type sloBucket struct {
+	minute int64
+	total  int64
+	failed int64
+	slow   int64
+}
+
+// SLOTracker computes availability and latency SLIs over sliding windows of recent requests and reports
+// them with their burn rates as gauges, so operators can alert on SLO violations without recording rules
+type SLOTracker struct {
+	objectives SLOObjectives
+	now        func() time.Time
+
+	mu      sync.Mutex
+	buckets []sloBucket
+}
+
+// NewSLOTracker creates a tracker for objectives and registers its gauges with meter:
+// mcp_registry_slo_sli is the fraction of good requests and mcp_registry_slo_burn_rate how many times faster
+// than sustainable the error budget is being spent, both by slo and window. A burn rate of 1 spends exactly
+// the error budget over the SLO period; multi-window alerts typically fire at 14.4 over 1h and 6 over 6h.
+func NewSLOTracker(meter metric.Meter, objectives SLOObjectives) (*SLOTracker, error) {
+	for slo, objective := range map[string]float64{SLOAvailability: objectives.Availability, SLOLatency: objectives.Latency} {
+		if objective <= 0 || objective >= 1 {
+			return nil, fmt.Errorf("%s objective must be between 0 and 1, exclusive: %g", slo, objective)
+		}
+	}
+	if objectives.LatencyThreshold <= 0 {
+		return nil, fmt.Errorf("latency threshold must be positive: %s", objectives.LatencyThreshold)
+	}
+
+	longest := BurnRateWindows[len(BurnRateWindows)-1]
+	t := &SLOTracker{
+		objectives: objectives,
+		now:        time.Now,
+		buckets:    make([]sloBucket, longest/sloBucketWidth),
+	}
+
+	sli, err := meter.Float64ObservableGauge(
+		Namespace+".slo.sli",
+		metric.WithDescription("Fraction of good requests over the window, by SLO"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SLI gauge: %w", err)
+	}
+	burnRate, err := meter.Float64ObservableGauge(
+		Namespace+".slo.burn_rate",
+		metric.WithDescription("Rate at which the error budget is spent over the window, by SLO; 1 spends exactly the budget"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create burn rate gauge: %w", err)
+	}
+	objective, err := meter.Float64ObservableGauge(
+		Namespace+".slo.objective",
+		metric.WithDescription("Target fraction of good requests, by SLO"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SLO objective gauge: %w", err)
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for _, slo := range []string{SLOAvailability, SLOLatency} {
+			o.ObserveFloat64(objective, t.objective(slo), metric.WithAttributes(attribute.String("slo", slo)))
+			for _, window := range BurnRateWindows {
+				attrs := metric.WithAttributes(attribute.String("slo", slo), attribute.String("window", formatWindow(window)))
+				o.ObserveFloat64(sli, t.SLI(slo, window), attrs)
+				o.ObserveFloat64(burnRate, t.BurnRate(slo, window), attrs)
+			}
+		}
+		return nil
+	}, sli, burnRate, objective)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register SLO callback: %w", err)
+	}
+	return t, nil
+}
+
+// SetClock sets the function the tracker reads the current time from (used for testing)
+func (t *SLOTracker) SetClock(now func() time.Time) {
+	t.now = now
+}
+
+// Record counts a completed request towards the SLIs
+func (t *SLOTracker) Record(statusCode int, duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	minute := t.now().Unix() / int64(sloBucketWidth/time.Second)
+	bucket := &t.buckets[minute%int64(len(t.buckets))]
+	if bucket.minute != minute {
+		*bucket = sloBucket{minute: minute}
+	}
+	bucket.total++
+	if statusCode >= 500 {
+		bucket.failed++
+	}
+	if duration > t.objectives.LatencyThreshold {
+		bucket.slow++
+	}
+}
+
+// SLI returns the fraction of good requests for slo over the window ending now. Without requests, nothing
+// went wrong and the SLI is 1.
+func (t *SLOTracker) SLI(slo string, window time.Duration) float64 {
+	total, bad := t.count(slo, window)
+	if total == 0 {
+		return 1
+	}
+	return 1 - float64(bad)/float64(total)
+}
+
+// BurnRate returns how many times faster than sustainable slo's error budget was spent over the window ending now
+func (t *SLOTracker) BurnRate(slo string, window time.Duration) float64 {
+	return (1 - t.SLI(slo, window)) / (1 - t.objective(slo))
+}
+
+func (t *SLOTracker) objective(slo string) float64 {
+	if slo == SLOLatency {
+		return t.objectives.Latency
+	}
+	return t.objectives.Availability
+}
+
+// count returns how many requests completed within the window ending now and how many of them were bad for slo
+func (t *SLOTracker) count(slo string, window time.Duration) (int64, int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := t.now().Unix() / int64(sloBucketWidth/time.Second)
+	oldest := current - int64(window/sloBucketWidth) + 1
+	var total, bad int64
+	for _, bucket := range t.buckets {
+		if bucket.minute < oldest || bucket.minute > current {
+			continue
+		}
+		total += bucket.total
+		if slo == SLOLatency {
+			bad += bucket.slow
+		} else {
+			bad += bucket.failed
+		}
+	}
+	return total, bad
+}
+
+// formatWindow formats a window the way alerting rules usually name them, such as 1h or 30m
+func formatWindow(window time.Duration) string {
+	if window%time.Hour == 0 {
+		return fmt.Sprintf("%dh", window/time.Hour)
+	}
+	return fmt.Sprintf("%dm", window/time.Minute)
+}
@@ -0,0 +1,106 @@
+package telemetry_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+var testObjectives = telemetry.SLOObjectives{Availability: 0.99, Latency: 0.9, LatencyThreshold: 500 * time.Millisecond}
+
+func TestNewSLOTracker_InvalidObjectives(t *testing.T) {
+	meter := noop.NewMeterProvider().Meter("test")
+	for _, objectives := range []telemetry.SLOObjectives{
+		{Availability: 1, Latency: 0.9, LatencyThreshold: time.Second},
+		{Availability: 0.99, Latency: 0, LatencyThreshold: time.Second},
+		{Availability: 0.99, Latency: 0.9},
+	} {
+		_, err := telemetry.NewSLOTracker(meter, objectives)
+		assert.Error(t, err, "%+v", objectives)
+	}
+}
+
+func TestSLOTracker_Windows(t *testing.T) {
+	tracker, err := telemetry.NewSLOTracker(noop.NewMeterProvider().Meter("test"), testObjectives)
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tracker.SetClock(func() time.Time { return now })
+
+	assert.InDelta(t, 1.0, tracker.SLI(telemetry.SLOAvailability, time.Hour), 1e-9, "no requests means nothing went wrong")
+	assert.InDelta(t, 0.0, tracker.BurnRate(telemetry.SLOAvailability, time.Hour), 1e-9)
+
+	// Two hours ago: 100 requests, all failing
+	now = now.Add(-2 * time.Hour)
+	for range 100 {
+		tracker.Record(http.StatusBadGateway, time.Millisecond)
+	}
+	// Now: 100 requests, 2 failing and 20 slow
+	now = now.Add(2 * time.Hour)
+	for i := range 100 {
+		status := http.StatusOK
+		if i < 2 {
+			status = http.StatusInternalServerError
+		}
+		duration := 100 * time.Millisecond
+		if i >= 80 {
+			duration = time.Second
+		}
+		tracker.Record(status, duration)
+	}
+
+	assert.InDelta(t, 0.98, tracker.SLI(telemetry.SLOAvailability, time.Hour), 1e-9)
+	assert.InDelta(t, 2.0, tracker.BurnRate(telemetry.SLOAvailability, time.Hour), 1e-9)
+	assert.InDelta(t, 0.8, tracker.SLI(telemetry.SLOLatency, time.Hour), 1e-9)
+	assert.InDelta(t, 2.0, tracker.BurnRate(telemetry.SLOLatency, time.Hour), 1e-9)
+
+	// The long window still sees the earlier outage
+	assert.InDelta(t, 0.49, tracker.SLI(telemetry.SLOAvailability, 6*time.Hour), 1e-9)
+	assert.InDelta(t, 51.0, tracker.BurnRate(telemetry.SLOAvailability, 6*time.Hour), 1e-9)
+
+	// Requests age out of the windows
+	now = now.Add(6 * time.Hour)
+	assert.InDelta(t, 1.0, tracker.SLI(telemetry.SLOAvailability, 6*time.Hour), 1e-9)
+}
+
+func TestSLOTracker_Gauges(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics, err := telemetry.NewMetrics(provider.Meter("test"))
+	require.NoError(t, err)
+	require.NoError(t, metrics.TrackSLOs(testObjectives))
+
+	metrics.SLO.Record(http.StatusServiceUnavailable, time.Millisecond)
+	metrics.SLO.Record(http.StatusOK, time.Millisecond)
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+	burnRates := map[string]float64{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != telemetry.Namespace+".slo.burn_rate" {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Gauge[float64]).DataPoints {
+				slo, _ := point.Attributes.Value(attribute.Key("slo"))
+				window, _ := point.Attributes.Value(attribute.Key("window"))
+				burnRates[slo.AsString()+"/"+window.AsString()] = point.Value
+			}
+		}
+	}
+
+	require.Len(t, burnRates, 4)
+	assert.InDelta(t, 50.0, burnRates["availability/1h"], 1e-9)
+	assert.InDelta(t, 50.0, burnRates["availability/6h"], 1e-9)
+	assert.InDelta(t, 0.0, burnRates["latency/1h"], 1e-9)
+	assert.InDelta(t, 0.0, burnRates["latency/6h"], 1e-9)
+}
@@ -0,0 +1,112 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Stages of the validator pipeline
+const (
+	// ValidatorStageSchema validates server.json against the schema and semantic rules
+	ValidatorStageSchema = "schema"
+	// ValidatorStagePackage looks a package up in its upstream registry to check ownership
+	ValidatorStagePackage = "package"
+	// ValidatorStageLink probes one of the server's website, documentation, support or security links
+	ValidatorStageLink = "link"
+	// ValidatorStageRepository checks the server's GitHub repository against its namespace
+	ValidatorStageRepository = "repository"
+)
+
+// Outcomes of a validator stage
+const (
+	// ValidatorOutcomePass is a stage that accepted the server
+	ValidatorOutcomePass = "pass"
+	// ValidatorOutcomeFail is a stage that rejected the server
+	ValidatorOutcomeFail = "fail"
+	// ValidatorOutcomeError is a stage that could not reach its upstream, or timed out
+	ValidatorOutcomeError = "error"
+)
+
+// ValidatorMetrics records the duration and outcome of each validator stage, so operators can see which
+// upstream slows publishes down or makes them fail
+type ValidatorMetrics struct {
+	duration metric.Float64Histogram
+	runs     metric.Int64Counter
+}
+
+// NewValidatorMetrics creates the validator stage instruments with meter
+func NewValidatorMetrics(meter metric.Meter) (*ValidatorMetrics, error) {
+	duration, err := meter.Float64Histogram(
+		Namespace+".validator.stage.duration",
+		metric.WithDescription("Duration of validator stages in seconds, by stage, package registry type and outcome"),
+		metric.WithExplicitBucketBoundaries(
+			0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0,
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validator stage duration histogram: %w", err)
+	}
+
+	runs, err := meter.Int64Counter(
+		Namespace+".validator.stage.runs",
+		metric.WithDescription("Total number of validator stage runs, by stage, package registry type and outcome"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validator stage counter: %w", err)
+	}
+
+	return &ValidatorMetrics{duration: duration, runs: runs}, nil
+}
+
+// Record records a run of stage that started at start and ended with err. registryType is the package
+// registry type for package lookups and empty for other stages.
+func (m *ValidatorMetrics) Record(ctx context.Context, stage, registryType string, start time.Time, err error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("stage", stage),
+		attribute.String("outcome", ValidatorOutcome(err)),
+	}
+	if registryType != "" {
+		attrs = append(attrs, attribute.String("registry_type", registryType))
+	}
+	m.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+	m.runs.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
+
+// ValidatorOutcome classifies the error a validator stage ended with. Network errors and timeouts are errors
+// rather than failures, since they say nothing about the server.
+func ValidatorOutcome(err error) string {
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ValidatorOutcomePass
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return ValidatorOutcomeError
+	default:
+		return ValidatorOutcomeFail
+	}
+}
+
+// validatorMetrics are created from the global meter provider on first use, which InitMetrics sets before the
+// registry validates anything
+var validatorMetrics = sync.OnceValue(func() *ValidatorMetrics {
+	metrics, err := NewValidatorMetrics(otel.Meter(Namespace))
+	if err != nil {
+		return nil
+	}
+	return metrics
+})
+
+// RecordValidatorStage records a run of a validator stage with the registry's metrics, like ValidatorMetrics.Record
+func RecordValidatorStage(ctx context.Context, stage, registryType string, start time.Time, err error) {
+	if metrics := validatorMetrics(); metrics != nil {
+		metrics.Record(ctx, stage, registryType, start, err)
+	}
+}
@@ -0,0 +1,75 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+func TestValidatorMetrics_Record(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics, err := telemetry.NewValidatorMetrics(provider.Meter("test"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	start := time.Now().Add(-2 * time.Second)
+	metrics.Record(ctx, telemetry.ValidatorStagePackage, "npm", start, nil)
+	metrics.Record(ctx, telemetry.ValidatorStagePackage, "npm", start, errors.New("package not found"))
+	metrics.Record(ctx, telemetry.ValidatorStagePackage, "pypi", start, fmt.Errorf("failed to fetch: %w", context.DeadlineExceeded))
+	metrics.Record(ctx, telemetry.ValidatorStageSchema, "", start, nil)
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &data))
+	runs := map[string]int64{}
+	var durations []float64
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch m.Name {
+			case telemetry.Namespace + ".validator.stage.runs":
+				for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+					stage, _ := point.Attributes.Value(attribute.Key("stage"))
+					registryType, _ := point.Attributes.Value(attribute.Key("registry_type"))
+					outcome, _ := point.Attributes.Value(attribute.Key("outcome"))
+					runs[stage.AsString()+"/"+registryType.AsString()+"/"+outcome.AsString()] = point.Value
+				}
+			case telemetry.Namespace + ".validator.stage.duration":
+				for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+					durations = append(durations, point.Sum)
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]int64{
+		"package/npm/pass":   1,
+		"package/npm/fail":   1,
+		"package/pypi/error": 1,
+		"schema//pass":       1,
+	}, runs)
+	require.Len(t, durations, 4)
+	for _, duration := range durations {
+		assert.GreaterOrEqual(t, duration, 2.0)
+	}
+}
+
+func TestValidatorOutcome(t *testing.T) {
+	_, dialErr := http.Get("http://127.0.0.1:0/")
+	require.Error(t, dialErr)
+
+	assert.Equal(t, telemetry.ValidatorOutcomePass, telemetry.ValidatorOutcome(nil))
+	assert.Equal(t, telemetry.ValidatorOutcomeFail, telemetry.ValidatorOutcome(errors.New("status 404")))
+	assert.Equal(t, telemetry.ValidatorOutcomeError, telemetry.ValidatorOutcome(context.DeadlineExceeded))
+	assert.Equal(t, telemetry.ValidatorOutcomeError, telemetry.ValidatorOutcome(fmt.Errorf("failed to fetch package metadata: %w", dialErr)))
+}
@@ -0,0 +1,164 @@
+// Package toolindex finds the tools MCP servers expose, so servers can be searched by what they can do. Tools are
+// read from the MCPB manifest in a server's GitHub repository, or listed by a remote that answers without
+// credentials.
+package toolindex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/probe"
+	"github.com/modelcontextprotocol/registry/internal/repourl"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+const (
+	// maxTools is how many tools are indexed per server
+	maxTools = 200
+	// maxToolNameLength is the longest tool name indexed, in bytes; longer names are skipped
+	maxToolNameLength = 128
+	// maxDescriptionLength is how much of a tool description is indexed, in characters
+	maxDescriptionLength = 1000
+	// maxManifestBytes bounds how much of a manifest is read
+	maxManifestBytes = 1 << 20
+)
+
+// manifest is the subset of an MCPB manifest.json that is indexed
+type manifest struct {
+	Tools []database.ServerTool `json:"tools"`
+}
+
+// Indexer finds the tools servers expose
+type Indexer struct {
+	prober  *probe.Prober
+	token   string
+	baseURL string // Configurable for testing
+	client  *http.Client
+}
+
+// NewIndexer creates an indexer that reads repositories with the configured GitHub API token, if any, and lists
+// the tools of remotes with prober
+func NewIndexer(cfg *config.Config, prober *probe.Prober) *Indexer {
+	return &Indexer{
+		prober:  prober,
+		token:   cfg.GitHubAPIToken,
+		baseURL: "https://api.github.com",
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SetGitHubBaseURL sets the GitHub API base URL (used for testing)
+func (i *Indexer) SetGitHubBaseURL(baseURL string) {
+	i.baseURL = baseURL
+}
+
+// Tools returns the tools a server exposes and where they were found, as a database.ToolSource* constant. The
+// manifest.json at the root of the server's GitHub repository, or in its subfolder, is read first; without one,
+// each streamable HTTP remote is asked in turn until one lists its tools. Nothing is returned when no source
+// declares any tools. An error is returned when the repository cannot be read, so a server's index is never
+// dropped because GitHub is unreachable.
+func (i *Indexer) Tools(ctx context.Context, server *apiv0.ServerJSON) ([]database.ServerTool, string, error) {
+	tools, err := i.manifestTools(ctx, server)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(tools) > 0 {
+		return tools, database.ToolSourceManifest, nil
+	}
+
+	if i.prober == nil {
+		return nil, "", nil
+	}
+	for _, remote := range server.Remotes {
+		if remote.Type != model.TransportTypeStreamableHTTP || !probe.Probeable(remote) {
+			continue
+		}
+		listed, err := i.prober.ListTools(ctx, remote)
+		if err != nil {
+			continue
+		}
+		tools := make([]database.ServerTool, len(listed))
+		for j, tool := range listed {
+			tools[j] = database.ServerTool{Name: tool.Name, Description: tool.Description}
+		}
+		if tools = normalizeTools(tools); len(tools) > 0 {
+			return tools, database.ToolSourceRemote, nil
+		}
+	}
+	return nil, "", nil
+}
+
+// manifestTools reads the tools declared by the manifest.json in the server's GitHub repository, returning
+// nothing when the server has no GitHub repository or the repository has no manifest
+func (i *Indexer) manifestTools(ctx context.Context, server *apiv0.ServerJSON) ([]database.ServerTool, error) {
+	if server.Repository == nil || server.Repository.Source != string(validator.SourceGitHub) {
+		return nil, nil
+	}
+	owner, name, err := repourl.ParseGitHub(server.Repository.URL)
+	if err != nil {
+		return nil, nil //nolint:nilerr // servers not linking a GitHub repository declare no tools here
+	}
+
+	manifestPath := path.Join(server.Repository.Subfolder, "manifest.json")
+	requestURL := i.baseURL + "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name) + "/contents/" + (&url.URL{Path: manifestPath}).EscapedPath()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	repourl.SetGitHubHeaders(req, "application/vnd.github.raw+json", i.token)
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest from github.com/%s/%s: %w", owner, name, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusGone, http.StatusUnavailableForLegalReasons:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unable to fetch manifest from github.com/%s/%s (GitHub API status %d)", owner, name, resp.StatusCode)
+	}
+
+	var parsed manifest
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestBytes)).Decode(&parsed); err != nil {
+		// A manifest.json that is not an MCPB manifest declares no tools
+		return nil, nil
+	}
+	return normalizeTools(parsed.Tools), nil
+}
+
+// normalizeTools trims tool names and descriptions, skipping tools without a usable name and repeated names, and
+// truncates the list and long descriptions to what is indexed
+func normalizeTools(tools []database.ServerTool) []database.ServerTool {
+	var normalized []database.ServerTool
+	seen := map[string]bool{}
+	for _, tool := range tools {
+		tool.Name = strings.TrimSpace(tool.Name)
+		if tool.Name == "" || len(tool.Name) > maxToolNameLength || seen[tool.Name] {
+			continue
+		}
+		seen[tool.Name] = true
+		tool.Description = strings.TrimSpace(tool.Description)
+		if description := []rune(tool.Description); len(description) > maxDescriptionLength {
+			tool.Description = string(description[:maxDescriptionLength])
+		}
+		normalized = append(normalized, tool)
+		if len(normalized) == maxTools {
+			break
+		}
+	}
+	return normalized
+}
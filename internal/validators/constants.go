@@ -2,44 +2,25 @@ package validators
 
 import "errors"
 
-// Error messages for validation
+// Error messages for checks that need the network or registry configuration. Errors of the checks
+// anyone can run are in pkg/validator.
 var (
-	// Repository validation errors
-	ErrInvalidRepositoryURL = errors.New("invalid repository URL")
-	ErrInvalidSubfolderPath = errors.New("invalid subfolder path")
-
-	// Package validation errors
-	ErrPackageNameHasSpaces  = errors.New("package name cannot contain spaces")
-	ErrReservedVersionString = errors.New("version string 'latest' is reserved and cannot be used")
-	ErrVersionLooksLikeRange = errors.New("version must be a specific version, not a range")
-
-	// Transport validation errors
-	ErrInvalidPackageTransportURL = errors.New("invalid package transport URL")
-	ErrInvalidRemoteURL           = errors.New("invalid remote URL")
-
 	// Registry validation errors
 	ErrUnsupportedRegistryBaseURL   = errors.New("unsupported registry base URL")
 	ErrMismatchedRegistryTypeAndURL = errors.New("registry type and base URL do not match")
+	ErrRegistryValidationFailed     = errors.New("registry validation failed")
 
-	// Argument validation errors
-	ErrNamedArgumentNameRequired     = errors.New("named argument name is required")
-	ErrInvalidNamedArgumentName      = errors.New("invalid named argument name format")
-	ErrArgumentValueStartsWithName   = errors.New("argument value cannot start with the argument name")
-	ErrArgumentDefaultStartsWithName = errors.New("argument default cannot start with the argument name")
+	// Repository ownership errors
+	ErrRepositoryVerificationFailed = errors.New("repository ownership verification failed")
 
-	// Server name validation errors
-	ErrMultipleSlashesInServerName = errors.New("server name cannot contain multiple slashes")
-	ErrInvalidServerNameFormat     = errors.New("server name format is invalid")
-)
-
-// RepositorySource represents valid repository sources
-type RepositorySource string
+	// ErrUpstreamUnavailable means a check could not run because the service it asks is failing or rate
+	// limiting, so it may pass when tried again later
+	ErrUpstreamUnavailable = errors.New("upstream temporarily unavailable")
 
-const (
-	SourceGitHub RepositorySource = "github"
-	SourceGitLab RepositorySource = "gitlab"
-)
+	// Link check errors
+	ErrLinkUnreachable = errors.New("link is unreachable")
 
-const (
-	SchemeHTTPS = "https"
+	// Screening errors
+	ErrReservedTerm   = errors.New("uses a reserved term")
+	ErrProhibitedTerm = errors.New("uses a prohibited term")
 )
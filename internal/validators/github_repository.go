@@ -0,0 +1,143 @@
+package validators
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/repourl"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// GitHub repository visibility policies
+const (
+	// RepoVisibilityPublic requires linked repositories to be public
+	RepoVisibilityPublic = "public"
+	// RepoVisibilityAny also accepts private repositories visible to the configured GitHub API token
+	RepoVisibilityAny = "any"
+)
+
+// githubRepository is the subset of the GitHub repository API response that is checked
+type githubRepository struct {
+	ID       int64  `json:"id"`
+	FullName string `json:"full_name"`
+	Private  bool   `json:"private"`
+	Owner    struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+// GitHubRepositoryVerifier re-checks that the GitHub repository linked from an io.github.* server still
+// exists, meets the visibility policy, and belongs to the user or organization that owns the namespace.
+// This catches repositories that were deleted, made private, or transferred after the server was first published.
+type GitHubRepositoryVerifier struct {
+	enabled    bool
+	visibility string
+	token      string
+	baseURL    string // Configurable for testing
+	client     *http.Client
+}
+
+// NewGitHubRepositoryVerifier creates a verifier from the registry configuration
+func NewGitHubRepositoryVerifier(cfg *config.Config) *GitHubRepositoryVerifier {
+	return &GitHubRepositoryVerifier{
+		enabled:    cfg.GitHubRepoVerification,
+		visibility: cfg.GitHubRepoVisibility,
+		token:      cfg.GitHubAPIToken,
+		baseURL:    "https://api.github.com",
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SetBaseURL sets the GitHub API base URL (used for testing)
+func (v *GitHubRepositoryVerifier) SetBaseURL(baseURL string) {
+	v.baseURL = baseURL
+}
+
+// Verify checks the server's GitHub repository. Servers outside io.github.* and servers without a
+// GitHub repository are not checked, and nothing is checked when verification is disabled.
+func (v *GitHubRepositoryVerifier) Verify(ctx context.Context, server *apiv0.ServerJSON) error {
+	if !v.enabled || server.Repository == nil || server.Repository.Source != string(validator.SourceGitHub) {
+		return nil
+	}
+
+	namespace, _, found := strings.Cut(server.Name, "/")
+	namespaceOwner, isGitHub := strings.CutPrefix(namespace, "io.github.")
+	if !found || !isGitHub {
+		return nil
+	}
+
+	start := time.Now()
+	err := v.verify(ctx, server, namespace, namespaceOwner)
+	telemetry.RecordValidatorStage(ctx, telemetry.ValidatorStageRepository, "", start, err)
+	return err
+}
+
+// verify checks the GitHub repository of a server in namespace, owned by namespaceOwner
+func (v *GitHubRepositoryVerifier) verify(ctx context.Context, server *apiv0.ServerJSON, namespace, namespaceOwner string) error {
+	repoOwner, repoName, err := repourl.ParseGitHub(server.Repository.URL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRepositoryVerificationFailed, err)
+	}
+
+	repo, err := v.fetchRepository(ctx, repoOwner, repoName)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRepositoryVerificationFailed, err)
+	}
+
+	// A repository deleted and recreated under the same name gets a new ID
+	if server.Repository.ID != "" {
+		if id, err := strconv.ParseInt(server.Repository.ID, 10, 64); err == nil && id != repo.ID {
+			return fmt.Errorf("%w: repository %s was recreated (ID %d, expected %d)", ErrRepositoryVerificationFailed, repo.FullName, repo.ID, id)
+		}
+	}
+
+	if repo.Private && v.visibility != RepoVisibilityAny {
+		return fmt.Errorf("%w: repository %s is private; linked repositories must be public", ErrRepositoryVerificationFailed, repo.FullName)
+	}
+
+	// GitHub redirects transferred repositories, so the owner reported here is the current one
+	if !strings.EqualFold(repo.Owner.Login, namespaceOwner) {
+		return fmt.Errorf("%w: repository %s is owned by %s, not by %s who owns the %s namespace",
+			ErrRepositoryVerificationFailed, repo.FullName, repo.Owner.Login, namespaceOwner, namespace)
+	}
+
+	return nil
+}
+
+func (v *GitHubRepositoryVerifier) fetchRepository(ctx context.Context, owner, name string) (*githubRepository, error) {
+	requestURL := v.baseURL + "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	repourl.SetGitHubHeaders(req, "application/vnd.github+json", v.token)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch repository github.com/%s/%s: %w", owner, name, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("repository github.com/%s/%s does not exist or is not public", owner, name)
+	default:
+		return nil, fmt.Errorf("unable to verify repository github.com/%s/%s right now (GitHub API status %d), try again later: %w", owner, name, resp.StatusCode, ErrUpstreamUnavailable)
+	}
+
+	var repo githubRepository
+	if err := json.NewDecoder(resp.Body).Decode(&repo); err != nil {
+		return nil, fmt.Errorf("failed to parse repository response: %w", err)
+	}
+	return &repo, nil
+}
@@ -0,0 +1,102 @@
+package validators_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestGitHubRepositoryVerifier_Verify(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/octo/server":
+			_, _ = w.Write([]byte(`{"id": 42, "full_name": "octo/server", "private": false, "owner": {"login": "Octo"}}`))
+		case "/repos/octo/private":
+			_, _ = w.Write([]byte(`{"id": 43, "full_name": "octo/private", "private": true, "owner": {"login": "octo"}}`))
+		case "/repos/octo/moved":
+			// GitHub follows the redirect for transferred repositories
+			_, _ = w.Write([]byte(`{"id": 44, "full_name": "someone-else/moved", "private": false, "owner": {"login": "someone-else"}}`))
+		case "/repos/octo/flaky":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	newVerifier := func(visibility string) *validators.GitHubRepositoryVerifier {
+		verifier := validators.NewGitHubRepositoryVerifier(&config.Config{
+			GitHubRepoVerification: true,
+			GitHubRepoVisibility:   visibility,
+			GitHubAPIToken:         "test-token",
+		})
+		verifier.SetBaseURL(server.URL)
+		return verifier
+	}
+
+	serverJSON := func(name, repoURL, repoID string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Name:       name,
+			Repository: &model.Repository{URL: repoURL, Source: "github", ID: repoID},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		visibility  string
+		server      *apiv0.ServerJSON
+		expectError string
+	}{
+		{"owned public repository", "public", serverJSON("io.github.octo/server", "https://github.com/octo/server", ""), ""},
+		{"matching repository ID", "public", serverJSON("io.github.octo/server", "https://github.com/octo/server.git", "42"), ""},
+		{"recreated repository", "public", serverJSON("io.github.octo/server", "https://github.com/octo/server", "7"), "was recreated"},
+		{"deleted repository", "public", serverJSON("io.github.octo/server", "https://github.com/octo/gone", ""), "does not exist"},
+		{"private repository", "public", serverJSON("io.github.octo/server", "https://github.com/octo/private", ""), "is private"},
+		{"private repository allowed", "any", serverJSON("io.github.octo/server", "https://github.com/octo/private", ""), ""},
+		{"transferred repository", "public", serverJSON("io.github.octo/server", "https://github.com/octo/moved", ""), "owned by someone-else"},
+		{"GitHub API error", "public", serverJSON("io.github.octo/server", "https://github.com/octo/flaky", ""), "unable to verify"},
+		{"non-GitHub namespace", "public", serverJSON("com.example/server", "https://github.com/octo/gone", ""), ""},
+		{"non-GitHub repository", "public", &apiv0.ServerJSON{Name: "io.github.octo/server", Repository: &model.Repository{URL: "https://gitlab.com/octo/gone", Source: "gitlab"}}, ""},
+		{"no repository", "public", &apiv0.ServerJSON{Name: "io.github.octo/server"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newVerifier(tt.visibility).Verify(context.Background(), tt.server)
+			if tt.expectError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, validators.ErrRepositoryVerificationFailed))
+			assert.Contains(t, err.Error(), tt.expectError)
+		})
+	}
+
+	require.NoError(t, newVerifier("public").Verify(context.Background(), serverJSON("io.github.octo/server", "https://github.com/octo/server", "")))
+	assert.Equal(t, "Bearer test-token", gotAuth)
+}
+
+func TestGitHubRepositoryVerifier_Disabled(t *testing.T) {
+	verifier := validators.NewGitHubRepositoryVerifier(&config.Config{})
+	verifier.SetBaseURL("http://127.0.0.1:0")
+
+	err := verifier.Verify(context.Background(), &apiv0.ServerJSON{
+		Name:       "io.github.octo/server",
+		Repository: &model.Repository{URL: "https://github.com/octo/gone", Source: "github"},
+	})
+	assert.NoError(t, err)
+}
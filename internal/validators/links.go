@@ -0,0 +1,64 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+// CheckLinks verifies that the server's website, documentation, support and security links respond without
+// an error status, so clients are not sent to dead pages. mailto: addresses are not checked.
+func CheckLinks(ctx context.Context, server *apiv0.ServerJSON) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	for _, link := range validator.Links(server) {
+		if err := checkLink(ctx, client, link.URL); err != nil {
+			return fmt.Errorf("%w: %s (%s): %w", ErrLinkUnreachable, link.Path, link.URL, err)
+		}
+	}
+	return nil
+}
+
+// LinkChecker checks links with plain HTTP GET requests, for validator.CheckServer
+type LinkChecker struct {
+	Client *http.Client
+}
+
+var _ validator.LinkChecker = LinkChecker{}
+
+// CheckLink implements validator.LinkChecker
+func (c LinkChecker) CheckLink(ctx context.Context, link string) error {
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return checkLink(ctx, client, link)
+}
+
+func checkLink(ctx context.Context, client *http.Client, link string) (err error) {
+	start := time.Now()
+	defer func() {
+		telemetry.RecordValidatorStage(ctx, telemetry.ValidatorStageLink, "", start, err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
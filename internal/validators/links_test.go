@@ -0,0 +1,62 @@
+package validators_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+func TestCheckLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	err := validators.CheckLinks(context.Background(), &apiv0.ServerJSON{
+		WebsiteURL:       server.URL + "/",
+		DocumentationURL: server.URL + "/docs",
+		SupportURL:       "mailto:support@example.com",
+	})
+	assert.NoError(t, err)
+
+	err = validators.CheckLinks(context.Background(), &apiv0.ServerJSON{
+		WebsiteURL:       server.URL + "/",
+		DocumentationURL: server.URL + "/missing",
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, validators.ErrLinkUnreachable))
+	assert.Contains(t, err.Error(), "documentationUrl")
+	assert.Contains(t, err.Error(), "status 404")
+}
+
+func TestLinkChecker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	result := validator.CheckServer(context.Background(), &apiv0.ServerJSON{
+		WebsiteURL:       server.URL + "/",
+		DocumentationURL: server.URL + "/missing",
+	}, validator.Checks{Links: validators.LinkChecker{Client: server.Client()}})
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, "documentationUrl", result.Issues[0].Path)
+	assert.Equal(t, "status 404", result.Issues[0].Message)
+}
@@ -3,9 +3,13 @@ package validators
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
 	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 )
 
 // ValidatePackage validates that the package referenced in the server configuration is:
@@ -27,3 +31,47 @@ func ValidatePackage(ctx context.Context, pkg model.Package, serverName string)
 		return fmt.Errorf("unsupported registry type: %s", pkg.RegistryType)
 	}
 }
+
+// ResolvePackage validates a package like ValidatePackage and returns what it resolved to upstream: the exact
+// version and integrity hash for NPM and PyPI, and the manifest or index digest for OCI images. Registries
+// without provenance, and OCI images whose validation was skipped due to rate limiting, return nil.
+func ResolvePackage(ctx context.Context, pkg model.Package, serverName string) (*apiv0.PackageProvenance, error) {
+	start := time.Now()
+	resolved, err := resolvePackage(ctx, pkg, serverName)
+	telemetry.RecordValidatorStage(ctx, telemetry.ValidatorStagePackage, pkg.RegistryType, start, err)
+	return resolved, err
+}
+
+func resolvePackage(ctx context.Context, pkg model.Package, serverName string) (*apiv0.PackageProvenance, error) {
+	switch pkg.RegistryType {
+	case model.RegistryTypeNPM:
+		return registries.ResolveNPM(ctx, pkg, serverName)
+	case model.RegistryTypePyPI:
+		return registries.ResolvePyPI(ctx, pkg, serverName)
+	case model.RegistryTypeOCI:
+		digest, err := registries.ResolveOCI(ctx, pkg, serverName)
+		if err != nil || digest == "" {
+			return nil, err
+		}
+		return &apiv0.PackageProvenance{
+			RegistryType: model.RegistryTypeOCI,
+			Identifier:   pkg.Identifier,
+			Digest:       digest,
+		}, nil
+	default:
+		return nil, ValidatePackage(ctx, pkg, serverName)
+	}
+}
+
+// PackageChecker checks packages against their registries with ValidatePackage, for validator.CheckServer
+type PackageChecker struct{}
+
+var _ validator.PackageChecker = PackageChecker{}
+
+// CheckPackage implements validator.PackageChecker
+func (PackageChecker) CheckPackage(ctx context.Context, pkg model.Package, serverName string) error {
+	start := time.Now()
+	err := ValidatePackage(ctx, pkg, serverName)
+	telemetry.RecordValidatorStage(ctx, telemetry.ValidatorStagePackage, pkg.RegistryType, start, err)
+	return err
+}
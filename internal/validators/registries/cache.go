@@ -0,0 +1,146 @@
+package registries
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// maxCachedBodySize bounds the responses stored in the metadata cache. Larger responses, such as the full
+// package documents of popular npm packages, are passed through uncached.
+const maxCachedBodySize = 4 << 20
+
+type metadataCacheKey struct{}
+
+// MetadataCache is a read-through cache of upstream registry lookups stored in the database, so it is
+// shared by every replica. Successful responses are kept for ttl and not-found responses for negativeTTL;
+// other statuses, including rate limiting, are never cached.
+type MetadataCache struct {
+	db          database.Database
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+// NewMetadataCache creates a metadata cache, or returns nil if both TTLs are zero
+func NewMetadataCache(db database.Database, ttl, negativeTTL time.Duration) *MetadataCache {
+	if ttl <= 0 && negativeTTL <= 0 {
+		return nil
+	}
+	return &MetadataCache{db: db, ttl: ttl, negativeTTL: negativeTTL}
+}
+
+// WithMetadataCache returns a context whose registry lookups are served through cache. A nil cache
+// leaves lookups uncached.
+func WithMetadataCache(ctx context.Context, cache *MetadataCache) context.Context {
+	if cache == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, metadataCacheKey{}, cache)
+}
+
+// ttlFor returns how long a response with the given status is cached, or 0 if it isn't
+func (c *MetadataCache) ttlFor(statusCode int) time.Duration {
+	switch statusCode {
+	case http.StatusOK:
+		return c.ttl
+	case http.StatusNotFound, http.StatusGone:
+		return c.negativeTTL
+	default:
+		return 0
+	}
+}
+
+// metadataClient is the HTTP client for registry lookups, cached when the request context carries a MetadataCache
+var metadataClient = &http.Client{
+	Timeout:   10 * time.Second,
+	Transport: &cachingTransport{base: http.DefaultTransport},
+}
+
+// cachingTransport serves GET requests from the MetadataCache in the request context, storing misses
+type cachingTransport struct {
+	base http.RoundTripper
+	// cacheable limits which requests are cached; nil caches every GET
+	cacheable func(req *http.Request) bool
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cache, _ := req.Context().Value(metadataCacheKey{}).(*MetadataCache)
+	if cache == nil || req.Method != http.MethodGet || (t.cacheable != nil && !t.cacheable(req)) {
+		return t.base.RoundTrip(req)
+	}
+
+	// Responses vary by content negotiation, so the Accept header is part of the key
+	key := req.Method + " " + req.URL.String() + " " + req.Header.Get("Accept")
+	entry, err := cache.db.GetUpstreamCacheEntry(req.Context(), nil, key)
+	switch {
+	case err == nil:
+		return cachedResponse(req, entry), nil
+	case !errors.Is(err, database.ErrNotFound):
+		log.Printf("Upstream cache lookup failed for %s: %v", req.URL.Redacted(), err)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	ttl := cache.ttlFor(resp.StatusCode)
+	if ttl <= 0 {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBodySize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBodySize {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = cache.db.PutUpstreamCacheEntry(req.Context(), nil, database.UpstreamCacheEntry{
+		Key:        key,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		ExpiresAt:  time.Now().Add(ttl),
+	})
+	if err != nil {
+		log.Printf("Failed to cache upstream response for %s: %v", req.URL.Redacted(), err)
+	}
+	return resp, nil
+}
+
+// cachedResponse rebuilds an HTTP response from a cache entry
+func cachedResponse(req *http.Request, entry *database.UpstreamCacheEntry) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.StatusCode, http.StatusText(entry.StatusCode)),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header(entry.Header),
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}
+}
+
+// isOCIContentRequest reports whether an OCI distribution request fetches a manifest or blob. Registry
+// pings and token requests are not cached, since tokens expire.
+func isOCIContentRequest(req *http.Request) bool {
+	return strings.Contains(req.URL.Path, "/manifests/") || strings.Contains(req.URL.Path, "/blobs/")
+}
@@ -0,0 +1,77 @@
+package registries
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+func TestCachingTransport(t *testing.T) {
+	hits := map[string]int{}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits[r.URL.Path]++
+		switch r.URL.Path {
+		case "/found":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"version":"1.0.0"}`))
+		case "/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/large":
+			_, _ = w.Write([]byte(strings.Repeat("x", maxCachedBodySize+1)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	db := database.NewMemory()
+	cache := NewMetadataCache(db, time.Minute, time.Minute)
+	get := func(ctx context.Context, path string) (int, string, string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, upstream.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := metadataClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, resp.Header.Get("Content-Type"), string(body)
+	}
+	ctx := WithMetadataCache(context.Background(), cache)
+
+	for range 3 {
+		status, contentType, body := get(ctx, "/found")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "application/json", contentType)
+		assert.JSONEq(t, `{"version":"1.0.0"}`, body)
+
+		status, _, _ = get(ctx, "/missing")
+		assert.Equal(t, http.StatusNotFound, status)
+
+		status, _, _ = get(ctx, "/limited")
+		assert.Equal(t, http.StatusTooManyRequests, status)
+
+		_, _, body = get(ctx, "/large")
+		assert.Len(t, body, maxCachedBodySize+1)
+	}
+	assert.Equal(t, 1, hits["/found"])
+	assert.Equal(t, 1, hits["/missing"], "not-found responses are cached")
+	assert.Equal(t, 3, hits["/limited"], "rate limiting is never cached")
+	assert.Equal(t, 3, hits["/large"], "oversized responses are passed through")
+
+	// Lookups without a cache in the context always reach the upstream
+	get(context.Background(), "/found")
+	assert.Equal(t, 2, hits["/found"])
+
+	// Disabled caches are nil and leave the context unchanged
+	assert.Nil(t, NewMetadataCache(db, 0, 0))
+	assert.Equal(t, context.Background(), WithMetadataCache(context.Background(), nil))
+}
@@ -0,0 +1,26 @@
+package registries
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPackageNotFound matches errors returned when a referenced package does not exist in its upstream registry
+var ErrPackageNotFound = errors.New("package not found in upstream registry")
+
+// packageNotFoundError keeps the registry-specific message while matching ErrPackageNotFound with errors.Is
+type packageNotFoundError struct {
+	msg string
+}
+
+func (e *packageNotFoundError) Error() string {
+	return e.msg
+}
+
+func (e *packageNotFoundError) Is(target error) bool {
+	return target == ErrPackageNotFound
+}
+
+func newPackageNotFoundError(format string, args ...any) error {
+	return &packageNotFoundError{msg: fmt.Sprintf(format, args...)}
+}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"time"
 
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
@@ -20,17 +21,34 @@ var (
 // NPMPackageResponse represents the structure returned by the NPM registry API
 type NPMPackageResponse struct {
 	MCPName string `json:"mcpName"`
+	Version string `json:"version"`
+	Dist    struct {
+		Integrity string `json:"integrity"`
+		Shasum    string `json:"shasum"`
+	} `json:"dist"`
+}
+
+// npmPackumentTimes is the part of the full package document listing when each version was published
+type npmPackumentTimes struct {
+	Time map[string]string `json:"time"`
 }
 
 // ValidateNPM validates that an NPM package contains the correct MCP server name
 func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) error {
+	_, err := ResolveNPM(ctx, pkg, serverName)
+	return err
+}
+
+// ResolveNPM validates an NPM package like ValidateNPM and returns the exact version, tarball integrity
+// and upstream publish time it resolved to
+func ResolveNPM(ctx context.Context, pkg model.Package, serverName string) (*apiv0.PackageProvenance, error) {
 	// Set default registry base URL if empty
 	if pkg.RegistryBaseURL == "" {
 		pkg.RegistryBaseURL = model.RegistryURLNPM
 	}
 
 	if pkg.Identifier == "" {
-		return ErrMissingIdentifierForNPM
+		return nil, ErrMissingIdentifierForNPM
 	}
 
 	// we need version to look up the package metadata
@@ -38,26 +56,26 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 	// and we won't be able to validate the mcpName field
 	// against the server name
 	if pkg.Version == "" {
-		return ErrMissingVersionForNPM
+		return nil, ErrMissingVersionForNPM
 	}
 
 	// Validate that MCPB-specific fields are not present
 	if pkg.FileSHA256 != "" {
-		return fmt.Errorf("NPM packages must not have 'fileSha256' field")
+		return nil, fmt.Errorf("NPM packages must not have 'fileSha256' field")
 	}
 
 	// Validate that the registry base URL matches NPM exactly
 	if pkg.RegistryBaseURL != model.RegistryURLNPM {
-		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
+		return nil, fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
 			pkg.RegistryBaseURL, model.RegistryTypeNPM, model.RegistryURLNPM)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := metadataClient
 
 	requestURL := pkg.RegistryBaseURL + "/" + url.PathEscape(pkg.Identifier) + "/" + url.PathEscape(pkg.Version)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
@@ -65,26 +83,73 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to fetch package metadata from NPM: %w", err)
+		return nil, fmt.Errorf("failed to fetch package metadata from NPM: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("NPM package '%s' not found (status: %d)", pkg.Identifier, resp.StatusCode)
+		return nil, newPackageNotFoundError("NPM package '%s' not found (status: %d)", pkg.Identifier, resp.StatusCode)
 	}
 
 	var npmResp NPMPackageResponse
 	if err := json.NewDecoder(resp.Body).Decode(&npmResp); err != nil {
-		return fmt.Errorf("failed to parse NPM package metadata: %w", err)
+		return nil, fmt.Errorf("failed to parse NPM package metadata: %w", err)
 	}
 
 	if npmResp.MCPName == "" {
-		return fmt.Errorf("NPM package '%s' is missing required 'mcpName' field. Add this to your package.json: \"mcpName\": \"%s\"", pkg.Identifier, serverName)
+		return nil, fmt.Errorf("NPM package '%s' is missing required 'mcpName' field. Add this to your package.json: \"mcpName\": \"%s\"", pkg.Identifier, serverName)
 	}
 
 	if npmResp.MCPName != serverName {
-		return fmt.Errorf("NPM package ownership validation failed. Expected mcpName '%s', got '%s'", serverName, npmResp.MCPName)
+		return nil, fmt.Errorf("NPM package ownership validation failed. Expected mcpName '%s', got '%s'", serverName, npmResp.MCPName)
+	}
+
+	digest := npmResp.Dist.Integrity
+	if digest == "" && npmResp.Dist.Shasum != "" {
+		// Packages published before npm recorded SRI hashes only have a SHA-1 shasum
+		digest = "sha1:" + npmResp.Dist.Shasum
+	}
+	resolvedVersion := npmResp.Version
+	if resolvedVersion == "" {
+		resolvedVersion = pkg.Version
+	}
+
+	return &apiv0.PackageProvenance{
+		RegistryType:        model.RegistryTypeNPM,
+		Identifier:          pkg.Identifier,
+		Version:             resolvedVersion,
+		Digest:              digest,
+		UpstreamPublishedAt: npmPublishTime(ctx, client, pkg, resolvedVersion),
+	}, nil
+}
+
+// npmPublishTime looks up when a version was published. The version document doesn't include it, so
+// it's read from the full package document; failures are not fatal since ownership is already verified.
+func npmPublishTime(ctx context.Context, client *http.Client, pkg model.Package, version string) *time.Time {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pkg.RegistryBaseURL+"/"+url.PathEscape(pkg.Identifier), nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
 	}
 
-	return nil
+	var packument npmPackumentTimes
+	if err := json.NewDecoder(resp.Body).Decode(&packument); err != nil {
+		return nil
+	}
+	published, err := time.Parse(time.RFC3339, packument.Time[version])
+	if err != nil {
+		return nil
+	}
+	published = published.UTC()
+	return &published
 }
@@ -2,11 +2,13 @@ package registries_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateNPM_RealPackages(t *testing.T) {
@@ -135,3 +137,17 @@ func TestValidateNPM_RealPackages(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveNPM_RecordsProvenance(t *testing.T) {
+	provenance, err := registries.ResolveNPM(context.Background(), model.Package{
+		RegistryType: model.RegistryTypeNPM,
+		Identifier:   "@hellocoop/admin-mcp",
+		Version:      "1.5.7",
+	}, "io.github.hellocoop/admin-mcp")
+	require.NoError(t, err)
+
+	assert.Equal(t, model.RegistryTypeNPM, provenance.RegistryType)
+	assert.Equal(t, "1.5.7", provenance.Version)
+	assert.True(t, strings.HasPrefix(provenance.Digest, "sha512-"), provenance.Digest)
+	require.NotNil(t, provenance.UpstreamPublishedAt)
+}
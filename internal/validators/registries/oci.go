@@ -39,7 +39,26 @@ var allowedOCIRegistries = map[string]bool{
 	// Azure Container Registry (*.azurecr.io pattern handled in isAllowedRegistry)
 }
 
+// ociTransport fetches OCI content through the metadata cache
+var ociTransport = &cachingTransport{base: remote.DefaultTransport, cacheable: isOCIContentRequest}
+
+// ociServerNameAnnotation is the label or annotation that ties an OCI image to an MCP server name
+const ociServerNameAnnotation = "io.modelcontextprotocol.server.name"
+
 // ValidateOCI validates that an OCI image contains the correct MCP server name annotation.
+// See ResolveOCI for the supported references and registries.
+func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) error {
+	_, err := ResolveOCI(ctx, pkg, serverName)
+	return err
+}
+
+// ResolveOCI validates that an OCI image exists and carries the correct MCP server name, and returns
+// the digest the reference currently resolves to. For multi-arch images this is the digest of the index.
+// The digest is empty if validation was skipped because the registry rate limited us.
+//
+// The server name may be set as an image config label (LABEL in a Dockerfile) or as a manifest or
+// index annotation; every one that is present must match.
+//
 // Supports canonical OCI references including:
 //   - registry/namespace/image:tag
 //   - registry/namespace/image@sha256:digest
@@ -51,33 +70,33 @@ var allowedOCIRegistries = map[string]bool{
 //   - GitHub Container Registry (ghcr.io)
 //   - Google Artifact Registry (*.pkg.dev)
 //   - Microsoft Container Registry (mcr.microsoft.com)
-func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) error {
+func ResolveOCI(ctx context.Context, pkg model.Package, serverName string) (string, error) {
 	if pkg.Identifier == "" {
-		return ErrMissingIdentifierForOCI
+		return "", ErrMissingIdentifierForOCI
 	}
 
 	// Validate that old format fields are not present
 	if pkg.RegistryBaseURL != "" {
-		return fmt.Errorf("OCI packages must not have 'registryBaseUrl' field - use canonical reference in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
+		return "", fmt.Errorf("OCI packages must not have 'registryBaseUrl' field - use canonical reference in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
 	}
 	if pkg.Version != "" {
-		return fmt.Errorf("OCI packages must not have 'version' field - include version in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
+		return "", fmt.Errorf("OCI packages must not have 'version' field - include version in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
 	}
 	if pkg.FileSHA256 != "" {
-		return fmt.Errorf("OCI packages must not have 'fileSha256' field")
+		return "", fmt.Errorf("OCI packages must not have 'fileSha256' field")
 	}
 
 	// Parse the OCI reference using go-containerregistry's name package
 	// This handles all the complexity of reference parsing including defaults
 	ref, err := name.ParseReference(pkg.Identifier)
 	if err != nil {
-		return fmt.Errorf("invalid OCI reference: %w", err)
+		return "", fmt.Errorf("invalid OCI reference: %w", err)
 	}
 
 	// Validate that the registry is in the allowlist
 	registry := ref.Context().RegistryStr()
 	if !isAllowedRegistry(registry) {
-		return fmt.Errorf("%w: %s", ErrUnsupportedRegistry, registry)
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedRegistry, registry)
 	}
 
 	// Add explicit timeout to prevent hanging on slow registries
@@ -85,17 +104,18 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	// Fetch the image using anonymous authentication (public images only)
+	// Resolve the reference using anonymous authentication (public images only)
 	// The go-containerregistry library handles:
 	// - OCI auth discovery via WWW-Authenticate headers
 	// - Token negotiation for different registries
 	// - Rate limiting and retries
-	// - Multi-arch manifest resolution
-	img, err := remote.Image(ref, remote.WithAuth(authn.Anonymous), remote.WithContext(timeoutCtx))
+	// Fetching a digest reference also confirms that the digest exists
+	// Manifests and blobs are served from the metadata cache when the context carries one
+	desc, err := remote.Get(ref, remote.WithAuth(authn.Anonymous), remote.WithContext(timeoutCtx), remote.WithTransport(ociTransport))
 	if err != nil {
 		// Check if this is a timeout error
 		if errors.Is(err, context.DeadlineExceeded) {
-			return fmt.Errorf("OCI image validation timed out after 30 seconds for '%s'. The registry may be slow or unreachable", pkg.Identifier)
+			return "", fmt.Errorf("OCI image validation timed out after 30 seconds for '%s'. The registry may be slow or unreachable", pkg.Identifier)
 		}
 
 		// Check for specific HTTP status codes
@@ -106,37 +126,86 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 				// Rate limited - skip validation to avoid blocking publishers
 				// This is intentional: we prioritize UX over strict validation during high traffic
 				log.Printf("Skipping OCI validation for %s due to rate limiting", pkg.Identifier)
-				return nil
+				return "", nil
 			case http.StatusNotFound:
-				return fmt.Errorf("OCI image '%s' does not exist in the registry", pkg.Identifier)
+				return "", newPackageNotFoundError("OCI image '%s' does not exist in the registry", pkg.Identifier)
 			case http.StatusUnauthorized, http.StatusForbidden:
-				return fmt.Errorf("OCI image '%s' is private or requires authentication. Only public images are supported", pkg.Identifier)
+				return "", fmt.Errorf("OCI image '%s' is private or requires authentication. Only public images are supported", pkg.Identifier)
 			}
 		}
-		return fmt.Errorf("failed to fetch OCI image: %w", err)
+		return "", fmt.Errorf("failed to fetch OCI image: %w", err)
 	}
 
-	// Get the image config which contains labels
-	configFile, err := img.ConfigFile()
+	serverNames, err := ociServerNames(desc)
 	if err != nil {
-		return fmt.Errorf("failed to get image config: %w", err)
+		return "", err
 	}
 
-	// Validate the MCP server name label
-	if configFile.Config.Labels == nil {
-		return fmt.Errorf("OCI image '%s' is missing required annotation. Add this to your Dockerfile: LABEL io.modelcontextprotocol.server.name=\"%s\"", pkg.Identifier, serverName)
+	if len(serverNames) == 0 {
+		return "", fmt.Errorf("OCI image '%s' is missing required annotation. Add this to your Dockerfile: LABEL %s=\"%s\"", pkg.Identifier, ociServerNameAnnotation, serverName)
 	}
 
-	mcpName, exists := configFile.Config.Labels["io.modelcontextprotocol.server.name"]
-	if !exists {
-		return fmt.Errorf("OCI image '%s' is missing required annotation. Add this to your Dockerfile: LABEL io.modelcontextprotocol.server.name=\"%s\"", pkg.Identifier, serverName)
+	for _, mcpName := range serverNames {
+		if mcpName != serverName {
+			return "", fmt.Errorf("OCI image ownership validation failed. Expected annotation '%s' = '%s', got '%s'", ociServerNameAnnotation, serverName, mcpName)
+		}
 	}
 
-	if mcpName != serverName {
-		return fmt.Errorf("OCI image ownership validation failed. Expected annotation 'io.modelcontextprotocol.server.name' = '%s', got '%s'", serverName, mcpName)
+	return desc.Digest.String(), nil
+}
+
+// ociServerNames collects every MCP server name declared on an image: the index annotation for multi-arch
+// images, then the manifest annotation and config label of the resolved platform image
+func ociServerNames(desc *remote.Descriptor) ([]string, error) {
+	var names []string
+
+	if desc.MediaType.IsIndex() {
+		index, err := desc.ImageIndex()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get image index: %w", err)
+		}
+		indexManifest, err := index.IndexManifest()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get image index manifest: %w", err)
+		}
+		if mcpName, ok := indexManifest.Annotations[ociServerNameAnnotation]; ok {
+			names = append(names, mcpName)
+		}
 	}
 
-	return nil
+	// Image() picks the default platform for multi-arch images
+	img, err := desc.Image()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image manifest: %w", err)
+	}
+	if mcpName, ok := manifest.Annotations[ociServerNameAnnotation]; ok {
+		names = append(names, mcpName)
+	}
+
+	// Get the image config which contains labels
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image config: %w", err)
+	}
+	if mcpName, ok := configFile.Config.Labels[ociServerNameAnnotation]; ok {
+		names = append(names, mcpName)
+	}
+
+	return names, nil
+}
+
+// PinOCIDigest returns the identifier with the resolved digest appended, so it keeps referring to the
+// validated image even if the tag is later moved. Identifiers that already contain a digest are unchanged.
+func PinOCIDigest(identifier, digest string) string {
+	if digest == "" || strings.Contains(identifier, "@") {
+		return identifier
+	}
+	return identifier + "@" + digest
 }
 
 // isAllowedRegistry checks if the given registry is in the allowlist.
@@ -306,3 +306,39 @@ func TestValidateOCI_LabelMismatch(t *testing.T) {
 	assert.Contains(t, err.Error(), "ownership validation failed")
 	assert.Contains(t, err.Error(), "Expected annotation")
 }
+
+func TestPinOCIDigest(t *testing.T) {
+	const digest = "sha256:aeb5a1e1e7a0bb2a2a4b1d1b7a8e9c1f6d0e6b3a6c5f3e3c0d8b6a2f1e9d4c7b"
+
+	tests := []struct {
+		name       string
+		identifier string
+		digest     string
+		expected   string
+	}{
+		{
+			name:       "tag is pinned",
+			identifier: "ghcr.io/example/server:1.0.0",
+			digest:     digest,
+			expected:   "ghcr.io/example/server:1.0.0@" + digest,
+		},
+		{
+			name:       "existing digest is kept",
+			identifier: "ghcr.io/example/server@sha256:1111111111111111111111111111111111111111111111111111111111111111",
+			digest:     digest,
+			expected:   "ghcr.io/example/server@sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		},
+		{
+			name:       "unresolved digest leaves identifier unchanged",
+			identifier: "ghcr.io/example/server:1.0.0",
+			digest:     "",
+			expected:   "ghcr.io/example/server:1.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, registries.PinOCIDigest(tt.identifier, tt.digest))
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
@@ -21,41 +22,56 @@ var (
 type PyPIPackageResponse struct {
 	Info struct {
 		Description string `json:"description"`
+		Version     string `json:"version"`
 	} `json:"info"`
+	URLs []struct {
+		Filename string `json:"filename"`
+		Digests  struct {
+			SHA256 string `json:"sha256"`
+		} `json:"digests"`
+		UploadTime string `json:"upload_time_iso_8601"`
+	} `json:"urls"`
 }
 
 // ValidatePyPI validates that a PyPI package contains the correct MCP server name
 func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) error {
+	_, err := ResolvePyPI(ctx, pkg, serverName)
+	return err
+}
+
+// ResolvePyPI validates a PyPI package like ValidatePyPI and returns the exact version, the SHA-256 of
+// every distribution file and the upstream publish time it resolved to
+func ResolvePyPI(ctx context.Context, pkg model.Package, serverName string) (*apiv0.PackageProvenance, error) {
 	// Set default registry base URL if empty
 	if pkg.RegistryBaseURL == "" {
 		pkg.RegistryBaseURL = model.RegistryURLPyPI
 	}
 
 	if pkg.Identifier == "" {
-		return ErrMissingIdentifierForPyPI
+		return nil, ErrMissingIdentifierForPyPI
 	}
 
 	if pkg.Version == "" {
-		return ErrMissingVersionForPyPi
+		return nil, ErrMissingVersionForPyPi
 	}
 
 	// Validate that MCPB-specific fields are not present
 	if pkg.FileSHA256 != "" {
-		return fmt.Errorf("PyPI packages must not have 'fileSha256' field - this is only for MCPB packages")
+		return nil, fmt.Errorf("PyPI packages must not have 'fileSha256' field - this is only for MCPB packages")
 	}
 
 	// Validate that the registry base URL matches PyPI exactly
 	if pkg.RegistryBaseURL != model.RegistryURLPyPI {
-		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
+		return nil, fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
 			pkg.RegistryBaseURL, model.RegistryTypePyPI, model.RegistryURLPyPI)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := metadataClient
 
 	url := fmt.Sprintf("%s/pypi/%s/%s/json", pkg.RegistryBaseURL, pkg.Identifier, pkg.Version)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
@@ -63,17 +79,17 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to fetch package metadata from PyPI: %w", err)
+		return nil, fmt.Errorf("failed to fetch package metadata from PyPI: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("PyPI package '%s' not found (status: %d)", pkg.Identifier, resp.StatusCode)
+		return nil, newPackageNotFoundError("PyPI package '%s' not found (status: %d)", pkg.Identifier, resp.StatusCode)
 	}
 
 	var pypiResp PyPIPackageResponse
 	if err := json.NewDecoder(resp.Body).Decode(&pypiResp); err != nil {
-		return fmt.Errorf("failed to parse PyPI package metadata: %w", err)
+		return nil, fmt.Errorf("failed to parse PyPI package metadata: %w", err)
 	}
 
 	// Check description (README) content
@@ -81,9 +97,27 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 
 	// Check for mcp-name: format (more specific)
 	mcpNamePattern := "mcp-name: " + serverName
-	if strings.Contains(description, mcpNamePattern) {
-		return nil // Found as mcp-name: format
+	if !strings.Contains(description, mcpNamePattern) {
+		return nil, fmt.Errorf("PyPI package '%s' ownership validation failed. The server name '%s' must appear as 'mcp-name: %s' in the package README", pkg.Identifier, serverName, serverName)
 	}
 
-	return fmt.Errorf("PyPI package '%s' ownership validation failed. The server name '%s' must appear as 'mcp-name: %s' in the package README", pkg.Identifier, serverName, serverName)
+	provenance := &apiv0.PackageProvenance{
+		RegistryType: model.RegistryTypePyPI,
+		Identifier:   pkg.Identifier,
+		Version:      pypiResp.Info.Version,
+	}
+	if provenance.Version == "" {
+		provenance.Version = pkg.Version
+	}
+	for _, file := range pypiResp.URLs {
+		provenance.Files = append(provenance.Files, apiv0.ProvenanceFile{Filename: file.Filename, Digest: "sha256:" + file.Digests.SHA256})
+		// The release was published when its first file was uploaded
+		if uploaded, err := time.Parse(time.RFC3339, file.UploadTime); err == nil {
+			if provenance.UpstreamPublishedAt == nil || uploaded.Before(*provenance.UpstreamPublishedAt) {
+				uploaded = uploaded.UTC()
+				provenance.UpstreamPublishedAt = &uploaded
+			}
+		}
+	}
+	return provenance, nil
 }
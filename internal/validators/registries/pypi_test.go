@@ -2,11 +2,13 @@ package registries_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidatePyPI_RealPackages(t *testing.T) {
@@ -88,3 +90,19 @@ func TestValidatePyPI_RealPackages(t *testing.T) {
 		})
 	}
 }
+
+func TestResolvePyPI_RecordsProvenance(t *testing.T) {
+	provenance, err := registries.ResolvePyPI(context.Background(), model.Package{
+		RegistryType: model.RegistryTypePyPI,
+		Identifier:   "time-mcp-pypi",
+		Version:      "1.0.6",
+	}, "io.github.domdomegg/time-mcp-pypi")
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.6", provenance.Version)
+	require.NotEmpty(t, provenance.Files)
+	for _, file := range provenance.Files {
+		assert.True(t, strings.HasPrefix(file.Digest, "sha256:"), file.Digest)
+	}
+	require.NotNil(t, provenance.UpstreamPublishedAt)
+}
@@ -0,0 +1,145 @@
+package validators
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// screeningTerm is a configured term split into lowercase words. A trailing "*" on the last word
+// matches any word starting with it.
+type screeningTerm struct {
+	term   string
+	words  []string
+	prefix bool
+}
+
+// Screener rejects server names, titles and descriptions that use reserved terms, which suggest an
+// affiliation the publisher may not have, or prohibited terms, such as profanity. Terms match whole
+// words case-insensitively, so "mcp-official-tools" uses the term "official" but "officially" does not.
+// Words on the allow list never match, which covers legitimate words caught by a prefix term.
+type Screener struct {
+	enabled    bool
+	reserved   []screeningTerm
+	prohibited []screeningTerm
+	allowed    []string
+}
+
+// NewScreener creates a screener from the registry configuration
+func NewScreener(cfg *config.Config) *Screener {
+	allowed := make([]string, 0, len(cfg.ScreeningAllowedWords))
+	for _, word := range cfg.ScreeningAllowedWords {
+		allowed = append(allowed, strings.ToLower(strings.TrimSpace(word)))
+	}
+	return &Screener{
+		enabled:    cfg.ScreeningEnabled,
+		reserved:   parseScreeningTerms(cfg.ScreeningReservedTerms),
+		prohibited: parseScreeningTerms(cfg.ScreeningProhibitedTerms),
+		allowed:    allowed,
+	}
+}
+
+// parseScreeningTerms splits terms into words, skipping empty terms
+func parseScreeningTerms(terms []string) []screeningTerm {
+	parsed := make([]screeningTerm, 0, len(terms))
+	for _, term := range terms {
+		term = strings.ToLower(strings.TrimSpace(term))
+		prefix := strings.HasSuffix(term, "*")
+		words := screeningWords(strings.TrimSuffix(term, "*"))
+		if len(words) == 0 {
+			continue
+		}
+		parsed = append(parsed, screeningTerm{term: term, words: words, prefix: prefix})
+	}
+	return parsed
+}
+
+// screeningWords splits text into lowercase words at anything that is not a letter or digit, so
+// "io.github.user/weather-tools" becomes io, github, user, weather and tools
+func screeningWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Enabled reports whether screening is turned on
+func (s *Screener) Enabled() bool {
+	return s.enabled
+}
+
+// ScreeningError reports a field that matched a screened term. It wraps ErrProhibitedTerm or ErrReservedTerm.
+type ScreeningError struct {
+	Field string
+	Term  string
+	Err   error
+}
+
+func (e *ScreeningError) Error() string {
+	return fmt.Sprintf("server %s %s %q", e.Field, e.Err, e.Term)
+}
+
+func (e *ScreeningError) Unwrap() error {
+	return e.Err
+}
+
+// Screen checks the server's name, title and description, returning a *ScreeningError for the first match.
+// Prohibited terms are checked first and reported with ErrProhibitedTerm; reserved terms are reported with
+// ErrReservedTerm. Nothing is checked when screening is disabled.
+func (s *Screener) Screen(server *apiv0.ServerJSON) error {
+	if !s.enabled {
+		return nil
+	}
+
+	fields := []struct {
+		name string
+		text string
+	}{
+		{"name", server.Name},
+		{"title", server.Title},
+		{"description", server.Description},
+	}
+	for _, check := range []struct {
+		terms []screeningTerm
+		err   error
+	}{
+		{s.prohibited, ErrProhibitedTerm},
+		{s.reserved, ErrReservedTerm},
+	} {
+		for _, field := range fields {
+			if term, found := s.match(check.terms, screeningWords(field.text)); found {
+				return &ScreeningError{Field: field.name, Term: term, Err: check.err}
+			}
+		}
+	}
+	return nil
+}
+
+// match returns the first term found in words
+func (s *Screener) match(terms []screeningTerm, words []string) (string, bool) {
+	for _, term := range terms {
+		for start := 0; start+len(term.words) <= len(words); start++ {
+			if s.matchAt(term, words[start:start+len(term.words)]) {
+				return term.term, true
+			}
+		}
+	}
+	return "", false
+}
+
+// matchAt reports whether term matches words, which are as many as the term has
+func (s *Screener) matchAt(term screeningTerm, words []string) bool {
+	for i, word := range words {
+		if slices.Contains(s.allowed, word) {
+			return false
+		}
+		last := i == len(words)-1
+		if word != term.words[i] && !(last && term.prefix && strings.HasPrefix(word, term.words[i])) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestScreener(t *testing.T) {
+	screener := validators.NewScreener(&config.Config{
+		ScreeningEnabled:         true,
+		ScreeningReservedTerms:   []string{"official", "model context protocol"},
+		ScreeningProhibitedTerms: []string{"darn*", "heck"},
+		ScreeningAllowedWords:    []string{"darnley"},
+	})
+
+	tests := []struct {
+		name   string
+		server apiv0.ServerJSON
+		want   error
+	}{
+		{"clean", apiv0.ServerJSON{Name: "io.github.user/weather", Description: "Officially the best weather server"}, nil},
+		{"reserved word in name", apiv0.ServerJSON{Name: "io.github.user/official-weather", Description: "Weather"}, validators.ErrReservedTerm},
+		{"reserved word in any case", apiv0.ServerJSON{Name: "io.github.user/weather", Title: "OFFICIAL Weather"}, validators.ErrReservedTerm},
+		{"reserved phrase", apiv0.ServerJSON{Name: "io.github.user/weather", Description: "The Model-Context Protocol weather server"}, validators.ErrReservedTerm},
+		{"prohibited prefix", apiv0.ServerJSON{Name: "io.github.user/weather", Description: "Darnedest forecasts"}, validators.ErrProhibitedTerm},
+		{"prohibited before reserved", apiv0.ServerJSON{Name: "io.github.user/official", Description: "What the heck"}, validators.ErrProhibitedTerm},
+		{"prohibited word only as whole word", apiv0.ServerJSON{Name: "io.github.user/heckler", Description: "Weather"}, nil},
+		{"allowed word", apiv0.ServerJSON{Name: "io.github.darnley/weather", Description: "Weather"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := screener.Screen(&tt.server)
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+
+	disabled := validators.NewScreener(&config.Config{ScreeningReservedTerms: []string{"official"}})
+	assert.NoError(t, disabled.Screen(&apiv0.ServerJSON{Name: "io.github.user/official"}), "nothing is screened when disabled")
+}
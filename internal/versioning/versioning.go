@@ -1,4 +1,5 @@
-package service
+// Package versioning compares server versions the way the registry orders them
+package versioning
 
 import (
 	"strings"
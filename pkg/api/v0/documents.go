@@ -0,0 +1,53 @@
+package v0
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// DocumentDigestPrefix starts every document digest, naming the hash function
+const DocumentDigestPrefix = "sha256:"
+
+// CanonicalJSON encodes v with object keys sorted and no insignificant whitespace or HTML escaping, so equal
+// documents always encode to the same bytes. Numbers keep the representation v encodes them with.
+func CanonicalJSON(v any) ([]byte, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decoding into generic values turns objects into maps, which encoding/json writes in key order
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(generic); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// DocumentDigest returns the content address of a stored document: DocumentDigestPrefix followed by the hex
+// SHA-256 of its bytes
+func DocumentDigest(document []byte) string {
+	sum := sha256.Sum256(document)
+	return DocumentDigestPrefix + hex.EncodeToString(sum[:])
+}
+
+// ServerDocument returns the canonical server.json document of a server version and its digest, as the
+// registry stores and serves it
+func ServerDocument(server *ServerJSON) ([]byte, string, error) {
+	document, err := CanonicalJSON(server)
+	if err != nil {
+		return nil, "", err
+	}
+	return document, DocumentDigest(document), nil
+}
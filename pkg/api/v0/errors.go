@@ -0,0 +1,65 @@
+package v0
+
+// ErrorCode is a stable, machine-readable identifier returned in the "code" field of
+// problem+json error responses. Clients should branch on codes rather than on error text.
+type ErrorCode string
+
+// Generic codes, used when no more specific code applies
+const (
+	ErrorCodeBadRequest         ErrorCode = "BAD_REQUEST"
+	ErrorCodeUnauthorized       ErrorCode = "UNAUTHORIZED"
+	ErrorCodeForbidden          ErrorCode = "FORBIDDEN"
+	ErrorCodeNotFound           ErrorCode = "NOT_FOUND"
+	ErrorCodeMethodNotAllowed   ErrorCode = "METHOD_NOT_ALLOWED"
+	ErrorCodeConflict           ErrorCode = "CONFLICT"
+	ErrorCodeRequestTooLarge    ErrorCode = "REQUEST_TOO_LARGE"
+	ErrorCodeValidationFailed   ErrorCode = "VALIDATION_FAILED"
+	ErrorCodeRateLimited        ErrorCode = "RATE_LIMITED"
+	ErrorCodeInternal           ErrorCode = "INTERNAL_ERROR"
+	ErrorCodeServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
+	ErrorCodeRequestTimeout     ErrorCode = "REQUEST_TIMEOUT"
+)
+
+// Authentication and authorization codes
+const (
+	ErrorCodeInvalidAuthHeader      ErrorCode = "INVALID_AUTH_HEADER"
+	ErrorCodeInvalidToken           ErrorCode = "INVALID_TOKEN"
+	ErrorCodeNamespaceForbidden     ErrorCode = "NAMESPACE_FORBIDDEN"
+	ErrorCodeNamespacePendingReview ErrorCode = "NAMESPACE_PENDING_REVIEW"
+	ErrorCodeIdentityBanned         ErrorCode = "IDENTITY_BANNED"
+	ErrorCodePermissionDenied       ErrorCode = "PERMISSION_DENIED"
+	ErrorCodeAdminRequired          ErrorCode = "ADMIN_REQUIRED"
+	ErrorCodeReadAuthRequired       ErrorCode = "READ_AUTH_REQUIRED"
+	ErrorCodeInvalidURLSignature    ErrorCode = "INVALID_URL_SIGNATURE"
+	ErrorCodeSignedURLExpired       ErrorCode = "SIGNED_URL_EXPIRED"
+	ErrorCodeInvalidCSRFToken       ErrorCode = "INVALID_CSRF_TOKEN"
+)
+
+// Server lifecycle codes
+const (
+	ErrorCodeServerNotFound     ErrorCode = "SERVER_NOT_FOUND"
+	ErrorCodeVersionExists      ErrorCode = "VERSION_EXISTS"
+	ErrorCodeMaxVersionsReached ErrorCode = "MAX_VERSIONS_REACHED"
+	ErrorCodeRenameNotAllowed   ErrorCode = "RENAME_NOT_ALLOWED"
+	ErrorCodeVersionMismatch    ErrorCode = "VERSION_MISMATCH"
+	ErrorCodeNoStatusChange     ErrorCode = "NO_STATUS_CHANGE"
+	ErrorCodeRemoteURLInUse     ErrorCode = "REMOTE_URL_IN_USE"
+	ErrorCodeInvalidParameter   ErrorCode = "INVALID_PARAMETER"
+	ErrorCodeEndpointNotFound   ErrorCode = "ENDPOINT_NOT_FOUND"
+	ErrorCodeMaintenanceMode    ErrorCode = "MAINTENANCE_MODE"
+	ErrorCodeReadOnly           ErrorCode = "READ_ONLY"
+	ErrorCodeNotInstallable     ErrorCode = "NOT_INSTALLABLE"
+	ErrorCodeNotDeployable      ErrorCode = "NOT_DEPLOYABLE"
+)
+
+// Validation codes
+const (
+	ErrorCodeSchemaValidationFailed       ErrorCode = "SCHEMA_VALIDATION_FAILED"
+	ErrorCodeInvalidJSON                  ErrorCode = "INVALID_JSON"
+	ErrorCodePackageValidationFailed      ErrorCode = "PACKAGE_VALIDATION_FAILED"
+	ErrorCodePackageNotFoundUpstream      ErrorCode = "PACKAGE_NOT_FOUND_UPSTREAM"
+	ErrorCodeLinkUnreachable              ErrorCode = "LINK_UNREACHABLE"
+	ErrorCodeRepositoryVerificationFailed ErrorCode = "REPOSITORY_VERIFICATION_FAILED"
+	ErrorCodeReservedTerm                 ErrorCode = "RESERVED_TERM"
+	ErrorCodeProhibitedTerm               ErrorCode = "PROHIBITED_TERM"
+)
@@ -0,0 +1,59 @@
+package v0
+
+import (
+	"strconv"
+	"time"
+)
+
+// CloudEvents types of the events the registry emits. They are stable: new kinds of events get new types
+// rather than changing the meaning of existing ones.
+const (
+	// EventTypeServerPublished is emitted when a server version is published
+	EventTypeServerPublished = "io.modelcontextprotocol.registry.server.published"
+	// EventTypeServerUpdated is emitted when a published server version is edited or its status changes
+	EventTypeServerUpdated = "io.modelcontextprotocol.registry.server.updated"
+	// EventTypeServerRenamed is emitted when a server is renamed, for its latest version under the new name
+	EventTypeServerRenamed = "io.modelcontextprotocol.registry.server.renamed"
+	// EventTypeServerDeleted is emitted when a server version is permanently removed, such as by version retention
+	EventTypeServerDeleted = "io.modelcontextprotocol.registry.server.deleted"
+)
+
+// CloudEventsContentType is the media type of a CloudEvent in structured JSON mode
+const CloudEventsContentType = "application/cloudevents+json"
+
+// serverChangeEventTypes maps changes feed types to CloudEvents types
+var serverChangeEventTypes = map[string]string{
+	"created": EventTypeServerPublished,
+	"updated": EventTypeServerUpdated,
+	"renamed": EventTypeServerRenamed,
+	"deleted": EventTypeServerDeleted,
+}
+
+// CloudEvent is a CloudEvents 1.0 event in structured JSON mode
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion" doc:"CloudEvents specification version" example:"1.0"`
+	ID              string    `json:"id" doc:"Event ID, unique for the source. For server changes, the change's sequence number." example:"1024"`
+	Source          string    `json:"source" doc:"Registry that emitted the event" example:"https://registry.modelcontextprotocol.io"`
+	Type            string    `json:"type" doc:"Event type" example:"io.modelcontextprotocol.registry.server.published"`
+	Subject         string    `json:"subject,omitempty" doc:"Resource the event is about. For server changes, <name>@<version>." example:"io.github.octocat/weather@1.0.2"`
+	Time            time.Time `json:"time" format:"date-time" doc:"When the event occurred"`
+	DataContentType string    `json:"datacontenttype" doc:"Media type of data" example:"application/json"`
+	Data            any       `json:"data" doc:"Event payload. For server changes, the server version as served by the server detail endpoint."`
+	PreviousName    string    `json:"previousname,omitempty" doc:"Extension attribute set on renamed events: the name the server was renamed from" example:"io.github.octocat/weather-server"`
+}
+
+// NewServerChangeEvent wraps a changes feed entry in a CloudEvent. source identifies the registry, such as
+// its public URL.
+func NewServerChangeEvent(change *ServerChange, source string) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              strconv.FormatInt(change.Seq, 10),
+		Source:          source,
+		Type:            serverChangeEventTypes[change.Type],
+		Subject:         change.Server.Server.Name + "@" + change.Server.Server.Version,
+		Time:            change.ChangedAt,
+		DataContentType: "application/json",
+		Data:            change.Server,
+		PreviousName:    change.PreviousName,
+	}
+}
@@ -7,47 +7,280 @@ import (
 )
 
 type RegistryExtensions struct {
-	Status          model.Status `json:"status" enum:"active,deprecated,deleted" doc:"Server lifecycle status"`
-	StatusChangedAt time.Time    `json:"statusChangedAt" format:"date-time" doc:"Timestamp when the server status was last changed"`
-	StatusMessage   *string      `json:"statusMessage,omitempty" doc:"Optional message explaining status change (e.g., deprecation reason, migration guidance)"`
-	PublishedAt     time.Time    `json:"publishedAt" format:"date-time" doc:"Timestamp when the server was first published to the registry"`
-	UpdatedAt       time.Time    `json:"updatedAt,omitempty" format:"date-time" doc:"Timestamp when the server entry was last updated"`
-	IsLatest        bool         `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	Status            model.Status  `json:"status" enum:"active,deprecated,deleted,quarantined" doc:"Server lifecycle status"`
+	StatusChangedAt   time.Time     `json:"statusChangedAt" format:"date-time" doc:"Timestamp when the server status was last changed"`
+	StatusMessage     *string       `json:"statusMessage,omitempty" doc:"Optional message explaining status change (e.g., deprecation reason, migration guidance)"`
+	PublishedAt       time.Time     `json:"publishedAt" format:"date-time" doc:"Timestamp when the server was first published to the registry"`
+	UpdatedAt         time.Time     `json:"updatedAt,omitempty" format:"date-time" doc:"Timestamp when the server entry was last updated"`
+	IsLatest          bool          `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	Runtimes          []string      `json:"runtimes,omitempty" doc:"Runtimes needed to run the server locally, derived from its package types unless overridden in server.json _meta" example:"[\"node\"]"`
+	Publisher         *Publisher    `json:"publisher,omitempty" doc:"Identity that published this version, recorded from the registry token it was published with"`
+	VerifiedPublisher bool          `json:"verifiedPublisher" doc:"Whether the publisher's identity was proven when its registry token was issued. False for versions published anonymously or before publishers were recorded."`
+	Curation          *Curation     `json:"curation,omitempty" doc:"How registry operators curated the server, for curated sections in clients"`
+	Unmaintained      *Unmaintained `json:"unmaintained,omitempty" doc:"Set when the registry marked the server unmaintained because it went untouched while its repository or packages disappeared"`
+	DocumentDigest    string        `json:"documentDigest,omitempty" doc:"Content address of the server.json document the version was published with, as kept in the document store: sha256: and the hex SHA-256 of the document" example:"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"`
+}
+
+// Unmaintained records why and since when the registry considers a server unmaintained. It applies to
+// every version of the server and is cleared when a new version is published.
+type Unmaintained struct {
+	Since   time.Time `json:"since" format:"date-time" doc:"When the server was marked unmaintained"`
+	Reasons []string  `json:"reasons" doc:"Why the server went stale: repository-archived, repository-deleted or package-unpublished" example:"[\"repository-archived\"]"`
+}
+
+// Curation is how registry operators curated a server. It applies to every version of the server.
+type Curation struct {
+	Labels   []string `json:"labels" doc:"Curation labels, sorted: featured, official (vendor-verified) or community (community-maintained)" example:"[\"featured\", \"official\"]"`
+	Position *int     `json:"position,omitempty" doc:"Order of the server in lists sorted with sort=curated, lowest first" example:"1"`
+}
+
+// Publisher is the identity that published a server version, as proven when its registry token was issued
+type Publisher struct {
+	AuthMethod  string `json:"authMethod" enum:"github-at,github-oidc,oidc,dns,http,api-key,none" doc:"How the publisher authenticated"`
+	Subject     string `json:"subject" doc:"Identity the auth method proved: a GitHub login, an OIDC subject claim, a domain, or an organization and API key name" example:"repo:acme/weather:ref:refs/heads/main"`
+	GitHubLogin string `json:"githubLogin,omitempty" doc:"GitHub user that published, for GitHub OAuth logins" example:"octocat"`
+	GitHubOrg   string `json:"githubOrg,omitempty" doc:"GitHub account the server was published for: the repository owner for GitHub Actions, or the account named by an io.github namespace" example:"acme"`
+	Domain      string `json:"domain,omitempty" doc:"Domain whose ownership the publisher proved, for DNS and HTTP logins and API keys of domain namespaces" example:"example.com"`
+}
+
+// IsVerified reports whether p was proven by authentication rather than recorded for an anonymous publish
+func (p *Publisher) IsVerified() bool {
+	return p != nil && p.AuthMethod != "none"
 }
 
 type ResponseMeta struct {
-	Official *RegistryExtensions `json:"io.modelcontextprotocol.registry/official,omitempty" doc:"Official MCP registry metadata"`
+	Official        *RegistryExtensions `json:"io.modelcontextprotocol.registry/official,omitempty" doc:"Official MCP registry metadata"`
+	RemoteHealth    *RemoteHealthBadge  `json:"io.modelcontextprotocol.registry/remote-health,omitempty" doc:"Results of the registry probing the server's remotes, when remote probing is enabled"`
+	InstallFeedback *InstallFeedback    `json:"io.modelcontextprotocol.registry/install-feedback,omitempty" doc:"How many MCP clients reported installing and running this version worked, once enough have, when install feedback is enabled"`
+	Preview         *PreviewMetadata    `json:"io.modelcontextprotocol.registry/preview,omitempty" doc:"Set on previews, which are served for review only and are not published"`
+}
+
+// PreviewMetadata describes a preview: a server.json published for review, typically from a pull request, that
+// is only reachable through its URL until it expires
+type PreviewMetadata struct {
+	URL         string     `json:"url" doc:"Where the preview is served; its install configurations are under /install" example:"https://registry.example.com/v0.1/previews/3f2a9c0e4b1d7a8e6c5f4d3b2a1e0f9c"`
+	PullRequest string     `json:"pullRequest,omitempty" doc:"Pull request the preview was published from, as owner/repository#number" example:"octo-org/weather#42"`
+	Publisher   *Publisher `json:"publisher,omitempty" doc:"Identity that published the preview"`
+	CreatedAt   time.Time  `json:"createdAt" format:"date-time" doc:"When the preview was published"`
+	ExpiresAt   time.Time  `json:"expiresAt" format:"date-time" doc:"When the preview stops being served"`
 }
 
+// InstallFeedback summarizes MCP clients' reports of whether installing and running a server version worked
+type InstallFeedback struct {
+	Reports     int `json:"reports" doc:"Distinct clients that reported on this version within the registry's feedback window" example:"42"`
+	SuccessRate int `json:"successRate" minimum:"0" maximum:"100" doc:"Percentage of reporters the version worked for, rounded down" example:"88"`
+}
+
+// Remote health statuses of a single remote endpoint
+const (
+	RemoteHealthy      = "healthy"
+	RemoteAuthRequired = "auth_required"
+	RemoteUnhealthy    = "unhealthy"
+)
+
+// Remote health badge statuses summarizing every probed remote of a server version
+const (
+	RemoteBadgeHealthy   = "healthy"
+	RemoteBadgeDegraded  = "degraded"
+	RemoteBadgeUnhealthy = "unhealthy"
+	RemoteBadgeDead      = "dead"
+)
+
+// RemoteHealth is the result of the registry's latest MCP handshake with one remote endpoint
+type RemoteHealth struct {
+	URL             string     `json:"url" doc:"Remote endpoint URL" example:"https://mcp.example.com/mcp"`
+	Status          string     `json:"status" enum:"healthy,auth_required,unhealthy" doc:"healthy if the MCP handshake succeeded, auth_required if the endpoint answered but requires credentials, unhealthy otherwise"`
+	ProtocolVersion string     `json:"protocolVersion,omitempty" doc:"MCP protocol version the endpoint negotiated" example:"2025-06-18"`
+	LatencyMs       int64      `json:"latencyMs" doc:"Time taken by the probe in milliseconds" example:"182"`
+	Error           string     `json:"error,omitempty" doc:"Why the probe failed"`
+	CheckedAt       time.Time  `json:"checkedAt" format:"date-time" doc:"When the endpoint was last probed"`
+	LastHealthyAt   *time.Time `json:"lastHealthyAt,omitempty" format:"date-time" doc:"When the endpoint last answered a probe"`
+	FailingSince    *time.Time `json:"failingSince,omitempty" format:"date-time" doc:"When the endpoint started failing probes, if it is failing"`
+}
+
+// RemoteHealthBadge summarizes the health of a server version's probed remotes
+type RemoteHealthBadge struct {
+	Status    string         `json:"status" enum:"healthy,degraded,unhealthy,dead" doc:"healthy if every probed remote answers, degraded if some do, unhealthy if none do, and dead if none have answered for the registry's dead-after period"`
+	CheckedAt time.Time      `json:"checkedAt" format:"date-time" doc:"When the least recently probed remote was probed"`
+	Remotes   []RemoteHealth `json:"remotes" doc:"Latest probe result for each probed remote"`
+}
+
+// PackageProvenance records what a package resolved to in its upstream registry when it was validated at
+// publish time. Records are never modified, so consumers can compare them against the registry today to
+// detect artifacts that were republished under the same version.
+type PackageProvenance struct {
+	RegistryType        string           `json:"registryType" doc:"Package registry type" example:"npm"`
+	Identifier          string           `json:"identifier" doc:"Package identifier as published" example:"@modelcontextprotocol/server-brave-search"`
+	Version             string           `json:"version,omitempty" doc:"Exact version the upstream registry resolved the package to" example:"1.0.2"`
+	Digest              string           `json:"digest,omitempty" doc:"Integrity of the artifact: the npm tarball's Subresource Integrity string, or the OCI manifest or index digest" example:"sha512-7h3Fh0RQ0OKpe6WjA7k0mDeVDWS9eEJZxkLhvSaMGi8sQ6KgYDh4oMgdu9tqPBQ1w+ZFk4h+hwAZk3X2vSsvJw=="`
+	Files               []ProvenanceFile `json:"files,omitempty" doc:"Digests of each distribution file, for registries that publish several files per version (PyPI)"`
+	UpstreamPublishedAt *time.Time       `json:"upstreamPublishedAt,omitempty" format:"date-time" doc:"When the upstream registry says this version was published"`
+	RecordedAt          time.Time        `json:"recordedAt" format:"date-time" doc:"When the registry recorded this provenance"`
+}
+
+// ProvenanceFile is the digest of one distribution file of a package version
+type ProvenanceFile struct {
+	Filename string `json:"filename" doc:"Distribution file name" example:"weather_mcp-1.0.2-py3-none-any.whl"`
+	Digest   string `json:"digest" doc:"Algorithm-prefixed file digest" example:"sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+// PackageProvenanceResponse lists the recorded provenance of a server version's packages
+type PackageProvenanceResponse struct {
+	Packages []PackageProvenance `json:"packages" doc:"Provenance of each package validated against its registry, in the order it was recorded"`
+}
+
+// PackageArtifact is a copy of a package's artifact that the registry mirrored into its own storage when
+// the server version was published, so clients without access to the upstream registry can install it
+type PackageArtifact struct {
+	RegistryType string    `json:"registryType" doc:"Package registry type" example:"npm"`
+	Identifier   string    `json:"identifier" doc:"Package identifier as published" example:"@modelcontextprotocol/server-brave-search"`
+	Filename     string    `json:"filename" doc:"File name of the artifact: the npm tarball, a PyPI distribution file, an MCPB bundle, or an OCI image as a docker load archive" example:"server-brave-search-1.0.2.tgz"`
+	SHA256       string    `json:"sha256" doc:"Hex-encoded SHA-256 of the artifact" example:"fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce"`
+	Size         int64     `json:"size" doc:"Size of the artifact in bytes" example:"48213"`
+	MirroredAt   time.Time `json:"mirroredAt" format:"date-time" doc:"When the registry mirrored the artifact"`
+	DownloadURL  string    `json:"downloadUrl,omitempty" doc:"Signed, time-limited path downloading the artifact from the registry" example:"/v0.1/artifacts/fe333e59.../server-brave-search-1.0.2.tgz?expires=1767225600&signature=5f1d..."`
+}
+
+// PackageArtifactsResponse lists the artifacts mirrored for a server version's packages
+type PackageArtifactsResponse struct {
+	Artifacts []PackageArtifact `json:"artifacts" doc:"Mirrored artifacts, in the order they were mirrored"`
+}
+
+// PossibleDuplicatesHeader lists, comma-separated, other servers that share a repository URL or remote
+// endpoint with a newly published server
+const PossibleDuplicatesHeader = "X-Registry-Possible-Duplicates"
+
+// ValidationWarningHeader is repeated once per non-blocking validation warning on a publish response,
+// formatted as "<path>: <message> (<rule>)"
+const ValidationWarningHeader = "X-Registry-Validation-Warning"
+
+// PrimaryURLHeader is set by read-only registries on every response to the base URL of the primary registry,
+// which accepts the writes they reject
+const PrimaryURLHeader = "X-Registry-Primary"
+
 type ServerResponse struct {
 	Server ServerJSON   `json:"server" doc:"Server configuration and metadata"`
 	Meta   ResponseMeta `json:"_meta" doc:"Registry-managed metadata"`
 }
 
 type ServerListResponse struct {
-	Servers  []ServerResponse `json:"servers" doc:"List of server entries"`
-	Metadata Metadata         `json:"metadata" doc:"Pagination metadata"`
+	Servers       []ServerResponse `json:"servers" doc:"List of server entries"`
+	Metadata      Metadata         `json:"metadata" doc:"Pagination metadata"`
+	Announcements []Announcement   `json:"announcements,omitempty" doc:"Announcements registry operators are currently showing, when requested with include_announcements"`
+}
+
+// Announcement severities, from least to most urgent
+const (
+	AnnouncementSeverityInfo     = "info"
+	AnnouncementSeverityWarning  = "warning"
+	AnnouncementSeverityCritical = "critical"
+)
+
+// AnnouncementSeverities lists the announcement severities from least to most urgent
+var AnnouncementSeverities = []string{AnnouncementSeverityInfo, AnnouncementSeverityWarning, AnnouncementSeverityCritical}
+
+// Announcement is a message registry operators show to every client, such as a maintenance window or a policy change
+type Announcement struct {
+	ID        int64      `json:"id" doc:"Announcement ID"`
+	Message   string     `json:"message" doc:"Message to show users" example:"Publishing is paused on 2025-11-01 from 09:00 to 10:00 UTC for database maintenance"`
+	Severity  string     `json:"severity" enum:"info,warning,critical" doc:"How prominently clients should show the message"`
+	StartsAt  *time.Time `json:"startsAt,omitempty" format:"date-time" doc:"When the announcement starts being shown. Announcements without a start are shown once created."`
+	EndsAt    *time.Time `json:"endsAt,omitempty" format:"date-time" doc:"When the announcement stops being shown. Announcements without an end are shown until deleted."`
+	UpdatedAt time.Time  `json:"updatedAt" format:"date-time" doc:"When the announcement was created or last changed"`
+}
+
+// Active reports whether the announcement is shown at now
+func (a *Announcement) Active(now time.Time) bool {
+	return (a.StartsAt == nil || !now.Before(*a.StartsAt)) && (a.EndsAt == nil || now.Before(*a.EndsAt))
+}
+
+// ServerExistsResponse says whether a server, or a version of it, is in the registry
+type ServerExistsResponse struct {
+	Exists        bool         `json:"exists" doc:"Whether the server, or the requested version of it, exists"`
+	LatestVersion string       `json:"latestVersion,omitempty" doc:"Latest version of the server, when it has one" example:"1.2.0"`
+	Status        model.Status `json:"status,omitempty" enum:"active,deprecated,deleted,quarantined" doc:"Status of the requested version, or of the latest version when none was requested"`
 }
 
 type ServerMeta struct {
 	PublisherProvided map[string]interface{} `json:"io.modelcontextprotocol.registry/publisher-provided,omitempty" doc:"Publisher-provided metadata for downstream registries"`
+	Deployment        *model.DeploymentHints `json:"io.modelcontextprotocol.registry/deployment,omitempty" doc:"Hints for deploying the server's OCI image as a remote MCP server"`
+	Runtimes          []string               `json:"io.modelcontextprotocol.registry/runtimes,omitempty" enum:"node,python,docker,dotnet,binary" doc:"Runtimes needed to run the server locally, replacing those derived from its package types"`
+}
+
+// ServerRuntimes returns the runtimes needed to run a server locally: the _meta runtimes override when
+// present, otherwise the runtime of each package's registry type. Remote-only servers need none.
+// Runtimes are stored with each version for filtering, so changing how they are derived needs a migration.
+func ServerRuntimes(server *ServerJSON) []string {
+	needed := map[string]bool{}
+	if server.Meta != nil && len(server.Meta.Runtimes) > 0 {
+		for _, runtime := range server.Meta.Runtimes {
+			needed[runtime] = true
+		}
+	} else {
+		for _, pkg := range server.Packages {
+			if runtime, ok := model.RegistryTypeRuntimes[pkg.RegistryType]; ok {
+				needed[runtime] = true
+			}
+		}
+	}
+
+	runtimes := []string{}
+	for _, runtime := range model.Runtimes {
+		if needed[runtime] {
+			runtimes = append(runtimes, runtime)
+		}
+	}
+	return runtimes
 }
 
 type ServerJSON struct {
-	Schema      string            `json:"$schema" required:"true" minLength:"1" format:"uri" doc:"JSON Schema URI for this server.json format" example:"https://static.modelcontextprotocol.io/schemas/2025-12-11/server.schema.json"`
-	Name        string            `json:"name" minLength:"3" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"Server name in reverse-DNS format. Must contain exactly one forward slash separating namespace from server name." example:"io.github.user/weather"`
-	Description string            `json:"description" minLength:"1" maxLength:"100" doc:"Clear human-readable explanation of server functionality." example:"MCP server providing weather data and forecasts via OpenWeatherMap API"`
-	Title       string            `json:"title,omitempty" minLength:"1" maxLength:"100" doc:"Optional human-readable title or display name for the MCP server." example:"Weather API"`
-	Repository  *model.Repository `json:"repository,omitempty" doc:"Optional repository metadata for the MCP server source code."`
-	Version     string            `json:"version" doc:"Version string for this server. SHOULD follow semantic versioning." example:"1.0.2"`
-	WebsiteURL  string            `json:"websiteUrl,omitempty" format:"uri" doc:"Optional URL to the server's homepage, documentation, or project website." example:"https://modelcontextprotocol.io/examples"`
-	Icons       []model.Icon      `json:"icons,omitempty" doc:"Optional set of sized icons that the client can display in a user interface."`
-	Packages    []model.Package   `json:"packages,omitempty" doc:"Array of package configurations"`
-	Remotes     []model.Transport `json:"remotes,omitempty" doc:"Array of remote configurations"`
-	Meta        *ServerMeta       `json:"_meta,omitempty" doc:"Extension metadata using reverse DNS namespacing for vendor-specific data"`
+	Schema           string                `json:"$schema" required:"true" minLength:"1" format:"uri" doc:"JSON Schema URI for this server.json format" example:"https://static.modelcontextprotocol.io/schemas/2025-12-11/server.schema.json"`
+	Name             string                `json:"name" minLength:"3" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"Server name in reverse-DNS format. Must contain exactly one forward slash separating namespace from server name." example:"io.github.user/weather"`
+	Description      string                `json:"description" minLength:"1" maxLength:"100" doc:"Clear human-readable explanation of server functionality." example:"MCP server providing weather data and forecasts via OpenWeatherMap API"`
+	Title            string                `json:"title,omitempty" minLength:"1" maxLength:"100" doc:"Optional human-readable title or display name for the MCP server." example:"Weather API"`
+	Repository       *model.Repository     `json:"repository,omitempty" doc:"Optional repository metadata for the MCP server source code."`
+	Version          string                `json:"version" doc:"Version string for this server. SHOULD follow semantic versioning." example:"1.0.2"`
+	WebsiteURL       string                `json:"websiteUrl,omitempty" format:"uri" doc:"Optional URL to the server's homepage, documentation, or project website." example:"https://modelcontextprotocol.io/examples"`
+	DocumentationURL string                `json:"documentationUrl,omitempty" format:"uri" doc:"Optional URL to the server's documentation, such as setup and usage guides." example:"https://example.com/docs/weather"`
+	SupportURL       string                `json:"supportUrl,omitempty" format:"uri" doc:"Optional URL where users can get help, such as an issue tracker, forum, or mailto: address." example:"https://github.com/user/weather/issues"`
+	Security         *model.SecurityPolicy `json:"security,omitempty" doc:"Optional contacts and disclosure policy for reporting vulnerabilities in the server."`
+	Icons            []model.Icon          `json:"icons,omitempty" doc:"Optional set of sized icons that the client can display in a user interface."`
+	Packages         []model.Package       `json:"packages,omitempty" doc:"Array of package configurations"`
+	Remotes          []model.Transport     `json:"remotes,omitempty" doc:"Array of remote configurations"`
+	Meta             *ServerMeta           `json:"_meta,omitempty" doc:"Extension metadata using reverse DNS namespacing for vendor-specific data"`
 }
 
 type Metadata struct {
-	NextCursor string `json:"nextCursor,omitempty" doc:"Pagination cursor for retrieving the next page of results. Use this exact value in the cursor query parameter of your next request."`
-	Count      int    `json:"count" doc:"Number of items in current page"`
+	NextCursor      string `json:"nextCursor,omitempty" doc:"Pagination cursor for retrieving the next page of results. Use this exact value in the cursor query parameter of your next request."`
+	Count           int    `json:"count" doc:"Number of items in current page"`
+	Total           int    `json:"total" doc:"Total number of items matching the filters across all pages"`
+	TotalIsEstimate bool   `json:"total_is_estimate" doc:"Whether total is an estimate rather than an exact count. Large result sets are estimated to keep listing cheap."`
+}
+
+type ServerChange struct {
+	Seq          int64          `json:"seq" doc:"Monotonically increasing sequence number of this change"`
+	Type         string         `json:"type" enum:"created,updated,renamed,deleted" doc:"Kind of change applied to the server version. renamed is recorded against the latest version of a server when it is renamed. deleted is recorded when a version is permanently removed, such as by version retention, with the state it was removed in."`
+	ChangedAt    time.Time      `json:"changedAt" format:"date-time" doc:"Timestamp when the change was recorded"`
+	PreviousName string         `json:"previousName,omitempty" doc:"For renamed changes, the name the server was renamed from, which is now an alias of it" example:"io.github.octocat/weather-server"`
+	Server       ServerResponse `json:"server" doc:"Current state of the changed server version"`
+}
+
+// ServerChangeEnvelope identifies a change to a server version without the server's details
+type ServerChangeEnvelope struct {
+	Seq          int64     `json:"seq" doc:"Sequence number of the change in the changes feed"`
+	Type         string    `json:"type" enum:"created,updated,renamed,deleted" doc:"Kind of change applied to the server version"`
+	ChangedAt    time.Time `json:"changedAt" format:"date-time" doc:"Timestamp when the change was recorded"`
+	Name         string    `json:"name" doc:"Name of the changed server" example:"io.github.octocat/weather"`
+	Version      string    `json:"version" doc:"Changed version" example:"1.0.2"`
+	PreviousName string    `json:"previousName,omitempty" doc:"For renamed changes, the name the server was renamed from" example:"io.github.octocat/weather-server"`
+}
+
+type ServerChangesResponse struct {
+	Changes  []ServerChange  `json:"changes" doc:"Changes in sequence order"`
+	Metadata ChangesMetadata `json:"metadata" doc:"Feed position metadata"`
+}
+
+type ChangesMetadata struct {
+	NextSince int64 `json:"nextSince" doc:"Sequence number to pass as the since query parameter to continue reading the feed"`
+	Count     int   `json:"count" doc:"Number of changes in current page"`
 }
@@ -33,6 +33,27 @@ const (
 	RuntimeHintDNX    = "dnx"
 )
 
+// Runtimes - implementation runtimes a server needs to run locally
+const (
+	RuntimeNode   = "node"
+	RuntimePython = "python"
+	RuntimeDocker = "docker"
+	RuntimeDotNet = "dotnet"
+	RuntimeBinary = "binary"
+)
+
+// Runtimes lists the supported runtimes in the order servers report them
+var Runtimes = []string{RuntimeNode, RuntimePython, RuntimeDocker, RuntimeDotNet, RuntimeBinary}
+
+// RegistryTypeRuntimes maps each package registry type to the runtime its packages run on
+var RegistryTypeRuntimes = map[string]string{
+	RegistryTypeNPM:   RuntimeNode,
+	RegistryTypePyPI:  RuntimePython,
+	RegistryTypeOCI:   RuntimeDocker,
+	RegistryTypeNuGet: RuntimeDotNet,
+	RegistryTypeMCPB:  RuntimeBinary,
+}
+
 // Schema versions
 const (
 	// CurrentSchemaVersion is the current supported schema version date
@@ -6,6 +6,8 @@ const (
 	StatusActive     Status = "active"
 	StatusDeprecated Status = "deprecated"
 	StatusDeleted    Status = "deleted"
+	// StatusQuarantined hides the versions of servers published by a banned identity until the ban is lifted
+	StatusQuarantined Status = "quarantined"
 )
 
 // Transport represents transport configuration for both Package and Remote contexts.
@@ -57,6 +59,13 @@ type Repository struct {
 	Subfolder string `json:"subfolder,omitempty" doc:"Optional relative path from repository root to the server location within a monorepo or nested package structure. Must be a clean relative path." example:"src/everything"`
 }
 
+// SecurityPolicy tells security researchers how to report vulnerabilities in a server, like a security.txt file
+// (RFC 9116) does for a website
+type SecurityPolicy struct {
+	Contact   []string `json:"contact" minItems:"1" doc:"Where vulnerabilities should be reported, most preferred first: https URLs of a reporting form or page, or mailto: addresses" example:"[\"mailto:security@example.com\"]"`
+	PolicyURL string   `json:"policyUrl,omitempty" format:"uri" doc:"Optional https URL of the server's vulnerability disclosure policy" example:"https://example.com/security/policy"`
+}
+
 type Format string
 
 const (
@@ -108,3 +117,29 @@ type Icon struct {
 	Sizes    []string `json:"sizes,omitempty" doc:"Optional array of strings that specify sizes at which the icon can be used. Each string should be in WxH format (e.g., '48x48', '96x96') or 'any' for scalable formats like SVG. If not provided, the client should assume that the icon can be used at any size." items.pattern:"^(\\d+x\\d+|any)$"`
 	Theme    *string  `json:"theme,omitempty" enum:"light,dark" doc:"Optional specifier for the theme this icon is designed for. 'light' indicates the icon is designed to be used with a light background, and 'dark' indicates the icon is designed to be used with a dark background. If not provided, the client should assume the icon can be used with any theme."`
 }
+
+// DeploymentHints suggests how to run a server's OCI image as a long-lived remote MCP server,
+// for example on Kubernetes. Registries and tools may use them to render deployment manifests.
+type DeploymentHints struct {
+	Port      int                 `json:"port,omitempty" minimum:"1" maximum:"65535" doc:"Container port the MCP server listens on. Defaults to the port in the OCI package's transport URL." example:"8080"`
+	Resources *ContainerResources `json:"resources,omitempty" doc:"Suggested container resource requests and limits"`
+	Secrets   []DeploymentSecret  `json:"secrets,omitempty" doc:"Environment variables that must be supplied from a secret, in addition to the package's environment variables marked isSecret"`
+}
+
+// ContainerResources holds suggested resource requests and limits using Kubernetes quantities
+type ContainerResources struct {
+	Requests *ResourceQuantities `json:"requests,omitempty" doc:"Resources the container needs to be scheduled"`
+	Limits   *ResourceQuantities `json:"limits,omitempty" doc:"Resources the container must not exceed"`
+}
+
+// ResourceQuantities holds CPU and memory amounts in Kubernetes quantity format
+type ResourceQuantities struct {
+	CPU    string `json:"cpu,omitempty" doc:"CPU amount, e.g. 250m or 1" example:"250m"`
+	Memory string `json:"memory,omitempty" doc:"Memory amount, e.g. 256Mi or 1Gi" example:"256Mi"`
+}
+
+// DeploymentSecret is an environment variable whose value must come from a secret
+type DeploymentSecret struct {
+	Name        string `json:"name" minLength:"1" doc:"Environment variable name" example:"WEATHER_API_KEY"`
+	Description string `json:"description,omitempty" doc:"What the secret is used for"`
+}
@@ -0,0 +1,103 @@
+package registrytest
+
+import (
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Dataset returns a small canned set of server versions covering the common shapes of server.json:
+// npm, PyPI and OCI packages, a remote-only server, and a server with several versions. Each call
+// returns fresh copies, so tests may modify them.
+func Dataset() []apiv0.ServerJSON {
+	return []apiv0.ServerJSON{
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.example/weather",
+			Title:       "Weather",
+			Description: "Current conditions and forecasts for any city",
+			Version:     "1.0.0",
+			Repository:  &model.Repository{URL: "https://github.com/example/weather", Source: "github"},
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@example/weather-mcp",
+				Version:      "1.0.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+				EnvironmentVariables: []model.KeyValueInput{{
+					Name: "WEATHER_API_KEY",
+					InputWithVariables: model.InputWithVariables{
+						Input: model.Input{Description: "Weather service API key", IsRequired: true, IsSecret: true},
+					},
+				}},
+			}},
+		},
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.example/weather",
+			Title:       "Weather",
+			Description: "Current conditions, forecasts and severe weather alerts for any city",
+			Version:     "1.1.0",
+			Repository:  &model.Repository{URL: "https://github.com/example/weather", Source: "github"},
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@example/weather-mcp",
+				Version:      "1.1.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+				EnvironmentVariables: []model.KeyValueInput{{
+					Name: "WEATHER_API_KEY",
+					InputWithVariables: model.InputWithVariables{
+						Input: model.Input{Description: "Weather service API key", IsRequired: true, IsSecret: true},
+					},
+				}},
+			}},
+		},
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.example/sqlite",
+			Description: "Query and inspect local SQLite databases",
+			Version:     "0.3.2",
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypePyPI,
+				Identifier:   "mcp-sqlite-example",
+				Version:      "0.3.2",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+				PackageArguments: []model.Argument{{
+					Type:      model.ArgumentTypeNamed,
+					Name:      "--db-path",
+					ValueHint: "db_path",
+					InputWithVariables: model.InputWithVariables{
+						Input: model.Input{Description: "Path to the database file", IsRequired: true},
+					},
+				}},
+			}},
+		},
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.example/browser",
+			Description: "Headless browser automation",
+			Version:     "2.0.0",
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeOCI,
+				Identifier:   "ghcr.io/example/browser-mcp:2.0.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			}},
+		},
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/tickets",
+			Title:       "Tickets",
+			Description: "Search and update support tickets",
+			Version:     "1.4.0",
+			WebsiteURL:  "https://example.com/tickets",
+			Remotes: []model.Transport{{
+				Type: model.TransportTypeStreamableHTTP,
+				URL:  "https://mcp.example.com/tickets",
+				Headers: []model.KeyValueInput{{
+					Name: "Authorization",
+					InputWithVariables: model.InputWithVariables{
+						Input: model.Input{Description: "Bearer token", IsRequired: true, IsSecret: true},
+					},
+				}},
+			}},
+		},
+	}
+}
@@ -0,0 +1,323 @@
+// Package registrytest provides a fake MCP registry for testing registry clients and CI pipelines
+// without network access.
+//
+// The fake serves the real registry API from an in-memory database on an httptest server, so responses,
+// validation and error codes match a real registry. Package registry lookups, link checks and other
+// outbound requests are disabled. Tests can load canned servers, make requests fail or slow down, and
+// check what was published:
+//
+//	registry := registrytest.New(t, registrytest.WithServers(registrytest.Dataset()...))
+//	registry.Fail(registrytest.Failure{Method: http.MethodGet, Path: "/v0/servers", Status: http.StatusServiceUnavailable, Times: 1})
+//	client := NewClient(registry.URL, registry.Token(t, "io.github.example"))
+//	...
+//	published := registry.AssertPublished(t, "io.github.example/weather", "1.2.0")
+package registrytest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Server is a fake registry serving the registry API over HTTP. Its URL field is the registry's base URL.
+type Server struct {
+	*httptest.Server
+
+	registry   service.RegistryService
+	jwtManager *auth.JWTManager
+
+	mu        sync.Mutex
+	failures  []*Failure
+	publishes []Publish
+}
+
+// Option configures a fake registry
+type Option func(*options)
+
+type options struct {
+	servers []apiv0.ServerJSON
+}
+
+// WithServers publishes the given server versions before the registry starts serving, in order, so the last
+// version of each server is its latest
+func WithServers(servers ...apiv0.ServerJSON) Option {
+	return func(o *options) {
+		o.servers = append(o.servers, servers...)
+	}
+}
+
+// Failure makes matching requests fail or slow down. A request matches if its method equals Method, or
+// Method is empty, and its path starts with Path.
+type Failure struct {
+	Method string
+	Path   string
+	// Status is the status code returned instead of the real response, with a problem+json body carrying
+	// Code. 429 and 503 responses include Retry-After: 1. Zero lets the request through after Delay.
+	Status int
+	Code   apiv0.ErrorCode
+	// Delay is how long the registry waits before responding
+	Delay time.Duration
+	// Times is how many requests the failure applies to; zero applies it until ClearFailures is called
+	Times int
+}
+
+// Publish is a request to the publish endpoint, as received
+type Publish struct {
+	// Server is the published server.json, decoded from the request body
+	Server apiv0.ServerJSON
+	// Status is the status code the registry responded with
+	Status int
+	// Header holds the request headers
+	Header http.Header
+}
+
+// New starts a fake registry that is closed when the test finishes
+func New(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		t.Fatalf("registrytest: failed to generate signing key: %v", err)
+	}
+	cfg := config.Defaults()
+	cfg.JWTPrivateKey = hex.EncodeToString(seed)
+	cfg.EnableRegistryValidation = false
+
+	shutdownTelemetry, metrics, err := telemetry.InitMetrics("registrytest")
+	if err != nil {
+		t.Fatalf("registrytest: failed to initialize metrics: %v", err)
+	}
+	t.Cleanup(func() { _ = shutdownTelemetry(context.Background()) })
+
+	registry, err := service.NewRegistryService(database.NewMemory(), cfg)
+	if err != nil {
+		t.Fatalf("registrytest: failed to create registry: %v", err)
+	}
+	s := &Server{
+		registry:   registry,
+		jwtManager: auth.NewJWTManager(cfg),
+	}
+	for _, server := range o.servers {
+		if _, err := s.registry.CreateServer(context.Background(), &server); err != nil {
+			t.Fatalf("registrytest: failed to load %s %s: %v", server.Name, server.Version, err)
+		}
+	}
+
+	handler := api.NewServer(cfg, s.registry, metrics, &v0.VersionBody{Version: "registrytest"}).Handler()
+	s.Server = httptest.NewServer(s.middleware(handler))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Token returns a registry token that may publish and edit servers in namespace, or in every namespace
+// if namespace is empty
+func (s *Server) Token(t testing.TB, namespace string) string {
+	t.Helper()
+
+	pattern := "*"
+	if namespace != "" {
+		pattern = namespace + "/*"
+	}
+	token, err := s.jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+		AuthMethod:        auth.MethodNone,
+		AuthMethodSubject: "registrytest",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: pattern},
+			{Action: auth.PermissionActionEdit, ResourcePattern: pattern},
+		},
+	})
+	if err != nil {
+		t.Fatalf("registrytest: failed to create token: %v", err)
+	}
+	return token.RegistryToken
+}
+
+// SetStatus changes the status of a server version, for example to test how clients show deprecated servers
+func (s *Server) SetStatus(t testing.TB, name, version string, status model.Status, message string) {
+	t.Helper()
+
+	change := &service.StatusChangeRequest{NewStatus: status}
+	if message != "" {
+		change.StatusMessage = &message
+	}
+	if _, err := s.registry.UpdateServerStatus(context.Background(), name, version, change); err != nil {
+		t.Fatalf("registrytest: failed to set status of %s %s: %v", name, version, err)
+	}
+}
+
+// Fail makes matching requests fail or slow down. Failures are checked in the order they were added, and
+// the first match applies.
+func (s *Server) Fail(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure)
+}
+
+// ClearFailures removes every failure, so requests are served normally again
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = nil
+}
+
+// Publishes returns every request made to the publish endpoint, in the order they were received,
+// including rejected ones
+func (s *Server) Publishes() []Publish {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Publish(nil), s.publishes...)
+}
+
+// AssertPublished fails the test unless a server version was published successfully, and returns the
+// server.json that was published
+func (s *Server) AssertPublished(t testing.TB, name, version string) apiv0.ServerJSON {
+	t.Helper()
+
+	var attempts []string
+	for _, publish := range s.Publishes() {
+		if publish.Server.Name == name && publish.Server.Version == version && publish.Status < http.StatusBadRequest {
+			return publish.Server
+		}
+		attempts = append(attempts, publish.Server.Name+" "+publish.Server.Version+" ("+strconv.Itoa(publish.Status)+")")
+	}
+	t.Errorf("registrytest: %s %s was not published; publish requests: [%s]", name, version, strings.Join(attempts, ", "))
+	return apiv0.ServerJSON{}
+}
+
+// AssertNoPublishes fails the test if any request was made to the publish endpoint
+func (s *Server) AssertNoPublishes(t testing.TB) {
+	t.Helper()
+
+	if publishes := s.Publishes(); len(publishes) > 0 {
+		t.Errorf("registrytest: expected no publish requests, got %d, the first for %s %s",
+			len(publishes), publishes[0].Server.Name, publishes[0].Server.Version)
+	}
+}
+
+// middleware applies failures and records publish requests
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failure := s.matchFailure(r); failure != nil {
+			if failure.Delay > 0 {
+				select {
+				case <-time.After(failure.Delay):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			if failure.Status != 0 {
+				writeFailure(w, failure)
+				return
+			}
+		}
+
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/publish") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		publish := Publish{Status: recorder.status, Header: r.Header.Clone()}
+		_ = json.Unmarshal(body, &publish.Server)
+		s.mu.Lock()
+		s.publishes = append(s.publishes, publish)
+		s.mu.Unlock()
+	})
+}
+
+// matchFailure returns the first failure matching r, counting it against the failure's Times
+func (s *Server) matchFailure(r *http.Request) *Failure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, failure := range s.failures {
+		if (failure.Method != "" && failure.Method != r.Method) || !strings.HasPrefix(r.URL.Path, failure.Path) {
+			continue
+		}
+		matched := *failure
+		if failure.Times > 0 {
+			failure.Times--
+			if failure.Times == 0 {
+				s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+			}
+		}
+		return &matched
+	}
+	return nil
+}
+
+// writeFailure writes a failure's response in the registry's problem+json error format
+func writeFailure(w http.ResponseWriter, failure *Failure) {
+	code := failure.Code
+	if code == "" {
+		code = apiv0.ErrorCodeInternal
+	}
+	if failure.Status == http.StatusTooManyRequests || failure.Status == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "1")
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(failure.Status)
+	_ = json.NewEncoder(w).Encode(v0.ErrorModel{
+		ErrorModel: huma.ErrorModel{
+			Title:  http.StatusText(failure.Status),
+			Status: failure.Status,
+			Detail: "failure injected by registrytest",
+		},
+		Code: code,
+	})
+}
+
+// statusRecorder remembers the status code written through it
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
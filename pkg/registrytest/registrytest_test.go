@@ -0,0 +1,107 @@
+package registrytest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/registrytest"
+)
+
+func TestServer_Dataset(t *testing.T) {
+	registry := registrytest.New(t, registrytest.WithServers(registrytest.Dataset()...))
+	registry.SetStatus(t, "io.github.example/browser", "2.0.0", model.StatusDeprecated, "Use io.github.example/browser-next")
+
+	resp, err := http.Get(registry.URL + "/v0.1/servers?version=latest")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list apiv0.ServerListResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	statuses := map[string]model.Status{}
+	for _, server := range list.Servers {
+		statuses[server.Server.Name+" "+server.Server.Version] = server.Meta.Official.Status
+	}
+	assert.Equal(t, map[string]model.Status{
+		"io.github.example/weather 1.1.0": model.StatusActive,
+		"io.github.example/sqlite 0.3.2":  model.StatusActive,
+		"io.github.example/browser 2.0.0": model.StatusDeprecated,
+		"com.example/tickets 1.4.0":       model.StatusActive,
+	}, statuses)
+}
+
+func TestServer_Failures(t *testing.T) {
+	registry := registrytest.New(t)
+	registry.Fail(registrytest.Failure{Method: http.MethodGet, Path: "/v0.1/servers", Status: http.StatusServiceUnavailable, Times: 1})
+	registry.Fail(registrytest.Failure{Path: "/v0.1/ping", Delay: 20 * time.Millisecond})
+
+	get := func(path string) *http.Response {
+		resp, err := http.Get(registry.URL + path)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := get("/v0.1/servers")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+	var problem struct {
+		Code apiv0.ErrorCode `json:"code"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, apiv0.ErrorCodeInternal, problem.Code)
+
+	assert.Equal(t, http.StatusOK, get("/v0.1/servers").StatusCode, "the failure applied once")
+
+	start := time.Now()
+	assert.Equal(t, http.StatusOK, get("/v0.1/ping").StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	registry.ClearFailures()
+	start = time.Now()
+	get("/v0.1/ping")
+	assert.Less(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestServer_Publishes(t *testing.T) {
+	registry := registrytest.New(t)
+	registry.AssertNoPublishes(t)
+
+	publish := func(token string, server apiv0.ServerJSON) int {
+		body, err := json.Marshal(server)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, registry.URL+"/v0.1/publish", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	server := registrytest.Dataset()[0]
+	assert.Equal(t, http.StatusForbidden, publish(registry.Token(t, "com.example"), server), "tokens are scoped to a namespace")
+	assert.Equal(t, http.StatusOK, publish(registry.Token(t, "io.github.example"), server))
+
+	published := registry.AssertPublished(t, "io.github.example/weather", "1.0.0")
+	assert.Equal(t, "@example/weather-mcp", published.Packages[0].Identifier)
+
+	publishes := registry.Publishes()
+	require.Len(t, publishes, 2)
+	assert.Equal(t, http.StatusForbidden, publishes[0].Status)
+	assert.Equal(t, "application/json", publishes[1].Header.Get("Content-Type"))
+
+	resp, err := http.Get(registry.URL + "/v0.1/servers/io.github.example%2Fweather/versions/latest")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "published servers are served")
+}
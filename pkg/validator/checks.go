@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"context"
+	"net/url"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// PackageChecker checks a package against the registry hosting it, such as that it exists and names the
+// server it is published for. The registry's own implementation queries npm, PyPI, NuGet and OCI registries.
+type PackageChecker interface {
+	CheckPackage(ctx context.Context, pkg model.Package, serverName string) error
+}
+
+// LinkChecker checks that a website, documentation, support or security link responds without an error status
+type LinkChecker interface {
+	CheckLink(ctx context.Context, link string) error
+}
+
+// Checks holds the checks that need the network. The validator never makes network requests itself, so
+// embedders supply implementations suited to where they run, such as fetch from WASM in a browser.
+// Nil checkers are skipped.
+type Checks struct {
+	Packages PackageChecker
+	Links    LinkChecker
+}
+
+// CheckServer runs checks against the packages and links of serverJSON and returns the failures as issues.
+// It is meant to follow ValidateServerJSON, so servers that fail validation aren't sent over the network.
+func CheckServer(ctx context.Context, serverJSON *apiv0.ServerJSON, checks Checks) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+	vctx := &ValidationContext{}
+
+	if checks.Packages != nil {
+		for i, pkg := range serverJSON.Packages {
+			if err := checks.Packages.CheckPackage(ctx, pkg, serverJSON.Name); err != nil {
+				result.AddIssue(NewValidationIssueFromError(ValidationIssueTypeNetwork, vctx.Field("packages").Index(i).String(), err, "package-check-failed"))
+			}
+		}
+	}
+
+	if checks.Links != nil {
+		for _, link := range Links(serverJSON) {
+			if err := checks.Links.CheckLink(ctx, link.URL); err != nil {
+				result.AddIssue(NewValidationIssueFromError(ValidationIssueTypeNetwork, link.Path, err, "link-unreachable"))
+			}
+		}
+	}
+
+	return result
+}
+
+// Link is a web page linked from a server.json, with the path of the field linking to it
+type Link struct {
+	Path string
+	URL  string
+}
+
+// Links returns the web pages serverJSON links to: its website, documentation and support links, and its
+// security contacts and disclosure policy. mailto: addresses are left out, as there is no page to check.
+func Links(serverJSON *apiv0.ServerJSON) []Link {
+	candidates := []Link{
+		{"websiteUrl", serverJSON.WebsiteURL},
+		{"documentationUrl", serverJSON.DocumentationURL},
+		{"supportUrl", serverJSON.SupportURL},
+	}
+	if security := serverJSON.Security; security != nil {
+		ctx := (&ValidationContext{}).Field("security")
+		for i, contact := range security.Contact {
+			candidates = append(candidates, Link{ctx.Field("contact").Index(i).String(), contact})
+		}
+		candidates = append(candidates, Link{ctx.Field("policyUrl").String(), security.PolicyURL})
+	}
+
+	var links []Link
+	for _, link := range candidates {
+		if link.URL == "" {
+			continue
+		}
+		if parsed, err := url.Parse(link.URL); err == nil && parsed.Scheme == SchemeMailto {
+			continue
+		}
+		links = append(links, link)
+	}
+	return links
+}
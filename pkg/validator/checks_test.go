@@ -0,0 +1,65 @@
+package validator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+type fakePackageChecker map[string]error
+
+func (f fakePackageChecker) CheckPackage(_ context.Context, pkg model.Package, _ string) error {
+	return f[pkg.Identifier]
+}
+
+type fakeLinkChecker struct{ checked []string }
+
+func (f *fakeLinkChecker) CheckLink(_ context.Context, link string) error {
+	f.checked = append(f.checked, link)
+	if link == "https://example.com/dead" {
+		return errors.New("status 404")
+	}
+	return nil
+}
+
+func TestCheckServer(t *testing.T) {
+	server := &apiv0.ServerJSON{
+		Name:             "io.github.octocat/weather",
+		WebsiteURL:       "https://example.com",
+		DocumentationURL: "https://example.com/dead",
+		SupportURL:       "mailto:help@example.com",
+		Security: &model.SecurityPolicy{
+			Contact:   []string{"mailto:security@example.com", "https://example.com/dead"},
+			PolicyURL: "https://example.com/security",
+		},
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeNPM, Identifier: "weather"},
+			{RegistryType: model.RegistryTypeNPM, Identifier: "missing"},
+		},
+	}
+
+	links := &fakeLinkChecker{}
+	result := validator.CheckServer(context.Background(), server, validator.Checks{
+		Packages: fakePackageChecker{"missing": errors.New("package not found")},
+		Links:    links,
+	})
+	assert.False(t, result.Valid)
+	assert.Equal(t, []validator.ValidationIssue{
+		{Type: validator.ValidationIssueTypeNetwork, Path: "packages[1]", Message: "package not found", Severity: validator.ValidationIssueSeverityError, Reference: "package-check-failed"},
+		{Type: validator.ValidationIssueTypeNetwork, Path: "documentationUrl", Message: "status 404", Severity: validator.ValidationIssueSeverityError, Reference: "link-unreachable"},
+		{Type: validator.ValidationIssueTypeNetwork, Path: "security.contact[1]", Message: "status 404", Severity: validator.ValidationIssueSeverityError, Reference: "link-unreachable"},
+	}, result.Issues)
+	// mailto: support and security addresses aren't links to check
+	assert.Equal(t, []string{"https://example.com", "https://example.com/dead", "https://example.com/dead", "https://example.com/security"}, links.checked)
+
+	// Without checkers nothing is checked
+	result = validator.CheckServer(context.Background(), server, validator.Checks{})
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Issues)
+}
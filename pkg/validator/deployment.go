@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/modelcontextprotocol/registry/internal/deploy"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// deploymentMetaKey is the _meta key holding deployment hints
+const deploymentMetaKey = "io.modelcontextprotocol.registry/deployment"
+
+var (
+	// quantityPattern matches Kubernetes CPU and memory quantities such as 250m, 0.5, 512Mi or 1G
+	quantityPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?(m|k|M|G|T|P|E|Ki|Mi|Gi|Ti|Pi|Ei)?$`)
+	// envVarNamePattern matches portable environment variable names
+	envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+func validateDeploymentHints(ctx *ValidationContext, serverJSON *apiv0.ServerJSON) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+	if serverJSON.Meta == nil || serverJSON.Meta.Deployment == nil {
+		return result
+	}
+	hints := serverJSON.Meta.Deployment
+	ctx = ctx.Field("_meta").Field(deploymentMetaKey)
+
+	addIssue := func(path, reference string, err error) {
+		result.AddIssue(NewValidationIssueFromError(ValidationIssueTypeSemantic, path, err, reference))
+	}
+
+	if deploy.Package(serverJSON) == nil {
+		addIssue(ctx.String(), "deployment-requires-oci-package",
+			errors.New("deployment hints require an oci package with a streamable-http or sse transport"))
+	}
+
+	if hints.Port != 0 && (hints.Port < 1 || hints.Port > 65535) {
+		addIssue(ctx.Field("port").String(), "deployment-invalid-port", fmt.Errorf("port must be between 1 and 65535, got %d", hints.Port))
+	}
+
+	if hints.Resources != nil {
+		validateQuantities := func(field string, quantities *model.ResourceQuantities) {
+			if quantities == nil {
+				return
+			}
+			for _, q := range []struct{ resource, value string }{{"cpu", quantities.CPU}, {"memory", quantities.Memory}} {
+				if q.value != "" && !quantityPattern.MatchString(q.value) {
+					addIssue(ctx.Field("resources").Field(field).Field(q.resource).String(), "deployment-invalid-quantity",
+						fmt.Errorf("%s %s %q is not a valid quantity (e.g. 250m, 0.5, 512Mi, 1Gi)", field, q.resource, q.value))
+				}
+			}
+		}
+		validateQuantities("requests", hints.Resources.Requests)
+		validateQuantities("limits", hints.Resources.Limits)
+	}
+
+	seen := map[string]bool{}
+	for i, secret := range hints.Secrets {
+		path := ctx.Field("secrets").Index(i).Field("name").String()
+		switch {
+		case !envVarNamePattern.MatchString(secret.Name):
+			addIssue(path, "deployment-invalid-secret-name", fmt.Errorf("secret name %q is not a valid environment variable name", secret.Name))
+		case seen[secret.Name]:
+			addIssue(path, "deployment-duplicate-secret", fmt.Errorf("secret %q is listed more than once", secret.Name))
+		}
+		seen[secret.Name] = true
+	}
+
+	return result
+}
@@ -0,0 +1,84 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+func deployableServerJSON(hints *model.DeploymentHints) *apiv0.ServerJSON {
+	return &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/deploy-server",
+		Version:     "1.0.0",
+		Description: "A server deployed as a container",
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeOCI,
+				Identifier:   "ghcr.io/example/deploy-server:1.0.0",
+				Transport:    model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "http://localhost:3000/mcp"},
+			},
+		},
+		Meta: &apiv0.ServerMeta{Deployment: hints},
+	}
+}
+
+func TestValidateServerJSON_DeploymentHints(t *testing.T) {
+	tests := []struct {
+		name       string
+		serverJSON *apiv0.ServerJSON
+		wantRefs   []string
+	}{
+		{
+			name: "valid hints",
+			serverJSON: deployableServerJSON(&model.DeploymentHints{
+				Port: 3000,
+				Resources: &model.ContainerResources{
+					Requests: &model.ResourceQuantities{CPU: "250m", Memory: "256Mi"},
+					Limits:   &model.ResourceQuantities{CPU: "1", Memory: "1Gi"},
+				},
+				Secrets: []model.DeploymentSecret{{Name: "API_KEY"}},
+			}),
+		},
+		{
+			name: "invalid port, quantities and secrets",
+			serverJSON: deployableServerJSON(&model.DeploymentHints{
+				Port:      70000,
+				Resources: &model.ContainerResources{Requests: &model.ResourceQuantities{CPU: "lots", Memory: "1GB"}},
+				Secrets:   []model.DeploymentSecret{{Name: "API-KEY"}, {Name: "TOKEN"}, {Name: "TOKEN"}},
+			}),
+			wantRefs: []string{
+				"deployment-invalid-port",
+				"deployment-invalid-quantity",
+				"deployment-invalid-quantity",
+				"deployment-invalid-secret-name",
+				"deployment-duplicate-secret",
+			},
+		},
+		{
+			name: "hints without an oci package",
+			serverJSON: func() *apiv0.ServerJSON {
+				s := deployableServerJSON(&model.DeploymentHints{Port: 8080})
+				s.Packages[0].Transport = model.Transport{Type: model.TransportTypeStdio}
+				return s
+			}(),
+			wantRefs: []string{"deployment-requires-oci-package"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validator.ValidateServerJSON(tt.serverJSON, validator.ValidationSemanticOnly)
+			var refs []string
+			for _, issue := range result.Issues {
+				refs = append(refs, issue.Reference)
+			}
+			assert.Equal(t, tt.wantRefs, refs)
+			assert.Equal(t, len(tt.wantRefs) == 0, result.Valid)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+// Package validator checks server.json files against the same schema and semantic rules the registry
+// applies when servers are published, so editors and web tooling can report problems before publishing.
+//
+// The package makes no network requests and needs no database, so it also builds for WASM
+// (GOOS=js GOARCH=wasm or GOOS=wasip1 GOARCH=wasm). Checks that need the network, such as whether a package
+// exists on its registry, are run by CheckServer with implementations the caller supplies.
+//
+// The registry additionally screens names for reserved terms and verifies repository ownership at publish
+// time; those checks depend on its configuration and are not part of this package.
+package validator
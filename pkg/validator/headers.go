@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// hopByHopHeaders are the headers that describe a single connection rather than the request (RFC 9110 section
+// 7.6.1), along with their legacy proxy forms. Clients and proxies set or strip them, so a remote cannot rely on
+// receiving them.
+var hopByHopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"TE",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+var (
+	// headerNameRe matches a header field name, which is an RFC 9110 token
+	headerNameRe = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+	// secretTokenRe matches the formats of well-known API credentials: GitHub, OpenAI, Anthropic and Slack
+	// tokens, AWS access key IDs, and JWTs
+	secretTokenRe = regexp.MustCompile(`(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,}|sk-[A-Za-z0-9_-]{20,}|xox[abprs]-[A-Za-z0-9-]{10,}|AKIA[0-9A-Z]{16}|eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,})`)
+	// credentialSchemeRe matches an authorization scheme followed by a literal credential
+	credentialSchemeRe = regexp.MustCompile(`(?i)^(?:bearer|basic|token|apikey)\s+[^\s{}]{8,}$`)
+	// secretHeaderNameRe matches the names of headers that usually carry credentials
+	secretHeaderNameRe = regexp.MustCompile(`(?i)(?:authorization|api[-_]?key|token|secret|password|credential)`)
+)
+
+// minSecretLength is the shortest literal value of a credential header that is taken for a secret
+const minSecretLength = 16
+
+// validateRemoteHeaders checks that the headers a remote declares can be sent: each name is a valid header
+// field name and not a hop-by-hop header. References to variables in the values are checked with the other
+// templates.
+func validateRemoteHeaders(ctx *ValidationContext, headers []model.KeyValueInput) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+
+	for i, header := range headers {
+		nameCtx := ctx.Index(i).Field("name")
+		switch {
+		case !headerNameRe.MatchString(header.Name):
+			result.AddIssue(NewValidationIssueFromError(
+				ValidationIssueTypeSemantic,
+				nameCtx.String(),
+				fmt.Errorf("%w: %q", ErrInvalidHeaderName, header.Name),
+				"invalid-header-name",
+			))
+		case isHopByHopHeader(header.Name):
+			result.AddIssue(NewValidationIssueFromError(
+				ValidationIssueTypeSemantic,
+				nameCtx.String(),
+				fmt.Errorf("%w: %s applies to a single connection and is not passed on to the server", ErrForbiddenHeader, header.Name),
+				"forbidden-header",
+			))
+		}
+	}
+
+	return result
+}
+
+// isHopByHopHeader reports whether a header name, in any case, is a hop-by-hop header
+func isHopByHopHeader(name string) bool {
+	for _, hopByHop := range hopByHopHeaders {
+		if strings.EqualFold(name, hopByHop) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLiteralSecret reports whether a header's value looks like it holds a credential written out in the
+// server.json rather than a {variable} the user fills in: a well-known token format outside any reference, an
+// authorization scheme followed by a literal credential, or a long literal value for a header that is marked
+// secret or named like one that carries credentials
+func hasLiteralSecret(header *model.KeyValueInput) bool {
+	literal := templateReferenceRe.ReplaceAllString(header.Value, "")
+	if secretTokenRe.MatchString(literal) || credentialSchemeRe.MatchString(header.Value) {
+		return true
+	}
+	if literal != header.Value || len(header.Value) < minSecretLength || strings.ContainsAny(header.Value, " \t") {
+		return false
+	}
+	return header.IsSecret || secretHeaderNameRe.MatchString(header.Name)
+}
@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Lint rule references. Lint issues are warnings unless the rule is listed in
+// ValidationOptions.BlockingLintRules, which lets the registry tighten a rule into an error
+// once publishers have had time to adopt it.
+const (
+	LintRuleMissingIcon      = "missing-icon"
+	LintRuleShortDescription = "short-description"
+	LintRuleUnusedVariable   = "unused-variable"
+	LintRuleUnpinnedPackage  = "unpinned-package"
+	LintRuleSecretHeader     = "secret-header-value"
+)
+
+// LintRules lists every lint rule
+var LintRules = []string{LintRuleMissingIcon, LintRuleShortDescription, LintRuleUnusedVariable, LintRuleUnpinnedPackage, LintRuleSecretHeader}
+
+// minDescriptionLength is the shortest description that is not flagged as too short
+const minDescriptionLength = 20
+
+// lintServerJSON checks publishing best practices that do not make a server.json invalid
+func lintServerJSON(serverJSON *apiv0.ServerJSON, blockingRules []string) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+	ctx := &ValidationContext{}
+
+	addIssue := func(ctx *ValidationContext, message, rule string) {
+		severity := ValidationIssueSeverityWarning
+		if slices.Contains(blockingRules, rule) {
+			severity = ValidationIssueSeverityError
+		}
+		issue := NewValidationIssue(ValidationIssueTypeLinter, ctx.String(), message, severity, rule)
+		issue.Pointer = ctx.Pointer()
+		result.AddIssue(issue)
+	}
+
+	if len(serverJSON.Icons) == 0 {
+		addIssue(ctx.Field("icons"), "server has no icons; clients show a generic placeholder", LintRuleMissingIcon)
+	}
+
+	if description := strings.TrimSpace(serverJSON.Description); description != "" && len(description) < minDescriptionLength {
+		addIssue(ctx.Field("description"),
+			fmt.Sprintf("description is shorter than %d characters; describe what the server does", minDescriptionLength),
+			LintRuleShortDescription)
+	}
+
+	for i, pkg := range serverJSON.Packages {
+		pkgCtx := ctx.Field("packages").Index(i)
+		if pkg.Transport.Type == model.TransportTypeStdio && isUnpinnedPackage(&pkg) {
+			addIssue(pkgCtx.Field("version"),
+				"package does not pin a version; clients install whatever the registry serves as latest",
+				LintRuleUnpinnedPackage)
+		}
+		for j, arg := range pkg.RuntimeArguments {
+			lintInputVariables(pkgCtx.Field("runtimeArguments").Index(j), &arg.InputWithVariables, addIssue)
+		}
+		for j, arg := range pkg.PackageArguments {
+			lintInputVariables(pkgCtx.Field("packageArguments").Index(j), &arg.InputWithVariables, addIssue)
+		}
+		for j, env := range pkg.EnvironmentVariables {
+			lintInputVariables(pkgCtx.Field("environmentVariables").Index(j), &env.InputWithVariables, addIssue)
+		}
+		for j, header := range pkg.Transport.Headers {
+			lintInputVariables(pkgCtx.Field("transport").Field("headers").Index(j), &header.InputWithVariables, addIssue)
+		}
+	}
+
+	for i, remote := range serverJSON.Remotes {
+		remoteCtx := ctx.Field("remotes").Index(i)
+		for _, name := range sortedVariableNames(remote.Variables) {
+			if !strings.Contains(remote.URL, "{"+name+"}") {
+				addIssue(remoteCtx.Field("variables").Field(name),
+					fmt.Sprintf("variable %q is not referenced in the transport URL", name),
+					LintRuleUnusedVariable)
+			}
+		}
+		for j, header := range remote.Headers {
+			headerCtx := remoteCtx.Field("headers").Index(j)
+			if hasLiteralSecret(&header) {
+				addIssue(headerCtx.Field("value"),
+					fmt.Sprintf("value of header %q looks like a credential; publish a {variable} marked isSecret for users to fill in instead", header.Name),
+					LintRuleSecretHeader)
+			}
+			lintInputVariables(headerCtx, &header.InputWithVariables, addIssue)
+		}
+	}
+
+	return result
+}
+
+// lintInputVariables flags variables that are declared on an input but never substituted into its value
+func lintInputVariables(ctx *ValidationContext, input *model.InputWithVariables, addIssue func(ctx *ValidationContext, message, rule string)) {
+	for _, name := range sortedVariableNames(input.Variables) {
+		if !strings.Contains(input.Value, "{"+name+"}") {
+			addIssue(ctx.Field("variables").Field(name),
+				fmt.Sprintf("variable %q is not referenced in the value", name),
+				LintRuleUnusedVariable)
+		}
+	}
+}
+
+// sortedVariableNames returns the variable names in a stable order so issues are reported deterministically
+func sortedVariableNames(variables map[string]model.Input) []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,131 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+func lintedServerJSON() *apiv0.ServerJSON {
+	return &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/lint-server",
+		Version:     "1.0.0",
+		Description: "A server that is linted by the registry",
+		Icons:       []model.Icon{{Src: "https://example.com/icon.png"}},
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "lint-server",
+				Version:      "1.0.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+				EnvironmentVariables: []model.KeyValueInput{
+					{
+						Name: "API_URL",
+						InputWithVariables: model.InputWithVariables{
+							Input: model.Input{Value: "https://{host}/api"},
+							Variables: map[string]model.Input{
+								"host":   {Description: "API host"},
+								"region": {Description: "Unused region"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Remotes: []model.Transport{
+			{
+				Type:      model.TransportTypeStreamableHTTP,
+				URL:       "https://example.com/mcp",
+				Variables: map[string]model.Input{"tenant": {Description: "Unused tenant"}},
+			},
+		},
+	}
+}
+
+func lintOptions(blocking ...string) validator.ValidationOptions {
+	opts := validator.ValidationSemanticOnly
+	opts.ValidateLint = true
+	opts.BlockingLintRules = blocking
+	return opts
+}
+
+func TestValidateServerJSON_Lint(t *testing.T) {
+	t.Run("unused variables are warnings", func(t *testing.T) {
+		result := validator.ValidateServerJSON(lintedServerJSON(), lintOptions())
+
+		assert.True(t, result.Valid)
+		warnings := result.Warnings()
+		require.Len(t, warnings, 2)
+		assert.Equal(t, validator.ValidationIssueTypeLinter, warnings[0].Type)
+		assert.Equal(t, validator.LintRuleUnusedVariable, warnings[0].Reference)
+		assert.Equal(t, "packages[0].environmentVariables[0].variables.region", warnings[0].Path)
+		assert.Equal(t, "remotes[0].variables.tenant", warnings[1].Path)
+	})
+
+	t.Run("missing icon and short description", func(t *testing.T) {
+		serverJSON := lintedServerJSON()
+		serverJSON.Icons = nil
+		serverJSON.Description = "Too short"
+		serverJSON.Packages = nil
+		serverJSON.Remotes = nil
+
+		result := validator.ValidateServerJSON(serverJSON, lintOptions())
+
+		assert.True(t, result.Valid)
+		var rules []string
+		for _, warning := range result.Warnings() {
+			rules = append(rules, warning.Reference)
+		}
+		assert.Equal(t, []string{validator.LintRuleMissingIcon, validator.LintRuleShortDescription}, rules)
+	})
+
+	t.Run("unpinned stdio packages", func(t *testing.T) {
+		serverJSON := lintedServerJSON()
+		serverJSON.Packages[0].Version = ""
+		serverJSON.Packages[0].EnvironmentVariables = nil
+		serverJSON.Remotes = nil
+		serverJSON.Packages = append(serverJSON.Packages,
+			model.Package{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/example/lint-server", Transport: model.Transport{Type: model.TransportTypeStdio}},
+			model.Package{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/example/lint-server:latest", Transport: model.Transport{Type: model.TransportTypeStdio}},
+			model.Package{RegistryType: model.RegistryTypeOCI, Identifier: "localhost:5000/example/lint-server:1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+			model.Package{RegistryType: model.RegistryTypePyPI, Identifier: "lint-server", Version: "1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+			model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "lint-server", Transport: model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "http://localhost:3000/mcp"}},
+		)
+
+		result := validator.ValidateServerJSON(serverJSON, lintOptions())
+
+		assert.True(t, result.Valid, result.Issues)
+		var paths []string
+		for _, warning := range result.Warnings() {
+			assert.Equal(t, validator.LintRuleUnpinnedPackage, warning.Reference)
+			paths = append(paths, warning.Path)
+		}
+		assert.Equal(t, []string{"packages[0].version", "packages[1].version", "packages[2].version"}, paths)
+	})
+
+	t.Run("blocking rules are errors", func(t *testing.T) {
+		serverJSON := lintedServerJSON()
+		serverJSON.Icons = nil
+
+		result := validator.ValidateServerJSON(serverJSON, lintOptions(validator.LintRuleMissingIcon))
+
+		assert.False(t, result.Valid)
+		require.Error(t, result.FirstError())
+		assert.Contains(t, result.FirstError().Error(), "no icons")
+		assert.Len(t, result.Warnings(), 2, "non-blocking rules remain warnings")
+	})
+
+	t.Run("lint is opt-in", func(t *testing.T) {
+		serverJSON := lintedServerJSON()
+		serverJSON.Icons = nil
+
+		result := validator.ValidateServerJSON(serverJSON, validator.ValidationSemanticOnly)
+		assert.Empty(t, result.Issues)
+	})
+}
@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"slices"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// PolicyRulePackageHashRequired is the reference of issues raised for packages without a fileSha256 hash in
+// namespaces whose policy requires one
+const PolicyRulePackageHashRequired = "package-hash-required"
+
+// NamespacePolicy loosens or tightens validation for the servers of a namespace. Operators set policies per
+// namespace; the zero value validates like no policy.
+type NamespacePolicy struct {
+	// AllowHTTPRemotes accepts http:// remote URLs, which are otherwise rejected
+	AllowHTTPRemotes bool
+	// RequirePackageHashes rejects packages without a fileSha256 hash
+	RequirePackageHashes bool
+	// BlockingLintRules lists lint rules reported as errors, in addition to ValidationOptions.BlockingLintRules
+	BlockingLintRules []string
+}
+
+// blockingLintRules returns the lint rules reported as errors, including those of the namespace policy
+func (opts ValidationOptions) blockingLintRules() []string {
+	if opts.Policy == nil || len(opts.Policy.BlockingLintRules) == 0 {
+		return opts.BlockingLintRules
+	}
+	return slices.Concat(opts.BlockingLintRules, opts.Policy.BlockingLintRules)
+}
+
+// validateNamespacePolicy checks the requirements a namespace policy adds to the usual rules
+func validateNamespacePolicy(serverJSON *apiv0.ServerJSON, policy *NamespacePolicy) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+	ctx := &ValidationContext{}
+
+	if policy.RequirePackageHashes {
+		for i, pkg := range serverJSON.Packages {
+			if pkg.FileSHA256 == "" {
+				result.AddIssue(NewValidationIssue(
+					ValidationIssueTypePolicy,
+					ctx.Field("packages").Index(i).Field("fileSha256").String(),
+					"this namespace requires a fileSha256 hash for every package",
+					ValidationIssueSeverityError,
+					PolicyRulePackageHashRequired,
+				))
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,86 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+func policyServerJSON() *apiv0.ServerJSON {
+	return &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.bank/payments",
+		Version:     "1.0.0",
+		Description: "Payments for the bank",
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "bank-payments",
+				Version:      "1.0.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+		},
+		Remotes: []model.Transport{
+			{Type: model.TransportTypeStreamableHTTP, URL: "http://payments.bank.example/mcp"},
+		},
+	}
+}
+
+func policyOptions(policy *validator.NamespacePolicy) validator.ValidationOptions {
+	opts := validator.ValidationSemanticOnly
+	opts.ValidateLint = true
+	opts.Policy = policy
+	return opts
+}
+
+// issueReferences returns the references of the errors in result
+func issueReferences(result *validator.ValidationResult) []string {
+	var references []string
+	for _, issue := range result.Issues {
+		if issue.Severity == validator.ValidationIssueSeverityError {
+			references = append(references, issue.Reference)
+		}
+	}
+	return references
+}
+
+func TestValidateServerJSON_NamespacePolicy(t *testing.T) {
+	t.Run("http remotes are rejected without a policy", func(t *testing.T) {
+		result := validator.ValidateServerJSON(policyServerJSON(), policyOptions(nil))
+		assert.False(t, result.Valid)
+		assert.Equal(t, []string{"invalid-remote-url"}, issueReferences(result))
+	})
+
+	t.Run("policies can allow http remotes", func(t *testing.T) {
+		result := validator.ValidateServerJSON(policyServerJSON(), policyOptions(&validator.NamespacePolicy{AllowHTTPRemotes: true}))
+		assert.True(t, result.Valid, result.Issues)
+	})
+
+	t.Run("policies can require package hashes", func(t *testing.T) {
+		server := policyServerJSON()
+		server.Remotes = nil
+		result := validator.ValidateServerJSON(server, policyOptions(&validator.NamespacePolicy{RequirePackageHashes: true}))
+		assert.False(t, result.Valid)
+		require.Equal(t, []string{validator.PolicyRulePackageHashRequired}, issueReferences(result))
+		assert.Equal(t, "packages[0].fileSha256", result.Issues[len(result.Issues)-1].Path)
+
+		server.Packages[0].FileSHA256 = "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce"
+		result = validator.ValidateServerJSON(server, policyOptions(&validator.NamespacePolicy{RequirePackageHashes: true}))
+		assert.True(t, result.Valid, result.Issues)
+	})
+
+	t.Run("policies can make lint rules blocking", func(t *testing.T) {
+		server := policyServerJSON()
+		server.Remotes = nil
+		opts := policyOptions(&validator.NamespacePolicy{BlockingLintRules: []string{validator.LintRuleMissingIcon}})
+		opts.BlockingLintRules = []string{validator.LintRuleShortDescription}
+		result := validator.ValidateServerJSON(server, opts)
+		assert.False(t, result.Valid)
+		assert.Equal(t, []string{validator.LintRuleMissingIcon}, issueReferences(result))
+	})
+}
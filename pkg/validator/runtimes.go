@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// runtimesMetaKey is the _meta key holding the runtimes override
+const runtimesMetaKey = "io.modelcontextprotocol.registry/runtimes"
+
+func validateRuntimes(ctx *ValidationContext, serverJSON *apiv0.ServerJSON) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+	if serverJSON.Meta == nil {
+		return result
+	}
+	ctx = ctx.Field("_meta").Field(runtimesMetaKey)
+
+	seen := map[string]bool{}
+	for i, runtime := range serverJSON.Meta.Runtimes {
+		path := ctx.Index(i).String()
+		switch {
+		case !slices.Contains(model.Runtimes, runtime):
+			result.AddIssue(NewValidationIssue(ValidationIssueTypeSemantic, path,
+				fmt.Sprintf("unknown runtime %q (expected one of %s)", runtime, strings.Join(model.Runtimes, ", ")),
+				ValidationIssueSeverityError, "unknown-runtime"))
+		case seen[runtime]:
+			result.AddIssue(NewValidationIssue(ValidationIssueTypeSemantic, path,
+				fmt.Sprintf("runtime %q is listed more than once", runtime),
+				ValidationIssueSeverityError, "duplicate-runtime"))
+		}
+		seen[runtime] = true
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+func TestValidateServerJSON_Runtimes(t *testing.T) {
+	serverJSON := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/runtime-server",
+		Version:     "1.0.0",
+		Description: "A server with a runtimes override",
+		Meta:        &apiv0.ServerMeta{Runtimes: []string{model.RuntimeNode, "ruby", model.RuntimeNode}},
+	}
+
+	result := validator.ValidateServerJSON(serverJSON, validator.ValidationSemanticOnly)
+	var refs []string
+	for _, issue := range result.Issues {
+		refs = append(refs, issue.Reference)
+	}
+	assert.Equal(t, []string{"unknown-runtime", "duplicate-runtime"}, refs)
+	assert.Equal(t, "_meta.io.modelcontextprotocol.registry/runtimes[1]", result.Issues[0].Path)
+
+	serverJSON.Meta.Runtimes = []string{model.RuntimePython}
+	assert.True(t, validator.ValidateServerJSON(serverJSON, validator.ValidationSemanticOnly).Valid)
+}
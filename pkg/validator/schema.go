@@ -1,4 +1,4 @@
-package validators
+package validator
 
 import (
 	"bytes"
@@ -134,21 +134,6 @@ func validateServerJSONSchema(serverJSON *apiv0.ServerJSON, performValidation bo
 		return result
 	}
 
-	// Parse the schema
-	var schema map[string]any
-	if err := json.Unmarshal(schemaData, &schema); err != nil {
-		// If we can't parse the schema, return an error
-		issue := NewValidationIssue(
-			ValidationIssueTypeSchema,
-			ctx.Field("schema").String(),
-			fmt.Sprintf("failed to parse schema file: %v", err),
-			ValidationIssueSeverityError,
-			"schema-parse-error",
-		)
-		result.AddIssue(issue)
-		return result
-	}
-
 	// Convert the server JSON to a map for validation
 	serverData, err := json.Marshal(serverJSON)
 	if err != nil {
@@ -176,6 +161,30 @@ func validateServerJSONSchema(serverJSON *apiv0.ServerJSON, performValidation bo
 		return result
 	}
 
+	validateAgainstSchema(result, version, schemaData, serverMap)
+	return result
+}
+
+// validateAgainstSchema validates a decoded JSON document against the embedded schema of a version, adding
+// the violations it finds to result
+func validateAgainstSchema(result *ValidationResult, version string, schemaData []byte, instance any) {
+	ctx := &ValidationContext{}
+
+	// Parse the schema
+	var schema map[string]any
+	if err := json.Unmarshal(schemaData, &schema); err != nil {
+		// If we can't parse the schema, return an error
+		issue := NewValidationIssue(
+			ValidationIssueTypeSchema,
+			ctx.Field("schema").String(),
+			fmt.Sprintf("failed to parse schema file: %v", err),
+			ValidationIssueSeverityError,
+			"schema-parse-error",
+		)
+		result.AddIssue(issue)
+		return
+	}
+
 	// Get the schema $id for proper reference resolution
 	// Schema files must have $id (required by JSON Schema spec and verified by sync process)
 	// However, we check here in case a schema file exists but is malformed or missing $id
@@ -189,7 +198,7 @@ func validateServerJSONSchema(serverJSON *apiv0.ServerJSON, performValidation bo
 			"schema-missing-id",
 		)
 		result.AddIssue(issue)
-		return result
+		return
 	}
 
 	// Validate against schema using jsonschema library
@@ -204,7 +213,7 @@ func validateServerJSONSchema(serverJSON *apiv0.ServerJSON, performValidation bo
 			"schema-resource-error",
 		)
 		result.AddIssue(issue)
-		return result
+		return
 	}
 
 	schemaInstance, err := compiler.Compile(schemaID)
@@ -218,11 +227,11 @@ func validateServerJSONSchema(serverJSON *apiv0.ServerJSON, performValidation bo
 			"schema-compile-error",
 		)
 		result.AddIssue(issue)
-		return result
+		return
 	}
 
 	// Perform validation
-	if err := schemaInstance.Validate(serverMap); err != nil {
+	if err := schemaInstance.Validate(instance); err != nil {
 		// Convert validation error to our issue format
 		var validationErr *jsonschema.ValidationError
 		if errors.As(err, &validationErr) {
@@ -240,8 +249,6 @@ func validateServerJSONSchema(serverJSON *apiv0.ServerJSON, performValidation bo
 			result.AddIssue(issue)
 		}
 	}
-
-	return result
 }
 
 // addValidationError processes validation errors and extracts useful information
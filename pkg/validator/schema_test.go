@@ -1,9 +1,9 @@
-package validators_test
+package validator_test
 
 import (
 	"testing"
 
-	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -114,7 +114,7 @@ func TestConvertJSONPointerToBracketNotation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := validators.ConvertJSONPointerToBracketNotation(tt.jsonPointer)
+			result := validator.ConvertJSONPointerToBracketNotation(tt.jsonPointer)
 			assert.Equal(t, tt.expectedOutput, result, "%s: JSON Pointer format should convert to bracket notation", tt.description)
 		})
 	}
@@ -0,0 +1,150 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// runtimeHints lists the runtime hints that can launch the packages of each registry type. A hint listed
+// for another registry type, such as uvx for an npm package, cannot run the package.
+var runtimeHints = map[string][]string{
+	model.RegistryTypeNPM:   {model.RuntimeHintNPX, "bunx", "pnpx", "pnpm", "yarn"},
+	model.RegistryTypePyPI:  {model.RuntimeHintUVX, "uv", "pipx", "pip", "python", "python3"},
+	model.RegistryTypeOCI:   {model.RuntimeHintDocker, "podman"},
+	model.RegistryTypeNuGet: {model.RuntimeHintDNX, "dotnet"},
+}
+
+// shellMetacharacters are the characters a shell would interpret in an argument. Clients start stdio
+// packages without a shell and users paste install instructions into one, so arguments relying on them
+// break one way or the other. Variable references such as $HOME are allowed: clients expand them.
+const shellMetacharacters = ";|&`<>\n\r"
+
+// commandSubstitution starts a command substitution, which is refused like the shell metacharacters
+const commandSubstitution = "$("
+
+var (
+	// npmIdentifierRe matches npm package names, optionally scoped
+	npmIdentifierRe = regexp.MustCompile(`^(?:@[a-z0-9-~][a-z0-9-._~]*/)?[a-z0-9-~][a-z0-9-._~]*$`)
+	// pypiIdentifierRe matches PyPI project names as defined by PEP 508
+	pypiIdentifierRe = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+	// nugetIdentifierRe matches NuGet package IDs
+	nugetIdentifierRe = regexp.MustCompile(`^[A-Za-z0-9_]+(?:[.-][A-Za-z0-9_]+)*$`)
+	// ociIdentifierRe matches image references: an optional registry host, a lowercase repository path, and
+	// an optional tag and digest
+	ociIdentifierRe = regexp.MustCompile(`^(?:[A-Za-z0-9.-]+(?::[0-9]+)?/)?[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*(?::[A-Za-z0-9_][A-Za-z0-9_.-]{0,127})?(?:@sha256:[a-f0-9]{64})?$`)
+)
+
+// maxNPMIdentifierLength is the longest package name npm accepts
+const maxNPMIdentifierLength = 214
+
+// validateStdioPackage checks that the install instructions of a package run locally over stdio can work:
+// its identifier is well formed for its registry, its runtime hint can launch it, and its arguments pass
+// through a shell unchanged
+func validateStdioPackage(ctx *ValidationContext, obj *model.Package) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+
+	if err := validatePackageIdentifier(obj.RegistryType, obj.Identifier); err != nil {
+		result.AddIssue(NewValidationIssueFromError(
+			ValidationIssueTypeSemantic,
+			ctx.Field("identifier").String(),
+			err,
+			"invalid-package-identifier",
+		))
+	}
+
+	if hint := obj.RunTimeHint; hint != "" && !slices.Contains(runtimeHints[obj.RegistryType], hint) {
+		for registryType, hints := range runtimeHints {
+			if slices.Contains(hints, hint) {
+				result.AddIssue(NewValidationIssueFromError(
+					ValidationIssueTypeSemantic,
+					ctx.Field("runtimeHint").String(),
+					fmt.Errorf("%w: %q launches %s packages, not %s packages", ErrRuntimeHintMismatch, hint, registryType, obj.RegistryType),
+					"runtime-hint-mismatch",
+				))
+				break
+			}
+		}
+	}
+
+	for i, arg := range obj.RuntimeArguments {
+		result.Merge(validateArgumentShellSafe(ctx.Field("runtimeArguments").Index(i), &arg))
+	}
+	for i, arg := range obj.PackageArguments {
+		result.Merge(validateArgumentShellSafe(ctx.Field("packageArguments").Index(i), &arg))
+	}
+
+	return result
+}
+
+// validatePackageIdentifier checks that an identifier has the format its registry type requires.
+// MCPB download URLs, and identifiers of unknown registry types, are left to the registry validators.
+func validatePackageIdentifier(registryType, identifier string) error {
+	var valid bool
+	switch registryType {
+	case model.RegistryTypeNPM:
+		valid = len(identifier) <= maxNPMIdentifierLength && npmIdentifierRe.MatchString(identifier)
+	case model.RegistryTypePyPI:
+		valid = pypiIdentifierRe.MatchString(identifier)
+	case model.RegistryTypeNuGet:
+		valid = nugetIdentifierRe.MatchString(identifier)
+	case model.RegistryTypeOCI:
+		valid = ociIdentifierRe.MatchString(identifier)
+	default:
+		return nil
+	}
+	if !valid {
+		return fmt.Errorf("%w: %q is not a valid %s package identifier", ErrInvalidPackageIdentifier, identifier, registryType)
+	}
+	return nil
+}
+
+// validateArgumentShellSafe checks that an argument's value and default hold no shell metacharacters
+func validateArgumentShellSafe(ctx *ValidationContext, obj *model.Argument) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+
+	for _, field := range []struct{ name, value string }{{"value", obj.Value}, {"default", obj.Default}} {
+		if metacharacter := findShellMetacharacter(field.value); metacharacter != "" {
+			result.AddIssue(NewValidationIssueFromError(
+				ValidationIssueTypeSemantic,
+				ctx.Field(field.name).String(),
+				fmt.Errorf("%w: %q", ErrArgumentShellMetacharacter, metacharacter),
+				"argument-shell-metacharacter",
+			))
+		}
+	}
+
+	return result
+}
+
+// findShellMetacharacter returns the first shell metacharacter or command substitution in value, or ""
+func findShellMetacharacter(value string) string {
+	if i := strings.IndexAny(value, shellMetacharacters); i >= 0 {
+		return value[i : i+1]
+	}
+	if strings.Contains(value, commandSubstitution) {
+		return commandSubstitution
+	}
+	return ""
+}
+
+// isUnpinnedPackage reports whether a package leaves the version clients install to its registry: an npm,
+// PyPI or NuGet package without a version, or an image without a tag or digest or tagged latest
+func isUnpinnedPackage(obj *model.Package) bool {
+	switch obj.RegistryType {
+	case model.RegistryTypeNPM, model.RegistryTypePyPI, model.RegistryTypeNuGet:
+		return obj.Version == ""
+	case model.RegistryTypeOCI:
+		if strings.Contains(obj.Identifier, "@") {
+			return false
+		}
+		repository := obj.Identifier[strings.LastIndex(obj.Identifier, "/")+1:]
+		_, tag, tagged := strings.Cut(repository, ":")
+		return !tagged || tag == "latest"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,132 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+func stdioServerJSON(pkg model.Package) *apiv0.ServerJSON {
+	pkg.Transport = model.Transport{Type: model.TransportTypeStdio}
+	return &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/stdio-server",
+		Version:     "1.0.0",
+		Description: "A server run locally over stdio",
+		Packages:    []model.Package{pkg},
+	}
+}
+
+func TestValidateServerJSON_StdioPackages(t *testing.T) {
+	tests := []struct {
+		name      string
+		pkg       model.Package
+		path      string
+		reference string
+	}{
+		{
+			name: "scoped npm package run with npx",
+			pkg:  model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "@example/stdio-server", Version: "1.0.0", RunTimeHint: "npx"},
+		},
+		{
+			name: "pypi package run with uvx",
+			pkg:  model.Package{RegistryType: model.RegistryTypePyPI, Identifier: "Stdio_Server.py", Version: "1.0.0", RunTimeHint: "uvx"},
+		},
+		{
+			name: "image pinned by digest run with podman",
+			pkg: model.Package{RegistryType: model.RegistryTypeOCI, RunTimeHint: "podman",
+				Identifier: "ghcr.io/example/stdio-server@sha256:fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce"},
+		},
+		{
+			name: "nuget package run with dnx",
+			pkg:  model.Package{RegistryType: model.RegistryTypeNuGet, Identifier: "Example.StdioServer", Version: "1.0.0", RunTimeHint: "dnx"},
+		},
+		{
+			name: "unknown runtime hints are left alone",
+			pkg:  model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "stdio-server", Version: "1.0.0", RunTimeHint: "deno"},
+		},
+		{
+			name: "environment variable references in arguments",
+			pkg: model.Package{RegistryType: model.RegistryTypePyPI, Identifier: "stdio-server", Version: "1.0.0",
+				PackageArguments: []model.Argument{{Type: model.ArgumentTypePositional, InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "$HOME/data"}}}}},
+		},
+		{
+			name:      "npm package run with uvx",
+			pkg:       model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "stdio-server", Version: "1.0.0", RunTimeHint: "uvx"},
+			path:      "packages[0].runtimeHint",
+			reference: "runtime-hint-mismatch",
+		},
+		{
+			name:      "image run with npx",
+			pkg:       model.Package{RegistryType: model.RegistryTypeOCI, Identifier: "example/stdio-server:1.0.0", RunTimeHint: "npx"},
+			path:      "packages[0].runtimeHint",
+			reference: "runtime-hint-mismatch",
+		},
+		{
+			name:      "uppercase npm package",
+			pkg:       model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "@Example/Stdio-Server", Version: "1.0.0"},
+			path:      "packages[0].identifier",
+			reference: "invalid-package-identifier",
+		},
+		{
+			name:      "pypi package with a version specifier",
+			pkg:       model.Package{RegistryType: model.RegistryTypePyPI, Identifier: "stdio-server==1.0.0", Version: "1.0.0"},
+			path:      "packages[0].identifier",
+			reference: "invalid-package-identifier",
+		},
+		{
+			name:      "nuget package with a slash",
+			pkg:       model.Package{RegistryType: model.RegistryTypeNuGet, Identifier: "Example/StdioServer", Version: "1.0.0"},
+			path:      "packages[0].identifier",
+			reference: "invalid-package-identifier",
+		},
+		{
+			name:      "image with an uppercase repository",
+			pkg:       model.Package{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/Example/stdio-server:1.0.0"},
+			path:      "packages[0].identifier",
+			reference: "invalid-package-identifier",
+		},
+		{
+			name: "piped runtime argument",
+			pkg: model.Package{RegistryType: model.RegistryTypeOCI, Identifier: "example/stdio-server:1.0.0", RunTimeHint: "docker",
+				RuntimeArguments: []model.Argument{{Type: model.ArgumentTypePositional, InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "run | tee log"}}}}},
+			path:      "packages[0].runtimeArguments[0].value",
+			reference: "argument-shell-metacharacter",
+		},
+		{
+			name: "command substitution in a default",
+			pkg: model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "stdio-server", Version: "1.0.0",
+				PackageArguments: []model.Argument{{Type: model.ArgumentTypeNamed, Name: "--token", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: "$(cat token)"}}}}},
+			path:      "packages[0].packageArguments[0].default",
+			reference: "argument-shell-metacharacter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validator.ValidateServerJSON(stdioServerJSON(tt.pkg), validator.ValidationSemanticOnly)
+			if tt.reference == "" {
+				assert.True(t, result.Valid, result.Issues)
+				return
+			}
+			assert.False(t, result.Valid)
+			require.Len(t, result.Issues, 1, result.Issues)
+			assert.Equal(t, tt.path, result.Issues[0].Path)
+			assert.Equal(t, tt.reference, result.Issues[0].Reference)
+		})
+	}
+
+	t.Run("packages served over HTTP are not checked", func(t *testing.T) {
+		pkg := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "stdio-server", Version: "1.0.0", RunTimeHint: "uvx"}
+		serverJSON := stdioServerJSON(pkg)
+		serverJSON.Packages[0].Transport = model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "http://localhost:3000/mcp"}
+
+		result := validator.ValidateServerJSON(serverJSON, validator.ValidationSemanticOnly)
+		assert.True(t, result.Valid, result.Issues)
+	})
+}
@@ -0,0 +1,199 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+var (
+	// templateReferenceRe matches a {variable} reference. Braces around anything else, such as JSON in an
+	// argument value, are not references.
+	templateReferenceRe = regexp.MustCompile(`\{([A-Za-z0-9_.-]+)\}`)
+	// portReferenceRe matches a reference in the port position of a URL, as in http://localhost:{port}/mcp
+	portReferenceRe = regexp.MustCompile(`:\{([A-Za-z0-9_.-]+)\}(?:/|$)`)
+)
+
+// validateTemplates checks the {variable} references in argument, environment variable and header values and
+// in transport URLs: every reference must name a declared variable of a format that can be substituted there,
+// and each variable's default and choices must be of its format. Undeclared references in transport URLs are
+// reported by the transport checks; variables that are declared but never referenced are a lint warning.
+func validateTemplates(ctx *ValidationContext, serverJSON *apiv0.ServerJSON) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+
+	for i, pkg := range serverJSON.Packages {
+		pkgCtx := ctx.Field("packages").Index(i)
+		for j, arg := range pkg.RuntimeArguments {
+			result.Merge(validateTemplatedValue(pkgCtx.Field("runtimeArguments").Index(j), &arg.InputWithVariables, true))
+		}
+		for j, arg := range pkg.PackageArguments {
+			result.Merge(validateTemplatedValue(pkgCtx.Field("packageArguments").Index(j), &arg.InputWithVariables, true))
+		}
+		for j, env := range pkg.EnvironmentVariables {
+			result.Merge(validateTemplatedValue(pkgCtx.Field("environmentVariables").Index(j), &env.InputWithVariables, true))
+		}
+		for j, header := range pkg.Transport.Headers {
+			result.Merge(validateTemplatedValue(pkgCtx.Field("transport").Field("headers").Index(j), &header.InputWithVariables, false))
+		}
+		result.Merge(validateTemplatedURL(pkgCtx.Field("transport").Field("url"), pkg.Transport.URL, packageTemplateVariables(&pkg)))
+	}
+
+	for i, remote := range serverJSON.Remotes {
+		remoteCtx := ctx.Field("remotes").Index(i)
+		for j, header := range remote.Headers {
+			result.Merge(validateTemplatedValue(remoteCtx.Field("headers").Index(j), &header.InputWithVariables, false))
+		}
+		for _, name := range sortedVariableNames(remote.Variables) {
+			result.Merge(validateVariableFormat(remoteCtx.Field("variables").Field(name), remote.Variables[name]))
+		}
+		result.Merge(validateTemplatedURL(remoteCtx.Field("url"), remote.URL, remote.Variables))
+	}
+
+	return result
+}
+
+// validateTemplatedValue checks the references in an input's value against the variables it declares.
+// allowFilepath is false for values sent over the network, such as headers, where a path on the user's
+// machine means nothing.
+func validateTemplatedValue(ctx *ValidationContext, input *model.InputWithVariables, allowFilepath bool) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+
+	for _, name := range templateReferences(input.Value) {
+		variable, declared := input.Variables[name]
+		switch {
+		case !declared:
+			result.AddIssue(newTemplateIssue(ctx.Field("value"),
+				fmt.Sprintf("value references {%s}, which is not declared in variables", name),
+				"undefined-template-variable"))
+		case variable.Format == model.FormatFilePath && !allowFilepath:
+			result.AddIssue(newTemplateIssue(ctx.Field("variables").Field(name).Field("format"),
+				fmt.Sprintf("variable %q is a filepath, which cannot be sent in a header", name),
+				"incompatible-template-variable"))
+		}
+	}
+
+	for _, name := range sortedVariableNames(input.Variables) {
+		result.Merge(validateVariableFormat(ctx.Field("variables").Field(name), input.Variables[name]))
+	}
+
+	return result
+}
+
+// validateTemplatedURL checks that the variables a URL references can be substituted into it: booleans and
+// file paths cannot, and a variable in the port position must have numeric default and choices
+func validateTemplatedURL(ctx *ValidationContext, rawURL string, variables map[string]model.Input) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+
+	var ports []string
+	for _, match := range portReferenceRe.FindAllStringSubmatch(rawURL, -1) {
+		ports = append(ports, match[1])
+	}
+
+	for _, name := range templateReferences(rawURL) {
+		variable, declared := variables[name]
+		if !declared {
+			continue
+		}
+		switch {
+		case variable.Format == model.FormatBoolean || variable.Format == model.FormatFilePath:
+			result.AddIssue(newTemplateIssue(ctx,
+				fmt.Sprintf("URL references {%s}, a %s, which cannot be substituted into a URL", name, variable.Format),
+				"incompatible-template-variable"))
+		case slices.Contains(ports, name) && !inputValuesMatch(&variable, model.FormatNumber):
+			result.AddIssue(newTemplateIssue(ctx,
+				fmt.Sprintf("URL uses {%s} as a port, but its default or choices are not numbers", name),
+				"incompatible-template-variable"))
+		}
+	}
+
+	return result
+}
+
+// validateVariableFormat checks that a variable's default and choices are values of its format
+func validateVariableFormat(ctx *ValidationContext, variable model.Input) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+
+	if variable.Format != model.FormatNumber && variable.Format != model.FormatBoolean {
+		return result
+	}
+	if variable.Default != "" && !matchesFormat(variable.Default, variable.Format) {
+		result.AddIssue(newTemplateIssue(ctx.Field("default"),
+			fmt.Sprintf("default %q is not a %s", variable.Default, variable.Format),
+			"variable-format-mismatch"))
+	}
+	for i, choice := range variable.Choices {
+		if !matchesFormat(choice, variable.Format) {
+			result.AddIssue(newTemplateIssue(ctx.Field("choices").Index(i),
+				fmt.Sprintf("choice %q is not a %s", choice, variable.Format),
+				"variable-format-mismatch"))
+		}
+	}
+
+	return result
+}
+
+// packageTemplateVariables returns the inputs a package's transport URL can reference: its environment
+// variables by name, and its arguments by name and value hint
+func packageTemplateVariables(pkg *model.Package) map[string]model.Input {
+	variables := map[string]model.Input{}
+	for _, env := range pkg.EnvironmentVariables {
+		variables[env.Name] = env.Input
+	}
+	for _, arg := range slices.Concat(pkg.RuntimeArguments, pkg.PackageArguments) {
+		if arg.Name != "" {
+			variables[arg.Name] = arg.Input
+		}
+		if arg.ValueHint != "" {
+			variables[arg.ValueHint] = arg.Input
+		}
+	}
+	return variables
+}
+
+// templateReferences returns the names of the variables a templated string references, each once, in order
+func templateReferences(s string) []string {
+	var names []string
+	for _, match := range templateReferenceRe.FindAllStringSubmatch(s, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// inputValuesMatch reports whether an input's default and choices are all values of format
+func inputValuesMatch(input *model.Input, format model.Format) bool {
+	if input.Default != "" && !matchesFormat(input.Default, format) {
+		return false
+	}
+	for _, choice := range input.Choices {
+		if !matchesFormat(choice, format) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesFormat reports whether value is a value of a number or boolean format
+func matchesFormat(value string, format model.Format) bool {
+	switch format {
+	case model.FormatNumber:
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil
+	case model.FormatBoolean:
+		return value == "true" || value == "false"
+	default:
+		return true
+	}
+}
+
+// newTemplateIssue creates a semantic error at ctx, with its JSON pointer
+func newTemplateIssue(ctx *ValidationContext, message, reference string) ValidationIssue {
+	issue := NewValidationIssue(ValidationIssueTypeSemantic, ctx.String(), message, ValidationIssueSeverityError, reference)
+	issue.Pointer = ctx.Pointer()
+	return issue
+}
@@ -0,0 +1,154 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+func templatedServerJSON() *apiv0.ServerJSON {
+	return &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/templated-server",
+		Version:     "1.0.0",
+		Description: "A server with templated arguments and headers",
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "templated-server",
+				Version:      "1.0.0",
+				Transport:    model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "http://localhost:{--port}/mcp"},
+				RuntimeArguments: []model.Argument{
+					{
+						Type: model.ArgumentTypeNamed,
+						Name: "--mount",
+						InputWithVariables: model.InputWithVariables{
+							Input: model.Input{Value: "src={source_path},opts={\"ro\": true}"},
+							Variables: map[string]model.Input{
+								"source_path": {Format: model.FormatFilePath},
+							},
+						},
+					},
+				},
+				PackageArguments: []model.Argument{
+					{
+						Type:               model.ArgumentTypeNamed,
+						Name:               "--port",
+						InputWithVariables: model.InputWithVariables{Input: model.Input{Format: model.FormatNumber, Default: "3000"}},
+					},
+				},
+			},
+		},
+		Remotes: []model.Transport{
+			{
+				Type: model.TransportTypeStreamableHTTP,
+				URL:  "https://{tenant.region}.example.com/mcp",
+				Headers: []model.KeyValueInput{
+					{
+						Name: "Authorization",
+						InputWithVariables: model.InputWithVariables{
+							Input:     model.Input{Value: "Bearer {api_key}"},
+							Variables: map[string]model.Input{"api_key": {IsSecret: true}},
+						},
+					},
+				},
+				Variables: map[string]model.Input{"tenant.region": {Choices: []string{"us", "eu"}}},
+			},
+		},
+	}
+}
+
+func TestValidateServerJSON_Templates(t *testing.T) {
+	t.Run("declared references are valid", func(t *testing.T) {
+		result := validator.ValidateServerJSON(templatedServerJSON(), validator.ValidationSemanticOnly)
+		assert.True(t, result.Valid, result.Issues)
+	})
+
+	tests := []struct {
+		name      string
+		modify    func(*apiv0.ServerJSON)
+		reference string
+		path      string
+		pointer   string
+	}{
+		{
+			name: "undeclared reference in an argument",
+			modify: func(s *apiv0.ServerJSON) {
+				s.Packages[0].RuntimeArguments[0].Value = "src={source_path},dst={target_path}"
+			},
+			reference: "undefined-template-variable",
+			path:      "packages[0].runtimeArguments[0].value",
+			pointer:   "/packages/0/runtimeArguments/0/value",
+		},
+		{
+			name: "undeclared reference in a header",
+			modify: func(s *apiv0.ServerJSON) {
+				s.Remotes[0].Headers[0].Value = "Bearer {token}"
+			},
+			reference: "undefined-template-variable",
+			path:      "remotes[0].headers[0].value",
+			pointer:   "/remotes/0/headers/0/value",
+		},
+		{
+			name: "file path in a header",
+			modify: func(s *apiv0.ServerJSON) {
+				s.Remotes[0].Headers[0].Variables["api_key"] = model.Input{Format: model.FormatFilePath}
+			},
+			reference: "incompatible-template-variable",
+			path:      "remotes[0].headers[0].variables.api_key.format",
+			pointer:   "/remotes/0/headers/0/variables/api_key/format",
+		},
+		{
+			name: "boolean in a URL",
+			modify: func(s *apiv0.ServerJSON) {
+				s.Remotes[0].Variables["tenant.region"] = model.Input{Format: model.FormatBoolean}
+			},
+			reference: "incompatible-template-variable",
+			path:      "remotes[0].url",
+			pointer:   "/remotes/0/url",
+		},
+		{
+			name: "port that is not a number",
+			modify: func(s *apiv0.ServerJSON) {
+				s.Packages[0].PackageArguments[0].Format = ""
+				s.Packages[0].PackageArguments[0].Default = "auto"
+			},
+			reference: "incompatible-template-variable",
+			path:      "packages[0].transport.url",
+			pointer:   "/packages/0/transport/url",
+		},
+		{
+			name: "choice that does not match the format",
+			modify: func(s *apiv0.ServerJSON) {
+				s.Remotes[0].Variables["tenant.region"] = model.Input{Format: model.FormatNumber, Choices: []string{"1", "two"}}
+			},
+			reference: "variable-format-mismatch",
+			path:      "remotes[0].variables.tenant.region.choices[1]",
+			pointer:   "/remotes/0/variables/tenant.region/choices/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverJSON := templatedServerJSON()
+			tt.modify(serverJSON)
+
+			result := validator.ValidateServerJSON(serverJSON, validator.ValidationSemanticOnly)
+
+			assert.False(t, result.Valid)
+			var found bool
+			for _, issue := range result.Issues {
+				if issue.Reference == tt.reference {
+					found = true
+					assert.Equal(t, tt.path, issue.Path)
+					assert.Equal(t, tt.pointer, issue.Pointer)
+				}
+			}
+			assert.True(t, found, "expected a %s issue, got %v", tt.reference, result.Issues)
+		})
+	}
+}
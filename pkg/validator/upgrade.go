@@ -0,0 +1,189 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// officialMetaKey is the _meta key of the registry-managed metadata schema versions before 2025-09-29 allowed
+// publishers to send
+const officialMetaKey = "io.modelcontextprotocol.registry/official"
+
+// LegacyTopLevelFields are the top-level fields of earlier schema versions that the current schema renamed or
+// removed. Documents for those versions may have them until they are upgraded.
+var LegacyTopLevelFields = []string{"website_url", "status"}
+
+// schemaMigration rewrites documents written for schema versions before a version that changed their structure
+type schemaMigration struct {
+	// before is the version that introduced the change: documents for earlier versions need the migration
+	before  string
+	migrate func(document map[string]any)
+}
+
+// schemaMigrations are applied in order to upgrade documents to the current schema version
+var schemaMigrations = []schemaMigration{
+	{before: "2025-09-16", migrate: camelCaseFields},
+	{before: "2025-09-29", migrate: removeRegistryManagedFields},
+}
+
+// snakeCaseFields maps the field names of schema versions before 2025-09-16 to their current names
+var snakeCaseFields = map[string]string{
+	"registry_type":         "registryType",
+	"registry_base_url":     "registryBaseUrl",
+	"file_sha256":           "fileSha256",
+	"runtime_hint":          "runtimeHint",
+	"runtime_arguments":     "runtimeArguments",
+	"package_arguments":     "packageArguments",
+	"environment_variables": "environmentVariables",
+	"is_required":           "isRequired",
+	"is_secret":             "isSecret",
+	"value_hint":            "valueHint",
+	"is_repeated":           "isRepeated",
+	"website_url":           "websiteUrl",
+}
+
+// SchemaUpgrade is the outcome of upgrading a server.json document to the current schema version
+type SchemaUpgrade struct {
+	// Original is the document as it was submitted
+	Original []byte
+	// Document is the upgraded document, or the original one when it needed no upgrade
+	Document []byte
+	// Version is the schema version the original document declared in $schema, or "" if it declared none
+	Version string
+	// Upgraded reports whether the document was written for an earlier schema version and rewritten
+	Upgraded bool
+	// Result holds the violations of the original document's own schema version, and a warning that it was
+	// upgraded. It is valid and empty for documents that were not upgraded.
+	Result *ValidationResult
+}
+
+// SupportedSchemaVersions returns the schema versions documents can be published with, oldest first
+func SupportedSchemaVersions() []string {
+	entries, err := fs.ReadDir(schemaFS, "schemas")
+	if err != nil {
+		return nil
+	}
+	var versions []string
+	for _, entry := range entries {
+		if version, ok := strings.CutSuffix(entry.Name(), ".json"); ok {
+			versions = append(versions, version)
+		}
+	}
+	// Versions are dates, so they sort chronologically
+	slices.Sort(versions)
+	return versions
+}
+
+// UpgradeServerJSON upgrades a server.json document written for an earlier schema version to the current one.
+// The document is validated against the schema version it declares in $schema, then rewritten to the current
+// field names and structure, and its $schema set to the current schema. Documents for the current version,
+// and documents whose $schema is missing or names no known version, are returned unchanged for validation
+// to report.
+func UpgradeServerJSON(data []byte) *SchemaUpgrade {
+	upgrade := &SchemaUpgrade{Original: data, Document: data, Result: &ValidationResult{Valid: true, Issues: []ValidationIssue{}}}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document map[string]any
+	if err := decoder.Decode(&document); err != nil {
+		return upgrade
+	}
+	schemaURL, _ := document["$schema"].(string)
+	version, err := extractVersionFromSchemaURL(schemaURL)
+	if err != nil {
+		return upgrade
+	}
+	upgrade.Version = version
+	if schemaURL == model.CurrentSchemaURL || version >= model.CurrentSchemaVersion {
+		return upgrade
+	}
+	schemaData, err := loadSchemaByVersion(version)
+	if err != nil {
+		return upgrade
+	}
+
+	validateAgainstSchema(upgrade.Result, version, schemaData, document)
+
+	for _, migration := range schemaMigrations {
+		if version < migration.before {
+			migration.migrate(document)
+		}
+	}
+	document["$schema"] = model.CurrentSchemaURL
+	upgraded, err := json.Marshal(document)
+	if err != nil {
+		return upgrade
+	}
+	upgrade.Document = upgraded
+	upgrade.Upgraded = true
+	upgrade.Result.AddIssue(NewValidationIssue(
+		ValidationIssueTypeSemantic,
+		"schema",
+		fmt.Sprintf("server.json was written for schema version %s and was upgraded to the current version (%s). Update it to the current schema version", version, model.CurrentSchemaVersion),
+		ValidationIssueSeverityWarning,
+		"schema-version-upgraded",
+	))
+	return upgrade
+}
+
+// camelCaseFields renames the snake_case fields of schema versions before 2025-09-16
+func camelCaseFields(document map[string]any) {
+	renameFields(document)
+}
+
+// renameFields renames the snake_case fields of an object and the objects in it. Publisher-provided _meta is
+// left alone, as are the names of variables, which publishers choose.
+func renameFields(value any) {
+	switch value := value.(type) {
+	case []any:
+		for _, item := range value {
+			renameFields(item)
+		}
+	case map[string]any:
+		renames := map[string]string{}
+		for key, field := range value {
+			switch key {
+			case "_meta":
+				continue
+			case "variables":
+				if variables, ok := field.(map[string]any); ok {
+					for _, variable := range variables {
+						renameFields(variable)
+					}
+				}
+			default:
+				renameFields(field)
+			}
+			if name, ok := snakeCaseFields[key]; ok {
+				renames[key] = name
+			}
+		}
+		for from, to := range renames {
+			// A document setting both names keeps the current one
+			if _, exists := value[to]; !exists {
+				value[to] = value[from]
+			}
+			delete(value, from)
+		}
+	}
+}
+
+// removeRegistryManagedFields removes the fields schema versions before 2025-09-29 let publishers send but the
+// registry now manages: the server's status and its official metadata
+func removeRegistryManagedFields(document map[string]any) {
+	delete(document, "status")
+	meta, ok := document["_meta"].(map[string]any)
+	if !ok {
+		return
+	}
+	delete(meta, officialMetaKey)
+	if len(meta) == 0 {
+		delete(document, "_meta")
+	}
+}
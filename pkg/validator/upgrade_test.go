@@ -0,0 +1,114 @@
+package validator_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
+)
+
+func TestSupportedSchemaVersions(t *testing.T) {
+	versions := validator.SupportedSchemaVersions()
+	require.NotEmpty(t, versions)
+	assert.Equal(t, "2025-07-09", versions[0])
+	assert.Equal(t, model.CurrentSchemaVersion, versions[len(versions)-1])
+}
+
+func TestUpgradeServerJSON(t *testing.T) {
+	t.Run("upgrades snake_case documents", func(t *testing.T) {
+		upgrade := validator.UpgradeServerJSON([]byte(`{
+			"$schema": "https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json",
+			"name": "io.github.example/weather",
+			"description": "Weather forecasts",
+			"version": "1.0.0",
+			"status": "active",
+			"website_url": "https://example.com/weather",
+			"packages": [{
+				"registry_type": "npm",
+				"identifier": "@example/weather",
+				"version": "1.0.0",
+				"transport": {"type": "stdio"},
+				"environment_variables": [{"name": "API_KEY", "is_required": true, "is_secret": true}]
+			}],
+			"remotes": [{
+				"type": "streamable-http",
+				"url": "https://example.com/mcp",
+				"headers": [{"name": "X-API-Key", "is_secret": true}]
+			}],
+			"_meta": {
+				"io.modelcontextprotocol.registry/official": {"status": "active"},
+				"io.modelcontextprotocol.registry/publisher-provided": {"build_id": "42"}
+			}
+		}`))
+		assert.True(t, upgrade.Upgraded)
+		assert.Equal(t, "2025-07-09", upgrade.Version)
+		assert.True(t, upgrade.Result.Valid)
+		require.Len(t, upgrade.Result.Warnings(), 1)
+		assert.Equal(t, "schema-version-upgraded", upgrade.Result.Warnings()[0].Reference)
+
+		var server apiv0.ServerJSON
+		require.NoError(t, json.Unmarshal(upgrade.Document, &server))
+		assert.Equal(t, model.CurrentSchemaURL, server.Schema)
+		assert.Equal(t, "https://example.com/weather", server.WebsiteURL)
+		require.Len(t, server.Packages, 1)
+		assert.Equal(t, "npm", server.Packages[0].RegistryType)
+		require.Len(t, server.Packages[0].EnvironmentVariables, 1)
+		assert.True(t, server.Packages[0].EnvironmentVariables[0].IsRequired)
+		assert.True(t, server.Packages[0].EnvironmentVariables[0].IsSecret)
+		require.Len(t, server.Remotes, 1)
+		require.Len(t, server.Remotes[0].Headers, 1)
+		assert.True(t, server.Remotes[0].Headers[0].IsSecret)
+
+		var document map[string]any
+		require.NoError(t, json.Unmarshal(upgrade.Document, &document))
+		assert.NotContains(t, document, "status")
+		assert.NotContains(t, document, "website_url")
+		assert.Equal(t, map[string]any{
+			"io.modelcontextprotocol.registry/publisher-provided": map[string]any{"build_id": "42"},
+		}, document["_meta"], "publisher-provided metadata is kept as is")
+
+		result := validator.ValidateServerJSON(&server, validator.ValidationAll)
+		assert.True(t, result.Valid, result.Issues)
+	})
+
+	t.Run("reports violations of the original schema version", func(t *testing.T) {
+		upgrade := validator.UpgradeServerJSON([]byte(`{
+			"$schema": "https://static.modelcontextprotocol.io/schemas/2025-09-16/server.schema.json",
+			"name": "io.github.example/weather",
+			"description": "Weather forecasts",
+			"version": "1.0.0",
+			"packages": [{"registryType": "npm", "version": "1.0.0", "transport": {"type": "stdio"}}]
+		}`))
+		assert.True(t, upgrade.Upgraded)
+		assert.False(t, upgrade.Result.Valid)
+		require.Error(t, upgrade.Result.FirstError())
+		assert.Contains(t, upgrade.Result.FirstError().Error(), "identifier")
+	})
+
+	t.Run("leaves current documents alone", func(t *testing.T) {
+		data := []byte(`{"$schema": "` + model.CurrentSchemaURL + `", "name": "io.github.example/weather"}`)
+		upgrade := validator.UpgradeServerJSON(data)
+		assert.False(t, upgrade.Upgraded)
+		assert.Equal(t, model.CurrentSchemaVersion, upgrade.Version)
+		assert.Equal(t, data, upgrade.Document)
+		assert.Empty(t, upgrade.Result.Issues)
+	})
+
+	t.Run("leaves unknown versions for validation to report", func(t *testing.T) {
+		for _, data := range []string{
+			`{"name": "io.github.example/weather"}`,
+			`{"$schema": "https://static.modelcontextprotocol.io/schemas/2024-01-01/server.schema.json"}`,
+			`not json`,
+		} {
+			upgrade := validator.UpgradeServerJSON([]byte(data))
+			assert.False(t, upgrade.Upgraded, data)
+			assert.Equal(t, []byte(data), upgrade.Document, data)
+			assert.True(t, upgrade.Result.Valid, data)
+		}
+	})
+}
@@ -1,4 +1,4 @@
-package validators
+package validator
 
 import (
 	"net/url"
@@ -133,6 +133,11 @@ func IsValidSubfolderPath(path string) bool {
 
 // IsValidRemoteURL checks if a URL is valid for remotes (stricter than packages - no localhost allowed)
 func IsValidRemoteURL(rawURL string) bool {
+	return isValidRemoteURL(rawURL, false)
+}
+
+// isValidRemoteURL checks a remote URL like IsValidRemoteURL, also accepting http:// URLs if allowHTTP is set
+func isValidRemoteURL(rawURL string, allowHTTP bool) bool {
 	// First check basic URL structure
 	if !IsValidURL(rawURL) {
 		return false
@@ -153,7 +158,7 @@ func IsValidRemoteURL(rawURL string) bool {
 		return false
 	}
 
-	if u.Scheme != "https" {
+	if u.Scheme != "https" && (!allowHTTP || u.Scheme != "http") {
 		return false
 	}
 
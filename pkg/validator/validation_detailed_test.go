@@ -1,12 +1,12 @@
-package validators_test
+package validator_test
 
 import (
 	"strings"
 	"testing"
 
-	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,7 +50,7 @@ func TestValidateServerJSON_CollectsAllErrors(t *testing.T) {
 	}
 
 	// Run detailed validation
-	result := validators.ValidateServerJSON(serverJSON, validators.ValidationSchemaVersionAndSemantic)
+	result := validator.ValidateServerJSON(serverJSON, validator.ValidationSchemaVersionAndSemantic)
 
 	// Verify it's invalid
 	assert.False(t, result.Valid)
@@ -61,10 +61,10 @@ func TestValidateServerJSON_CollectsAllErrors(t *testing.T) {
 	hasSemantic := false
 
 	for _, issue := range result.Issues {
-		if issue.Severity == validators.ValidationIssueSeverityError {
+		if issue.Severity == validator.ValidationIssueSeverityError {
 			hasError = true
 		}
-		if issue.Type == validators.ValidationIssueTypeSemantic {
+		if issue.Type == validator.ValidationIssueTypeSemantic {
 			hasSemantic = true
 		}
 	}
@@ -128,7 +128,7 @@ func TestValidateServerJSON_ValidServer(t *testing.T) {
 	}
 
 	// Run detailed validation
-	result := validators.ValidateServerJSON(serverJSON, validators.ValidationSchemaVersionAndSemantic)
+	result := validator.ValidateServerJSON(serverJSON, validator.ValidationSchemaVersionAndSemantic)
 
 	// Verify it's valid
 	assert.True(t, result.Valid)
@@ -169,7 +169,7 @@ func TestValidateServerJSON_ContextPaths(t *testing.T) {
 	}
 
 	// Run detailed validation
-	result := validators.ValidateServerJSON(serverJSON, validators.ValidationSchemaVersionAndSemantic)
+	result := validator.ValidateServerJSON(serverJSON, validator.ValidationSchemaVersionAndSemantic)
 
 	// Verify we have issues at the correct paths
 	issuePaths := make(map[string]bool)
@@ -219,7 +219,7 @@ func TestValidateServerJSON_RefResolution(t *testing.T) {
 	}
 
 	// Run validation with schema validation enabled
-	result := validators.ValidateServerJSON(serverJSON, validators.ValidationAll)
+	result := validator.ValidateServerJSON(serverJSON, validator.ValidationAll)
 
 	// Check that we have validation errors
 	assert.False(t, result.Valid, "Expected validation errors")
@@ -228,7 +228,7 @@ func TestValidateServerJSON_RefResolution(t *testing.T) {
 	// Check that we have schema validation issues with proper $ref resolution
 	hasSchemaIssues := false
 	for _, issue := range result.Issues {
-		if issue.Type == validators.ValidationIssueTypeSchema {
+		if issue.Type == validator.ValidationIssueTypeSchema {
 			hasSchemaIssues = true
 			// Check that there are no unresolved [$ref] segments
 			assert.NotContains(t, issue.Reference, "[$ref]", "Found unresolved $ref segment in reference: %s", issue.Reference)
@@ -273,7 +273,7 @@ func TestValidateServerJSON_EmptySchema(t *testing.T) {
 		},
 	}
 
-	result := validators.ValidateServerJSON(serverJSON, validators.ValidationAll)
+	result := validator.ValidateServerJSON(serverJSON, validator.ValidationAll)
 
 	// Should be invalid due to missing schema
 	assert.False(t, result.Valid, "Empty schema should cause validation failure")
@@ -281,7 +281,7 @@ func TestValidateServerJSON_EmptySchema(t *testing.T) {
 	// Should have an error issue for missing schema
 	hasSchemaError := false
 	for _, issue := range result.Issues {
-		if issue.Path == schemaPath && issue.Severity == validators.ValidationIssueSeverityError {
+		if issue.Path == schemaPath && issue.Severity == validator.ValidationIssueSeverityError {
 			if strings.Contains(issue.Message, "$schema field is required") {
 				hasSchemaError = true
 			}
@@ -303,7 +303,7 @@ func TestValidateServerJSON_NonCurrentSchema_Warning(t *testing.T) {
 		},
 	}
 
-	result := validators.ValidateServerJSON(serverJSON, validators.ValidationAll)
+	result := validator.ValidateServerJSON(serverJSON, validator.ValidationAll)
 
 	// Should be valid (warnings don't make it invalid)
 	assert.True(t, result.Valid, "Non-current schema should produce warning but still be valid")
@@ -311,7 +311,7 @@ func TestValidateServerJSON_NonCurrentSchema_Warning(t *testing.T) {
 	// Should have a warning issue for non-current schema
 	hasSchemaWarning := false
 	for _, issue := range result.Issues {
-		if issue.Path == schemaPath && issue.Severity == validators.ValidationIssueSeverityWarning {
+		if issue.Path == schemaPath && issue.Severity == validator.ValidationIssueSeverityWarning {
 			if strings.Contains(issue.Message, "not the current version") || strings.Contains(issue.Message, "Consider updating") {
 				hasSchemaWarning = true
 			}
@@ -333,7 +333,7 @@ func TestValidateServerJSON_InvalidSchema_Error(t *testing.T) {
 		},
 	}
 
-	result := validators.ValidateServerJSON(serverJSON, validators.ValidationAll)
+	result := validator.ValidateServerJSON(serverJSON, validator.ValidationAll)
 
 	// Should be invalid due to schema not available
 	assert.False(t, result.Valid, "Invalid schema version should cause validation failure")
@@ -341,7 +341,7 @@ func TestValidateServerJSON_InvalidSchema_Error(t *testing.T) {
 	// Should have an error issue for schema not available
 	hasSchemaError := false
 	for _, issue := range result.Issues {
-		if issue.Path == schemaPath && issue.Severity == validators.ValidationIssueSeverityError {
+		if issue.Path == schemaPath && issue.Severity == validator.ValidationIssueSeverityError {
 			if strings.Contains(issue.Message, "not available") || strings.Contains(issue.Message, "not found") {
 				hasSchemaError = true
 			}
@@ -365,7 +365,7 @@ func TestValidateServerJSON_NonCurrentSchema_Policies(t *testing.T) {
 
 	tests := []struct {
 		name             string
-		policy           validators.SchemaVersionPolicy
+		policy           validator.SchemaVersionPolicy
 		expectValid      bool
 		expectWarning    bool
 		expectError      bool
@@ -373,7 +373,7 @@ func TestValidateServerJSON_NonCurrentSchema_Policies(t *testing.T) {
 	}{
 		{
 			name:             "Allow policy - no warning or error",
-			policy:           validators.SchemaVersionPolicyAllow,
+			policy:           validator.SchemaVersionPolicyAllow,
 			expectValid:      true,
 			expectWarning:    false,
 			expectError:      false,
@@ -381,7 +381,7 @@ func TestValidateServerJSON_NonCurrentSchema_Policies(t *testing.T) {
 		},
 		{
 			name:             "Warn policy - warning but still valid",
-			policy:           validators.SchemaVersionPolicyWarn,
+			policy:           validator.SchemaVersionPolicyWarn,
 			expectValid:      true,
 			expectWarning:    true,
 			expectError:      false,
@@ -389,7 +389,7 @@ func TestValidateServerJSON_NonCurrentSchema_Policies(t *testing.T) {
 		},
 		{
 			name:             "Error policy - error and invalid",
-			policy:           validators.SchemaVersionPolicyError,
+			policy:           validator.SchemaVersionPolicyError,
 			expectValid:      false,
 			expectWarning:    false,
 			expectError:      true,
@@ -399,12 +399,12 @@ func TestValidateServerJSON_NonCurrentSchema_Policies(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			opts := validators.ValidationOptions{
+			opts := validator.ValidationOptions{
 				ValidateSchema:         true,
 				ValidateSemantic:       true,
 				NonCurrentSchemaPolicy: tt.policy,
 			}
-			result := validators.ValidateServerJSON(serverJSON, opts)
+			result := validator.ValidateServerJSON(serverJSON, opts)
 
 			assert.Equal(t, tt.expectValid, result.Valid, "Validation result should match expected")
 
@@ -415,14 +415,14 @@ func TestValidateServerJSON_NonCurrentSchema_Policies(t *testing.T) {
 
 			for _, issue := range result.Issues {
 				if issue.Path == schemaPath {
-					if issue.Severity == validators.ValidationIssueSeverityWarning {
+					if issue.Severity == validator.ValidationIssueSeverityWarning {
 						hasWarning = true
 						schemaWarnings++
 						if !strings.Contains(issue.Message, "not the current version") {
 							t.Errorf("Warning message should mention 'not the current version', got: %s", issue.Message)
 						}
 					}
-					if issue.Severity == validators.ValidationIssueSeverityError {
+					if issue.Severity == validator.ValidationIssueSeverityError {
 						if strings.Contains(issue.Message, "not the current version") {
 							hasError = true
 							schemaErrors++
@@ -1,6 +1,9 @@
-package validators
+package validator
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Validation issue type with constrained values
 type ValidationIssueType string
@@ -10,6 +13,8 @@ const (
 	ValidationIssueTypeSchema   ValidationIssueType = "schema"
 	ValidationIssueTypeSemantic ValidationIssueType = "semantic"
 	ValidationIssueTypeLinter   ValidationIssueType = "linter"
+	ValidationIssueTypePolicy   ValidationIssueType = "policy"
+	ValidationIssueTypeNetwork  ValidationIssueType = "network"
 )
 
 // Validation issue severity with constrained values
@@ -40,6 +45,9 @@ type ValidationOptions struct {
 	ValidateSchema         bool                // Perform full schema validation (implies ValidateSchemaVersion)
 	ValidateSemantic       bool                // Perform semantic validation
 	NonCurrentSchemaPolicy SchemaVersionPolicy // Policy for non-current schemas (only used when schema validation is performed)
+	ValidateLint           bool                // Check publishing best practices, reported as warnings
+	BlockingLintRules      []string            // Lint rules reported as errors instead of warnings (only used when linting)
+	Policy                 *NamespacePolicy    // Policy of the server's namespace, if it has one
 }
 
 // Common validation configurations
@@ -77,6 +85,10 @@ type ValidationIssue struct {
 	Message   string                  `json:"message"` // Error description (extracted from error.Error())
 	Severity  ValidationIssueSeverity `json:"severity"`
 	Reference string                  `json:"reference"` // Reference to validation trigger (schema rule path, named rule, etc.)
+	// Pointer is the RFC 6901 JSON pointer of the offending value, like "/packages/0/transport/headers/1/value".
+	// Unlike Path it stays unambiguous when a key holds dots, as variable names may. Set by template checks and
+	// lint rules.
+	Pointer string `json:"pointer,omitempty"`
 }
 
 // ValidationResult contains the results of validation
@@ -87,7 +99,8 @@ type ValidationResult struct {
 
 // ValidationContext tracks the current JSON path during validation
 type ValidationContext struct {
-	path string
+	path    string
+	pointer string
 }
 
 // NewValidationIssue creates a validation issue with manual field setting
@@ -128,6 +141,17 @@ func (vr *ValidationResult) Merge(other *ValidationResult) {
 	}
 }
 
+// Warnings returns the warning-level issues, which do not make the result invalid
+func (vr *ValidationResult) Warnings() []ValidationIssue {
+	var warnings []ValidationIssue
+	for _, issue := range vr.Issues {
+		if issue.Severity == ValidationIssueSeverityWarning {
+			warnings = append(warnings, issue)
+		}
+	}
+	return warnings
+}
+
 // FirstError returns the first error-level issue as an error, or nil if valid
 // This provides backward compatibility for code that expects an error return type
 func (vr *ValidationResult) FirstError() error {
@@ -144,15 +168,24 @@ func (vr *ValidationResult) FirstError() error {
 
 // Field adds a field name to the context path
 func (ctx *ValidationContext) Field(name string) *ValidationContext {
+	pointer := ctx.pointer + "/" + jsonPointerEscaper.Replace(name)
 	if ctx.path == "" {
-		return &ValidationContext{path: name}
+		return &ValidationContext{path: name, pointer: pointer}
 	}
-	return &ValidationContext{path: ctx.path + "." + name}
+	return &ValidationContext{path: ctx.path + "." + name, pointer: pointer}
 }
 
 // Index adds an array index to the context path
 func (ctx *ValidationContext) Index(i int) *ValidationContext {
-	return &ValidationContext{path: ctx.path + fmt.Sprintf("[%d]", i)}
+	return &ValidationContext{path: ctx.path + fmt.Sprintf("[%d]", i), pointer: ctx.pointer + fmt.Sprintf("/%d", i)}
+}
+
+// jsonPointerEscaper escapes a key for use as a JSON pointer reference token
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Pointer returns the current path as a JSON pointer
+func (ctx *ValidationContext) Pointer() string {
+	return ctx.pointer
 }
 
 // String returns the current path as a string
@@ -1,23 +1,23 @@
-package validators_test
+package validator_test
 
 import (
 	"errors"
 	"testing"
 
-	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestValidationIssueTypes(t *testing.T) {
 	tests := []struct {
 		name      string
-		issueType validators.ValidationIssueType
+		issueType validator.ValidationIssueType
 		expected  string
 	}{
-		{"JSON type", validators.ValidationIssueTypeJSON, "json"},
-		{"Schema type", validators.ValidationIssueTypeSchema, "schema"},
-		{"Semantic type", validators.ValidationIssueTypeSemantic, "semantic"},
-		{"Linter type", validators.ValidationIssueTypeLinter, "linter"},
+		{"JSON type", validator.ValidationIssueTypeJSON, "json"},
+		{"Schema type", validator.ValidationIssueTypeSchema, "schema"},
+		{"Semantic type", validator.ValidationIssueTypeSemantic, "semantic"},
+		{"Linter type", validator.ValidationIssueTypeLinter, "linter"},
 	}
 
 	for _, tt := range tests {
@@ -30,12 +30,12 @@ func TestValidationIssueTypes(t *testing.T) {
 func TestValidationIssueSeverity(t *testing.T) {
 	tests := []struct {
 		name     string
-		severity validators.ValidationIssueSeverity
+		severity validator.ValidationIssueSeverity
 		expected string
 	}{
-		{"Error severity", validators.ValidationIssueSeverityError, "error"},
-		{"Warning severity", validators.ValidationIssueSeverityWarning, "warning"},
-		{"Info severity", validators.ValidationIssueSeverityInfo, "info"},
+		{"Error severity", validator.ValidationIssueSeverityError, "error"},
+		{"Warning severity", validator.ValidationIssueSeverityWarning, "warning"},
+		{"Info severity", validator.ValidationIssueSeverityInfo, "info"},
 	}
 
 	for _, tt := range tests {
@@ -46,46 +46,46 @@ func TestValidationIssueSeverity(t *testing.T) {
 }
 
 func TestNewValidationIssue(t *testing.T) {
-	issue := validators.NewValidationIssue(
-		validators.ValidationIssueTypeSemantic,
+	issue := validator.NewValidationIssue(
+		validator.ValidationIssueTypeSemantic,
 		"repository.url",
 		"invalid repository URL",
-		validators.ValidationIssueSeverityError,
+		validator.ValidationIssueSeverityError,
 		"invalid-repository-url",
 	)
 
-	assert.Equal(t, validators.ValidationIssueTypeSemantic, issue.Type)
+	assert.Equal(t, validator.ValidationIssueTypeSemantic, issue.Type)
 	assert.Equal(t, "repository.url", issue.Path)
 	assert.Equal(t, "invalid repository URL", issue.Message)
-	assert.Equal(t, validators.ValidationIssueSeverityError, issue.Severity)
+	assert.Equal(t, validator.ValidationIssueSeverityError, issue.Severity)
 	assert.Equal(t, "invalid-repository-url", issue.Reference)
 }
 
 func TestNewValidationIssueFromError(t *testing.T) {
 	err := errors.New("invalid repository URL: https://bad-url.com")
-	issue := validators.NewValidationIssueFromError(
-		validators.ValidationIssueTypeSemantic,
+	issue := validator.NewValidationIssueFromError(
+		validator.ValidationIssueTypeSemantic,
 		"repository.url",
 		err,
 		"invalid-repository-url",
 	)
 
-	assert.Equal(t, validators.ValidationIssueTypeSemantic, issue.Type)
+	assert.Equal(t, validator.ValidationIssueTypeSemantic, issue.Type)
 	assert.Equal(t, "repository.url", issue.Path)
 	assert.Equal(t, "invalid repository URL: https://bad-url.com", issue.Message)
-	assert.Equal(t, validators.ValidationIssueSeverityError, issue.Severity)
+	assert.Equal(t, validator.ValidationIssueSeverityError, issue.Severity)
 	assert.Equal(t, "invalid-repository-url", issue.Reference)
 }
 
 func TestValidationResultAddIssue(t *testing.T) {
-	result := &validators.ValidationResult{Valid: true, Issues: []validators.ValidationIssue{}}
+	result := &validator.ValidationResult{Valid: true, Issues: []validator.ValidationIssue{}}
 
 	// Add a warning issue - should not affect validity
-	warningIssue := validators.NewValidationIssue(
-		validators.ValidationIssueTypeLinter,
+	warningIssue := validator.NewValidationIssue(
+		validator.ValidationIssueTypeLinter,
 		"description",
 		"consider adding a description",
-		validators.ValidationIssueSeverityWarning,
+		validator.ValidationIssueSeverityWarning,
 		"descriptive-naming",
 	)
 	result.AddIssue(warningIssue)
@@ -94,11 +94,11 @@ func TestValidationResultAddIssue(t *testing.T) {
 	assert.Len(t, result.Issues, 1)
 
 	// Add an error issue - should make invalid
-	errorIssue := validators.NewValidationIssue(
-		validators.ValidationIssueTypeSemantic,
+	errorIssue := validator.NewValidationIssue(
+		validator.ValidationIssueTypeSemantic,
 		"name",
 		"server name is required",
-		validators.ValidationIssueSeverityError,
+		validator.ValidationIssueSeverityError,
 		"missing-server-name",
 	)
 	result.AddIssue(errorIssue)
@@ -108,24 +108,24 @@ func TestValidationResultAddIssue(t *testing.T) {
 }
 
 func TestValidationResultMerge(t *testing.T) {
-	result1 := &validators.ValidationResult{Valid: true, Issues: []validators.ValidationIssue{}}
-	result2 := &validators.ValidationResult{Valid: false, Issues: []validators.ValidationIssue{}}
+	result1 := &validator.ValidationResult{Valid: true, Issues: []validator.ValidationIssue{}}
+	result2 := &validator.ValidationResult{Valid: false, Issues: []validator.ValidationIssue{}}
 
 	// Add issues to both
-	issue1 := validators.NewValidationIssue(
-		validators.ValidationIssueTypeSemantic,
+	issue1 := validator.NewValidationIssue(
+		validator.ValidationIssueTypeSemantic,
 		"name",
 		"server name is required",
-		validators.ValidationIssueSeverityError,
+		validator.ValidationIssueSeverityError,
 		"missing-server-name",
 	)
 	result1.AddIssue(issue1)
 
-	issue2 := validators.NewValidationIssue(
-		validators.ValidationIssueTypeSchema,
+	issue2 := validator.NewValidationIssue(
+		validator.ValidationIssueTypeSchema,
 		"version",
 		"version must be a string",
-		validators.ValidationIssueSeverityError,
+		validator.ValidationIssueSeverityError,
 		"schema-validation",
 	)
 	result2.AddIssue(issue2)
@@ -139,7 +139,7 @@ func TestValidationResultMerge(t *testing.T) {
 
 func TestValidationContext(t *testing.T) {
 	// Test empty context
-	ctx := &validators.ValidationContext{}
+	ctx := &validator.ValidationContext{}
 	assert.Equal(t, "", ctx.String())
 
 	// Test field addition
@@ -151,19 +151,19 @@ func TestValidationContext(t *testing.T) {
 	assert.Equal(t, "repository.url", ctx.String())
 
 	// Test array index
-	ctx = &validators.ValidationContext{}
+	ctx = &validator.ValidationContext{}
 	ctx = ctx.Field("packages").Index(0).Field("transport")
 	assert.Equal(t, "packages[0].transport", ctx.String())
 
 	// Test multiple array indices
-	ctx = &validators.ValidationContext{}
+	ctx = &validator.ValidationContext{}
 	ctx = ctx.Field("packages").Index(0).Field("environmentVariables").Index(1).Field("name")
 	assert.Equal(t, "packages[0].environmentVariables[1].name", ctx.String())
 }
 
 func TestValidationContextImmutability(t *testing.T) {
 	// Test that context operations return new instances
-	ctx1 := &validators.ValidationContext{}
+	ctx1 := &validator.ValidationContext{}
 	ctx2 := ctx1.Field("repository")
 	ctx3 := ctx2.Field("url")
 
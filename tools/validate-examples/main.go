@@ -12,8 +12,8 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/validator"
 	jsonschema "github.com/santhosh-tekuri/jsonschema/v5"
 )
 
@@ -170,7 +170,7 @@ func validateWithObjectValidator(serverData any) bool {
 
 	// ValidateServerJSON returns all validation results; using FirstError() to preserve existing behavior
 	// In future, consider displaying all issues from result.Issues for comprehensive feedback
-	result := validators.ValidateServerJSON(&serverDetail, validators.ValidationSchemaVersionAndSemantic)
+	result := validator.ValidateServerJSON(&serverDetail, validator.ValidationSchemaVersionAndSemantic)
 	if err := result.FirstError(); err != nil {
 		log.Printf("    Validating with Go Validator: ❌")
 		log.Printf("      Error: %v", err)